@@ -0,0 +1,136 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/go-kit/kit/endpoint"
+)
+
+var header = []string{"id", "created_at", "source", "operation", "entity_type", "entity_id", "actor", "org_id", "before", "after", "metadata"}
+
+func listEventsEndpoint(svc audit.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listEventsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListEvents(ctx, req.token, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		res := eventsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Events: []eventRes{},
+		}
+		for _, e := range page.Events {
+			res.Events = append(res.Events, toEventRes(e))
+		}
+
+		return res, nil
+	}
+}
+
+func exportEventsEndpoint(svc audit.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listEventsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		events, err := svc.ExportEvents(ctx, req.token, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := generateCSV(events)
+		if err != nil {
+			return nil, err
+		}
+
+		return exportFileRes{file: data}, nil
+	}
+}
+
+func toEventRes(e audit.Event) eventRes {
+	return eventRes{
+		ID:         e.ID,
+		Source:     e.Source,
+		Operation:  e.Operation,
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID,
+		Actor:      e.Actor,
+		OrgID:      e.OrgID,
+		Before:     e.Before,
+		After:      e.After,
+		Metadata:   e.Metadata,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func generateCSV(events []audit.Event) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, e := range events {
+		row := []string{
+			e.ID,
+			e.CreatedAt.Format(time.RFC3339),
+			e.Source,
+			e.Operation,
+			e.EntityType,
+			e.EntityID,
+			e.Actor,
+			e.OrgID,
+		}
+
+		for _, m := range []map[string]interface{}{e.Before, e.After, e.Metadata} {
+			val, err := encodeJSON(m)
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, val)
+		}
+
+		if err := writer.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodeJSON(m map[string]interface{}) (string, error) {
+	if len(m) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
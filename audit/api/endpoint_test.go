@@ -0,0 +1,279 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	httpapi "github.com/MainfluxLabs/mainflux/audit/api"
+	"github.com/MainfluxLabs/mainflux/audit/mocks"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminToken    = "admin@example.com"
+	orgAdminToken = "orgadmin@example.com"
+	userToken     = "user@example.com"
+	adminID       = "adminID"
+	orgAdminID    = "orgAdminID"
+	userID        = "userID"
+	orgID         = "orgID"
+	numEvents     = 20
+)
+
+var (
+	users = map[string]string{adminToken: adminID, orgAdminToken: orgAdminID, userToken: userID}
+	orgs  = map[string]mocks.Org{orgID: {Admins: []string{orgAdminToken}}}
+)
+
+type testRequest struct {
+	client *http.Client
+	method string
+	url    string
+	token  string
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+func newService(t *testing.T) audit.Service {
+	auth := mocks.NewAuthService(adminToken, users, orgs)
+	svc := audit.New(auth, mocks.NewEventRepository())
+
+	now := time.Now().UTC()
+	var events []audit.Event
+	for i := 0; i < numEvents; i++ {
+		event := audit.Event{
+			ID:         fmt.Sprintf("things-%d", i),
+			Source:     "things",
+			Operation:  "thing.update",
+			EntityType: "thing",
+			EntityID:   fmt.Sprintf("thing-%d", i),
+			Actor:      userID,
+			Before:     map[string]interface{}{"name": "before"},
+			After:      map[string]interface{}{"name": "after"},
+			CreatedAt:  now.Add(-time.Duration(i) * time.Minute),
+		}
+		if i%2 == 0 {
+			event.Actor = orgAdminID
+			event.OrgID = orgID
+		}
+		events = append(events, event)
+	}
+
+	err := svc.SaveEvents(context.Background(), events...)
+	require.Nil(t, err, fmt.Sprintf("saving events expected to succeed: %s", err))
+
+	return svc
+}
+
+func newServer(svc audit.Service) *httptest.Server {
+	mux := httpapi.MakeHandler(svc, mocktracer.New(), logger.NewMock())
+	return httptest.NewServer(mux)
+}
+
+type eventsPageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+	Events []struct {
+		ID     string                 `json:"id"`
+		Actor  string                 `json:"actor"`
+		Before map[string]interface{} `json:"before"`
+	} `json:"events"`
+}
+
+func TestListEvents(t *testing.T) {
+	ts := newServer(newService(t))
+	defer ts.Close()
+
+	from := url.QueryEscape(time.Now().UTC().Add(-4*time.Minute - time.Second).Format(time.RFC3339))
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		total  uint64
+		size   int
+	}{
+		{
+			desc:   "list events as root admin",
+			query:  "",
+			token:  adminToken,
+			status: http.StatusOK,
+			total:  numEvents,
+			size:   10,
+		},
+		{
+			desc:   "list events with limit and offset",
+			query:  "?offset=15&limit=10",
+			token:  adminToken,
+			status: http.StatusOK,
+			total:  numEvents,
+			size:   5,
+		},
+		{
+			desc:   "list events by actor",
+			query:  fmt.Sprintf("?actor=%s&limit=%d", userID, numEvents),
+			token:  adminToken,
+			status: http.StatusOK,
+			total:  numEvents / 2,
+			size:   numEvents / 2,
+		},
+		{
+			desc:   "list events from time",
+			query:  fmt.Sprintf("?from=%s", from),
+			token:  adminToken,
+			status: http.StatusOK,
+			total:  5,
+			size:   5,
+		},
+		{
+			desc:   "list events of the org as org admin",
+			query:  fmt.Sprintf("?org_id=%s&limit=%d", orgID, numEvents),
+			token:  orgAdminToken,
+			status: http.StatusOK,
+			total:  numEvents / 2,
+			size:   numEvents / 2,
+		},
+		{
+			desc:   "list events of the org as unauthorized user",
+			query:  fmt.Sprintf("?org_id=%s", orgID),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "list events with invalid time",
+			query:  "?from=invalid",
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list events with invalid limit",
+			query:  "?limit=invalid",
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list events with limit greater than max",
+			query:  "?limit=1001",
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list events with invalid token",
+			query:  "",
+			token:  "invalid",
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list events with empty token",
+			query:  "",
+			token:  "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/events%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page eventsPageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+		assert.Equal(t, tc.size, len(page.Events), fmt.Sprintf("%s: expected size %d got %d", tc.desc, tc.size, len(page.Events)))
+	}
+}
+
+func TestExportEvents(t *testing.T) {
+	ts := newServer(newService(t))
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		rows   int
+	}{
+		{
+			desc:   "export events as root admin",
+			query:  "",
+			token:  adminToken,
+			status: http.StatusOK,
+			rows:   numEvents + 1,
+		},
+		{
+			desc:   "export events of the org as org admin",
+			query:  fmt.Sprintf("?org_id=%s", orgID),
+			token:  orgAdminToken,
+			status: http.StatusOK,
+			rows:   numEvents/2 + 1,
+		},
+		{
+			desc:   "export events as unauthorized user",
+			query:  "",
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "export events with invalid token",
+			query:  "",
+			token:  "invalid",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/events/export%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		rows, err := csv.NewReader(res.Body).ReadAll()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.rows, len(rows), fmt.Sprintf("%s: expected %d rows got %d", tc.desc, tc.rows, len(rows)))
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	log "github.com/MainfluxLabs/mainflux/logger"
+)
+
+var _ audit.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    audit.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc audit.Service, logger log.Logger) audit.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) ListEvents(ctx context.Context, token string, pm audit.PageMetadata) (page audit.EventsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_events for org %s took %s to complete", pm.OrgID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListEvents(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) ExportEvents(ctx context.Context, token string, pm audit.PageMetadata) (events []audit.Event, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method export_events for org %s took %s to complete", pm.OrgID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ExportEvents(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) SaveEvents(ctx context.Context, events ...audit.Event) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method save_events took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SaveEvents(ctx, events...)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ audit.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     audit.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc audit.Service, counter metrics.Counter, latency metrics.Histogram) audit.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) ListEvents(ctx context.Context, token string, pm audit.PageMetadata) (audit.EventsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_events").Add(1)
+		ms.latency.With("method", "list_events").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListEvents(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) ExportEvents(ctx context.Context, token string, pm audit.PageMetadata) ([]audit.Event, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "export_events").Add(1)
+		ms.latency.With("method", "export_events").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ExportEvents(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) SaveEvents(ctx context.Context, events ...audit.Event) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "save_events").Add(1)
+		ms.latency.With("method", "save_events").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.SaveEvents(ctx, events...)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+)
+
+const maxLimitSize = 1000
+
+type listEventsReq struct {
+	token    string
+	pageMeta audit.PageMetadata
+}
+
+func (req listEventsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.pageMeta.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	if !req.pageMeta.From.IsZero() && !req.pageMeta.To.IsZero() && req.pageMeta.From.After(req.pageMeta.To) {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+var (
+	_ mainflux.Response = (*eventsPageRes)(nil)
+	_ mainflux.Response = (*exportFileRes)(nil)
+)
+
+type eventRes struct {
+	ID         string                 `json:"id"`
+	Source     string                 `json:"source"`
+	Operation  string                 `json:"operation"`
+	EntityType string                 `json:"entity_type,omitempty"`
+	EntityID   string                 `json:"entity_id,omitempty"`
+	Actor      string                 `json:"actor,omitempty"`
+	OrgID      string                 `json:"org_id,omitempty"`
+	Before     map[string]interface{} `json:"before,omitempty"`
+	After      map[string]interface{} `json:"after,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type eventsPageRes struct {
+	pageRes
+	Events []eventRes `json:"events"`
+}
+
+func (res eventsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res eventsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res eventsPageRes) Empty() bool {
+	return false
+}
+
+type exportFileRes struct {
+	file []byte
+}
+
+func (res exportFileRes) Code() int {
+	return http.StatusOK
+}
+
+func (res exportFileRes) Headers() map[string]string {
+	return map[string]string{
+		"Content-Disposition": "attachment; filename=events.csv",
+	}
+}
+
+func (res exportFileRes) Empty() bool {
+	return false
+}
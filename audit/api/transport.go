@@ -0,0 +1,192 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	contentType    = "application/json"
+	csvContentType = "text/csv"
+	offsetKey      = "offset"
+	limitKey       = "limit"
+	sourceKey      = "source"
+	operationKey   = "operation"
+	entityTypeKey  = "entity_type"
+	entityIDKey    = "entity_id"
+	actorKey       = "actor"
+	orgIDKey       = "org_id"
+	fromKey        = "from"
+	toKey          = "to"
+	defOffset      = 0
+	defLimit       = 10
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc audit.Service, tracer opentracing.Tracer, logger logger.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+		kithttp.ServerBefore(apiutil.ClientIPToContext),
+	}
+
+	mux := bone.New()
+
+	mux.Get("/events", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_events")(listEventsEndpoint(svc)),
+		decodeListEvents,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/events/export", kithttp.NewServer(
+		kitot.TraceServer(tracer, "export_events")(exportEventsEndpoint(svc)),
+		decodeListEvents,
+		encodeFileResponse,
+		opts...,
+	))
+
+	mux.GetFunc("/health", mainflux.Health("audit"))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeListEvents(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := apiutil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	pm := audit.PageMetadata{
+		Offset: offset,
+		Limit:  limit,
+	}
+
+	for key, val := range map[string]*string{
+		sourceKey:     &pm.Source,
+		operationKey:  &pm.Operation,
+		entityTypeKey: &pm.EntityType,
+		entityIDKey:   &pm.EntityID,
+		actorKey:      &pm.Actor,
+		orgIDKey:      &pm.OrgID,
+	} {
+		if *val, err = apiutil.ReadStringQuery(r, key, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if pm.From, err = readTimeQuery(r, fromKey); err != nil {
+		return nil, err
+	}
+
+	if pm.To, err = readTimeQuery(r, toKey); err != nil {
+		return nil, err
+	}
+
+	req := listEventsReq{
+		token:    apiutil.ExtractBearerToken(r),
+		pageMeta: pm,
+	}
+
+	return req, nil
+}
+
+// readTimeQuery reads the RFC3339 formatted time http query parameter.
+func readTimeQuery(r *http.Request, key string) (time.Time, error) {
+	val, err := apiutil.ReadStringQuery(r, key, "")
+	if err != nil || val == "" {
+		return time.Time{}, err
+	}
+
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, apiutil.ErrInvalidQueryParams
+	}
+
+	return t, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeFileResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", csvContentType)
+
+	if ar, ok := response.(exportFileRes); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+
+		if _, err := w.Write(ar.file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case errors.Contains(err, apiutil.ErrInvalidQueryParams),
+		errors.Contains(err, apiutil.ErrLimitSize):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrAuthentication),
+		err == apiutil.ErrBearerToken:
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, errors.ErrRetrieveEntity):
+		w.WriteHeader(http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
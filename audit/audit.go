@@ -0,0 +1,55 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Event represents a single administrative change recorded by the audit log.
+type Event struct {
+	ID         string
+	Source     string
+	Operation  string
+	EntityType string
+	EntityID   string
+	Actor      string
+	OrgID      string
+	Before     map[string]interface{}
+	After      map[string]interface{}
+	Metadata   map[string]interface{}
+	CreatedAt  time.Time
+}
+
+// PageMetadata contains page metadata and filters used for event retrieval.
+type PageMetadata struct {
+	Total      uint64
+	Offset     uint64
+	Limit      uint64
+	Source     string
+	Operation  string
+	EntityType string
+	EntityID   string
+	Actor      string
+	OrgID      string
+	From       time.Time
+	To         time.Time
+}
+
+// EventsPage contains a page of events.
+type EventsPage struct {
+	PageMetadata
+	Events []Event
+}
+
+// EventRepository specifies an audit event persistence API.
+type EventRepository interface {
+	// Save persists the events. Events that are already stored are ignored.
+	Save(ctx context.Context, events ...Event) error
+
+	// RetrieveAll retrieves the events matching the provided page metadata,
+	// ordered from the most recent one. Zero limit retrieves all the events.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (EventsPage, error)
+}
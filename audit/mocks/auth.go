@@ -0,0 +1,125 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+)
+
+var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
+
+// Org represents an org known to the auth service mock, with its admins
+// and members identified by their tokens.
+type Org struct {
+	Admins  []string
+	Members []string
+}
+
+type authServiceMock struct {
+	rootAdmin string
+	users     map[string]string
+	orgs      map[string]Org
+}
+
+// NewAuthService creates mock of auth service. Users are identified by
+// tokens, which are mapped to user IDs.
+func NewAuthService(rootAdmin string, users map[string]string, orgs map[string]Org) mainflux.AuthServiceClient {
+	return &authServiceMock{
+		rootAdmin: rootAdmin,
+		users:     users,
+		orgs:      orgs,
+	}
+}
+
+func (svc authServiceMock) Identify(_ context.Context, in *mainflux.Token, _ ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	id, ok := svc.users[in.GetValue()]
+	if !ok {
+		return nil, errors.ErrAuthentication
+	}
+
+	return &mainflux.UserIdentity{Id: id, Email: in.GetValue()}, nil
+}
+
+func (svc authServiceMock) Issue(_ context.Context, _ *mainflux.IssueReq, _ ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Authorize(_ context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	if _, ok := svc.users[req.GetToken()]; !ok {
+		return &empty.Empty{}, errors.ErrAuthentication
+	}
+
+	switch req.GetSubject() {
+	case auth.RootSubject:
+		if req.GetToken() == svc.rootAdmin {
+			return &empty.Empty{}, nil
+		}
+	case auth.OrgSubject:
+		for _, admin := range svc.orgs[req.GetObject()].Admins {
+			if admin == req.GetToken() {
+				return &empty.Empty{}, nil
+			}
+		}
+	}
+
+	return &empty.Empty{}, errors.ErrAuthorization
+}
+
+func (svc authServiceMock) Members(_ context.Context, req *mainflux.MembersReq, _ ...grpc.CallOption) (*mainflux.MembersRes, error) {
+	org, ok := svc.orgs[req.GetGroupID()]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+
+	var members []string
+	for _, token := range org.Admins {
+		members = append(members, svc.users[token])
+	}
+	for _, token := range org.Members {
+		members = append(members, svc.users[token])
+	}
+
+	total := uint64(len(members))
+	start, end := req.GetOffset(), req.GetOffset()+req.GetLimit()
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	return &mainflux.MembersRes{
+		Total:   total,
+		Offset:  req.GetOffset(),
+		Limit:   req.GetLimit(),
+		Type:    req.GetType(),
+		Members: members[start:end],
+	}, nil
+}
+
+func (svc authServiceMock) Assign(_ context.Context, _ *mainflux.Assignment, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) AddPolicy(_ context.Context, _ *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) AssignRole(_ context.Context, _ *mainflux.AssignRoleReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) RetrieveRole(_ context.Context, _ *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (*mainflux.RetrieveRoleRes, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) RetrieveQuota(_ context.Context, _ *mainflux.QuotaReq, _ ...grpc.CallOption) (*mainflux.QuotaRes, error) {
+	return &mainflux.QuotaRes{}, nil
+}
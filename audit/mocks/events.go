@@ -0,0 +1,91 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+)
+
+var _ audit.EventRepository = (*eventRepositoryMock)(nil)
+
+type eventRepositoryMock struct {
+	mu     sync.Mutex
+	events map[string]audit.Event
+}
+
+// NewEventRepository returns a new audit events repository mock.
+func NewEventRepository() audit.EventRepository {
+	return &eventRepositoryMock{
+		events: make(map[string]audit.Event),
+	}
+}
+
+func (erm *eventRepositoryMock) Save(_ context.Context, events ...audit.Event) error {
+	erm.mu.Lock()
+	defer erm.mu.Unlock()
+
+	for _, event := range events {
+		if _, ok := erm.events[event.ID]; ok {
+			continue
+		}
+		erm.events[event.ID] = event
+	}
+
+	return nil
+}
+
+func (erm *eventRepositoryMock) RetrieveAll(_ context.Context, pm audit.PageMetadata) (audit.EventsPage, error) {
+	erm.mu.Lock()
+	defer erm.mu.Unlock()
+
+	var items []audit.Event
+	for _, event := range erm.events {
+		if matches(event, pm) {
+			items = append(items, event)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].CreatedAt.After(items[j].CreatedAt)
+	})
+
+	total := uint64(len(items))
+	if pm.Limit > 0 {
+		start, end := pm.Offset, pm.Offset+pm.Limit
+		if start > total {
+			start = total
+		}
+		if end > total {
+			end = total
+		}
+		items = items[start:end]
+	}
+
+	page := audit.EventsPage{
+		PageMetadata: pm,
+		Events:       items,
+	}
+	page.Total = total
+
+	return page, nil
+}
+
+func matches(event audit.Event, pm audit.PageMetadata) bool {
+	switch {
+	case pm.Source != "" && event.Source != pm.Source,
+		pm.Operation != "" && event.Operation != pm.Operation,
+		pm.EntityType != "" && event.EntityType != pm.EntityType,
+		pm.EntityID != "" && event.EntityID != pm.EntityID,
+		pm.Actor != "" && event.Actor != pm.Actor,
+		!pm.From.IsZero() && event.CreatedAt.Before(pm.From),
+		!pm.To.IsZero() && event.CreatedAt.After(pm.To):
+		return false
+	}
+
+	return pm.OrgID == "" || event.OrgID == pm.OrgID
+}
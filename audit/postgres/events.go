@@ -0,0 +1,241 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ audit.EventRepository = (*eventRepository)(nil)
+
+type eventRepository struct {
+	db Database
+}
+
+// New instantiates a PostgreSQL implementation of audit events repository.
+func New(db Database) audit.EventRepository {
+	return &eventRepository{
+		db: db,
+	}
+}
+
+func (er eventRepository) Save(ctx context.Context, events ...audit.Event) error {
+	q := `INSERT INTO events (id, source, operation, entity_type, entity_id, actor, org_id, before, after, metadata, created_at)
+		  VALUES (:id, :source, :operation, :entity_type, :entity_id, :actor, :org_id, :before, :after, :metadata, :created_at)
+		  ON CONFLICT (id) DO NOTHING;`
+
+	for _, event := range events {
+		dbe, err := toDBEvent(event)
+		if err != nil {
+			return errors.Wrap(errors.ErrCreateEntity, err)
+		}
+
+		if _, err := er.db.NamedExecContext(ctx, q, dbe); err != nil {
+			return errors.Wrap(errors.ErrCreateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (er eventRepository) RetrieveAll(ctx context.Context, pm audit.PageMetadata) (audit.EventsPage, error) {
+	params := map[string]interface{}{
+		"source":      pm.Source,
+		"operation":   pm.Operation,
+		"entity_type": pm.EntityType,
+		"entity_id":   pm.EntityID,
+		"actor":       pm.Actor,
+		"org_id":      pm.OrgID,
+		"from":        pm.From,
+		"to":          pm.To,
+		"limit":       pm.Limit,
+		"offset":      pm.Offset,
+	}
+
+	var conds []string
+	for _, key := range []string{"source", "operation", "entity_type", "entity_id", "actor"} {
+		if params[key] != "" {
+			conds = append(conds, fmt.Sprintf("%s = :%s", key, key))
+		}
+	}
+
+	if pm.OrgID != "" {
+		conds = append(conds, "org_id = :org_id")
+	}
+
+	if !pm.From.IsZero() {
+		conds = append(conds, "created_at >= :from")
+	}
+	if !pm.To.IsZero() {
+		conds = append(conds, "created_at <= :to")
+	}
+
+	var wq string
+	if len(conds) > 0 {
+		wq = fmt.Sprintf("WHERE %s", strings.Join(conds, " AND "))
+	}
+
+	var olq string
+	if pm.Limit > 0 {
+		olq = "LIMIT :limit OFFSET :offset"
+	}
+
+	q := fmt.Sprintf(`SELECT id, source, operation, entity_type, entity_id, actor, org_id, before, after, metadata, created_at
+		  FROM events %s ORDER BY created_at DESC, id DESC %s;`, wq, olq)
+
+	rows, err := er.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return audit.EventsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []audit.Event
+	for rows.Next() {
+		dbe := dbEvent{}
+		if err := rows.StructScan(&dbe); err != nil {
+			return audit.EventsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		event, err := toEvent(dbe)
+		if err != nil {
+			return audit.EventsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, event)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM events %s;`, wq)
+	total, err := total(ctx, er.db, cq, params)
+	if err != nil {
+		return audit.EventsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := audit.EventsPage{
+		PageMetadata: pm,
+		Events:       items,
+	}
+	page.Total = total
+
+	return page, nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var total uint64
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+type dbEvent struct {
+	ID         string    `db:"id"`
+	Source     string    `db:"source"`
+	Operation  string    `db:"operation"`
+	EntityType string    `db:"entity_type"`
+	EntityID   string    `db:"entity_id"`
+	Actor      string    `db:"actor"`
+	OrgID      string    `db:"org_id"`
+	Before     []byte    `db:"before"`
+	After      []byte    `db:"after"`
+	Metadata   []byte    `db:"metadata"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func toDBEvent(e audit.Event) (dbEvent, error) {
+	before, err := toJSON(e.Before)
+	if err != nil {
+		return dbEvent{}, err
+	}
+
+	after, err := toJSON(e.After)
+	if err != nil {
+		return dbEvent{}, err
+	}
+
+	metadata, err := toJSON(e.Metadata)
+	if err != nil {
+		return dbEvent{}, err
+	}
+
+	return dbEvent{
+		ID:         e.ID,
+		Source:     e.Source,
+		Operation:  e.Operation,
+		EntityType: e.EntityType,
+		EntityID:   e.EntityID,
+		Actor:      e.Actor,
+		OrgID:      e.OrgID,
+		Before:     before,
+		After:      after,
+		Metadata:   metadata,
+		CreatedAt:  e.CreatedAt,
+	}, nil
+}
+
+func toEvent(dbe dbEvent) (audit.Event, error) {
+	before, err := fromJSON(dbe.Before)
+	if err != nil {
+		return audit.Event{}, err
+	}
+
+	after, err := fromJSON(dbe.After)
+	if err != nil {
+		return audit.Event{}, err
+	}
+
+	metadata, err := fromJSON(dbe.Metadata)
+	if err != nil {
+		return audit.Event{}, err
+	}
+
+	return audit.Event{
+		ID:         dbe.ID,
+		Source:     dbe.Source,
+		Operation:  dbe.Operation,
+		EntityType: dbe.EntityType,
+		EntityID:   dbe.EntityID,
+		Actor:      dbe.Actor,
+		OrgID:      dbe.OrgID,
+		Before:     before,
+		After:      after,
+		Metadata:   metadata,
+		CreatedAt:  dbe.CreatedAt,
+	}, nil
+}
+
+func toJSON(m map[string]interface{}) ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(m)
+}
+
+func fromJSON(data []byte) (map[string]interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
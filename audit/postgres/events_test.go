@@ -0,0 +1,153 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/audit/postgres"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	source    = "things"
+	numEvents = 50
+)
+
+func TestSave(t *testing.T) {
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	event := audit.Event{
+		ID:         id,
+		Source:     source,
+		Operation:  "thing.update",
+		EntityType: "thing",
+		EntityID:   id,
+		Actor:      id,
+		Before:     map[string]interface{}{"name": "before"},
+		After:      map[string]interface{}{"name": "after"},
+		Metadata:   map[string]interface{}{"ip": "10.0.0.1"},
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	cases := []struct {
+		desc  string
+		event audit.Event
+		err   error
+	}{
+		{
+			desc:  "save event",
+			event: event,
+			err:   nil,
+		},
+		{
+			desc:  "save already saved event",
+			event: event,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.event)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	_, err := db.Exec("DELETE FROM events")
+	require.Nil(t, err, fmt.Sprintf("cleaning events table expected to succeed: %s", err))
+
+	repo := postgres.New(postgres.NewDatabase(db))
+
+	actor, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	orgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().UTC()
+	var events []audit.Event
+	for i := 0; i < numEvents; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		event := audit.Event{
+			ID:         id,
+			Source:     source,
+			Operation:  "thing.create",
+			EntityType: "thing",
+			EntityID:   id,
+			Actor:      id,
+			CreatedAt:  now.Add(-time.Duration(i) * time.Minute),
+		}
+		if i%2 == 0 {
+			event.Actor = actor
+		}
+		if i%5 == 0 {
+			event.OrgID = orgID
+		}
+		events = append(events, event)
+	}
+	err = repo.Save(context.Background(), events...)
+	require.Nil(t, err, fmt.Sprintf("saving events expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		pm    audit.PageMetadata
+		size  int
+		total uint64
+	}{
+		{
+			desc:  "retrieve all events",
+			pm:    audit.PageMetadata{},
+			size:  numEvents,
+			total: numEvents,
+		},
+		{
+			desc:  "retrieve page of events",
+			pm:    audit.PageMetadata{Offset: 10, Limit: 5},
+			size:  5,
+			total: numEvents,
+		},
+		{
+			desc:  "retrieve events by actor",
+			pm:    audit.PageMetadata{Actor: actor, Limit: numEvents},
+			size:  numEvents / 2,
+			total: numEvents / 2,
+		},
+		{
+			desc:  "retrieve events by org",
+			pm:    audit.PageMetadata{OrgID: orgID, Limit: numEvents},
+			size:  numEvents / 5,
+			total: numEvents / 5,
+		},
+		{
+			desc:  "retrieve events in time range",
+			pm:    audit.PageMetadata{From: now.Add(-9*time.Minute - time.Second), To: now, Limit: numEvents},
+			size:  10,
+			total: 10,
+		},
+		{
+			desc:  "retrieve events with unknown operation",
+			pm:    audit.PageMetadata{Operation: "thing.unknown", Limit: numEvents},
+			size:  0,
+			total: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Events), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Events)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	"github.com/jmoiron/sqlx"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Config defines the options that are used when connecting to a PostgreSQL instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+}
+
+// Connect creates a connection to the PostgreSQL instance and applies any
+// unapplied database migrations. A non-nil error is returned to indicate
+// failure.
+func Connect(cfg Config) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("pgx", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "audit_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS events (
+                        id          VARCHAR(254) PRIMARY KEY,
+                        source      VARCHAR(64) NOT NULL,
+                        operation   VARCHAR(254) NOT NULL,
+                        entity_type VARCHAR(64),
+                        entity_id   VARCHAR(254),
+                        actor       VARCHAR(254),
+                        org_id      VARCHAR(254),
+                        before      JSONB,
+                        after       JSONB,
+                        metadata    JSONB,
+                        created_at  TIMESTAMPTZ NOT NULL
+                    )`,
+					`CREATE INDEX IF NOT EXISTS events_created_at_idx ON events (created_at DESC)`,
+					`CREATE INDEX IF NOT EXISTS events_actor_idx ON events (actor)`,
+					`CREATE INDEX IF NOT EXISTS events_org_id_idx ON events (org_id)`,
+					`CREATE INDEX IF NOT EXISTS events_entity_idx ON events (entity_type, entity_id)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS events",
+				},
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+	return err
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis contains the event store consumer which records events
+// published by the Mainflux services to Redis streams.
+package redis
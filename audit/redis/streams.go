@@ -0,0 +1,164 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	group        = "mainflux.audit"
+	streamPrefix = "mainflux."
+
+	operationKey = "operation"
+	actorKey     = "actor"
+	ownerKey     = "owner"
+	orgIDKey     = "org_id"
+	ipKey        = "ip"
+	beforeKey    = "before"
+	timestampKey = "timestamp"
+
+	exists = "BUSYGROUP Consumer Group name already exists"
+)
+
+// Entity ID keys, in order of precedence.
+var entityKeys = []string{"id", "thing_id", "serial"}
+
+// Subscriber represents event source of the audit log.
+type Subscriber interface {
+	// Subscribe subscribes to the given stream and records received events.
+	Subscribe(context.Context, string) error
+}
+
+type eventStore struct {
+	svc      audit.Service
+	client   *redis.Client
+	consumer string
+	logger   logger.Logger
+}
+
+// NewEventStore returns new event store instance.
+func NewEventStore(svc audit.Service, client *redis.Client, consumer string, log logger.Logger) Subscriber {
+	return eventStore{
+		svc:      svc,
+		client:   client,
+		consumer: consumer,
+		logger:   log,
+	}
+}
+
+func (es eventStore) Subscribe(ctx context.Context, stream string) error {
+	err := es.client.XGroupCreateMkStream(ctx, stream, group, "0").Err()
+	if err != nil && err.Error() != exists {
+		return err
+	}
+
+	for {
+		streams, err := es.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: es.consumer,
+			Streams:  []string{stream, ">"},
+			Count:    100,
+		}).Result()
+		if err != nil || len(streams) == 0 {
+			continue
+		}
+
+		for _, msg := range streams[0].Messages {
+			event := decodeEvent(stream, msg)
+			if err := es.svc.SaveEvents(ctx, event); err != nil {
+				es.logger.Warn(fmt.Sprintf("Failed to record audit event: %s", err.Error()))
+				break
+			}
+			es.client.XAck(ctx, stream, group, msg.ID)
+		}
+	}
+}
+
+func decodeEvent(stream string, msg redis.XMessage) audit.Event {
+	source := strings.TrimPrefix(stream, streamPrefix)
+	operation := read(msg.Values, operationKey, "")
+
+	event := audit.Event{
+		ID:         fmt.Sprintf("%s-%s", source, msg.ID),
+		Source:     source,
+		Operation:  operation,
+		EntityType: strings.SplitN(operation, ".", 2)[0],
+		Actor:      read(msg.Values, actorKey, read(msg.Values, ownerKey, "")),
+		OrgID:      read(msg.Values, orgIDKey, ""),
+		Before:     decodeJSON(read(msg.Values, beforeKey, "")),
+		CreatedAt:  messageTime(msg.ID),
+	}
+
+	for _, key := range entityKeys {
+		if id := read(msg.Values, key, ""); id != "" {
+			event.EntityID = id
+			break
+		}
+	}
+
+	if ip := read(msg.Values, ipKey, ""); ip != "" {
+		event.Metadata = map[string]interface{}{ipKey: ip}
+	}
+
+	event.After = map[string]interface{}{}
+	for key, val := range msg.Values {
+		switch key {
+		case operationKey, actorKey, orgIDKey, ipKey, beforeKey, timestampKey:
+			continue
+		}
+
+		if str, ok := val.(string); ok {
+			if m := decodeJSON(str); m != nil {
+				event.After[key] = m
+				continue
+			}
+		}
+		event.After[key] = val
+	}
+
+	return event
+}
+
+// messageTime extracts the time the message was added to the stream
+// from the message ID, which is prefixed with the Unix time in milliseconds.
+func messageTime(id string) time.Time {
+	ms, err := strconv.ParseInt(strings.SplitN(id, "-", 2)[0], 10, 64)
+	if err != nil {
+		return time.Now().UTC()
+	}
+
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
+func decodeJSON(val string) map[string]interface{} {
+	if !strings.HasPrefix(val, "{") {
+		return nil
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(val), &m); err != nil {
+		return nil
+	}
+
+	return m
+}
+
+func read(event map[string]interface{}, key, def string) string {
+	val, ok := event[key].(string)
+	if !ok || val == "" {
+		return def
+	}
+
+	return val
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// ListEvents retrieves a page of the events matching the provided filters.
+	// Root admins can list all the events, while org admins can only list
+	// events of their org.
+	ListEvents(ctx context.Context, token string, pm PageMetadata) (EventsPage, error)
+
+	// ExportEvents retrieves all the events matching the provided filters,
+	// disregarding the pagination. Access rules are the same as for ListEvents.
+	ExportEvents(ctx context.Context, token string, pm PageMetadata) ([]Event, error)
+
+	// SaveEvents persists events ingested from the services event streams.
+	SaveEvents(ctx context.Context, events ...Event) error
+}
+
+var _ Service = (*auditService)(nil)
+
+type auditService struct {
+	auth   mainflux.AuthServiceClient
+	events EventRepository
+}
+
+// New instantiates the audit service implementation.
+func New(auth mainflux.AuthServiceClient, events EventRepository) Service {
+	return &auditService{
+		auth:   auth,
+		events: events,
+	}
+}
+
+func (as *auditService) ListEvents(ctx context.Context, token string, pm PageMetadata) (EventsPage, error) {
+	pm, err := as.authorize(ctx, token, pm)
+	if err != nil {
+		return EventsPage{}, err
+	}
+
+	return as.events.RetrieveAll(ctx, pm)
+}
+
+func (as *auditService) ExportEvents(ctx context.Context, token string, pm PageMetadata) ([]Event, error) {
+	pm, err := as.authorize(ctx, token, pm)
+	if err != nil {
+		return nil, err
+	}
+
+	pm.Offset = 0
+	pm.Limit = 0
+	page, err := as.events.RetrieveAll(ctx, pm)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Events, nil
+}
+
+func (as *auditService) SaveEvents(ctx context.Context, events ...Event) error {
+	return as.events.Save(ctx, events...)
+}
+
+// authorize checks whether the user is allowed to read the requested events
+// and restricts the page metadata to the events visible to the user.
+func (as *auditService) authorize(ctx context.Context, token string, pm PageMetadata) (PageMetadata, error) {
+	if _, err := as.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
+		return PageMetadata{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if _, err := as.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.RootSubject}); err == nil {
+		return pm, nil
+	}
+
+	if pm.OrgID == "" {
+		return PageMetadata{}, errors.ErrAuthorization
+	}
+
+	req := &mainflux.AuthorizeReq{
+		Token:   token,
+		Object:  pm.OrgID,
+		Subject: auth.OrgSubject,
+		Action:  auth.AdminRole,
+	}
+	if _, err := as.auth.Authorize(ctx, req); err != nil {
+		return PageMetadata{}, errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return pm, nil
+}
@@ -0,0 +1,221 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/audit/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminToken    = "admin@example.com"
+	orgAdminToken = "orgadmin@example.com"
+	memberToken   = "member@example.com"
+	userToken     = "user@example.com"
+	adminID       = "adminID"
+	orgAdminID    = "orgAdminID"
+	memberID      = "memberID"
+	userID        = "userID"
+	orgID         = "orgID"
+	otherOrgID    = "otherOrgID"
+	numEvents     = 30
+)
+
+var (
+	users = map[string]string{adminToken: adminID, orgAdminToken: orgAdminID, memberToken: memberID, userToken: userID}
+	orgs  = map[string]mocks.Org{orgID: {Admins: []string{orgAdminToken}, Members: []string{memberToken}}}
+)
+
+func newService() audit.Service {
+	auth := mocks.NewAuthService(adminToken, users, orgs)
+	return audit.New(auth, mocks.NewEventRepository())
+}
+
+// saveEvents saves events performed in turns by the user, the org member
+// and the org admin. Every second event is recorded in the org.
+func saveEvents(t *testing.T, svc audit.Service) []audit.Event {
+	actors := []string{userID, memberID, orgAdminID}
+	now := time.Now().UTC()
+
+	var events []audit.Event
+	for i := 0; i < numEvents; i++ {
+		event := audit.Event{
+			ID:         fmt.Sprintf("things-%d", i),
+			Source:     "things",
+			Operation:  "thing.update",
+			EntityType: "thing",
+			EntityID:   fmt.Sprintf("thing-%d", i),
+			Actor:      actors[i%len(actors)],
+			CreatedAt:  now.Add(-time.Duration(i) * time.Minute),
+		}
+		if i%2 == 0 {
+			event.OrgID = orgID
+		}
+		events = append(events, event)
+	}
+
+	err := svc.SaveEvents(context.Background(), events...)
+	require.Nil(t, err, fmt.Sprintf("saving events expected to succeed: %s", err))
+
+	return events
+}
+
+func TestListEvents(t *testing.T) {
+	svc := newService()
+	events := saveEvents(t, svc)
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    audit.PageMetadata
+		size  int
+		total uint64
+		err   error
+	}{
+		{
+			desc:  "list all events as root admin",
+			token: adminToken,
+			pm:    audit.PageMetadata{Limit: numEvents},
+			size:  numEvents,
+			total: numEvents,
+			err:   nil,
+		},
+		{
+			desc:  "list page of events as root admin",
+			token: adminToken,
+			pm:    audit.PageMetadata{Offset: 5, Limit: 10},
+			size:  10,
+			total: numEvents,
+			err:   nil,
+		},
+		{
+			desc:  "list events by actor as root admin",
+			token: adminToken,
+			pm:    audit.PageMetadata{Actor: userID, Limit: numEvents},
+			size:  numEvents / 3,
+			total: numEvents / 3,
+			err:   nil,
+		},
+		{
+			desc:  "list events by entity as root admin",
+			token: adminToken,
+			pm:    audit.PageMetadata{EntityID: events[0].EntityID, Limit: numEvents},
+			size:  1,
+			total: 1,
+			err:   nil,
+		},
+		{
+			desc:  "list events of the org as org admin",
+			token: orgAdminToken,
+			pm:    audit.PageMetadata{OrgID: orgID, Limit: numEvents},
+			size:  numEvents / 2,
+			total: numEvents / 2,
+			err:   nil,
+		},
+		{
+			desc:  "list events in time range as org admin",
+			token: orgAdminToken,
+			pm:    audit.PageMetadata{OrgID: orgID, From: events[5].CreatedAt, Limit: numEvents},
+			size:  3,
+			total: 3,
+			err:   nil,
+		},
+		{
+			desc:  "list events without org as org admin",
+			token: orgAdminToken,
+			pm:    audit.PageMetadata{Limit: numEvents},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "list events of the org as org member",
+			token: memberToken,
+			pm:    audit.PageMetadata{OrgID: orgID, Limit: numEvents},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "list events of other org as org admin",
+			token: orgAdminToken,
+			pm:    audit.PageMetadata{OrgID: otherOrgID, Limit: numEvents},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "list events with invalid token",
+			token: "invalid",
+			pm:    audit.PageMetadata{Limit: numEvents},
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListEvents(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Events), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Events)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestExportEvents(t *testing.T) {
+	svc := newService()
+	saveEvents(t, svc)
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    audit.PageMetadata
+		size  int
+		err   error
+	}{
+		{
+			desc:  "export all events as root admin",
+			token: adminToken,
+			pm:    audit.PageMetadata{Offset: 5, Limit: 5},
+			size:  numEvents,
+			err:   nil,
+		},
+		{
+			desc:  "export events of the org as org admin",
+			token: orgAdminToken,
+			pm:    audit.PageMetadata{OrgID: orgID},
+			size:  numEvents / 2,
+			err:   nil,
+		},
+		{
+			desc:  "export events of the org as unauthorized user",
+			token: userToken,
+			pm:    audit.PageMetadata{OrgID: orgID},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "export events with invalid token",
+			token: "invalid",
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		events, err := svc.ExportEvents(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(events), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(events)))
+	}
+}
+
+func TestSaveEvents(t *testing.T) {
+	svc := newService()
+	events := saveEvents(t, svc)
+
+	err := svc.SaveEvents(context.Background(), events...)
+	assert.Nil(t, err, fmt.Sprintf("saving already saved events expected to succeed: %s", err))
+
+	page, err := svc.ListEvents(context.Background(), adminToken, audit.PageMetadata{Limit: numEvents})
+	require.Nil(t, err, fmt.Sprintf("listing events expected to succeed: %s", err))
+	assert.Equal(t, uint64(numEvents), page.Total, fmt.Sprintf("expected total %d got %d\n", numEvents, page.Total))
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing contains middlewares that will add spans
+// to existing traces.
+package tracing
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveOp        = "save_op"
+	retrieveAllOp = "retrieve_all_op"
+)
+
+var _ audit.EventRepository = (*eventRepositoryMiddleware)(nil)
+
+type eventRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   audit.EventRepository
+}
+
+// New instantiates a new Events repository that
+// tracks request and their latency, and adds spans to context.
+func New(repo audit.EventRepository, tracer opentracing.Tracer) audit.EventRepository {
+	return eventRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (erm eventRepositoryMiddleware) Save(ctx context.Context, events ...audit.Event) error {
+	span := createSpan(ctx, erm.tracer, saveOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return erm.repo.Save(ctx, events...)
+}
+
+func (erm eventRepositoryMiddleware) RetrieveAll(ctx context.Context, pm audit.PageMetadata) (audit.EventsPage, error) {
+	span := createSpan(ctx, erm.tracer, retrieveAllOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return erm.repo.RetrieveAll(ctx, pm)
+}
+
+func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
+	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
+		return tracer.StartSpan(
+			opName,
+			opentracing.ChildOf(parentSpan.Context()),
+		)
+	}
+	return tracer.StartSpan(opName)
+}
@@ -8,30 +8,34 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/go-kit/kit/endpoint"
 	kitot "github.com/go-kit/kit/tracing/opentracing"
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
 	"github.com/golang/protobuf/ptypes/empty"
 	opentracing "github.com/opentracing/opentracing-go"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
-	svcName = "mainflux.AuthService"
+	svcName        = "mainflux.AuthService"
+	clientIPHeader = "x-client-ip"
 )
 
 var _ mainflux.AuthServiceClient = (*grpcClient)(nil)
 
 type grpcClient struct {
-	issue        endpoint.Endpoint
-	identify     endpoint.Endpoint
-	authorize    endpoint.Endpoint
-	addPolicy    endpoint.Endpoint
-	assign       endpoint.Endpoint
-	members      endpoint.Endpoint
-	retrieveRole endpoint.Endpoint
-	assignRole   endpoint.Endpoint
-	timeout      time.Duration
+	issue         endpoint.Endpoint
+	identify      endpoint.Endpoint
+	authorize     endpoint.Endpoint
+	addPolicy     endpoint.Endpoint
+	assign        endpoint.Endpoint
+	members       endpoint.Endpoint
+	retrieveRole  endpoint.Endpoint
+	assignRole    endpoint.Endpoint
+	retrieveQuota endpoint.Endpoint
+	timeout       time.Duration
 }
 
 // NewClient returns new gRPC client instance.
@@ -52,6 +56,7 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			encodeIdentifyRequest,
 			decodeIdentifyResponse,
 			mainflux.UserIdentity{},
+			kitgrpc.ClientBefore(encodeClientIP),
 		).Endpoint()),
 		authorize: kitot.TraceClient(tracer, "authorize")(kitgrpc.NewClient(
 			conn,
@@ -60,6 +65,7 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			encodeAuthorizeRequest,
 			decodeEmptyResponse,
 			empty.Empty{},
+			kitgrpc.ClientBefore(encodeClientIP),
 		).Endpoint()),
 		addPolicy: kitot.TraceClient(tracer, "add_policy")(kitgrpc.NewClient(
 			conn,
@@ -101,6 +107,14 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeEmptyResponse,
 			empty.Empty{},
 		).Endpoint()),
+		retrieveQuota: kitot.TraceClient(tracer, "retrieve_quota")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveQuota",
+			encodeRetrieveQuotaRequest,
+			decodeRetrieveQuotaResponse,
+			mainflux.QuotaRes{},
+		).Endpoint()),
 
 		timeout: timeout,
 	}
@@ -139,7 +153,14 @@ func (client grpcClient) Identify(ctx context.Context, token *mainflux.Token, _
 	}
 
 	ir := res.(identityRes)
-	return &mainflux.UserIdentity{Id: ir.id, Email: ir.email}, nil
+	return &mainflux.UserIdentity{
+		Id:         ir.id,
+		Email:      ir.email,
+		Scopes:     ir.scopes,
+		OrgIDs:     ir.orgIDs,
+		GroupIDs:   ir.groupIDs,
+		ChannelIDs: ir.channelIDs,
+	}, nil
 }
 
 func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -149,7 +170,24 @@ func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{},
 
 func decodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*mainflux.UserIdentity)
-	return identityRes{id: res.GetId(), email: res.GetEmail()}, nil
+	return identityRes{
+		id:         res.GetId(),
+		email:      res.GetEmail(),
+		scopes:     res.GetScopes(),
+		orgIDs:     res.GetOrgIDs(),
+		groupIDs:   res.GetGroupIDs(),
+		channelIDs: res.GetChannelIDs(),
+	}, nil
+}
+
+// encodeClientIP forwards the IP address of the client which issued
+// the original request, so that API key IP allow-lists can be enforced.
+func encodeClientIP(ctx context.Context, md *metadata.MD) context.Context {
+	if ip := auth.ClientIP(ctx); ip != "" {
+		md.Set(clientIPHeader, ip)
+	}
+
+	return ctx
 }
 
 func (client grpcClient) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
@@ -268,6 +306,48 @@ func decodeRetrieveRoleResponse(_ context.Context, grpcRes interface{}) (interfa
 	return retrieveRoleRes{role: res.GetRole()}, nil
 }
 
+func (client grpcClient) RetrieveQuota(ctx context.Context, req *mainflux.QuotaReq, _ ...grpc.CallOption) (*mainflux.QuotaRes, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.retrieveQuota(ctx, quotaReq{subject: req.GetSubject(), id: req.GetId()})
+	if err != nil {
+		return &mainflux.QuotaRes{}, err
+	}
+
+	qr := res.(quotaRes)
+	return &mainflux.QuotaRes{
+		Things:        qr.things,
+		Channels:      qr.channels,
+		Groups:        qr.groups,
+		Keys:          qr.keys,
+		Subscriptions: qr.subscriptions,
+		OrgID:         qr.orgID,
+		GroupIDs:      qr.groupIDs,
+	}, nil
+}
+
+func encodeRetrieveQuotaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(quotaReq)
+	return &mainflux.QuotaReq{
+		Subject: req.subject,
+		Id:      req.id,
+	}, nil
+}
+
+func decodeRetrieveQuotaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.QuotaRes)
+	return quotaRes{
+		things:        res.GetThings(),
+		channels:      res.GetChannels(),
+		groups:        res.GetGroups(),
+		keys:          res.GetKeys(),
+		subscriptions: res.GetSubscriptions(),
+		orgID:         res.GetOrgID(),
+		groupIDs:      res.GetGroupIDs(),
+	}, nil
+}
+
 func encodeMembersRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(membersReq)
 	return &mainflux.MembersReq{
@@ -47,8 +47,12 @@ func identifyEndpoint(svc auth.Service) endpoint.Endpoint {
 		}
 
 		ret := identityRes{
-			id:    id.ID,
-			email: id.Email,
+			id:         id.ID,
+			email:      id.Email,
+			scopes:     id.Scopes,
+			orgIDs:     id.OrgIDs,
+			groupIDs:   id.GroupIDs,
+			channelIDs: id.ChannelIDs,
 		}
 
 		return ret, nil
@@ -114,6 +118,33 @@ func retrieveRoleEndpoint(svc auth.Service) endpoint.Endpoint {
 	}
 }
 
+func retrieveQuotaEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(quotaReq)
+
+		if err := req.validate(); err != nil {
+			return quotaRes{}, err
+		}
+
+		q, err := svc.RetrieveQuota(ctx, req.subject, req.id)
+		if err != nil {
+			return quotaRes{}, err
+		}
+
+		res := quotaRes{
+			things:        q.Limits.Things,
+			channels:      q.Limits.Channels,
+			groups:        q.Limits.Groups,
+			keys:          q.Limits.Keys,
+			subscriptions: q.Limits.Subscriptions,
+			orgID:         q.OrgID,
+			groupIDs:      q.GroupIDs,
+		}
+
+		return res, nil
+	}
+}
+
 func addPolicyEndpoint(svc auth.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(policyReq)
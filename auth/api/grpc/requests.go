@@ -94,7 +94,13 @@ func (req authReq) validate() error {
 		return apiutil.ErrBearerToken
 	}
 
-	if req.Subject != auth.RootSubject && req.Subject != auth.GroupSubject {
+	switch req.Subject {
+	case auth.RootSubject, auth.GroupSubject:
+	case auth.OrgSubject:
+		if req.Object == "" {
+			return apiutil.ErrMissingID
+		}
+	default:
 		return apiutil.ErrInvalidSubject
 	}
 
@@ -156,3 +162,20 @@ func (req retrieveRoleReq) validate() error {
 
 	return nil
 }
+
+type quotaReq struct {
+	subject string
+	id      string
+}
+
+func (req quotaReq) validate() error {
+	if req.subject == "" {
+		return apiutil.ErrMalformedEntity
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
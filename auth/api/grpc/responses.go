@@ -4,8 +4,12 @@
 package grpc
 
 type identityRes struct {
-	id    string
-	email string
+	id         string
+	email      string
+	scopes     []string
+	orgIDs     []string
+	groupIDs   []string
+	channelIDs []string
 }
 
 type issueRes struct {
@@ -26,3 +30,13 @@ type emptyRes struct {
 type retrieveRoleRes struct {
 	role string
 }
+
+type quotaRes struct {
+	things        uint64
+	channels      uint64
+	groups        uint64
+	keys          uint64
+	subscriptions uint64
+	orgID         string
+	groupIDs      []string
+}
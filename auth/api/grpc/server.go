@@ -15,20 +15,22 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	opentracing "github.com/opentracing/opentracing-go"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 var _ mainflux.AuthServiceServer = (*grpcServer)(nil)
 
 type grpcServer struct {
-	issue        kitgrpc.Handler
-	identify     kitgrpc.Handler
-	authorize    kitgrpc.Handler
-	addPolicy    kitgrpc.Handler
-	assign       kitgrpc.Handler
-	members      kitgrpc.Handler
-	assignRole   kitgrpc.Handler
-	retrieveRole kitgrpc.Handler
+	issue         kitgrpc.Handler
+	identify      kitgrpc.Handler
+	authorize     kitgrpc.Handler
+	addPolicy     kitgrpc.Handler
+	assign        kitgrpc.Handler
+	members       kitgrpc.Handler
+	assignRole    kitgrpc.Handler
+	retrieveRole  kitgrpc.Handler
+	retrieveQuota kitgrpc.Handler
 }
 
 // NewServer returns new AuthServiceServer instance.
@@ -43,11 +45,13 @@ func NewServer(tracer opentracing.Tracer, svc auth.Service) mainflux.AuthService
 			kitot.TraceServer(tracer, "identify")(identifyEndpoint(svc)),
 			decodeIdentifyRequest,
 			encodeIdentifyResponse,
+			kitgrpc.ServerBefore(decodeClientIP),
 		),
 		authorize: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "authorize")(authorizeEndpoint(svc)),
 			decodeAuthorizeRequest,
 			encodeEmptyResponse,
+			kitgrpc.ServerBefore(decodeClientIP),
 		),
 		addPolicy: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "add_policy")(addPolicyEndpoint(svc)),
@@ -74,6 +78,11 @@ func NewServer(tracer opentracing.Tracer, svc auth.Service) mainflux.AuthService
 			decodeRetrieveRoleRequest,
 			encodeRetrieveRoleResponse,
 		),
+		retrieveQuota: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_quota")(retrieveQuotaEndpoint(svc)),
+			decodeRetrieveQuotaRequest,
+			encodeRetrieveQuotaResponse,
+		),
 	}
 }
 
@@ -141,6 +150,14 @@ func (s *grpcServer) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRol
 	return res.(*mainflux.RetrieveRoleRes), nil
 }
 
+func (s *grpcServer) RetrieveQuota(ctx context.Context, req *mainflux.QuotaReq) (*mainflux.QuotaRes, error) {
+	_, res, err := s.retrieveQuota.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.QuotaRes), nil
+}
+
 func decodeAssignRoleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AssignRoleReq)
 	return assignRoleReq{ID: req.GetId(), Role: req.GetRole()}, nil
@@ -156,6 +173,24 @@ func encodeRetrieveRoleResponse(_ context.Context, grpcRes interface{}) (interfa
 	return &mainflux.RetrieveRoleRes{Role: res.role}, nil
 }
 
+func decodeRetrieveQuotaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.QuotaReq)
+	return quotaReq{subject: req.GetSubject(), id: req.GetId()}, nil
+}
+
+func encodeRetrieveQuotaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(quotaRes)
+	return &mainflux.QuotaRes{
+		Things:        res.things,
+		Channels:      res.channels,
+		Groups:        res.groups,
+		Keys:          res.keys,
+		Subscriptions: res.subscriptions,
+		OrgID:         res.orgID,
+		GroupIDs:      res.groupIDs,
+	}, nil
+}
+
 func decodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.IssueReq)
 	return issueReq{id: req.GetId(), email: req.GetEmail(), keyType: req.GetType()}, nil
@@ -173,7 +208,24 @@ func decodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{},
 
 func encodeIdentifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(identityRes)
-	return &mainflux.UserIdentity{Id: res.id, Email: res.email}, nil
+	return &mainflux.UserIdentity{
+		Id:         res.id,
+		Email:      res.email,
+		Scopes:     res.scopes,
+		OrgIDs:     res.orgIDs,
+		GroupIDs:   res.groupIDs,
+		ChannelIDs: res.channelIDs,
+	}, nil
+}
+
+// decodeClientIP puts the IP address of the client which issued the
+// original request, forwarded by the caller service, into the context.
+func decodeClientIP(ctx context.Context, md metadata.MD) context.Context {
+	if vals := md.Get(clientIPHeader); len(vals) > 0 {
+		return auth.WithClientIP(ctx, vals[0])
+	}
+
+	return ctx
 }
 
 func decodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -224,7 +276,10 @@ func encodeError(err error) error {
 	case errors.Contains(err, apiutil.ErrMalformedEntity),
 		err == apiutil.ErrInvalidAuthKey,
 		err == apiutil.ErrMissingID,
-		err == apiutil.ErrMissingMemberType:
+		err == apiutil.ErrMissingMemberType,
+		err == auth.ErrInvalidScope,
+		err == auth.ErrInvalidIPAddress,
+		err == auth.ErrInvalidQuotaSubject:
 		return status.Error(codes.InvalidArgument, err.Error())
 	case errors.Contains(err, errors.ErrAuthentication),
 		errors.Contains(err, auth.ErrKeyExpired),
@@ -233,6 +288,8 @@ func encodeError(err error) error {
 		return status.Error(codes.Unauthenticated, err.Error())
 	case errors.Contains(err, errors.ErrAuthorization):
 		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Contains(err, errors.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
 	default:
 		return status.Error(codes.Internal, "internal server error")
 	}
@@ -20,8 +20,13 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 
 		now := time.Now().UTC()
 		newKey := auth.Key{
-			IssuedAt: now,
-			Type:     req.Type,
+			IssuedAt:   now,
+			Type:       req.Type,
+			Scopes:     req.Scopes,
+			OrgIDs:     req.OrgIDs,
+			GroupIDs:   req.GroupIDs,
+			ChannelIDs: req.ChannelIDs,
+			AllowedIPs: req.AllowedIPs,
 		}
 
 		duration := time.Duration(req.Duration * time.Second)
@@ -61,15 +66,23 @@ func retrieveEndpoint(svc auth.Service) endpoint.Endpoint {
 			return nil, err
 		}
 		ret := retrieveKeyRes{
-			ID:       key.ID,
-			IssuerID: key.IssuerID,
-			Subject:  key.Subject,
-			Type:     key.Type,
-			IssuedAt: key.IssuedAt,
+			ID:         key.ID,
+			IssuerID:   key.IssuerID,
+			Subject:    key.Subject,
+			Type:       key.Type,
+			IssuedAt:   key.IssuedAt,
+			Scopes:     key.Scopes,
+			OrgIDs:     key.OrgIDs,
+			GroupIDs:   key.GroupIDs,
+			ChannelIDs: key.ChannelIDs,
+			AllowedIPs: key.AllowedIPs,
 		}
 		if !key.ExpiresAt.IsZero() {
 			ret.ExpiresAt = &key.ExpiresAt
 		}
+		if !key.LastUsedAt.IsZero() {
+			ret.LastUsedAt = &key.LastUsedAt
+		}
 
 		return ret, nil
 	}
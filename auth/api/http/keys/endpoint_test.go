@@ -34,8 +34,10 @@ const (
 )
 
 type issueRequest struct {
-	Duration time.Duration `json:"duration,omitempty"`
-	Type     uint32        `json:"type,omitempty"`
+	Duration   time.Duration `json:"duration,omitempty"`
+	Type       uint32        `json:"type,omitempty"`
+	Scopes     []string      `json:"scopes,omitempty"`
+	AllowedIPs []string      `json:"allowed_ips,omitempty"`
 }
 
 type testRequest struct {
@@ -68,7 +70,7 @@ func newService() auth.Service {
 	idProvider := uuid.NewMock()
 	t := jwt.New(secret)
 
-	return auth.New(nil, nil, nil, repo, nil, nil, idProvider, t, loginDuration)
+	return auth.New(nil, nil, nil, nil, repo, nil, nil, mocks.NewQuotasRepository(), auth.QuotaDefaults{}, idProvider, t, loginDuration)
 }
 
 func newServer(svc auth.Service) *httptest.Server {
@@ -94,6 +96,7 @@ func TestIssue(t *testing.T) {
 	lk := issueRequest{Type: auth.LoginKey}
 	ak := issueRequest{Type: auth.APIKey, Duration: time.Hour}
 	rk := issueRequest{Type: auth.RecoveryKey}
+	sk := issueRequest{Type: auth.APIKey, Duration: time.Hour, Scopes: []string{auth.ThingsReadScope}, AllowedIPs: []string{"10.0.0.0/24"}}
 
 	cases := []struct {
 		desc   string
@@ -123,6 +126,34 @@ func TestIssue(t *testing.T) {
 			token:  loginSecret,
 			status: http.StatusCreated,
 		},
+		{
+			desc:   "issue scoped API key",
+			req:    toJSON(sk),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "issue API key with invalid scope",
+			req:    toJSON(issueRequest{Type: auth.APIKey, Scopes: []string{"invalid"}}),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "issue API key with invalid allowed IP address",
+			req:    toJSON(issueRequest{Type: auth.APIKey, AllowedIPs: []string{"invalid"}}),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "issue scoped login key",
+			req:    toJSON(issueRequest{Type: auth.LoginKey, Scopes: []string{auth.ThingsReadScope}}),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
 		{
 			desc:   "issue login key wrong content type",
 			req:    toJSON(lk),
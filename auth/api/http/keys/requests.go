@@ -11,9 +11,14 @@ import (
 )
 
 type issueKeyReq struct {
-	token    string
-	Type     uint32        `json:"type,omitempty"`
-	Duration time.Duration `json:"duration,omitempty"`
+	token      string
+	Type       uint32        `json:"type,omitempty"`
+	Duration   time.Duration `json:"duration,omitempty"`
+	Scopes     []string      `json:"scopes,omitempty"`
+	OrgIDs     []string      `json:"org_ids,omitempty"`
+	GroupIDs   []string      `json:"group_ids,omitempty"`
+	ChannelIDs []string      `json:"channel_ids,omitempty"`
+	AllowedIPs []string      `json:"allowed_ips,omitempty"`
 }
 
 // It is not possible to issue Reset key using HTTP API.
@@ -28,6 +33,13 @@ func (req issueKeyReq) validate() error {
 		return apiutil.ErrInvalidAPIKey
 	}
 
+	// Only API keys are persisted, so only they can carry restrictions.
+	restricted := len(req.Scopes) > 0 || len(req.OrgIDs) > 0 || len(req.GroupIDs) > 0 ||
+		len(req.ChannelIDs) > 0 || len(req.AllowedIPs) > 0
+	if restricted && req.Type != auth.APIKey {
+		return apiutil.ErrInvalidAPIKey
+	}
+
 	return nil
 }
 
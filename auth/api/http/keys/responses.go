@@ -35,12 +35,18 @@ func (res issueKeyRes) Empty() bool {
 }
 
 type retrieveKeyRes struct {
-	ID        string     `json:"id,omitempty"`
-	IssuerID  string     `json:"issuer_id,omitempty"`
-	Subject   string     `json:"subject,omitempty"`
-	Type      uint32     `json:"type,omitempty"`
-	IssuedAt  time.Time  `json:"issued_at,omitempty"`
-	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ID         string     `json:"id,omitempty"`
+	IssuerID   string     `json:"issuer_id,omitempty"`
+	Subject    string     `json:"subject,omitempty"`
+	Type       uint32     `json:"type,omitempty"`
+	IssuedAt   time.Time  `json:"issued_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Scopes     []string   `json:"scopes,omitempty"`
+	OrgIDs     []string   `json:"org_ids,omitempty"`
+	GroupIDs   []string   `json:"group_ids,omitempty"`
+	ChannelIDs []string   `json:"channel_ids,omitempty"`
+	AllowedIPs []string   `json:"allowed_ips,omitempty"`
 }
 
 func (res retrieveKeyRes) Code() int {
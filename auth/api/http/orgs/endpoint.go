@@ -329,6 +329,31 @@ func createGroupPoliciesEndpoint(svc auth.Service) endpoint.Endpoint {
 	}
 }
 
+func createTagPoliciesEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(tagPoliciesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		var gps []auth.GroupPolicyByID
+		for _, g := range req.GroupPolicies {
+			gp := auth.GroupPolicyByID{
+				MemberID: g.ID,
+				Policy:   g.Policy,
+			}
+			gps = append(gps, gp)
+		}
+
+		groupIDs, err := svc.CreateTagPolicies(ctx, req.token, req.orgID, req.tag, gps...)
+		if err != nil {
+			return nil, err
+		}
+
+		return tagPoliciesRes{GroupIDs: groupIDs}, nil
+	}
+}
+
 func updateGroupPoliciesEndpoint(svc auth.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(groupPoliciesReq)
@@ -423,6 +448,43 @@ func restoreEndpoint(svc auth.Service) endpoint.Endpoint {
 	}
 }
 
+func exportBackupEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportBackupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		f := auth.BackupFilter{
+			OrgID: req.orgID,
+			Since: req.since,
+		}
+
+		stream, err := svc.ExportBackup(ctx, req.token, f)
+		if err != nil {
+			return nil, err
+		}
+
+		return exportBackupRes{stream: stream}, nil
+	}
+}
+
+func importBackupEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(importBackupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		summary, err := svc.ImportBackup(ctx, req.token, req.decoder, req.strategy)
+		if err != nil {
+			return nil, err
+		}
+
+		return importBackupRes(summary), nil
+	}
+}
+
 func buildOrgsResponse(op auth.OrgsPage) orgsPageRes {
 	res := orgsPageRes{
 		pageRes: pageRes{
@@ -494,9 +556,135 @@ func buildGroupsResponse(gp auth.GroupsPage) groupsPageRes {
 	return res
 }
 
+func createOrgRoleEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(orgRoleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		role := auth.OrgRole{
+			Name:        req.Name,
+			Description: req.Description,
+			Permissions: req.Permissions,
+		}
+
+		r, err := svc.CreateOrgRole(ctx, req.token, req.orgID, role)
+		if err != nil {
+			return nil, err
+		}
+
+		return orgRoleRes{orgID: r.OrgID, name: r.Name, created: true}, nil
+	}
+}
+
+func updateOrgRoleEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(orgRoleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		role := auth.OrgRole{
+			Name:        req.Name,
+			Description: req.Description,
+			Permissions: req.Permissions,
+		}
+
+		r, err := svc.UpdateOrgRole(ctx, req.token, req.orgID, role)
+		if err != nil {
+			return nil, err
+		}
+
+		return orgRoleRes{orgID: r.OrgID, name: r.Name, created: false}, nil
+	}
+}
+
+func viewOrgRoleEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewOrgRoleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		r, err := svc.ViewOrgRole(ctx, req.token, req.orgID, req.name)
+		if err != nil {
+			return nil, err
+		}
+
+		return toViewOrgRoleRes(r), nil
+	}
+}
+
+func listOrgRolesEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listOrgRolesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := auth.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+
+		page, err := svc.ListOrgRoles(ctx, req.token, req.orgID, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := orgRolesPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Roles: []viewOrgRoleRes{},
+		}
+
+		for _, r := range page.OrgRoles {
+			res.Roles = append(res.Roles, toViewOrgRoleRes(r))
+		}
+
+		return res, nil
+	}
+}
+
+func removeOrgRoleEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewOrgRoleReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveOrgRole(ctx, req.token, req.orgID, req.name); err != nil {
+			return nil, err
+		}
+
+		return deleteRes{}, nil
+	}
+}
+
+func toViewOrgRoleRes(r auth.OrgRole) viewOrgRoleRes {
+	perms := r.Permissions
+	if perms == nil {
+		perms = []string{}
+	}
+
+	return viewOrgRoleRes{
+		OrgID:       r.OrgID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: perms,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
 func buildBackupResponse(b auth.Backup) backupRes {
 	res := backupRes{
 		Orgs:          []viewOrgRes{},
+		OrgRoles:      []viewOrgRoleRes{},
 		OrgMembers:    []viewOrgMembers{},
 		OrgGroups:     []viewOrgGroups{},
 		GroupPolicies: []viewGroupPolicies{},
@@ -515,6 +703,10 @@ func buildBackupResponse(b auth.Backup) backupRes {
 		res.Orgs = append(res.Orgs, view)
 	}
 
+	for _, r := range b.OrgRoles {
+		res.OrgRoles = append(res.OrgRoles, toViewOrgRoleRes(r))
+	}
+
 	for _, mRel := range b.OrgMembers {
 		view := viewOrgMembers{
 			OrgID:     mRel.OrgID,
@@ -562,6 +754,18 @@ func buildRestoreReq(req restoreReq) (b auth.Backup) {
 		b.Orgs = append(b.Orgs, o)
 	}
 
+	for _, r := range req.OrgRoles {
+		role := auth.OrgRole{
+			OrgID:       r.OrgID,
+			Name:        r.Name,
+			Description: r.Description,
+			Permissions: r.Permissions,
+			CreatedAt:   r.CreatedAt,
+			UpdatedAt:   r.UpdatedAt,
+		}
+		b.OrgRoles = append(b.OrgRoles, role)
+	}
+
 	for _, om := range req.OrgMembers {
 		m := auth.OrgMember{
 			OrgID:     om.OrgID,
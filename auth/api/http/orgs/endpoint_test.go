@@ -36,6 +36,7 @@ const (
 	viewerID      = "viewerID"
 	groupID       = "groupID"
 	groupID2      = "groupID2"
+	tag           = "prod"
 	email         = "user@example.com"
 	adminEmail    = "admin@example.com"
 	editorEmail   = "editor@example.com"
@@ -59,7 +60,7 @@ var (
 	adminMember   = auth.OrgMember{MemberID: adminID, Email: adminEmail, Role: auth.AdminRole}
 	usersByEmails = map[string]users.User{adminEmail: {ID: adminID, Email: adminEmail}, editorEmail: {ID: editorID, Email: editorEmail}, viewerEmail: {ID: viewerID, Email: viewerEmail}, email: {ID: id, Email: email}}
 	usersByIDs    = map[string]users.User{adminID: {ID: adminID, Email: adminEmail}, editorID: {ID: editorID, Email: editorEmail}, viewerID: {ID: viewerID, Email: viewerEmail}, id: {ID: id, Email: email}}
-	groups        = map[string]things.Group{groupID: {ID: groupID, OwnerID: id, Name: name, Description: description}, groupID2: {ID: groupID2, OwnerID: id, Name: name, Description: description}}
+	groups        = map[string]things.Group{groupID: {ID: groupID, OwnerID: id, Name: name, Description: description, Tags: []string{tag}}, groupID2: {ID: groupID2, OwnerID: id, Name: name, Description: description}}
 )
 
 type testRequest struct {
@@ -98,7 +99,7 @@ func newService() auth.Service {
 	uc := mocks.NewUsersService(usersByIDs, usersByEmails)
 	tc := thmocks.NewThingsServiceClient(nil, groups)
 
-	return auth.New(orgsRepo, tc, uc, nil, rolesRepo, policiesRepo, idProvider, t, loginDuration)
+	return auth.New(orgsRepo, mocks.NewOrgRolesRepository(), tc, uc, nil, rolesRepo, policiesRepo, mocks.NewQuotasRepository(), auth.QuotaDefaults{}, idProvider, t, loginDuration)
 }
 
 func newServer(svc auth.Service) *httptest.Server {
@@ -1539,6 +1540,278 @@ func TestListGroups(t *testing.T) {
 	}
 }
 
+func TestCreateTagPolicies(t *testing.T) {
+	svc := newService()
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	or, err := svc.CreateOrg(context.Background(), token, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignMembers(context.Background(), token, or.ID, editorMember)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignGroups(context.Background(), token, or.ID, groupID, groupID2)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	data := toJSON(tagPoliciesReq{GroupPolicies: []groupPolicy{{ID: editorID, Policy: auth.RwPolicy}}})
+	invalidData := toJSON(tagPoliciesReq{GroupPolicies: []groupPolicy{{ID: editorID, Policy: wrongValue}}})
+
+	cases := []struct {
+		desc     string
+		token    string
+		orgID    string
+		tag      string
+		req      string
+		status   int
+		groupIDs []string
+	}{
+		{
+			desc:     "create tag policies",
+			token:    token,
+			orgID:    or.ID,
+			tag:      tag,
+			req:      data,
+			status:   http.StatusOK,
+			groupIDs: []string{groupID},
+		},
+		{
+			desc:   "create tag policies with invalid auth token",
+			token:  wrongValue,
+			orgID:  or.ID,
+			tag:    tag,
+			req:    data,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "create tag policies with invalid policy",
+			token:  token,
+			orgID:  or.ID,
+			tag:    tag,
+			req:    invalidData,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "create tag policies without policies",
+			token:  token,
+			orgID:  or.ID,
+			tag:    tag,
+			req:    toJSON(tagPoliciesReq{}),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "create tag policies with invalid request body",
+			token:  token,
+			orgID:  or.ID,
+			tag:    tag,
+			req:    "}",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:     "create policies for tag without groups",
+			token:    token,
+			orgID:    or.ID,
+			tag:      wrongValue,
+			req:      data,
+			status:   http.StatusOK,
+			groupIDs: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/orgs/%s/tags/%s/policies", ts.URL, tc.orgID, tc.tag),
+			token:       tc.token,
+			contentType: contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body tagPoliciesRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.ElementsMatch(t, tc.groupIDs, body.GroupIDs, fmt.Sprintf("%s: expected group ids %v got %v", tc.desc, tc.groupIDs, body.GroupIDs))
+	}
+}
+
+func TestCreateOrgRole(t *testing.T) {
+	svc := newService()
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	or, err := svc.CreateOrg(context.Background(), token, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	data := toJSON(orgRoleReq{Name: "technician", Permissions: []string{auth.ViewOrgPermission, auth.CreateThingsPermission}})
+
+	cases := []struct {
+		desc        string
+		token       string
+		id          string
+		req         string
+		contentType string
+		status      int
+		location    string
+	}{
+		{
+			desc:        "create org role",
+			token:       token,
+			id:          or.ID,
+			req:         data,
+			contentType: contentType,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/orgs/%s/roles/technician", or.ID),
+		},
+		{
+			desc:        "create existing org role",
+			token:       token,
+			id:          or.ID,
+			req:         data,
+			contentType: contentType,
+			status:      http.StatusConflict,
+		},
+		{
+			desc:        "create org role with built-in role name",
+			token:       token,
+			id:          or.ID,
+			req:         toJSON(orgRoleReq{Name: auth.EditorRole}),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create org role with invalid permission",
+			token:       token,
+			id:          or.ID,
+			req:         toJSON(orgRoleReq{Name: "reader", Permissions: []string{wrongValue}}),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create org role without name",
+			token:       token,
+			id:          or.ID,
+			req:         toJSON(orgRoleReq{Permissions: []string{auth.ViewOrgPermission}}),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create org role with invalid auth token",
+			token:       wrongValue,
+			id:          or.ID,
+			req:         data,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create org role without content type",
+			token:       token,
+			id:          or.ID,
+			req:         data,
+			contentType: "",
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/orgs/%s/roles", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, res.Header.Get("Location")))
+	}
+}
+
+func TestViewOrgRole(t *testing.T) {
+	svc := newService()
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	or, err := svc.CreateOrg(context.Background(), token, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	role := auth.OrgRole{Name: "technician", Description: description, Permissions: []string{auth.ViewOrgPermission}}
+	_, err = svc.CreateOrgRole(context.Background(), token, or.ID, role)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		name   string
+		status int
+		res    orgRoleRes
+	}{
+		{
+			desc:   "view custom org role",
+			token:  token,
+			name:   role.Name,
+			status: http.StatusOK,
+			res:    orgRoleRes{OrgID: or.ID, Name: role.Name, Description: description, Permissions: role.Permissions},
+		},
+		{
+			desc:   "view built-in org role",
+			token:  token,
+			name:   auth.ViewerRole,
+			status: http.StatusOK,
+			res:    orgRoleRes{OrgID: or.ID, Name: auth.ViewerRole, Permissions: []string{auth.ViewOrgPermission, auth.ReadGroupsPermission, auth.ReadMessagesPermission}},
+		},
+		{
+			desc:   "view non-existing org role",
+			token:  token,
+			name:   wrongValue,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view org role with invalid auth token",
+			token:  wrongValue,
+			name:   role.Name,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/orgs/%s/roles/%s", ts.URL, or.ID, tc.name),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		var data orgRoleRes
+		err = json.NewDecoder(res.Body).Decode(&data)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, data, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, data))
+	}
+}
+
 func TestBackup(t *testing.T) {
 	svc := newService()
 	_, adminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
@@ -1592,18 +1865,22 @@ func TestBackup(t *testing.T) {
 		{
 			MemberID: id,
 			OrgID:    o.ID,
+			Role:     auth.OwnerRole,
 		},
 		{
 			MemberID: adminID,
 			OrgID:    o.ID,
+			Role:     auth.AdminRole,
 		},
 		{
 			MemberID: editorID,
 			OrgID:    o.ID,
+			Role:     auth.EditorRole,
 		},
 		{
 			MemberID: viewerID,
 			OrgID:    o.ID,
+			Role:     auth.ViewerRole,
 		},
 	}
 
@@ -1620,10 +1897,12 @@ func TestBackup(t *testing.T) {
 
 	gp := []viewGroupPolicies{
 		{
+			GroupID:  grIDs[1],
 			MemberID: o.OwnerID,
 			Policy:   auth.RwPolicy,
 		},
 		{
+			GroupID:  grIDs[0],
 			MemberID: viewerID,
 			Policy:   auth.RPolicy,
 		},
@@ -1900,12 +2179,38 @@ type viewOrgGroups struct {
 	OrgID   string `json:"org_id"`
 }
 
+type groupPolicy struct {
+	ID     string `json:"id"`
+	Policy string `json:"policy"`
+}
+
+type tagPoliciesReq struct {
+	GroupPolicies []groupPolicy `json:"group_policies"`
+}
+
+type tagPoliciesRes struct {
+	GroupIDs []string `json:"group_ids"`
+}
+
 type viewGroupPolicies struct {
 	GroupID  string `json:"group_id"`
 	MemberID string `json:"member_id"`
 	Policy   string `json:"policy"`
 }
 
+type orgRoleReq struct {
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+type orgRoleRes struct {
+	OrgID       string   `json:"org_id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
 type backup struct {
 	Orgs          []orgRes            `json:"orgs"`
 	OrgMembers    []viewOrgMembers    `json:"org_members"`
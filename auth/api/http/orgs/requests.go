@@ -1,8 +1,11 @@
 package orgs
 
 import (
+	"time"
+
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 )
 
 type createOrgReq struct {
@@ -160,7 +163,7 @@ func (req membersReq) validate() error {
 	}
 
 	for _, m := range req.OrgMembers {
-		if m.Role != auth.AdminRole && m.Role != auth.ViewerRole && m.Role != auth.EditorRole {
+		if m.Role == "" || m.Role == auth.OwnerRole || len(m.Role) > maxRoleSize {
 			return apiutil.ErrInvalidMemberRole
 		}
 	}
@@ -260,6 +263,34 @@ func (req backupReq) validate() error {
 	return nil
 }
 
+type exportBackupReq struct {
+	token string
+	orgID string
+	since time.Time
+}
+
+func (req exportBackupReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type importBackupReq struct {
+	token    string
+	strategy string
+	decoder  *backup.Decoder
+}
+
+func (req importBackupReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return backup.ValidateStrategy(req.strategy)
+}
+
 type groupPoliciesReq struct {
 	token         string
 	groupID       string
@@ -292,6 +323,43 @@ func (req groupPoliciesReq) validate() error {
 	return nil
 }
 
+type tagPoliciesReq struct {
+	token         string
+	orgID         string
+	tag           string
+	GroupPolicies []groupPolicy `json:"group_policies"`
+}
+
+func (req tagPoliciesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.orgID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.tag == "" {
+		return apiutil.ErrMissingTag
+	}
+
+	if len(req.GroupPolicies) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	for _, gp := range req.GroupPolicies {
+		if gp.Policy != auth.RPolicy && gp.Policy != auth.RwPolicy {
+			return apiutil.ErrInvalidPolicy
+		}
+
+		if gp.ID == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
+
 type removeGroupPoliciesReq struct {
 	token     string
 	groupID   string
@@ -337,9 +405,76 @@ func (req viewGroupMembershipReq) validate() error {
 	return nil
 }
 
+type orgRoleReq struct {
+	token       string
+	orgID       string
+	name        string
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+func (req orgRoleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.orgID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Name == "" || len(req.Name) > maxRoleSize {
+		return apiutil.ErrNameSize
+	}
+
+	if len(req.Description) > maxDescSize {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type viewOrgRoleReq struct {
+	token string
+	orgID string
+	name  string
+}
+
+func (req viewOrgRoleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.orgID == "" || req.name == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listOrgRolesReq struct {
+	token  string
+	orgID  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listOrgRolesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.orgID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
 type restoreReq struct {
 	token         string
 	Orgs          []viewOrgRes        `json:"orgs"`
+	OrgRoles      []viewOrgRoleRes    `json:"org_roles"`
 	OrgMembers    []viewOrgMembers    `json:"org_members"`
 	OrgGroups     []viewOrgGroups     `json:"org_groups"`
 	GroupPolicies []viewGroupPolicies `json:"group_policies"`
@@ -350,7 +485,7 @@ func (req restoreReq) validate() error {
 		return apiutil.ErrBearerToken
 	}
 
-	if len(req.Orgs) == 0 && len(req.OrgMembers) == 0 && len(req.OrgGroups) == 0 && len(req.GroupPolicies) == 0 {
+	if len(req.Orgs) == 0 && len(req.OrgRoles) == 0 && len(req.OrgMembers) == 0 && len(req.OrgGroups) == 0 && len(req.GroupPolicies) == 0 {
 		return apiutil.ErrEmptyList
 	}
 
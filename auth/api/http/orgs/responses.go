@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 )
 
 var (
@@ -16,9 +17,14 @@ var (
 	_ mainflux.Response = (*unassignRes)(nil)
 	_ mainflux.Response = (*backupRes)(nil)
 	_ mainflux.Response = (*restoreRes)(nil)
+	_ mainflux.Response = (*importBackupRes)(nil)
 	_ mainflux.Response = (*listGroupPoliciesRes)(nil)
 	_ mainflux.Response = (*updateGroupPoliciesRes)(nil)
 	_ mainflux.Response = (*createGroupPoliciesRes)(nil)
+	_ mainflux.Response = (*tagPoliciesRes)(nil)
+	_ mainflux.Response = (*orgRoleRes)(nil)
+	_ mainflux.Response = (*viewOrgRoleRes)(nil)
+	_ mainflux.Response = (*orgRolesPageRes)(nil)
 )
 
 type viewMemberRes struct {
@@ -216,8 +222,75 @@ type viewGroupPolicies struct {
 	Policy   string `json:"policy"`
 }
 
+type orgRoleRes struct {
+	orgID   string
+	name    string
+	created bool
+}
+
+func (res orgRoleRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res orgRoleRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/orgs/%s/roles/%s", res.orgID, res.name),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res orgRoleRes) Empty() bool {
+	return true
+}
+
+type viewOrgRoleRes struct {
+	OrgID       string    `json:"org_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (res viewOrgRoleRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewOrgRoleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewOrgRoleRes) Empty() bool {
+	return false
+}
+
+type orgRolesPageRes struct {
+	pageRes
+	Roles []viewOrgRoleRes `json:"roles"`
+}
+
+func (res orgRolesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res orgRolesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res orgRolesPageRes) Empty() bool {
+	return false
+}
+
 type backupRes struct {
 	Orgs          []viewOrgRes        `json:"orgs"`
+	OrgRoles      []viewOrgRoleRes    `json:"org_roles"`
 	OrgMembers    []viewOrgMembers    `json:"org_members"`
 	OrgGroups     []viewOrgGroups     `json:"org_groups"`
 	GroupPolicies []viewGroupPolicies `json:"group_policies"`
@@ -272,6 +345,28 @@ func (res restoreRes) Empty() bool {
 	return true
 }
 
+type exportBackupRes struct {
+	stream backup.Stream
+}
+
+type importBackupRes struct {
+	Created uint64 `json:"created"`
+	Updated uint64 `json:"updated"`
+	Skipped uint64 `json:"skipped"`
+}
+
+func (res importBackupRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res importBackupRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res importBackupRes) Empty() bool {
+	return false
+}
+
 type createGroupPoliciesRes struct{}
 
 func (res createGroupPoliciesRes) Code() int {
@@ -286,6 +381,22 @@ func (res createGroupPoliciesRes) Empty() bool {
 	return true
 }
 
+type tagPoliciesRes struct {
+	GroupIDs []string `json:"group_ids"`
+}
+
+func (res tagPoliciesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res tagPoliciesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res tagPoliciesRes) Empty() bool {
+	return false
+}
+
 type updateGroupPoliciesRes struct{}
 
 func (res updateGroupPoliciesRes) Code() int {
@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
 	kitot "github.com/go-kit/kit/tracing/opentracing"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
@@ -20,6 +23,8 @@ import (
 const (
 	contentType = "application/json"
 	maxNameSize = 254
+	maxDescSize = 1024
+	maxRoleSize = 64
 	offsetKey   = "offset"
 	limitKey    = "limit"
 	metadataKey = "metadata"
@@ -30,12 +35,18 @@ const (
 	orgIDKey    = "orgID"
 	memberKey   = "memberID"
 	groupIDKey  = "groupID"
+	roleNameKey = "roleName"
+	tagKey      = "tag"
+	orgKey      = "org_id"
+	sinceKey    = "since"
+	strategyKey = "strategy"
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
 func MakeHandler(svc auth.Service, mux *bone.Mux, tracer opentracing.Tracer, logger logger.Logger) *bone.Mux {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+		kithttp.ServerBefore(apiutil.ClientIPToContext),
 	}
 	mux.Post("/orgs", kithttp.NewServer(
 		kitot.TraceServer(tracer, "create_org")(createOrgEndpoint(svc)),
@@ -107,6 +118,41 @@ func MakeHandler(svc auth.Service, mux *bone.Mux, tracer opentracing.Tracer, log
 		opts...,
 	))
 
+	mux.Post("/orgs/:orgID/roles", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_org_role")(createOrgRoleEndpoint(svc)),
+		decodeOrgRoleRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/orgs/:orgID/roles", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_org_roles")(listOrgRolesEndpoint(svc)),
+		decodeListOrgRolesRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/orgs/:orgID/roles/:roleName", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_org_role")(viewOrgRoleEndpoint(svc)),
+		decodeViewOrgRoleRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Put("/orgs/:orgID/roles/:roleName", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_org_role")(updateOrgRoleEndpoint(svc)),
+		decodeOrgRoleRequest,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/orgs/:orgID/roles/:roleName", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_org_role")(removeOrgRoleEndpoint(svc)),
+		decodeViewOrgRoleRequest,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Get("/groups/:groupID/orgs", kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_group_membership")(viewGroupMembershipEndpoint(svc)),
 		decodeViewGroupMembershipRequest,
@@ -142,6 +188,13 @@ func MakeHandler(svc auth.Service, mux *bone.Mux, tracer opentracing.Tracer, log
 		opts...,
 	))
 
+	mux.Post("/orgs/:orgID/tags/:tag/policies", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_tag_policies")(createTagPoliciesEndpoint(svc)),
+		decodeTagPoliciesRequest,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Post("/orgs/:orgID/groups", kithttp.NewServer(
 		kitot.TraceServer(tracer, "assign_groups")(assignOrgGroupsEndpoint(svc)),
 		decodeGroupsRequest,
@@ -184,6 +237,20 @@ func MakeHandler(svc auth.Service, mux *bone.Mux, tracer opentracing.Tracer, log
 		opts...,
 	))
 
+	mux.Get("/backup/export", kithttp.NewServer(
+		kitot.TraceServer(tracer, "export_backup")(exportBackupEndpoint(svc)),
+		decodeExportBackup,
+		encodeBackupStream,
+		opts...,
+	))
+
+	mux.Post("/backup/import", kithttp.NewServer(
+		kitot.TraceServer(tracer, "import_backup")(importBackupEndpoint(svc)),
+		decodeImportBackup,
+		encodeResponse,
+		opts...,
+	))
+
 	return mux
 }
 
@@ -304,6 +371,24 @@ func decodeGroupPoliciesRequest(_ context.Context, r *http.Request) (interface{}
 	return req, nil
 }
 
+func decodeTagPoliciesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := tagPoliciesReq{
+		token: apiutil.ExtractBearerToken(r),
+		orgID: bone.GetValue(r, orgIDKey),
+		tag:   bone.GetValue(r, tagKey),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeListMembershipsRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
 	if err != nil {
@@ -398,6 +483,60 @@ func decodeMembersRequest(_ context.Context, r *http.Request) (interface{}, erro
 	return req, nil
 }
 
+func decodeOrgRoleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := orgRoleReq{
+		token: apiutil.ExtractBearerToken(r),
+		orgID: bone.GetValue(r, orgIDKey),
+		name:  bone.GetValue(r, roleNameKey),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	// On update, the role is identified by the path.
+	if req.name != "" {
+		req.Name = req.name
+	}
+
+	return req, nil
+}
+
+func decodeViewOrgRoleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := viewOrgRoleReq{
+		token: apiutil.ExtractBearerToken(r),
+		orgID: bone.GetValue(r, orgIDKey),
+		name:  bone.GetValue(r, roleNameKey),
+	}
+
+	return req, nil
+}
+
+func decodeListOrgRolesRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := apiutil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listOrgRolesReq{
+		token:  apiutil.ExtractBearerToken(r),
+		orgID:  bone.GetValue(r, orgIDKey),
+		offset: o,
+		limit:  l,
+	}
+
+	return req, nil
+}
+
 func decodeMemberRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	req := memberReq{
 		token:    apiutil.ExtractBearerToken(r),
@@ -492,6 +631,61 @@ func decodeRestore(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeExportBackup(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := apiutil.ReadStringQuery(r, orgKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := apiutil.ReadStringQuery(r, sinceKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if s != "" {
+		if since, err = time.Parse(time.RFC3339, s); err != nil {
+			return nil, errors.Wrap(apiutil.ErrInvalidQueryParams, err)
+		}
+	}
+
+	req := exportBackupReq{
+		token: apiutil.ExtractBearerToken(r),
+		orgID: orgID,
+		since: since,
+	}
+
+	return req, nil
+}
+
+func decodeImportBackup(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), backup.ContentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	strategy, err := apiutil.ReadStringQuery(r, strategyKey, backup.StrategyFail)
+	if err != nil {
+		return nil, err
+	}
+
+	req := importBackupReq{
+		token:    apiutil.ExtractBearerToken(r),
+		strategy: strategy,
+		decoder:  backup.NewDecoder(r.Body),
+	}
+
+	return req, nil
+}
+
+func encodeBackupStream(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(exportBackupRes)
+
+	w.Header().Set("Content-Type", backup.ContentType)
+	w.WriteHeader(http.StatusOK)
+
+	return res.stream(backup.NewEncoder(w))
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", contentType)
 
@@ -514,11 +708,19 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, apiutil.ErrMalformedEntity),
 		err == apiutil.ErrMissingID,
+		err == apiutil.ErrMissingTag,
+		err == apiutil.ErrInvalidPolicy,
 		err == apiutil.ErrEmptyList,
 		err == apiutil.ErrMissingMemberType,
 		err == apiutil.ErrNameSize,
 		err == apiutil.ErrInvalidMemberRole,
-		err == apiutil.ErrInvalidQueryParams:
+		errors.Contains(err, auth.ErrInvalidOrgRole),
+		errors.Contains(err, auth.ErrInvalidPermission),
+		errors.Contains(err, auth.ErrBuiltinOrgRole),
+		errors.Contains(err, apiutil.ErrInvalidQueryParams),
+		errors.Contains(err, backup.ErrMalformedRecord),
+		errors.Contains(err, backup.ErrUnsupportedVersion),
+		err == backup.ErrInvalidStrategy:
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errors.ErrAuthentication),
 		err == apiutil.ErrBearerToken:
@@ -533,6 +735,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusConflict)
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
+	case errors.Contains(err, quota.ErrQuotaExceeded):
+		w.WriteHeader(http.StatusTooManyRequests)
 
 	case errors.Contains(err, errors.ErrCreateEntity),
 		errors.Contains(err, errors.ErrUpdateEntity),
@@ -0,0 +1,98 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package quotas
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func updateQuotaEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateQuotaReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		q := auth.Quota{
+			Subject: req.subject,
+			ID:      req.id,
+			Limits: auth.Limits{
+				Things:        req.Things,
+				Channels:      req.Channels,
+				Groups:        req.Groups,
+				Keys:          req.Keys,
+				Subscriptions: req.Subscriptions,
+			},
+		}
+
+		if err := svc.UpdateQuota(ctx, req.token, q); err != nil {
+			return nil, err
+		}
+
+		return updateRes{}, nil
+	}
+}
+
+func viewQuotaEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(quotaReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		q, err := svc.ViewQuota(ctx, req.token, req.subject, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		res := quotaRes{
+			Subject:       q.Subject,
+			ID:            q.ID,
+			Things:        q.Limits.Things,
+			Channels:      q.Limits.Channels,
+			Groups:        q.Limits.Groups,
+			Keys:          q.Limits.Keys,
+			Subscriptions: q.Limits.Subscriptions,
+		}
+		if !q.UpdatedAt.IsZero() {
+			res.UpdatedAt = &q.UpdatedAt
+		}
+
+		return res, nil
+	}
+}
+
+func removeQuotaEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(quotaReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveQuota(ctx, req.token, req.subject, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func viewUsageEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewUsageReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		usage, err := svc.ViewUsage(ctx, req.token, req.orgID)
+		if err != nil {
+			return nil, err
+		}
+
+		return usageRes(usage), nil
+	}
+}
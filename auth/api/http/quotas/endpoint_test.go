@@ -0,0 +1,404 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package quotas_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	httpapi "github.com/MainfluxLabs/mainflux/auth/api/http"
+	"github.com/MainfluxLabs/mainflux/auth/jwt"
+	"github.com/MainfluxLabs/mainflux/auth/mocks"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	thmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/things"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	secret        = "secret"
+	contentType   = "application/json"
+	userID        = "userID"
+	adminID       = "adminID"
+	userEmail     = "user@example.com"
+	adminEmail    = "admin@example.com"
+	wrongValue    = "wrong_value"
+	loginDuration = 30 * time.Minute
+)
+
+var (
+	defaults      = auth.QuotaDefaults{User: auth.Limits{Things: 1, Keys: 2}, Org: auth.Limits{Groups: 3}}
+	usersByEmails = map[string]users.User{userEmail: {ID: userID, Email: userEmail}, adminEmail: {ID: adminID, Email: adminEmail}}
+	usersByIDs    = map[string]users.User{userID: {ID: userID, Email: userEmail}, adminID: {ID: adminID, Email: adminEmail}}
+)
+
+type quotaReq struct {
+	Things        uint64 `json:"things"`
+	Channels      uint64 `json:"channels"`
+	Groups        uint64 `json:"groups"`
+	Keys          uint64 `json:"keys"`
+	Subscriptions uint64 `json:"subscriptions"`
+}
+
+type quotaRes struct {
+	Subject       string `json:"subject"`
+	ID            string `json:"id"`
+	Things        uint64 `json:"things"`
+	Channels      uint64 `json:"channels"`
+	Groups        uint64 `json:"groups"`
+	Keys          uint64 `json:"keys"`
+	Subscriptions uint64 `json:"subscriptions"`
+}
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	req.Header.Set("Referer", "http://localhost")
+	return tr.client.Do(req)
+}
+
+func newService() auth.Service {
+	t := jwt.New(secret)
+	uc := mocks.NewUsersService(usersByIDs, usersByEmails)
+	tc := thmocks.NewThingsServiceClient(nil, map[string]things.Group{})
+
+	return auth.New(mocks.NewOrgRepository(), mocks.NewOrgRolesRepository(), tc, uc, mocks.NewKeyRepository(), mocks.NewRolesRepository(), mocks.NewPoliciesRepository(), mocks.NewQuotasRepository(), defaults, uuid.NewMock(), t, loginDuration)
+}
+
+func newServer(svc auth.Service) *httptest.Server {
+	logger := logger.NewMock()
+	mux := httpapi.MakeHandler(svc, mocktracer.New(), logger)
+	return httptest.NewServer(mux)
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+func issueTokens(t *testing.T, svc auth.Service) (string, string) {
+	_, userToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: userID, Subject: userEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, adminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: adminID, Subject: adminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.AssignRole(context.Background(), adminID, auth.RoleRootAdmin)
+	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
+
+	return userToken, adminToken
+}
+
+func TestUpdateQuota(t *testing.T) {
+	svc := newService()
+	userToken, adminToken := issueTokens(t, svc)
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	data := toJSON(quotaReq{Things: 10, Channels: 10, Keys: 5})
+
+	cases := []struct {
+		desc    string
+		req     string
+		ct      string
+		token   string
+		subject string
+		id      string
+		status  int
+	}{
+		{
+			desc:    "update user quota as root admin",
+			req:     data,
+			ct:      contentType,
+			token:   adminToken,
+			subject: quota.User,
+			id:      userID,
+			status:  http.StatusOK,
+		},
+		{
+			desc:    "update user quota as user",
+			req:     data,
+			ct:      contentType,
+			token:   userToken,
+			subject: quota.User,
+			id:      userID,
+			status:  http.StatusForbidden,
+		},
+		{
+			desc:    "update quota of non-existing org",
+			req:     data,
+			ct:      contentType,
+			token:   adminToken,
+			subject: quota.Org,
+			id:      wrongValue,
+			status:  http.StatusNotFound,
+		},
+		{
+			desc:    "update quota with invalid subject",
+			req:     data,
+			ct:      contentType,
+			token:   adminToken,
+			subject: wrongValue,
+			id:      userID,
+			status:  http.StatusBadRequest,
+		},
+		{
+			desc:    "update quota with invalid request format",
+			req:     "{",
+			ct:      contentType,
+			token:   adminToken,
+			subject: quota.User,
+			id:      userID,
+			status:  http.StatusBadRequest,
+		},
+		{
+			desc:    "update quota without content type",
+			req:     data,
+			ct:      "",
+			token:   adminToken,
+			subject: quota.User,
+			id:      userID,
+			status:  http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:    "update quota with empty token",
+			req:     data,
+			ct:      contentType,
+			token:   "",
+			subject: quota.User,
+			id:      userID,
+			status:  http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/quotas/%s/%s", ts.URL, tc.subject, tc.id),
+			contentType: tc.ct,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestViewQuota(t *testing.T) {
+	svc := newService()
+	userToken, adminToken := issueTokens(t, svc)
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	limits := auth.Limits{Things: 10, Channels: 20}
+	err := svc.UpdateQuota(context.Background(), adminToken, auth.Quota{Subject: quota.User, ID: adminID, Limits: limits})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		id     string
+		status int
+		res    quotaRes
+	}{
+		{
+			desc:   "view own default quota",
+			token:  userToken,
+			id:     userID,
+			status: http.StatusOK,
+			res:    quotaRes{Subject: quota.User, ID: userID, Things: defaults.User.Things, Keys: defaults.User.Keys},
+		},
+		{
+			desc:   "view own updated quota",
+			token:  adminToken,
+			id:     adminID,
+			status: http.StatusOK,
+			res:    quotaRes{Subject: quota.User, ID: adminID, Things: limits.Things, Channels: limits.Channels},
+		},
+		{
+			desc:   "view quota of another user",
+			token:  userToken,
+			id:     adminID,
+			status: http.StatusForbidden,
+			res:    quotaRes{},
+		},
+		{
+			desc:   "view quota with invalid token",
+			token:  wrongValue,
+			id:     userID,
+			status: http.StatusUnauthorized,
+			res:    quotaRes{},
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/quotas/%s/%s", ts.URL, quota.User, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var body quotaRes
+		if res.StatusCode == http.StatusOK {
+			err = json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		}
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, body))
+	}
+}
+
+func TestRemoveQuota(t *testing.T) {
+	svc := newService()
+	userToken, adminToken := issueTokens(t, svc)
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	err := svc.UpdateQuota(context.Background(), adminToken, auth.Quota{Subject: quota.User, ID: userID, Limits: auth.Limits{Things: 10}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		subject string
+		status  int
+	}{
+		{
+			desc:    "remove quota as user",
+			token:   userToken,
+			subject: quota.User,
+			status:  http.StatusForbidden,
+		},
+		{
+			desc:    "remove quota with invalid subject",
+			token:   adminToken,
+			subject: wrongValue,
+			status:  http.StatusBadRequest,
+		},
+		{
+			desc:    "remove quota as root admin",
+			token:   adminToken,
+			subject: quota.User,
+			status:  http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/quotas/%s/%s", ts.URL, tc.subject, userID),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestViewUsage(t *testing.T) {
+	svc := newService()
+	userToken, _ := issueTokens(t, svc)
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	_, _, err := svc.Issue(context.Background(), userToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), userToken, auth.Org{Name: "org"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		query  string
+		status int
+		res    map[string]quota.Usage
+	}{
+		{
+			desc:   "view user usage",
+			token:  userToken,
+			query:  "",
+			status: http.StatusOK,
+			res:    map[string]quota.Usage{quota.Keys: {Used: 1, Limit: defaults.User.Keys}},
+		},
+		{
+			desc:   "view org usage",
+			token:  userToken,
+			query:  fmt.Sprintf("?org_id=%s", or.ID),
+			status: http.StatusOK,
+			res:    map[string]quota.Usage{quota.Groups: {Used: 0, Limit: defaults.Org.Groups}},
+		},
+		{
+			desc:   "view usage with invalid token",
+			token:  wrongValue,
+			query:  "",
+			status: http.StatusUnauthorized,
+			res:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/usage%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var body map[string]quota.Usage
+		if res.StatusCode == http.StatusOK {
+			err = json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		}
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, body))
+	}
+}
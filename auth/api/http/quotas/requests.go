@@ -0,0 +1,60 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package quotas
+
+import (
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+)
+
+type quotaReq struct {
+	token   string
+	subject string
+	id      string
+}
+
+func (req quotaReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.subject != quota.User && req.subject != quota.Org {
+		return auth.ErrInvalidQuotaSubject
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type updateQuotaReq struct {
+	token         string
+	subject       string
+	id            string
+	Things        uint64 `json:"things"`
+	Channels      uint64 `json:"channels"`
+	Groups        uint64 `json:"groups"`
+	Keys          uint64 `json:"keys"`
+	Subscriptions uint64 `json:"subscriptions"`
+}
+
+func (req updateQuotaReq) validate() error {
+	return quotaReq{token: req.token, subject: req.subject, id: req.id}.validate()
+}
+
+type viewUsageReq struct {
+	token string
+	orgID string
+}
+
+func (req viewUsageReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package quotas
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+)
+
+var (
+	_ mainflux.Response = (*quotaRes)(nil)
+	_ mainflux.Response = (*updateRes)(nil)
+	_ mainflux.Response = (*removeRes)(nil)
+	_ mainflux.Response = (*usageRes)(nil)
+)
+
+type quotaRes struct {
+	Subject       string     `json:"subject"`
+	ID            string     `json:"id"`
+	Things        uint64     `json:"things"`
+	Channels      uint64     `json:"channels"`
+	Groups        uint64     `json:"groups"`
+	Keys          uint64     `json:"keys"`
+	Subscriptions uint64     `json:"subscriptions"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+func (res quotaRes) Code() int {
+	return http.StatusOK
+}
+
+func (res quotaRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res quotaRes) Empty() bool {
+	return false
+}
+
+type updateRes struct{}
+
+func (res updateRes) Code() int {
+	return http.StatusOK
+}
+
+func (res updateRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res updateRes) Empty() bool {
+	return true
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
+
+type usageRes map[string]quota.Usage
+
+func (res usageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res usageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res usageRes) Empty() bool {
+	return false
+}
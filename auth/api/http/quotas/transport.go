@@ -0,0 +1,150 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package quotas
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/opentracing/opentracing-go"
+)
+
+const (
+	contentType = "application/json"
+	orgIDKey    = "org_id"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc auth.Service, mux *bone.Mux, tracer opentracing.Tracer, logger logger.Logger) *bone.Mux {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
+	mux.Put("/quotas/:subject/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_quota")(updateQuotaEndpoint(svc)),
+		decodeUpdateQuota,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/quotas/:subject/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_quota")(viewQuotaEndpoint(svc)),
+		decodeQuotaReq,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/quotas/:subject/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_quota")(removeQuotaEndpoint(svc)),
+		decodeQuotaReq,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/usage", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_usage")(viewUsageEndpoint(svc)),
+		decodeViewUsage,
+		encodeResponse,
+		opts...,
+	))
+
+	return mux
+}
+
+func decodeUpdateQuota(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := updateQuotaReq{
+		token:   apiutil.ExtractBearerToken(r),
+		subject: bone.GetValue(r, "subject"),
+		id:      bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeQuotaReq(_ context.Context, r *http.Request) (interface{}, error) {
+	req := quotaReq{
+		token:   apiutil.ExtractBearerToken(r),
+		subject: bone.GetValue(r, "subject"),
+		id:      bone.GetValue(r, "id"),
+	}
+
+	return req, nil
+}
+
+func decodeViewUsage(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := apiutil.ReadStringQuery(r, orgIDKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req := viewUsageReq{
+		token: apiutil.ExtractBearerToken(r),
+		orgID: orgID,
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case errors.Contains(err, apiutil.ErrMalformedEntity),
+		err == apiutil.ErrMissingID,
+		errors.Contains(err, apiutil.ErrInvalidQueryParams),
+		errors.Contains(err, auth.ErrInvalidQuotaSubject):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrAuthentication),
+		err == apiutil.ErrBearerToken:
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
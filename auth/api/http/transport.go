@@ -9,6 +9,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/auth/api/http/keys"
 	"github.com/MainfluxLabs/mainflux/auth/api/http/orgs"
+	"github.com/MainfluxLabs/mainflux/auth/api/http/quotas"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/go-zoo/bone"
 	"github.com/opentracing/opentracing-go"
@@ -20,6 +21,7 @@ func MakeHandler(svc auth.Service, tracer opentracing.Tracer, logger logger.Logg
 	mux := bone.New()
 	mux = orgs.MakeHandler(svc, mux, tracer, logger)
 	mux = keys.MakeHandler(svc, mux, tracer, logger)
+	mux = quotas.MakeHandler(svc, mux, tracer, logger)
 	mux.GetFunc("/health", mainflux.Health("auth"))
 	mux.Handle("/metrics", promhttp.Handler())
 	return mux
@@ -12,6 +12,8 @@ import (
 
 	"github.com/MainfluxLabs/mainflux/auth"
 	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
 )
 
 var _ auth.Service = (*loggingMiddleware)(nil)
@@ -301,6 +303,71 @@ func (lm *loggingMiddleware) AddPolicy(ctx context.Context, token, groupID, poli
 	return lm.svc.AddPolicy(ctx, token, groupID, policy)
 }
 
+func (lm *loggingMiddleware) CreateOrgRole(ctx context.Context, token, orgID string, role auth.OrgRole) (r auth.OrgRole, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_org_role for org %s and role %s took %s to complete", orgID, role.Name, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateOrgRole(ctx, token, orgID, role)
+}
+
+func (lm *loggingMiddleware) UpdateOrgRole(ctx context.Context, token, orgID string, role auth.OrgRole) (r auth.OrgRole, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_org_role for org %s and role %s took %s to complete", orgID, role.Name, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateOrgRole(ctx, token, orgID, role)
+}
+
+func (lm *loggingMiddleware) ViewOrgRole(ctx context.Context, token, orgID, name string) (r auth.OrgRole, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_org_role for org %s and role %s took %s to complete", orgID, name, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewOrgRole(ctx, token, orgID, name)
+}
+
+func (lm *loggingMiddleware) ListOrgRoles(ctx context.Context, token, orgID string, pm auth.PageMetadata) (rp auth.OrgRolesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_org_roles for org %s took %s to complete", orgID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListOrgRoles(ctx, token, orgID, pm)
+}
+
+func (lm *loggingMiddleware) RemoveOrgRole(ctx context.Context, token, orgID, name string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_org_role for org %s and role %s took %s to complete", orgID, name, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveOrgRole(ctx, token, orgID, name)
+}
+
 func (lm *loggingMiddleware) Backup(ctx context.Context, token string) (backup auth.Backup, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method backup for token %s took %s to complete", token, time.Since(begin))
@@ -327,6 +394,32 @@ func (lm *loggingMiddleware) Restore(ctx context.Context, token string, backup a
 	return lm.svc.Restore(ctx, token, backup)
 }
 
+func (lm *loggingMiddleware) ExportBackup(ctx context.Context, token string, f auth.BackupFilter) (_ backup.Stream, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method export_backup for org %s took %s to complete", f.OrgID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ExportBackup(ctx, token, f)
+}
+
+func (lm *loggingMiddleware) ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (_ backup.Summary, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method import_backup with strategy %s took %s to complete", strategy, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ImportBackup(ctx, token, dec, strategy)
+}
+
 func (lm *loggingMiddleware) AssignRole(ctx context.Context, id, role string) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method assign_role for id %s and role %s took %s to complete", id, role, time.Since(begin))
@@ -398,3 +491,80 @@ func (lm *loggingMiddleware) RemoveGroupPolicies(ctx context.Context, token, gro
 
 	return lm.svc.RemoveGroupPolicies(ctx, token, groupID, memberIDs...)
 }
+
+func (lm *loggingMiddleware) CreateTagPolicies(ctx context.Context, token, orgID, tag string, gps ...auth.GroupPolicyByID) (groupIDs []string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_tag_policies for token %s, org id %s and tag %s took %s to complete", token, orgID, tag, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateTagPolicies(ctx, token, orgID, tag, gps...)
+}
+
+func (lm *loggingMiddleware) UpdateQuota(ctx context.Context, token string, q auth.Quota) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_quota for %s %s took %s to complete", q.Subject, q.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateQuota(ctx, token, q)
+}
+
+func (lm *loggingMiddleware) RemoveQuota(ctx context.Context, token, subject, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_quota for %s %s took %s to complete", subject, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveQuota(ctx, token, subject, id)
+}
+
+func (lm *loggingMiddleware) ViewQuota(ctx context.Context, token, subject, id string) (_ auth.Quota, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_quota for %s %s took %s to complete", subject, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewQuota(ctx, token, subject, id)
+}
+
+func (lm *loggingMiddleware) RetrieveQuota(ctx context.Context, subject, id string) (_ auth.Quota, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method retrieve_quota for %s %s took %s to complete", subject, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+	}(time.Now())
+
+	return lm.svc.RetrieveQuota(ctx, subject, id)
+}
+
+func (lm *loggingMiddleware) ViewUsage(ctx context.Context, token, orgID string) (_ map[string]quota.Usage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_usage for org %s took %s to complete", orgID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewUsage(ctx, token, orgID)
+}
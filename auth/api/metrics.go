@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
 	"github.com/go-kit/kit/metrics"
 )
 
@@ -214,6 +216,51 @@ func (ms *metricsMiddleware) AddPolicy(ctx context.Context, token, groupID, poli
 	return ms.svc.AddPolicy(ctx, token, groupID, policy)
 }
 
+func (ms *metricsMiddleware) CreateOrgRole(ctx context.Context, token, orgID string, role auth.OrgRole) (auth.OrgRole, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_org_role").Add(1)
+		ms.latency.With("method", "create_org_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateOrgRole(ctx, token, orgID, role)
+}
+
+func (ms *metricsMiddleware) UpdateOrgRole(ctx context.Context, token, orgID string, role auth.OrgRole) (auth.OrgRole, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_org_role").Add(1)
+		ms.latency.With("method", "update_org_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateOrgRole(ctx, token, orgID, role)
+}
+
+func (ms *metricsMiddleware) ViewOrgRole(ctx context.Context, token, orgID, name string) (auth.OrgRole, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_org_role").Add(1)
+		ms.latency.With("method", "view_org_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewOrgRole(ctx, token, orgID, name)
+}
+
+func (ms *metricsMiddleware) ListOrgRoles(ctx context.Context, token, orgID string, pm auth.PageMetadata) (auth.OrgRolesPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_org_roles").Add(1)
+		ms.latency.With("method", "list_org_roles").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListOrgRoles(ctx, token, orgID, pm)
+}
+
+func (ms *metricsMiddleware) RemoveOrgRole(ctx context.Context, token, orgID, name string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_org_role").Add(1)
+		ms.latency.With("method", "remove_org_role").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveOrgRole(ctx, token, orgID, name)
+}
+
 func (ms *metricsMiddleware) Backup(ctx context.Context, token string) (auth.Backup, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "backup").Add(1)
@@ -232,6 +279,24 @@ func (ms *metricsMiddleware) Restore(ctx context.Context, token string, backup a
 	return ms.svc.Restore(ctx, token, backup)
 }
 
+func (ms *metricsMiddleware) ExportBackup(ctx context.Context, token string, f auth.BackupFilter) (backup.Stream, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "export_backup").Add(1)
+		ms.latency.With("method", "export_backup").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ExportBackup(ctx, token, f)
+}
+
+func (ms *metricsMiddleware) ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (backup.Summary, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "import_backup").Add(1)
+		ms.latency.With("method", "import_backup").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ImportBackup(ctx, token, dec, strategy)
+}
+
 func (ms *metricsMiddleware) AssignRole(ctx context.Context, id, role string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "assign_role").Add(1)
@@ -285,3 +350,57 @@ func (ms *metricsMiddleware) RemoveGroupPolicies(ctx context.Context, token, gro
 
 	return ms.svc.RemoveGroupPolicies(ctx, token, groupID, memberIDs...)
 }
+
+func (ms *metricsMiddleware) CreateTagPolicies(ctx context.Context, token, orgID, tag string, gps ...auth.GroupPolicyByID) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_tag_policies").Add(1)
+		ms.latency.With("method", "create_tag_policies").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateTagPolicies(ctx, token, orgID, tag, gps...)
+}
+
+func (ms *metricsMiddleware) UpdateQuota(ctx context.Context, token string, q auth.Quota) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_quota").Add(1)
+		ms.latency.With("method", "update_quota").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateQuota(ctx, token, q)
+}
+
+func (ms *metricsMiddleware) RemoveQuota(ctx context.Context, token, subject, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_quota").Add(1)
+		ms.latency.With("method", "remove_quota").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveQuota(ctx, token, subject, id)
+}
+
+func (ms *metricsMiddleware) ViewQuota(ctx context.Context, token, subject, id string) (auth.Quota, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_quota").Add(1)
+		ms.latency.With("method", "view_quota").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewQuota(ctx, token, subject, id)
+}
+
+func (ms *metricsMiddleware) RetrieveQuota(ctx context.Context, subject, id string) (auth.Quota, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_quota").Add(1)
+		ms.latency.With("method", "retrieve_quota").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrieveQuota(ctx, subject, id)
+}
+
+func (ms *metricsMiddleware) ViewUsage(ctx context.Context, token, orgID string) (map[string]quota.Usage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_usage").Add(1)
+		ms.latency.With("method", "view_usage").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewUsage(ctx, token, orgID)
+}
@@ -0,0 +1,591 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// BackupService is the name of the service written to the backup header.
+const BackupService = "auth"
+
+const (
+	orgRecord         = "org"
+	orgRoleRecord     = "org_role"
+	orgMemberRecord   = "org_member"
+	orgGroupRecord    = "org_group"
+	groupPolicyRecord = "group_policy"
+)
+
+// BackupFilter limits the entities included in a backup.
+type BackupFilter struct {
+	// OrgID limits the backup to the org, its roles, members, groups and
+	// the policies of its groups. Empty OrgID backs up all entities.
+	OrgID string
+	// Since limits the backup to the entities created or updated after it.
+	// Group policies carry no timestamps, so the policies of the backed up
+	// groups are always included. Zero Since backs up all entities.
+	Since time.Time
+}
+
+type backupOrg struct {
+	ID          string                 `json:"id"`
+	OwnerID     string                 `json:"owner_id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+type backupOrgRole struct {
+	OrgID       string    `json:"org_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type backupOrgMember struct {
+	MemberID  string    `json:"member_id"`
+	OrgID     string    `json:"org_id"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type backupOrgGroup struct {
+	GroupID   string    `json:"group_id"`
+	OrgID     string    `json:"org_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type backupGroupPolicy struct {
+	GroupID  string `json:"group_id"`
+	MemberID string `json:"member_id"`
+	Policy   string `json:"policy"`
+}
+
+func (svc service) ExportBackup(ctx context.Context, token string, f BackupFilter) (backup.Stream, error) {
+	if err := svc.authorizeBackup(ctx, token, f.OrgID); err != nil {
+		return nil, err
+	}
+
+	bk, err := svc.collectBackup(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	header := backup.Header{Service: BackupService}
+	if f.OrgID != "" {
+		header.Scope = &backup.Scope{Type: backup.ScopeOrg, ID: f.OrgID}
+	}
+	if !f.Since.IsZero() {
+		since := f.Since
+		header.Since = &since
+	}
+
+	return func(enc *backup.Encoder) error {
+		if err := enc.WriteHeader(header); err != nil {
+			return err
+		}
+
+		for _, o := range bk.Orgs {
+			if err := enc.Encode(orgRecord, backupOrg{
+				ID:          o.ID,
+				OwnerID:     o.OwnerID,
+				Name:        o.Name,
+				Description: o.Description,
+				Metadata:    o.Metadata,
+				CreatedAt:   o.CreatedAt,
+				UpdatedAt:   o.UpdatedAt,
+			}); err != nil {
+				return err
+			}
+		}
+		for _, r := range bk.OrgRoles {
+			if err := enc.Encode(orgRoleRecord, backupOrgRole(r)); err != nil {
+				return err
+			}
+		}
+		for _, m := range bk.OrgMembers {
+			if err := enc.Encode(orgMemberRecord, backupOrgMember{
+				MemberID:  m.MemberID,
+				OrgID:     m.OrgID,
+				Role:      m.Role,
+				CreatedAt: m.CreatedAt,
+				UpdatedAt: m.UpdatedAt,
+			}); err != nil {
+				return err
+			}
+		}
+		for _, g := range bk.OrgGroups {
+			if err := enc.Encode(orgGroupRecord, backupOrgGroup(g)); err != nil {
+				return err
+			}
+		}
+		for _, gp := range bk.GroupPolicies {
+			if err := enc.Encode(groupPolicyRecord, backupGroupPolicy{
+				GroupID:  gp.GroupID,
+				MemberID: gp.MemberID,
+				Policy:   gp.Policy,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+func (svc service) ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (backup.Summary, error) {
+	if err := backup.ValidateStrategy(strategy); err != nil {
+		return backup.Summary{}, err
+	}
+
+	header, err := dec.Header()
+	if err != nil {
+		return backup.Summary{}, err
+	}
+	if header.Service != BackupService {
+		return backup.Summary{}, errors.Wrap(backup.ErrMalformedRecord, errors.New(header.Service))
+	}
+
+	var orgID string
+	if header.Scope != nil {
+		if header.Scope.Type != backup.ScopeOrg {
+			return backup.Summary{}, errors.Wrap(backup.ErrMalformedRecord, errors.New(header.Scope.Type))
+		}
+		orgID = header.Scope.ID
+	}
+
+	if err := svc.authorizeBackup(ctx, token, orgID); err != nil {
+		return backup.Summary{}, err
+	}
+
+	im := importer{svc: svc, token: token, strategy: strategy, orgID: orgID, groups: map[string]bool{}}
+	if orgID != "" {
+		user, err := svc.Identify(ctx, token)
+		if err != nil {
+			return backup.Summary{}, err
+		}
+		im.userID = user.ID
+	}
+
+	for {
+		rec, err := dec.Next()
+		if err == io.EOF {
+			return im.summary, nil
+		}
+		if err != nil {
+			return im.summary, err
+		}
+
+		if err := im.restore(ctx, rec); err != nil {
+			return im.summary, err
+		}
+	}
+}
+
+// authorizeBackup checks whether the user can back up and restore the org
+// identified by orgID or, if orgID is empty, all entities.
+func (svc service) authorizeBackup(ctx context.Context, token, orgID string) error {
+	if orgID == "" {
+		return svc.isAdmin(ctx, token)
+	}
+
+	return svc.orgRolesAuth(ctx, token, orgID, OwnerRole)
+}
+
+func (svc service) collectBackup(ctx context.Context, f BackupFilter) (Backup, error) {
+	bk, err := svc.retrieveAll(ctx)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	after := func(t time.Time) bool {
+		return f.Since.IsZero() || !t.Before(f.Since)
+	}
+	inOrg := func(id string) bool {
+		return f.OrgID == "" || id == f.OrgID
+	}
+
+	var res Backup
+	for _, o := range bk.Orgs {
+		if inOrg(o.ID) && after(o.UpdatedAt) {
+			res.Orgs = append(res.Orgs, o)
+		}
+	}
+	for _, r := range bk.OrgRoles {
+		if inOrg(r.OrgID) && after(r.UpdatedAt) {
+			res.OrgRoles = append(res.OrgRoles, r)
+		}
+	}
+	for _, m := range bk.OrgMembers {
+		if inOrg(m.OrgID) && after(m.UpdatedAt) {
+			res.OrgMembers = append(res.OrgMembers, m)
+		}
+	}
+
+	groups := map[string]bool{}
+	for _, g := range bk.OrgGroups {
+		if !inOrg(g.OrgID) {
+			continue
+		}
+		groups[g.GroupID] = true
+		if after(g.UpdatedAt) {
+			res.OrgGroups = append(res.OrgGroups, g)
+		}
+	}
+	for _, gp := range bk.GroupPolicies {
+		if groups[gp.GroupID] || f.OrgID == "" {
+			res.GroupPolicies = append(res.GroupPolicies, gp)
+		}
+	}
+
+	return res, nil
+}
+
+func (svc service) retrieveAll(ctx context.Context) (Backup, error) {
+	orgs, err := svc.orgs.RetrieveAll(ctx)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	mrs, err := svc.orgs.RetrieveAllOrgMembers(ctx)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	ogs, err := svc.orgs.RetrieveAllOrgGroups(ctx)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	gps, err := svc.policies.RetrieveAllGroupPolicies(ctx)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	ors, err := svc.orgRoles.RetrieveAll(ctx)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	return Backup{
+		Orgs:          orgs,
+		OrgRoles:      ors,
+		OrgMembers:    mrs,
+		OrgGroups:     ogs,
+		GroupPolicies: gps,
+	}, nil
+}
+
+// importer restores backup records one by one, resolving conflicts with
+// existing entities according to the chosen strategy.
+type importer struct {
+	svc      service
+	token    string
+	strategy string
+	// orgID limits the records to a single org. Empty orgID means that the
+	// import isn't scoped.
+	orgID string
+	// userID is the ID of the user performing the scoped import.
+	userID string
+	// groups contains IDs of the groups assigned to the org by the import.
+	groups  map[string]bool
+	summary backup.Summary
+}
+
+func (im *importer) restore(ctx context.Context, rec backup.Record) error {
+	switch rec.Type {
+	case orgRecord:
+		var o backupOrg
+		if err := rec.Decode(&o); err != nil {
+			return err
+		}
+		return im.restoreOrg(ctx, Org{
+			ID:          o.ID,
+			OwnerID:     o.OwnerID,
+			Name:        o.Name,
+			Description: o.Description,
+			Metadata:    o.Metadata,
+			CreatedAt:   o.CreatedAt,
+			UpdatedAt:   o.UpdatedAt,
+		})
+	case orgRoleRecord:
+		var r backupOrgRole
+		if err := rec.Decode(&r); err != nil {
+			return err
+		}
+		return im.restoreOrgRole(ctx, OrgRole(r))
+	case orgMemberRecord:
+		var m backupOrgMember
+		if err := rec.Decode(&m); err != nil {
+			return err
+		}
+		return im.restoreOrgMember(ctx, OrgMember{
+			MemberID:  m.MemberID,
+			OrgID:     m.OrgID,
+			Role:      m.Role,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+		})
+	case orgGroupRecord:
+		var g backupOrgGroup
+		if err := rec.Decode(&g); err != nil {
+			return err
+		}
+		return im.restoreOrgGroup(ctx, OrgGroup(g))
+	case groupPolicyRecord:
+		var gp backupGroupPolicy
+		if err := rec.Decode(&gp); err != nil {
+			return err
+		}
+		return im.restoreGroupPolicy(ctx, GroupPolicy{GroupID: gp.GroupID, MemberID: gp.MemberID, Policy: gp.Policy})
+	default:
+		return errors.Wrap(backup.ErrMalformedRecord, errors.New(rec.Type))
+	}
+}
+
+// resolve reports whether an existing entity has to be overwritten. It
+// returns an error if the import has to be aborted.
+func (im *importer) resolve() (bool, error) {
+	switch im.strategy {
+	case backup.StrategySkip:
+		im.summary.Skipped++
+		return false, nil
+	case backup.StrategyOverwrite:
+		im.summary.Updated++
+		return true, nil
+	default:
+		return false, errors.ErrConflict
+	}
+}
+
+func (im *importer) inScope(orgID string) error {
+	if im.orgID != "" && orgID != im.orgID {
+		return errors.ErrAuthorization
+	}
+
+	return nil
+}
+
+func (im *importer) restoreOrg(ctx context.Context, o Org) error {
+	if err := im.inScope(o.ID); err != nil {
+		return err
+	}
+
+	_, err := im.svc.orgs.RetrieveByID(ctx, o.ID)
+	switch {
+	case err == nil:
+		overwrite, err := im.resolve()
+		if err != nil || !overwrite {
+			return err
+		}
+		return im.svc.orgs.Update(ctx, o)
+	case errors.Contains(err, errors.ErrNotFound):
+		if err := im.svc.orgs.Save(ctx, o); err != nil {
+			return err
+		}
+		im.summary.Created++
+		return nil
+	default:
+		return err
+	}
+}
+
+func (im *importer) restoreOrgRole(ctx context.Context, r OrgRole) error {
+	if err := im.inScope(r.OrgID); err != nil {
+		return err
+	}
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	if im.orgID != "" {
+		if err := im.svc.canGrant(ctx, im.token, r.OrgID, r.Name, r.Permissions); err != nil {
+			return err
+		}
+	}
+
+	_, err := im.svc.orgRoles.Retrieve(ctx, r.OrgID, r.Name)
+	switch {
+	case err == nil:
+		overwrite, err := im.resolve()
+		if err != nil || !overwrite {
+			return err
+		}
+		return im.svc.orgRoles.Update(ctx, r)
+	case errors.Contains(err, errors.ErrNotFound):
+		if err := im.svc.orgRoles.Save(ctx, r); err != nil {
+			return err
+		}
+		im.summary.Created++
+		return nil
+	default:
+		return err
+	}
+}
+
+func (im *importer) restoreOrgMember(ctx context.Context, m OrgMember) error {
+	if err := im.inScope(m.OrgID); err != nil {
+		return err
+	}
+	if err := im.canRestoreMember(ctx, m); err != nil {
+		return err
+	}
+
+	_, err := im.svc.orgs.RetrieveRole(ctx, m.MemberID, m.OrgID)
+	switch {
+	case err == nil:
+		overwrite, err := im.resolve()
+		if err != nil || !overwrite {
+			return err
+		}
+		return im.svc.orgs.UpdateMembers(ctx, m)
+	case errors.Contains(err, errors.ErrNotFound):
+		if err := im.svc.orgs.AssignMembers(ctx, m); err != nil {
+			return err
+		}
+		im.summary.Created++
+		return nil
+	default:
+		return err
+	}
+}
+
+func (im *importer) restoreOrgGroup(ctx context.Context, g OrgGroup) error {
+	if err := im.inScope(g.OrgID); err != nil {
+		return err
+	}
+
+	current, err := im.svc.orgs.RetrieveByGroupID(ctx, g.GroupID)
+	if err != nil && !errors.Contains(err, errors.ErrNotFound) {
+		return err
+	}
+
+	switch {
+	case current.ID == "":
+		if err := im.canAssignGroup(ctx, g.GroupID); err != nil {
+			return err
+		}
+		if err := im.svc.orgs.AssignGroups(ctx, g); err != nil {
+			return err
+		}
+		im.summary.Created++
+	case im.orgID != "" && current.ID != im.orgID:
+		// A scoped import can't move groups out of other orgs.
+		return errors.ErrAuthorization
+	default:
+		overwrite, err := im.resolve()
+		if err != nil || !overwrite {
+			return err
+		}
+		if current.ID != g.OrgID {
+			if err := im.svc.orgs.UnassignGroups(ctx, current.ID, g.GroupID); err != nil {
+				return err
+			}
+			if err := im.svc.orgs.AssignGroups(ctx, g); err != nil {
+				return err
+			}
+		}
+	}
+
+	im.groups[g.GroupID] = true
+	return nil
+}
+
+// canRestoreMember checks that a scoped import grants the member a role the
+// same way as the org members API does. The org owner keeps the owner role.
+func (im *importer) canRestoreMember(ctx context.Context, m OrgMember) error {
+	if im.orgID == "" {
+		return nil
+	}
+
+	org, err := im.svc.orgs.RetrieveByID(ctx, m.OrgID)
+	if err != nil {
+		return err
+	}
+	if m.MemberID == org.OwnerID {
+		if m.Role != OwnerRole {
+			return errors.ErrAuthorization
+		}
+		return nil
+	}
+
+	return im.svc.canGrantRoles(ctx, im.token, m.OrgID, m)
+}
+
+// canAssignGroup checks that a scoped import assigns to the org only the
+// groups owned by the importing user or by the org members.
+func (im *importer) canAssignGroup(ctx context.Context, groupID string) error {
+	if im.orgID == "" {
+		return nil
+	}
+
+	res, err := im.svc.things.GetGroupsByIDs(ctx, &mainflux.GroupsReq{Ids: []string{groupID}})
+	if err != nil {
+		return err
+	}
+
+	for _, g := range res.GetGroups() {
+		if g.GetOwnerID() == im.userID {
+			return nil
+		}
+
+		_, err := im.svc.orgs.RetrieveRole(ctx, g.GetOwnerID(), im.orgID)
+		switch {
+		case err == nil:
+			return nil
+		case errors.Contains(err, errors.ErrNotFound):
+			return errors.ErrAuthorization
+		default:
+			return err
+		}
+	}
+
+	return errors.ErrNotFound
+}
+
+func (im *importer) restoreGroupPolicy(ctx context.Context, gp GroupPolicy) error {
+	if im.orgID != "" && !im.groups[gp.GroupID] {
+		org, err := im.svc.orgs.RetrieveByGroupID(ctx, gp.GroupID)
+		if err != nil && !errors.Contains(err, errors.ErrNotFound) {
+			return err
+		}
+		if err := im.inScope(org.ID); err != nil {
+			return err
+		}
+		im.groups[gp.GroupID] = true
+	}
+
+	gpByID := GroupPolicyByID{MemberID: gp.MemberID, Policy: gp.Policy}
+
+	policy, err := im.svc.policies.RetrieveGroupPolicy(ctx, gp)
+	if err != nil && !errors.Contains(err, errors.ErrNotFound) {
+		return err
+	}
+	if policy == "" {
+		if err := im.svc.policies.SaveGroupPolicies(ctx, gp.GroupID, gpByID); err != nil {
+			return err
+		}
+		im.summary.Created++
+		return nil
+	}
+
+	overwrite, err := im.resolve()
+	if err != nil || !overwrite {
+		return err
+	}
+
+	return im.svc.policies.UpdateGroupPolicies(ctx, gp.GroupID, gpByID)
+}
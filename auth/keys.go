@@ -6,7 +6,11 @@ package auth
 import (
 	"context"
 	"errors"
+	"net"
+	"strings"
 	"time"
+
+	"github.com/MainfluxLabs/mainflux"
 )
 
 var (
@@ -19,6 +23,17 @@ var (
 	// ErrAPIKeyExpired indicates that the Key is expired
 	// and that the key type is API key.
 	ErrAPIKeyExpired = errors.New("use of expired API key")
+
+	// ErrInvalidScope indicates that the Key carries an unknown scope.
+	ErrInvalidScope = errors.New("invalid key scope")
+
+	// ErrInvalidIPAddress indicates that the Key allow-list contains
+	// a malformed IP address or network.
+	ErrInvalidIPAddress = errors.New("invalid IP address")
+
+	// ErrIPNotAllowed indicates that the Key is used from an IP address
+	// that is not present in its allow-list.
+	ErrIPNotAllowed = errors.New("key use from disallowed IP address")
 )
 
 const (
@@ -30,20 +45,59 @@ const (
 	APIKey
 )
 
+// Scopes limit what an API key is allowed to do on behalf of its issuer.
+// A write scope implies the read scope of the same entity.
+const (
+	ThingsReadScope    = "things:read"
+	ThingsWriteScope   = "things:write"
+	ChannelsReadScope  = "channels:read"
+	ChannelsWriteScope = "channels:write"
+	GroupsReadScope    = "groups:read"
+	GroupsWriteScope   = "groups:write"
+	MessagesReadScope  = "messages:read"
+	MessagesWriteScope = "messages:write"
+	OrgsReadScope      = "orgs:read"
+	OrgsWriteScope     = "orgs:write"
+)
+
+var scopes = map[string]bool{
+	ThingsReadScope:    true,
+	ThingsWriteScope:   true,
+	ChannelsReadScope:  true,
+	ChannelsWriteScope: true,
+	GroupsReadScope:    true,
+	GroupsWriteScope:   true,
+	MessagesReadScope:  true,
+	MessagesWriteScope: true,
+	OrgsReadScope:      true,
+	OrgsWriteScope:     true,
+}
+
 // Key represents API key.
 type Key struct {
-	ID        string
-	Type      uint32
-	IssuerID  string
-	Subject   string
-	IssuedAt  time.Time
-	ExpiresAt time.Time
+	ID         string
+	Type       uint32
+	IssuerID   string
+	Subject    string
+	IssuedAt   time.Time
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	Scopes     []string
+	OrgIDs     []string
+	GroupIDs   []string
+	ChannelIDs []string
+	AllowedIPs []string
 }
 
-// Identity contains ID and Email.
+// Identity contains ID and Email. Identities obtained using a scoped
+// API key also carry the scopes and resource restrictions of that key.
 type Identity struct {
-	ID    string
-	Email string
+	ID         string
+	Email      string
+	Scopes     []string
+	OrgIDs     []string
+	GroupIDs   []string
+	ChannelIDs []string
 }
 
 // Expired verifies if the key is expired.
@@ -54,6 +108,129 @@ func (k Key) Expired() bool {
 	return k.ExpiresAt.UTC().Before(time.Now().UTC())
 }
 
+// Validate verifies that the key scopes and IP allow-list are well formed.
+func (k Key) Validate() error {
+	for _, s := range k.Scopes {
+		if !scopes[s] {
+			return ErrInvalidScope
+		}
+	}
+
+	for _, ip := range k.AllowedIPs {
+		if net.ParseIP(ip) != nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(ip); err != nil {
+			return ErrInvalidIPAddress
+		}
+	}
+
+	return nil
+}
+
+// AllowsIP checks whether the key can be used from the provided IP address.
+// Keys without an allow-list can be used from any address, while keys
+// with an allow-list can not be used when the origin is unknown.
+func (k Key) AllowsIP(ip string) bool {
+	if len(k.AllowedIPs) == 0 {
+		return true
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+
+	for _, allowed := range k.AllowedIPs {
+		if a := net.ParseIP(allowed); a != nil {
+			if a.Equal(addr) {
+				return true
+			}
+			continue
+		}
+		if _, cidr, err := net.ParseCIDR(allowed); err == nil && cidr.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Scoped returns true if the identity is restricted by an API key.
+func (id Identity) Scoped() bool {
+	return len(id.Scopes) > 0 || len(id.OrgIDs) > 0 || len(id.GroupIDs) > 0 || len(id.ChannelIDs) > 0
+}
+
+// IsScoped returns true if the identity obtained from the auth service is
+// restricted by an API key. Services that don't define scopes for their
+// entities can't enforce the restrictions and reject such identities.
+func IsScoped(id *mainflux.UserIdentity) bool {
+	return len(id.GetScopes()) > 0 || len(id.GetOrgIDs()) > 0 || len(id.GetGroupIDs()) > 0 || len(id.GetChannelIDs()) > 0
+}
+
+// HasScope checks whether the provided list of scopes grants the scope.
+// An empty list of scopes grants full authority of the key issuer.
+func HasScope(granted []string, scope string) bool {
+	if len(granted) == 0 {
+		return true
+	}
+
+	write := scope
+	if strings.HasSuffix(scope, ":read") {
+		write = strings.TrimSuffix(scope, ":read") + ":write"
+	}
+
+	for _, g := range granted {
+		if g == scope || g == write {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasResource checks whether the provided list of resource IDs contains
+// the resource. An empty list does not restrict access to any resource.
+func HasResource(ids []string, id string) bool {
+	if len(ids) == 0 {
+		return true
+	}
+
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasAnyResource reports whether any of the given resource IDs is in
+// the list of allowed resource IDs. An empty list allows all resources.
+func hasAnyResource(ids []string, resIDs []string) bool {
+	for _, id := range resIDs {
+		if HasResource(ids, id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type clientIPKey struct{}
+
+// WithClientIP returns a copy of the context carrying the IP address of
+// the client that issued the request.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP returns the client IP address carried by the context.
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
 // KeyRepository specifies Key persistence API.
 type KeyRepository interface {
 	// Save persists the Key. A non-nil error is returned to indicate
@@ -63,6 +240,13 @@ type KeyRepository interface {
 	// Retrieve retrieves Key by its unique identifier.
 	Retrieve(context.Context, string, string) (Key, error)
 
+	// UpdateLastUsed updates the time the Key was last used at.
+	UpdateLastUsed(context.Context, string, string, time.Time) error
+
 	// Remove removes Key with provided ID.
 	Remove(context.Context, string, string) error
+
+	// RetrieveAPIKeysCount returns the number of unexpired API keys issued
+	// by the user.
+	RetrieveAPIKeysCount(context.Context, string) (uint64, error)
 }
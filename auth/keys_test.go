@@ -58,3 +58,132 @@ func TestExpired(t *testing.T) {
 		assert.Equal(t, tc.expired, res, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.expired, res))
 	}
 }
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		key  auth.Key
+		err  error
+	}{
+		{
+			desc: "validate key without restrictions",
+			key:  auth.Key{Type: auth.APIKey},
+			err:  nil,
+		},
+		{
+			desc: "validate key with valid scopes and allowed IPs",
+			key: auth.Key{
+				Type:       auth.APIKey,
+				Scopes:     []string{auth.ThingsReadScope, auth.MessagesWriteScope},
+				AllowedIPs: []string{"10.0.0.1", "192.168.0.0/24"},
+			},
+			err: nil,
+		},
+		{
+			desc: "validate key with invalid scope",
+			key:  auth.Key{Type: auth.APIKey, Scopes: []string{"things:delete"}},
+			err:  auth.ErrInvalidScope,
+		},
+		{
+			desc: "validate key with invalid IP address",
+			key:  auth.Key{Type: auth.APIKey, AllowedIPs: []string{"10.0.0.256"}},
+			err:  auth.ErrInvalidIPAddress,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.key.Validate()
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAllowsIP(t *testing.T) {
+	key := auth.Key{AllowedIPs: []string{"10.0.0.1", "192.168.0.0/24"}}
+	cases := []struct {
+		desc    string
+		key     auth.Key
+		ip      string
+		allowed bool
+	}{
+		{
+			desc:    "key without allow-list",
+			key:     auth.Key{},
+			ip:      "172.16.0.1",
+			allowed: true,
+		},
+		{
+			desc:    "IP address present in allow-list",
+			key:     key,
+			ip:      "10.0.0.1",
+			allowed: true,
+		},
+		{
+			desc:    "IP address within allowed network",
+			key:     key,
+			ip:      "192.168.0.15",
+			allowed: true,
+		},
+		{
+			desc:    "IP address outside of allow-list",
+			key:     key,
+			ip:      "172.16.0.1",
+			allowed: false,
+		},
+		{
+			desc:    "unknown IP address",
+			key:     key,
+			ip:      "",
+			allowed: false,
+		},
+	}
+
+	for _, tc := range cases {
+		res := tc.key.AllowsIP(tc.ip)
+		assert.Equal(t, tc.allowed, res, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.allowed, res))
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	cases := []struct {
+		desc    string
+		granted []string
+		scope   string
+		res     bool
+	}{
+		{
+			desc:    "unrestricted scopes",
+			granted: nil,
+			scope:   auth.ThingsWriteScope,
+			res:     true,
+		},
+		{
+			desc:    "granted scope",
+			granted: []string{auth.ThingsReadScope},
+			scope:   auth.ThingsReadScope,
+			res:     true,
+		},
+		{
+			desc:    "read scope implied by write scope",
+			granted: []string{auth.ChannelsWriteScope},
+			scope:   auth.ChannelsReadScope,
+			res:     true,
+		},
+		{
+			desc:    "write scope not implied by read scope",
+			granted: []string{auth.ChannelsReadScope},
+			scope:   auth.ChannelsWriteScope,
+			res:     false,
+		},
+		{
+			desc:    "scope of another entity",
+			granted: []string{auth.ThingsWriteScope},
+			scope:   auth.MessagesReadScope,
+			res:     false,
+		},
+	}
+
+	for _, tc := range cases {
+		res := auth.HasScope(tc.granted, tc.scope)
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.res, res))
+	}
+}
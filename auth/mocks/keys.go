@@ -6,6 +6,7 @@ package mocks
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -46,6 +47,21 @@ func (krm *keyRepositoryMock) Retrieve(ctx context.Context, issuerID, id string)
 
 	return auth.Key{}, errors.ErrNotFound
 }
+
+func (krm *keyRepositoryMock) UpdateLastUsed(ctx context.Context, issuerID, id string, usedAt time.Time) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	key, ok := krm.keys[id]
+	if !ok || key.IssuerID != issuerID {
+		return errors.ErrNotFound
+	}
+
+	key.LastUsedAt = usedAt
+	krm.keys[id] = key
+	return nil
+}
+
 func (krm *keyRepositoryMock) Remove(ctx context.Context, issuerID, id string) error {
 	krm.mu.Lock()
 	defer krm.mu.Unlock()
@@ -54,3 +70,17 @@ func (krm *keyRepositoryMock) Remove(ctx context.Context, issuerID, id string) e
 	}
 	return nil
 }
+
+func (krm *keyRepositoryMock) RetrieveAPIKeysCount(ctx context.Context, issuerID string) (uint64, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	var count uint64
+	for _, key := range krm.keys {
+		if key.IssuerID == issuerID && key.Type == auth.APIKey && !key.Expired() {
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ auth.OrgRolesRepository = (*orgRolesRepositoryMock)(nil)
+
+type orgRolesRepositoryMock struct {
+	mu    sync.Mutex
+	roles map[string]map[string]auth.OrgRole
+}
+
+// NewOrgRolesRepository returns mock of org roles repository.
+func NewOrgRolesRepository() auth.OrgRolesRepository {
+	return &orgRolesRepositoryMock{
+		roles: make(map[string]map[string]auth.OrgRole),
+	}
+}
+
+func (rrm *orgRolesRepositoryMock) Save(ctx context.Context, roles ...auth.OrgRole) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	for _, r := range roles {
+		if _, ok := rrm.roles[r.OrgID][r.Name]; ok {
+			return errors.ErrConflict
+		}
+	}
+
+	for _, r := range roles {
+		if _, ok := rrm.roles[r.OrgID]; !ok {
+			rrm.roles[r.OrgID] = make(map[string]auth.OrgRole)
+		}
+		rrm.roles[r.OrgID][r.Name] = r
+	}
+
+	return nil
+}
+
+func (rrm *orgRolesRepositoryMock) Update(ctx context.Context, role auth.OrgRole) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	r, ok := rrm.roles[role.OrgID][role.Name]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	r.Description = role.Description
+	r.Permissions = role.Permissions
+	r.UpdatedAt = role.UpdatedAt
+	rrm.roles[role.OrgID][role.Name] = r
+
+	return nil
+}
+
+func (rrm *orgRolesRepositoryMock) Retrieve(ctx context.Context, orgID, name string) (auth.OrgRole, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	r, ok := rrm.roles[orgID][name]
+	if !ok {
+		return auth.OrgRole{}, errors.ErrNotFound
+	}
+
+	return r, nil
+}
+
+func (rrm *orgRolesRepositoryMock) RetrieveByOrg(ctx context.Context, orgID string, pm auth.PageMetadata) (auth.OrgRolesPage, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	var roles []auth.OrgRole
+	for _, r := range rrm.roles[orgID] {
+		roles = append(roles, r)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+
+	total := uint64(len(roles))
+	if pm.Limit > 0 {
+		start := pm.Offset
+		if start > total {
+			start = total
+		}
+		end := start + pm.Limit
+		if end > total {
+			end = total
+		}
+		roles = roles[start:end]
+	}
+
+	return auth.OrgRolesPage{
+		OrgRoles: roles,
+		PageMetadata: auth.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
+func (rrm *orgRolesRepositoryMock) RetrieveAll(ctx context.Context) ([]auth.OrgRole, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	var roles []auth.OrgRole
+	for _, rs := range rrm.roles {
+		for _, r := range rs {
+			roles = append(roles, r)
+		}
+	}
+
+	return roles, nil
+}
+
+func (rrm *orgRolesRepositoryMock) Remove(ctx context.Context, orgID, name string) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if _, ok := rrm.roles[orgID][name]; !ok {
+		return errors.ErrNotFound
+	}
+	delete(rrm.roles[orgID], name)
+
+	return nil
+}
@@ -144,8 +144,11 @@ func (orm *orgRepositoryMock) AssignMembers(ctx context.Context, oms ...auth.Org
 			return errors.ErrNotFound
 		}
 		orm.orgMembers[om.MemberID] = auth.OrgMember{
-			MemberID: om.MemberID,
-			Role:     om.Role,
+			MemberID:  om.MemberID,
+			OrgID:     om.OrgID,
+			Role:      om.Role,
+			CreatedAt: om.CreatedAt,
+			UpdatedAt: om.UpdatedAt,
 		}
 	}
 
@@ -232,7 +235,10 @@ func (orm *orgRepositoryMock) AssignGroups(ctx context.Context, ogs ...auth.OrgG
 			return errors.ErrNotFound
 		}
 		orm.orgGroups[gr.GroupID] = auth.OrgGroup{
-			GroupID: gr.GroupID,
+			GroupID:   gr.GroupID,
+			OrgID:     gr.OrgID,
+			CreatedAt: gr.CreatedAt,
+			UpdatedAt: gr.UpdatedAt,
 		}
 	}
 
@@ -260,13 +266,14 @@ func (orm *orgRepositoryMock) RetrieveGroups(ctx context.Context, orgID string,
 	i := uint64(0)
 	ogs := []auth.OrgGroup{}
 	for _, og := range orm.orgGroups {
-		if i >= pm.Offset && i < pm.Offset+pm.Limit {
-			if _, ok := orm.orgs[orgID]; ok {
-				ogs = append(ogs, auth.OrgGroup{
-					OrgID:   orgID,
-					GroupID: og.GroupID,
-				})
-			}
+		if og.OrgID != orgID {
+			continue
+		}
+		if i >= pm.Offset && (pm.Limit == 0 || i < pm.Offset+pm.Limit) {
+			ogs = append(ogs, auth.OrgGroup{
+				OrgID:   orgID,
+				GroupID: og.GroupID,
+			})
 		}
 		i++
 	}
@@ -274,7 +281,7 @@ func (orm *orgRepositoryMock) RetrieveGroups(ctx context.Context, orgID string,
 	return auth.OrgGroupsPage{
 		OrgGroups: ogs,
 		PageMetadata: auth.PageMetadata{
-			Total:  uint64(len(orm.orgGroups)),
+			Total:  i,
 			Offset: pm.Offset,
 			Limit:  pm.Limit,
 		},
@@ -290,7 +297,7 @@ func (orm *orgRepositoryMock) RetrieveByGroupID(ctx context.Context, groupID str
 		return auth.Org{}, errors.ErrNotFound
 	}
 
-	return orm.orgs[org.GroupID], nil
+	return orm.orgs[org.OrgID], nil
 }
 
 func (orm *orgRepositoryMock) RetrieveAll(ctx context.Context) ([]auth.Org, error) {
@@ -341,8 +348,11 @@ func (orm *orgRepositoryMock) RetrieveAllOrgMembers(ctx context.Context) ([]auth
 	for _, org := range orm.orgs {
 		for _, member := range orm.orgMembers {
 			mrs = append(mrs, auth.OrgMember{
-				OrgID:    org.ID,
-				MemberID: member.MemberID,
+				OrgID:     org.ID,
+				MemberID:  member.MemberID,
+				Role:      member.Role,
+				CreatedAt: member.CreatedAt,
+				UpdatedAt: member.UpdatedAt,
 			})
 		}
 	}
@@ -358,8 +368,10 @@ func (orm *orgRepositoryMock) RetrieveAllOrgGroups(ctx context.Context) ([]auth.
 	for _, org := range orm.orgs {
 		for _, group := range orm.orgGroups {
 			ogs = append(ogs, auth.OrgGroup{
-				OrgID:   org.ID,
-				GroupID: group.GroupID,
+				OrgID:     org.ID,
+				GroupID:   group.GroupID,
+				CreatedAt: group.CreatedAt,
+				UpdatedAt: group.UpdatedAt,
 			})
 		}
 	}
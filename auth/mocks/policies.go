@@ -5,13 +5,15 @@ import (
 	"sync"
 
 	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 )
 
 var _ auth.PoliciesRepository = (*policiesRepositoryMock)(nil)
 
 type policiesRepositoryMock struct {
-	mu                sync.Mutex
-	groupPolicies     map[string]auth.GroupPolicy
+	mu            sync.Mutex
+	groupPolicies map[string]auth.GroupPolicy
+	// Map of group policies, where the key is a group ID concatenated with a member ID.
 	groupPoliciesByID map[string]auth.GroupPolicyByID
 }
 
@@ -29,10 +31,11 @@ func (mrm *policiesRepositoryMock) SaveGroupPolicies(ctx context.Context, groupI
 
 	for _, gp := range gps {
 		mrm.groupPolicies[groupID] = auth.GroupPolicy{
+			GroupID:  groupID,
 			MemberID: gp.MemberID,
 			Policy:   gp.Policy,
 		}
-		mrm.groupPoliciesByID[gp.MemberID] = gp
+		mrm.groupPoliciesByID[groupID+gp.MemberID] = gp
 	}
 
 	return nil
@@ -42,7 +45,7 @@ func (mrm *policiesRepositoryMock) RetrieveGroupPolicy(ctx context.Context, gp a
 	mrm.mu.Lock()
 	defer mrm.mu.Unlock()
 
-	return mrm.groupPoliciesByID[gp.MemberID].Policy, nil
+	return mrm.groupPoliciesByID[gp.GroupID+gp.MemberID].Policy, nil
 }
 
 func (mrm *policiesRepositoryMock) RetrieveGroupPolicies(ctx context.Context, groupID string, pm auth.PageMetadata) (auth.GroupPoliciesPage, error) {
@@ -60,8 +63,19 @@ func (mrm *policiesRepositoryMock) RetrieveAllGroupPolicies(ctx context.Context)
 
 	return gps, nil
 }
+
 func (mrm *policiesRepositoryMock) UpdateGroupPolicies(ctx context.Context, groupID string, gps ...auth.GroupPolicyByID) error {
-	panic("not implemented")
+	mrm.mu.Lock()
+	defer mrm.mu.Unlock()
+
+	for _, gp := range gps {
+		if _, ok := mrm.groupPoliciesByID[groupID+gp.MemberID]; !ok {
+			return errors.ErrNotFound
+		}
+		mrm.groupPoliciesByID[groupID+gp.MemberID] = gp
+	}
+
+	return nil
 }
 
 func (mrm *policiesRepositoryMock) RemoveGroupPolicies(ctx context.Context, groupID string, memberIDs ...string) error {
@@ -0,0 +1,59 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ auth.QuotasRepository = (*quotasRepositoryMock)(nil)
+
+type quotasRepositoryMock struct {
+	mu     sync.Mutex
+	quotas map[string]auth.Quota
+}
+
+// NewQuotasRepository returns mock of quotas repository.
+func NewQuotasRepository() auth.QuotasRepository {
+	return &quotasRepositoryMock{
+		quotas: make(map[string]auth.Quota),
+	}
+}
+
+func (qrm *quotasRepositoryMock) Save(ctx context.Context, q auth.Quota) error {
+	qrm.mu.Lock()
+	defer qrm.mu.Unlock()
+
+	qrm.quotas[q.Subject+q.ID] = q
+
+	return nil
+}
+
+func (qrm *quotasRepositoryMock) Retrieve(ctx context.Context, subject, id string) (auth.Quota, error) {
+	qrm.mu.Lock()
+	defer qrm.mu.Unlock()
+
+	q, ok := qrm.quotas[subject+id]
+	if !ok {
+		return auth.Quota{}, errors.ErrNotFound
+	}
+
+	return q, nil
+}
+
+func (qrm *quotasRepositoryMock) Remove(ctx context.Context, subject, id string) error {
+	qrm.mu.Lock()
+	defer qrm.mu.Unlock()
+
+	if _, ok := qrm.quotas[subject+id]; !ok {
+		return errors.ErrNotFound
+	}
+	delete(qrm.quotas, subject+id)
+
+	return nil
+}
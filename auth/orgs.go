@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 )
 
 var (
@@ -77,6 +79,7 @@ type Group struct {
 	OwnerID     string
 	Name        string
 	Description string
+	Tags        []string
 }
 
 type GroupsPage struct {
@@ -107,6 +110,7 @@ type OrgGroup struct {
 
 type Backup struct {
 	Orgs          []Org
+	OrgRoles      []OrgRole
 	OrgMembers    []OrgMember
 	OrgGroups     []OrgGroup
 	GroupPolicies []GroupPolicy
@@ -160,11 +164,20 @@ type Orgs interface {
 	// ListOrgGroups retrieves groups assigned to an org identified by orgID.
 	ListOrgGroups(ctx context.Context, token, orgID string, pm PageMetadata) (GroupsPage, error)
 
-	// Backup retrieves all orgs, org members and org groups. Only accessible by admin.
+	// Backup retrieves all orgs, org roles, org members and org groups. Only accessible by admin.
 	Backup(ctx context.Context, token string) (Backup, error)
 
-	// Restore adds orgs, org members and org groups from a backup. Only accessible by admin.
+	// Restore adds orgs, org roles, org members and org groups from a backup. Only accessible by admin.
 	Restore(ctx context.Context, token string, backup Backup) error
+
+	// ExportBackup returns a stream of the entities matching the filter.
+	// Full backups are only accessible by admin, while backups of an org
+	// are also accessible by the org owner.
+	ExportBackup(ctx context.Context, token string, f BackupFilter) (backup.Stream, error)
+
+	// ImportBackup restores the entities read from the decoder, resolving
+	// conflicts with the existing entities according to the strategy.
+	ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (backup.Summary, error)
 }
 
 // OrgRepository specifies an org persistence API.
@@ -0,0 +1,383 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// Permissions which can be granted to org members through org roles.
+const (
+	// ViewOrgPermission allows viewing the org, its members, roles and groups.
+	ViewOrgPermission = "view_org"
+
+	// ManageOrgPermission allows updating the org.
+	ManageOrgPermission = "manage_org"
+
+	// ManageMembersPermission allows assigning, updating and unassigning org members.
+	ManageMembersPermission = "manage_members"
+
+	// ManageRolesPermission allows creating, updating and removing custom org roles.
+	ManageRolesPermission = "manage_roles"
+
+	// AssignGroupsPermission allows assigning groups to and unassigning them from the org.
+	AssignGroupsPermission = "assign_groups"
+
+	// AllGroupsPermission allows full access to all the org groups,
+	// regardless of the group policies.
+	AllGroupsPermission = "access_all_groups"
+
+	// ReadGroupsPermission allows reading the groups the member has a policy for.
+	ReadGroupsPermission = "read_groups"
+
+	// WriteGroupsPermission allows modifying the groups the member has
+	// a read_write policy for.
+	WriteGroupsPermission = "write_groups"
+
+	// CreateThingsPermission allows assigning things to the org groups.
+	CreateThingsPermission = "create_things"
+
+	// ReadMessagesPermission allows reading messages of the org groups.
+	ReadMessagesPermission = "read_messages"
+
+	// ManageNotifiersPermission allows managing notifiers of the org groups
+	// the member has a read_write policy for.
+	ManageNotifiersPermission = "manage_notifiers"
+)
+
+const maxRoleNameSize = 64
+
+var (
+	// ErrInvalidOrgRole indicates that the org role doesn't exist or can't be assigned.
+	ErrInvalidOrgRole = errors.New("invalid org role")
+
+	// ErrInvalidPermission indicates unknown permission.
+	ErrInvalidPermission = errors.New("invalid permission")
+
+	// ErrBuiltinOrgRole indicates an attempt to create or modify a built-in org role.
+	ErrBuiltinOrgRole = errors.New("built-in org role can't be modified")
+
+	roleNameRegexp = regexp.MustCompile("^[a-z0-9][a-z0-9_-]*$")
+
+	viewerPermissions = []string{
+		ViewOrgPermission,
+		ReadGroupsPermission,
+		ReadMessagesPermission,
+	}
+
+	editorPermissions = append([]string{
+		AssignGroupsPermission,
+		WriteGroupsPermission,
+		CreateThingsPermission,
+		ManageNotifiersPermission,
+	}, viewerPermissions...)
+
+	adminPermissions = append([]string{
+		ManageOrgPermission,
+		ManageMembersPermission,
+		ManageRolesPermission,
+		AllGroupsPermission,
+	}, editorPermissions...)
+
+	// builtinRoles maps the built-in org roles to their permissions.
+	builtinRoles = map[string][]string{
+		ViewerRole: viewerPermissions,
+		EditorRole: editorPermissions,
+		AdminRole:  adminPermissions,
+		OwnerRole:  adminPermissions,
+	}
+
+	// readPermissions are satisfied by a read group policy, all the other
+	// permissions require a read_write group policy.
+	readPermissions = map[string]bool{
+		ViewOrgPermission:      true,
+		ReadGroupsPermission:   true,
+		ReadMessagesPermission: true,
+	}
+)
+
+// OrgRole represents a custom, org-defined role composed of permissions.
+type OrgRole struct {
+	OrgID       string
+	Name        string
+	Description string
+	Permissions []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Validate returns an error if the org role representation is invalid.
+func (r OrgRole) Validate() error {
+	if len(r.Name) > maxRoleNameSize || !roleNameRegexp.MatchString(r.Name) {
+		return ErrInvalidOrgRole
+	}
+
+	if _, ok := builtinRoles[r.Name]; ok {
+		return ErrBuiltinOrgRole
+	}
+
+	for _, p := range r.Permissions {
+		if !contains(adminPermissions, p) {
+			return ErrInvalidPermission
+		}
+	}
+
+	return nil
+}
+
+// OrgRolesPage contains page related metadata as well as list of org roles
+// that belong to this page.
+type OrgRolesPage struct {
+	PageMetadata
+	OrgRoles []OrgRole
+}
+
+// OrgRoles specifies an API for managing custom org roles.
+type OrgRoles interface {
+	// CreateOrgRole creates a custom role in the org identified by orgID.
+	CreateOrgRole(ctx context.Context, token, orgID string, role OrgRole) (OrgRole, error)
+
+	// UpdateOrgRole updates the description and the permissions of the custom org role.
+	UpdateOrgRole(ctx context.Context, token, orgID string, role OrgRole) (OrgRole, error)
+
+	// ViewOrgRole retrieves the org role identified by name. Built-in roles
+	// are retrieved as well.
+	ViewOrgRole(ctx context.Context, token, orgID, name string) (OrgRole, error)
+
+	// ListOrgRoles retrieves custom roles of the org identified by orgID.
+	ListOrgRoles(ctx context.Context, token, orgID string, pm PageMetadata) (OrgRolesPage, error)
+
+	// RemoveOrgRole removes the custom org role. Members holding the removed
+	// role lose all the permissions in the org until assigned another role.
+	RemoveOrgRole(ctx context.Context, token, orgID, name string) error
+}
+
+// OrgRolesRepository specifies a custom org roles persistence API.
+type OrgRolesRepository interface {
+	// Save persists the org roles.
+	Save(ctx context.Context, roles ...OrgRole) error
+
+	// Update updates the description and the permissions of the org role.
+	Update(ctx context.Context, role OrgRole) error
+
+	// Retrieve retrieves the org role identified by name.
+	Retrieve(ctx context.Context, orgID, name string) (OrgRole, error)
+
+	// RetrieveByOrg retrieves the roles of the org identified by orgID.
+	RetrieveByOrg(ctx context.Context, orgID string, pm PageMetadata) (OrgRolesPage, error)
+
+	// RetrieveAll retrieves all the org roles.
+	RetrieveAll(ctx context.Context) ([]OrgRole, error)
+
+	// Remove removes the org role identified by name.
+	Remove(ctx context.Context, orgID, name string) error
+}
+
+func (svc service) CreateOrgRole(ctx context.Context, token, orgID string, role OrgRole) (OrgRole, error) {
+	if err := svc.orgRolesAuth(ctx, token, orgID, ManageRolesPermission); err != nil {
+		return OrgRole{}, err
+	}
+
+	if err := role.Validate(); err != nil {
+		return OrgRole{}, err
+	}
+
+	if err := svc.canGrant(ctx, token, orgID, role.Name, role.Permissions); err != nil {
+		return OrgRole{}, err
+	}
+
+	timestamp := getTimestmap()
+	role.OrgID = orgID
+	role.CreatedAt = timestamp
+	role.UpdatedAt = timestamp
+
+	if err := svc.orgRoles.Save(ctx, role); err != nil {
+		return OrgRole{}, err
+	}
+
+	return role, nil
+}
+
+func (svc service) UpdateOrgRole(ctx context.Context, token, orgID string, role OrgRole) (OrgRole, error) {
+	if err := svc.orgRolesAuth(ctx, token, orgID, ManageRolesPermission); err != nil {
+		return OrgRole{}, err
+	}
+
+	if err := role.Validate(); err != nil {
+		return OrgRole{}, err
+	}
+
+	if err := svc.canGrant(ctx, token, orgID, role.Name, role.Permissions); err != nil {
+		return OrgRole{}, err
+	}
+
+	role.OrgID = orgID
+	role.UpdatedAt = getTimestmap()
+
+	if err := svc.orgRoles.Update(ctx, role); err != nil {
+		return OrgRole{}, err
+	}
+
+	return svc.orgRoles.Retrieve(ctx, orgID, role.Name)
+}
+
+func (svc service) ViewOrgRole(ctx context.Context, token, orgID, name string) (OrgRole, error) {
+	if err := svc.orgRolesAuth(ctx, token, orgID, ViewOrgPermission); err != nil {
+		return OrgRole{}, err
+	}
+
+	if perms, ok := builtinRoles[name]; ok {
+		return OrgRole{OrgID: orgID, Name: name, Permissions: append([]string{}, perms...)}, nil
+	}
+
+	return svc.orgRoles.Retrieve(ctx, orgID, name)
+}
+
+func (svc service) ListOrgRoles(ctx context.Context, token, orgID string, pm PageMetadata) (OrgRolesPage, error) {
+	if err := svc.orgRolesAuth(ctx, token, orgID, ViewOrgPermission); err != nil {
+		return OrgRolesPage{}, err
+	}
+
+	return svc.orgRoles.RetrieveByOrg(ctx, orgID, pm)
+}
+
+func (svc service) RemoveOrgRole(ctx context.Context, token, orgID, name string) error {
+	if err := svc.orgRolesAuth(ctx, token, orgID, ManageRolesPermission); err != nil {
+		return err
+	}
+
+	if _, ok := builtinRoles[name]; ok {
+		return ErrBuiltinOrgRole
+	}
+
+	return svc.orgRoles.Remove(ctx, orgID, name)
+}
+
+// rolePermissions returns the permissions granted by the org role.
+func (svc service) rolePermissions(ctx context.Context, orgID, role string) ([]string, error) {
+	if perms, ok := builtinRoles[role]; ok {
+		return perms, nil
+	}
+
+	r, err := svc.orgRoles.Retrieve(ctx, orgID, role)
+	if err != nil {
+		if errors.Contains(err, errors.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.Permissions, nil
+}
+
+// canGrantRoles returns an error if the roles of the members can't be
+// assigned or if they grant permissions which the caller doesn't have.
+func (svc service) canGrantRoles(ctx context.Context, token, orgID string, members ...OrgMember) error {
+	roles := make(map[string][]string)
+	for _, m := range members {
+		// Ownership can't be granted through member roles.
+		if m.Role == OwnerRole {
+			return errors.ErrAuthorization
+		}
+		if _, ok := roles[m.Role]; ok {
+			continue
+		}
+
+		if perms, ok := builtinRoles[m.Role]; ok {
+			roles[m.Role] = perms
+			continue
+		}
+
+		r, err := svc.orgRoles.Retrieve(ctx, orgID, m.Role)
+		if err != nil {
+			if errors.Contains(err, errors.ErrNotFound) {
+				return ErrInvalidOrgRole
+			}
+			return err
+		}
+		roles[m.Role] = r.Permissions
+	}
+
+	var perms [][]string
+	for _, p := range roles {
+		perms = append(perms, p)
+	}
+
+	return svc.canGrant(ctx, token, orgID, "", perms...)
+}
+
+// canGrant returns an error if the caller, unless being the root admin or
+// the org owner, lacks any of the permissions to grant, or holds the role
+// being modified, which would let the caller extend their own permissions.
+func (svc service) canGrant(ctx context.Context, token, orgID, modifiedRole string, perms ...[]string) error {
+	if err := svc.isAdmin(ctx, token); err == nil {
+		return nil
+	}
+
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	role, err := svc.orgs.RetrieveRole(ctx, user.ID, orgID)
+	if err != nil {
+		return err
+	}
+
+	if role == OwnerRole {
+		return nil
+	}
+
+	if modifiedRole != "" && role == modifiedRole {
+		return errors.ErrAuthorization
+	}
+
+	granted, err := svc.rolePermissions(ctx, orgID, role)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range perms {
+		if !containsAll(granted, p) {
+			return errors.ErrAuthorization
+		}
+	}
+
+	return nil
+}
+
+// requiredPermissions returns the permissions required by the action, which
+// is either a permission or a built-in role. Acting as a built-in role
+// requires all the permissions of that role.
+func requiredPermissions(action string) []string {
+	if perms, ok := builtinRoles[action]; ok {
+		return perms
+	}
+
+	return []string{action}
+}
+
+func containsAll(perms, required []string) bool {
+	for _, r := range required {
+		if !contains(perms, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
@@ -30,6 +30,10 @@ type Policies interface {
 
 	// RemoveGroupPolicies removes group policies.
 	RemoveGroupPolicies(ctx context.Context, token, groupID string, memberIDs ...string) error
+
+	// CreateTagPolicies creates or updates group policies on all the groups
+	// of the org having the tag, and returns the identifiers of those groups.
+	CreateTagPolicies(ctx context.Context, token, orgID, tag string, gps ...GroupPolicyByID) ([]string, error)
 }
 
 type PoliciesRepository interface {
@@ -147,6 +147,54 @@ func migrateDB(db *sqlx.DB) error {
 					`ALTER TABLE group_relations ADD CONSTRAINT group_relations_org_id_fkey FOREIGN KEY (org_id) REFERENCES orgs (id) ON DELETE CASCADE ON UPDATE CASCADE`,
 				},
 			},
+			{
+				Id: "auth_8",
+				Up: []string{
+					`ALTER TABLE IF EXISTS keys ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP`,
+					`ALTER TABLE IF EXISTS keys ADD COLUMN IF NOT EXISTS scopes      VARCHAR(64)[]`,
+					`ALTER TABLE IF EXISTS keys ADD COLUMN IF NOT EXISTS org_ids     VARCHAR(254)[]`,
+					`ALTER TABLE IF EXISTS keys ADD COLUMN IF NOT EXISTS group_ids   VARCHAR(254)[]`,
+					`ALTER TABLE IF EXISTS keys ADD COLUMN IF NOT EXISTS channel_ids VARCHAR(254)[]`,
+					`ALTER TABLE IF EXISTS keys ADD COLUMN IF NOT EXISTS allowed_ips VARCHAR(64)[]`,
+				},
+			},
+			{
+				Id: "auth_9",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS org_roles (
+						org_id      UUID NOT NULL,
+						name        VARCHAR(64) NOT NULL,
+						description VARCHAR(1024),
+						permissions VARCHAR(64)[],
+						created_at  TIMESTAMPTZ,
+						updated_at  TIMESTAMPTZ,
+						FOREIGN KEY (org_id) REFERENCES orgs (id) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (org_id, name)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS org_roles`,
+				},
+			},
+			{
+				Id: "auth_10",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS quotas (
+						subject       VARCHAR(16) NOT NULL,
+						id            VARCHAR(254) NOT NULL,
+						things        BIGINT NOT NULL DEFAULT 0,
+						channels      BIGINT NOT NULL DEFAULT 0,
+						groups        BIGINT NOT NULL DEFAULT 0,
+						keys          BIGINT NOT NULL DEFAULT 0,
+						subscriptions BIGINT NOT NULL DEFAULT 0,
+						updated_at    TIMESTAMPTZ,
+						PRIMARY KEY (subject, id)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS quotas`,
+				},
+			},
 		},
 	}
 
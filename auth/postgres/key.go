@@ -8,6 +8,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
@@ -25,10 +26,14 @@ func New(db Database) auth.KeyRepository {
 }
 
 func (kr repo) Save(ctx context.Context, key auth.Key) (string, error) {
-	q := `INSERT INTO keys (id, type, issuer_id, subject, issued_at, expires_at)
-	      VALUES (:id, :type, :issuer_id, :subject, :issued_at, :expires_at)`
+	q := `INSERT INTO keys (id, type, issuer_id, subject, issued_at, expires_at, scopes, org_ids, group_ids, channel_ids, allowed_ips)
+	      VALUES (:id, :type, :issuer_id, :subject, :issued_at, :expires_at, :scopes, :org_ids, :group_ids, :channel_ids, :allowed_ips)`
+
+	dbKey, err := toDBKey(key)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrCreateEntity, err)
+	}
 
-	dbKey := toDBKey(key)
 	if _, err := kr.db.NamedExecContext(ctx, q, dbKey); err != nil {
 
 		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
@@ -42,7 +47,8 @@ func (kr repo) Save(ctx context.Context, key auth.Key) (string, error) {
 }
 
 func (kr repo) Retrieve(ctx context.Context, issuerID, id string) (auth.Key, error) {
-	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at FROM keys WHERE issuer_id = $1 AND id = $2`
+	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at, last_used_at, scopes, org_ids, group_ids, channel_ids, allowed_ips
+	      FROM keys WHERE issuer_id = $1 AND id = $2`
 	key := dbKey{}
 	if err := kr.db.QueryRowxContext(ctx, q, issuerID, id).StructScan(&key); err != nil {
 		pgErr, ok := err.(*pgconn.PgError)
@@ -53,7 +59,32 @@ func (kr repo) Retrieve(ctx context.Context, issuerID, id string) (auth.Key, err
 		return auth.Key{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
 
-	return toKey(key), nil
+	return toKey(key)
+}
+
+func (kr repo) UpdateLastUsed(ctx context.Context, issuerID, id string, usedAt time.Time) error {
+	q := `UPDATE keys SET last_used_at = :last_used_at WHERE issuer_id = :issuer_id AND id = :id`
+	key := dbKey{
+		ID:         id,
+		IssuerID:   issuerID,
+		LastUsedAt: sql.NullTime{Time: usedAt, Valid: true},
+	}
+
+	res, err := kr.db.NamedExecContext(ctx, q, key)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
 }
 
 func (kr repo) Remove(ctx context.Context, issuerID, id string) error {
@@ -69,17 +100,34 @@ func (kr repo) Remove(ctx context.Context, issuerID, id string) error {
 	return nil
 }
 
+func (kr repo) RetrieveAPIKeysCount(ctx context.Context, issuerID string) (uint64, error) {
+	q := `SELECT COUNT(*) FROM keys WHERE issuer_id = $1 AND type = $2 AND (expires_at IS NULL OR expires_at > $3)`
+
+	var count uint64
+	if err := kr.db.QueryRowxContext(ctx, q, issuerID, auth.APIKey, time.Now().UTC()).Scan(&count); err != nil {
+		return 0, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return count, nil
+}
+
 type dbKey struct {
-	ID        string       `db:"id"`
-	Type      uint32       `db:"type"`
-	IssuerID  string       `db:"issuer_id"`
-	Subject   string       `db:"subject"`
-	Revoked   bool         `db:"revoked"`
-	IssuedAt  time.Time    `db:"issued_at"`
-	ExpiresAt sql.NullTime `db:"expires_at"`
+	ID         string           `db:"id"`
+	Type       uint32           `db:"type"`
+	IssuerID   string           `db:"issuer_id"`
+	Subject    string           `db:"subject"`
+	Revoked    bool             `db:"revoked"`
+	IssuedAt   time.Time        `db:"issued_at"`
+	ExpiresAt  sql.NullTime     `db:"expires_at"`
+	LastUsedAt sql.NullTime     `db:"last_used_at"`
+	Scopes     pgtype.TextArray `db:"scopes"`
+	OrgIDs     pgtype.TextArray `db:"org_ids"`
+	GroupIDs   pgtype.TextArray `db:"group_ids"`
+	ChannelIDs pgtype.TextArray `db:"channel_ids"`
+	AllowedIPs pgtype.TextArray `db:"allowed_ips"`
 }
 
-func toDBKey(key auth.Key) dbKey {
+func toDBKey(key auth.Key) (dbKey, error) {
 	ret := dbKey{
 		ID:       key.ID,
 		Type:     key.Type,
@@ -91,10 +139,27 @@ func toDBKey(key auth.Key) dbKey {
 		ret.ExpiresAt = sql.NullTime{Time: key.ExpiresAt, Valid: true}
 	}
 
-	return ret
+	var err error
+	if ret.Scopes, err = toTextArray(key.Scopes); err != nil {
+		return dbKey{}, err
+	}
+	if ret.OrgIDs, err = toTextArray(key.OrgIDs); err != nil {
+		return dbKey{}, err
+	}
+	if ret.GroupIDs, err = toTextArray(key.GroupIDs); err != nil {
+		return dbKey{}, err
+	}
+	if ret.ChannelIDs, err = toTextArray(key.ChannelIDs); err != nil {
+		return dbKey{}, err
+	}
+	if ret.AllowedIPs, err = toTextArray(key.AllowedIPs); err != nil {
+		return dbKey{}, err
+	}
+
+	return ret, nil
 }
 
-func toKey(key dbKey) auth.Key {
+func toKey(key dbKey) (auth.Key, error) {
 	ret := auth.Key{
 		ID:       key.ID,
 		Type:     key.Type,
@@ -105,6 +170,44 @@ func toKey(key dbKey) auth.Key {
 	if key.ExpiresAt.Valid {
 		ret.ExpiresAt = key.ExpiresAt.Time
 	}
+	if key.LastUsedAt.Valid {
+		ret.LastUsedAt = key.LastUsedAt.Time
+	}
+
+	var err error
+	if ret.Scopes, err = fromTextArray(key.Scopes); err != nil {
+		return auth.Key{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	if ret.OrgIDs, err = fromTextArray(key.OrgIDs); err != nil {
+		return auth.Key{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	if ret.GroupIDs, err = fromTextArray(key.GroupIDs); err != nil {
+		return auth.Key{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	if ret.ChannelIDs, err = fromTextArray(key.ChannelIDs); err != nil {
+		return auth.Key{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	if ret.AllowedIPs, err = fromTextArray(key.AllowedIPs); err != nil {
+		return auth.Key{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
 
-	return ret
+	return ret, nil
+}
+
+func toTextArray(vals []string) (pgtype.TextArray, error) {
+	var arr pgtype.TextArray
+	if vals == nil {
+		vals = []string{}
+	}
+	err := arr.Set(vals)
+	return arr, err
+}
+
+func fromTextArray(arr pgtype.TextArray) ([]string, error) {
+	var vals []string
+	if arr.Status != pgtype.Present || len(arr.Elements) == 0 {
+		return vals, nil
+	}
+	err := arr.AssignTo(&vals)
+	return vals, err
 }
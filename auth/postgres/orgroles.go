@@ -0,0 +1,263 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ auth.OrgRolesRepository = (*orgRolesRepository)(nil)
+
+type orgRolesRepository struct {
+	db Database
+}
+
+// NewOrgRolesRepo instantiates a PostgreSQL implementation of org roles
+// repository.
+func NewOrgRolesRepo(db Database) auth.OrgRolesRepository {
+	return &orgRolesRepository{
+		db: db,
+	}
+}
+
+func (rr orgRolesRepository) Save(ctx context.Context, roles ...auth.OrgRole) error {
+	q := `INSERT INTO org_roles (org_id, name, description, permissions, created_at, updated_at)
+		  VALUES (:org_id, :name, :description, :permissions, :created_at, :updated_at)`
+
+	for _, role := range roles {
+		dbr, err := toDBOrgRole(role)
+		if err != nil {
+			return errors.Wrap(errors.ErrCreateEntity, err)
+		}
+
+		if _, err := rr.db.NamedExecContext(ctx, q, dbr); err != nil {
+			pgErr, ok := err.(*pgconn.PgError)
+			if ok {
+				switch pgErr.Code {
+				case pgerrcode.InvalidTextRepresentation:
+					return errors.Wrap(errors.ErrMalformedEntity, err)
+				case pgerrcode.ForeignKeyViolation:
+					return errors.Wrap(errors.ErrNotFound, err)
+				case pgerrcode.UniqueViolation:
+					return errors.Wrap(errors.ErrConflict, err)
+				case pgerrcode.StringDataRightTruncationDataException:
+					return errors.Wrap(errors.ErrMalformedEntity, err)
+				}
+			}
+
+			return errors.Wrap(errors.ErrCreateEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (rr orgRolesRepository) Update(ctx context.Context, role auth.OrgRole) error {
+	q := `UPDATE org_roles SET description = :description, permissions = :permissions, updated_at = :updated_at
+		  WHERE org_id = :org_id AND name = :name;`
+
+	dbr, err := toDBOrgRole(role)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	res, err := rr.db.NamedExecContext(ctx, q, dbr)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.StringDataRightTruncationDataException:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+		}
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt != 1 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (rr orgRolesRepository) Retrieve(ctx context.Context, orgID, name string) (auth.OrgRole, error) {
+	q := `SELECT org_id, name, description, permissions, created_at, updated_at FROM org_roles
+		  WHERE org_id = :org_id AND name = :name;`
+
+	params := map[string]interface{}{
+		"org_id": orgID,
+		"name":   name,
+	}
+
+	rows, err := rr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return auth.OrgRole{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return auth.OrgRole{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return auth.OrgRole{}, errors.ErrNotFound
+	}
+
+	dbr := dbOrgRole{}
+	if err := rows.StructScan(&dbr); err != nil {
+		return auth.OrgRole{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toOrgRole(dbr)
+}
+
+func (rr orgRolesRepository) RetrieveByOrg(ctx context.Context, orgID string, pm auth.PageMetadata) (auth.OrgRolesPage, error) {
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT org_id, name, description, permissions, created_at, updated_at FROM org_roles
+					  WHERE org_id = :org_id ORDER BY name %s;`, olq)
+
+	params := map[string]interface{}{
+		"org_id": orgID,
+		"limit":  pm.Limit,
+		"offset": pm.Offset,
+	}
+
+	roles, err := rr.retrieve(ctx, q, params)
+	if err != nil {
+		return auth.OrgRolesPage{}, err
+	}
+
+	cq := `SELECT COUNT(*) FROM org_roles WHERE org_id = :org_id;`
+	total, err := total(ctx, rr.db, cq, params)
+	if err != nil {
+		return auth.OrgRolesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := auth.OrgRolesPage{
+		OrgRoles: roles,
+		PageMetadata: auth.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (rr orgRolesRepository) RetrieveAll(ctx context.Context) ([]auth.OrgRole, error) {
+	q := `SELECT org_id, name, description, permissions, created_at, updated_at FROM org_roles;`
+
+	return rr.retrieve(ctx, q, map[string]interface{}{})
+}
+
+func (rr orgRolesRepository) Remove(ctx context.Context, orgID, name string) error {
+	q := `DELETE FROM org_roles WHERE org_id = :org_id AND name = :name;`
+
+	params := map[string]interface{}{
+		"org_id": orgID,
+		"name":   name,
+	}
+
+	res, err := rr.db.NamedExecContext(ctx, q, params)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	if cnt != 1 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (rr orgRolesRepository) retrieve(ctx context.Context, query string, params interface{}) ([]auth.OrgRole, error) {
+	rows, err := rr.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var roles []auth.OrgRole
+	for rows.Next() {
+		dbr := dbOrgRole{}
+		if err := rows.StructScan(&dbr); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		role, err := toOrgRole(dbr)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, nil
+}
+
+type dbOrgRole struct {
+	OrgID       string           `db:"org_id"`
+	Name        string           `db:"name"`
+	Description string           `db:"description"`
+	Permissions pgtype.TextArray `db:"permissions"`
+	CreatedAt   time.Time        `db:"created_at"`
+	UpdatedAt   time.Time        `db:"updated_at"`
+}
+
+func toDBOrgRole(role auth.OrgRole) (dbOrgRole, error) {
+	perms, err := toTextArray(role.Permissions)
+	if err != nil {
+		return dbOrgRole{}, err
+	}
+
+	return dbOrgRole{
+		OrgID:       role.OrgID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: perms,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}, nil
+}
+
+func toOrgRole(dbr dbOrgRole) (auth.OrgRole, error) {
+	perms, err := fromTextArray(dbr.Permissions)
+	if err != nil {
+		return auth.OrgRole{}, err
+	}
+
+	return auth.OrgRole{
+		OrgID:       dbr.OrgID,
+		Name:        dbr.Name,
+		Description: dbr.Description,
+		Permissions: perms,
+		CreatedAt:   dbr.CreatedAt,
+		UpdatedAt:   dbr.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/auth/postgres"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const roleName = "technician"
+
+func saveOrg(t *testing.T) auth.Org {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewOrgRepo(dbMiddleware)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ownerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	org := auth.Org{
+		ID:        id,
+		OwnerID:   ownerID,
+		Name:      orgName,
+		CreatedAt: time.Now().UTC().Round(time.Millisecond),
+		UpdatedAt: time.Now().UTC().Round(time.Millisecond),
+	}
+	err = repo.Save(context.Background(), org)
+	require.Nil(t, err, fmt.Sprintf("save org: unexpected error: %s", err))
+
+	return org
+}
+
+func TestSaveOrgRole(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewOrgRolesRepo(dbMiddleware)
+	org := saveOrg(t)
+
+	role := auth.OrgRole{
+		OrgID:       org.ID,
+		Name:        roleName,
+		Permissions: []string{auth.ViewOrgPermission, auth.CreateThingsPermission},
+	}
+
+	cases := []struct {
+		desc string
+		role auth.OrgRole
+		err  error
+	}{
+		{
+			desc: "save org role",
+			role: role,
+			err:  nil,
+		},
+		{
+			desc: "save existing org role",
+			role: role,
+			err:  errors.ErrConflict,
+		},
+		{
+			desc: "save org role with invalid org id",
+			role: auth.OrgRole{OrgID: invalidID, Name: roleName},
+			err:  errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.role)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRetrieveOrgRole(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewOrgRolesRepo(dbMiddleware)
+	org := saveOrg(t)
+
+	role := auth.OrgRole{
+		OrgID:       org.ID,
+		Name:        roleName,
+		Description: orgDesc,
+		Permissions: []string{auth.ViewOrgPermission, auth.CreateThingsPermission},
+	}
+	err := repo.Save(context.Background(), role)
+	require.Nil(t, err, fmt.Sprintf("save org role: unexpected error: %s", err))
+
+	role.Permissions = []string{auth.ReadMessagesPermission}
+	err = repo.Update(context.Background(), role)
+	require.Nil(t, err, fmt.Sprintf("update org role: unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		orgID string
+		name  string
+		perms []string
+		err   error
+	}{
+		{
+			desc:  "retrieve updated org role",
+			orgID: org.ID,
+			name:  roleName,
+			perms: []string{auth.ReadMessagesPermission},
+			err:   nil,
+		},
+		{
+			desc:  "retrieve non-existing org role",
+			orgID: org.ID,
+			name:  invalidID,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "retrieve org role with invalid org id",
+			orgID: invalidID,
+			name:  roleName,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := repo.Retrieve(context.Background(), tc.orgID, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.perms, r.Permissions, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.perms, r.Permissions))
+	}
+
+	page, err := repo.RetrieveByOrg(context.Background(), org.ID, auth.PageMetadata{Limit: n})
+	require.Nil(t, err, fmt.Sprintf("retrieve org roles: unexpected error: %s", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("retrieve org roles: expected total 1 got %d\n", page.Total))
+
+	err = repo.Remove(context.Background(), org.ID, roleName)
+	assert.Nil(t, err, fmt.Sprintf("remove org role: unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), org.ID, roleName)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("remove removed org role: expected %s got %s\n", errors.ErrNotFound, err))
+}
@@ -0,0 +1,130 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ auth.QuotasRepository = (*quotasRepository)(nil)
+
+type quotasRepository struct {
+	db Database
+}
+
+// NewQuotasRepo instantiates a PostgreSQL implementation of quotas
+// repository.
+func NewQuotasRepo(db Database) auth.QuotasRepository {
+	return &quotasRepository{
+		db: db,
+	}
+}
+
+func (qr quotasRepository) Save(ctx context.Context, q auth.Quota) error {
+	query := `INSERT INTO quotas (subject, id, things, channels, groups, keys, subscriptions, updated_at)
+		      VALUES (:subject, :id, :things, :channels, :groups, :keys, :subscriptions, :updated_at)
+		      ON CONFLICT (subject, id) DO UPDATE SET things = :things, channels = :channels, groups = :groups,
+		      keys = :keys, subscriptions = :subscriptions, updated_at = :updated_at;`
+
+	if _, err := qr.db.NamedExecContext(ctx, query, toDBQuota(q)); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (qr quotasRepository) Retrieve(ctx context.Context, subject, id string) (auth.Quota, error) {
+	query := `SELECT subject, id, things, channels, groups, keys, subscriptions, updated_at FROM quotas
+		      WHERE subject = :subject AND id = :id;`
+
+	params := map[string]interface{}{
+		"subject": subject,
+		"id":      id,
+	}
+
+	rows, err := qr.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return auth.Quota{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return auth.Quota{}, errors.ErrNotFound
+	}
+
+	dbq := dbQuota{}
+	if err := rows.StructScan(&dbq); err != nil {
+		return auth.Quota{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toQuota(dbq), nil
+}
+
+func (qr quotasRepository) Remove(ctx context.Context, subject, id string) error {
+	query := `DELETE FROM quotas WHERE subject = :subject AND id = :id;`
+
+	params := map[string]interface{}{
+		"subject": subject,
+		"id":      id,
+	}
+
+	res, err := qr.db.NamedExecContext(ctx, query, params)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	if cnt != 1 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbQuota struct {
+	Subject       string    `db:"subject"`
+	ID            string    `db:"id"`
+	Things        uint64    `db:"things"`
+	Channels      uint64    `db:"channels"`
+	Groups        uint64    `db:"groups"`
+	Keys          uint64    `db:"keys"`
+	Subscriptions uint64    `db:"subscriptions"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+func toDBQuota(q auth.Quota) dbQuota {
+	return dbQuota{
+		Subject:       q.Subject,
+		ID:            q.ID,
+		Things:        q.Limits.Things,
+		Channels:      q.Limits.Channels,
+		Groups:        q.Limits.Groups,
+		Keys:          q.Limits.Keys,
+		Subscriptions: q.Limits.Subscriptions,
+		UpdatedAt:     q.UpdatedAt,
+	}
+}
+
+func toQuota(dbq dbQuota) auth.Quota {
+	return auth.Quota{
+		Subject: dbq.Subject,
+		ID:      dbq.ID,
+		Limits: auth.Limits{
+			Things:        dbq.Things,
+			Channels:      dbq.Channels,
+			Groups:        dbq.Groups,
+			Keys:          dbq.Keys,
+			Subscriptions: dbq.Subscriptions,
+		},
+		UpdatedAt: dbq.UpdatedAt,
+	}
+}
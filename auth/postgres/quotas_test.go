@@ -0,0 +1,132 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/auth/postgres"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveQuota(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewQuotasRepo(dbMiddleware)
+
+	userID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	q := auth.Quota{
+		Subject:   quota.User,
+		ID:        userID,
+		Limits:    auth.Limits{Things: 10, Channels: 20},
+		UpdatedAt: time.Now().UTC().Round(time.Millisecond),
+	}
+	updated := q
+	updated.Limits = auth.Limits{Things: 5, Keys: 3}
+
+	cases := []struct {
+		desc  string
+		quota auth.Quota
+		err   error
+	}{
+		{
+			desc:  "save quota",
+			quota: q,
+			err:   nil,
+		},
+		{
+			desc:  "save quota of subject with existing quota",
+			quota: updated,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.quota)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		saved, err := repo.Retrieve(context.Background(), tc.quota.Subject, tc.quota.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.quota.Limits, saved.Limits, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.quota.Limits, saved.Limits))
+	}
+}
+
+func TestRetrieveQuota(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewQuotasRepo(dbMiddleware)
+
+	orgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	q := auth.Quota{
+		Subject:   quota.Org,
+		ID:        orgID,
+		Limits:    auth.Limits{Groups: 3},
+		UpdatedAt: time.Now().UTC().Round(time.Millisecond),
+	}
+	err = repo.Save(context.Background(), q)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		subject string
+		id      string
+		err     error
+	}{
+		{
+			desc:    "retrieve quota",
+			subject: quota.Org,
+			id:      orgID,
+			err:     nil,
+		},
+		{
+			desc:    "retrieve quota of another subject",
+			subject: quota.User,
+			id:      orgID,
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "retrieve non-existing quota",
+			subject: quota.Org,
+			id:      invalid,
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.Retrieve(context.Background(), tc.subject, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRemoveQuota(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewQuotasRepo(dbMiddleware)
+
+	userID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	q := auth.Quota{
+		Subject:   quota.User,
+		ID:        userID,
+		Limits:    auth.Limits{Keys: 1},
+		UpdatedAt: time.Now().UTC().Round(time.Millisecond),
+	}
+	err = repo.Save(context.Background(), q)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), quota.User, userID)
+	assert.Nil(t, err, fmt.Sprintf("remove quota: unexpected error: %s", err))
+
+	_, err = repo.Retrieve(context.Background(), quota.User, userID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve removed quota: expected %s got %s\n", errors.ErrNotFound, err))
+}
@@ -0,0 +1,271 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+)
+
+// ErrInvalidQuotaSubject indicates a quota subject other than a user or an org.
+var ErrInvalidQuotaSubject = errors.New("invalid quota subject")
+
+// Limits contains the maximum number of entities of each type. Zero means
+// unlimited.
+type Limits struct {
+	Things        uint64
+	Channels      uint64
+	Groups        uint64
+	Keys          uint64
+	Subscriptions uint64
+}
+
+// QuotaDefaults contains the limits applied to the users and the orgs
+// without a quota of their own.
+type QuotaDefaults struct {
+	User Limits
+	Org  Limits
+}
+
+// Quota represents the limits of a user or an org.
+type Quota struct {
+	Subject string
+	ID      string
+	Limits  Limits
+	// OrgID and GroupIDs identify the org and the groups assigned to it
+	// for org and group quotas.
+	OrgID     string
+	GroupIDs  []string
+	UpdatedAt time.Time
+}
+
+// Quotas specifies an API for managing user and org quotas.
+type Quotas interface {
+	// UpdateQuota overrides the default limits of a user or an org.
+	// Only admin can update quotas.
+	UpdateQuota(ctx context.Context, token string, q Quota) error
+
+	// RemoveQuota restores the default limits of a user or an org.
+	// Only admin can remove quotas.
+	RemoveQuota(ctx context.Context, token, subject, id string) error
+
+	// ViewQuota retrieves the limits of a user or an org. Users can view
+	// their own quota and the quotas of the orgs they are members of.
+	ViewQuota(ctx context.Context, token, subject, id string) (Quota, error)
+
+	// RetrieveQuota retrieves the limits of a user, an org, or the org
+	// that a group belongs to, without authorization. It is used by the
+	// services enforcing the quotas.
+	RetrieveQuota(ctx context.Context, subject, id string) (Quota, error)
+
+	// ViewUsage retrieves the API keys usage of the user or, if orgID is
+	// provided, the groups usage of the org.
+	ViewUsage(ctx context.Context, token, orgID string) (map[string]quota.Usage, error)
+}
+
+// QuotasRepository specifies a quota persistence API.
+type QuotasRepository interface {
+	// Save persists the quota, replacing the existing quota of the subject.
+	Save(ctx context.Context, q Quota) error
+
+	// Retrieve retrieves the quota of the subject identified by id.
+	Retrieve(ctx context.Context, subject, id string) (Quota, error)
+
+	// Remove removes the quota of the subject identified by id.
+	Remove(ctx context.Context, subject, id string) error
+}
+
+func (svc service) UpdateQuota(ctx context.Context, token string, q Quota) error {
+	if err := svc.isAdmin(ctx, token); err != nil {
+		return err
+	}
+
+	switch q.Subject {
+	case quota.User:
+	case quota.Org:
+		if _, err := svc.orgs.RetrieveByID(ctx, q.ID); err != nil {
+			return err
+		}
+	default:
+		return ErrInvalidQuotaSubject
+	}
+
+	q.UpdatedAt = getTimestmap()
+	return svc.quotas.Save(ctx, q)
+}
+
+func (svc service) RemoveQuota(ctx context.Context, token, subject, id string) error {
+	if err := svc.isAdmin(ctx, token); err != nil {
+		return err
+	}
+
+	if subject != quota.User && subject != quota.Org {
+		return ErrInvalidQuotaSubject
+	}
+
+	return svc.quotas.Remove(ctx, subject, id)
+}
+
+func (svc service) ViewQuota(ctx context.Context, token, subject, id string) (Quota, error) {
+	switch subject {
+	case quota.User:
+		user, err := svc.Identify(ctx, token)
+		if err != nil {
+			return Quota{}, err
+		}
+		if user.ID != id {
+			if err := svc.isAdmin(ctx, token); err != nil {
+				return Quota{}, err
+			}
+		}
+	case quota.Org:
+		if err := svc.orgRolesAuth(ctx, token, id, ViewerRole); err != nil {
+			return Quota{}, err
+		}
+	default:
+		return Quota{}, ErrInvalidQuotaSubject
+	}
+
+	return svc.RetrieveQuota(ctx, subject, id)
+}
+
+func (svc service) RetrieveQuota(ctx context.Context, subject, id string) (Quota, error) {
+	switch subject {
+	case quota.User:
+		return svc.subjectQuota(ctx, quota.User, id, svc.quotaDefaults.User)
+	case quota.Org:
+		if _, err := svc.orgs.RetrieveByID(ctx, id); err != nil {
+			return Quota{}, err
+		}
+		return svc.orgQuota(ctx, id)
+	case quota.Group:
+		lineage, err := svc.groupLineage(ctx, id)
+		if err != nil {
+			return Quota{}, err
+		}
+
+		org, err := svc.groupOrg(ctx, lineage)
+		switch {
+		case errors.Contains(err, errors.ErrNotFound):
+			// Groups outside of orgs are only limited by the quota of their owner.
+			return Quota{Subject: quota.Group, ID: id}, nil
+		case err != nil:
+			return Quota{}, err
+		}
+
+		return svc.orgQuota(ctx, org.ID)
+	default:
+		return Quota{}, ErrInvalidQuotaSubject
+	}
+}
+
+func (svc service) ViewUsage(ctx context.Context, token, orgID string) (map[string]quota.Usage, error) {
+	if orgID != "" {
+		if err := svc.orgRolesAuth(ctx, token, orgID, ViewerRole); err != nil {
+			return nil, err
+		}
+
+		q, err := svc.orgQuota(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+
+		usage := map[string]quota.Usage{
+			quota.Groups: {Used: uint64(len(q.GroupIDs)), Limit: q.Limits.Groups},
+		}
+		return usage, nil
+	}
+
+	user, err := svc.Identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := svc.subjectQuota(ctx, quota.User, user.ID, svc.quotaDefaults.User)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := svc.keys.RetrieveAPIKeysCount(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := map[string]quota.Usage{
+		quota.Keys: {Used: keys, Limit: q.Limits.Keys},
+	}
+	return usage, nil
+}
+
+// subjectQuota retrieves the quota of the subject, falling back to the defaults.
+func (svc service) subjectQuota(ctx context.Context, subject, id string, defaults Limits) (Quota, error) {
+	q, err := svc.quotas.Retrieve(ctx, subject, id)
+	switch {
+	case errors.Contains(err, errors.ErrNotFound):
+		return Quota{Subject: subject, ID: id, Limits: defaults}, nil
+	case err != nil:
+		return Quota{}, err
+	}
+
+	return q, nil
+}
+
+func (svc service) orgQuota(ctx context.Context, orgID string) (Quota, error) {
+	q, err := svc.subjectQuota(ctx, quota.Org, orgID, svc.quotaDefaults.Org)
+	if err != nil {
+		return Quota{}, err
+	}
+
+	ogp, err := svc.orgs.RetrieveGroups(ctx, orgID, PageMetadata{})
+	if err != nil {
+		return Quota{}, err
+	}
+
+	q.OrgID = orgID
+	for _, og := range ogp.OrgGroups {
+		q.GroupIDs = append(q.GroupIDs, og.GroupID)
+	}
+
+	return q, nil
+}
+
+// checkKeysQuota returns ErrQuotaExceeded if the user can't issue more API keys.
+func (svc service) checkKeysQuota(ctx context.Context, userID string) error {
+	q, err := svc.subjectQuota(ctx, quota.User, userID, svc.quotaDefaults.User)
+	if err != nil {
+		return err
+	}
+	if q.Limits.Keys == 0 {
+		return nil
+	}
+
+	used, err := svc.keys.RetrieveAPIKeysCount(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return quota.Check(used, 1, q.Limits.Keys)
+}
+
+// checkGroupsQuota returns ErrQuotaExceeded if n more groups can't be
+// assigned to the org.
+func (svc service) checkGroupsQuota(ctx context.Context, orgID string, n int) error {
+	q, err := svc.subjectQuota(ctx, quota.Org, orgID, svc.quotaDefaults.Org)
+	if err != nil {
+		return err
+	}
+	if q.Limits.Groups == 0 {
+		return nil
+	}
+
+	ogp, err := svc.orgs.RetrieveGroups(ctx, orgID, PageMetadata{})
+	if err != nil {
+		return err
+	}
+
+	return quota.Check(ogp.Total, uint64(n), q.Limits.Groups)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+)
+
+const (
+	orgPrefix         = "org."
+	orgCreate         = orgPrefix + "create"
+	orgUpdate         = orgPrefix + "update"
+	orgRemove         = orgPrefix + "remove"
+	orgAssignMember   = orgPrefix + "assign_member"
+	orgUnassignMember = orgPrefix + "unassign_member"
+	orgUpdateMember   = orgPrefix + "update_member"
+	orgAssignGroup    = orgPrefix + "assign_group"
+	orgUnassignGroup  = orgPrefix + "unassign_group"
+
+	orgRolePrefix = "org_role."
+	orgRoleCreate = orgRolePrefix + "create"
+	orgRoleUpdate = orgRolePrefix + "update"
+	orgRoleRemove = orgRolePrefix + "remove"
+
+	policyPrefix = "policy."
+	policyCreate = policyPrefix + "create"
+	policyUpdate = policyPrefix + "update"
+	policyRemove = policyPrefix + "remove"
+
+	keyPrefix = "key."
+	keyIssue  = keyPrefix + "issue"
+	keyRevoke = keyPrefix + "revoke"
+)
+
+type event interface {
+	Encode() map[string]interface{}
+}
+
+var (
+	_ event = (*createOrgEvent)(nil)
+	_ event = (*updateOrgEvent)(nil)
+	_ event = (*removeOrgEvent)(nil)
+	_ event = (*orgMemberEvent)(nil)
+	_ event = (*orgGroupEvent)(nil)
+	_ event = (*orgRoleEvent)(nil)
+	_ event = (*groupPolicyEvent)(nil)
+	_ event = (*issueKeyEvent)(nil)
+	_ event = (*revokeKeyEvent)(nil)
+)
+
+type createOrgEvent struct {
+	org auth.Org
+}
+
+func (coe createOrgEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        coe.org.ID,
+		"org_id":    coe.org.ID,
+		"owner":     coe.org.OwnerID,
+		"name":      coe.org.Name,
+		"operation": orgCreate,
+	}
+
+	if coe.org.Description != "" {
+		val["description"] = coe.org.Description
+	}
+
+	encodeJSON(val, "metadata", coe.org.Metadata)
+
+	return val
+}
+
+type updateOrgEvent struct {
+	org    auth.Org
+	before map[string]interface{}
+}
+
+func (uoe updateOrgEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":          uoe.org.ID,
+		"org_id":      uoe.org.ID,
+		"name":        uoe.org.Name,
+		"description": uoe.org.Description,
+		"operation":   orgUpdate,
+	}
+
+	encodeJSON(val, "metadata", uoe.org.Metadata)
+	encodeJSON(val, "before", uoe.before)
+
+	return val
+}
+
+type removeOrgEvent struct {
+	id string
+}
+
+func (roe removeOrgEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        roe.id,
+		"org_id":    roe.id,
+		"operation": orgRemove,
+	}
+}
+
+type orgMemberEvent struct {
+	orgID     string
+	memberID  string
+	role      string
+	before    map[string]interface{}
+	operation string
+}
+
+func (ome orgMemberEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        ome.orgID,
+		"org_id":    ome.orgID,
+		"member_id": ome.memberID,
+		"operation": ome.operation,
+	}
+
+	if ome.role != "" {
+		val["role"] = ome.role
+	}
+
+	encodeJSON(val, "before", ome.before)
+
+	return val
+}
+
+type orgGroupEvent struct {
+	orgID     string
+	groupID   string
+	operation string
+}
+
+func (oge orgGroupEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        oge.orgID,
+		"org_id":    oge.orgID,
+		"group_id":  oge.groupID,
+		"operation": oge.operation,
+	}
+}
+
+type orgRoleEvent struct {
+	role      auth.OrgRole
+	before    map[string]interface{}
+	operation string
+}
+
+func (ore orgRoleEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        ore.role.OrgID,
+		"org_id":    ore.role.OrgID,
+		"name":      ore.role.Name,
+		"operation": ore.operation,
+	}
+
+	if ore.operation != orgRoleRemove {
+		val["description"] = ore.role.Description
+		val["permissions"] = strings.Join(ore.role.Permissions, ",")
+	}
+
+	encodeJSON(val, "before", ore.before)
+
+	return val
+}
+
+type groupPolicyEvent struct {
+	groupID   string
+	memberID  string
+	policy    string
+	operation string
+}
+
+func (gpe groupPolicyEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        gpe.groupID,
+		"member_id": gpe.memberID,
+		"operation": gpe.operation,
+	}
+
+	if gpe.policy != "" {
+		val["policy"] = gpe.policy
+	}
+
+	return val
+}
+
+type issueKeyEvent struct {
+	key auth.Key
+}
+
+func (ike issueKeyEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        ike.key.ID,
+		"owner":     ike.key.IssuerID,
+		"operation": keyIssue,
+	}
+
+	if !ike.key.ExpiresAt.IsZero() {
+		val["expires_at"] = ike.key.ExpiresAt.Format(time.RFC3339)
+	}
+
+	if len(ike.key.Scopes) > 0 {
+		val["scopes"] = strings.Join(ike.key.Scopes, ",")
+	}
+
+	return val
+}
+
+type revokeKeyEvent struct {
+	id string
+}
+
+func (rke revokeKeyEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        rke.id,
+		"operation": keyRevoke,
+	}
+}
+
+func encodeJSON(val map[string]interface{}, key string, m map[string]interface{}) {
+	if m == nil {
+		return
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return
+	}
+
+	val[key] = string(data)
+}
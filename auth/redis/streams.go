@@ -0,0 +1,427 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	streamID  = "mainflux.auth"
+	streamLen = 1000
+)
+
+var _ auth.Service = (*eventStore)(nil)
+
+type eventStore struct {
+	svc    auth.Service
+	client *redis.Client
+}
+
+// NewEventStoreMiddleware returns wrapper around auth service that sends
+// events to event store.
+func NewEventStoreMiddleware(svc auth.Service, client *redis.Client) auth.Service {
+	return eventStore{
+		svc:    svc,
+		client: client,
+	}
+}
+
+func (es eventStore) Issue(ctx context.Context, token string, key auth.Key) (auth.Key, string, error) {
+	k, secret, err := es.svc.Issue(ctx, token, key)
+	if err != nil {
+		return k, secret, err
+	}
+
+	// Login and recovery keys are issued far too often to be recorded.
+	if k.Type == auth.APIKey {
+		es.add(ctx, k.IssuerID, issueKeyEvent{key: k})
+	}
+
+	return k, secret, nil
+}
+
+func (es eventStore) Revoke(ctx context.Context, token, id string) error {
+	if err := es.svc.Revoke(ctx, token, id); err != nil {
+		return err
+	}
+
+	es.add(ctx, es.actor(ctx, token), revokeKeyEvent{id: id})
+
+	return nil
+}
+
+func (es eventStore) RetrieveKey(ctx context.Context, token, id string) (auth.Key, error) {
+	return es.svc.RetrieveKey(ctx, token, id)
+}
+
+func (es eventStore) Identify(ctx context.Context, token string) (auth.Identity, error) {
+	return es.svc.Identify(ctx, token)
+}
+
+func (es eventStore) Authorize(ctx context.Context, ar auth.AuthzReq) error {
+	return es.svc.Authorize(ctx, ar)
+}
+
+func (es eventStore) AddPolicy(ctx context.Context, token, groupID, policy string) error {
+	return es.svc.AddPolicy(ctx, token, groupID, policy)
+}
+
+func (es eventStore) AssignRole(ctx context.Context, id, role string) error {
+	return es.svc.AssignRole(ctx, id, role)
+}
+
+func (es eventStore) RetrieveRole(ctx context.Context, id string) (string, error) {
+	return es.svc.RetrieveRole(ctx, id)
+}
+
+func (es eventStore) CreateOrg(ctx context.Context, token string, org auth.Org) (auth.Org, error) {
+	o, err := es.svc.CreateOrg(ctx, token, org)
+	if err != nil {
+		return o, err
+	}
+
+	es.add(ctx, o.OwnerID, createOrgEvent{org: o})
+
+	return o, nil
+}
+
+func (es eventStore) UpdateOrg(ctx context.Context, token string, org auth.Org) (auth.Org, error) {
+	before, err := es.svc.ViewOrg(ctx, token, org.ID)
+	if err != nil {
+		return auth.Org{}, err
+	}
+
+	o, err := es.svc.UpdateOrg(ctx, token, org)
+	if err != nil {
+		return o, err
+	}
+
+	ev := updateOrgEvent{
+		org: org,
+		before: map[string]interface{}{
+			"name":        before.Name,
+			"description": before.Description,
+			"metadata":    before.Metadata,
+		},
+	}
+	es.add(ctx, es.actor(ctx, token), ev)
+
+	return o, nil
+}
+
+func (es eventStore) ViewOrg(ctx context.Context, token, id string) (auth.Org, error) {
+	return es.svc.ViewOrg(ctx, token, id)
+}
+
+func (es eventStore) ListOrgs(ctx context.Context, token string, admin bool, pm auth.PageMetadata) (auth.OrgsPage, error) {
+	return es.svc.ListOrgs(ctx, token, admin, pm)
+}
+
+func (es eventStore) ListOrgMemberships(ctx context.Context, token, memberID string, pm auth.PageMetadata) (auth.OrgsPage, error) {
+	return es.svc.ListOrgMemberships(ctx, token, memberID, pm)
+}
+
+func (es eventStore) RemoveOrg(ctx context.Context, token, id string) error {
+	if err := es.svc.RemoveOrg(ctx, token, id); err != nil {
+		return err
+	}
+
+	es.add(ctx, es.actor(ctx, token), removeOrgEvent{id: id})
+
+	return nil
+}
+
+func (es eventStore) AssignMembers(ctx context.Context, token, orgID string, oms ...auth.OrgMember) error {
+	if err := es.svc.AssignMembers(ctx, token, orgID, oms...); err != nil {
+		return err
+	}
+
+	actor := es.actor(ctx, token)
+	for _, om := range oms {
+		ev := orgMemberEvent{
+			orgID:     orgID,
+			memberID:  om.MemberID,
+			role:      om.Role,
+			operation: orgAssignMember,
+		}
+		es.add(ctx, actor, ev)
+	}
+
+	return nil
+}
+
+func (es eventStore) UnassignMembers(ctx context.Context, token string, orgID string, memberIDs ...string) error {
+	if err := es.svc.UnassignMembers(ctx, token, orgID, memberIDs...); err != nil {
+		return err
+	}
+
+	actor := es.actor(ctx, token)
+	for _, id := range memberIDs {
+		ev := orgMemberEvent{
+			orgID:     orgID,
+			memberID:  id,
+			operation: orgUnassignMember,
+		}
+		es.add(ctx, actor, ev)
+	}
+
+	return nil
+}
+
+func (es eventStore) UpdateMembers(ctx context.Context, token, orgID string, oms ...auth.OrgMember) error {
+	roles := make(map[string]string, len(oms))
+	for _, om := range oms {
+		if m, err := es.svc.ViewMember(ctx, token, orgID, om.MemberID); err == nil {
+			roles[om.MemberID] = m.Role
+		}
+	}
+
+	if err := es.svc.UpdateMembers(ctx, token, orgID, oms...); err != nil {
+		return err
+	}
+
+	actor := es.actor(ctx, token)
+	for _, om := range oms {
+		ev := orgMemberEvent{
+			orgID:     orgID,
+			memberID:  om.MemberID,
+			role:      om.Role,
+			operation: orgUpdateMember,
+		}
+		if role, ok := roles[om.MemberID]; ok {
+			ev.before = map[string]interface{}{"role": role}
+		}
+		es.add(ctx, actor, ev)
+	}
+
+	return nil
+}
+
+func (es eventStore) ListOrgMembers(ctx context.Context, token, orgID string, pm auth.PageMetadata) (auth.OrgMembersPage, error) {
+	return es.svc.ListOrgMembers(ctx, token, orgID, pm)
+}
+
+func (es eventStore) ViewMember(ctx context.Context, token, orgID, memberID string) (auth.OrgMember, error) {
+	return es.svc.ViewMember(ctx, token, orgID, memberID)
+}
+
+func (es eventStore) AssignGroups(ctx context.Context, token, orgID string, groupIDs ...string) error {
+	if err := es.svc.AssignGroups(ctx, token, orgID, groupIDs...); err != nil {
+		return err
+	}
+
+	actor := es.actor(ctx, token)
+	for _, id := range groupIDs {
+		es.add(ctx, actor, orgGroupEvent{orgID: orgID, groupID: id, operation: orgAssignGroup})
+	}
+
+	return nil
+}
+
+func (es eventStore) UnassignGroups(ctx context.Context, token, orgID string, groupIDs ...string) error {
+	if err := es.svc.UnassignGroups(ctx, token, orgID, groupIDs...); err != nil {
+		return err
+	}
+
+	actor := es.actor(ctx, token)
+	for _, id := range groupIDs {
+		es.add(ctx, actor, orgGroupEvent{orgID: orgID, groupID: id, operation: orgUnassignGroup})
+	}
+
+	return nil
+}
+
+func (es eventStore) ViewGroupMembership(ctx context.Context, token, groupID string) (auth.Org, error) {
+	return es.svc.ViewGroupMembership(ctx, token, groupID)
+}
+
+func (es eventStore) ListOrgGroups(ctx context.Context, token, orgID string, pm auth.PageMetadata) (auth.GroupsPage, error) {
+	return es.svc.ListOrgGroups(ctx, token, orgID, pm)
+}
+
+func (es eventStore) CreateOrgRole(ctx context.Context, token, orgID string, role auth.OrgRole) (auth.OrgRole, error) {
+	r, err := es.svc.CreateOrgRole(ctx, token, orgID, role)
+	if err != nil {
+		return r, err
+	}
+
+	es.add(ctx, es.actor(ctx, token), orgRoleEvent{role: r, operation: orgRoleCreate})
+
+	return r, nil
+}
+
+func (es eventStore) UpdateOrgRole(ctx context.Context, token, orgID string, role auth.OrgRole) (auth.OrgRole, error) {
+	before, err := es.svc.ViewOrgRole(ctx, token, orgID, role.Name)
+	if err != nil {
+		return auth.OrgRole{}, err
+	}
+
+	r, err := es.svc.UpdateOrgRole(ctx, token, orgID, role)
+	if err != nil {
+		return r, err
+	}
+
+	ev := orgRoleEvent{
+		role: r,
+		before: map[string]interface{}{
+			"description": before.Description,
+			"permissions": before.Permissions,
+		},
+		operation: orgRoleUpdate,
+	}
+	es.add(ctx, es.actor(ctx, token), ev)
+
+	return r, nil
+}
+
+func (es eventStore) ViewOrgRole(ctx context.Context, token, orgID, name string) (auth.OrgRole, error) {
+	return es.svc.ViewOrgRole(ctx, token, orgID, name)
+}
+
+func (es eventStore) ListOrgRoles(ctx context.Context, token, orgID string, pm auth.PageMetadata) (auth.OrgRolesPage, error) {
+	return es.svc.ListOrgRoles(ctx, token, orgID, pm)
+}
+
+func (es eventStore) RemoveOrgRole(ctx context.Context, token, orgID, name string) error {
+	if err := es.svc.RemoveOrgRole(ctx, token, orgID, name); err != nil {
+		return err
+	}
+
+	role := auth.OrgRole{OrgID: orgID, Name: name}
+	es.add(ctx, es.actor(ctx, token), orgRoleEvent{role: role, operation: orgRoleRemove})
+
+	return nil
+}
+
+func (es eventStore) Backup(ctx context.Context, token string) (auth.Backup, error) {
+	return es.svc.Backup(ctx, token)
+}
+
+func (es eventStore) Restore(ctx context.Context, token string, backup auth.Backup) error {
+	return es.svc.Restore(ctx, token, backup)
+}
+
+func (es eventStore) ExportBackup(ctx context.Context, token string, f auth.BackupFilter) (backup.Stream, error) {
+	return es.svc.ExportBackup(ctx, token, f)
+}
+
+func (es eventStore) ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (backup.Summary, error) {
+	return es.svc.ImportBackup(ctx, token, dec, strategy)
+}
+
+func (es eventStore) CreateGroupPolicies(ctx context.Context, token, groupID string, gps ...auth.GroupPolicyByID) error {
+	if err := es.svc.CreateGroupPolicies(ctx, token, groupID, gps...); err != nil {
+		return err
+	}
+
+	es.addPolicies(ctx, token, groupID, policyCreate, gps...)
+
+	return nil
+}
+
+func (es eventStore) ListGroupPolicies(ctx context.Context, token, groupID string, pm auth.PageMetadata) (auth.GroupPoliciesPage, error) {
+	return es.svc.ListGroupPolicies(ctx, token, groupID, pm)
+}
+
+func (es eventStore) UpdateGroupPolicies(ctx context.Context, token, groupID string, gps ...auth.GroupPolicyByID) error {
+	if err := es.svc.UpdateGroupPolicies(ctx, token, groupID, gps...); err != nil {
+		return err
+	}
+
+	es.addPolicies(ctx, token, groupID, policyUpdate, gps...)
+
+	return nil
+}
+
+func (es eventStore) RemoveGroupPolicies(ctx context.Context, token, groupID string, memberIDs ...string) error {
+	if err := es.svc.RemoveGroupPolicies(ctx, token, groupID, memberIDs...); err != nil {
+		return err
+	}
+
+	var gps []auth.GroupPolicyByID
+	for _, id := range memberIDs {
+		gps = append(gps, auth.GroupPolicyByID{MemberID: id})
+	}
+	es.addPolicies(ctx, token, groupID, policyRemove, gps...)
+
+	return nil
+}
+
+func (es eventStore) CreateTagPolicies(ctx context.Context, token, orgID, tag string, gps ...auth.GroupPolicyByID) ([]string, error) {
+	groupIDs, err := es.svc.CreateTagPolicies(ctx, token, orgID, tag, gps...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, groupID := range groupIDs {
+		es.addPolicies(ctx, token, groupID, policyUpdate, gps...)
+	}
+
+	return groupIDs, nil
+}
+
+func (es eventStore) addPolicies(ctx context.Context, token, groupID, operation string, gps ...auth.GroupPolicyByID) {
+	actor := es.actor(ctx, token)
+	for _, gp := range gps {
+		ev := groupPolicyEvent{
+			groupID:   groupID,
+			memberID:  gp.MemberID,
+			policy:    gp.Policy,
+			operation: operation,
+		}
+		es.add(ctx, actor, ev)
+	}
+}
+
+func (es eventStore) actor(ctx context.Context, token string) string {
+	id, err := es.svc.Identify(ctx, token)
+	if err != nil {
+		return ""
+	}
+
+	return id.ID
+}
+
+func (es eventStore) add(ctx context.Context, actor string, ev event) {
+	values := ev.Encode()
+	if actor != "" {
+		values["actor"] = actor
+	}
+	if ip := auth.ClientIP(ctx); ip != "" {
+		values["ip"] = ip
+	}
+
+	record := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       values,
+	}
+	es.client.XAdd(ctx, record).Err()
+}
+
+func (es eventStore) UpdateQuota(ctx context.Context, token string, q auth.Quota) error {
+	return es.svc.UpdateQuota(ctx, token, q)
+}
+
+func (es eventStore) RemoveQuota(ctx context.Context, token, subject, id string) error {
+	return es.svc.RemoveQuota(ctx, token, subject, id)
+}
+
+func (es eventStore) ViewQuota(ctx context.Context, token, subject, id string) (auth.Quota, error) {
+	return es.svc.ViewQuota(ctx, token, subject, id)
+}
+
+func (es eventStore) RetrieveQuota(ctx context.Context, subject, id string) (auth.Quota, error) {
+	return es.svc.RetrieveQuota(ctx, subject, id)
+}
+
+func (es eventStore) ViewUsage(ctx context.Context, token, orgID string) (map[string]quota.Usage, error) {
+	return es.svc.ViewUsage(ctx, token, orgID)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/auth/jwt"
+	"github.com/MainfluxLabs/mainflux/auth/mocks"
+	"github.com/MainfluxLabs/mainflux/auth/redis"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	thmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/users"
+	r "github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	streamID   = "mainflux.auth"
+	secret     = "secret"
+	ownerID    = "ownerID"
+	ownerEmail = "owner@test.com"
+	orgCreate  = "org.create"
+	orgUpdate  = "org.update"
+	orgRemove  = "org.remove"
+)
+
+var (
+	org           = auth.Org{Name: "name", Description: "description"}
+	usersByEmails = map[string]users.User{ownerEmail: {ID: ownerID, Email: ownerEmail}}
+	usersByIDs    = map[string]users.User{ownerID: {ID: ownerID, Email: ownerEmail}}
+)
+
+func newService() auth.Service {
+	uc := mocks.NewUsersService(usersByIDs, usersByEmails)
+	tc := thmocks.NewThingsServiceClient(nil, nil)
+	svc := auth.New(mocks.NewOrgRepository(), mocks.NewOrgRolesRepository(), tc, uc, mocks.NewKeyRepository(), mocks.NewRolesRepository(), mocks.NewPoliciesRepository(), mocks.NewQuotasRepository(), auth.QuotaDefaults{}, uuid.NewMock(), jwt.New(secret), time.Minute)
+	return redis.NewEventStoreMiddleware(svc, redisClient)
+}
+
+func readEvent(lastID string) (map[string]interface{}, string) {
+	streams := redisClient.XRead(context.Background(), &r.XReadArgs{
+		Streams: []string{streamID, lastID},
+		Count:   1,
+		Block:   time.Second,
+	}).Val()
+
+	if len(streams) == 0 || len(streams[0].Messages) == 0 {
+		return nil, lastID
+	}
+
+	msg := streams[0].Messages[0]
+	return msg.Values, msg.ID
+}
+
+func issueToken(t *testing.T, svc auth.Service) string {
+	_, token, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("issuing login key expected to succeed: %s", err))
+	return token
+}
+
+func TestOrgEvents(t *testing.T) {
+	redisClient.FlushAll(context.Background()).Err()
+	svc := newService()
+	token := issueToken(t, svc)
+
+	_, lastID := readEvent("0")
+	assert.Equal(t, "0", lastID, "issuing login key expected to produce no event")
+
+	created, err := svc.CreateOrg(context.Background(), token, org)
+	require.Nil(t, err, fmt.Sprintf("creating org expected to succeed: %s", err))
+
+	updated := created
+	updated.Name = "new-name"
+
+	cases := []struct {
+		desc  string
+		op    func() error
+		err   error
+		event map[string]interface{}
+	}{
+		{
+			desc: "update org",
+			op: func() error {
+				_, err := svc.UpdateOrg(context.Background(), token, updated)
+				return err
+			},
+			err: nil,
+			event: map[string]interface{}{
+				"id":          created.ID,
+				"org_id":      created.ID,
+				"name":        updated.Name,
+				"description": org.Description,
+				"before":      "{\"description\":\"description\",\"metadata\":null,\"name\":\"name\"}",
+				"actor":       ownerID,
+				"operation":   orgUpdate,
+			},
+		},
+		{
+			desc: "update org with invalid token",
+			op: func() error {
+				_, err := svc.UpdateOrg(context.Background(), "invalid", updated)
+				return err
+			},
+			err:   errors.ErrAuthentication,
+			event: nil,
+		},
+		{
+			desc: "remove org",
+			op: func() error {
+				return svc.RemoveOrg(context.Background(), token, created.ID)
+			},
+			err: nil,
+			event: map[string]interface{}{
+				"id":        created.ID,
+				"org_id":    created.ID,
+				"actor":     ownerID,
+				"operation": orgRemove,
+			},
+		},
+	}
+
+	event, lastID := readEvent(lastID)
+	assert.Equal(t, orgCreate, event["operation"], fmt.Sprintf("create org: expected operation %s got %v\n", orgCreate, event["operation"]))
+	assert.Equal(t, ownerID, event["actor"], fmt.Sprintf("create org: expected actor %s got %v\n", ownerID, event["actor"]))
+	assert.Equal(t, created.ID, event["org_id"], fmt.Sprintf("create org: expected org %s got %v\n", created.ID, event["org_id"]))
+
+	for _, tc := range cases {
+		err := tc.op()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		event, lastID = readEvent(lastID)
+		assert.Equal(t, tc.event, event, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.event, event))
+	}
+}
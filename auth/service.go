@@ -19,6 +19,7 @@ const (
 	EditorRole       = "editor"
 	RootSubject      = "root"
 	GroupSubject     = "group"
+	OrgSubject       = "org"
 	ReadAction       = "read"
 	WriteAction      = "read_write"
 	RPolicy          = "read"
@@ -94,33 +95,41 @@ type Service interface {
 	Authz
 	Roles
 	Orgs
+	OrgRoles
 	Policies
+	Quotas
 }
 
 var _ Service = (*service)(nil)
 
 type service struct {
 	orgs          OrgRepository
+	orgRoles      OrgRolesRepository
 	users         mainflux.UsersServiceClient
 	things        mainflux.ThingsServiceClient
 	keys          KeyRepository
 	roles         RolesRepository
 	policies      PoliciesRepository
+	quotas        QuotasRepository
+	quotaDefaults QuotaDefaults
 	idProvider    mainflux.IDProvider
 	tokenizer     Tokenizer
 	loginDuration time.Duration
 }
 
 // New instantiates the auth service implementation.
-func New(orgs OrgRepository, tc mainflux.ThingsServiceClient, uc mainflux.UsersServiceClient, keys KeyRepository, roles RolesRepository, policies PoliciesRepository, idp mainflux.IDProvider, tokenizer Tokenizer, duration time.Duration) Service {
+func New(orgs OrgRepository, orgRoles OrgRolesRepository, tc mainflux.ThingsServiceClient, uc mainflux.UsersServiceClient, keys KeyRepository, roles RolesRepository, policies PoliciesRepository, quotas QuotasRepository, qd QuotaDefaults, idp mainflux.IDProvider, tokenizer Tokenizer, duration time.Duration) Service {
 	return &service{
 		tokenizer:     tokenizer,
 		things:        tc,
 		orgs:          orgs,
+		orgRoles:      orgRoles,
 		users:         uc,
 		keys:          keys,
 		roles:         roles,
 		policies:      policies,
+		quotas:        quotas,
+		quotaDefaults: qd,
 		idProvider:    idp,
 		loginDuration: duration,
 	}
@@ -132,6 +141,9 @@ func (svc service) Issue(ctx context.Context, token string, key Key) (Key, strin
 	}
 	switch key.Type {
 	case APIKey:
+		if err := key.Validate(); err != nil {
+			return Key{}, "", err
+		}
 		return svc.userKey(ctx, token, key)
 	case RecoveryKey:
 		return svc.tmpKey(recoveryDuration, key)
@@ -170,6 +182,8 @@ func (svc service) Authorize(ctx context.Context, ar AuthzReq) error {
 		return svc.isAdmin(ctx, ar.Token)
 	case GroupSubject:
 		return svc.canAccessGroup(ctx, ar.Token, ar.Object, ar.Action)
+	case OrgSubject:
+		return svc.orgRolesAuth(ctx, ar.Token, ar.Object, ar.Action)
 	default:
 		return errUnknownSubject
 	}
@@ -196,6 +210,10 @@ func (svc service) userKey(ctx context.Context, token string, key Key) (Key, str
 		key.Subject = sub
 	}
 
+	if err := svc.checkKeysQuota(ctx, id); err != nil {
+		return Key{}, "", err
+	}
+
 	keyID, err := svc.idProvider.ID()
 	if err != nil {
 		return Key{}, "", errors.Wrap(errIssueUser, err)
@@ -307,7 +325,7 @@ func (svc service) UpdateOrg(ctx context.Context, token string, o Org) (Org, err
 		return Org{}, err
 	}
 
-	if err := svc.orgRolesAuth(ctx, token, o.ID, AdminRole); err != nil {
+	if err := svc.orgRolesAuth(ctx, token, o.ID, ManageOrgPermission); err != nil {
 		return Org{}, err
 	}
 
@@ -328,7 +346,7 @@ func (svc service) UpdateOrg(ctx context.Context, token string, o Org) (Org, err
 }
 
 func (svc service) ViewOrg(ctx context.Context, token, id string) (Org, error) {
-	if err := svc.orgRolesAuth(ctx, token, id, ViewerRole); err != nil {
+	if err := svc.orgRolesAuth(ctx, token, id, ViewOrgPermission); err != nil {
 		return Org{}, err
 	}
 
@@ -341,7 +359,11 @@ func (svc service) ViewOrg(ctx context.Context, token, id string) (Org, error) {
 }
 
 func (svc service) AssignMembers(ctx context.Context, token, orgID string, oms ...OrgMember) error {
-	if err := svc.orgRolesAuth(ctx, token, orgID, AdminRole); err != nil {
+	if err := svc.orgRolesAuth(ctx, token, orgID, ManageMembersPermission); err != nil {
+		return err
+	}
+
+	if err := svc.canGrantRoles(ctx, token, orgID, oms...); err != nil {
 		return err
 	}
 
@@ -403,7 +425,7 @@ func (svc service) UnassignMembers(ctx context.Context, token string, orgID stri
 }
 
 func (svc service) ViewMember(ctx context.Context, token, orgID, memberID string) (OrgMember, error) {
-	if err := svc.orgRolesAuth(ctx, token, orgID, ViewerRole); err != nil {
+	if err := svc.orgRolesAuth(ctx, token, orgID, ViewOrgPermission); err != nil {
 		return OrgMember{}, err
 	}
 
@@ -428,7 +450,11 @@ func (svc service) ViewMember(ctx context.Context, token, orgID, memberID string
 }
 
 func (svc service) UpdateMembers(ctx context.Context, token, orgID string, members ...OrgMember) error {
-	if err := svc.orgRolesAuth(ctx, token, orgID, AdminRole); err != nil {
+	if err := svc.orgRolesAuth(ctx, token, orgID, ManageMembersPermission); err != nil {
+		return err
+	}
+
+	if err := svc.canGrantRoles(ctx, token, orgID, members...); err != nil {
 		return err
 	}
 
@@ -474,7 +500,7 @@ func (svc service) UpdateMembers(ctx context.Context, token, orgID string, membe
 }
 
 func (svc service) ListOrgMembers(ctx context.Context, token string, orgID string, pm PageMetadata) (OrgMembersPage, error) {
-	if err := svc.orgRolesAuth(ctx, token, orgID, ViewerRole); err != nil {
+	if err := svc.orgRolesAuth(ctx, token, orgID, ViewOrgPermission); err != nil {
 		return OrgMembersPage{}, err
 	}
 
@@ -526,7 +552,11 @@ func (svc service) AssignGroups(ctx context.Context, token, orgID string, groupI
 		return err
 	}
 
-	if err := svc.orgRolesAuth(ctx, token, orgID, EditorRole); err != nil {
+	if err := svc.orgRolesAuth(ctx, token, orgID, AssignGroupsPermission); err != nil {
+		return err
+	}
+
+	if err := svc.checkGroupsQuota(ctx, orgID, len(groupIDs)); err != nil {
 		return err
 	}
 
@@ -562,7 +592,7 @@ func (svc service) AssignGroups(ctx context.Context, token, orgID string, groupI
 }
 
 func (svc service) UnassignGroups(ctx context.Context, token string, orgID string, groupIDs ...string) error {
-	if err := svc.orgRolesAuth(ctx, token, orgID, EditorRole); err != nil {
+	if err := svc.orgRolesAuth(ctx, token, orgID, AssignGroupsPermission); err != nil {
 		return err
 	}
 
@@ -574,7 +604,7 @@ func (svc service) UnassignGroups(ctx context.Context, token string, orgID strin
 }
 
 func (svc service) ListOrgGroups(ctx context.Context, token string, orgID string, pm PageMetadata) (GroupsPage, error) {
-	if err := svc.orgRolesAuth(ctx, token, orgID, ViewerRole); err != nil {
+	if err := svc.orgRolesAuth(ctx, token, orgID, ViewOrgPermission); err != nil {
 		return GroupsPage{}, err
 	}
 
@@ -602,6 +632,7 @@ func (svc service) ListOrgGroups(ctx context.Context, token string, orgID string
 				OwnerID:     g.OwnerID,
 				Name:        g.Name,
 				Description: g.Description,
+				Tags:        g.Tags,
 			}
 			groups = append(groups, gr)
 		}
@@ -750,6 +781,83 @@ func (svc service) RemoveGroupPolicies(ctx context.Context, token, groupID strin
 	return nil
 }
 
+func (svc service) CreateTagPolicies(ctx context.Context, token, orgID, tag string, gps ...GroupPolicyByID) ([]string, error) {
+	if err := svc.orgRolesAuth(ctx, token, orgID, ManageMembersPermission); err != nil {
+		return nil, err
+	}
+
+	org, err := svc.orgs.RetrieveByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, gp := range gps {
+		if gp.MemberID == org.OwnerID {
+			return nil, errors.ErrAuthorization
+		}
+	}
+
+	ogp, err := svc.orgs.RetrieveGroups(ctx, orgID, PageMetadata{})
+	if err != nil {
+		return nil, err
+	}
+
+	var groupIDs []string
+	for _, g := range ogp.OrgGroups {
+		groupIDs = append(groupIDs, g.GroupID)
+	}
+
+	if len(groupIDs) == 0 {
+		return []string{}, nil
+	}
+
+	res, err := svc.things.GetGroupsByIDs(ctx, &mainflux.GroupsReq{Ids: groupIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	tagged := []string{}
+	for _, g := range res.GetGroups() {
+		if !hasTag(g.GetTags(), tag) {
+			continue
+		}
+
+		for _, gp := range gps {
+			if err := svc.saveGroupPolicy(ctx, g.GetId(), gp); err != nil {
+				return nil, err
+			}
+		}
+		tagged = append(tagged, g.GetId())
+	}
+
+	return tagged, nil
+}
+
+// saveGroupPolicy creates the group policy of the member, or updates it
+// if the member already has one.
+func (svc service) saveGroupPolicy(ctx context.Context, groupID string, gp GroupPolicyByID) error {
+	policy, err := svc.policies.RetrieveGroupPolicy(ctx, GroupPolicy{GroupID: groupID, MemberID: gp.MemberID})
+	if err != nil && !errors.Contains(err, errors.ErrNotFound) {
+		return err
+	}
+
+	if policy == "" {
+		return svc.policies.SaveGroupPolicies(ctx, groupID, gp)
+	}
+
+	return svc.policies.UpdateGroupPolicies(ctx, groupID, gp)
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (svc service) ViewGroupMembership(ctx context.Context, token, groupID string) (Org, error) {
 	if err := svc.canAccessGroup(ctx, token, groupID, ReadAction); err != nil {
 		return Org{}, err
@@ -768,46 +876,123 @@ func (svc service) canAccessGroup(ctx context.Context, token, Object, action str
 		return err
 	}
 
-	gp := GroupPolicy{
-		MemberID: user.ID,
-		GroupID:  Object,
+	// Policies and org membership of an ancestor group apply to all of its descendants.
+	lineage, err := svc.groupLineage(ctx, Object)
+	if err != nil {
+		return err
 	}
 
-	policy, err := svc.policies.RetrieveGroupPolicy(ctx, gp)
+	if !hasAnyResource(user.GroupIDs, lineage) {
+		return errors.ErrAuthorization
+	}
+
+	var policy string
+	for _, groupID := range lineage {
+		gp := GroupPolicy{
+			MemberID: user.ID,
+			GroupID:  groupID,
+		}
+
+		policy, err = svc.policies.RetrieveGroupPolicy(ctx, gp)
+		if err != nil {
+			return err
+		}
+
+		if policy != "" {
+			break
+		}
+	}
+
+	org, err := svc.groupOrg(ctx, lineage)
 	if err != nil {
 		return err
 	}
 
-	org, err := svc.orgs.RetrieveByGroupID(ctx, Object)
+	if !HasResource(user.OrgIDs, org.ID) {
+		return errors.ErrAuthorization
+	}
+
+	role, err := svc.orgs.RetrieveRole(ctx, user.ID, org.ID)
 	if err != nil {
 		return err
 	}
 
-	role, err := svc.orgs.RetrieveRole(ctx, user.ID, org.ID)
+	if role == OwnerRole {
+		return nil
+	}
+
+	perms, err := svc.rolePermissions(ctx, org.ID, role)
 	if err != nil {
 		return err
 	}
 
-	if role == OwnerRole || role == AdminRole {
+	if contains(perms, AllGroupsPermission) {
 		return nil
 	}
 
-	if role == "" || policy == "" {
+	if policy == "" {
 		return errors.ErrAuthorization
 	}
 
+	var required string
 	switch action {
+	case ReadAction:
+		required = ReadGroupsPermission
 	case WriteAction:
-		if policy == RPolicy || role == ViewerRole {
-			return errors.ErrAuthorization
-		}
+		required = WriteGroupsPermission
 	default:
-		return nil
+		required = action
+	}
+
+	if !contains(perms, required) {
+		return errors.ErrAuthorization
+	}
+
+	if !readPermissions[required] && policy != RwPolicy {
+		return errors.ErrAuthorization
 	}
 
 	return nil
 }
 
+// groupLineage returns ID of the given group followed by IDs of its ancestors, nearest first.
+func (svc service) groupLineage(ctx context.Context, groupID string) ([]string, error) {
+	lineage := []string{groupID}
+
+	res, err := svc.things.GetGroupsByIDs(ctx, &mainflux.GroupsReq{Ids: []string{groupID}})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range res.GetGroups() {
+		path := g.GetPath()
+		for i := len(path) - 2; i >= 0; i-- {
+			lineage = append(lineage, path[i])
+		}
+	}
+
+	return lineage, nil
+}
+
+// groupOrg returns the org that the nearest group of the lineage is assigned to.
+func (svc service) groupOrg(ctx context.Context, lineage []string) (Org, error) {
+	for _, groupID := range lineage {
+		org, err := svc.orgs.RetrieveByGroupID(ctx, groupID)
+		if err != nil {
+			if errors.Contains(err, errors.ErrNotFound) {
+				continue
+			}
+			return Org{}, err
+		}
+
+		if org.ID != "" {
+			return org, nil
+		}
+	}
+
+	return Org{}, errors.ErrNotFound
+}
+
 func (svc service) AddPolicy(ctx context.Context, token, groupID, policy string) error {
 	user, err := svc.Identify(ctx, token)
 	if err != nil {
@@ -831,34 +1016,7 @@ func (svc service) Backup(ctx context.Context, token string) (Backup, error) {
 		return Backup{}, err
 	}
 
-	orgs, err := svc.orgs.RetrieveAll(ctx)
-	if err != nil {
-		return Backup{}, err
-	}
-
-	mrs, err := svc.orgs.RetrieveAllOrgMembers(ctx)
-	if err != nil {
-		return Backup{}, err
-	}
-
-	ogs, err := svc.orgs.RetrieveAllOrgGroups(ctx)
-	if err != nil {
-		return Backup{}, err
-	}
-
-	gps, err := svc.policies.RetrieveAllGroupPolicies(ctx)
-	if err != nil {
-		return Backup{}, err
-	}
-
-	backup := Backup{
-		Orgs:          orgs,
-		OrgMembers:    mrs,
-		OrgGroups:     ogs,
-		GroupPolicies: gps,
-	}
-
-	return backup, nil
+	return svc.retrieveAll(ctx)
 }
 
 func (svc service) Restore(ctx context.Context, token string, backup Backup) error {
@@ -870,6 +1028,10 @@ func (svc service) Restore(ctx context.Context, token string, backup Backup) err
 		return err
 	}
 
+	if err := svc.orgRoles.Save(ctx, backup.OrgRoles...); err != nil {
+		return err
+	}
+
 	if err := svc.orgs.AssignMembers(ctx, backup.OrgMembers...); err != nil {
 		return err
 	}
@@ -906,6 +1068,11 @@ func (svc service) isAdmin(ctx context.Context, token string) error {
 		return err
 	}
 
+	// Scoped API keys never carry the admin authority of their issuer.
+	if user.Scoped() {
+		return errors.ErrAuthorization
+	}
+
 	role, err := svc.roles.RetrieveRole(ctx, user.ID)
 	if err != nil {
 		return err
@@ -928,33 +1095,42 @@ func (svc service) orgRolesAuth(ctx context.Context, token, orgID string, action
 		return nil
 	}
 
+	scope := OrgsWriteScope
+	if action == ViewerRole || readPermissions[action] {
+		scope = OrgsReadScope
+	}
+
+	if !HasScope(user.Scopes, scope) || !HasResource(user.OrgIDs, orgID) {
+		return errors.ErrAuthorization
+	}
+
 	role, err := svc.orgs.RetrieveRole(ctx, user.ID, orgID)
 	if err != nil {
 		return err
 	}
 
-	switch role {
-	case OwnerRole:
+	if role == OwnerRole {
 		return nil
-	case AdminRole:
-		if action == ViewerRole || action == EditorRole || action == AdminRole {
-			return nil
-		}
-	case EditorRole:
-		if action == ViewerRole || action == EditorRole {
-			return nil
-		}
-	case ViewerRole:
-		if action == ViewerRole {
-			return nil
-		}
 	}
 
-	return errors.ErrAuthorization
+	if action == OwnerRole {
+		return errors.ErrAuthorization
+	}
+
+	perms, err := svc.rolePermissions(ctx, orgID, role)
+	if err != nil {
+		return err
+	}
+
+	if !containsAll(perms, requiredPermissions(action)) {
+		return errors.ErrAuthorization
+	}
+
+	return nil
 }
 
 func (svc service) canAssignMembers(ctx context.Context, token, orgID string, memberIDs ...string) error {
-	if err := svc.orgRolesAuth(ctx, token, orgID, AdminRole); err != nil {
+	if err := svc.orgRolesAuth(ctx, token, orgID, ManageMembersPermission); err != nil {
 		return err
 	}
 
@@ -986,11 +1162,29 @@ func (svc service) identify(ctx context.Context, token string) (Identity, error)
 	case RecoveryKey, LoginKey:
 		return Identity{ID: key.IssuerID, Email: key.Subject}, nil
 	case APIKey:
-		_, err := svc.keys.Retrieve(context.TODO(), key.IssuerID, key.ID)
+		k, err := svc.keys.Retrieve(ctx, key.IssuerID, key.ID)
 		if err != nil {
 			return Identity{}, errors.ErrAuthentication
 		}
-		return Identity{ID: key.IssuerID, Email: key.Subject}, nil
+
+		if !k.AllowsIP(ClientIP(ctx)) {
+			return Identity{}, errors.Wrap(errors.ErrAuthentication, ErrIPNotAllowed)
+		}
+
+		if err := svc.keys.UpdateLastUsed(ctx, k.IssuerID, k.ID, getTimestmap()); err != nil {
+			return Identity{}, errors.Wrap(errIdentify, err)
+		}
+
+		identity := Identity{
+			ID:         key.IssuerID,
+			Email:      key.Subject,
+			Scopes:     k.Scopes,
+			OrgIDs:     k.OrgIDs,
+			GroupIDs:   k.GroupIDs,
+			ChannelIDs: k.ChannelIDs,
+		}
+
+		return identity, nil
 	default:
 		return Identity{}, errors.ErrAuthentication
 	}
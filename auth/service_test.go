@@ -4,16 +4,21 @@
 package auth_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/auth/jwt"
 	"github.com/MainfluxLabs/mainflux/auth/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	thmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/MainfluxLabs/mainflux/users"
@@ -38,6 +43,7 @@ const (
 	description     = "description"
 	name            = "name"
 	invalid         = "invalid"
+	evenTag         = "even"
 	n               = 10
 
 	loginDuration = 30 * time.Minute
@@ -52,6 +58,10 @@ var (
 )
 
 func newService() auth.Service {
+	return newServiceWithQuotas(auth.QuotaDefaults{})
+}
+
+func newServiceWithQuotas(qd auth.QuotaDefaults) auth.Service {
 	keyRepo := mocks.NewKeyRepository()
 	idMockProvider := uuid.NewMock()
 	orgRepo := mocks.NewOrgRepository()
@@ -60,19 +70,30 @@ func newService() auth.Service {
 	uc := mocks.NewUsersService(usersByIDs, usersByEmails)
 	tc := thmocks.NewThingsServiceClient(nil, createGroups())
 	t := jwt.New(secret)
-	return auth.New(orgRepo, tc, uc, keyRepo, roleRepo, policiesRepo, idMockProvider, t, loginDuration)
+	return auth.New(orgRepo, mocks.NewOrgRolesRepository(), tc, uc, keyRepo, roleRepo, policiesRepo, mocks.NewQuotasRepository(), qd, idMockProvider, t, loginDuration)
 }
 
+// createGroups creates n groups, where each group is a child of the previous one.
 func createGroups() map[string]things.Group {
 	groups := make(map[string]things.Group, n)
 	for i := 0; i < n; i++ {
 		groupId := fmt.Sprintf(id+"-%d", i)
+		var parentID string
+		if i > 0 {
+			parentID = fmt.Sprintf(id+"-%d", i-1)
+		}
+		var tags []string
+		if i%2 == 0 {
+			tags = []string{evenTag}
+		}
 		groups[groupId] = things.Group{
 			ID:          groupId,
 			OwnerID:     ownerID,
+			ParentID:    parentID,
 			Name:        fmt.Sprintf(name+"-%d", i),
 			Description: fmt.Sprintf(description+"-%d", i),
 			Metadata:    map[string]interface{}{"meta": "data"},
+			Tags:        tags,
 		}
 	}
 
@@ -125,6 +146,26 @@ func TestIssue(t *testing.T) {
 			token: "invalid",
 			err:   errors.ErrAuthentication,
 		},
+		{
+			desc: "issue API key with invalid scope",
+			key: auth.Key{
+				Type:     auth.APIKey,
+				IssuedAt: time.Now(),
+				Scopes:   []string{invalid},
+			},
+			token: secret,
+			err:   auth.ErrInvalidScope,
+		},
+		{
+			desc: "issue API key with invalid allowed IP address",
+			key: auth.Key{
+				Type:       auth.APIKey,
+				IssuedAt:   time.Now(),
+				AllowedIPs: []string{invalid},
+			},
+			token: secret,
+			err:   auth.ErrInvalidIPAddress,
+		},
 		{
 			desc: "issue API key with no time",
 			key: auth.Key{
@@ -296,19 +337,19 @@ func TestIdentify(t *testing.T) {
 		{
 			desc: "identify login key",
 			key:  loginSecret,
-			idt:  auth.Identity{id, email},
+			idt:  auth.Identity{ID: id, Email: email},
 			err:  nil,
 		},
 		{
 			desc: "identify recovery key",
 			key:  recoverySecret,
-			idt:  auth.Identity{id, email},
+			idt:  auth.Identity{ID: id, Email: email},
 			err:  nil,
 		},
 		{
 			desc: "identify API key",
 			key:  apiSecret,
-			idt:  auth.Identity{id, email},
+			idt:  auth.Identity{ID: id, Email: email},
 			err:  nil,
 		},
 		{
@@ -338,6 +379,56 @@ func TestIdentify(t *testing.T) {
 	}
 }
 
+func TestIdentifyScopedKey(t *testing.T) {
+	svc := newService()
+
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	key := auth.Key{
+		Type:       auth.APIKey,
+		IssuedAt:   time.Now(),
+		Scopes:     []string{auth.ThingsReadScope},
+		ChannelIDs: []string{id},
+		AllowedIPs: []string{"10.0.0.0/24"},
+	}
+	_, apiSecret, err := svc.Issue(context.Background(), loginSecret, key)
+	assert.Nil(t, err, fmt.Sprintf("Issuing scoped API key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		ip   string
+		idt  auth.Identity
+		err  error
+	}{
+		{
+			desc: "identify scoped API key from allowed IP address",
+			ip:   "10.0.0.5",
+			idt:  auth.Identity{ID: id, Email: email, Scopes: key.Scopes, ChannelIDs: key.ChannelIDs},
+			err:  nil,
+		},
+		{
+			desc: "identify scoped API key from disallowed IP address",
+			ip:   "10.0.1.5",
+			idt:  auth.Identity{},
+			err:  auth.ErrIPNotAllowed,
+		},
+		{
+			desc: "identify scoped API key from unknown IP address",
+			ip:   "",
+			idt:  auth.Identity{},
+			err:  auth.ErrIPNotAllowed,
+		},
+	}
+
+	for _, tc := range cases {
+		ctx := auth.WithClientIP(context.Background(), tc.ip)
+		idt, err := svc.Identify(ctx, apiSecret)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.idt, idt, fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.idt, idt))
+	}
+}
+
 func TestAuthorize(t *testing.T) {
 	svc := newService()
 
@@ -350,6 +441,13 @@ func TestAuthorize(t *testing.T) {
 	pr := auth.AuthzReq{Token: adminToken, Subject: auth.RootSubject}
 	err = svc.Authorize(context.Background(), pr)
 	require.Nil(t, err, fmt.Sprintf("authorizing initial %v authz request expected to succeed: %s", pr, err))
+
+	_, scopedToken, err := svc.Issue(context.Background(), adminToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), Scopes: []string{auth.ThingsReadScope}})
+	require.Nil(t, err, fmt.Sprintf("issuing scoped API key expected to succeed: %s", err))
+
+	pr = auth.AuthzReq{Token: scopedToken, Subject: auth.RootSubject}
+	err = svc.Authorize(context.Background(), pr)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("authorizing %v with scoped API key: expected %s got %s", pr, errors.ErrAuthorization, err))
 }
 
 func TestCreateOrg(t *testing.T) {
@@ -1712,183 +1810,1243 @@ func TestListOrgMemberships(t *testing.T) {
 	}
 }
 
-func TestBackup(t *testing.T) {
+func TestCreateOrgRole(t *testing.T) {
 	svc := newService()
 
 	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
-	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	_, editorToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: editorID, Subject: editorEmail})
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
-	_, superAdminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: rootAdminID, Subject: superAdminEmail})
+	_, adminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: adminID, Subject: adminEmail})
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 
-	var grIDs []string
-	for i := 0; i < n; i++ {
-		groupID, err := idProvider.ID()
-		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-		grIDs = append(grIDs, groupID)
-	}
-
 	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
-	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, grIDs...)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-
-	gp := auth.GroupPolicyByID{
-		MemberID: viewerID,
-		Policy:   auth.RPolicy,
-	}
-
-	err = svc.CreateGroupPolicies(context.Background(), ownerToken, grIDs[0], gp)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-
-	err = svc.AssignRole(context.Background(), rootAdminID, auth.RoleRootAdmin)
-	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
+	technician := auth.OrgRole{Name: "technician", Permissions: []string{auth.ViewOrgPermission, auth.CreateThingsPermission}}
 
 	cases := []struct {
-		desc           string
-		token          string
-		orgSize        int
-		orgMemberSize  int
-		orgGroupSize   int
-		grPoliciesSize int
-		err            error
+		desc  string
+		token string
+		orgID string
+		role  auth.OrgRole
+		err   error
 	}{
 		{
-			desc:           "backup all orgs, org members and org groups",
-			token:          superAdminToken,
-			orgSize:        1,
-			orgMemberSize:  len(members) + 1,
-			orgGroupSize:   len(grIDs),
-			grPoliciesSize: 10,
-			err:            nil,
+			desc:  "create org role as owner",
+			token: ownerToken,
+			orgID: or.ID,
+			role:  technician,
+			err:   nil,
 		},
 		{
-			desc:           "backup with invalid credentials",
-			token:          invalid,
-			orgSize:        0,
-			orgMemberSize:  0,
-			orgGroupSize:   0,
-			grPoliciesSize: 0,
-			err:            errors.ErrAuthentication,
+			desc:  "create existing org role",
+			token: ownerToken,
+			orgID: or.ID,
+			role:  technician,
+			err:   errors.ErrConflict,
 		},
 		{
-			desc:           "backup without credentials",
-			token:          "",
-			orgSize:        0,
-			orgMemberSize:  0,
-			orgGroupSize:   0,
-			grPoliciesSize: 0,
-			err:            errors.ErrAuthentication,
+			desc:  "create org role as admin",
+			token: adminToken,
+			orgID: or.ID,
+			role:  auth.OrgRole{Name: "operator", Permissions: []string{auth.ReadMessagesPermission}},
+			err:   nil,
 		},
 		{
-			desc:           "backup with unauthorised credentials",
-			token:          viewerToken,
-			orgSize:        0,
-			orgMemberSize:  0,
-			orgGroupSize:   0,
-			grPoliciesSize: 0,
-			err:            errors.ErrAuthorization,
+			desc:  "create org role as editor",
+			token: editorToken,
+			orgID: or.ID,
+			role:  auth.OrgRole{Name: "reader", Permissions: []string{auth.ReadMessagesPermission}},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "create org role with built-in role name",
+			token: ownerToken,
+			orgID: or.ID,
+			role:  auth.OrgRole{Name: auth.AdminRole},
+			err:   auth.ErrBuiltinOrgRole,
+		},
+		{
+			desc:  "create org role with invalid name",
+			token: ownerToken,
+			orgID: or.ID,
+			role:  auth.OrgRole{Name: "Field Technician"},
+			err:   auth.ErrInvalidOrgRole,
+		},
+		{
+			desc:  "create org role with invalid permission",
+			token: ownerToken,
+			orgID: or.ID,
+			role:  auth.OrgRole{Name: "reader", Permissions: []string{invalid}},
+			err:   auth.ErrInvalidPermission,
+		},
+		{
+			desc:  "create org role with invalid credentials",
+			token: invalid,
+			orgID: or.ID,
+			role:  auth.OrgRole{Name: "reader"},
+			err:   errors.ErrAuthentication,
 		},
 	}
 
 	for _, tc := range cases {
-		page, err := svc.Backup(context.Background(), tc.token)
-		orgSize := len(page.Orgs)
-		orgMemberSize := len(page.OrgMembers)
-		orgGroupSize := len(page.OrgGroups)
-		assert.Equal(t, tc.orgSize, orgSize, fmt.Sprintf("%s expected %d got %d\n", tc.desc, tc.orgSize, orgSize))
-		assert.Equal(t, tc.orgMemberSize, orgMemberSize, fmt.Sprintf("%s expected %d got %d\n", tc.desc, tc.orgMemberSize, orgMemberSize))
-		assert.Equal(t, tc.orgGroupSize, orgGroupSize, fmt.Sprintf("%s expected %d got %d\n", tc.desc, tc.orgGroupSize, orgGroupSize))
-		assert.Equal(t, tc.grPoliciesSize, len(page.GroupPolicies), fmt.Sprintf("%s expected %d got %d\n", tc.desc, tc.grPoliciesSize, len(page.GroupPolicies)))
+		_, err := svc.CreateOrgRole(context.Background(), tc.token, tc.orgID, tc.role)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
 
-func TestRestore(t *testing.T) {
+func TestUpdateOrgRole(t *testing.T) {
 	svc := newService()
 
-	_, superAdminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: rootAdminID, Subject: superAdminEmail})
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 
-	err = svc.AssignRole(context.Background(), rootAdminID, auth.RoleRootAdmin)
-	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
-
-	var memberIDs []string
-	var groupIDs []string
-	for i := 0; i < n; i++ {
-		memberID, err := idProvider.ID()
-		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-		memberIDs = append(memberIDs, memberID)
-
-		groupID, err := idProvider.ID()
-		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-		groupIDs = append(groupIDs, groupID)
-	}
-
-	orgs := []auth.Org{{ID: id, OwnerID: ownerID, Name: name}}
-	var orgMembers []auth.OrgMember
-	for _, memberID := range memberIDs {
-		orgMembers = append(orgMembers, auth.OrgMember{MemberID: memberID, OrgID: id})
-	}
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
-	var orgGroups []auth.OrgGroup
-	for _, groupID := range groupIDs {
-		orgGroups = append(orgGroups, auth.OrgGroup{GroupID: groupID, OrgID: id})
-	}
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
-	var groupPolicies []auth.GroupPolicy
-	for _, groupID := range groupIDs {
-		groupPolicies = append(groupPolicies, auth.GroupPolicy{GroupID: groupID, MemberID: viewerID, Policy: auth.RPolicy})
-	}
+	// The viewer is given the custom role which lets them manage roles.
+	manager := auth.OrgRole{Name: "role-manager", Permissions: []string{auth.ViewOrgPermission, auth.ManageRolesPermission, auth.ReadMessagesPermission}}
+	_, err = svc.CreateOrgRole(context.Background(), ownerToken, or.ID, manager)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.UpdateMembers(context.Background(), ownerToken, or.ID, auth.OrgMember{Email: viewerEmail, Role: manager.Name})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
-	backup := auth.Backup{
-		Orgs:          orgs,
-		OrgMembers:    orgMembers,
-		OrgGroups:     orgGroups,
-		GroupPolicies: groupPolicies,
-	}
+	reader := auth.OrgRole{Name: "reader", Permissions: []string{auth.ViewOrgPermission}}
+	_, err = svc.CreateOrgRole(context.Background(), ownerToken, or.ID, reader)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := []struct {
-		desc   string
-		token  string
-		backup auth.Backup
-		err    error
+		desc  string
+		token string
+		role  auth.OrgRole
+		err   error
 	}{
 		{
-			desc:   "restore all orgs, org members and org groups",
-			token:  superAdminToken,
-			backup: backup,
-			err:    nil,
+			desc:  "update org role as owner",
+			token: ownerToken,
+			role:  auth.OrgRole{Name: reader.Name, Permissions: []string{auth.ViewOrgPermission, auth.ManageMembersPermission}},
+			err:   nil,
 		},
 		{
-			desc:   "restore with invalid credentials",
-			token:  invalid,
-			backup: backup,
-			err:    errors.ErrAuthentication,
+			desc:  "update org role granting permissions the member holds",
+			token: viewerToken,
+			role:  auth.OrgRole{Name: reader.Name, Permissions: []string{auth.ViewOrgPermission, auth.ReadMessagesPermission}},
+			err:   nil,
 		},
 		{
-			desc:   "restore without credentials",
-			token:  "",
-			backup: backup,
-			err:    errors.ErrAuthentication,
+			desc:  "update org role granting permissions the member doesn't hold",
+			token: viewerToken,
+			role:  auth.OrgRole{Name: reader.Name, Permissions: []string{auth.ViewOrgPermission, auth.AllGroupsPermission}},
+			err:   errors.ErrAuthorization,
 		},
 		{
-			desc:   "restore with unauthorised credentials",
-			token:  viewerToken,
-			backup: backup,
-			err:    errors.ErrAuthorization,
+			desc:  "update org role of the member",
+			token: viewerToken,
+			role:  auth.OrgRole{Name: manager.Name, Permissions: []string{auth.ViewOrgPermission, auth.ManageRolesPermission, auth.ManageMembersPermission}},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "update org role of the member without new permissions",
+			token: viewerToken,
+			role:  manager,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "update non-existing org role",
+			token: ownerToken,
+			role:  auth.OrgRole{Name: "unknown"},
+			err:   errors.ErrNotFound,
 		},
 	}
 
 	for _, tc := range cases {
-		err := svc.Restore(context.Background(), tc.token, backup)
+		_, err := svc.UpdateOrgRole(context.Background(), tc.token, or.ID, tc.role)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
 	}
+
+	// Roles created by the member are restricted to the member permissions as well.
+	_, err = svc.CreateOrgRole(context.Background(), viewerToken, or.ID, auth.OrgRole{Name: "members-manager", Permissions: []string{auth.ManageMembersPermission}})
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("create org role granting permissions the member doesn't hold: expected %s got %s\n", errors.ErrAuthorization, err))
+}
+
+func TestRemoveOrgRole(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	role := auth.OrgRole{Name: "technician", Permissions: []string{auth.ViewOrgPermission}}
+	_, err = svc.CreateOrgRole(context.Background(), ownerToken, or.ID, role)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		name  string
+		err   error
+	}{
+		{
+			desc:  "remove org role as viewer",
+			token: viewerToken,
+			name:  role.Name,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "remove built-in org role",
+			token: ownerToken,
+			name:  auth.EditorRole,
+			err:   auth.ErrBuiltinOrgRole,
+		},
+		{
+			desc:  "remove org role as owner",
+			token: ownerToken,
+			name:  role.Name,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed org role",
+			token: ownerToken,
+			name:  role.Name,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveOrgRole(context.Background(), tc.token, or.ID, tc.name)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAuthorizeCustomOrgRole(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, editorToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: editorID, Subject: editorEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	technician := auth.OrgRole{Name: "technician", Permissions: []string{auth.ViewOrgPermission, auth.ReadGroupsPermission, auth.CreateThingsPermission}}
+	_, err = svc.CreateOrgRole(context.Background(), ownerToken, or.ID, technician)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignMembers(context.Background(), editorToken, or.ID, auth.OrgMember{Email: viewerEmail, Role: auth.AdminRole})
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("granting admin role as editor: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	err = svc.UpdateMembers(context.Background(), ownerToken, or.ID, auth.OrgMember{Email: viewerEmail, Role: "unknown"})
+	assert.True(t, errors.Contains(err, auth.ErrInvalidOrgRole), fmt.Sprintf("assigning unknown role: expected %s got %s\n", auth.ErrInvalidOrgRole, err))
+
+	err = svc.UpdateMembers(context.Background(), ownerToken, or.ID, auth.OrgMember{Email: viewerEmail, Role: technician.Name})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	groupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, groupID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateGroupPolicies(context.Background(), ownerToken, groupID, auth.GroupPolicyByID{MemberID: viewerID, Policy: auth.RwPolicy})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc string
+		req  auth.AuthzReq
+		err  error
+	}{
+		{
+			desc: "authorize granted org permission",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.OrgSubject, Object: or.ID, Action: auth.ViewOrgPermission},
+			err:  nil,
+		},
+		{
+			desc: "authorize not granted org permission",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.OrgSubject, Object: or.ID, Action: auth.ManageMembersPermission},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize built-in role action",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.OrgSubject, Object: or.ID, Action: auth.ViewerRole},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize group read",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction},
+			err:  nil,
+		},
+		{
+			desc: "authorize group write",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.WriteAction},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize granted group permission",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.CreateThingsPermission},
+			err:  nil,
+		},
+		{
+			desc: "authorize not granted group permission",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadMessagesPermission},
+			err:  errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Authorize(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = svc.RemoveOrgRole(context.Background(), ownerToken, or.ID, technician.Name)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	req := auth.AuthzReq{Token: viewerToken, Subject: auth.OrgSubject, Object: or.ID, Action: auth.ViewOrgPermission}
+	err = svc.Authorize(context.Background(), req)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("authorizing with removed role: expected %s got %s\n", errors.ErrAuthorization, err))
+}
+
+func TestAuthorizeNestedGroup(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	// Groups created by createGroups form a chain: id-0 is the root, id-1 its child and so on.
+	root := fmt.Sprintf(id+"-%d", 0)
+	building := fmt.Sprintf(id+"-%d", 1)
+	floor := fmt.Sprintf(id+"-%d", 2)
+
+	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, root)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateGroupPolicies(context.Background(), ownerToken, building, auth.GroupPolicyByID{MemberID: viewerID, Policy: auth.RPolicy})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc string
+		req  auth.AuthzReq
+		err  error
+	}{
+		{
+			desc: "authorize read of group with policy",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.GroupSubject, Object: building, Action: auth.ReadAction},
+			err:  nil,
+		},
+		{
+			desc: "authorize read of descendant of group with policy",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.GroupSubject, Object: floor, Action: auth.ReadAction},
+			err:  nil,
+		},
+		{
+			desc: "authorize write of descendant of group with read policy",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.GroupSubject, Object: floor, Action: auth.WriteAction},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize read of ancestor of group with policy",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.GroupSubject, Object: root, Action: auth.ReadAction},
+			err:  errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Authorize(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestCreateTagPolicies(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	var grIDs []string
+	for _, g := range createGroups() {
+		grIDs = append(grIDs, g.ID)
+	}
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, grIDs...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		orgID string
+		tag   string
+		gps   []auth.GroupPolicyByID
+		size  int
+		err   error
+	}{
+		{
+			desc:  "create tag policies as viewer",
+			token: viewerToken,
+			orgID: or.ID,
+			tag:   evenTag,
+			gps:   []auth.GroupPolicyByID{{MemberID: editorID, Policy: auth.RwPolicy}},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "create tag policies for org owner",
+			token: ownerToken,
+			orgID: or.ID,
+			tag:   evenTag,
+			gps:   []auth.GroupPolicyByID{{MemberID: ownerID, Policy: auth.RPolicy}},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "create tag policies",
+			token: ownerToken,
+			orgID: or.ID,
+			tag:   evenTag,
+			gps:   []auth.GroupPolicyByID{{MemberID: editorID, Policy: auth.RPolicy}},
+			size:  n / 2,
+			err:   nil,
+		},
+		{
+			desc:  "update existing tag policies",
+			token: ownerToken,
+			orgID: or.ID,
+			tag:   evenTag,
+			gps:   []auth.GroupPolicyByID{{MemberID: editorID, Policy: auth.RwPolicy}},
+			size:  n / 2,
+			err:   nil,
+		},
+		{
+			desc:  "create policies for tag without groups",
+			token: ownerToken,
+			orgID: or.ID,
+			tag:   invalid,
+			gps:   []auth.GroupPolicyByID{{MemberID: editorID, Policy: auth.RwPolicy}},
+			size:  0,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		groupIDs, err := svc.CreateTagPolicies(context.Background(), tc.token, tc.orgID, tc.tag, tc.gps...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(groupIDs), fmt.Sprintf("%s: expected %d groups got %d\n", tc.desc, tc.size, len(groupIDs)))
+	}
+}
+
+func TestBackup(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, superAdminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: rootAdminID, Subject: superAdminEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	var grIDs []string
+	for i := 0; i < n; i++ {
+		groupID, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		grIDs = append(grIDs, groupID)
+	}
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, grIDs...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	gp := auth.GroupPolicyByID{
+		MemberID: viewerID,
+		Policy:   auth.RPolicy,
+	}
+
+	err = svc.CreateGroupPolicies(context.Background(), ownerToken, grIDs[0], gp)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignRole(context.Background(), rootAdminID, auth.RoleRootAdmin)
+	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
+
+	cases := []struct {
+		desc           string
+		token          string
+		orgSize        int
+		orgMemberSize  int
+		orgGroupSize   int
+		grPoliciesSize int
+		err            error
+	}{
+		{
+			desc:           "backup all orgs, org members and org groups",
+			token:          superAdminToken,
+			orgSize:        1,
+			orgMemberSize:  len(members) + 1,
+			orgGroupSize:   len(grIDs),
+			grPoliciesSize: 10,
+			err:            nil,
+		},
+		{
+			desc:           "backup with invalid credentials",
+			token:          invalid,
+			orgSize:        0,
+			orgMemberSize:  0,
+			orgGroupSize:   0,
+			grPoliciesSize: 0,
+			err:            errors.ErrAuthentication,
+		},
+		{
+			desc:           "backup without credentials",
+			token:          "",
+			orgSize:        0,
+			orgMemberSize:  0,
+			orgGroupSize:   0,
+			grPoliciesSize: 0,
+			err:            errors.ErrAuthentication,
+		},
+		{
+			desc:           "backup with unauthorised credentials",
+			token:          viewerToken,
+			orgSize:        0,
+			orgMemberSize:  0,
+			orgGroupSize:   0,
+			grPoliciesSize: 0,
+			err:            errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.Backup(context.Background(), tc.token)
+		orgSize := len(page.Orgs)
+		orgMemberSize := len(page.OrgMembers)
+		orgGroupSize := len(page.OrgGroups)
+		assert.Equal(t, tc.orgSize, orgSize, fmt.Sprintf("%s expected %d got %d\n", tc.desc, tc.orgSize, orgSize))
+		assert.Equal(t, tc.orgMemberSize, orgMemberSize, fmt.Sprintf("%s expected %d got %d\n", tc.desc, tc.orgMemberSize, orgMemberSize))
+		assert.Equal(t, tc.orgGroupSize, orgGroupSize, fmt.Sprintf("%s expected %d got %d\n", tc.desc, tc.orgGroupSize, orgGroupSize))
+		assert.Equal(t, tc.grPoliciesSize, len(page.GroupPolicies), fmt.Sprintf("%s expected %d got %d\n", tc.desc, tc.grPoliciesSize, len(page.GroupPolicies)))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRestore(t *testing.T) {
+	svc := newService()
+
+	_, superAdminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: rootAdminID, Subject: superAdminEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.AssignRole(context.Background(), rootAdminID, auth.RoleRootAdmin)
+	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
+
+	var memberIDs []string
+	var groupIDs []string
+	for i := 0; i < n; i++ {
+		memberID, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		memberIDs = append(memberIDs, memberID)
+
+		groupID, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		groupIDs = append(groupIDs, groupID)
+	}
+
+	orgs := []auth.Org{{ID: id, OwnerID: ownerID, Name: name}}
+	var orgMembers []auth.OrgMember
+	for _, memberID := range memberIDs {
+		orgMembers = append(orgMembers, auth.OrgMember{MemberID: memberID, OrgID: id})
+	}
+
+	var orgGroups []auth.OrgGroup
+	for _, groupID := range groupIDs {
+		orgGroups = append(orgGroups, auth.OrgGroup{GroupID: groupID, OrgID: id})
+	}
+
+	var groupPolicies []auth.GroupPolicy
+	for _, groupID := range groupIDs {
+		groupPolicies = append(groupPolicies, auth.GroupPolicy{GroupID: groupID, MemberID: viewerID, Policy: auth.RPolicy})
+	}
+
+	backup := auth.Backup{
+		Orgs:          orgs,
+		OrgMembers:    orgMembers,
+		OrgGroups:     orgGroups,
+		GroupPolicies: groupPolicies,
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		backup auth.Backup
+		err    error
+	}{
+		{
+			desc:   "restore all orgs, org members and org groups",
+			token:  superAdminToken,
+			backup: backup,
+			err:    nil,
+		},
+		{
+			desc:   "restore with invalid credentials",
+			token:  invalid,
+			backup: backup,
+			err:    errors.ErrAuthentication,
+		},
+		{
+			desc:   "restore without credentials",
+			token:  "",
+			backup: backup,
+			err:    errors.ErrAuthentication,
+		},
+		{
+			desc:   "restore with unauthorised credentials",
+			token:  viewerToken,
+			backup: backup,
+			err:    errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Restore(context.Background(), tc.token, backup)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestExportBackup(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, superAdminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: rootAdminID, Subject: superAdminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.AssignRole(context.Background(), rootAdminID, auth.RoleRootAdmin)
+	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	groupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, groupID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		filter  auth.BackupFilter
+		records int
+		err     error
+	}{
+		{
+			desc:    "export full backup",
+			token:   superAdminToken,
+			records: 8,
+			err:     nil,
+		},
+		{
+			desc:    "export backup of org as org owner",
+			token:   ownerToken,
+			filter:  auth.BackupFilter{OrgID: or.ID},
+			records: 8,
+			err:     nil,
+		},
+		{
+			desc:    "export incremental backup",
+			token:   superAdminToken,
+			filter:  auth.BackupFilter{Since: time.Now().Add(time.Hour)},
+			records: 2,
+			err:     nil,
+		},
+		{
+			desc:  "export full backup as org owner",
+			token: ownerToken,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:   "export backup of org as org viewer",
+			token:  viewerToken,
+			filter: auth.BackupFilter{OrgID: or.ID},
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:  "export backup with invalid credentials",
+			token: invalid,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		stream, err := svc.ExportBackup(context.Background(), tc.token, tc.filter)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+
+		var buf bytes.Buffer
+		err = stream(backup.NewEncoder(&buf))
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		records := countRecords(t, &buf)
+		assert.Equal(t, tc.records, records, fmt.Sprintf("%s: expected %d records got %d\n", tc.desc, tc.records, records))
+	}
+}
+
+func TestImportBackup(t *testing.T) {
+	src := newService()
+
+	_, ownerToken, err := src.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, superAdminToken, err := src.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: rootAdminID, Subject: superAdminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = src.AssignRole(context.Background(), rootAdminID, auth.RoleRootAdmin)
+	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
+
+	or, err := src.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = src.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	groupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = src.AssignGroups(context.Background(), ownerToken, or.ID, groupID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	stream, err := src.ExportBackup(context.Background(), superAdminToken, auth.BackupFilter{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	var buf bytes.Buffer
+	err = stream(backup.NewEncoder(&buf))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	data := buf.String()
+
+	stream, err = src.ExportBackup(context.Background(), ownerToken, auth.BackupFilter{OrgID: or.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	buf.Reset()
+	err = stream(backup.NewEncoder(&buf))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	orgData := buf.String()
+
+	svc := newService()
+	err = svc.AssignRole(context.Background(), rootAdminID, auth.RoleRootAdmin)
+	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		data     string
+		strategy string
+		summary  backup.Summary
+		err      error
+	}{
+		{
+			desc:     "import backup",
+			token:    superAdminToken,
+			data:     data,
+			strategy: backup.StrategyFail,
+			summary:  backup.Summary{Created: 7},
+			err:      nil,
+		},
+		{
+			desc:     "import existing backup skipping conflicts",
+			token:    superAdminToken,
+			data:     data,
+			strategy: backup.StrategySkip,
+			summary:  backup.Summary{Skipped: 7},
+			err:      nil,
+		},
+		{
+			desc:     "import backup of org as org owner overwriting conflicts",
+			token:    ownerToken,
+			data:     orgData,
+			strategy: backup.StrategyOverwrite,
+			summary:  backup.Summary{Updated: 7},
+			err:      nil,
+		},
+		{
+			desc:     "import existing backup failing on conflicts",
+			token:    superAdminToken,
+			data:     data,
+			strategy: backup.StrategyFail,
+			err:      errors.ErrConflict,
+		},
+		{
+			desc:     "import full backup as org owner",
+			token:    ownerToken,
+			data:     data,
+			strategy: backup.StrategySkip,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "import backup with invalid strategy",
+			token:    superAdminToken,
+			data:     data,
+			strategy: invalid,
+			err:      backup.ErrInvalidStrategy,
+		},
+		{
+			desc:     "import malformed backup",
+			token:    superAdminToken,
+			data:     "{}\n",
+			strategy: backup.StrategySkip,
+			err:      backup.ErrMalformedRecord,
+		},
+	}
+
+	for _, tc := range cases {
+		summary, err := svc.ImportBackup(context.Background(), tc.token, backup.NewDecoder(strings.NewReader(tc.data)), tc.strategy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tc.summary, summary, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.summary, summary))
+	}
+}
+
+func TestImportScopedBackup(t *testing.T) {
+	svc := newService()
+
+	_, editorToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: editorID, Subject: editorEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), editorToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	type record struct {
+		typ  string
+		data map[string]interface{}
+	}
+
+	cases := []struct {
+		desc    string
+		records []record
+		summary backup.Summary
+		err     error
+	}{
+		{
+			desc:    "import custom org role",
+			records: []record{{"org_role", map[string]interface{}{"org_id": or.ID, "name": "operator", "permissions": []string{auth.ReadMessagesPermission}}}},
+			summary: backup.Summary{Created: 1},
+			err:     nil,
+		},
+		{
+			desc:    "import built-in org role",
+			records: []record{{"org_role", map[string]interface{}{"org_id": or.ID, "name": auth.AdminRole, "permissions": []string{auth.ManageOrgPermission}}}},
+			err:     auth.ErrBuiltinOrgRole,
+		},
+		{
+			desc:    "import org role with invalid permission",
+			records: []record{{"org_role", map[string]interface{}{"org_id": or.ID, "name": "custom", "permissions": []string{invalid}}}},
+			err:     auth.ErrInvalidPermission,
+		},
+		{
+			desc:    "import org member",
+			records: []record{{"org_member", map[string]interface{}{"org_id": or.ID, "member_id": viewerID, "role": auth.ViewerRole}}},
+			summary: backup.Summary{Created: 1},
+			err:     nil,
+		},
+		{
+			desc:    "import org member with owner role",
+			records: []record{{"org_member", map[string]interface{}{"org_id": or.ID, "member_id": adminID, "role": auth.OwnerRole}}},
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "import org owner with other role",
+			records: []record{{"org_member", map[string]interface{}{"org_id": or.ID, "member_id": editorID, "role": auth.ViewerRole}}},
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "import org member with non-existing role",
+			records: []record{{"org_member", map[string]interface{}{"org_id": or.ID, "member_id": adminID, "role": invalid}}},
+			err:     auth.ErrInvalidOrgRole,
+		},
+		{
+			desc:    "import group owned by non-member",
+			records: []record{{"org_group", map[string]interface{}{"org_id": or.ID, "group_id": fmt.Sprintf("%s-0", id)}}},
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "import non-existing group",
+			records: []record{{"org_group", map[string]interface{}{"org_id": or.ID, "group_id": invalid}}},
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		var buf bytes.Buffer
+		enc := backup.NewEncoder(&buf)
+		err := enc.WriteHeader(backup.Header{Service: auth.BackupService, Scope: &backup.Scope{Type: backup.ScopeOrg, ID: or.ID}})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		for _, rec := range tc.records {
+			err := enc.Encode(rec.typ, rec.data)
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		}
+
+		summary, err := svc.ImportBackup(context.Background(), editorToken, backup.NewDecoder(&buf), backup.StrategyFail)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tc.summary, summary, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.summary, summary))
+	}
+}
+
+func countRecords(t *testing.T, r io.Reader) int {
+	dec := backup.NewDecoder(r)
+	_, err := dec.Header()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	records := 1
+	for {
+		if _, err := dec.Next(); err != nil {
+			require.Equal(t, io.EOF, err, fmt.Sprintf("unexpected error: %s", err))
+			return records
+		}
+		records++
+	}
+}
+
+func TestUpdateQuota(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, superAdminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: rootAdminID, Subject: superAdminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.AssignRole(context.Background(), rootAdminID, auth.RoleRootAdmin)
+	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	limits := auth.Limits{Things: 10, Channels: 20, Groups: 5, Keys: 3, Subscriptions: 2}
+
+	cases := []struct {
+		desc  string
+		token string
+		quota auth.Quota
+		err   error
+	}{
+		{
+			desc:  "update user quota as root admin",
+			token: superAdminToken,
+			quota: auth.Quota{Subject: quota.User, ID: ownerID, Limits: limits},
+			err:   nil,
+		},
+		{
+			desc:  "update org quota as root admin",
+			token: superAdminToken,
+			quota: auth.Quota{Subject: quota.Org, ID: or.ID, Limits: limits},
+			err:   nil,
+		},
+		{
+			desc:  "update quota of non-existing org",
+			token: superAdminToken,
+			quota: auth.Quota{Subject: quota.Org, ID: invalid, Limits: limits},
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "update quota with invalid subject",
+			token: superAdminToken,
+			quota: auth.Quota{Subject: invalid, ID: ownerID, Limits: limits},
+			err:   auth.ErrInvalidQuotaSubject,
+		},
+		{
+			desc:  "update quota as org owner",
+			token: ownerToken,
+			quota: auth.Quota{Subject: quota.Org, ID: or.ID, Limits: limits},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "update quota with invalid token",
+			token: invalid,
+			quota: auth.Quota{Subject: quota.User, ID: ownerID, Limits: limits},
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateQuota(context.Background(), tc.token, tc.quota)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestViewQuota(t *testing.T) {
+	defaults := auth.QuotaDefaults{User: auth.Limits{Things: 1}, Org: auth.Limits{Groups: 1}}
+	svc := newServiceWithQuotas(defaults)
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, editorToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: editorID, Subject: editorEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, superAdminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: rootAdminID, Subject: superAdminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.AssignRole(context.Background(), rootAdminID, auth.RoleRootAdmin)
+	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, auth.OrgMember{Email: viewerEmail, Role: auth.ViewerRole})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	limits := auth.Limits{Things: 10, Channels: 20}
+	err = svc.UpdateQuota(context.Background(), superAdminToken, auth.Quota{Subject: quota.User, ID: viewerID, Limits: limits})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		subject string
+		id      string
+		limits  auth.Limits
+		err     error
+	}{
+		{
+			desc:    "view own default user quota",
+			token:   ownerToken,
+			subject: quota.User,
+			id:      ownerID,
+			limits:  defaults.User,
+			err:     nil,
+		},
+		{
+			desc:    "view own updated user quota",
+			token:   viewerToken,
+			subject: quota.User,
+			id:      viewerID,
+			limits:  limits,
+			err:     nil,
+		},
+		{
+			desc:    "view user quota as root admin",
+			token:   superAdminToken,
+			subject: quota.User,
+			id:      viewerID,
+			limits:  limits,
+			err:     nil,
+		},
+		{
+			desc:    "view quota of another user",
+			token:   ownerToken,
+			subject: quota.User,
+			id:      viewerID,
+			limits:  auth.Limits{},
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "view org quota as org viewer",
+			token:   viewerToken,
+			subject: quota.Org,
+			id:      or.ID,
+			limits:  defaults.Org,
+			err:     nil,
+		},
+		{
+			desc:    "view org quota as non-member",
+			token:   editorToken,
+			subject: quota.Org,
+			id:      or.ID,
+			limits:  auth.Limits{},
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "view quota with invalid subject",
+			token:   ownerToken,
+			subject: invalid,
+			id:      ownerID,
+			limits:  auth.Limits{},
+			err:     auth.ErrInvalidQuotaSubject,
+		},
+	}
+
+	for _, tc := range cases {
+		q, err := svc.ViewQuota(context.Background(), tc.token, tc.subject, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.limits, q.Limits, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.limits, q.Limits))
+	}
+}
+
+func TestRemoveQuota(t *testing.T) {
+	defaults := auth.QuotaDefaults{User: auth.Limits{Things: 1}}
+	svc := newServiceWithQuotas(defaults)
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, superAdminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: rootAdminID, Subject: superAdminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.AssignRole(context.Background(), rootAdminID, auth.RoleRootAdmin)
+	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
+
+	err = svc.UpdateQuota(context.Background(), superAdminToken, auth.Quota{Subject: quota.User, ID: ownerID, Limits: auth.Limits{Things: 10}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		subject string
+		err     error
+	}{
+		{
+			desc:    "remove quota as quota subject",
+			token:   ownerToken,
+			subject: quota.User,
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "remove quota with invalid subject",
+			token:   superAdminToken,
+			subject: invalid,
+			err:     auth.ErrInvalidQuotaSubject,
+		},
+		{
+			desc:    "remove quota as root admin",
+			token:   superAdminToken,
+			subject: quota.User,
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveQuota(context.Background(), tc.token, tc.subject, ownerID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	q, err := svc.RetrieveQuota(context.Background(), quota.User, ownerID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, defaults.User, q.Limits, fmt.Sprintf("expected default limits %v got %v\n", defaults.User, q.Limits))
+}
+
+func TestRetrieveQuota(t *testing.T) {
+	defaults := auth.QuotaDefaults{User: auth.Limits{Things: 1}, Org: auth.Limits{Things: 5}}
+	svc := newServiceWithQuotas(defaults)
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	rootGroupID := fmt.Sprintf(id+"-%d", 0)
+	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, rootGroupID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		subject string
+		id      string
+		quota   auth.Quota
+		err     error
+	}{
+		{
+			desc:    "retrieve user quota",
+			subject: quota.User,
+			id:      ownerID,
+			quota:   auth.Quota{Subject: quota.User, ID: ownerID, Limits: defaults.User},
+			err:     nil,
+		},
+		{
+			desc:    "retrieve org quota",
+			subject: quota.Org,
+			id:      or.ID,
+			quota:   auth.Quota{Subject: quota.Org, ID: or.ID, Limits: defaults.Org, OrgID: or.ID, GroupIDs: []string{rootGroupID}},
+			err:     nil,
+		},
+		{
+			desc:    "retrieve quota of a nested group in org",
+			subject: quota.Group,
+			id:      fmt.Sprintf(id+"-%d", 3),
+			quota:   auth.Quota{Subject: quota.Org, ID: or.ID, Limits: defaults.Org, OrgID: or.ID, GroupIDs: []string{rootGroupID}},
+			err:     nil,
+		},
+		{
+			desc:    "retrieve quota of non-existing org",
+			subject: quota.Org,
+			id:      invalid,
+			quota:   auth.Quota{},
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "retrieve quota with invalid subject",
+			subject: invalid,
+			id:      ownerID,
+			quota:   auth.Quota{},
+			err:     auth.ErrInvalidQuotaSubject,
+		},
+	}
+
+	for _, tc := range cases {
+		q, err := svc.RetrieveQuota(context.Background(), tc.subject, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.quota, q, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.quota, q))
+	}
+}
+
+func TestQuotaEnforcement(t *testing.T) {
+	svc := newServiceWithQuotas(auth.QuotaDefaults{User: auth.Limits{Keys: 1}, Org: auth.Limits{Groups: 2}})
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, _, err = svc.Issue(context.Background(), ownerToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("issuing API key within quota expected to succeed: %s", err))
+	_, _, err = svc.Issue(context.Background(), ownerToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now()})
+	assert.True(t, errors.Contains(err, quota.ErrQuotaExceeded), fmt.Sprintf("issuing API key over quota: expected %s got %s\n", quota.ErrQuotaExceeded, err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, fmt.Sprintf(id+"-%d", 0), fmt.Sprintf(id+"-%d", 1), fmt.Sprintf(id+"-%d", 2))
+	assert.True(t, errors.Contains(err, quota.ErrQuotaExceeded), fmt.Sprintf("assigning groups over quota: expected %s got %s\n", quota.ErrQuotaExceeded, err))
+	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, fmt.Sprintf(id+"-%d", 0), fmt.Sprintf(id+"-%d", 1))
+	assert.Nil(t, err, fmt.Sprintf("assigning groups within quota expected to succeed: %s", err))
+
+	usage, err := svc.ViewUsage(context.Background(), ownerToken, or.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, quota.Usage{Used: 2, Limit: 2}, usage[quota.Groups], fmt.Sprintf("unexpected groups usage: %v", usage))
+
+	usage, err = svc.ViewUsage(context.Background(), ownerToken, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, quota.Usage{Used: 1, Limit: 1}, usage[quota.Keys], fmt.Sprintf("unexpected keys usage: %v", usage))
 }
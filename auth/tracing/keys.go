@@ -7,6 +7,7 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/auth"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -15,7 +16,9 @@ import (
 const (
 	saveOp     = "save"
 	retrieveOp = "retrieve_by_id"
+	lastUsedOp = "update_last_used"
 	revokeOp   = "remove"
+	countOp    = "retrieve_api_keys_count"
 )
 
 var _ auth.KeyRepository = (*keyRepositoryMiddleware)(nil)
@@ -52,6 +55,14 @@ func (krm keyRepositoryMiddleware) Retrieve(ctx context.Context, owner, id strin
 	return krm.repo.Retrieve(ctx, owner, id)
 }
 
+func (krm keyRepositoryMiddleware) UpdateLastUsed(ctx context.Context, owner, id string, usedAt time.Time) error {
+	span := createSpan(ctx, krm.tracer, lastUsedOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.UpdateLastUsed(ctx, owner, id, usedAt)
+}
+
 func (krm keyRepositoryMiddleware) Remove(ctx context.Context, owner, id string) error {
 	span := createSpan(ctx, krm.tracer, revokeOp)
 	defer span.Finish()
@@ -60,6 +71,14 @@ func (krm keyRepositoryMiddleware) Remove(ctx context.Context, owner, id string)
 	return krm.repo.Remove(ctx, owner, id)
 }
 
+func (krm keyRepositoryMiddleware) RetrieveAPIKeysCount(ctx context.Context, owner string) (uint64, error) {
+	span := createSpan(ctx, krm.tracer, countOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RetrieveAPIKeysCount(ctx, owner)
+}
+
 func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		return tracer.StartSpan(
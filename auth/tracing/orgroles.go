@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveOrgRoles          = "save_org_roles"
+	updateOrgRole         = "update_org_role"
+	retrieveOrgRole       = "retrieve_org_role"
+	retrieveOrgRolesByOrg = "retrieve_org_roles_by_org"
+	retrieveAllOrgRoles   = "retrieve_all_org_roles"
+	removeOrgRole         = "remove_org_role"
+)
+
+var _ auth.OrgRolesRepository = (*orgRolesRepositoryMiddleware)(nil)
+
+type orgRolesRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   auth.OrgRolesRepository
+}
+
+// OrgRolesRepositoryMiddleware tracks request and their latency, and adds spans to context.
+func OrgRolesRepositoryMiddleware(tracer opentracing.Tracer, rr auth.OrgRolesRepository) auth.OrgRolesRepository {
+	return orgRolesRepositoryMiddleware{
+		tracer: tracer,
+		repo:   rr,
+	}
+}
+
+func (rrm orgRolesRepositoryMiddleware) Save(ctx context.Context, roles ...auth.OrgRole) error {
+	span := createSpan(ctx, rrm.tracer, saveOrgRoles)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Save(ctx, roles...)
+}
+
+func (rrm orgRolesRepositoryMiddleware) Update(ctx context.Context, role auth.OrgRole) error {
+	span := createSpan(ctx, rrm.tracer, updateOrgRole)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Update(ctx, role)
+}
+
+func (rrm orgRolesRepositoryMiddleware) Retrieve(ctx context.Context, orgID, name string) (auth.OrgRole, error) {
+	span := createSpan(ctx, rrm.tracer, retrieveOrgRole)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Retrieve(ctx, orgID, name)
+}
+
+func (rrm orgRolesRepositoryMiddleware) RetrieveByOrg(ctx context.Context, orgID string, pm auth.PageMetadata) (auth.OrgRolesPage, error) {
+	span := createSpan(ctx, rrm.tracer, retrieveOrgRolesByOrg)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.RetrieveByOrg(ctx, orgID, pm)
+}
+
+func (rrm orgRolesRepositoryMiddleware) RetrieveAll(ctx context.Context) ([]auth.OrgRole, error) {
+	span := createSpan(ctx, rrm.tracer, retrieveAllOrgRoles)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.RetrieveAll(ctx)
+}
+
+func (rrm orgRolesRepositoryMiddleware) Remove(ctx context.Context, orgID, name string) error {
+	span := createSpan(ctx, rrm.tracer, removeOrgRole)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return rrm.repo.Remove(ctx, orgID, name)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveQuota     = "save_quota"
+	retrieveQuota = "retrieve_quota"
+	removeQuota   = "remove_quota"
+)
+
+var _ auth.QuotasRepository = (*quotasRepositoryMiddleware)(nil)
+
+type quotasRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   auth.QuotasRepository
+}
+
+// QuotasRepositoryMiddleware tracks request and their latency, and adds spans to context.
+func QuotasRepositoryMiddleware(tracer opentracing.Tracer, qr auth.QuotasRepository) auth.QuotasRepository {
+	return quotasRepositoryMiddleware{
+		tracer: tracer,
+		repo:   qr,
+	}
+}
+
+func (qrm quotasRepositoryMiddleware) Save(ctx context.Context, q auth.Quota) error {
+	span := createSpan(ctx, qrm.tracer, saveQuota)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return qrm.repo.Save(ctx, q)
+}
+
+func (qrm quotasRepositoryMiddleware) Retrieve(ctx context.Context, subject, id string) (auth.Quota, error) {
+	span := createSpan(ctx, qrm.tracer, retrieveQuota)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return qrm.repo.Retrieve(ctx, subject, id)
+}
+
+func (qrm quotasRepositoryMiddleware) Remove(ctx context.Context, subject, id string) error {
+	span := createSpan(ctx, qrm.tracer, removeQuota)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return qrm.repo.Remove(ctx, subject, id)
+}
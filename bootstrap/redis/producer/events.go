@@ -4,6 +4,7 @@
 package producer
 
 import (
+	"encoding/json"
 	"strings"
 	"time"
 
@@ -62,17 +63,27 @@ type updateConfigEvent struct {
 	mfThing   string
 	name      string
 	content   string
+	before    map[string]interface{}
 	timestamp time.Time
 }
 
 func (uce updateConfigEvent) encode() map[string]interface{} {
-	return map[string]interface{}{
+	val := map[string]interface{}{
 		"thing_id":  uce.mfThing,
 		"name":      uce.name,
 		"content":   uce.content,
 		"timestamp": uce.timestamp.Unix(),
 		"operation": configUpdate,
 	}
+
+	if uce.before != nil {
+		before, err := json.Marshal(uce.before)
+		if err == nil {
+			val["before"] = string(before)
+		}
+	}
+
+	return val
 }
 
 type removeConfigEvent struct {
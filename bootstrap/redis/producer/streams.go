@@ -7,6 +7,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/bootstrap"
 	"github.com/go-redis/redis/v8"
 )
@@ -20,14 +22,16 @@ var _ bootstrap.Service = (*eventStore)(nil)
 
 type eventStore struct {
 	svc    bootstrap.Service
+	auth   mainflux.AuthServiceClient
 	client *redis.Client
 }
 
 // NewEventStoreMiddleware returns wrapper around bootstrap service that sends
 // events to event store.
-func NewEventStoreMiddleware(svc bootstrap.Service, client *redis.Client) bootstrap.Service {
+func NewEventStoreMiddleware(svc bootstrap.Service, auth mainflux.AuthServiceClient, client *redis.Client) bootstrap.Service {
 	return eventStore{
 		svc:    svc,
+		auth:   auth,
 		client: client,
 	}
 }
@@ -53,7 +57,7 @@ func (es eventStore) Add(ctx context.Context, token string, cfg bootstrap.Config
 		timestamp:  time.Now(),
 	}
 
-	es.add(ctx, ev)
+	es.add(ctx, es.actor(ctx, token), ev)
 
 	return saved, err
 }
@@ -63,6 +67,11 @@ func (es eventStore) View(ctx context.Context, token, id string) (bootstrap.Conf
 }
 
 func (es eventStore) Update(ctx context.Context, token string, cfg bootstrap.Config) error {
+	before, err := es.svc.View(ctx, token, cfg.ThingID)
+	if err != nil {
+		return err
+	}
+
 	if err := es.svc.Update(ctx, token, cfg); err != nil {
 		return err
 	}
@@ -71,10 +80,11 @@ func (es eventStore) Update(ctx context.Context, token string, cfg bootstrap.Con
 		mfThing:   cfg.ThingID,
 		name:      cfg.Name,
 		content:   cfg.Content,
+		before:    map[string]interface{}{"name": before.Name, "content": before.Content},
 		timestamp: time.Now(),
 	}
 
-	es.add(ctx, ev)
+	es.add(ctx, es.actor(ctx, token), ev)
 
 	return nil
 }
@@ -94,7 +104,7 @@ func (es eventStore) UpdateConnections(ctx context.Context, token, id string, co
 		timestamp:  time.Now(),
 	}
 
-	es.add(ctx, ev)
+	es.add(ctx, es.actor(ctx, token), ev)
 
 	return nil
 }
@@ -113,7 +123,7 @@ func (es eventStore) Remove(ctx context.Context, token, id string) error {
 		timestamp: time.Now(),
 	}
 
-	es.add(ctx, ev)
+	es.add(ctx, es.actor(ctx, token), ev)
 
 	return nil
 }
@@ -131,7 +141,7 @@ func (es eventStore) Bootstrap(ctx context.Context, externalKey, externalID stri
 		ev.success = false
 	}
 
-	es.add(ctx, ev)
+	es.add(ctx, "", ev)
 
 	return cfg, err
 }
@@ -147,7 +157,7 @@ func (es eventStore) ChangeState(ctx context.Context, token, id string, state bo
 		timestamp: time.Now(),
 	}
 
-	es.add(ctx, ev)
+	es.add(ctx, es.actor(ctx, token), ev)
 
 	return nil
 }
@@ -168,11 +178,28 @@ func (es eventStore) DisconnectThingHandler(ctx context.Context, channelID, thin
 	return es.svc.DisconnectThingHandler(ctx, channelID, thingID)
 }
 
-func (es eventStore) add(ctx context.Context, ev event) error {
+func (es eventStore) actor(ctx context.Context, token string) string {
+	res, err := es.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return ""
+	}
+
+	return res.GetId()
+}
+
+func (es eventStore) add(ctx context.Context, actor string, ev event) error {
+	values := ev.encode()
+	if actor != "" {
+		values["actor"] = actor
+	}
+	if ip := auth.ClientIP(ctx); ip != "" {
+		values["ip"] = ip
+	}
+
 	record := &redis.XAddArgs{
 		Stream:       streamID,
 		MaxLenApprox: streamLen,
-		Values:       ev.encode(),
+		Values:       values,
 	}
 
 	return es.client.XAdd(ctx, record).Err()
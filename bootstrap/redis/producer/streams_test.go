@@ -102,7 +102,7 @@ func TestAdd(t *testing.T) {
 
 	server := newThingsServer(newThingsService(users))
 	svc := newService(users, server.URL)
-	svc = producer.NewEventStoreMiddleware(svc, redisClient)
+	svc = producer.NewEventStoreMiddleware(svc, users, redisClient)
 
 	var channels []string
 	for _, ch := range config.Channels {
@@ -176,7 +176,7 @@ func TestView(t *testing.T) {
 
 	svcConfig, svcErr := svc.View(context.Background(), validToken, saved.ThingID)
 
-	svc = producer.NewEventStoreMiddleware(svc, redisClient)
+	svc = producer.NewEventStoreMiddleware(svc, users, redisClient)
 	esConfig, esErr := svc.View(context.Background(), validToken, saved.ThingID)
 
 	assert.Equal(t, svcConfig, esConfig, fmt.Sprintf("event sourcing changed service behavior: expected %v got %v", svcConfig, esConfig))
@@ -189,7 +189,7 @@ func TestUpdate(t *testing.T) {
 	users := mocks.NewAuthService("", usersList)
 	server := newThingsServer(newThingsService(users))
 	svc := newService(users, server.URL)
-	svc = producer.NewEventStoreMiddleware(svc, redisClient)
+	svc = producer.NewEventStoreMiddleware(svc, users, redisClient)
 
 	c := config
 
@@ -223,6 +223,7 @@ func TestUpdate(t *testing.T) {
 				"thing_id":  modified.ThingID,
 				"name":      modified.Name,
 				"content":   modified.Content,
+				"before":    "{\"content\":\"config\",\"name\":\"\"}",
 				"timestamp": time.Now().Unix(),
 				"operation": configUpdate,
 			},
@@ -264,7 +265,7 @@ func TestUpdateConnections(t *testing.T) {
 	users := mocks.NewAuthService("", usersList)
 	server := newThingsServer(newThingsService(users))
 	svc := newService(users, server.URL)
-	svc = producer.NewEventStoreMiddleware(svc, redisClient)
+	svc = producer.NewEventStoreMiddleware(svc, users, redisClient)
 
 	saved, err := svc.Add(context.Background(), validToken, config)
 	require.Nil(t, err, fmt.Sprintf("Saving config expected to succeed: %s.\n", err))
@@ -334,7 +335,7 @@ func TestList(t *testing.T) {
 	limit := uint64(10)
 	svcConfigs, svcErr := svc.List(context.Background(), validToken, bootstrap.Filter{}, offset, limit)
 
-	svc = producer.NewEventStoreMiddleware(svc, redisClient)
+	svc = producer.NewEventStoreMiddleware(svc, users, redisClient)
 	esConfigs, esErr := svc.List(context.Background(), validToken, bootstrap.Filter{}, offset, limit)
 
 	assert.Equal(t, svcConfigs, esConfigs, fmt.Sprintf("event sourcing changed service behavior: expected %v got %v", svcConfigs, esConfigs))
@@ -347,7 +348,7 @@ func TestRemove(t *testing.T) {
 	users := mocks.NewAuthService("", usersList)
 	server := newThingsServer(newThingsService(users))
 	svc := newService(users, server.URL)
-	svc = producer.NewEventStoreMiddleware(svc, redisClient)
+	svc = producer.NewEventStoreMiddleware(svc, users, redisClient)
 
 	c := config
 
@@ -410,7 +411,7 @@ func TestBootstrap(t *testing.T) {
 	users := mocks.NewAuthService("", usersList)
 	server := newThingsServer(newThingsService(users))
 	svc := newService(users, server.URL)
-	svc = producer.NewEventStoreMiddleware(svc, redisClient)
+	svc = producer.NewEventStoreMiddleware(svc, users, redisClient)
 
 	c := config
 
@@ -479,7 +480,7 @@ func TestChangeState(t *testing.T) {
 	users := mocks.NewAuthService("", usersList)
 	server := newThingsServer(newThingsService(users))
 	svc := newService(users, server.URL)
-	svc = producer.NewEventStoreMiddleware(svc, redisClient)
+	svc = producer.NewEventStoreMiddleware(svc, users, redisClient)
 
 	c := config
 
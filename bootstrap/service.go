@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
 )
@@ -382,6 +383,10 @@ func (bs bootstrapService) identify(token string) (string, error) {
 		return "", errors.ErrAuthentication
 	}
 
+	if auth.IsScoped(res) {
+		return "", errors.ErrAuthorization
+	}
+
 	return res.GetId(), nil
 }
 
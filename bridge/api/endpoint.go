@@ -0,0 +1,105 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createBridgeEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createBridgeReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		b, err := svc.CreateBridge(ctx, req.token, req.toBridge(""))
+		if err != nil {
+			return nil, err
+		}
+
+		return createBridgeRes{ID: b.ID}, nil
+	}
+}
+
+func viewBridgeEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(bridgeReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		b, err := svc.ViewBridge(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return newBridgeRes(b), nil
+	}
+}
+
+func listBridgesEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listBridgesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := bridge.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.ListBridges(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := bridgesPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Bridges: []bridgeRes{},
+		}
+		for _, b := range page.Bridges {
+			res.Bridges = append(res.Bridges, newBridgeRes(b))
+		}
+
+		return res, nil
+	}
+}
+
+func updateBridgeEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateBridgeReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.UpdateBridge(ctx, req.token, req.toBridge(req.id)); err != nil {
+			return nil, err
+		}
+
+		return updateBridgeRes{}, nil
+	}
+}
+
+func removeBridgeEndpoint(svc bridge.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(bridgeReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveBridge(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeBridgeRes{}, nil
+	}
+}
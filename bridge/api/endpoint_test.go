@@ -0,0 +1,531 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	httpapi "github.com/MainfluxLabs/mainflux/bridge/api"
+	"github.com/MainfluxLabs/mainflux/bridge/mocks"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	pkgmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contentType = "application/json"
+	userID      = "userID"
+	email       = "user@example.com"
+	otherID     = "otherID"
+	otherEmail  = "other@example.com"
+	password    = "password"
+	token       = email
+	wrongValue  = "wrong_value"
+	chanID      = "chanID"
+	brokerURL   = "tcp://broker.example.com:1883"
+)
+
+var (
+	usersList = []users.User{
+		{ID: userID, Email: email, Password: password},
+		{ID: otherID, Email: otherEmail, Password: password},
+	}
+	rules = []bridge.Rule{
+		{Direction: bridge.Inbound, Topic: "plant/+/temp", ChanID: chanID, Subtopic: "temp", QoS: 1},
+		{Direction: bridge.Outbound, Topic: "mainflux/#", ChanID: chanID, QoS: 2},
+	}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+type bridgeReq struct {
+	Name     string        `json:"name,omitempty"`
+	URL      string        `json:"url"`
+	Username string        `json:"username,omitempty"`
+	Password string        `json:"password,omitempty"`
+	Rules    []bridge.Rule `json:"rules"`
+}
+
+type bridgeRes struct {
+	ID       string        `json:"id"`
+	OwnerID  string        `json:"owner_id"`
+	Name     string        `json:"name,omitempty"`
+	URL      string        `json:"url"`
+	Username string        `json:"username,omitempty"`
+	Password string        `json:"password,omitempty"`
+	Rules    []bridge.Rule `json:"rules"`
+}
+
+type bridgesPageRes struct {
+	Total   uint64      `json:"total"`
+	Offset  uint64      `json:"offset"`
+	Limit   uint64      `json:"limit"`
+	Bridges []bridgeRes `json:"bridges"`
+}
+
+func newService() bridge.Service {
+	auth := pkgmocks.NewAuthService("", usersList)
+	things := pkgmocks.NewThingsServiceClient(map[string]string{
+		userID: chanID,
+		email:  chanID,
+	}, nil)
+
+	return bridge.New(auth, things, mocks.NewBridgeRepository(), uuid.NewMock(), mocks.NewPubSub(), mocks.NewConnector(), 0, logger.NewMock())
+}
+
+func newServer(svc bridge.Service) *httptest.Server {
+	mux := httpapi.MakeHandler(svc, mocktracer.New(), logger.NewMock())
+	return httptest.NewServer(mux)
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+func create(t *testing.T, ts *httptest.Server, b bridgeReq) string {
+	req := testRequest{
+		client:      ts.Client(),
+		method:      http.MethodPost,
+		url:         fmt.Sprintf("%s/bridges", ts.URL),
+		contentType: contentType,
+		token:       token,
+		body:        strings.NewReader(toJSON(b)),
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Equal(t, http.StatusCreated, res.StatusCode, "unexpected status")
+
+	return strings.TrimPrefix(res.Header.Get("Location"), "/bridges/")
+}
+
+func TestCreateBridge(t *testing.T) {
+	ts := newServer(newService())
+	defer ts.Close()
+
+	valid := bridgeReq{Name: "partner", URL: brokerURL, Password: password, Rules: rules}
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		token       string
+		status      int
+		location    string
+	}{
+		{
+			desc:        "create bridge",
+			req:         toJSON(valid),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/bridges/%s%012d", uuid.Prefix, 1),
+		},
+		{
+			desc:        "create bridge with invalid token",
+			req:         toJSON(valid),
+			contentType: contentType,
+			token:       wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create bridge with empty token",
+			req:         toJSON(valid),
+			contentType: contentType,
+			token:       "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create bridge of channel not owned by user",
+			req:         toJSON(valid),
+			contentType: contentType,
+			token:       otherEmail,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "create bridge with invalid URL scheme",
+			req:         toJSON(bridgeReq{URL: "http://broker.example.com", Rules: rules}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create bridge with outbound single-level wildcard",
+			req:         toJSON(bridgeReq{URL: brokerURL, Rules: []bridge.Rule{{Direction: bridge.Outbound, Topic: "a/+/b", ChanID: chanID}}}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create bridge with misplaced multi-level wildcard",
+			req:         toJSON(bridgeReq{URL: brokerURL, Rules: []bridge.Rule{{Direction: bridge.Inbound, Topic: "a/#/b", ChanID: chanID}}}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create bridge with invalid QoS",
+			req:         toJSON(bridgeReq{URL: brokerURL, Rules: []bridge.Rule{{Direction: bridge.Inbound, Topic: "a", ChanID: chanID, QoS: 3}}}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create bridge with invalid direction",
+			req:         toJSON(bridgeReq{URL: brokerURL, Rules: []bridge.Rule{{Direction: "both", Topic: "a", ChanID: chanID}}}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create bridge with invalid subtopic",
+			req:         toJSON(bridgeReq{URL: brokerURL, Rules: []bridge.Rule{{Direction: bridge.Inbound, Topic: "a", ChanID: chanID, Subtopic: "a.>"}}}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create bridge with name too long",
+			req:         toJSON(bridgeReq{Name: strings.Repeat("a", 1025), URL: brokerURL, Rules: rules}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create bridge with malformed body",
+			req:         "{",
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create bridge without content type",
+			req:         toJSON(valid),
+			contentType: "",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/bridges", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, res.Header.Get("Location")))
+	}
+}
+
+func TestViewBridge(t *testing.T) {
+	ts := newServer(newService())
+	defer ts.Close()
+
+	b := bridgeReq{Name: "partner", URL: brokerURL, Username: "user", Password: password, Rules: rules}
+	id := create(t, ts, b)
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+		res    bridgeRes
+	}{
+		{
+			desc:   "view bridge",
+			id:     id,
+			token:  token,
+			status: http.StatusOK,
+			res:    bridgeRes{ID: id, OwnerID: userID, Name: b.Name, URL: b.URL, Username: b.Username, Rules: b.Rules},
+		},
+		{
+			desc:   "view bridge with invalid token",
+			id:     id,
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view bridge of other user",
+			id:     id,
+			token:  otherEmail,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "view non-existing bridge",
+			id:     wrongValue,
+			token:  token,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/bridges/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body bridgeRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, body))
+	}
+}
+
+func TestListBridges(t *testing.T) {
+	ts := newServer(newService())
+	defer ts.Close()
+
+	n := 5
+	for i := 0; i < n; i++ {
+		create(t, ts, bridgeReq{URL: brokerURL, Rules: rules})
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list bridges",
+			url:    "/bridges",
+			token:  token,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list page of bridges",
+			url:    "/bridges?offset=1&limit=2",
+			token:  token,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list bridges of other user",
+			url:    "/bridges",
+			token:  otherEmail,
+			status: http.StatusOK,
+			size:   0,
+		},
+		{
+			desc:   "list bridges with invalid token",
+			url:    "/bridges",
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list bridges with limit too large",
+			url:    "/bridges?limit=101",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list bridges with invalid offset",
+			url:    "/bridges?offset=invalid",
+			token:  token,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    ts.URL + tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page bridgesPageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Bridges), fmt.Sprintf("%s: expected %d bridges got %d", tc.desc, tc.size, len(page.Bridges)))
+	}
+}
+
+func TestUpdateBridge(t *testing.T) {
+	ts := newServer(newService())
+	defer ts.Close()
+
+	id := create(t, ts, bridgeReq{URL: brokerURL, Rules: rules})
+	updated := toJSON(bridgeReq{Name: "updated", URL: brokerURL, Rules: rules[:1]})
+
+	cases := []struct {
+		desc        string
+		id          string
+		req         string
+		contentType string
+		token       string
+		status      int
+	}{
+		{
+			desc:        "update bridge",
+			id:          id,
+			req:         updated,
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update bridge with invalid token",
+			id:          id,
+			req:         updated,
+			contentType: contentType,
+			token:       wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "update bridge of other user",
+			id:          id,
+			req:         updated,
+			contentType: contentType,
+			token:       otherEmail,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "update non-existing bridge",
+			id:          wrongValue,
+			req:         updated,
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "update bridge with invalid rule",
+			id:          id,
+			req:         toJSON(bridgeReq{URL: brokerURL, Rules: []bridge.Rule{{Direction: bridge.Inbound, Topic: "a/#/b", ChanID: chanID}}}),
+			contentType: contentType,
+			token:       token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update bridge without content type",
+			id:          id,
+			req:         updated,
+			contentType: "",
+			token:       token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/bridges/%s", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRemoveBridge(t *testing.T) {
+	ts := newServer(newService())
+	defer ts.Close()
+
+	id := create(t, ts, bridgeReq{URL: brokerURL, Rules: rules})
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "remove bridge with invalid token",
+			id:     id,
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove bridge of other user",
+			id:     id,
+			token:  otherEmail,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "remove bridge",
+			id:     id,
+			token:  token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed bridge",
+			id:     id,
+			token:  token,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/bridges/%s", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, _ := ioutil.ReadAll(res.Body)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d: %s", tc.desc, tc.status, res.StatusCode, body))
+	}
+}
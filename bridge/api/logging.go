@@ -0,0 +1,105 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	log "github.com/MainfluxLabs/mainflux/logger"
+)
+
+var _ bridge.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger log.Logger
+	svc    bridge.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc bridge.Service, logger log.Logger) bridge.Service {
+	return &loggingMiddleware{logger, svc}
+}
+
+func (lm *loggingMiddleware) CreateBridge(ctx context.Context, token string, b bridge.Bridge) (saved bridge.Bridge, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_bridge with the id %s took %s to complete", saved.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateBridge(ctx, token, b)
+}
+
+func (lm *loggingMiddleware) ViewBridge(ctx context.Context, token, id string) (b bridge.Bridge, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_bridge for bridge %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewBridge(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListBridges(ctx context.Context, token string, pm bridge.PageMetadata) (page bridge.Page, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_bridges took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListBridges(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) UpdateBridge(ctx context.Context, token string, b bridge.Bridge) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_bridge for bridge %s took %s to complete", b.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateBridge(ctx, token, b)
+}
+
+func (lm *loggingMiddleware) RemoveBridge(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_bridge for bridge %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveBridge(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) Restore(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method restore took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Restore(ctx)
+}
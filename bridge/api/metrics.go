@@ -0,0 +1,85 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ bridge.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     bridge.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc bridge.Service, counter metrics.Counter, latency metrics.Histogram) bridge.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) CreateBridge(ctx context.Context, token string, b bridge.Bridge) (bridge.Bridge, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_bridge").Add(1)
+		ms.latency.With("method", "create_bridge").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateBridge(ctx, token, b)
+}
+
+func (ms *metricsMiddleware) ViewBridge(ctx context.Context, token, id string) (bridge.Bridge, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_bridge").Add(1)
+		ms.latency.With("method", "view_bridge").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewBridge(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListBridges(ctx context.Context, token string, pm bridge.PageMetadata) (bridge.Page, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_bridges").Add(1)
+		ms.latency.With("method", "list_bridges").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListBridges(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) UpdateBridge(ctx context.Context, token string, b bridge.Bridge) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_bridge").Add(1)
+		ms.latency.With("method", "update_bridge").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateBridge(ctx, token, b)
+}
+
+func (ms *metricsMiddleware) RemoveBridge(ctx context.Context, token, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_bridge").Add(1)
+		ms.latency.With("method", "remove_bridge").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveBridge(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) Restore(ctx context.Context) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "restore").Add(1)
+		ms.latency.With("method", "restore").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Restore(ctx)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+)
+
+const (
+	maxLimitSize = 100
+	maxNameSize  = 1024
+)
+
+type bridgeConfig struct {
+	Name     string        `json:"name,omitempty"`
+	URL      string        `json:"url"`
+	ClientID string        `json:"client_id,omitempty"`
+	Username string        `json:"username,omitempty"`
+	Password string        `json:"password,omitempty"`
+	Rules    []bridge.Rule `json:"rules"`
+}
+
+func (cfg bridgeConfig) validate() error {
+	if len(cfg.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return cfg.toBridge("").Validate()
+}
+
+func (cfg bridgeConfig) toBridge(id string) bridge.Bridge {
+	return bridge.Bridge{
+		ID:       id,
+		Name:     cfg.Name,
+		URL:      cfg.URL,
+		ClientID: cfg.ClientID,
+		Username: cfg.Username,
+		Password: cfg.Password,
+		Rules:    cfg.Rules,
+	}
+}
+
+type createBridgeReq struct {
+	token string
+	bridgeConfig
+}
+
+func (req createBridgeReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return req.bridgeConfig.validate()
+}
+
+type updateBridgeReq struct {
+	token string
+	id    string
+	bridgeConfig
+}
+
+func (req updateBridgeReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return req.bridgeConfig.validate()
+}
+
+type bridgeReq struct {
+	token string
+	id    string
+}
+
+func (req bridgeReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listBridgesReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listBridgesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.limit == 0 || req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/bridge"
+)
+
+var (
+	_ mainflux.Response = (*createBridgeRes)(nil)
+	_ mainflux.Response = (*bridgeRes)(nil)
+	_ mainflux.Response = (*bridgesPageRes)(nil)
+	_ mainflux.Response = (*updateBridgeRes)(nil)
+	_ mainflux.Response = (*removeBridgeRes)(nil)
+)
+
+type createBridgeRes struct {
+	ID string
+}
+
+func (res createBridgeRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res createBridgeRes) Headers() map[string]string {
+	return map[string]string{
+		"Location": fmt.Sprintf("/bridges/%s", res.ID),
+	}
+}
+
+func (res createBridgeRes) Empty() bool {
+	return true
+}
+
+// bridgeRes is the bridge representation, which leaves out the password of
+// the external broker.
+type bridgeRes struct {
+	ID       string        `json:"id"`
+	OwnerID  string        `json:"owner_id"`
+	Name     string        `json:"name,omitempty"`
+	URL      string        `json:"url"`
+	ClientID string        `json:"client_id,omitempty"`
+	Username string        `json:"username,omitempty"`
+	Rules    []bridge.Rule `json:"rules"`
+}
+
+func newBridgeRes(b bridge.Bridge) bridgeRes {
+	rules := b.Rules
+	if rules == nil {
+		rules = []bridge.Rule{}
+	}
+
+	return bridgeRes{
+		ID:       b.ID,
+		OwnerID:  b.OwnerID,
+		Name:     b.Name,
+		URL:      b.URL,
+		ClientID: b.ClientID,
+		Username: b.Username,
+		Rules:    rules,
+	}
+}
+
+func (res bridgeRes) Code() int {
+	return http.StatusOK
+}
+
+func (res bridgeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res bridgeRes) Empty() bool {
+	return false
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type bridgesPageRes struct {
+	pageRes
+	Bridges []bridgeRes `json:"bridges"`
+}
+
+func (res bridgesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res bridgesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res bridgesPageRes) Empty() bool {
+	return false
+}
+
+type updateBridgeRes struct{}
+
+func (res updateBridgeRes) Code() int {
+	return http.StatusOK
+}
+
+func (res updateBridgeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res updateBridgeRes) Empty() bool {
+	return true
+}
+
+type removeBridgeRes struct{}
+
+func (res removeBridgeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeBridgeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeBridgeRes) Empty() bool {
+	return true
+}
@@ -0,0 +1,182 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	// Inbound rules forward the messages of the external broker to the channel.
+	Inbound = "inbound"
+
+	// Outbound rules forward the messages of the channel to the external broker.
+	Outbound = "outbound"
+
+	maxQoS = 2
+)
+
+var (
+	// ErrMalformedBridge indicates malformed bridge configuration.
+	ErrMalformedBridge = errors.New("malformed bridge")
+
+	// ErrMalformedRule indicates malformed bridge rule.
+	ErrMalformedRule = errors.New("malformed bridge rule")
+)
+
+var schemes = map[string]bool{
+	"tcp":   true,
+	"ssl":   true,
+	"tls":   true,
+	"mqtt":  true,
+	"mqtts": true,
+	"ws":    true,
+	"wss":   true,
+}
+
+// Bridge represents the connection to the external MQTT broker, and the rules
+// of mirroring messages between its topics and the channels.
+type Bridge struct {
+	ID       string
+	OwnerID  string
+	Name     string
+	URL      string
+	ClientID string
+	Username string
+	Password string
+	Rules    []Rule
+}
+
+// Validate returns ErrMalformedBridge or ErrMalformedRule if the bridge
+// configuration is invalid.
+func (b Bridge) Validate() error {
+	u, err := url.Parse(b.URL)
+	if err != nil || !schemes[u.Scheme] || u.Host == "" {
+		return ErrMalformedBridge
+	}
+
+	for _, r := range b.Rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Rule maps the topic filter of the external broker to the channel and its
+// subtopic. Rules whose topic ends with the multi-level wildcard map the
+// levels matched by the wildcard to the subtopic levels, e.g. the inbound
+// rule of the topic "plant/#" and subtopic "plant" forwards the messages of
+// "plant/line1/temp" to the subtopic "plant.line1.temp", and vice versa.
+type Rule struct {
+	Direction string `json:"direction"`
+	Topic     string `json:"topic"`
+	ChanID    string `json:"channel"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	QoS       byte   `json:"qos"`
+}
+
+// Validate returns ErrMalformedRule if the rule is invalid. The single-level
+// wildcard is allowed only in the topics of the inbound rules, since the
+// outbound rules need the whole topic to publish to.
+func (r Rule) Validate() error {
+	if r.ChanID == "" || r.QoS > maxQoS || !validSubtopic(r.Subtopic) {
+		return ErrMalformedRule
+	}
+
+	switch r.Direction {
+	case Inbound:
+		if !validTopic(r.Topic, true) {
+			return ErrMalformedRule
+		}
+	case Outbound:
+		if !validTopic(r.Topic, false) {
+			return ErrMalformedRule
+		}
+	default:
+		return ErrMalformedRule
+	}
+
+	return nil
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Total  uint64
+	Offset uint64
+	Limit  uint64
+}
+
+// Page contains a page of bridges.
+type Page struct {
+	PageMetadata
+	Bridges []Bridge
+}
+
+// BridgeRepository specifies the bridges persistence API.
+type BridgeRepository interface {
+	// Save persists the bridge.
+	Save(ctx context.Context, b Bridge) error
+
+	// Update updates the bridge configuration.
+	Update(ctx context.Context, b Bridge) error
+
+	// RetrieveByID retrieves the bridge having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Bridge, error)
+
+	// RetrieveByOwner retrieves the page of the bridges of the owner.
+	RetrieveByOwner(ctx context.Context, ownerID string, pm PageMetadata) (Page, error)
+
+	// RetrieveAll retrieves all the bridges.
+	RetrieveAll(ctx context.Context) ([]Bridge, error)
+
+	// Remove removes the bridge having the provided identifier.
+	Remove(ctx context.Context, id string) error
+}
+
+// validTopic checks the MQTT topic filter, in which the multi-level wildcard
+// can only be the last level.
+func validTopic(topic string, wildcards bool) bool {
+	if topic == "" || strings.ContainsRune(topic, 0) {
+		return false
+	}
+
+	levels := strings.Split(topic, "/")
+	for i, l := range levels {
+		switch {
+		case l == "#":
+			if i != len(levels)-1 {
+				return false
+			}
+		case l == "+":
+			if !wildcards {
+				return false
+			}
+		case strings.ContainsAny(l, "#+"):
+			return false
+		}
+	}
+
+	return true
+}
+
+// validSubtopic checks the subtopic, which can't contain wildcards.
+func validSubtopic(subtopic string) bool {
+	if subtopic == "" {
+		return true
+	}
+
+	for _, e := range strings.Split(subtopic, ".") {
+		if e == "" || strings.ContainsAny(e, "*> \t\r\n") {
+			return false
+		}
+	}
+
+	return true
+}
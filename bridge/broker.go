@@ -0,0 +1,27 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+// Handler handles the message received on the topic of the external broker.
+type Handler func(topic string, payload []byte)
+
+// Broker represents the connection to the external MQTT broker.
+type Broker interface {
+	// Subscribe subscribes to the topic filter. Subscriptions are restored
+	// once the connection is reestablished.
+	Subscribe(topic string, qos byte, h Handler) error
+
+	// Publish publishes the payload to the topic.
+	Publish(topic string, qos byte, payload []byte) error
+
+	// Close disconnects from the broker.
+	Close() error
+}
+
+// Connector connects to the external MQTT brokers.
+type Connector interface {
+	// Connect returns the connection to the broker of the bridge, which
+	// reconnects on its own until it is closed.
+	Connect(b Bridge) (Broker, error)
+}
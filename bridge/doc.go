@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bridge contains the domain concept definitions needed to support
+// Mainflux MQTT bridge service functionality, which mirrors messages between
+// the external MQTT brokers and the channels.
+package bridge
@@ -0,0 +1,104 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+type echo struct {
+	bridgeID string
+	sum      [sha256.Size]byte
+}
+
+// echoes records the messages the bridges publish to the external brokers
+// on the topics of their inbound rules, so that they are dropped once the
+// brokers deliver them back instead of being forwarded to the channels again.
+type echoes struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	pruned  time.Time
+	expires map[echo][]time.Time
+}
+
+func newEchoes(ttl time.Duration) *echoes {
+	return &echoes{
+		ttl:     ttl,
+		pruned:  time.Now(),
+		expires: make(map[echo][]time.Time),
+	}
+}
+
+func (e *echoes) add(bridgeID, topic string, payload []byte) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(e.pruned) > e.ttl {
+		e.prune(now)
+	}
+
+	k := key(bridgeID, topic, payload)
+	e.expires[k] = append(e.expires[k], now.Add(e.ttl))
+}
+
+// seen checks if the message is the echo of the recorded one, and if so
+// removes the record.
+func (e *echoes) seen(bridgeID, topic string, payload []byte) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	k := key(bridgeID, topic, payload)
+	now := time.Now()
+	for len(e.expires[k]) > 0 {
+		exp := e.expires[k][0]
+		e.expires[k] = e.expires[k][1:]
+		if now.Before(exp) {
+			if len(e.expires[k]) == 0 {
+				delete(e.expires, k)
+			}
+			return true
+		}
+	}
+	delete(e.expires, k)
+
+	return false
+}
+
+// clear removes the records of the bridge.
+func (e *echoes) clear(bridgeID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for k := range e.expires {
+		if k.bridgeID == bridgeID {
+			delete(e.expires, k)
+		}
+	}
+}
+
+// prune removes the expired records, i.e. of the messages which the
+// brokers didn't deliver back.
+func (e *echoes) prune(now time.Time) {
+	for k, exps := range e.expires {
+		if now.After(exps[len(exps)-1]) {
+			delete(e.expires, k)
+		}
+	}
+	e.pruned = now
+}
+
+func key(bridgeID, topic string, payload []byte) echo {
+	h := sha256.New()
+	h.Write([]byte(topic))
+	h.Write([]byte{0})
+	h.Write(payload)
+
+	k := echo{bridgeID: bridgeID}
+	copy(k.sum[:], h.Sum(nil))
+
+	return k
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ bridge.BridgeRepository = (*bridgeRepositoryMock)(nil)
+
+type bridgeRepositoryMock struct {
+	mu      sync.Mutex
+	bridges map[string]bridge.Bridge
+}
+
+// NewBridgeRepository returns mock implementation of bridges repository.
+func NewBridgeRepository() bridge.BridgeRepository {
+	return &bridgeRepositoryMock{
+		bridges: make(map[string]bridge.Bridge),
+	}
+}
+
+func (brm *bridgeRepositoryMock) Save(_ context.Context, b bridge.Bridge) error {
+	brm.mu.Lock()
+	defer brm.mu.Unlock()
+
+	if _, ok := brm.bridges[b.ID]; ok {
+		return errors.ErrConflict
+	}
+
+	brm.bridges[b.ID] = b
+	return nil
+}
+
+func (brm *bridgeRepositoryMock) Update(_ context.Context, b bridge.Bridge) error {
+	brm.mu.Lock()
+	defer brm.mu.Unlock()
+
+	if _, ok := brm.bridges[b.ID]; !ok {
+		return errors.ErrNotFound
+	}
+
+	brm.bridges[b.ID] = b
+	return nil
+}
+
+func (brm *bridgeRepositoryMock) RetrieveByID(_ context.Context, id string) (bridge.Bridge, error) {
+	brm.mu.Lock()
+	defer brm.mu.Unlock()
+
+	b, ok := brm.bridges[id]
+	if !ok {
+		return bridge.Bridge{}, errors.ErrNotFound
+	}
+
+	return b, nil
+}
+
+func (brm *bridgeRepositoryMock) RetrieveByOwner(_ context.Context, ownerID string, pm bridge.PageMetadata) (bridge.Page, error) {
+	brm.mu.Lock()
+	defer brm.mu.Unlock()
+
+	var bridges []bridge.Bridge
+	for _, b := range brm.bridges {
+		if b.OwnerID == ownerID {
+			bridges = append(bridges, b)
+		}
+	}
+	sort.Slice(bridges, func(i, j int) bool {
+		return bridges[i].ID < bridges[j].ID
+	})
+
+	page := bridge.Page{
+		PageMetadata: bridge.PageMetadata{
+			Total:  uint64(len(bridges)),
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Bridges: []bridge.Bridge{},
+	}
+	if pm.Offset >= uint64(len(bridges)) {
+		return page, nil
+	}
+
+	end := pm.Offset + pm.Limit
+	if end > uint64(len(bridges)) {
+		end = uint64(len(bridges))
+	}
+	page.Bridges = bridges[pm.Offset:end]
+
+	return page, nil
+}
+
+func (brm *bridgeRepositoryMock) RetrieveAll(_ context.Context) ([]bridge.Bridge, error) {
+	brm.mu.Lock()
+	defer brm.mu.Unlock()
+
+	var bridges []bridge.Bridge
+	for _, b := range brm.bridges {
+		bridges = append(bridges, b)
+	}
+
+	return bridges, nil
+}
+
+func (brm *bridgeRepositoryMock) Remove(_ context.Context, id string) error {
+	brm.mu.Lock()
+	defer brm.mu.Unlock()
+
+	delete(brm.bridges, id)
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// Message is the message published to the external broker.
+type Message struct {
+	Topic   string
+	QoS     byte
+	Payload []byte
+}
+
+type subscription struct {
+	filter  string
+	handler bridge.Handler
+}
+
+// Broker is a mock external MQTT broker which records the published messages
+// and lets the tests deliver the messages to the subscriptions. Messages
+// published on the subscribed topics are delivered back, as a real broker
+// would do.
+type Broker struct {
+	mu        sync.Mutex
+	closed    bool
+	subs      []subscription
+	published []Message
+}
+
+var _ bridge.Broker = (*Broker)(nil)
+
+// Subscribe records the subscription.
+func (b *Broker) Subscribe(topic string, _ byte, h bridge.Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.subs = append(b.subs, subscription{filter: topic, handler: h})
+	return nil
+}
+
+// Publish records the message and delivers it to the matching subscriptions.
+func (b *Broker) Publish(topic string, qos byte, payload []byte) error {
+	b.mu.Lock()
+	b.published = append(b.published, Message{Topic: topic, QoS: qos, Payload: payload})
+	b.mu.Unlock()
+
+	b.Deliver(topic, payload)
+	return nil
+}
+
+// Close closes the connection.
+func (b *Broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	b.subs = nil
+	return nil
+}
+
+// Deliver delivers the message to the subscriptions matching the topic.
+func (b *Broker) Deliver(topic string, payload []byte) {
+	b.mu.Lock()
+	var hs []bridge.Handler
+	for _, s := range b.subs {
+		if matches(s.filter, topic) {
+			hs = append(hs, s.handler)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, h := range hs {
+		h(topic, payload)
+	}
+}
+
+// Published returns the messages published to the broker, in order.
+func (b *Broker) Published() []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]Message{}, b.published...)
+}
+
+// Closed checks if the connection is closed.
+func (b *Broker) Closed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.closed
+}
+
+// UnreachableURL is the URL of the external broker the mock connector fails
+// to connect to.
+const UnreachableURL = "tcp://unreachable.example.com:1883"
+
+var errUnreachable = errors.New("broker unreachable")
+
+// Connector is a mock connector which connects the bridges to the mock brokers.
+type Connector struct {
+	mu      sync.Mutex
+	fail    bool
+	brokers map[string]*Broker
+}
+
+var _ bridge.Connector = (*Connector)(nil)
+
+// NewConnector returns mock connector instance.
+func NewConnector() *Connector {
+	return &Connector{
+		brokers: make(map[string]*Broker),
+	}
+}
+
+// Connect returns the new mock broker connection of the bridge.
+func (c *Connector) Connect(b bridge.Bridge) (bridge.Broker, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.fail || b.URL == UnreachableURL {
+		return nil, errUnreachable
+	}
+
+	broker := &Broker{}
+	c.brokers[b.ID] = broker
+	return broker, nil
+}
+
+// Fail makes the connector fail to connect any bridge until it's called
+// with false.
+func (c *Connector) Fail(fail bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.fail = fail
+}
+
+// Broker returns the last broker connection of the bridge.
+func (c *Connector) Broker(bridgeID string) *Broker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.brokers[bridgeID]
+}
@@ -0,0 +1,150 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const chansPrefix = "channels"
+
+var _ messaging.PubSub = (*PubSub)(nil)
+
+// PubSub is a mock message broker which delivers the published messages
+// to the handlers subscribed to the matching subjects, and records them.
+type PubSub struct {
+	mu       sync.Mutex
+	subs     map[string]map[string]messaging.MessageHandler
+	messages []messaging.Message
+}
+
+// NewPubSub returns mock message publisher-subscriber.
+func NewPubSub() *PubSub {
+	return &PubSub{
+		subs: make(map[string]map[string]messaging.MessageHandler),
+	}
+}
+
+// Publish records the message and delivers it to the subscriptions.
+func (ps *PubSub) Publish(_ string, msg messaging.Message) error {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, msg.Channel)
+	if msg.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+	}
+
+	ps.mu.Lock()
+	ps.messages = append(ps.messages, msg)
+	var hs []messaging.MessageHandler
+	for s, ids := range ps.subs {
+		if matchesSubject(s, subject) {
+			for _, h := range ids {
+				hs = append(hs, h)
+			}
+		}
+	}
+	ps.mu.Unlock()
+
+	for _, h := range hs {
+		if err := h.Handle(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Subscribe subscribes the handler to the subject.
+func (ps *PubSub) Subscribe(id, subject string, h messaging.MessageHandler) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if ps.subs[subject] == nil {
+		ps.subs[subject] = make(map[string]messaging.MessageHandler)
+	}
+	ps.subs[subject][id] = h
+	return nil
+}
+
+// Unsubscribe removes the subscription.
+func (ps *PubSub) Unsubscribe(id, subject string) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	delete(ps.subs[subject], id)
+	if len(ps.subs[subject]) == 0 {
+		delete(ps.subs, subject)
+	}
+	return nil
+}
+
+// Close does nothing.
+func (ps *PubSub) Close() error {
+	return nil
+}
+
+// Messages returns the messages published on the channel, in order.
+func (ps *PubSub) Messages(chanID string) []messaging.Message {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	var msgs []messaging.Message
+	for _, msg := range ps.messages {
+		if msg.Channel == chanID {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	return msgs
+}
+
+// Subscriptions returns the number of the subscriptions.
+func (ps *PubSub) Subscriptions() int {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	n := 0
+	for _, ids := range ps.subs {
+		n += len(ids)
+	}
+	return n
+}
+
+// matchesSubject checks if the subject matches the NATS subject filter,
+// in which the full wildcard matches one or more tokens.
+func matchesSubject(filter, subject string) bool {
+	fl, sl := strings.Split(filter, "."), strings.Split(subject, ".")
+	for i, f := range fl {
+		switch {
+		case f == ">":
+			return len(sl) > i
+		case i == len(sl):
+			return false
+		case f != "*" && f != sl[i]:
+			return false
+		}
+	}
+
+	return len(fl) == len(sl)
+}
+
+// matches checks if the topic matches the MQTT topic filter.
+func matches(filter, topic string) bool {
+	fl, tl := strings.Split(filter, "/"), strings.Split(topic, "/")
+	for i, f := range fl {
+		switch {
+		case f == "#":
+			return true
+		case i == len(tl):
+			return false
+		case f != "+" && f != tl[i]:
+			return false
+		}
+	}
+
+	return len(fl) == len(tl)
+}
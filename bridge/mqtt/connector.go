@@ -0,0 +1,156 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	mfmqtt "github.com/MainfluxLabs/mainflux/pkg/messaging/mqtt"
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	clientIDPrefix       = "mainflux-bridge-"
+	disconnectQuiesce    = 250
+	maxReconnectInterval = time.Minute
+)
+
+var errPublishTimeout = errors.New("failed to publish due to timeout reached")
+
+var _ bridge.Connector = (*connector)(nil)
+
+type connector struct {
+	timeout time.Duration
+	logger  logger.Logger
+}
+
+// NewConnector returns the connector of the bridges to the external MQTT
+// brokers, which waits for the broker operations up to the timeout.
+func NewConnector(timeout time.Duration, logger logger.Logger) bridge.Connector {
+	return connector{
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+func (c connector) Connect(b bridge.Bridge) (bridge.Broker, error) {
+	br := &broker{
+		bridgeID: b.ID,
+		timeout:  c.timeout,
+		logger:   c.logger,
+		subs:     make(map[string]subscription),
+	}
+
+	clientID := b.ClientID
+	if clientID == "" {
+		clientID = clientIDPrefix + b.ID
+	}
+
+	// The session is kept by the broker, so that the messages of QoS 1 and 2
+	// published while the bridge is disconnected are delivered once it
+	// reconnects. Subscriptions are renewed on each connect anyway, since
+	// the broker may have dropped the session in the meantime.
+	opts := paho.NewClientOptions().
+		AddBroker(b.URL).
+		SetClientID(clientID).
+		SetUsername(b.Username).
+		SetPassword(b.Password).
+		SetCleanSession(false).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetMaxReconnectInterval(maxReconnectInterval).
+		SetConnectTimeout(c.timeout).
+		SetOnConnectHandler(br.onConnect).
+		SetConnectionLostHandler(br.onConnectionLost)
+	br.client = paho.NewClient(opts)
+
+	// The connection is retried in the background until it succeeds or the
+	// broker is closed, so the bridge starts even if the broker is down.
+	token := br.client.Connect()
+	go func() {
+		if token.Wait() && token.Error() != nil {
+			c.logger.Warn(fmt.Sprintf("Bridge %s failed to connect to broker: %s", b.ID, token.Error()))
+		}
+	}()
+
+	return br, nil
+}
+
+type subscription struct {
+	qos     byte
+	handler bridge.Handler
+}
+
+type broker struct {
+	bridgeID string
+	client   paho.Client
+	timeout  time.Duration
+	logger   logger.Logger
+	mu       sync.Mutex
+	subs     map[string]subscription
+}
+
+func (br *broker) Subscribe(topic string, qos byte, h bridge.Handler) error {
+	br.mu.Lock()
+	br.subs[topic] = subscription{qos: qos, handler: h}
+	br.mu.Unlock()
+
+	// Otherwise, the topic is subscribed to once the bridge connects.
+	if !br.client.IsConnectionOpen() {
+		return nil
+	}
+
+	return br.subscribe(topic, qos, h)
+}
+
+func (br *broker) Publish(topic string, qos byte, payload []byte) error {
+	token := br.client.Publish(topic, qos, false, payload)
+	if ok := token.WaitTimeout(br.timeout); !ok {
+		return errPublishTimeout
+	}
+
+	return token.Error()
+}
+
+func (br *broker) Close() error {
+	br.client.Disconnect(disconnectQuiesce)
+	return nil
+}
+
+func (br *broker) onConnect(_ paho.Client) {
+	br.logger.Info(fmt.Sprintf("Bridge %s connected to broker", br.bridgeID))
+
+	br.mu.Lock()
+	subs := make(map[string]subscription, len(br.subs))
+	for topic, s := range br.subs {
+		subs[topic] = s
+	}
+	br.mu.Unlock()
+
+	for topic, s := range subs {
+		if err := br.subscribe(topic, s.qos, s.handler); err != nil {
+			br.logger.Warn(fmt.Sprintf("Bridge %s failed to subscribe to %s: %s", br.bridgeID, topic, err))
+		}
+	}
+}
+
+func (br *broker) onConnectionLost(_ paho.Client, err error) {
+	br.logger.Warn(fmt.Sprintf("Bridge %s lost connection to broker: %s", br.bridgeID, err))
+}
+
+func (br *broker) subscribe(topic string, qos byte, h bridge.Handler) error {
+	token := br.client.Subscribe(topic, qos, func(_ paho.Client, m paho.Message) {
+		h(m.Topic(), m.Payload())
+	})
+	if ok := token.WaitTimeout(br.timeout); !ok {
+		return mfmqtt.ErrSubscribeTimeout
+	}
+
+	return token.Error()
+}
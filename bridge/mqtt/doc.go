@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mqtt contains the connector of the bridges to the external MQTT
+// brokers.
+package mqtt
@@ -0,0 +1,212 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ bridge.BridgeRepository = (*bridgeRepository)(nil)
+
+type bridgeRepository struct {
+	db Database
+}
+
+// NewBridgeRepository instantiates a PostgreSQL implementation of bridges repository.
+func NewBridgeRepository(db Database) bridge.BridgeRepository {
+	return &bridgeRepository{
+		db: db,
+	}
+}
+
+func (br bridgeRepository) Save(ctx context.Context, b bridge.Bridge) error {
+	q := `INSERT INTO bridges (id, owner_id, name, url, client_id, username, password, rules)
+		VALUES (:id, :owner_id, :name, :url, :client_id, :username, :password, :rules)`
+
+	dbb, err := toDBBridge(b)
+	if err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	if _, err := br.db.NamedExecContext(ctx, q, dbb); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (br bridgeRepository) Update(ctx context.Context, b bridge.Bridge) error {
+	q := `UPDATE bridges SET name = :name, url = :url, client_id = :client_id, username = :username,
+		password = :password, rules = :rules WHERE id = :id`
+
+	dbb, err := toDBBridge(b)
+	if err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	res, err := br.db.NamedExecContext(ctx, q, dbb)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (br bridgeRepository) RetrieveByID(ctx context.Context, id string) (bridge.Bridge, error) {
+	q := `SELECT id, owner_id, name, url, client_id, username, password, rules FROM bridges WHERE id = $1`
+
+	dbb := dbBridge{}
+	if err := br.db.QueryRowxContext(ctx, q, id).StructScan(&dbb); err != nil {
+		if err == sql.ErrNoRows {
+			return bridge.Bridge{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return bridge.Bridge{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toBridge(dbb)
+}
+
+func (br bridgeRepository) RetrieveByOwner(ctx context.Context, ownerID string, pm bridge.PageMetadata) (bridge.Page, error) {
+	q := `SELECT id, owner_id, name, url, client_id, username, password, rules FROM bridges
+		WHERE owner_id = :owner_id ORDER BY id LIMIT :limit OFFSET :offset`
+
+	params := map[string]interface{}{
+		"owner_id": ownerID,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+
+	bridges, err := br.retrieve(ctx, q, params)
+	if err != nil {
+		return bridge.Page{}, err
+	}
+
+	cq := `SELECT COUNT(*) FROM bridges WHERE owner_id = $1`
+	var total uint64
+	if err := br.db.GetContext(ctx, &total, cq, ownerID); err != nil {
+		return bridge.Page{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := bridge.Page{
+		PageMetadata: bridge.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Bridges: bridges,
+	}
+
+	return page, nil
+}
+
+func (br bridgeRepository) RetrieveAll(ctx context.Context) ([]bridge.Bridge, error) {
+	q := `SELECT id, owner_id, name, url, client_id, username, password, rules FROM bridges`
+
+	return br.retrieve(ctx, q, map[string]interface{}{})
+}
+
+func (br bridgeRepository) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM bridges WHERE id = :id`
+
+	if _, err := br.db.NamedExecContext(ctx, q, dbBridge{ID: id}); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (br bridgeRepository) retrieve(ctx context.Context, query string, params interface{}) ([]bridge.Bridge, error) {
+	rows, err := br.db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	bridges := []bridge.Bridge{}
+	for rows.Next() {
+		dbb := dbBridge{}
+		if err := rows.StructScan(&dbb); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		b, err := toBridge(dbb)
+		if err != nil {
+			return nil, err
+		}
+		bridges = append(bridges, b)
+	}
+
+	return bridges, nil
+}
+
+type dbBridge struct {
+	ID       string `db:"id"`
+	OwnerID  string `db:"owner_id"`
+	Name     string `db:"name"`
+	URL      string `db:"url"`
+	ClientID string `db:"client_id"`
+	Username string `db:"username"`
+	Password string `db:"password"`
+	Rules    []byte `db:"rules"`
+}
+
+func toDBBridge(b bridge.Bridge) (dbBridge, error) {
+	rules := b.Rules
+	if rules == nil {
+		rules = []bridge.Rule{}
+	}
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return dbBridge{}, err
+	}
+
+	return dbBridge{
+		ID:       b.ID,
+		OwnerID:  b.OwnerID,
+		Name:     b.Name,
+		URL:      b.URL,
+		ClientID: b.ClientID,
+		Username: b.Username,
+		Password: b.Password,
+		Rules:    data,
+	}, nil
+}
+
+func toBridge(dbb dbBridge) (bridge.Bridge, error) {
+	var rules []bridge.Rule
+	if err := json.Unmarshal(dbb.Rules, &rules); err != nil {
+		return bridge.Bridge{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return bridge.Bridge{
+		ID:       dbb.ID,
+		OwnerID:  dbb.OwnerID,
+		Name:     dbb.Name,
+		URL:      dbb.URL,
+		ClientID: dbb.ClientID,
+		Username: dbb.Username,
+		Password: dbb.Password,
+		Rules:    rules,
+	}, nil
+}
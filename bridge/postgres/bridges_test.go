@@ -0,0 +1,194 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/bridge/postgres"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const numBridges = 10
+
+func newBridge(t *testing.T, ownerID string) bridge.Bridge {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return bridge.Bridge{
+		ID:       id,
+		OwnerID:  ownerID,
+		Name:     "partner",
+		URL:      "tcp://broker.example.com:1883",
+		Username: "user",
+		Password: "password",
+		Rules: []bridge.Rule{
+			{Direction: bridge.Inbound, Topic: "plant/#", ChanID: "chanID", Subtopic: "plant", QoS: 1},
+			{Direction: bridge.Outbound, Topic: "mainflux/out", ChanID: "chanID", QoS: 2},
+		},
+	}
+}
+
+func TestSaveBridge(t *testing.T) {
+	repo := postgres.NewBridgeRepository(postgres.NewDatabase(db))
+	b := newBridge(t, "owner")
+
+	cases := []struct {
+		desc   string
+		bridge bridge.Bridge
+		err    error
+	}{
+		{
+			desc:   "save bridge",
+			bridge: b,
+			err:    nil,
+		},
+		{
+			desc:   "save existing bridge",
+			bridge: b,
+			err:    errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.bridge)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUpdateBridge(t *testing.T) {
+	repo := postgres.NewBridgeRepository(postgres.NewDatabase(db))
+	b := newBridge(t, "owner")
+	err := repo.Save(context.Background(), b)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	updated := b
+	updated.Name = "updated"
+	updated.Rules = []bridge.Rule{{Direction: bridge.Inbound, Topic: "plant/+/temp", ChanID: "otherChanID"}}
+
+	cases := []struct {
+		desc   string
+		bridge bridge.Bridge
+		err    error
+	}{
+		{
+			desc:   "update bridge",
+			bridge: updated,
+			err:    nil,
+		},
+		{
+			desc:   "update non-existing bridge",
+			bridge: newBridge(t, "owner"),
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Update(context.Background(), tc.bridge)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	res, err := repo.RetrieveByID(context.Background(), b.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, updated, res, fmt.Sprintf("expected %v got %v", updated, res))
+}
+
+func TestRetrieveBridgeByID(t *testing.T) {
+	repo := postgres.NewBridgeRepository(postgres.NewDatabase(db))
+	b := newBridge(t, "owner")
+	err := repo.Save(context.Background(), b)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		bridge bridge.Bridge
+		err    error
+	}{
+		{
+			desc:   "retrieve bridge",
+			id:     b.ID,
+			bridge: b,
+			err:    nil,
+		},
+		{
+			desc:   "retrieve non-existing bridge",
+			id:     "non-existing",
+			bridge: bridge.Bridge{},
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := repo.RetrieveByID(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.bridge, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.bridge, res))
+	}
+}
+
+func TestRetrieveBridgesByOwner(t *testing.T) {
+	repo := postgres.NewBridgeRepository(postgres.NewDatabase(db))
+	ownerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i := 0; i < numBridges; i++ {
+		err := repo.Save(context.Background(), newBridge(t, ownerID))
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc    string
+		ownerID string
+		pm      bridge.PageMetadata
+		size    int
+		total   uint64
+	}{
+		{
+			desc:    "retrieve all bridges of owner",
+			ownerID: ownerID,
+			pm:      bridge.PageMetadata{Limit: numBridges},
+			size:    numBridges,
+			total:   numBridges,
+		},
+		{
+			desc:    "retrieve page of bridges of owner",
+			ownerID: ownerID,
+			pm:      bridge.PageMetadata{Offset: numBridges - 3, Limit: 5},
+			size:    3,
+			total:   numBridges,
+		},
+		{
+			desc:    "retrieve bridges of owner without bridges",
+			ownerID: "non-existing",
+			pm:      bridge.PageMetadata{Limit: numBridges},
+			size:    0,
+			total:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveByOwner(context.Background(), tc.ownerID, tc.pm)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Bridges), fmt.Sprintf("%s: expected %d bridges got %d\n", tc.desc, tc.size, len(page.Bridges)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestRemoveBridge(t *testing.T) {
+	repo := postgres.NewBridgeRepository(postgres.NewDatabase(db))
+	b := newBridge(t, "owner")
+	err := repo.Save(context.Background(), b)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), b.ID)
+	assert.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	_, err = repo.RetrieveByID(context.Background(), b.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("expected %s got %s", errors.ErrNotFound, err))
+}
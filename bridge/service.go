@@ -0,0 +1,505 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Protocol is the protocol of the messages forwarded to the channels.
+const Protocol = "bridge"
+
+const echoTTL = time.Minute
+
+// ErrConnect indicates failure to start mirroring the messages of the bridge.
+var ErrConnect = errors.New("failed to connect bridge")
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateBridge persists the bridge of the user and starts mirroring
+	// the messages according to its rules.
+	CreateBridge(ctx context.Context, token string, b Bridge) (Bridge, error)
+
+	// ViewBridge retrieves the bridge of the user having the provided identifier.
+	ViewBridge(ctx context.Context, token, id string) (Bridge, error)
+
+	// ListBridges retrieves the page of the bridges of the user.
+	ListBridges(ctx context.Context, token string, pm PageMetadata) (Page, error)
+
+	// UpdateBridge updates the bridge of the user and restarts mirroring
+	// the messages according to its new rules. The password of the external
+	// broker is kept unless the new one is provided.
+	UpdateBridge(ctx context.Context, token string, b Bridge) error
+
+	// RemoveBridge stops mirroring the messages of the bridge of the user,
+	// and removes it.
+	RemoveBridge(ctx context.Context, token, id string) error
+
+	// Restore starts mirroring the messages of all the stored bridges, e.g. on
+	// start. Until the context is done, the bridges are checked periodically:
+	// the ones which failed to start are started again, and the ones whose
+	// owner no longer owns the channels of their rules are stopped.
+	Restore(ctx context.Context) error
+}
+
+var _ Service = (*bridgeService)(nil)
+
+// connection is the running bridge, i.e. its external broker connection
+// and the subscriptions to the channels of its outbound rules.
+type connection struct {
+	broker Broker
+	subs   []subscription
+}
+
+type subscription struct {
+	id      string
+	subject string
+}
+
+type bridgeService struct {
+	auth      mainflux.AuthServiceClient
+	things    mainflux.ThingsServiceClient
+	bridges   BridgeRepository
+	idp       mainflux.IDProvider
+	pubsub    messaging.PubSub
+	connector Connector
+	interval  time.Duration
+	echoes    *echoes
+	logger    logger.Logger
+	mu        sync.Mutex
+	conns     map[string]connection
+
+	// ops serializes starting and stopping the bridges between the API
+	// requests and the periodic checks.
+	ops sync.Mutex
+}
+
+// New instantiates the bridge service implementation, which checks the
+// bridges restored on start every interval.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, bridges BridgeRepository, idp mainflux.IDProvider, pubsub messaging.PubSub, connector Connector, interval time.Duration, logger logger.Logger) Service {
+	return &bridgeService{
+		auth:      auth,
+		things:    things,
+		bridges:   bridges,
+		idp:       idp,
+		pubsub:    pubsub,
+		connector: connector,
+		interval:  interval,
+		echoes:    newEchoes(echoTTL),
+		logger:    logger,
+		conns:     make(map[string]connection),
+	}
+}
+
+func (bs *bridgeService) CreateBridge(ctx context.Context, token string, b Bridge) (Bridge, error) {
+	res, err := bs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Bridge{}, err
+	}
+
+	if err := bs.authorize(ctx, token, res, b.Rules); err != nil {
+		return Bridge{}, err
+	}
+
+	b.ID, err = bs.idp.ID()
+	if err != nil {
+		return Bridge{}, err
+	}
+	b.OwnerID = res.GetId()
+
+	bs.ops.Lock()
+	defer bs.ops.Unlock()
+
+	if err := bs.bridges.Save(ctx, b); err != nil {
+		return Bridge{}, err
+	}
+
+	if err := bs.start(b); err != nil {
+		if err := bs.bridges.Remove(ctx, b.ID); err != nil {
+			bs.logger.Warn(fmt.Sprintf("Failed to remove bridge %s: %s", b.ID, err))
+		}
+		return Bridge{}, err
+	}
+
+	return b, nil
+}
+
+func (bs *bridgeService) ViewBridge(ctx context.Context, token, id string) (Bridge, error) {
+	res, err := bs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Bridge{}, err
+	}
+
+	return bs.retrieve(ctx, res.GetId(), id)
+}
+
+func (bs *bridgeService) ListBridges(ctx context.Context, token string, pm PageMetadata) (Page, error) {
+	res, err := bs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Page{}, err
+	}
+
+	return bs.bridges.RetrieveByOwner(ctx, res.GetId(), pm)
+}
+
+func (bs *bridgeService) UpdateBridge(ctx context.Context, token string, b Bridge) error {
+	res, err := bs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return err
+	}
+
+	current, err := bs.retrieve(ctx, res.GetId(), b.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := bs.authorize(ctx, token, res, b.Rules); err != nil {
+		return err
+	}
+
+	if b.Password == "" {
+		b.Password = current.Password
+	}
+	b.OwnerID = res.GetId()
+
+	bs.ops.Lock()
+	defer bs.ops.Unlock()
+
+	// The new configuration is stored only once the bridge runs with it,
+	// otherwise the bridge keeps running with the current one.
+	bs.stop(b.ID)
+	if err := bs.start(b); err != nil {
+		bs.restart(current)
+		return err
+	}
+
+	if err := bs.bridges.Update(ctx, b); err != nil {
+		bs.stop(b.ID)
+		bs.restart(current)
+		return err
+	}
+
+	return nil
+}
+
+func (bs *bridgeService) RemoveBridge(ctx context.Context, token, id string) error {
+	res, err := bs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return err
+	}
+
+	if _, err := bs.retrieve(ctx, res.GetId(), id); err != nil {
+		return err
+	}
+
+	bs.ops.Lock()
+	defer bs.ops.Unlock()
+
+	if err := bs.bridges.Remove(ctx, id); err != nil {
+		return err
+	}
+
+	bs.stop(id)
+	return nil
+}
+
+func (bs *bridgeService) Restore(ctx context.Context) error {
+	bridges, err := bs.bridges.RetrieveAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Bridges failing to start don't prevent the others from starting, and
+	// are started again by the periodic checks.
+	for _, b := range bridges {
+		bs.check(ctx, b.ID)
+	}
+
+	if bs.interval > 0 {
+		go bs.watch(ctx)
+	}
+
+	return nil
+}
+
+// watch checks the stored bridges every interval until the context is done.
+func (bs *bridgeService) watch(ctx context.Context) {
+	ticker := time.NewTicker(bs.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			bridges, err := bs.bridges.RetrieveAll(ctx)
+			if err != nil {
+				bs.logger.Warn(fmt.Sprintf("Failed to retrieve bridges: %s", err))
+				continue
+			}
+			for _, b := range bridges {
+				bs.check(ctx, b.ID)
+			}
+		}
+	}
+}
+
+// check stops the bridge if its owner no longer owns the channels of its
+// rules, and otherwise starts it unless it is already running.
+func (bs *bridgeService) check(ctx context.Context, id string) {
+	bs.ops.Lock()
+	defer bs.ops.Unlock()
+
+	// The bridge may have been updated or removed in the meantime.
+	b, err := bs.bridges.RetrieveByID(ctx, id)
+	if err != nil {
+		if !errors.Contains(err, errors.ErrNotFound) {
+			bs.logger.Warn(fmt.Sprintf("Failed to retrieve bridge %s: %s", id, err))
+		}
+		return
+	}
+
+	if err := bs.owns(ctx, b); err != nil {
+		if !denied(err) {
+			bs.logger.Warn(fmt.Sprintf("Failed to check channels of bridge %s: %s", id, err))
+			return
+		}
+		if bs.running(id) {
+			bs.logger.Warn(fmt.Sprintf("Stopped bridge %s whose owner lost access to its channels: %s", id, err))
+			bs.stop(id)
+		}
+		return
+	}
+
+	if bs.running(id) {
+		return
+	}
+	if err := bs.start(b); err != nil {
+		bs.logger.Error(fmt.Sprintf("Failed to start bridge %s, retrying in %s: %s", id, bs.interval, err))
+	}
+}
+
+// owns checks that the owner of the bridge still owns the channels of its
+// rules. Unlike authorize, it doesn't need the token of the owner.
+func (bs *bridgeService) owns(ctx context.Context, b Bridge) error {
+	for _, r := range b.Rules {
+		if _, err := bs.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: b.OwnerID, ChanID: r.ChanID}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (bs *bridgeService) running(id string) bool {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	_, ok := bs.conns[id]
+	return ok
+}
+
+// denied reports whether the things service denied the access, as opposed
+// to failing to check it.
+func denied(err error) bool {
+	if errors.Contains(err, errors.ErrAuthorization) || errors.Contains(err, errors.ErrNotFound) {
+		return true
+	}
+
+	switch status.Code(err) {
+	case codes.PermissionDenied, codes.NotFound:
+		return true
+	default:
+		return false
+	}
+}
+
+// retrieve returns the bridge if it's owned by the user.
+func (bs *bridgeService) retrieve(ctx context.Context, userID, id string) (Bridge, error) {
+	b, err := bs.bridges.RetrieveByID(ctx, id)
+	if err != nil {
+		return Bridge{}, err
+	}
+
+	if b.OwnerID != userID {
+		return Bridge{}, errors.ErrAuthorization
+	}
+
+	return b, nil
+}
+
+// authorize checks that the user owns the channels of the rules, and can read
+// the channels the outbound rules mirror with the token, which may be an API
+// key restricted to some of the channels. Ownership is required in both
+// directions, so that it can be checked again when no token is at hand.
+func (bs *bridgeService) authorize(ctx context.Context, token string, user *mainflux.UserIdentity, rules []Rule) error {
+	for _, r := range rules {
+		switch r.Direction {
+		case Inbound:
+			if !auth.HasScope(user.GetScopes(), auth.MessagesWriteScope) || !auth.HasResource(user.GetChannelIDs(), r.ChanID) {
+				return errors.ErrAuthorization
+			}
+
+			if _, err := bs.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: user.GetId(), ChanID: r.ChanID}); err != nil {
+				return err
+			}
+		case Outbound:
+			if _, err := bs.things.CanReadChannel(ctx, &mainflux.ChannelReadReq{Token: token, ChanID: r.ChanID}); err != nil {
+				return err
+			}
+
+			if _, err := bs.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: user.GetId(), ChanID: r.ChanID}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// start connects to the external broker of the bridge and subscribes to
+// the topics and the channels of its rules.
+func (bs *bridgeService) start(b Bridge) error {
+	broker, err := bs.connector.Connect(b)
+	if err != nil {
+		return errors.Wrap(ErrConnect, err)
+	}
+
+	conn := connection{broker: broker}
+	for i, r := range b.Rules {
+		switch r.Direction {
+		case Inbound:
+			err = broker.Subscribe(r.Topic, r.QoS, bs.inbound(b, r))
+		case Outbound:
+			h := outboundHandler{svc: bs, bridge: b, rule: r, broker: broker}
+			id := fmt.Sprintf("%s-%d", b.ID, i)
+			for _, subject := range subjects(r) {
+				if err = bs.pubsub.Subscribe(id, subject, h); err != nil {
+					break
+				}
+				conn.subs = append(conn.subs, subscription{id: id, subject: subject})
+			}
+		}
+		if err != nil {
+			bs.close(conn)
+			return errors.Wrap(ErrConnect, err)
+		}
+	}
+
+	bs.mu.Lock()
+	bs.conns[b.ID] = conn
+	bs.mu.Unlock()
+
+	return nil
+}
+
+// restart starts the bridge with the configuration it ran with before the
+// failed update.
+func (bs *bridgeService) restart(b Bridge) {
+	if err := bs.start(b); err != nil {
+		bs.logger.Error(fmt.Sprintf("Failed to restart bridge %s: %s", b.ID, err))
+	}
+}
+
+// stop stops mirroring the messages of the bridge.
+func (bs *bridgeService) stop(id string) {
+	bs.mu.Lock()
+	conn, ok := bs.conns[id]
+	delete(bs.conns, id)
+	bs.mu.Unlock()
+
+	if ok {
+		bs.close(conn)
+	}
+	bs.echoes.clear(id)
+}
+
+func (bs *bridgeService) close(conn connection) {
+	for _, s := range conn.subs {
+		if err := bs.pubsub.Unsubscribe(s.id, s.subject); err != nil {
+			bs.logger.Warn(fmt.Sprintf("Failed to unsubscribe from %s: %s", s.subject, err))
+		}
+	}
+
+	if err := conn.broker.Close(); err != nil {
+		bs.logger.Warn(fmt.Sprintf("Failed to close broker connection: %s", err))
+	}
+}
+
+// inbound returns the handler which publishes the messages received on the
+// topic of the external broker to the channel of the rule, except for the
+// ones which the bridge has just published to the broker itself.
+func (bs *bridgeService) inbound(b Bridge, r Rule) Handler {
+	return func(topic string, payload []byte) {
+		if bs.echoes.seen(b.ID, topic, payload) {
+			return
+		}
+
+		subtopic, ok := inboundSubtopic(r, topic)
+		if !ok {
+			bs.logger.Warn(fmt.Sprintf("Bridge %s dropped message of topic %s which doesn't map to subtopic", b.ID, topic))
+			return
+		}
+
+		msg := messaging.Message{
+			Channel:   r.ChanID,
+			Subtopic:  subtopic,
+			Publisher: b.ID,
+			Protocol:  Protocol,
+			Payload:   payload,
+			Created:   time.Now().UnixNano(),
+		}
+		if err := bs.pubsub.Publish(r.ChanID, msg); err != nil {
+			bs.logger.Warn(fmt.Sprintf("Bridge %s failed to publish message to channel %s: %s", b.ID, r.ChanID, err))
+		}
+	}
+}
+
+// outboundHandler publishes the messages of the channel to the topic of the
+// external broker mapped by the rule. Messages the bridge has forwarded to
+// the channel itself are skipped, so they don't loop back to the broker.
+type outboundHandler struct {
+	svc    *bridgeService
+	bridge Bridge
+	rule   Rule
+	broker Broker
+}
+
+func (h outboundHandler) Handle(msg messaging.Message) error {
+	if msg.Protocol == Protocol && msg.Publisher == h.bridge.ID {
+		return nil
+	}
+
+	topic, ok := outboundTopic(h.rule, msg.Subtopic)
+	if !ok {
+		return nil
+	}
+
+	// The broker delivers the message back if it matches any of the
+	// inbound rules of the bridge, so it's recorded to be dropped then.
+	for _, r := range h.bridge.Rules {
+		if r.Direction == Inbound && matches(r.Topic, topic) {
+			h.svc.echoes.add(h.bridge.ID, topic, msg.Payload)
+			break
+		}
+	}
+
+	return h.broker.Publish(topic, h.rule.QoS, msg.Payload)
+}
+
+func (h outboundHandler) Cancel() error {
+	return nil
+}
@@ -0,0 +1,571 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/bridge"
+	"github.com/MainfluxLabs/mainflux/bridge/mocks"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	pkgmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userID     = "userID"
+	userEmail  = "user@example.com"
+	otherID    = "otherID"
+	otherEmail = "other@example.com"
+	wrongValue = "wrong-value"
+	chanID     = "chanID"
+	otherChan  = "otherChanID"
+	brokerURL  = "tcp://broker.example.com:1883"
+	password   = "password"
+	readKey    = "read-key"
+)
+
+var (
+	usersList = []users.User{
+		{ID: userID, Email: userEmail, Password: password},
+		{ID: otherID, Email: otherEmail, Password: password},
+	}
+
+	inbound  = bridge.Rule{Direction: bridge.Inbound, Topic: "plant/+/temp", ChanID: chanID, Subtopic: "temp", QoS: 1}
+	outbound = bridge.Rule{Direction: bridge.Outbound, Topic: "mainflux/#", ChanID: chanID, Subtopic: "out", QoS: 2}
+	b        = bridge.Bridge{Name: "partner", URL: brokerURL, Rules: []bridge.Rule{inbound, outbound}}
+)
+
+func newService() (bridge.Service, *mocks.Connector, *mocks.PubSub) {
+	keys := map[string]*mainflux.UserIdentity{
+		readKey: {Id: userID, Email: userEmail, Scopes: []string{auth.MessagesReadScope}},
+	}
+	auth := pkgmocks.NewAuthServiceWithKeys("", usersList, keys)
+	// Users own the channels by ID, and read them by token.
+	things := pkgmocks.NewThingsServiceClient(map[string]string{
+		userID:     chanID,
+		userEmail:  chanID,
+		otherID:    otherChan,
+		otherEmail: otherChan,
+	}, nil)
+	connector := mocks.NewConnector()
+	pubsub := mocks.NewPubSub()
+
+	svc := bridge.New(auth, things, mocks.NewBridgeRepository(), uuid.NewMock(), pubsub, connector, 0, logger.NewMock())
+	return svc, connector, pubsub
+}
+
+func TestCreateBridge(t *testing.T) {
+	svc, connector, pubsub := newService()
+
+	cases := []struct {
+		desc   string
+		token  string
+		bridge bridge.Bridge
+		err    error
+	}{
+		{
+			desc:   "create bridge",
+			token:  userEmail,
+			bridge: b,
+			err:    nil,
+		},
+		{
+			desc:   "create bridge with invalid token",
+			token:  wrongValue,
+			bridge: b,
+			err:    errors.ErrAuthentication,
+		},
+		{
+			desc:   "create bridge with inbound rule of channel owned by other user",
+			token:  otherEmail,
+			bridge: bridge.Bridge{URL: brokerURL, Rules: []bridge.Rule{inbound}},
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "create bridge with outbound rule of channel not readable by user",
+			token:  otherEmail,
+			bridge: bridge.Bridge{URL: brokerURL, Rules: []bridge.Rule{outbound}},
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "create bridge with inbound rule using API key without messages write scope",
+			token:  readKey,
+			bridge: bridge.Bridge{URL: brokerURL, Rules: []bridge.Rule{inbound}},
+			err:    errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		saved, err := svc.CreateBridge(context.Background(), tc.token, tc.bridge)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.NotEmpty(t, saved.ID, fmt.Sprintf("%s: expected bridge ID to be set\n", tc.desc))
+		assert.Equal(t, userID, saved.OwnerID, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, userID, saved.OwnerID))
+		assert.NotNil(t, connector.Broker(saved.ID), fmt.Sprintf("%s: expected bridge to be connected\n", tc.desc))
+	}
+
+	// The outbound rule ending with the multi-level wildcard subscribes to
+	// the subtopic and all of its descendants.
+	assert.Equal(t, 2, pubsub.Subscriptions(), "expected subscriptions of outbound rule")
+}
+
+func TestViewBridge(t *testing.T) {
+	svc, _, _ := newService()
+	saved, err := svc.CreateBridge(context.Background(), userEmail, b)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "view bridge",
+			token: userEmail,
+			id:    saved.ID,
+			err:   nil,
+		},
+		{
+			desc:  "view bridge with invalid token",
+			token: wrongValue,
+			id:    saved.ID,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "view bridge of other user",
+			token: otherEmail,
+			id:    saved.ID,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "view non-existing bridge",
+			token: userEmail,
+			id:    wrongValue,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := svc.ViewBridge(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, saved, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, saved, res))
+		}
+	}
+}
+
+func TestListBridges(t *testing.T) {
+	svc, _, _ := newService()
+
+	n := 5
+	for i := 0; i < n; i++ {
+		_, err := svc.CreateBridge(context.Background(), userEmail, b)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    bridge.PageMetadata
+		size  int
+		total uint64
+		err   error
+	}{
+		{
+			desc:  "list all bridges",
+			token: userEmail,
+			pm:    bridge.PageMetadata{Limit: 10},
+			size:  n,
+			total: uint64(n),
+			err:   nil,
+		},
+		{
+			desc:  "list page of bridges",
+			token: userEmail,
+			pm:    bridge.PageMetadata{Offset: 3, Limit: 10},
+			size:  2,
+			total: uint64(n),
+			err:   nil,
+		},
+		{
+			desc:  "list bridges of other user",
+			token: otherEmail,
+			pm:    bridge.PageMetadata{Limit: 10},
+			size:  0,
+			total: 0,
+			err:   nil,
+		},
+		{
+			desc:  "list bridges with invalid token",
+			token: wrongValue,
+			pm:    bridge.PageMetadata{Limit: 10},
+			size:  0,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListBridges(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Bridges), fmt.Sprintf("%s: expected %d bridges got %d\n", tc.desc, tc.size, len(page.Bridges)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestUpdateBridge(t *testing.T) {
+	svc, connector, pubsub := newService()
+	withPassword := b
+	withPassword.Password = password
+	saved, err := svc.CreateBridge(context.Background(), userEmail, withPassword)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	old := connector.Broker(saved.ID)
+
+	// The password is kept, since the update doesn't provide the new one.
+	updated := saved
+	updated.Name = "updated"
+	updated.Password = ""
+	updated.Rules = []bridge.Rule{inbound}
+
+	cases := []struct {
+		desc   string
+		token  string
+		bridge bridge.Bridge
+		err    error
+	}{
+		{
+			desc:   "update bridge with invalid token",
+			token:  wrongValue,
+			bridge: updated,
+			err:    errors.ErrAuthentication,
+		},
+		{
+			desc:   "update bridge of other user",
+			token:  otherEmail,
+			bridge: updated,
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "update non-existing bridge",
+			token:  userEmail,
+			bridge: bridge.Bridge{ID: wrongValue, URL: brokerURL},
+			err:    errors.ErrNotFound,
+		},
+		{
+			desc:   "update bridge",
+			token:  userEmail,
+			bridge: updated,
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateBridge(context.Background(), tc.token, tc.bridge)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	updated.Password = password
+	res, err := svc.ViewBridge(context.Background(), userEmail, saved.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, updated, res, fmt.Sprintf("expected %v got %v", updated, res))
+	assert.True(t, old.Closed(), "expected old broker connection to be closed")
+	assert.NotEqual(t, old, connector.Broker(saved.ID), "expected bridge to reconnect")
+	assert.Equal(t, 0, pubsub.Subscriptions(), "expected subscriptions of removed outbound rule to be cancelled")
+}
+
+func TestUpdateBridgeConnectFailure(t *testing.T) {
+	svc, connector, pubsub := newService()
+	saved, err := svc.CreateBridge(context.Background(), userEmail, b)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	unreachable := saved
+	unreachable.URL = mocks.UnreachableURL
+	err = svc.UpdateBridge(context.Background(), userEmail, unreachable)
+	assert.True(t, errors.Contains(err, bridge.ErrConnect), fmt.Sprintf("expected %s got %s\n", bridge.ErrConnect, err))
+
+	// The bridge keeps running with the stored configuration.
+	res, err := svc.ViewBridge(context.Background(), userEmail, saved.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, saved, res, fmt.Sprintf("expected %v got %v", saved, res))
+	assert.False(t, connector.Broker(saved.ID).Closed(), "expected bridge to be reconnected")
+	assert.Equal(t, 2, pubsub.Subscriptions(), "expected subscriptions of outbound rule to be renewed")
+
+	connector.Broker(saved.ID).Deliver("plant/line1/temp", []byte("21"))
+	msgs := pubsub.Messages(chanID)
+	assert.Equal(t, 1, len(msgs), fmt.Sprintf("expected 1 message got %d", len(msgs)))
+}
+
+func TestRemoveBridge(t *testing.T) {
+	svc, connector, pubsub := newService()
+	saved, err := svc.CreateBridge(context.Background(), userEmail, b)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "remove bridge with invalid token",
+			token: wrongValue,
+			id:    saved.ID,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "remove bridge of other user",
+			token: otherEmail,
+			id:    saved.ID,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "remove bridge",
+			token: userEmail,
+			id:    saved.ID,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed bridge",
+			token: userEmail,
+			id:    saved.ID,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveBridge(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	assert.True(t, connector.Broker(saved.ID).Closed(), "expected broker connection to be closed")
+	assert.Equal(t, 0, pubsub.Subscriptions(), "expected subscriptions to be cancelled")
+}
+
+func TestRestore(t *testing.T) {
+	svc, connector, pubsub := newService()
+	saved, err := svc.CreateBridge(context.Background(), userEmail, b)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// The service restarted on the same repository reconnects the bridge.
+	restored := bridge.New(pkgmocks.NewAuthService("", usersList), owners(), repository(t, saved), uuid.NewMock(), pubsub, connector, 0, logger.NewMock())
+	err = restored.Restore(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	connector.Broker(saved.ID).Deliver("plant/line1/temp", []byte("21"))
+	msgs := pubsub.Messages(chanID)
+	assert.Equal(t, 1, len(msgs), fmt.Sprintf("expected 1 message got %d", len(msgs)))
+}
+
+func TestRestoreConnectFailure(t *testing.T) {
+	svc, _, _ := newService()
+	saved, err := svc.CreateBridge(context.Background(), userEmail, b)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The bridge failing to start doesn't fail the restore, and is started
+	// once the broker is reachable again.
+	connector := mocks.NewConnector()
+	connector.Fail(true)
+	restored := bridge.New(pkgmocks.NewAuthService("", usersList), owners(), repository(t, saved), uuid.NewMock(), mocks.NewPubSub(), connector, 10*time.Millisecond, logger.NewMock())
+	err = restored.Restore(ctx)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Nil(t, connector.Broker(saved.ID), "expected bridge not to be connected")
+
+	connector.Fail(false)
+	assert.Eventually(t, func() bool { return connector.Broker(saved.ID) != nil }, time.Second, 10*time.Millisecond, "expected bridge to be connected")
+}
+
+func TestRestoreLostAccess(t *testing.T) {
+	svc, _, _ := newService()
+	saved, err := svc.CreateBridge(context.Background(), userEmail, b)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// The owner of the bridge no longer owns the channel of its rules.
+	things := pkgmocks.NewThingsServiceClient(map[string]string{userID: otherChan}, nil)
+	connector := mocks.NewConnector()
+	pubsub := mocks.NewPubSub()
+	restored := bridge.New(pkgmocks.NewAuthService("", usersList), things, repository(t, saved), uuid.NewMock(), pubsub, connector, 0, logger.NewMock())
+	err = restored.Restore(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Nil(t, connector.Broker(saved.ID), "expected bridge not to be connected")
+	assert.Equal(t, 0, pubsub.Subscriptions(), "expected no subscriptions")
+}
+
+// owners returns the things service client in which the user owns the channel.
+func owners() mainflux.ThingsServiceClient {
+	return pkgmocks.NewThingsServiceClient(map[string]string{userID: chanID}, nil)
+}
+
+// repository returns the repository containing the bridge.
+func repository(t *testing.T, b bridge.Bridge) bridge.BridgeRepository {
+	repo := mocks.NewBridgeRepository()
+	err := repo.Save(context.Background(), b)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return repo
+}
+
+func TestInbound(t *testing.T) {
+	rules := []bridge.Rule{
+		inbound,
+		{Direction: bridge.Inbound, Topic: "factory/#", ChanID: chanID, Subtopic: "factory"},
+	}
+	svc, connector, pubsub := newService()
+	saved, err := svc.CreateBridge(context.Background(), userEmail, bridge.Bridge{URL: brokerURL, Rules: rules})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	broker := connector.Broker(saved.ID)
+
+	cases := []struct {
+		desc     string
+		topic    string
+		subtopic string
+		dropped  bool
+	}{
+		{
+			desc:     "forward message of single-level wildcard topic",
+			topic:    "plant/line1/temp",
+			subtopic: "temp",
+		},
+		{
+			desc:     "forward message of multi-level wildcard topic",
+			topic:    "factory/line1/hum",
+			subtopic: "factory.line1.hum",
+		},
+		{
+			desc:     "forward message of multi-level wildcard topic parent",
+			topic:    "factory",
+			subtopic: "factory",
+		},
+		{
+			desc:    "drop message of topic with invalid subtopic level",
+			topic:   "factory/line.1",
+			dropped: true,
+		},
+		{
+			desc:    "drop message of topic with empty level",
+			topic:   "factory//hum",
+			dropped: true,
+		},
+	}
+
+	for _, tc := range cases {
+		before := len(pubsub.Messages(chanID))
+		broker.Deliver(tc.topic, []byte(tc.desc))
+		msgs := pubsub.Messages(chanID)
+		if tc.dropped {
+			assert.Equal(t, before, len(msgs), fmt.Sprintf("%s: expected message to be dropped\n", tc.desc))
+			continue
+		}
+		require.Equal(t, before+1, len(msgs), fmt.Sprintf("%s: expected message to be forwarded\n", tc.desc))
+		msg := msgs[len(msgs)-1]
+		expected := messaging.Message{
+			Channel:   chanID,
+			Subtopic:  tc.subtopic,
+			Publisher: saved.ID,
+			Protocol:  bridge.Protocol,
+			Payload:   []byte(tc.desc),
+			Created:   msg.Created,
+		}
+		assert.Equal(t, expected, msg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, expected, msg))
+	}
+}
+
+func TestOutbound(t *testing.T) {
+	rules := []bridge.Rule{
+		outbound,
+		{Direction: bridge.Outbound, Topic: "partner/status", ChanID: chanID, Subtopic: "status", QoS: 1},
+	}
+	svc, connector, pubsub := newService()
+	saved, err := svc.CreateBridge(context.Background(), userEmail, bridge.Bridge{URL: brokerURL, Rules: rules})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	broker := connector.Broker(saved.ID)
+
+	cases := []struct {
+		desc      string
+		msg       messaging.Message
+		published []mocks.Message
+	}{
+		{
+			desc:      "mirror message of subtopic",
+			msg:       messaging.Message{Channel: chanID, Subtopic: "out", Payload: []byte("1")},
+			published: []mocks.Message{{Topic: "mainflux", QoS: 2, Payload: []byte("1")}},
+		},
+		{
+			desc:      "mirror message of subtopic descendant",
+			msg:       messaging.Message{Channel: chanID, Subtopic: "out.line1.temp", Payload: []byte("2")},
+			published: []mocks.Message{{Topic: "mainflux/line1/temp", QoS: 2, Payload: []byte("2")}},
+		},
+		{
+			desc:      "mirror message of exact subtopic",
+			msg:       messaging.Message{Channel: chanID, Subtopic: "status", Payload: []byte("3")},
+			published: []mocks.Message{{Topic: "partner/status", QoS: 1, Payload: []byte("3")}},
+		},
+		{
+			desc:      "skip message of other subtopic",
+			msg:       messaging.Message{Channel: chanID, Subtopic: "status.line1", Payload: []byte("4")},
+			published: nil,
+		},
+		{
+			desc:      "skip message forwarded by bridge",
+			msg:       messaging.Message{Channel: chanID, Subtopic: "out", Publisher: saved.ID, Protocol: bridge.Protocol, Payload: []byte("5")},
+			published: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		before := len(broker.Published())
+		err := pubsub.Publish(tc.msg.Channel, tc.msg)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		published := broker.Published()[before:]
+		if tc.published == nil {
+			assert.Empty(t, published, fmt.Sprintf("%s: expected no published messages got %v\n", tc.desc, published))
+			continue
+		}
+		assert.Equal(t, tc.published, published, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.published, published))
+	}
+}
+
+func TestLoopPrevention(t *testing.T) {
+	rules := []bridge.Rule{
+		{Direction: bridge.Inbound, Topic: "shared/#", ChanID: chanID, Subtopic: "shared"},
+		{Direction: bridge.Outbound, Topic: "shared/#", ChanID: chanID, Subtopic: "shared"},
+	}
+	svc, connector, pubsub := newService()
+	saved, err := svc.CreateBridge(context.Background(), userEmail, bridge.Bridge{URL: brokerURL, Rules: rules})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	broker := connector.Broker(saved.ID)
+
+	// The message received from the broker is forwarded to the channel once,
+	// and isn't mirrored back to the broker.
+	broker.Deliver("shared/in", []byte("in"))
+	assert.Equal(t, 1, len(pubsub.Messages(chanID)), "expected inbound message to be forwarded once")
+	assert.Empty(t, broker.Published(), "expected inbound message not to be mirrored back")
+
+	// The message published to the channel is mirrored to the broker once,
+	// and isn't forwarded back to the channel when the broker echoes it.
+	msg := messaging.Message{Channel: chanID, Subtopic: "shared.out", Payload: []byte("out")}
+	err = pubsub.Publish(chanID, msg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, 1, len(broker.Published()), "expected outbound message to be mirrored once")
+	assert.Equal(t, 2, len(pubsub.Messages(chanID)), "expected echoed outbound message to be dropped")
+
+	// The same payload received later from the broker is forwarded again.
+	broker.Deliver("shared/out", []byte("out"))
+	assert.Equal(t, 3, len(pubsub.Messages(chanID)), "expected message after echo to be forwarded")
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package bridge
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	chansPrefix = "channels"
+	multiLevel  = "#"
+)
+
+// wildcard returns the prefix of the topic before the trailing multi-level
+// wildcard, and whether the topic ends with it.
+func wildcard(topic string) (string, bool) {
+	if topic != multiLevel && !strings.HasSuffix(topic, "/"+multiLevel) {
+		return "", false
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(topic, multiLevel), "/"), true
+}
+
+// inboundSubtopic returns the subtopic of the message received on the topic
+// of the external broker, or false if the levels matched by the multi-level
+// wildcard can't be subtopic levels.
+func inboundSubtopic(r Rule, topic string) (string, bool) {
+	prefix, ok := wildcard(r.Topic)
+	if !ok {
+		return r.Subtopic, true
+	}
+
+	n := 0
+	if prefix != "" {
+		n = len(strings.Split(prefix, "/"))
+	}
+	levels := strings.Split(topic, "/")
+	if len(levels) < n {
+		return "", false
+	}
+
+	elems := []string{}
+	if r.Subtopic != "" {
+		elems = append(elems, r.Subtopic)
+	}
+	for _, l := range levels[n:] {
+		if l == "" || strings.ContainsAny(l, ".*> \t\r\n") {
+			return "", false
+		}
+		elems = append(elems, l)
+	}
+
+	return strings.Join(elems, "."), true
+}
+
+// outboundTopic returns the topic of the external broker to publish the
+// message of the subtopic to, or false if the subtopic isn't mapped by the
+// rule.
+func outboundTopic(r Rule, subtopic string) (string, bool) {
+	prefix, ok := wildcard(r.Topic)
+	if !ok {
+		return r.Topic, subtopic == r.Subtopic
+	}
+
+	rest := subtopic
+	if r.Subtopic != "" {
+		if subtopic != r.Subtopic && !strings.HasPrefix(subtopic, r.Subtopic+".") {
+			return "", false
+		}
+		rest = strings.TrimPrefix(strings.TrimPrefix(subtopic, r.Subtopic), ".")
+	}
+	rest = strings.Replace(rest, ".", "/", -1)
+
+	switch {
+	case prefix == "":
+		return rest, rest != ""
+	case rest == "":
+		return prefix, true
+	default:
+		return prefix + "/" + rest, true
+	}
+}
+
+// subjects returns the message broker subjects of the channel messages
+// mirrored by the outbound rule.
+func subjects(r Rule) []string {
+	subject := fmt.Sprintf("%s.%s", chansPrefix, r.ChanID)
+	if r.Subtopic != "" {
+		subject = fmt.Sprintf("%s.%s", subject, r.Subtopic)
+	}
+
+	if _, ok := wildcard(r.Topic); ok {
+		return []string{subject, subject + ".>"}
+	}
+
+	return []string{subject}
+}
+
+// matches checks if the topic matches the MQTT topic filter.
+func matches(filter, topic string) bool {
+	fl, tl := strings.Split(filter, "/"), strings.Split(topic, "/")
+	for i, f := range fl {
+		switch {
+		case f == multiLevel:
+			return true
+		case i == len(tl):
+			return false
+		case f != "+" && f != tl[i]:
+			return false
+		}
+	}
+
+	return len(fl) == len(tl)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing contains middlewares that will add spans
+// to existing traces.
+package tracing
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/bridge"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveBridgeOp             = "save_bridge"
+	updateBridgeOp           = "update_bridge"
+	retrieveBridgeByIDOp     = "retrieve_bridge_by_id"
+	retrieveBridgesByOwnerOp = "retrieve_bridges_by_owner"
+	retrieveAllBridgesOp     = "retrieve_all_bridges"
+	removeBridgeOp           = "remove_bridge"
+)
+
+var _ bridge.BridgeRepository = (*bridgeRepositoryMiddleware)(nil)
+
+type bridgeRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   bridge.BridgeRepository
+}
+
+// BridgeRepositoryMiddleware tracks request and their latency, and adds spans
+// to context.
+func BridgeRepositoryMiddleware(tracer opentracing.Tracer, repo bridge.BridgeRepository) bridge.BridgeRepository {
+	return bridgeRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (brm bridgeRepositoryMiddleware) Save(ctx context.Context, b bridge.Bridge) error {
+	span := createSpan(ctx, brm.tracer, saveBridgeOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return brm.repo.Save(ctx, b)
+}
+
+func (brm bridgeRepositoryMiddleware) Update(ctx context.Context, b bridge.Bridge) error {
+	span := createSpan(ctx, brm.tracer, updateBridgeOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return brm.repo.Update(ctx, b)
+}
+
+func (brm bridgeRepositoryMiddleware) RetrieveByID(ctx context.Context, id string) (bridge.Bridge, error) {
+	span := createSpan(ctx, brm.tracer, retrieveBridgeByIDOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return brm.repo.RetrieveByID(ctx, id)
+}
+
+func (brm bridgeRepositoryMiddleware) RetrieveByOwner(ctx context.Context, ownerID string, pm bridge.PageMetadata) (bridge.Page, error) {
+	span := createSpan(ctx, brm.tracer, retrieveBridgesByOwnerOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return brm.repo.RetrieveByOwner(ctx, ownerID, pm)
+}
+
+func (brm bridgeRepositoryMiddleware) RetrieveAll(ctx context.Context) ([]bridge.Bridge, error) {
+	span := createSpan(ctx, brm.tracer, retrieveAllBridgesOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return brm.repo.RetrieveAll(ctx)
+}
+
+func (brm bridgeRepositoryMiddleware) Remove(ctx context.Context, id string) error {
+	span := createSpan(ctx, brm.tracer, removeBridgeOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return brm.repo.Remove(ctx, id)
+}
+
+func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
+	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
+		return tracer.StartSpan(
+			opName,
+			opentracing.ChildOf(parentSpan.Context()),
+		)
+	}
+	return tracer.StartSpan(opName)
+}
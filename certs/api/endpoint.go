@@ -85,6 +85,22 @@ func viewCert(svc certs.Service) endpoint.Endpoint {
 	}
 }
 
+func certStatus(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(certStatusReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		revoked, err := svc.IsRevoked(ctx, req.serialID)
+		if err != nil {
+			return nil, err
+		}
+
+		return certStatusRes{Revoked: revoked}, nil
+	}
+}
+
 func revokeCert(svc certs.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(revokeReq)
@@ -90,3 +90,16 @@ func (lm *loggingMiddleware) RevokeCert(ctx context.Context, token, thingID stri
 
 	return lm.svc.RevokeCert(ctx, token, thingID)
 }
+
+func (lm *loggingMiddleware) IsRevoked(ctx context.Context, serialID string) (revoked bool, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method is_revoked for serial id %s took %s to complete", serialID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.IsRevoked(ctx, serialID)
+}
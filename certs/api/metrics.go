@@ -74,3 +74,12 @@ func (ms *metricsMiddleware) RevokeCert(ctx context.Context, token, thingID stri
 
 	return ms.svc.RevokeCert(ctx, token, thingID)
 }
+
+func (ms *metricsMiddleware) IsRevoked(ctx context.Context, serialID string) (bool, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "is_revoked").Add(1)
+		ms.latency.With("method", "is_revoked").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.IsRevoked(ctx, serialID)
+}
@@ -64,6 +64,18 @@ func (req *viewReq) validate() error {
 	return nil
 }
 
+type certStatusReq struct {
+	serialID string
+}
+
+func (req *certStatusReq) validate() error {
+	if req.serialID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
 type revokeReq struct {
 	token  string
 	certID string
@@ -55,3 +55,19 @@ func (res certsRes) Headers() map[string]string {
 func (res certsRes) Empty() bool {
 	return false
 }
+
+type certStatusRes struct {
+	Revoked bool `json:"revoked"`
+}
+
+func (res certStatusRes) Code() int {
+	return http.StatusOK
+}
+
+func (res certStatusRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res certStatusRes) Empty() bool {
+	return false
+}
@@ -48,6 +48,13 @@ func MakeHandler(svc certs.Service, logger logger.Logger) http.Handler {
 		opts...,
 	))
 
+	r.Get("/certs/:certId/status", kithttp.NewServer(
+		certStatus(svc),
+		decodeCertStatus,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Delete("/certs/:certId", kithttp.NewServer(
 		revokeCert(svc),
 		decodeRevokeCerts,
@@ -114,6 +121,14 @@ func decodeViewCert(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeCertStatus(_ context.Context, r *http.Request) (interface{}, error) {
+	req := certStatusReq{
+		serialID: bone.GetValue(r, "certId"),
+	}
+
+	return req, nil
+}
+
 func decodeCerts(_ context.Context, r *http.Request) (interface{}, error) {
 	if r.Header.Get("Content-Type") != contentType {
 		return nil, apiutil.ErrUnsupportedContentType
@@ -141,6 +156,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	case errors.Contains(err, errors.ErrAuthentication),
 		err == apiutil.ErrBearerToken:
 		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	case errors.Contains(err, apiutil.ErrMalformedEntity),
@@ -148,6 +165,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		err == apiutil.ErrMissingCertData,
 		err == apiutil.ErrLimitSize:
 		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
 	case errors.Contains(err, errors.ErrConflict):
 		w.WriteHeader(http.StatusConflict)
 
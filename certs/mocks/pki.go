@@ -157,7 +157,16 @@ func (a *agent) Read(serial string) (pki.Cert, error) {
 }
 
 func (a *agent) Revoke(serial string) (time.Time, error) {
-	return time.Now(), nil
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	revoked := time.Now()
+	if crt, ok := a.certs[serial]; ok {
+		crt.RevocationTime = revoked
+		a.certs[serial] = crt
+	}
+
+	return revoked, nil
 }
 
 func publicKey(priv interface{}) (interface{}, error) {
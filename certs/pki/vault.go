@@ -45,6 +45,7 @@ type Cert struct {
 	PrivateKeyType string    `json:"private_key_type" mapstructure:"private_key_type"`
 	Serial         string    `json:"serial" mapstructure:"serial_number"`
 	Expire         time.Time `json:"expire" mapstructure:"-"`
+	RevocationTime time.Time `json:"revocation_time" mapstructure:"-"`
 }
 
 // Agent represents the Vault PKI interface.
@@ -175,6 +176,17 @@ func (p *pkiAgent) Read(serial string) (Cert, error) {
 		return Cert{}, errors.Wrap(errFailedCertDecoding, err)
 	}
 
+	// Vault reports the revocation time as Unix seconds, zero if not revoked.
+	if rt, ok := s.Data["revocation_time"].(json.Number); ok {
+		secs, err := rt.Int64()
+		if err != nil {
+			return Cert{}, errors.Wrap(errFailedCertDecoding, err)
+		}
+		if secs > 0 {
+			cert.RevocationTime = time.Unix(secs, 0)
+		}
+	}
+
 	return cert, nil
 }
 
@@ -0,0 +1,51 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import "time"
+
+const (
+	certPrefix = "cert."
+	certIssue  = certPrefix + "issue"
+	certRevoke = certPrefix + "revoke"
+)
+
+type event interface {
+	Encode() map[string]interface{}
+}
+
+var (
+	_ event = (*issueCertEvent)(nil)
+	_ event = (*revokeCertEvent)(nil)
+)
+
+type issueCertEvent struct {
+	serial  string
+	thingID string
+	owner   string
+	expire  time.Time
+}
+
+func (ice issueCertEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        ice.serial,
+		"thing_id":  ice.thingID,
+		"owner":     ice.owner,
+		"expire":    ice.expire.Format(time.RFC3339),
+		"operation": certIssue,
+	}
+}
+
+type revokeCertEvent struct {
+	serial         string
+	revocationTime time.Time
+}
+
+func (rce revokeCertEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":              rce.serial,
+		"revocation_time": rce.revocationTime.Format(time.RFC3339),
+		"operation":       certRevoke,
+	}
+}
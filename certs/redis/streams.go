@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/certs"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	streamID  = "mainflux.certs"
+	streamLen = 1000
+)
+
+var _ certs.Service = (*eventStore)(nil)
+
+type eventStore struct {
+	svc    certs.Service
+	auth   mainflux.AuthServiceClient
+	client *redis.Client
+}
+
+// NewEventStoreMiddleware returns wrapper around certs service that sends
+// events to event store.
+func NewEventStoreMiddleware(svc certs.Service, auth mainflux.AuthServiceClient, client *redis.Client) certs.Service {
+	return eventStore{
+		svc:    svc,
+		auth:   auth,
+		client: client,
+	}
+}
+
+func (es eventStore) IssueCert(ctx context.Context, token, thingID, ttl string, keyBits int, keyType string) (certs.Cert, error) {
+	cert, err := es.svc.IssueCert(ctx, token, thingID, ttl, keyBits, keyType)
+	if err != nil {
+		return cert, err
+	}
+
+	ev := issueCertEvent{
+		serial:  cert.Serial,
+		thingID: cert.ThingID,
+		owner:   cert.OwnerID,
+		expire:  cert.Expire,
+	}
+	es.add(ctx, cert.OwnerID, ev)
+
+	return cert, nil
+}
+
+func (es eventStore) ListCerts(ctx context.Context, token, thingID string, offset, limit uint64) (certs.Page, error) {
+	return es.svc.ListCerts(ctx, token, thingID, offset, limit)
+}
+
+func (es eventStore) ListSerials(ctx context.Context, token, thingID string, offset, limit uint64) (certs.Page, error) {
+	return es.svc.ListSerials(ctx, token, thingID, offset, limit)
+}
+
+func (es eventStore) ViewCert(ctx context.Context, token, serialID string) (certs.Cert, error) {
+	return es.svc.ViewCert(ctx, token, serialID)
+}
+
+func (es eventStore) IsRevoked(ctx context.Context, serialID string) (bool, error) {
+	return es.svc.IsRevoked(ctx, serialID)
+}
+
+func (es eventStore) RevokeCert(ctx context.Context, token, serialID string) (certs.Revoke, error) {
+	rev, err := es.svc.RevokeCert(ctx, token, serialID)
+	if err != nil {
+		return rev, err
+	}
+
+	ev := revokeCertEvent{
+		serial:         serialID,
+		revocationTime: rev.RevocationTime,
+	}
+	es.add(ctx, es.actor(ctx, token), ev)
+
+	return rev, nil
+}
+
+func (es eventStore) actor(ctx context.Context, token string) string {
+	res, err := es.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return ""
+	}
+
+	return res.GetId()
+}
+
+func (es eventStore) add(ctx context.Context, actor string, ev event) {
+	values := ev.Encode()
+	if actor != "" {
+		values["actor"] = actor
+	}
+	if ip := auth.ClientIP(ctx); ip != "" {
+		values["ip"] = ip
+	}
+
+	record := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       values,
+	}
+	es.client.XAdd(ctx, record).Err()
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/certs/pki"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
@@ -44,6 +45,11 @@ type Service interface {
 
 	// RevokeCert revokes a certificate for a given serial ID
 	RevokeCert(ctx context.Context, token, serialID string) (Revoke, error)
+
+	// IsRevoked reports whether the certificate with the given serial ID
+	// has been revoked. It is used by the adapters to reject revoked
+	// client certificates, so it requires no user token.
+	IsRevoked(ctx context.Context, serialID string) (bool, error)
 }
 
 // Config defines the service parameters
@@ -106,7 +112,7 @@ type Cert struct {
 }
 
 func (cs *certsService) IssueCert(ctx context.Context, token, thingID string, ttl string, keyBits int, keyType string) (Cert, error) {
-	owner, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	owner, err := cs.identify(ctx, token)
 	if err != nil {
 		return Cert{}, err
 	}
@@ -139,7 +145,7 @@ func (cs *certsService) IssueCert(ctx context.Context, token, thingID string, tt
 
 func (cs *certsService) RevokeCert(ctx context.Context, token, thingID string) (Revoke, error) {
 	var revoke Revoke
-	u, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	u, err := cs.identify(ctx, token)
 	if err != nil {
 		return revoke, err
 	}
@@ -170,7 +176,7 @@ func (cs *certsService) RevokeCert(ctx context.Context, token, thingID string) (
 }
 
 func (cs *certsService) ListCerts(ctx context.Context, token, thingID string, offset, limit uint64) (Page, error) {
-	u, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	u, err := cs.identify(ctx, token)
 	if err != nil {
 		return Page{}, err
 	}
@@ -193,7 +199,7 @@ func (cs *certsService) ListCerts(ctx context.Context, token, thingID string, of
 }
 
 func (cs *certsService) ListSerials(ctx context.Context, token, thingID string, offset, limit uint64) (Page, error) {
-	u, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	u, err := cs.identify(ctx, token)
 	if err != nil {
 		return Page{}, err
 	}
@@ -202,7 +208,7 @@ func (cs *certsService) ListSerials(ctx context.Context, token, thingID string,
 }
 
 func (cs *certsService) ViewCert(ctx context.Context, token, serialID string) (Cert, error) {
-	u, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	u, err := cs.identify(ctx, token)
 	if err != nil {
 		return Cert{}, err
 	}
@@ -226,3 +232,27 @@ func (cs *certsService) ViewCert(ctx context.Context, token, serialID string) (C
 
 	return c, nil
 }
+
+func (cs *certsService) IsRevoked(ctx context.Context, serialID string) (bool, error) {
+	cert, err := cs.pki.Read(serialID)
+	if err != nil {
+		return false, err
+	}
+
+	return !cert.RevocationTime.IsZero(), nil
+}
+
+// identify validates the user token. API keys restricted by scopes or
+// resources are rejected, since certificates are not covered by the scopes.
+func (cs *certsService) identify(ctx context.Context, token string) (*mainflux.UserIdentity, error) {
+	res, err := cs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, err
+	}
+
+	if auth.IsScoped(res) {
+		return nil, errors.ErrAuthorization
+	}
+
+	return res, nil
+}
@@ -228,6 +228,52 @@ func TestRevokeCert(t *testing.T) {
 
 }
 
+func TestIsRevoked(t *testing.T) {
+	svc, err := newService()
+	require.Nil(t, err, fmt.Sprintf("unexpected service creation error: %s\n", err))
+
+	c1, err := svc.IssueCert(context.Background(), token, thingID, ttl, keyBits, key)
+	require.Nil(t, err, fmt.Sprintf("unexpected cert creation error: %s\n", err))
+
+	_, err = svc.RevokeCert(context.Background(), token, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected cert revocation error: %s\n", err))
+
+	c2, err := svc.IssueCert(context.Background(), token, thingID, ttl, keyBits, key)
+	require.Nil(t, err, fmt.Sprintf("unexpected cert creation error: %s\n", err))
+
+	cases := []struct {
+		desc     string
+		serialID string
+		revoked  bool
+		err      error
+	}{
+		{
+			desc:     "check revoked cert",
+			serialID: c1.Serial,
+			revoked:  true,
+			err:      nil,
+		},
+		{
+			desc:     "check valid cert",
+			serialID: c2.Serial,
+			revoked:  false,
+			err:      nil,
+		},
+		{
+			desc:     "check non-existing cert",
+			serialID: wrongValue,
+			revoked:  false,
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		revoked, err := svc.IsRevoked(context.Background(), tc.serialID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.revoked, revoked, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.revoked, revoked))
+	}
+}
+
 func TestListCerts(t *testing.T) {
 	svc, err := newService()
 	require.Nil(t, err, fmt.Sprintf("unexpected service creation error: %s\n", err))
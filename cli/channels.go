@@ -58,6 +58,7 @@ var cmdChannels = []cobra.Command{
 				Offset:   uint64(Offset),
 				Limit:    uint64(Limit),
 				Metadata: metadata,
+				Tags:     convertTags(Tags),
 			}
 
 			if args[0] == "all" {
@@ -159,12 +160,32 @@ var cmdChannels = []cobra.Command{
 			logJSON(cl)
 		},
 	},
+	{
+		Use:   "tag <channel_ids> <tags> <user_auth_token>",
+		Short: "Tag channels",
+		Long: `Add tags to channels.
+				channel_ids - '["channel_id",...]'
+				tags - '["tag",...]'`,
+		Run: func(cmd *cobra.Command, args []string) {
+			updateTags(cmd, args, sdk.AddChannelTags)
+		},
+	},
+	{
+		Use:   "untag <channel_ids> <tags> <user_auth_token>",
+		Short: "Untag channels",
+		Long: `Remove tags from channels.
+				channel_ids - '["channel_id",...]'
+				tags - '["tag",...]'`,
+		Run: func(cmd *cobra.Command, args []string) {
+			updateTags(cmd, args, sdk.RemoveChannelTags)
+		},
+	},
 }
 
 // NewChannelsCmd returns channels command.
 func NewChannelsCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "channels [create | get | update | delete | connections | not-connected]",
+		Use:   "channels [create | get | update | delete | connections | not-connected | tag | untag]",
 		Short: "Channels management",
 		Long:  `Channels management: create, get, update or delete Channel and get list of Things connected or not connected to a Channel`,
 	}
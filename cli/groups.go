@@ -5,6 +5,7 @@ package cli
 
 import (
 	"encoding/json"
+	"strconv"
 
 	mfxsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
 	"github.com/spf13/cobra"
@@ -59,6 +60,7 @@ var cmdGroups = []cobra.Command{
 				meta := mfxsdk.PageMetadata{
 					Offset: uint64(Offset),
 					Limit:  uint64(Limit),
+					Tags:   convertTags(Tags),
 				}
 				l, err := sdk.Groups(meta, args[1])
 				if err != nil {
@@ -157,6 +159,48 @@ var cmdGroups = []cobra.Command{
 			logJSON(up)
 		},
 	},
+	{
+		Use:   "children <group_id> <depth> <user_auth_token>",
+		Short: "Group children list",
+		Long:  `Lists descendants of a group that are at most depth levels below it.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+			depth, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				logError(err)
+				return
+			}
+			gp, err := sdk.ListGroupChildren(args[0], args[2], depth, uint64(Offset), uint64(Limit))
+			if err != nil {
+				logError(err)
+				return
+			}
+			logJSON(gp)
+		},
+	},
+	{
+		Use:   "move <group_id> [<parent_id>] <user_auth_token>",
+		Short: "Move group",
+		Long:  `Moves group with its descendants under the parent group. Without the parent, group becomes a root group.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 && len(args) != 3 {
+				logUsage(cmd.Use)
+				return
+			}
+			var parentID string
+			if len(args) == 3 {
+				parentID = args[1]
+			}
+			if err := sdk.MoveGroup(args[0], parentID, args[len(args)-1]); err != nil {
+				logError(err)
+				return
+			}
+			logOK()
+		},
+	},
 	{
 		Use:   "membership <thing_id> <user_auth_token>",
 		Short: "Thing membership",
@@ -174,12 +218,32 @@ var cmdGroups = []cobra.Command{
 			logJSON(up)
 		},
 	},
+	{
+		Use:   "tag <group_ids> <tags> <user_auth_token>",
+		Short: "Tag groups",
+		Long: `Add tags to groups.
+				group_ids - '["group_id",...]'
+				tags - '["tag",...]'`,
+		Run: func(cmd *cobra.Command, args []string) {
+			updateTags(cmd, args, sdk.AddGroupTags)
+		},
+	},
+	{
+		Use:   "untag <group_ids> <tags> <user_auth_token>",
+		Short: "Untag groups",
+		Long: `Remove tags from groups.
+				group_ids - '["group_id",...]'
+				tags - '["tag",...]'`,
+		Run: func(cmd *cobra.Command, args []string) {
+			updateTags(cmd, args, sdk.RemoveGroupTags)
+		},
+	},
 }
 
 // NewGroupsCmd returns users command.
 func NewGroupsCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "groups [create | get | delete | assign | unassign | members | membership]",
+		Use:   "groups [create | get | delete | assign | unassign | members | children | move | membership | tag | untag]",
 		Short: "Groups management",
 		Long:  `Groups management: create groups and assigns member to groups"`,
 	}
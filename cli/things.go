@@ -57,6 +57,7 @@ var cmdThings = []cobra.Command{
 				Offset:   uint64(Offset),
 				Limit:    uint64(Limit),
 				Metadata: metadata,
+				Tags:     convertTags(Tags),
 			}
 			if args[0] == "all" {
 				l, err := sdk.Things(args[1], pageMetadata)
@@ -201,12 +202,32 @@ var cmdThings = []cobra.Command{
 			logJSON(cl)
 		},
 	},
+	{
+		Use:   "tag <thing_ids> <tags> <user_auth_token>",
+		Short: "Tag things",
+		Long: `Add tags to things.
+				thing_ids - '["thing_id",...]'
+				tags - '["tag",...]'`,
+		Run: func(cmd *cobra.Command, args []string) {
+			updateTags(cmd, args, sdk.AddThingTags)
+		},
+	},
+	{
+		Use:   "untag <thing_ids> <tags> <user_auth_token>",
+		Short: "Untag things",
+		Long: `Remove tags from things.
+				thing_ids - '["thing_id",...]'
+				tags - '["tag",...]'`,
+		Run: func(cmd *cobra.Command, args []string) {
+			updateTags(cmd, args, sdk.RemoveThingTags)
+		},
+	},
 }
 
 // NewThingsCmd returns things command.
 func NewThingsCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "things [create | get | update | delete | identify | connect | disconnect | connections | not-connected]",
+		Use:   "things [create | get | update | delete | identify | connect | disconnect | connections | not-connected | tag | untag]",
 		Short: "Things management",
 		Long:  `Things management: create, get, update, identify or delete Thing, connect or disconnect Thing from Channel and get the list of Channels connected or disconnected from a Thing`,
 	}
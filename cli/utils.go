@@ -6,9 +6,11 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	prettyjson "github.com/hokaccha/go-prettyjson"
+	"github.com/spf13/cobra"
 )
 
 var (
@@ -22,6 +24,8 @@ var (
 	Email string = ""
 	// Metadata query parameter
 	Metadata string = ""
+	// Tags query parameter
+	Tags string = ""
 	// ConfigPath config path parameter
 	ConfigPath string = ""
 	// RawOutput raw output mode
@@ -79,3 +83,33 @@ func convertMetadata(m string) (map[string]interface{}, error) {
 	}
 	return nil, nil
 }
+
+func convertTags(t string) []string {
+	if t == "" {
+		return nil
+	}
+	return strings.Split(t, ",")
+}
+
+// updateTags parses the JSON arrays of entity IDs and tags from the command
+// arguments and applies the update to the entities.
+func updateTags(cmd *cobra.Command, args []string, update func(ids, tags []string, token string) error) {
+	if len(args) != 3 {
+		logUsage(cmd.Use)
+		return
+	}
+	var ids, tags []string
+	if err := json.Unmarshal([]byte(args[0]), &ids); err != nil {
+		logError(err)
+		return
+	}
+	if err := json.Unmarshal([]byte(args[1]), &tags); err != nil {
+		logError(err)
+		return
+	}
+	if err := update(ids, tags, args[2]); err != nil {
+		logError(err)
+		return
+	}
+	logOK()
+}
@@ -0,0 +1,354 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/audit/api"
+	"github.com/MainfluxLabs/mainflux/audit/postgres"
+	rediscons "github.com/MainfluxLabs/mainflux/audit/redis"
+	"github.com/MainfluxLabs/mainflux/audit/tracing"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	r "github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	stopWaitTime  = 5 * time.Second
+	httpProtocol  = "http"
+	httpsProtocol = "https"
+
+	defLogLevel        = "error"
+	defDBHost          = "localhost"
+	defDBPort          = "5432"
+	defDBUser          = "mainflux"
+	defDBPass          = "mainflux"
+	defDB              = "audit"
+	defDBSSLMode       = "disable"
+	defDBSSLCert       = ""
+	defDBSSLKey        = ""
+	defDBSSLRootCert   = ""
+	defClientTLS       = "false"
+	defCACerts         = ""
+	defPort            = "9023"
+	defServerCert      = ""
+	defServerKey       = ""
+	defESURL           = "localhost:6379"
+	defESPass          = ""
+	defESDB            = "0"
+	defESConsumerName  = "audit"
+	defJaegerURL       = ""
+	defTrustedProxies  = ""
+	defAuthGRPCURL     = "localhost:8181"
+	defAuthGRPCTimeout = "1s"
+
+	envLogLevel        = "MF_AUDIT_LOG_LEVEL"
+	envDBHost          = "MF_AUDIT_DB_HOST"
+	envDBPort          = "MF_AUDIT_DB_PORT"
+	envDBUser          = "MF_AUDIT_DB_USER"
+	envDBPass          = "MF_AUDIT_DB_PASS"
+	envDB              = "MF_AUDIT_DB"
+	envDBSSLMode       = "MF_AUDIT_DB_SSL_MODE"
+	envDBSSLCert       = "MF_AUDIT_DB_SSL_CERT"
+	envDBSSLKey        = "MF_AUDIT_DB_SSL_KEY"
+	envDBSSLRootCert   = "MF_AUDIT_DB_SSL_ROOT_CERT"
+	envClientTLS       = "MF_AUDIT_CLIENT_TLS"
+	envCACerts         = "MF_AUDIT_CA_CERTS"
+	envPort            = "MF_AUDIT_HTTP_PORT"
+	envServerCert      = "MF_AUDIT_SERVER_CERT"
+	envServerKey       = "MF_AUDIT_SERVER_KEY"
+	envESURL           = "MF_AUDIT_ES_URL"
+	envESPass          = "MF_AUDIT_ES_PASS"
+	envESDB            = "MF_AUDIT_ES_DB"
+	envESConsumerName  = "MF_AUDIT_EVENT_CONSUMER"
+	envJaegerURL       = "MF_JAEGER_URL"
+	envTrustedProxies  = "MF_TRUSTED_PROXIES"
+	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
+)
+
+// streams lists event streams of the services whose operations are audited.
+var streams = []string{
+	"mainflux.things",
+	"mainflux.users",
+	"mainflux.auth",
+	"mainflux.bootstrap",
+	"mainflux.certs",
+}
+
+type config struct {
+	logLevel        string
+	dbConfig        postgres.Config
+	clientTLS       bool
+	caCerts         string
+	httpPort        string
+	serverCert      string
+	serverKey       string
+	esURL           string
+	esPass          string
+	esDB            string
+	esConsumerName  string
+	jaegerURL       string
+	authGRPCURL     string
+	authGRPCTimeout time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	db := connectToDB(cfg.dbConfig, logger)
+	defer db.Close()
+
+	esClient := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esClient.Close()
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
+
+	tracer, closer := initJaeger("audit", cfg.jaegerURL, logger)
+	defer closer.Close()
+
+	dbTracer, dbCloser := initJaeger("audit_db", cfg.jaegerURL, logger)
+	defer dbCloser.Close()
+
+	svc := newService(auth, db, dbTracer, logger)
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, svc, tracer, cfg, logger)
+	})
+
+	for _, stream := range streams {
+		go subscribeToES(svc, esClient, stream, cfg.esConsumerName, logger)
+	}
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("Audit service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Audit service terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	if err := apiutil.SetTrustedProxies(mainflux.Env(envTrustedProxies, defTrustedProxies)); err != nil {
+		log.Fatalf("Invalid %s value: %s", envTrustedProxies, err.Error())
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		tls = false
+	}
+
+	dbConfig := postgres.Config{
+		Host:        mainflux.Env(envDBHost, defDBHost),
+		Port:        mainflux.Env(envDBPort, defDBPort),
+		User:        mainflux.Env(envDBUser, defDBUser),
+		Pass:        mainflux.Env(envDBPass, defDBPass),
+		Name:        mainflux.Env(envDB, defDB),
+		SSLMode:     mainflux.Env(envDBSSLMode, defDBSSLMode),
+		SSLCert:     mainflux.Env(envDBSSLCert, defDBSSLCert),
+		SSLKey:      mainflux.Env(envDBSSLKey, defDBSSLKey),
+		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
+	}
+
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
+	return config{
+		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
+		dbConfig:        dbConfig,
+		clientTLS:       tls,
+		caCerts:         mainflux.Env(envCACerts, defCACerts),
+		httpPort:        mainflux.Env(envPort, defPort),
+		serverCert:      mainflux.Env(envServerCert, defServerCert),
+		serverKey:       mainflux.Env(envServerKey, defServerKey),
+		esURL:           mainflux.Env(envESURL, defESURL),
+		esPass:          mainflux.Env(envESPass, defESPass),
+		esDB:            mainflux.Env(envESDB, defESDB),
+		esConsumerName:  mainflux.Env(envESConsumerName, defESConsumerName),
+		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
+		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout: authGRPCTimeout,
+	}
+}
+
+func connectToDB(cfg postgres.Config, logger logger.Logger) *sqlx.DB {
+	db, err := postgres.Connect(cfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to postgres: %s", err))
+		os.Exit(1)
+	}
+	return db
+}
+
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *r.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return r.NewClient(&r.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, tracer opentracing.Tracer, logger logger.Logger) audit.Service {
+	database := postgres.NewDatabase(db)
+	eventsRepo := tracing.New(postgres.New(database), tracer)
+
+	svc := audit.New(ac, eventsRepo)
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "audit",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "audit",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+	return svc
+}
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+
+	return conn
+}
+
+func startHTTPServer(ctx context.Context, svc audit.Service, tracer opentracing.Tracer, cfg config, logger logger.Logger) error {
+	p := fmt.Sprintf(":%s", cfg.httpPort)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, tracer, logger)}
+	errCh := make(chan error)
+	protocol := httpProtocol
+	switch {
+	case cfg.serverCert != "" || cfg.serverKey != "":
+		logger.Info(fmt.Sprintf("Audit service started using https on port %s with cert %s key %s",
+			cfg.httpPort, cfg.serverCert, cfg.serverKey))
+		go func() {
+			errCh <- server.ListenAndServeTLS(cfg.serverCert, cfg.serverKey)
+		}()
+		protocol = httpsProtocol
+
+	default:
+		logger.Info(fmt.Sprintf("Audit service started using http on port %s", cfg.httpPort))
+		go func() {
+			errCh <- server.ListenAndServe()
+		}()
+	}
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("Audit %s service error occurred during shutdown at %s: %s", protocol, p, err))
+			return fmt.Errorf("audit %s service error occurred during shutdown at %s: %w", protocol, p, err)
+		}
+		logger.Info(fmt.Sprintf("Audit %s service shutdown of http at %s", protocol, p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+func subscribeToES(svc audit.Service, client *r.Client, stream, consumer string, logger logger.Logger) {
+	eventStore := rediscons.NewEventStore(svc, client, consumer, logger)
+	logger.Info(fmt.Sprintf("Subscribed to Redis Event Store stream %s", stream))
+	if err := eventStore.Subscribe(context.Background(), stream); err != nil {
+		logger.Warn(fmt.Sprintf("Audit service failed to subscribe to event sourcing: %s", err))
+	}
+}
@@ -19,13 +19,16 @@ import (
 	httpapi "github.com/MainfluxLabs/mainflux/auth/api/http"
 	"github.com/MainfluxLabs/mainflux/auth/jwt"
 	"github.com/MainfluxLabs/mainflux/auth/postgres"
+	redisprod "github.com/MainfluxLabs/mainflux/auth/redis"
 	"github.com/MainfluxLabs/mainflux/auth/tracing"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	usersapi "github.com/MainfluxLabs/mainflux/users/api/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -56,6 +59,7 @@ const (
 	defServerCert      = ""
 	defServerKey       = ""
 	defJaegerURL       = ""
+	defTrustedProxies  = ""
 	defLoginDuration   = "10h"
 	defAdminEmail      = ""
 	defTimeout         = "1s"
@@ -65,6 +69,10 @@ const (
 	defUsersCACerts    = ""
 	defUsersClientTLS  = "false"
 	defUsersGRPCURL    = "localhost:8184"
+	defESURL           = "localhost:6379"
+	defESPass          = ""
+	defESDB            = "0"
+	defQuota           = "0"
 
 	envLogLevel        = "MF_AUTH_LOG_LEVEL"
 	envDBHost          = "MF_AUTH_DB_HOST"
@@ -83,6 +91,7 @@ const (
 	envServerCert      = "MF_AUTH_SERVER_CERT"
 	envServerKey       = "MF_AUTH_SERVER_KEY"
 	envJaegerURL       = "MF_JAEGER_URL"
+	envTrustedProxies  = "MF_TRUSTED_PROXIES"
 	envLoginDuration   = "MF_AUTH_LOGIN_TOKEN_DURATION"
 	envAdminEmail      = "MF_USERS_ADMIN_EMAIL"
 	envThingsGRPCURL   = "MF_THINGS_AUTH_GRPC_URL"
@@ -91,6 +100,18 @@ const (
 	envUsersGRPCURL    = "MF_USERS_GRPC_URL"
 	envUsersCACerts    = "MF_USERS_CA_CERTS"
 	envUsersClientTLS  = "MF_USERS_CLIENT_TLS"
+	envESURL           = "MF_AUTH_ES_URL"
+	envESPass          = "MF_AUTH_ES_PASS"
+	envESDB            = "MF_AUTH_ES_DB"
+
+	envUserQuotaThings        = "MF_AUTH_USER_QUOTA_THINGS"
+	envUserQuotaChannels      = "MF_AUTH_USER_QUOTA_CHANNELS"
+	envUserQuotaGroups        = "MF_AUTH_USER_QUOTA_GROUPS"
+	envUserQuotaKeys          = "MF_AUTH_USER_QUOTA_KEYS"
+	envUserQuotaSubscriptions = "MF_AUTH_USER_QUOTA_SUBSCRIPTIONS"
+	envOrgQuotaThings         = "MF_AUTH_ORG_QUOTA_THINGS"
+	envOrgQuotaChannels       = "MF_AUTH_ORG_QUOTA_CHANNELS"
+	envOrgQuotaGroups         = "MF_AUTH_ORG_QUOTA_GROUPS"
 )
 
 type config struct {
@@ -111,6 +132,10 @@ type config struct {
 	usersClientTLS  bool
 	usersCACerts    string
 	usersGRPCURL    string
+	esURL           string
+	esPass          string
+	esDB            string
+	quotaDefaults   auth.QuotaDefaults
 }
 
 func main() {
@@ -147,7 +172,10 @@ func main() {
 
 	tc := thingsapi.NewClient(thConn, thingsTracer, cfg.timeout)
 
-	svc := newService(db, tc, uc, dbTracer, cfg.secret, logger, cfg.loginDuration)
+	esClient := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esClient.Close()
+
+	svc := newService(db, tc, uc, esClient, dbTracer, cfg.secret, cfg.quotaDefaults, logger, cfg.loginDuration)
 
 	g.Go(func() error {
 		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger)
@@ -169,6 +197,10 @@ func main() {
 }
 
 func loadConfig() config {
+	if err := apiutil.SetTrustedProxies(mainflux.Env(envTrustedProxies, defTrustedProxies)); err != nil {
+		log.Fatalf("Invalid %s value: %s", envTrustedProxies, err.Error())
+	}
+
 	dbConfig := postgres.Config{
 		Host:        mainflux.Env(envDBHost, defDBHost),
 		Port:        mainflux.Env(envDBPort, defDBPort),
@@ -201,6 +233,21 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
 	}
 
+	quotaDefaults := auth.QuotaDefaults{
+		User: auth.Limits{
+			Things:        parseQuota(envUserQuotaThings),
+			Channels:      parseQuota(envUserQuotaChannels),
+			Groups:        parseQuota(envUserQuotaGroups),
+			Keys:          parseQuota(envUserQuotaKeys),
+			Subscriptions: parseQuota(envUserQuotaSubscriptions),
+		},
+		Org: auth.Limits{
+			Things:   parseQuota(envOrgQuotaThings),
+			Channels: parseQuota(envOrgQuotaChannels),
+			Groups:   parseQuota(envOrgQuotaGroups),
+		},
+	}
+
 	return config{
 		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
 		dbConfig:        dbConfig,
@@ -219,10 +266,23 @@ func loadConfig() config {
 		usersClientTLS:  usersClientTLS,
 		usersCACerts:    mainflux.Env(envUsersCACerts, defUsersCACerts),
 		usersGRPCURL:    mainflux.Env(envUsersGRPCURL, defUsersGRPCURL),
+		esURL:           mainflux.Env(envESURL, defESURL),
+		esPass:          mainflux.Env(envESPass, defESPass),
+		esDB:            mainflux.Env(envESDB, defESDB),
+		quotaDefaults:   quotaDefaults,
 	}
 
 }
 
+func parseQuota(key string) uint64 {
+	limit, err := strconv.ParseUint(mainflux.Env(key, defQuota), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", key, err.Error())
+	}
+
+	return limit
+}
+
 func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
 	if url == "" {
 		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
@@ -307,23 +367,44 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
-func newService(db *sqlx.DB, tc mainflux.ThingsServiceClient, uc mainflux.UsersServiceClient, tracer opentracing.Tracer, secret string, logger logger.Logger, duration time.Duration) auth.Service {
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func newService(db *sqlx.DB, tc mainflux.ThingsServiceClient, uc mainflux.UsersServiceClient, esClient *redis.Client, tracer opentracing.Tracer, secret string, qd auth.QuotaDefaults, logger logger.Logger, duration time.Duration) auth.Service {
 	orgsRepo := postgres.NewOrgRepo(db)
 	orgsRepo = tracing.OrgRepositoryMiddleware(tracer, orgsRepo)
 
 	database := postgres.NewDatabase(db)
 	keysRepo := tracing.New(postgres.New(database), tracer)
 
+	orgRolesRepo := postgres.NewOrgRolesRepo(db)
+	orgRolesRepo = tracing.OrgRolesRepositoryMiddleware(tracer, orgRolesRepo)
+
 	rolesRepo := postgres.NewRolesRepo(db)
 	rolesRepo = tracing.RolesRepositoryMiddleware(tracer, rolesRepo)
 
 	policiesRepo := postgres.NewPoliciesRepo(db)
 	policiesRepo = tracing.PoliciesRepositoryMiddleware(tracer, policiesRepo)
 
+	quotasRepo := postgres.NewQuotasRepo(db)
+	quotasRepo = tracing.QuotasRepositoryMiddleware(tracer, quotasRepo)
+
 	idProvider := uuid.New()
 	t := jwt.New(secret)
 
-	svc := auth.New(orgsRepo, tc, uc, keysRepo, rolesRepo, policiesRepo, idProvider, t, duration)
+	svc := auth.New(orgsRepo, orgRolesRepo, tc, uc, keysRepo, rolesRepo, policiesRepo, quotasRepo, qd, idProvider, t, duration)
+	svc = redisprod.NewEventStoreMiddleware(svc, esClient)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
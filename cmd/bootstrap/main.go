@@ -280,7 +280,7 @@ func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, logger logger.Logger
 	sdk := mfsdk.NewSDK(config)
 
 	svc := bootstrap.New(ac, thingsRepo, sdk, cfg.encKey)
-	svc = redisprod.NewEventStoreMiddleware(svc, esClient)
+	svc = redisprod.NewEventStoreMiddleware(svc, ac, esClient)
 	svc = api.NewLoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -21,10 +21,12 @@ import (
 	"github.com/MainfluxLabs/mainflux/certs/api"
 	vault "github.com/MainfluxLabs/mainflux/certs/pki"
 	"github.com/MainfluxLabs/mainflux/certs/postgres"
+	redisprod "github.com/MainfluxLabs/mainflux/certs/redis"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -57,6 +59,9 @@ const (
 	defJaegerURL       = ""
 	defAuthGRPCURL     = "localhost:8181"
 	defAuthGRPCTimeout = "1s"
+	defESURL           = "localhost:6379"
+	defESPass          = ""
+	defESDB            = "0"
 
 	defSignCAPath     = "ca.crt"
 	defSignCAKeyPath  = "ca.key"
@@ -87,6 +92,9 @@ const (
 	envJaegerURL       = "MF_JAEGER_URL"
 	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
 	envAuthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
+	envESURL           = "MF_CERTS_ES_URL"
+	envESPass          = "MF_CERTS_ES_PASS"
+	envESDB            = "MF_CERTS_ES_DB"
 	envThingsURL       = "MF_THINGS_URL"
 	envSignCAPath      = "MF_CERTS_SIGN_CA_PATH"
 	envSignCAKey       = "MF_CERTS_SIGN_CA_KEY_PATH"
@@ -119,6 +127,9 @@ type config struct {
 	jaegerURL       string
 	authGRPCURL     string
 	authGRPCTimeout time.Duration
+	esURL           string
+	esPass          string
+	esDB            string
 	// Sign and issue certificates without 3rd party PKI
 	signCAPath     string
 	signCAKeyPath  string
@@ -166,7 +177,10 @@ func main() {
 
 	auth := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
 
-	svc := newService(auth, db, logger, tlsCert, caCert, cfg, pkiClient)
+	esClient := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esClient.Close()
+
+	svc := newService(auth, db, esClient, logger, tlsCert, caCert, cfg, pkiClient)
 
 	g.Go(func() error {
 		return startHTTPServer(ctx, svc, cfg, logger)
@@ -225,6 +239,9 @@ func loadConfig() config {
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
 		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
 		authGRPCTimeout: authGRPCTimeout,
+		esURL:           mainflux.Env(envESURL, defESURL),
+		esPass:          mainflux.Env(envESPass, defESPass),
+		esDB:            mainflux.Env(envESDB, defESDB),
 
 		signCAKeyPath:  mainflux.Env(envSignCAKey, defSignCAKeyPath),
 		signCAPath:     mainflux.Env(envSignCAPath, defSignCAPath),
@@ -297,7 +314,21 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 	return tracer, closer
 }
 
-func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, logger logger.Logger, tlsCert tls.Certificate, x509Cert *x509.Certificate, cfg config, pkiAgent vault.Agent) certs.Service {
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, esClient *redis.Client, logger logger.Logger, tlsCert tls.Certificate, x509Cert *x509.Certificate, cfg config, pkiAgent vault.Agent) certs.Service {
 	certsRepo := postgres.NewRepository(db, logger)
 
 	certsConfig := certs.Config{
@@ -329,6 +360,7 @@ func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, logger logger.Logger
 	sdk := mfsdk.NewSDK(config)
 
 	svc := certs.New(ac, certsRepo, sdk, certsConfig, pkiAgent)
+	svc = redisprod.NewEventStoreMiddleware(svc, ac, esClient)
 	svc = api.NewLoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -186,6 +186,14 @@ func main() {
 		"Metadata query parameter",
 	)
 
+	rootCmd.PersistentFlags().StringVarP(
+		&cli.Tags,
+		"tags",
+		"T",
+		"",
+		"Comma-separated tag filter terms query parameter",
+	)
+
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
 	}
@@ -5,6 +5,8 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -20,10 +22,17 @@ import (
 	logger "github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	opentracing "github.com/opentracing/opentracing-go"
-	gocoap "github.com/plgd-dev/go-coap/v2"
+	piondtls "github.com/pion/dtls/v2"
+	"github.com/plgd-dev/go-coap/v2/dtls"
+	coapnet "github.com/plgd-dev/go-coap/v2/net"
+	"github.com/plgd-dev/go-coap/v2/net/blockwise"
+	"github.com/plgd-dev/go-coap/v2/udp"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
 	"golang.org/x/sync/errgroup"
@@ -42,6 +51,16 @@ const (
 	defJaegerURL         = ""
 	defThingsGRPCURL     = "localhost:8183"
 	defThingsGRPCTimeout = "1s"
+	defRateLimit         = "0"
+	defRateBurst         = "0"
+	defMaxPayloadSize    = "0"
+	defDTLSPort          = "5684"
+	defServerCert        = ""
+	defServerKey         = ""
+	defClientCACerts     = ""
+	defCertsURL          = ""
+	defBlockSize         = "1024"
+	defBlockwiseTimeout  = "10s"
 
 	envPort              = "MF_COAP_ADAPTER_PORT"
 	envBrokerURL         = "MF_BROKER_URL"
@@ -51,6 +70,16 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envRateLimit         = "MF_COAP_ADAPTER_RATE_LIMIT"
+	envRateBurst         = "MF_COAP_ADAPTER_RATE_BURST"
+	envMaxPayloadSize    = "MF_COAP_ADAPTER_MAX_PAYLOAD_SIZE"
+	envDTLSPort          = "MF_COAP_ADAPTER_DTLS_PORT"
+	envServerCert        = "MF_COAP_ADAPTER_SERVER_CERT"
+	envServerKey         = "MF_COAP_ADAPTER_SERVER_KEY"
+	envClientCACerts     = "MF_COAP_ADAPTER_CLIENT_CA_CERTS"
+	envCertsURL          = "MF_COAP_ADAPTER_CERTS_URL"
+	envBlockSize         = "MF_COAP_ADAPTER_BLOCK_SIZE"
+	envBlockwiseTimeout  = "MF_COAP_ADAPTER_BLOCKWISE_TIMEOUT"
 )
 
 type config struct {
@@ -62,6 +91,14 @@ type config struct {
 	jaegerURL         string
 	thingsGRPCURL     string
 	thingsGRPCTimeout time.Duration
+	limits            ratelimit.Config
+	dtlsPort          string
+	serverCert        string
+	serverKey         string
+	clientCACerts     string
+	certsURL          string
+	blockSZX          blockwise.SZX
+	blockwiseTimeout  time.Duration
 }
 
 func main() {
@@ -89,7 +126,7 @@ func main() {
 	}
 	defer nps.Close()
 
-	svc := coap.New(tc, nps)
+	svc := coap.New(tc, nps, ratelimit.New(cfg.limits), schema.NewChannelValidator(tc))
 
 	svc = api.LoggingMiddleware(svc, logger)
 
@@ -107,6 +144,12 @@ func main() {
 			Name:      "request_latency_microseconds",
 			Help:      "Total duration of requests in microseconds.",
 		}, []string{"method"}),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "coap_adapter",
+			Subsystem: "api",
+			Name:      "payload_schema_violations",
+			Help:      "Number of messages rejected for not conforming to the channel schema.",
+		}, []string{"channel"}),
 	)
 
 	g.Go(func() error {
@@ -117,6 +160,12 @@ func main() {
 		return startCOAPServer(ctx, cfg, svc, logger)
 	})
 
+	if cfg.serverCert != "" {
+		g.Go(func() error {
+			return startDTLSServer(ctx, cfg, svc, logger)
+		})
+	}
+
 	g.Go(func() error {
 		if sig := errors.SignalHandler(ctx); sig != nil {
 			cancel()
@@ -141,6 +190,35 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
 	}
 
+	rateLimit, err := strconv.ParseFloat(mainflux.Env(envRateLimit, defRateLimit), 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRateLimit, err.Error())
+	}
+
+	rateBurst, err := strconv.Atoi(mainflux.Env(envRateBurst, defRateBurst))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRateBurst, err.Error())
+	}
+
+	maxPayloadSize, err := strconv.Atoi(mainflux.Env(envMaxPayloadSize, defMaxPayloadSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envMaxPayloadSize, err.Error())
+	}
+
+	blockSize, err := strconv.Atoi(mainflux.Env(envBlockSize, defBlockSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBlockSize, err.Error())
+	}
+	blockSZX, err := parseBlockSize(blockSize)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBlockSize, err.Error())
+	}
+
+	blockwiseTimeout, err := time.ParseDuration(mainflux.Env(envBlockwiseTimeout, defBlockwiseTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBlockwiseTimeout, err.Error())
+	}
+
 	return config{
 		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
 		port:              mainflux.Env(envPort, defPort),
@@ -150,7 +228,60 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
 		thingsGRPCTimeout: thingsGRPCTimeout,
+		limits: ratelimit.Config{
+			Rate:           rateLimit,
+			Burst:          rateBurst,
+			MaxPayloadSize: maxPayloadSize,
+		},
+		dtlsPort:         mainflux.Env(envDTLSPort, defDTLSPort),
+		serverCert:       mainflux.Env(envServerCert, defServerCert),
+		serverKey:        mainflux.Env(envServerKey, defServerKey),
+		clientCACerts:    mainflux.Env(envClientCACerts, defClientCACerts),
+		certsURL:         mainflux.Env(envCertsURL, defCertsURL),
+		blockSZX:         blockSZX,
+		blockwiseTimeout: blockwiseTimeout,
+	}
+}
+
+// parseBlockSize converts the block size in bytes to its SZX exponent.
+func parseBlockSize(size int) (blockwise.SZX, error) {
+	for szx, s := blockwise.SZX16, 16; szx <= blockwise.SZX1024; szx, s = szx+1, s*2 {
+		if s == size {
+			return szx, nil
+		}
 	}
+	return 0, fmt.Errorf("block size must be a power of two between 16 and 1024, got %d", size)
+}
+
+func dtlsConfig(cfg config) (*piondtls.Config, error) {
+	dc := &piondtls.Config{}
+	cert, err := tls.LoadX509KeyPair(cfg.serverCert, cfg.serverKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	dc.Certificates = []tls.Certificate{cert}
+
+	if cfg.clientCACerts != "" {
+		ca, err := ioutil.ReadFile(cfg.clientCACerts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client CA certificates: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to parse client CA certificates")
+		}
+		dc.ClientCAs = pool
+		// Things without a certificate may still authenticate with the
+		// key in the request.
+		dc.ClientAuth = piondtls.VerifyClientCertIfGiven
+	}
+
+	if cfg.certsURL != "" {
+		sdk := mfsdk.NewSDK(mfsdk.Config{CertsURL: cfg.certsURL})
+		dc.VerifyPeerCertificate = api.MakeCertVerifier(sdk)
+	}
+
+	return dc, nil
 }
 
 func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
@@ -228,16 +359,62 @@ func startHTTPServer(ctx context.Context, port string, logger logger.Logger) err
 
 func startCOAPServer(ctx context.Context, cfg config, svc coap.Service, l logger.Logger) error {
 	p := fmt.Sprintf(":%s", cfg.port)
+	ln, err := coapnet.NewListenUDP("udp", p)
+	if err != nil {
+		return err
+	}
+	defer ln.Close()
+
+	server := udp.NewServer(
+		udp.WithMux(api.MakeCoAPHandler(svc, l)),
+		udp.WithBlockwise(true, cfg.blockSZX, cfg.blockwiseTimeout),
+	)
+
 	errCh := make(chan error)
 	l.Info(fmt.Sprintf("CoAP adapter service started, exposed port %s", cfg.port))
 	go func() {
-		errCh <- gocoap.ListenAndServe("udp", p, api.MakeCoAPHandler(svc, l))
+		errCh <- server.Serve(ln)
 	}()
 	select {
 	case <-ctx.Done():
+		server.Stop()
 		l.Info(fmt.Sprintf("CoAP adapter service shutdown of http at %s", p))
 		return nil
 	case err := <-errCh:
 		return err
 	}
 }
+
+func startDTLSServer(ctx context.Context, cfg config, svc coap.Service, l logger.Logger) error {
+	dc, err := dtlsConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	p := fmt.Sprintf(":%s", cfg.dtlsPort)
+	ln, err := coapnet.NewDTLSListener("udp", p, dc)
+	if err != nil {
+		return err
+	}
+	defer ln.Close()
+
+	server := dtls.NewServer(
+		dtls.WithMux(api.MakeCoAPHandler(svc, l)),
+		dtls.WithBlockwise(true, cfg.blockSZX, cfg.blockwiseTimeout),
+		dtls.WithOnNewClientConn(api.MakeDTLSConnHandler(l)),
+	)
+
+	errCh := make(chan error)
+	l.Info(fmt.Sprintf("CoAP adapter DTLS service started, exposed port %s", cfg.dtlsPort))
+	go func() {
+		errCh <- server.Serve(ln)
+	}()
+	select {
+	case <-ctx.Done():
+		server.Stop()
+		l.Info(fmt.Sprintf("CoAP adapter DTLS service shutdown at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
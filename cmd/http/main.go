@@ -20,6 +20,8 @@ import (
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/opentracing/opentracing-go"
@@ -41,6 +43,11 @@ const (
 	defJaegerURL         = ""
 	defThingsGRPCURL     = "localhost:8183"
 	defThingsGRPCTimeout = "1s"
+	defRateLimit         = "0"
+	defRateBurst         = "0"
+	defMaxPayloadSize    = "0"
+	defMaxBatchSize      = "1000"
+	defMaxBatchBytes     = "1048576"
 
 	envLogLevel          = "MF_HTTP_ADAPTER_LOG_LEVEL"
 	envClientTLS         = "MF_HTTP_ADAPTER_CLIENT_TLS"
@@ -50,6 +57,11 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envRateLimit         = "MF_HTTP_ADAPTER_RATE_LIMIT"
+	envRateBurst         = "MF_HTTP_ADAPTER_RATE_BURST"
+	envMaxPayloadSize    = "MF_HTTP_ADAPTER_MAX_PAYLOAD_SIZE"
+	envMaxBatchSize      = "MF_HTTP_ADAPTER_MAX_BATCH_SIZE"
+	envMaxBatchBytes     = "MF_HTTP_ADAPTER_MAX_BATCH_BYTES"
 )
 
 type config struct {
@@ -61,6 +73,8 @@ type config struct {
 	jaegerURL         string
 	thingsGRPCURL     string
 	thingsGRPCTimeout time.Duration
+	limits            ratelimit.Config
+	batchLimits       api.BatchLimits
 }
 
 func main() {
@@ -90,7 +104,7 @@ func main() {
 	defer pub.Close()
 
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout)
-	svc := adapter.New(pub, tc)
+	svc := adapter.New(pub, tc, ratelimit.New(cfg.limits), schema.NewChannelValidator(tc))
 
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
@@ -107,6 +121,12 @@ func main() {
 			Name:      "request_latency_microseconds",
 			Help:      "Total duration of requests in microseconds.",
 		}, []string{"method"}),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "http_adapter",
+			Subsystem: "api",
+			Name:      "payload_schema_violations",
+			Help:      "Number of messages rejected for not conforming to the channel schema.",
+		}, []string{"channel"}),
 	)
 
 	g.Go(func() error {
@@ -137,6 +157,31 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
 	}
 
+	rateLimit, err := strconv.ParseFloat(mainflux.Env(envRateLimit, defRateLimit), 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRateLimit, err.Error())
+	}
+
+	rateBurst, err := strconv.Atoi(mainflux.Env(envRateBurst, defRateBurst))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRateBurst, err.Error())
+	}
+
+	maxPayloadSize, err := strconv.Atoi(mainflux.Env(envMaxPayloadSize, defMaxPayloadSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envMaxPayloadSize, err.Error())
+	}
+
+	maxBatchSize, err := strconv.Atoi(mainflux.Env(envMaxBatchSize, defMaxBatchSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envMaxBatchSize, err.Error())
+	}
+
+	maxBatchBytes, err := strconv.ParseInt(mainflux.Env(envMaxBatchBytes, defMaxBatchBytes), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envMaxBatchBytes, err.Error())
+	}
+
 	return config{
 		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
 		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
@@ -146,6 +191,15 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
 		thingsGRPCTimeout: thingsGRPCTimeout,
+		limits: ratelimit.Config{
+			Rate:           rateLimit,
+			Burst:          rateBurst,
+			MaxPayloadSize: maxPayloadSize,
+		},
+		batchLimits: api.BatchLimits{
+			MaxMessages: maxBatchSize,
+			MaxBytes:    maxBatchBytes,
+		},
 	}
 }
 
@@ -200,7 +254,7 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 func startHTTPServer(ctx context.Context, svc adapter.Service, cfg config, logger logger.Logger, tracer opentracing.Tracer) error {
 	p := fmt.Sprintf(":%s", cfg.port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, tracer, logger)}
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, cfg.batchLimits, tracer, logger)}
 	logger.Info(fmt.Sprintf("HTTP adapter service started on port %s", cfg.port))
 	go func() {
 		errCh <- server.ListenAndServe()
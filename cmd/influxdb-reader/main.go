@@ -13,6 +13,7 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/readers"
@@ -47,6 +48,7 @@ const (
 	defServerCert        = ""
 	defServerKey         = ""
 	defJaegerURL         = ""
+	defTrustedProxies    = ""
 	defThingsGRPCURL     = "localhost:8183"
 	defThingsGRPCTimeout = "1s"
 	defAuthGRPCURL       = "localhost:8181"
@@ -67,6 +69,7 @@ const (
 	envServerCert        = "MF_INFLUX_READER_SERVER_CERT"
 	envServerKey         = "MF_INFLUX_READER_SERVER_KEY"
 	envJaegerURL         = "MF_JAEGER_URL"
+	envTrustedProxies    = "MF_TRUSTED_PROXIES"
 	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
@@ -180,6 +183,10 @@ func connectToInfluxDB(cfg config) (influxdb2.Client, error) {
 }
 
 func loadConfigs() (config, influxdb.RepoConfig) {
+	if err := apiutil.SetTrustedProxies(mainflux.Env(envTrustedProxies, defTrustedProxies)); err != nil {
+		log.Fatalf("Invalid %s value: %s", envTrustedProxies, err.Error())
+	}
+
 	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
 	if err != nil {
 		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
@@ -18,6 +18,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/lora/api"
 	"github.com/MainfluxLabs/mainflux/lora/mqtt"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
 	mqttPaho "github.com/eclipse/paho.mqtt.golang"
 	r "github.com/go-redis/redis/v8"
@@ -36,6 +37,13 @@ const (
 	defMsgURL         = "tcp://localhost:1883"
 	defBrokerURL      = "nats://localhost:4222"
 	defMsgTopic       = "application/+/device/+/event/up"
+	defAckTopic       = "application/+/device/+/event/ack"
+	defDownSubtopic   = "lora.downlink"
+	defRadioSubtopic  = ""
+	defTTNURL         = ""
+	defTTNTopic       = "v3/+/devices/+/up"
+	defTTNUser        = ""
+	defTTNPass        = ""
 	defMsgUser        = ""
 	defMsgPass        = ""
 	defMsgTimeout     = "30s"
@@ -51,6 +59,13 @@ const (
 	envMsgURL         = "MF_LORA_ADAPTER_MESSAGES_URL"
 	envBrokerURL      = "MF_BROKER_URL"
 	envMsgTopic       = "MF_LORA_ADAPTER_MESSAGES_TOPIC"
+	envAckTopic       = "MF_LORA_ADAPTER_ACK_TOPIC"
+	envDownSubtopic   = "MF_LORA_ADAPTER_DOWNLINK_SUBTOPIC"
+	envRadioSubtopic  = "MF_LORA_ADAPTER_RADIO_SUBTOPIC"
+	envTTNURL         = "MF_LORA_ADAPTER_TTN_URL"
+	envTTNTopic       = "MF_LORA_ADAPTER_TTN_TOPIC"
+	envTTNUser        = "MF_LORA_ADAPTER_TTN_USER"
+	envTTNPass        = "MF_LORA_ADAPTER_TTN_PASS"
 	envMsgUser        = "MF_LORA_ADAPTER_MESSAGES_USER"
 	envMsgPass        = "MF_LORA_ADAPTER_MESSAGES_PASS"
 	envMsgTimeout     = "MF_LORA_ADAPTER_MESSAGES_TIMEOUT"
@@ -66,6 +81,9 @@ const (
 	thingsRMPrefix   = "thing"
 	channelsRMPrefix = "channel"
 	connsRMPrefix    = "connection"
+
+	svcName   = "lora"
+	ackSuffix = "ack"
 )
 
 type config struct {
@@ -75,6 +93,13 @@ type config struct {
 	msgUser        string
 	msgPass        string
 	msgTopic       string
+	ackTopic       string
+	downSubtopic   string
+	radioSubtopic  string
+	ttnURL         string
+	ttnTopic       string
+	ttnUser        string
+	ttnPass        string
 	msgTimeout     time.Duration
 	logLevel       string
 	esURL          string
@@ -102,18 +127,23 @@ func main() {
 	esConn := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
 	defer esConn.Close()
 
-	pub, err := brokers.NewPublisher(cfg.brokerURL)
+	pubSub, err := brokers.NewPubSub(cfg.brokerURL, svcName, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
 		os.Exit(1)
 	}
-	defer pub.Close()
+	defer pubSub.Close()
+
+	mqttConn := connectToMQTTBroker(cfg.msgURL, cfg.msgUser, cfg.msgPass, cfg.msgTimeout, logger)
 
 	thingsRM := newRouteMapRepository(rmConn, thingsRMPrefix, logger)
 	chansRM := newRouteMapRepository(rmConn, channelsRMPrefix, logger)
 	connsRM := newRouteMapRepository(rmConn, connsRMPrefix, logger)
 
-	svc := lora.New(pub, thingsRM, chansRM, connsRM)
+	downlinks := mqtt.NewPublisher(mqttConn, cfg.msgTimeout)
+	ackSubtopic := fmt.Sprintf("%s.%s", cfg.downSubtopic, ackSuffix)
+
+	svc := lora.New(pubSub, downlinks, thingsRM, chansRM, connsRM, ackSubtopic, cfg.radioSubtopic)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -131,9 +161,12 @@ func main() {
 		}, []string{"method"}),
 	)
 
-	mqttConn := connectToMQTTBroker(cfg.msgURL, cfg.msgUser, cfg.msgPass, cfg.msgTimeout, logger)
-
-	go subscribeToLoRaBroker(svc, mqttConn, cfg.msgTimeout, cfg.msgTopic, logger)
+	go subscribeToLoRaBroker(svc, mqttConn, lora.ChirpStack, cfg.msgTimeout, logger, cfg.msgTopic, cfg.ackTopic)
+	if cfg.ttnURL != "" {
+		ttnConn := connectToMQTTBroker(cfg.ttnURL, cfg.ttnUser, cfg.ttnPass, cfg.msgTimeout, logger)
+		go subscribeToLoRaBroker(svc, ttnConn, lora.TTN, cfg.msgTimeout, logger, cfg.ttnTopic)
+	}
+	go subscribeToDownlinks(svc, pubSub, cfg.downSubtopic, logger)
 	go subscribeToThingsES(svc, esConn, cfg.esConsumerName, logger)
 
 	g.Go(func() error {
@@ -165,6 +198,13 @@ func loadConfig() config {
 		msgURL:         mainflux.Env(envMsgURL, defMsgURL),
 		brokerURL:      mainflux.Env(envBrokerURL, defBrokerURL),
 		msgTopic:       mainflux.Env(envMsgTopic, defMsgTopic),
+		ackTopic:       mainflux.Env(envAckTopic, defAckTopic),
+		downSubtopic:   mainflux.Env(envDownSubtopic, defDownSubtopic),
+		radioSubtopic:  mainflux.Env(envRadioSubtopic, defRadioSubtopic),
+		ttnURL:         mainflux.Env(envTTNURL, defTTNURL),
+		ttnTopic:       mainflux.Env(envTTNTopic, defTTNTopic),
+		ttnUser:        mainflux.Env(envTTNUser, defTTNUser),
+		ttnPass:        mainflux.Env(envTTNPass, defTTNPass),
 		msgUser:        mainflux.Env(envMsgUser, defMsgUser),
 		msgPass:        mainflux.Env(envMsgPass, defMsgPass),
 		msgTimeout:     mqttTimeout,
@@ -216,13 +256,30 @@ func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *
 	})
 }
 
-func subscribeToLoRaBroker(svc lora.Service, mc mqttPaho.Client, timeout time.Duration, topic string, logger logger.Logger) {
-	mqtt := mqtt.NewBroker(svc, mc, timeout, logger)
-	logger.Info("Subscribed to Lora MQTT broker")
-	if err := mqtt.Subscribe(topic); err != nil {
-		logger.Error(fmt.Sprintf("Failed to subscribe to Lora MQTT broker: %s", err))
+func subscribeToLoRaBroker(svc lora.Service, mc mqttPaho.Client, lns string, timeout time.Duration, logger logger.Logger, topics ...string) {
+	decoder, err := lora.NewDecoder(lns)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create %s decoder: %s", lns, err))
+		os.Exit(1)
+	}
+
+	mqtt := mqtt.NewBroker(svc, mc, decoder, timeout, logger)
+	logger.Info(fmt.Sprintf("Subscribed to %s MQTT broker", lns))
+	for _, topic := range topics {
+		if err := mqtt.Subscribe(topic); err != nil {
+			logger.Error(fmt.Sprintf("Failed to subscribe to %s MQTT broker: %s", lns, err))
+			os.Exit(1)
+		}
+	}
+}
+
+func subscribeToDownlinks(svc lora.Service, sub messaging.Subscriber, subtopic string, logger logger.Logger) {
+	topic := fmt.Sprintf("channels.*.%s", subtopic)
+	if err := sub.Subscribe(svcName, topic, lora.NewDownlinkHandler(svc)); err != nil {
+		logger.Error(fmt.Sprintf("Failed to subscribe to %s downlink subtopic: %s", subtopic, err))
 		os.Exit(1)
 	}
+	logger.Info(fmt.Sprintf("Subscribed to %s downlink subtopic", subtopic))
 }
 
 func subscribeToThingsES(svc lora.Service, client *r.Client, consumer string, logger logger.Logger) {
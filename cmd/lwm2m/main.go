@@ -0,0 +1,440 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+	"github.com/MainfluxLabs/mainflux/lwm2m/api"
+	"github.com/MainfluxLabs/mainflux/lwm2m/redis"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	r "github.com/go-redis/redis/v8"
+	opentracing "github.com/opentracing/opentracing-go"
+	piondtls "github.com/pion/dtls/v2"
+	gocoap "github.com/plgd-dev/go-coap/v2"
+	"github.com/plgd-dev/go-coap/v2/dtls"
+	coapnet "github.com/plgd-dev/go-coap/v2/net"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel          = "error"
+	defPort              = "5683"
+	defHTTPPort          = "8192"
+	defBrokerURL         = "nats://localhost:4222"
+	defTimeout           = "10s"
+	defESURL             = "localhost:6379"
+	defESPass            = ""
+	defESDB              = "0"
+	defESConsumerName    = "lwm2m"
+	defDBURL             = "localhost:6379"
+	defDBPass            = ""
+	defDBDB              = "0"
+	defJaegerURL         = ""
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defAuthGRPCURL       = "localhost:8181"
+	defAuthGRPCTimeout   = "1s"
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+	defDTLSPort          = "5684"
+	defServerCert        = ""
+	defServerKey         = ""
+	defClientCACerts     = ""
+	defCertsURL          = ""
+
+	envPort              = "MF_LWM2M_ADAPTER_PORT"
+	envHTTPPort          = "MF_LWM2M_ADAPTER_HTTP_PORT"
+	envBrokerURL         = "MF_BROKER_URL"
+	envTimeout           = "MF_LWM2M_ADAPTER_TIMEOUT"
+	envLogLevel          = "MF_LWM2M_ADAPTER_LOG_LEVEL"
+	envESURL             = "MF_THINGS_ES_URL"
+	envESPass            = "MF_THINGS_ES_PASS"
+	envESDB              = "MF_THINGS_ES_DB"
+	envESConsumerName    = "MF_LWM2M_ADAPTER_EVENT_CONSUMER"
+	envDBURL             = "MF_LWM2M_ADAPTER_DB_URL"
+	envDBPass            = "MF_LWM2M_ADAPTER_DB_PASS"
+	envDBDB              = "MF_LWM2M_ADAPTER_DB"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envClientTLS         = "MF_LWM2M_ADAPTER_CLIENT_TLS"
+	envCACerts           = "MF_LWM2M_ADAPTER_CA_CERTS"
+	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envDTLSPort          = "MF_LWM2M_ADAPTER_DTLS_PORT"
+	envServerCert        = "MF_LWM2M_ADAPTER_SERVER_CERT"
+	envServerKey         = "MF_LWM2M_ADAPTER_SERVER_KEY"
+	envClientCACerts     = "MF_LWM2M_ADAPTER_CLIENT_CA_CERTS"
+	envCertsURL          = "MF_LWM2M_ADAPTER_CERTS_URL"
+
+	svcName = "lwm2m"
+)
+
+type config struct {
+	port              string
+	httpPort          string
+	brokerURL         string
+	timeout           time.Duration
+	logLevel          string
+	esURL             string
+	esPass            string
+	esDB              string
+	esConsumerName    string
+	dbURL             string
+	dbPass            string
+	dbDB              string
+	jaegerURL         string
+	clientTLS         bool
+	caCerts           string
+	authGRPCURL       string
+	authGRPCTimeout   time.Duration
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
+	dtlsPort          string
+	serverCert        string
+	serverKey         string
+	clientCACerts     string
+	certsURL          string
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	dbConn := connectToRedis(cfg.dbURL, cfg.dbPass, cfg.dbDB, logger)
+	defer dbConn.Close()
+
+	esConn := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esConn.Close()
+
+	pubSub, err := brokers.NewPubSub(cfg.brokerURL, svcName, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToGRPC(cfg, cfg.authGRPCURL, "auth", logger)
+	defer authConn.Close()
+
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	thingsConn := connectToGRPC(cfg, cfg.thingsGRPCURL, "things", logger)
+	defer thingsConn.Close()
+
+	ac := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
+	tc := thingsapi.NewClient(thingsConn, thingsTracer, cfg.thingsGRPCTimeout)
+	things := redis.NewThingRepository(dbConn)
+
+	svc := lwm2m.New(pubSub, ac, tc, things, uuid.New())
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "lwm2m_adapter",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "lwm2m_adapter",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	go subscribeToThingsES(svc, esConn, cfg.esConsumerName, logger)
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, cfg, svc, logger)
+	})
+
+	g.Go(func() error {
+		return startCOAPServer(ctx, cfg, svc, logger)
+	})
+
+	if cfg.serverCert != "" {
+		g.Go(func() error {
+			return startDTLSServer(ctx, cfg, svc, logger)
+		})
+	}
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("LwM2M adapter shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("LwM2M adapter terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	timeout, err := time.ParseDuration(mainflux.Env(envTimeout, defTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
+	return config{
+		port:              mainflux.Env(envPort, defPort),
+		httpPort:          mainflux.Env(envHTTPPort, defHTTPPort),
+		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
+		timeout:           timeout,
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		esURL:             mainflux.Env(envESURL, defESURL),
+		esPass:            mainflux.Env(envESPass, defESPass),
+		esDB:              mainflux.Env(envESDB, defESDB),
+		esConsumerName:    mainflux.Env(envESConsumerName, defESConsumerName),
+		dbURL:             mainflux.Env(envDBURL, defDBURL),
+		dbPass:            mainflux.Env(envDBPass, defDBPass),
+		dbDB:              mainflux.Env(envDBDB, defDBDB),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout:   authGRPCTimeout,
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
+		dtlsPort:          mainflux.Env(envDTLSPort, defDTLSPort),
+		serverCert:        mainflux.Env(envServerCert, defServerCert),
+		serverKey:         mainflux.Env(envServerKey, defServerKey),
+		clientCACerts:     mainflux.Env(envClientCACerts, defClientCACerts),
+		certsURL:          mainflux.Env(envCertsURL, defCertsURL),
+	}
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToGRPC(cfg config, url, name string, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(url, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to %s service: %s", name, err))
+		os.Exit(1)
+	}
+
+	return conn
+}
+
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *r.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return r.NewClient(&r.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func subscribeToThingsES(svc lwm2m.Service, client *r.Client, consumer string, logger logger.Logger) {
+	eventStore := redis.NewEventStore(svc, client, consumer, logger)
+	logger.Info("Subscribed to Redis Event Store")
+	if err := eventStore.Subscribe(context.Background(), "mainflux.things"); err != nil {
+		logger.Warn(fmt.Sprintf("LwM2M-adapter service failed to subscribe to Redis event source: %s", err))
+	}
+}
+
+func startHTTPServer(ctx context.Context, cfg config, svc lwm2m.Service, logger logger.Logger) error {
+	p := fmt.Sprintf(":%s", cfg.httpPort)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, logger)}
+
+	logger.Info(fmt.Sprintf("LwM2M-adapter service started, exposed port %s", cfg.httpPort))
+
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("LwM2M-adapter service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("LwM2M-adapter service error occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("LwM2M-adapter service shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+func startCOAPServer(ctx context.Context, cfg config, svc lwm2m.Service, logger logger.Logger) error {
+	p := fmt.Sprintf(":%s", cfg.port)
+	errCh := make(chan error)
+	logger.Info(fmt.Sprintf("LwM2M-adapter service started, exposed CoAP port %s", cfg.port))
+
+	go func() {
+		errCh <- gocoap.ListenAndServe("udp", p, api.MakeCoAPHandler(svc, cfg.timeout, logger))
+	}()
+
+	select {
+	case <-ctx.Done():
+		logger.Info(fmt.Sprintf("LwM2M-adapter service shutdown of CoAP at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+// dtlsConfig returns the DTLS configuration of the adapter. Clients must
+// authenticate with a certificate issued by the client CAs, whose common
+// name is the thing key.
+func dtlsConfig(cfg config) (*piondtls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.serverCert, cfg.serverKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	ca, err := ioutil.ReadFile(cfg.clientCACerts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client CA certificates: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("failed to parse client CA certificates")
+	}
+
+	dc := &piondtls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   piondtls.RequireAndVerifyClientCert,
+	}
+	if cfg.certsURL != "" {
+		sdk := mfsdk.NewSDK(mfsdk.Config{CertsURL: cfg.certsURL})
+		dc.VerifyPeerCertificate = api.MakeCertVerifier(sdk)
+	}
+
+	return dc, nil
+}
+
+func startDTLSServer(ctx context.Context, cfg config, svc lwm2m.Service, logger logger.Logger) error {
+	dc, err := dtlsConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	p := fmt.Sprintf(":%s", cfg.dtlsPort)
+	ln, err := coapnet.NewDTLSListener("udp", p, dc)
+	if err != nil {
+		return err
+	}
+	defer ln.Close()
+
+	server := dtls.NewServer(
+		dtls.WithMux(api.MakeCoAPHandler(svc, cfg.timeout, logger)),
+		dtls.WithOnNewClientConn(api.MakeDTLSConnHandler(logger)),
+	)
+
+	errCh := make(chan error)
+	logger.Info(fmt.Sprintf("LwM2M-adapter service started, exposed DTLS port %s", cfg.dtlsPort))
+	go func() {
+		errCh <- server.Serve(ln)
+	}()
+
+	select {
+	case <-ctx.Done():
+		server.Stop()
+		logger.Info(fmt.Sprintf("LwM2M-adapter service shutdown of DTLS at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
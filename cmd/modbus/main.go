@@ -0,0 +1,224 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/modbus"
+	"github.com/MainfluxLabs/mainflux/modbus/api"
+	"github.com/MainfluxLabs/mainflux/modbus/client"
+	"github.com/MainfluxLabs/mainflux/modbus/redis"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	r "github.com/go-redis/redis/v8"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel       = "error"
+	defHTTPPort       = "8191"
+	defBrokerURL      = "nats://localhost:4222"
+	defWriteSubtopic  = "modbus.write"
+	defTimeout        = "5s"
+	defESURL          = "localhost:6379"
+	defESPass         = ""
+	defESDB           = "0"
+	defESConsumerName = "modbus"
+	defDBURL          = "localhost:6379"
+	defDBPass         = ""
+	defDBDB           = "0"
+
+	envHTTPPort       = "MF_MODBUS_ADAPTER_HTTP_PORT"
+	envBrokerURL      = "MF_BROKER_URL"
+	envWriteSubtopic  = "MF_MODBUS_ADAPTER_WRITE_SUBTOPIC"
+	envTimeout        = "MF_MODBUS_ADAPTER_TIMEOUT"
+	envLogLevel       = "MF_MODBUS_ADAPTER_LOG_LEVEL"
+	envESURL          = "MF_THINGS_ES_URL"
+	envESPass         = "MF_THINGS_ES_PASS"
+	envESDB           = "MF_THINGS_ES_DB"
+	envESConsumerName = "MF_MODBUS_ADAPTER_EVENT_CONSUMER"
+	envDBURL          = "MF_MODBUS_ADAPTER_DB_URL"
+	envDBPass         = "MF_MODBUS_ADAPTER_DB_PASS"
+	envDBDB           = "MF_MODBUS_ADAPTER_DB"
+
+	svcName = "modbus"
+)
+
+type config struct {
+	httpPort       string
+	brokerURL      string
+	writeSubtopic  string
+	timeout        time.Duration
+	logLevel       string
+	esURL          string
+	esPass         string
+	esDB           string
+	esConsumerName string
+	dbURL          string
+	dbPass         string
+	dbDB           string
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	dbConn := connectToRedis(cfg.dbURL, cfg.dbPass, cfg.dbDB, logger)
+	defer dbConn.Close()
+
+	esConn := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esConn.Close()
+
+	pubSub, err := brokers.NewPubSub(cfg.brokerURL, svcName, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	things := redis.NewThingRepository(dbConn)
+	dialer := client.NewDialer(cfg.timeout)
+	scheduler := modbus.NewScheduler(logger)
+
+	svc := modbus.New(pubSub, dialer, things, scheduler)
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "modbus_adapter",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "modbus_adapter",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	if err := svc.Restore(ctx); err != nil {
+		logger.Error(fmt.Sprintf("Failed to restore Modbus things: %s", err))
+		os.Exit(1)
+	}
+
+	go subscribeToWrites(svc, pubSub, cfg.writeSubtopic, logger)
+	go subscribeToThingsES(svc, esConn, cfg.esConsumerName, logger)
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, cfg, logger)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("Modbus adapter shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Modbus adapter terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	timeout, err := time.ParseDuration(mainflux.Env(envTimeout, defTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
+	}
+
+	return config{
+		httpPort:       mainflux.Env(envHTTPPort, defHTTPPort),
+		brokerURL:      mainflux.Env(envBrokerURL, defBrokerURL),
+		writeSubtopic:  mainflux.Env(envWriteSubtopic, defWriteSubtopic),
+		timeout:        timeout,
+		logLevel:       mainflux.Env(envLogLevel, defLogLevel),
+		esURL:          mainflux.Env(envESURL, defESURL),
+		esPass:         mainflux.Env(envESPass, defESPass),
+		esDB:           mainflux.Env(envESDB, defESDB),
+		esConsumerName: mainflux.Env(envESConsumerName, defESConsumerName),
+		dbURL:          mainflux.Env(envDBURL, defDBURL),
+		dbPass:         mainflux.Env(envDBPass, defDBPass),
+		dbDB:           mainflux.Env(envDBDB, defDBDB),
+	}
+}
+
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *r.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return r.NewClient(&r.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func subscribeToWrites(svc modbus.Service, sub messaging.Subscriber, subtopic string, logger logger.Logger) {
+	topic := fmt.Sprintf("channels.*.%s", subtopic)
+	if err := sub.Subscribe(svcName, topic, modbus.NewDownlinkHandler(svc)); err != nil {
+		logger.Error(fmt.Sprintf("Failed to subscribe to %s write subtopic: %s", subtopic, err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Subscribed to %s write subtopic", subtopic))
+}
+
+func subscribeToThingsES(svc modbus.Service, client *r.Client, consumer string, logger logger.Logger) {
+	eventStore := redis.NewEventStore(svc, client, consumer, logger)
+	logger.Info("Subscribed to Redis Event Store")
+	if err := eventStore.Subscribe(context.Background(), "mainflux.things"); err != nil {
+		logger.Warn(fmt.Sprintf("Modbus-adapter service failed to subscribe to Redis event source: %s", err))
+	}
+}
+
+func startHTTPServer(ctx context.Context, cfg config, logger logger.Logger) error {
+	p := fmt.Sprintf(":%s", cfg.httpPort)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler()}
+
+	logger.Info(fmt.Sprintf("Modbus-adapter service started, exposed port %s", cfg.httpPort))
+
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("Modbus-adapter service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("Modbus-adapter service error occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("Modbus-adapter service shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/readers"
@@ -46,6 +47,7 @@ const (
 	defServerCert        = ""
 	defServerKey         = ""
 	defJaegerURL         = ""
+	defTrustedProxies    = ""
 	defThingsGRPCURL     = "localhost:8183"
 	defThingsGRPCTimeout = "1s"
 	defAuthGRPCURL       = "localhost:8181"
@@ -61,6 +63,7 @@ const (
 	envServerCert        = "MF_MONGO_READER_SERVER_CERT"
 	envServerKey         = "MF_MONGO_READER_SERVER_KEY"
 	envJaegerURL         = "MF_JAEGER_URL"
+	envTrustedProxies    = "MF_TRUSTED_PROXIES"
 	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
@@ -158,6 +161,10 @@ func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 }
 
 func loadConfigs() config {
+	if err := apiutil.SetTrustedProxies(mainflux.Env(envTrustedProxies, defTrustedProxies)); err != nil {
+		log.Fatalf("Invalid %s value: %s", envTrustedProxies, err.Error())
+	}
+
 	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
 	if err != nil {
 		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
@@ -23,6 +23,8 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
 	mqttpub "github.com/MainfluxLabs/mainflux/pkg/messaging/mqtt"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/pkg/ulid"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	"github.com/MainfluxLabs/mproxy/logger"
@@ -60,6 +62,9 @@ const (
 	defWSPort            = "8285"
 	defThingsGRPCURL     = "localhost:8183"
 	defThingsGRPCTimeout = "1s"
+	defRateLimit         = "0"
+	defRateBurst         = "0"
+	defMaxPayloadSize    = "0"
 	defBrokerURL         = "nats://localhost:4222"
 	defJaegerURL         = ""
 	defClientTLS         = "false"
@@ -98,6 +103,9 @@ const (
 	envWSPort            = "MF_MQTT_ADAPTER_WS_PORT"
 	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envRateLimit         = "MF_MQTT_ADAPTER_RATE_LIMIT"
+	envRateBurst         = "MF_MQTT_ADAPTER_RATE_BURST"
+	envMaxPayloadSize    = "MF_MQTT_ADAPTER_MAX_PAYLOAD_SIZE"
 	envBrokerURL         = "MF_BROKER_URL"
 	envJaegerURL         = "MF_JAEGER_URL"
 	envClientTLS         = "MF_MQTT_ADAPTER_CLIENT_TLS"
@@ -154,6 +162,7 @@ type config struct {
 	serverKey         string
 	authGRPCTimeout   time.Duration
 	dbConfig          postgres.Config
+	limits            ratelimit.Config
 }
 
 func main() {
@@ -239,7 +248,7 @@ func main() {
 	svc := newService(usersAuth, tc, db, logger)
 
 	// Event handler for MQTT hooks
-	h := mqtt.NewHandler([]messaging.Publisher{np}, es, logger, authClient, svc)
+	h := mqtt.NewHandler([]messaging.Publisher{np}, es, logger, authClient, svc, ratelimit.New(cfg.limits), schema.NewChannelValidator(tc))
 
 	logger.Info(fmt.Sprintf("Starting MQTT proxy on port %s", cfg.port))
 	g.Go(func() error {
@@ -302,6 +311,21 @@ func loadConfig() config {
 		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
 	}
 
+	rateLimit, err := strconv.ParseFloat(mainflux.Env(envRateLimit, defRateLimit), 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRateLimit, err.Error())
+	}
+
+	rateBurst, err := strconv.Atoi(mainflux.Env(envRateBurst, defRateBurst))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRateBurst, err.Error())
+	}
+
+	maxPayloadSize, err := strconv.Atoi(mainflux.Env(envMaxPayloadSize, defMaxPayloadSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envMaxPayloadSize, err.Error())
+	}
+
 	return config{
 		port:              mainflux.Env(envMQTTPort, defMQTTPort),
 		targetHost:        mainflux.Env(envTargetHost, defTargetHost),
@@ -332,6 +356,11 @@ func loadConfig() config {
 		serverKey:         mainflux.Env(envServerKey, defServerKey),
 		authGRPCTimeout:   authGRPCTimeout,
 		dbConfig:          dbConfig,
+		limits: ratelimit.Config{
+			Rate:           rateLimit,
+			Burst:          rateBurst,
+			MaxPayloadSize: maxPayloadSize,
+		},
 	}
 }
 
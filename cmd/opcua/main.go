@@ -0,0 +1,258 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/opcua"
+	"github.com/MainfluxLabs/mainflux/opcua/api"
+	"github.com/MainfluxLabs/mainflux/opcua/client"
+	"github.com/MainfluxLabs/mainflux/opcua/redis"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	r "github.com/go-redis/redis/v8"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel       = "error"
+	defHTTPPort       = "8188"
+	defBrokerURL      = "nats://localhost:4222"
+	defWriteSubtopic  = "opcua.write"
+	defInterval       = "1s"
+	defTimeout        = "10s"
+	defPolicy         = "None"
+	defMode           = "None"
+	defCertFile       = ""
+	defKeyFile        = ""
+	defESURL          = "localhost:6379"
+	defESPass         = ""
+	defESDB           = "0"
+	defESConsumerName = "opcua"
+	defRouteMapURL    = "localhost:6379"
+	defRouteMapPass   = ""
+	defRouteMapDB     = "0"
+
+	envHTTPPort       = "MF_OPCUA_ADAPTER_HTTP_PORT"
+	envBrokerURL      = "MF_BROKER_URL"
+	envWriteSubtopic  = "MF_OPCUA_ADAPTER_WRITE_SUBTOPIC"
+	envInterval       = "MF_OPCUA_ADAPTER_INTERVAL"
+	envTimeout        = "MF_OPCUA_ADAPTER_TIMEOUT"
+	envPolicy         = "MF_OPCUA_ADAPTER_POLICY"
+	envMode           = "MF_OPCUA_ADAPTER_MODE"
+	envCertFile       = "MF_OPCUA_ADAPTER_CERT_FILE"
+	envKeyFile        = "MF_OPCUA_ADAPTER_KEY_FILE"
+	envLogLevel       = "MF_OPCUA_ADAPTER_LOG_LEVEL"
+	envESURL          = "MF_THINGS_ES_URL"
+	envESPass         = "MF_THINGS_ES_PASS"
+	envESDB           = "MF_THINGS_ES_DB"
+	envESConsumerName = "MF_OPCUA_ADAPTER_EVENT_CONSUMER"
+	envRouteMapURL    = "MF_OPCUA_ADAPTER_ROUTE_MAP_URL"
+	envRouteMapPass   = "MF_OPCUA_ADAPTER_ROUTE_MAP_PASS"
+	envRouteMapDB     = "MF_OPCUA_ADAPTER_ROUTE_MAP_DB"
+
+	thingsRMPrefix   = "thing"
+	channelsRMPrefix = "channel"
+	connsRMPrefix    = "connection"
+
+	svcName = "opcua"
+)
+
+type config struct {
+	httpPort       string
+	brokerURL      string
+	writeSubtopic  string
+	opcua          client.Config
+	logLevel       string
+	esURL          string
+	esPass         string
+	esDB           string
+	esConsumerName string
+	routeMapURL    string
+	routeMapPass   string
+	routeMapDB     string
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	rmConn := connectToRedis(cfg.routeMapURL, cfg.routeMapPass, cfg.routeMapDB, logger)
+	defer rmConn.Close()
+
+	esConn := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esConn.Close()
+
+	pubSub, err := brokers.NewPubSub(cfg.brokerURL, svcName, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	thingsRM := newRouteMapRepository(rmConn, thingsRMPrefix, logger)
+	chansRM := newRouteMapRepository(rmConn, channelsRMPrefix, logger)
+	connsRM := newRouteMapRepository(rmConn, connsRMPrefix, logger)
+
+	// The client forwards the data changes to the service, which is
+	// created with the client, so it's referenced through the closure.
+	var svc opcua.Service
+	opcuaClient := client.NewClient(cfg.opcua, func(ctx context.Context, m opcua.Message) error {
+		return svc.Publish(ctx, m)
+	}, logger)
+	defer opcuaClient.Close()
+
+	svc = opcua.New(pubSub, opcuaClient, thingsRM, chansRM, connsRM)
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "opcua_adapter",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "opcua_adapter",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	go subscribeToWrites(svc, pubSub, cfg.writeSubtopic, logger)
+	go subscribeToThingsES(svc, esConn, cfg.esConsumerName, logger)
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, svc, cfg, logger)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("OPC UA adapter shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("OPC UA adapter terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	interval, err := time.ParseDuration(mainflux.Env(envInterval, defInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envInterval, err.Error())
+	}
+
+	timeout, err := time.ParseDuration(mainflux.Env(envTimeout, defTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
+	}
+
+	return config{
+		httpPort:      mainflux.Env(envHTTPPort, defHTTPPort),
+		brokerURL:     mainflux.Env(envBrokerURL, defBrokerURL),
+		writeSubtopic: mainflux.Env(envWriteSubtopic, defWriteSubtopic),
+		opcua: client.Config{
+			Interval: interval,
+			Timeout:  timeout,
+			Policy:   mainflux.Env(envPolicy, defPolicy),
+			Mode:     mainflux.Env(envMode, defMode),
+			CertFile: mainflux.Env(envCertFile, defCertFile),
+			KeyFile:  mainflux.Env(envKeyFile, defKeyFile),
+		},
+		logLevel:       mainflux.Env(envLogLevel, defLogLevel),
+		esURL:          mainflux.Env(envESURL, defESURL),
+		esPass:         mainflux.Env(envESPass, defESPass),
+		esDB:           mainflux.Env(envESDB, defESDB),
+		esConsumerName: mainflux.Env(envESConsumerName, defESConsumerName),
+		routeMapURL:    mainflux.Env(envRouteMapURL, defRouteMapURL),
+		routeMapPass:   mainflux.Env(envRouteMapPass, defRouteMapPass),
+		routeMapDB:     mainflux.Env(envRouteMapDB, defRouteMapDB),
+	}
+}
+
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *r.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return r.NewClient(&r.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func subscribeToWrites(svc opcua.Service, sub messaging.Subscriber, subtopic string, logger logger.Logger) {
+	topic := fmt.Sprintf("channels.*.%s", subtopic)
+	if err := sub.Subscribe(svcName, topic, opcua.NewDownlinkHandler(svc)); err != nil {
+		logger.Error(fmt.Sprintf("Failed to subscribe to %s write subtopic: %s", subtopic, err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Subscribed to %s write subtopic", subtopic))
+}
+
+func subscribeToThingsES(svc opcua.Service, client *r.Client, consumer string, logger logger.Logger) {
+	eventStore := redis.NewEventStore(svc, client, consumer, logger)
+	logger.Info("Subscribed to Redis Event Store")
+	if err := eventStore.Subscribe(context.Background(), "mainflux.things"); err != nil {
+		logger.Warn(fmt.Sprintf("OPC-UA-adapter service failed to subscribe to Redis event source: %s", err))
+	}
+}
+
+func newRouteMapRepository(client *r.Client, prefix string, logger logger.Logger) opcua.RouteMapRepository {
+	logger.Info(fmt.Sprintf("Connected to %s Redis Route-map", prefix))
+	return redis.NewRouteMapRepository(client, prefix)
+}
+
+func startHTTPServer(ctx context.Context, svc opcua.Service, cfg config, logger logger.Logger) error {
+	p := fmt.Sprintf(":%s", cfg.httpPort)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, logger)}
+
+	logger.Info(fmt.Sprintf("OPC-UA-adapter service started, exposed port %s", cfg.httpPort))
+
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("OPC-UA-adapter service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("OPC-UA-adapter service error occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("OPC-UA-adapter service shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/readers"
@@ -50,6 +51,7 @@ const (
 	defDBSSLKey          = ""
 	defDBSSLRootCert     = ""
 	defJaegerURL         = ""
+	defTrustedProxies    = ""
 	defThingsGRPCURL     = "localhost:8183"
 	defThingsGRPCTimeout = "1s"
 	defAuthGRPCURL       = "localhost:8181"
@@ -69,6 +71,7 @@ const (
 	envDBSSLKey          = "MF_POSTGRES_READER_DB_SSL_KEY"
 	envDBSSLRootCert     = "MF_POSTGRES_READER_DB_SSL_ROOT_CERT"
 	envJaegerURL         = "MF_JAEGER_URL"
+	envTrustedProxies    = "MF_TRUSTED_PROXIES"
 	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
@@ -163,6 +166,10 @@ func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 }
 
 func loadConfig() config {
+	if err := apiutil.SetTrustedProxies(mainflux.Env(envTrustedProxies, defTrustedProxies)); err != nil {
+		log.Fatalf("Invalid %s value: %s", envTrustedProxies, err.Error())
+	}
+
 	dbConfig := postgres.Config{
 		Host:        mainflux.Env(envDBHost, defDBHost),
 		Port:        mainflux.Env(envDBPort, defDBPort),
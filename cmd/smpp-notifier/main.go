@@ -30,6 +30,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
 	"github.com/MainfluxLabs/mainflux/pkg/ulid"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
@@ -71,6 +72,9 @@ const (
 	defAuthGRPCURL     = "localhost:8181"
 	defAuthGRPCTimeout = "1s"
 
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+
 	envLogLevel      = "MF_SMPP_NOTIFIER_LOG_LEVEL"
 	envDBHost        = "MF_SMPP_NOTIFIER_DB_HOST"
 	envDBPort        = "MF_SMPP_NOTIFIER_DB_PORT"
@@ -101,22 +105,27 @@ const (
 	envAuthCACerts     = "MF_AUTH_CA_CERTS"
 	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
 	envAuthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
+
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 )
 
 type config struct {
-	brokerURL       string
-	logLevel        string
-	dbConfig        postgres.Config
-	smppConf        mfsmpp.Config
-	from            string
-	httpPort        string
-	serverCert      string
-	serverKey       string
-	jaegerURL       string
-	authTLS         bool
-	authCACerts     string
-	authGRPCURL     string
-	authGRPCTimeout time.Duration
+	brokerURL         string
+	logLevel          string
+	dbConfig          postgres.Config
+	smppConf          mfsmpp.Config
+	from              string
+	httpPort          string
+	serverCert        string
+	serverKey         string
+	jaegerURL         string
+	authTLS           bool
+	authCACerts       string
+	authGRPCURL       string
+	authGRPCTimeout   time.Duration
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
 }
 
 func main() {
@@ -147,13 +156,19 @@ func main() {
 		defer close()
 	}
 
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	things, thingsClose := connectToThings(cfg, thingsTracer, logger)
+	defer thingsClose()
+
 	tracer, closer := initJaeger("smpp-notifier", cfg.jaegerURL, logger)
 	defer closer.Close()
 
 	dbTracer, dbCloser := initJaeger("smpp-notifier_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
-	svc := newService(db, dbTracer, auth, cfg, logger)
+	svc := newService(db, dbTracer, auth, things, cfg, logger)
 
 	if err = consumers.Start(svcName, pubSub, svc, logger); err != nil {
 		logger.Error(fmt.Sprintf("Failed to create Postgres writer: %s", err))
@@ -183,6 +198,11 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
 	}
 
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
 	tls, err := strconv.ParseBool(mainflux.Env(envAuthTLS, defAuthTLS))
 	if err != nil {
 		log.Fatalf("Invalid value passed for %s\n", envAuthTLS)
@@ -229,19 +249,21 @@ func loadConfig() config {
 	}
 
 	return config{
-		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
-		brokerURL:       mainflux.Env(envBrokerURL, defBrokerURL),
-		dbConfig:        dbConfig,
-		smppConf:        smppConf,
-		from:            mainflux.Env(envFrom, defFrom),
-		httpPort:        mainflux.Env(envHTTPPort, defHTTPPort),
-		serverCert:      mainflux.Env(envServerCert, defServerCert),
-		serverKey:       mainflux.Env(envServerKey, defServerKey),
-		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
-		authTLS:         tls,
-		authCACerts:     mainflux.Env(envAuthCACerts, defAuthCACerts),
-		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
-		authGRPCTimeout: authGRPCTimeout,
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
+		dbConfig:          dbConfig,
+		smppConf:          smppConf,
+		from:              mainflux.Env(envFrom, defFrom),
+		httpPort:          mainflux.Env(envHTTPPort, defHTTPPort),
+		serverCert:        mainflux.Env(envServerCert, defServerCert),
+		serverKey:         mainflux.Env(envServerKey, defServerKey),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		authTLS:           tls,
+		authCACerts:       mainflux.Env(envAuthCACerts, defAuthCACerts),
+		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout:   authGRPCTimeout,
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
 	}
 
 }
@@ -304,12 +326,36 @@ func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger)
 	return authapi.NewClient(tracer, conn, cfg.authGRPCTimeout), conn.Close
 }
 
-func newService(db *sqlx.DB, tracer opentracing.Tracer, ac mainflux.AuthServiceClient, c config, logger logger.Logger) notifiers.Service {
+func connectToThings(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.ThingsServiceClient, func() error) {
+	var opts []grpc.DialOption
+	if cfg.authTLS {
+		if cfg.authCACerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.authCACerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+
+	return thingsapi.NewClient(conn, tracer, cfg.thingsGRPCTimeout), conn.Close
+}
+
+func newService(db *sqlx.DB, tracer opentracing.Tracer, ac mainflux.AuthServiceClient, tc mainflux.ThingsServiceClient, c config, logger logger.Logger) notifiers.Service {
 	database := postgres.NewDatabase(db)
 	repo := tracing.New(postgres.New(database), tracer)
 	idp := ulid.New()
 	notifier := mfsmpp.New(c.smppConf)
-	svc := notifiers.New(ac, repo, idp, notifier, c.from)
+	svc := notifiers.New(ac, tc, repo, idp, notifier, c.from)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -17,6 +17,7 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
@@ -69,8 +70,11 @@ const (
 	defStandaloneEmail = ""
 	defStandaloneToken = ""
 	defJaegerURL       = ""
+	defTrustedProxies  = ""
 	defAuthGRPCURL     = "localhost:8181"
 	defAuthGRPCTimeout = "1s"
+	defTrashRetention  = "720h"
+	defTrashPurge      = "1h"
 
 	envLogLevel        = "MF_THINGS_LOG_LEVEL"
 	envDBHost          = "MF_THINGS_DB_HOST"
@@ -98,8 +102,11 @@ const (
 	envStandaloneEmail = "MF_THINGS_STANDALONE_EMAIL"
 	envStandaloneToken = "MF_THINGS_STANDALONE_TOKEN"
 	envJaegerURL       = "MF_JAEGER_URL"
+	envTrustedProxies  = "MF_TRUSTED_PROXIES"
 	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
 	envauthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
+	envTrashRetention  = "MF_THINGS_TRASH_RETENTION"
+	envTrashPurge      = "MF_THINGS_TRASH_PURGE_INTERVAL"
 )
 
 type config struct {
@@ -123,6 +130,8 @@ type config struct {
 	jaegerURL       string
 	authGRPCURL     string
 	authGRPCTimeout time.Duration
+	trashRetention  time.Duration
+	trashPurge      time.Duration
 }
 
 func main() {
@@ -173,6 +182,11 @@ func main() {
 		return startGRPCServer(ctx, svc, thingsTracer, cfg, logger)
 	})
 
+	g.Go(func() error {
+		purgeTrash(ctx, svc, cfg.trashRetention, cfg.trashPurge, logger)
+		return nil
+	})
+
 	g.Go(func() error {
 		if sig := errors.SignalHandler(ctx); sig != nil {
 			cancel()
@@ -187,6 +201,10 @@ func main() {
 }
 
 func loadConfig() config {
+	if err := apiutil.SetTrustedProxies(mainflux.Env(envTrustedProxies, defTrustedProxies)); err != nil {
+		log.Fatalf("Invalid %s value: %s", envTrustedProxies, err.Error())
+	}
+
 	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
 	if err != nil {
 		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
@@ -197,6 +215,16 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envauthGRPCTimeout, err.Error())
 	}
 
+	trashRetention, err := time.ParseDuration(mainflux.Env(envTrashRetention, defTrashRetention))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envTrashRetention, err.Error())
+	}
+
+	trashPurge, err := time.ParseDuration(mainflux.Env(envTrashPurge, defTrashPurge))
+	if err != nil || trashPurge <= 0 {
+		log.Fatalf("Invalid %s value: %s", envTrashPurge, mainflux.Env(envTrashPurge, defTrashPurge))
+	}
+
 	dbConfig := postgres.Config{
 		Host:        mainflux.Env(envDBHost, defDBHost),
 		Port:        mainflux.Env(envDBPort, defDBPort),
@@ -230,6 +258,26 @@ func loadConfig() config {
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
 		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
 		authGRPCTimeout: authGRPCTimeout,
+		trashRetention:  trashRetention,
+		trashPurge:      trashPurge,
+	}
+}
+
+// purgeTrash periodically removes things, channels and groups that have
+// been in the trash for longer than the retention period.
+func purgeTrash(ctx context.Context, svc things.Service, retention, interval time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := svc.PurgeTrash(ctx, time.Now().Add(-retention)); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to purge trash: %s", err))
+			}
+		}
 	}
 }
 
@@ -320,12 +368,18 @@ func newService(ac mainflux.AuthServiceClient, dbTracer opentracing.Tracer, cach
 	thingsRepo := postgres.NewThingRepository(database)
 	thingsRepo = tracing.ThingRepositoryMiddleware(dbTracer, thingsRepo)
 
+	thingKeysRepo := postgres.NewThingKeyRepository(database)
+	thingKeysRepo = tracing.ThingKeyRepositoryMiddleware(dbTracer, thingKeysRepo)
+
 	channelsRepo := postgres.NewChannelRepository(database)
 	channelsRepo = tracing.ChannelRepositoryMiddleware(dbTracer, channelsRepo)
 
 	groupsRepo := postgres.NewGroupRepo(database)
 	groupsRepo = tracing.GroupRepositoryMiddleware(dbTracer, groupsRepo)
 
+	profilesRepo := postgres.NewProfileRepository(database)
+	profilesRepo = tracing.ProfileRepositoryMiddleware(dbTracer, profilesRepo)
+
 	chanCache := rediscache.NewChannelCache(cacheClient)
 	chanCache = tracing.ChannelCacheMiddleware(cacheTracer, chanCache)
 
@@ -333,7 +387,7 @@ func newService(ac mainflux.AuthServiceClient, dbTracer opentracing.Tracer, cach
 	thingCache = tracing.ThingCacheMiddleware(cacheTracer, thingCache)
 	idProvider := uuid.New()
 
-	svc := things.New(ac, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	svc := things.New(ac, thingsRepo, thingKeysRepo, channelsRepo, groupsRepo, profilesRepo, chanCache, thingCache, idProvider)
 	svc = rediscache.NewEventStoreMiddleware(svc, esClient)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
@@ -29,11 +29,14 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	grpcapi "github.com/MainfluxLabs/mainflux/users/api/grpc"
 	httpapi "github.com/MainfluxLabs/mainflux/users/api/http"
 	"github.com/MainfluxLabs/mainflux/users/postgres"
+	redisprod "github.com/MainfluxLabs/mainflux/users/redis"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -43,20 +46,24 @@ import (
 const (
 	stopWaitTime = 5 * time.Second
 
-	defLogLevel      = "error"
-	defDBHost        = "localhost"
-	defDBPort        = "5432"
-	defDBUser        = "mainflux"
-	defDBPass        = "mainflux"
-	defDB            = "users"
-	defDBSSLMode     = "disable"
-	defDBSSLCert     = ""
-	defDBSSLKey      = ""
-	defDBSSLRootCert = ""
-	defHTTPPort      = "8180"
-	defServerCert    = ""
-	defServerKey     = ""
-	defJaegerURL     = ""
+	defLogLevel       = "error"
+	defDBHost         = "localhost"
+	defDBPort         = "5432"
+	defDBUser         = "mainflux"
+	defDBPass         = "mainflux"
+	defDB             = "users"
+	defDBSSLMode      = "disable"
+	defDBSSLCert      = ""
+	defDBSSLKey       = ""
+	defDBSSLRootCert  = ""
+	defHTTPPort       = "8180"
+	defServerCert     = ""
+	defServerKey      = ""
+	defJaegerURL      = ""
+	defTrustedProxies = ""
+	defESURL          = "localhost:6379"
+	defESPass         = ""
+	defESDB           = "0"
 
 	defEmailHost        = "localhost"
 	defEmailPort        = "25"
@@ -68,6 +75,15 @@ const (
 	defAdminEmail       = ""
 	defAdminPassword    = ""
 	defPassRegex        = "^.{8,}$"
+	defPassMinLength    = "0"
+	defPassUpper        = "false"
+	defPassLower        = "false"
+	defPassDigit        = "false"
+	defPassSpecial      = "false"
+	defPassHistory      = "0"
+	defPassBreachedFile = ""
+	defPassBreachedURL  = ""
+	defPassBreachedTO   = "5s"
 
 	defTokenResetEndpoint = "/reset-request" // URL where user lands after click on the reset link from email
 
@@ -79,24 +95,45 @@ const (
 
 	defSelfRegister = "true" // By default, everybody can create a user. Otherwise, only admin can create a user.
 
-	envLogLevel      = "MF_USERS_LOG_LEVEL"
-	envDBHost        = "MF_USERS_DB_HOST"
-	envDBPort        = "MF_USERS_DB_PORT"
-	envDBUser        = "MF_USERS_DB_USER"
-	envDBPass        = "MF_USERS_DB_PASS"
-	envDB            = "MF_USERS_DB"
-	envDBSSLMode     = "MF_USERS_DB_SSL_MODE"
-	envDBSSLCert     = "MF_USERS_DB_SSL_CERT"
-	envDBSSLKey      = "MF_USERS_DB_SSL_KEY"
-	envDBSSLRootCert = "MF_USERS_DB_SSL_ROOT_CERT"
-	envHTTPPort      = "MF_USERS_HTTP_PORT"
-	envServerCert    = "MF_USERS_SERVER_CERT"
-	envServerKey     = "MF_USERS_SERVER_KEY"
-	envJaegerURL     = "MF_JAEGER_URL"
-
-	envAdminEmail    = "MF_USERS_ADMIN_EMAIL"
-	envAdminPassword = "MF_USERS_ADMIN_PASSWORD"
-	envPassRegex     = "MF_USERS_PASS_REGEX"
+	defLoginMaxAttempts    = "5"
+	defLoginMaxIPAttempts  = "20"
+	defLoginAttemptsWindow = "15m"
+	defLockoutDuration     = "1m"
+	defLockoutMaxDuration  = "1h"
+	defResetLimit          = "3"
+	defResetWindow         = "1h"
+
+	envLogLevel       = "MF_USERS_LOG_LEVEL"
+	envDBHost         = "MF_USERS_DB_HOST"
+	envDBPort         = "MF_USERS_DB_PORT"
+	envDBUser         = "MF_USERS_DB_USER"
+	envDBPass         = "MF_USERS_DB_PASS"
+	envDB             = "MF_USERS_DB"
+	envDBSSLMode      = "MF_USERS_DB_SSL_MODE"
+	envDBSSLCert      = "MF_USERS_DB_SSL_CERT"
+	envDBSSLKey       = "MF_USERS_DB_SSL_KEY"
+	envDBSSLRootCert  = "MF_USERS_DB_SSL_ROOT_CERT"
+	envHTTPPort       = "MF_USERS_HTTP_PORT"
+	envServerCert     = "MF_USERS_SERVER_CERT"
+	envServerKey      = "MF_USERS_SERVER_KEY"
+	envJaegerURL      = "MF_JAEGER_URL"
+	envTrustedProxies = "MF_TRUSTED_PROXIES"
+	envESURL          = "MF_USERS_ES_URL"
+	envESPass         = "MF_USERS_ES_PASS"
+	envESDB           = "MF_USERS_ES_DB"
+
+	envAdminEmail       = "MF_USERS_ADMIN_EMAIL"
+	envAdminPassword    = "MF_USERS_ADMIN_PASSWORD"
+	envPassRegex        = "MF_USERS_PASS_REGEX"
+	envPassMinLength    = "MF_USERS_PASS_MIN_LENGTH"
+	envPassUpper        = "MF_USERS_PASS_REQUIRE_UPPER"
+	envPassLower        = "MF_USERS_PASS_REQUIRE_LOWER"
+	envPassDigit        = "MF_USERS_PASS_REQUIRE_DIGIT"
+	envPassSpecial      = "MF_USERS_PASS_REQUIRE_SPECIAL"
+	envPassHistory      = "MF_USERS_PASS_HISTORY"
+	envPassBreachedFile = "MF_USERS_PASS_BREACHED_FILE"
+	envPassBreachedURL  = "MF_USERS_PASS_BREACHED_URL"
+	envPassBreachedTO   = "MF_USERS_PASS_BREACHED_TIMEOUT"
 
 	envEmailHost        = "MF_EMAIL_HOST"
 	envEmailPort        = "MF_EMAIL_PORT"
@@ -115,6 +152,14 @@ const (
 	envGRPCPort        = "MF_USERS_GRPC_PORT"
 
 	envSelfRegister = "MF_USERS_ALLOW_SELF_REGISTER"
+
+	envLoginMaxAttempts    = "MF_USERS_LOGIN_MAX_ATTEMPTS"
+	envLoginMaxIPAttempts  = "MF_USERS_LOGIN_MAX_IP_ATTEMPTS"
+	envLoginAttemptsWindow = "MF_USERS_LOGIN_ATTEMPTS_WINDOW"
+	envLockoutDuration     = "MF_USERS_LOCKOUT_DURATION"
+	envLockoutMaxDuration  = "MF_USERS_LOCKOUT_MAX_DURATION"
+	envResetLimit          = "MF_USERS_RESET_LIMIT"
+	envResetWindow         = "MF_USERS_RESET_WINDOW"
 )
 
 type config struct {
@@ -126,6 +171,9 @@ type config struct {
 	serverCert      string
 	serverKey       string
 	jaegerURL       string
+	esURL           string
+	esPass          string
+	esDB            string
 	resetURL        string
 	authTLS         bool
 	authCACerts     string
@@ -133,8 +181,9 @@ type config struct {
 	authGRPCTimeout time.Duration
 	adminEmail      string
 	adminPassword   string
-	passRegex       *regexp.Regexp
+	passPolicy      users.PasswordPolicy
 	selfRegister    bool
+	lockoutConfig   users.LockoutConfig
 }
 
 func main() {
@@ -166,7 +215,10 @@ func main() {
 	dbTracer, dbCloser := initJaeger("users_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
-	svc := newService(db, dbTracer, auth, cfg, logger)
+	esClient := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esClient.Close()
+
+	svc := newService(db, dbTracer, auth, esClient, cfg, logger)
 
 	g.Go(func() error {
 		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger)
@@ -190,6 +242,10 @@ func main() {
 }
 
 func loadConfig() config {
+	if err := apiutil.SetTrustedProxies(mainflux.Env(envTrustedProxies, defTrustedProxies)); err != nil {
+		log.Fatalf("Invalid %s value: %s", envTrustedProxies, err.Error())
+	}
+
 	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envauthGRPCTimeout, defAuthGRPCTimeout))
 	if err != nil {
 		log.Fatalf("Invalid %s value: %s", envauthGRPCTimeout, err.Error())
@@ -241,6 +297,9 @@ func loadConfig() config {
 		serverCert:      mainflux.Env(envServerCert, defServerCert),
 		serverKey:       mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
+		esURL:           mainflux.Env(envESURL, defESURL),
+		esPass:          mainflux.Env(envESPass, defESPass),
+		esDB:            mainflux.Env(envESDB, defESDB),
 		resetURL:        mainflux.Env(envTokenResetEndpoint, defTokenResetEndpoint),
 		authTLS:         tls,
 		authCACerts:     mainflux.Env(envAuthCACerts, defAuthCACerts),
@@ -248,12 +307,84 @@ func loadConfig() config {
 		authGRPCTimeout: authGRPCTimeout,
 		adminEmail:      mainflux.Env(envAdminEmail, defAdminEmail),
 		adminPassword:   mainflux.Env(envAdminPassword, defAdminPassword),
-		passRegex:       passRegex,
+		passPolicy:      loadPasswordPolicy(passRegex),
 		selfRegister:    selfRegister,
+		lockoutConfig:   loadLockoutConfig(),
 	}
 
 }
 
+func loadPasswordPolicy(passRegex *regexp.Regexp) users.PasswordPolicy {
+	minLength := parseUint(envPassMinLength, defPassMinLength)
+
+	pp := users.PasswordPolicy{
+		Regex:          passRegex,
+		MinLength:      int(minLength),
+		RequireUpper:   parseBool(envPassUpper, defPassUpper),
+		RequireLower:   parseBool(envPassLower, defPassLower),
+		RequireDigit:   parseBool(envPassDigit, defPassDigit),
+		RequireSpecial: parseBool(envPassSpecial, defPassSpecial),
+		History:        parseUint(envPassHistory, defPassHistory),
+	}
+
+	if path := mainflux.Env(envPassBreachedFile, defPassBreachedFile); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Fatalf("Failed to open breached passwords file: %s", err.Error())
+		}
+		defer f.Close()
+
+		if pp.Breached, err = users.ReadBreachedPasswords(f); err != nil {
+			log.Fatalf("Failed to read breached passwords file: %s", err.Error())
+		}
+	}
+
+	if url := mainflux.Env(envPassBreachedURL, defPassBreachedURL); url != "" {
+		pp.Breached = users.NewBreachedPasswordsRange(url, parseDuration(envPassBreachedTO, defPassBreachedTO))
+	}
+
+	return pp
+}
+
+func loadLockoutConfig() users.LockoutConfig {
+	return users.LockoutConfig{
+		MaxAttempts:   parseUint(envLoginMaxAttempts, defLoginMaxAttempts),
+		MaxIPAttempts: parseUint(envLoginMaxIPAttempts, defLoginMaxIPAttempts),
+		Window:        parseDuration(envLoginAttemptsWindow, defLoginAttemptsWindow),
+		Duration:      parseDuration(envLockoutDuration, defLockoutDuration),
+		MaxDuration:   parseDuration(envLockoutMaxDuration, defLockoutMaxDuration),
+		ResetLimit:    parseUint(envResetLimit, defResetLimit),
+		ResetWindow:   parseDuration(envResetWindow, defResetWindow),
+	}
+}
+
+func parseUint(key, fallback string) uint64 {
+	v, err := strconv.ParseUint(mainflux.Env(key, fallback), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", key, err.Error())
+	}
+
+	return v
+}
+
+func parseBool(key, fallback string) bool {
+	b, err := strconv.ParseBool(mainflux.Env(key, fallback))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", key, err.Error())
+	}
+
+	return b
+}
+
+func parseDuration(key, fallback string) time.Duration {
+	d, err := time.ParseDuration(mainflux.Env(key, fallback))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", key, err.Error())
+	}
+
+	return d
+}
+
 func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
 	if url == "" {
 		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
@@ -286,6 +417,20 @@ func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
 func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.AuthServiceClient, func() error) {
 	var opts []grpc.DialOption
 	if cfg.authTLS {
@@ -311,10 +456,11 @@ func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger)
 	return authapi.NewClient(tracer, conn, cfg.authGRPCTimeout), conn.Close
 }
 
-func newService(db *sqlx.DB, tracer opentracing.Tracer, ac mainflux.AuthServiceClient, c config, logger logger.Logger) users.Service {
+func newService(db *sqlx.DB, tracer opentracing.Tracer, ac mainflux.AuthServiceClient, esClient *redis.Client, c config, logger logger.Logger) users.Service {
 	database := postgres.NewDatabase(db)
 	hasher := bcrypt.New()
 	userRepo := tracing.UserRepositoryMiddleware(postgres.NewUserRepo(database), tracer)
+	lockoutRepo := tracing.LockoutRepositoryMiddleware(postgres.NewLockoutRepo(database), tracer)
 
 	emailer, err := emailer.New(c.resetURL, &c.emailConf)
 	if err != nil {
@@ -323,7 +469,8 @@ func newService(db *sqlx.DB, tracer opentracing.Tracer, ac mainflux.AuthServiceC
 
 	idProvider := uuid.New()
 
-	svc := users.New(userRepo, hasher, ac, emailer, idProvider, c.passRegex)
+	svc := users.New(userRepo, hasher, ac, emailer, idProvider, c.passPolicy, lockoutRepo, c.lockoutConfig)
+	svc = redisprod.NewEventStoreMiddleware(svc, esClient)
 	svc = httpapi.LoggingMiddleware(svc, logger)
 	svc = httpapi.MetricsMiddleware(
 		svc,
@@ -22,9 +22,12 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	adapter "github.com/MainfluxLabs/mainflux/ws"
 	"github.com/MainfluxLabs/mainflux/ws/api"
+	"github.com/MainfluxLabs/mainflux/ws/history"
 	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
 	jconfig "github.com/uber/jaeger-client-go/config"
@@ -44,6 +47,13 @@ const (
 	defJaegerURL         = ""
 	defThingsGRPCURL     = "localhost:8183"
 	defThingsGRPCTimeout = "1s"
+	defRateLimit         = "0"
+	defRateBurst         = "0"
+	defMaxPayloadSize    = "0"
+	defReaderURL         = ""
+	defReaderTimeout     = "5s"
+	defHistoryLimit      = "1000"
+	defHeartbeat         = "30s"
 
 	envPort              = "MF_WS_ADAPTER_PORT"
 	envBrokerURL         = "MF_BROKER_URL"
@@ -53,6 +63,13 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envRateLimit         = "MF_WS_ADAPTER_RATE_LIMIT"
+	envRateBurst         = "MF_WS_ADAPTER_RATE_BURST"
+	envMaxPayloadSize    = "MF_WS_ADAPTER_MAX_PAYLOAD_SIZE"
+	envReaderURL         = "MF_WS_ADAPTER_READER_URL"
+	envReaderTimeout     = "MF_WS_ADAPTER_READER_TIMEOUT"
+	envHistoryLimit      = "MF_WS_ADAPTER_HISTORY_LIMIT"
+	envHeartbeat         = "MF_WS_ADAPTER_HEARTBEAT_INTERVAL"
 )
 
 type config struct {
@@ -64,6 +81,11 @@ type config struct {
 	jaegerURL         string
 	thingsGRPCURL     string
 	thingsGRPCTimeout time.Duration
+	limits            ratelimit.Config
+	readerURL         string
+	readerTimeout     time.Duration
+	historyLimit      uint64
+	heartbeat         time.Duration
 }
 
 func main() {
@@ -92,7 +114,7 @@ func main() {
 	}
 	defer nps.Close()
 
-	svc := newService(tc, nps, logger)
+	svc := newService(tc, nps, ratelimit.New(cfg.limits), newReader(cfg), logger)
 
 	g.Go(func() error {
 		return startWSServer(ctx, cfg, svc, logger)
@@ -123,6 +145,36 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
 	}
 
+	rateLimit, err := strconv.ParseFloat(mainflux.Env(envRateLimit, defRateLimit), 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRateLimit, err.Error())
+	}
+
+	rateBurst, err := strconv.Atoi(mainflux.Env(envRateBurst, defRateBurst))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRateBurst, err.Error())
+	}
+
+	maxPayloadSize, err := strconv.Atoi(mainflux.Env(envMaxPayloadSize, defMaxPayloadSize))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envMaxPayloadSize, err.Error())
+	}
+
+	readerTimeout, err := time.ParseDuration(mainflux.Env(envReaderTimeout, defReaderTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envReaderTimeout, err.Error())
+	}
+
+	historyLimit, err := strconv.ParseUint(mainflux.Env(envHistoryLimit, defHistoryLimit), 10, 64)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envHistoryLimit, err.Error())
+	}
+
+	heartbeat, err := time.ParseDuration(mainflux.Env(envHeartbeat, defHeartbeat))
+	if err != nil || heartbeat <= 0 {
+		log.Fatalf("Invalid %s value: %s", envHeartbeat, mainflux.Env(envHeartbeat, defHeartbeat))
+	}
+
 	return config{
 		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
 		port:              mainflux.Env(envPort, defPort),
@@ -132,9 +184,26 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
 		thingsGRPCTimeout: thingsGRPCTimeout,
+		limits: ratelimit.Config{
+			Rate:           rateLimit,
+			Burst:          rateBurst,
+			MaxPayloadSize: maxPayloadSize,
+		},
+		readerURL:     mainflux.Env(envReaderURL, defReaderURL),
+		readerTimeout: readerTimeout,
+		historyLimit:  historyLimit,
+		heartbeat:     heartbeat,
 	}
 }
 
+func newReader(cfg config) adapter.MessageReader {
+	if cfg.readerURL == "" {
+		return nil
+	}
+
+	return history.NewReader(cfg.readerURL, cfg.historyLimit, &http.Client{Timeout: cfg.readerTimeout})
+}
+
 func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	var opts []grpc.DialOption
 	if cfg.clientTLS {
@@ -184,8 +253,8 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 	return tracer, closer
 }
 
-func newService(tc mainflux.ThingsServiceClient, nps messaging.PubSub, logger logger.Logger) adapter.Service {
-	svc := adapter.New(tc, nps)
+func newService(tc mainflux.ThingsServiceClient, nps messaging.PubSub, limiter ratelimit.Limiter, reader adapter.MessageReader, logger logger.Logger) adapter.Service {
+	svc := adapter.New(tc, nps, limiter, schema.NewChannelValidator(tc), reader)
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -201,6 +270,12 @@ func newService(tc mainflux.ThingsServiceClient, nps messaging.PubSub, logger lo
 			Name:      "request_latency_microsecond",
 			Help:      "Total duration of requests in microseconds",
 		}, []string{"method"}),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "ws_adapter",
+			Subsystem: "api",
+			Name:      "payload_schema_violations",
+			Help:      "Number of messages rejected for not conforming to the channel schema",
+		}, []string{"channel"}),
 	)
 
 	return svc
@@ -209,7 +284,7 @@ func newService(tc mainflux.ThingsServiceClient, nps messaging.PubSub, logger lo
 func startWSServer(ctx context.Context, cfg config, svc adapter.Service, l logger.Logger) error {
 	p := fmt.Sprintf(":%s", cfg.port)
 	errCh := make(chan error, 2)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, l)}
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svc, cfg.heartbeat, l)}
 	l.Info(fmt.Sprintf("WS adapter service started, exposed port %s", cfg.port))
 
 	go func() {
@@ -15,6 +15,9 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/things"
 )
 
 const chansPrefix = "channels"
@@ -33,46 +36,71 @@ type Service interface {
 
 	// Unsubscribe method is used to stop observing resource.
 	Unsubscribe(ctx context.Context, key, chanID, subptopic, token string) error
+
+	// GetOSCORESecret returns the OSCORE master secret of the thing with the
+	// given ID. The secret is derived from the thing key by the things service,
+	// so the key itself is never disclosed to the adapter.
+	GetOSCORESecret(ctx context.Context, thingID string) ([]byte, error)
 }
 
 var _ Service = (*adapterService)(nil)
 
 // Observers is a map of maps,
 type adapterService struct {
-	things  mainflux.ThingsServiceClient
-	pubsub  messaging.PubSub
-	obsLock sync.Mutex
+	things    mainflux.ThingsServiceClient
+	pubsub    messaging.PubSub
+	limiter   ratelimit.Limiter
+	validator schema.ChannelValidator
+	obsLock   sync.Mutex
 }
 
 // New instantiates the CoAP adapter implementation.
-func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub) Service {
+func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub, limiter ratelimit.Limiter, validator schema.ChannelValidator) Service {
 	as := &adapterService{
-		things:  things,
-		pubsub:  pubsub,
-		obsLock: sync.Mutex{},
+		things:    things,
+		pubsub:    pubsub,
+		limiter:   limiter,
+		validator: validator,
+		obsLock:   sync.Mutex{},
 	}
 
 	return as
 }
 
 func (svc *adapterService) Publish(ctx context.Context, key string, msg messaging.Message) error {
-	cr := &mainflux.ConnByKeyReq{
-		Key: key,
+	if err := svc.limiter.CheckPayload(len(msg.Payload)); err != nil {
+		return err
+	}
+
+	ar := &mainflux.AccessByKeyReq{
+		Token:  key,
+		ChanID: msg.Channel,
+		Action: things.PublishAction,
 	}
-	conn, err := svc.things.GetConnByKey(ctx, cr)
+	thid, err := svc.things.CanAccessByKey(ctx, ar)
 	if err != nil {
 		return errors.Wrap(errors.ErrAuthorization, err)
 	}
-	msg.Publisher = conn.ThingID
+	msg.Publisher = thid.GetValue()
+
+	if err := svc.limiter.Allow(msg.Publisher); err != nil {
+		return err
+	}
+
+	if err := svc.validator.Validate(ctx, msg.Channel, msg.Payload); err != nil {
+		return err
+	}
 
 	return svc.pubsub.Publish(msg.Channel, msg)
 }
 
 func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic string, c Client) error {
-	cr := &mainflux.ConnByKeyReq{
-		Key: key,
+	ar := &mainflux.AccessByKeyReq{
+		Token:  key,
+		ChanID: chanID,
+		Action: things.SubscribeAction,
 	}
-	if _, err := svc.things.GetConnByKey(ctx, cr); err != nil {
+	if _, err := svc.things.CanAccessByKey(ctx, ar); err != nil {
 		return errors.Wrap(errors.ErrAuthorization, err)
 	}
 	subject := fmt.Sprintf("%s.%s", chansPrefix, chanID)
@@ -83,16 +111,26 @@ func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic
 }
 
 func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopic, token string) error {
-	cr := &mainflux.ConnByKeyReq{
-		Key: key,
+	ar := &mainflux.AccessByKeyReq{
+		Token:  key,
+		ChanID: chanID,
+		Action: things.SubscribeAction,
 	}
-	conn, err := svc.things.GetConnByKey(ctx, cr)
-	if err != nil {
+	if _, err := svc.things.CanAccessByKey(ctx, ar); err != nil {
 		return errors.Wrap(errors.ErrAuthorization, err)
 	}
-	subject := fmt.Sprintf("%s.%s", chansPrefix, conn.ChannelID)
+	subject := fmt.Sprintf("%s.%s", chansPrefix, chanID)
 	if subtopic != "" {
 		subject = fmt.Sprintf("%s.%s", subject, subtopic)
 	}
 	return svc.pubsub.Unsubscribe(token, subject)
 }
+
+func (svc *adapterService) GetOSCORESecret(ctx context.Context, thingID string) ([]byte, error) {
+	secret, err := svc.things.GetOSCORESecret(ctx, &mainflux.ThingID{Value: thingID})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	return secret.GetValue(), nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	piondtls "github.com/pion/dtls/v2"
+	"github.com/plgd-dev/go-coap/v2/udp/client"
+)
+
+var errRevokedCert = errors.New("client certificate has been revoked")
+
+// thingKey is the key of the client connection context value holding the
+// key of the thing authenticated by the DTLS handshake.
+type thingKey struct{}
+
+// MakeCertVerifier returns the DTLS peer certificate callback, which rejects
+// client certificates revoked by the certs service. It runs after the chain
+// has been verified against the client CAs.
+func MakeCertVerifier(sdk mfsdk.SDK) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.ErrAuthentication
+		}
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return errors.Wrap(errors.ErrAuthentication, err)
+		}
+
+		revoked, err := sdk.IsCertRevoked(serial(cert))
+		if err != nil {
+			return errors.Wrap(errors.ErrAuthentication, err)
+		}
+		if revoked {
+			return errRevokedCert
+		}
+
+		return nil
+	}
+}
+
+// MakeDTLSConnHandler returns the callback which binds new DTLS connections
+// to the thing authenticated by the client certificate, whose common name is
+// the thing key. Requests sent over such connections don't need to carry the
+// thing key.
+func MakeDTLSConnHandler(l log.Logger) func(*client.ClientConn, *piondtls.Conn) {
+	return func(cc *client.ClientConn, conn *piondtls.Conn) {
+		state := conn.ConnectionState()
+		if len(state.PeerCertificates) == 0 {
+			return
+		}
+		cert, err := x509.ParseCertificate(state.PeerCertificates[0])
+		if err != nil {
+			l.Warn(fmt.Sprintf("Failed to parse client certificate: %s", err))
+			return
+		}
+		cc.SetContextValue(thingKey{}, cert.Subject.CommonName)
+	}
+}
+
+// serial formats the certificate serial number the way the PKI reports it,
+// as colon separated pairs of lowercase hex digits.
+func serial(cert *x509.Certificate) string {
+	b := cert.SerialNumber.Bytes()
+	parts := make([]string, len(b))
+	for i, v := range b {
+		parts[i] = fmt.Sprintf("%02x", v)
+	}
+
+	return strings.Join(parts, ":")
+}
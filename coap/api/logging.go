@@ -78,3 +78,16 @@ func (lm *loggingMiddleware) Unsubscribe(ctx context.Context, key, chanID, subto
 	return lm.svc.Unsubscribe(ctx, key, chanID, subtopic, token)
 
 }
+
+func (lm *loggingMiddleware) GetOSCORESecret(ctx context.Context, thingID string) (secret []byte, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method get_oscore_secret for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GetOSCORESecret(ctx, thingID)
+}
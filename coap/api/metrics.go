@@ -11,23 +11,28 @@ import (
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/MainfluxLabs/mainflux/coap"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 )
 
 var _ coap.Service = (*metricsMiddleware)(nil)
 
 type metricsMiddleware struct {
-	counter metrics.Counter
-	latency metrics.Histogram
-	svc     coap.Service
+	counter    metrics.Counter
+	latency    metrics.Histogram
+	violations metrics.Counter
+	svc        coap.Service
 }
 
-// MetricsMiddleware instruments adapter by tracking request count and latency.
-func MetricsMiddleware(svc coap.Service, counter metrics.Counter, latency metrics.Histogram) coap.Service {
+// MetricsMiddleware instruments adapter by tracking request count and latency,
+// and the number of payload schema violations per channel.
+func MetricsMiddleware(svc coap.Service, counter metrics.Counter, latency metrics.Histogram, violations metrics.Counter) coap.Service {
 	return &metricsMiddleware{
-		counter: counter,
-		latency: latency,
-		svc:     svc,
+		counter:    counter,
+		latency:    latency,
+		violations: violations,
+		svc:        svc,
 	}
 }
 
@@ -37,7 +42,12 @@ func (mm *metricsMiddleware) Publish(ctx context.Context, key string, msg messag
 		mm.latency.With("method", "publish").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.Publish(ctx, key, msg)
+	err := mm.svc.Publish(ctx, key, msg)
+	if errors.Contains(err, schema.ErrInvalidPayload) {
+		mm.violations.With("channel", msg.Channel).Add(1)
+	}
+
+	return err
 }
 
 func (mm *metricsMiddleware) Subscribe(ctx context.Context, key, chanID, subtopic string, c coap.Client) error {
@@ -57,3 +67,12 @@ func (mm *metricsMiddleware) Unsubscribe(ctx context.Context, key, chanID, subto
 
 	return mm.svc.Unsubscribe(ctx, key, chanID, subtopic, token)
 }
+
+func (mm *metricsMiddleware) GetOSCORESecret(ctx context.Context, thingID string) ([]byte, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "get_oscore_secret").Add(1)
+		mm.latency.With("method", "get_oscore_secret").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.GetOSCORESecret(ctx, thingID)
+}
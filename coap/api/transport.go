@@ -18,8 +18,11 @@ import (
 	"github.com/go-zoo/bone"
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/coap"
+	"github.com/MainfluxLabs/mainflux/coap/oscore"
 	log "github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/plgd-dev/go-coap/v2/message"
 	"github.com/plgd-dev/go-coap/v2/message/codes"
 	"github.com/plgd-dev/go-coap/v2/mux"
@@ -30,6 +33,8 @@ const (
 	protocol     = "coap"
 	authQuery    = "auth"
 	startObserve = 0 // observe option value that indicates start of observation
+	// tooManyRequests is the 4.29 response code defined by RFC 8516.
+	tooManyRequests codes.Code = 157
 )
 
 var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
@@ -42,6 +47,7 @@ const (
 var (
 	errMalformedSubtopic = errors.New("malformed subtopic")
 	errBadOptions        = errors.New("bad options")
+	errMalformedMessage  = errors.New("malformed message")
 )
 
 var (
@@ -66,55 +72,76 @@ func MakeCoAPHandler(svc coap.Service, l log.Logger) mux.HandlerFunc {
 	return handler
 }
 
-func sendResp(w mux.ResponseWriter, resp *message.Message) {
-	if err := w.Client().WriteMessage(resp); err != nil {
+func sendResp(c mux.Client, resp *message.Message) {
+	if err := c.WriteMessage(resp); err != nil {
 		logger.Warn(fmt.Sprintf("Can't set response: %s", err))
 	}
 }
 
-func handler(w mux.ResponseWriter, m *mux.Message) {
-	resp := message.Message{
-		Code:    codes.Content,
+func response(m *mux.Message, code codes.Code) *message.Message {
+	return &message.Message{
+		Code:    code,
 		Token:   m.Token,
 		Context: m.Context,
 		Options: make(message.Options, 0, 16),
 	}
+}
+
+func handler(w mux.ResponseWriter, m *mux.Message) {
+	if m.Options.HasOption(oscore.OptionID) {
+		handleOSCORE(w, m)
+		return
+	}
 
+	if err := serve(w.Client(), m, ""); err != nil {
+		sendResp(w.Client(), response(m, errorCode(err)))
+	}
+}
+
+// serve handles the request on behalf of the thing with the given key. If the
+// key is empty, the thing is authenticated by the DTLS session or by the key
+// sent in the request.
+func serve(c mux.Client, m *mux.Message, key string) error {
 	msg, err := decodeMessage(m)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Error decoding message: %s", err))
-		resp.Code = codes.BadRequest
-		sendResp(w, &resp)
-		return
+		return errors.Wrap(errMalformedMessage, err)
 	}
-	key, err := parseKey(m)
-	if err != nil {
-		logger.Warn(fmt.Sprintf("Error parsing auth: %s", err))
-		resp.Code = codes.Unauthorized
-		sendResp(w, &resp)
-		return
+	if key == "" {
+		if key, err = parseKey(c, m); err != nil {
+			logger.Warn(fmt.Sprintf("Error parsing auth: %s", err))
+			return errors.Wrap(errors.ErrAuthentication, err)
+		}
 	}
 	switch m.Code {
 	case codes.GET:
-		err = handleGet(m, w.Client(), msg, key)
+		return handleGet(m, c, msg, key)
 	case codes.POST:
-		err = service.Publish(context.Background(), key, msg)
+		return service.Publish(context.Background(), key, msg)
 	default:
-		err = errors.ErrNotFound
+		return errors.ErrNotFound
 	}
-	if err != nil {
-		switch {
-		case err == errBadOptions:
-			resp.Code = codes.BadOption
-		case err == errors.ErrNotFound:
-			resp.Code = codes.NotFound
-		case errors.Contains(err, errors.ErrAuthorization),
-			errors.Contains(err, errors.ErrAuthentication):
-			resp.Code = codes.Unauthorized
-		default:
-			resp.Code = codes.InternalServerError
-		}
-		sendResp(w, &resp)
+}
+
+func errorCode(err error) codes.Code {
+	switch {
+	case err == errBadOptions:
+		return codes.BadOption
+	case err == errors.ErrNotFound:
+		return codes.NotFound
+	case errors.Contains(err, errMalformedMessage):
+		return codes.BadRequest
+	case errors.Contains(err, errors.ErrAuthorization),
+		errors.Contains(err, errors.ErrAuthentication):
+		return codes.Unauthorized
+	case errors.Contains(err, schema.ErrInvalidPayload):
+		return codes.BadRequest
+	case errors.Contains(err, ratelimit.ErrPayloadTooLarge):
+		return codes.RequestEntityTooLarge
+	case errors.Contains(err, ratelimit.ErrRateLimited):
+		return tooManyRequests
+	default:
+		return codes.InternalServerError
 	}
 }
 
@@ -167,10 +194,13 @@ func decodeMessage(msg *mux.Message) (messaging.Message, error) {
 	return ret, nil
 }
 
-func parseKey(msg *mux.Message) (string, error) {
+func parseKey(c mux.Client, msg *mux.Message) (string, error) {
 	if obs, _ := msg.Options.Observe(); obs != 0 && msg.Code == codes.GET {
 		return "", nil
 	}
+	if key, ok := c.Context().Value(thingKey{}).(string); ok {
+		return key, nil
+	}
 	authKey, err := msg.Options.GetString(message.URIQuery)
 	if err != nil {
 		return "", err
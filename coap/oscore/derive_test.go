@@ -0,0 +1,97 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oscore
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// TestDerive checks the key derivation against the test vectors from
+// RFC 8613, Appendix C.1 - C.3.
+func TestDerive(t *testing.T) {
+	secret := decode("0102030405060708090a0b0c0d0e0f10")
+	salt := decode("9e7ca92223786340")
+
+	cases := map[string]struct {
+		cfg            Config
+		senderKey      []byte
+		recipientKey   []byte
+		commonIV       []byte
+		senderNonce    []byte
+		recipientNonce []byte
+	}{
+		"derive client context with master salt (C.1.1)": {
+			cfg:            Config{MasterSecret: secret, MasterSalt: salt, SenderID: []byte{}, RecipientID: []byte{0x01}},
+			senderKey:      decode("f0910ed7295e6ad4b54fc793154302ff"),
+			recipientKey:   decode("ffb14e093c94c9cac9471648b4f98710"),
+			commonIV:       decode("4622d4dd6d944168eefb54987c"),
+			senderNonce:    decode("4622d4dd6d944168eefb54987c"),
+			recipientNonce: decode("4722d4dd6d944169eefb54987c"),
+		},
+		"derive server context with master salt (C.1.2)": {
+			cfg:            Config{MasterSecret: secret, MasterSalt: salt, SenderID: []byte{0x01}, RecipientID: []byte{}},
+			senderKey:      decode("ffb14e093c94c9cac9471648b4f98710"),
+			recipientKey:   decode("f0910ed7295e6ad4b54fc793154302ff"),
+			commonIV:       decode("4622d4dd6d944168eefb54987c"),
+			senderNonce:    decode("4722d4dd6d944169eefb54987c"),
+			recipientNonce: decode("4622d4dd6d944168eefb54987c"),
+		},
+		"derive client context without master salt (C.2.1)": {
+			cfg:            Config{MasterSecret: secret, SenderID: []byte{0x00}, RecipientID: []byte{0x01}},
+			senderKey:      decode("321b26943253c7ffb6003b0b64d74041"),
+			recipientKey:   decode("e57b5635815177cd679ab4bcec9d7dda"),
+			commonIV:       decode("be35ae297d2dace910c52e99f9"),
+			senderNonce:    decode("bf35ae297d2dace910c52e99f9"),
+			recipientNonce: decode("bf35ae297d2dace810c52e99f9"),
+		},
+		"derive server context without master salt (C.2.2)": {
+			cfg:            Config{MasterSecret: secret, SenderID: []byte{0x01}, RecipientID: []byte{0x00}},
+			senderKey:      decode("e57b5635815177cd679ab4bcec9d7dda"),
+			recipientKey:   decode("321b26943253c7ffb6003b0b64d74041"),
+			commonIV:       decode("be35ae297d2dace910c52e99f9"),
+			senderNonce:    decode("bf35ae297d2dace810c52e99f9"),
+			recipientNonce: decode("bf35ae297d2dace910c52e99f9"),
+		},
+		"derive client context with ID context (C.3.1)": {
+			cfg:            Config{MasterSecret: secret, MasterSalt: salt, IDContext: decode("37cbf3210017a2d3"), SenderID: []byte{}, RecipientID: []byte{0x01}},
+			senderKey:      decode("af2a1300a5e95788b356336eeecd2b92"),
+			recipientKey:   decode("e39a0c7c77b43f03b4b39ab9a268699f"),
+			commonIV:       decode("2ca58fb85ff1b81c0b7181b85e"),
+			senderNonce:    decode("2ca58fb85ff1b81c0b7181b85e"),
+			recipientNonce: decode("2da58fb85ff1b81d0b7181b85e"),
+		},
+	}
+
+	for desc, tc := range cases {
+		senderKey, err := derive(tc.cfg, tc.cfg.SenderID, "Key", keyLen)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+		recipientKey, err := derive(tc.cfg, tc.cfg.RecipientID, "Key", keyLen)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+		commonIV, err := derive(tc.cfg, []byte{}, "IV", nonceLen)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+
+		assert.Equal(t, tc.senderKey, senderKey, fmt.Sprintf("%s: sender key mismatch", desc))
+		assert.Equal(t, tc.recipientKey, recipientKey, fmt.Sprintf("%s: recipient key mismatch", desc))
+		assert.Equal(t, tc.commonIV, commonIV, fmt.Sprintf("%s: common IV mismatch", desc))
+
+		c, err := NewContext(tc.cfg)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+		// The nonces of the vectors are computed for the partial IV 0.
+		assert.Equal(t, tc.senderNonce, c.nonce(c.senderID, []byte{0x00}), fmt.Sprintf("%s: sender nonce mismatch", desc))
+		assert.Equal(t, tc.recipientNonce, c.nonce(c.recipientID, []byte{0x00}), fmt.Sprintf("%s: recipient nonce mismatch", desc))
+	}
+}
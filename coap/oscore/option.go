@@ -0,0 +1,87 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oscore
+
+import "github.com/plgd-dev/go-coap/v2/message"
+
+// OptionID is the number of the CoAP OSCORE option.
+const OptionID message.OptionID = 9
+
+const (
+	flagKID        = 0x08
+	flagKIDContext = 0x10
+	flagsReserved  = 0xe0
+	pivLenMask     = 0x07
+)
+
+// Option represents the value of the OSCORE option.
+type Option struct {
+	// PartialIV is the sender sequence number of the message, empty if
+	// the message reuses the nonce of the request.
+	PartialIV []byte
+
+	// KID is the sender ID of the message, nil if absent.
+	KID []byte
+
+	// KIDContext is the ID Context of the security context, nil if absent.
+	KIDContext []byte
+}
+
+// ParseOption decodes the OSCORE option value.
+func ParseOption(v []byte) (Option, error) {
+	var o Option
+	if len(v) == 0 {
+		return o, nil
+	}
+
+	flags := v[0]
+	n := int(flags & pivLenMask)
+	if flags&flagsReserved != 0 || n > maxPIVLen {
+		return Option{}, ErrMalformedOption
+	}
+	v = v[1:]
+
+	if len(v) < n {
+		return Option{}, ErrMalformedOption
+	}
+	o.PartialIV, v = v[:n], v[n:]
+
+	if flags&flagKIDContext != 0 {
+		if len(v) == 0 || len(v) < int(v[0])+1 {
+			return Option{}, ErrMalformedOption
+		}
+		s := int(v[0])
+		o.KIDContext, v = append([]byte{}, v[1:s+1]...), v[s+1:]
+	}
+
+	switch {
+	case flags&flagKID != 0:
+		o.KID = append([]byte{}, v...)
+	case len(v) > 0:
+		return Option{}, ErrMalformedOption
+	}
+
+	return o, nil
+}
+
+func (o Option) encode() []byte {
+	flags := byte(len(o.PartialIV))
+	if o.KIDContext != nil {
+		flags |= flagKIDContext
+	}
+	if o.KID != nil {
+		flags |= flagKID
+	}
+	if flags == 0 {
+		return []byte{}
+	}
+
+	v := append([]byte{flags}, o.PartialIV...)
+	if o.KIDContext != nil {
+		v = append(v, byte(len(o.KIDContext)))
+		v = append(v, o.KIDContext...)
+	}
+
+	return append(v, o.KID...)
+}
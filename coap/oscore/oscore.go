@@ -0,0 +1,490 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oscore implements Object Security for Constrained RESTful
+// Environments (OSCORE), as defined by RFC 8613, using the default
+// AES-CCM-16-64-128 algorithm and HKDF SHA-256 key derivation.
+package oscore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/pion/dtls/v2/pkg/crypto/ccm"
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/go-coap/v2/message/codes"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	version     = 1
+	algorithm   = 10 // AES-CCM-16-64-128
+	keyLen      = 16
+	nonceLen    = 13
+	tagLen      = 8
+	maxIDLen    = nonceLen - 6
+	maxPIVLen   = 5
+	maxSequence = 1<<40 - 1
+	windowSize  = 32
+	payloadMark = 0xff
+
+	// fetch is the code of the CoAP FETCH method, defined by RFC 8132.
+	fetch codes.Code = 5
+)
+
+var (
+	// ErrInvalidConfig indicates invalid security context parameters.
+	ErrInvalidConfig = errors.New("invalid OSCORE security context configuration")
+
+	// ErrMalformedOption indicates a missing or malformed OSCORE option.
+	ErrMalformedOption = errors.New("malformed OSCORE option")
+
+	// ErrMalformedMessage indicates a message which can't be decoded after decryption.
+	ErrMalformedMessage = errors.New("malformed OSCORE message")
+
+	// ErrUnknownContext indicates a message protected by another security context.
+	ErrUnknownContext = errors.New("OSCORE security context not found")
+
+	// ErrDecryption indicates a message which fails the integrity verification.
+	ErrDecryption = errors.New("OSCORE decryption failed")
+
+	// ErrReplay indicates a request which was already received.
+	ErrReplay = errors.New("OSCORE replay detected")
+
+	// ErrSequenceExhausted indicates that the sender sequence numbers are used up.
+	ErrSequenceExhausted = errors.New("OSCORE sender sequence number exhausted")
+)
+
+// Config contains the input parameters of a security context.
+type Config struct {
+	MasterSecret []byte
+	MasterSalt   []byte
+
+	// IDContext is sent in requests, if set, to let the server choose the
+	// security context.
+	IDContext []byte
+
+	SenderID    []byte
+	RecipientID []byte
+
+	// SequenceNumber is the initial sender sequence number. It must not
+	// restart when the context is derived again from the same master secret.
+	SequenceNumber uint64
+}
+
+// Request binds responses to the request they answer.
+type Request struct {
+	KID       []byte
+	PartialIV []byte
+
+	// answered reports whether the nonce of the request was used by a response.
+	answered bool
+}
+
+// Context is an OSCORE security context shared by a client and a server.
+// It is safe for concurrent use.
+type Context struct {
+	senderID    []byte
+	recipientID []byte
+	idContext   []byte
+	commonIV    []byte
+	sender      cipher.AEAD
+	recipient   cipher.AEAD
+
+	mu     sync.Mutex
+	seq    uint64
+	replay window
+}
+
+// NewContext derives the security context from the configuration.
+func NewContext(cfg Config) (*Context, error) {
+	if len(cfg.MasterSecret) == 0 || len(cfg.SenderID) > maxIDLen || len(cfg.RecipientID) > maxIDLen ||
+		bytes.Equal(cfg.SenderID, cfg.RecipientID) || cfg.SequenceNumber > maxSequence {
+		return nil, ErrInvalidConfig
+	}
+
+	senderID, recipientID := nonNil(cfg.SenderID), nonNil(cfg.RecipientID)
+	senderKey, err := derive(cfg, senderID, "Key", keyLen)
+	if err != nil {
+		return nil, err
+	}
+	recipientKey, err := derive(cfg, recipientID, "Key", keyLen)
+	if err != nil {
+		return nil, err
+	}
+	commonIV, err := derive(cfg, []byte{}, "IV", nonceLen)
+	if err != nil {
+		return nil, err
+	}
+
+	sender, err := newAEAD(senderKey)
+	if err != nil {
+		return nil, err
+	}
+	recipient, err := newAEAD(recipientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Context{
+		senderID:    senderID,
+		recipientID: recipientID,
+		idContext:   cfg.IDContext,
+		commonIV:    commonIV,
+		sender:      sender,
+		recipient:   recipient,
+		seq:         cfg.SequenceNumber,
+	}, nil
+}
+
+// ProtectRequest encrypts the request and returns the OSCORE request along
+// with the binding needed to verify its responses.
+func (c *Context) ProtectRequest(m *message.Message) (*message.Message, *Request, error) {
+	piv, err := c.next()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req := &Request{KID: c.senderID, PartialIV: piv}
+	opt := Option{PartialIV: piv, KID: c.senderID, KIDContext: c.idContext}
+	code := codes.POST
+	if m.Options.HasOption(message.Observe) {
+		code = fetch
+	}
+
+	ret, err := c.protect(m, code, opt, c.nonce(c.senderID, piv), aad(req), true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ret, req, nil
+}
+
+// UnprotectRequest verifies and decrypts the OSCORE request and returns the
+// original request along with the binding needed to protect its responses.
+func (c *Context) UnprotectRequest(m *message.Message) (*message.Message, *Request, error) {
+	v, err := m.Options.GetBytes(OptionID)
+	if err != nil {
+		return nil, nil, ErrMalformedOption
+	}
+	opt, err := ParseOption(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opt.KID == nil || len(opt.PartialIV) == 0 {
+		return nil, nil, ErrMalformedOption
+	}
+	if !bytes.Equal(opt.KID, c.recipientID) {
+		return nil, nil, ErrUnknownContext
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seq := sequence(opt.PartialIV)
+	if !c.replay.valid(seq) {
+		return nil, nil, ErrReplay
+	}
+
+	req := &Request{KID: opt.KID, PartialIV: opt.PartialIV}
+	ret, err := c.unprotect(m, c.nonce(opt.KID, opt.PartialIV), aad(req))
+	if err != nil {
+		return nil, nil, err
+	}
+	c.replay.update(seq)
+
+	return ret, req, nil
+}
+
+// ProtectResponse encrypts the response to the request. The first response
+// reuses the nonce of the request, unless it is a notification, i.e. carries
+// the Observe option. Notifications and any further responses to the same
+// request are sent with a new partial IV, so that a nonce is never reused.
+func (c *Context) ProtectResponse(req *Request, m *message.Message) (*message.Message, error) {
+	code := codes.Changed
+	observe := m.Options.HasOption(message.Observe)
+	if observe {
+		code = codes.Content
+	}
+
+	c.mu.Lock()
+	reuse := !observe && !req.answered
+	req.answered = req.answered || reuse
+	c.mu.Unlock()
+
+	var opt Option
+	nonce := c.nonce(req.KID, req.PartialIV)
+	if !reuse {
+		piv, err := c.next()
+		if err != nil {
+			return nil, err
+		}
+		opt.PartialIV = piv
+		nonce = c.nonce(c.senderID, piv)
+	}
+
+	return c.protect(m, code, opt, nonce, aad(req), false)
+}
+
+// UnprotectResponse verifies and decrypts the OSCORE response to the request.
+func (c *Context) UnprotectResponse(req *Request, m *message.Message) (*message.Message, error) {
+	v, err := m.Options.GetBytes(OptionID)
+	if err != nil {
+		return nil, ErrMalformedOption
+	}
+	opt, err := ParseOption(v)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := c.nonce(req.KID, req.PartialIV)
+	if len(opt.PartialIV) > 0 {
+		nonce = c.nonce(c.recipientID, opt.PartialIV)
+	}
+
+	return c.unprotect(m, nonce, aad(req))
+}
+
+func (c *Context) next() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.seq > maxSequence {
+		return nil, ErrSequenceExhausted
+	}
+	piv := partialIV(c.seq)
+	c.seq++
+
+	return piv, nil
+}
+
+// protect moves the options which are not meant for proxies, along with
+// the code and the payload, to the encrypted payload of the OSCORE message.
+func (c *Context) protect(m *message.Message, code codes.Code, opt Option, nonce, aad []byte, request bool) (*message.Message, error) {
+	payload, err := body(m)
+	if err != nil {
+		return nil, err
+	}
+
+	inner := make(message.Options, 0, len(m.Options))
+	outer := make(message.Options, 0, len(m.Options)+1)
+	for _, o := range m.Options {
+		switch {
+		case o.ID == OptionID:
+		case o.ID == message.Observe:
+			// Observe is an outer option, for the sake of proxies, as well as an
+			// inner one, which is empty in notifications.
+			outer = outer.Add(o)
+			if !request {
+				o = message.Option{ID: message.Observe, Value: []byte{}}
+			}
+			inner = inner.Add(o)
+		case unprotected(o.ID):
+			outer = outer.Add(o)
+		default:
+			inner = inner.Add(o)
+		}
+	}
+
+	n, err := inner.Marshal(nil)
+	if err != nil && err != message.ErrTooSmall {
+		return nil, err
+	}
+	plaintext := make([]byte, 1+n, 1+n+1+len(payload))
+	plaintext[0] = byte(m.Code)
+	if n > 0 {
+		if _, err := inner.Marshal(plaintext[1:]); err != nil {
+			return nil, err
+		}
+	}
+	if len(payload) > 0 {
+		plaintext = append(plaintext, payloadMark)
+		plaintext = append(plaintext, payload...)
+	}
+
+	outer = outer.Add(message.Option{ID: OptionID, Value: opt.encode()})
+	return &message.Message{
+		Code:    code,
+		Token:   m.Token,
+		Context: m.Context,
+		Options: outer,
+		Body:    bytes.NewReader(c.sender.Seal(nil, nonce, plaintext, aad)),
+	}, nil
+}
+
+func (c *Context) unprotect(m *message.Message, nonce, aad []byte) (*message.Message, error) {
+	ciphertext, err := body(m)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := c.recipient.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, ErrDecryption
+	}
+	if len(plaintext) == 0 {
+		return nil, ErrMalformedMessage
+	}
+
+	opts := make(message.Options, 0, 16)
+	n, err := opts.Unmarshal(plaintext[1:], message.CoapOptionDefs)
+	for err == message.ErrOptionsTooSmall {
+		opts = make(message.Options, 0, 2*cap(opts))
+		n, err = opts.Unmarshal(plaintext[1:], message.CoapOptionDefs)
+	}
+	if err != nil {
+		return nil, errors.Wrap(ErrMalformedMessage, err)
+	}
+	payload := plaintext[1+n:]
+
+	for _, o := range m.Options {
+		switch {
+		case o.ID == OptionID:
+		case o.ID == message.Observe:
+			opts = opts.Remove(message.Observe).Add(o)
+		case unprotected(o.ID):
+			opts = opts.Add(o)
+		}
+	}
+
+	return &message.Message{
+		Code:    codes.Code(plaintext[0]),
+		Token:   m.Token,
+		Context: m.Context,
+		Options: opts,
+		Body:    bytes.NewReader(payload),
+	}, nil
+}
+
+// nonce combines the sender ID and the partial IV of a message with the
+// common IV.
+func (c *Context) nonce(id, piv []byte) []byte {
+	nonce := make([]byte, nonceLen)
+	nonce[0] = byte(len(id))
+	copy(nonce[1+maxIDLen-len(id):], id)
+	copy(nonce[nonceLen-len(piv):], piv)
+	for i := range nonce {
+		nonce[i] ^= c.commonIV[i]
+	}
+
+	return nonce
+}
+
+// unprotected reports whether the option is left outside the encrypted
+// payload. Block-wise transfer options are outer, so that block-wise
+// transfers are applied to OSCORE messages.
+func unprotected(id message.OptionID) bool {
+	switch id {
+	case message.URIHost, message.URIPort, message.ProxyURI, message.ProxyScheme,
+		message.Block1, message.Block2, message.Size1, message.Size2:
+		return true
+	default:
+		return false
+	}
+}
+
+func derive(cfg Config, id []byte, typ string, l int) ([]byte, error) {
+	var idContext interface{}
+	if cfg.IDContext != nil {
+		idContext = cfg.IDContext
+	}
+
+	info, err := cbor.Marshal([]interface{}{id, idContext, algorithm, typ, l})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]byte, l)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, cfg.MasterSecret, cfg.MasterSalt, info), ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return ccm.NewCCM(b, tagLen, nonceLen)
+}
+
+// aad returns the additional authenticated data, i.e. the COSE Enc_structure
+// of the request the message belongs to.
+func aad(req *Request) []byte {
+	external, _ := cbor.Marshal([]interface{}{version, []interface{}{algorithm}, nonNil(req.KID), nonNil(req.PartialIV), []byte{}})
+	ret, _ := cbor.Marshal([]interface{}{"Encrypt0", []byte{}, external})
+	return ret
+}
+
+func body(m *message.Message) ([]byte, error) {
+	if m.Body == nil {
+		return nil, nil
+	}
+	if _, err := m.Body.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadAll(m.Body)
+}
+
+func partialIV(seq uint64) []byte {
+	piv := []byte{byte(seq)}
+	for seq >>= 8; seq > 0; seq >>= 8 {
+		piv = append([]byte{byte(seq)}, piv...)
+	}
+
+	return piv
+}
+
+func sequence(piv []byte) uint64 {
+	var seq uint64
+	for _, b := range piv {
+		seq = seq<<8 | uint64(b)
+	}
+
+	return seq
+}
+
+func nonNil(b []byte) []byte {
+	if b == nil {
+		return []byte{}
+	}
+
+	return b
+}
+
+// window is the sliding replay window of received sequence numbers.
+type window struct {
+	top  uint64
+	seen uint32
+	init bool
+}
+
+func (w *window) valid(seq uint64) bool {
+	if !w.init || seq > w.top {
+		return true
+	}
+	d := w.top - seq
+
+	return d < windowSize && w.seen&(1<<d) == 0
+}
+
+func (w *window) update(seq uint64) {
+	switch {
+	case !w.init:
+		w.top, w.seen, w.init = seq, 1, true
+	case seq > w.top:
+		w.top, w.seen = seq, w.seen<<(seq-w.top)|1
+	default:
+		w.seen |= 1 << (w.top - seq)
+	}
+}
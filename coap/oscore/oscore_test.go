@@ -0,0 +1,431 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oscore_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/coap/oscore"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/go-coap/v2/message/codes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Security context parameters of the test vectors from RFC 8613, Appendix C.
+var (
+	secret   = decode("0102030405060708090a0b0c0d0e0f10")
+	salt     = decode("9e7ca92223786340")
+	clientID = []byte{}
+	serverID = []byte{0x01}
+	token    = message.Token(decode("00003974"))
+)
+
+func decode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func newContexts(t *testing.T, seq uint64, idContext []byte) (*oscore.Context, *oscore.Context) {
+	client, err := oscore.NewContext(oscore.Config{
+		MasterSecret:   secret,
+		MasterSalt:     salt,
+		IDContext:      idContext,
+		SenderID:       clientID,
+		RecipientID:    serverID,
+		SequenceNumber: seq,
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	server, err := oscore.NewContext(oscore.Config{
+		MasterSecret: secret,
+		MasterSalt:   salt,
+		IDContext:    idContext,
+		SenderID:     serverID,
+		RecipientID:  clientID,
+	})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	return client, server
+}
+
+func newMessage(code codes.Code, path, payload string, opts ...message.Option) *message.Message {
+	m := &message.Message{
+		Code:    code,
+		Token:   token,
+		Context: context.Background(),
+		Options: make(message.Options, 0, 16),
+	}
+	if path != "" {
+		m.Options = m.Options.Add(message.Option{ID: message.URIPath, Value: []byte(path)})
+	}
+	for _, o := range opts {
+		m.Options = m.Options.Add(o)
+	}
+	if payload != "" {
+		m.Body = bytes.NewReader([]byte(payload))
+	}
+
+	return m
+}
+
+func body(t *testing.T, m *message.Message) []byte {
+	if m.Body == nil {
+		return nil
+	}
+	b, err := ioutil.ReadAll(m.Body)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = m.Body.Seek(0, 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return b
+}
+
+func TestNewContext(t *testing.T) {
+	cases := map[string]struct {
+		cfg oscore.Config
+		err error
+	}{
+		"create context": {
+			cfg: oscore.Config{MasterSecret: secret, SenderID: serverID, RecipientID: clientID},
+			err: nil,
+		},
+		"create context without master secret": {
+			cfg: oscore.Config{SenderID: serverID, RecipientID: clientID},
+			err: oscore.ErrInvalidConfig,
+		},
+		"create context with equal sender and recipient IDs": {
+			cfg: oscore.Config{MasterSecret: secret, SenderID: serverID, RecipientID: serverID},
+			err: oscore.ErrInvalidConfig,
+		},
+		"create context with too long sender ID": {
+			cfg: oscore.Config{MasterSecret: secret, SenderID: []byte("12345678"), RecipientID: clientID},
+			err: oscore.ErrInvalidConfig,
+		},
+		"create context with too large sequence number": {
+			cfg: oscore.Config{MasterSecret: secret, SenderID: serverID, RecipientID: clientID, SequenceNumber: 1 << 40},
+			err: oscore.ErrInvalidConfig,
+		},
+	}
+
+	for desc, tc := range cases {
+		_, err := oscore.NewContext(tc.cfg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", desc, tc.err, err))
+	}
+}
+
+func TestTestVectors(t *testing.T) {
+	client, server := newContexts(t, 20, nil)
+
+	req := newMessage(codes.GET, "tv1", "", message.Option{ID: message.URIHost, Value: []byte("localhost")})
+	preq, binding, err := client.ProtectRequest(req)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	opt, err := preq.Options.GetBytes(oscore.OptionID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	host, err := preq.Options.GetString(message.URIHost)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, codes.POST, preq.Code, "protected request code mismatch")
+	assert.Equal(t, decode("0914"), opt, "OSCORE option of protected request mismatch")
+	assert.Equal(t, "localhost", host, "Uri-Host option of protected request mismatch")
+	assert.False(t, preq.Options.HasOption(message.URIPath), "protected request contains Uri-Path option")
+	assert.Equal(t, decode("612f1092f1776f1c1668b3825e"), body(t, preq), "protected request ciphertext mismatch")
+
+	ureq, sbinding, err := server.UnprotectRequest(preq)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	path, err := ureq.Options.Path()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, codes.GET, ureq.Code, "unprotected request code mismatch")
+	assert.Equal(t, "/tv1", path, "unprotected request path mismatch")
+	assert.Equal(t, binding, sbinding, "request binding mismatch")
+
+	res := newMessage(codes.Content, "", "Hello World!")
+	pres, err := server.ProtectResponse(sbinding, res)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	opt, err = pres.Options.GetBytes(oscore.OptionID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, codes.Changed, pres.Code, "protected response code mismatch")
+	assert.Empty(t, opt, "OSCORE option of protected response mismatch")
+	assert.Equal(t, decode("dbaad1e9a7e7b2a813d3c31524378303cdafae119106"), body(t, pres), "protected response ciphertext mismatch")
+
+	ures, err := client.UnprotectResponse(binding, pres)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, codes.Content, ures.Code, "unprotected response code mismatch")
+	assert.Equal(t, []byte("Hello World!"), body(t, ures), "unprotected response payload mismatch")
+
+	// Any further response to the same request carries its own partial IV,
+	// which matches the response with partial IV from RFC 8613, Appendix C.8.
+	pres, err = server.ProtectResponse(sbinding, newMessage(codes.Content, "", "Hello World!"))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	opt, err = pres.Options.GetBytes(oscore.OptionID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, codes.Changed, pres.Code, "protected response code mismatch")
+	assert.Equal(t, decode("0100"), opt, "OSCORE option of protected response mismatch")
+	assert.Equal(t, decode("4d4c13669384b67354b2b6175ff4b8658c666a6cf88e"), body(t, pres), "protected response ciphertext mismatch")
+
+	ures, err = client.UnprotectResponse(binding, pres)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, []byte("Hello World!"), body(t, ures), "unprotected response payload mismatch")
+}
+
+// TestRequestVectors checks the protected requests against the test vectors
+// from RFC 8613, Appendix C.5 and C.6.
+func TestRequestVectors(t *testing.T) {
+	cases := map[string]struct {
+		client     oscore.Config
+		server     oscore.Config
+		option     []byte
+		ciphertext []byte
+	}{
+		"protect request without master salt (C.5)": {
+			client:     oscore.Config{MasterSecret: secret, SenderID: []byte{0x00}, RecipientID: serverID, SequenceNumber: 20},
+			server:     oscore.Config{MasterSecret: secret, SenderID: serverID, RecipientID: []byte{0x00}},
+			option:     decode("091400"),
+			ciphertext: decode("4ed339a5a379b0b8bc731fffb0"),
+		},
+		"protect request with ID context (C.6)": {
+			client:     oscore.Config{MasterSecret: secret, MasterSalt: salt, IDContext: decode("37cbf3210017a2d3"), SenderID: clientID, RecipientID: serverID, SequenceNumber: 20},
+			server:     oscore.Config{MasterSecret: secret, MasterSalt: salt, IDContext: decode("37cbf3210017a2d3"), SenderID: serverID, RecipientID: clientID},
+			option:     decode("19140837cbf3210017a2d3"),
+			ciphertext: decode("72cd7273fd331ac45cffbe55c3"),
+		},
+	}
+
+	for desc, tc := range cases {
+		client, err := oscore.NewContext(tc.client)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+		server, err := oscore.NewContext(tc.server)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+
+		req := newMessage(codes.GET, "tv1", "", message.Option{ID: message.URIHost, Value: []byte("localhost")})
+		preq, _, err := client.ProtectRequest(req)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+		opt, err := preq.Options.GetBytes(oscore.OptionID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+		assert.Equal(t, tc.option, opt, fmt.Sprintf("%s: OSCORE option of protected request mismatch", desc))
+		assert.Equal(t, tc.ciphertext, body(t, preq), fmt.Sprintf("%s: protected request ciphertext mismatch", desc))
+
+		ureq, _, err := server.UnprotectRequest(preq)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+		path, err := ureq.Options.Path()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", desc, err))
+		assert.Equal(t, "/tv1", path, fmt.Sprintf("%s: unprotected request path mismatch", desc))
+	}
+}
+
+func TestUnprotectRequest(t *testing.T) {
+	idContext := []byte("thing-id")
+	client, server := newContexts(t, 0, idContext)
+	other, _ := newContexts(t, 1, []byte("other"))
+
+	req := newMessage(codes.POST, "channels/1/messages", `{"v":1}`, message.Option{ID: message.URIQuery, Value: []byte("a=b")})
+	preq, _, err := client.ProtectRequest(req)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	v, err := preq.Options.GetBytes(oscore.OptionID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	opt, err := oscore.ParseOption(v)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, idContext, opt.KIDContext, "OSCORE option ID Context mismatch")
+
+	oreq, _, err := other.ProtectRequest(newMessage(codes.POST, "channels/1/messages", `{"v":1}`))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	treq, _, err := client.ProtectRequest(newMessage(codes.POST, "channels/1/messages", `{"v":1}`))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	tampered := newMessage(codes.POST, "", "")
+	tampered.Options = treq.Options
+	ct := body(t, treq)
+	tampered.Body = bytes.NewReader(append(append([]byte{}, ct[:len(ct)-1]...), ct[len(ct)-1]^1))
+
+	plain := newMessage(codes.POST, "channels/1/messages", `{"v":1}`)
+
+	cases := []struct {
+		desc    string
+		msg     *message.Message
+		path    string
+		query   string
+		payload []byte
+		err     error
+	}{
+		{
+			desc:    "unprotect request",
+			msg:     preq,
+			path:    "/channels/1/messages",
+			query:   "a=b",
+			payload: []byte(`{"v":1}`),
+			err:     nil,
+		},
+		{
+			desc: "unprotect replayed request",
+			msg:  preq,
+			err:  oscore.ErrReplay,
+		},
+		{
+			desc: "unprotect request protected by another context",
+			msg:  oreq,
+			err:  oscore.ErrDecryption,
+		},
+		{
+			desc: "unprotect tampered request",
+			msg:  tampered,
+			err:  oscore.ErrDecryption,
+		},
+		{
+			desc: "unprotect request without OSCORE option",
+			msg:  plain,
+			err:  oscore.ErrMalformedOption,
+		},
+	}
+
+	for _, tc := range cases {
+		m, _, err := server.UnprotectRequest(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		path, err := m.Options.Path()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		query, err := m.Options.GetString(message.URIQuery)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, codes.POST, m.Code, fmt.Sprintf("%s: code mismatch", tc.desc))
+		assert.Equal(t, tc.path, path, fmt.Sprintf("%s: expected path %s got %s", tc.desc, tc.path, path))
+		assert.Equal(t, tc.query, query, fmt.Sprintf("%s: expected query %s got %s", tc.desc, tc.query, query))
+		assert.Equal(t, tc.payload, body(t, m), fmt.Sprintf("%s: payload mismatch", tc.desc))
+	}
+}
+
+func TestReplayWindow(t *testing.T) {
+	client, server := newContexts(t, 0, nil)
+
+	var reqs []*message.Message
+	for i := 0; i < 40; i++ {
+		m, _, err := client.ProtectRequest(newMessage(codes.POST, "tv1", "payload"))
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		reqs = append(reqs, m)
+	}
+
+	cases := []struct {
+		desc string
+		seq  int
+		err  error
+	}{
+		{desc: "receive request", seq: 5, err: nil},
+		{desc: "receive older request within window", seq: 2, err: nil},
+		{desc: "receive replayed request", seq: 2, err: oscore.ErrReplay},
+		{desc: "receive newer request", seq: 39, err: nil},
+		{desc: "receive request within window after window move", seq: 10, err: nil},
+		{desc: "receive request outside of window", seq: 5, err: oscore.ErrReplay},
+		{desc: "receive unseen request outside of window", seq: 6, err: oscore.ErrReplay},
+	}
+
+	for _, tc := range cases {
+		_, _, err := server.UnprotectRequest(reqs[tc.seq])
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	client, server := newContexts(t, 0, []byte("thing-id"))
+
+	req := newMessage(codes.GET, "channels/1/messages", "", message.Option{ID: message.Observe, Value: []byte{}})
+	preq, binding, err := client.ProtectRequest(req)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, codes.Code(5), preq.Code, "protected observe request is not sent as FETCH")
+	assert.True(t, preq.Options.HasOption(message.Observe), "protected observe request lacks outer Observe option")
+
+	ureq, sbinding, err := server.UnprotectRequest(preq)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	obs, err := ureq.Options.Observe()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, codes.GET, ureq.Code, "unprotected observe request code mismatch")
+	assert.Equal(t, uint32(0), obs, "unprotected observe request Observe option mismatch")
+
+	var pivs [][]byte
+	for i, payload := range []string{"first", "second"} {
+		n := newMessage(codes.Content, "", payload, message.Option{ID: message.Observe, Value: []byte{byte(i + 2)}})
+		pn, err := server.ProtectResponse(sbinding, n)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		v, err := pn.Options.GetBytes(oscore.OptionID)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		opt, err := oscore.ParseOption(v)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		pivs = append(pivs, opt.PartialIV)
+		assert.Equal(t, codes.Content, pn.Code, "protected notification code mismatch")
+
+		un, err := client.UnprotectResponse(binding, pn)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		obs, err := un.Options.Observe()
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, uint32(i+2), obs, "unprotected notification Observe option mismatch")
+		assert.Equal(t, []byte(payload), body(t, un), "unprotected notification payload mismatch")
+	}
+	assert.NotEqual(t, pivs[0], pivs[1], "notifications share the partial IV")
+}
+
+func TestParseOption(t *testing.T) {
+	cases := []struct {
+		desc  string
+		value []byte
+		opt   oscore.Option
+		err   error
+	}{
+		{
+			desc:  "parse empty option",
+			value: []byte{},
+			opt:   oscore.Option{},
+			err:   nil,
+		},
+		{
+			desc:  "parse option with partial IV and empty kid",
+			value: decode("0914"),
+			opt:   oscore.Option{PartialIV: decode("14"), KID: []byte{}},
+			err:   nil,
+		},
+		{
+			desc:  "parse option with partial IV, kid context and kid",
+			value: decode("19140837cbf3210017a2d300"),
+			opt:   oscore.Option{PartialIV: decode("14"), KIDContext: decode("37cbf3210017a2d3"), KID: decode("00")},
+			err:   nil,
+		},
+		{
+			desc:  "parse option with reserved flags",
+			value: decode("2914"),
+			err:   oscore.ErrMalformedOption,
+		},
+		{
+			desc:  "parse option with too long partial IV",
+			value: decode("06010203040506"),
+			err:   oscore.ErrMalformedOption,
+		},
+		{
+			desc:  "parse option with truncated partial IV",
+			value: decode("0201"),
+			err:   oscore.ErrMalformedOption,
+		},
+		{
+			desc:  "parse option with truncated kid context",
+			value: decode("11140837cb"),
+			err:   oscore.ErrMalformedOption,
+		},
+		{
+			desc:  "parse option with trailing bytes",
+			value: decode("011401"),
+			err:   oscore.ErrMalformedOption,
+		},
+	}
+
+	for _, tc := range cases {
+		opt, err := oscore.ParseOption(tc.value)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.opt, opt, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.opt, opt))
+	}
+}
@@ -98,3 +98,17 @@ func deleteSubscriptionEndpint(svc notifiers.Service) endpoint.Endpoint {
 		return removeSubRes{}, nil
 	}
 }
+
+func viewUsageEndpoint(svc notifiers.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(usageReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		usage, err := svc.ViewUsage(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+		return usageRes(usage), nil
+	}
+}
@@ -76,7 +76,8 @@ func newService() notifiers.Service {
 	idp := uuid.NewMock()
 	notif := ntmocks.NewNotifier()
 	from := "exampleFrom"
-	return notifiers.New(auth, repo, idp, notif, from)
+	things := mocks.NewThingsServiceClient(map[string]string{token: topic}, nil)
+	return notifiers.New(auth, things, repo, idp, notif, from)
 }
 
 func newServer(svc notifiers.Service) *httptest.Server {
@@ -12,6 +12,7 @@ import (
 
 	notifiers "github.com/MainfluxLabs/mainflux/consumers/notifiers"
 	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
 )
 
 var _ notifiers.Service = (*loggingMiddleware)(nil)
@@ -78,6 +79,19 @@ func (lm *loggingMiddleware) RemoveSubscription(ctx context.Context, token, id s
 	return lm.svc.RemoveSubscription(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) ViewUsage(ctx context.Context, token string) (usage map[string]quota.Usage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_usage took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewUsage(ctx, token)
+}
+
 func (lm *loggingMiddleware) Consume(msg interface{}) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method consume took %s to complete", time.Since(begin))
@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-kit/kit/metrics"
 	notifiers "github.com/MainfluxLabs/mainflux/consumers/notifiers"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
 )
 
 var _ notifiers.Service = (*metricsMiddleware)(nil)
@@ -66,6 +67,15 @@ func (ms *metricsMiddleware) RemoveSubscription(ctx context.Context, token, id s
 	return ms.svc.RemoveSubscription(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) ViewUsage(ctx context.Context, token string) (map[string]quota.Usage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_usage").Add(1)
+		ms.latency.With("method", "view_usage").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewUsage(ctx, token)
+}
+
 func (ms *metricsMiddleware) Consume(msg interface{}) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "consume").Add(1)
@@ -53,3 +53,14 @@ func (req listSubsReq) validate() error {
 	}
 	return nil
 }
+
+type usageReq struct {
+	token string
+}
+
+func (req usageReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	return nil
+}
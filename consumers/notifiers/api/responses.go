@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
 )
 
 var (
@@ -15,6 +16,7 @@ var (
 	_ mainflux.Response = (*viewSubRes)(nil)
 	_ mainflux.Response = (*listSubsRes)(nil)
 	_ mainflux.Response = (*removeSubRes)(nil)
+	_ mainflux.Response = (*usageRes)(nil)
 )
 
 type createSubRes struct {
@@ -87,3 +89,17 @@ func (res removeSubRes) Headers() map[string]string {
 func (res removeSubRes) Empty() bool {
 	return true
 }
+
+type usageRes map[string]quota.Usage
+
+func (res usageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res usageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res usageRes) Empty() bool {
+	return false
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
 	kitot "github.com/go-kit/kit/tracing/opentracing"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
@@ -67,6 +68,13 @@ func MakeHandler(svc notifiers.Service, tracer opentracing.Tracer, logger logger
 		opts...,
 	))
 
+	mux.Get("/usage", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_usage")(viewUsageEndpoint(svc)),
+		decodeUsage,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.GetFunc("/health", mainflux.Health("notifier"))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -122,6 +130,11 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeUsage(_ context.Context, r *http.Request) (interface{}, error) {
+	req := usageReq{token: apiutil.ExtractBearerToken(r)}
+	return req, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	if ar, ok := response.(mainflux.Response); ok {
 		for k, v := range ar.Headers() {
@@ -151,10 +164,14 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	case errors.Contains(err, errors.ErrAuthentication),
 		err == apiutil.ErrBearerToken:
 		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
 	case errors.Contains(err, errors.ErrConflict):
 		w.WriteHeader(http.StatusConflict)
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
+	case errors.Contains(err, quota.ErrQuotaExceeded):
+		w.WriteHeader(http.StatusTooManyRequests)
 
 	case errors.Contains(err, errors.ErrCreateEntity),
 		errors.Contains(err, errors.ErrRetrieveEntity),
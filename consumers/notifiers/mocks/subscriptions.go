@@ -68,6 +68,9 @@ func (srm *subRepoMock) RetrieveAll(_ context.Context, pm notifiers.PageMetadata
 	offset := int(pm.Offset)
 	for _, k := range keys {
 		v := srm.subs[k]
+		if pm.OwnerID != "" && pm.OwnerID != v.OwnerID {
+			continue
+		}
 		if pm.Topic == "" {
 			if pm.Contact == "" {
 				if total < offset {
@@ -120,6 +123,20 @@ func appendSubs(subs []notifiers.Subscription, sub notifiers.Subscription, max i
 	return subs
 }
 
+func (srm *subRepoMock) RetrieveCountByOwner(_ context.Context, ownerID string) (uint64, error) {
+	srm.mu.Lock()
+	defer srm.mu.Unlock()
+
+	var count uint64
+	for _, sub := range srm.subs {
+		if sub.OwnerID == ownerID {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
 func (srm *subRepoMock) Remove(_ context.Context, id string) error {
 	srm.mu.Lock()
 	defer srm.mu.Unlock()
@@ -72,6 +72,9 @@ func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.Page
 	if pm.Contact != "" {
 		args["contact"] = pm.Contact
 	}
+	if pm.OwnerID != "" {
+		args["owner_id"] = pm.OwnerID
+	}
 	var condition string
 	if len(args) > 0 {
 		var cond []string
@@ -122,6 +125,17 @@ func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.Page
 	return ret, nil
 }
 
+func (repo subscriptionsRepo) RetrieveCountByOwner(ctx context.Context, ownerID string) (uint64, error) {
+	q := `SELECT COUNT(*) FROM subscriptions WHERE owner_id = $1`
+
+	var count uint64
+	if err := repo.db.QueryRowxContext(ctx, q, ownerID).Scan(&count); err != nil {
+		return 0, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return count, nil
+}
+
 func (repo subscriptionsRepo) Remove(ctx context.Context, id string) error {
 	q := `DELETE from subscriptions WHERE id = $1`
 
@@ -221,6 +221,54 @@ func TestRetrieveAll(t *testing.T) {
 	}
 }
 
+func TestRetrieveCountByOwner(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	ownerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
+
+	for i := 0; i < 3; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
+
+		sub := notifiers.Subscription{
+			OwnerID: ownerID,
+			ID:      id,
+			Contact: owner,
+			Topic:   fmt.Sprintf("count.%d", i),
+		}
+		_, err = repo.Save(context.Background(), sub)
+		require.Nil(t, err, fmt.Sprintf("got an error saving subscription: %s", err))
+	}
+
+	unknownID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got an error creating id: %s", err))
+
+	cases := []struct {
+		desc    string
+		ownerID string
+		count   uint64
+	}{
+		{
+			desc:    "count subscriptions of owner",
+			ownerID: ownerID,
+			count:   3,
+		},
+		{
+			desc:    "count subscriptions of owner without subscriptions",
+			ownerID: unknownID,
+			count:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		count, err := repo.RetrieveCountByOwner(context.Background(), tc.ownerID)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.count, count, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.count, count))
+	}
+}
+
 func TestRemove(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	repo := postgres.New(dbMiddleware)
@@ -6,11 +6,14 @@ package notifiers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/consumers"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
 )
 
 var (
@@ -28,11 +31,16 @@ type Service interface {
 	ViewSubscription(ctx context.Context, token, id string) (Subscription, error)
 
 	// ListSubscriptions lists subscriptions having the provided user token and search params.
+	// Users are listed their own subscriptions, unless the search is narrowed to
+	// the topic of a channel whose notifiers they are allowed to manage.
 	ListSubscriptions(ctx context.Context, token string, pm PageMetadata) (Page, error)
 
 	// RemoveSubscription removes the subscription having the provided identifier.
 	RemoveSubscription(ctx context.Context, token, id string) error
 
+	// ViewUsage retrieves the number of subscriptions of the user and its limit.
+	ViewUsage(ctx context.Context, token string) (map[string]quota.Usage, error)
+
 	consumers.Consumer
 }
 
@@ -40,6 +48,7 @@ var _ Service = (*notifierService)(nil)
 
 type notifierService struct {
 	auth     mainflux.AuthServiceClient
+	things   mainflux.ThingsServiceClient
 	subs     SubscriptionsRepository
 	idp      mainflux.IDProvider
 	notifier Notifier
@@ -47,9 +56,10 @@ type notifierService struct {
 }
 
 // New instantiates the subscriptions service implementation.
-func New(auth mainflux.AuthServiceClient, subs SubscriptionsRepository, idp mainflux.IDProvider, notifier Notifier, from string) Service {
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, subs SubscriptionsRepository, idp mainflux.IDProvider, notifier Notifier, from string) Service {
 	return &notifierService{
 		auth:     auth,
+		things:   things,
 		subs:     subs,
 		idp:      idp,
 		notifier: notifier,
@@ -58,10 +68,19 @@ func New(auth mainflux.AuthServiceClient, subs SubscriptionsRepository, idp main
 }
 
 func (ns *notifierService) CreateSubscription(ctx context.Context, token string, sub Subscription) (string, error) {
-	res, err := ns.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ns.identify(ctx, token)
 	if err != nil {
 		return "", err
 	}
+
+	if err := ns.canManage(ctx, token, sub.Topic); err != nil {
+		return "", err
+	}
+
+	if err := ns.checkQuota(ctx, res.GetId()); err != nil {
+		return "", err
+	}
+
 	sub.ID, err = ns.idp.ID()
 	if err != nil {
 		return "", err
@@ -72,29 +91,128 @@ func (ns *notifierService) CreateSubscription(ctx context.Context, token string,
 }
 
 func (ns *notifierService) ViewSubscription(ctx context.Context, token, id string) (Subscription, error) {
-	if _, err := ns.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
+	res, err := ns.identify(ctx, token)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	sub, err := ns.subs.Retrieve(ctx, id)
+	if err != nil {
 		return Subscription{}, err
 	}
 
-	return ns.subs.Retrieve(ctx, id)
+	if sub.OwnerID != res.GetId() {
+		if err := ns.canManage(ctx, token, sub.Topic); err != nil {
+			return Subscription{}, err
+		}
+	}
+
+	return sub, nil
 }
 
 func (ns *notifierService) ListSubscriptions(ctx context.Context, token string, pm PageMetadata) (Page, error) {
-	if _, err := ns.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
+	res, err := ns.identify(ctx, token)
+	if err != nil {
 		return Page{}, err
 	}
 
+	if pm.Topic == "" || ns.canManage(ctx, token, pm.Topic) != nil {
+		pm.OwnerID = res.GetId()
+	}
+
 	return ns.subs.RetrieveAll(ctx, pm)
 }
 
 func (ns *notifierService) RemoveSubscription(ctx context.Context, token, id string) error {
-	if _, err := ns.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
+	res, err := ns.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	sub, err := ns.subs.Retrieve(ctx, id)
+	if errors.Contains(err, errors.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
 		return err
 	}
 
+	if sub.OwnerID != res.GetId() {
+		if err := ns.canManage(ctx, token, sub.Topic); err != nil {
+			return err
+		}
+	}
+
 	return ns.subs.Remove(ctx, id)
 }
 
+func (ns *notifierService) ViewUsage(ctx context.Context, token string) (map[string]quota.Usage, error) {
+	res, err := ns.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := ns.auth.RetrieveQuota(ctx, &mainflux.QuotaReq{Subject: quota.User, Id: res.GetId()})
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := ns.subs.RetrieveCountByOwner(ctx, res.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	usage := map[string]quota.Usage{
+		quota.Subscriptions: {Used: used, Limit: q.GetSubscriptions()},
+	}
+	return usage, nil
+}
+
+// identify validates the user token. API keys restricted by scopes or
+// resources are rejected, since subscriptions are not covered by the scopes.
+func (ns *notifierService) identify(ctx context.Context, token string) (*mainflux.UserIdentity, error) {
+	res, err := ns.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, err
+	}
+
+	if auth.IsScoped(res) {
+		return nil, errors.ErrAuthorization
+	}
+
+	return res, nil
+}
+
+// canManage checks whether the user is allowed to manage notifiers of the
+// channel the topic belongs to, either as the channel owner or through the
+// manage_notifiers permission on the channel group.
+func (ns *notifierService) canManage(ctx context.Context, token, topic string) error {
+	chanID := strings.SplitN(topic, ".", 2)[0]
+	if _, err := ns.things.CanReadChannel(ctx, &mainflux.ChannelReadReq{Token: token, ChanID: chanID, Permission: auth.ManageNotifiersPermission}); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
+
+// checkQuota returns ErrQuotaExceeded if the user can't create more subscriptions.
+func (ns *notifierService) checkQuota(ctx context.Context, userID string) error {
+	q, err := ns.auth.RetrieveQuota(ctx, &mainflux.QuotaReq{Subject: quota.User, Id: userID})
+	if err != nil {
+		return err
+	}
+	if q.GetSubscriptions() == 0 {
+		return nil
+	}
+
+	used, err := ns.subs.RetrieveCountByOwner(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return quota.Check(used, 1, q.GetSubscriptions())
+}
+
 func (ns *notifierService) Consume(message interface{}) error {
 	msg, ok := message.(messaging.Message)
 	if !ok {
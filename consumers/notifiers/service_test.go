@@ -8,11 +8,13 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/MainfluxLabs/mainflux"
 	notifiers "github.com/MainfluxLabs/mainflux/consumers/notifiers"
 	ntmocks "github.com/MainfluxLabs/mainflux/consumers/notifiers/mocks"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/users"
 	"github.com/stretchr/testify/assert"
@@ -23,23 +25,38 @@ const (
 	total          = 100
 	userEmail      = "user@example.com"
 	otherUserEmail = "otherUser@example.com"
+	quotaUserEmail = "quotaUser@example.com"
 	invalidUser    = "invalid@example.com"
 	password       = "password"
+	scopedKey      = "scoped-key"
 )
 
 var (
-	user      = users.User{Email: userEmail, Password: password}
-	otherUser = users.User{Email: otherUserEmail, Password: password}
-	usersList = []users.User{user, otherUser}
+	user      = users.User{ID: "userID", Email: userEmail, Password: password}
+	otherUser = users.User{ID: "otherUserID", Email: otherUserEmail, Password: password}
+	quotaUser = users.User{ID: "quotaUserID", Email: quotaUserEmail, Password: password}
+	usersList = []users.User{user, otherUser, quotaUser}
 )
 
 func newService() notifiers.Service {
+	keys := map[string]*mainflux.UserIdentity{
+		scopedKey: {Email: userEmail, ChannelIDs: []string{"channel"}},
+	}
+	return newServiceWithAuth(mocks.NewAuthServiceWithKeys("", usersList, keys))
+}
+
+func newServiceWithQuotas(quotas map[string]*mainflux.QuotaRes) notifiers.Service {
+	return newServiceWithAuth(mocks.NewAuthServiceWithQuotas("", usersList, quotas))
+}
+
+func newServiceWithAuth(auth mainflux.AuthServiceClient) notifiers.Service {
 	repo := ntmocks.NewRepo(make(map[string]notifiers.Subscription))
-	auth := mocks.NewAuthService("", usersList)
 	notifier := ntmocks.NewNotifier()
 	idp := uuid.NewMock()
 	from := "exampleFrom"
-	return notifiers.New(auth, repo, idp, notifier, from)
+	// Users manage notifiers of the channels they are registered with.
+	things := mocks.NewThingsServiceClient(map[string]string{userEmail: "topic", otherUserEmail: "other", quotaUserEmail: "topic"}, nil)
+	return notifiers.New(auth, things, repo, idp, notifier, from)
 }
 
 func TestCreateSubscription(t *testing.T) {
@@ -55,24 +72,38 @@ func TestCreateSubscription(t *testing.T) {
 		{
 			desc:  "test success",
 			token: userEmail,
-			sub:   notifiers.Subscription{Contact: user.Email, Topic: "valid.topic"},
+			sub:   notifiers.Subscription{Contact: user.Email, Topic: "topic.valid"},
 			id:    uuid.Prefix + fmt.Sprintf("%012d", 1),
 			err:   nil,
 		},
 		{
 			desc:  "test already existing",
 			token: userEmail,
-			sub:   notifiers.Subscription{Contact: userEmail, Topic: "valid.topic"},
+			sub:   notifiers.Subscription{Contact: userEmail, Topic: "topic.valid"},
 			id:    "",
 			err:   errors.ErrConflict,
 		},
 		{
 			desc:  "test with empty token",
 			token: "",
-			sub:   notifiers.Subscription{Contact: userEmail, Topic: "valid.topic"},
+			sub:   notifiers.Subscription{Contact: userEmail, Topic: "topic.valid"},
 			id:    "",
 			err:   errors.ErrAuthentication,
 		},
+		{
+			desc:  "test with scoped API key",
+			token: scopedKey,
+			sub:   notifiers.Subscription{Contact: userEmail, Topic: "other.topic"},
+			id:    "",
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "test without manage notifiers permission",
+			token: userEmail,
+			sub:   notifiers.Subscription{Contact: userEmail, Topic: "other.topic"},
+			id:    "",
+			err:   errors.ErrAuthorization,
+		},
 	}
 
 	for _, tc := range cases {
@@ -84,7 +115,7 @@ func TestCreateSubscription(t *testing.T) {
 
 func TestViewSubscription(t *testing.T) {
 	svc := newService()
-	sub := notifiers.Subscription{Contact: userEmail, Topic: "valid.topic"}
+	sub := notifiers.Subscription{Contact: userEmail, Topic: "topic.valid"}
 	id, err := svc.CreateSubscription(context.Background(), userEmail, sub)
 	require.Nil(t, err, "Saving a Subscription must succeed")
 	sub.ID = id
@@ -118,6 +149,13 @@ func TestViewSubscription(t *testing.T) {
 			sub:   notifiers.Subscription{},
 			err:   errors.ErrAuthentication,
 		},
+		{
+			desc:  "test without manage notifiers permission",
+			token: otherUserEmail,
+			id:    id,
+			sub:   notifiers.Subscription{},
+			err:   errors.ErrAuthorization,
+		},
 	}
 
 	for _, tc := range cases {
@@ -131,23 +169,31 @@ func TestListSubscriptions(t *testing.T) {
 	svc := newService()
 	sub := notifiers.Subscription{Contact: userEmail, OwnerID: user.ID}
 	topic := "topic.subtopic"
+	otherTopic := "other.subtopic"
 	var subs []notifiers.Subscription
 	for i := 0; i < total; i++ {
 		tmp := sub
 		token := userEmail
+		tmp.Topic = fmt.Sprintf("%s.%d", topic, i)
 		if i%2 == 0 {
 			tmp.Contact = otherUserEmail
 			tmp.OwnerID = otherUser.ID
+			tmp.Topic = fmt.Sprintf("%s.%d", otherTopic, i)
 			token = otherUserEmail
 		}
 
-		tmp.Topic = fmt.Sprintf("%s.%d", topic, i)
 		id, err := svc.CreateSubscription(context.Background(), token, tmp)
 		require.Nil(t, err, "Saving a Subscription must succeed")
 		tmp.ID = id
 		subs = append(subs, tmp)
 	}
 
+	// Subscription of another user to the topic of the channel managed by the user.
+	quotaSub := notifiers.Subscription{Contact: quotaUserEmail, OwnerID: quotaUser.ID, Topic: subs[1].Topic}
+	id, err := svc.CreateSubscription(context.Background(), quotaUserEmail, quotaSub)
+	require.Nil(t, err, "Saving a Subscription must succeed")
+	quotaSub.ID = id
+
 	var offsetSubs []notifiers.Subscription
 	for i := 20; i < 40; i += 2 {
 		offsetSubs = append(offsetSubs, subs[i])
@@ -170,11 +216,12 @@ func TestListSubscriptions(t *testing.T) {
 			err: nil,
 			page: notifiers.Page{
 				PageMetadata: notifiers.PageMetadata{
-					Offset: 0,
-					Limit:  3,
+					Offset:  0,
+					Limit:   3,
+					OwnerID: user.ID,
 				},
-				Subscriptions: subs[:3],
-				Total:         total,
+				Subscriptions: []notifiers.Subscription{subs[1], subs[3], subs[5]},
+				Total:         total / 2,
 			},
 		},
 		{
@@ -199,25 +246,35 @@ func TestListSubscriptions(t *testing.T) {
 			err:  errors.ErrAuthentication,
 		},
 		{
-			desc:  "test with topic",
+			desc:  "test with topic of managed channel",
 			token: userEmail,
 			pageMeta: notifiers.PageMetadata{
 				Limit: 10,
-				Topic: fmt.Sprintf("%s.%d", topic, 4),
+				Topic: subs[1].Topic,
 			},
 			page: notifiers.Page{
 				PageMetadata: notifiers.PageMetadata{
 					Limit: 10,
-					Topic: fmt.Sprintf("%s.%d", topic, 4),
+					Topic: subs[1].Topic,
 				},
-				Subscriptions: subs[4:5],
-				Total:         1,
+				Subscriptions: []notifiers.Subscription{subs[1], quotaSub},
+				Total:         2,
 			},
 			err: nil,
 		},
 		{
-			desc:  "test with contact and offset",
+			desc:  "test with topic without manage notifiers permission",
 			token: userEmail,
+			pageMeta: notifiers.PageMetadata{
+				Limit: 10,
+				Topic: subs[4].Topic,
+			},
+			page: notifiers.Page{},
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc:  "test with contact and offset",
+			token: otherUserEmail,
 			pageMeta: notifiers.PageMetadata{
 				Offset:  10,
 				Limit:   10,
@@ -228,6 +285,7 @@ func TestListSubscriptions(t *testing.T) {
 					Offset:  10,
 					Limit:   10,
 					Contact: otherUserEmail,
+					OwnerID: otherUser.ID,
 				},
 				Subscriptions: offsetSubs,
 				Total:         uint(total / 2),
@@ -245,7 +303,7 @@ func TestListSubscriptions(t *testing.T) {
 
 func TestRemoveSubscription(t *testing.T) {
 	svc := newService()
-	sub := notifiers.Subscription{Contact: userEmail, Topic: "valid.topic"}
+	sub := notifiers.Subscription{Contact: userEmail, Topic: "topic.valid"}
 	id, err := svc.CreateSubscription(context.Background(), userEmail, sub)
 	require.Nil(t, err, "Saving a Subscription must succeed")
 	sub.ID = id
@@ -257,6 +315,12 @@ func TestRemoveSubscription(t *testing.T) {
 		id    string
 		err   error
 	}{
+		{
+			desc:  "test without manage notifiers permission",
+			token: otherUserEmail,
+			id:    id,
+			err:   errors.ErrAuthorization,
+		},
 		{
 			desc:  "test success",
 			token: userEmail,
@@ -335,3 +399,45 @@ func TestConsume(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestSubscriptionsQuota(t *testing.T) {
+	svc := newServiceWithQuotas(map[string]*mainflux.QuotaRes{quotaUser.ID: {Subscriptions: 1}})
+
+	cases := []struct {
+		desc  string
+		token string
+		sub   notifiers.Subscription
+		err   error
+	}{
+		{
+			desc:  "create subscription within quota",
+			token: quotaUserEmail,
+			sub:   notifiers.Subscription{Contact: quotaUserEmail, Topic: "topic.1"},
+			err:   nil,
+		},
+		{
+			desc:  "create subscription over quota",
+			token: quotaUserEmail,
+			sub:   notifiers.Subscription{Contact: quotaUserEmail, Topic: "topic.2"},
+			err:   quota.ErrQuotaExceeded,
+		},
+		{
+			desc:  "create subscription without quota",
+			token: userEmail,
+			sub:   notifiers.Subscription{Contact: userEmail, Topic: "topic.2"},
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.CreateSubscription(context.Background(), tc.token, tc.sub)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	usage, err := svc.ViewUsage(context.Background(), quotaUserEmail)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, map[string]quota.Usage{quota.Subscriptions: {Used: 1, Limit: 1}}, usage, fmt.Sprintf("unexpected usage: %v", usage))
+
+	_, err = svc.ViewUsage(context.Background(), invalidUser)
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("view usage with invalid token: expected %s got %s\n", errors.ErrAuthentication, err))
+}
@@ -27,6 +27,7 @@ type PageMetadata struct {
 	Limit   int
 	Topic   string
 	Contact string
+	OwnerID string
 }
 
 // SubscriptionsRepository specifies a Subscription persistence API.
@@ -41,6 +42,9 @@ type SubscriptionsRepository interface {
 	// RetrieveAll retrieves all the subscriptions for the given page metadata.
 	RetrieveAll(ctx context.Context, pm PageMetadata) (Page, error)
 
+	// RetrieveCountByOwner retrieves the number of subscriptions of the owner.
+	RetrieveCountByOwner(ctx context.Context, ownerID string) (uint64, error)
+
 	// Remove removes the subscription for the given ID.
 	Remove(ctx context.Context, id string) error
 }
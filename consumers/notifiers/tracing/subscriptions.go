@@ -16,6 +16,7 @@ const (
 	saveOp        = "save_op"
 	retrieveOp    = "retrieve_op"
 	retrieveAllOp = "retrieve_all_op"
+	countOp       = "retrieve_count_by_owner_op"
 	removeOp      = "remove_op"
 )
 
@@ -59,6 +60,14 @@ func (urm subRepositoryMiddleware) RetrieveAll(ctx context.Context, pm notifiers
 	return urm.repo.RetrieveAll(ctx, pm)
 }
 
+func (urm subRepositoryMiddleware) RetrieveCountByOwner(ctx context.Context, ownerID string) (uint64, error) {
+	span := createSpan(ctx, urm.tracer, countOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.RetrieveCountByOwner(ctx, ownerID)
+}
+
 func (urm subRepositoryMiddleware) Remove(ctx context.Context, id string) error {
 	span := createSpan(ctx, urm.tracer, removeOp)
 	defer span.Finish()
@@ -10,12 +10,20 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/things"
 )
 
 // Service specifies coap service API.
 type Service interface {
 	// Publish Messssage
 	Publish(ctx context.Context, token string, msg messaging.Message) error
+
+	// PublishBatch publishes the messages, possibly to different channels,
+	// on behalf of the thing identified by the token. The returned errors
+	// correspond to the messages, nil for the published ones.
+	PublishBatch(ctx context.Context, token string, msgs []messaging.Message) []error
 }
 
 var _ Service = (*adapterService)(nil)
@@ -23,26 +31,91 @@ var _ Service = (*adapterService)(nil)
 type adapterService struct {
 	publisher messaging.Publisher
 	things    mainflux.ThingsServiceClient
+	limiter   ratelimit.Limiter
+	validator schema.ChannelValidator
 }
 
 // New instantiates the HTTP adapter implementation.
-func New(publisher messaging.Publisher, things mainflux.ThingsServiceClient) Service {
+func New(publisher messaging.Publisher, things mainflux.ThingsServiceClient, limiter ratelimit.Limiter, validator schema.ChannelValidator) Service {
 	return &adapterService{
 		publisher: publisher,
 		things:    things,
+		limiter:   limiter,
+		validator: validator,
 	}
 }
 
 func (as *adapterService) Publish(ctx context.Context, key string, msg messaging.Message) error {
-	cr := &mainflux.ConnByKeyReq{
-		Key: key,
+	if err := as.limiter.CheckPayload(len(msg.Payload)); err != nil {
+		return err
 	}
-	conn, err := as.things.GetConnByKey(ctx, cr)
+
+	publisher, err := as.authorize(ctx, key, msg.Channel)
 	if err != nil {
 		return err
 	}
-	msg.Publisher = conn.ChannelID
-	msg.Publisher = conn.ThingID
+	msg.Publisher = publisher
+
+	return as.publish(ctx, msg)
+}
+
+func (as *adapterService) PublishBatch(ctx context.Context, key string, msgs []messaging.Message) []error {
+	type access struct {
+		publisher string
+		err       error
+	}
+	// Batches usually carry many messages of the same channel, so each
+	// channel is authorized only once.
+	channels := make(map[string]access)
+
+	errs := make([]error, len(msgs))
+	for i, msg := range msgs {
+		if err := as.limiter.CheckPayload(len(msg.Payload)); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		acc, ok := channels[msg.Channel]
+		if !ok {
+			acc.publisher, acc.err = as.authorize(ctx, key, msg.Channel)
+			channels[msg.Channel] = acc
+		}
+		if acc.err != nil {
+			errs[i] = acc.err
+			continue
+		}
+		msg.Publisher = acc.publisher
+
+		errs[i] = as.publish(ctx, msg)
+	}
+
+	return errs
+}
+
+// authorize returns the ID of the thing identified by the key, if it is
+// allowed to publish to the channel.
+func (as *adapterService) authorize(ctx context.Context, key, chanID string) (string, error) {
+	ar := &mainflux.AccessByKeyReq{
+		Token:  key,
+		ChanID: chanID,
+		Action: things.PublishAction,
+	}
+	thid, err := as.things.CanAccessByKey(ctx, ar)
+	if err != nil {
+		return "", err
+	}
+
+	return thid.GetValue(), nil
+}
+
+func (as *adapterService) publish(ctx context.Context, msg messaging.Message) error {
+	if err := as.limiter.Allow(msg.Publisher); err != nil {
+		return err
+	}
+
+	if err := as.validator.Validate(ctx, msg.Channel, msg.Payload); err != nil {
+		return err
+	}
 
-	return as.publisher.Publish(conn.ChannelID, msg)
+	return as.publisher.Publish(msg.Channel, msg)
 }
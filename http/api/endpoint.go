@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/MainfluxLabs/mainflux/http"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 )
 
 func sendMessageEndpoint(svc http.Service) endpoint.Endpoint {
@@ -22,3 +23,29 @@ func sendMessageEndpoint(svc http.Service) endpoint.Endpoint {
 		return nil, err
 	}
 }
+
+func publishBatchEndpoint(svc http.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(publishBatchReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		// Only the messages decoded without errors are published.
+		errs := make([]error, len(req.msgs))
+		var msgs []messaging.Message
+		var idxs []int
+		for i, msg := range req.msgs {
+			if errs[i] = req.errs[i]; errs[i] == nil {
+				msgs = append(msgs, msg)
+				idxs = append(idxs, i)
+			}
+		}
+		for i, err := range svc.PublishBatch(ctx, req.token, msgs) {
+			errs[idxs[i]] = err
+		}
+
+		return publishBatchRes{errs: errs}, nil
+	}
+}
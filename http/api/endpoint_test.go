@@ -4,6 +4,9 @@
 package api_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,6 +20,8 @@ import (
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,13 +29,17 @@ import (
 const ServiceErrToken = "unavailable"
 
 func newService(tc mainflux.ThingsServiceClient) adapter.Service {
+	return newServiceWithLimits(tc, ratelimit.Config{})
+}
+
+func newServiceWithLimits(tc mainflux.ThingsServiceClient, limits ratelimit.Config) adapter.Service {
 	pub := mocks.NewPublisher()
-	return adapter.New(pub, tc)
+	return adapter.New(pub, tc, ratelimit.New(limits), schema.NewChannelValidator(tc))
 }
 
 func newHTTPServer(svc adapter.Service) *httptest.Server {
 	logger := logger.NewMock()
-	mux := api.MakeHandler(svc, mocktracer.New(), logger)
+	mux := api.MakeHandler(svc, api.BatchLimits{}, mocktracer.New(), logger)
 	return httptest.NewServer(mux)
 }
 
@@ -42,6 +51,7 @@ type testRequest struct {
 	token       string
 	body        io.Reader
 	basicAuth   bool
+	encoding    string
 }
 
 func (tr testRequest) make() (*http.Response, error) {
@@ -59,6 +69,9 @@ func (tr testRequest) make() (*http.Response, error) {
 	if tr.contentType != "" {
 		req.Header.Set("Content-Type", tr.contentType)
 	}
+	if tr.encoding != "" {
+		req.Header.Set("Content-Encoding", tr.encoding)
+	}
 	return tr.client.Do(req)
 }
 
@@ -174,3 +187,281 @@ func TestPublish(t *testing.T) {
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
 	}
 }
+
+func TestPublishLimits(t *testing.T) {
+	chanID := "1"
+	ctJSON := "application/json"
+	thingKey := "thing_key"
+	msg := `{"field1":"val1"}`
+	largeMsg := fmt.Sprintf(`{"field1":"%s"}`, strings.Repeat("a", 64))
+	thingsClient := mocks.NewThingsServiceClient(map[string]string{thingKey: chanID}, nil)
+	svc := newServiceWithLimits(thingsClient, ratelimit.Config{Rate: 0.001, Burst: 1, MaxPayloadSize: 32})
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		msg    string
+		status int
+	}{
+		{
+			desc:   "publish message with payload exceeding the maximum size",
+			msg:    largeMsg,
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:   "publish message within the rate limit",
+			msg:    msg,
+			status: http.StatusAccepted,
+		},
+		{
+			desc:   "publish message exceeding the rate limit",
+			msg:    msg,
+			status: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+			contentType: ctJSON,
+			token:       thingKey,
+			body:        strings.NewReader(tc.msg),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestPublishSchema(t *testing.T) {
+	chanID := "1"
+	ctJSON := "application/json"
+	thingKey := "thing_key"
+	schemas := map[string]schema.Schema{
+		chanID: {
+			JSON: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{"field1": map[string]interface{}{"type": "string"}},
+			},
+		},
+	}
+	thingsClient := mocks.NewThingsServiceClientWithSchemas(map[string]string{thingKey: chanID}, nil, schemas)
+	svc := newService(thingsClient)
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		msg    string
+		status int
+	}{
+		{
+			desc:   "publish message conforming to the channel schema",
+			msg:    `{"field1":"val1"}`,
+			status: http.StatusAccepted,
+		},
+		{
+			desc:   "publish message not conforming to the channel schema",
+			msg:    `{"field1":1}`,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+			contentType: ctJSON,
+			token:       thingKey,
+			body:        strings.NewReader(tc.msg),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestPublishBatch(t *testing.T) {
+	chanID := "1"
+	ctJSON := "application/json"
+	ctNDJSON := "application/x-ndjson"
+	thingKey := "thing_key"
+	thingsClient := mocks.NewThingsServiceClient(map[string]string{thingKey: chanID}, nil)
+	svc := newService(thingsClient)
+	limits := api.BatchLimits{MaxMessages: 3, MaxBytes: 300}
+	ts := httptest.NewServer(api.MakeHandler(svc, limits, mocktracer.New(), logger.NewMock()))
+	defer ts.Close()
+
+	senml := fmt.Sprintf(`{"channel":"%s","subtopic":"temp","content-type":"application/senml+json","payload":[{"n":"current","t":-1,"v":1.6}]}`, chanID)
+	cbor := fmt.Sprintf(`{"channel":"%s","content-type":"application/senml+cbor","payload":"gaNhbmdjdXJyZW50YXQgYXb7P/mZmZmZmZo="}`, chanID)
+	other := `{"channel":"2","payload":{"field1":"val1"}}`
+	noChannel := `{"payload":{"field1":"val1"}}`
+	badSubtopic := fmt.Sprintf(`{"channel":"%s","subtopic":"a*b","payload":{"field1":"val1"}}`, chanID)
+	badCT := fmt.Sprintf(`{"channel":"%s","content-type":"text/plain","payload":"text"}`, chanID)
+
+	var gzipped bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	fmt.Fprintf(gw, "[%s,%s]", senml, cbor)
+	gw.Close()
+
+	cases := []struct {
+		desc        string
+		body        io.Reader
+		contentType string
+		encoding    string
+		key         string
+		status      int
+		results     []int
+	}{
+		{
+			desc:        "publish batch",
+			body:        strings.NewReader(fmt.Sprintf("[%s,%s]", senml, cbor)),
+			contentType: ctJSON,
+			key:         thingKey,
+			status:      http.StatusAccepted,
+			results:     []int{http.StatusAccepted, http.StatusAccepted},
+		},
+		{
+			desc:        "publish batch with invalid entries",
+			body:        strings.NewReader(fmt.Sprintf("[%s,%s,%s]", other, noChannel, badSubtopic)),
+			contentType: ctJSON,
+			key:         thingKey,
+			status:      http.StatusMultiStatus,
+			results:     []int{http.StatusForbidden, http.StatusBadRequest, http.StatusBadRequest},
+		},
+		{
+			desc:        "publish batch with entry of unsupported content type",
+			body:        strings.NewReader(fmt.Sprintf("[%s,%s]", senml, badCT)),
+			contentType: ctJSON,
+			key:         thingKey,
+			status:      http.StatusMultiStatus,
+			results:     []int{http.StatusAccepted, http.StatusUnsupportedMediaType},
+		},
+		{
+			desc:        "publish NDJSON batch",
+			body:        strings.NewReader(fmt.Sprintf("%s\n%s\n", senml, cbor)),
+			contentType: ctNDJSON,
+			key:         thingKey,
+			status:      http.StatusAccepted,
+			results:     []int{http.StatusAccepted, http.StatusAccepted},
+		},
+		{
+			desc:        "publish NDJSON batch with malformed entry",
+			body:        strings.NewReader(fmt.Sprintf("%s\n{\"channel\":\n", senml)),
+			contentType: ctNDJSON,
+			key:         thingKey,
+			status:      http.StatusMultiStatus,
+			results:     []int{http.StatusAccepted, http.StatusBadRequest},
+		},
+		{
+			desc:        "publish gzip compressed batch",
+			body:        bytes.NewReader(gzipped.Bytes()),
+			contentType: ctJSON,
+			encoding:    "gzip",
+			key:         thingKey,
+			status:      http.StatusAccepted,
+			results:     []int{http.StatusAccepted, http.StatusAccepted},
+		},
+		{
+			desc:        "publish batch with invalid thing key",
+			body:        strings.NewReader(fmt.Sprintf("[%s]", senml)),
+			contentType: ctJSON,
+			key:         "invalid",
+			status:      http.StatusMultiStatus,
+			results:     []int{http.StatusUnauthorized},
+		},
+		{
+			desc:        "publish batch without thing key",
+			body:        strings.NewReader(fmt.Sprintf("[%s]", senml)),
+			contentType: ctJSON,
+			key:         "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "publish malformed batch",
+			body:        strings.NewReader(fmt.Sprintf("[%s", senml)),
+			contentType: ctJSON,
+			key:         thingKey,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "publish empty batch",
+			body:        strings.NewReader("[]"),
+			contentType: ctJSON,
+			key:         thingKey,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "publish batch with invalid gzip body",
+			body:        strings.NewReader(fmt.Sprintf("[%s]", senml)),
+			contentType: ctJSON,
+			encoding:    "gzip",
+			key:         thingKey,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "publish batch exceeding the maximum number of messages",
+			body:        strings.NewReader(fmt.Sprintf("[%s,%s,%s,%s]", cbor, cbor, cbor, cbor)),
+			contentType: ctJSON,
+			key:         thingKey,
+			status:      http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:        "publish batch exceeding the maximum size",
+			body:        strings.NewReader(fmt.Sprintf("[%s,%s,%s]", senml, senml, senml)),
+			contentType: ctJSON,
+			key:         thingKey,
+			status:      http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:        "publish batch with unsupported content type",
+			body:        strings.NewReader(fmt.Sprintf("[%s]", senml)),
+			contentType: "application/senml+json",
+			key:         thingKey,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "publish batch with unsupported content encoding",
+			body:        strings.NewReader(fmt.Sprintf("[%s]", senml)),
+			contentType: ctJSON,
+			encoding:    "br",
+			key:         thingKey,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/messages", ts.URL),
+			contentType: tc.contentType,
+			encoding:    tc.encoding,
+			token:       tc.key,
+			body:        tc.body,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.results == nil {
+			continue
+		}
+
+		var body struct {
+			Results []struct {
+				Status int `json:"status"`
+			} `json:"results"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var results []int
+		for _, r := range body.Results {
+			results = append(results, r.Status)
+		}
+		assert.Equal(t, tc.results, results, fmt.Sprintf("%s: expected results %v got %v", tc.desc, tc.results, results))
+	}
+}
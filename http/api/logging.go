@@ -43,3 +43,24 @@ func (lm *loggingMiddleware) Publish(ctx context.Context, token string, msg mess
 
 	return lm.svc.Publish(ctx, token, msg)
 }
+
+func (lm *loggingMiddleware) PublishBatch(ctx context.Context, token string, msgs []messaging.Message) (errs []error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method publish_batch of %d messages took %s to complete", len(msgs), time.Since(begin))
+		failed := 0
+		var lastErr error
+		for _, err := range errs {
+			if err != nil {
+				failed++
+				lastErr = err
+			}
+		}
+		if failed > 0 {
+			lm.logger.Warn(fmt.Sprintf("%s with %d errors, last one: %s.", message, failed, lastErr))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PublishBatch(ctx, token, msgs)
+}
@@ -11,23 +11,28 @@ import (
 
 	"github.com/go-kit/kit/metrics"
 	"github.com/MainfluxLabs/mainflux/http"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 )
 
 var _ http.Service = (*metricsMiddleware)(nil)
 
 type metricsMiddleware struct {
-	counter metrics.Counter
-	latency metrics.Histogram
-	svc     http.Service
+	counter    metrics.Counter
+	latency    metrics.Histogram
+	violations metrics.Counter
+	svc        http.Service
 }
 
-// MetricsMiddleware instruments adapter by tracking request count and latency.
-func MetricsMiddleware(svc http.Service, counter metrics.Counter, latency metrics.Histogram) http.Service {
+// MetricsMiddleware instruments adapter by tracking request count and latency,
+// and the number of payload schema violations per channel.
+func MetricsMiddleware(svc http.Service, counter metrics.Counter, latency metrics.Histogram, violations metrics.Counter) http.Service {
 	return &metricsMiddleware{
-		counter: counter,
-		latency: latency,
-		svc:     svc,
+		counter:    counter,
+		latency:    latency,
+		violations: violations,
+		svc:        svc,
 	}
 }
 
@@ -37,5 +42,26 @@ func (mm *metricsMiddleware) Publish(ctx context.Context, token string, msg mess
 		mm.latency.With("method", "publish").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.Publish(ctx, token, msg)
+	err := mm.svc.Publish(ctx, token, msg)
+	if errors.Contains(err, schema.ErrInvalidPayload) {
+		mm.violations.With("channel", msg.Channel).Add(1)
+	}
+
+	return err
+}
+
+func (mm *metricsMiddleware) PublishBatch(ctx context.Context, token string, msgs []messaging.Message) []error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "publish_batch").Add(1)
+		mm.latency.With("method", "publish_batch").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	errs := mm.svc.PublishBatch(ctx, token, msgs)
+	for i, err := range errs {
+		if errors.Contains(err, schema.ErrInvalidPayload) {
+			mm.violations.With("channel", msgs[i].Channel).Add(1)
+		}
+	}
+
+	return errs
 }
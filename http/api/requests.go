@@ -20,3 +20,21 @@ func (req publishReq) validate() error {
 
 	return nil
 }
+
+type publishBatchReq struct {
+	msgs  []messaging.Message
+	errs  []error
+	token string
+}
+
+func (req publishBatchReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if len(req.msgs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+type publishBatchRes struct {
+	errs []error
+}
+
+type messageRes struct {
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type messagesRes struct {
+	Results []messageRes `json:"results"`
+}
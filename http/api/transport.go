@@ -4,8 +4,11 @@
 package api
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -19,6 +22,8 @@ import (
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	kitot "github.com/go-kit/kit/tracing/opentracing"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
@@ -33,16 +38,39 @@ const (
 	ctSenmlJSON = "application/senml+json"
 	ctSenmlCBOR = "application/senml+cbor"
 	ctJSON      = "application/json"
+	ctNDJSON    = "application/x-ndjson"
+	ceGzip      = "gzip"
 )
 
 var (
 	errMalformedSubtopic = errors.New("malformed subtopic")
+	errBatchTooLarge     = errors.New("batch exceeds the maximum size")
 )
 
+// BatchLimits bounds the batch publish requests.
+type BatchLimits struct {
+	// MaxMessages is the maximum number of messages in a batch, 0 for unlimited.
+	MaxMessages int
+
+	// MaxBytes is the maximum size of the decompressed request body in bytes,
+	// 0 for unlimited.
+	MaxBytes int64
+}
+
+// batchEntry is a single message of the batch publish request. Payloads of
+// the JSON content types are embedded as JSON values, while CBOR payloads
+// are base64 encoded strings.
+type batchEntry struct {
+	Channel     string          `json:"channel"`
+	Subtopic    string          `json:"subtopic,omitempty"`
+	ContentType string          `json:"content-type,omitempty"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
 var channelPartRegExp = regexp.MustCompile(`^/channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
 
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(svc adapter.Service, tracer opentracing.Tracer, logger logger.Logger) http.Handler {
+func MakeHandler(svc adapter.Service, limits BatchLimits, tracer opentracing.Tracer, logger logger.Logger) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
 	}
@@ -62,6 +90,13 @@ func MakeHandler(svc adapter.Service, tracer opentracing.Tracer, logger logger.L
 		opts...,
 	))
 
+	r.Post("/messages", kithttp.NewServer(
+		kitot.TraceServer(tracer, "publish_batch")(publishBatchEndpoint(svc)),
+		decodeBatchRequest(limits),
+		encodeBatchResponse,
+		opts...,
+	))
+
 	r.GetFunc("/health", mainflux.Health("http"))
 	r.Handle("/metrics", promhttp.Handler())
 
@@ -113,15 +148,6 @@ func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
-	var token string
-	_, pass, ok := r.BasicAuth()
-	switch {
-	case ok:
-		token = pass
-	case !ok:
-		token = apiutil.ExtractThingKey(r)
-	}
-
 	payload, err := ioutil.ReadAll(r.Body)
 	if err != nil {
 		return nil, apiutil.ErrMalformedEntity
@@ -136,52 +162,214 @@ func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 			Payload:  payload,
 			Created:  time.Now().UnixNano(),
 		},
-		token: token,
+		token: extractThingKey(r),
 	}
 
 	return req, nil
 }
 
+func decodeBatchRequest(limits BatchLimits) kithttp.DecodeRequestFunc {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		ct := r.Header.Get("Content-Type")
+		if ct != ctJSON && ct != ctNDJSON {
+			return nil, apiutil.ErrUnsupportedContentType
+		}
+		defer r.Body.Close()
+
+		var body io.Reader = r.Body
+		switch r.Header.Get("Content-Encoding") {
+		case "":
+		case ceGzip:
+			gr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+			}
+			defer gr.Close()
+			body = gr
+		default:
+			return nil, apiutil.ErrUnsupportedContentType
+		}
+
+		if limits.MaxBytes > 0 {
+			body = io.LimitReader(body, limits.MaxBytes+1)
+		}
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+		}
+		if limits.MaxBytes > 0 && int64(len(data)) > limits.MaxBytes {
+			return nil, errBatchTooLarge
+		}
+
+		entries, errs, err := decodeBatch(ct, data)
+		if err != nil {
+			return nil, err
+		}
+		if limits.MaxMessages > 0 && len(entries) > limits.MaxMessages {
+			return nil, errBatchTooLarge
+		}
+
+		req := publishBatchReq{
+			msgs:  make([]messaging.Message, len(entries)),
+			errs:  errs,
+			token: extractThingKey(r),
+		}
+		for i, e := range entries {
+			if errs[i] != nil {
+				continue
+			}
+			req.msgs[i], req.errs[i] = e.message()
+		}
+
+		return req, nil
+	}
+}
+
+// decodeBatch decodes the JSON array or the newline delimited JSON entries.
+// Malformed NDJSON lines are reported as entry errors, since the rest of the
+// batch can still be published.
+func decodeBatch(ct string, data []byte) ([]batchEntry, []error, error) {
+	if ct == ctJSON {
+		var entries []batchEntry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+		}
+		return entries, make([]error, len(entries)), nil
+	}
+
+	var entries []batchEntry
+	var errs []error
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var e batchEntry
+		err := json.Unmarshal(line, &e)
+		if err != nil {
+			err = apiutil.ErrMalformedEntity
+		}
+		entries = append(entries, e)
+		errs = append(errs, err)
+	}
+
+	return entries, errs, nil
+}
+
+func (e batchEntry) message() (messaging.Message, error) {
+	if e.Channel == "" {
+		return messaging.Message{}, apiutil.ErrMissingID
+	}
+
+	subtopic, err := parseSubtopic(e.Subtopic)
+	if err != nil {
+		return messaging.Message{}, err
+	}
+
+	var payload []byte
+	switch e.ContentType {
+	case "", ctSenmlJSON, ctJSON:
+		payload = e.Payload
+	case ctSenmlCBOR:
+		if err := json.Unmarshal(e.Payload, &payload); err != nil {
+			return messaging.Message{}, apiutil.ErrMalformedEntity
+		}
+	default:
+		return messaging.Message{}, apiutil.ErrUnsupportedContentType
+	}
+
+	return messaging.Message{
+		Protocol: protocol,
+		Channel:  e.Channel,
+		Subtopic: subtopic,
+		Payload:  payload,
+		Created:  time.Now().UnixNano(),
+	}, nil
+}
+
+func extractThingKey(r *http.Request) string {
+	if _, pass, ok := r.BasicAuth(); ok {
+		return pass
+	}
+
+	return apiutil.ExtractThingKey(r)
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.WriteHeader(http.StatusAccepted)
 	return nil
 }
 
+// encodeBatchResponse responds with the status of each message, and with
+// 207 unless all the messages are accepted.
+func encodeBatchResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(publishBatchRes)
+
+	code := http.StatusAccepted
+	body := messagesRes{Results: make([]messageRes, len(res.errs))}
+	for i, err := range res.errs {
+		if err == nil {
+			body.Results[i] = messageRes{Status: http.StatusAccepted}
+			continue
+		}
+
+		code = http.StatusMultiStatus
+		msg := err.Error()
+		if e, ok := err.(errors.Error); ok {
+			msg = e.Msg()
+		}
+		body.Results[i] = messageRes{Status: errorStatus(err), Error: msg}
+	}
+
+	w.Header().Set("Content-Type", ctJSON)
+	w.WriteHeader(code)
+
+	return json.NewEncoder(w).Encode(body)
+}
+
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	w.WriteHeader(errorStatus(err))
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", ctJSON)
+		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
+
+func errorStatus(err error) int {
 	switch {
 	case errors.Contains(err, errors.ErrAuthentication),
 		err == apiutil.ErrBearerToken:
-		w.WriteHeader(http.StatusUnauthorized)
+		return http.StatusUnauthorized
 	case errors.Contains(err, errors.ErrAuthorization):
-		w.WriteHeader(http.StatusForbidden)
+		return http.StatusForbidden
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
-		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return http.StatusUnsupportedMediaType
 	case errors.Contains(err, errMalformedSubtopic),
-		errors.Contains(err, apiutil.ErrMalformedEntity):
-		w.WriteHeader(http.StatusBadRequest)
-
-	default:
-		switch e, ok := status.FromError(err); {
-		case ok:
-			switch e.Code() {
-			case codes.Unauthenticated:
-				w.WriteHeader(http.StatusUnauthorized)
-			case codes.PermissionDenied:
-				w.WriteHeader(http.StatusForbidden)
-			case codes.Internal:
-				w.WriteHeader(http.StatusInternalServerError)
-			default:
-				w.WriteHeader(http.StatusInternalServerError)
-			}
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		errors.Contains(err, apiutil.ErrMalformedEntity),
+		errors.Contains(err, apiutil.ErrMissingID),
+		errors.Contains(err, apiutil.ErrEmptyList),
+		errors.Contains(err, schema.ErrInvalidPayload):
+		return http.StatusBadRequest
+	case errors.Contains(err, ratelimit.ErrPayloadTooLarge),
+		errors.Contains(err, errBatchTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Contains(err, ratelimit.ErrRateLimited):
+		return http.StatusTooManyRequests
 	}
 
-	if errorVal, ok := err.(errors.Error); ok {
-		w.Header().Set("Content-Type", ctJSON)
-		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
+	switch e, ok := status.FromError(err); {
+	case ok:
+		switch e.Code() {
+		case codes.Unauthenticated:
+			return http.StatusUnauthorized
+		case codes.PermissionDenied:
+			return http.StatusForbidden
+		default:
+			return http.StatusInternalServerError
 		}
+	default:
+		return http.StatusInternalServerError
 	}
 }
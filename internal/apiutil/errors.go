@@ -21,6 +21,9 @@ var (
 	// ErrMissingRole indicates missing role.
 	ErrMissingRole = errors.New("missing role")
 
+	// ErrMissingTag indicates missing tag.
+	ErrMissingTag = errors.New("missing tag")
+
 	// ErrMissingObject indicates missing object.
 	ErrMissingObject = errors.New("missing object")
 
@@ -4,8 +4,12 @@
 package apiutil
 
 import (
+	"context"
+	"net"
 	"net/http"
 	"strings"
+
+	"github.com/MainfluxLabs/mainflux/auth"
 )
 
 // BearerPrefix represents the token prefix for Bearer authentication scheme.
@@ -35,3 +39,85 @@ func ExtractThingKey(r *http.Request) string {
 
 	return strings.TrimPrefix(token, ThingPrefix)
 }
+
+// trustedProxies are the reverse proxies whose forwarding headers are trusted.
+var trustedProxies []*net.IPNet
+
+// SetTrustedProxies sets the reverse proxies, given as the comma-separated
+// IP addresses and CIDRs, whose X-Forwarded-For and X-Real-IP headers are
+// trusted to carry the client IP address.
+func SetTrustedProxies(proxies string) error {
+	var nets []*net.IPNet
+	for _, p := range strings.Split(proxies, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil && ip.To4() != nil {
+				p += "/32"
+			} else {
+				p += "/128"
+			}
+		}
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return err
+		}
+		nets = append(nets, n)
+	}
+
+	trustedProxies = nets
+	return nil
+}
+
+func isTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExtractClientIP returns the IP address of the client that issued the request.
+// The X-Forwarded-For and X-Real-IP headers are read only if the remote peer
+// is a trusted proxy, in which case the client is the last address in the
+// forwarding chain that isn't a trusted proxy itself. Otherwise, the client
+// is the remote peer.
+func ExtractClientIP(r *http.Request) string {
+	peer, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		peer = r.RemoteAddr
+	}
+	if !isTrustedProxy(peer) {
+		return peer
+	}
+
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		hops := strings.Split(fwd, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if i == 0 || !isTrustedProxy(hop) {
+				return hop
+			}
+		}
+	}
+
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return strings.TrimSpace(ip)
+	}
+
+	return peer
+}
+
+// ClientIPToContext stores the IP address of the client into the request context,
+// so it can be forwarded to the auth service for API key IP restrictions.
+func ClientIPToContext(ctx context.Context, r *http.Request) context.Context {
+	return auth.WithClientIP(ctx, ExtractClientIP(r))
+}
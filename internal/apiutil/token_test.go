@@ -0,0 +1,93 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package apiutil_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractClientIP(t *testing.T) {
+	err := apiutil.SetTrustedProxies("10.0.0.1, 172.16.0.0/12")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	defer apiutil.SetTrustedProxies("")
+
+	cases := []struct {
+		desc    string
+		remote  string
+		headers map[string]string
+		ip      string
+	}{
+		{
+			desc:   "extract IP of remote peer",
+			remote: "192.168.0.5:4321",
+			ip:     "192.168.0.5",
+		},
+		{
+			desc:    "extract IP of untrusted remote peer sending forwarding headers",
+			remote:  "192.168.0.5:4321",
+			headers: map[string]string{"X-Forwarded-For": "1.2.3.4", "X-Real-IP": "1.2.3.4"},
+			ip:      "192.168.0.5",
+		},
+		{
+			desc:    "extract IP forwarded by trusted proxy",
+			remote:  "10.0.0.1:4321",
+			headers: map[string]string{"X-Forwarded-For": "1.2.3.4"},
+			ip:      "1.2.3.4",
+		},
+		{
+			desc:    "extract IP forwarded through chain of trusted proxies",
+			remote:  "10.0.0.1:4321",
+			headers: map[string]string{"X-Forwarded-For": "6.6.6.6, 1.2.3.4, 172.16.0.9"},
+			ip:      "1.2.3.4",
+		},
+		{
+			desc:    "extract real IP set by trusted proxy",
+			remote:  "172.20.0.3:4321",
+			headers: map[string]string{"X-Real-IP": "1.2.3.4"},
+			ip:      "1.2.3.4",
+		},
+		{
+			desc:   "extract IP of trusted proxy without forwarding headers",
+			remote: "10.0.0.1:4321",
+			ip:     "10.0.0.1",
+		},
+	}
+
+	for _, tc := range cases {
+		req, err := http.NewRequest(http.MethodGet, "/", nil)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		req.RemoteAddr = tc.remote
+		for k, v := range tc.headers {
+			req.Header.Set(k, v)
+		}
+
+		ip := apiutil.ExtractClientIP(req)
+		assert.Equal(t, tc.ip, ip, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.ip, ip))
+	}
+}
+
+func TestSetTrustedProxies(t *testing.T) {
+	defer apiutil.SetTrustedProxies("")
+
+	cases := []struct {
+		desc    string
+		proxies string
+		valid   bool
+	}{
+		{desc: "set empty trusted proxies", proxies: "", valid: true},
+		{desc: "set trusted proxy addresses and networks", proxies: "10.0.0.1,fd00::1,172.16.0.0/12", valid: true},
+		{desc: "set invalid trusted proxy", proxies: "10.0.0.1,proxy", valid: false},
+	}
+
+	for _, tc := range cases {
+		err := apiutil.SetTrustedProxies(tc.proxies)
+		assert.Equal(t, tc.valid, err == nil, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/senml"
 )
 
 const protocol = "lora"
@@ -25,6 +26,13 @@ var (
 
 	// ErrNotConnected indicates a non-existent route map for a connection.
 	ErrNotConnected = errors.New("route map not found for this connection")
+
+	// ErrMalformedCommand indicates malformed downlink command.
+	ErrMalformedCommand = errors.New("malformed downlink command")
+
+	// ErrDownlinkNotSupported indicates that the network server of the
+	// application doesn't support downlinks.
+	ErrDownlinkNotSupported = errors.New("downlinks not supported for this network server")
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -56,24 +64,41 @@ type Service interface {
 
 	// Publish forwards messages from the LoRa MQTT broker to Mainflux Message Broker
 	Publish(ctx context.Context, msg Message) error
+
+	// SendCommand sends the downlink command received on the channel to the
+	// LoRa device of the thing.
+	SendCommand(ctx context.Context, chanID string, cmd Command) error
+
+	// HandleAck reports the downlink acknowledgement received from the LoRa
+	// MQTT broker on the channel of the device application.
+	HandleAck(ctx context.Context, ack Ack) error
 }
 
 var _ Service = (*adapterService)(nil)
 
 type adapterService struct {
-	publisher  messaging.Publisher
-	thingsRM   RouteMapRepository
-	channelsRM RouteMapRepository
-	connectRM  RouteMapRepository
+	publisher     messaging.Publisher
+	downlinks     DownlinkPublisher
+	thingsRM      RouteMapRepository
+	channelsRM    RouteMapRepository
+	connectRM     RouteMapRepository
+	ackSubtopic   string
+	radioSubtopic string
 }
 
-// New instantiates the LoRa adapter implementation.
-func New(publisher messaging.Publisher, thingsRM, channelsRM, connectRM RouteMapRepository) Service {
+// New instantiates the LoRa adapter implementation. Downlink acknowledgements
+// are published on the ackSubtopic of the channels. If radioSubtopic isn't
+// empty, the radio metadata and the device status of the uplinks are
+// published on it as SenML.
+func New(publisher messaging.Publisher, downlinks DownlinkPublisher, thingsRM, channelsRM, connectRM RouteMapRepository, ackSubtopic, radioSubtopic string) Service {
 	return &adapterService{
-		publisher:  publisher,
-		thingsRM:   thingsRM,
-		channelsRM: channelsRM,
-		connectRM:  connectRM,
+		publisher:     publisher,
+		downlinks:     downlinks,
+		thingsRM:      thingsRM,
+		channelsRM:    channelsRM,
+		connectRM:     connectRM,
+		ackSubtopic:   ackSubtopic,
+		radioSubtopic: radioSubtopic,
 	}
 }
 
@@ -86,7 +111,12 @@ func (as *adapterService) Publish(ctx context.Context, m Message) error {
 	}
 
 	// Get route map of lora application
-	chanID, err := as.channelsRM.Get(ctx, m.ApplicationID)
+	appID, err := RouteID(m.LNS, m.ApplicationID)
+	if err != nil {
+		return err
+	}
+
+	chanID, err := as.channelsRM.Get(ctx, appID)
 	if err != nil {
 		return ErrNotFoundApp
 	}
@@ -122,6 +152,107 @@ func (as *adapterService) Publish(ctx context.Context, m Message) error {
 		Created:   time.Now().UnixNano(),
 	}
 
+	if err := as.publisher.Publish(msg.Channel, msg); err != nil {
+		return err
+	}
+
+	return as.publishRadio(m, msg)
+}
+
+// publishRadio publishes the radio metadata and the device status of the
+// message on the radio subtopic of the channel, if enabled.
+func (as *adapterService) publishRadio(m Message, msg messaging.Message) error {
+	if as.radioSubtopic == "" {
+		return nil
+	}
+
+	pack := radioPack(m, float64(msg.Created)/1e9)
+	payload, err := senml.Encode(pack, senml.JSON)
+	if err != nil {
+		return err
+	}
+
+	msg.Subtopic = as.radioSubtopic
+	msg.Payload = payload
+
+	return as.publisher.Publish(msg.Channel, msg)
+}
+
+// SendCommand publishes the command to the LoRa MQTT broker as a downlink
+// for the device of the thing.
+func (as *adapterService) SendCommand(ctx context.Context, chanID string, cmd Command) error {
+	if cmd.ThingID == "" || cmd.FPort < minFPort || cmd.FPort > maxFPort {
+		return ErrMalformedCommand
+	}
+
+	if _, err := base64.StdEncoding.DecodeString(cmd.Data); err != nil {
+		return ErrMalformedCommand
+	}
+
+	routeID, err := as.channelsRM.Get(ctx, chanID)
+	if err != nil {
+		return ErrNotFoundApp
+	}
+
+	lns, appID := parseRouteID(routeID)
+	if lns != ChirpStack {
+		return ErrDownlinkNotSupported
+	}
+
+	devEUI, err := as.thingsRM.Get(ctx, cmd.ThingID)
+	if err != nil {
+		return ErrNotFoundDev
+	}
+
+	c := fmt.Sprintf("%s:%s", chanID, cmd.ThingID)
+	if _, err := as.connectRM.Get(ctx, c); err != nil {
+		return ErrNotConnected
+	}
+
+	dl := Downlink{
+		Confirmed: cmd.Confirmed,
+		FPort:     cmd.FPort,
+		Data:      cmd.Data,
+	}
+
+	return as.downlinks.Publish(appID, devEUI, dl)
+}
+
+// HandleAck publishes the downlink acknowledgement on the channel.
+func (as *adapterService) HandleAck(ctx context.Context, ack Ack) error {
+	thingID, err := as.thingsRM.Get(ctx, ack.DevEUI)
+	if err != nil {
+		return ErrNotFoundDev
+	}
+
+	chanID, err := as.channelsRM.Get(ctx, ack.ApplicationID)
+	if err != nil {
+		return ErrNotFoundApp
+	}
+
+	ev := ackEvent{
+		ThingID: thingID,
+		Status:  nackStatus,
+		FCnt:    ack.FCnt,
+	}
+	if ack.Acknowledged {
+		ev.Status = ackStatus
+	}
+
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Publisher: thingID,
+		Protocol:  protocol,
+		Channel:   chanID,
+		Subtopic:  as.ackSubtopic,
+		Payload:   payload,
+		Created:   time.Now().UnixNano(),
+	}
+
 	return as.publisher.Publish(msg.Channel, msg)
 }
 
@@ -12,20 +12,25 @@ import (
 	"github.com/MainfluxLabs/mainflux/lora/mocks"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	pubmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/senml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 const (
-	thingID  = "thingID-1"
-	chanID   = "chanID-1"
-	devEUI   = "devEUI-1"
-	appID    = "appID-1"
-	thingID2 = "thingID-2"
-	chanID2  = "chanID-2"
-	devEUI2  = "devEUI-2"
-	appID2   = "appID-2"
-	msg      = `[{"bn":"msg-base-name","n":"temperature","v": 17},{"n":"humidity","v": 56}]`
+	thingID   = "thingID-1"
+	chanID    = "chanID-1"
+	devEUI    = "devEUI-1"
+	appID     = "appID-1"
+	thingID2  = "thingID-2"
+	chanID2   = "chanID-2"
+	devEUI2   = "devEUI-2"
+	appID2    = "appID-2"
+	ttnAppID  = "ttn-app-1"
+	ttnChanID = "chanID-3"
+
+	radioSubtopic = "lora.radio"
+	msg           = `[{"bn":"msg-base-name","n":"temperature","v": 17},{"n":"humidity","v": 56}]`
 )
 
 func newService() lora.Service {
@@ -34,7 +39,9 @@ func newService() lora.Service {
 	channelsRM := mocks.NewRouteMap()
 	connsRM := mocks.NewRouteMap()
 
-	return lora.New(pub, thingsRM, channelsRM, connsRM)
+	downlinks := mocks.NewDownlinkPublisher()
+
+	return lora.New(pub, downlinks, thingsRM, channelsRM, connsRM, "lora.downlink.ack", radioSubtopic)
 }
 
 func TestPublish(t *testing.T) {
@@ -55,6 +62,15 @@ func TestPublish(t *testing.T) {
 	err = svc.CreateThing(nil, thingID2, devEUI2)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
+	ttnRouteID, err := lora.RouteID(lora.TTN, ttnAppID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateChannel(nil, ttnChanID, ttnRouteID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.ConnectThing(nil, ttnChanID, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
 	msgBase64 := base64.StdEncoding.EncodeToString([]byte(msg))
 
 	cases := []struct {
@@ -71,6 +87,37 @@ func TestPublish(t *testing.T) {
 				Data:          msgBase64,
 			},
 		},
+		{
+			desc: "publish message with radio metadata",
+			err:  nil,
+			msg: lora.Message{
+				ApplicationID: appID,
+				DevEUI:        devEUI,
+				Data:          msgBase64,
+				RxInfo:        lora.RxInfo{{Name: "gw-1", Rssi: -57, LoRaSNR: 10}},
+				TxInfo:        lora.TxInfo{Frequency: 868100000},
+			},
+		},
+		{
+			desc: "publish The Things Stack message with existing route-map",
+			err:  nil,
+			msg: lora.Message{
+				LNS:           lora.TTN,
+				ApplicationID: ttnAppID,
+				DevEUI:        devEUI,
+				Data:          msgBase64,
+			},
+		},
+		{
+			desc: "publish The Things Stack message with ChirpStack application route-map",
+			err:  lora.ErrNotFoundApp,
+			msg: lora.Message{
+				LNS:           lora.TTN,
+				ApplicationID: appID,
+				DevEUI:        devEUI,
+				Data:          msgBase64,
+			},
+		},
 		{
 			desc: "publish message with existing route-map and invalid Data",
 			err:  lora.ErrMalformedMessage,
@@ -111,3 +158,219 @@ func TestPublish(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestSendCommand(t *testing.T) {
+	svc := newService()
+
+	err := svc.CreateChannel(nil, chanID, appID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateThing(nil, thingID, devEUI)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.ConnectThing(nil, chanID, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateThing(nil, thingID2, devEUI2)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	ttnRouteID, err := lora.RouteID(lora.TTN, ttnAppID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateChannel(nil, ttnChanID, ttnRouteID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.ConnectThing(nil, ttnChanID, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	data := base64.StdEncoding.EncodeToString([]byte{0x01, 0x02})
+
+	cases := []struct {
+		desc   string
+		err    error
+		chanID string
+		cmd    lora.Command
+	}{
+		{
+			desc:   "send command with existing route-map and valid data",
+			err:    nil,
+			chanID: chanID,
+			cmd:    lora.Command{ThingID: thingID, FPort: 10, Confirmed: true, Data: data},
+		},
+		{
+			desc:   "send command without thing ID",
+			err:    lora.ErrMalformedCommand,
+			chanID: chanID,
+			cmd:    lora.Command{FPort: 10, Data: data},
+		},
+		{
+			desc:   "send command with invalid fPort",
+			err:    lora.ErrMalformedCommand,
+			chanID: chanID,
+			cmd:    lora.Command{ThingID: thingID, FPort: 0, Data: data},
+		},
+		{
+			desc:   "send command with reserved fPort",
+			err:    lora.ErrMalformedCommand,
+			chanID: chanID,
+			cmd:    lora.Command{ThingID: thingID, FPort: 224, Data: data},
+		},
+		{
+			desc:   "send command with invalid data",
+			err:    lora.ErrMalformedCommand,
+			chanID: chanID,
+			cmd:    lora.Command{ThingID: thingID, FPort: 10, Data: "wrong"},
+		},
+		{
+			desc:   "send command with non existing channel route-map",
+			err:    lora.ErrNotFoundApp,
+			chanID: "wrong",
+			cmd:    lora.Command{ThingID: thingID, FPort: 10, Data: data},
+		},
+		{
+			desc:   "send command with non existing thing route-map",
+			err:    lora.ErrNotFoundDev,
+			chanID: chanID,
+			cmd:    lora.Command{ThingID: "wrong", FPort: 10, Data: data},
+		},
+		{
+			desc:   "send command to The Things Stack application",
+			err:    lora.ErrDownlinkNotSupported,
+			chanID: ttnChanID,
+			cmd:    lora.Command{ThingID: thingID, FPort: 10, Data: data},
+		},
+		{
+			desc:   "send command with non existing connection route-map",
+			err:    lora.ErrNotConnected,
+			chanID: chanID,
+			cmd:    lora.Command{ThingID: thingID2, FPort: 10, Data: data},
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.SendCommand(nil, tc.chanID, tc.cmd)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestHandleAck(t *testing.T) {
+	svc := newService()
+
+	err := svc.CreateChannel(nil, chanID, appID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateThing(nil, thingID, devEUI)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc string
+		err  error
+		ack  lora.Ack
+	}{
+		{
+			desc: "handle positive acknowledgement with existing route-map",
+			err:  nil,
+			ack:  lora.Ack{ApplicationID: appID, DevEUI: devEUI, Acknowledged: true, FCnt: 1},
+		},
+		{
+			desc: "handle negative acknowledgement with existing route-map",
+			err:  nil,
+			ack:  lora.Ack{ApplicationID: appID, DevEUI: devEUI, Acknowledged: false, FCnt: 2},
+		},
+		{
+			desc: "handle acknowledgement with non existing devEUI route-map",
+			err:  lora.ErrNotFoundDev,
+			ack:  lora.Ack{ApplicationID: appID, DevEUI: "wrong"},
+		},
+		{
+			desc: "handle acknowledgement with non existing appID route-map",
+			err:  lora.ErrNotFoundApp,
+			ack:  lora.Ack{ApplicationID: "wrong", DevEUI: devEUI},
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.HandleAck(nil, tc.ack)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPublishRadio(t *testing.T) {
+	pub := pubmocks.NewPublisher()
+	svc := lora.New(pub, mocks.NewDownlinkPublisher(), mocks.NewRouteMap(), mocks.NewRouteMap(), mocks.NewRouteMap(), "lora.downlink.ack", radioSubtopic)
+
+	err := svc.CreateChannel(nil, chanID, appID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateThing(nil, thingID, devEUI)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.ConnectThing(nil, chanID, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	msgBase64 := base64.StdEncoding.EncodeToString([]byte(msg))
+
+	cases := []struct {
+		desc    string
+		msg     lora.Message
+		records map[string]float64
+	}{
+		{
+			desc: "publish radio metadata of message without device status",
+			msg: lora.Message{
+				ApplicationID: appID,
+				DevEUI:        devEUI,
+				Data:          msgBase64,
+				FCnt:          10,
+				RxInfo:        lora.RxInfo{{Name: "gw-1", Rssi: -57, LoRaSNR: 10}, {Mac: "gw-2", Rssi: -101, LoRaSNR: -3.5}},
+				TxInfo:        lora.TxInfo{Frequency: 868100000, DataRate: lora.DataRate{SpreadFactor: 7}},
+			},
+			records: map[string]float64{
+				devEUI + ":fcnt":             10,
+				devEUI + ":frequency":        868100000,
+				devEUI + ":spreading_factor": 7,
+				devEUI + ":gw-1:rssi":        -57,
+				devEUI + ":gw-1:snr":         10,
+				devEUI + ":gw-2:rssi":        -101,
+				devEUI + ":gw-2:snr":         -3.5,
+			},
+		},
+		{
+			desc: "publish radio metadata of message with device status",
+			msg: lora.Message{
+				ApplicationID:       appID,
+				DevEUI:              devEUI,
+				Data:                msgBase64,
+				FCnt:                11,
+				DeviceStatusBattery: "254",
+				DeviceStatusMrgin:   "7",
+			},
+			records: map[string]float64{
+				devEUI + ":fcnt":    11,
+				devEUI + ":battery": 254,
+				devEUI + ":margin":  7,
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		err := svc.Publish(nil, tc.msg)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+
+		msgs := pub.Messages("", radioSubtopic)
+		require.Len(t, msgs, i+1, fmt.Sprintf("%s: expected %d radio messages got %d\n", tc.desc, i+1, len(msgs)))
+		assert.Equal(t, chanID, msgs[i].Channel, fmt.Sprintf("%s: expected channel %s got %s\n", tc.desc, chanID, msgs[i].Channel))
+		assert.Equal(t, thingID, msgs[i].Publisher, fmt.Sprintf("%s: expected publisher %s got %s\n", tc.desc, thingID, msgs[i].Publisher))
+
+		pack, err := senml.Decode(msgs[i].Payload, senml.JSON)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		pack, err = senml.Normalize(pack)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+
+		records := map[string]float64{}
+		for _, r := range pack.Records {
+			records[r.Name] = *r.Value
+		}
+		assert.Equal(t, tc.records, records, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.records, records))
+	}
+}
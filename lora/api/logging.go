@@ -145,3 +145,29 @@ func (lm loggingMiddleware) Publish(ctx context.Context, msg lora.Message) (err
 
 	return lm.svc.Publish(ctx, msg)
 }
+
+func (lm loggingMiddleware) SendCommand(ctx context.Context, chanID string, cmd lora.Command) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("send_command for channel %s and thing %s took %s to complete", chanID, cmd.ThingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SendCommand(ctx, chanID, cmd)
+}
+
+func (lm loggingMiddleware) HandleAck(ctx context.Context, ack lora.Ack) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("handle_ack application/%s/device/%s/event/ack took %s to complete", ack.ApplicationID, ack.DevEUI, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.HandleAck(ctx, ack)
+}
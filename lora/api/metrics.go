@@ -110,3 +110,21 @@ func (mm *metricsMiddleware) Publish(ctx context.Context, msg lora.Message) erro
 
 	return mm.svc.Publish(ctx, msg)
 }
+
+func (mm *metricsMiddleware) SendCommand(ctx context.Context, chanID string, cmd lora.Command) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "send_command").Add(1)
+		mm.latency.With("method", "send_command").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SendCommand(ctx, chanID, cmd)
+}
+
+func (mm *metricsMiddleware) HandleAck(ctx context.Context, ack lora.Ack) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "handle_ack").Add(1)
+		mm.latency.With("method", "handle_ack").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.HandleAck(ctx, ack)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// ChirpStack identifies the ChirpStack network server.
+	ChirpStack = "chirpstack"
+
+	// TTN identifies The Things Stack (The Things Network v3).
+	TTN = "ttn"
+
+	routeSep = ":"
+)
+
+// ErrUnknownLNS indicates an unsupported LoRaWAN network server.
+var ErrUnknownLNS = errors.New("unknown LoRaWAN network server")
+
+// Decoder decodes the uplinks of a LoRaWAN network server (LNS) into
+// messages understood by the adapter.
+type Decoder interface {
+	// Decode decodes the uplink payload received from the LNS MQTT broker.
+	Decode(payload []byte) (Message, error)
+}
+
+// NewDecoder returns the uplink decoder of the given LNS.
+func NewDecoder(lns string) (Decoder, error) {
+	switch lns {
+	case ChirpStack:
+		return chirpStackDecoder{}, nil
+	case TTN:
+		return ttnDecoder{}, nil
+	default:
+		return nil, ErrUnknownLNS
+	}
+}
+
+// RouteID returns the identifier under which the application of the given
+// LNS is stored in the route map. ChirpStack applications are stored by
+// their ID, while the applications of other servers are prefixed with the
+// server name, so that the applications of different servers don't clash.
+func RouteID(lns, appID string) (string, error) {
+	switch lns {
+	case "", ChirpStack:
+		return appID, nil
+	case TTN:
+		return fmt.Sprintf("%s%s%s", lns, routeSep, appID), nil
+	default:
+		return "", ErrUnknownLNS
+	}
+}
+
+// parseRouteID returns the LNS and the application ID of the route map
+// identifier.
+func parseRouteID(id string) (string, string) {
+	if lns := strings.SplitN(id, routeSep, 2); len(lns) == 2 && lns[0] == TTN {
+		return lns[0], lns[1]
+	}
+
+	return ChirpStack, id
+}
+
+type chirpStackDecoder struct{}
+
+func (chirpStackDecoder) Decode(payload []byte) (Message, error) {
+	m := Message{}
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return Message{}, err
+	}
+	m.LNS = ChirpStack
+
+	return m, nil
+}
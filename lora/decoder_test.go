@@ -0,0 +1,167 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/lora"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chirpStackUplink = `{"applicationID":"appID-1","devEUI":"devEUI-1","fCnt":10,"fPort":5,"data":"AQI=","deviceStatusBattery":254,
+		"rxInfo":[{"mac":"gw-1","rssi":-57,"loRaSNR":10}],"txInfo":{"frequency":868100000}}`
+	ttnUplink = `{"end_device_ids":{"device_id":"dev-1","application_ids":{"application_id":"app-1"},"dev_eui":"devEUI-1"},
+		"uplink_message":{"f_port":5,"f_cnt":10,"frm_payload":"AQI=","decoded_payload":{"temperature":21.5},
+		"rx_metadata":[{"gateway_ids":{"gateway_id":"gw-1","eui":"B827EBFFFE000001"},"rssi":-57,"snr":10}],
+		"settings":{"data_rate":{"lora":{"bandwidth":125000,"spreading_factor":7}},"frequency":"868100000"}}}`
+)
+
+func TestNewDecoder(t *testing.T) {
+	cases := []struct {
+		desc string
+		lns  string
+		err  error
+	}{
+		{
+			desc: "create ChirpStack decoder",
+			lns:  lora.ChirpStack,
+			err:  nil,
+		},
+		{
+			desc: "create The Things Stack decoder",
+			lns:  lora.TTN,
+			err:  nil,
+		},
+		{
+			desc: "create decoder of unknown network server",
+			lns:  "unknown",
+			err:  lora.ErrUnknownLNS,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := lora.NewDecoder(tc.lns)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestDecode(t *testing.T) {
+	cases := []struct {
+		desc    string
+		lns     string
+		payload string
+		msg     lora.Message
+		err     bool
+	}{
+		{
+			desc:    "decode ChirpStack uplink",
+			lns:     lora.ChirpStack,
+			payload: chirpStackUplink,
+			msg: lora.Message{
+				LNS:                 lora.ChirpStack,
+				ApplicationID:       appID,
+				DevEUI:              devEUI,
+				DeviceStatusBattery: "254",
+				FCnt:                10,
+				FPort:               5,
+				Data:                "AQI=",
+				RxInfo:              lora.RxInfo{{Mac: "gw-1", Rssi: -57, LoRaSNR: 10}},
+				TxInfo:              lora.TxInfo{Frequency: 868100000},
+			},
+		},
+		{
+			desc:    "decode The Things Stack uplink",
+			lns:     lora.TTN,
+			payload: ttnUplink,
+			msg: lora.Message{
+				LNS:           lora.TTN,
+				ApplicationID: "app-1",
+				DeviceName:    "dev-1",
+				DevEUI:        devEUI,
+				FCnt:          10,
+				FPort:         5,
+				Data:          "AQI=",
+				Object:        map[string]interface{}{"temperature": 21.5},
+				RxInfo:        lora.RxInfo{{Mac: "B827EBFFFE000001", Name: "gw-1", Rssi: -57, LoRaSNR: 10}},
+				TxInfo: lora.TxInfo{
+					Frequency: 868100000,
+					DataRate:  lora.DataRate{Modulation: "LORA", Bandwith: 125000, SpreadFactor: 7},
+				},
+			},
+		},
+		{
+			desc:    "decode malformed ChirpStack uplink",
+			lns:     lora.ChirpStack,
+			payload: "{",
+			err:     true,
+		},
+		{
+			desc:    "decode malformed The Things Stack uplink",
+			lns:     lora.TTN,
+			payload: "{",
+			err:     true,
+		},
+		{
+			desc:    "decode The Things Stack uplink with invalid frequency",
+			lns:     lora.TTN,
+			payload: `{"uplink_message":{"settings":{"frequency":"wrong"}}}`,
+			err:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		decoder, err := lora.NewDecoder(tc.lns)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+		msg, err := decoder.Decode([]byte(tc.payload))
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.msg, msg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.msg, msg))
+	}
+}
+
+func TestRouteID(t *testing.T) {
+	cases := []struct {
+		desc  string
+		lns   string
+		appID string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "route ID of ChirpStack application",
+			lns:   lora.ChirpStack,
+			appID: appID,
+			id:    appID,
+		},
+		{
+			desc:  "route ID of application without network server",
+			lns:   "",
+			appID: appID,
+			id:    appID,
+		},
+		{
+			desc:  "route ID of The Things Stack application",
+			lns:   lora.TTN,
+			appID: appID,
+			id:    "ttn:" + appID,
+		},
+		{
+			desc:  "route ID of unknown network server application",
+			lns:   "unknown",
+			appID: appID,
+			err:   lora.ErrUnknownLNS,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := lora.RouteID(tc.lns, tc.appID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.id, id))
+	}
+}
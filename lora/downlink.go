@@ -0,0 +1,81 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const (
+	minFPort = 1
+	maxFPort = 223
+
+	ackStatus  = "ack"
+	nackStatus = "nack"
+)
+
+// Command represents a downlink command published on the downlink subtopic
+// of a channel. Data is the base64 encoded frame payload.
+type Command struct {
+	ThingID   string `json:"thing_id"`
+	FPort     int    `json:"f_port"`
+	Confirmed bool   `json:"confirmed"`
+	Data      string `json:"data"`
+}
+
+// Downlink represents a ChirpStack downlink
+// (https://www.chirpstack.io/application-server/integrations/mqtt/#scheduling-a-downlink).
+type Downlink struct {
+	Confirmed bool   `json:"confirmed"`
+	FPort     int    `json:"fPort"`
+	Data      string `json:"data"`
+}
+
+// Ack represents a ChirpStack acknowledgement of a confirmed downlink
+// (https://www.chirpstack.io/application-server/integrations/events/#ack---confirmed-downlink-acknowledgement).
+type Ack struct {
+	ApplicationID string `json:"applicationID"`
+	DevEUI        string `json:"devEUI"`
+	Acknowledged  bool   `json:"acknowledged"`
+	FCnt          int    `json:"fCnt"`
+}
+
+// ackEvent is the acknowledgement reported on the channel.
+type ackEvent struct {
+	ThingID string `json:"thing_id"`
+	Status  string `json:"status"`
+	FCnt    int    `json:"f_cnt"`
+}
+
+// DownlinkPublisher publishes downlinks to the LoRa MQTT broker.
+type DownlinkPublisher interface {
+	// Publish schedules the downlink for the device of the application.
+	Publish(appID, devEUI string, dl Downlink) error
+}
+
+type downlinkHandler struct {
+	svc Service
+}
+
+// NewDownlinkHandler returns a handler of the messages published on the
+// downlink subtopic, which sends the commands they carry to the LoRa devices.
+func NewDownlinkHandler(svc Service) messaging.MessageHandler {
+	return downlinkHandler{svc: svc}
+}
+
+func (h downlinkHandler) Handle(msg messaging.Message) error {
+	var cmd Command
+	if err := json.Unmarshal(msg.Payload, &cmd); err != nil {
+		return ErrMalformedCommand
+	}
+
+	return h.svc.SendCommand(context.Background(), msg.Channel, cmd)
+}
+
+func (h downlinkHandler) Cancel() error {
+	return nil
+}
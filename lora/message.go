@@ -1,7 +1,12 @@
 package lora
 
+import "encoding/json"
+
 // RxInfo receiver parameters
-type RxInfo []struct {
+type RxInfo []RxGateway
+
+// RxGateway parameters of a gateway that received the message
+type RxGateway struct {
 	Mac       string  `json:"mac"`
 	Name      string  `json:"name"`
 	Latitude  float64 `json:"latitude"`
@@ -29,12 +34,14 @@ type TxInfo struct {
 
 // Message lora msg (https://www.chirpstack.io/application-server/integrations/events)
 type Message struct {
+	// LNS is the network server the message is received from.
+	LNS                 string      `json:"-"`
 	ApplicationID       string      `json:"applicationID"`
 	ApplicationName     string      `json:"applicationName"`
 	DeviceName          string      `json:"deviceName"`
 	DevEUI              string      `json:"devEUI"`
-	DeviceStatusBattery string      `json:"deviceStatusBattery"`
-	DeviceStatusMrgin   string      `json:"deviceStatusMargin"`
+	DeviceStatusBattery json.Number `json:"deviceStatusBattery"`
+	DeviceStatusMrgin   json.Number `json:"deviceStatusMargin"`
 	RxInfo              RxInfo      `json:"rxInfo"`
 	TxInfo              TxInfo      `json:"txInfo"`
 	FCnt                int         `json:"fCnt"`
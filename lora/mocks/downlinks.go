@@ -0,0 +1,31 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/lora"
+)
+
+type downlinkPublisherMock struct {
+	mu        sync.Mutex
+	downlinks map[string][]lora.Downlink
+}
+
+// NewDownlinkPublisher returns mock downlink publisher instance.
+func NewDownlinkPublisher() lora.DownlinkPublisher {
+	return &downlinkPublisherMock{
+		downlinks: make(map[string][]lora.Downlink),
+	}
+}
+
+func (dpm *downlinkPublisherMock) Publish(appID, devEUI string, dl lora.Downlink) error {
+	dpm.mu.Lock()
+	defer dpm.mu.Unlock()
+
+	key := appID + "/" + devEUI
+	dpm.downlinks[key] = append(dpm.downlinks[key], dl)
+	return nil
+}
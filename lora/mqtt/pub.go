@@ -0,0 +1,52 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/lora"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const downlinkQoS = 1
+
+var errPublishTimeout = errors.New("failed to publish downlink due to timeout reached")
+
+var _ lora.DownlinkPublisher = (*publisher)(nil)
+
+type publisher struct {
+	client  mqtt.Client
+	timeout time.Duration
+}
+
+// NewPublisher returns new downlink publisher instance.
+func NewPublisher(client mqtt.Client, t time.Duration) lora.DownlinkPublisher {
+	return publisher{
+		client:  client,
+		timeout: t,
+	}
+}
+
+// Publish publishes the downlink to the command topic of the device.
+func (p publisher) Publish(appID, devEUI string, dl lora.Downlink) error {
+	payload, err := json.Marshal(dl)
+	if err != nil {
+		return err
+	}
+
+	topic := fmt.Sprintf("application/%s/device/%s/command/down", appID, devEUI)
+	token := p.client.Publish(topic, downlinkQoS, false, payload)
+	if token.Error() != nil {
+		return token.Error()
+	}
+	if ok := token.WaitTimeout(p.timeout); !ok {
+		return errPublishTimeout
+	}
+
+	return token.Error()
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/logger"
@@ -13,6 +14,8 @@ import (
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 )
 
+const ackEventSuffix = "/event/ack"
+
 // Subscriber represents the MQTT broker.
 type Subscriber interface {
 	// Subscribes to given subject and receives events.
@@ -22,15 +25,18 @@ type Subscriber interface {
 type broker struct {
 	svc     lora.Service
 	client  mqtt.Client
+	decoder lora.Decoder
 	logger  logger.Logger
 	timeout time.Duration
 }
 
-// NewBroker returns new MQTT broker instance.
-func NewBroker(svc lora.Service, client mqtt.Client, t time.Duration, log logger.Logger) Subscriber {
+// NewBroker returns new MQTT broker instance. Uplinks received on the broker
+// are decoded using the decoder of the LoRaWAN network server.
+func NewBroker(svc lora.Service, client mqtt.Client, decoder lora.Decoder, t time.Duration, log logger.Logger) Subscriber {
 	return broker{
 		svc:     svc,
 		client:  client,
+		decoder: decoder,
 		logger:  log,
 		timeout: t,
 	}
@@ -48,11 +54,27 @@ func (b broker) Subscribe(subject string) error {
 
 // handleMsg triggered when new message is received on Lora MQTT broker
 func (b broker) handleMsg(c mqtt.Client, msg mqtt.Message) {
-	m := lora.Message{}
-	if err := json.Unmarshal(msg.Payload(), &m); err != nil {
-		b.logger.Warn(fmt.Sprintf("Failed to unmarshal message: %s", err.Error()))
+	if strings.HasSuffix(msg.Topic(), ackEventSuffix) {
+		b.handleAck(msg)
+		return
+	}
+
+	m, err := b.decoder.Decode(msg.Payload())
+	if err != nil {
+		b.logger.Warn(fmt.Sprintf("Failed to decode message: %s", err.Error()))
 		return
 	}
 
 	b.svc.Publish(context.Background(), m)
 }
+
+// handleAck triggered when a downlink acknowledgement is received on Lora MQTT broker
+func (b broker) handleAck(msg mqtt.Message) {
+	ack := lora.Ack{}
+	if err := json.Unmarshal(msg.Payload(), &ack); err != nil {
+		b.logger.Warn(fmt.Sprintf("Failed to unmarshal acknowledgement: %s", err.Error()))
+		return
+	}
+
+	b.svc.HandleAck(context.Background(), ack)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"fmt"
+
+	"github.com/MainfluxLabs/senml"
+)
+
+const (
+	rssiName    = "rssi"
+	snrName     = "snr"
+	freqName    = "frequency"
+	freqUnit    = "Hz"
+	sfName      = "spreading_factor"
+	fCntName    = "fcnt"
+	batteryName = "battery"
+	marginName  = "margin"
+)
+
+// radioPack returns the SenML pack of the radio metadata and the device
+// status of the message: the frame counter, the frequency, the spreading
+// factor, the RSSI and SNR per gateway and the battery level and link
+// margin reported by the device.
+func radioPack(m Message, t float64) senml.Pack {
+	recs := []senml.Record{
+		{BaseName: fmt.Sprintf("%s:", m.DevEUI), BaseTime: t, Name: fCntName, Value: float(float64(m.FCnt))},
+	}
+
+	if m.TxInfo.Frequency != 0 {
+		recs = append(recs, senml.Record{Name: freqName, Unit: freqUnit, Value: float(m.TxInfo.Frequency)})
+	}
+
+	if sf := m.TxInfo.DataRate.SpreadFactor; sf != 0 {
+		recs = append(recs, senml.Record{Name: sfName, Value: float(float64(sf))})
+	}
+
+	for _, rx := range m.RxInfo {
+		gw := rx.Name
+		if gw == "" {
+			gw = rx.Mac
+		}
+		if gw == "" {
+			continue
+		}
+
+		recs = append(recs,
+			senml.Record{Name: fmt.Sprintf("%s:%s", gw, rssiName), Value: float(rx.Rssi)},
+			senml.Record{Name: fmt.Sprintf("%s:%s", gw, snrName), Value: float(rx.LoRaSNR)},
+		)
+	}
+
+	if battery, err := m.DeviceStatusBattery.Float64(); err == nil {
+		recs = append(recs, senml.Record{Name: batteryName, Value: float(battery)})
+	}
+
+	if margin, err := m.DeviceStatusMrgin.Float64(); err == nil {
+		recs = append(recs, senml.Record{Name: marginName, Value: float(margin)})
+	}
+
+	return senml.Pack{Records: recs}
+}
+
+func float(v float64) *float64 {
+	return &v
+}
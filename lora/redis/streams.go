@@ -15,6 +15,7 @@ const (
 	keyType   = "lora"
 	keyDevEUI = "dev_eui"
 	keyAppID  = "app_id"
+	keyLNS    = "lns"
 
 	group  = "mainflux.lora"
 	stream = "mainflux.things"
@@ -42,6 +43,8 @@ var (
 	errMetadataAppID = errors.New("application ID not found in channel metadatada")
 
 	errMetadataDevEUI = errors.New("device EUI not found in thing metadatada")
+
+	errMetadataLNS = errors.New("malformed network server in channel metadata")
 )
 
 // Subscriber represents event source for things and channels provisioning.
@@ -202,7 +205,19 @@ func decodeCreateChannel(event map[string]interface{}) (createChannelEvent, erro
 		return createChannelEvent{}, errMetadataAppID
 	}
 
-	cce.loraAppID = val
+	lns := lora.ChirpStack
+	if v, ok := lm[keyLNS]; ok {
+		if lns, ok = v.(string); !ok {
+			return createChannelEvent{}, errMetadataLNS
+		}
+	}
+
+	appID, err := lora.RouteID(lns, val)
+	if err != nil {
+		return createChannelEvent{}, err
+	}
+
+	cce.loraAppID = appID
 	return cce, nil
 }
 
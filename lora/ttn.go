@@ -0,0 +1,104 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lora
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// ttnUplink represents The Things Stack uplink message
+// (https://www.thethingsindustries.com/docs/reference/data-formats/#uplink-messages).
+type ttnUplink struct {
+	EndDeviceIDs struct {
+		DeviceID       string `json:"device_id"`
+		ApplicationIDs struct {
+			ApplicationID string `json:"application_id"`
+		} `json:"application_ids"`
+		DevEUI string `json:"dev_eui"`
+	} `json:"end_device_ids"`
+	UplinkMessage struct {
+		FPort          int         `json:"f_port"`
+		FCnt           int         `json:"f_cnt"`
+		FRMPayload     string      `json:"frm_payload"`
+		DecodedPayload interface{} `json:"decoded_payload"`
+		RxMetadata     []struct {
+			GatewayIDs struct {
+				GatewayID string `json:"gateway_id"`
+				EUI       string `json:"eui"`
+			} `json:"gateway_ids"`
+			Time     string  `json:"time"`
+			RSSI     float64 `json:"rssi"`
+			SNR      float64 `json:"snr"`
+			Location struct {
+				Latitude  float64 `json:"latitude"`
+				Longitude float64 `json:"longitude"`
+				Altitude  float64 `json:"altitude"`
+			} `json:"location"`
+		} `json:"rx_metadata"`
+		Settings struct {
+			DataRate struct {
+				LoRa struct {
+					Bandwidth       float64 `json:"bandwidth"`
+					SpreadingFactor int64   `json:"spreading_factor"`
+					CodingRate      string  `json:"coding_rate"`
+				} `json:"lora"`
+			} `json:"data_rate"`
+			// Frequency is an uint64 and thus encoded as a string.
+			Frequency string `json:"frequency"`
+		} `json:"settings"`
+	} `json:"uplink_message"`
+}
+
+type ttnDecoder struct{}
+
+func (ttnDecoder) Decode(payload []byte) (Message, error) {
+	up := ttnUplink{}
+	if err := json.Unmarshal(payload, &up); err != nil {
+		return Message{}, err
+	}
+
+	um := up.UplinkMessage
+	m := Message{
+		LNS:           TTN,
+		ApplicationID: up.EndDeviceIDs.ApplicationIDs.ApplicationID,
+		DeviceName:    up.EndDeviceIDs.DeviceID,
+		DevEUI:        up.EndDeviceIDs.DevEUI,
+		FCnt:          um.FCnt,
+		FPort:         um.FPort,
+		Data:          um.FRMPayload,
+		Object:        um.DecodedPayload,
+		TxInfo: TxInfo{
+			DataRate: DataRate{
+				Modulation:   "LORA",
+				Bandwith:     um.Settings.DataRate.LoRa.Bandwidth,
+				SpreadFactor: um.Settings.DataRate.LoRa.SpreadingFactor,
+			},
+			CodeRate: um.Settings.DataRate.LoRa.CodingRate,
+		},
+	}
+
+	if um.Settings.Frequency != "" {
+		freq, err := strconv.ParseFloat(um.Settings.Frequency, 64)
+		if err != nil {
+			return Message{}, err
+		}
+		m.TxInfo.Frequency = freq
+	}
+
+	for _, rx := range um.RxMetadata {
+		m.RxInfo = append(m.RxInfo, RxGateway{
+			Mac:       rx.GatewayIDs.EUI,
+			Name:      rx.GatewayIDs.GatewayID,
+			Latitude:  rx.Location.Latitude,
+			Longitude: rx.Location.Longitude,
+			Altitude:  rx.Location.Altitude,
+			Time:      rx.Time,
+			Rssi:      rx.RSSI,
+			LoRaSNR:   rx.SNR,
+		})
+	}
+
+	return m, nil
+}
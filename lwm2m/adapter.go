@@ -0,0 +1,575 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lwm2m
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/senml"
+)
+
+const (
+	protocol = "lwm2m"
+
+	// defLifetime is the registration lifetime of the clients which don't
+	// send one, as defined by the LwM2M specification.
+	defLifetime = 86400 * time.Second
+
+	// maxRelTime is the largest relative SenML time, as defined by RFC 8428.
+	maxRelTime = 1 << 28
+)
+
+var (
+	// ErrNotFound indicates a thing that isn't mapped to an LwM2M client.
+	ErrNotFound = errors.New("lwm2m thing not found")
+
+	// ErrNotRegistered indicates a client that isn't registered.
+	ErrNotRegistered = errors.New("lwm2m client not registered")
+
+	// ErrInvalidConfig indicates a malformed LwM2M client configuration.
+	ErrInvalidConfig = errors.New("invalid lwm2m configuration")
+
+	// ErrMalformedRegistration indicates a registration without endpoint
+	// name.
+	ErrMalformedRegistration = errors.New("malformed lwm2m registration")
+)
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateThing maps the thing to the LwM2M client of the configuration.
+	CreateThing(ctx context.Context, thingID string, cfg Config) error
+
+	// UpdateThing updates the LwM2M client configuration of the thing.
+	UpdateThing(ctx context.Context, thingID string, cfg Config) error
+
+	// RemoveThing removes the thing mapping and its client registration.
+	RemoveThing(ctx context.Context, thingID string) error
+
+	// ConnectThing starts publishing the notifications of the thing on the
+	// channel.
+	ConnectThing(ctx context.Context, chanID, thingID string) error
+
+	// DisconnectThing stops publishing the notifications of the thing on
+	// the channel.
+	DisconnectThing(ctx context.Context, chanID, thingID string) error
+
+	// Register registers the client of the thing identified by the key,
+	// connected over the device, and returns the ID of its registration.
+	// The endpoint name of the registration must be the one configured for
+	// the thing.
+	Register(ctx context.Context, key string, reg Registration, dev Device) (string, error)
+
+	// Update updates the lifetime and, if not empty, the objects of the
+	// registration.
+	Update(ctx context.Context, id string, lifetime time.Duration, objects []string) error
+
+	// Deregister removes the registration and cancels its observations.
+	Deregister(ctx context.Context, id string) error
+
+	// Observe observes the paths of the registration which aren't observed
+	// yet, publishing the notifications on the channels of the thing.
+	Observe(ctx context.Context, id string) error
+
+	// Read reads the object, object instance or resource on the path from
+	// the client of the thing owned by the user identified by the token.
+	Read(ctx context.Context, token, thingID, path string) (senml.Pack, error)
+
+	// Write writes the value to the resource on the path of the client of
+	// the thing owned by the user identified by the token.
+	Write(ctx context.Context, token, thingID, path string, value interface{}) error
+
+	// Execute executes the resource on the path of the client of the thing
+	// owned by the user identified by the token.
+	Execute(ctx context.Context, token, thingID, path, args string) error
+}
+
+var _ Service = (*adapterService)(nil)
+
+type session struct {
+	id       string
+	thingID  string
+	reg      Registration
+	dev      Device
+	timer    *time.Timer
+	observed map[string]bool
+}
+
+type adapterService struct {
+	publisher  messaging.Publisher
+	auth       mainflux.AuthServiceClient
+	thingc     mainflux.ThingsServiceClient
+	things     ThingRepository
+	idProvider mainflux.IDProvider
+	mu         sync.Mutex
+	sessions   map[string]*session
+	clients    map[string]*session
+}
+
+// New instantiates the LwM2M adapter implementation.
+func New(publisher messaging.Publisher, auth mainflux.AuthServiceClient, thingc mainflux.ThingsServiceClient, things ThingRepository, idProvider mainflux.IDProvider) Service {
+	return &adapterService{
+		publisher:  publisher,
+		auth:       auth,
+		thingc:     thingc,
+		things:     things,
+		idProvider: idProvider,
+		sessions:   make(map[string]*session),
+		clients:    make(map[string]*session),
+	}
+}
+
+// Validate checks whether the configuration is valid.
+func (cfg Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.Wrap(ErrInvalidConfig, errors.New("missing endpoint"))
+	}
+
+	for _, p := range cfg.Observe {
+		if _, err := parsePath(p); err != nil {
+			return errors.Wrap(ErrInvalidConfig, err)
+		}
+	}
+
+	return nil
+}
+
+func (as *adapterService) CreateThing(ctx context.Context, thingID string, cfg Config) error {
+	return as.UpdateThing(ctx, thingID, cfg)
+}
+
+func (as *adapterService) UpdateThing(ctx context.Context, thingID string, cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	th, err := as.things.RetrieveByEndpoint(ctx, cfg.Endpoint)
+	switch {
+	case err == nil:
+		if th.ID != thingID {
+			return errors.ErrConflict
+		}
+	case errors.Contains(err, ErrNotFound):
+	default:
+		return err
+	}
+
+	th, err = as.things.Retrieve(ctx, thingID)
+	switch {
+	case err == nil:
+	case errors.Contains(err, ErrNotFound):
+		th = Thing{ID: thingID}
+	default:
+		return err
+	}
+
+	if th.Config.Endpoint != cfg.Endpoint {
+		as.closeSession(thingID)
+	}
+	th.Config = cfg
+
+	return as.things.Save(ctx, th)
+}
+
+func (as *adapterService) RemoveThing(ctx context.Context, thingID string) error {
+	as.closeSession(thingID)
+	return as.things.Remove(ctx, thingID)
+}
+
+func (as *adapterService) ConnectThing(ctx context.Context, chanID, thingID string) error {
+	th, err := as.things.Retrieve(ctx, thingID)
+	if err != nil {
+		return err
+	}
+
+	if th.connected(chanID) {
+		return nil
+	}
+	th.Channels = append(th.Channels, chanID)
+
+	return as.things.Save(ctx, th)
+}
+
+func (as *adapterService) DisconnectThing(ctx context.Context, chanID, thingID string) error {
+	th, err := as.things.Retrieve(ctx, thingID)
+	if err != nil {
+		return err
+	}
+
+	var chs []string
+	for _, c := range th.Channels {
+		if c != chanID {
+			chs = append(chs, c)
+		}
+	}
+	th.Channels = chs
+
+	return as.things.Save(ctx, th)
+}
+
+func (as *adapterService) Register(ctx context.Context, key string, reg Registration, dev Device) (string, error) {
+	if reg.Endpoint == "" {
+		return "", ErrMalformedRegistration
+	}
+
+	thID, err := as.thingc.Identify(ctx, &mainflux.Token{Value: key})
+	if err != nil {
+		return "", errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	th, err := as.things.RetrieveByEndpoint(ctx, reg.Endpoint)
+	if err != nil {
+		return "", err
+	}
+	if th.ID != thID.GetValue() {
+		return "", errors.ErrAuthorization
+	}
+
+	id, err := as.idProvider.ID()
+	if err != nil {
+		return "", err
+	}
+
+	if reg.Lifetime <= 0 {
+		reg.Lifetime = defLifetime
+	}
+
+	ses := &session{
+		id:       id,
+		thingID:  th.ID,
+		reg:      reg,
+		dev:      dev,
+		observed: make(map[string]bool),
+	}
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	// The registration replaces the previous one of the client, e.g. if
+	// the client rebooted without deregistering.
+	if old, ok := as.clients[th.ID]; ok {
+		as.remove(old)
+	}
+	as.sessions[id] = ses
+	as.clients[th.ID] = ses
+	ses.timer = time.AfterFunc(reg.Lifetime, func() {
+		as.expire(ses)
+	})
+
+	return id, nil
+}
+
+func (as *adapterService) Update(ctx context.Context, id string, lifetime time.Duration, objects []string) error {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	ses, ok := as.sessions[id]
+	if !ok {
+		return ErrNotRegistered
+	}
+
+	if lifetime > 0 {
+		ses.reg.Lifetime = lifetime
+	}
+	if len(objects) > 0 {
+		ses.reg.Objects = objects
+	}
+	ses.timer.Reset(ses.reg.Lifetime)
+
+	return nil
+}
+
+func (as *adapterService) Deregister(ctx context.Context, id string) error {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	ses, ok := as.sessions[id]
+	if !ok {
+		return ErrNotRegistered
+	}
+	as.remove(ses)
+
+	return nil
+}
+
+func (as *adapterService) Observe(ctx context.Context, id string) error {
+	as.mu.Lock()
+	ses, ok := as.sessions[id]
+	if !ok {
+		as.mu.Unlock()
+		return ErrNotRegistered
+	}
+	objects := ses.reg.Objects
+	as.mu.Unlock()
+
+	th, err := as.things.Retrieve(ctx, ses.thingID)
+	if err != nil {
+		return err
+	}
+
+	var paths []path
+	for _, p := range observed(th.Config.Observe, objects) {
+		as.mu.Lock()
+		if !ses.observed[p.String()] {
+			ses.observed[p.String()] = true
+			paths = append(paths, p)
+		}
+		as.mu.Unlock()
+	}
+
+	// A failed observation doesn't prevent the other ones, and is retried
+	// on the next call.
+	var ret error
+	for _, p := range paths {
+		p := p
+		notify := func(c Content) error {
+			return as.publish(context.Background(), ses.thingID, p, c)
+		}
+		if err := ses.dev.Observe(ctx, p.String(), notify); err != nil {
+			as.mu.Lock()
+			delete(ses.observed, p.String())
+			as.mu.Unlock()
+			if ret == nil {
+				ret = err
+			}
+		}
+	}
+
+	return ret
+}
+
+func (as *adapterService) Read(ctx context.Context, token, thingID, p string) (senml.Pack, error) {
+	if err := as.authorize(ctx, token, thingID, auth.ThingsReadScope); err != nil {
+		return senml.Pack{}, err
+	}
+
+	pp, err := parsePath(p)
+	if err != nil {
+		return senml.Pack{}, err
+	}
+
+	dev, err := as.device(ctx, thingID)
+	if err != nil {
+		return senml.Pack{}, err
+	}
+
+	c, err := dev.Read(ctx, pp.String())
+	if err != nil {
+		return senml.Pack{}, err
+	}
+
+	recs, err := decode(pp, c)
+	if err != nil {
+		return senml.Pack{}, err
+	}
+	stamp(recs, time.Now())
+
+	return senml.Pack{Records: recs}, nil
+}
+
+func (as *adapterService) Write(ctx context.Context, token, thingID, p string, value interface{}) error {
+	if err := as.authorize(ctx, token, thingID, auth.ThingsWriteScope); err != nil {
+		return err
+	}
+
+	pp, err := parsePath(p)
+	if err != nil {
+		return err
+	}
+
+	c, err := encode(pp, value)
+	if err != nil {
+		return err
+	}
+
+	dev, err := as.device(ctx, thingID)
+	if err != nil {
+		return err
+	}
+
+	return dev.Write(ctx, pp.String(), c)
+}
+
+func (as *adapterService) Execute(ctx context.Context, token, thingID, p, args string) error {
+	if err := as.authorize(ctx, token, thingID, auth.ThingsWriteScope); err != nil {
+		return err
+	}
+
+	pp, err := parsePath(p)
+	if err != nil {
+		return err
+	}
+	if len(pp) != 3 {
+		return ErrMalformedPath
+	}
+
+	dev, err := as.device(ctx, thingID)
+	if err != nil {
+		return err
+	}
+
+	return dev.Execute(ctx, pp.String(), args)
+}
+
+// authorize checks that the user identified by the token is the root admin
+// or owns the thing, and that the token grants the scope.
+func (as *adapterService) authorize(ctx context.Context, token, thingID, scope string) error {
+	user, err := as.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if !auth.HasScope(user.GetScopes(), scope) {
+		return errors.ErrAuthorization
+	}
+
+	if _, err := as.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.RootSubject}); err == nil {
+		return nil
+	}
+
+	if _, err := as.thingc.IsThingOwner(ctx, &mainflux.ThingOwnerReq{Owner: user.GetId(), ThingID: thingID}); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
+
+// publish publishes the content observed on the path as SenML on the
+// channels of the thing.
+func (as *adapterService) publish(ctx context.Context, thingID string, p path, c Content) error {
+	th, err := as.things.Retrieve(ctx, thingID)
+	if err != nil {
+		return err
+	}
+
+	if len(th.Channels) == 0 {
+		return nil
+	}
+
+	recs, err := decode(p, c)
+	if err != nil {
+		return err
+	}
+	if len(recs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	stamp(recs, now)
+
+	payload, err := senml.Encode(senml.Pack{Records: recs}, senml.JSON)
+	if err != nil {
+		return err
+	}
+
+	for _, chanID := range th.Channels {
+		msg := messaging.Message{
+			Publisher: thingID,
+			Protocol:  protocol,
+			Channel:   chanID,
+			Payload:   payload,
+			Created:   now.UnixNano(),
+		}
+		if err := as.publisher.Publish(msg.Channel, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// device returns the device of the registered client of the thing.
+func (as *adapterService) device(ctx context.Context, thingID string) (Device, error) {
+	if _, err := as.things.Retrieve(ctx, thingID); err != nil {
+		return nil, err
+	}
+
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	ses, ok := as.clients[thingID]
+	if !ok {
+		return nil, ErrNotRegistered
+	}
+
+	return ses.dev, nil
+}
+
+func (as *adapterService) expire(ses *session) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if as.sessions[ses.id] == ses {
+		as.remove(ses)
+	}
+}
+
+func (as *adapterService) closeSession(thingID string) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if ses, ok := as.clients[thingID]; ok {
+		as.remove(ses)
+	}
+}
+
+// remove removes the session, which must be called with the lock held.
+func (as *adapterService) remove(ses *session) {
+	ses.timer.Stop()
+	ses.dev.Close()
+	delete(as.sessions, ses.id)
+	if as.clients[ses.thingID] == ses {
+		delete(as.clients, ses.thingID)
+	}
+}
+
+// observed returns the paths observed for the objects of the registration,
+// which are the configured paths of the reported objects, or all the
+// reported instances of the known objects if none are configured.
+func observed(paths []string, objects []string) []path {
+	reported := make(map[uint16]bool)
+	var instances []path
+	for _, o := range objects {
+		p, err := parsePath(o)
+		if err != nil {
+			continue
+		}
+		reported[p[0]] = true
+		if known(p[0]) {
+			instances = append(instances, p)
+		}
+	}
+
+	if len(paths) == 0 {
+		return instances
+	}
+
+	var ret []path
+	for _, o := range paths {
+		p, err := parsePath(o)
+		if err != nil || !reported[p[0]] {
+			continue
+		}
+		ret = append(ret, p)
+	}
+
+	return ret
+}
+
+// stamp sets the absolute times of the records, which are either missing
+// or relative to the current time if lower than 2^28, as per RFC 8428.
+func stamp(recs []senml.Record, now time.Time) {
+	t := float64(now.UnixNano()) / 1e9
+	for i := range recs {
+		if recs[i].Time < maxRelTime {
+			recs[i].Time += t
+		}
+	}
+}
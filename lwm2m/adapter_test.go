@@ -0,0 +1,728 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lwm2m_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+	"github.com/MainfluxLabs/mainflux/lwm2m/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	pubmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/MainfluxLabs/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	thingID    = "thingID-1"
+	thingID2   = "thingID-2"
+	chanID     = "chanID-1"
+	endpoint   = "urn:imei:490154203237511"
+	wrong      = "wrong"
+	adminToken = "admin@example.com"
+	userToken  = "user@example.com"
+	otherToken = "other@example.com"
+)
+
+var (
+	admin     = users.User{ID: "admin-id", Email: adminToken}
+	user      = users.User{ID: "user-id", Email: userToken}
+	otherUser = users.User{ID: "other-id", Email: otherToken}
+
+	objects = []string{"/1/0", "/3/0", "/3303/0"}
+
+	// deviceTLV contains the Manufacturer, Available Power Sources and
+	// Battery Level resources of the Device object instance.
+	deviceTLV = []byte{
+		0xC3, 0x00, 'O', 'M', 'A',
+		0x86, 0x06, 0x41, 0x00, 0x01, 0x41, 0x01, 0x05,
+		0xC1, 0x09, 0x64,
+	}
+
+	// temperatureTLV contains the Sensor Value and Sensor Units resources of
+	// the Temperature object instance.
+	temperatureTLV = []byte{
+		0x08, 0x00, 0x0D,
+		0xE4, 0x16, 0x44, 0x41, 0xAC, 0x00, 0x00,
+		0xE3, 0x16, 0x45, 'C', 'e', 'l',
+	}
+
+	temperatureSenML = []byte(`[{"bn":"/3303/0/","n":"5700","v":22.5},{"n":"5701","vs":"Cel"}]`)
+)
+
+func newService(pub *pubmocks.Publisher) (lwm2m.Service, lwm2m.ThingRepository) {
+	auth := pubmocks.NewAuthService(admin.ID, []users.User{admin, user, otherUser})
+	thingc := pubmocks.NewThingsServiceClient(map[string]string{user.ID: thingID}, nil)
+	things := mocks.NewThingRepository()
+	return lwm2m.New(pub, auth, thingc, things, uuid.NewMock()), things
+}
+
+func newDevice() *mocks.Device {
+	v := 23.5
+	cbor, _ := senml.Encode(senml.Pack{Records: []senml.Record{{BaseName: "/3303/1/", Name: "5700", Value: &v}}}, senml.CBOR)
+
+	return mocks.NewDevice(map[string]lwm2m.Content{
+		"/3303/1":      {Format: lwm2m.SenMLCBOR, Payload: cbor},
+		"/3/0":         {Format: lwm2m.TLV, Payload: deviceTLV},
+		"/3/0/9":       {Format: lwm2m.TextPlain, Payload: []byte("87")},
+		"/3/0/0":       {Format: lwm2m.TLV, Payload: deviceTLV[:5]},
+		"/3303/0":      {Format: lwm2m.TLV, Payload: temperatureTLV},
+		"/3303/0/5700": {Format: lwm2m.SenMLJSON, Payload: []byte(`[{"n":"/3303/0/5700","v":22.5}]`)},
+		"/3304/0":      {Format: 11543, Payload: []byte("{}")},
+	})
+}
+
+// values returns the values of the records by their names, and checks
+// whether they are stamped with the current time.
+func values(t *testing.T, recs []senml.Record) map[string]interface{} {
+	vals := make(map[string]interface{})
+	for _, r := range recs {
+		assert.InDelta(t, float64(time.Now().Unix()), r.Time, 5, fmt.Sprintf("expected record %s to be stamped with current time", r.Name))
+		switch {
+		case r.Value != nil:
+			vals[r.Name] = *r.Value
+		case r.BoolValue != nil:
+			vals[r.Name] = *r.BoolValue
+		case r.StringValue != nil:
+			vals[r.Name] = *r.StringValue
+		case r.DataValue != nil:
+			vals[r.Name] = *r.DataValue
+		}
+	}
+
+	return vals
+}
+
+func TestCreateThing(t *testing.T) {
+	svc, _ := newService(pubmocks.NewPublisher())
+
+	err := svc.CreateThing(nil, thingID2, lwm2m.Config{Endpoint: "other"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		thingID string
+		cfg     lwm2m.Config
+		err     error
+	}{
+		{
+			desc:    "create thing with valid config",
+			thingID: thingID,
+			cfg:     lwm2m.Config{Endpoint: endpoint, Observe: []string{"/3303/0/5700"}},
+			err:     nil,
+		},
+		{
+			desc:    "create thing without endpoint",
+			thingID: thingID,
+			cfg:     lwm2m.Config{},
+			err:     lwm2m.ErrInvalidConfig,
+		},
+		{
+			desc:    "create thing with malformed observed path",
+			thingID: thingID,
+			cfg:     lwm2m.Config{Endpoint: endpoint, Observe: []string{"/temperature"}},
+			err:     lwm2m.ErrInvalidConfig,
+		},
+		{
+			desc:    "create thing with endpoint of other thing",
+			thingID: thingID,
+			cfg:     lwm2m.Config{Endpoint: "other"},
+			err:     errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.CreateThing(nil, tc.thingID, tc.cfg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRegister(t *testing.T) {
+	svc, _ := newService(pubmocks.NewPublisher())
+
+	err := svc.CreateThing(nil, thingID, lwm2m.Config{Endpoint: endpoint})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.CreateThing(nil, thingID2, lwm2m.Config{Endpoint: "other"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	dev := newDevice()
+	cases := []struct {
+		desc string
+		key  string
+		reg  lwm2m.Registration
+		id   string
+		err  error
+	}{
+		{
+			desc: "register client",
+			key:  thingID,
+			reg:  lwm2m.Registration{Endpoint: endpoint, Objects: objects},
+			id:   fmt.Sprintf("%s%012d", uuid.Prefix, 1),
+			err:  nil,
+		},
+		{
+			desc: "register client without endpoint",
+			key:  thingID,
+			reg:  lwm2m.Registration{Objects: objects},
+			id:   "",
+			err:  lwm2m.ErrMalformedRegistration,
+		},
+		{
+			desc: "register client of unknown endpoint",
+			key:  thingID,
+			reg:  lwm2m.Registration{Endpoint: wrong, Objects: objects},
+			id:   "",
+			err:  lwm2m.ErrNotFound,
+		},
+		{
+			desc: "register client without key",
+			key:  "",
+			reg:  lwm2m.Registration{Endpoint: endpoint, Objects: objects},
+			id:   "",
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "register client with invalid key",
+			key:  "invalid",
+			reg:  lwm2m.Registration{Endpoint: endpoint, Objects: objects},
+			id:   "",
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "register client with endpoint of other thing",
+			key:  thingID2,
+			reg:  lwm2m.Registration{Endpoint: endpoint, Objects: objects},
+			id:   "",
+			err:  errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := svc.Register(nil, tc.key, tc.reg, dev)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected id %s got %s\n", tc.desc, tc.id, id))
+	}
+
+	dev2 := newDevice()
+	_, err = svc.Register(nil, thingID, lwm2m.Registration{Endpoint: endpoint, Objects: objects}, dev2)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.True(t, dev.Closed(), "expected re-registration to close the previous device")
+	assert.False(t, dev2.Closed(), "expected re-registration device to be open")
+
+	err = svc.RemoveThing(nil, thingID)
+	assert.Nil(t, err, fmt.Sprintf("remove thing: unexpected error: %s\n", err))
+	assert.True(t, dev2.Closed(), "expected thing removal to close the device")
+}
+
+func TestUpdate(t *testing.T) {
+	svc, _ := newService(pubmocks.NewPublisher())
+
+	err := svc.CreateThing(nil, thingID, lwm2m.Config{Endpoint: endpoint})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	dev := newDevice()
+	id, err := svc.Register(nil, thingID, lwm2m.Registration{Endpoint: endpoint, Lifetime: 50 * time.Millisecond, Objects: objects}, dev)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.Update(nil, wrong, time.Second, nil)
+	assert.True(t, errors.Contains(err, lwm2m.ErrNotRegistered), fmt.Sprintf("update unknown registration: expected %s got %s\n", lwm2m.ErrNotRegistered, err))
+
+	for i := 0; i < 4; i++ {
+		time.Sleep(25 * time.Millisecond)
+		err = svc.Update(nil, id, 0, nil)
+		require.Nil(t, err, fmt.Sprintf("update registration: unexpected error: %s\n", err))
+	}
+	assert.False(t, dev.Closed(), "expected updated registration not to expire")
+
+	assert.Eventually(t, dev.Closed, time.Second, 10*time.Millisecond, "expected registration to expire")
+	err = svc.Update(nil, id, 0, nil)
+	assert.True(t, errors.Contains(err, lwm2m.ErrNotRegistered), fmt.Sprintf("update expired registration: expected %s got %s\n", lwm2m.ErrNotRegistered, err))
+}
+
+func TestDeregister(t *testing.T) {
+	svc, _ := newService(pubmocks.NewPublisher())
+
+	err := svc.CreateThing(nil, thingID, lwm2m.Config{Endpoint: endpoint})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	dev := newDevice()
+	id, err := svc.Register(nil, thingID, lwm2m.Registration{Endpoint: endpoint, Objects: objects}, dev)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "deregister client",
+			id:   id,
+			err:  nil,
+		},
+		{
+			desc: "deregister deregistered client",
+			id:   id,
+			err:  lwm2m.ErrNotRegistered,
+		},
+		{
+			desc: "deregister unknown client",
+			id:   wrong,
+			err:  lwm2m.ErrNotRegistered,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Deregister(nil, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+	assert.True(t, dev.Closed(), "expected deregistration to close the device")
+
+	_, err = svc.Read(nil, adminToken, thingID, "/3/0")
+	assert.True(t, errors.Contains(err, lwm2m.ErrNotRegistered), fmt.Sprintf("read deregistered client: expected %s got %s\n", lwm2m.ErrNotRegistered, err))
+}
+
+func TestObserve(t *testing.T) {
+	pub := pubmocks.NewPublisher()
+	svc, _ := newService(pub)
+
+	err := svc.CreateThing(nil, thingID, lwm2m.Config{Endpoint: endpoint})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ConnectThing(nil, chanID, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cfg := lwm2m.Config{Endpoint: "configured", Observe: []string{"/3303/0/5700", "/5/0"}}
+	err = svc.CreateThing(nil, thingID2, cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	dev := newDevice()
+	id, err := svc.Register(nil, thingID, lwm2m.Registration{Endpoint: endpoint, Objects: objects}, dev)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	dev2 := newDevice()
+	id2, err := svc.Register(nil, thingID2, lwm2m.Registration{Endpoint: cfg.Endpoint, Objects: objects}, dev2)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.Observe(nil, wrong)
+	assert.True(t, errors.Contains(err, lwm2m.ErrNotRegistered), fmt.Sprintf("observe unknown registration: expected %s got %s\n", lwm2m.ErrNotRegistered, err))
+
+	err = svc.Observe(nil, id)
+	assert.Nil(t, err, fmt.Sprintf("observe known objects: unexpected error: %s\n", err))
+	assert.ElementsMatch(t, []string{"/3/0", "/3303/0"}, dev.Observed(), "expected the instances of the known objects to be observed")
+
+	err = svc.Observe(nil, id2)
+	assert.Nil(t, err, fmt.Sprintf("observe configured paths: unexpected error: %s\n", err))
+	assert.ElementsMatch(t, []string{"/3303/0/5700"}, dev2.Observed(), "expected the configured paths of the reported objects to be observed")
+
+	err = svc.Update(nil, id, 0, append(objects, "/3301/0"))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Observe(nil, id)
+	assert.NotNil(t, err, "observe unavailable object: expected error")
+
+	err = dev.Notify("/3303/0", lwm2m.Content{Format: lwm2m.TLV, Payload: temperatureTLV})
+	assert.Nil(t, err, fmt.Sprintf("notify TLV: unexpected error: %s\n", err))
+	err = dev.Notify("/3303/0", lwm2m.Content{Format: lwm2m.SenMLJSON, Payload: temperatureSenML})
+	assert.Nil(t, err, fmt.Sprintf("notify SenML: unexpected error: %s\n", err))
+	err = dev2.Notify("/3303/0/5700", lwm2m.Content{Format: lwm2m.TextPlain, Payload: []byte("23")})
+	assert.Nil(t, err, fmt.Sprintf("notify thing without channels: unexpected error: %s\n", err))
+	err = dev.Notify("/3303/0", lwm2m.Content{Format: lwm2m.TLV, Payload: temperatureTLV[:5]})
+	assert.True(t, errors.Contains(err, lwm2m.ErrMalformedPayload), fmt.Sprintf("notify malformed TLV: expected %s got %s\n", lwm2m.ErrMalformedPayload, err))
+
+	msgs := pub.Messages(chanID, "")
+	require.Len(t, msgs, 2, fmt.Sprintf("expected 2 messages got %d\n", len(msgs)))
+
+	expected := []map[string]interface{}{
+		{"3303/0/5700": 21.5, "3303/0/5701": "Cel"},
+		{"3303/0/5700": 22.5, "3303/0/5701": "Cel"},
+	}
+	for i, msg := range msgs {
+		assert.Equal(t, thingID, msg.Publisher, fmt.Sprintf("expected publisher %s got %s\n", thingID, msg.Publisher))
+		pack, err := senml.Decode(msg.Payload, senml.JSON)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		_, err = senml.Normalize(pack)
+		assert.Nil(t, err, fmt.Sprintf("expected valid SenML: %s\n", err))
+		vals := values(t, pack.Records)
+		assert.Equal(t, expected[i], vals, fmt.Sprintf("expected values %v got %v\n", expected[i], vals))
+	}
+}
+
+func TestRead(t *testing.T) {
+	svc, _ := newService(pubmocks.NewPublisher())
+
+	err := svc.CreateThing(nil, thingID, lwm2m.Config{Endpoint: endpoint})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.CreateThing(nil, thingID2, lwm2m.Config{Endpoint: "other"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	_, err = svc.Register(nil, thingID, lwm2m.Registration{Endpoint: endpoint, Objects: objects}, newDevice())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		thingID string
+		path    string
+		values  map[string]interface{}
+		err     error
+	}{
+		{
+			desc:    "read object instance as TLV",
+			token:   adminToken,
+			thingID: thingID,
+			path:    "/3/0",
+			values: map[string]interface{}{
+				"3/0/0":   "OMA",
+				"3/0/6/0": 1.0,
+				"3/0/6/1": 5.0,
+				"3/0/9":   100.0,
+			},
+			err: nil,
+		},
+		{
+			desc:    "read resource as TLV",
+			token:   adminToken,
+			thingID: thingID,
+			path:    "3/0/0",
+			values:  map[string]interface{}{"3/0/0": "OMA"},
+			err:     nil,
+		},
+		{
+			desc:    "read object instance of IPSO object as TLV",
+			token:   adminToken,
+			thingID: thingID,
+			path:    "/3303/0",
+			values:  map[string]interface{}{"3303/0/5700": 21.5, "3303/0/5701": "Cel"},
+			err:     nil,
+		},
+		{
+			desc:    "read resource as text",
+			token:   adminToken,
+			thingID: thingID,
+			path:    "/3/0/9",
+			values:  map[string]interface{}{"3/0/9": 87.0},
+			err:     nil,
+		},
+		{
+			desc:    "read resource as SenML",
+			token:   adminToken,
+			thingID: thingID,
+			path:    "/3303/0/5700",
+			values:  map[string]interface{}{"3303/0/5700": 22.5},
+			err:     nil,
+		},
+		{
+			desc:    "read object instance as SenML CBOR",
+			token:   adminToken,
+			thingID: thingID,
+			path:    "/3303/1",
+			values:  map[string]interface{}{"3303/1/5700": 23.5},
+			err:     nil,
+		},
+		{
+			desc:    "read unsupported content format",
+			token:   adminToken,
+			thingID: thingID,
+			path:    "/3304/0",
+			err:     lwm2m.ErrUnsupportedFormat,
+		},
+		{
+			desc:    "read malformed path",
+			token:   adminToken,
+			thingID: thingID,
+			path:    "/3/0/manufacturer",
+			err:     lwm2m.ErrMalformedPath,
+		},
+		{
+			desc:    "read unavailable path",
+			token:   adminToken,
+			thingID: thingID,
+			path:    "/5/0",
+			err:     mocks.ErrUnreachable,
+		},
+		{
+			desc:    "read unregistered client",
+			token:   adminToken,
+			thingID: thingID2,
+			path:    "/3/0",
+			err:     lwm2m.ErrNotRegistered,
+		},
+		{
+			desc:    "read unknown thing",
+			token:   adminToken,
+			thingID: wrong,
+			path:    "/3/0",
+			err:     lwm2m.ErrNotFound,
+		},
+		{
+			desc:    "read owned thing",
+			token:   userToken,
+			thingID: thingID,
+			path:    "/3/0/9",
+			values:  map[string]interface{}{"3/0/9": 87.0},
+			err:     nil,
+		},
+		{
+			desc:    "read thing owned by other user",
+			token:   otherToken,
+			thingID: thingID,
+			path:    "/3/0/9",
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "read with invalid token",
+			token:   wrong,
+			thingID: thingID,
+			path:    "/3/0/9",
+			err:     errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		pack, err := svc.Read(nil, tc.token, tc.thingID, tc.path)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			vals := values(t, pack.Records)
+			assert.Equal(t, tc.values, vals, fmt.Sprintf("%s: expected values %v got %v\n", tc.desc, tc.values, vals))
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	svc, _ := newService(pubmocks.NewPublisher())
+
+	err := svc.CreateThing(nil, thingID, lwm2m.Config{Endpoint: endpoint})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	dev := newDevice()
+	_, err = svc.Register(nil, thingID, lwm2m.Registration{Endpoint: endpoint, Objects: objects}, dev)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		path    string
+		value   interface{}
+		content lwm2m.Content
+		err     error
+	}{
+		{
+			desc:    "write string resource",
+			token:   adminToken,
+			path:    "/3/0/14",
+			value:   "+02:00",
+			content: lwm2m.Content{Format: lwm2m.TextPlain, Payload: []byte("+02:00")},
+			err:     nil,
+		},
+		{
+			desc:    "write time resource",
+			token:   adminToken,
+			path:    "/3/0/13",
+			value:   1650000000.0,
+			content: lwm2m.Content{Format: lwm2m.TextPlain, Payload: []byte("1650000000")},
+			err:     nil,
+		},
+		{
+			desc:    "write float resource",
+			token:   adminToken,
+			path:    "/3306/0/5821",
+			value:   0.25,
+			content: lwm2m.Content{Format: lwm2m.TextPlain, Payload: []byte("0.25")},
+			err:     nil,
+		},
+		{
+			desc:    "write boolean resource",
+			token:   adminToken,
+			path:    "/3311/0/5850",
+			value:   true,
+			content: lwm2m.Content{Format: lwm2m.TextPlain, Payload: []byte("1")},
+			err:     nil,
+		},
+		{
+			desc:    "write opaque resource",
+			token:   adminToken,
+			path:    "/6/0/4",
+			value:   "AQI=",
+			content: lwm2m.Content{Format: lwm2m.Opaque, Payload: []byte{1, 2}},
+			err:     nil,
+		},
+		{
+			desc:    "write unknown resource",
+			token:   adminToken,
+			path:    "/10241/0/1",
+			value:   12.0,
+			content: lwm2m.Content{Format: lwm2m.TextPlain, Payload: []byte("12")},
+			err:     nil,
+		},
+		{
+			desc:  "write fraction to integer resource",
+			token: adminToken,
+			path:  "/3/0/13",
+			value: 1.5,
+			err:   lwm2m.ErrMalformedValue,
+		},
+		{
+			desc:  "write string to boolean resource",
+			token: adminToken,
+			path:  "/3311/0/5850",
+			value: "on",
+			err:   lwm2m.ErrMalformedValue,
+		},
+		{
+			desc:  "write executable resource",
+			token: adminToken,
+			path:  "/3/0/4",
+			value: "",
+			err:   lwm2m.ErrMalformedValue,
+		},
+		{
+			desc:  "write object instance",
+			token: adminToken,
+			path:  "/3/0",
+			value: "",
+			err:   lwm2m.ErrMalformedPath,
+		},
+		{
+			desc:    "write resource of owned thing",
+			token:   userToken,
+			path:    "/3/0/14",
+			value:   "+01:00",
+			content: lwm2m.Content{Format: lwm2m.TextPlain, Payload: []byte("+01:00")},
+			err:     nil,
+		},
+		{
+			desc:  "write resource of thing owned by other user",
+			token: otherToken,
+			path:  "/3/0/14",
+			value: "+03:00",
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "write with invalid token",
+			token: wrong,
+			path:  "/3/0/14",
+			value: "+03:00",
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Write(nil, tc.token, thingID, tc.path, tc.value)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			c, ok := dev.Written(tc.path)
+			assert.True(t, ok, fmt.Sprintf("%s: expected %s to be written", tc.desc, tc.path))
+			assert.Equal(t, tc.content, c, fmt.Sprintf("%s: expected content %v got %v\n", tc.desc, tc.content, c))
+		}
+	}
+}
+
+func TestExecute(t *testing.T) {
+	svc, _ := newService(pubmocks.NewPublisher())
+
+	err := svc.CreateThing(nil, thingID, lwm2m.Config{Endpoint: endpoint})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	dev := newDevice()
+	_, err = svc.Register(nil, thingID, lwm2m.Registration{Endpoint: endpoint, Objects: objects}, dev)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		thingID string
+		path    string
+		args    string
+		err     error
+	}{
+		{
+			desc:    "execute resource",
+			token:   adminToken,
+			thingID: thingID,
+			path:    "/3/0/4",
+			args:    "0='delay'",
+			err:     nil,
+		},
+		{
+			desc:    "execute object instance",
+			token:   adminToken,
+			thingID: thingID,
+			path:    "/3/0",
+			err:     lwm2m.ErrMalformedPath,
+		},
+		{
+			desc:    "execute resource of unknown thing",
+			token:   adminToken,
+			thingID: wrong,
+			path:    "/3/0/4",
+			err:     lwm2m.ErrNotFound,
+		},
+		{
+			desc:    "execute resource of thing owned by other user",
+			token:   otherToken,
+			thingID: thingID,
+			path:    "/3/0/4",
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "execute with invalid token",
+			token:   wrong,
+			thingID: thingID,
+			path:    "/3/0/4",
+			err:     errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Execute(nil, tc.token, tc.thingID, tc.path, tc.args)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	args, ok := dev.Executed("/3/0/4")
+	assert.True(t, ok, "expected /3/0/4 to be executed")
+	assert.Equal(t, "0='delay'", args, fmt.Sprintf("expected arguments 0='delay' got %s\n", args))
+}
+
+func TestParseObjects(t *testing.T) {
+	cases := []struct {
+		desc    string
+		links   string
+		objects []string
+		err     error
+	}{
+		{
+			desc:    "parse objects",
+			links:   `</>;rt="oma.lwm2m";ct=11543,</1/0>,</3/0>,</3303/0>,</3303/1>,</3304>`,
+			objects: []string{"/1/0", "/3/0", "/3303/0", "/3303/1", "/3304"},
+			err:     nil,
+		},
+		{
+			desc:    "parse objects on alternate path",
+			links:   `</lwm2m>;rt="oma.lwm2m", </lwm2m/1/0>, </lwm2m/3/0>;ver=1.1`,
+			objects: []string{"/1/0", "/3/0"},
+			err:     nil,
+		},
+		{
+			desc:    "parse malformed link",
+			links:   `</1/0>,<3/0`,
+			objects: nil,
+			err:     lwm2m.ErrMalformedPayload,
+		},
+		{
+			desc:    "parse resource link",
+			links:   `</1/0>,</3/0/1>`,
+			objects: nil,
+			err:     lwm2m.ErrMalformedPayload,
+		},
+	}
+
+	for _, tc := range cases {
+		objs, err := lwm2m.ParseObjects(tc.links)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.objects, objs, fmt.Sprintf("%s: expected objects %v got %v\n", tc.desc, tc.objects, objs))
+	}
+}
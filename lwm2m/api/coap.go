@@ -0,0 +1,234 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/go-coap/v2/message/codes"
+	"github.com/plgd-dev/go-coap/v2/mux"
+)
+
+const (
+	rdPath = "/rd"
+
+	endpointQuery = "ep"
+	lifetimeQuery = "lt"
+	versionQuery  = "lwm2m"
+	bindingQuery  = "b"
+	authQuery     = "auth"
+)
+
+var errMalformedQuery = errors.New("malformed registration query")
+
+// MakeCoAPHandler returns the handler of the LwM2M registration interface.
+// The operations on the registered clients time out after the timeout.
+func MakeCoAPHandler(svc lwm2m.Service, timeout time.Duration, logger logger.Logger) mux.HandlerFunc {
+	rh := registrationHandler{
+		svc:     svc,
+		timeout: timeout,
+		logger:  logger,
+	}
+
+	return rh.handle
+}
+
+type registrationHandler struct {
+	svc     lwm2m.Service
+	timeout time.Duration
+	logger  logger.Logger
+}
+
+func (rh registrationHandler) handle(w mux.ResponseWriter, m *mux.Message) {
+	resp := message.Message{
+		Code:    codes.NotFound,
+		Token:   m.Token,
+		Context: m.Context,
+		Options: make(message.Options, 0, 16),
+	}
+
+	path, err := m.Options.Path()
+	if err != nil {
+		rh.send(w, &resp)
+		return
+	}
+
+	var id string
+	switch {
+	case path == rdPath && m.Code == codes.POST:
+		id, err = rh.register(w, m, &resp)
+	case strings.HasPrefix(path, rdPath+"/") && m.Code == codes.POST:
+		id = strings.TrimPrefix(path, rdPath+"/")
+		err = rh.update(m, id)
+		resp.Code = codes.Changed
+	case strings.HasPrefix(path, rdPath+"/") && m.Code == codes.DELETE:
+		err = rh.svc.Deregister(m.Context, strings.TrimPrefix(path, rdPath+"/"))
+		resp.Code = codes.Deleted
+	default:
+		rh.send(w, &resp)
+		return
+	}
+
+	if err != nil {
+		switch {
+		case errors.Contains(err, errMalformedQuery),
+			errors.Contains(err, lwm2m.ErrMalformedRegistration),
+			errors.Contains(err, lwm2m.ErrMalformedPayload):
+			resp.Code = codes.BadRequest
+		case errors.Contains(err, errors.ErrAuthentication):
+			resp.Code = codes.Unauthorized
+		case errors.Contains(err, lwm2m.ErrNotFound),
+			errors.Contains(err, errors.ErrAuthorization):
+			resp.Code = codes.Forbidden
+		case errors.Contains(err, lwm2m.ErrNotRegistered):
+			resp.Code = codes.NotFound
+		default:
+			resp.Code = codes.InternalServerError
+		}
+		rh.send(w, &resp)
+		return
+	}
+	rh.send(w, &resp)
+
+	// The objects are observed once the client has received the response.
+	if resp.Code == codes.Created || resp.Code == codes.Changed {
+		go rh.observe(id)
+	}
+}
+
+func (rh registrationHandler) register(w mux.ResponseWriter, m *mux.Message, resp *message.Message) (string, error) {
+	q, err := queries(m)
+	if err != nil {
+		return "", err
+	}
+
+	reg := lwm2m.Registration{
+		Endpoint: q[endpointQuery],
+		Version:  q[versionQuery],
+		Binding:  q[bindingQuery],
+	}
+	if reg.Lifetime, err = lifetime(q); err != nil {
+		return "", err
+	}
+	if reg.Objects, err = objects(m); err != nil {
+		return "", err
+	}
+
+	dev := NewDevice(w.Client(), rh.timeout, rh.logger)
+	id, err := rh.svc.Register(m.Context, thingKeyOf(w.Client(), q), reg, dev)
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, len(rdPath)+len(id)+8)
+	opts, _, err := resp.Options.SetLocationPath(buf, fmt.Sprintf("%s/%s", rdPath, id))
+	if err != nil {
+		return "", err
+	}
+	resp.Options = opts
+	resp.Code = codes.Created
+
+	return id, nil
+}
+
+func (rh registrationHandler) update(m *mux.Message, id string) error {
+	q, err := queries(m)
+	if err != nil {
+		return err
+	}
+
+	lt, err := lifetime(q)
+	if err != nil {
+		return err
+	}
+
+	objs, err := objects(m)
+	if err != nil {
+		return err
+	}
+
+	return rh.svc.Update(m.Context, id, lt, objs)
+}
+
+func (rh registrationHandler) observe(id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), rh.timeout)
+	defer cancel()
+
+	if err := rh.svc.Observe(ctx, id); err != nil {
+		rh.logger.Warn(fmt.Sprintf("Failed to observe objects of registration %s: %s", id, err))
+	}
+}
+
+func (rh registrationHandler) send(w mux.ResponseWriter, resp *message.Message) {
+	if err := w.Client().WriteMessage(resp); err != nil {
+		rh.logger.Warn(fmt.Sprintf("Can't set response: %s", err))
+	}
+}
+
+func queries(m *mux.Message) (map[string]string, error) {
+	q := make(map[string]string)
+
+	qs, err := m.Options.Queries()
+	if err != nil {
+		// The queries are optional on update.
+		return q, nil
+	}
+
+	for _, s := range qs {
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 {
+			return nil, errMalformedQuery
+		}
+		q[kv[0]] = kv[1]
+	}
+
+	return q, nil
+}
+
+// thingKeyOf returns the key of the thing authenticated by the DTLS
+// handshake of the client connection or, over plain CoAP, the key sent in the
+// auth query of the registration.
+func thingKeyOf(c mux.Client, q map[string]string) string {
+	if key, ok := c.Context().Value(thingKey{}).(string); ok {
+		return key
+	}
+
+	return q[authQuery]
+}
+
+func lifetime(q map[string]string) (time.Duration, error) {
+	lt, ok := q[lifetimeQuery]
+	if !ok {
+		return 0, nil
+	}
+
+	secs, err := strconv.ParseUint(lt, 10, 32)
+	if err != nil {
+		return 0, errMalformedQuery
+	}
+
+	return time.Duration(secs) * time.Second, nil
+}
+
+func objects(m *mux.Message) ([]string, error) {
+	if m.Body == nil {
+		return nil, nil
+	}
+
+	b, err := ioutil.ReadAll(m.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return lwm2m.ParseObjects(string(b))
+}
@@ -0,0 +1,152 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/plgd-dev/go-coap/v2/message"
+	"github.com/plgd-dev/go-coap/v2/message/codes"
+	"github.com/plgd-dev/go-coap/v2/mux"
+)
+
+// errOperation indicates an operation rejected by the client.
+var errOperation = errors.New("lwm2m operation failed")
+
+var _ lwm2m.Device = (*device)(nil)
+
+type device struct {
+	client       mux.Client
+	timeout      time.Duration
+	logger       logger.Logger
+	mu           sync.Mutex
+	observations []mux.Observation
+}
+
+// NewDevice returns the device performing the operations over the CoAP
+// connection of the client.
+func NewDevice(client mux.Client, timeout time.Duration, logger logger.Logger) lwm2m.Device {
+	return &device{
+		client:  client,
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+func (d *device) Read(ctx context.Context, path string) (lwm2m.Content, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	resp, err := d.client.Get(ctx, path)
+	if err != nil {
+		return lwm2m.Content{}, err
+	}
+	if resp.Code != codes.Content {
+		return lwm2m.Content{}, errors.Wrap(errOperation, errors.New(resp.Code.String()))
+	}
+
+	return content(resp)
+}
+
+func (d *device) Write(ctx context.Context, path string, c lwm2m.Content) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	resp, err := d.client.Put(ctx, path, message.MediaType(c.Format), bytes.NewReader(c.Payload))
+	if err != nil {
+		return err
+	}
+
+	return check(resp, codes.Changed)
+}
+
+func (d *device) Execute(ctx context.Context, path, args string) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	resp, err := d.client.Post(ctx, path, message.TextPlain, bytes.NewReader([]byte(args)))
+	if err != nil {
+		return err
+	}
+
+	return check(resp, codes.Changed)
+}
+
+func (d *device) Observe(ctx context.Context, path string, notify func(lwm2m.Content) error) error {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	obs, err := d.client.Observe(ctx, path, func(n *message.Message) {
+		c, err := content(n)
+		if err == nil {
+			err = notify(c)
+		}
+		if err != nil {
+			d.logger.Warn(fmt.Sprintf("Failed to publish notification of %s: %s", path, err))
+		}
+	})
+	if err != nil {
+		return errors.Wrap(errOperation, err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.observations = append(d.observations, obs)
+
+	return nil
+}
+
+func (d *device) Close() error {
+	d.mu.Lock()
+	obs := d.observations
+	d.observations = nil
+	d.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+
+	var ret error
+	for _, o := range obs {
+		if err := o.Cancel(ctx); err != nil && ret == nil {
+			ret = err
+		}
+	}
+
+	return ret
+}
+
+// content returns the content of the response or notification. The content
+// format defaults to plain text, as per the LwM2M specification.
+func content(msg *message.Message) (lwm2m.Content, error) {
+	c := lwm2m.Content{Format: lwm2m.TextPlain}
+	if cf, err := msg.Options.ContentFormat(); err == nil {
+		c.Format = uint16(cf)
+	}
+
+	if msg.Body != nil {
+		b, err := ioutil.ReadAll(msg.Body)
+		if err != nil {
+			return lwm2m.Content{}, err
+		}
+		c.Payload = b
+	}
+
+	return c, nil
+}
+
+func check(resp *message.Message, expected codes.Code) error {
+	if resp.Code != expected {
+		return errors.Wrap(errOperation, errors.New(resp.Code.String()))
+	}
+
+	return nil
+}
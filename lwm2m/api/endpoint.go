@@ -0,0 +1,57 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func readEndpoint(svc lwm2m.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(readReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pack, err := svc.Read(ctx, req.token, req.thingID, req.path)
+		if err != nil {
+			return nil, err
+		}
+
+		return readRes(pack.Records), nil
+	}
+}
+
+func writeEndpoint(svc lwm2m.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(writeReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.Write(ctx, req.token, req.thingID, req.Path, req.Value); err != nil {
+			return nil, err
+		}
+
+		return operationRes{}, nil
+	}
+}
+
+func executeEndpoint(svc lwm2m.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(executeReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.Execute(ctx, req.token, req.thingID, req.Path, req.Args); err != nil {
+			return nil, err
+		}
+
+		return operationRes{}, nil
+	}
+}
@@ -0,0 +1,390 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+	"github.com/MainfluxLabs/mainflux/lwm2m/api"
+	"github.com/MainfluxLabs/mainflux/lwm2m/mocks"
+	pubmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/MainfluxLabs/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	thingID     = "thingID-1"
+	thingID2    = "thingID-2"
+	endpoint    = "urn:imei:490154203237511"
+	contentType = "application/json"
+	wrong       = "wrong"
+	adminToken  = "admin@example.com"
+	userToken   = "user@example.com"
+	otherToken  = "other@example.com"
+)
+
+var (
+	admin     = users.User{ID: "admin-id", Email: adminToken}
+	user      = users.User{ID: "user-id", Email: userToken}
+	otherUser = users.User{ID: "other-id", Email: otherToken}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        string
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, strings.NewReader(tr.body))
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+func newService(t *testing.T, dev lwm2m.Device) lwm2m.Service {
+	auth := pubmocks.NewAuthService(admin.ID, []users.User{admin, user, otherUser})
+	thingc := pubmocks.NewThingsServiceClient(map[string]string{user.ID: thingID}, nil)
+	svc := lwm2m.New(pubmocks.NewPublisher(), auth, thingc, mocks.NewThingRepository(), uuid.NewMock())
+
+	err := svc.CreateThing(nil, thingID, lwm2m.Config{Endpoint: endpoint})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.CreateThing(nil, thingID2, lwm2m.Config{Endpoint: "other"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	_, err = svc.Register(nil, thingID, lwm2m.Registration{Endpoint: endpoint, Objects: []string{"/3/0"}}, dev)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	return svc
+}
+
+func newHTTPServer(svc lwm2m.Service) *httptest.Server {
+	return httptest.NewServer(api.MakeHandler(svc, logger.NewMock()))
+}
+
+func TestRead(t *testing.T) {
+	dev := mocks.NewDevice(map[string]lwm2m.Content{
+		"/3/0/9": {Format: lwm2m.TextPlain, Payload: []byte("87")},
+	})
+	ts := newHTTPServer(newService(t, dev))
+	defer ts.Close()
+
+	cases := []struct {
+		desc    string
+		token   string
+		thingID string
+		path    string
+		status  int
+		value   float64
+	}{
+		{
+			desc:    "read resource",
+			token:   userToken,
+			thingID: thingID,
+			path:    "/3/0/9",
+			status:  http.StatusOK,
+			value:   87,
+		},
+		{
+			desc:    "read without path",
+			token:   userToken,
+			thingID: thingID,
+			status:  http.StatusBadRequest,
+		},
+		{
+			desc:    "read malformed path",
+			token:   userToken,
+			thingID: thingID,
+			path:    "/device",
+			status:  http.StatusBadRequest,
+		},
+		{
+			desc:    "read unregistered client",
+			token:   adminToken,
+			thingID: thingID2,
+			path:    "/3/0/9",
+			status:  http.StatusNotFound,
+		},
+		{
+			desc:    "read unknown thing",
+			token:   adminToken,
+			thingID: wrong,
+			path:    "/3/0/9",
+			status:  http.StatusNotFound,
+		},
+		{
+			desc:    "read unavailable path",
+			token:   userToken,
+			thingID: thingID,
+			path:    "/3/0/0",
+			status:  http.StatusInternalServerError,
+		},
+		{
+			desc:    "read without token",
+			thingID: thingID,
+			path:    "/3/0/9",
+			status:  http.StatusUnauthorized,
+		},
+		{
+			desc:    "read with invalid token",
+			token:   wrong,
+			thingID: thingID,
+			path:    "/3/0/9",
+			status:  http.StatusUnauthorized,
+		},
+		{
+			desc:    "read thing owned by other user",
+			token:   otherToken,
+			thingID: thingID,
+			path:    "/3/0/9",
+			status:  http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		q := url.Values{}
+		if tc.path != "" {
+			q.Set("path", tc.path)
+		}
+
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/things/%s/read?%s", ts.URL, tc.thingID, q.Encode()),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		if tc.status == http.StatusOK {
+			var recs []senml.Record
+			err := json.NewDecoder(res.Body).Decode(&recs)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			require.Len(t, recs, 1, fmt.Sprintf("%s: expected one record got %d", tc.desc, len(recs)))
+			assert.Equal(t, "3/0/9", recs[0].Name, fmt.Sprintf("%s: expected name 3/0/9 got %s", tc.desc, recs[0].Name))
+			require.NotNil(t, recs[0].Value, fmt.Sprintf("%s: expected value", tc.desc))
+			assert.Equal(t, tc.value, *recs[0].Value, fmt.Sprintf("%s: expected value %f got %f", tc.desc, tc.value, *recs[0].Value))
+		}
+		res.Body.Close()
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dev := mocks.NewDevice(map[string]lwm2m.Content{})
+	ts := newHTTPServer(newService(t, dev))
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		thingID     string
+		contentType string
+		body        string
+		status      int
+	}{
+		{
+			desc:        "write resource",
+			token:       userToken,
+			thingID:     thingID,
+			contentType: contentType,
+			body:        `{"path": "/3/0/14", "value": "+02:00"}`,
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:        "write malformed value",
+			token:       userToken,
+			thingID:     thingID,
+			contentType: contentType,
+			body:        `{"path": "/3/0/13", "value": "now"}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "write without value",
+			token:       userToken,
+			thingID:     thingID,
+			contentType: contentType,
+			body:        `{"path": "/3/0/14"}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "write without path",
+			token:       userToken,
+			thingID:     thingID,
+			contentType: contentType,
+			body:        `{"value": "+02:00"}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "write malformed JSON",
+			token:       userToken,
+			thingID:     thingID,
+			contentType: contentType,
+			body:        `{"path": "/3/0/14"`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "write without content type",
+			token:       userToken,
+			thingID:     thingID,
+			contentType: "",
+			body:        `{"path": "/3/0/14", "value": "+02:00"}`,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "write unregistered client",
+			token:       adminToken,
+			thingID:     thingID2,
+			contentType: contentType,
+			body:        `{"path": "/3/0/14", "value": "+02:00"}`,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "write without token",
+			thingID:     thingID,
+			contentType: contentType,
+			body:        `{"path": "/3/0/14", "value": "+03:00"}`,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "write with invalid token",
+			token:       wrong,
+			thingID:     thingID,
+			contentType: contentType,
+			body:        `{"path": "/3/0/14", "value": "+03:00"}`,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "write thing owned by other user",
+			token:       otherToken,
+			thingID:     thingID,
+			contentType: contentType,
+			body:        `{"path": "/3/0/14", "value": "+03:00"}`,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/things/%s/write", ts.URL, tc.thingID),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        tc.body,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		res.Body.Close()
+	}
+
+	c, ok := dev.Written("/3/0/14")
+	assert.True(t, ok, "expected /3/0/14 to be written")
+	assert.Equal(t, "+02:00", string(c.Payload), fmt.Sprintf("expected written value +02:00 got %s", c.Payload))
+}
+
+func TestExecute(t *testing.T) {
+	dev := mocks.NewDevice(map[string]lwm2m.Content{})
+	ts := newHTTPServer(newService(t, dev))
+	defer ts.Close()
+
+	cases := []struct {
+		desc    string
+		token   string
+		thingID string
+		body    string
+		status  int
+	}{
+		{
+			desc:    "execute resource",
+			token:   userToken,
+			thingID: thingID,
+			body:    `{"path": "/3/0/4"}`,
+			status:  http.StatusNoContent,
+		},
+		{
+			desc:    "execute object instance",
+			token:   userToken,
+			thingID: thingID,
+			body:    `{"path": "/3/0"}`,
+			status:  http.StatusBadRequest,
+		},
+		{
+			desc:    "execute without path",
+			token:   userToken,
+			thingID: thingID,
+			body:    `{}`,
+			status:  http.StatusBadRequest,
+		},
+		{
+			desc:    "execute resource of unknown thing",
+			token:   adminToken,
+			thingID: wrong,
+			body:    `{"path": "/3/0/4"}`,
+			status:  http.StatusNotFound,
+		},
+		{
+			desc:    "execute without token",
+			thingID: thingID,
+			body:    `{"path": "/3/0/4"}`,
+			status:  http.StatusUnauthorized,
+		},
+		{
+			desc:    "execute with invalid token",
+			token:   wrong,
+			thingID: thingID,
+			body:    `{"path": "/3/0/4"}`,
+			status:  http.StatusUnauthorized,
+		},
+		{
+			desc:    "execute thing owned by other user",
+			token:   otherToken,
+			thingID: thingID,
+			body:    `{"path": "/3/0/4"}`,
+			status:  http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/%s/execute", ts.URL, tc.thingID),
+			contentType: contentType,
+			token:       tc.token,
+			body:        tc.body,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		res.Body.Close()
+	}
+
+	_, ok := dev.Executed("/3/0/4")
+	assert.True(t, ok, "expected /3/0/4 to be executed")
+}
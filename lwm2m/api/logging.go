@@ -0,0 +1,187 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+	"github.com/MainfluxLabs/senml"
+)
+
+var _ lwm2m.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger logger.Logger
+	svc    lwm2m.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc lwm2m.Service, logger logger.Logger) lwm2m.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm loggingMiddleware) CreateThing(ctx context.Context, thingID string, cfg lwm2m.Config) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("create_thing for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateThing(ctx, thingID, cfg)
+}
+
+func (lm loggingMiddleware) UpdateThing(ctx context.Context, thingID string, cfg lwm2m.Config) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("update_thing for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateThing(ctx, thingID, cfg)
+}
+
+func (lm loggingMiddleware) RemoveThing(ctx context.Context, thingID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("remove_thing for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveThing(ctx, thingID)
+}
+
+func (lm loggingMiddleware) ConnectThing(ctx context.Context, chanID, thingID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("connect_thing for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ConnectThing(ctx, chanID, thingID)
+}
+
+func (lm loggingMiddleware) DisconnectThing(ctx context.Context, chanID, thingID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("disconnect_thing for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DisconnectThing(ctx, chanID, thingID)
+}
+
+func (lm loggingMiddleware) Register(ctx context.Context, key string, reg lwm2m.Registration, dev lwm2m.Device) (id string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("register of endpoint %s took %s to complete", reg.Endpoint, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Register(ctx, key, reg, dev)
+}
+
+func (lm loggingMiddleware) Update(ctx context.Context, id string, lifetime time.Duration, objects []string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("update of registration %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Update(ctx, id, lifetime, objects)
+}
+
+func (lm loggingMiddleware) Deregister(ctx context.Context, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("deregister of registration %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Deregister(ctx, id)
+}
+
+func (lm loggingMiddleware) Observe(ctx context.Context, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("observe of registration %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Observe(ctx, id)
+}
+
+func (lm loggingMiddleware) Read(ctx context.Context, token, thingID, path string) (pack senml.Pack, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("read of %s for thing %s took %s to complete", path, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Read(ctx, token, thingID, path)
+}
+
+func (lm loggingMiddleware) Write(ctx context.Context, token, thingID, path string, value interface{}) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("write of %s for thing %s took %s to complete", path, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Write(ctx, token, thingID, path, value)
+}
+
+func (lm loggingMiddleware) Execute(ctx context.Context, token, thingID, path, args string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("execute of %s for thing %s took %s to complete", path, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Execute(ctx, token, thingID, path, args)
+}
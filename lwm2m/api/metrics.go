@@ -0,0 +1,140 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+	"github.com/MainfluxLabs/senml"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ lwm2m.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     lwm2m.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc lwm2m.Service, counter metrics.Counter, latency metrics.Histogram) lwm2m.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) CreateThing(ctx context.Context, thingID string, cfg lwm2m.Config) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_thing").Add(1)
+		mm.latency.With("method", "create_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateThing(ctx, thingID, cfg)
+}
+
+func (mm *metricsMiddleware) UpdateThing(ctx context.Context, thingID string, cfg lwm2m.Config) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_thing").Add(1)
+		mm.latency.With("method", "update_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateThing(ctx, thingID, cfg)
+}
+
+func (mm *metricsMiddleware) RemoveThing(ctx context.Context, thingID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_thing").Add(1)
+		mm.latency.With("method", "remove_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveThing(ctx, thingID)
+}
+
+func (mm *metricsMiddleware) ConnectThing(ctx context.Context, chanID, thingID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "connect_thing").Add(1)
+		mm.latency.With("method", "connect_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ConnectThing(ctx, chanID, thingID)
+}
+
+func (mm *metricsMiddleware) DisconnectThing(ctx context.Context, chanID, thingID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "disconnect_thing").Add(1)
+		mm.latency.With("method", "disconnect_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DisconnectThing(ctx, chanID, thingID)
+}
+
+func (mm *metricsMiddleware) Register(ctx context.Context, key string, reg lwm2m.Registration, dev lwm2m.Device) (string, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "register").Add(1)
+		mm.latency.With("method", "register").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Register(ctx, key, reg, dev)
+}
+
+func (mm *metricsMiddleware) Update(ctx context.Context, id string, lifetime time.Duration, objects []string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update").Add(1)
+		mm.latency.With("method", "update").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Update(ctx, id, lifetime, objects)
+}
+
+func (mm *metricsMiddleware) Deregister(ctx context.Context, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "deregister").Add(1)
+		mm.latency.With("method", "deregister").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Deregister(ctx, id)
+}
+
+func (mm *metricsMiddleware) Observe(ctx context.Context, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "observe").Add(1)
+		mm.latency.With("method", "observe").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Observe(ctx, id)
+}
+
+func (mm *metricsMiddleware) Read(ctx context.Context, token, thingID, path string) (senml.Pack, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "read").Add(1)
+		mm.latency.With("method", "read").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Read(ctx, token, thingID, path)
+}
+
+func (mm *metricsMiddleware) Write(ctx context.Context, token, thingID, path string, value interface{}) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "write").Add(1)
+		mm.latency.With("method", "write").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Write(ctx, token, thingID, path, value)
+}
+
+func (mm *metricsMiddleware) Execute(ctx context.Context, token, thingID, path, args string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "execute").Add(1)
+		mm.latency.With("method", "execute").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Execute(ctx, token, thingID, path, args)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+)
+
+type readReq struct {
+	token   string
+	thingID string
+	path    string
+}
+
+func (req readReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.path == "" {
+		return lwm2m.ErrMalformedPath
+	}
+
+	return nil
+}
+
+type writeReq struct {
+	token   string
+	thingID string
+	Path    string      `json:"path"`
+	Value   interface{} `json:"value"`
+}
+
+func (req writeReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Path == "" {
+		return lwm2m.ErrMalformedPath
+	}
+
+	if req.Value == nil {
+		return lwm2m.ErrMalformedValue
+	}
+
+	return nil
+}
+
+type executeReq struct {
+	token   string
+	thingID string
+	Path    string `json:"path"`
+	Args    string `json:"args,omitempty"`
+}
+
+func (req executeReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Path == "" {
+		return lwm2m.ErrMalformedPath
+	}
+
+	return nil
+}
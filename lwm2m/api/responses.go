@@ -0,0 +1,47 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/senml"
+)
+
+var (
+	_ mainflux.Response = (*readRes)(nil)
+	_ mainflux.Response = (*operationRes)(nil)
+)
+
+// readRes contains the records of the resources read, encoded as SenML.
+type readRes []senml.Record
+
+func (res readRes) Code() int {
+	return http.StatusOK
+}
+
+func (res readRes) Headers() map[string]string {
+	return map[string]string{
+		"Content-Type": senmlType,
+	}
+}
+
+func (res readRes) Empty() bool {
+	return false
+}
+
+type operationRes struct{}
+
+func (res operationRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res operationRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res operationRes) Empty() bool {
+	return true
+}
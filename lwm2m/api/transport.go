@@ -0,0 +1,150 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	contentType = "application/json"
+	senmlType   = "application/senml+json"
+	pathKey     = "path"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc lwm2m.Service, logger logger.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
+	r := bone.New()
+
+	r.Get("/things/:id/read", kithttp.NewServer(
+		readEndpoint(svc),
+		decodeRead,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/things/:id/write", kithttp.NewServer(
+		writeEndpoint(svc),
+		decodeWrite,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/things/:id/execute", kithttp.NewServer(
+		executeEndpoint(svc),
+		decodeExecute,
+		encodeResponse,
+		opts...,
+	))
+
+	r.GetFunc("/health", mainflux.Health("lwm2m-adapter"))
+	r.Handle("/metrics", promhttp.Handler())
+
+	return r
+}
+
+func decodeRead(_ context.Context, r *http.Request) (interface{}, error) {
+	req := readReq{
+		token:   apiutil.ExtractBearerToken(r),
+		thingID: bone.GetValue(r, "id"),
+		path:    r.URL.Query().Get(pathKey),
+	}
+
+	return req, nil
+}
+
+func decodeWrite(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := writeReq{
+		token:   apiutil.ExtractBearerToken(r),
+		thingID: bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeExecute(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := executeReq{
+		token:   apiutil.ExtractBearerToken(r),
+		thingID: bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case errors.Contains(err, errors.ErrAuthentication),
+		err == apiutil.ErrBearerToken:
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case err == apiutil.ErrMissingID,
+		errors.Contains(err, apiutil.ErrMalformedEntity),
+		errors.Contains(err, lwm2m.ErrMalformedPath),
+		errors.Contains(err, lwm2m.ErrMalformedValue):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+	case errors.Contains(err, lwm2m.ErrNotFound),
+		errors.Contains(err, lwm2m.ErrNotRegistered):
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,418 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lwm2m
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/senml"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// TLV identifier types.
+const (
+	objectInstance   = 0
+	resourceInstance = 1
+	multipleResource = 2
+	resourceValue    = 3
+)
+
+var (
+	// ErrMalformedPath indicates a malformed object, object instance or
+	// resource path.
+	ErrMalformedPath = errors.New("malformed lwm2m path")
+
+	// ErrMalformedPayload indicates a payload that can't be decoded.
+	ErrMalformedPayload = errors.New("malformed lwm2m payload")
+
+	// ErrMalformedValue indicates a value that can't be written to the
+	// resource.
+	ErrMalformedValue = errors.New("malformed resource value")
+
+	// ErrUnsupportedFormat indicates an unsupported content format.
+	ErrUnsupportedFormat = errors.New("unsupported content format")
+)
+
+// path contains the object, object instance, resource and resource
+// instance IDs, in that order, of which only the object ID is mandatory.
+type path []uint16
+
+func parsePath(p string) (path, error) {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil, ErrMalformedPath
+	}
+
+	parts := strings.Split(p, "/")
+	if len(parts) > 4 {
+		return nil, ErrMalformedPath
+	}
+
+	ids := make(path, len(parts))
+	for i, part := range parts {
+		id, err := strconv.ParseUint(part, 10, 16)
+		if err != nil {
+			return nil, ErrMalformedPath
+		}
+		ids[i] = uint16(id)
+	}
+
+	return ids, nil
+}
+
+func (p path) String() string {
+	return "/" + p.name()
+}
+
+// name returns the path as the SenML record name, e.g. 3303/0/5700.
+func (p path) name() string {
+	ids := make([]string, len(p))
+	for i, id := range p {
+		ids[i] = strconv.Itoa(int(id))
+	}
+
+	return strings.Join(ids, "/")
+}
+
+// resource returns the data type of the resource on the path.
+func (p path) resource() string {
+	if len(p) < 3 {
+		return ""
+	}
+
+	return resourceType(p[0], p[2])
+}
+
+// decode returns the SenML records of the content read from the path.
+func decode(p path, c Content) ([]senml.Record, error) {
+	switch c.Format {
+	case TLV:
+		return decodeTLV(p, c.Payload)
+	case SenMLJSON:
+		var recs []senml.Record
+		if err := json.Unmarshal(c.Payload, &recs); err != nil {
+			return nil, errors.Wrap(ErrMalformedPayload, err)
+		}
+		return resolve(recs)
+	case SenMLCBOR:
+		var recs []senml.Record
+		if err := cbor.Unmarshal(c.Payload, &recs); err != nil {
+			return nil, errors.Wrap(ErrMalformedPayload, err)
+		}
+		return resolve(recs)
+	case TextPlain:
+		if len(p) < 3 {
+			return nil, ErrMalformedPayload
+		}
+		rec, err := decodeText(p, string(c.Payload))
+		if err != nil {
+			return nil, err
+		}
+		return []senml.Record{rec}, nil
+	case Opaque:
+		if len(p) < 3 {
+			return nil, ErrMalformedPayload
+		}
+		v := base64.StdEncoding.EncodeToString(c.Payload)
+		return []senml.Record{{Name: p.name(), DataValue: &v}}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// decodeTLV decodes the OMA-TLV payload read from the path.
+func decodeTLV(p path, b []byte) ([]senml.Record, error) {
+	var recs []senml.Record
+	for len(b) > 0 {
+		if len(b) < 2 {
+			return nil, ErrMalformedPayload
+		}
+		typ := b[0]
+		i := 1
+
+		var id uint16
+		if typ&0x20 == 0 {
+			id = uint16(b[i])
+			i++
+		} else {
+			if len(b) < i+2 {
+				return nil, ErrMalformedPayload
+			}
+			id = binary.BigEndian.Uint16(b[i:])
+			i += 2
+		}
+
+		length := int(typ & 0x07)
+		if n := int(typ>>3) & 0x03; n > 0 {
+			if len(b) < i+n {
+				return nil, ErrMalformedPayload
+			}
+			length = 0
+			for _, l := range b[i : i+n] {
+				length = length<<8 | int(l)
+			}
+			i += n
+		}
+		if len(b) < i+length {
+			return nil, ErrMalformedPayload
+		}
+		val := b[i : i+length]
+		b = b[i+length:]
+
+		// The identifier replaces the path element on its depth, so that
+		// e.g. the resource read from /3/0/0 is decoded as /3/0/0 too.
+		kind := typ >> 6
+		depth := 2
+		switch kind {
+		case objectInstance:
+			depth = 1
+		case resourceInstance:
+			depth = 3
+		}
+		if len(p) < depth {
+			return nil, ErrMalformedPayload
+		}
+		rp := append(append(path{}, p[:depth]...), id)
+
+		if kind == objectInstance || kind == multipleResource {
+			rs, err := decodeTLV(rp, val)
+			if err != nil {
+				return nil, err
+			}
+			recs = append(recs, rs...)
+			continue
+		}
+
+		rec, err := decodeValue(rp, val)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+
+	return recs, nil
+}
+
+// decodeValue decodes the TLV value of the resource on the path.
+func decodeValue(p path, b []byte) (senml.Record, error) {
+	rec := senml.Record{Name: p.name()}
+
+	switch p.resource() {
+	case typeInteger, typeTime:
+		var v int64
+		switch len(b) {
+		case 1:
+			v = int64(int8(b[0]))
+		case 2:
+			v = int64(int16(binary.BigEndian.Uint16(b)))
+		case 4:
+			v = int64(int32(binary.BigEndian.Uint32(b)))
+		case 8:
+			v = int64(binary.BigEndian.Uint64(b))
+		default:
+			return rec, ErrMalformedPayload
+		}
+		f := float64(v)
+		rec.Value = &f
+	case typeFloat:
+		var v float64
+		switch len(b) {
+		case 4:
+			v = float64(math.Float32frombits(binary.BigEndian.Uint32(b)))
+		case 8:
+			v = math.Float64frombits(binary.BigEndian.Uint64(b))
+		default:
+			return rec, ErrMalformedPayload
+		}
+		rec.Value = &v
+	case typeBoolean:
+		if len(b) != 1 || b[0] > 1 {
+			return rec, ErrMalformedPayload
+		}
+		v := b[0] == 1
+		rec.BoolValue = &v
+	case typeString:
+		v := string(b)
+		rec.StringValue = &v
+	case typeObjLnk:
+		if len(b) != 4 {
+			return rec, ErrMalformedPayload
+		}
+		v := fmt.Sprintf("%d:%d", binary.BigEndian.Uint16(b), binary.BigEndian.Uint16(b[2:]))
+		rec.StringValue = &v
+	default:
+		// The values of the unknown resources are published as strings
+		// if printable, and as opaque otherwise.
+		if printable(b) {
+			v := string(b)
+			rec.StringValue = &v
+			break
+		}
+		v := base64.StdEncoding.EncodeToString(b)
+		rec.DataValue = &v
+	}
+
+	return rec, nil
+}
+
+// decodeText decodes the plain text value of the resource on the path.
+func decodeText(p path, s string) (senml.Record, error) {
+	rec := senml.Record{Name: p.name()}
+
+	switch p.resource() {
+	case typeInteger, typeTime:
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return rec, ErrMalformedPayload
+		}
+		f := float64(v)
+		rec.Value = &f
+	case typeFloat:
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return rec, ErrMalformedPayload
+		}
+		rec.Value = &v
+	case typeBoolean:
+		if s != "0" && s != "1" {
+			return rec, ErrMalformedPayload
+		}
+		v := s == "1"
+		rec.BoolValue = &v
+	case typeString, typeObjLnk:
+		rec.StringValue = &s
+	default:
+		if v, err := strconv.ParseFloat(s, 64); err == nil {
+			rec.Value = &v
+			break
+		}
+		rec.StringValue = &s
+	}
+
+	return rec, nil
+}
+
+// resolve resolves the base names, times and units of the SenML records,
+// since the LwM2M base names, e.g. /3303/0/, aren't valid SenML names.
+func resolve(recs []senml.Record) ([]senml.Record, error) {
+	var bn, bu string
+	var bt float64
+	for i, r := range recs {
+		if r.BaseName != "" {
+			bn = r.BaseName
+		}
+		if r.BaseTime != 0 {
+			bt = r.BaseTime
+		}
+		if r.BaseUnit != "" {
+			bu = r.BaseUnit
+		}
+
+		r.Name = strings.TrimPrefix(bn+r.Name, "/")
+		r.Time += bt
+		if r.Unit == "" {
+			r.Unit = bu
+		}
+		r.BaseName, r.BaseTime, r.BaseUnit = "", 0, ""
+		recs[i] = r
+	}
+
+	if err := senml.Validate(senml.Pack{Records: recs}); err != nil {
+		return nil, errors.Wrap(ErrMalformedPayload, err)
+	}
+
+	return recs, nil
+}
+
+// encode returns the content of the value written to the resource on the
+// path. The value is a boolean, a number or a string, as decoded from JSON.
+func encode(p path, value interface{}) (Content, error) {
+	if len(p) < 3 {
+		return Content{}, ErrMalformedPath
+	}
+
+	var s string
+	switch typ := p.resource(); typ {
+	case typeInteger, typeTime:
+		v, ok := value.(float64)
+		if !ok || v != math.Trunc(v) {
+			return Content{}, ErrMalformedValue
+		}
+		s = strconv.FormatInt(int64(v), 10)
+	case typeFloat:
+		v, ok := value.(float64)
+		if !ok {
+			return Content{}, ErrMalformedValue
+		}
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	case typeBoolean:
+		v, ok := value.(bool)
+		if !ok {
+			return Content{}, ErrMalformedValue
+		}
+		s = formatBool(v)
+	case typeString, typeObjLnk:
+		v, ok := value.(string)
+		if !ok {
+			return Content{}, ErrMalformedValue
+		}
+		s = v
+	case typeOpaque:
+		v, ok := value.(string)
+		if !ok {
+			return Content{}, ErrMalformedValue
+		}
+		b, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return Content{}, ErrMalformedValue
+		}
+		return Content{Format: Opaque, Payload: b}, nil
+	case typeNone:
+		return Content{}, ErrMalformedValue
+	default:
+		switch v := value.(type) {
+		case bool:
+			s = formatBool(v)
+		case float64:
+			s = strconv.FormatFloat(v, 'g', -1, 64)
+		case string:
+			s = v
+		default:
+			return Content{}, ErrMalformedValue
+		}
+	}
+
+	return Content{Format: TextPlain, Payload: []byte(s)}, nil
+}
+
+func formatBool(v bool) string {
+	if v {
+		return "1"
+	}
+
+	return "0"
+}
+
+func printable(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+
+	return true
+}
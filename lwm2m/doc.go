@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lwm2m contains the domain concept definitions needed to support
+// Mainflux LwM2M adapter service functionality. The adapter acts as the
+// LwM2M server of the registered clients.
+package lwm2m
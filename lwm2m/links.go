@@ -0,0 +1,44 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lwm2m
+
+import "strings"
+
+const rootType = `rt="oma.lwm2m"`
+
+// ParseObjects returns the object and object instance paths of the CoRE
+// link format payload sent upon registration, e.g. </1/0>,</3/0>,</3303/0>.
+// The links are relative to the alternate path of the objects, if any.
+func ParseObjects(links string) ([]string, error) {
+	var root string
+	var objs []string
+	for _, link := range strings.Split(links, ",") {
+		link = strings.TrimSpace(link)
+		if link == "" {
+			continue
+		}
+
+		end := strings.Index(link, ">")
+		if !strings.HasPrefix(link, "<") || end < 0 {
+			return nil, ErrMalformedPayload
+		}
+		uri := link[1:end]
+
+		if strings.Contains(link[end:], rootType) {
+			root = strings.TrimSuffix(uri, "/")
+			continue
+		}
+		if uri == "/" {
+			continue
+		}
+
+		p, err := parsePath(strings.TrimPrefix(uri, root))
+		if err != nil || len(p) > 2 {
+			return nil, ErrMalformedPayload
+		}
+		objs = append(objs, p.String())
+	}
+
+	return objs, nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lwm2m
+
+import (
+	"context"
+	"time"
+)
+
+// Content formats of the LwM2M payloads.
+const (
+	TextPlain  uint16 = 0
+	LinkFormat uint16 = 40
+	Opaque     uint16 = 42
+	SenMLJSON  uint16 = 110
+	SenMLCBOR  uint16 = 112
+	TLV        uint16 = 11542
+)
+
+// Content represents a payload exchanged with the LwM2M client.
+type Content struct {
+	Format  uint16
+	Payload []byte
+}
+
+// Registration represents the registration of the LwM2M client.
+type Registration struct {
+	Endpoint string
+	Lifetime time.Duration
+	Version  string
+	Binding  string
+	// Objects contains the paths of the object instances, or of the objects
+	// without instances, reported by the client, e.g. /3/0 and /3303/0.
+	Objects []string
+}
+
+// Config represents the LwM2M client of a thing.
+type Config struct {
+	Endpoint string `json:"endpoint"`
+	// Observe contains the paths observed upon registration. If empty, all
+	// the instances of the known objects reported by the client are observed.
+	Observe []string `json:"observe,omitempty"`
+}
+
+// Thing represents a thing mapped to the LwM2M client and the channels it
+// is connected to.
+type Thing struct {
+	ID       string   `json:"id"`
+	Config   Config   `json:"config"`
+	Channels []string `json:"channels"`
+}
+
+func (th Thing) connected(chanID string) bool {
+	for _, c := range th.Channels {
+		if c == chanID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ThingRepository specifies the persistence API of the mapped things.
+type ThingRepository interface {
+	// Save persists the thing.
+	Save(ctx context.Context, th Thing) error
+
+	// Retrieve returns the thing having the ID.
+	Retrieve(ctx context.Context, id string) (Thing, error)
+
+	// RetrieveByEndpoint returns the thing mapped to the client endpoint name.
+	RetrieveByEndpoint(ctx context.Context, endpoint string) (Thing, error)
+
+	// Remove removes the thing having the ID.
+	Remove(ctx context.Context, id string) error
+}
+
+// Device represents the connection to the registered LwM2M client, over
+// which the device management and information reporting operations are
+// performed.
+type Device interface {
+	// Read reads the object, object instance or resource on the path.
+	Read(ctx context.Context, path string) (Content, error)
+
+	// Write writes the content to the resource on the path.
+	Write(ctx context.Context, path string, c Content) error
+
+	// Execute executes the resource on the path with the arguments.
+	Execute(ctx context.Context, path, args string) error
+
+	// Observe observes the path, calling notify with every notification
+	// sent by the client until the device is closed.
+	Observe(ctx context.Context, path string, notify func(Content) error) error
+
+	// Close cancels the observations of the device.
+	Close() error
+}
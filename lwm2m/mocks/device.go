@@ -0,0 +1,139 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// ErrUnreachable indicates a path the device fails to serve.
+var ErrUnreachable = errors.New("not found on device")
+
+var _ lwm2m.Device = (*Device)(nil)
+
+// Device is the mock of the registered client, serving the contents of its
+// resources and recording the operations performed on it.
+type Device struct {
+	mu        sync.Mutex
+	contents  map[string]lwm2m.Content
+	written   map[string]lwm2m.Content
+	executed  map[string]string
+	observers map[string]func(lwm2m.Content) error
+	closed    bool
+}
+
+// NewDevice returns the mock device serving the contents on their paths.
+func NewDevice(contents map[string]lwm2m.Content) *Device {
+	return &Device{
+		contents:  contents,
+		written:   make(map[string]lwm2m.Content),
+		executed:  make(map[string]string),
+		observers: make(map[string]func(lwm2m.Content) error),
+	}
+}
+
+func (dev *Device) Read(_ context.Context, path string) (lwm2m.Content, error) {
+	dev.mu.Lock()
+	defer dev.mu.Unlock()
+
+	c, ok := dev.contents[path]
+	if !ok {
+		return lwm2m.Content{}, ErrUnreachable
+	}
+
+	return c, nil
+}
+
+func (dev *Device) Write(_ context.Context, path string, c lwm2m.Content) error {
+	dev.mu.Lock()
+	defer dev.mu.Unlock()
+
+	dev.written[path] = c
+	return nil
+}
+
+func (dev *Device) Execute(_ context.Context, path, args string) error {
+	dev.mu.Lock()
+	defer dev.mu.Unlock()
+
+	dev.executed[path] = args
+	return nil
+}
+
+func (dev *Device) Observe(_ context.Context, path string, notify func(lwm2m.Content) error) error {
+	dev.mu.Lock()
+	defer dev.mu.Unlock()
+
+	if _, ok := dev.contents[path]; !ok {
+		return ErrUnreachable
+	}
+	dev.observers[path] = notify
+
+	return nil
+}
+
+func (dev *Device) Close() error {
+	dev.mu.Lock()
+	defer dev.mu.Unlock()
+
+	dev.closed = true
+	dev.observers = make(map[string]func(lwm2m.Content) error)
+	return nil
+}
+
+// Notify sends the notification of the observed path.
+func (dev *Device) Notify(path string, c lwm2m.Content) error {
+	dev.mu.Lock()
+	notify, ok := dev.observers[path]
+	dev.mu.Unlock()
+
+	if !ok {
+		return ErrUnreachable
+	}
+
+	return notify(c)
+}
+
+// Observed returns the observed paths.
+func (dev *Device) Observed() []string {
+	dev.mu.Lock()
+	defer dev.mu.Unlock()
+
+	var paths []string
+	for p := range dev.observers {
+		paths = append(paths, p)
+	}
+
+	return paths
+}
+
+// Written returns the content written to the path.
+func (dev *Device) Written(path string) (lwm2m.Content, bool) {
+	dev.mu.Lock()
+	defer dev.mu.Unlock()
+
+	c, ok := dev.written[path]
+	return c, ok
+}
+
+// Executed returns the arguments of the execution of the path.
+func (dev *Device) Executed(path string) (string, bool) {
+	dev.mu.Lock()
+	defer dev.mu.Unlock()
+
+	args, ok := dev.executed[path]
+	return args, ok
+}
+
+// Closed reports whether the device was closed.
+func (dev *Device) Closed() bool {
+	dev.mu.Lock()
+	defer dev.mu.Unlock()
+
+	return dev.closed
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+)
+
+var _ lwm2m.ThingRepository = (*thingRepositoryMock)(nil)
+
+type thingRepositoryMock struct {
+	mu     sync.Mutex
+	things map[string]lwm2m.Thing
+}
+
+// NewThingRepository returns mock thing repository instance.
+func NewThingRepository() lwm2m.ThingRepository {
+	return &thingRepositoryMock{
+		things: make(map[string]lwm2m.Thing),
+	}
+}
+
+func (trm *thingRepositoryMock) Save(_ context.Context, th lwm2m.Thing) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	trm.things[th.ID] = th
+	return nil
+}
+
+func (trm *thingRepositoryMock) Retrieve(_ context.Context, id string) (lwm2m.Thing, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	th, ok := trm.things[id]
+	if !ok {
+		return lwm2m.Thing{}, lwm2m.ErrNotFound
+	}
+
+	return th, nil
+}
+
+func (trm *thingRepositoryMock) RetrieveByEndpoint(_ context.Context, endpoint string) (lwm2m.Thing, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	for _, th := range trm.things {
+		if th.Config.Endpoint == endpoint {
+			return th, nil
+		}
+	}
+
+	return lwm2m.Thing{}, lwm2m.ErrNotFound
+}
+
+func (trm *thingRepositoryMock) Remove(_ context.Context, id string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	delete(trm.things, id)
+	return nil
+}
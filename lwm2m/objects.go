@@ -0,0 +1,114 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package lwm2m
+
+// Resource data types defined by the LwM2M specification.
+const (
+	typeString  = "string"
+	typeInteger = "integer"
+	typeFloat   = "float"
+	typeBoolean = "boolean"
+	typeOpaque  = "opaque"
+	typeTime    = "time"
+	typeObjLnk  = "objlnk"
+	typeNone    = "none"
+)
+
+// Object IDs of the known objects.
+const (
+	deviceObject       = 3
+	connectivityObject = 4
+	locationObject     = 6
+	ipsoFirst          = 3200
+	ipsoLast           = 3399
+)
+
+var objects = map[uint16]map[uint16]string{
+	deviceObject: {
+		0:  typeString,  // Manufacturer
+		1:  typeString,  // Model Number
+		2:  typeString,  // Serial Number
+		3:  typeString,  // Firmware Version
+		4:  typeNone,    // Reboot
+		5:  typeNone,    // Factory Reset
+		6:  typeInteger, // Available Power Sources
+		7:  typeInteger, // Power Source Voltage
+		8:  typeInteger, // Power Source Current
+		9:  typeInteger, // Battery Level
+		10: typeInteger, // Memory Free
+		11: typeInteger, // Error Code
+		12: typeNone,    // Reset Error Code
+		13: typeTime,    // Current Time
+		14: typeString,  // UTC Offset
+		15: typeString,  // Timezone
+		16: typeString,  // Supported Binding and Modes
+		17: typeString,  // Device Type
+		18: typeString,  // Hardware Version
+		19: typeString,  // Software Version
+		20: typeInteger, // Battery Status
+		21: typeInteger, // Memory Total
+	},
+	connectivityObject: {
+		0:  typeInteger, // Network Bearer
+		1:  typeInteger, // Available Network Bearer
+		2:  typeInteger, // Radio Signal Strength
+		3:  typeInteger, // Link Quality
+		4:  typeString,  // IP Addresses
+		5:  typeString,  // Router IP Addresses
+		6:  typeInteger, // Link Utilization
+		7:  typeString,  // APN
+		8:  typeInteger, // Cell ID
+		9:  typeInteger, // SMNC
+		10: typeInteger, // SMCC
+	},
+	locationObject: {
+		0: typeFloat,  // Latitude
+		1: typeFloat,  // Longitude
+		2: typeFloat,  // Altitude
+		3: typeFloat,  // Radius
+		4: typeOpaque, // Velocity
+		5: typeTime,   // Timestamp
+		6: typeFloat,  // Speed
+	},
+}
+
+// ipsoResources contains the resources reused by the IPSO Smart Objects,
+// e.g. 3303 Temperature or 3304 Humidity.
+var ipsoResources = map[uint16]string{
+	5500: typeBoolean, // Digital Input State
+	5501: typeInteger, // Digital Input Counter
+	5518: typeTime,    // Timestamp
+	5550: typeBoolean, // Digital Output State
+	5601: typeFloat,   // Min Measured Value
+	5602: typeFloat,   // Max Measured Value
+	5603: typeFloat,   // Min Range Value
+	5604: typeFloat,   // Max Range Value
+	5605: typeNone,    // Reset Min and Max Measured Values
+	5700: typeFloat,   // Sensor Value
+	5701: typeString,  // Sensor Units
+	5750: typeString,  // Application Type
+	5821: typeFloat,   // Current Calibration
+	5850: typeBoolean, // On/Off
+	5851: typeInteger, // Dimmer
+}
+
+// resourceType returns the data type of the resource of the object, or an
+// empty string if the resource is unknown.
+func resourceType(objID, resID uint16) string {
+	if res, ok := objects[objID]; ok {
+		return res[resID]
+	}
+
+	if objID >= ipsoFirst && objID <= ipsoLast {
+		return ipsoResources[resID]
+	}
+
+	return ""
+}
+
+// known reports whether the object is observed by default.
+func known(objID uint16) bool {
+	_, ok := objects[objID]
+	return ok || (objID >= ipsoFirst && objID <= ipsoLast)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+	"github.com/MainfluxLabs/mainflux/pkg/events"
+	eventsredis "github.com/MainfluxLabs/mainflux/pkg/events/redis"
+	"github.com/go-redis/redis/v8"
+)
+
+const keyType = "lwm2m"
+
+var _ events.ThingHandler = (*thingHandler)(nil)
+
+type thingHandler struct {
+	svc lwm2m.Service
+}
+
+// NewEventStore returns new event store instance, which provisions the
+// things with the LwM2M configuration in their metadata.
+func NewEventStore(svc lwm2m.Service, client *redis.Client, consumer string, log logger.Logger) events.Subscriber {
+	return eventsredis.NewThingsSubscriber(thingHandler{svc: svc}, client, keyType, consumer, log, lwm2m.ErrNotFound)
+}
+
+func (th thingHandler) CreateThing(ctx context.Context, thingID string, config json.RawMessage) error {
+	var cfg lwm2m.Config
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return lwm2m.ErrInvalidConfig
+	}
+
+	return th.svc.CreateThing(ctx, thingID, cfg)
+}
+
+func (th thingHandler) UpdateThing(ctx context.Context, thingID string, config json.RawMessage) error {
+	var cfg lwm2m.Config
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return lwm2m.ErrInvalidConfig
+	}
+
+	return th.svc.UpdateThing(ctx, thingID, cfg)
+}
+
+func (th thingHandler) RemoveThing(ctx context.Context, thingID string) error {
+	return th.svc.RemoveThing(ctx, thingID)
+}
+
+func (th thingHandler) ConnectThing(ctx context.Context, chanID, thingID string) error {
+	return th.svc.ConnectThing(ctx, chanID, thingID)
+}
+
+func (th thingHandler) DisconnectThing(ctx context.Context, chanID, thingID string) error {
+	return th.svc.DisconnectThing(ctx, chanID, thingID)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MainfluxLabs/mainflux/lwm2m"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	thingsKey    = "lwm2m:things"
+	endpointsKey = "lwm2m:endpoints"
+)
+
+var _ lwm2m.ThingRepository = (*thingRepository)(nil)
+
+type thingRepository struct {
+	client *redis.Client
+}
+
+// NewThingRepository returns redis thing repository implementation.
+func NewThingRepository(client *redis.Client) lwm2m.ThingRepository {
+	return &thingRepository{
+		client: client,
+	}
+}
+
+func (tr *thingRepository) Save(ctx context.Context, th lwm2m.Thing) error {
+	b, err := json.Marshal(th)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	old, err := tr.Retrieve(ctx, th.ID)
+	if err != nil && !errors.Contains(err, lwm2m.ErrNotFound) {
+		return err
+	}
+
+	pipe := tr.client.TxPipeline()
+	if old.Config.Endpoint != "" && old.Config.Endpoint != th.Config.Endpoint {
+		pipe.HDel(ctx, endpointsKey, old.Config.Endpoint)
+	}
+	pipe.HSet(ctx, thingsKey, th.ID, b)
+	pipe.HSet(ctx, endpointsKey, th.Config.Endpoint, th.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tr *thingRepository) Retrieve(ctx context.Context, id string) (lwm2m.Thing, error) {
+	b, err := tr.client.HGet(ctx, thingsKey, id).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return lwm2m.Thing{}, lwm2m.ErrNotFound
+		}
+		return lwm2m.Thing{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	var th lwm2m.Thing
+	if err := json.Unmarshal(b, &th); err != nil {
+		return lwm2m.Thing{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return th, nil
+}
+
+func (tr *thingRepository) RetrieveByEndpoint(ctx context.Context, endpoint string) (lwm2m.Thing, error) {
+	id, err := tr.client.HGet(ctx, endpointsKey, endpoint).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return lwm2m.Thing{}, lwm2m.ErrNotFound
+		}
+		return lwm2m.Thing{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return tr.Retrieve(ctx, id)
+}
+
+func (tr *thingRepository) Remove(ctx context.Context, id string) error {
+	th, err := tr.Retrieve(ctx, id)
+	if err != nil {
+		if errors.Contains(err, lwm2m.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	pipe := tr.client.TxPipeline()
+	pipe.HDel(ctx, thingsKey, id)
+	pipe.HDel(ctx, endpointsKey, th.Config.Endpoint)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
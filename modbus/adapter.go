@@ -0,0 +1,365 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/senml"
+)
+
+const (
+	protocol = "modbus"
+
+	coilOn  = 0xFF00
+	coilOff = 0x0000
+)
+
+var (
+	// ErrNotFound indicates a thing that isn't polled by the adapter.
+	ErrNotFound = errors.New("modbus thing not found")
+
+	// ErrNotConnected indicates a thing that isn't connected to the channel.
+	ErrNotConnected = errors.New("modbus thing not connected to channel")
+
+	// ErrMalformedCommand indicates malformed write command.
+	ErrMalformedCommand = errors.New("malformed write command")
+
+	// ErrReadOnly indicates a write to a read-only register.
+	ErrReadOnly = errors.New("register is read-only")
+)
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateThing stores the Modbus configuration of the thing.
+	CreateThing(ctx context.Context, thingID string, cfg Config) error
+
+	// UpdateThing updates the Modbus configuration of the thing.
+	UpdateThing(ctx context.Context, thingID string, cfg Config) error
+
+	// RemoveThing stops polling the thing and removes its configuration.
+	RemoveThing(ctx context.Context, thingID string) error
+
+	// ConnectThing starts publishing the readings of the thing on the channel.
+	ConnectThing(ctx context.Context, chanID, thingID string) error
+
+	// DisconnectThing stops publishing the readings of the thing on the channel.
+	DisconnectThing(ctx context.Context, chanID, thingID string) error
+
+	// Poll reads the registers of the thing and publishes them as SenML on
+	// the channels it is connected to.
+	Poll(ctx context.Context, thingID string) error
+
+	// Write writes the value of the command received on the channel to the
+	// register of the thing.
+	Write(ctx context.Context, chanID string, cmd Command) error
+
+	// Restore schedules the polls of all the stored things, e.g. on start.
+	Restore(ctx context.Context) error
+}
+
+var _ Service = (*adapterService)(nil)
+
+type adapterService struct {
+	publisher messaging.Publisher
+	dialer    Dialer
+	things    ThingRepository
+	scheduler Scheduler
+	mu        sync.Mutex
+	clients   map[string]Client
+}
+
+// New instantiates the Modbus adapter implementation.
+func New(publisher messaging.Publisher, dialer Dialer, things ThingRepository, scheduler Scheduler) Service {
+	return &adapterService{
+		publisher: publisher,
+		dialer:    dialer,
+		things:    things,
+		scheduler: scheduler,
+		clients:   make(map[string]Client),
+	}
+}
+
+func (as *adapterService) CreateThing(ctx context.Context, thingID string, cfg Config) error {
+	return as.UpdateThing(ctx, thingID, cfg)
+}
+
+func (as *adapterService) UpdateThing(ctx context.Context, thingID string, cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	th, err := as.things.Retrieve(ctx, thingID)
+	switch {
+	case err == nil:
+	case errors.Contains(err, ErrNotFound):
+		th = Thing{ID: thingID}
+	default:
+		return err
+	}
+	th.Config = cfg
+
+	if err := as.things.Save(ctx, th); err != nil {
+		return err
+	}
+
+	as.closeClient(thingID)
+	return as.schedule(th)
+}
+
+func (as *adapterService) RemoveThing(ctx context.Context, thingID string) error {
+	as.scheduler.Cancel(thingID)
+	as.closeClient(thingID)
+
+	return as.things.Remove(ctx, thingID)
+}
+
+func (as *adapterService) ConnectThing(ctx context.Context, chanID, thingID string) error {
+	th, err := as.things.Retrieve(ctx, thingID)
+	if err != nil {
+		return err
+	}
+
+	if th.connected(chanID) {
+		return nil
+	}
+	th.Channels = append(th.Channels, chanID)
+
+	if err := as.things.Save(ctx, th); err != nil {
+		return err
+	}
+
+	return as.schedule(th)
+}
+
+func (as *adapterService) DisconnectThing(ctx context.Context, chanID, thingID string) error {
+	th, err := as.things.Retrieve(ctx, thingID)
+	if err != nil {
+		return err
+	}
+
+	var chs []string
+	for _, c := range th.Channels {
+		if c != chanID {
+			chs = append(chs, c)
+		}
+	}
+	th.Channels = chs
+
+	if err := as.things.Save(ctx, th); err != nil {
+		return err
+	}
+
+	return as.schedule(th)
+}
+
+func (as *adapterService) Poll(ctx context.Context, thingID string) error {
+	th, err := as.things.Retrieve(ctx, thingID)
+	if err != nil {
+		return err
+	}
+
+	if len(th.Channels) == 0 {
+		return nil
+	}
+
+	client, err := as.client(th)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	recs, err := read(client, th.Config.Registers)
+	if err != nil {
+		as.closeClient(thingID)
+		return err
+	}
+	recs[0].BaseTime = float64(now.UnixNano()) / 1e9
+
+	payload, err := senml.Encode(senml.Pack{Records: recs}, senml.JSON)
+	if err != nil {
+		return err
+	}
+
+	for _, chanID := range th.Channels {
+		msg := messaging.Message{
+			Publisher: thingID,
+			Protocol:  protocol,
+			Channel:   chanID,
+			Payload:   payload,
+			Created:   now.UnixNano(),
+		}
+		if err := as.publisher.Publish(msg.Channel, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (as *adapterService) Write(ctx context.Context, chanID string, cmd Command) error {
+	if cmd.ThingID == "" || cmd.Register == "" || cmd.Value == nil {
+		return ErrMalformedCommand
+	}
+
+	th, err := as.things.Retrieve(ctx, cmd.ThingID)
+	if err != nil {
+		return err
+	}
+
+	if !th.connected(chanID) {
+		return ErrNotConnected
+	}
+
+	reg, ok := th.Config.Register(cmd.Register)
+	if !ok {
+		return ErrMalformedCommand
+	}
+
+	if !reg.Writable() {
+		return ErrReadOnly
+	}
+
+	client, err := as.client(th)
+	if err != nil {
+		return err
+	}
+
+	switch v := cmd.Value.(type) {
+	case bool:
+		if reg.Type != Coil {
+			return ErrMalformedCommand
+		}
+		val := uint16(coilOff)
+		if v {
+			val = coilOn
+		}
+		_, err = client.WriteSingleCoil(reg.Address, val)
+	case float64:
+		if reg.Type != Holding {
+			return ErrMalformedCommand
+		}
+		_, err = client.WriteMultipleRegisters(reg.Address, reg.quantity(), reg.encode(v))
+	default:
+		return ErrMalformedCommand
+	}
+
+	if err != nil {
+		as.closeClient(th.ID)
+		return err
+	}
+
+	return nil
+}
+
+func (as *adapterService) Restore(ctx context.Context) error {
+	ths, err := as.things.RetrieveAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, th := range ths {
+		if err := as.schedule(th); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// schedule polls the thing while it's connected to any channel.
+func (as *adapterService) schedule(th Thing) error {
+	if len(th.Channels) == 0 {
+		as.scheduler.Cancel(th.ID)
+		return nil
+	}
+
+	interval, err := th.Config.PollInterval()
+	if err != nil {
+		return err
+	}
+
+	as.scheduler.Schedule(th.ID, interval, func() error {
+		return as.Poll(context.Background(), th.ID)
+	})
+
+	return nil
+}
+
+// client returns the cached client of the thing, connecting to the device
+// if there's none.
+func (as *adapterService) client(th Thing) (Client, error) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if c, ok := as.clients[th.ID]; ok {
+		return c, nil
+	}
+
+	c, err := as.dialer.Dial(th.Config)
+	if err != nil {
+		return nil, err
+	}
+	as.clients[th.ID] = c
+
+	return c, nil
+}
+
+func (as *adapterService) closeClient(thingID string) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if c, ok := as.clients[thingID]; ok {
+		c.Close()
+		delete(as.clients, thingID)
+	}
+}
+
+// read reads the registers and returns their values as SenML records.
+func read(client Client, regs []Register) ([]senml.Record, error) {
+	var recs []senml.Record
+	for _, r := range regs {
+		rec := senml.Record{Name: r.Name, Unit: r.Unit}
+
+		switch r.Type {
+		case Coil, DiscreteInput:
+			readBits := client.ReadCoils
+			if r.Type == DiscreteInput {
+				readBits = client.ReadDiscreteInputs
+			}
+			b, err := readBits(r.Address, 1)
+			if err != nil {
+				return nil, err
+			}
+			if len(b) == 0 {
+				return nil, errors.New("short coil read")
+			}
+			v := b[0]&1 == 1
+			rec.BoolValue = &v
+		default:
+			readRegs := client.ReadHoldingRegisters
+			if r.Type == Input {
+				readRegs = client.ReadInputRegisters
+			}
+			b, err := readRegs(r.Address, r.quantity())
+			if err != nil {
+				return nil, err
+			}
+			v, err := r.decode(b)
+			if err != nil {
+				return nil, err
+			}
+			rec.Value = &v
+		}
+
+		recs = append(recs, rec)
+	}
+
+	return recs, nil
+}
@@ -0,0 +1,358 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus_test
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/modbus"
+	"github.com/MainfluxLabs/mainflux/modbus/client"
+	"github.com/MainfluxLabs/mainflux/modbus/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	pubmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tbrandon/mbserver"
+)
+
+const (
+	thingID  = "thingID-1"
+	thingID2 = "thingID-2"
+	chanID   = "chanID-1"
+	chanID2  = "chanID-2"
+	timeout  = time.Second
+)
+
+func newService(pub *pubmocks.Publisher, scheduler modbus.Scheduler) modbus.Service {
+	return modbus.New(pub, client.NewDialer(timeout), mocks.NewThingRepository(), scheduler)
+}
+
+// newSimulator starts the Modbus TCP simulator and returns its address.
+func newSimulator(t *testing.T) (*mbserver.Server, string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	addr := l.Addr().String()
+	l.Close()
+
+	srv := mbserver.NewServer()
+	err = srv.ListenTCP(addr)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	return srv, addr
+}
+
+func newConfig(addr string) modbus.Config {
+	return modbus.Config{
+		Mode:     modbus.TCP,
+		Address:  addr,
+		SlaveID:  1,
+		Interval: "1s",
+		Registers: []modbus.Register{
+			{Name: "temperature", Type: modbus.Holding, Address: 0, DataType: modbus.Int16, Scale: 0.1, Unit: "Cel"},
+			{Name: "energy", Type: modbus.Input, Address: 10, DataType: modbus.Uint32},
+			{Name: "pressure", Type: modbus.Holding, Address: 20, DataType: modbus.Float32, SwapWords: true},
+			{Name: "pump", Type: modbus.Coil, Address: 3},
+			{Name: "alarm", Type: modbus.DiscreteInput, Address: 4},
+		},
+	}
+}
+
+func TestCreateThing(t *testing.T) {
+	scheduler := mocks.NewScheduler()
+	svc := newService(pubmocks.NewPublisher(), scheduler)
+
+	valid := newConfig("127.0.0.1:502")
+
+	invalidMode := newConfig("127.0.0.1:502")
+	invalidMode.Mode = "ascii"
+
+	missingAddress := newConfig("")
+
+	invalidInterval := newConfig("127.0.0.1:502")
+	invalidInterval.Interval = "1ms"
+
+	missingRegisters := newConfig("127.0.0.1:502")
+	missingRegisters.Registers = nil
+
+	invalidName := newConfig("127.0.0.1:502")
+	invalidName.Registers = []modbus.Register{{Name: "temp erature", Type: modbus.Holding}}
+
+	duplicateName := newConfig("127.0.0.1:502")
+	duplicateName.Registers = []modbus.Register{{Name: "temperature", Type: modbus.Holding}, {Name: "temperature", Type: modbus.Input}}
+
+	invalidType := newConfig("127.0.0.1:502")
+	invalidType.Registers = []modbus.Register{{Name: "temperature", Type: "analog"}}
+
+	invalidCoilDataType := newConfig("127.0.0.1:502")
+	invalidCoilDataType.Registers = []modbus.Register{{Name: "pump", Type: modbus.Coil, DataType: modbus.Int16}}
+
+	invalidRegisterDataType := newConfig("127.0.0.1:502")
+	invalidRegisterDataType.Registers = []modbus.Register{{Name: "temperature", Type: modbus.Holding, DataType: modbus.Bool}}
+
+	cases := []struct {
+		desc string
+		cfg  modbus.Config
+		err  error
+	}{
+		{
+			desc: "create thing with valid config",
+			cfg:  valid,
+			err:  nil,
+		},
+		{
+			desc: "create thing with invalid mode",
+			cfg:  invalidMode,
+			err:  modbus.ErrInvalidConfig,
+		},
+		{
+			desc: "create thing without address",
+			cfg:  missingAddress,
+			err:  modbus.ErrInvalidConfig,
+		},
+		{
+			desc: "create thing with too short interval",
+			cfg:  invalidInterval,
+			err:  modbus.ErrInvalidConfig,
+		},
+		{
+			desc: "create thing without registers",
+			cfg:  missingRegisters,
+			err:  modbus.ErrInvalidConfig,
+		},
+		{
+			desc: "create thing with invalid register name",
+			cfg:  invalidName,
+			err:  modbus.ErrInvalidConfig,
+		},
+		{
+			desc: "create thing with duplicate register name",
+			cfg:  duplicateName,
+			err:  modbus.ErrInvalidConfig,
+		},
+		{
+			desc: "create thing with invalid register type",
+			cfg:  invalidType,
+			err:  modbus.ErrInvalidConfig,
+		},
+		{
+			desc: "create thing with numeric coil",
+			cfg:  invalidCoilDataType,
+			err:  modbus.ErrInvalidConfig,
+		},
+		{
+			desc: "create thing with boolean holding register",
+			cfg:  invalidRegisterDataType,
+			err:  modbus.ErrInvalidConfig,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.CreateThing(nil, thingID, tc.cfg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, ok := scheduler.Interval(thingID)
+	assert.False(t, ok, "unexpected poll of thing without channels")
+}
+
+func TestConnectThing(t *testing.T) {
+	scheduler := mocks.NewScheduler()
+	svc := newService(pubmocks.NewPublisher(), scheduler)
+
+	err := svc.CreateThing(nil, thingID, newConfig("127.0.0.1:502"))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.ConnectThing(nil, chanID, thingID)
+	assert.Nil(t, err, fmt.Sprintf("connect thing: unexpected error: %s\n", err))
+	interval, ok := scheduler.Interval(thingID)
+	assert.True(t, ok, "connect thing: expected the thing to be polled")
+	assert.Equal(t, time.Second, interval, fmt.Sprintf("connect thing: expected interval %s got %s\n", time.Second, interval))
+
+	err = svc.ConnectThing(nil, chanID, "wrong")
+	assert.True(t, errors.Contains(err, modbus.ErrNotFound), fmt.Sprintf("connect non existing thing: expected %s got %s\n", modbus.ErrNotFound, err))
+
+	err = svc.DisconnectThing(nil, chanID, thingID)
+	assert.Nil(t, err, fmt.Sprintf("disconnect thing: unexpected error: %s\n", err))
+	_, ok = scheduler.Interval(thingID)
+	assert.False(t, ok, "disconnect thing: expected the poll of the thing to be canceled")
+
+	err = svc.ConnectThing(nil, chanID, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.RemoveThing(nil, thingID)
+	assert.Nil(t, err, fmt.Sprintf("remove thing: unexpected error: %s\n", err))
+	_, ok = scheduler.Interval(thingID)
+	assert.False(t, ok, "remove thing: expected the poll of the thing to be canceled")
+}
+
+func TestPoll(t *testing.T) {
+	srv, addr := newSimulator(t)
+	defer srv.Close()
+
+	srv.HoldingRegisters[0] = uint16(0xFF38) // -200
+	srv.InputRegisters[10] = 0x0001
+	srv.InputRegisters[11] = 0x86A0 // 100000
+	pressure := math.Float32bits(1.5)
+	srv.HoldingRegisters[20] = uint16(pressure)
+	srv.HoldingRegisters[21] = uint16(pressure >> 16)
+	srv.Coils[3] = 1
+	srv.DiscreteInputs[4] = 0
+
+	pub := pubmocks.NewPublisher()
+	svc := newService(pub, mocks.NewScheduler())
+
+	err := svc.CreateThing(nil, thingID, newConfig(addr))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ConnectThing(nil, chanID, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ConnectThing(nil, chanID2, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateThing(nil, thingID2, newConfig("127.0.0.1:1"))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.Poll(nil, thingID2)
+	assert.Nil(t, err, fmt.Sprintf("poll thing without channels: unexpected error: %s\n", err))
+
+	err = svc.Poll(nil, "wrong")
+	assert.True(t, errors.Contains(err, modbus.ErrNotFound), fmt.Sprintf("poll non existing thing: expected %s got %s\n", modbus.ErrNotFound, err))
+
+	err = svc.ConnectThing(nil, chanID, thingID2)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.Poll(nil, thingID2)
+	assert.NotNil(t, err, "poll unreachable device: expected error")
+
+	err = svc.Poll(nil, thingID)
+	require.Nil(t, err, fmt.Sprintf("poll thing: unexpected error: %s\n", err))
+
+	for _, ch := range []string{chanID, chanID2} {
+		msgs := pub.Messages(ch, "")
+		require.Len(t, msgs, 1, fmt.Sprintf("expected one message on channel %s got %d\n", ch, len(msgs)))
+		assert.Equal(t, thingID, msgs[0].Publisher, fmt.Sprintf("expected publisher %s got %s\n", thingID, msgs[0].Publisher))
+
+		pack, err := senml.Decode(msgs[0].Payload, senml.JSON)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		pack, err = senml.Normalize(pack)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+		vals := map[string]interface{}{}
+		for _, r := range pack.Records {
+			switch {
+			case r.Value != nil:
+				vals[r.Name] = math.Round(*r.Value*100) / 100
+			case r.BoolValue != nil:
+				vals[r.Name] = *r.BoolValue
+			}
+		}
+
+		expected := map[string]interface{}{
+			"temperature": -20.0,
+			"energy":      100000.0,
+			"pressure":    1.5,
+			"pump":        true,
+			"alarm":       false,
+		}
+		assert.Equal(t, expected, vals, fmt.Sprintf("expected readings %v got %v\n", expected, vals))
+	}
+}
+
+func TestWrite(t *testing.T) {
+	srv, addr := newSimulator(t)
+	defer srv.Close()
+
+	svc := newService(pubmocks.NewPublisher(), mocks.NewScheduler())
+
+	err := svc.CreateThing(nil, thingID, newConfig(addr))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.ConnectThing(nil, chanID, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		chanID string
+		cmd    modbus.Command
+		err    error
+	}{
+		{
+			desc:   "write scaled holding register",
+			chanID: chanID,
+			cmd:    modbus.Command{ThingID: thingID, Register: "temperature", Value: -12.5},
+			err:    nil,
+		},
+		{
+			desc:   "write float holding register",
+			chanID: chanID,
+			cmd:    modbus.Command{ThingID: thingID, Register: "pressure", Value: 2.25},
+			err:    nil,
+		},
+		{
+			desc:   "write coil",
+			chanID: chanID,
+			cmd:    modbus.Command{ThingID: thingID, Register: "pump", Value: true},
+			err:    nil,
+		},
+		{
+			desc:   "write input register",
+			chanID: chanID,
+			cmd:    modbus.Command{ThingID: thingID, Register: "energy", Value: 1.0},
+			err:    modbus.ErrReadOnly,
+		},
+		{
+			desc:   "write discrete input",
+			chanID: chanID,
+			cmd:    modbus.Command{ThingID: thingID, Register: "alarm", Value: true},
+			err:    modbus.ErrReadOnly,
+		},
+		{
+			desc:   "write boolean to holding register",
+			chanID: chanID,
+			cmd:    modbus.Command{ThingID: thingID, Register: "temperature", Value: true},
+			err:    modbus.ErrMalformedCommand,
+		},
+		{
+			desc:   "write string to holding register",
+			chanID: chanID,
+			cmd:    modbus.Command{ThingID: thingID, Register: "temperature", Value: "hot"},
+			err:    modbus.ErrMalformedCommand,
+		},
+		{
+			desc:   "write unknown register",
+			chanID: chanID,
+			cmd:    modbus.Command{ThingID: thingID, Register: "wrong", Value: 1.0},
+			err:    modbus.ErrMalformedCommand,
+		},
+		{
+			desc:   "write without value",
+			chanID: chanID,
+			cmd:    modbus.Command{ThingID: thingID, Register: "temperature"},
+			err:    modbus.ErrMalformedCommand,
+		},
+		{
+			desc:   "write to not connected channel",
+			chanID: chanID2,
+			cmd:    modbus.Command{ThingID: thingID, Register: "temperature", Value: 1.0},
+			err:    modbus.ErrNotConnected,
+		},
+		{
+			desc:   "write to non existing thing",
+			chanID: chanID,
+			cmd:    modbus.Command{ThingID: "wrong", Register: "temperature", Value: 1.0},
+			err:    modbus.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Write(nil, tc.chanID, tc.cmd)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	assert.Equal(t, uint16(0xFF83), srv.HoldingRegisters[0], "expected temperature register to be -125")
+	pressure := uint32(srv.HoldingRegisters[21])<<16 | uint32(srv.HoldingRegisters[20])
+	assert.Equal(t, float32(2.25), math.Float32frombits(pressure), "expected pressure register to be 2.25")
+	assert.Equal(t, byte(1), srv.Coils[3], "expected pump coil to be on")
+}
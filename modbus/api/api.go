@@ -0,0 +1,21 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/go-zoo/bone"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler() http.Handler {
+	r := bone.New()
+	r.GetFunc("/health", mainflux.Health("modbus-adapter"))
+	r.Handle("/metrics", promhttp.Handler())
+
+	return r
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/modbus"
+)
+
+var _ modbus.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger logger.Logger
+	svc    modbus.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc modbus.Service, logger logger.Logger) modbus.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm loggingMiddleware) CreateThing(ctx context.Context, thingID string, cfg modbus.Config) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("create_thing for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateThing(ctx, thingID, cfg)
+}
+
+func (lm loggingMiddleware) UpdateThing(ctx context.Context, thingID string, cfg modbus.Config) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("update_thing for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateThing(ctx, thingID, cfg)
+}
+
+func (lm loggingMiddleware) RemoveThing(ctx context.Context, thingID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("remove_thing for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveThing(ctx, thingID)
+}
+
+func (lm loggingMiddleware) ConnectThing(ctx context.Context, chanID, thingID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("connect_thing for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ConnectThing(ctx, chanID, thingID)
+}
+
+func (lm loggingMiddleware) DisconnectThing(ctx context.Context, chanID, thingID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("disconnect_thing for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DisconnectThing(ctx, chanID, thingID)
+}
+
+func (lm loggingMiddleware) Poll(ctx context.Context, thingID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("poll for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Poll(ctx, thingID)
+}
+
+func (lm loggingMiddleware) Write(ctx context.Context, chanID string, cmd modbus.Command) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("write for channel %s, thing %s and register %s took %s to complete", chanID, cmd.ThingID, cmd.Register, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Write(ctx, chanID, cmd)
+}
+
+func (lm loggingMiddleware) Restore(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("restore took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Restore(ctx)
+}
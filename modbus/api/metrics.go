@@ -0,0 +1,103 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/modbus"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ modbus.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     modbus.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc modbus.Service, counter metrics.Counter, latency metrics.Histogram) modbus.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) CreateThing(ctx context.Context, thingID string, cfg modbus.Config) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_thing").Add(1)
+		mm.latency.With("method", "create_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateThing(ctx, thingID, cfg)
+}
+
+func (mm *metricsMiddleware) UpdateThing(ctx context.Context, thingID string, cfg modbus.Config) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_thing").Add(1)
+		mm.latency.With("method", "update_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateThing(ctx, thingID, cfg)
+}
+
+func (mm *metricsMiddleware) RemoveThing(ctx context.Context, thingID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_thing").Add(1)
+		mm.latency.With("method", "remove_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveThing(ctx, thingID)
+}
+
+func (mm *metricsMiddleware) ConnectThing(ctx context.Context, chanID, thingID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "connect_thing").Add(1)
+		mm.latency.With("method", "connect_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ConnectThing(ctx, chanID, thingID)
+}
+
+func (mm *metricsMiddleware) DisconnectThing(ctx context.Context, chanID, thingID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "disconnect_thing").Add(1)
+		mm.latency.With("method", "disconnect_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DisconnectThing(ctx, chanID, thingID)
+}
+
+func (mm *metricsMiddleware) Poll(ctx context.Context, thingID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "poll").Add(1)
+		mm.latency.With("method", "poll").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Poll(ctx, thingID)
+}
+
+func (mm *metricsMiddleware) Write(ctx context.Context, chanID string, cmd modbus.Command) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "write").Add(1)
+		mm.latency.With("method", "write").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Write(ctx, chanID, cmd)
+}
+
+func (mm *metricsMiddleware) Restore(ctx context.Context) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "restore").Add(1)
+		mm.latency.With("method", "restore").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Restore(ctx)
+}
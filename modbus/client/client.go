@@ -0,0 +1,71 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package client contains the Modbus TCP and RTU client implementation.
+package client
+
+import (
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/modbus"
+	mb "github.com/goburrow/modbus"
+)
+
+var _ modbus.Dialer = (*dialer)(nil)
+
+type dialer struct {
+	timeout time.Duration
+}
+
+// NewDialer returns the dialer of the Modbus TCP and RTU devices.
+func NewDialer(timeout time.Duration) modbus.Dialer {
+	return dialer{timeout: timeout}
+}
+
+func (d dialer) Dial(cfg modbus.Config) (modbus.Client, error) {
+	switch cfg.Mode {
+	case modbus.RTU:
+		h := mb.NewRTUClientHandler(cfg.Address)
+		h.SlaveId = cfg.SlaveID
+		h.Timeout = d.timeout
+		if cfg.BaudRate != 0 {
+			h.BaudRate = cfg.BaudRate
+		}
+		if cfg.DataBits != 0 {
+			h.DataBits = cfg.DataBits
+		}
+		if cfg.Parity != "" {
+			h.Parity = cfg.Parity
+		}
+		if cfg.StopBits != 0 {
+			h.StopBits = cfg.StopBits
+		}
+		if err := h.Connect(); err != nil {
+			return nil, err
+		}
+
+		return client{Client: mb.NewClient(h), closer: h}, nil
+	default:
+		h := mb.NewTCPClientHandler(cfg.Address)
+		h.SlaveId = cfg.SlaveID
+		h.Timeout = d.timeout
+		if err := h.Connect(); err != nil {
+			return nil, err
+		}
+
+		return client{Client: mb.NewClient(h), closer: h}, nil
+	}
+}
+
+type closer interface {
+	Close() error
+}
+
+type client struct {
+	mb.Client
+	closer closer
+}
+
+func (c client) Close() error {
+	return c.closer.Close()
+}
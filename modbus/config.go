@@ -0,0 +1,250 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"encoding/binary"
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// Modbus transmission modes.
+const (
+	TCP = "tcp"
+	RTU = "rtu"
+)
+
+// Register types.
+const (
+	Coil          = "coil"
+	DiscreteInput = "discrete_input"
+	Holding       = "holding"
+	Input         = "input"
+)
+
+// Register data types.
+const (
+	Bool    = "bool"
+	Int16   = "int16"
+	Uint16  = "uint16"
+	Int32   = "int32"
+	Uint32  = "uint32"
+	Float32 = "float32"
+	Float64 = "float64"
+)
+
+const (
+	defInterval = 10 * time.Second
+	minInterval = 100 * time.Millisecond
+)
+
+// ErrInvalidConfig indicates a malformed Modbus device configuration.
+var ErrInvalidConfig = errors.New("invalid modbus configuration")
+
+var nameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:/-]*$`)
+
+// Config represents the Modbus device of a thing and its register map.
+type Config struct {
+	Mode      string     `json:"mode"`
+	Address   string     `json:"address"`
+	SlaveID   byte       `json:"slave_id"`
+	BaudRate  int        `json:"baud_rate,omitempty"`
+	DataBits  int        `json:"data_bits,omitempty"`
+	Parity    string     `json:"parity,omitempty"`
+	StopBits  int        `json:"stop_bits,omitempty"`
+	Interval  string     `json:"interval,omitempty"`
+	Registers []Register `json:"registers"`
+}
+
+// Register represents a coil, a discrete input or a (set of) holding or input
+// registers holding a single value. The published value is the raw value
+// multiplied by the scale and increased by the offset.
+type Register struct {
+	Name      string  `json:"name"`
+	Type      string  `json:"type"`
+	Address   uint16  `json:"address"`
+	DataType  string  `json:"data_type,omitempty"`
+	Scale     float64 `json:"scale,omitempty"`
+	Offset    float64 `json:"offset,omitempty"`
+	Unit      string  `json:"unit,omitempty"`
+	SwapWords bool    `json:"swap_words,omitempty"`
+}
+
+// Validate checks whether the configuration is valid.
+func (cfg Config) Validate() error {
+	if cfg.Address == "" {
+		return errors.Wrap(ErrInvalidConfig, errors.New("missing address"))
+	}
+
+	switch cfg.Mode {
+	case TCP, RTU:
+	default:
+		return errors.Wrap(ErrInvalidConfig, errors.New("invalid mode"))
+	}
+
+	if _, err := cfg.PollInterval(); err != nil {
+		return err
+	}
+
+	if len(cfg.Registers) == 0 {
+		return errors.Wrap(ErrInvalidConfig, errors.New("missing registers"))
+	}
+
+	names := make(map[string]bool)
+	for _, r := range cfg.Registers {
+		if err := r.validate(); err != nil {
+			return err
+		}
+		if names[r.Name] {
+			return errors.Wrap(ErrInvalidConfig, errors.New("duplicate register name "+r.Name))
+		}
+		names[r.Name] = true
+	}
+
+	return nil
+}
+
+// PollInterval returns the polling interval of the device.
+func (cfg Config) PollInterval() (time.Duration, error) {
+	if cfg.Interval == "" {
+		return defInterval, nil
+	}
+
+	d, err := time.ParseDuration(cfg.Interval)
+	if err != nil || d < minInterval {
+		return 0, errors.Wrap(ErrInvalidConfig, errors.New("invalid interval"))
+	}
+
+	return d, nil
+}
+
+// Register returns the register having the name.
+func (cfg Config) Register(name string) (Register, bool) {
+	for _, r := range cfg.Registers {
+		if r.Name == name {
+			return r, true
+		}
+	}
+
+	return Register{}, false
+}
+
+func (r Register) validate() error {
+	if !nameRegexp.MatchString(r.Name) {
+		return errors.Wrap(ErrInvalidConfig, errors.New("invalid register name "+r.Name))
+	}
+
+	switch r.Type {
+	case Coil, DiscreteInput:
+		if r.DataType != "" && r.DataType != Bool {
+			return errors.Wrap(ErrInvalidConfig, errors.New("invalid data type of register "+r.Name))
+		}
+	case Holding, Input:
+		if r.DataType == Bool || r.quantity() == 0 {
+			return errors.Wrap(ErrInvalidConfig, errors.New("invalid data type of register "+r.Name))
+		}
+	default:
+		return errors.Wrap(ErrInvalidConfig, errors.New("invalid type of register "+r.Name))
+	}
+
+	return nil
+}
+
+// Writable reports whether the register can be written.
+func (r Register) Writable() bool {
+	return r.Type == Coil || r.Type == Holding
+}
+
+// quantity returns the number of 16-bit registers holding the value.
+func (r Register) quantity() uint16 {
+	switch r.DataType {
+	case "", Int16, Uint16:
+		return 1
+	case Int32, Uint32, Float32:
+		return 2
+	case Float64:
+		return 4
+	default:
+		return 0
+	}
+}
+
+func (r Register) scale() float64 {
+	if r.Scale == 0 {
+		return 1
+	}
+
+	return r.Scale
+}
+
+// decode returns the scaled value of the big-endian register contents.
+func (r Register) decode(b []byte) (float64, error) {
+	if len(b) < int(r.quantity())*2 {
+		return 0, errors.New("short register read")
+	}
+	b = r.swap(b[:r.quantity()*2])
+
+	var v float64
+	switch r.DataType {
+	case "", Uint16:
+		v = float64(binary.BigEndian.Uint16(b))
+	case Int16:
+		v = float64(int16(binary.BigEndian.Uint16(b)))
+	case Uint32:
+		v = float64(binary.BigEndian.Uint32(b))
+	case Int32:
+		v = float64(int32(binary.BigEndian.Uint32(b)))
+	case Float32:
+		v = float64(math.Float32frombits(binary.BigEndian.Uint32(b)))
+	case Float64:
+		v = math.Float64frombits(binary.BigEndian.Uint64(b))
+	}
+
+	return v*r.scale() + r.Offset, nil
+}
+
+// encode returns the big-endian register contents of the scaled value.
+func (r Register) encode(v float64) []byte {
+	raw := (v - r.Offset) / r.scale()
+	if r.DataType != Float32 && r.DataType != Float64 {
+		raw = math.Round(raw)
+	}
+
+	b := make([]byte, r.quantity()*2)
+	switch r.DataType {
+	case "", Uint16:
+		binary.BigEndian.PutUint16(b, uint16(raw))
+	case Int16:
+		binary.BigEndian.PutUint16(b, uint16(int16(raw)))
+	case Uint32:
+		binary.BigEndian.PutUint32(b, uint32(raw))
+	case Int32:
+		binary.BigEndian.PutUint32(b, uint32(int32(raw)))
+	case Float32:
+		binary.BigEndian.PutUint32(b, math.Float32bits(float32(raw)))
+	case Float64:
+		binary.BigEndian.PutUint64(b, math.Float64bits(raw))
+	}
+
+	return r.swap(b)
+}
+
+// swap reverses the order of the 16-bit words of the value, for devices
+// storing the least significant word first.
+func (r Register) swap(b []byte) []byte {
+	if !r.SwapWords {
+		return b
+	}
+
+	s := make([]byte, len(b))
+	for i := 0; i < len(b); i += 2 {
+		j := len(b) - i - 2
+		s[j], s[j+1] = b[i], b[i+1]
+	}
+
+	return s
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package modbus contains the domain concept definitions needed to support
+// Mainflux Modbus adapter service functionality.
+package modbus
@@ -0,0 +1,43 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// Command represents a write command published on the write subtopic of a
+// channel. The value is a boolean for coils and a number for holding
+// registers, which is scaled the same way as the readings.
+type Command struct {
+	ThingID  string      `json:"thing_id"`
+	Register string      `json:"register"`
+	Value    interface{} `json:"value"`
+}
+
+type downlinkHandler struct {
+	svc Service
+}
+
+// NewDownlinkHandler returns a handler of the messages published on the
+// write subtopic, which writes the values they carry to the Modbus devices.
+func NewDownlinkHandler(svc Service) messaging.MessageHandler {
+	return downlinkHandler{svc: svc}
+}
+
+func (h downlinkHandler) Handle(msg messaging.Message) error {
+	var cmd Command
+	if err := json.Unmarshal(msg.Payload, &cmd); err != nil {
+		return ErrMalformedCommand
+	}
+
+	return h.svc.Write(context.Background(), msg.Channel, cmd)
+}
+
+func (h downlinkHandler) Cancel() error {
+	return nil
+}
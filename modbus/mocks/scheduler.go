@@ -0,0 +1,52 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/modbus"
+)
+
+// Scheduler is a mock scheduler which records the scheduled polls instead of
+// running them.
+type Scheduler struct {
+	mu    sync.Mutex
+	polls map[string]time.Duration
+}
+
+var _ modbus.Scheduler = (*Scheduler)(nil)
+
+// NewScheduler returns mock scheduler instance.
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		polls: make(map[string]time.Duration),
+	}
+}
+
+// Schedule records the poll interval of the thing.
+func (s *Scheduler) Schedule(thingID string, interval time.Duration, _ func() error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.polls[thingID] = interval
+}
+
+// Cancel removes the poll of the thing.
+func (s *Scheduler) Cancel(thingID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.polls, thingID)
+}
+
+// Interval returns the poll interval of the thing, if it's scheduled.
+func (s *Scheduler) Interval(thingID string) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.polls[thingID]
+	return d, ok
+}
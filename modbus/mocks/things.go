@@ -0,0 +1,65 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/modbus"
+)
+
+var _ modbus.ThingRepository = (*thingRepositoryMock)(nil)
+
+type thingRepositoryMock struct {
+	mu     sync.Mutex
+	things map[string]modbus.Thing
+}
+
+// NewThingRepository returns mock thing repository instance.
+func NewThingRepository() modbus.ThingRepository {
+	return &thingRepositoryMock{
+		things: make(map[string]modbus.Thing),
+	}
+}
+
+func (trm *thingRepositoryMock) Save(_ context.Context, th modbus.Thing) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	trm.things[th.ID] = th
+	return nil
+}
+
+func (trm *thingRepositoryMock) Retrieve(_ context.Context, id string) (modbus.Thing, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	th, ok := trm.things[id]
+	if !ok {
+		return modbus.Thing{}, modbus.ErrNotFound
+	}
+
+	return th, nil
+}
+
+func (trm *thingRepositoryMock) RetrieveAll(_ context.Context) ([]modbus.Thing, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	ths := []modbus.Thing{}
+	for _, th := range trm.things {
+		ths = append(ths, th)
+	}
+
+	return ths, nil
+}
+
+func (trm *thingRepositoryMock) Remove(_ context.Context, id string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	delete(trm.things, id)
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"context"
+	"time"
+)
+
+// Thing represents a thing polled by the adapter, i.e. its Modbus device
+// configuration and the channels it is connected to.
+type Thing struct {
+	ID       string   `json:"id"`
+	Config   Config   `json:"config"`
+	Channels []string `json:"channels"`
+}
+
+func (th Thing) connected(chanID string) bool {
+	for _, c := range th.Channels {
+		if c == chanID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ThingRepository specifies the persistence API of the polled things.
+type ThingRepository interface {
+	// Save persists the thing.
+	Save(ctx context.Context, th Thing) error
+
+	// Retrieve returns the thing having the ID.
+	Retrieve(ctx context.Context, id string) (Thing, error)
+
+	// RetrieveAll returns all the things.
+	RetrieveAll(ctx context.Context) ([]Thing, error)
+
+	// Remove removes the thing having the ID.
+	Remove(ctx context.Context, id string) error
+}
+
+// Client represents a connection to a Modbus device.
+type Client interface {
+	// ReadCoils reads the status of the coils.
+	ReadCoils(address, quantity uint16) ([]byte, error)
+
+	// ReadDiscreteInputs reads the status of the discrete inputs.
+	ReadDiscreteInputs(address, quantity uint16) ([]byte, error)
+
+	// ReadHoldingRegisters reads the contents of the holding registers.
+	ReadHoldingRegisters(address, quantity uint16) ([]byte, error)
+
+	// ReadInputRegisters reads the contents of the input registers.
+	ReadInputRegisters(address, quantity uint16) ([]byte, error)
+
+	// WriteSingleCoil sets the coil to ON (0xFF00) or OFF (0x0000).
+	WriteSingleCoil(address, value uint16) ([]byte, error)
+
+	// WriteMultipleRegisters writes the contents of the holding registers.
+	WriteMultipleRegisters(address, quantity uint16, value []byte) ([]byte, error)
+
+	// Close closes the connection to the device.
+	Close() error
+}
+
+// Dialer connects to Modbus devices.
+type Dialer interface {
+	// Dial returns the client connected to the device of the configuration.
+	Dial(cfg Config) (Client, error)
+}
+
+// Scheduler runs the periodic polls of the things.
+type Scheduler interface {
+	// Schedule runs the poll of the thing every interval, replacing the
+	// poll scheduled for the thing before, if any.
+	Schedule(thingID string, interval time.Duration, poll func() error)
+
+	// Cancel stops polling the thing.
+	Cancel(thingID string)
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/modbus"
+	"github.com/MainfluxLabs/mainflux/pkg/events"
+	eventsredis "github.com/MainfluxLabs/mainflux/pkg/events/redis"
+	"github.com/go-redis/redis/v8"
+)
+
+const keyType = "modbus"
+
+var _ events.ThingHandler = (*thingHandler)(nil)
+
+type thingHandler struct {
+	svc modbus.Service
+}
+
+// NewEventStore returns new event store instance, which provisions the
+// things with the Modbus configuration in their metadata.
+func NewEventStore(svc modbus.Service, client *redis.Client, consumer string, log logger.Logger) events.Subscriber {
+	return eventsredis.NewThingsSubscriber(thingHandler{svc: svc}, client, keyType, consumer, log, modbus.ErrNotFound)
+}
+
+func (th thingHandler) CreateThing(ctx context.Context, thingID string, config json.RawMessage) error {
+	var cfg modbus.Config
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return modbus.ErrInvalidConfig
+	}
+
+	return th.svc.CreateThing(ctx, thingID, cfg)
+}
+
+func (th thingHandler) UpdateThing(ctx context.Context, thingID string, config json.RawMessage) error {
+	var cfg modbus.Config
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return modbus.ErrInvalidConfig
+	}
+
+	return th.svc.UpdateThing(ctx, thingID, cfg)
+}
+
+func (th thingHandler) RemoveThing(ctx context.Context, thingID string) error {
+	return th.svc.RemoveThing(ctx, thingID)
+}
+
+func (th thingHandler) ConnectThing(ctx context.Context, chanID, thingID string) error {
+	return th.svc.ConnectThing(ctx, chanID, thingID)
+}
+
+func (th thingHandler) DisconnectThing(ctx context.Context, chanID, thingID string) error {
+	return th.svc.DisconnectThing(ctx, chanID, thingID)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MainfluxLabs/mainflux/modbus"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/go-redis/redis/v8"
+)
+
+const thingsKey = "modbus:things"
+
+var _ modbus.ThingRepository = (*thingRepository)(nil)
+
+type thingRepository struct {
+	client *redis.Client
+}
+
+// NewThingRepository returns redis thing repository implementation.
+func NewThingRepository(client *redis.Client) modbus.ThingRepository {
+	return &thingRepository{
+		client: client,
+	}
+}
+
+func (tr *thingRepository) Save(ctx context.Context, th modbus.Thing) error {
+	b, err := json.Marshal(th)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	if err := tr.client.HSet(ctx, thingsKey, th.ID, b).Err(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tr *thingRepository) Retrieve(ctx context.Context, id string) (modbus.Thing, error) {
+	b, err := tr.client.HGet(ctx, thingsKey, id).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return modbus.Thing{}, modbus.ErrNotFound
+		}
+		return modbus.Thing{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	var th modbus.Thing
+	if err := json.Unmarshal(b, &th); err != nil {
+		return modbus.Thing{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return th, nil
+}
+
+func (tr *thingRepository) RetrieveAll(ctx context.Context) ([]modbus.Thing, error) {
+	vals, err := tr.client.HGetAll(ctx, thingsKey).Result()
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	ths := []modbus.Thing{}
+	for _, v := range vals {
+		var th modbus.Thing
+		if err := json.Unmarshal([]byte(v), &th); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		ths = append(ths, th)
+	}
+
+	return ths, nil
+}
+
+func (tr *thingRepository) Remove(ctx context.Context, id string) error {
+	if err := tr.client.HDel(ctx, thingsKey, id).Err(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
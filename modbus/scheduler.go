@@ -0,0 +1,67 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package modbus
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+)
+
+var _ Scheduler = (*scheduler)(nil)
+
+type scheduler struct {
+	mu     sync.Mutex
+	polls  map[string]chan struct{}
+	logger logger.Logger
+}
+
+// NewScheduler returns the scheduler running every poll in its own goroutine.
+// Failed polls are logged and retried on the next tick.
+func NewScheduler(logger logger.Logger) Scheduler {
+	return &scheduler{
+		polls:  make(map[string]chan struct{}),
+		logger: logger,
+	}
+}
+
+func (s *scheduler) Schedule(thingID string, interval time.Duration, poll func() error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if done, ok := s.polls[thingID]; ok {
+		close(done)
+	}
+
+	done := make(chan struct{})
+	s.polls[thingID] = done
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := poll(); err != nil {
+					s.logger.Warn(fmt.Sprintf("Failed to poll thing %s: %s", thingID, err))
+				}
+			}
+		}
+	}()
+}
+
+func (s *scheduler) Cancel(thingID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if done, ok := s.polls[thingID]; ok {
+		close(done)
+		delete(s.polls, thingID)
+	}
+}
@@ -16,6 +16,9 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/MainfluxLabs/mproxy/pkg/session"
 )
 
@@ -42,6 +45,7 @@ const (
 	logErrFailedParseSubtopic          = "failed to parse subtopic: "
 	LogErrFailedPublishConnectEvent    = "failed to publish connect event: "
 	LogErrFailedPublishToMsgBroker     = "failed to publish to mainflux message broker: "
+	LogErrInvalidPayload               = "rejected payload of client_id %s to channel %s: "
 )
 
 var (
@@ -63,17 +67,21 @@ type handler struct {
 	logger     logger.Logger
 	es         redis.EventStore
 	service    Service
+	limiter    ratelimit.Limiter
+	validator  schema.ChannelValidator
 }
 
 // NewHandler creates new Handler entity
 func NewHandler(publishers []messaging.Publisher, es redis.EventStore,
-	logger logger.Logger, auth auth.Client, svc Service) session.Handler {
+	logger logger.Logger, auth auth.Client, svc Service, limiter ratelimit.Limiter, validator schema.ChannelValidator) session.Handler {
 	return &handler{
 		es:         es,
 		logger:     logger,
 		publishers: publishers,
 		auth:       auth,
 		service:    svc,
+		limiter:    limiter,
+		validator:  validator,
 	}
 }
 
@@ -114,10 +122,28 @@ func (h *handler) AuthPublish(c *session.Client, topic *string, payload *[]byte)
 		return ErrMissingTopicPub
 	}
 
-	if err := h.authAccess(c, *topic); err != nil {
+	if payload != nil {
+		if err := h.limiter.CheckPayload(len(*payload)); err != nil {
+			return err
+		}
+	}
+
+	chanID, err := h.authAccess(c, *topic, things.PublishAction)
+	if err != nil {
+		return err
+	}
+
+	if err := h.limiter.Allow(c.Username); err != nil {
 		return err
 	}
 
+	if payload != nil {
+		if err := h.validator.Validate(context.Background(), chanID, *payload); err != nil {
+			h.logger.Warn(fmt.Sprintf(LogErrInvalidPayload, c.ID, chanID) + err.Error())
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -132,8 +158,7 @@ func (h *handler) AuthSubscribe(c *session.Client, topics *[]string) error {
 	}
 
 	for _, t := range *topics {
-		err := h.authAccess(c, t)
-		if err != nil {
+		if _, err := h.authAccess(c, t, things.SubscribeAction); err != nil {
 			return err
 		}
 	}
@@ -250,28 +275,30 @@ func (h *handler) Disconnect(c *session.Client) {
 	}
 }
 
-func (h *handler) authAccess(c *session.Client, topic string) error {
+// authAccess authorizes the client action on the topic and returns the ID
+// of the topic channel.
+func (h *handler) authAccess(c *session.Client, topic, action string) (string, error) {
 	// Topics are in the format:
 	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
 	if !channelRegExp.Match([]byte(topic)) {
-		return ErrMalformedTopic
+		return "", ErrMalformedTopic
 	}
 
 	channelParts := channelRegExp.FindStringSubmatch(topic)
-	if len(channelParts) < 1 {
-		return ErrMalformedTopic
+	if len(channelParts) < 2 {
+		return "", ErrMalformedTopic
 	}
 
-	thID, _, err := h.auth.ConnectionIDS(context.Background(), string(c.Password))
+	thID, err := h.auth.Authorize(context.Background(), channelParts[1], string(c.Password), action)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if thID != c.Username {
-		return ErrAuthentication
+		return "", ErrAuthentication
 	}
 
-	return nil
+	return channelParts[1], nil
 }
 
 func parseSubtopic(subtopic string) (string, error) {
@@ -12,6 +12,8 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	pubmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mproxy/pkg/session"
 	"github.com/stretchr/testify/assert"
 )
@@ -143,6 +145,72 @@ func TestAuthPublish(t *testing.T) {
 	}
 }
 
+func TestAuthPublishLimits(t *testing.T) {
+	handler := newHandlerWith(ratelimit.Config{Rate: 0.001, Burst: 1, MaxPayloadSize: len(payload)}, nil)
+	largePayload := append([]byte{' '}, payload...)
+
+	cases := []struct {
+		desc    string
+		err     error
+		payload []byte
+	}{
+		{
+			desc:    "publish with payload exceeding the maximum size",
+			err:     ratelimit.ErrPayloadTooLarge,
+			payload: largePayload,
+		},
+		{
+			desc:    "publish within the rate limit",
+			err:     nil,
+			payload: payload,
+		},
+		{
+			desc:    "publish exceeding the rate limit",
+			err:     ratelimit.ErrRateLimited,
+			payload: payload,
+		},
+	}
+
+	for _, tc := range cases {
+		err := handler.AuthPublish(&sessionClient, &topic, &tc.payload)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAuthPublishSchema(t *testing.T) {
+	schemas := map[string]schema.Schema{
+		chanID: {SenML: map[string]string{"test-name": ""}},
+	}
+	handler := newHandlerWith(ratelimit.Config{}, schemas)
+	logBuffer.Reset()
+
+	cases := []struct {
+		desc    string
+		err     error
+		payload []byte
+		logMsg  string
+	}{
+		{
+			desc:    "publish payload conforming to the channel schema",
+			err:     nil,
+			payload: []byte(`[{"n":"test-name","v":1.2}]`),
+			logMsg:  "",
+		},
+		{
+			desc:    "publish payload not conforming to the channel schema",
+			err:     schema.ErrInvalidPayload,
+			payload: []byte(`[{"n":"other-name","v":1.2}]`),
+			logMsg:  fmt.Sprintf(mqtt.LogErrInvalidPayload, clientID, chanID),
+		},
+	}
+
+	for _, tc := range cases {
+		err := handler.AuthPublish(&sessionClient, &topic, &tc.payload)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Contains(t, logBuffer.String(), tc.logMsg)
+	}
+}
+
 func TestAuthSubscribe(t *testing.T) {
 	handler := newHandler()
 
@@ -380,6 +448,10 @@ func TestDisconnect(t *testing.T) {
 }
 
 func newHandler() session.Handler {
+	return newHandlerWith(ratelimit.Config{}, nil)
+}
+
+func newHandlerWith(limits ratelimit.Config, schemas map[string]schema.Schema) session.Handler {
 	logger, err := logger.New(&logBuffer, "debug")
 	if err != nil {
 		log.Fatalf("failed to create logger: %s", err)
@@ -387,5 +459,6 @@ func newHandler() session.Handler {
 
 	authClient := mocks.NewClient(map[string]string{password: thingID}, map[string]string{thingID: chanID})
 	eventStore := mocks.NewEventStore()
-	return mqtt.NewHandler([]messaging.Publisher{pubmocks.NewPublisher()}, eventStore, logger, authClient, newService())
+	thingsClient := pubmocks.NewThingsServiceClientWithSchemas(nil, nil, schemas)
+	return mqtt.NewHandler([]messaging.Publisher{pubmocks.NewPublisher()}, eventStore, logger, authClient, newService(), ratelimit.New(limits), schema.NewChannelValidator(thingsClient))
 }
@@ -21,18 +21,17 @@ func NewClient(key map[string]string, conns map[string]string) auth.Client {
 	return MockClient{key: key, conns: conns}
 }
 
-func (cli MockClient) ConnectionIDS(ctx context.Context, key string) (string, string, error) {
+func (cli MockClient) Authorize(ctx context.Context, chanID, key, action string) (string, error) {
 	thID, ok := cli.key[key]
 	if !ok {
-		return "", "", errors.ErrAuthentication
+		return "", errors.ErrAuthentication
 	}
 
-	chID, ok := cli.conns[thID]
-	if !ok {
-		return "", "", errors.ErrAuthentication
+	if chID, ok := cli.conns[thID]; !ok || chID != chanID {
+		return "", errors.ErrAuthorization
 	}
 
-	return thID, chID, nil
+	return thID, nil
 }
 
 func (cli MockClient) Identify(ctx context.Context, thingKey string) (string, error) {
@@ -101,3 +100,7 @@ func (svc authServiceMock) AssignRole(ctx context.Context, req *mainflux.AssignR
 func (svc authServiceMock) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (r *mainflux.RetrieveRoleRes, err error) {
 	panic("not implemented")
 }
+
+func (svc authServiceMock) RetrieveQuota(_ context.Context, _ *mainflux.QuotaReq, _ ...grpc.CallOption) (*mainflux.QuotaRes, error) {
+	return &mainflux.QuotaRes{}, nil
+}
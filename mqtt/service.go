@@ -5,6 +5,8 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -80,6 +82,10 @@ func (ms *mqttService) authorize(ctx context.Context, token, key, chanID string)
 			return err
 		}
 
+		if !auth.HasScope(user.GetScopes(), auth.MessagesReadScope) || !auth.HasResource(user.GetChannelIDs(), chanID) {
+			return errors.ErrAuthorization
+		}
+
 		if _, err := ms.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.RootSubject}); err == nil {
 			return nil
 		}
@@ -89,7 +95,7 @@ func (ms *mqttService) authorize(ctx context.Context, token, key, chanID string)
 		}
 		return nil
 	default:
-		if _, err := ms.things.GetConnByKey(ctx, &mainflux.ConnByKeyReq{Key: key}); err != nil {
+		if _, err := ms.things.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: key, ChanID: chanID, Action: things.SubscribeAction}); err != nil {
 			return err
 		}
 		return nil
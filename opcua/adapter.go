@@ -0,0 +1,317 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/senml"
+)
+
+const (
+	protocol = "opcua"
+
+	// RootNodeID is the identifier of the root folder of the OPC UA address space.
+	RootNodeID = "i=84"
+)
+
+var (
+	// ErrNotFoundServerURI indicates a non-existent route map for a server URI.
+	ErrNotFoundServerURI = errors.New("route map not found for this server URI")
+
+	// ErrNotFoundNodeID indicates a non-existent route map for a node ID.
+	ErrNotFoundNodeID = errors.New("route map not found for this node ID")
+
+	// ErrNotConnected indicates a non-existent route map for a connection.
+	ErrNotConnected = errors.New("route map not found for this connection")
+
+	// ErrUnsupportedValue indicates a node value that can't be converted to SenML.
+	ErrUnsupportedValue = errors.New("unsupported node value type")
+
+	// ErrMalformedCommand indicates malformed write command.
+	ErrMalformedCommand = errors.New("malformed write command")
+)
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateThing creates thingID:nodeID route-map
+	CreateThing(ctx context.Context, thingID, nodeID string) error
+
+	// UpdateThing updates thingID:nodeID route-map
+	UpdateThing(ctx context.Context, thingID, nodeID string) error
+
+	// RemoveThing removes thingID:nodeID route-map
+	RemoveThing(ctx context.Context, thingID string) error
+
+	// CreateChannel creates channelID:serverURI route-map
+	CreateChannel(ctx context.Context, chanID, serverURI string) error
+
+	// UpdateChannel updates channelID:serverURI route-map
+	UpdateChannel(ctx context.Context, chanID, serverURI string) error
+
+	// RemoveChannel removes channelID:serverURI route-map
+	RemoveChannel(ctx context.Context, chanID string) error
+
+	// ConnectThing creates thingID:channelID route-map and subscribes to
+	// the node of the thing on the server of the channel.
+	ConnectThing(ctx context.Context, chanID, thingID string) error
+
+	// DisconnectThing removes thingID:channelID route-map and cancels the
+	// subscription to the node of the thing.
+	DisconnectThing(ctx context.Context, chanID, thingID string) error
+
+	// Browse returns the nodes referenced by the node on the OPC UA server.
+	Browse(ctx context.Context, serverURI, nodeID string) ([]BrowsedNode, error)
+
+	// Publish forwards the data change of the OPC UA node to Mainflux
+	// Message Broker as SenML.
+	Publish(ctx context.Context, m Message) error
+
+	// WriteNode writes the value of the command received on the channel to
+	// the node of the thing.
+	WriteNode(ctx context.Context, chanID string, cmd Command) error
+}
+
+var _ Service = (*adapterService)(nil)
+
+type adapterService struct {
+	publisher  messaging.Publisher
+	client     Client
+	thingsRM   RouteMapRepository
+	channelsRM RouteMapRepository
+	connectRM  RouteMapRepository
+}
+
+// New instantiates the OPC UA adapter implementation.
+func New(publisher messaging.Publisher, client Client, thingsRM, channelsRM, connectRM RouteMapRepository) Service {
+	return &adapterService{
+		publisher:  publisher,
+		client:     client,
+		thingsRM:   thingsRM,
+		channelsRM: channelsRM,
+		connectRM:  connectRM,
+	}
+}
+
+func (as *adapterService) CreateThing(ctx context.Context, thingID, nodeID string) error {
+	return as.thingsRM.Save(ctx, thingID, nodeID)
+}
+
+func (as *adapterService) UpdateThing(ctx context.Context, thingID, nodeID string) error {
+	return as.thingsRM.Save(ctx, thingID, nodeID)
+}
+
+func (as *adapterService) RemoveThing(ctx context.Context, thingID string) error {
+	return as.thingsRM.Remove(ctx, thingID)
+}
+
+func (as *adapterService) CreateChannel(ctx context.Context, chanID, serverURI string) error {
+	return as.channelsRM.Save(ctx, chanID, serverURI)
+}
+
+func (as *adapterService) UpdateChannel(ctx context.Context, chanID, serverURI string) error {
+	return as.channelsRM.Save(ctx, chanID, serverURI)
+}
+
+func (as *adapterService) RemoveChannel(ctx context.Context, chanID string) error {
+	return as.channelsRM.Remove(ctx, chanID)
+}
+
+func (as *adapterService) ConnectThing(ctx context.Context, chanID, thingID string) error {
+	serverURI, err := as.channelsRM.Get(ctx, chanID)
+	if err != nil {
+		return ErrNotFoundServerURI
+	}
+
+	nodeID, err := as.thingsRM.Get(ctx, thingID)
+	if err != nil {
+		return ErrNotFoundNodeID
+	}
+
+	c := fmt.Sprintf("%s:%s", chanID, thingID)
+	if err := as.connectRM.Save(ctx, c, c); err != nil {
+		return err
+	}
+
+	return as.client.Subscribe(ctx, serverURI, nodeID)
+}
+
+func (as *adapterService) DisconnectThing(ctx context.Context, chanID, thingID string) error {
+	serverURI, err := as.channelsRM.Get(ctx, chanID)
+	if err != nil {
+		return ErrNotFoundServerURI
+	}
+
+	nodeID, err := as.thingsRM.Get(ctx, thingID)
+	if err != nil {
+		return ErrNotFoundNodeID
+	}
+
+	c := fmt.Sprintf("%s:%s", chanID, thingID)
+	if err := as.connectRM.Remove(ctx, c); err != nil {
+		return err
+	}
+
+	return as.client.Unsubscribe(ctx, serverURI, nodeID)
+}
+
+func (as *adapterService) Browse(ctx context.Context, serverURI, nodeID string) ([]BrowsedNode, error) {
+	if nodeID == "" {
+		nodeID = RootNodeID
+	}
+
+	return as.client.Browse(ctx, serverURI, nodeID)
+}
+
+// Publish forwards the data change of the OPC UA node to Mainflux Message broker
+func (as *adapterService) Publish(ctx context.Context, m Message) error {
+	thingID, err := as.thingsRM.Get(ctx, m.NodeID)
+	if err != nil {
+		return ErrNotFoundNodeID
+	}
+
+	chanID, err := as.channelsRM.Get(ctx, m.ServerURI)
+	if err != nil {
+		return ErrNotFoundServerURI
+	}
+
+	c := fmt.Sprintf("%s:%s", chanID, thingID)
+	if _, err := as.connectRM.Get(ctx, c); err != nil {
+		return ErrNotConnected
+	}
+
+	created := time.Now()
+	if m.Time.IsZero() {
+		m.Time = created
+	}
+
+	rec, err := record(m)
+	if err != nil {
+		return err
+	}
+
+	payload, err := senml.Encode(senml.Pack{Records: []senml.Record{rec}}, senml.JSON)
+	if err != nil {
+		return err
+	}
+
+	msg := messaging.Message{
+		Publisher: thingID,
+		Protocol:  protocol,
+		Channel:   chanID,
+		Payload:   payload,
+		Created:   created.UnixNano(),
+	}
+
+	return as.publisher.Publish(msg.Channel, msg)
+}
+
+// WriteNode writes the value of the command to the OPC UA node of the thing
+func (as *adapterService) WriteNode(ctx context.Context, chanID string, cmd Command) error {
+	if cmd.ThingID == "" || cmd.Value == nil {
+		return ErrMalformedCommand
+	}
+
+	serverURI, err := as.channelsRM.Get(ctx, chanID)
+	if err != nil {
+		return ErrNotFoundServerURI
+	}
+
+	nodeID, err := as.thingsRM.Get(ctx, cmd.ThingID)
+	if err != nil {
+		return ErrNotFoundNodeID
+	}
+
+	c := fmt.Sprintf("%s:%s", chanID, cmd.ThingID)
+	if _, err := as.connectRM.Get(ctx, c); err != nil {
+		return ErrNotConnected
+	}
+
+	return as.client.Write(ctx, serverURI, nodeID, cmd.Value)
+}
+
+// record returns the SenML record of the data change, named by the node ID.
+func record(m Message) (senml.Record, error) {
+	rec := senml.Record{
+		Name: recordName(m.NodeID),
+		Time: float64(m.Time.UnixNano()) / 1e9,
+	}
+
+	switch v := m.Value.(type) {
+	case float64:
+		rec.Value = &v
+	case float32:
+		f := float64(v)
+		rec.Value = &f
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		f, err := toFloat(v)
+		if err != nil {
+			return senml.Record{}, err
+		}
+		rec.Value = &f
+	case bool:
+		rec.BoolValue = &v
+	case string:
+		rec.StringValue = &v
+	case []byte:
+		d := base64.StdEncoding.EncodeToString(v)
+		rec.DataValue = &d
+	default:
+		return senml.Record{}, ErrUnsupportedValue
+	}
+
+	return rec, nil
+}
+
+func toFloat(v interface{}) (float64, error) {
+	switch i := v.(type) {
+	case int:
+		return float64(i), nil
+	case int8:
+		return float64(i), nil
+	case int16:
+		return float64(i), nil
+	case int32:
+		return float64(i), nil
+	case int64:
+		return float64(i), nil
+	case uint:
+		return float64(i), nil
+	case uint8:
+		return float64(i), nil
+	case uint16:
+		return float64(i), nil
+	case uint32:
+		return float64(i), nil
+	case uint64:
+		return float64(i), nil
+	default:
+		return 0, ErrUnsupportedValue
+	}
+}
+
+// recordName converts the node ID, e.g. ns=2;s=Temperature, into a valid
+// SenML name, e.g. ns:2/s:Temperature.
+func recordName(nodeID string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '=':
+			return ':'
+		case r == ';':
+			return '/'
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '-', r == ':', r == '.', r == '/', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, nodeID)
+}
@@ -0,0 +1,233 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/opcua"
+	"github.com/MainfluxLabs/mainflux/opcua/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	pubmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	thingID    = "thingID-1"
+	chanID     = "chanID-1"
+	nodeID     = "ns=2;s=Temperature"
+	serverURI  = "opc.tcp://localhost:4840"
+	thingID2   = "thingID-2"
+	chanID2    = "chanID-2"
+	nodeID2    = "ns=2;s=Pressure"
+	serverURI2 = "opc.tcp://localhost:4841"
+)
+
+var nodes = map[string]map[string][]opcua.BrowsedNode{
+	serverURI: {
+		opcua.RootNodeID: {
+			{NodeID: "i=85", Name: "Objects"},
+			{NodeID: "i=86", Name: "Types"},
+		},
+		"i=85": {
+			{NodeID: nodeID, Name: "Temperature", DataType: "Double"},
+		},
+	},
+	serverURI2: {},
+}
+
+func newService() opcua.Service {
+	pub := pubmocks.NewPublisher()
+	client := mocks.NewClient(nodes)
+	thingsRM := mocks.NewRouteMap()
+	channelsRM := mocks.NewRouteMap()
+	connsRM := mocks.NewRouteMap()
+
+	return opcua.New(pub, client, thingsRM, channelsRM, connsRM)
+}
+
+func TestConnectThing(t *testing.T) {
+	svc := newService()
+
+	err := svc.CreateChannel(nil, chanID, serverURI)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateThing(nil, thingID, nodeID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		chanID  string
+		thingID string
+		err     error
+	}{
+		{
+			desc:    "connect thing with existing route-maps",
+			chanID:  chanID,
+			thingID: thingID,
+			err:     nil,
+		},
+		{
+			desc:    "connect thing with non existing channel route-map",
+			chanID:  "wrong",
+			thingID: thingID,
+			err:     opcua.ErrNotFoundServerURI,
+		},
+		{
+			desc:    "connect thing with non existing thing route-map",
+			chanID:  chanID,
+			thingID: "wrong",
+			err:     opcua.ErrNotFoundNodeID,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ConnectThing(nil, tc.chanID, tc.thingID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPublish(t *testing.T) {
+	svc := newService()
+
+	err := svc.CreateChannel(nil, chanID, serverURI)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateThing(nil, thingID, nodeID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.ConnectThing(nil, chanID, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateChannel(nil, chanID2, serverURI2)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateThing(nil, thingID2, nodeID2)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc string
+		msg  opcua.Message
+		err  error
+	}{
+		{
+			desc: "publish float value",
+			msg:  opcua.Message{ServerURI: serverURI, NodeID: nodeID, Time: time.Now(), Value: 21.5},
+			err:  nil,
+		},
+		{
+			desc: "publish integer value",
+			msg:  opcua.Message{ServerURI: serverURI, NodeID: nodeID, Value: int32(21)},
+			err:  nil,
+		},
+		{
+			desc: "publish bool value",
+			msg:  opcua.Message{ServerURI: serverURI, NodeID: nodeID, Value: true},
+			err:  nil,
+		},
+		{
+			desc: "publish string value",
+			msg:  opcua.Message{ServerURI: serverURI, NodeID: nodeID, Value: "running"},
+			err:  nil,
+		},
+		{
+			desc: "publish byte string value",
+			msg:  opcua.Message{ServerURI: serverURI, NodeID: nodeID, Value: []byte{0x01, 0x02}},
+			err:  nil,
+		},
+		{
+			desc: "publish unsupported value",
+			msg:  opcua.Message{ServerURI: serverURI, NodeID: nodeID, Value: []float64{1, 2}},
+			err:  opcua.ErrUnsupportedValue,
+		},
+		{
+			desc: "publish with non existing node route-map",
+			msg:  opcua.Message{ServerURI: serverURI, NodeID: "wrong", Value: 21.5},
+			err:  opcua.ErrNotFoundNodeID,
+		},
+		{
+			desc: "publish with non existing server route-map",
+			msg:  opcua.Message{ServerURI: "wrong", NodeID: nodeID, Value: 21.5},
+			err:  opcua.ErrNotFoundServerURI,
+		},
+		{
+			desc: "publish with non existing connection route-map",
+			msg:  opcua.Message{ServerURI: serverURI2, NodeID: nodeID2, Value: 21.5},
+			err:  opcua.ErrNotConnected,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Publish(nil, tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestWriteNode(t *testing.T) {
+	svc := newService()
+
+	err := svc.CreateChannel(nil, chanID, serverURI)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateThing(nil, thingID, nodeID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.ConnectThing(nil, chanID, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.CreateThing(nil, thingID2, nodeID2)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		chanID string
+		cmd    opcua.Command
+		err    error
+	}{
+		{
+			desc:   "write value to connected node",
+			chanID: chanID,
+			cmd:    opcua.Command{ThingID: thingID, Value: 42.0},
+			err:    nil,
+		},
+		{
+			desc:   "write without thing ID",
+			chanID: chanID,
+			cmd:    opcua.Command{Value: 42.0},
+			err:    opcua.ErrMalformedCommand,
+		},
+		{
+			desc:   "write without value",
+			chanID: chanID,
+			cmd:    opcua.Command{ThingID: thingID},
+			err:    opcua.ErrMalformedCommand,
+		},
+		{
+			desc:   "write with non existing channel route-map",
+			chanID: "wrong",
+			cmd:    opcua.Command{ThingID: thingID, Value: 42.0},
+			err:    opcua.ErrNotFoundServerURI,
+		},
+		{
+			desc:   "write with non existing thing route-map",
+			chanID: chanID,
+			cmd:    opcua.Command{ThingID: "wrong", Value: 42.0},
+			err:    opcua.ErrNotFoundNodeID,
+		},
+		{
+			desc:   "write with non existing connection route-map",
+			chanID: chanID,
+			cmd:    opcua.Command{ThingID: thingID2, Value: 42.0},
+			err:    opcua.ErrNotConnected,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.WriteNode(nil, tc.chanID, tc.cmd)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/opcua"
+	"github.com/MainfluxLabs/mainflux/opcua/api"
+	"github.com/MainfluxLabs/mainflux/opcua/mocks"
+	pubmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	serverURI = "opc.tcp://localhost:4840"
+	nodeID    = "ns=2;s=Line 1,Temperature"
+)
+
+var nodes = map[string]map[string][]opcua.BrowsedNode{
+	serverURI: {
+		opcua.RootNodeID: {
+			{NodeID: "i=85", Name: "Objects"},
+		},
+		"i=85": {
+			{NodeID: nodeID, Name: "Temperature", DataType: "Double"},
+		},
+	},
+}
+
+func newHTTPServer() *httptest.Server {
+	svc := opcua.New(pubmocks.NewPublisher(), mocks.NewClient(nodes), mocks.NewRouteMap(), mocks.NewRouteMap(), mocks.NewRouteMap())
+	return httptest.NewServer(api.MakeHandler(svc, logger.NewMock()))
+}
+
+type browseRes struct {
+	Nodes []opcua.BrowsedNode `json:"nodes"`
+}
+
+func TestBrowse(t *testing.T) {
+	ts := newHTTPServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		server string
+		node   string
+		status int
+		nodes  []opcua.BrowsedNode
+	}{
+		{
+			desc:   "browse root node",
+			server: serverURI,
+			status: http.StatusOK,
+			nodes:  nodes[serverURI][opcua.RootNodeID],
+		},
+		{
+			desc:   "browse objects node",
+			server: serverURI,
+			node:   "i=85",
+			status: http.StatusOK,
+			nodes:  nodes[serverURI]["i=85"],
+		},
+		{
+			desc:   "browse node without references",
+			server: serverURI,
+			node:   nodeID,
+			status: http.StatusOK,
+			nodes:  []opcua.BrowsedNode{},
+		},
+		{
+			desc:   "browse without server",
+			node:   "i=85",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "browse unknown server",
+			server: "opc.tcp://unknown:4840",
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		q := url.Values{}
+		if tc.server != "" {
+			q.Set("server", tc.server)
+		}
+		if tc.node != "" {
+			q.Set("node", tc.node)
+		}
+
+		res, err := http.Get(fmt.Sprintf("%s/browse?%s", ts.URL, q.Encode()))
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		if tc.status == http.StatusOK {
+			var body browseRes
+			err := json.NewDecoder(res.Body).Decode(&body)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.nodes, body.Nodes, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.nodes, body.Nodes))
+		}
+		res.Body.Close()
+	}
+}
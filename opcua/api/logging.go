@@ -0,0 +1,173 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/opcua"
+)
+
+var _ opcua.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger logger.Logger
+	svc    opcua.Service
+}
+
+// LoggingMiddleware adds logging facilities to the core service.
+func LoggingMiddleware(svc opcua.Service, logger logger.Logger) opcua.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm loggingMiddleware) CreateThing(ctx context.Context, thingID, nodeID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("create_thing for thing %s and node %s took %s to complete", thingID, nodeID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateThing(ctx, thingID, nodeID)
+}
+
+func (lm loggingMiddleware) UpdateThing(ctx context.Context, thingID, nodeID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("update_thing for thing %s and node %s took %s to complete", thingID, nodeID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateThing(ctx, thingID, nodeID)
+}
+
+func (lm loggingMiddleware) RemoveThing(ctx context.Context, thingID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("remove_thing for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveThing(ctx, thingID)
+}
+
+func (lm loggingMiddleware) CreateChannel(ctx context.Context, chanID, serverURI string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("create_channel for channel %s and server %s took %s to complete", chanID, serverURI, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateChannel(ctx, chanID, serverURI)
+}
+
+func (lm loggingMiddleware) UpdateChannel(ctx context.Context, chanID, serverURI string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("update_channel for channel %s and server %s took %s to complete", chanID, serverURI, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateChannel(ctx, chanID, serverURI)
+}
+
+func (lm loggingMiddleware) RemoveChannel(ctx context.Context, chanID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("remove_channel for channel %s took %s to complete", chanID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveChannel(ctx, chanID)
+}
+
+func (lm loggingMiddleware) ConnectThing(ctx context.Context, chanID, thingID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("connect_thing for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ConnectThing(ctx, chanID, thingID)
+}
+
+func (lm loggingMiddleware) DisconnectThing(ctx context.Context, chanID, thingID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("disconnect_thing for channel %s and thing %s took %s to complete", chanID, thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DisconnectThing(ctx, chanID, thingID)
+}
+
+func (lm loggingMiddleware) Browse(ctx context.Context, serverURI, nodeID string) (nodes []opcua.BrowsedNode, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("browse for server %s and node %s took %s to complete", serverURI, nodeID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Browse(ctx, serverURI, nodeID)
+}
+
+func (lm loggingMiddleware) Publish(ctx context.Context, m opcua.Message) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("publish for server %s and node %s took %s to complete", m.ServerURI, m.NodeID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.Publish(ctx, m)
+}
+
+func (lm loggingMiddleware) WriteNode(ctx context.Context, chanID string, cmd opcua.Command) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("write_node for channel %s and thing %s took %s to complete", chanID, cmd.ThingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.WriteNode(ctx, chanID, cmd)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/opcua"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ opcua.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     opcua.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc opcua.Service, counter metrics.Counter, latency metrics.Histogram) opcua.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) CreateThing(ctx context.Context, thingID, nodeID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_thing").Add(1)
+		mm.latency.With("method", "create_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateThing(ctx, thingID, nodeID)
+}
+
+func (mm *metricsMiddleware) UpdateThing(ctx context.Context, thingID, nodeID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_thing").Add(1)
+		mm.latency.With("method", "update_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateThing(ctx, thingID, nodeID)
+}
+
+func (mm *metricsMiddleware) RemoveThing(ctx context.Context, thingID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_thing").Add(1)
+		mm.latency.With("method", "remove_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveThing(ctx, thingID)
+}
+
+func (mm *metricsMiddleware) CreateChannel(ctx context.Context, chanID, serverURI string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_channel").Add(1)
+		mm.latency.With("method", "create_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateChannel(ctx, chanID, serverURI)
+}
+
+func (mm *metricsMiddleware) UpdateChannel(ctx context.Context, chanID, serverURI string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_channel").Add(1)
+		mm.latency.With("method", "update_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateChannel(ctx, chanID, serverURI)
+}
+
+func (mm *metricsMiddleware) RemoveChannel(ctx context.Context, chanID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_channel").Add(1)
+		mm.latency.With("method", "remove_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveChannel(ctx, chanID)
+}
+
+func (mm *metricsMiddleware) ConnectThing(ctx context.Context, chanID, thingID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "connect_thing").Add(1)
+		mm.latency.With("method", "connect_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ConnectThing(ctx, chanID, thingID)
+}
+
+func (mm *metricsMiddleware) DisconnectThing(ctx context.Context, chanID, thingID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "disconnect_thing").Add(1)
+		mm.latency.With("method", "disconnect_thing").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.DisconnectThing(ctx, chanID, thingID)
+}
+
+func (mm *metricsMiddleware) Browse(ctx context.Context, serverURI, nodeID string) ([]opcua.BrowsedNode, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "browse").Add(1)
+		mm.latency.With("method", "browse").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Browse(ctx, serverURI, nodeID)
+}
+
+func (mm *metricsMiddleware) Publish(ctx context.Context, m opcua.Message) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "publish").Add(1)
+		mm.latency.With("method", "publish").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Publish(ctx, m)
+}
+
+func (mm *metricsMiddleware) WriteNode(ctx context.Context, chanID string, cmd opcua.Command) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "write_node").Add(1)
+		mm.latency.With("method", "write_node").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.WriteNode(ctx, chanID, cmd)
+}
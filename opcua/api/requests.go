@@ -0,0 +1,19 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import "github.com/MainfluxLabs/mainflux/internal/apiutil"
+
+type browseReq struct {
+	serverURI string
+	nodeID    string
+}
+
+func (req browseReq) validate() error {
+	if req.serverURI == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
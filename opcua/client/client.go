@@ -0,0 +1,418 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package client contains the OPC UA client implementation backed by gopcua.
+package client
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/opcua"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	gopcua "github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+)
+
+const notificationsSize = 256
+
+var (
+	errConnect     = errors.New("failed to connect to OPC UA server")
+	errNodeID      = errors.New("malformed OPC UA node ID")
+	errSubscribe   = errors.New("failed to subscribe to OPC UA node")
+	errUnsubscribe = errors.New("failed to unsubscribe from OPC UA node")
+	errBrowse      = errors.New("failed to browse OPC UA node")
+	errWrite       = errors.New("failed to write OPC UA node")
+)
+
+// Config represents the OPC UA connection parameters, shared by the servers.
+type Config struct {
+	// Interval is the publishing interval of the subscriptions.
+	Interval time.Duration
+	// Timeout is the timeout of the connection and the requests.
+	Timeout time.Duration
+	// Policy is the security policy, e.g. None or Basic256Sha256.
+	Policy string
+	// Mode is the security mode, i.e. None, Sign or SignAndEncrypt.
+	Mode string
+	// CertFile and KeyFile are the client certificate and private key,
+	// required by the policies other than None.
+	CertFile string
+	KeyFile  string
+}
+
+// PublishFunc handles the data changes of the subscribed nodes.
+type PublishFunc func(ctx context.Context, m opcua.Message) error
+
+var _ opcua.Client = (*Client)(nil)
+
+// Client keeps a single connection, with a single subscription, per server.
+// The connections are opened on the first request to the server.
+type Client struct {
+	cfg     Config
+	publish PublishFunc
+	logger  logger.Logger
+	done    chan struct{}
+
+	mu      sync.Mutex
+	servers map[string]*server
+}
+
+type server struct {
+	mu   sync.Mutex
+	conn *gopcua.Client
+	sub  *gopcua.Subscription
+	// nodes maps the client handles of the monitored items to node IDs.
+	nodes map[uint32]string
+	// items maps the node IDs to the monitored item IDs.
+	items  map[string]uint32
+	handle uint32
+}
+
+// NewClient returns the OPC UA client, which passes the data changes of the
+// subscribed nodes to publish.
+func NewClient(cfg Config, publish PublishFunc, logger logger.Logger) *Client {
+	return &Client{
+		cfg:     cfg,
+		publish: publish,
+		logger:  logger,
+		done:    make(chan struct{}),
+		servers: make(map[string]*server),
+	}
+}
+
+func (c *Client) Subscribe(ctx context.Context, serverURI, nodeID string) error {
+	nid, err := ua.ParseNodeID(nodeID)
+	if err != nil {
+		return errors.Wrap(errNodeID, err)
+	}
+
+	s, err := c.connect(ctx, serverURI)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[nodeID]; ok {
+		return nil
+	}
+
+	if s.sub == nil {
+		notifs := make(chan *gopcua.PublishNotificationData, notificationsSize)
+		sub, err := s.conn.Subscribe(ctx, &gopcua.SubscriptionParameters{Interval: c.cfg.Interval}, notifs)
+		if err != nil {
+			return errors.Wrap(errSubscribe, err)
+		}
+		s.sub = sub
+		go c.listen(serverURI, s, notifs)
+	}
+
+	s.handle++
+	req := gopcua.NewMonitoredItemCreateRequestWithDefaults(nid, ua.AttributeIDValue, s.handle)
+	res, err := s.sub.Monitor(ctx, ua.TimestampsToReturnBoth, req)
+	if err != nil {
+		return errors.Wrap(errSubscribe, err)
+	}
+	if len(res.Results) == 0 {
+		return errSubscribe
+	}
+	if status := res.Results[0].StatusCode; status != ua.StatusOK {
+		return errors.Wrap(errSubscribe, status)
+	}
+
+	s.nodes[s.handle] = nodeID
+	s.items[nodeID] = res.Results[0].MonitoredItemID
+
+	return nil
+}
+
+func (c *Client) Unsubscribe(ctx context.Context, serverURI, nodeID string) error {
+	c.mu.Lock()
+	s, ok := c.servers[serverURI]
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	itemID, ok := s.items[nodeID]
+	if !ok {
+		return nil
+	}
+
+	res, err := s.sub.Unmonitor(ctx, itemID)
+	if err != nil {
+		return errors.Wrap(errUnsubscribe, err)
+	}
+	if len(res.Results) > 0 && res.Results[0] != ua.StatusOK {
+		return errors.Wrap(errUnsubscribe, res.Results[0])
+	}
+
+	delete(s.items, nodeID)
+	for h, n := range s.nodes {
+		if n == nodeID {
+			delete(s.nodes, h)
+		}
+	}
+
+	if len(s.items) == 0 {
+		if err := s.sub.Cancel(ctx); err != nil {
+			return errors.Wrap(errUnsubscribe, err)
+		}
+		s.sub = nil
+	}
+
+	return nil
+}
+
+func (c *Client) Browse(ctx context.Context, serverURI, nodeID string) ([]opcua.BrowsedNode, error) {
+	nid, err := ua.ParseNodeID(nodeID)
+	if err != nil {
+		return nil, errors.Wrap(errNodeID, err)
+	}
+
+	s, err := c.connect(ctx, serverURI)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := s.conn.Node(nid).References(ctx, id.HierarchicalReferences, ua.BrowseDirectionForward, ua.NodeClassAll, true)
+	if err != nil {
+		return nil, errors.Wrap(errBrowse, err)
+	}
+
+	nodes := make([]opcua.BrowsedNode, 0, len(refs))
+	for _, ref := range refs {
+		node := opcua.BrowsedNode{
+			NodeID: ref.NodeID.NodeID.String(),
+			Name:   ref.BrowseName.Name,
+		}
+		if ref.NodeClass == ua.NodeClassVariable {
+			node.DataType, node.Description = describe(ctx, s.conn.Node(ref.NodeID.NodeID))
+		}
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+// Write writes the value to the node, converted to the type of the current
+// value of the node, since the JSON numbers of the commands are decoded
+// as float64 regardless of the node data type.
+func (c *Client) Write(ctx context.Context, serverURI, nodeID string, value interface{}) error {
+	nid, err := ua.ParseNodeID(nodeID)
+	if err != nil {
+		return errors.Wrap(errNodeID, err)
+	}
+
+	s, err := c.connect(ctx, serverURI)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.conn.Node(nid).Value(ctx)
+	if err != nil {
+		return errors.Wrap(errWrite, err)
+	}
+
+	v, err := ua.NewVariant(convert(value, current.Value()))
+	if err != nil {
+		return errors.Wrap(errWrite, err)
+	}
+
+	req := &ua.WriteRequest{
+		NodesToWrite: []*ua.WriteValue{
+			{
+				NodeID:      nid,
+				AttributeID: ua.AttributeIDValue,
+				Value: &ua.DataValue{
+					EncodingMask: ua.DataValueValue,
+					Value:        v,
+				},
+			},
+		},
+	}
+
+	res, err := s.conn.Write(ctx, req)
+	if err != nil {
+		return errors.Wrap(errWrite, err)
+	}
+	if len(res.Results) > 0 && res.Results[0] != ua.StatusOK {
+		return errors.Wrap(errWrite, res.Results[0])
+	}
+
+	return nil
+}
+
+// Close cancels the subscriptions and closes the connections to the servers.
+func (c *Client) Close() error {
+	close(c.done)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+	defer cancel()
+
+	var err error
+	for uri, s := range c.servers {
+		if e := s.conn.Close(ctx); e != nil {
+			err = e
+		}
+		delete(c.servers, uri)
+	}
+
+	return err
+}
+
+// connect returns the connection to the server, opening it if needed. The
+// endpoint is selected by the configured security policy and mode.
+func (c *Client) connect(ctx context.Context, serverURI string) (*server, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if s, ok := c.servers[serverURI]; ok {
+		return s, nil
+	}
+
+	endpoints, err := gopcua.GetEndpoints(ctx, serverURI)
+	if err != nil {
+		return nil, errors.Wrap(errConnect, err)
+	}
+	ep, err := gopcua.SelectEndpoint(endpoints, c.cfg.Policy, ua.MessageSecurityModeFromString(c.cfg.Mode))
+	if err != nil {
+		return nil, errors.Wrap(errConnect, err)
+	}
+
+	opts := []gopcua.Option{
+		gopcua.SecurityPolicy(c.cfg.Policy),
+		gopcua.SecurityModeString(c.cfg.Mode),
+		gopcua.AuthAnonymous(),
+		gopcua.SecurityFromEndpoint(ep, ua.UserTokenTypeAnonymous),
+		gopcua.DialTimeout(c.cfg.Timeout),
+		gopcua.RequestTimeout(c.cfg.Timeout),
+	}
+	if c.cfg.CertFile != "" && c.cfg.KeyFile != "" {
+		opts = append(opts, gopcua.CertificateFile(c.cfg.CertFile), gopcua.PrivateKeyFile(c.cfg.KeyFile))
+	}
+
+	// The server URI is dialed instead of the endpoint URL, which may
+	// contain a host name that isn't resolvable by the adapter.
+	conn, err := gopcua.NewClient(serverURI, opts...)
+	if err != nil {
+		return nil, errors.Wrap(errConnect, err)
+	}
+	if err := conn.Connect(ctx); err != nil {
+		return nil, errors.Wrap(errConnect, err)
+	}
+
+	s := &server{
+		conn:  conn,
+		nodes: make(map[uint32]string),
+		items: make(map[string]uint32),
+	}
+	c.servers[serverURI] = s
+
+	return s, nil
+}
+
+// listen passes the data changes of the server subscription to publish
+// until the client is closed.
+func (c *Client) listen(serverURI string, s *server, notifs <-chan *gopcua.PublishNotificationData) {
+	for {
+		select {
+		case <-c.done:
+			return
+		case n := <-notifs:
+			if n.Error != nil {
+				c.logger.Warn(fmt.Sprintf("OPC UA subscription of %s failed: %s", serverURI, n.Error))
+				continue
+			}
+
+			dc, ok := n.Value.(*ua.DataChangeNotification)
+			if !ok {
+				continue
+			}
+
+			for _, item := range dc.MonitoredItems {
+				s.mu.Lock()
+				nodeID, ok := s.nodes[item.ClientHandle]
+				s.mu.Unlock()
+				if !ok || item.Value == nil || item.Value.Value == nil {
+					continue
+				}
+
+				m := opcua.Message{
+					ServerURI: serverURI,
+					NodeID:    nodeID,
+					Time:      item.Value.SourceTimestamp,
+					Value:     item.Value.Value.Value(),
+				}
+				if err := c.publish(context.Background(), m); err != nil {
+					c.logger.Warn(fmt.Sprintf("Failed to publish data change of %s on %s: %s", nodeID, serverURI, err))
+				}
+			}
+		}
+	}
+}
+
+// describe returns the data type name and the description of the variable
+// node. The attributes are informative, so they're left empty on failure.
+func describe(ctx context.Context, node *gopcua.Node) (string, string) {
+	attrs, err := node.Attributes(ctx, ua.AttributeIDDataType, ua.AttributeIDDescription)
+	if err != nil || len(attrs) != 2 {
+		return "", ""
+	}
+
+	var dataType, desc string
+	if v := attrs[0].Value; attrs[0].Status == ua.StatusOK && v != nil {
+		if nid, ok := v.Value().(*ua.NodeID); ok {
+			dataType = id.Name(nid.IntID())
+			if dataType == "" {
+				dataType = nid.String()
+			}
+		}
+	}
+	if v := attrs[1].Value; attrs[1].Status == ua.StatusOK && v != nil {
+		if lt, ok := v.Value().(*ua.LocalizedText); ok {
+			desc = lt.Text
+		}
+	}
+
+	return dataType, desc
+}
+
+// convert converts the numeric value to the type of the current numeric
+// value of the node. Other values are written as they are.
+func convert(value, current interface{}) interface{} {
+	if current == nil {
+		return value
+	}
+
+	v, t := reflect.ValueOf(value), reflect.TypeOf(current)
+	if isNumber(v.Kind()) && isNumber(t.Kind()) {
+		return v.Convert(t).Interface()
+	}
+
+	return value
+}
+
+func isNumber(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/opcua"
+	"github.com/MainfluxLabs/mainflux/opcua/client"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/server"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tempName  = "Temperature"
+	countName = "Count"
+	timeout   = 5 * time.Second
+)
+
+var cfg = client.Config{
+	Interval: 50 * time.Millisecond,
+	Timeout:  timeout,
+	Policy:   "None",
+	Mode:     "None",
+}
+
+// newServer starts the in-process OPC UA server with the float64
+// temperature and the int32 count variables on a free port.
+func newServer(t *testing.T) (string, uint16) {
+	l, err := net.Listen("tcp", "localhost:0")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	srv := server.New(
+		server.EnableSecurity("None", ua.MessageSecurityModeNone),
+		server.EnableAuthMode(ua.UserTokenTypeAnonymous),
+		server.EndPoint("localhost", port),
+	)
+
+	ns := server.NewNodeNameSpace(srv, "mainflux")
+	root, err := srv.Namespace(0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	root.Objects().AddRef(ns.Objects(), id.HasComponent, true)
+	ns.Objects().AddRef(ns.AddNewVariableStringNode(tempName, float64(21.5)), id.HasComponent, true)
+	ns.Objects().AddRef(ns.AddNewVariableStringNode(countName, int32(1)), id.HasComponent, true)
+
+	err = srv.Start(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	t.Cleanup(func() { srv.Close() })
+
+	return fmt.Sprintf("opc.tcp://localhost:%d", port), ns.ID()
+}
+
+func newClient(t *testing.T, publish client.PublishFunc) *client.Client {
+	c := client.NewClient(cfg, publish, logger.NewMock())
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func nodeID(ns uint16, name string) string {
+	return ua.NewStringNodeID(ns, name).String()
+}
+
+func TestBrowse(t *testing.T) {
+	uri, ns := newServer(t)
+	c := newClient(t, func(context.Context, opcua.Message) error { return nil })
+
+	nodes, err := c.Browse(context.Background(), uri, ua.NewNumericNodeID(ns, id.ObjectsFolder).String())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	names := map[string]string{}
+	for _, n := range nodes {
+		names[n.Name] = n.NodeID
+	}
+	assert.Equal(t, nodeID(ns, tempName), names[tempName], fmt.Sprintf("expected %s to be browsed", tempName))
+	assert.Equal(t, nodeID(ns, countName), names[countName], fmt.Sprintf("expected %s to be browsed", countName))
+
+	cases := []struct {
+		desc   string
+		uri    string
+		nodeID string
+	}{
+		{
+			desc:   "browse malformed node ID",
+			uri:    uri,
+			nodeID: "ns=x;s",
+		},
+		{
+			desc:   "browse unreachable server",
+			uri:    "opc.tcp://localhost:1",
+			nodeID: opcua.RootNodeID,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := c.Browse(context.Background(), tc.uri, tc.nodeID)
+		assert.NotNil(t, err, fmt.Sprintf("%s: expected error", tc.desc))
+	}
+}
+
+func TestWrite(t *testing.T) {
+	uri, ns := newServer(t)
+	msgs := make(chan opcua.Message, 16)
+	c := newClient(t, func(_ context.Context, m opcua.Message) error {
+		msgs <- m
+		return nil
+	})
+
+	cases := []struct {
+		desc     string
+		nodeID   string
+		value    interface{}
+		expected interface{}
+		err      bool
+	}{
+		{
+			desc:     "write float to double node",
+			nodeID:   nodeID(ns, tempName),
+			value:    23.5,
+			expected: float64(23.5),
+		},
+		{
+			desc:     "write float to int32 node",
+			nodeID:   nodeID(ns, countName),
+			value:    float64(7),
+			expected: int32(7),
+		},
+		{
+			desc:   "write malformed node ID",
+			nodeID: "ns=x;s",
+			value:  1.0,
+			err:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := c.Write(context.Background(), uri, tc.nodeID, tc.value)
+		if tc.err {
+			assert.NotNil(t, err, fmt.Sprintf("%s: expected error", tc.desc))
+			continue
+		}
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		// The written value is read back through the subscription.
+		err = c.Subscribe(context.Background(), uri, tc.nodeID)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		m := receive(t, msgs, tc.nodeID)
+		assert.Equal(t, tc.expected, m.Value, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.expected, m.Value))
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	uri, ns := newServer(t)
+	msgs := make(chan opcua.Message, 16)
+	c := newClient(t, func(_ context.Context, m opcua.Message) error {
+		msgs <- m
+		return nil
+	})
+
+	temp := nodeID(ns, tempName)
+	err := c.Subscribe(context.Background(), uri, temp)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	m := receive(t, msgs, temp)
+	assert.Equal(t, uri, m.ServerURI, fmt.Sprintf("expected server URI %s got %s", uri, m.ServerURI))
+	assert.Equal(t, float64(21.5), m.Value, fmt.Sprintf("expected initial value 21.5 got %v", m.Value))
+
+	err = c.Write(context.Background(), uri, temp, 30.0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	m = receive(t, msgs, temp)
+	assert.Equal(t, float64(30), m.Value, fmt.Sprintf("expected changed value 30 got %v", m.Value))
+
+	err = c.Subscribe(context.Background(), uri, "ns=x;s")
+	assert.NotNil(t, err, "subscribe malformed node ID: expected error")
+
+	err = c.Unsubscribe(context.Background(), uri, temp)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = c.Write(context.Background(), uri, temp, 31.0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	select {
+	case m := <-msgs:
+		assert.Fail(t, fmt.Sprintf("unexpected data change of %s after unsubscribe", m.NodeID))
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	err = c.Unsubscribe(context.Background(), uri, temp)
+	assert.Nil(t, err, fmt.Sprintf("unsubscribe twice: unexpected error: %s", err))
+}
+
+// receive returns the next data change of the node, skipping the others.
+func receive(t *testing.T, msgs <-chan opcua.Message, nodeID string) opcua.Message {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case m := <-msgs:
+			if m.NodeID == nodeID {
+				return m
+			}
+		case <-deadline:
+			require.FailNow(t, fmt.Sprintf("no data change of %s received", nodeID))
+		}
+	}
+}
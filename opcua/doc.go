@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package opcua contains the domain concept definitions needed to support
+// Mainflux OPC UA adapter service functionality.
+package opcua
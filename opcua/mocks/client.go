@@ -0,0 +1,82 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/opcua"
+)
+
+var errServerNotFound = errors.New("OPC UA server not found")
+
+var _ opcua.Client = (*clientMock)(nil)
+
+type clientMock struct {
+	mu            sync.Mutex
+	nodes         map[string]map[string][]opcua.BrowsedNode
+	values        map[string]interface{}
+	subscriptions map[string]bool
+}
+
+// NewClient returns mock OPC UA client instance serving the address spaces
+// of the servers, i.e. the nodes referenced by the browsed nodes per server URI.
+func NewClient(nodes map[string]map[string][]opcua.BrowsedNode) opcua.Client {
+	return &clientMock{
+		nodes:         nodes,
+		values:        make(map[string]interface{}),
+		subscriptions: make(map[string]bool),
+	}
+}
+
+func (cm *clientMock) Subscribe(_ context.Context, serverURI, nodeID string) error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if _, ok := cm.nodes[serverURI]; !ok {
+		return errServerNotFound
+	}
+
+	cm.subscriptions[key(serverURI, nodeID)] = true
+	return nil
+}
+
+func (cm *clientMock) Unsubscribe(_ context.Context, serverURI, nodeID string) error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	delete(cm.subscriptions, key(serverURI, nodeID))
+	return nil
+}
+
+func (cm *clientMock) Browse(_ context.Context, serverURI, nodeID string) ([]opcua.BrowsedNode, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	nodes, ok := cm.nodes[serverURI]
+	if !ok {
+		return nil, errServerNotFound
+	}
+
+	return nodes[nodeID], nil
+}
+
+func (cm *clientMock) Write(_ context.Context, serverURI, nodeID string, value interface{}) error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if _, ok := cm.nodes[serverURI]; !ok {
+		return errServerNotFound
+	}
+
+	cm.values[key(serverURI, nodeID)] = value
+	return nil
+}
+
+func key(serverURI, nodeID string) string {
+	return fmt.Sprintf("%s|%s", serverURI, nodeID)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+import (
+	"context"
+	"time"
+)
+
+// Message represents a data change of an OPC UA node.
+type Message struct {
+	ServerURI string
+	NodeID    string
+	Time      time.Time
+	Value     interface{}
+}
+
+// BrowsedNode represents a node of the address space of an OPC UA server.
+type BrowsedNode struct {
+	NodeID      string `json:"node_id"`
+	Name        string `json:"name"`
+	DataType    string `json:"data_type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Client represents the connections to the OPC UA servers.
+type Client interface {
+	// Subscribe subscribes to the data changes of the node on the server.
+	// The data changes are forwarded to the Publish method of the service.
+	Subscribe(ctx context.Context, serverURI, nodeID string) error
+
+	// Unsubscribe cancels the subscription to the node on the server.
+	Unsubscribe(ctx context.Context, serverURI, nodeID string) error
+
+	// Browse returns the nodes referenced by the node on the server.
+	Browse(ctx context.Context, serverURI, nodeID string) ([]BrowsedNode, error)
+
+	// Write writes the value to the node on the server.
+	Write(ctx context.Context, serverURI, nodeID string, value interface{}) error
+}
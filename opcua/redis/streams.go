@@ -0,0 +1,125 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/opcua"
+	"github.com/MainfluxLabs/mainflux/pkg/events"
+	eventsredis "github.com/MainfluxLabs/mainflux/pkg/events/redis"
+	"github.com/go-redis/redis/v8"
+)
+
+const keyType = "opcua"
+
+var (
+	errMetadataFormat = errors.New("malformed metadata")
+
+	errMetadataServerURI = errors.New("server URI not found in channel metadata")
+
+	errMetadataNodeID = errors.New("node ID not found in thing metadata")
+)
+
+var (
+	_ events.ThingHandler   = (*eventHandler)(nil)
+	_ events.ChannelHandler = (*eventHandler)(nil)
+)
+
+type thingConfig struct {
+	NodeID string `json:"node_id"`
+}
+
+type channelConfig struct {
+	ServerURI string `json:"server_uri"`
+}
+
+type eventHandler struct {
+	svc opcua.Service
+}
+
+// NewEventStore returns new event store instance, which provisions the
+// things and the channels with the OPC UA configuration in their metadata.
+func NewEventStore(svc opcua.Service, client *redis.Client, consumer string, log logger.Logger) events.Subscriber {
+	return eventsredis.NewThingsSubscriber(eventHandler{svc: svc}, client, keyType, consumer, log)
+}
+
+func (eh eventHandler) CreateThing(ctx context.Context, thingID string, config json.RawMessage) error {
+	nodeID, err := decodeNodeID(config)
+	if err != nil {
+		return err
+	}
+
+	return eh.svc.CreateThing(ctx, thingID, nodeID)
+}
+
+func (eh eventHandler) UpdateThing(ctx context.Context, thingID string, config json.RawMessage) error {
+	nodeID, err := decodeNodeID(config)
+	if err != nil {
+		return err
+	}
+
+	return eh.svc.UpdateThing(ctx, thingID, nodeID)
+}
+
+func (eh eventHandler) RemoveThing(ctx context.Context, thingID string) error {
+	return eh.svc.RemoveThing(ctx, thingID)
+}
+
+func (eh eventHandler) ConnectThing(ctx context.Context, chanID, thingID string) error {
+	return eh.svc.ConnectThing(ctx, chanID, thingID)
+}
+
+func (eh eventHandler) DisconnectThing(ctx context.Context, chanID, thingID string) error {
+	return eh.svc.DisconnectThing(ctx, chanID, thingID)
+}
+
+func (eh eventHandler) CreateChannel(ctx context.Context, chanID string, config json.RawMessage) error {
+	serverURI, err := decodeServerURI(config)
+	if err != nil {
+		return err
+	}
+
+	return eh.svc.CreateChannel(ctx, chanID, serverURI)
+}
+
+func (eh eventHandler) UpdateChannel(ctx context.Context, chanID string, config json.RawMessage) error {
+	serverURI, err := decodeServerURI(config)
+	if err != nil {
+		return err
+	}
+
+	return eh.svc.UpdateChannel(ctx, chanID, serverURI)
+}
+
+func (eh eventHandler) RemoveChannel(ctx context.Context, chanID string) error {
+	return eh.svc.RemoveChannel(ctx, chanID)
+}
+
+func decodeNodeID(config json.RawMessage) (string, error) {
+	var cfg thingConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return "", errMetadataFormat
+	}
+	if cfg.NodeID == "" {
+		return "", errMetadataNodeID
+	}
+
+	return cfg.NodeID, nil
+}
+
+func decodeServerURI(config json.RawMessage) (string, error) {
+	var cfg channelConfig
+	if err := json.Unmarshal(config, &cfg); err != nil {
+		return "", errMetadataFormat
+	}
+	if cfg.ServerURI == "" {
+		return "", errMetadataServerURI
+	}
+
+	return cfg.ServerURI, nil
+}
@@ -0,0 +1,20 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package opcua
+
+import "context"
+
+// RouteMapRepository store route map between OPC UA servers and Mainflux
+type RouteMapRepository interface {
+	// Save stores/routes pair OPC UA server & Mainflux channel, or OPC UA
+	// node & Mainflux thing.
+	Save(context.Context, string, string) error
+
+	// Get returns the Mainflux identifier for the given OPC UA identifier,
+	// and vice versa.
+	Get(context.Context, string) (string, error)
+
+	// Removes mapping from cache.
+	Remove(context.Context, string) error
+}
@@ -13,7 +13,7 @@ import (
 // Client represents Auth cache.
 type Client interface {
 	Identify(ctx context.Context, thingKey string) (string, error)
-	ConnectionIDS(ctx context.Context, thingKey string) (string, string, error)
+	Authorize(ctx context.Context, chanID, thingKey, action string) (string, error)
 }
 
 const (
@@ -51,15 +51,17 @@ func (c client) Identify(ctx context.Context, thingKey string) (string, error) {
 	return thingID, nil
 }
 
-func (c client) ConnectionIDS(ctx context.Context, thingKey string) (string, string, error) {
-	req := &mainflux.ConnByKeyReq{
-		Key: thingKey,
+func (c client) Authorize(ctx context.Context, chanID, thingKey, action string) (string, error) {
+	req := &mainflux.AccessByKeyReq{
+		Token:  thingKey,
+		ChanID: chanID,
+		Action: action,
 	}
 
-	conn, err := c.things.GetConnByKey(context.TODO(), req)
+	thid, err := c.things.CanAccessByKey(context.TODO(), req)
 	if err != nil {
-		return "", "", err
+		return "", err
 	}
 
-	return conn.ThingID, conn.ChannelID, nil
+	return thid.GetValue(), nil
 }
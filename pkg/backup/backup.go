@@ -0,0 +1,234 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package backup provides the streaming NDJSON format shared by the backup
+// and restore APIs of the Mainflux services.
+package backup
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	// ContentType is the media type of the backup stream.
+	ContentType = "application/x-ndjson"
+
+	// Version is the current version of the backup format.
+	Version = 1
+
+	// HeaderType is the type of the record that opens every backup stream.
+	HeaderType = "header"
+
+	// StrategySkip leaves existing entities untouched.
+	StrategySkip = "skip"
+	// StrategyOverwrite replaces existing entities with the backed up ones.
+	StrategyOverwrite = "overwrite"
+	// StrategyFail aborts the restore on the first existing entity.
+	StrategyFail = "fail"
+
+	// ScopeGroup marks a backup limited to a group and its descendants.
+	ScopeGroup = "group"
+	// ScopeOrg marks a backup limited to an organization.
+	ScopeOrg = "org"
+
+	maxRecordSize = 16 * 1024 * 1024
+)
+
+var (
+	// ErrInvalidStrategy indicates an unknown conflict strategy.
+	ErrInvalidStrategy = errors.New("invalid conflict strategy")
+
+	// ErrMalformedRecord indicates a backup record that can't be decoded.
+	ErrMalformedRecord = errors.New("malformed backup record")
+
+	// ErrUnsupportedVersion indicates a backup created by an unsupported
+	// version of the format.
+	ErrUnsupportedVersion = errors.New("unsupported backup version")
+)
+
+// Scope limits the backup to a subset of the service entities.
+type Scope struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Header describes the backup stream. It is always the first record.
+type Header struct {
+	Service   string    `json:"service"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	// Since is set for incremental backups, which contain only entities
+	// created or updated after it.
+	Since *time.Time `json:"since,omitempty"`
+	Scope *Scope     `json:"scope,omitempty"`
+}
+
+// Record represents a single line of the backup stream.
+type Record struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Decode unmarshals the record data into v.
+func (r Record) Decode(v interface{}) error {
+	if err := json.Unmarshal(r.Data, v); err != nil {
+		return errors.Wrap(ErrMalformedRecord, err)
+	}
+
+	return nil
+}
+
+// Summary reports the outcome of a restore.
+type Summary struct {
+	Created uint64 `json:"created"`
+	Updated uint64 `json:"updated"`
+	Skipped uint64 `json:"skipped"`
+}
+
+// Stream writes the backup records to the encoder. Services return streams
+// so that the records are encoded directly to the response instead of being
+// marshaled into a single document.
+type Stream func(enc *Encoder) error
+
+// ValidateStrategy returns an error if the conflict strategy is unknown.
+// An empty strategy defaults to StrategyFail.
+func ValidateStrategy(strategy string) error {
+	switch strategy {
+	case "", StrategySkip, StrategyOverwrite, StrategyFail:
+		return nil
+	default:
+		return ErrInvalidStrategy
+	}
+}
+
+// Encoder writes backup records as newline delimited JSON.
+type Encoder struct {
+	enc     *json.Encoder
+	flusher http.Flusher
+}
+
+// NewEncoder returns an encoder writing to w. If w is an http.Flusher,
+// every record is flushed to the client as soon as it is written.
+func NewEncoder(w io.Writer) *Encoder {
+	flusher, _ := w.(http.Flusher)
+	return &Encoder{
+		enc:     json.NewEncoder(w),
+		flusher: flusher,
+	}
+}
+
+// WriteHeader writes the header record. It has to be called before any
+// other record is written.
+func (e *Encoder) WriteHeader(h Header) error {
+	if h.Version == 0 {
+		h.Version = Version
+	}
+	if h.CreatedAt.IsZero() {
+		h.CreatedAt = time.Now().UTC()
+	}
+
+	return e.Encode(HeaderType, h)
+}
+
+// Encode writes a record of the given type.
+func (e *Encoder) Encode(typ string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if err := e.enc.Encode(Record{Type: typ, Data: data}); err != nil {
+		return err
+	}
+
+	if e.flusher != nil {
+		e.flusher.Flush()
+	}
+
+	return nil
+}
+
+// Decoder reads backup records from newline delimited JSON.
+type Decoder struct {
+	scanner *bufio.Scanner
+	header  *Header
+}
+
+// NewDecoder returns a decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+
+	return &Decoder{scanner: scanner}
+}
+
+// Header reads and validates the header record. It is read once and
+// cached, so subsequent calls return the same header.
+func (d *Decoder) Header() (Header, error) {
+	if d.header != nil {
+		return *d.header, nil
+	}
+
+	rec, err := d.next()
+	if err != nil {
+		if err == io.EOF {
+			return Header{}, ErrMalformedRecord
+		}
+		return Header{}, err
+	}
+	if rec.Type != HeaderType {
+		return Header{}, ErrMalformedRecord
+	}
+
+	var h Header
+	if err := rec.Decode(&h); err != nil {
+		return Header{}, err
+	}
+	if h.Version != Version {
+		return Header{}, ErrUnsupportedVersion
+	}
+
+	d.header = &h
+	return h, nil
+}
+
+// Next returns the next record following the header. It returns io.EOF
+// once the stream is exhausted.
+func (d *Decoder) Next() (Record, error) {
+	if _, err := d.Header(); err != nil {
+		return Record{}, err
+	}
+
+	return d.next()
+}
+
+func (d *Decoder) next() (Record, error) {
+	for d.scanner.Scan() {
+		line := d.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var rec Record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return Record{}, errors.Wrap(ErrMalformedRecord, err)
+		}
+		if rec.Type == "" {
+			return Record{}, ErrMalformedRecord
+		}
+
+		return rec, nil
+	}
+
+	if err := d.scanner.Err(); err != nil {
+		return Record{}, errors.Wrap(ErrMalformedRecord, err)
+	}
+
+	return Record{}, io.EOF
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package backup_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type entity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	since := time.Now().UTC().Add(-time.Hour).Round(time.Second)
+	header := backup.Header{
+		Service: "things",
+		Since:   &since,
+		Scope:   &backup.Scope{Type: backup.ScopeGroup, ID: "1"},
+	}
+	entities := []entity{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}}
+
+	var buf bytes.Buffer
+	enc := backup.NewEncoder(&buf)
+	require.Nil(t, enc.WriteHeader(header), "writing header expected to succeed")
+	for _, e := range entities {
+		require.Nil(t, enc.Encode("entity", e), "encoding record expected to succeed")
+	}
+	assert.Equal(t, len(entities)+1, strings.Count(buf.String(), "\n"), "expected one record per line")
+
+	dec := backup.NewDecoder(&buf)
+	h, err := dec.Header()
+	require.Nil(t, err, fmt.Sprintf("reading header expected to succeed: %s", err))
+	assert.Equal(t, header.Service, h.Service, "header service mismatch")
+	assert.Equal(t, backup.Version, h.Version, "header version mismatch")
+	assert.Equal(t, since, *h.Since, "header since mismatch")
+	assert.Equal(t, *header.Scope, *h.Scope, "header scope mismatch")
+
+	var decoded []entity
+	for {
+		rec, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		require.Nil(t, err, fmt.Sprintf("reading record expected to succeed: %s", err))
+		assert.Equal(t, "entity", rec.Type, "record type mismatch")
+
+		var e entity
+		require.Nil(t, rec.Decode(&e), "decoding record expected to succeed")
+		decoded = append(decoded, e)
+	}
+	assert.Equal(t, entities, decoded, "decoded entities mismatch")
+}
+
+func TestDecoderHeader(t *testing.T) {
+	cases := []struct {
+		desc  string
+		input string
+		err   error
+	}{
+		{
+			desc:  "read valid header",
+			input: `{"type":"header","data":{"service":"things","version":1}}` + "\n",
+			err:   nil,
+		},
+		{
+			desc:  "read empty stream",
+			input: "",
+			err:   backup.ErrMalformedRecord,
+		},
+		{
+			desc:  "read stream without header",
+			input: `{"type":"thing","data":{}}` + "\n",
+			err:   backup.ErrMalformedRecord,
+		},
+		{
+			desc:  "read header with unsupported version",
+			input: `{"type":"header","data":{"service":"things","version":2}}` + "\n",
+			err:   backup.ErrUnsupportedVersion,
+		},
+		{
+			desc:  "read invalid JSON",
+			input: "{\n",
+			err:   backup.ErrMalformedRecord,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := backup.NewDecoder(strings.NewReader(tc.input)).Header()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestValidateStrategy(t *testing.T) {
+	cases := []struct {
+		strategy string
+		err      error
+	}{
+		{strategy: "", err: nil},
+		{strategy: backup.StrategySkip, err: nil},
+		{strategy: backup.StrategyOverwrite, err: nil},
+		{strategy: backup.StrategyFail, err: nil},
+		{strategy: "merge", err: backup.ErrInvalidStrategy},
+	}
+
+	for _, tc := range cases {
+		err := backup.ValidateStrategy(tc.strategy)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("strategy %q: expected %s got %s\n", tc.strategy, tc.err, err))
+	}
+}
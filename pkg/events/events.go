@@ -0,0 +1,49 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events contains the API shared by the services consuming the
+// things events.
+package events
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// ThingHandler handles the events of the things a service is configured
+// for, i.e. the things whose metadata carries the service configuration.
+type ThingHandler interface {
+	// CreateThing handles the creation of the thing with the given configuration.
+	CreateThing(ctx context.Context, thingID string, config json.RawMessage) error
+
+	// UpdateThing handles the update of the thing configuration.
+	UpdateThing(ctx context.Context, thingID string, config json.RawMessage) error
+
+	// RemoveThing handles the removal of the thing or of its configuration.
+	RemoveThing(ctx context.Context, thingID string) error
+
+	// ConnectThing handles connecting the thing to the channel.
+	ConnectThing(ctx context.Context, chanID, thingID string) error
+
+	// DisconnectThing handles disconnecting the thing from the channel.
+	DisconnectThing(ctx context.Context, chanID, thingID string) error
+}
+
+// ChannelHandler handles the events of the channels a service is configured
+// for. The channel events are passed to the thing handlers implementing it.
+type ChannelHandler interface {
+	// CreateChannel handles the creation of the channel with the given configuration.
+	CreateChannel(ctx context.Context, chanID string, config json.RawMessage) error
+
+	// UpdateChannel handles the update of the channel configuration.
+	UpdateChannel(ctx context.Context, chanID string, config json.RawMessage) error
+
+	// RemoveChannel handles the removal of the channel or of its configuration.
+	RemoveChannel(ctx context.Context, chanID string) error
+}
+
+// Subscriber represents event source for things provisioning.
+type Subscriber interface {
+	// Subscribes to given subject and receives events.
+	Subscribe(context.Context, string) error
+}
@@ -0,0 +1,223 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis contains the things events consumer backed by Redis streams.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/events"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	groupPrefix = "mainflux."
+	stream      = "mainflux.things"
+
+	thingPrefix     = "thing."
+	thingCreate     = thingPrefix + "create"
+	thingUpdate     = thingPrefix + "update"
+	thingRemove     = thingPrefix + "remove"
+	thingConnect    = thingPrefix + "connect"
+	thingDisconnect = thingPrefix + "disconnect"
+
+	channelPrefix = "channel."
+	channelCreate = channelPrefix + "create"
+	channelUpdate = channelPrefix + "update"
+	channelRemove = channelPrefix + "remove"
+
+	exists = "BUSYGROUP Consumer Group name already exists"
+)
+
+var errMetadataType = errors.New("configuration field is missing in the metadata")
+
+// configEvent is either create or update event of a thing or a channel.
+type configEvent struct {
+	id     string
+	config json.RawMessage
+}
+
+type removeEvent struct {
+	id string
+}
+
+type connectionThingEvent struct {
+	chanID  string
+	thingID string
+}
+
+type thingsSubscriber struct {
+	handler  events.ThingHandler
+	client   *redis.Client
+	key      string
+	consumer string
+	ignored  []error
+	logger   logger.Logger
+}
+
+// NewThingsSubscriber returns the things events subscriber, which passes the
+// events of the things whose metadata contains the key to the handler. The
+// subscriber reads the events in the mainflux.<key> consumer group. Failures
+// caused by the ignored errors, such as events of the things unknown to the
+// handler, are acknowledged without being reported. If the handler implements
+// events.ChannelHandler, it receives the events of the channels too.
+func NewThingsSubscriber(h events.ThingHandler, client *redis.Client, key, consumer string, log logger.Logger, ignored ...error) events.Subscriber {
+	return thingsSubscriber{
+		handler:  h,
+		client:   client,
+		key:      key,
+		consumer: consumer,
+		ignored:  ignored,
+		logger:   log,
+	}
+}
+
+func (ts thingsSubscriber) Subscribe(ctx context.Context, subject string) error {
+	group := groupPrefix + ts.key
+	err := ts.client.XGroupCreateMkStream(ctx, stream, group, "$").Err()
+	if err != nil && err.Error() != exists {
+		return err
+	}
+
+	for {
+		streams, err := ts.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: ts.consumer,
+			Streams:  []string{stream, ">"},
+			Count:    100,
+		}).Result()
+		if err != nil || len(streams) == 0 {
+			continue
+		}
+
+		for _, msg := range streams[0].Messages {
+			if err := ts.handle(ctx, msg.Values); err != nil && !ts.ignore(err) {
+				ts.logger.Warn(fmt.Sprintf("Failed to handle event sourcing: %s", err.Error()))
+				break
+			}
+			ts.client.XAck(ctx, stream, group, msg.ID)
+		}
+	}
+}
+
+func (ts thingsSubscriber) handle(ctx context.Context, event map[string]interface{}) error {
+	switch event["operation"] {
+	case thingCreate:
+		cte, err := ts.decodeConfig(event)
+		if err != nil {
+			return err
+		}
+		return ts.handler.CreateThing(ctx, cte.id, cte.config)
+	case thingUpdate:
+		ute, err := ts.decodeConfig(event)
+		if err == errMetadataType {
+			// The thing is no longer handled if its
+			// configuration is removed.
+			return ts.handler.RemoveThing(ctx, ute.id)
+		}
+		if err != nil {
+			return err
+		}
+		return ts.handler.UpdateThing(ctx, ute.id, ute.config)
+	case thingRemove:
+		rte := decodeRemove(event)
+		return ts.handler.RemoveThing(ctx, rte.id)
+	case thingConnect:
+		tce := decodeConnectionThing(event)
+		return ts.handler.ConnectThing(ctx, tce.chanID, tce.thingID)
+	case thingDisconnect:
+		tde := decodeConnectionThing(event)
+		return ts.handler.DisconnectThing(ctx, tde.chanID, tde.thingID)
+	}
+
+	if ch, ok := ts.handler.(events.ChannelHandler); ok {
+		return ts.handleChannel(ctx, ch, event)
+	}
+
+	return nil
+}
+
+func (ts thingsSubscriber) handleChannel(ctx context.Context, ch events.ChannelHandler, event map[string]interface{}) error {
+	switch event["operation"] {
+	case channelCreate:
+		cce, err := ts.decodeConfig(event)
+		if err != nil {
+			return err
+		}
+		return ch.CreateChannel(ctx, cce.id, cce.config)
+	case channelUpdate:
+		uce, err := ts.decodeConfig(event)
+		if err == errMetadataType {
+			return ch.RemoveChannel(ctx, uce.id)
+		}
+		if err != nil {
+			return err
+		}
+		return ch.UpdateChannel(ctx, uce.id, uce.config)
+	case channelRemove:
+		rce := decodeRemove(event)
+		return ch.RemoveChannel(ctx, rce.id)
+	}
+
+	return nil
+}
+
+func (ts thingsSubscriber) ignore(err error) bool {
+	if err == errMetadataType {
+		return true
+	}
+	for _, e := range ts.ignored {
+		if errors.Contains(err, e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (ts thingsSubscriber) decodeConfig(event map[string]interface{}) (configEvent, error) {
+	cte := configEvent{
+		id: read(event, "id", ""),
+	}
+
+	strmeta := read(event, "metadata", "{}")
+	var metadata map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(strmeta), &metadata); err != nil {
+		return cte, err
+	}
+
+	m, ok := metadata[ts.key]
+	if !ok {
+		return cte, errMetadataType
+	}
+	cte.config = m
+
+	return cte, nil
+}
+
+func decodeRemove(event map[string]interface{}) removeEvent {
+	return removeEvent{
+		id: read(event, "id", ""),
+	}
+}
+
+func decodeConnectionThing(event map[string]interface{}) connectionThingEvent {
+	return connectionThingEvent{
+		chanID:  read(event, "chan_id", ""),
+		thingID: read(event, "thing_id", ""),
+	}
+}
+
+func read(event map[string]interface{}, key, def string) string {
+	val, ok := event[key].(string)
+	if !ok {
+		return def
+	}
+
+	return val
+}
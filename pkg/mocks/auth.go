@@ -18,6 +18,8 @@ var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
 type authServiceMock struct {
 	roles        map[string]string
 	usersByEmail map[string]users.User
+	quotas       map[string]*mainflux.QuotaRes
+	keys         map[string]*mainflux.UserIdentity
 }
 
 // NewAuthService creates mock of users service.
@@ -32,13 +34,47 @@ func NewAuthService(adminID string, userList []users.User) mainflux.AuthServiceC
 	return &authServiceMock{
 		roles:        roles,
 		usersByEmail: usersByEmail,
+		quotas:       make(map[string]*mainflux.QuotaRes),
+		keys:         make(map[string]*mainflux.UserIdentity),
 	}
 }
 
+// NewAuthServiceWithQuotas creates mock of users service that reports the
+// quotas identified by the user or the group ID.
+func NewAuthServiceWithQuotas(adminID string, userList []users.User, quotas map[string]*mainflux.QuotaRes) mainflux.AuthServiceClient {
+	svc := NewAuthService(adminID, userList).(*authServiceMock)
+	svc.quotas = quotas
+
+	return svc
+}
+
+// NewAuthServiceWithKeys creates mock of users service that identifies the
+// API keys as the given identities, together with their scopes and resource
+// restrictions. API keys never carry the admin authority of their issuer.
+func NewAuthServiceWithKeys(adminID string, userList []users.User, keys map[string]*mainflux.UserIdentity) mainflux.AuthServiceClient {
+	svc := NewAuthService(adminID, userList).(*authServiceMock)
+	svc.keys = keys
+
+	return svc
+}
+
+// NewAuthServiceWithKeysAndQuotas creates mock of users service that
+// identifies the API keys as NewAuthServiceWithKeys does and reports the
+// quotas as NewAuthServiceWithQuotas does.
+func NewAuthServiceWithKeysAndQuotas(adminID string, userList []users.User, keys map[string]*mainflux.UserIdentity, quotas map[string]*mainflux.QuotaRes) mainflux.AuthServiceClient {
+	svc := NewAuthServiceWithKeys(adminID, userList, keys).(*authServiceMock)
+	svc.quotas = quotas
+
+	return svc
+}
+
 func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
 	if u, ok := svc.usersByEmail[in.Value]; ok {
 		return &mainflux.UserIdentity{Id: u.ID, Email: u.Email}, nil
 	}
+	if id, ok := svc.keys[in.Value]; ok {
+		return id, nil
+	}
 	return nil, errors.ErrAuthentication
 }
 
@@ -53,6 +89,10 @@ func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opt
 }
 
 func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	if _, ok := svc.keys[req.Token]; ok {
+		return &empty.Empty{}, errors.ErrAuthorization
+	}
+
 	u, ok := svc.usersByEmail[req.Token]
 	if !ok {
 		return &empty.Empty{}, errors.ErrAuthentication
@@ -83,9 +123,18 @@ func (svc authServiceMock) AddPolicy(ctx context.Context, in *mainflux.PolicyReq
 }
 
 func (svc authServiceMock) AssignRole(ctx context.Context, in *mainflux.AssignRoleReq, opts ...grpc.CallOption) (r *empty.Empty, err error) {
-	panic("not implemented")
+	svc.roles[in.GetRole()] = in.GetId()
+	return &empty.Empty{}, nil
 }
 
 func (svc authServiceMock) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (r *mainflux.RetrieveRoleRes, err error) {
 	panic("not implemented")
 }
+
+func (svc authServiceMock) RetrieveQuota(ctx context.Context, req *mainflux.QuotaReq, _ ...grpc.CallOption) (r *mainflux.QuotaRes, err error) {
+	if q, ok := svc.quotas[req.GetId()]; ok {
+		return q, nil
+	}
+
+	return &mainflux.QuotaRes{}, nil
+}
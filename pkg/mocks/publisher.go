@@ -3,19 +3,51 @@
 
 package mocks
 
-import "github.com/MainfluxLabs/mainflux/pkg/messaging"
+import (
+	"sync"
 
-type mockPublisher struct{}
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var _ messaging.Publisher = (*Publisher)(nil)
+
+// Publisher is a mock message publisher that records the published messages.
+type Publisher struct {
+	mu       sync.Mutex
+	messages []messaging.Message
+}
 
 // NewPublisher returns mock message publisher.
-func NewPublisher() messaging.Publisher {
-	return mockPublisher{}
+func NewPublisher() *Publisher {
+	return &Publisher{}
 }
 
-func (pub mockPublisher) Publish(topic string, msg messaging.Message) error {
+// Publish records the message.
+func (pub *Publisher) Publish(_ string, msg messaging.Message) error {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	pub.messages = append(pub.messages, msg)
 	return nil
 }
 
-func (pub mockPublisher) Close() error {
+// Close does nothing.
+func (pub *Publisher) Close() error {
 	return nil
 }
+
+// Messages returns the messages published on the channel and the subtopic,
+// in order. Empty channel or subtopic matches any.
+func (pub *Publisher) Messages(chanID, subtopic string) []messaging.Message {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	var msgs []messaging.Message
+	for _, msg := range pub.messages {
+		if (chanID == "" || msg.Channel == chanID) && (subtopic == "" || msg.Subtopic == subtopic) {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	return msgs
+}
@@ -7,9 +7,13 @@ import (
 	"context"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things"
 )
 
@@ -71,7 +75,7 @@ func (svc *mainfluxThings) ViewThing(_ context.Context, owner, id string) (thing
 	return things.Thing{}, errors.ErrNotFound
 }
 
-func (svc *mainfluxThings) Connect(_ context.Context, owner, chID string, thIDs []string) error {
+func (svc *mainfluxThings) Connect(_ context.Context, owner, chID string, thIDs []string, connType string) error {
 	svc.mu.Lock()
 	defer svc.mu.Unlock()
 
@@ -161,6 +165,26 @@ func (svc *mainfluxThings) UpdateKey(context.Context, string, string, string) er
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) RotateKey(context.Context, string, string, string, time.Duration) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) CreateThingKey(context.Context, string, string, things.ThingKey) (things.ThingKey, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListThingKeys(context.Context, string, string, things.PageMetadata) (things.ThingKeysPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UpdateThingKey(context.Context, string, string, things.ThingKey) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RemoveThingKey(context.Context, string, string, string) error {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) ListThings(context.Context, string, bool, things.PageMetadata) (things.Page, error) {
 	panic("not implemented")
 }
@@ -181,6 +205,26 @@ func (svc *mainfluxThings) Restore(context.Context, string, things.Backup) error
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) ExportBackup(context.Context, string, things.BackupFilter) (backup.Stream, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ImportBackup(context.Context, string, *backup.Decoder, string) (backup.Summary, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ViewUsage(context.Context, string, string) (map[string]quota.Usage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) AddThingTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RemoveThingTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) CreateChannels(_ context.Context, owner string, chs ...things.Channel) ([]things.Channel, error) {
 	svc.mu.Lock()
 	defer svc.mu.Unlock()
@@ -211,7 +255,7 @@ func (svc *mainfluxThings) RemoveChannels(context.Context, string, ...string) er
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) GetConnByKey(context.Context, string) (things.Connection, error) {
+func (svc *mainfluxThings) CanAccessByKey(context.Context, string, string, string) (string, error) {
 	panic("not implemented")
 }
 
@@ -219,10 +263,26 @@ func (svc *mainfluxThings) IsChannelOwner(context.Context, string, string) error
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) IsThingOwner(context.Context, string, string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) CanReadChannel(context.Context, string, string, string) error {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) Identify(context.Context, string) (string, error) {
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) GetChannelSchema(context.Context, string) (schema.Schema, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) GetOSCORESecret(context.Context, string) ([]byte, error) {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) ShareThing(ctx context.Context, token, thingID string, actions, userIDs []string) error {
 	panic("not implemented")
 }
@@ -257,6 +317,46 @@ func (svc *mainfluxThings) ListGroupsByIDs(ctx context.Context, groupIDs []strin
 	panic("not implemented")
 }
 
+func (svc *mainfluxThings) ListGroupChildren(ctx context.Context, token, groupID string, depth uint64, pm things.PageMetadata) (things.GroupPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) MoveGroup(ctx context.Context, token, groupID, parentID string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) AddChannelTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RemoveChannelTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) CreateProfiles(ctx context.Context, token string, profiles ...things.Profile) ([]things.Profile, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) UpdateProfile(ctx context.Context, token string, profile things.Profile) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ViewProfile(ctx context.Context, token, id string) (things.Profile, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListProfiles(ctx context.Context, token string, pm things.PageMetadata) (things.ProfilesPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListThingsByProfile(ctx context.Context, token, profileID string, pm things.PageMetadata) (things.Page, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RemoveProfiles(ctx context.Context, token string, ids ...string) error {
+	panic("not implemented")
+}
+
 func (svc *mainfluxThings) RemoveGroups(ctx context.Context, token string, ids ...string) error {
 	panic("not implemented")
 }
@@ -296,3 +396,39 @@ func (svc *mainfluxThings) ViewChannelMembership(ctx context.Context, token stri
 func (svc *mainfluxThings) ListGroupChannels(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.GroupChannelsPage, error) {
 	panic("not implemented")
 }
+
+func (svc *mainfluxThings) ListDeletedThings(ctx context.Context, token string, pm things.PageMetadata) (things.DeletedThingsPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RestoreThings(ctx context.Context, token string, ids ...string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListDeletedChannels(ctx context.Context, token string, pm things.PageMetadata) (things.DeletedChannelsPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RestoreChannels(ctx context.Context, token string, ids ...string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) ListDeletedGroups(ctx context.Context, token string, pm things.PageMetadata) (things.DeletedGroupsPage, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RestoreGroups(ctx context.Context, token string, ids ...string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) AddGroupTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) RemoveGroupTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) PurgeTrash(ctx context.Context, before time.Time) error {
+	panic("not implemented")
+}
@@ -5,9 +5,11 @@ package mocks
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/grpc"
@@ -20,15 +22,22 @@ var _ mainflux.ThingsServiceClient = (*thingsServiceMock)(nil)
 type thingsServiceMock struct {
 	channels map[string]string
 	groups   map[string]things.Group
+	schemas  map[string]schema.Schema
 }
 
 // NewThingsService returns mock implementation of things service
 func NewThingsServiceClient(channels map[string]string, groups map[string]things.Group) mainflux.ThingsServiceClient {
-	return &thingsServiceMock{channels, groups}
+	return NewThingsServiceClientWithSchemas(channels, groups, nil)
 }
 
-func (svc thingsServiceMock) GetConnByKey(ctx context.Context, in *mainflux.ConnByKeyReq, opts ...grpc.CallOption) (*mainflux.ConnByKeyRes, error) {
-	key := in.GetKey()
+// NewThingsServiceClientWithSchemas returns mock implementation of things
+// service with the given payload schemas, mapped by channel ID.
+func NewThingsServiceClientWithSchemas(channels map[string]string, groups map[string]things.Group, schemas map[string]schema.Schema) mainflux.ThingsServiceClient {
+	return &thingsServiceMock{channels, groups, schemas}
+}
+
+func (svc thingsServiceMock) CanAccessByKey(ctx context.Context, in *mainflux.AccessByKeyReq, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
+	key := in.GetToken()
 
 	if key == "invalid" {
 		return nil, errors.ErrAuthentication
@@ -49,7 +58,12 @@ func (svc thingsServiceMock) GetConnByKey(ctx context.Context, in *mainflux.Conn
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
 
-	return &mainflux.ConnByKeyRes{ChannelID: key, ThingID: key}, nil
+	// Keys registered with a channel are allowed to access only that channel.
+	if chanID, ok := svc.channels[key]; ok && chanID != in.GetChanID() {
+		return nil, errors.ErrAuthorization
+	}
+
+	return &mainflux.ThingID{Value: key}, nil
 }
 
 func (svc thingsServiceMock) IsChannelOwner(ctx context.Context, in *mainflux.ChannelOwnerReq, opts ...grpc.CallOption) (*empty.Empty, error) {
@@ -61,17 +75,80 @@ func (svc thingsServiceMock) IsChannelOwner(ctx context.Context, in *mainflux.Ch
 	return nil, errors.ErrAuthorization
 }
 
-func (svc thingsServiceMock) Identify(context.Context, *mainflux.Token, ...grpc.CallOption) (*mainflux.ThingID, error) {
-	panic("not implemented")
+func (svc thingsServiceMock) IsThingOwner(ctx context.Context, in *mainflux.ThingOwnerReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	// Owners are registered with the IDs of their entities.
+	if id, ok := svc.channels[in.GetOwner()]; ok && id == in.GetThingID() {
+		return &empty.Empty{}, nil
+	}
+
+	return nil, errors.ErrAuthorization
+}
+
+func (svc thingsServiceMock) Identify(ctx context.Context, req *mainflux.Token, opts ...grpc.CallOption) (*mainflux.ThingID, error) {
+	// Thing keys are used as thing IDs by the mock, see CanAccessByKey.
+	key := req.GetValue()
+	if key == "" || key == "invalid" {
+		return nil, errors.ErrAuthentication
+	}
+
+	return &mainflux.ThingID{Value: key}, nil
 }
 
 func (svc thingsServiceMock) GetGroupsByIDs(ctx context.Context, req *mainflux.GroupsReq, opts ...grpc.CallOption) (*mainflux.GroupsRes, error) {
 	var groups []*mainflux.Group
 	for _, id := range req.Ids {
 		if group, ok := svc.groups[id]; ok {
-			groups = append(groups, &mainflux.Group{Id: group.ID, OwnerID: group.OwnerID, Name: group.Name, Description: group.Description})
+			groups = append(groups, &mainflux.Group{Id: group.ID, OwnerID: group.OwnerID, Name: group.Name, Description: group.Description, ParentID: group.ParentID, Path: svc.path(group), Tags: group.Tags})
 		}
 	}
 
 	return &mainflux.GroupsRes{Groups: groups}, nil
 }
+
+func (svc thingsServiceMock) GetChannelSchema(ctx context.Context, req *mainflux.ChannelID, opts ...grpc.CallOption) (*mainflux.ChannelSchema, error) {
+	s, ok := svc.schemas[req.GetValue()]
+	if !ok {
+		return &mainflux.ChannelSchema{}, nil
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mainflux.ChannelSchema{Value: b}, nil
+}
+
+func (svc thingsServiceMock) GetOSCORESecret(ctx context.Context, req *mainflux.ThingID, opts ...grpc.CallOption) (*mainflux.Secret, error) {
+	// Thing keys are used as thing IDs by the mock, see CanAccessByKey.
+	id := req.GetValue()
+	if id == "" || id == "invalid" {
+		return nil, errors.ErrNotFound
+	}
+
+	secret, err := things.DeriveOSCORESecret(id, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mainflux.Secret{Value: secret}, nil
+}
+
+func (svc thingsServiceMock) CanReadChannel(ctx context.Context, req *mainflux.ChannelReadReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	// Tokens are registered with the channels they are allowed to read.
+	if id, ok := svc.channels[req.GetToken()]; ok && id == req.GetChanID() {
+		return &empty.Empty{}, nil
+	}
+
+	return nil, errors.ErrAuthorization
+}
+
+// path returns IDs of groups from the root down to the given group by walking up its parents.
+func (svc thingsServiceMock) path(group things.Group) []string {
+	path := []string{group.ID}
+	for parent, ok := svc.groups[group.ParentID]; ok; parent, ok = svc.groups[parent.ParentID] {
+		path = append([]string{parent.ID}, path...)
+	}
+
+	return path
+}
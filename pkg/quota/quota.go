@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package quota contains the types shared by the services enforcing the
+// quotas managed by the auth service.
+package quota
+
+import "github.com/MainfluxLabs/mainflux/pkg/errors"
+
+const (
+	// User identifies the quota of a user.
+	User = "user"
+	// Org identifies the quota of an org.
+	Org = "org"
+	// Group identifies the quota of the org that a group belongs to.
+	Group = "group"
+
+	// Things identifies the things quota.
+	Things = "things"
+	// Channels identifies the channels quota.
+	Channels = "channels"
+	// Groups identifies the groups quota.
+	Groups = "groups"
+	// Keys identifies the API keys quota.
+	Keys = "keys"
+	// Subscriptions identifies the notifier subscriptions quota.
+	Subscriptions = "subscriptions"
+)
+
+// ErrQuotaExceeded indicates that the operation would exceed the quota.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// Usage reports the number of entities in use against their limit.
+type Usage struct {
+	Used uint64 `json:"used"`
+	// Limit is the maximum number of entities, where zero means unlimited.
+	Limit uint64 `json:"limit"`
+}
+
+// Check returns ErrQuotaExceeded if adding n entities to the used ones
+// exceeds the limit. Zero limit means unlimited.
+func Check(used, n, limit uint64) error {
+	if limit > 0 && used+n > limit {
+		return ErrQuotaExceeded
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit limits the rate and the payload size of the messages
+// published through the protocol adapters.
+package ratelimit
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"golang.org/x/time/rate"
+)
+
+var (
+	// ErrRateLimited indicates that the publisher exceeded its message rate.
+	ErrRateLimited = errors.New("message rate limit exceeded")
+
+	// ErrPayloadTooLarge indicates that the message payload exceeds the maximum size.
+	ErrPayloadTooLarge = errors.New("message payload too large")
+)
+
+// Config contains the message limits of an adapter. Zero values mean unlimited.
+type Config struct {
+	// Rate is the number of messages per second a publisher can send.
+	Rate float64
+	// Burst is the number of messages a publisher can send at once. It
+	// defaults to the rate rounded up.
+	Burst int
+	// MaxPayloadSize is the maximum payload size in bytes.
+	MaxPayloadSize int
+}
+
+// Limiter enforces the message limits of the publishers.
+type Limiter interface {
+	// CheckPayload returns ErrPayloadTooLarge if the payload size exceeds
+	// the maximum size.
+	CheckPayload(size int) error
+
+	// Allow returns ErrRateLimited if the publisher identified by id
+	// exceeded its message rate.
+	Allow(id string) error
+}
+
+var _ Limiter = (*limiter)(nil)
+
+type limiter struct {
+	cfg      Config
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// New returns a limiter enforcing the given limits.
+func New(cfg Config) Limiter {
+	if cfg.Rate > 0 && cfg.Burst <= 0 {
+		cfg.Burst = int(cfg.Rate)
+		if float64(cfg.Burst) < cfg.Rate {
+			cfg.Burst++
+		}
+	}
+
+	return &limiter{
+		cfg:      cfg,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+func (l *limiter) CheckPayload(size int) error {
+	if l.cfg.MaxPayloadSize > 0 && size > l.cfg.MaxPayloadSize {
+		return ErrPayloadTooLarge
+	}
+
+	return nil
+}
+
+func (l *limiter) Allow(id string) error {
+	if l.cfg.Rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	rl, ok := l.limiters[id]
+	if !ok {
+		rl = rate.NewLimiter(rate.Limit(l.cfg.Rate), l.cfg.Burst)
+		l.limiters[id] = rl
+	}
+	l.mu.Unlock()
+
+	if !rl.Allow() {
+		return ErrRateLimited
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPayload(t *testing.T) {
+	cases := []struct {
+		desc  string
+		limit int
+		size  int
+		err   error
+	}{
+		{
+			desc:  "check payload without limit",
+			limit: 0,
+			size:  1024,
+			err:   nil,
+		},
+		{
+			desc:  "check payload within limit",
+			limit: 1024,
+			size:  1024,
+			err:   nil,
+		},
+		{
+			desc:  "check payload exceeding limit",
+			limit: 1024,
+			size:  1025,
+			err:   ratelimit.ErrPayloadTooLarge,
+		},
+	}
+
+	for _, tc := range cases {
+		l := ratelimit.New(ratelimit.Config{MaxPayloadSize: tc.limit})
+		err := l.CheckPayload(tc.size)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAllow(t *testing.T) {
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "allow first message",
+			id:   "thing1",
+			err:  nil,
+		},
+		{
+			desc: "allow message exceeding burst",
+			id:   "thing1",
+			err:  ratelimit.ErrRateLimited,
+		},
+		{
+			desc: "allow message of another publisher",
+			id:   "thing2",
+			err:  nil,
+		},
+	}
+
+	l := ratelimit.New(ratelimit.Config{Rate: 0.001, Burst: 1})
+	for _, tc := range cases {
+		err := l.Allow(tc.id)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	unlimited := ratelimit.New(ratelimit.Config{})
+	for i := 0; i < 100; i++ {
+		err := unlimited.Allow("thing")
+		assert.Nil(t, err, fmt.Sprintf("allow message without limit: unexpected error %s", err))
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// cacheTTL is the period after which a cached channel schema is
+// retrieved from the things service again. Expired schemas are evicted
+// from the cache at most once per the period.
+const cacheTTL = time.Minute
+
+// ChannelValidator validates payloads against the schemas of their channels.
+type ChannelValidator interface {
+	// Validate returns ErrInvalidPayload if the payload doesn't conform to
+	// the schema of the channel identified by chanID.
+	Validate(ctx context.Context, chanID string, payload []byte) error
+}
+
+var _ ChannelValidator = (*channelValidator)(nil)
+
+type cachedValidator struct {
+	validator Validator
+	expires   time.Time
+}
+
+type channelValidator struct {
+	things     mainflux.ThingsServiceClient
+	mu         sync.Mutex
+	validators map[string]cachedValidator
+	sweptAt    time.Time
+}
+
+// NewChannelValidator returns a validator retrieving the channel schemas
+// from the things service.
+func NewChannelValidator(things mainflux.ThingsServiceClient) ChannelValidator {
+	return &channelValidator{
+		things:     things,
+		validators: make(map[string]cachedValidator),
+	}
+}
+
+func (cv *channelValidator) Validate(ctx context.Context, chanID string, payload []byte) error {
+	v, err := cv.validator(ctx, chanID)
+	if err != nil {
+		return err
+	}
+
+	return v.Validate(payload)
+}
+
+func (cv *channelValidator) validator(ctx context.Context, chanID string) (Validator, error) {
+	now := time.Now()
+
+	cv.mu.Lock()
+	cached, ok := cv.validators[chanID]
+	cv.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.validator, nil
+	}
+
+	res, err := cv.things.GetChannelSchema(ctx, &mainflux.ChannelID{Value: chanID})
+	if err != nil {
+		return nil, err
+	}
+
+	var s Schema
+	if len(res.GetValue()) > 0 {
+		if err := json.Unmarshal(res.GetValue(), &s); err != nil {
+			return nil, errors.Wrap(ErrInvalidSchema, err)
+		}
+	}
+
+	v, err := s.Compile()
+	if err != nil {
+		return nil, err
+	}
+
+	cv.store(chanID, v, now)
+
+	return v, nil
+}
+
+// store caches the validator of the channel. Expired validators of the
+// channels which are no longer used are evicted, so that the cache doesn't
+// grow with every channel ever validated.
+func (cv *channelValidator) store(chanID string, v Validator, now time.Time) {
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+
+	if now.Sub(cv.sweptAt) >= cacheTTL {
+		for id, cached := range cv.validators {
+			if !now.Before(cached.expires) {
+				delete(cv.validators, id)
+			}
+		}
+		cv.sweptAt = now
+	}
+
+	cv.validators[chanID] = cachedValidator{validator: v, expires: now.Add(cacheTTL)}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelValidatorEviction(t *testing.T) {
+	now := time.Now()
+	cv := &channelValidator{
+		validators: map[string]cachedValidator{
+			"expired": {expires: now.Add(-time.Second)},
+			"valid":   {expires: now.Add(time.Second)},
+		},
+	}
+
+	cases := []struct {
+		desc   string
+		chanID string
+		now    time.Time
+		cached []string
+	}{
+		{
+			desc:   "store validator evicting expired ones",
+			chanID: "first",
+			now:    now,
+			cached: []string{"first", "valid"},
+		},
+		{
+			desc:   "store validator before the next sweep",
+			chanID: "second",
+			now:    now.Add(cacheTTL / 2),
+			cached: []string{"first", "second", "valid"},
+		},
+		{
+			desc:   "store validator after the next sweep",
+			chanID: "third",
+			now:    now.Add(cacheTTL),
+			cached: []string{"second", "third"},
+		},
+	}
+
+	for _, tc := range cases {
+		cv.store(tc.chanID, nil, tc.now)
+
+		var cached []string
+		for id := range cv.validators {
+			cached = append(cached, id)
+		}
+		assert.ElementsMatch(t, tc.cached, cached, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.cached, cached))
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schema validates message payloads against the schemas declared
+// by their channels.
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/senml"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+var (
+	// ErrInvalidSchema indicates a malformed payload schema.
+	ErrInvalidSchema = errors.New("invalid payload schema")
+
+	// ErrInvalidPayload indicates that the payload doesn't conform to the channel schema.
+	ErrInvalidPayload = errors.New("payload doesn't conform to the channel schema")
+
+	errMixedSchema = errors.New("json and senml schemas are mutually exclusive")
+)
+
+// Schema describes the payloads accepted by a channel. At most one of its
+// fields can be set, and an empty schema accepts any payload.
+type Schema struct {
+	// JSON is the JSON schema that JSON payloads must conform to.
+	JSON map[string]interface{} `json:"json,omitempty"`
+	// SenML maps the allowed SenML record names to their units, where an
+	// empty unit allows any unit. Record names include the base name.
+	SenML map[string]string `json:"senml,omitempty"`
+}
+
+// Empty reports whether the schema accepts any payload.
+func (s Schema) Empty() bool {
+	return len(s.JSON) == 0 && len(s.SenML) == 0
+}
+
+// Validate checks whether the schema is well formed.
+func (s Schema) Validate() error {
+	_, err := s.Compile()
+	return err
+}
+
+// Validator validates payloads against a compiled schema.
+type Validator interface {
+	// Validate returns ErrInvalidPayload if the payload doesn't conform
+	// to the schema.
+	Validate(payload []byte) error
+}
+
+// Compile returns the validator of the schema.
+func (s Schema) Compile() (Validator, error) {
+	switch {
+	case len(s.JSON) > 0 && len(s.SenML) > 0:
+		return nil, errors.Wrap(ErrInvalidSchema, errMixedSchema)
+	case len(s.JSON) > 0:
+		js, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(s.JSON))
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidSchema, err)
+		}
+		return jsonValidator{schema: js}, nil
+	case len(s.SenML) > 0:
+		return senmlValidator{units: s.SenML}, nil
+	default:
+		return anyValidator{}, nil
+	}
+}
+
+type anyValidator struct{}
+
+func (anyValidator) Validate([]byte) error {
+	return nil
+}
+
+type jsonValidator struct {
+	schema *gojsonschema.Schema
+}
+
+func (v jsonValidator) Validate(payload []byte) error {
+	res, err := v.schema.Validate(gojsonschema.NewBytesLoader(payload))
+	if err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+
+	if !res.Valid() {
+		var msgs []string
+		for _, re := range res.Errors() {
+			msgs = append(msgs, re.String())
+		}
+		return errors.Wrap(ErrInvalidPayload, errors.New(strings.Join(msgs, "; ")))
+	}
+
+	return nil
+}
+
+type senmlValidator struct {
+	units map[string]string
+}
+
+func (v senmlValidator) Validate(payload []byte) error {
+	pack, err := senml.Decode(payload, senml.JSON)
+	if err != nil {
+		if pack, err = senml.Decode(payload, senml.CBOR); err != nil {
+			return errors.Wrap(ErrInvalidPayload, err)
+		}
+	}
+
+	pack, err = senml.Normalize(pack)
+	if err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+
+	for _, r := range pack.Records {
+		unit, ok := v.units[r.Name]
+		if !ok {
+			return errors.Wrap(ErrInvalidPayload, fmt.Errorf("record name %s is not allowed", r.Name))
+		}
+		if unit != "" && r.Unit != unit {
+			return errors.Wrap(ErrInvalidPayload, fmt.Errorf("record %s has unit %s instead of %s", r.Name, r.Unit, unit))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package schema_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	jsonSchema = schema.Schema{
+		JSON: map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"temperature"},
+			"properties": map[string]interface{}{
+				"temperature": map[string]interface{}{"type": "number"},
+			},
+		},
+	}
+	senmlSchema = schema.Schema{
+		SenML: map[string]string{
+			"dev:temp": "Cel",
+			"dev:hum":  "",
+		},
+	}
+)
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		desc   string
+		schema schema.Schema
+		err    error
+	}{
+		{
+			desc:   "validate empty schema",
+			schema: schema.Schema{},
+			err:    nil,
+		},
+		{
+			desc:   "validate json schema",
+			schema: jsonSchema,
+			err:    nil,
+		},
+		{
+			desc:   "validate senml schema",
+			schema: senmlSchema,
+			err:    nil,
+		},
+		{
+			desc:   "validate invalid json schema",
+			schema: schema.Schema{JSON: map[string]interface{}{"type": 1}},
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "validate schema with both json and senml",
+			schema: schema.Schema{JSON: jsonSchema.JSON, SenML: senmlSchema.SenML},
+			err:    schema.ErrInvalidSchema,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.schema.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestValidatePayload(t *testing.T) {
+	cases := []struct {
+		desc    string
+		schema  schema.Schema
+		payload string
+		err     error
+	}{
+		{
+			desc:    "validate any payload against empty schema",
+			schema:  schema.Schema{},
+			payload: "payload",
+			err:     nil,
+		},
+		{
+			desc:    "validate conforming json payload",
+			schema:  jsonSchema,
+			payload: `{"temperature": 21.5}`,
+			err:     nil,
+		},
+		{
+			desc:    "validate json payload with invalid field type",
+			schema:  jsonSchema,
+			payload: `{"temperature": "hot"}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate json payload without required field",
+			schema:  jsonSchema,
+			payload: `{"humidity": 40}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate malformed json payload",
+			schema:  jsonSchema,
+			payload: `{"temperature":`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate conforming senml payload",
+			schema:  senmlSchema,
+			payload: `[{"bn":"dev:","n":"temp","u":"Cel","v":21.5},{"n":"hum","u":"%RH","v":40}]`,
+			err:     nil,
+		},
+		{
+			desc:    "validate senml payload with unknown name",
+			schema:  senmlSchema,
+			payload: `[{"bn":"dev:","n":"pressure","u":"Pa","v":1000}]`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate senml payload with wrong unit",
+			schema:  senmlSchema,
+			payload: `[{"bn":"dev:","n":"temp","u":"K","v":294}]`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate malformed senml payload",
+			schema:  senmlSchema,
+			payload: "payload",
+			err:     schema.ErrInvalidPayload,
+		},
+	}
+
+	for _, tc := range cases {
+		v, err := tc.schema.Compile()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		err = v.Validate([]byte(tc.payload))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestChannelValidator(t *testing.T) {
+	schemas := map[string]schema.Schema{
+		"json":  jsonSchema,
+		"senml": senmlSchema,
+	}
+	things := mocks.NewThingsServiceClientWithSchemas(nil, nil, schemas)
+	cv := schema.NewChannelValidator(things)
+
+	cases := []struct {
+		desc    string
+		chanID  string
+		payload string
+		err     error
+	}{
+		{
+			desc:    "validate payload of channel without schema",
+			chanID:  "none",
+			payload: "payload",
+			err:     nil,
+		},
+		{
+			desc:    "validate conforming payload of json channel",
+			chanID:  "json",
+			payload: `{"temperature": 21.5}`,
+			err:     nil,
+		},
+		{
+			desc:    "validate non-conforming payload of json channel",
+			chanID:  "json",
+			payload: `{"temperature": "hot"}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate non-conforming payload of senml channel",
+			chanID:  "senml",
+			payload: `{"temperature": 21.5}`,
+			err:     schema.ErrInvalidPayload,
+		},
+	}
+
+	for _, tc := range cases {
+		err := cv.Validate(context.Background(), tc.chanID, []byte(tc.payload))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -73,6 +73,30 @@ func (sdk mfSDK) RevokeCert(thingID, certID string, token string) error {
 	panic("not implemented")
 }
 
+func (sdk mfSDK) IsCertRevoked(serial string) (bool, error) {
+	url := fmt.Sprintf("%s/%s/%s/status", sdk.certsURL, certsEndpoint, serial)
+	res, err := request(http.MethodGet, "", url, nil)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, errors.ErrNotFound
+	default:
+		return false, ErrCerts
+	}
+
+	var s certStatusRes
+	if err := json.NewDecoder(res.Body).Decode(&s); err != nil {
+		return false, err
+	}
+
+	return s.Revoked, nil
+}
+
 func request(method, jwt, url string, data []byte) (*http.Response, error) {
 	req, err := http.NewRequest(method, url, bytes.NewReader(data))
 	if err != nil {
@@ -96,3 +120,7 @@ type certReq struct {
 	Encryption string `json:"encryption"`
 	Valid      string `json:"valid"`
 }
+
+type certStatusRes struct {
+	Revoked bool `json:"revoked"`
+}
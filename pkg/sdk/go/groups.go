@@ -401,6 +401,35 @@ func (sdk mfSDK) UpdateGroup(t Group, token string) error {
 	return nil
 }
 
+func (sdk mfSDK) ListGroupChildren(groupID, token string, depth, offset, limit uint64) (GroupsPage, error) {
+	url := fmt.Sprintf("%s/%s/%s/children?depth=%d&offset=%d&limit=%d", sdk.thingsURL, groupsEndpoint, groupID, depth, offset, limit)
+	return sdk.getGroups(token, url)
+}
+
+func (sdk mfSDK) MoveGroup(groupID, parentID, token string) error {
+	data, err := json.Marshal(moveGroupReq{ParentID: parentID})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/parent", sdk.thingsURL, groupsEndpoint, groupID)
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrap(ErrFailedUpdate, errors.New(resp.Status))
+	}
+
+	return nil
+}
+
 func (sdk mfSDK) ViewThingMembership(thingID, token string, offset, limit uint64) (Group, error) {
 	url := fmt.Sprintf("%s/%s/%s/%s?offset=%d&limit=%d", sdk.thingsURL, thingsEndpoint, thingID, groupsEndpoint, offset, limit)
 	req, err := http.NewRequest(http.MethodGet, url, nil)
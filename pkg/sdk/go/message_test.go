@@ -14,6 +14,8 @@ import (
 	"github.com/MainfluxLabs/mainflux/http/api"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	sdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
@@ -21,12 +23,12 @@ import (
 
 func newMessageService(tc mainflux.ThingsServiceClient) adapter.Service {
 	pub := mocks.NewPublisher()
-	return adapter.New(pub, tc)
+	return adapter.New(pub, tc, ratelimit.New(ratelimit.Config{}), schema.NewChannelValidator(tc))
 }
 
 func newMessageServer(svc adapter.Service) *httptest.Server {
 	logger := logger.NewMock()
-	mux := api.MakeHandler(svc, mocktracer.New(), logger)
+	mux := api.MakeHandler(svc, api.BatchLimits{}, mocktracer.New(), logger)
 	return httptest.NewServer(mux)
 }
 
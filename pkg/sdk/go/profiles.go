@@ -0,0 +1,191 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const profilesEndpoint = "profiles"
+
+func (sdk mfSDK) CreateProfile(p Profile, token string) (string, error) {
+	data, err := json.Marshal([]Profile{p})
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.thingsURL, profilesEndpoint)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", errors.Wrap(ErrFailedCreation, errors.New(resp.Status))
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var cpr createProfilesRes
+	if err := json.Unmarshal(body, &cpr); err != nil {
+		return "", err
+	}
+
+	if len(cpr.Profiles) < 1 {
+		return "", nil
+	}
+
+	return cpr.Profiles[0].ID, nil
+}
+
+func (sdk mfSDK) Profiles(token string, pm PageMetadata) (ProfilesPage, error) {
+	url, err := sdk.withQueryParams(sdk.thingsURL, profilesEndpoint, pm)
+	if err != nil {
+		return ProfilesPage{}, err
+	}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return ProfilesPage{}, err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return ProfilesPage{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return ProfilesPage{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ProfilesPage{}, errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+	}
+
+	var pp ProfilesPage
+	if err := json.Unmarshal(body, &pp); err != nil {
+		return ProfilesPage{}, err
+	}
+
+	return pp, nil
+}
+
+func (sdk mfSDK) Profile(id, token string) (Profile, error) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.thingsURL, profilesEndpoint, id)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return Profile{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Profile{}, errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+	}
+
+	var p Profile
+	if err := json.Unmarshal(body, &p); err != nil {
+		return Profile{}, err
+	}
+
+	return p, nil
+}
+
+func (sdk mfSDK) UpdateProfile(p Profile, token string) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.thingsURL, profilesEndpoint, p.ID)
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrap(ErrFailedUpdate, errors.New(resp.Status))
+	}
+
+	return nil
+}
+
+func (sdk mfSDK) DeleteProfile(id, token string) error {
+	url := fmt.Sprintf("%s/%s/%s", sdk.thingsURL, profilesEndpoint, id)
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Wrap(ErrFailedRemoval, errors.New(resp.Status))
+	}
+
+	return nil
+}
+
+func (sdk mfSDK) ThingsByProfile(token, profileID string, offset, limit uint64) (ThingsPage, error) {
+	url := fmt.Sprintf("%s/%s/%s/%s?offset=%d&limit=%d", sdk.thingsURL, profilesEndpoint, profileID, thingsEndpoint, offset, limit)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return ThingsPage{}, err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return ThingsPage{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return ThingsPage{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ThingsPage{}, errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+	}
+
+	var tp ThingsPage
+	if err := json.Unmarshal(body, &tp); err != nil {
+		return ThingsPage{}, err
+	}
+
+	return tp, nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	sdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var profile = sdk.Profile{
+	Name: "sensor",
+	Schema: map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"serial"},
+		"properties": map[string]interface{}{
+			"serial": map[string]interface{}{"type": "string"},
+		},
+	},
+	Defaults: map[string]interface{}{"interval": float64(60)},
+}
+
+func TestCreateProfile(t *testing.T) {
+	svc := newThingsService()
+	ts := newThingsServer(svc)
+	defer ts.Close()
+
+	sdkConf := sdk.Config{
+		ThingsURL:       ts.URL,
+		MsgContentType:  contentType,
+		TLSVerification: false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+
+	cases := []struct {
+		desc     string
+		profile  sdk.Profile
+		token    string
+		err      error
+		location string
+	}{
+		{
+			desc:     "create new profile",
+			profile:  profile,
+			token:    token,
+			err:      nil,
+			location: fmt.Sprintf("%s%012d", uuid.Prefix, 1),
+		},
+		{
+			desc:     "create profile with existing name",
+			profile:  profile,
+			token:    token,
+			err:      createError(sdk.ErrFailedCreation, http.StatusConflict),
+			location: "",
+		},
+		{
+			desc:     "create profile with invalid schema",
+			profile:  sdk.Profile{Name: "invalid", Schema: map[string]interface{}{"type": 5}},
+			token:    token,
+			err:      createError(sdk.ErrFailedCreation, http.StatusBadRequest),
+			location: "",
+		},
+		{
+			desc:     "create profile with invalid token",
+			profile:  profile,
+			token:    wrongValue,
+			err:      createError(sdk.ErrFailedCreation, http.StatusUnauthorized),
+			location: "",
+		},
+	}
+	for _, tc := range cases {
+		loc, err := mainfluxSDK.CreateProfile(tc.profile, tc.token)
+
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.location, loc, fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, loc))
+	}
+}
+
+func TestThingsByProfile(t *testing.T) {
+	svc := newThingsService()
+	ts := newThingsServer(svc)
+	defer ts.Close()
+
+	sdkConf := sdk.Config{
+		ThingsURL:       ts.URL,
+		MsgContentType:  contentType,
+		TLSVerification: false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+
+	prID, err := mainfluxSDK.CreateProfile(profile, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	th := sdk.Thing{Name: "sensor", ProfileID: prID, Metadata: map[string]interface{}{"serial": "A1"}}
+	_, err = mainfluxSDK.CreateThing(th, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	invalid := sdk.Thing{Name: "invalid", ProfileID: prID}
+	_, err = mainfluxSDK.CreateThing(invalid, token)
+	assert.Equal(t, createError(sdk.ErrFailedCreation, http.StatusBadRequest), err, fmt.Sprintf("create thing with invalid metadata: unexpected error %s", err))
+
+	page, err := mainfluxSDK.ThingsByProfile(token, prID, 0, 10)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, page.Things, 1)
+	assert.Equal(t, prID, page.Things[0].ProfileID, fmt.Sprintf("expected profile %s got %s", prID, page.Things[0].ProfileID))
+	assert.Equal(t, float64(60), page.Things[0].Metadata["interval"], "expected profile defaults to be applied")
+
+	_, err = mainfluxSDK.ThingsByProfile(wrongValue, prID, 0, 10)
+	assert.Equal(t, createError(sdk.ErrFailedFetch, http.StatusUnauthorized), err, fmt.Sprintf("list things by profile with invalid token: unexpected error %s", err))
+}
@@ -17,10 +17,13 @@ type UserPasswordReq struct {
 	Password    string `json:"password,omitempty"`
 }
 
-// ConnectionIDs contains ID lists of things and channel to be connected
+// ConnectionIDs contains ID lists of things and channel to be connected.
+// Type is one of "publish", "subscribe" or "publish_subscribe" and defaults
+// to "publish_subscribe" when empty.
 type ConnectionIDs struct {
 	ChannelID string   `json:"channel_id"`
 	ThingIDs  []string `json:"thing_ids"`
+	Type      string   `json:"type,omitempty"`
 }
 
 // deleteChannelsReq contains IDs of channels to be deleted
@@ -33,6 +36,11 @@ type deleteThingsReq struct {
 	ThingIDs []string `json:"thing_ids"`
 }
 
+// moveGroupReq contains ID of the new parent of a group
+type moveGroupReq struct {
+	ParentID string `json:"parent_id"`
+}
+
 // deleteGroupsReq contains IDs of groups to be deleted
 type deleteGroupsReq struct {
 	GroupIDs []string `json:"group_ids"`
@@ -22,6 +22,10 @@ type createChannelsRes struct {
 	Channels []Channel `json:"channels"`
 }
 
+type createProfilesRes struct {
+	Profiles []Profile `json:"profiles"`
+}
+
 type createGroupsRes struct {
 	Groups []Group `json:"groups"`
 }
@@ -44,6 +48,12 @@ type ChannelsPage struct {
 	pageRes
 }
 
+// ProfilesPage contains list of profiles in a page with proper metadata.
+type ProfilesPage struct {
+	Profiles []Profile `json:"profiles"`
+	pageRes
+}
+
 // MessagesPage contains list of messages in a page with proper metadata.
 type MessagesPage struct {
 	Messages []senml.Message `json:"messages,omitempty"`
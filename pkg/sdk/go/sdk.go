@@ -11,10 +11,12 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 )
 
 const (
@@ -98,6 +100,7 @@ type PageMetadata struct {
 	Name     string                 `json:"name,omitempty"`
 	Type     string                 `json:"type,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Tags     []string               `json:"tags,omitempty"`
 }
 
 // Group represents mainflux users group.
@@ -105,25 +108,45 @@ type Group struct {
 	ID          string                 `json:"id,omitempty"`
 	Name        string                 `json:"name,omitempty"`
 	OwnerID     string                 `json:"owner_id,omitempty"`
+	ParentID    string                 `json:"parent_id,omitempty"`
+	Path        []string               `json:"path,omitempty"`
 	Description string                 `json:"description,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
 	CreatedAt   time.Time              `json:"created_at,omitempty"`
 	UpdatedAt   time.Time              `json:"updated_at,omitempty"`
 }
 
 // Thing represents mainflux thing.
 type Thing struct {
-	ID       string                 `json:"id,omitempty"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID        string                 `json:"id,omitempty"`
+	Name      string                 `json:"name,omitempty"`
+	Key       string                 `json:"key,omitempty"`
+	ProfileID string                 `json:"profile_id,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Tags      []string               `json:"tags,omitempty"`
 }
 
 // Channel represents mainflux channel.
 type Channel struct {
-	ID       string                 `json:"id,omitempty"`
-	Name     string                 `json:"name,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID            string                 `json:"id,omitempty"`
+	Name          string                 `json:"name,omitempty"`
+	ProfileID     string                 `json:"profile_id,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
+	PayloadSchema schema.Schema          `json:"payload_schema,omitempty"`
+}
+
+// Profile represents mainflux thing and channel profile.
+type Profile struct {
+	ID          string                 `json:"id,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	OwnerID     string                 `json:"owner_id,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Schema      map[string]interface{} `json:"schema,omitempty"`
+	Defaults    map[string]interface{} `json:"defaults,omitempty"`
+	CreatedAt   time.Time              `json:"created_at,omitempty"`
+	UpdatedAt   time.Time              `json:"updated_at,omitempty"`
 }
 
 type Key struct {
@@ -167,6 +190,9 @@ type SDK interface {
 	// Things returns page of things.
 	Things(token string, pm PageMetadata) (ThingsPage, error)
 
+	// SearchThings returns page of things matching the search filters.
+	SearchThings(token string, sm SearchMetadata) (ThingsPage, error)
+
 	// ThingsByChannel returns page of things that are connected or not connected
 	// to specified channel.
 	ThingsByChannel(token, chanID string, offset, limit uint64, disconnected bool) (ThingsPage, error)
@@ -183,6 +209,18 @@ type SDK interface {
 	// DeleteThings removes existing things.
 	DeleteThings(ids []string, token string) error
 
+	// DeletedThings returns page of things in the trash.
+	DeletedThings(token string, pm PageMetadata) (DeletedThingsPage, error)
+
+	// RestoreThings moves things out of the trash.
+	RestoreThings(ids []string, token string) error
+
+	// AddThingTags adds tags to things.
+	AddThingTags(ids, tags []string, token string) error
+
+	// RemoveThingTags removes tags from things.
+	RemoveThingTags(ids, tags []string, token string) error
+
 	// IdentifyThing validates thing's key and returns its ID
 	IdentifyThing(key string) (string, error)
 
@@ -195,6 +233,18 @@ type SDK interface {
 	// DeleteGroups delete users groups.
 	DeleteGroups(ids []string, token string) error
 
+	// DeletedGroups returns page of groups in the trash.
+	DeletedGroups(token string, pm PageMetadata) (DeletedGroupsPage, error)
+
+	// RestoreGroups moves groups out of the trash.
+	RestoreGroups(ids []string, token string) error
+
+	// AddGroupTags adds tags to groups.
+	AddGroupTags(ids, tags []string, token string) error
+
+	// RemoveGroupTags removes tags from groups.
+	RemoveGroupTags(ids, tags []string, token string) error
+
 	// Groups returns page of groups.
 	Groups(meta PageMetadata, token string) (GroupsPage, error)
 
@@ -216,6 +266,13 @@ type SDK interface {
 	// UpdateGroup updates existing group.
 	UpdateGroup(group Group, token string) error
 
+	// ListGroupChildren lists descendants of the specified group that are at most depth levels below it.
+	ListGroupChildren(groupID, token string, depth, offset, limit uint64) (GroupsPage, error)
+
+	// MoveGroup moves the group with its descendants under the specified parent.
+	// Empty parentID makes the group a root group.
+	MoveGroup(groupID, parentID, token string) error
+
 	// Connect connects a list of things to a channel.
 	Connect(conns ConnectionIDs, token string) error
 
@@ -231,6 +288,9 @@ type SDK interface {
 	// Channels returns page of channels.
 	Channels(token string, pm PageMetadata) (ChannelsPage, error)
 
+	// SearchChannels returns page of channels matching the search filters.
+	SearchChannels(token string, sm SearchMetadata) (ChannelsPage, error)
+
 	// ViewChannelByThing returns channel that are connected to specified thing.
 	ViewChannelByThing(token, thingID string) (Channel, error)
 
@@ -246,6 +306,18 @@ type SDK interface {
 	// DeleteChannels removes existing channel.
 	DeleteChannels(ids []string, token string) error
 
+	// DeletedChannels returns page of channels in the trash.
+	DeletedChannels(token string, pm PageMetadata) (DeletedChannelsPage, error)
+
+	// RestoreChannels moves channels out of the trash.
+	RestoreChannels(ids []string, token string) error
+
+	// AddChannelTags adds tags to channels.
+	AddChannelTags(ids, tags []string, token string) error
+
+	// RemoveChannelTags removes tags from channels.
+	RemoveChannelTags(ids, tags []string, token string) error
+
 	// AssignChannel assigns channel to a group.
 	AssignChannel(channelIDs []string, groupID string, token string) error
 
@@ -258,6 +330,24 @@ type SDK interface {
 	// ViewChannelMembership retrieves a group that the specified channel is a member of.
 	ViewChannelMembership(channelID, token string, offset, limit uint64) (Group, error)
 
+	// CreateProfile creates new thing and channel profile and returns its id.
+	CreateProfile(profile Profile, token string) (string, error)
+
+	// Profiles returns page of profiles.
+	Profiles(token string, pm PageMetadata) (ProfilesPage, error)
+
+	// Profile returns profile data by id.
+	Profile(id, token string) (Profile, error)
+
+	// UpdateProfile updates existing profile.
+	UpdateProfile(profile Profile, token string) error
+
+	// DeleteProfile removes existing profile.
+	DeleteProfile(id, token string) error
+
+	// ThingsByProfile returns page of things created from the specified profile.
+	ThingsByProfile(token, profileID string, offset, limit uint64) (ThingsPage, error)
+
 	// SendMessage send message to specified channel.
 	SendMessage(chanID, msg, token string) error
 
@@ -300,6 +390,9 @@ type SDK interface {
 	// RevokeCert revokes certificate with certID for thing with thingID
 	RevokeCert(thingID, certID, token string) error
 
+	// IsCertRevoked reports whether the certificate with the given serial has been revoked.
+	IsCertRevoked(serial string) (bool, error)
+
 	// Issue issues a new key, returning its token value alongside.
 	Issue(token string, duration time.Duration) (KeyRes, error)
 
@@ -415,5 +508,8 @@ func (pm PageMetadata) query() (string, error) {
 		}
 		q.Add("metadata", string(md))
 	}
+	if len(pm.Tags) > 0 {
+		q.Add("tags", strings.Join(pm.Tags, ","))
+	}
 	return q.Encode(), nil
 }
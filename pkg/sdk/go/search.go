@@ -0,0 +1,108 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// Metadata filter operators.
+const (
+	OpEq       = "eq"
+	OpNe       = "ne"
+	OpGt       = "gt"
+	OpGte      = "gte"
+	OpLt       = "lt"
+	OpLte      = "lte"
+	OpIn       = "in"
+	OpPrefix   = "prefix"
+	OpContains = "contains"
+)
+
+// MetadataFilter matches things and channels whose metadata value at the
+// dot-separated Path satisfies the Op comparison with Value.
+type MetadataFilter struct {
+	Path  string      `json:"path"`
+	Op    string      `json:"op"`
+	Value interface{} `json:"value"`
+}
+
+// TimeRange represents a time interval. A zero bound leaves the interval
+// unbounded on that side.
+type TimeRange struct {
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+}
+
+// SearchMetadata contains search filters and paging parameters of things
+// and channels search. All the filters must match.
+type SearchMetadata struct {
+	Offset    uint64                 `json:"offset"`
+	Limit     uint64                 `json:"limit"`
+	Name      string                 `json:"name,omitempty"`
+	Order     string                 `json:"order,omitempty"`
+	Dir       string                 `json:"dir,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	Filters   []MetadataFilter       `json:"filters,omitempty"`
+	Created   TimeRange              `json:"created,omitempty"`
+	Updated   TimeRange              `json:"updated,omitempty"`
+	GroupID   string                 `json:"group_id,omitempty"`
+	Connected *bool                  `json:"connected,omitempty"`
+	Tags      []string               `json:"tags,omitempty"`
+}
+
+func (sdk mfSDK) SearchThings(token string, sm SearchMetadata) (ThingsPage, error) {
+	var tp ThingsPage
+	if err := sdk.search(token, thingsEndpoint, sm, &tp); err != nil {
+		return ThingsPage{}, err
+	}
+
+	return tp, nil
+}
+
+func (sdk mfSDK) SearchChannels(token string, sm SearchMetadata) (ChannelsPage, error) {
+	var cp ChannelsPage
+	if err := sdk.search(token, channelsEndpoint, sm, &cp); err != nil {
+		return ChannelsPage{}, err
+	}
+
+	return cp, nil
+}
+
+func (sdk mfSDK) search(token, endpoint string, sm SearchMetadata, page interface{}) error {
+	data, err := json.Marshal(sm)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s/search", sdk.thingsURL, endpoint)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+	}
+
+	return json.Unmarshal(body, page)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const tagsEndpoint = "tags"
+
+type tagsReq struct {
+	IDs  []string `json:"ids"`
+	Tags []string `json:"tags"`
+}
+
+func (sdk mfSDK) AddThingTags(ids, tags []string, token string) error {
+	return sdk.updateTags(token, thingsEndpoint, http.MethodPost, ids, tags)
+}
+
+func (sdk mfSDK) RemoveThingTags(ids, tags []string, token string) error {
+	return sdk.updateTags(token, thingsEndpoint, http.MethodPatch, ids, tags)
+}
+
+func (sdk mfSDK) AddChannelTags(ids, tags []string, token string) error {
+	return sdk.updateTags(token, channelsEndpoint, http.MethodPost, ids, tags)
+}
+
+func (sdk mfSDK) RemoveChannelTags(ids, tags []string, token string) error {
+	return sdk.updateTags(token, channelsEndpoint, http.MethodPatch, ids, tags)
+}
+
+func (sdk mfSDK) AddGroupTags(ids, tags []string, token string) error {
+	return sdk.updateTags(token, groupsEndpoint, http.MethodPost, ids, tags)
+}
+
+func (sdk mfSDK) RemoveGroupTags(ids, tags []string, token string) error {
+	return sdk.updateTags(token, groupsEndpoint, http.MethodPatch, ids, tags)
+}
+
+func (sdk mfSDK) updateTags(token, endpoint, method string, ids, tags []string) error {
+	data, err := json.Marshal(tagsReq{IDs: ids, Tags: tags})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.thingsURL, endpoint, tagsEndpoint)
+	req, err := http.NewRequest(method, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Wrap(ErrFailedUpdate, errors.New(resp.Status))
+	}
+
+	return nil
+}
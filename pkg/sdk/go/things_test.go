@@ -48,13 +48,15 @@ func newThingsService() things.Service {
 	auth := mocks.NewAuthService("", usersList)
 	conns := make(chan thmocks.Connection)
 	thingsRepo := thmocks.NewThingRepository(conns)
+	thingKeysRepo := thmocks.NewThingKeyRepository()
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
 	groupsRepo := thmocks.NewGroupRepository()
+	profilesRepo := thmocks.NewProfileRepository()
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, thingKeysRepo, channelsRepo, groupsRepo, profilesRepo, chanCache, thingCache, idProvider)
 }
 
 func newThingsServer(svc things.Service) *httptest.Server {
@@ -722,6 +724,81 @@ func TestDeleteThings(t *testing.T) {
 	}
 }
 
+func TestThingTags(t *testing.T) {
+	svc := newThingsService()
+	ts := newThingsServer(svc)
+	defer ts.Close()
+	sdkConf := sdk.Config{
+		ThingsURL:       ts.URL,
+		MsgContentType:  contentType,
+		TLSVerification: false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+	id1, err := mainfluxSDK.CreateThing(th1, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		remove   bool
+		thingIDs []string
+		tags     []string
+		token    string
+		err      error
+	}{
+		{
+			desc:     "add tags with invalid token",
+			thingIDs: []string{id1},
+			tags:     []string{"outdoor"},
+			token:    wrongValue,
+			err:      createError(sdk.ErrFailedUpdate, http.StatusUnauthorized),
+		},
+		{
+			desc:     "add tags to non-existing thing",
+			thingIDs: []string{wrongID},
+			tags:     []string{"outdoor"},
+			token:    token,
+			err:      createError(sdk.ErrFailedUpdate, http.StatusNotFound),
+		},
+		{
+			desc:     "add invalid tags",
+			thingIDs: []string{id1},
+			tags:     []string{"out door"},
+			token:    token,
+			err:      createError(sdk.ErrFailedUpdate, http.StatusBadRequest),
+		},
+		{
+			desc:     "add tags",
+			thingIDs: []string{id1},
+			tags:     []string{"outdoor", "faulty"},
+			token:    token,
+			err:      nil,
+		},
+		{
+			desc:     "remove tags",
+			remove:   true,
+			thingIDs: []string{id1},
+			tags:     []string{"faulty"},
+			token:    token,
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		update := mainfluxSDK.AddThingTags
+		if tc.remove {
+			update = mainfluxSDK.RemoveThingTags
+		}
+		err := update(tc.thingIDs, tc.tags, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+	}
+
+	page, err := mainfluxSDK.Things(token, sdk.PageMetadata{Limit: 10, Tags: []string{"outdoor", "!faulty"}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Equal(t, 1, len(page.Things), fmt.Sprintf("list things by tags: expected 1 thing got %d", len(page.Things)))
+	assert.Equal(t, []string{"outdoor"}, page.Things[0].Tags, fmt.Sprintf("list things by tags: expected tags %v got %v", []string{"outdoor"}, page.Things[0].Tags))
+}
+
 func TestIdentifyThing(t *testing.T) {
 	svc := newThingsService()
 	ts := newThingsServer(svc)
@@ -988,8 +1065,8 @@ func TestConnect(t *testing.T) {
 
 	for _, tc := range cases {
 		connIDs := sdk.ConnectionIDs{
-			tc.thingID,
-			[]string{tc.chanID},
+			ChannelID: tc.thingID,
+			ThingIDs:  []string{tc.chanID},
 		}
 
 		err := mainfluxSDK.Connect(connIDs, tc.token)
@@ -1096,3 +1173,73 @@ func TestDisconnect(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
 	}
 }
+
+func TestSearchThings(t *testing.T) {
+	svc := newThingsService()
+	ts := newThingsServer(svc)
+	defer ts.Close()
+
+	sdkConf := sdk.Config{
+		ThingsURL:       ts.URL,
+		MsgContentType:  contentType,
+		TLSVerification: false,
+	}
+
+	mainfluxSDK := sdk.NewSDK(sdkConf)
+
+	var ths []sdk.Thing
+	for i := 1; i < 6; i++ {
+		th := sdk.Thing{
+			ID:       fmt.Sprintf("%s%012d", uuid.Prefix, i),
+			Name:     fmt.Sprintf("test-%d", i),
+			Metadata: metadata,
+		}
+		ths = append(ths, th)
+	}
+	created, err := mainfluxSDK.CreateThings(ths, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	connected := false
+	cases := []struct {
+		desc   string
+		token  string
+		search sdk.SearchMetadata
+		err    error
+		res    []sdk.Thing
+	}{
+		{
+			desc:  "search things with filters",
+			token: token,
+			search: sdk.SearchMetadata{
+				Limit:     10,
+				Filters:   []sdk.MetadataFilter{{Path: "meta", Op: sdk.OpPrefix, Value: "da"}},
+				Connected: &connected,
+			},
+			err: nil,
+			res: created,
+		},
+		{
+			desc:  "search things with invalid filter operator",
+			token: token,
+			search: sdk.SearchMetadata{
+				Limit:   10,
+				Filters: []sdk.MetadataFilter{{Path: "meta", Op: "like", Value: "da"}},
+			},
+			err: createError(sdk.ErrFailedFetch, http.StatusBadRequest),
+			res: nil,
+		},
+		{
+			desc:   "search things with invalid token",
+			token:  wrongValue,
+			search: sdk.SearchMetadata{Limit: 10},
+			err:    createError(sdk.ErrFailedFetch, http.StatusUnauthorized),
+			res:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := mainfluxSDK.SearchThings(tc.token, tc.search)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.ElementsMatch(t, tc.res, page.Things, fmt.Sprintf("%s: expected response %v got %v", tc.desc, tc.res, page.Things))
+	}
+}
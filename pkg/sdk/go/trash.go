@@ -0,0 +1,161 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const trashEndpoint = "trash"
+
+// DeletedThing represents a thing in the trash.
+type DeletedThing struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID  string                 `json:"profile_id,omitempty"`
+	ChannelIDs []string               `json:"channel_ids,omitempty"`
+	GroupID    string                 `json:"group_id,omitempty"`
+	DeletedAt  time.Time              `json:"deleted_at"`
+}
+
+// DeletedThingsPage contains list of things in the trash in a page with proper metadata.
+type DeletedThingsPage struct {
+	Things []DeletedThing `json:"things"`
+	pageRes
+}
+
+// DeletedChannel represents a channel in the trash.
+type DeletedChannel struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID string                 `json:"profile_id,omitempty"`
+	ThingIDs  []string               `json:"thing_ids,omitempty"`
+	GroupID   string                 `json:"group_id,omitempty"`
+	DeletedAt time.Time              `json:"deleted_at"`
+}
+
+// DeletedChannelsPage contains list of channels in the trash in a page with proper metadata.
+type DeletedChannelsPage struct {
+	Channels []DeletedChannel `json:"channels"`
+	pageRes
+}
+
+// DeletedGroup represents a group in the trash.
+type DeletedGroup struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	ParentID    string                 `json:"parent_id,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	ThingIDs    []string               `json:"thing_ids,omitempty"`
+	ChannelIDs  []string               `json:"channel_ids,omitempty"`
+	DeletedAt   time.Time              `json:"deleted_at"`
+}
+
+// DeletedGroupsPage contains list of groups in the trash in a page with proper metadata.
+type DeletedGroupsPage struct {
+	Groups []DeletedGroup `json:"groups"`
+	pageRes
+}
+
+func (sdk mfSDK) DeletedThings(token string, pm PageMetadata) (DeletedThingsPage, error) {
+	var tp DeletedThingsPage
+	if err := sdk.deleted(token, thingsEndpoint, pm, &tp); err != nil {
+		return DeletedThingsPage{}, err
+	}
+
+	return tp, nil
+}
+
+func (sdk mfSDK) RestoreThings(ids []string, token string) error {
+	return sdk.restore(token, thingsEndpoint, deleteThingsReq{ThingIDs: ids})
+}
+
+func (sdk mfSDK) DeletedChannels(token string, pm PageMetadata) (DeletedChannelsPage, error) {
+	var cp DeletedChannelsPage
+	if err := sdk.deleted(token, channelsEndpoint, pm, &cp); err != nil {
+		return DeletedChannelsPage{}, err
+	}
+
+	return cp, nil
+}
+
+func (sdk mfSDK) RestoreChannels(ids []string, token string) error {
+	return sdk.restore(token, channelsEndpoint, deleteChannelsReq{ChannelIDs: ids})
+}
+
+func (sdk mfSDK) DeletedGroups(token string, pm PageMetadata) (DeletedGroupsPage, error) {
+	var gp DeletedGroupsPage
+	if err := sdk.deleted(token, groupsEndpoint, pm, &gp); err != nil {
+		return DeletedGroupsPage{}, err
+	}
+
+	return gp, nil
+}
+
+func (sdk mfSDK) RestoreGroups(ids []string, token string) error {
+	return sdk.restore(token, groupsEndpoint, deleteGroupsReq{GroupIDs: ids})
+}
+
+func (sdk mfSDK) deleted(token, endpoint string, pm PageMetadata, page interface{}) error {
+	url, err := sdk.withQueryParams(sdk.thingsURL, fmt.Sprintf("%s/%s", trashEndpoint, endpoint), pm)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+	}
+
+	return json.Unmarshal(body, page)
+}
+
+func (sdk mfSDK) restore(token, endpoint string, ids interface{}) error {
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/restore", sdk.thingsURL, trashEndpoint, endpoint)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		return errors.Wrap(ErrFailedCreation, errors.New(resp.Status))
+	}
+
+	return nil
+}
@@ -46,7 +46,7 @@ func newUserService() users.Service {
 	auth := mocks.NewAuthService(admin.ID, usersList)
 	emailer := usmocks.NewEmailer()
 
-	return users.New(usersRepo, hasher, auth, emailer, idProvider, passRegex)
+	return users.New(usersRepo, hasher, auth, emailer, idProvider, users.PasswordPolicy{Regex: passRegex}, usmocks.NewLockoutRepository(), users.LockoutConfig{})
 }
 
 func newUserServer(svc users.Service) *httptest.Server {
@@ -15,6 +15,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/MainfluxLabs/mainflux/things"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -54,6 +55,7 @@ func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient,
 
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(encodeError),
+		kithttp.ServerBefore(apiutil.ClientIPToContext),
 	}
 
 	mux := bone.New()
@@ -382,16 +384,25 @@ func authorize(ctx context.Context, token, key, chanID string) (err error) {
 			return err
 		}
 
+		if !auth.HasScope(user.GetScopes(), auth.MessagesReadScope) || !auth.HasResource(user.GetChannelIDs(), chanID) {
+			return errors.ErrAuthorization
+		}
+
 		if err := authorizeAdmin(ctx, auth.RootSubject, token); err == nil {
 			return nil
 		}
 
-		if _, err = thingc.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: user.Id, ChanID: chanID}); err != nil {
+		if _, err = thingc.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: user.Id, ChanID: chanID}); err == nil {
+			return nil
+		}
+
+		// Members of the channel group may read its messages as well.
+		if _, err = thingc.CanReadChannel(ctx, &mainflux.ChannelReadReq{Token: token, ChanID: chanID}); err != nil {
 			return err
 		}
 		return nil
 	default:
-		if _, err := thingc.GetConnByKey(ctx, &mainflux.ConnByKeyReq{Key: key}); err != nil {
+		if _, err := thingc.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: key, ChanID: chanID, Action: things.SubscribeAction}); err != nil {
 			return err
 		}
 		return nil
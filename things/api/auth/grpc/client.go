@@ -19,11 +19,15 @@ import (
 var _ mainflux.ThingsServiceClient = (*grpcClient)(nil)
 
 type grpcClient struct {
-	timeout        time.Duration
-	getConnByKey   endpoint.Endpoint
-	isChannelOwner endpoint.Endpoint
-	identify       endpoint.Endpoint
-	getGroupsByIDs endpoint.Endpoint
+	timeout          time.Duration
+	canAccessByKey   endpoint.Endpoint
+	isChannelOwner   endpoint.Endpoint
+	isThingOwner     endpoint.Endpoint
+	identify         endpoint.Endpoint
+	getGroupsByIDs   endpoint.Endpoint
+	getChannelSchema endpoint.Endpoint
+	getOSCORESecret  endpoint.Endpoint
+	canReadChannel   endpoint.Endpoint
 }
 
 // NewClient returns new gRPC client instance.
@@ -32,13 +36,13 @@ func NewClient(conn *grpc.ClientConn, tracer opentracing.Tracer, timeout time.Du
 
 	return &grpcClient{
 		timeout: timeout,
-		getConnByKey: kitot.TraceClient(tracer, "get_conn_by_key")(kitgrpc.NewClient(
+		canAccessByKey: kitot.TraceClient(tracer, "can_access_by_key")(kitgrpc.NewClient(
 			conn,
 			svcName,
-			"GetConnByKey",
-			encodeGetConnByKeyRequest,
-			decodeGetConnByKeyResponse,
-			mainflux.ConnByKeyRes{},
+			"CanAccessByKey",
+			encodeCanAccessByKeyRequest,
+			decodeIdentityResponse,
+			mainflux.ThingID{},
 		).Endpoint()),
 		isChannelOwner: kitot.TraceClient(tracer, "is_channel_owner")(kitgrpc.NewClient(
 			conn,
@@ -48,6 +52,14 @@ func NewClient(conn *grpc.ClientConn, tracer opentracing.Tracer, timeout time.Du
 			decodeEmptyResponse,
 			empty.Empty{},
 		).Endpoint()),
+		isThingOwner: kitot.TraceClient(tracer, "is_thing_owner")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"IsThingOwner",
+			encodeIsThingOwner,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
 		identify: kitot.TraceClient(tracer, "identify")(kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -64,23 +76,49 @@ func NewClient(conn *grpc.ClientConn, tracer opentracing.Tracer, timeout time.Du
 			decodeGetGroupsByIDsResponse,
 			mainflux.GroupsRes{},
 		).Endpoint()),
+		getChannelSchema: kitot.TraceClient(tracer, "get_channel_schema")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"GetChannelSchema",
+			encodeGetChannelSchemaRequest,
+			decodeGetChannelSchemaResponse,
+			mainflux.ChannelSchema{},
+		).Endpoint()),
+		getOSCORESecret: kitot.TraceClient(tracer, "get_oscore_secret")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"GetOSCORESecret",
+			encodeGetOSCORESecretRequest,
+			decodeGetOSCORESecretResponse,
+			mainflux.Secret{},
+		).Endpoint()),
+		canReadChannel: kitot.TraceClient(tracer, "can_read_channel")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"CanReadChannel",
+			encodeCanReadChannelRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
 	}
 }
 
-func (client grpcClient) GetConnByKey(ctx context.Context, req *mainflux.ConnByKeyReq, _ ...grpc.CallOption) (*mainflux.ConnByKeyRes, error) {
+func (client grpcClient) CanAccessByKey(ctx context.Context, req *mainflux.AccessByKeyReq, _ ...grpc.CallOption) (*mainflux.ThingID, error) {
 	ctx, cancel := context.WithTimeout(ctx, client.timeout)
 	defer cancel()
 
-	ar := connByKeyReq{
-		key: req.GetKey(),
+	ar := accessByKeyReq{
+		key:    req.GetToken(),
+		chanID: req.GetChanID(),
+		action: req.GetAction(),
 	}
-	res, err := client.getConnByKey(ctx, ar)
+	res, err := client.canAccessByKey(ctx, ar)
 	if err != nil {
 		return nil, err
 	}
 
-	cr := res.(connByKeyRes)
-	return &mainflux.ConnByKeyRes{ChannelID: cr.channelOD, ThingID: cr.thingID}, nil
+	ir := res.(identityRes)
+	return &mainflux.ThingID{Value: ir.id}, nil
 }
 
 func (client grpcClient) IsChannelOwner(ctx context.Context, req *mainflux.ChannelOwnerReq, _ ...grpc.CallOption) (*empty.Empty, error) {
@@ -94,6 +132,20 @@ func (client grpcClient) IsChannelOwner(ctx context.Context, req *mainflux.Chann
 	return &empty.Empty{}, er.err
 }
 
+func (client grpcClient) IsThingOwner(ctx context.Context, req *mainflux.ThingOwnerReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	ar := thingOwnerReq{owner: req.GetOwner(), thingID: req.GetThingID()}
+	res, err := client.isThingOwner(ctx, ar)
+	if err != nil {
+		return nil, err
+	}
+
+	er := res.(emptyRes)
+	return &empty.Empty{}, er.err
+}
+
 func (client grpcClient) Identify(ctx context.Context, req *mainflux.Token, _ ...grpc.CallOption) (*mainflux.ThingID, error) {
 	ctx, cancel := context.WithTimeout(ctx, client.timeout)
 	defer cancel()
@@ -120,9 +172,48 @@ func (client grpcClient) GetGroupsByIDs(ctx context.Context, req *mainflux.Group
 	return &mainflux.GroupsRes{Groups: gr.groups}, nil
 }
 
-func encodeGetConnByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
-	req := grpcReq.(connByKeyReq)
-	return &mainflux.ConnByKeyReq{Key: req.key}, nil
+func (client grpcClient) GetChannelSchema(ctx context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.ChannelSchema, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.getChannelSchema(ctx, channelSchemaReq{chanID: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	sr := res.(channelSchemaRes)
+	return &mainflux.ChannelSchema{Value: sr.schema}, nil
+}
+
+func (client grpcClient) GetOSCORESecret(ctx context.Context, req *mainflux.ThingID, _ ...grpc.CallOption) (*mainflux.Secret, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.getOSCORESecret(ctx, oscoreSecretReq{thingID: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	sr := res.(oscoreSecretRes)
+	return &mainflux.Secret{Value: sr.secret}, nil
+}
+
+func (client grpcClient) CanReadChannel(ctx context.Context, req *mainflux.ChannelReadReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.canReadChannel(ctx, channelReadReq{token: req.GetToken(), chanID: req.GetChanID(), permission: req.GetPermission()})
+	if err != nil {
+		return nil, err
+	}
+
+	er := res.(emptyRes)
+	return &empty.Empty{}, er.err
+}
+
+func encodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(accessByKeyReq)
+	return &mainflux.AccessByKeyReq{Token: req.key, ChanID: req.chanID, Action: req.action}, nil
 }
 
 func encodeIsChannelOwner(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -130,6 +221,11 @@ func encodeIsChannelOwner(_ context.Context, grpcReq interface{}) (interface{},
 	return &mainflux.ChannelOwnerReq{Owner: req.owner, ChanID: req.chanID}, nil
 }
 
+func encodeIsThingOwner(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(thingOwnerReq)
+	return &mainflux.ThingOwnerReq{Owner: req.owner, ThingID: req.thingID}, nil
+}
+
 func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(identifyReq)
 	return &mainflux.Token{Value: req.key}, nil
@@ -140,16 +236,26 @@ func encodeGetGroupsByIDsRequest(_ context.Context, grpcReq interface{}) (interf
 	return &mainflux.GroupsReq{Ids: req.ids}, nil
 }
 
+func encodeGetChannelSchemaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(channelSchemaReq)
+	return &mainflux.ChannelID{Value: req.chanID}, nil
+}
+
+func encodeGetOSCORESecretRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(oscoreSecretReq)
+	return &mainflux.ThingID{Value: req.thingID}, nil
+}
+
+func encodeCanReadChannelRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(channelReadReq)
+	return &mainflux.ChannelReadReq{Token: req.token, ChanID: req.chanID, Permission: req.permission}, nil
+}
+
 func decodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*mainflux.ThingID)
 	return identityRes{id: res.GetValue()}, nil
 }
 
-func decodeGetConnByKeyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
-	res := grpcRes.(*mainflux.ConnByKeyRes)
-	return connByKeyRes{channelOD: res.ChannelID, thingID: res.ThingID}, nil
-}
-
 func decodeEmptyResponse(_ context.Context, _ interface{}) (interface{}, error) {
 	return emptyRes{}, nil
 }
@@ -158,3 +264,13 @@ func decodeGetGroupsByIDsResponse(_ context.Context, grpcRes interface{}) (inter
 	res := grpcRes.(*mainflux.GroupsRes)
 	return getGroupsByIDsRes{groups: res.GetGroups()}, nil
 }
+
+func decodeGetChannelSchemaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.ChannelSchema)
+	return channelSchemaRes{schema: res.GetValue()}, nil
+}
+
+func decodeGetOSCORESecretResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.Secret)
+	return oscoreSecretRes{secret: res.GetValue()}, nil
+}
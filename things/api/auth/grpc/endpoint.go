@@ -5,24 +5,26 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/go-kit/kit/endpoint"
 )
 
-func getConnByKeyEndpoint(svc things.Service) endpoint.Endpoint {
+func canAccessByKeyEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(connByKeyReq)
+		req := request.(accessByKeyReq)
 		if err := req.validate(); err != nil {
 			return nil, err
 		}
 
-		conn, err := svc.GetConnByKey(ctx, req.key)
+		id, err := svc.CanAccessByKey(ctx, req.chanID, req.key, req.action)
 		if err != nil {
-			return connByKeyRes{}, err
+			return identityRes{}, err
 		}
-		return connByKeyRes{channelOD: conn.ChannelID, thingID: conn.ThingID}, nil
+
+		return identityRes{id: id}, nil
 	}
 }
 
@@ -38,6 +40,18 @@ func isChannelOwnerEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func isThingOwnerEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(thingOwnerReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		err := svc.IsThingOwner(ctx, req.owner, req.thingID)
+		return emptyRes{err: err}, err
+	}
+}
+
 func identifyEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(identifyReq)
@@ -74,6 +88,9 @@ func listGroupsByIDsEndpoint(svc things.Service) endpoint.Endpoint {
 				OwnerID:     g.OwnerID,
 				Name:        g.Name,
 				Description: g.Description,
+				ParentID:    g.ParentID,
+				Path:        g.Path,
+				Tags:        g.Tags,
 			}
 			mgr = append(mgr, &gr)
 		}
@@ -81,3 +98,56 @@ func listGroupsByIDsEndpoint(svc things.Service) endpoint.Endpoint {
 		return getGroupsByIDsRes{groups: mgr}, nil
 	}
 }
+
+func getChannelSchemaEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(channelSchemaReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		s, err := svc.GetChannelSchema(ctx, req.chanID)
+		if err != nil {
+			return channelSchemaRes{}, err
+		}
+
+		if s.Empty() {
+			return channelSchemaRes{}, nil
+		}
+
+		b, err := json.Marshal(s)
+		if err != nil {
+			return channelSchemaRes{}, err
+		}
+
+		return channelSchemaRes{schema: b}, nil
+	}
+}
+
+func getOSCORESecretEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(oscoreSecretReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		secret, err := svc.GetOSCORESecret(ctx, req.thingID)
+		if err != nil {
+			return oscoreSecretRes{}, err
+		}
+
+		return oscoreSecretRes{secret: secret}, nil
+	}
+}
+
+func canReadChannelEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(channelReadReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		err := svc.CanReadChannel(ctx, req.token, req.chanID, req.permission)
+		return emptyRes{err: err}, err
+	}
+}
@@ -28,7 +28,7 @@ var (
 	group   = things.Group{Name: "test-group", Description: "test-group-desc"}
 )
 
-func TestGetConnByKey(t *testing.T) {
+func TestCanAccessByKey(t *testing.T) {
 	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th1 := ths[0]
@@ -48,7 +48,7 @@ func TestGetConnByKey(t *testing.T) {
 	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, ch.ID, []string{th1.ID})
+	err = svc.Connect(context.Background(), token, ch.ID, []string{th1.ID}, things.ConnTypePublish)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	usersAddr := fmt.Sprintf("localhost:%d", port)
@@ -59,27 +59,62 @@ func TestGetConnByKey(t *testing.T) {
 	defer cancel()
 
 	cases := map[string]struct {
-		key  string
-		code codes.Code
+		key    string
+		chanID string
+		action string
+		id     string
+		code   codes.Code
 	}{
-		"check if connected thing can access existing channel": {
-			key:  th1.Key,
-			code: codes.OK,
+		"check if connected thing can publish to existing channel": {
+			key:    th1.Key,
+			chanID: ch.ID,
+			action: things.PublishAction,
+			id:     th1.ID,
+			code:   codes.OK,
+		},
+		"check if publish-only thing can subscribe to existing channel": {
+			key:    th1.Key,
+			chanID: ch.ID,
+			action: things.SubscribeAction,
+			code:   codes.PermissionDenied,
 		},
 		"check if unconnected thing can access existing channel": {
-			key:  th2.Key,
-			code: codes.PermissionDenied,
+			key:    th2.Key,
+			chanID: ch.ID,
+			action: things.PublishAction,
+			code:   codes.PermissionDenied,
+		},
+		"check if connected thing can access non-existent channel": {
+			key:    th1.Key,
+			chanID: "non-existent",
+			action: things.PublishAction,
+			code:   codes.PermissionDenied,
 		},
 		"check if thing with wrong access key can access existing channel": {
-			key:  wrong,
-			code: codes.NotFound,
+			key:    wrong,
+			chanID: ch.ID,
+			action: things.PublishAction,
+			code:   codes.NotFound,
+		},
+		"check access without channel id": {
+			key:    th1.Key,
+			chanID: "",
+			action: things.PublishAction,
+			code:   codes.InvalidArgument,
+		},
+		"check access with invalid action": {
+			key:    th1.Key,
+			chanID: ch.ID,
+			action: "invalid",
+			code:   codes.InvalidArgument,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := cli.GetConnByKey(ctx, &mainflux.ConnByKeyReq{Key: tc.key})
+		id, err := cli.CanAccessByKey(ctx, &mainflux.AccessByKeyReq{Token: tc.key, ChanID: tc.chanID, Action: tc.action})
 		e, ok := status.FromError(err)
 		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.id, id.GetValue(), fmt.Sprintf("%s: expected %s got %s", desc, tc.id, id.GetValue()))
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
 	}
 }
@@ -121,3 +156,100 @@ func TestIdentify(t *testing.T) {
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
 	}
 }
+
+func TestGetOSCORESecret(t *testing.T) {
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	sth := ths[0]
+	secret, err := things.DeriveOSCORESecret(sth.ID, sth.Key)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		id     string
+		secret []byte
+		code   codes.Code
+	}{
+		"get OSCORE secret of existing thing": {
+			id:     sth.ID,
+			secret: secret,
+			code:   codes.OK,
+		},
+		"get OSCORE secret of non-existent thing": {
+			id:     wrong,
+			secret: nil,
+			code:   codes.NotFound,
+		},
+		"get OSCORE secret with empty thing id": {
+			id:     wrongID,
+			secret: nil,
+			code:   codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		s, err := cli.GetOSCORESecret(ctx, &mainflux.ThingID{Value: tc.id})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.secret, s.GetValue(), fmt.Sprintf("%s: expected %x got %x", desc, tc.secret, s.GetValue()))
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
+
+func TestCanReadChannel(t *testing.T) {
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		token  string
+		chanID string
+		code   codes.Code
+	}{
+		"check if user can read owned channel": {
+			token:  token,
+			chanID: ch.ID,
+			code:   codes.OK,
+		},
+		"check if user can read non-existent channel": {
+			token:  token,
+			chanID: wrong,
+			code:   codes.NotFound,
+		},
+		"check if user with invalid token can read channel": {
+			token:  wrong,
+			chanID: ch.ID,
+			code:   codes.Unauthenticated,
+		},
+		"check read access without token": {
+			token:  "",
+			chanID: ch.ID,
+			code:   codes.Unauthenticated,
+		},
+		"check read access without channel id": {
+			token:  token,
+			chanID: wrongID,
+			code:   codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		_, err := cli.CanReadChannel(ctx, &mainflux.ChannelReadReq{Token: tc.token, ChanID: tc.chanID})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+	}
+}
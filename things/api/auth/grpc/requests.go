@@ -3,17 +3,30 @@
 
 package grpc
 
-import "github.com/MainfluxLabs/mainflux/internal/apiutil"
+import (
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/things"
+)
 
-type connByKeyReq struct {
-	key string
+type accessByKeyReq struct {
+	key    string
+	chanID string
+	action string
 }
 
-func (req connByKeyReq) validate() error {
+func (req accessByKeyReq) validate() error {
 	if req.key == "" {
 		return apiutil.ErrBearerKey
 	}
 
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.action != things.PublishAction && req.action != things.SubscribeAction {
+		return apiutil.ErrInvalidAction
+	}
+
 	return nil
 }
 
@@ -43,6 +56,19 @@ func (req channelOwnerReq) validate() error {
 	return nil
 }
 
+type thingOwnerReq struct {
+	owner   string
+	thingID string
+}
+
+func (req thingOwnerReq) validate() error {
+	if req.owner == "" || req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
 type identifyReq struct {
 	key string
 }
@@ -66,3 +92,45 @@ func (req getGroupsByIDsReq) validate() error {
 
 	return nil
 }
+
+type channelSchemaReq struct {
+	chanID string
+}
+
+func (req channelSchemaReq) validate() error {
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type oscoreSecretReq struct {
+	thingID string
+}
+
+func (req oscoreSecretReq) validate() error {
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type channelReadReq struct {
+	token      string
+	chanID     string
+	permission string
+}
+
+func (req channelReadReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
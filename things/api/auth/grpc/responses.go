@@ -9,11 +9,6 @@ type identityRes struct {
 	id string
 }
 
-type connByKeyRes struct {
-	channelOD string
-	thingID   string
-}
-
 type emptyRes struct {
 	err error
 }
@@ -21,3 +16,11 @@ type emptyRes struct {
 type getGroupsByIDsRes struct {
 	groups []*mainflux.Group
 }
+
+type channelSchemaRes struct {
+	schema []byte
+}
+
+type oscoreSecretRes struct {
+	secret []byte
+}
@@ -21,25 +21,34 @@ import (
 var _ mainflux.ThingsServiceServer = (*grpcServer)(nil)
 
 type grpcServer struct {
-	getConnByKey   kitgrpc.Handler
-	isChannelOwner kitgrpc.Handler
-	identify       kitgrpc.Handler
-	getGroupsByIDs kitgrpc.Handler
+	canAccessByKey   kitgrpc.Handler
+	isChannelOwner   kitgrpc.Handler
+	isThingOwner     kitgrpc.Handler
+	identify         kitgrpc.Handler
+	getGroupsByIDs   kitgrpc.Handler
+	getChannelSchema kitgrpc.Handler
+	getOSCORESecret  kitgrpc.Handler
+	canReadChannel   kitgrpc.Handler
 }
 
 // NewServer returns new ThingsServiceServer instance.
 func NewServer(tracer opentracing.Tracer, svc things.Service) mainflux.ThingsServiceServer {
 	return &grpcServer{
-		getConnByKey: kitgrpc.NewServer(
-			kitot.TraceServer(tracer, "get_conn_by_key")(getConnByKeyEndpoint(svc)),
-			decodeGetConnByKeyRequest,
-			encodeGetConnByKeyResponse,
+		canAccessByKey: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "can_access_by_key")(canAccessByKeyEndpoint(svc)),
+			decodeCanAccessByKeyRequest,
+			encodeIdentityResponse,
 		),
 		isChannelOwner: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "is_channel_owner")(isChannelOwnerEndpoint(svc)),
 			decodeIsChannelOwnerRequest,
 			encodeEmptyResponse,
 		),
+		isThingOwner: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "is_thing_owner")(isThingOwnerEndpoint(svc)),
+			decodeIsThingOwnerRequest,
+			encodeEmptyResponse,
+		),
 		identify: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "identify")(identifyEndpoint(svc)),
 			decodeIdentifyRequest,
@@ -50,16 +59,31 @@ func NewServer(tracer opentracing.Tracer, svc things.Service) mainflux.ThingsSer
 			decodeGetGroupsByIDsRequest,
 			encodeGetGroupsByIDsResponse,
 		),
+		getChannelSchema: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "get_channel_schema")(getChannelSchemaEndpoint(svc)),
+			decodeGetChannelSchemaRequest,
+			encodeGetChannelSchemaResponse,
+		),
+		getOSCORESecret: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "get_oscore_secret")(getOSCORESecretEndpoint(svc)),
+			decodeGetOSCORESecretRequest,
+			encodeGetOSCORESecretResponse,
+		),
+		canReadChannel: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "can_read_channel")(canReadChannelEndpoint(svc)),
+			decodeCanReadChannelRequest,
+			encodeEmptyResponse,
+		),
 	}
 }
 
-func (gs *grpcServer) GetConnByKey(ctx context.Context, req *mainflux.ConnByKeyReq) (*mainflux.ConnByKeyRes, error) {
-	_, res, err := gs.getConnByKey.ServeGRPC(ctx, req)
+func (gs *grpcServer) CanAccessByKey(ctx context.Context, req *mainflux.AccessByKeyReq) (*mainflux.ThingID, error) {
+	_, res, err := gs.canAccessByKey.ServeGRPC(ctx, req)
 	if err != nil {
 		return nil, encodeError(err)
 	}
 
-	return res.(*mainflux.ConnByKeyRes), nil
+	return res.(*mainflux.ThingID), nil
 }
 
 func (gs *grpcServer) IsChannelOwner(ctx context.Context, req *mainflux.ChannelOwnerReq) (*empty.Empty, error) {
@@ -71,6 +95,15 @@ func (gs *grpcServer) IsChannelOwner(ctx context.Context, req *mainflux.ChannelO
 	return res.(*empty.Empty), nil
 }
 
+func (gs *grpcServer) IsThingOwner(ctx context.Context, req *mainflux.ThingOwnerReq) (*empty.Empty, error) {
+	_, res, err := gs.isThingOwner.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*empty.Empty), nil
+}
+
 func (gs *grpcServer) Identify(ctx context.Context, req *mainflux.Token) (*mainflux.ThingID, error) {
 	_, res, err := gs.identify.ServeGRPC(ctx, req)
 	if err != nil {
@@ -89,9 +122,36 @@ func (gs *grpcServer) GetGroupsByIDs(ctx context.Context, req *mainflux.GroupsRe
 	return res.(*mainflux.GroupsRes), nil
 }
 
-func decodeGetConnByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
-	req := grpcReq.(*mainflux.ConnByKeyReq)
-	return connByKeyReq{key: req.GetKey()}, nil
+func (gs *grpcServer) GetChannelSchema(ctx context.Context, req *mainflux.ChannelID) (*mainflux.ChannelSchema, error) {
+	_, res, err := gs.getChannelSchema.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.ChannelSchema), nil
+}
+
+func (gs *grpcServer) GetOSCORESecret(ctx context.Context, req *mainflux.ThingID) (*mainflux.Secret, error) {
+	_, res, err := gs.getOSCORESecret.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.Secret), nil
+}
+
+func (gs *grpcServer) CanReadChannel(ctx context.Context, req *mainflux.ChannelReadReq) (*empty.Empty, error) {
+	_, res, err := gs.canReadChannel.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*empty.Empty), nil
+}
+
+func decodeCanAccessByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.AccessByKeyReq)
+	return accessByKeyReq{key: req.GetToken(), chanID: req.GetChanID(), action: req.GetAction()}, nil
 }
 
 func decodeIsChannelOwnerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -99,6 +159,11 @@ func decodeIsChannelOwnerRequest(_ context.Context, grpcReq interface{}) (interf
 	return channelOwnerReq{owner: req.GetOwner(), chanID: req.GetChanID()}, nil
 }
 
+func decodeIsThingOwnerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ThingOwnerReq)
+	return thingOwnerReq{owner: req.GetOwner(), thingID: req.GetThingID()}, nil
+}
+
 func decodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.Token)
 	return identifyReq{key: req.GetValue()}, nil
@@ -109,16 +174,26 @@ func decodeGetGroupsByIDsRequest(_ context.Context, grpcReq interface{}) (interf
 	return getGroupsByIDsReq{ids: req.GetIds()}, nil
 }
 
+func decodeGetChannelSchemaRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ChannelID)
+	return channelSchemaReq{chanID: req.GetValue()}, nil
+}
+
+func decodeGetOSCORESecretRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ThingID)
+	return oscoreSecretReq{thingID: req.GetValue()}, nil
+}
+
+func decodeCanReadChannelRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ChannelReadReq)
+	return channelReadReq{token: req.GetToken(), chanID: req.GetChanID(), permission: req.GetPermission()}, nil
+}
+
 func encodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(identityRes)
 	return &mainflux.ThingID{Value: res.id}, nil
 }
 
-func encodeGetConnByKeyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
-	res := grpcRes.(connByKeyRes)
-	return &mainflux.ConnByKeyRes{ChannelID: res.channelOD, ThingID: res.thingID}, nil
-}
-
 func encodeEmptyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(emptyRes)
 	return &empty.Empty{}, encodeError(res.err)
@@ -129,15 +204,27 @@ func encodeGetGroupsByIDsResponse(_ context.Context, grpcRes interface{}) (inter
 	return &mainflux.GroupsRes{Groups: res.groups}, nil
 }
 
+func encodeGetChannelSchemaResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(channelSchemaRes)
+	return &mainflux.ChannelSchema{Value: res.schema}, nil
+}
+
+func encodeGetOSCORESecretResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(oscoreSecretRes)
+	return &mainflux.Secret{Value: res.secret}, nil
+}
+
 func encodeError(err error) error {
 	switch {
 	case err == nil:
 		return nil
 	case errors.Contains(err, apiutil.ErrMalformedEntity),
 		err == apiutil.ErrMissingID,
-		err == apiutil.ErrBearerKey:
+		err == apiutil.ErrBearerKey,
+		err == apiutil.ErrInvalidAction:
 		return status.Error(codes.InvalidArgument, err.Error())
-	case errors.Contains(err, errors.ErrAuthentication):
+	case errors.Contains(err, errors.ErrAuthentication),
+		err == apiutil.ErrBearerToken:
 		return status.Error(codes.Unauthenticated, err.Error())
 	case errors.Contains(err, errors.ErrAuthorization):
 		return status.Error(codes.PermissionDenied, err.Error())
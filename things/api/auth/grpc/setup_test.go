@@ -50,11 +50,13 @@ func newService(tokens map[string]string) things.Service {
 	auth := mocks.NewAuthService("", usersList)
 	conns := make(chan thmocks.Connection)
 	thingsRepo := thmocks.NewThingRepository(conns)
+	thingKeysRepo := thmocks.NewThingKeyRepository()
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
 	groupsRepo := thmocks.NewGroupRepository()
+	profilesRepo := thmocks.NewProfileRepository()
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, thingKeysRepo, channelsRepo, groupsRepo, profilesRepo, chanCache, thingCache, idProvider)
 }
@@ -30,21 +30,20 @@ func identifyEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
-func getConnByKeyEndpoint(svc things.Service) endpoint.Endpoint {
+func canAccessByKeyEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(getConnByKeyReq)
+		req := request.(accessByKeyReq)
 		if err := req.validate(); err != nil {
 			return nil, err
 		}
 
-		conn, err := svc.GetConnByKey(ctx, req.Key)
+		id, err := svc.CanAccessByKey(ctx, req.chanID, req.Key, req.Action)
 		if err != nil {
 			return nil, err
 		}
 
-		res := connByKeyRes{
-			ChannelID: conn.ChannelID,
-			ThingID:   conn.ThingID,
+		res := identityRes{
+			ID: id,
 		}
 
 		return res, nil
@@ -74,13 +74,15 @@ func newService(tokens map[string]string) things.Service {
 	auth := mocks.NewAuthService("", usersList)
 	conns := make(chan thmocks.Connection)
 	thingsRepo := thmocks.NewThingRepository(conns)
+	thingKeysRepo := thmocks.NewThingKeyRepository()
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
 	groupsRepo := thmocks.NewGroupRepository()
+	profilesRepo := thmocks.NewProfileRepository()
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, thingKeysRepo, channelsRepo, groupsRepo, profilesRepo, chanCache, thingCache, idProvider)
 }
 
 func newServer(svc things.Service) *httptest.Server {
@@ -148,7 +150,7 @@ func TestIdentify(t *testing.T) {
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
 	}
 }
-func TestGetConnByThingKey(t *testing.T) {
+func TestCanAccessByKey(t *testing.T) {
 	svc := newService(map[string]string{token: email})
 	ts := newServer(svc)
 	defer ts.Close()
@@ -171,39 +173,71 @@ func TestGetConnByThingKey(t *testing.T) {
 	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
+	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID}, things.ConnTypeSubscribe)
 	require.Nil(t, err, fmt.Sprintf("failed to connect thing and channel: %s", err))
 
-	data := toJSON(getConnByKeyReq{
+	data := toJSON(accessByKeyReq{
+		Key:    th.Key,
+		Action: things.SubscribeAction,
+	})
+	pubData := toJSON(accessByKeyReq{
 		Key: th.Key,
 	})
+	invalidData := toJSON(accessByKeyReq{
+		Key:    th.Key,
+		Action: wrong,
+	})
 
 	cases := map[string]struct {
+		chanID      string
 		contentType string
 		req         string
 		status      int
 	}{
 		"check access for connected thing and channel": {
+			chanID:      ch.ID,
 			contentType: contentType,
 			req:         data,
 			status:      http.StatusOK,
 		},
+		"check publish access for subscribe-only connection": {
+			chanID:      ch.ID,
+			contentType: contentType,
+			req:         pubData,
+			status:      http.StatusForbidden,
+		},
+		"check access with invalid action": {
+			chanID:      ch.ID,
+			contentType: contentType,
+			req:         invalidData,
+			status:      http.StatusBadRequest,
+		},
+		"check access to non-connected channel": {
+			chanID:      wrong,
+			contentType: contentType,
+			req:         data,
+			status:      http.StatusForbidden,
+		},
 		"check access with invalid content type": {
+			chanID:      ch.ID,
 			contentType: wrong,
 			req:         data,
 			status:      http.StatusUnsupportedMediaType,
 		},
 		"check access with empty JSON request": {
+			chanID:      ch.ID,
 			contentType: contentType,
 			req:         "{}",
 			status:      http.StatusUnauthorized,
 		},
 		"check access with invalid JSON request": {
+			chanID:      ch.ID,
 			contentType: contentType,
 			req:         "}",
 			status:      http.StatusBadRequest,
 		},
 		"check access with empty request": {
+			chanID:      ch.ID,
 			contentType: contentType,
 			req:         "",
 			status:      http.StatusBadRequest,
@@ -214,7 +248,7 @@ func TestGetConnByThingKey(t *testing.T) {
 		req := testRequest{
 			client:      ts.Client(),
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/identify/channels/%s/access-by-key", ts.URL, ""),
+			url:         fmt.Sprintf("%s/identify/channels/%s/access-by-key", ts.URL, tc.chanID),
 			contentType: tc.contentType,
 			body:        strings.NewReader(tc.req),
 		}
@@ -228,6 +262,7 @@ type identifyReq struct {
 	Token string `json:"token"`
 }
 
-type getConnByKeyReq struct {
-	Key string `json:"key"`
+type accessByKeyReq struct {
+	Key    string `json:"key"`
+	Action string `json:"action,omitempty"`
 }
@@ -3,7 +3,10 @@
 
 package http
 
-import "github.com/MainfluxLabs/mainflux/internal/apiutil"
+import (
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/things"
+)
 
 type identifyReq struct {
 	Token string `json:"token"`
@@ -17,15 +20,24 @@ func (req identifyReq) validate() error {
 	return nil
 }
 
-type getConnByKeyReq struct {
+type accessByKeyReq struct {
 	chanID string
 	Key    string `json:"key"`
+	Action string `json:"action,omitempty"`
 }
 
-func (req getConnByKeyReq) validate() error {
+func (req accessByKeyReq) validate() error {
 	if req.Key == "" {
 		return apiutil.ErrBearerKey
 	}
 
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Action != things.PublishAction && req.Action != things.SubscribeAction {
+		return apiutil.ErrInvalidAction
+	}
+
 	return nil
 }
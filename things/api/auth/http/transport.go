@@ -38,8 +38,8 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger logger.Lo
 	))
 
 	r.Post("/identify/channels/:chanId/access-by-key", kithttp.NewServer(
-		kitot.TraceServer(tracer, "get_conn_by_key")(getConnByKeyEndpoint(svc)),
-		decodeGetConnByKey,
+		kitot.TraceServer(tracer, "can_access_by_key")(canAccessByKeyEndpoint(svc)),
+		decodeCanAccessByKey,
 		encodeResponse,
 		opts...,
 	))
@@ -60,18 +60,22 @@ func decodeIdentify(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
-func decodeGetConnByKey(_ context.Context, r *http.Request) (interface{}, error) {
+func decodeCanAccessByKey(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, apiutil.ErrUnsupportedContentType
 	}
 
-	req := getConnByKeyReq{
+	req := accessByKeyReq{
 		chanID: bone.GetValue(r, "chanId"),
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
 	}
 
+	if req.Action == "" {
+		req.Action = things.PublishAction
+	}
+
 	return req, nil
 }
 
@@ -104,7 +108,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	case errors.Contains(err, apiutil.ErrMalformedEntity),
-		errors.Contains(err, apiutil.ErrMissingID):
+		errors.Contains(err, apiutil.ErrMissingID),
+		errors.Contains(err, apiutil.ErrInvalidAction):
 		w.WriteHeader(http.StatusBadRequest)
 	default:
 		w.WriteHeader(http.StatusInternalServerError)
@@ -11,6 +11,9 @@ import (
 	"time"
 
 	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things"
 )
 
@@ -65,6 +68,71 @@ func (lm *loggingMiddleware) UpdateKey(ctx context.Context, token, id, key strin
 	return lm.svc.UpdateKey(ctx, token, id, key)
 }
 
+func (lm *loggingMiddleware) RotateKey(ctx context.Context, token, id, key string, grace time.Duration) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method rotate_key for thing %s with grace period %s took %s to complete", id, grace, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RotateKey(ctx, token, id, key, grace)
+}
+
+func (lm *loggingMiddleware) CreateThingKey(ctx context.Context, token, thingID string, key things.ThingKey) (saved things.ThingKey, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_thing_key for thing %s and key %s took %s to complete", thingID, saved.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateThingKey(ctx, token, thingID, key)
+}
+
+func (lm *loggingMiddleware) ListThingKeys(ctx context.Context, token, thingID string, pm things.PageMetadata) (_ things.ThingKeysPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_thing_keys for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListThingKeys(ctx, token, thingID, pm)
+}
+
+func (lm *loggingMiddleware) UpdateThingKey(ctx context.Context, token, thingID string, key things.ThingKey) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_thing_key for thing %s and key %s took %s to complete", thingID, key.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateThingKey(ctx, token, thingID, key)
+}
+
+func (lm *loggingMiddleware) RemoveThingKey(ctx context.Context, token, thingID, keyID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_thing_key for thing %s and key %s took %s to complete", thingID, keyID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveThingKey(ctx, token, thingID, keyID)
+}
+
 func (lm *loggingMiddleware) ViewThing(ctx context.Context, token, id string) (thing things.Thing, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method view_thing for token %s and thing %s took %s to complete", token, id, time.Since(begin))
@@ -133,6 +201,58 @@ func (lm *loggingMiddleware) RemoveThings(ctx context.Context, token string, ids
 	return lm.svc.RemoveThings(ctx, token, ids...)
 }
 
+func (lm *loggingMiddleware) ListDeletedThings(ctx context.Context, token string, pm things.PageMetadata) (page things.DeletedThingsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_deleted_things for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListDeletedThings(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) RestoreThings(ctx context.Context, token string, ids ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method restore_things for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RestoreThings(ctx, token, ids...)
+}
+
+func (lm *loggingMiddleware) AddThingTags(ctx context.Context, token string, tags []string, ids ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_thing_tags for token %s, tags %s and ids %s took %s to complete", token, tags, ids, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AddThingTags(ctx, token, tags, ids...)
+}
+
+func (lm *loggingMiddleware) RemoveThingTags(ctx context.Context, token string, tags []string, ids ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_thing_tags for token %s, tags %s and ids %s took %s to complete", token, tags, ids, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveThingTags(ctx, token, tags, ids...)
+}
+
 func (lm *loggingMiddleware) CreateChannels(ctx context.Context, token string, channels ...things.Channel) (saved []things.Channel, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method create_channels for token %s and channels %s took %s to complete", token, saved, time.Since(begin))
@@ -214,9 +334,139 @@ func (lm *loggingMiddleware) RemoveChannels(ctx context.Context, token string, i
 	return lm.svc.RemoveChannels(ctx, token, ids...)
 }
 
-func (lm *loggingMiddleware) Connect(ctx context.Context, token, chID string, thIDs []string) (err error) {
+func (lm *loggingMiddleware) ListDeletedChannels(ctx context.Context, token string, pm things.PageMetadata) (page things.DeletedChannelsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_deleted_channels for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListDeletedChannels(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) RestoreChannels(ctx context.Context, token string, ids ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method restore_channels for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RestoreChannels(ctx, token, ids...)
+}
+
+func (lm *loggingMiddleware) AddChannelTags(ctx context.Context, token string, tags []string, ids ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_channel_tags for token %s, tags %s and ids %s took %s to complete", token, tags, ids, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AddChannelTags(ctx, token, tags, ids...)
+}
+
+func (lm *loggingMiddleware) RemoveChannelTags(ctx context.Context, token string, tags []string, ids ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_channel_tags for token %s, tags %s and ids %s took %s to complete", token, tags, ids, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveChannelTags(ctx, token, tags, ids...)
+}
+
+func (lm *loggingMiddleware) CreateProfiles(ctx context.Context, token string, profiles ...things.Profile) (saved []things.Profile, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method create_profiles for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CreateProfiles(ctx, token, profiles...)
+}
+
+func (lm *loggingMiddleware) UpdateProfile(ctx context.Context, token string, profile things.Profile) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method update_profile for token %s and profile %s took %s to complete", token, profile.ID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UpdateProfile(ctx, token, profile)
+}
+
+func (lm *loggingMiddleware) ViewProfile(ctx context.Context, token, id string) (profile things.Profile, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_profile for token %s and profile %s took %s to complete", token, id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewProfile(ctx, token, id)
+}
+
+func (lm *loggingMiddleware) ListProfiles(ctx context.Context, token string, pm things.PageMetadata) (_ things.ProfilesPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_profiles for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListProfiles(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) ListThingsByProfile(ctx context.Context, token, profileID string, pm things.PageMetadata) (_ things.Page, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_things_by_profile for token %s and profile %s took %s to complete", token, profileID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListThingsByProfile(ctx, token, profileID, pm)
+}
+
+func (lm *loggingMiddleware) RemoveProfiles(ctx context.Context, token string, ids ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_profiles for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveProfiles(ctx, token, ids...)
+}
+
+func (lm *loggingMiddleware) Connect(ctx context.Context, token, chID string, thIDs []string, connType string) (err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method connect for token %s, channel %s and things %s took %s to complete", token, chID, thIDs, time.Since(begin))
+		message := fmt.Sprintf("Method connect for token %s, channel %s, things %s and type %s took %s to complete", token, chID, thIDs, connType, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -224,7 +474,7 @@ func (lm *loggingMiddleware) Connect(ctx context.Context, token, chID string, th
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.Connect(ctx, token, chID, thIDs)
+	return lm.svc.Connect(ctx, token, chID, thIDs, connType)
 }
 
 func (lm *loggingMiddleware) Disconnect(ctx context.Context, token, chID string, thIDs []string) (err error) {
@@ -240,9 +490,9 @@ func (lm *loggingMiddleware) Disconnect(ctx context.Context, token, chID string,
 	return lm.svc.Disconnect(ctx, token, chID, thIDs)
 }
 
-func (lm *loggingMiddleware) GetConnByKey(ctx context.Context, key string) (conn things.Connection, err error) {
+func (lm *loggingMiddleware) CanAccessByKey(ctx context.Context, chanID, key, action string) (id string, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method can_access for thing %s took %s to complete", conn.ThingID, time.Since(begin))
+		message := fmt.Sprintf("Method can_access_by_key for channel %s, thing %s and action %s took %s to complete", chanID, id, action, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -250,7 +500,7 @@ func (lm *loggingMiddleware) GetConnByKey(ctx context.Context, key string) (conn
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.GetConnByKey(ctx, key)
+	return lm.svc.CanAccessByKey(ctx, chanID, key, action)
 }
 
 func (lm *loggingMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) (err error) {
@@ -266,6 +516,32 @@ func (lm *loggingMiddleware) IsChannelOwner(ctx context.Context, owner, chanID s
 	return lm.svc.IsChannelOwner(ctx, owner, chanID)
 }
 
+func (lm *loggingMiddleware) IsThingOwner(ctx context.Context, owner, thingID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method is_thing_owner for thing %s and user %s took %s to complete", thingID, owner, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.IsThingOwner(ctx, owner, thingID)
+}
+
+func (lm *loggingMiddleware) CanReadChannel(ctx context.Context, token, chanID, permission string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method can_read_channel for channel %s and permission %s took %s to complete", chanID, permission, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CanReadChannel(ctx, token, chanID, permission)
+}
+
 func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id string, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method identify for token %s and thing %s took %s to complete", key, id, time.Since(begin))
@@ -279,6 +555,32 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id strin
 	return lm.svc.Identify(ctx, key)
 }
 
+func (lm *loggingMiddleware) GetChannelSchema(ctx context.Context, chanID string) (s schema.Schema, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method get_channel_schema for channel %s took %s to complete", chanID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GetChannelSchema(ctx, chanID)
+}
+
+func (lm *loggingMiddleware) GetOSCORESecret(ctx context.Context, thingID string) (secret []byte, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method get_oscore_secret for thing %s took %s to complete", thingID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.GetOSCORESecret(ctx, thingID)
+}
+
 func (lm *loggingMiddleware) Backup(ctx context.Context, token string) (bk things.Backup, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method backup for token %s took %s to complete", token, time.Since(begin))
@@ -305,6 +607,45 @@ func (lm *loggingMiddleware) Restore(ctx context.Context, token string, backup t
 	return lm.svc.Restore(ctx, token, backup)
 }
 
+func (lm *loggingMiddleware) ExportBackup(ctx context.Context, token string, f things.BackupFilter) (stream backup.Stream, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method export_backup for token %s and group %s took %s to complete", token, f.GroupID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ExportBackup(ctx, token, f)
+}
+
+func (lm *loggingMiddleware) ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (summary backup.Summary, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method import_backup for token %s with strategy %s took %s to complete", token, strategy, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ImportBackup(ctx, token, dec, strategy)
+}
+
+func (lm *loggingMiddleware) ViewUsage(ctx context.Context, token, orgID string) (usage map[string]quota.Usage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method view_usage for token %s and org %s took %s to complete", token, orgID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ViewUsage(ctx, token, orgID)
+}
+
 func (lm *loggingMiddleware) CreateGroups(ctx context.Context, token string, grs ...things.Group) (saved []things.Group, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method create_groups for token %s took %s to complete", token, time.Since(begin))
@@ -370,6 +711,32 @@ func (lm *loggingMiddleware) ListGroupsByIDs(ctx context.Context, groupIDs []str
 	return lm.svc.ListGroupsByIDs(ctx, groupIDs)
 }
 
+func (lm *loggingMiddleware) ListGroupChildren(ctx context.Context, token, groupID string, depth uint64, pm things.PageMetadata) (gp things.GroupPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_group_children for token %s and group id %s took %s to complete", token, groupID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListGroupChildren(ctx, token, groupID, depth, pm)
+}
+
+func (lm *loggingMiddleware) MoveGroup(ctx context.Context, token, groupID, parentID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method move_group for token %s, group id %s and parent id %s took %s to complete", token, groupID, parentID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.MoveGroup(ctx, token, groupID, parentID)
+}
+
 func (lm *loggingMiddleware) ListGroupThings(ctx context.Context, token, groupID string, pm things.PageMetadata) (mp things.GroupThingsPage, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method list_group_things for token %s and group id %s took %s to complete", token, groupID, time.Since(begin))
@@ -422,6 +789,71 @@ func (lm *loggingMiddleware) RemoveGroups(ctx context.Context, token string, ids
 	return lm.svc.RemoveGroups(ctx, token, ids...)
 }
 
+func (lm *loggingMiddleware) ListDeletedGroups(ctx context.Context, token string, pm things.PageMetadata) (page things.DeletedGroupsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_deleted_groups for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListDeletedGroups(ctx, token, pm)
+}
+
+func (lm *loggingMiddleware) RestoreGroups(ctx context.Context, token string, ids ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method restore_groups for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RestoreGroups(ctx, token, ids...)
+}
+
+func (lm *loggingMiddleware) AddGroupTags(ctx context.Context, token string, tags []string, ids ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_group_tags for token %s, tags %s and ids %s took %s to complete", token, tags, ids, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AddGroupTags(ctx, token, tags, ids...)
+}
+
+func (lm *loggingMiddleware) RemoveGroupTags(ctx context.Context, token string, tags []string, ids ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method remove_group_tags for token %s, tags %s and ids %s took %s to complete", token, tags, ids, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RemoveGroupTags(ctx, token, tags, ids...)
+}
+
+func (lm *loggingMiddleware) PurgeTrash(ctx context.Context, before time.Time) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method purge_trash for items removed before %s took %s to complete", before, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.PurgeTrash(ctx, before)
+}
+
 func (lm *loggingMiddleware) AssignThing(ctx context.Context, token, groupID string, thingIDs ...string) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method assign_thing for token %s took %s to complete", token, time.Since(begin))
@@ -9,6 +9,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/go-kit/kit/metrics"
 )
@@ -57,6 +60,51 @@ func (ms *metricsMiddleware) UpdateKey(ctx context.Context, token, id, key strin
 	return ms.svc.UpdateKey(ctx, token, id, key)
 }
 
+func (ms *metricsMiddleware) RotateKey(ctx context.Context, token, id, key string, grace time.Duration) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "rotate_key").Add(1)
+		ms.latency.With("method", "rotate_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RotateKey(ctx, token, id, key, grace)
+}
+
+func (ms *metricsMiddleware) CreateThingKey(ctx context.Context, token, thingID string, key things.ThingKey) (things.ThingKey, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_thing_key").Add(1)
+		ms.latency.With("method", "create_thing_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateThingKey(ctx, token, thingID, key)
+}
+
+func (ms *metricsMiddleware) ListThingKeys(ctx context.Context, token, thingID string, pm things.PageMetadata) (things.ThingKeysPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_thing_keys").Add(1)
+		ms.latency.With("method", "list_thing_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListThingKeys(ctx, token, thingID, pm)
+}
+
+func (ms *metricsMiddleware) UpdateThingKey(ctx context.Context, token, thingID string, key things.ThingKey) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_thing_key").Add(1)
+		ms.latency.With("method", "update_thing_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateThingKey(ctx, token, thingID, key)
+}
+
+func (ms *metricsMiddleware) RemoveThingKey(ctx context.Context, token, thingID, keyID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_thing_key").Add(1)
+		ms.latency.With("method", "remove_thing_key").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveThingKey(ctx, token, thingID, keyID)
+}
+
 func (ms *metricsMiddleware) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_thing").Add(1)
@@ -102,6 +150,42 @@ func (ms *metricsMiddleware) RemoveThings(ctx context.Context, token string, id
 	return ms.svc.RemoveThings(ctx, token, id...)
 }
 
+func (ms *metricsMiddleware) ListDeletedThings(ctx context.Context, token string, pm things.PageMetadata) (things.DeletedThingsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_deleted_things").Add(1)
+		ms.latency.With("method", "list_deleted_things").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListDeletedThings(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) RestoreThings(ctx context.Context, token string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "restore_things").Add(1)
+		ms.latency.With("method", "restore_things").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RestoreThings(ctx, token, ids...)
+}
+
+func (ms *metricsMiddleware) AddThingTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_thing_tags").Add(1)
+		ms.latency.With("method", "add_thing_tags").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AddThingTags(ctx, token, tags, ids...)
+}
+
+func (ms *metricsMiddleware) RemoveThingTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_thing_tags").Add(1)
+		ms.latency.With("method", "remove_thing_tags").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveThingTags(ctx, token, tags, ids...)
+}
+
 func (ms *metricsMiddleware) CreateChannels(ctx context.Context, token string, channels ...things.Channel) (saved []things.Channel, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "create_channels").Add(1)
@@ -156,13 +240,103 @@ func (ms *metricsMiddleware) RemoveChannels(ctx context.Context, token string, i
 	return ms.svc.RemoveChannels(ctx, token, ids...)
 }
 
-func (ms *metricsMiddleware) Connect(ctx context.Context, token, chID string, thIDs []string) error {
+func (ms *metricsMiddleware) ListDeletedChannels(ctx context.Context, token string, pm things.PageMetadata) (things.DeletedChannelsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_deleted_channels").Add(1)
+		ms.latency.With("method", "list_deleted_channels").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListDeletedChannels(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) RestoreChannels(ctx context.Context, token string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "restore_channels").Add(1)
+		ms.latency.With("method", "restore_channels").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RestoreChannels(ctx, token, ids...)
+}
+
+func (ms *metricsMiddleware) AddChannelTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_channel_tags").Add(1)
+		ms.latency.With("method", "add_channel_tags").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AddChannelTags(ctx, token, tags, ids...)
+}
+
+func (ms *metricsMiddleware) RemoveChannelTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_channel_tags").Add(1)
+		ms.latency.With("method", "remove_channel_tags").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveChannelTags(ctx, token, tags, ids...)
+}
+
+func (ms *metricsMiddleware) CreateProfiles(ctx context.Context, token string, profiles ...things.Profile) ([]things.Profile, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_profiles").Add(1)
+		ms.latency.With("method", "create_profiles").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateProfiles(ctx, token, profiles...)
+}
+
+func (ms *metricsMiddleware) UpdateProfile(ctx context.Context, token string, profile things.Profile) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_profile").Add(1)
+		ms.latency.With("method", "update_profile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateProfile(ctx, token, profile)
+}
+
+func (ms *metricsMiddleware) ViewProfile(ctx context.Context, token, id string) (things.Profile, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_profile").Add(1)
+		ms.latency.With("method", "view_profile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewProfile(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) ListProfiles(ctx context.Context, token string, pm things.PageMetadata) (things.ProfilesPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_profiles").Add(1)
+		ms.latency.With("method", "list_profiles").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListProfiles(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) ListThingsByProfile(ctx context.Context, token, profileID string, pm things.PageMetadata) (things.Page, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_things_by_profile").Add(1)
+		ms.latency.With("method", "list_things_by_profile").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListThingsByProfile(ctx, token, profileID, pm)
+}
+
+func (ms *metricsMiddleware) RemoveProfiles(ctx context.Context, token string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_profiles").Add(1)
+		ms.latency.With("method", "remove_profiles").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveProfiles(ctx, token, ids...)
+}
+
+func (ms *metricsMiddleware) Connect(ctx context.Context, token, chID string, thIDs []string, connType string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "connect").Add(1)
 		ms.latency.With("method", "connect").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.Connect(ctx, token, chID, thIDs)
+	return ms.svc.Connect(ctx, token, chID, thIDs, connType)
 }
 
 func (ms *metricsMiddleware) Disconnect(ctx context.Context, token, chID string, thIDs []string) error {
@@ -174,13 +348,13 @@ func (ms *metricsMiddleware) Disconnect(ctx context.Context, token, chID string,
 	return ms.svc.Disconnect(ctx, token, chID, thIDs)
 }
 
-func (ms *metricsMiddleware) GetConnByKey(ctx context.Context, key string) (things.Connection, error) {
+func (ms *metricsMiddleware) CanAccessByKey(ctx context.Context, chanID, key, action string) (string, error) {
 	defer func(begin time.Time) {
-		ms.counter.With("method", "get_conn_by_key").Add(1)
-		ms.latency.With("method", "get_conn_by_key").Observe(time.Since(begin).Seconds())
+		ms.counter.With("method", "can_access_by_key").Add(1)
+		ms.latency.With("method", "can_access_by_key").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.GetConnByKey(ctx, key)
+	return ms.svc.CanAccessByKey(ctx, chanID, key, action)
 }
 
 func (ms *metricsMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) error {
@@ -192,6 +366,24 @@ func (ms *metricsMiddleware) IsChannelOwner(ctx context.Context, owner, chanID s
 	return ms.svc.IsChannelOwner(ctx, owner, chanID)
 }
 
+func (ms *metricsMiddleware) IsThingOwner(ctx context.Context, owner, thingID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "is_thing_owner").Add(1)
+		ms.latency.With("method", "is_thing_owner").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.IsThingOwner(ctx, owner, thingID)
+}
+
+func (ms *metricsMiddleware) CanReadChannel(ctx context.Context, token, chanID, permission string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "can_read_channel").Add(1)
+		ms.latency.With("method", "can_read_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CanReadChannel(ctx, token, chanID, permission)
+}
+
 func (ms *metricsMiddleware) Identify(ctx context.Context, key string) (string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "identify").Add(1)
@@ -201,6 +393,24 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, key string) (string,
 	return ms.svc.Identify(ctx, key)
 }
 
+func (ms *metricsMiddleware) GetChannelSchema(ctx context.Context, chanID string) (schema.Schema, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "get_channel_schema").Add(1)
+		ms.latency.With("method", "get_channel_schema").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.GetChannelSchema(ctx, chanID)
+}
+
+func (ms *metricsMiddleware) GetOSCORESecret(ctx context.Context, thingID string) ([]byte, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "get_oscore_secret").Add(1)
+		ms.latency.With("method", "get_oscore_secret").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.GetOSCORESecret(ctx, thingID)
+}
+
 func (ms *metricsMiddleware) Backup(ctx context.Context, token string) (bk things.Backup, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "backup").Add(1)
@@ -219,6 +429,33 @@ func (ms *metricsMiddleware) Restore(ctx context.Context, token string, backup t
 	return ms.svc.Restore(ctx, token, backup)
 }
 
+func (ms *metricsMiddleware) ExportBackup(ctx context.Context, token string, f things.BackupFilter) (backup.Stream, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "export_backup").Add(1)
+		ms.latency.With("method", "export_backup").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ExportBackup(ctx, token, f)
+}
+
+func (ms *metricsMiddleware) ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (backup.Summary, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "import_backup").Add(1)
+		ms.latency.With("method", "import_backup").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ImportBackup(ctx, token, dec, strategy)
+}
+
+func (ms *metricsMiddleware) ViewUsage(ctx context.Context, token, orgID string) (map[string]quota.Usage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_usage").Add(1)
+		ms.latency.With("method", "view_usage").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewUsage(ctx, token, orgID)
+}
+
 func (ms *metricsMiddleware) CreateGroups(ctx context.Context, token string, grs ...things.Group) ([]things.Group, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "create_groups").Add(1)
@@ -264,6 +501,24 @@ func (ms *metricsMiddleware) ListGroupsByIDs(ctx context.Context, groupIDs []str
 	return ms.svc.ListGroupsByIDs(ctx, groupIDs)
 }
 
+func (ms *metricsMiddleware) ListGroupChildren(ctx context.Context, token, groupID string, depth uint64, pm things.PageMetadata) (things.GroupPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_group_children").Add(1)
+		ms.latency.With("method", "list_group_children").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListGroupChildren(ctx, token, groupID, depth, pm)
+}
+
+func (ms *metricsMiddleware) MoveGroup(ctx context.Context, token, groupID, parentID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "move_group").Add(1)
+		ms.latency.With("method", "move_group").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.MoveGroup(ctx, token, groupID, parentID)
+}
+
 func (ms *metricsMiddleware) ListGroupThings(ctx context.Context, token, groupID string, pm things.PageMetadata) (tp things.GroupThingsPage, err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "list_group_things").Add(1)
@@ -300,6 +555,51 @@ func (ms *metricsMiddleware) RemoveGroups(ctx context.Context, token string, ids
 	return ms.svc.RemoveGroups(ctx, token, ids...)
 }
 
+func (ms *metricsMiddleware) ListDeletedGroups(ctx context.Context, token string, pm things.PageMetadata) (things.DeletedGroupsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_deleted_groups").Add(1)
+		ms.latency.With("method", "list_deleted_groups").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListDeletedGroups(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) RestoreGroups(ctx context.Context, token string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "restore_groups").Add(1)
+		ms.latency.With("method", "restore_groups").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RestoreGroups(ctx, token, ids...)
+}
+
+func (ms *metricsMiddleware) AddGroupTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "add_group_tags").Add(1)
+		ms.latency.With("method", "add_group_tags").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.AddGroupTags(ctx, token, tags, ids...)
+}
+
+func (ms *metricsMiddleware) RemoveGroupTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_group_tags").Add(1)
+		ms.latency.With("method", "remove_group_tags").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveGroupTags(ctx, token, tags, ids...)
+}
+
+func (ms *metricsMiddleware) PurgeTrash(ctx context.Context, before time.Time) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "purge_trash").Add(1)
+		ms.latency.With("method", "purge_trash").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.PurgeTrash(ctx, before)
+}
+
 func (ms *metricsMiddleware) AssignThing(ctx context.Context, token, groupID string, thingIDs ...string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "assign_thing").Add(1)
@@ -5,6 +5,7 @@ package http
 
 import (
 	"context"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/things"
@@ -22,10 +23,12 @@ func createThingsEndpoint(svc things.Service) endpoint.Endpoint {
 		ths := []things.Thing{}
 		for _, tReq := range req.Things {
 			th := things.Thing{
-				Name:     tReq.Name,
-				Key:      tReq.Key,
-				ID:       tReq.ID,
-				Metadata: tReq.Metadata,
+				Name:      tReq.Name,
+				Key:       tReq.Key,
+				ID:        tReq.ID,
+				Metadata:  tReq.Metadata,
+				ProfileID: tReq.ProfileID,
+				Tags:      tReq.Tags,
 			}
 			ths = append(ths, th)
 		}
@@ -42,10 +45,12 @@ func createThingsEndpoint(svc things.Service) endpoint.Endpoint {
 
 		for _, th := range saved {
 			tRes := thingRes{
-				ID:       th.ID,
-				Name:     th.Name,
-				Key:      th.Key,
-				Metadata: th.Metadata,
+				ID:        th.ID,
+				Name:      th.Name,
+				Key:       th.Key,
+				Metadata:  th.Metadata,
+				ProfileID: th.ProfileID,
+				Tags:      th.Tags,
 			}
 			res.Things = append(res.Things, tRes)
 		}
@@ -66,6 +71,7 @@ func updateThingEndpoint(svc things.Service) endpoint.Endpoint {
 			ID:       req.id,
 			Name:     req.Name,
 			Metadata: req.Metadata,
+			Tags:     req.Tags,
 		}
 
 		if err := svc.UpdateThing(ctx, req.token, thing); err != nil {
@@ -85,7 +91,8 @@ func updateKeyEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.UpdateKey(ctx, req.token, req.id, req.Key); err != nil {
+		grace := time.Duration(req.GracePeriod) * time.Second
+		if err := svc.RotateKey(ctx, req.token, req.id, req.Key, grace); err != nil {
 			return nil, err
 		}
 
@@ -94,6 +101,108 @@ func updateKeyEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func createThingKeyEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createThingKeyReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		key := things.ThingKey{
+			Name: req.Name,
+			Key:  req.Key,
+		}
+		if req.ExpiresAt != nil {
+			key.ExpiresAt = *req.ExpiresAt
+		}
+
+		saved, err := svc.CreateThingKey(ctx, req.token, req.thingID, key)
+		if err != nil {
+			return nil, err
+		}
+
+		res := buildThingKeyResponse(saved)
+		res.created = true
+		return res, nil
+	}
+}
+
+func listThingKeysEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listThingKeysReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		pm := things.PageMetadata{
+			Offset: req.offset,
+			Limit:  req.limit,
+		}
+		page, err := svc.ListThingKeys(ctx, req.token, req.thingID, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := thingKeysPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Keys: []thingKeyRes{},
+		}
+		for _, k := range page.Keys {
+			res.Keys = append(res.Keys, buildThingKeyResponse(k))
+		}
+
+		return res, nil
+	}
+}
+
+func updateThingKeyEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateThingKeyReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		key := things.ThingKey{
+			ID:      req.keyID,
+			Name:    req.Name,
+			Enabled: req.Enabled,
+		}
+		if req.ExpiresAt != nil {
+			key.ExpiresAt = *req.ExpiresAt
+		}
+
+		if err := svc.UpdateThingKey(ctx, req.token, req.thingID, key); err != nil {
+			return nil, err
+		}
+
+		res := thingRes{ID: req.thingID, created: false}
+		return res, nil
+	}
+}
+
+func removeThingKeyEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(thingKeyReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveThingKey(ctx, req.token, req.thingID, req.keyID); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
 func viewThingEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(viewResourceReq)
@@ -108,11 +217,13 @@ func viewThingEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 
 		res := viewThingRes{
-			ID:       thing.ID,
-			Owner:    thing.Owner,
-			Name:     thing.Name,
-			Key:      thing.Key,
-			Metadata: thing.Metadata,
+			ID:        thing.ID,
+			Owner:     thing.Owner,
+			Name:      thing.Name,
+			Key:       thing.Key,
+			Metadata:  thing.Metadata,
+			ProfileID: thing.ProfileID,
+			Tags:      thing.Tags,
 		}
 		return res, nil
 	}
@@ -143,11 +254,13 @@ func listThingsEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 		for _, thing := range page.Things {
 			view := viewThingRes{
-				ID:       thing.ID,
-				Owner:    thing.Owner,
-				Name:     thing.Name,
-				Key:      thing.Key,
-				Metadata: thing.Metadata,
+				ID:        thing.ID,
+				Owner:     thing.Owner,
+				Name:      thing.Name,
+				Key:       thing.Key,
+				Metadata:  thing.Metadata,
+				ProfileID: thing.ProfileID,
+				Tags:      thing.Tags,
 			}
 			res.Things = append(res.Things, view)
 		}
@@ -179,11 +292,13 @@ func listThingsByChannelEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 		for _, thing := range page.Things {
 			view := viewThingRes{
-				ID:       thing.ID,
-				Owner:    thing.Owner,
-				Key:      thing.Key,
-				Name:     thing.Name,
-				Metadata: thing.Metadata,
+				ID:        thing.ID,
+				Owner:     thing.Owner,
+				Key:       thing.Key,
+				Name:      thing.Name,
+				Metadata:  thing.Metadata,
+				ProfileID: thing.ProfileID,
+				Tags:      thing.Tags,
 			}
 			res.Things = append(res.Things, view)
 		}
@@ -241,9 +356,12 @@ func createChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 		chs := []things.Channel{}
 		for _, cReq := range req.Channels {
 			ch := things.Channel{
-				Metadata: cReq.Metadata,
-				Name:     cReq.Name,
-				ID:       cReq.ID,
+				Metadata:      cReq.Metadata,
+				Name:          cReq.Name,
+				ID:            cReq.ID,
+				ProfileID:     cReq.ProfileID,
+				Tags:          cReq.Tags,
+				PayloadSchema: cReq.PayloadSchema,
 			}
 			chs = append(chs, ch)
 		}
@@ -260,9 +378,12 @@ func createChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 
 		for _, ch := range saved {
 			cRes := channelRes{
-				ID:       ch.ID,
-				Name:     ch.Name,
-				Metadata: ch.Metadata,
+				ID:            ch.ID,
+				Name:          ch.Name,
+				Metadata:      ch.Metadata,
+				ProfileID:     ch.ProfileID,
+				Tags:          ch.Tags,
+				PayloadSchema: payloadSchemaRes(ch.PayloadSchema),
 			}
 			res.Channels = append(res.Channels, cRes)
 		}
@@ -280,9 +401,11 @@ func updateChannelEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 
 		channel := things.Channel{
-			ID:       req.id,
-			Name:     req.Name,
-			Metadata: req.Metadata,
+			ID:            req.id,
+			Name:          req.Name,
+			Metadata:      req.Metadata,
+			PayloadSchema: req.PayloadSchema,
+			Tags:          req.Tags,
 		}
 		if err := svc.UpdateChannel(ctx, req.token, channel); err != nil {
 			return nil, err
@@ -310,10 +433,13 @@ func viewChannelEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 
 		res := viewChannelRes{
-			ID:       channel.ID,
-			Owner:    channel.Owner,
-			Name:     channel.Name,
-			Metadata: channel.Metadata,
+			ID:            channel.ID,
+			Owner:         channel.Owner,
+			Name:          channel.Name,
+			Metadata:      channel.Metadata,
+			ProfileID:     channel.ProfileID,
+			Tags:          channel.Tags,
+			PayloadSchema: payloadSchemaRes(channel.PayloadSchema),
 		}
 
 		return res, nil
@@ -346,10 +472,13 @@ func listChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 		// Cast channels
 		for _, channel := range page.Channels {
 			view := viewChannelRes{
-				ID:       channel.ID,
-				Owner:    channel.Owner,
-				Name:     channel.Name,
-				Metadata: channel.Metadata,
+				ID:            channel.ID,
+				Owner:         channel.Owner,
+				Name:          channel.Name,
+				Metadata:      channel.Metadata,
+				ProfileID:     channel.ProfileID,
+				Tags:          channel.Tags,
+				PayloadSchema: payloadSchemaRes(channel.PayloadSchema),
 			}
 
 			res.Channels = append(res.Channels, view)
@@ -373,10 +502,13 @@ func viewChannelByThingEndpoint(svc things.Service) endpoint.Endpoint {
 		}
 
 		res := viewChannelRes{
-			ID:       ch.ID,
-			Owner:    ch.Owner,
-			Name:     ch.Name,
-			Metadata: ch.Metadata,
+			ID:            ch.ID,
+			Owner:         ch.Owner,
+			Name:          ch.Name,
+			Metadata:      ch.Metadata,
+			ProfileID:     ch.ProfileID,
+			Tags:          ch.Tags,
+			PayloadSchema: payloadSchemaRes(ch.PayloadSchema),
 		}
 
 		return res, nil
@@ -421,6 +553,192 @@ func removeChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func createProfilesEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createProfilesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		prs := []things.Profile{}
+		for _, pReq := range req.Profiles {
+			pr := things.Profile{
+				Name:        pReq.Name,
+				Description: pReq.Description,
+				Schema:      pReq.Schema,
+				Defaults:    pReq.Defaults,
+			}
+			prs = append(prs, pr)
+		}
+
+		saved, err := svc.CreateProfiles(ctx, req.token, prs...)
+		if err != nil {
+			return nil, err
+		}
+
+		res := profilesRes{
+			Profiles: []profileRes{},
+			created:  true,
+		}
+
+		for _, pr := range saved {
+			pRes := profileRes{
+				ID:          pr.ID,
+				Name:        pr.Name,
+				Description: pr.Description,
+				Schema:      pr.Schema,
+				Defaults:    pr.Defaults,
+			}
+			res.Profiles = append(res.Profiles, pRes)
+		}
+
+		return res, nil
+	}
+}
+
+func updateProfileEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateProfileReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		profile := things.Profile{
+			ID:          req.id,
+			Name:        req.Name,
+			Description: req.Description,
+			Schema:      req.Schema,
+			Defaults:    req.Defaults,
+		}
+
+		if err := svc.UpdateProfile(ctx, req.token, profile); err != nil {
+			return nil, err
+		}
+
+		res := profileRes{ID: req.id, created: false}
+		return res, nil
+	}
+}
+
+func viewProfileEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		profile, err := svc.ViewProfile(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildViewProfileResponse(profile), nil
+	}
+}
+
+func listProfilesEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listResourcesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListProfiles(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := profilesPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+				Order:  page.Order,
+				Dir:    page.Dir,
+			},
+			Profiles: []viewProfileRes{},
+		}
+		for _, pr := range page.Profiles {
+			res.Profiles = append(res.Profiles, buildViewProfileResponse(pr))
+		}
+
+		return res, nil
+	}
+}
+
+func listThingsByProfileEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listByConnectionReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListThingsByProfile(ctx, req.token, req.id, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := thingsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+				Order:  page.Order,
+				Dir:    page.Dir,
+			},
+			Things: []viewThingRes{},
+		}
+		for _, thing := range page.Things {
+			view := viewThingRes{
+				ID:        thing.ID,
+				Owner:     thing.Owner,
+				Name:      thing.Name,
+				Key:       thing.Key,
+				Metadata:  thing.Metadata,
+				ProfileID: thing.ProfileID,
+				Tags:      thing.Tags,
+			}
+			res.Things = append(res.Things, view)
+		}
+
+		return res, nil
+	}
+}
+
+func removeProfileEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewResourceReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveProfiles(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func buildViewProfileResponse(pr things.Profile) viewProfileRes {
+	return viewProfileRes{
+		ID:          pr.ID,
+		OwnerID:     pr.OwnerID,
+		Name:        pr.Name,
+		Description: pr.Description,
+		Schema:      pr.Schema,
+		Defaults:    pr.Defaults,
+		CreatedAt:   pr.CreatedAt,
+		UpdatedAt:   pr.UpdatedAt,
+	}
+}
+
 func connectEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		cr := request.(connectionsReq)
@@ -429,7 +747,7 @@ func connectEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		if err := svc.Connect(ctx, cr.token, cr.ChannelID, cr.ThingIDs); err != nil {
+		if err := svc.Connect(ctx, cr.token, cr.ChannelID, cr.ThingIDs, cr.Type); err != nil {
 			return nil, err
 		}
 
@@ -485,6 +803,43 @@ func restoreEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func exportBackupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportBackupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		f := things.BackupFilter{
+			GroupID: req.groupID,
+			Since:   req.since,
+		}
+
+		stream, err := svc.ExportBackup(ctx, req.token, f)
+		if err != nil {
+			return nil, err
+		}
+
+		return exportBackupRes{stream: stream}, nil
+	}
+}
+
+func importBackupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(importBackupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		summary, err := svc.ImportBackup(ctx, req.token, req.decoder, req.strategy)
+		if err != nil {
+			return nil, err
+		}
+
+		return importBackupRes(summary), nil
+	}
+}
+
 func createGroupsEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(createGroupsReq)
@@ -496,8 +851,10 @@ func createGroupsEndpoint(svc things.Service) endpoint.Endpoint {
 		for _, gReq := range req.Groups {
 			group := things.Group{
 				Name:        gReq.Name,
+				ParentID:    gReq.ParentID,
 				Description: gReq.Description,
 				Metadata:    gReq.Metadata,
+				Tags:        gReq.Tags,
 			}
 			grs = append(grs, group)
 		}
@@ -516,8 +873,10 @@ func createGroupsEndpoint(svc things.Service) endpoint.Endpoint {
 			gRes := groupRes{
 				ID:          gr.ID,
 				Name:        gr.Name,
+				ParentID:    gr.ParentID,
 				Description: gr.Description,
 				Metadata:    gr.Metadata,
+				Tags:        gr.Tags,
 			}
 			res.Groups = append(res.Groups, gRes)
 		}
@@ -543,7 +902,10 @@ func viewGroupEndpoint(svc things.Service) endpoint.Endpoint {
 			Name:        group.Name,
 			Description: group.Description,
 			Metadata:    group.Metadata,
+			Tags:        group.Tags,
 			OwnerID:     group.OwnerID,
+			ParentID:    group.ParentID,
+			Path:        group.Path,
 			CreatedAt:   group.CreatedAt,
 			UpdatedAt:   group.UpdatedAt,
 		}
@@ -564,6 +926,7 @@ func updateGroupEndpoint(svc things.Service) endpoint.Endpoint {
 			Name:        req.Name,
 			Description: req.Description,
 			Metadata:    req.Metadata,
+			Tags:        req.Tags,
 		}
 
 		_, err := svc.UpdateGroup(ctx, req.token, group)
@@ -576,6 +939,37 @@ func updateGroupEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func moveGroupEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(moveGroupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.MoveGroup(ctx, req.token, req.id, req.ParentID); err != nil {
+			return nil, err
+		}
+
+		return groupRes{created: false}, nil
+	}
+}
+
+func listGroupChildrenEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listGroupChildrenReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListGroupChildren(ctx, req.token, req.id, req.depth, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildGroupsResponse(page), nil
+	}
+}
+
 func removeGroupEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(groupReq)
@@ -606,6 +1000,47 @@ func removeGroupsEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
+func addThingTagsEndpoint(svc things.Service) endpoint.Endpoint {
+	return tagsEndpoint(svc.AddThingTags)
+}
+
+func removeThingTagsEndpoint(svc things.Service) endpoint.Endpoint {
+	return tagsEndpoint(svc.RemoveThingTags)
+}
+
+func addChannelTagsEndpoint(svc things.Service) endpoint.Endpoint {
+	return tagsEndpoint(svc.AddChannelTags)
+}
+
+func removeChannelTagsEndpoint(svc things.Service) endpoint.Endpoint {
+	return tagsEndpoint(svc.RemoveChannelTags)
+}
+
+func addGroupTagsEndpoint(svc things.Service) endpoint.Endpoint {
+	return tagsEndpoint(svc.AddGroupTags)
+}
+
+func removeGroupTagsEndpoint(svc things.Service) endpoint.Endpoint {
+	return tagsEndpoint(svc.RemoveGroupTags)
+}
+
+// tagsEndpoint returns the endpoint that adds or removes tags using the
+// provided service method.
+func tagsEndpoint(update func(ctx context.Context, token string, tags []string, ids ...string) error) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(tagsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := update(ctx, req.token, req.Tags, req.IDs...); err != nil {
+			return nil, err
+		}
+
+		return tagsRes{}, nil
+	}
+}
+
 func listGroupsEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(listGroupsReq)
@@ -660,7 +1095,10 @@ func viewThingMembershipEndpoint(svc things.Service) endpoint.Endpoint {
 			Name:        group.Name,
 			Description: group.Description,
 			Metadata:    group.Metadata,
+			Tags:        group.Tags,
 			OwnerID:     group.OwnerID,
+			ParentID:    group.ParentID,
+			Path:        group.Path,
 			CreatedAt:   group.CreatedAt,
 			UpdatedAt:   group.UpdatedAt,
 		}
@@ -754,7 +1192,10 @@ func viewChannelMembershipEndpoint(svc things.Service) endpoint.Endpoint {
 			Name:        group.Name,
 			Description: group.Description,
 			Metadata:    group.Metadata,
+			Tags:        group.Tags,
 			OwnerID:     group.OwnerID,
+			ParentID:    group.ParentID,
+			Path:        group.Path,
 			CreatedAt:   group.CreatedAt,
 			UpdatedAt:   group.UpdatedAt,
 		}
@@ -807,9 +1248,12 @@ func buildGroupsResponse(gp things.GroupPage) groupPageRes {
 		view := viewGroupRes{
 			ID:          group.ID,
 			OwnerID:     group.OwnerID,
+			ParentID:    group.ParentID,
+			Path:        group.Path,
 			Name:        group.Name,
 			Description: group.Description,
 			Metadata:    group.Metadata,
+			Tags:        group.Tags,
 			CreatedAt:   group.CreatedAt,
 			UpdatedAt:   group.UpdatedAt,
 		}
@@ -832,10 +1276,12 @@ func buildGroupThingsResponse(tp things.GroupThingsPage) groupThingsPageRes {
 
 	for _, t := range tp.Things {
 		view := thingRes{
-			ID:       t.ID,
-			Metadata: t.Metadata,
-			Name:     t.Name,
-			Key:      t.Key,
+			ID:        t.ID,
+			Metadata:  t.Metadata,
+			ProfileID: t.ProfileID,
+			Tags:      t.Tags,
+			Name:      t.Name,
+			Key:       t.Key,
 		}
 		res.Things = append(res.Things, view)
 	}
@@ -856,9 +1302,11 @@ func buildGroupChannelsResponse(cp things.GroupChannelsPage) groupChannelsPageRe
 
 	for _, c := range cp.Channels {
 		view := channelRes{
-			ID:       c.ID,
-			Metadata: c.Metadata,
-			Name:     c.Name,
+			ID:        c.ID,
+			Metadata:  c.Metadata,
+			ProfileID: c.ProfileID,
+			Tags:      c.Tags,
+			Name:      c.Name,
 		}
 		res.Channels = append(res.Channels, view)
 	}
@@ -866,11 +1314,30 @@ func buildGroupChannelsResponse(cp things.GroupChannelsPage) groupChannelsPageRe
 	return res
 }
 
+func buildThingKeyResponse(key things.ThingKey) thingKeyRes {
+	res := thingKeyRes{
+		ID:        key.ID,
+		ThingID:   key.ThingID,
+		Name:      key.Name,
+		Key:       key.Key,
+		Enabled:   key.Enabled,
+		CreatedAt: key.CreatedAt,
+	}
+	if !key.ExpiresAt.IsZero() {
+		expiresAt := key.ExpiresAt
+		res.ExpiresAt = &expiresAt
+	}
+
+	return res
+}
+
 func buildBackupResponse(backup things.Backup) backupRes {
 	res := backupRes{
 		Things:                []backupThingRes{},
+		ThingKeys:             []thingKeyRes{},
 		Channels:              []backupChannelRes{},
 		Connections:           []backupConnectionRes{},
+		Profiles:              []viewProfileRes{},
 		Groups:                []viewGroupRes{},
 		GroupThingRelations:   []backupGroupThingRelationRes{},
 		GroupChannelRelations: []backupGroupChannelRelationRes{},
@@ -878,21 +1345,30 @@ func buildBackupResponse(backup things.Backup) backupRes {
 
 	for _, thing := range backup.Things {
 		view := backupThingRes{
-			ID:       thing.ID,
-			Name:     thing.Name,
-			Owner:    thing.Owner,
-			Key:      thing.Key,
-			Metadata: thing.Metadata,
+			ID:        thing.ID,
+			Name:      thing.Name,
+			Owner:     thing.Owner,
+			Key:       thing.Key,
+			Metadata:  thing.Metadata,
+			ProfileID: thing.ProfileID,
+			Tags:      thing.Tags,
 		}
 		res.Things = append(res.Things, view)
 	}
 
+	for _, key := range backup.ThingKeys {
+		res.ThingKeys = append(res.ThingKeys, buildThingKeyResponse(key))
+	}
+
 	for _, channel := range backup.Channels {
 		view := backupChannelRes{
-			ID:       channel.ID,
-			Name:     channel.Name,
-			Owner:    channel.Owner,
-			Metadata: channel.Metadata,
+			ID:            channel.ID,
+			Name:          channel.Name,
+			Owner:         channel.Owner,
+			Metadata:      channel.Metadata,
+			ProfileID:     channel.ProfileID,
+			Tags:          channel.Tags,
+			PayloadSchema: payloadSchemaRes(channel.PayloadSchema),
 		}
 		res.Channels = append(res.Channels, view)
 	}
@@ -903,17 +1379,25 @@ func buildBackupResponse(backup things.Backup) backupRes {
 			ChannelOwner: connection.ChannelOwner,
 			ThingID:      connection.ThingID,
 			ThingOwner:   connection.ThingOwner,
+			Type:         connection.Type,
 		}
 		res.Connections = append(res.Connections, view)
 	}
 
+	for _, pr := range backup.Profiles {
+		res.Profiles = append(res.Profiles, buildViewProfileResponse(pr))
+	}
+
 	for _, group := range backup.Groups {
 		view := viewGroupRes{
 			ID:          group.ID,
 			Name:        group.Name,
 			Description: group.Description,
 			Metadata:    group.Metadata,
+			Tags:        group.Tags,
 			OwnerID:     group.OwnerID,
+			ParentID:    group.ParentID,
+			Path:        group.Path,
 			CreatedAt:   group.CreatedAt,
 			UpdatedAt:   group.UpdatedAt,
 		}
@@ -946,21 +1430,41 @@ func buildBackupResponse(backup things.Backup) backupRes {
 func buildBackup(req restoreReq) (backup things.Backup) {
 	for _, thing := range req.Things {
 		th := things.Thing{
-			ID:       thing.ID,
-			Owner:    thing.Owner,
-			Name:     thing.Name,
-			Key:      thing.Key,
-			Metadata: thing.Metadata,
+			ID:        thing.ID,
+			Owner:     thing.Owner,
+			Name:      thing.Name,
+			Key:       thing.Key,
+			Metadata:  thing.Metadata,
+			ProfileID: thing.ProfileID,
+			Tags:      thing.Tags,
 		}
 		backup.Things = append(backup.Things, th)
 	}
 
+	for _, key := range req.ThingKeys {
+		k := things.ThingKey{
+			ID:        key.ID,
+			ThingID:   key.ThingID,
+			Name:      key.Name,
+			Key:       key.Key,
+			Enabled:   key.Enabled,
+			CreatedAt: key.CreatedAt,
+		}
+		if key.ExpiresAt != nil {
+			k.ExpiresAt = *key.ExpiresAt
+		}
+		backup.ThingKeys = append(backup.ThingKeys, k)
+	}
+
 	for _, channel := range req.Channels {
 		ch := things.Channel{
-			ID:       channel.ID,
-			Owner:    channel.Owner,
-			Name:     channel.Name,
-			Metadata: channel.Metadata,
+			ID:            channel.ID,
+			Owner:         channel.Owner,
+			Name:          channel.Name,
+			Metadata:      channel.Metadata,
+			ProfileID:     channel.ProfileID,
+			Tags:          channel.Tags,
+			PayloadSchema: channel.PayloadSchema,
 		}
 		backup.Channels = append(backup.Channels, ch)
 	}
@@ -971,17 +1475,35 @@ func buildBackup(req restoreReq) (backup things.Backup) {
 			ChannelOwner: connection.ChannelOwner,
 			ThingID:      connection.ThingID,
 			ThingOwner:   connection.ThingOwner,
+			Type:         connection.Type,
 		}
 		backup.Connections = append(backup.Connections, conn)
 	}
 
+	for _, profile := range req.Profiles {
+		pr := things.Profile{
+			ID:          profile.ID,
+			OwnerID:     profile.OwnerID,
+			Name:        profile.Name,
+			Description: profile.Description,
+			Schema:      profile.Schema,
+			Defaults:    profile.Defaults,
+			CreatedAt:   profile.CreatedAt,
+			UpdatedAt:   profile.UpdatedAt,
+		}
+		backup.Profiles = append(backup.Profiles, pr)
+	}
+
 	for _, group := range req.Groups {
 		gr := things.Group{
 			ID:          group.ID,
 			OwnerID:     group.OwnerID,
+			ParentID:    group.ParentID,
+			Path:        group.Path,
 			Name:        group.Name,
 			Description: group.Description,
 			Metadata:    group.Metadata,
+			Tags:        group.Tags,
 			CreatedAt:   group.CreatedAt,
 			UpdatedAt:   group.UpdatedAt,
 		}
@@ -1010,3 +1532,185 @@ func buildBackup(req restoreReq) (backup things.Backup) {
 
 	return backup
 }
+
+func listDeletedThingsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listResourcesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListDeletedThings(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := deletedThingsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Things: []deletedThingRes{},
+		}
+		for _, th := range page.Things {
+			view := deletedThingRes{
+				ID:         th.ID,
+				Name:       th.Name,
+				Metadata:   th.Metadata,
+				ProfileID:  th.ProfileID,
+				Tags:       th.Tags,
+				ChannelIDs: th.ChannelIDs,
+				GroupID:    th.GroupID,
+				DeletedAt:  th.DeletedAt,
+			}
+			res.Things = append(res.Things, view)
+		}
+
+		return res, nil
+	}
+}
+
+func restoreThingsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(removeThingsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RestoreThings(ctx, req.token, req.ThingIDs...); err != nil {
+			return nil, err
+		}
+
+		return restoreRes{}, nil
+	}
+}
+
+func listDeletedChannelsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listResourcesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListDeletedChannels(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := deletedChannelsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Channels: []deletedChannelRes{},
+		}
+		for _, ch := range page.Channels {
+			view := deletedChannelRes{
+				ID:        ch.ID,
+				Name:      ch.Name,
+				Metadata:  ch.Metadata,
+				ProfileID: ch.ProfileID,
+				Tags:      ch.Tags,
+				ThingIDs:  ch.ThingIDs,
+				GroupID:   ch.GroupID,
+				DeletedAt: ch.DeletedAt,
+			}
+			res.Channels = append(res.Channels, view)
+		}
+
+		return res, nil
+	}
+}
+
+func restoreChannelsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(removeChannelsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RestoreChannels(ctx, req.token, req.ChannelIDs...); err != nil {
+			return nil, err
+		}
+
+		return restoreRes{}, nil
+	}
+}
+
+func listDeletedGroupsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listResourcesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListDeletedGroups(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := deletedGroupsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Groups: []deletedGroupRes{},
+		}
+		for _, gr := range page.Groups {
+			view := deletedGroupRes{
+				ID:          gr.ID,
+				Name:        gr.Name,
+				ParentID:    gr.ParentID,
+				Description: gr.Description,
+				Metadata:    gr.Metadata,
+				Tags:        gr.Tags,
+				ThingIDs:    gr.ThingIDs,
+				ChannelIDs:  gr.ChannelIDs,
+				DeletedAt:   gr.DeletedAt,
+			}
+			res.Groups = append(res.Groups, view)
+		}
+
+		return res, nil
+	}
+}
+
+func restoreGroupsEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(removeGroupsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RestoreGroups(ctx, req.token, req.GroupIDs...); err != nil {
+			return nil, err
+		}
+
+		return restoreRes{}, nil
+	}
+}
+
+func viewUsageEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewUsageReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		usage, err := svc.ViewUsage(ctx, req.token, req.orgID)
+		if err != nil {
+			return nil, err
+		}
+
+		return usageRes(usage), nil
+	}
+}
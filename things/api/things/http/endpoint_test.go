@@ -16,10 +16,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/things"
 	httpapi "github.com/MainfluxLabs/mainflux/things/api/things/http"
@@ -76,6 +79,15 @@ var (
 	admin     = users.User{ID: "2e248e36-2d26-46ea-97b0-1e38d674cbe4", Email: adminEmail, Password: password}
 	usersList = []users.User{admin, user, otherUser}
 	group     = things.Group{Name: "test-group", Description: "test-group-desc"}
+	profile   = things.Profile{
+		Name: "sensor",
+		Schema: map[string]interface{}{
+			"type":       "object",
+			"required":   []interface{}{"serial"},
+			"properties": map[string]interface{}{"serial": map[string]interface{}{"type": "string"}},
+		},
+		Defaults: map[string]interface{}{"interval": float64(60)},
+	}
 )
 
 type testRequest struct {
@@ -102,16 +114,22 @@ func (tr testRequest) make() (*http.Response, error) {
 }
 
 func newService() things.Service {
-	auth := mocks.NewAuthService(admin.ID, usersList)
+	return newServiceWithQuotas(map[string]*mainflux.QuotaRes{})
+}
+
+func newServiceWithQuotas(quotas map[string]*mainflux.QuotaRes) things.Service {
+	auth := mocks.NewAuthServiceWithQuotas(admin.ID, usersList, quotas)
 	conns := make(chan thmocks.Connection)
 	thingsRepo := thmocks.NewThingRepository(conns)
+	thingKeysRepo := thmocks.NewThingKeyRepository()
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
 	groupsRepo := thmocks.NewGroupRepository()
+	profilesRepo := thmocks.NewProfileRepository()
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, thingKeysRepo, channelsRepo, groupsRepo, profilesRepo, chanCache, thingCache, idProvider)
 }
 
 func newServer(svc things.Service) *httptest.Server {
@@ -473,6 +491,223 @@ func TestUpdateKey(t *testing.T) {
 	}
 }
 
+func TestCreateThingKey(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	data := toJSON(map[string]interface{}{"name": "backup", "expires_at": time.Now().Add(time.Hour)})
+
+	cases := []struct {
+		desc        string
+		req         string
+		id          string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "create thing key",
+			req:         data,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create thing key equal to the primary key",
+			req:         toJSON(map[string]string{"key": th.Key}),
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusConflict,
+		},
+		{
+			desc:        "create key of non-existing thing",
+			req:         data,
+			id:          strconv.FormatUint(wrongID, 10),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "create thing key with invalid user token",
+			req:         data,
+			id:          th.ID,
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create thing key with invalid data format",
+			req:         "{",
+			id:          th.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create thing key without content type",
+			req:         data,
+			id:          th.ID,
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things/%s/keys", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusCreated {
+			continue
+		}
+
+		var body thingKeyRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		location := fmt.Sprintf("/things/%s/keys/%s", th.ID, body.ID)
+		assert.Equal(t, location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, location, res.Header.Get("Location")))
+		assert.NotEmpty(t, body.Key, fmt.Sprintf("%s: expected non-empty key", tc.desc))
+	}
+}
+
+func TestListThingKeys(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	var keys []thingKeyRes
+	for i := 0; i < 3; i++ {
+		k, err := svc.CreateThingKey(context.Background(), token, th.ID, things.ThingKey{Name: fmt.Sprintf("key-%d", i)})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		keys = append(keys, thingKeyRes{ID: k.ID, ThingID: k.ThingID, Name: k.Name, Key: k.Key, Enabled: k.Enabled})
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		auth   string
+		status int
+		res    []thingKeyRes
+	}{
+		{
+			desc:   "list thing keys",
+			url:    fmt.Sprintf("%s/things/%s/keys", ts.URL, th.ID),
+			auth:   token,
+			status: http.StatusOK,
+			res:    keys,
+		},
+		{
+			desc:   "list thing keys with limit",
+			url:    fmt.Sprintf("%s/things/%s/keys?limit=1", ts.URL, th.ID),
+			auth:   token,
+			status: http.StatusOK,
+			res:    keys[:1],
+		},
+		{
+			desc:   "list thing keys with invalid limit",
+			url:    fmt.Sprintf("%s/things/%s/keys?limit=%d", ts.URL, th.ID, 101),
+			auth:   token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list keys of other user's thing",
+			url:    fmt.Sprintf("%s/things/%s/keys", ts.URL, th.ID),
+			auth:   otherToken,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body thingKeysPageRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.ElementsMatch(t, tc.res, body.Keys, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, body.Keys))
+	}
+}
+
+func TestRemoveThingKey(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	k, err := svc.CreateThingKey(context.Background(), token, th.ID, things.ThingKey{Name: "key"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		status int
+	}{
+		{
+			desc:   "remove thing key with invalid user token",
+			id:     k.ID,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "remove thing key",
+			id:     k.ID,
+			auth:   token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove removed thing key",
+			id:     k.ID,
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/things/%s/keys/%s", ts.URL, th.ID, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestViewThing(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
@@ -801,6 +1036,36 @@ func TestSearchThings(t *testing.T) {
 	th.Name = invalidName
 	invalidData := toJSON(th)
 
+	connected := true
+	th = searchThingReq
+	th.Filters = []things.MetadataFilter{
+		{Path: "location.room", Op: things.OpIn, Value: []interface{}{"A1", "A2"}},
+		{Path: "interval", Op: things.OpGte, Value: 10},
+		{Path: "serial", Op: things.OpPrefix, Value: "SN"},
+	}
+	th.Created = things.TimeRange{From: time.Now().Add(-time.Hour), To: time.Now()}
+	th.Connected = &connected
+	th.Tags = []string{"!faulty"}
+	filtersData := toJSON(th)
+
+	th = searchThingReq
+	th.Filters = []things.MetadataFilter{{Path: "location.room", Op: "like", Value: "A1"}}
+	invalidOpData := toJSON(th)
+
+	th.Filters = []things.MetadataFilter{{Path: "location..room", Op: things.OpEq, Value: "A1"}}
+	invalidPathData := toJSON(th)
+
+	th.Filters = []things.MetadataFilter{{Path: "location.room", Op: things.OpIn, Value: "A1"}}
+	invalidInData := toJSON(th)
+
+	th = searchThingReq
+	th.Created = things.TimeRange{From: time.Now(), To: time.Now().Add(-time.Hour)}
+	invalidRangeData := toJSON(th)
+
+	th = searchThingReq
+	th.GroupID = wrongValue
+	invalidGroupData := toJSON(th)
+
 	data := []thingRes{}
 	for i := 0; i < 100; i++ {
 		name := "name_" + fmt.Sprintf("%03d", i+1)
@@ -942,6 +1207,48 @@ func TestSearchThings(t *testing.T) {
 			req:    invalidDirData,
 			res:    nil,
 		},
+		{
+			desc:   "search things with filters",
+			auth:   token,
+			status: http.StatusOK,
+			req:    filtersData,
+			res:    data[0:5],
+		},
+		{
+			desc:   "search things with invalid filter operator",
+			auth:   token,
+			status: http.StatusBadRequest,
+			req:    invalidOpData,
+			res:    nil,
+		},
+		{
+			desc:   "search things with invalid filter path",
+			auth:   token,
+			status: http.StatusBadRequest,
+			req:    invalidPathData,
+			res:    nil,
+		},
+		{
+			desc:   "search things with invalid in filter value",
+			auth:   token,
+			status: http.StatusBadRequest,
+			req:    invalidInData,
+			res:    nil,
+		},
+		{
+			desc:   "search things with invalid time range",
+			auth:   token,
+			status: http.StatusBadRequest,
+			req:    invalidRangeData,
+			res:    nil,
+		},
+		{
+			desc:   "search things with invalid group id",
+			auth:   token,
+			status: http.StatusBadRequest,
+			req:    invalidGroupData,
+			res:    nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -999,7 +1306,7 @@ func TestListThingsByChannel(t *testing.T) {
 	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, ch.ID, thIDs)
+	err = svc.Connect(context.Background(), token, ch.ID, thIDs, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	thingURL := fmt.Sprintf("%s/channels", ts.URL)
@@ -1300,48 +1607,309 @@ func TestRemoveThings(t *testing.T) {
 	}
 }
 
-func TestCreateChannels(t *testing.T) {
+func TestListDeletedThings(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
 	defer ts.Close()
 
-	data := `[{"name": "1"}, {"name": "2"}]`
-	invalidData := fmt.Sprintf(`[{"name": "%s"}]`, invalidName)
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing1)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.RemoveThings(context.Background(), token, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	trashURL := fmt.Sprintf("%s/trash/things", ts.URL)
 
 	cases := []struct {
-		desc        string
-		data        string
-		contentType string
-		auth        string
-		status      int
-		response    string
+		desc   string
+		auth   string
+		url    string
+		status int
+		res    []string
 	}{
 		{
-			desc:        "create valid channels",
-			data:        data,
-			contentType: contentType,
-			auth:        token,
-			status:      http.StatusCreated,
-			response:    "",
+			desc:   "get a list of deleted things",
+			auth:   token,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d", trashURL, 0, 5),
+			status: http.StatusOK,
+			res:    []string{ths[0].ID},
 		},
 		{
-			desc:        "create channel with empty request",
-			data:        "",
-			contentType: contentType,
-			auth:        token,
-			status:      http.StatusBadRequest,
-			response:    "",
+			desc:   "get a list of deleted things with invalid token",
+			auth:   wrongValue,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d", trashURL, 0, 5),
+			status: http.StatusUnauthorized,
+			res:    nil,
 		},
 		{
-			desc:        "create channels with empty JSON",
-			data:        "[]",
-			contentType: contentType,
-			auth:        token,
-			status:      http.StatusBadRequest,
-			response:    "",
+			desc:   "get a list of deleted things with limit greater than max",
+			auth:   token,
+			url:    fmt.Sprintf("%s?offset=%d&limit=%d", trashURL, 0, 110),
+			status: http.StatusBadRequest,
+			res:    nil,
 		},
-		{
-			desc:        "create channel with invalid auth token",
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var data struct {
+			Things []struct {
+				ID        string    `json:"id"`
+				DeletedAt time.Time `json:"deleted_at"`
+			} `json:"things"`
+		}
+		json.NewDecoder(res.Body).Decode(&data)
+		var ids []string
+		for _, th := range data.Things {
+			ids = append(ids, th.ID)
+		}
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.ElementsMatch(t, tc.res, ids, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, ids))
+	}
+}
+
+func TestRestoreThings(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.RemoveThings(context.Background(), token, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc        string
+		data        []string
+		auth        string
+		contentType string
+		status      int
+	}{
+		{
+			desc:        "restore things with invalid token",
+			data:        []string{ths[0].ID},
+			auth:        wrongValue,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "restore things with invalid content type",
+			data:        []string{ths[0].ID},
+			auth:        token,
+			contentType: wrongValue,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "restore empty list of things",
+			data:        []string{},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "restore removed things",
+			data:        []string{ths[0].ID},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "restore things that are not in the trash",
+			data:        []string{ths[0].ID},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		data := struct {
+			ThingIDs []string `json:"thing_ids"`
+		}{
+			tc.data,
+		}
+
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/trash/things/restore", ts.URL),
+			token:       tc.auth,
+			contentType: tc.contentType,
+			body:        strings.NewReader(toJSON(data)),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestThingTags(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	id := ths[0].ID
+
+	cases := []struct {
+		desc        string
+		method      string
+		ids         []string
+		tags        []string
+		auth        string
+		contentType string
+		status      int
+	}{
+		{
+			desc:        "add tags with invalid token",
+			method:      http.MethodPost,
+			ids:         []string{id},
+			tags:        []string{"outdoor"},
+			auth:        wrongValue,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "add tags with invalid content type",
+			method:      http.MethodPost,
+			ids:         []string{id},
+			tags:        []string{"outdoor"},
+			auth:        token,
+			contentType: wrongValue,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "add empty list of tags",
+			method:      http.MethodPost,
+			ids:         []string{id},
+			tags:        []string{},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add tags to empty list of things",
+			method:      http.MethodPost,
+			ids:         []string{},
+			tags:        []string{"outdoor"},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add invalid tags",
+			method:      http.MethodPost,
+			ids:         []string{id},
+			tags:        []string{"out door"},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "add tags to non-existing thing",
+			method:      http.MethodPost,
+			ids:         []string{wrongValue},
+			tags:        []string{"outdoor"},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "add tags to thing",
+			method:      http.MethodPost,
+			ids:         []string{id},
+			tags:        []string{"outdoor", "faulty"},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:        "remove tags from thing",
+			method:      http.MethodPatch,
+			ids:         []string{id},
+			tags:        []string{"faulty"},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		data := struct {
+			IDs  []string `json:"ids"`
+			Tags []string `json:"tags"`
+		}{
+			tc.ids,
+			tc.tags,
+		}
+
+		req := testRequest{
+			client:      ts.Client(),
+			method:      tc.method,
+			url:         fmt.Sprintf("%s/things/tags", ts.URL),
+			token:       tc.auth,
+			contentType: tc.contentType,
+			body:        strings.NewReader(toJSON(data)),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	th, err := svc.ViewThing(context.Background(), token, id)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, []string{"outdoor"}, th.Tags, fmt.Sprintf("expected tags %v got %v", []string{"outdoor"}, th.Tags))
+}
+
+func TestCreateChannels(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	data := `[{"name": "1"}, {"name": "2"}]`
+	invalidData := fmt.Sprintf(`[{"name": "%s"}]`, invalidName)
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		auth        string
+		status      int
+		response    string
+	}{
+		{
+			desc:        "create valid channels",
+			data:        data,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			response:    "",
+		},
+		{
+			desc:        "create channel with empty request",
+			data:        "",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			response:    "",
+		},
+		{
+			desc:        "create channels with empty JSON",
+			data:        "[]",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			response:    "",
+		},
+		{
+			desc:        "create channel with invalid auth token",
 			data:        data,
 			contentType: contentType,
 			auth:        wrongValue,
@@ -1379,6 +1947,22 @@ func TestCreateChannels(t *testing.T) {
 			status:      http.StatusBadRequest,
 			response:    "",
 		},
+		{
+			desc:        "create channel with payload schema",
+			data:        `[{"name": "3", "payload_schema": {"senml": {"temp": "Cel"}}}]`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			response:    "",
+		},
+		{
+			desc:        "create channel with invalid payload schema",
+			data:        `[{"name": "4", "payload_schema": {"json": {"type": 1}}}]`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			response:    "",
+		},
 	}
 
 	for _, tc := range cases {
@@ -1531,7 +2115,7 @@ func TestViewChannel(t *testing.T) {
 	ths, err := svc.CreateThings(context.Background(), token, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th := ths[0]
-	svc.Connect(context.Background(), token, sch.ID, []string{th.ID})
+	svc.Connect(context.Background(), token, sch.ID, []string{th.ID}, things.ConnTypePubSub)
 
 	data := toJSON(channelRes{
 		ID:       sch.ID,
@@ -1618,7 +2202,7 @@ func TestListChannels(t *testing.T) {
 		ths, err := svc.CreateThings(context.Background(), token, thing)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		th := ths[0]
-		svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
+		svc.Connect(context.Background(), token, ch.ID, []string{th.ID}, things.ConnTypePubSub)
 
 		channels = append(channels, channelRes{
 			ID:       ch.ID,
@@ -1821,38 +2405,130 @@ func TestListChannels(t *testing.T) {
 	}
 }
 
-func TestViewChannelByThing(t *testing.T) {
+func TestSearchChannels(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
 	defer ts.Close()
 
-	ths, err := svc.CreateThings(context.Background(), token, thing)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	th := ths[0]
-
-	grs, err := svc.CreateGroups(context.Background(), token, group)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	gr := grs[0]
+	channels := []channelRes{}
+	for i := 0; i < 10; i++ {
+		name := "name_" + fmt.Sprintf("%03d", i+1)
+		chs, err := svc.CreateChannels(context.Background(), token,
+			things.Channel{
+				Name:     name,
+				Metadata: map[string]interface{}{"floor": float64(i)},
+			})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		ch := chs[0]
 
-	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		channels = append(channels, channelRes{
+			ID:       ch.ID,
+			Name:     ch.Name,
+			Metadata: ch.Metadata,
+		})
+	}
 
-	channel1 := channel
+	pm := things.PageMetadata{
+		Limit: 5,
+		Filters: []things.MetadataFilter{
+			{Path: "floor", Op: things.OpLt, Value: 5},
+		},
+	}
+	validData := toJSON(pm)
 
-	chs, err := svc.CreateChannels(context.Background(), token, channel1)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	ch := chs[0]
+	pm.Filters = []things.MetadataFilter{{Path: "floor", Op: things.OpContains, Value: 5}}
+	invalidFilterData := toJSON(pm)
 
-	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	pm.Filters = nil
+	pm.Tags = []string{""}
+	invalidTagsData := toJSON(pm)
 
-	chRes := channelRes{
-		ID:       ch.ID,
-		Name:     ch.Name,
-		Metadata: ch.Metadata,
-	}
+	cases := []struct {
+		desc   string
+		auth   string
+		status int
+		req    string
+		res    []channelRes
+	}{
+		{
+			desc:   "search channels",
+			auth:   token,
+			status: http.StatusOK,
+			req:    validData,
+			res:    channels[0:5],
+		},
+		{
+			desc:   "search channels with invalid filter value",
+			auth:   token,
+			status: http.StatusBadRequest,
+			req:    invalidFilterData,
+			res:    nil,
+		},
+		{
+			desc:   "search channels with empty tag",
+			auth:   token,
+			status: http.StatusBadRequest,
+			req:    invalidTagsData,
+			res:    nil,
+		},
+		{
+			desc:   "search channels with invalid token",
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+			req:    validData,
+			res:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/channels/search", ts.URL),
+			token:  tc.auth,
+			body:   strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var data channelsPageRes
+		json.NewDecoder(res.Body).Decode(&data)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.ElementsMatch(t, tc.res, data.Channels, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, data.Channels))
+	}
+}
+
+func TestViewChannelByThing(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	th := ths[0]
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	channel1 := channel
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel1)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ch := chs[0]
+
+	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	chRes := channelRes{
+		ID:       ch.ID,
+		Name:     ch.Name,
+		Metadata: ch.Metadata,
+	}
 
-	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
+	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID}, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	channelURL := fmt.Sprintf("%s/things", ts.URL)
@@ -2079,218 +2755,308 @@ func TestRemoveChannels(t *testing.T) {
 	}
 }
 
-func TestConnect(t *testing.T) {
+func TestRestoreChannels(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
 	defer ts.Close()
 
-	ths, err := svc.CreateThings(context.Background(), token, thing)
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.RemoveChannels(context.Background(), token, chs[0].ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
-	thIDs := []string{}
-	for _, th := range ths {
-		thIDs = append(thIDs, th.ID)
+	cases := []struct {
+		desc   string
+		data   []string
+		auth   string
+		status int
+	}{
+		{
+			desc:   "restore channels with invalid token",
+			data:   []string{chs[0].ID},
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "restore removed channels",
+			data:   []string{chs[0].ID},
+			auth:   token,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "restore channels that are not in the trash",
+			data:   []string{chs[0].ID},
+			auth:   token,
+			status: http.StatusNotFound,
+		},
 	}
 
-	chs1, err := svc.CreateChannels(context.Background(), token, channel)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	ch1 := chs1[0]
-
-	chs2, err := svc.CreateChannels(context.Background(), otherToken, channel)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	ch2 := chs2[0]
+	for _, tc := range cases {
+		data := struct {
+			ChannelIDs []string `json:"channel_ids"`
+		}{
+			tc.data,
+		}
 
-	grs, err := svc.CreateGroups(context.Background(), token, group)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	gr := grs[0]
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/trash/channels/restore", ts.URL),
+			token:       tc.auth,
+			contentType: contentType,
+			body:        strings.NewReader(toJSON(data)),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
 
-	err = svc.AssignThing(context.Background(), token, gr.ID, thIDs...)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+func TestCreateProfiles(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
 
-	err = svc.AssignChannel(context.Background(), token, gr.ID, ch1.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	data := toJSON([]things.Profile{profile})
+	valid := toJSON([]map[string]interface{}{{"name": "valid", "schema": profile.Schema, "defaults": profile.Defaults}})
+	invalidSchema := toJSON([]map[string]interface{}{{"name": "invalid", "schema": map[string]interface{}{"type": 5}}})
+	emptyName := toJSON([]map[string]interface{}{{"schema": profile.Schema}})
 
-	err = svc.AssignChannel(context.Background(), token, gr.ID, ch2.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err := svc.CreateProfiles(context.Background(), token, profile)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := []struct {
 		desc        string
-		channelID   string
-		thingIDs    []string
-		auth        string
+		req         string
 		contentType string
-		body        string
+		auth        string
 		status      int
 	}{
 		{
-			desc:        "connect existing things to existing channel",
-			channelID:   ch1.ID,
-			thingIDs:    thIDs,
-			auth:        token,
+			desc:        "create valid profile",
+			req:         valid,
 			contentType: contentType,
-			status:      http.StatusOK,
-		},
-		{
-			desc:        "connect existing things to non-existent channel",
-			channelID:   strconv.FormatUint(wrongID, 10),
-			thingIDs:    thIDs,
 			auth:        token,
-			contentType: contentType,
-			status:      http.StatusNotFound,
+			status:      http.StatusCreated,
 		},
 		{
-			desc:        "connect non-existing things to existing channel",
-			channelID:   ch1.ID,
-			thingIDs:    []string{strconv.FormatUint(wrongID, 10)},
-			auth:        token,
+			desc:        "create profile with existing name",
+			req:         toJSON([]map[string]interface{}{{"name": profile.Name}}),
 			contentType: contentType,
-			status:      http.StatusNotFound,
-		},
-		{
-			desc:        "connect existing things to channel with invalid id",
-			channelID:   "invalid",
-			thingIDs:    thIDs,
 			auth:        token,
-			contentType: contentType,
-			status:      http.StatusNotFound,
+			status:      http.StatusConflict,
 		},
 		{
-			desc:        "connect things with invalid id to existing channel",
-			channelID:   ch1.ID,
-			thingIDs:    []string{"invalid"},
-			auth:        token,
+			desc:        "create profile with invalid schema",
+			req:         invalidSchema,
 			contentType: contentType,
-			status:      http.StatusNotFound,
-		},
-		{
-			desc:        "connect existing things to empty channel id",
-			channelID:   "",
-			thingIDs:    thIDs,
 			auth:        token,
-			contentType: contentType,
 			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "connect empty things id to existing channel",
-			channelID:   ch1.ID,
-			thingIDs:    []string{""},
-			auth:        token,
+			desc:        "create profile without name",
+			req:         emptyName,
 			contentType: contentType,
+			auth:        token,
 			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "connect existing things to existing channel with invalid token",
-			channelID:   ch1.ID,
-			thingIDs:    thIDs,
-			auth:        wrongValue,
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
-		},
-		{
-			desc:        "connect existing things to existing channel with empty token",
-			channelID:   ch1.ID,
-			thingIDs:    thIDs,
-			auth:        "",
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
-		},
-		{
-			desc:        "connect things from owner to channel of other user",
-			channelID:   ch2.ID,
-			thingIDs:    thIDs,
-			auth:        token,
+			desc:        "create profiles with empty list",
+			req:         "[]",
 			contentType: contentType,
-			status:      http.StatusForbidden,
-		},
-		{
-			desc:        "connect with invalid content type",
-			channelID:   ch2.ID,
-			thingIDs:    thIDs,
-			auth:        token,
-			contentType: "invalid",
-			status:      http.StatusUnsupportedMediaType,
-		},
-		{
-			desc:        "connect with invalid JSON",
 			auth:        token,
-			contentType: contentType,
 			status:      http.StatusBadRequest,
-			body:        "{",
 		},
 		{
-			desc:        "connect valid thing ids with empty channel id",
-			channelID:   "",
-			thingIDs:    thIDs,
-			auth:        token,
+			desc:        "create profile with invalid data format",
+			req:         "{",
 			contentType: contentType,
+			auth:        token,
 			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "connect valid channel id with empty thing ids",
-			channelID:   ch2.ID,
-			thingIDs:    []string{},
-			auth:        token,
+			desc:        "create profile with invalid user token",
+			req:         valid,
 			contentType: contentType,
-			status:      http.StatusBadRequest,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
 		},
 		{
-			desc:        "connect empty channel id and empty thing ids",
-			channelID:   "",
-			thingIDs:    []string{},
+			desc:        "create profile without content type",
+			req:         data,
+			contentType: "",
 			auth:        token,
-			contentType: contentType,
-			status:      http.StatusBadRequest,
+			status:      http.StatusUnsupportedMediaType,
 		},
 	}
 
 	for _, tc := range cases {
-		data := struct {
-			ChannelID string   `json:"channel_id"`
-			ThingIDs  []string `json:"thing_ids"`
-		}{
-			tc.channelID,
-			tc.thingIDs,
-		}
-		body := toJSON(data)
-
-		if tc.body != "" {
-			body = tc.body
-		}
-
 		req := testRequest{
 			client:      ts.Client(),
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/connect", ts.URL),
+			url:         fmt.Sprintf("%s/profiles", ts.URL),
 			contentType: tc.contentType,
 			token:       tc.auth,
-			body:        strings.NewReader(body),
+			body:        strings.NewReader(tc.req),
 		}
-
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 	}
 }
 
-func TestDisconnect(t *testing.T) {
+func TestViewProfile(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	prs, err := svc.CreateProfiles(context.Background(), token, profile)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	pr := prs[0]
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		status int
+		res    profileRes
+	}{
+		{
+			desc:   "view existing profile",
+			id:     pr.ID,
+			auth:   token,
+			status: http.StatusOK,
+			res:    profileRes{ID: pr.ID, Name: pr.Name, Schema: pr.Schema, Defaults: pr.Defaults},
+		},
+		{
+			desc:   "view other user's profile",
+			id:     pr.ID,
+			auth:   otherToken,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view non-existing profile",
+			id:     strconv.FormatUint(wrongID, 10),
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view profile with invalid token",
+			id:     pr.ID,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/profiles/%s", ts.URL, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body profileRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body))
+	}
+}
+
+func TestListThingsByProfile(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	prs, err := svc.CreateProfiles(context.Background(), token, profile)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	pr := prs[0]
+
+	cases := []struct {
+		desc   string
+		thing  map[string]interface{}
+		status int
+	}{
+		{
+			desc:   "create thing from profile",
+			thing:  map[string]interface{}{"name": "a", "profile_id": pr.ID, "metadata": map[string]interface{}{"serial": "a1"}},
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "create thing from profile with invalid metadata",
+			thing:  map[string]interface{}{"name": "b", "profile_id": pr.ID, "metadata": map[string]interface{}{"serial": 1}},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "create thing from profile with invalid profile id",
+			thing:  map[string]interface{}{"name": "c", "profile_id": wrongValue},
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "create thing without profile",
+			thing:  map[string]interface{}{"name": "d"},
+			status: http.StatusCreated,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/things", ts.URL),
+			contentType: contentType,
+			token:       token,
+			body:        strings.NewReader(toJSON([]map[string]interface{}{tc.thing})),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/profiles/%s/things", ts.URL, pr.ID),
+		token:  token,
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusOK, res.StatusCode))
+
+	var body thingsPageRes
+	err = json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	require.Len(t, body.Things, 1)
+	metadata := map[string]interface{}{"serial": "a1", "interval": float64(60)}
+	assert.Equal(t, metadata, body.Things[0].Metadata, fmt.Sprintf("expected metadata %v got %v", metadata, body.Things[0].Metadata))
+}
+
+func TestConnect(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
 	defer ts.Close()
 
 	ths, err := svc.CreateThings(context.Background(), token, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
 	thIDs := []string{}
 	for _, th := range ths {
 		thIDs = append(thIDs, th.ID)
 	}
 
-	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	chs1, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	ch := chs[0]
+	ch1 := chs1[0]
 
-	uCh, err := svc.CreateChannels(context.Background(), otherToken, channel)
+	chs2, err := svc.CreateChannels(context.Background(), otherToken, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	usrCh := uCh[0]
+	ch2 := chs2[0]
 
 	grs, err := svc.CreateGroups(context.Background(), token, group)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
@@ -2299,31 +3065,41 @@ func TestDisconnect(t *testing.T) {
 	err = svc.AssignThing(context.Background(), token, gr.ID, thIDs...)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+	err = svc.AssignChannel(context.Background(), token, gr.ID, ch1.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, ch.ID, thIDs)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignChannel(context.Background(), token, gr.ID, ch2.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	cases := []struct {
 		desc        string
 		channelID   string
 		thingIDs    []string
+		connType    string
 		auth        string
 		contentType string
 		body        string
 		status      int
 	}{
 		{
-			desc:        "disconnect existing things from existing channels",
-			channelID:   ch.ID,
+			desc:        "connect existing things to existing channel",
+			channelID:   ch1.ID,
 			thingIDs:    thIDs,
 			auth:        token,
 			contentType: contentType,
 			status:      http.StatusOK,
 		},
 		{
-			desc:        "disconnect existing things from non-existent channels",
+			desc:        "connect existing things to existing channel with invalid connection type",
+			channelID:   ch1.ID,
+			thingIDs:    thIDs,
+			connType:    "invalid",
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "connect existing things to non-existent channel",
 			channelID:   strconv.FormatUint(wrongID, 10),
 			thingIDs:    thIDs,
 			auth:        token,
@@ -2331,15 +3107,15 @@ func TestDisconnect(t *testing.T) {
 			status:      http.StatusNotFound,
 		},
 		{
-			desc:        "disconnect non-existing things from existing channels",
-			channelID:   ch.ID,
+			desc:        "connect non-existing things to existing channel",
+			channelID:   ch1.ID,
 			thingIDs:    []string{strconv.FormatUint(wrongID, 10)},
 			auth:        token,
 			contentType: contentType,
 			status:      http.StatusNotFound,
 		},
 		{
-			desc:        "disconnect existing things from channel with invalid id",
+			desc:        "connect existing things to channel with invalid id",
 			channelID:   "invalid",
 			thingIDs:    thIDs,
 			auth:        token,
@@ -2347,15 +3123,15 @@ func TestDisconnect(t *testing.T) {
 			status:      http.StatusNotFound,
 		},
 		{
-			desc:        "disconnect things with invalid id from existing channels",
-			channelID:   ch.ID,
+			desc:        "connect things with invalid id to existing channel",
+			channelID:   ch1.ID,
 			thingIDs:    []string{"invalid"},
 			auth:        token,
 			contentType: contentType,
 			status:      http.StatusNotFound,
 		},
 		{
-			desc:        "disconnect existing things from empty channel ids",
+			desc:        "connect existing things to empty channel id",
 			channelID:   "",
 			thingIDs:    thIDs,
 			auth:        token,
@@ -2363,54 +3139,54 @@ func TestDisconnect(t *testing.T) {
 			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "disconnect empty things id from existing channels",
-			channelID:   ch.ID,
+			desc:        "connect empty things id to existing channel",
+			channelID:   ch1.ID,
 			thingIDs:    []string{""},
 			auth:        token,
 			contentType: contentType,
 			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "disconnect existing things from existing channels with invalid token",
-			channelID:   ch.ID,
+			desc:        "connect existing things to existing channel with invalid token",
+			channelID:   ch1.ID,
 			thingIDs:    thIDs,
 			auth:        wrongValue,
 			contentType: contentType,
 			status:      http.StatusUnauthorized,
 		},
 		{
-			desc:        "disconnect existing things from existing channels with empty token",
-			channelID:   ch.ID,
+			desc:        "connect existing things to existing channel with empty token",
+			channelID:   ch1.ID,
 			thingIDs:    thIDs,
 			auth:        "",
 			contentType: contentType,
 			status:      http.StatusUnauthorized,
 		},
 		{
-			desc:        "disconnect things from channels of other user",
-			channelID:   usrCh.ID,
+			desc:        "connect things from owner to channel of other user",
+			channelID:   ch2.ID,
 			thingIDs:    thIDs,
 			auth:        token,
 			contentType: contentType,
-			status:      http.StatusNotFound,
+			status:      http.StatusForbidden,
 		},
 		{
-			desc:        "disconnect with invalid content type",
-			channelID:   ch.ID,
+			desc:        "connect with invalid content type",
+			channelID:   ch2.ID,
 			thingIDs:    thIDs,
 			auth:        token,
 			contentType: "invalid",
 			status:      http.StatusUnsupportedMediaType,
 		},
 		{
-			desc:        "disconnect with invalid JSON",
+			desc:        "connect with invalid JSON",
 			auth:        token,
 			contentType: contentType,
 			status:      http.StatusBadRequest,
 			body:        "{",
 		},
 		{
-			desc:        "disconnect valid thing ids from empty channel ids",
+			desc:        "connect valid thing ids with empty channel id",
 			channelID:   "",
 			thingIDs:    thIDs,
 			auth:        token,
@@ -2418,15 +3194,15 @@ func TestDisconnect(t *testing.T) {
 			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "disconnect empty thing ids from valid channel ids",
-			channelID:   ch.ID,
+			desc:        "connect valid channel id with empty thing ids",
+			channelID:   ch2.ID,
 			thingIDs:    []string{},
 			auth:        token,
 			contentType: contentType,
 			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "disconnect empty thing ids from empty channel ids",
+			desc:        "connect empty channel id and empty thing ids",
 			channelID:   "",
 			thingIDs:    []string{},
 			auth:        token,
@@ -2439,103 +3215,639 @@ func TestDisconnect(t *testing.T) {
 		data := struct {
 			ChannelID string   `json:"channel_id"`
 			ThingIDs  []string `json:"thing_ids"`
+			Type      string   `json:"type,omitempty"`
 		}{
 			tc.channelID,
 			tc.thingIDs,
+			tc.connType,
 		}
 		body := toJSON(data)
 
-		if tc.body != "" {
-			body = tc.body
+		if tc.body != "" {
+			body = tc.body
+		}
+
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/connect", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(body),
+		}
+
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	thIDs := []string{}
+	for _, th := range ths {
+		thIDs = append(thIDs, th.ID)
+	}
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	uCh, err := svc.CreateChannels(context.Background(), otherToken, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	usrCh := uCh[0]
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, thIDs...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.Connect(context.Background(), token, ch.ID, thIDs, things.ConnTypePubSub)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc        string
+		channelID   string
+		thingIDs    []string
+		auth        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{
+			desc:        "disconnect existing things from existing channels",
+			channelID:   ch.ID,
+			thingIDs:    thIDs,
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "disconnect existing things from non-existent channels",
+			channelID:   strconv.FormatUint(wrongID, 10),
+			thingIDs:    thIDs,
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "disconnect non-existing things from existing channels",
+			channelID:   ch.ID,
+			thingIDs:    []string{strconv.FormatUint(wrongID, 10)},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "disconnect existing things from channel with invalid id",
+			channelID:   "invalid",
+			thingIDs:    thIDs,
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "disconnect things with invalid id from existing channels",
+			channelID:   ch.ID,
+			thingIDs:    []string{"invalid"},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "disconnect existing things from empty channel ids",
+			channelID:   "",
+			thingIDs:    thIDs,
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "disconnect empty things id from existing channels",
+			channelID:   ch.ID,
+			thingIDs:    []string{""},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "disconnect existing things from existing channels with invalid token",
+			channelID:   ch.ID,
+			thingIDs:    thIDs,
+			auth:        wrongValue,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "disconnect existing things from existing channels with empty token",
+			channelID:   ch.ID,
+			thingIDs:    thIDs,
+			auth:        "",
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "disconnect things from channels of other user",
+			channelID:   usrCh.ID,
+			thingIDs:    thIDs,
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "disconnect with invalid content type",
+			channelID:   ch.ID,
+			thingIDs:    thIDs,
+			auth:        token,
+			contentType: "invalid",
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "disconnect with invalid JSON",
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			body:        "{",
+		},
+		{
+			desc:        "disconnect valid thing ids from empty channel ids",
+			channelID:   "",
+			thingIDs:    thIDs,
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "disconnect empty thing ids from valid channel ids",
+			channelID:   ch.ID,
+			thingIDs:    []string{},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "disconnect empty thing ids from empty channel ids",
+			channelID:   "",
+			thingIDs:    []string{},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		data := struct {
+			ChannelID string   `json:"channel_id"`
+			ThingIDs  []string `json:"thing_ids"`
+		}{
+			tc.channelID,
+			tc.thingIDs,
+		}
+		body := toJSON(data)
+
+		if tc.body != "" {
+			body = tc.body
+		}
+
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/disconnect", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRemoveGroups(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	var groups []things.Group
+	var groupIDs []string
+	for i := uint64(0); i < 10; i++ {
+		num := strconv.FormatUint(i, 10)
+		group := things.Group{
+			Name:        "test-group-" + num,
+			Description: "test group desc",
+		}
+		grs, err := svc.CreateGroups(context.Background(), token, group)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		gr := grs[0]
+
+		groups = append(groups, gr)
+		groupIDs = append(groupIDs, gr.ID)
+	}
+
+	cases := []struct {
+		desc        string
+		data        []string
+		auth        string
+		contentType string
+		status      int
+	}{
+		{
+			desc:        "remove existing groups",
+			data:        groupIDs,
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:        "remove non-existent groups",
+			data:        []string{wrongValue},
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "remove groups with invalid token",
+			data:        groupIDs,
+			auth:        wrongValue,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "remove groups without group ids",
+			data:        []string{},
+			auth:        wrongValue,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "remove groups with empty group ids",
+			data:        []string{""},
+			auth:        wrongValue,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "remove groups with empty token",
+			data:        groupIDs,
+			auth:        "",
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "remove groups with invalid content type",
+			data:        groupIDs,
+			auth:        token,
+			contentType: wrongValue,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		data := struct {
+			GroupIDs []string `json:"group_ids"`
+		}{
+			tc.data,
+		}
+
+		body := toJSON(data)
+
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPatch,
+			url:         fmt.Sprintf("%s/groups", ts.URL),
+			token:       tc.auth,
+			contentType: tc.contentType,
+			body:        strings.NewReader(body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRestoreGroups(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	grs, err := svc.CreateGroups(context.Background(), token, things.Group{Name: "test-group"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.RemoveGroups(context.Background(), token, grs[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		data   []string
+		auth   string
+		status int
+	}{
+		{
+			desc:   "restore groups with invalid token",
+			data:   []string{grs[0].ID},
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "restore groups without ids",
+			data:   []string{""},
+			auth:   token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "restore removed groups",
+			data:   []string{grs[0].ID},
+			auth:   token,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "restore groups that are not in the trash",
+			data:   []string{grs[0].ID},
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		data := struct {
+			GroupIDs []string `json:"group_ids"`
+		}{
+			tc.data,
+		}
+
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/trash/groups/restore", ts.URL),
+			token:       tc.auth,
+			contentType: contentType,
+			body:        strings.NewReader(toJSON(data)),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestGroupTags(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	grs, err := svc.CreateGroups(context.Background(), token, things.Group{Name: "test-group"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		method string
+		ids    []string
+		tags   []string
+		auth   string
+		status int
+	}{
+		{
+			desc:   "add tags with invalid token",
+			method: http.MethodPost,
+			ids:    []string{grs[0].ID},
+			tags:   []string{"site:berlin"},
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "add tags without ids",
+			method: http.MethodPost,
+			ids:    []string{""},
+			tags:   []string{"site:berlin"},
+			auth:   token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "add tags to group",
+			method: http.MethodPost,
+			ids:    []string{grs[0].ID},
+			tags:   []string{"site:berlin"},
+			auth:   token,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "remove tags from group",
+			method: http.MethodPatch,
+			ids:    []string{grs[0].ID},
+			tags:   []string{"site:berlin"},
+			auth:   token,
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		data := struct {
+			IDs  []string `json:"ids"`
+			Tags []string `json:"tags"`
+		}{
+			tc.ids,
+			tc.tags,
+		}
+
+		req := testRequest{
+			client:      ts.Client(),
+			method:      tc.method,
+			url:         fmt.Sprintf("%s/groups/tags", ts.URL),
+			token:       tc.auth,
+			contentType: contentType,
+			body:        strings.NewReader(toJSON(data)),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestListGroupChildren(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	grs, err := svc.CreateGroups(context.Background(), token, things.Group{Name: "site"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	site := grs[0]
+
+	grs, err = svc.CreateGroups(context.Background(), token, things.Group{Name: "building", ParentID: site.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	building := grs[0]
+
+	_, err = svc.CreateGroups(context.Background(), token, things.Group{Name: "floor", ParentID: building.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		url    string
+		auth   string
+		status int
+		total  uint64
+	}{
+		{
+			desc:   "list direct children",
+			url:    fmt.Sprintf("%s/groups/%s/children", ts.URL, site.ID),
+			auth:   token,
+			status: http.StatusOK,
+			total:  1,
+		},
+		{
+			desc:   "list children with depth",
+			url:    fmt.Sprintf("%s/groups/%s/children?depth=2", ts.URL, site.ID),
+			auth:   token,
+			status: http.StatusOK,
+			total:  2,
+		},
+		{
+			desc:   "list children with depth exceeding the limit",
+			url:    fmt.Sprintf("%s/groups/%s/children?depth=10", ts.URL, site.ID),
+			auth:   token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list children with invalid depth",
+			url:    fmt.Sprintf("%s/groups/%s/children?depth=0", ts.URL, site.ID),
+			auth:   token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list children of non-existent group",
+			url:    fmt.Sprintf("%s/groups/%s/children", ts.URL, wrongValue),
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "list children with invalid token",
+			url:    fmt.Sprintf("%s/groups/%s/children", ts.URL, site.ID),
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		if tc.status != http.StatusOK {
+			continue
 		}
 
-		req := testRequest{
-			client:      ts.Client(),
-			method:      http.MethodPut,
-			url:         fmt.Sprintf("%s/disconnect", ts.URL),
-			contentType: tc.contentType,
-			token:       tc.auth,
-			body:        strings.NewReader(body),
+		var body struct {
+			Total  uint64 `json:"total"`
+			Groups []struct {
+				ParentID string   `json:"parent_id"`
+				Path     []string `json:"path"`
+			} `json:"groups"`
 		}
-		res, err := req.make()
+		err = json.NewDecoder(res.Body).Decode(&body)
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, body.Total))
+		assert.Equal(t, site.ID, body.Groups[0].Path[0], fmt.Sprintf("%s: expected path to start with %s got %v", tc.desc, site.ID, body.Groups[0].Path))
 	}
 }
 
-func TestRemoveGroups(t *testing.T) {
+func TestMoveGroup(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
 	defer ts.Close()
 
-	var groups []things.Group
-	var groupIDs []string
-	for i := uint64(0); i < 10; i++ {
-		num := strconv.FormatUint(i, 10)
-		group := things.Group{
-			Name:        "test-group-" + num,
-			Description: "test group desc",
-		}
-		grs, err := svc.CreateGroups(context.Background(), token, group)
-		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-		gr := grs[0]
+	grs, err := svc.CreateGroups(context.Background(), token, things.Group{Name: "site"}, things.Group{Name: "building"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	site, building := grs[0], grs[1]
 
-		groups = append(groups, gr)
-		groupIDs = append(groupIDs, gr.ID)
-	}
+	grs, err = svc.CreateGroups(context.Background(), token, things.Group{Name: "floor", ParentID: building.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	floor := grs[0]
+
+	grs, err = svc.CreateGroups(context.Background(), otherToken, things.Group{Name: "other"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	other := grs[0]
 
 	cases := []struct {
 		desc        string
-		data        []string
+		id          string
+		parentID    string
 		auth        string
 		contentType string
 		status      int
 	}{
 		{
-			desc:        "remove existing groups",
-			data:        groupIDs,
+			desc:        "move group under a new parent",
+			id:          building.ID,
+			parentID:    site.ID,
 			auth:        token,
 			contentType: contentType,
-			status:      http.StatusNoContent,
+			status:      http.StatusOK,
 		},
 		{
-			desc:        "remove non-existent groups",
-			data:        []string{wrongValue},
+			desc:        "move group under its descendant",
+			id:          site.ID,
+			parentID:    floor.ID,
 			auth:        token,
 			contentType: contentType,
-			status:      http.StatusNotFound,
+			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "remove groups with invalid token",
-			data:        groupIDs,
-			auth:        wrongValue,
+			desc:        "move group under itself",
+			id:          site.ID,
+			parentID:    site.ID,
+			auth:        token,
 			contentType: contentType,
-			status:      http.StatusUnauthorized,
+			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "remove groups without group ids",
-			data:        []string{},
-			auth:        wrongValue,
+			desc:        "move group under non-existent parent",
+			id:          building.ID,
+			parentID:    wrongValue,
+			auth:        token,
 			contentType: contentType,
 			status:      http.StatusBadRequest,
 		},
 		{
-			desc:        "remove groups with empty group ids",
-			data:        []string{""},
-			auth:        wrongValue,
+			desc:        "move group under parent of another user",
+			id:          building.ID,
+			parentID:    other.ID,
+			auth:        token,
 			contentType: contentType,
-			status:      http.StatusBadRequest,
+			status:      http.StatusForbidden,
 		},
 		{
-			desc:        "remove groups with empty token",
-			data:        groupIDs,
-			auth:        "",
+			desc:        "move group to the root",
+			id:          floor.ID,
+			parentID:    "",
+			auth:        token,
+			contentType: contentType,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "move group with invalid token",
+			id:          building.ID,
+			parentID:    site.ID,
+			auth:        wrongValue,
 			contentType: contentType,
 			status:      http.StatusUnauthorized,
 		},
 		{
-			desc:        "remove groups with invalid content type",
-			data:        groupIDs,
+			desc:        "move group with invalid content type",
+			id:          building.ID,
+			parentID:    site.ID,
 			auth:        token,
 			contentType: wrongValue,
 			status:      http.StatusUnsupportedMediaType,
@@ -2544,25 +3856,29 @@ func TestRemoveGroups(t *testing.T) {
 
 	for _, tc := range cases {
 		data := struct {
-			GroupIDs []string `json:"group_ids"`
+			ParentID string `json:"parent_id"`
 		}{
-			tc.data,
+			tc.parentID,
 		}
 
-		body := toJSON(data)
-
 		req := testRequest{
 			client:      ts.Client(),
-			method:      http.MethodPatch,
-			url:         fmt.Sprintf("%s/groups", ts.URL),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/groups/%s/parent", ts.URL, tc.id),
 			token:       tc.auth,
 			contentType: tc.contentType,
-			body:        strings.NewReader(body),
+			body:        strings.NewReader(toJSON(data)),
 		}
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 	}
+
+	err = svc.RemoveGroups(context.Background(), token, site.ID)
+	assert.True(t, errors.Contains(err, things.ErrGroupHasChildren), fmt.Sprintf("remove group with children: expected %s got %s", things.ErrGroupHasChildren, err))
+
+	err = svc.RemoveGroups(context.Background(), token, site.ID, building.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove group with its children: unexpected error %s", err))
 }
 
 func TestBackup(t *testing.T) {
@@ -2633,7 +3949,7 @@ func TestBackup(t *testing.T) {
 	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
+	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID}, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	connections := []things.Connection{}
@@ -2969,6 +4285,28 @@ type thingRes struct {
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
+type profileRes struct {
+	ID       string                 `json:"id"`
+	Name     string                 `json:"name"`
+	Schema   map[string]interface{} `json:"schema,omitempty"`
+	Defaults map[string]interface{} `json:"defaults,omitempty"`
+}
+
+type thingKeyRes struct {
+	ID      string `json:"id"`
+	ThingID string `json:"thing_id"`
+	Name    string `json:"name,omitempty"`
+	Key     string `json:"key"`
+	Enabled bool   `json:"enabled"`
+}
+
+type thingKeysPageRes struct {
+	Keys   []thingKeyRes `json:"keys"`
+	Total  uint64        `json:"total"`
+	Offset uint64        `json:"offset"`
+	Limit  uint64        `json:"limit"`
+}
+
 type channelRes struct {
 	ID       string                 `json:"id"`
 	Name     string                 `json:"name,omitempty"`
@@ -3095,3 +4433,278 @@ type restoreReq struct {
 	GroupThingRelations   []restoreGroupThingRelationReq   `json:"group_thing_relations"`
 	GroupChannelRelations []restoreGroupChannelRelationReq `json:"group_channel_relations"`
 }
+
+func TestExportBackup(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	grs, err := svc.CreateGroups(context.Background(), token, things.Group{Name: "test-group"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.AssignThing(context.Background(), token, gr.ID, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	exportURL := fmt.Sprintf("%s/backup/export", ts.URL)
+
+	cases := []struct {
+		desc    string
+		auth    string
+		url     string
+		status  int
+		records int
+	}{
+		{
+			desc:    "export full backup",
+			auth:    adminToken,
+			url:     exportURL,
+			status:  http.StatusOK,
+			records: 4,
+		},
+		{
+			desc:    "export backup of group as group owner",
+			auth:    token,
+			url:     fmt.Sprintf("%s?group_id=%s", exportURL, gr.ID),
+			status:  http.StatusOK,
+			records: 4,
+		},
+		{
+			desc:    "export incremental backup",
+			auth:    adminToken,
+			url:     fmt.Sprintf("%s?since=%s", exportURL, time.Now().Add(time.Hour).UTC().Format(time.RFC3339)),
+			status:  http.StatusOK,
+			records: 1,
+		},
+		{
+			desc:   "export full backup as user",
+			auth:   token,
+			url:    exportURL,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "export backup with invalid since",
+			auth:   adminToken,
+			url:    fmt.Sprintf("%s?since=%s", exportURL, wrongValue),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export backup with empty token",
+			auth:   "",
+			url:    exportURL,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, backup.ContentType, res.Header.Get("Content-Type"), fmt.Sprintf("%s: unexpected content type", tc.desc))
+		records := strings.Count(string(body), "\n")
+		assert.Equal(t, tc.records, records, fmt.Sprintf("%s: expected %d records got %d", tc.desc, tc.records, records))
+	}
+}
+
+func TestImportBackup(t *testing.T) {
+	src := newService()
+	grs, err := src.CreateGroups(context.Background(), token, things.Group{Name: "test-group"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ths, err := src.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	chs, err := src.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = src.AssignThing(context.Background(), token, grs[0].ID, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = src.AssignChannel(context.Background(), token, grs[0].ID, chs[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = src.Connect(context.Background(), token, chs[0].ID, []string{ths[0].ID}, things.ConnTypePubSub)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	stream, err := src.ExportBackup(context.Background(), adminToken, things.BackupFilter{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	var buf strings.Builder
+	err = stream(backup.NewEncoder(&buf))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	data := buf.String()
+
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	importURL := fmt.Sprintf("%s/backup/import", ts.URL)
+
+	cases := []struct {
+		desc        string
+		auth        string
+		url         string
+		contentType string
+		data        string
+		status      int
+		res         backup.Summary
+	}{
+		{
+			desc:        "import backup",
+			auth:        adminToken,
+			url:         importURL,
+			contentType: backup.ContentType,
+			data:        data,
+			status:      http.StatusCreated,
+			res:         backup.Summary{Created: 6},
+		},
+		{
+			desc:        "import existing backup skipping conflicts",
+			auth:        adminToken,
+			url:         fmt.Sprintf("%s?strategy=%s", importURL, backup.StrategySkip),
+			contentType: backup.ContentType,
+			data:        data,
+			status:      http.StatusCreated,
+			res:         backup.Summary{Skipped: 6},
+		},
+		{
+			desc:        "import existing backup failing on conflicts",
+			auth:        adminToken,
+			url:         importURL,
+			contentType: backup.ContentType,
+			data:        data,
+			status:      http.StatusConflict,
+		},
+		{
+			desc:        "import backup with invalid strategy",
+			auth:        adminToken,
+			url:         fmt.Sprintf("%s?strategy=%s", importURL, wrongValue),
+			contentType: backup.ContentType,
+			data:        data,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "import malformed backup",
+			auth:        adminToken,
+			url:         importURL,
+			contentType: backup.ContentType,
+			data:        "{}\n",
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "import backup with invalid content type",
+			auth:        adminToken,
+			url:         importURL,
+			contentType: contentType,
+			data:        data,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "import backup as user",
+			auth:        token,
+			url:         importURL,
+			contentType: backup.ContentType,
+			data:        data,
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         tc.url,
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusCreated {
+			continue
+		}
+
+		var body backup.Summary
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected summary %v got %v", tc.desc, tc.res, body))
+	}
+}
+
+func TestViewUsage(t *testing.T) {
+	quotas := map[string]*mainflux.QuotaRes{user.ID: {Things: 1}}
+	svc := newServiceWithQuotas(quotas)
+	ts := newServer(svc)
+	defer ts.Close()
+
+	_, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	req := testRequest{
+		client:      ts.Client(),
+		method:      http.MethodPost,
+		url:         fmt.Sprintf("%s/things", ts.URL),
+		contentType: contentType,
+		token:       token,
+		body:        strings.NewReader(toJSON([]things.Thing{thing})),
+	}
+	res, err := req.make()
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode, fmt.Sprintf("create thing over quota: expected status code %d got %d", http.StatusTooManyRequests, res.StatusCode))
+
+	cases := []struct {
+		desc   string
+		auth   string
+		status int
+		usage  map[string]quota.Usage
+	}{
+		{
+			desc:   "view usage",
+			auth:   token,
+			status: http.StatusOK,
+			usage: map[string]quota.Usage{
+				quota.Things:   {Used: 1, Limit: 1},
+				quota.Channels: {Used: 0, Limit: 0},
+				quota.Groups:   {Used: 0, Limit: 0},
+			},
+		},
+		{
+			desc:   "view usage with invalid token",
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view usage with empty token",
+			auth:   "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/usage", ts.URL),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		var usage map[string]quota.Usage
+		if res.StatusCode == http.StatusOK {
+			err = json.NewDecoder(res.Body).Decode(&usage)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		}
+		assert.Equal(t, tc.usage, usage, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.usage, usage))
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/gofrs/uuid"
 )
@@ -14,6 +16,8 @@ import (
 const (
 	maxLimitSize = 100
 	maxNameSize  = 1024
+	maxDepth     = 5
+	maxKeySize   = 4096
 	nameOrder    = "name"
 	idOrder      = "id"
 	ascDir       = "asc"
@@ -21,10 +25,12 @@ const (
 )
 
 type createThingReq struct {
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key,omitempty"`
-	ID       string                 `json:"id,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Name      string                 `json:"name,omitempty"`
+	Key       string                 `json:"key,omitempty"`
+	ID        string                 `json:"id,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID string                 `json:"profile_id,omitempty"`
+	Tags      []string               `json:"tags,omitempty"`
 }
 
 type createThingsReq struct {
@@ -51,6 +57,12 @@ func (req createThingsReq) validate() error {
 		if len(thing.Name) > maxNameSize {
 			return apiutil.ErrNameSize
 		}
+
+		if thing.ProfileID != "" {
+			if err := validateUUID(thing.ProfileID); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -61,6 +73,7 @@ type updateThingReq struct {
 	id       string
 	Name     string                 `json:"name,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Tags     []string               `json:"tags,omitempty"`
 }
 
 func (req updateThingReq) validate() error {
@@ -83,6 +96,9 @@ type updateKeyReq struct {
 	token string
 	id    string
 	Key   string `json:"key"`
+	// GracePeriod is the number of seconds during which the previous
+	// key remains valid.
+	GracePeriod uint64 `json:"grace_period,omitempty"`
 }
 
 func (req updateKeyReq) validate() error {
@@ -101,10 +117,107 @@ func (req updateKeyReq) validate() error {
 	return nil
 }
 
+type createThingKeyReq struct {
+	token     string
+	thingID   string
+	Name      string     `json:"name,omitempty"`
+	Key       string     `json:"key,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func (req createThingKeyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	if len(req.Key) > maxKeySize {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type updateThingKeyReq struct {
+	token     string
+	thingID   string
+	keyID     string
+	Name      string     `json:"name,omitempty"`
+	Enabled   bool       `json:"enabled"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func (req updateThingKeyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.thingID == "" || req.keyID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+type thingKeyReq struct {
+	token   string
+	thingID string
+	keyID   string
+}
+
+func (req thingKeyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.thingID == "" || req.keyID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listThingKeysReq struct {
+	token   string
+	thingID string
+	offset  uint64
+	limit   uint64
+}
+
+func (req listThingKeysReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.thingID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
 type createChannelReq struct {
-	Name     string                 `json:"name,omitempty"`
-	ID       string                 `json:"id,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Name          string                 `json:"name,omitempty"`
+	ID            string                 `json:"id,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID     string                 `json:"profile_id,omitempty"`
+	PayloadSchema schema.Schema          `json:"payload_schema,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
 }
 
 type createChannelsReq struct {
@@ -131,16 +244,24 @@ func (req createChannelsReq) validate() error {
 		if len(channel.Name) > maxNameSize {
 			return apiutil.ErrNameSize
 		}
+
+		if channel.ProfileID != "" {
+			if err := validateUUID(channel.ProfileID); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
 type updateChannelReq struct {
-	token    string
-	id       string
-	Name     string                 `json:"name,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	token         string
+	id            string
+	Name          string                 `json:"name,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	PayloadSchema schema.Schema          `json:"payload_schema,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
 }
 
 func (req updateChannelReq) validate() error {
@@ -205,6 +326,61 @@ func (req removeChannelsReq) validate() error {
 	return nil
 }
 
+type createProfileReq struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Schema      map[string]interface{} `json:"schema,omitempty"`
+	Defaults    map[string]interface{} `json:"defaults,omitempty"`
+}
+
+type createProfilesReq struct {
+	token    string
+	Profiles []createProfileReq
+}
+
+func (req createProfilesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if len(req.Profiles) <= 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	for _, pr := range req.Profiles {
+		if pr.Name == "" || len(pr.Name) > maxNameSize {
+			return apiutil.ErrNameSize
+		}
+	}
+
+	return nil
+}
+
+type updateProfileReq struct {
+	token       string
+	id          string
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Schema      map[string]interface{} `json:"schema,omitempty"`
+	Defaults    map[string]interface{} `json:"defaults,omitempty"`
+}
+
+func (req updateProfileReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Name == "" || len(req.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
 type viewResourceReq struct {
 	token string
 	id    string
@@ -251,7 +427,13 @@ func (req *listResourcesReq) validate() error {
 		return apiutil.ErrInvalidDirection
 	}
 
-	return nil
+	if req.pageMetadata.GroupID != "" {
+		if err := validateUUID(req.pageMetadata.GroupID); err != nil {
+			return err
+		}
+	}
+
+	return req.pageMetadata.ValidateFilters()
 }
 
 type listByConnectionReq struct {
@@ -290,6 +472,7 @@ type connectionsReq struct {
 	token     string
 	ChannelID string   `json:"channel_id,omitempty"`
 	ThingIDs  []string `json:"thing_ids,omitempty"`
+	Type      string   `json:"type,omitempty"`
 }
 
 func (req connectionsReq) validate() error {
@@ -311,6 +494,12 @@ func (req connectionsReq) validate() error {
 		}
 	}
 
+	if req.Type != "" {
+		if err := things.ValidateConnType(req.Type); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -327,18 +516,44 @@ func (req backupReq) validate() error {
 }
 
 type restoreThingReq struct {
-	ID       string                 `json:"id"`
-	Owner    string                 `json:"owner"`
-	Name     string                 `json:"name"`
-	Key      string                 `json:"key"`
-	Metadata map[string]interface{} `json:"metadata"`
+	ID        string                 `json:"id"`
+	Owner     string                 `json:"owner"`
+	Name      string                 `json:"name"`
+	Key       string                 `json:"key"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	ProfileID string                 `json:"profile_id"`
+	Tags      []string               `json:"tags"`
+}
+
+type restoreThingKeyReq struct {
+	ID        string     `json:"id"`
+	ThingID   string     `json:"thing_id"`
+	Name      string     `json:"name"`
+	Key       string     `json:"key"`
+	Enabled   bool       `json:"enabled"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
 }
 
 type restoreChannelReq struct {
-	ID       string                 `json:"id"`
-	Owner    string                 `json:"owner"`
-	Name     string                 `json:"name"`
-	Metadata map[string]interface{} `json:"metadata"`
+	ID            string                 `json:"id"`
+	Owner         string                 `json:"owner"`
+	Name          string                 `json:"name"`
+	Metadata      map[string]interface{} `json:"metadata"`
+	ProfileID     string                 `json:"profile_id"`
+	PayloadSchema schema.Schema          `json:"payload_schema"`
+	Tags          []string               `json:"tags"`
+}
+
+type restoreProfileReq struct {
+	ID          string                 `json:"id"`
+	OwnerID     string                 `json:"owner_id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Schema      map[string]interface{} `json:"schema"`
+	Defaults    map[string]interface{} `json:"defaults"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
 }
 
 type restoreConnectionReq struct {
@@ -346,14 +561,18 @@ type restoreConnectionReq struct {
 	ChannelOwner string `json:"channel_owner"`
 	ThingID      string `json:"thing_id"`
 	ThingOwner   string `json:"thing_owner"`
+	Type         string `json:"type"`
 }
 
 type restoreGroupReq struct {
 	ID          string                 `json:"id"`
 	Name        string                 `json:"name"`
 	OwnerID     string                 `json:"owner_id"`
+	ParentID    string                 `json:"parent_id"`
+	Path        []string               `json:"path"`
 	Description string                 `json:"description"`
 	Metadata    map[string]interface{} `json:"metadata"`
+	Tags        []string               `json:"tags"`
 	CreatedAt   time.Time              `json:"created_at"`
 	UpdatedAt   time.Time              `json:"updated_at"`
 }
@@ -375,8 +594,10 @@ type restoreGroupChannelRelationReq struct {
 type restoreReq struct {
 	token                 string
 	Things                []restoreThingReq                `json:"things"`
+	ThingKeys             []restoreThingKeyReq             `json:"thing_keys"`
 	Channels              []restoreChannelReq              `json:"channels"`
 	Connections           []restoreConnectionReq           `json:"connections"`
+	Profiles              []restoreProfileReq              `json:"profiles"`
 	Groups                []restoreGroupReq                `json:"groups"`
 	GroupThingRelations   []restoreGroupThingRelationReq   `json:"group_thing_relations"`
 	GroupChannelRelations []restoreGroupChannelRelationReq `json:"group_channel_relations"`
@@ -396,8 +617,10 @@ func (req restoreReq) validate() error {
 
 type createGroupReq struct {
 	Name        string                 `json:"name,omitempty"`
+	ParentID    string                 `json:"parent_id,omitempty"`
 	Description string                 `json:"description,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
 }
 
 type createGroupsReq struct {
@@ -429,6 +652,7 @@ type updateGroupReq struct {
 	Name        string                 `json:"name,omitempty"`
 	Description string                 `json:"description,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
 }
 
 func (req updateGroupReq) validate() error {
@@ -459,6 +683,55 @@ func (req listGroupsReq) validate() error {
 		return apiutil.ErrLimitSize
 	}
 
+	return req.pageMetadata.ValidateFilters()
+}
+
+type listGroupChildrenReq struct {
+	token        string
+	id           string
+	depth        uint64
+	pageMetadata things.PageMetadata
+}
+
+func (req listGroupChildrenReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.depth < 1 || req.depth > maxDepth {
+		return apiutil.ErrMaxLevelExceeded
+	}
+
+	if req.pageMetadata.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type moveGroupReq struct {
+	token    string
+	id       string
+	ParentID string `json:"parent_id"`
+}
+
+func (req moveGroupReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.ParentID == req.id {
+		return things.ErrInvalidParent
+	}
+
 	return nil
 }
 
@@ -571,6 +844,30 @@ func validateUUID(extID string) (err error) {
 	return nil
 }
 
+type tagsReq struct {
+	token string
+	IDs   []string `json:"ids,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+func (req tagsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if len(req.IDs) < 1 || len(req.Tags) < 1 {
+		return apiutil.ErrEmptyList
+	}
+
+	for _, id := range req.IDs {
+		if id == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
+
 type removeGroupsReq struct {
 	token    string
 	GroupIDs []string `json:"group_ids,omitempty"`
@@ -593,3 +890,44 @@ func (req removeGroupsReq) validate() error {
 
 	return nil
 }
+
+type exportBackupReq struct {
+	token   string
+	groupID string
+	since   time.Time
+}
+
+func (req exportBackupReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type importBackupReq struct {
+	token    string
+	strategy string
+	decoder  *backup.Decoder
+}
+
+func (req importBackupReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return backup.ValidateStrategy(req.strategy)
+}
+
+type viewUsageReq struct {
+	token string
+	orgID string
+}
+
+func (req viewUsageReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
@@ -4,15 +4,21 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 )
 
 var (
 	_ mainflux.Response = (*viewThingRes)(nil)
 	_ mainflux.Response = (*thingsPageRes)(nil)
+	_ mainflux.Response = (*thingKeyRes)(nil)
+	_ mainflux.Response = (*thingKeysPageRes)(nil)
 	_ mainflux.Response = (*viewChannelRes)(nil)
 	_ mainflux.Response = (*channelsPageRes)(nil)
 	_ mainflux.Response = (*connectionsRes)(nil)
@@ -21,9 +27,17 @@ var (
 	_ mainflux.Response = (*groupThingsPageRes)(nil)
 	_ mainflux.Response = (*groupChannelsPageRes)(nil)
 	_ mainflux.Response = (*groupsRes)(nil)
+	_ mainflux.Response = (*profilesRes)(nil)
+	_ mainflux.Response = (*viewProfileRes)(nil)
+	_ mainflux.Response = (*profilesPageRes)(nil)
 	_ mainflux.Response = (*removeRes)(nil)
 	_ mainflux.Response = (*assignRes)(nil)
 	_ mainflux.Response = (*unassignRes)(nil)
+	_ mainflux.Response = (*deletedThingsPageRes)(nil)
+	_ mainflux.Response = (*deletedChannelsPageRes)(nil)
+	_ mainflux.Response = (*deletedGroupsPageRes)(nil)
+	_ mainflux.Response = (*importBackupRes)(nil)
+	_ mainflux.Response = (*usageRes)(nil)
 )
 
 type removeRes struct{}
@@ -40,6 +54,20 @@ func (res removeRes) Empty() bool {
 	return true
 }
 
+type tagsRes struct{}
+
+func (res tagsRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res tagsRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res tagsRes) Empty() bool {
+	return true
+}
+
 type shareThingRes struct{}
 
 func (res shareThingRes) Code() int {
@@ -55,11 +83,13 @@ func (res shareThingRes) Empty() bool {
 }
 
 type thingRes struct {
-	ID       string                 `json:"id"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
-	created  bool
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name,omitempty"`
+	Key       string                 `json:"key"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID string                 `json:"profile_id,omitempty"`
+	Tags      []string               `json:"tags,omitempty"`
+	created   bool
 }
 
 type thingsRes struct {
@@ -84,11 +114,13 @@ func (res thingsRes) Empty() bool {
 }
 
 type viewThingRes struct {
-	ID       string                 `json:"id"`
-	Owner    string                 `json:"-"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID        string                 `json:"id"`
+	Owner     string                 `json:"-"`
+	Name      string                 `json:"name,omitempty"`
+	Key       string                 `json:"key"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID string                 `json:"profile_id,omitempty"`
+	Tags      []string               `json:"tags,omitempty"`
 }
 
 func (res viewThingRes) Code() int {
@@ -120,11 +152,64 @@ func (res thingsPageRes) Empty() bool {
 	return false
 }
 
+type thingKeyRes struct {
+	ID        string     `json:"id"`
+	ThingID   string     `json:"thing_id"`
+	Name      string     `json:"name,omitempty"`
+	Key       string     `json:"key"`
+	Enabled   bool       `json:"enabled"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	created   bool
+}
+
+func (res thingKeyRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res thingKeyRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/things/%s/keys/%s", res.ThingID, res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res thingKeyRes) Empty() bool {
+	return false
+}
+
+type thingKeysPageRes struct {
+	pageRes
+	Keys []thingKeyRes `json:"keys"`
+}
+
+func (res thingKeysPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res thingKeysPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res thingKeysPageRes) Empty() bool {
+	return false
+}
+
 type channelRes struct {
-	ID       string                 `json:"id"`
-	Name     string                 `json:"name,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
-	created  bool
+	ID            string                 `json:"id"`
+	Name          string                 `json:"name,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID     string                 `json:"profile_id,omitempty"`
+	PayloadSchema *schema.Schema         `json:"payload_schema,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
+	created       bool
 }
 
 type channelsRes struct {
@@ -149,11 +234,14 @@ func (res channelsRes) Empty() bool {
 }
 
 type viewChannelRes struct {
-	ID       string                 `json:"id"`
-	Owner    string                 `json:"-"`
-	Name     string                 `json:"name,omitempty"`
-	Things   []viewThingRes         `json:"connected,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID            string                 `json:"id"`
+	Owner         string                 `json:"-"`
+	Name          string                 `json:"name,omitempty"`
+	Things        []viewThingRes         `json:"connected,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID     string                 `json:"profile_id,omitempty"`
+	PayloadSchema *schema.Schema         `json:"payload_schema,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
 }
 
 func (res viewChannelRes) Code() int {
@@ -168,6 +256,15 @@ func (res viewChannelRes) Empty() bool {
 	return false
 }
 
+// payloadSchemaRes omits the empty payload schema from the response.
+func payloadSchemaRes(s schema.Schema) *schema.Schema {
+	if s.Empty() {
+		return nil
+	}
+
+	return &s
+}
+
 type channelsPageRes struct {
 	pageRes
 	Channels []viewChannelRes `json:"channels"`
@@ -200,18 +297,23 @@ func (res connectionsRes) Empty() bool {
 }
 
 type backupThingRes struct {
-	ID       string                 `json:"id"`
-	Owner    string                 `json:"owner,omitempty"`
-	Name     string                 `json:"name,omitempty"`
-	Key      string                 `json:"key"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID        string                 `json:"id"`
+	Owner     string                 `json:"owner,omitempty"`
+	Name      string                 `json:"name,omitempty"`
+	Key       string                 `json:"key"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID string                 `json:"profile_id,omitempty"`
+	Tags      []string               `json:"tags,omitempty"`
 }
 
 type backupChannelRes struct {
-	ID       string                 `json:"id"`
-	Owner    string                 `json:"owner,omitempty"`
-	Name     string                 `json:"name,omitempty"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	ID            string                 `json:"id"`
+	Owner         string                 `json:"owner,omitempty"`
+	Name          string                 `json:"name,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID     string                 `json:"profile_id,omitempty"`
+	PayloadSchema *schema.Schema         `json:"payload_schema,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
 }
 
 type backupConnectionRes struct {
@@ -219,6 +321,7 @@ type backupConnectionRes struct {
 	ChannelOwner string `json:"channel_owner"`
 	ThingID      string `json:"thing_id"`
 	ThingOwner   string `json:"thing_owner"`
+	Type         string `json:"type"`
 }
 
 type backupGroupThingRelationRes struct {
@@ -237,8 +340,10 @@ type backupGroupChannelRelationRes struct {
 
 type backupRes struct {
 	Things                []backupThingRes                `json:"things"`
+	ThingKeys             []thingKeyRes                   `json:"thing_keys"`
 	Channels              []backupChannelRes              `json:"channels"`
 	Connections           []backupConnectionRes           `json:"connections"`
+	Profiles              []viewProfileRes                `json:"profiles"`
 	Groups                []viewGroupRes                  `json:"groups"`
 	GroupThingRelations   []backupGroupThingRelationRes   `json:"group_thing_relations"`
 	GroupChannelRelations []backupGroupChannelRelationRes `json:"group_channel_relations"`
@@ -270,6 +375,28 @@ func (res restoreRes) Empty() bool {
 	return true
 }
 
+type exportBackupRes struct {
+	stream backup.Stream
+}
+
+type importBackupRes struct {
+	Created uint64 `json:"created"`
+	Updated uint64 `json:"updated"`
+	Skipped uint64 `json:"skipped"`
+}
+
+func (res importBackupRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res importBackupRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res importBackupRes) Empty() bool {
+	return false
+}
+
 type pageRes struct {
 	Total  uint64 `json:"total"`
 	Offset uint64 `json:"offset"`
@@ -317,8 +444,11 @@ type viewGroupRes struct {
 	ID          string                 `json:"id"`
 	Name        string                 `json:"name"`
 	OwnerID     string                 `json:"owner_id"`
+	ParentID    string                 `json:"parent_id,omitempty"`
+	Path        []string               `json:"path,omitempty"`
 	Description string                 `json:"description,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
 	CreatedAt   time.Time              `json:"created_at"`
 	UpdatedAt   time.Time              `json:"updated_at"`
 }
@@ -338,8 +468,10 @@ func (res viewGroupRes) Empty() bool {
 type groupRes struct {
 	ID          string                 `json:"id"`
 	Name        string                 `json:"name,omitempty"`
+	ParentID    string                 `json:"parent_id,omitempty"`
 	Description string                 `json:"description,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
 	created     bool
 }
 
@@ -408,3 +540,172 @@ func (res unassignRes) Headers() map[string]string {
 func (res unassignRes) Empty() bool {
 	return true
 }
+
+type profileRes struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Schema      map[string]interface{} `json:"schema,omitempty"`
+	Defaults    map[string]interface{} `json:"defaults,omitempty"`
+	created     bool
+}
+
+type profilesRes struct {
+	Profiles []profileRes `json:"profiles"`
+	created  bool
+}
+
+func (res profilesRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res profilesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res profilesRes) Empty() bool {
+	return false
+}
+
+type viewProfileRes struct {
+	ID          string                 `json:"id"`
+	OwnerID     string                 `json:"owner_id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Schema      map[string]interface{} `json:"schema,omitempty"`
+	Defaults    map[string]interface{} `json:"defaults,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+func (res viewProfileRes) Code() int {
+	return http.StatusOK
+}
+
+func (res viewProfileRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res viewProfileRes) Empty() bool {
+	return false
+}
+
+type profilesPageRes struct {
+	pageRes
+	Profiles []viewProfileRes `json:"profiles"`
+}
+
+func (res profilesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res profilesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res profilesPageRes) Empty() bool {
+	return false
+}
+
+type deletedThingRes struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID  string                 `json:"profile_id,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
+	ChannelIDs []string               `json:"channel_ids,omitempty"`
+	GroupID    string                 `json:"group_id,omitempty"`
+	DeletedAt  time.Time              `json:"deleted_at"`
+}
+
+type deletedThingsPageRes struct {
+	pageRes
+	Things []deletedThingRes `json:"things"`
+}
+
+func (res deletedThingsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deletedThingsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deletedThingsPageRes) Empty() bool {
+	return false
+}
+
+type deletedChannelRes struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name,omitempty"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID string                 `json:"profile_id,omitempty"`
+	Tags      []string               `json:"tags,omitempty"`
+	ThingIDs  []string               `json:"thing_ids,omitempty"`
+	GroupID   string                 `json:"group_id,omitempty"`
+	DeletedAt time.Time              `json:"deleted_at"`
+}
+
+type deletedChannelsPageRes struct {
+	pageRes
+	Channels []deletedChannelRes `json:"channels"`
+}
+
+func (res deletedChannelsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deletedChannelsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deletedChannelsPageRes) Empty() bool {
+	return false
+}
+
+type deletedGroupRes struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	ParentID    string                 `json:"parent_id,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	ThingIDs    []string               `json:"thing_ids,omitempty"`
+	ChannelIDs  []string               `json:"channel_ids,omitempty"`
+	DeletedAt   time.Time              `json:"deleted_at"`
+}
+
+type deletedGroupsPageRes struct {
+	pageRes
+	Groups []deletedGroupRes `json:"groups"`
+}
+
+func (res deletedGroupsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deletedGroupsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deletedGroupsPageRes) Empty() bool {
+	return false
+}
+
+type usageRes map[string]quota.Usage
+
+func (res usageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res usageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res usageRes) Empty() bool {
+	return false
+}
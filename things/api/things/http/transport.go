@@ -8,11 +8,15 @@ import (
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/things"
 	kitot "github.com/go-kit/kit/tracing/opentracing"
@@ -34,16 +38,25 @@ const (
 	groupIDKey    = "groupID"
 	thingIDKey    = "thingID"
 	channelIDKey  = "channelID"
+	keyIDKey      = "keyID"
+	depthKey      = "depth"
+	groupKey      = "group_id"
+	sinceKey      = "since"
+	strategyKey   = "strategy"
+	orgKey        = "org_id"
+	tagsKey       = "tags"
 
 	adminKey      = "admin"
 	defOffset     = 0
 	defLimit      = 10
+	defDepth      = 1
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
 func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logger) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+		kithttp.ServerBefore(apiutil.ClientIPToContext),
 	}
 
 	r := bone.New()
@@ -62,6 +75,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Post("/things/tags", kithttp.NewServer(
+		kitot.TraceServer(tracer, "add_thing_tags")(addThingTagsEndpoint(svc)),
+		decodeTags,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Patch("/things/tags", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_thing_tags")(removeThingTagsEndpoint(svc)),
+		decodeTags,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Patch("/things/:id/key", kithttp.NewServer(
 		kitot.TraceServer(tracer, "update_key")(updateKeyEndpoint(svc)),
 		decodeKeyUpdate,
@@ -111,6 +138,13 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Post("/channels/search", kithttp.NewServer(
+		kitot.TraceServer(tracer, "search_channels")(listChannelsEndpoint(svc)),
+		decodeListByMetadata,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Post("/channels", kithttp.NewServer(
 		kitot.TraceServer(tracer, "create_channels")(createChannelsEndpoint(svc)),
 		decodeChannelsCreation,
@@ -125,6 +159,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Post("/channels/tags", kithttp.NewServer(
+		kitot.TraceServer(tracer, "add_channel_tags")(addChannelTagsEndpoint(svc)),
+		decodeTags,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Patch("/channels/tags", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_channel_tags")(removeChannelTagsEndpoint(svc)),
+		decodeTags,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Put("/channels/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "update_channel")(updateChannelEndpoint(svc)),
 		decodeChannelUpdate,
@@ -139,6 +187,34 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Post("/things/:id/keys", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_thing_key")(createThingKeyEndpoint(svc)),
+		decodeCreateThingKey,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/things/:id/keys", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_thing_keys")(listThingKeysEndpoint(svc)),
+		decodeListThingKeys,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/things/:id/keys/:keyID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_thing_key")(updateThingKeyEndpoint(svc)),
+		decodeUpdateThingKey,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/things/:id/keys/:keyID", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_thing_key")(removeThingKeyEndpoint(svc)),
+		decodeThingKey,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/channels/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_channel")(viewChannelEndpoint(svc)),
 		decodeView,
@@ -160,6 +236,48 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Post("/profiles", kithttp.NewServer(
+		kitot.TraceServer(tracer, "create_profiles")(createProfilesEndpoint(svc)),
+		decodeProfilesCreation,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/profiles", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_profiles")(listProfilesEndpoint(svc)),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/profiles/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_profile")(viewProfileEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Put("/profiles/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "update_profile")(updateProfileEndpoint(svc)),
+		decodeProfileUpdate,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Delete("/profiles/:id", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_profile")(removeProfileEndpoint(svc)),
+		decodeView,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/profiles/:id/things", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_things_by_profile")(listThingsByProfileEndpoint(svc)),
+		decodeListByConnection,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Post("/connect", kithttp.NewServer(
 		kitot.TraceServer(tracer, "connect")(connectEndpoint(svc)),
 		decodeConnectionsList,
@@ -195,6 +313,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Put("/groups/:groupID/parent", kithttp.NewServer(
+		kitot.TraceServer(tracer, "move_group")(moveGroupEndpoint(svc)),
+		decodeMoveGroup,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/groups/:groupID/children", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_group_children")(listGroupChildrenEndpoint(svc)),
+		decodeListGroupChildren,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Delete("/groups/:groupID", kithttp.NewServer(
 		kitot.TraceServer(tracer, "remove_group")(removeGroupEndpoint(svc)),
 		decodeGroupRequest,
@@ -216,6 +348,20 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Post("/groups/tags", kithttp.NewServer(
+		kitot.TraceServer(tracer, "add_group_tags")(addGroupTagsEndpoint(svc)),
+		decodeTags,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Patch("/groups/tags", kithttp.NewServer(
+		kitot.TraceServer(tracer, "remove_group_tags")(removeGroupTagsEndpoint(svc)),
+		decodeTags,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Post("/groups/:groupID/things", kithttp.NewServer(
 		kitot.TraceServer(tracer, "assign_things")(assignThingsEndpoint(svc)),
 		decodeGroupThingsRequest,
@@ -279,6 +425,48 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Get("/trash/things", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_deleted_things")(listDeletedThingsEndpoint(svc)),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/trash/things/restore", kithttp.NewServer(
+		kitot.TraceServer(tracer, "restore_things")(restoreThingsEndpoint(svc)),
+		decodeRemoveThings,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/trash/channels", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_deleted_channels")(listDeletedChannelsEndpoint(svc)),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/trash/channels/restore", kithttp.NewServer(
+		kitot.TraceServer(tracer, "restore_channels")(restoreChannelsEndpoint(svc)),
+		decodeRemoveChannels,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/trash/groups", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_deleted_groups")(listDeletedGroupsEndpoint(svc)),
+		decodeList,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/trash/groups/restore", kithttp.NewServer(
+		kitot.TraceServer(tracer, "restore_groups")(restoreGroupsEndpoint(svc)),
+		decodeRemoveGroupsRequest,
+		encodeResponse,
+		opts...,
+	))
+
 	r.Get("/backup", kithttp.NewServer(
 		kitot.TraceServer(tracer, "backup")(backupEndpoint(svc)),
 		decodeBackup,
@@ -293,6 +481,27 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 		opts...,
 	))
 
+	r.Get("/backup/export", kithttp.NewServer(
+		kitot.TraceServer(tracer, "export_backup")(exportBackupEndpoint(svc)),
+		decodeExportBackup,
+		encodeBackupStream,
+		opts...,
+	))
+
+	r.Post("/backup/import", kithttp.NewServer(
+		kitot.TraceServer(tracer, "import_backup")(importBackupEndpoint(svc)),
+		decodeImportBackup,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Get("/usage", kithttp.NewServer(
+		kitot.TraceServer(tracer, "view_usage")(viewUsageEndpoint(svc)),
+		decodeViewUsage,
+		encodeResponse,
+		opts...,
+	))
+
 	r.GetFunc("/health", mainflux.Health("things"))
 	r.Handle("/metrics", promhttp.Handler())
 
@@ -344,6 +553,70 @@ func decodeKeyUpdate(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeCreateThingKey(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := createThingKeyReq{
+		token:   apiutil.ExtractBearerToken(r),
+		thingID: bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeUpdateThingKey(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := updateThingKeyReq{
+		token:   apiutil.ExtractBearerToken(r),
+		thingID: bone.GetValue(r, "id"),
+		keyID:   bone.GetValue(r, keyIDKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeThingKey(_ context.Context, r *http.Request) (interface{}, error) {
+	req := thingKeyReq{
+		token:   apiutil.ExtractBearerToken(r),
+		thingID: bone.GetValue(r, "id"),
+		keyID:   bone.GetValue(r, keyIDKey),
+	}
+
+	return req, nil
+}
+
+func decodeListThingKeys(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listThingKeysReq{
+		token:   apiutil.ExtractBearerToken(r),
+		thingID: bone.GetValue(r, "id"),
+		offset:  o,
+		limit:   l,
+	}
+
+	return req, nil
+}
+
 func decodeChannelsCreation(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, apiutil.ErrUnsupportedContentType
@@ -389,6 +662,35 @@ func decodeRemoveChannels(_ context.Context, r *http.Request) (interface{}, erro
 	return req, nil
 }
 
+func decodeProfilesCreation(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := createProfilesReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req.Profiles); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeProfileUpdate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := updateProfileReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, "id"),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 
 func decodeView(_ context.Context, r *http.Request) (interface{}, error) {
 	req := viewResourceReq{
@@ -435,6 +737,11 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 		return nil, err
 	}
 
+	t, err := readTagsQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
 	req := listResourcesReq{
 		token: apiutil.ExtractBearerToken(r),
 		pageMetadata: things.PageMetadata{
@@ -444,6 +751,7 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 			Order:    or,
 			Dir:      d,
 			Metadata: m,
+			Tags:     t,
 		},
 		admin: a,
 	}
@@ -451,6 +759,20 @@ func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+// readTagsQuery reads the comma-separated tag filter terms, e.g.
+// "tags=outdoor,!faulty,floor-1|floor-2".
+func readTagsQuery(r *http.Request) ([]string, error) {
+	var tags []string
+	for _, t := range bone.GetQuery(r, tagsKey) {
+		if t == "" {
+			return nil, apiutil.ErrInvalidQueryParams
+		}
+		tags = append(tags, t)
+	}
+
+	return tags, nil
+}
+
 func decodeListByMetadata(_ context.Context, r *http.Request) (interface{}, error) {
 	req := listResourcesReq{token: apiutil.ExtractBearerToken(r)}
 	if err := json.NewDecoder(r.Body).Decode(&req.pageMetadata); err != nil {
@@ -604,6 +926,11 @@ func decodeListGroupsRequest(_ context.Context, r *http.Request) (interface{}, e
 		return nil, err
 	}
 
+	t, err := readTagsQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
 	req := listGroupsReq{
 		token: apiutil.ExtractBearerToken(r),
 		pageMetadata: things.PageMetadata{
@@ -611,6 +938,7 @@ func decodeListGroupsRequest(_ context.Context, r *http.Request) (interface{}, e
 			Limit:    l,
 			Metadata: m,
 			Name:     n,
+			Tags:     t,
 		},
 		id:    bone.GetValue(r, groupIDKey),
 		admin: a,
@@ -634,6 +962,67 @@ func decodeGroupUpdate(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+func decodeMoveGroup(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := moveGroupReq{
+		id:    bone.GetValue(r, groupIDKey),
+		token: apiutil.ExtractBearerToken(r),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeListGroupChildren(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := apiutil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := apiutil.ReadUintQuery(r, depthKey, defDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listGroupChildrenReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, groupIDKey),
+		depth: d,
+		pageMetadata: things.PageMetadata{
+			Offset: o,
+			Limit:  l,
+		},
+	}
+
+	return req, nil
+}
+
+func decodeTags(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := tagsReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
 func decodeRemoveGroupsRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, apiutil.ErrUnsupportedContentType
@@ -738,6 +1127,75 @@ func decodeRestore(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeExportBackup(_ context.Context, r *http.Request) (interface{}, error) {
+	groupID, err := apiutil.ReadStringQuery(r, groupKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := apiutil.ReadStringQuery(r, sinceKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if s != "" {
+		if since, err = time.Parse(time.RFC3339, s); err != nil {
+			return nil, errors.Wrap(apiutil.ErrInvalidQueryParams, err)
+		}
+	}
+
+	req := exportBackupReq{
+		token:   apiutil.ExtractBearerToken(r),
+		groupID: groupID,
+		since:   since,
+	}
+
+	return req, nil
+}
+
+func decodeViewUsage(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := apiutil.ReadStringQuery(r, orgKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req := viewUsageReq{
+		token: apiutil.ExtractBearerToken(r),
+		orgID: orgID,
+	}
+
+	return req, nil
+}
+
+func decodeImportBackup(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), backup.ContentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	strategy, err := apiutil.ReadStringQuery(r, strategyKey, backup.StrategyFail)
+	if err != nil {
+		return nil, err
+	}
+
+	req := importBackupReq{
+		token:    apiutil.ExtractBearerToken(r),
+		strategy: strategy,
+		decoder:  backup.NewDecoder(r.Body),
+	}
+
+	return req, nil
+}
+
+func encodeBackupStream(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(exportBackupRes)
+
+	w.Header().Set("Content-Type", backup.ContentType)
+	w.WriteHeader(http.StatusOK)
+
+	return res.stream(backup.NewEncoder(w))
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", contentType)
 
@@ -770,6 +1228,14 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	case errors.Contains(err, apiutil.ErrInvalidQueryParams),
 		errors.Contains(err, apiutil.ErrMalformedEntity),
+		errors.Contains(err, things.ErrInvalidMetadata),
+		errors.Contains(err, things.ErrInvalidProfileSchema),
+		errors.Contains(err, schema.ErrInvalidSchema),
+		errors.Contains(err, things.ErrInvalidFilter),
+		errors.Contains(err, things.ErrInvalidTag),
+		errors.Contains(err, backup.ErrMalformedRecord),
+		errors.Contains(err, backup.ErrUnsupportedVersion),
+		err == backup.ErrInvalidStrategy,
 		err == apiutil.ErrNameSize,
 		err == apiutil.ErrEmptyList,
 		err == apiutil.ErrMissingID,
@@ -779,12 +1245,18 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		err == apiutil.ErrOffsetSize,
 		err == apiutil.ErrInvalidOrder,
 		err == apiutil.ErrInvalidDirection,
-		err == apiutil.ErrInvalidIDFormat:
+		err == apiutil.ErrInvalidIDFormat,
+		err == apiutil.ErrMaxLevelExceeded,
+		err == things.ErrInvalidConnType,
+		err == things.ErrInvalidParent:
 		w.WriteHeader(http.StatusBadRequest)
-	case errors.Contains(err, errors.ErrConflict):
+	case errors.Contains(err, errors.ErrConflict),
+		err == things.ErrGroupHasChildren:
 		w.WriteHeader(http.StatusConflict)
 	case errors.Contains(err, errors.ErrScanMetadata):
 		w.WriteHeader(http.StatusUnprocessableEntity)
+	case errors.Contains(err, quota.ErrQuotaExceeded):
+		w.WriteHeader(http.StatusTooManyRequests)
 
 	case errors.Contains(err, errors.ErrCreateEntity),
 		errors.Contains(err, errors.ErrUpdateEntity),
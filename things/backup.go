@@ -0,0 +1,923 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+)
+
+// BackupService is the name of the service written to the backup header.
+const BackupService = "things"
+
+const (
+	groupRecord        = "group"
+	profileRecord      = "profile"
+	thingRecord        = "thing"
+	channelRecord      = "channel"
+	groupThingRecord   = "group_thing"
+	groupChannelRecord = "group_channel"
+	thingKeyRecord     = "thing_key"
+	connectionRecord   = "connection"
+)
+
+// BackupFilter limits the entities included in a backup.
+type BackupFilter struct {
+	// GroupID limits the backup to the group, its descendants and their
+	// members. Empty GroupID backs up all entities.
+	GroupID string
+	// Since limits the backup to the entities created or updated after it.
+	// Zero Since backs up all entities.
+	Since time.Time
+}
+
+type backupGroup struct {
+	ID          string                 `json:"id"`
+	OwnerID     string                 `json:"owner_id"`
+	ParentID    string                 `json:"parent_id,omitempty"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+type backupProfile struct {
+	ID          string                 `json:"id"`
+	OwnerID     string                 `json:"owner_id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Schema      map[string]interface{} `json:"schema,omitempty"`
+	Defaults    map[string]interface{} `json:"defaults,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+type backupThing struct {
+	ID        string                 `json:"id"`
+	Owner     string                 `json:"owner"`
+	Name      string                 `json:"name,omitempty"`
+	Key       string                 `json:"key"`
+	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID string                 `json:"profile_id,omitempty"`
+	Tags      []string               `json:"tags,omitempty"`
+}
+
+type backupChannel struct {
+	ID            string                 `json:"id"`
+	Owner         string                 `json:"owner"`
+	Name          string                 `json:"name,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	ProfileID     string                 `json:"profile_id,omitempty"`
+	PayloadSchema schema.Schema          `json:"payload_schema"`
+	Tags          []string               `json:"tags,omitempty"`
+}
+
+type backupGroupMember struct {
+	GroupID   string    `json:"group_id"`
+	MemberID  string    `json:"member_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type backupThingKey struct {
+	ID        string    `json:"id"`
+	ThingID   string    `json:"thing_id"`
+	Name      string    `json:"name,omitempty"`
+	Key       string    `json:"key"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+type backupConnection struct {
+	ChannelID    string `json:"channel_id"`
+	ChannelOwner string `json:"channel_owner"`
+	ThingID      string `json:"thing_id"`
+	ThingOwner   string `json:"thing_owner"`
+	Type         string `json:"type,omitempty"`
+}
+
+// backupSet holds the entities written to a backup stream, in the order in
+// which they have to be restored.
+type backupSet struct {
+	groups      []Group
+	profiles    []Profile
+	things      []Thing
+	channels    []Channel
+	groupThings []GroupThingRelation
+	groupChans  []GroupChannelRelation
+	thingKeys   []ThingKey
+	connections []Connection
+}
+
+func (ts *thingsService) collectBackup(ctx context.Context, f BackupFilter) (backupSet, error) {
+	groups, err := ts.groups.RetrieveAll(ctx)
+	if err != nil {
+		return backupSet{}, err
+	}
+	gtrs, err := ts.groups.RetrieveAllThingRelations(ctx)
+	if err != nil {
+		return backupSet{}, err
+	}
+	gcrs, err := ts.groups.RetrieveAllChannelRelations(ctx)
+	if err != nil {
+		return backupSet{}, err
+	}
+	ths, err := ts.things.RetrieveAll(ctx)
+	if err != nil {
+		return backupSet{}, err
+	}
+	chs, err := ts.channels.RetrieveAll(ctx)
+	if err != nil {
+		return backupSet{}, err
+	}
+	keys, err := ts.thingKeys.RetrieveAll(ctx)
+	if err != nil {
+		return backupSet{}, err
+	}
+	conns, err := ts.channels.RetrieveAllConnections(ctx)
+	if err != nil {
+		return backupSet{}, err
+	}
+	prs, err := ts.profiles.RetrieveAll(ctx)
+	if err != nil {
+		return backupSet{}, err
+	}
+	depths := groupDepths(groups)
+
+	if f.GroupID != "" {
+		subtree := groupSubtree(groups, f.GroupID)
+		if len(subtree) == 0 {
+			return backupSet{}, errors.ErrNotFound
+		}
+
+		groups = filterGroups(groups, func(g Group) bool { return subtree[g.ID] })
+		gtrs = filterGroupThings(gtrs, func(r GroupThingRelation) bool { return subtree[r.GroupID] })
+		gcrs = filterGroupChannels(gcrs, func(r GroupChannelRelation) bool { return subtree[r.GroupID] })
+
+		thIDs := map[string]bool{}
+		for _, r := range gtrs {
+			thIDs[r.ThingID] = true
+		}
+		chIDs := map[string]bool{}
+		for _, r := range gcrs {
+			chIDs[r.ChannelID] = true
+		}
+
+		ths = filterThings(ths, func(th Thing) bool { return thIDs[th.ID] })
+		chs = filterChannels(chs, func(ch Channel) bool { return chIDs[ch.ID] })
+		keys = filterThingKeys(keys, func(k ThingKey) bool { return thIDs[k.ThingID] })
+		conns = filterConnections(conns, func(c Connection) bool { return thIDs[c.ThingID] && chIDs[c.ChannelID] })
+
+		prIDs := map[string]bool{}
+		for _, th := range ths {
+			prIDs[th.ProfileID] = true
+		}
+		for _, ch := range chs {
+			prIDs[ch.ProfileID] = true
+		}
+		prs = filterProfiles(prs, func(pr Profile) bool { return prIDs[pr.ID] })
+	}
+
+	if !f.Since.IsZero() {
+		uths, err := ts.things.RetrieveUpdated(ctx, f.Since)
+		if err != nil {
+			return backupSet{}, err
+		}
+		uchs, err := ts.channels.RetrieveUpdated(ctx, f.Since)
+		if err != nil {
+			return backupSet{}, err
+		}
+
+		thIDs := map[string]bool{}
+		for _, th := range uths {
+			thIDs[th.ID] = true
+		}
+		chIDs := map[string]bool{}
+		for _, ch := range uchs {
+			chIDs[ch.ID] = true
+		}
+
+		after := func(t time.Time) bool { return !t.Before(f.Since) }
+		groups = filterGroups(groups, func(g Group) bool { return after(g.UpdatedAt) })
+		gtrs = filterGroupThings(gtrs, func(r GroupThingRelation) bool { return after(r.UpdatedAt) })
+		gcrs = filterGroupChannels(gcrs, func(r GroupChannelRelation) bool { return after(r.UpdatedAt) })
+		prs = filterProfiles(prs, func(pr Profile) bool { return after(pr.UpdatedAt) })
+		ths = filterThings(ths, func(th Thing) bool { return thIDs[th.ID] })
+		chs = filterChannels(chs, func(ch Channel) bool { return chIDs[ch.ID] })
+		keys = filterThingKeys(keys, func(k ThingKey) bool { return thIDs[k.ThingID] || after(k.CreatedAt) })
+		conns = filterConnections(conns, func(c Connection) bool { return thIDs[c.ThingID] || chIDs[c.ChannelID] })
+	}
+
+	// Parents have to be restored before their children.
+	sort.SliceStable(groups, func(i, j int) bool {
+		return depths[groups[i].ID] < depths[groups[j].ID]
+	})
+
+	return backupSet{
+		groups:      groups,
+		profiles:    prs,
+		things:      ths,
+		channels:    chs,
+		groupThings: gtrs,
+		groupChans:  gcrs,
+		thingKeys:   keys,
+		connections: conns,
+	}, nil
+}
+
+func (bs backupSet) encode(enc *backup.Encoder) error {
+	for _, g := range bs.groups {
+		if err := enc.Encode(groupRecord, backupGroup{
+			ID:          g.ID,
+			OwnerID:     g.OwnerID,
+			ParentID:    g.ParentID,
+			Name:        g.Name,
+			Description: g.Description,
+			Metadata:    g.Metadata,
+			Tags:        g.Tags,
+			CreatedAt:   g.CreatedAt,
+			UpdatedAt:   g.UpdatedAt,
+		}); err != nil {
+			return err
+		}
+	}
+	for _, pr := range bs.profiles {
+		if err := enc.Encode(profileRecord, backupProfile(pr)); err != nil {
+			return err
+		}
+	}
+	for _, th := range bs.things {
+		if err := enc.Encode(thingRecord, backupThing{
+			ID:        th.ID,
+			Owner:     th.Owner,
+			Name:      th.Name,
+			Key:       th.Key,
+			Metadata:  th.Metadata,
+			ProfileID: th.ProfileID,
+			Tags:      th.Tags,
+		}); err != nil {
+			return err
+		}
+	}
+	for _, ch := range bs.channels {
+		if err := enc.Encode(channelRecord, backupChannel(ch)); err != nil {
+			return err
+		}
+	}
+	for _, r := range bs.groupThings {
+		if err := enc.Encode(groupThingRecord, backupGroupMember{GroupID: r.GroupID, MemberID: r.ThingID, CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt}); err != nil {
+			return err
+		}
+	}
+	for _, r := range bs.groupChans {
+		if err := enc.Encode(groupChannelRecord, backupGroupMember{GroupID: r.GroupID, MemberID: r.ChannelID, CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt}); err != nil {
+			return err
+		}
+	}
+	for _, k := range bs.thingKeys {
+		if err := enc.Encode(thingKeyRecord, backupThingKey(k)); err != nil {
+			return err
+		}
+	}
+	for _, c := range bs.connections {
+		if err := enc.Encode(connectionRecord, backupConnection(c)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importer restores backup records one by one, resolving conflicts with
+// existing entities according to the chosen strategy.
+type importer struct {
+	ts       *thingsService
+	userID   string
+	strategy string
+	// groups contains IDs of the groups the records are allowed to touch.
+	// Nil groups means that the import isn't scoped.
+	groups  map[string]bool
+	summary backup.Summary
+}
+
+func (im *importer) run(ctx context.Context, dec *backup.Decoder) (backup.Summary, error) {
+	for {
+		rec, err := dec.Next()
+		if err == io.EOF {
+			return im.summary, nil
+		}
+		if err != nil {
+			return im.summary, err
+		}
+
+		if err := im.restore(ctx, rec); err != nil {
+			return im.summary, err
+		}
+	}
+}
+
+func (im *importer) restore(ctx context.Context, rec backup.Record) error {
+	switch rec.Type {
+	case groupRecord:
+		var g backupGroup
+		if err := rec.Decode(&g); err != nil {
+			return err
+		}
+		return im.restoreGroup(ctx, Group{
+			ID:          g.ID,
+			OwnerID:     g.OwnerID,
+			ParentID:    g.ParentID,
+			Name:        g.Name,
+			Description: g.Description,
+			Metadata:    g.Metadata,
+			Tags:        g.Tags,
+			CreatedAt:   g.CreatedAt,
+			UpdatedAt:   g.UpdatedAt,
+		})
+	case profileRecord:
+		var pr backupProfile
+		if err := rec.Decode(&pr); err != nil {
+			return err
+		}
+		return im.restoreProfile(ctx, Profile(pr))
+	case thingRecord:
+		var th backupThing
+		if err := rec.Decode(&th); err != nil {
+			return err
+		}
+		return im.restoreThing(ctx, Thing{
+			ID:        th.ID,
+			Owner:     th.Owner,
+			Name:      th.Name,
+			Key:       th.Key,
+			Metadata:  th.Metadata,
+			ProfileID: th.ProfileID,
+			Tags:      th.Tags,
+		})
+	case channelRecord:
+		var ch backupChannel
+		if err := rec.Decode(&ch); err != nil {
+			return err
+		}
+		return im.restoreChannel(ctx, Channel(ch))
+	case groupThingRecord:
+		var r backupGroupMember
+		if err := rec.Decode(&r); err != nil {
+			return err
+		}
+		return im.restoreGroupThing(ctx, r.GroupID, r.MemberID)
+	case groupChannelRecord:
+		var r backupGroupMember
+		if err := rec.Decode(&r); err != nil {
+			return err
+		}
+		return im.restoreGroupChannel(ctx, r.GroupID, r.MemberID)
+	case thingKeyRecord:
+		var k backupThingKey
+		if err := rec.Decode(&k); err != nil {
+			return err
+		}
+		return im.restoreThingKey(ctx, ThingKey(k))
+	case connectionRecord:
+		var c backupConnection
+		if err := rec.Decode(&c); err != nil {
+			return err
+		}
+		return im.restoreConnection(ctx, Connection(c))
+	default:
+		return errors.Wrap(backup.ErrMalformedRecord, errors.New(rec.Type))
+	}
+}
+
+// resolve reports whether an existing entity has to be overwritten. It
+// returns an error if the import has to be aborted.
+func (im *importer) resolve() (bool, error) {
+	switch im.strategy {
+	case backup.StrategySkip:
+		im.summary.Skipped++
+		return false, nil
+	case backup.StrategyOverwrite:
+		im.summary.Updated++
+		return true, nil
+	default:
+		return false, errors.ErrConflict
+	}
+}
+
+func (im *importer) scoped() bool {
+	return im.groups != nil
+}
+
+func (im *importer) restoreGroup(ctx context.Context, g Group) error {
+	if im.scoped() && !im.groups[g.ID] {
+		if !im.groups[g.ParentID] {
+			return errors.ErrAuthorization
+		}
+		im.groups[g.ID] = true
+	}
+
+	current, err := im.ts.groups.RetrieveByID(ctx, g.ID)
+	switch {
+	case err == nil:
+		if err := im.keepOwner(current.OwnerID, g.OwnerID); err != nil {
+			return err
+		}
+		if im.scoped() && current.ParentID != g.ParentID && !im.groups[g.ParentID] {
+			return errors.ErrAuthorization
+		}
+		overwrite, err := im.resolve()
+		if err != nil || !overwrite {
+			return err
+		}
+		if _, err := im.ts.groups.Update(ctx, g); err != nil {
+			return err
+		}
+		if current.ParentID != g.ParentID {
+			return im.ts.groups.Move(ctx, g.ID, g.ParentID)
+		}
+		return nil
+	case errors.Contains(err, errors.ErrNotFound):
+		if g.OwnerID, err = im.owner(g.OwnerID); err != nil {
+			return err
+		}
+		release, err := im.reserveUserQuota(ctx, g.OwnerID, quota.Groups)
+		if err != nil {
+			return err
+		}
+		defer release()
+		if _, err := im.ts.groups.Save(ctx, g); err != nil {
+			return err
+		}
+		im.summary.Created++
+		return nil
+	default:
+		return err
+	}
+}
+
+func (im *importer) restoreProfile(ctx context.Context, pr Profile) error {
+	current, err := im.ts.profiles.RetrieveByID(ctx, pr.ID)
+	switch {
+	case err == nil:
+		if im.scoped() && current.OwnerID != im.userID {
+			return errors.ErrAuthorization
+		}
+		overwrite, err := im.resolve()
+		if err != nil || !overwrite {
+			return err
+		}
+		return im.ts.profiles.Update(ctx, pr)
+	case errors.Contains(err, errors.ErrNotFound):
+		if pr.OwnerID, err = im.owner(pr.OwnerID); err != nil {
+			return err
+		}
+		if _, err := im.ts.profiles.Save(ctx, pr); err != nil {
+			return err
+		}
+		im.summary.Created++
+		return nil
+	default:
+		return err
+	}
+}
+
+func (im *importer) restoreThing(ctx context.Context, th Thing) error {
+	current, err := im.ts.things.RetrieveByID(ctx, th.ID)
+	switch {
+	case err == nil:
+		if err := im.canOverwrite(im.ts.groups.RetrieveThingMembership(ctx, th.ID)); err != nil {
+			return err
+		}
+		if err := im.keepOwner(current.Owner, th.Owner); err != nil {
+			return err
+		}
+		if err := im.canUseProfile(ctx, th.Owner, th.ProfileID); err != nil {
+			return err
+		}
+		overwrite, err := im.resolve()
+		if err != nil || !overwrite {
+			return err
+		}
+		if err := im.ts.things.Update(ctx, th); err != nil {
+			return err
+		}
+		if current.Key != th.Key {
+			if err := im.ts.things.UpdateKey(ctx, th.Owner, th.ID, th.Key); err != nil {
+				return err
+			}
+			return im.ts.thingCache.Remove(ctx, th.ID)
+		}
+		return nil
+	case errors.Contains(err, errors.ErrNotFound):
+		if th.Owner, err = im.owner(th.Owner); err != nil {
+			return err
+		}
+		if err := im.canUseProfile(ctx, th.Owner, th.ProfileID); err != nil {
+			return err
+		}
+		release, err := im.reserveUserQuota(ctx, th.Owner, quota.Things)
+		if err != nil {
+			return err
+		}
+		defer release()
+		if _, err := im.ts.things.Save(ctx, th); err != nil {
+			return err
+		}
+		im.summary.Created++
+		return nil
+	default:
+		return err
+	}
+}
+
+func (im *importer) restoreChannel(ctx context.Context, ch Channel) error {
+	current, err := im.ts.channels.RetrieveByID(ctx, ch.ID)
+	switch {
+	case err == nil:
+		if err := im.canOverwrite(im.ts.groups.RetrieveChannelMembership(ctx, ch.ID)); err != nil {
+			return err
+		}
+		if err := im.keepOwner(current.Owner, ch.Owner); err != nil {
+			return err
+		}
+		if err := im.canUseProfile(ctx, ch.Owner, ch.ProfileID); err != nil {
+			return err
+		}
+		overwrite, err := im.resolve()
+		if err != nil || !overwrite {
+			return err
+		}
+		return im.ts.channels.Update(ctx, ch)
+	case errors.Contains(err, errors.ErrNotFound):
+		if ch.Owner, err = im.owner(ch.Owner); err != nil {
+			return err
+		}
+		if err := im.canUseProfile(ctx, ch.Owner, ch.ProfileID); err != nil {
+			return err
+		}
+		release, err := im.reserveUserQuota(ctx, ch.Owner, quota.Channels)
+		if err != nil {
+			return err
+		}
+		defer release()
+		if _, err := im.ts.channels.Save(ctx, ch); err != nil {
+			return err
+		}
+		im.summary.Created++
+		return nil
+	default:
+		return err
+	}
+}
+
+func (im *importer) restoreGroupThing(ctx context.Context, groupID, thingID string) error {
+	if im.scoped() && !im.groups[groupID] {
+		return errors.ErrAuthorization
+	}
+
+	current, err := im.membership(im.ts.groups.RetrieveThingMembership(ctx, thingID))
+	if err != nil {
+		return err
+	}
+	if current != groupID {
+		release, err := im.reserveOrgQuota(ctx, groupID, quota.Things)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+	if current == "" {
+		if err := im.ts.groups.AssignThing(ctx, groupID, thingID); err != nil {
+			return err
+		}
+		im.summary.Created++
+		return nil
+	}
+
+	if err := im.canOverwrite(current, nil); err != nil {
+		return err
+	}
+	overwrite, err := im.resolve()
+	if err != nil || !overwrite || current == groupID {
+		return err
+	}
+	if err := im.ts.groups.UnassignThing(ctx, current, thingID); err != nil {
+		return err
+	}
+
+	return im.ts.groups.AssignThing(ctx, groupID, thingID)
+}
+
+func (im *importer) restoreGroupChannel(ctx context.Context, groupID, channelID string) error {
+	if im.scoped() && !im.groups[groupID] {
+		return errors.ErrAuthorization
+	}
+
+	current, err := im.membership(im.ts.groups.RetrieveChannelMembership(ctx, channelID))
+	if err != nil {
+		return err
+	}
+	if current != groupID {
+		release, err := im.reserveOrgQuota(ctx, groupID, quota.Channels)
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+	if current == "" {
+		if err := im.ts.groups.AssignChannel(ctx, groupID, channelID); err != nil {
+			return err
+		}
+		im.summary.Created++
+		return nil
+	}
+
+	if err := im.canOverwrite(current, nil); err != nil {
+		return err
+	}
+	overwrite, err := im.resolve()
+	if err != nil || !overwrite || current == groupID {
+		return err
+	}
+	if err := im.ts.groups.UnassignChannel(ctx, current, channelID); err != nil {
+		return err
+	}
+
+	return im.ts.groups.AssignChannel(ctx, groupID, channelID)
+}
+
+func (im *importer) restoreThingKey(ctx context.Context, k ThingKey) error {
+	if err := im.canOverwrite(im.ts.groups.RetrieveThingMembership(ctx, k.ThingID)); err != nil {
+		return err
+	}
+
+	_, err := im.ts.thingKeys.RetrieveByID(ctx, k.ThingID, k.ID)
+	switch {
+	case err == nil:
+		overwrite, err := im.resolve()
+		if err != nil || !overwrite {
+			return err
+		}
+		return im.ts.thingKeys.Update(ctx, k)
+	case errors.Contains(err, errors.ErrNotFound):
+		if _, err := im.ts.thingKeys.Save(ctx, k); err != nil {
+			return err
+		}
+		im.summary.Created++
+		return nil
+	default:
+		return err
+	}
+}
+
+func (im *importer) restoreConnection(ctx context.Context, c Connection) error {
+	if err := im.canOverwrite(im.ts.groups.RetrieveChannelMembership(ctx, c.ChannelID)); err != nil {
+		return err
+	}
+	if err := im.canOverwrite(im.ts.groups.RetrieveThingMembership(ctx, c.ThingID)); err != nil {
+		return err
+	}
+	if c.Type == "" {
+		c.Type = ConnTypePubSub
+	}
+
+	current, err := im.ts.channels.RetrieveConnection(ctx, c.ChannelID, c.ThingID)
+	switch {
+	case err == nil:
+		overwrite, err := im.resolve()
+		if err != nil || !overwrite || current.Type == c.Type {
+			return err
+		}
+		if err := im.ts.channels.Disconnect(ctx, c.ThingOwner, c.ChannelID, []string{c.ThingID}); err != nil {
+			return err
+		}
+		if err := im.ts.channelCache.Disconnect(ctx, c.ChannelID, c.ThingID); err != nil {
+			return err
+		}
+	case errors.Contains(err, errors.ErrNotFound):
+		im.summary.Created++
+	default:
+		return err
+	}
+
+	return im.ts.channels.Connect(ctx, c.ThingOwner, c.ChannelID, []string{c.ThingID}, c.Type)
+}
+
+// canOverwrite checks whether a scoped import is allowed to modify an
+// existing entity that is a member of the given group.
+func (im *importer) canOverwrite(groupID string, err error) error {
+	groupID, err = im.membership(groupID, err)
+	if err != nil {
+		return err
+	}
+	if im.scoped() && !im.groups[groupID] {
+		return errors.ErrAuthorization
+	}
+
+	return nil
+}
+
+// owner returns the owner of the entity created by the import. Entities
+// created by a scoped import are owned by the importing user, so the
+// records of the entities owned by other users are rejected.
+func (im *importer) owner(owner string) (string, error) {
+	if !im.scoped() {
+		return owner, nil
+	}
+	if owner != "" && owner != im.userID {
+		return "", errors.ErrAuthorization
+	}
+
+	return im.userID, nil
+}
+
+// keepOwner checks that a scoped import doesn't change the owner of an
+// existing entity.
+func (im *importer) keepOwner(current, owner string) error {
+	if im.scoped() && current != owner {
+		return errors.ErrAuthorization
+	}
+
+	return nil
+}
+
+// canUseProfile checks that the profile referenced by a thing or channel
+// restored by a scoped import belongs to the entity owner.
+func (im *importer) canUseProfile(ctx context.Context, owner, profileID string) error {
+	if !im.scoped() || profileID == "" {
+		return nil
+	}
+	if _, err := im.ts.retrieveProfile(ctx, owner, profileID); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
+
+// reserveUserQuota reserves the quota of the owner of the entity created by
+// a scoped import. Imports of the whole service by the root admin aren't
+// limited by the quotas.
+func (im *importer) reserveUserQuota(ctx context.Context, owner, resource string) (func(), error) {
+	if !im.scoped() {
+		return func() {}, nil
+	}
+
+	return im.ts.reserveUserQuota(ctx, owner, resource, 1)
+}
+
+// reserveOrgQuota reserves the quota of the org that the group, which a
+// scoped import assigns an entity to, belongs to.
+func (im *importer) reserveOrgQuota(ctx context.Context, groupID, resource string) (func(), error) {
+	if !im.scoped() {
+		return func() {}, nil
+	}
+
+	return im.ts.reserveOrgQuota(ctx, resource, map[string]int{groupID: 1})
+}
+
+// membership normalizes the result of a membership lookup, since the
+// repositories report a missing membership either as an empty group ID or
+// as ErrNotFound.
+func (im *importer) membership(groupID string, err error) (string, error) {
+	if err != nil && !errors.Contains(err, errors.ErrNotFound) {
+		return "", err
+	}
+
+	return groupID, nil
+}
+
+// groupSubtree returns IDs of the group identified by groupID and all of
+// its descendants.
+func groupSubtree(groups []Group, groupID string) map[string]bool {
+	subtree := map[string]bool{}
+	for _, g := range groups {
+		if g.ID == groupID {
+			subtree[g.ID] = true
+		}
+	}
+	if len(subtree) == 0 {
+		return subtree
+	}
+
+	for added := true; added; {
+		added = false
+		for _, g := range groups {
+			if !subtree[g.ID] && subtree[g.ParentID] {
+				subtree[g.ID] = true
+				added = true
+			}
+		}
+	}
+
+	return subtree
+}
+
+// groupDepths returns the number of ancestors of each group.
+func groupDepths(groups []Group) map[string]int {
+	parents := map[string]string{}
+	for _, g := range groups {
+		parents[g.ID] = g.ParentID
+	}
+
+	depths := map[string]int{}
+	for _, g := range groups {
+		depth := 0
+		for id := g.ParentID; id != "" && depth <= len(groups); id = parents[id] {
+			depth++
+		}
+		depths[g.ID] = depth
+	}
+
+	return depths
+}
+
+func filterGroups(groups []Group, keep func(Group) bool) []Group {
+	var res []Group
+	for _, g := range groups {
+		if keep(g) {
+			res = append(res, g)
+		}
+	}
+	return res
+}
+
+func filterGroupThings(rels []GroupThingRelation, keep func(GroupThingRelation) bool) []GroupThingRelation {
+	var res []GroupThingRelation
+	for _, r := range rels {
+		if keep(r) {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+func filterGroupChannels(rels []GroupChannelRelation, keep func(GroupChannelRelation) bool) []GroupChannelRelation {
+	var res []GroupChannelRelation
+	for _, r := range rels {
+		if keep(r) {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+func filterThings(ths []Thing, keep func(Thing) bool) []Thing {
+	var res []Thing
+	for _, th := range ths {
+		if keep(th) {
+			res = append(res, th)
+		}
+	}
+	return res
+}
+
+func filterChannels(chs []Channel, keep func(Channel) bool) []Channel {
+	var res []Channel
+	for _, ch := range chs {
+		if keep(ch) {
+			res = append(res, ch)
+		}
+	}
+	return res
+}
+
+func filterThingKeys(keys []ThingKey, keep func(ThingKey) bool) []ThingKey {
+	var res []ThingKey
+	for _, k := range keys {
+		if keep(k) {
+			res = append(res, k)
+		}
+	}
+	return res
+}
+
+func filterConnections(conns []Connection, keep func(Connection) bool) []Connection {
+	var res []Connection
+	for _, c := range conns {
+		if keep(c) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func filterProfiles(prs []Profile, keep func(Profile) bool) []Profile {
+	var res []Profile
+	for _, pr := range prs {
+		if keep(pr) {
+			res = append(res, pr)
+		}
+	}
+	return res
+}
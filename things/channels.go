@@ -5,8 +5,31 @@ package things
 
 import (
 	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+)
+
+const (
+	// PublishAction represents publishing messages to a channel.
+	PublishAction = "publish"
+	// SubscribeAction represents receiving messages from a channel.
+	SubscribeAction = "subscribe"
+)
+
+const (
+	// ConnTypePublish allows the thing only to publish to the channel.
+	ConnTypePublish = "publish"
+	// ConnTypeSubscribe allows the thing only to subscribe to the channel.
+	ConnTypeSubscribe = "subscribe"
+	// ConnTypePubSub allows the thing both to publish and subscribe to the channel.
+	ConnTypePubSub = "publish_subscribe"
 )
 
+// ErrInvalidConnType indicates an unsupported connection type.
+var ErrInvalidConnType = errors.New("invalid connection type")
+
 // Channel represents a Mainflux "communication group". This group contains the
 // things that can exchange messages between each other.
 type Channel struct {
@@ -14,6 +37,12 @@ type Channel struct {
 	Owner    string
 	Name     string
 	Metadata map[string]interface{}
+	// ProfileID identifies the profile that the channel metadata conforms to.
+	ProfileID string
+	// PayloadSchema describes the payloads that can be published to the channel.
+	PayloadSchema schema.Schema
+	// Tags label the channel, e.g. to filter channels.
+	Tags []string
 }
 
 // ChannelsPage contains page related metadata as well as list of channels that
@@ -29,6 +58,36 @@ type Connection struct {
 	ChannelOwner string
 	ThingID      string
 	ThingOwner   string
+	Type         string
+}
+
+// Allows reports whether the connection permits the given action.
+func (c Connection) Allows(action string) bool {
+	return ConnTypeAllows(c.Type, action)
+}
+
+// ConnTypeAllows reports whether the connection type permits the given action.
+func ConnTypeAllows(connType, action string) bool {
+	switch connType {
+	case ConnTypePubSub:
+		return action == PublishAction || action == SubscribeAction
+	case ConnTypePublish:
+		return action == PublishAction
+	case ConnTypeSubscribe:
+		return action == SubscribeAction
+	default:
+		return false
+	}
+}
+
+// ValidateConnType checks whether the connection type is supported.
+func ValidateConnType(connType string) error {
+	switch connType {
+	case ConnTypePublish, ConnTypeSubscribe, ConnTypePubSub:
+		return nil
+	default:
+		return ErrInvalidConnType
+	}
 }
 
 // ChannelRepository specifies a channel persistence API.
@@ -57,22 +116,50 @@ type ChannelRepository interface {
 	// thing.
 	RetrieveConns(ctx context.Context, thID string, pm PageMetadata) (ChannelsPage, error)
 
-	// Remove removes the channels having the provided identifiers, that is owned
-	// by the specified user.
+	// Remove moves the channels having the provided identifiers, that are
+	// owned by the specified user, to the trash along with their connections
+	// and group membership.
 	Remove(ctx context.Context, owner string, id ...string) error
 
-	// Connect connects a list of things to a channel.
-	Connect(ctx context.Context, owner, chID string, thIDs []string) error
+	// RetrieveDeleted retrieves the subset of channels in the trash that are
+	// owned by the specified user.
+	RetrieveDeleted(ctx context.Context, owner string, pm PageMetadata) (DeletedChannelsPage, error)
+
+	// Restore moves the channels having the provided identifiers, that are
+	// owned by the specified user, out of the trash. Connections and group
+	// membership are restored if the related things and groups still exist.
+	Restore(ctx context.Context, owner string, ids ...string) error
+
+	// Purge permanently removes channels moved to the trash before the
+	// provided time.
+	Purge(ctx context.Context, before time.Time) error
+
+	// Connect connects a list of things to a channel using the given
+	// connection type.
+	Connect(ctx context.Context, owner, chID string, thIDs []string, connType string) error
 
 	// Disconnect disconnects a list of things from a channel.
 	Disconnect(ctx context.Context, owner, chID string, thIDs []string) error
 
-	// RetrieveConnByThingKey retrieves connections IDs by ThingKey
-	RetrieveConnByThingKey(ctx context.Context, key string) (Connection, error)
+	// RetrieveConnection retrieves the connection between the given channel
+	// and thing.
+	RetrieveConnection(ctx context.Context, chID, thID string) (Connection, error)
+
+	// AddTags adds the tags to the channels having the provided identifiers,
+	// that are owned by the specified user.
+	AddTags(ctx context.Context, owner string, tags []string, ids ...string) error
+
+	// RemoveTags removes the tags from the channels having the provided
+	// identifiers, that are owned by the specified user.
+	RemoveTags(ctx context.Context, owner string, tags []string, ids ...string) error
 
 	// RetrieveAll retrieves all channels for all users.
 	RetrieveAll(ctx context.Context) ([]Channel, error)
 
+	// RetrieveUpdated retrieves all channels, for all users, that were
+	// created or updated at or after the given time.
+	RetrieveUpdated(ctx context.Context, since time.Time) ([]Channel, error)
+
 	// RetrieveByAdmin  retrieves all channels for all users with pagination.
 	RetrieveByAdmin(ctx context.Context, pm PageMetadata) (ChannelsPage, error)
 
@@ -82,11 +169,12 @@ type ChannelRepository interface {
 
 // ChannelCache contains channel-thing connection caching interface.
 type ChannelCache interface {
-	// Connect channel thing connection.
-	Connect(context.Context, string, string) error
+	// Connect caches the channel thing connection of the given type.
+	Connect(ctx context.Context, chanID, thingID, connType string) error
 
-	// HasThing checks if thing is connected to channel.
-	HasThing(context.Context, string, string) bool
+	// CanAccess checks if thing is connected to channel and is allowed to
+	// perform the given action on it.
+	CanAccess(ctx context.Context, chanID, thingID, action string) bool
 
 	// Disconnects thing from channel.
 	Disconnect(context.Context, string, string) error
@@ -0,0 +1,142 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// Metadata filter operators.
+const (
+	OpEq       = "eq"
+	OpNe       = "ne"
+	OpGt       = "gt"
+	OpGte      = "gte"
+	OpLt       = "lt"
+	OpLte      = "lte"
+	OpIn       = "in"
+	OpPrefix   = "prefix"
+	OpContains = "contains"
+)
+
+// ErrInvalidFilter indicates malformed search filter.
+var ErrInvalidFilter = errors.New("invalid search filter")
+
+var pathKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// MetadataFilter matches things and channels whose metadata value at Path
+// satisfies the Op comparison with Value. Path is a dot-separated list of
+// metadata keys, e.g. "location.room". Array values match if any of their
+// elements does.
+type MetadataFilter struct {
+	Path  string      `json:"path"`
+	Op    string      `json:"op"`
+	Value interface{} `json:"value"`
+}
+
+// Keys returns the metadata keys the filter path consists of.
+func (f MetadataFilter) Keys() []string {
+	return strings.Split(f.Path, ".")
+}
+
+// Validate checks whether the filter path, operator and value are well-formed.
+func (f MetadataFilter) Validate() error {
+	if f.Path == "" {
+		return errors.Wrap(ErrInvalidFilter, errors.New("missing metadata path"))
+	}
+
+	for _, k := range f.Keys() {
+		if !pathKeyRegexp.MatchString(k) {
+			return errors.Wrap(ErrInvalidFilter, errors.New("invalid metadata path "+f.Path))
+		}
+	}
+
+	switch f.Op {
+	case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte:
+		if !isScalar(f.Value) {
+			return errors.Wrap(ErrInvalidFilter, errors.New("value of "+f.Op+" must be a string, number or boolean"))
+		}
+	case OpIn:
+		vals, ok := f.Value.([]interface{})
+		if !ok || len(vals) == 0 {
+			return errors.Wrap(ErrInvalidFilter, errors.New("value of in must be a non-empty list"))
+		}
+		for _, v := range vals {
+			if !isScalar(v) {
+				return errors.Wrap(ErrInvalidFilter, errors.New("values of in must be strings, numbers or booleans"))
+			}
+		}
+	case OpPrefix, OpContains:
+		if s, ok := f.Value.(string); !ok || s == "" {
+			return errors.Wrap(ErrInvalidFilter, errors.New("value of "+f.Op+" must be a non-empty string"))
+		}
+	default:
+		return errors.Wrap(ErrInvalidFilter, errors.New("unknown operator "+f.Op))
+	}
+
+	return nil
+}
+
+// TimeRange represents a time interval. A zero bound leaves the interval
+// unbounded on that side.
+type TimeRange struct {
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+}
+
+// IsZero reports whether the range is unbounded on both sides.
+func (tr TimeRange) IsZero() bool {
+	return tr.From.IsZero() && tr.To.IsZero()
+}
+
+// Validate checks whether the range start doesn't come after its end.
+func (tr TimeRange) Validate() error {
+	if !tr.From.IsZero() && !tr.To.IsZero() && tr.From.After(tr.To) {
+		return errors.Wrap(ErrInvalidFilter, errors.New("time range start is after its end"))
+	}
+
+	return nil
+}
+
+// ValidateFilters checks whether the search filters of the page metadata are well-formed.
+func (pm PageMetadata) ValidateFilters() error {
+	for _, f := range pm.Filters {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := pm.Created.Validate(); err != nil {
+		return err
+	}
+
+	if err := pm.Updated.Validate(); err != nil {
+		return err
+	}
+
+	for _, t := range pm.Tags {
+		if _, err := ParseTagTerm(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case string, bool, json.Number,
+		float32, float64,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64:
+		return true
+	default:
+		return false
+	}
+}
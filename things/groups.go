@@ -39,6 +39,13 @@ var (
 
 	// ErrRetrieveChannelMembership indicates failure to retrieve channel membership
 	ErrRetrieveChannelMembership = errors.New("failed to retrieve channel membership")
+
+	// ErrInvalidParent indicates that the parent group doesn't exist or that
+	// setting it would create a cycle in the group hierarchy.
+	ErrInvalidParent = errors.New("invalid parent group")
+
+	// ErrGroupHasChildren indicates that the group can't be removed while it has child groups.
+	ErrGroupHasChildren = errors.New("group has child groups")
 )
 
 // Identity contains ID and Email.
@@ -50,15 +57,37 @@ type Identity struct {
 // GroupMetadata defines the Metadata type.
 type GroupMetadata map[string]interface{}
 
-// Group represents the group information.
+// Group represents the group information. Groups form a tree: a group
+// without a parent is a root group.
 type Group struct {
 	ID          string
 	OwnerID     string
+	ParentID    string
 	Name        string
 	Description string
 	Metadata    GroupMetadata
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	// Path contains IDs of all groups from the root down to and including
+	// this group.
+	Path []string
+	// Tags label the group, e.g. to filter groups or to apply policies to
+	// all groups carrying a tag.
+	Tags []string
+}
+
+// Ancestors returns IDs of the group's ancestors, nearest first.
+func (g Group) Ancestors() []string {
+	if len(g.Path) < 2 {
+		return nil
+	}
+
+	var ids []string
+	for i := len(g.Path) - 2; i >= 0; i-- {
+		ids = append(ids, g.Path[i])
+	}
+
+	return ids
 }
 
 // GroupThingRelation represents a relation between a group and a thing.
@@ -104,21 +133,47 @@ type GroupRepository interface {
 	// Update a group
 	Update(ctx context.Context, g Group) (Group, error)
 
-	// Remove a groups
+	// Remove moves groups to the trash along with their members.
 	Remove(ctx context.Context, groupIDs ...string) error
 
+	// RetrieveDeleted retrieves the subset of groups in the trash that are
+	// owned by the specified user.
+	RetrieveDeleted(ctx context.Context, ownerID string, pm PageMetadata) (DeletedGroupsPage, error)
+
+	// Restore moves the groups having the provided identifiers, that are
+	// owned by the specified user, out of the trash, parents before their
+	// children. A group whose parent no longer exists is restored as a root
+	// group, and members are restored if they still exist and aren't
+	// assigned to another group.
+	Restore(ctx context.Context, ownerID string, groupIDs ...string) error
+
+	// Purge permanently removes groups moved to the trash before the
+	// provided time.
+	Purge(ctx context.Context, before time.Time) error
+
 	// RetrieveByID retrieves group by its id
 	RetrieveByID(ctx context.Context, id string) (Group, error)
 
 	// RetrieveByIDs retrieves groups by their ids
 	RetrieveByIDs(ctx context.Context, groupIDs []string) (GroupPage, error)
 
+	// RetrieveChildren retrieves page of descendants of the group identified by
+	// groupID that are at most depth levels below it.
+	RetrieveChildren(ctx context.Context, groupID string, depth uint64, pm PageMetadata) (GroupPage, error)
+
+	// Move sets the parent of the group identified by groupID, moving the
+	// whole subtree along with it. Empty parentID makes the group a root.
+	Move(ctx context.Context, groupID, parentID string) error
+
 	// RetrieveByOwner retrieves all groups.
 	RetrieveByOwner(ctx context.Context, ownerID string, pm PageMetadata) (GroupPage, error)
 
 	// RetrieveThingMembership retrieves group that thing belongs to.
 	RetrieveThingMembership(ctx context.Context, thingID string) (string, error)
 
+	// RetrieveThingIDs retrieves the IDs of the things assigned to the groups or to their descendants.
+	RetrieveThingIDs(ctx context.Context, groupIDs []string) ([]string, error)
+
 	// RetrieveGroupThings retrieves page of things that are assigned to a group identified by groupID.
 	RetrieveGroupThings(ctx context.Context, groupID string, pm PageMetadata) (GroupThingsPage, error)
 
@@ -143,6 +198,14 @@ type GroupRepository interface {
 	// UnassignChannel unassigns a channel from a group
 	UnassignChannel(ctx context.Context, groupID string, ids ...string) error
 
+	// AddTags adds the tags to the groups having the provided identifiers,
+	// that are owned by the specified user.
+	AddTags(ctx context.Context, ownerID string, tags []string, groupIDs ...string) error
+
+	// RemoveTags removes the tags from the groups having the provided
+	// identifiers, that are owned by the specified user.
+	RemoveTags(ctx context.Context, ownerID string, tags []string, groupIDs ...string) error
+
 	// RetrieveAll retrieves all groups.
 	RetrieveAll(ctx context.Context) ([]Group, error)
 
@@ -0,0 +1,93 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// RotatedKeyName is the name given to the previous key of a thing that
+	// remains valid during the grace period of a key rotation.
+	RotatedKeyName = "rotated"
+
+	oscoreSecretInfo = "mainflux oscore master secret"
+	oscoreSecretSize = 32
+)
+
+// ThingKey represents an additional access key of a thing. A thing can be
+// authenticated by its primary key or by any of its active keys.
+type ThingKey struct {
+	ID        string
+	ThingID   string
+	Name      string
+	Key       string
+	Enabled   bool
+	CreatedAt time.Time
+	// ExpiresAt is the moment after which the key is no longer accepted.
+	// Zero value means that the key never expires.
+	ExpiresAt time.Time
+}
+
+// Active reports whether the key can be used for authentication at the
+// given moment.
+func (k ThingKey) Active(t time.Time) bool {
+	if !k.Enabled {
+		return false
+	}
+
+	return k.ExpiresAt.IsZero() || t.Before(k.ExpiresAt)
+}
+
+// ThingKeysPage contains page related metadata as well as list of thing
+// keys that belong to this page.
+type ThingKeysPage struct {
+	PageMetadata
+	Keys []ThingKey
+}
+
+// ThingKeyRepository specifies a thing key persistence API.
+type ThingKeyRepository interface {
+	// Save persists multiple thing keys. Keys are saved using a transaction.
+	Save(ctx context.Context, keys ...ThingKey) ([]ThingKey, error)
+
+	// Update updates the name, enabled flag and expiration time of the key.
+	Update(ctx context.Context, key ThingKey) error
+
+	// RetrieveByID retrieves the key identified by the given ID that belongs
+	// to the given thing.
+	RetrieveByID(ctx context.Context, thingID, id string) (ThingKey, error)
+
+	// RetrieveByKey retrieves the thing key having the given key value.
+	RetrieveByKey(ctx context.Context, key string) (ThingKey, error)
+
+	// RetrieveByThing retrieves the subset of keys that belong to the given thing.
+	RetrieveByThing(ctx context.Context, thingID string, pm PageMetadata) (ThingKeysPage, error)
+
+	// RetrieveAll retrieves all thing keys.
+	RetrieveAll(ctx context.Context) ([]ThingKey, error)
+
+	// Remove removes the key identified by the given ID that belongs to the
+	// given thing.
+	Remove(ctx context.Context, thingID, id string) error
+}
+
+// DeriveOSCORESecret derives the OSCORE master secret of the thing from its
+// key with HKDF-SHA256, using the thing ID as the salt. Things compute the
+// same secret locally, so that the thing key itself is never handed out to
+// the adapters.
+func DeriveOSCORESecret(thingID, key string) ([]byte, error) {
+	secret := make([]byte, oscoreSecretSize)
+	r := hkdf.New(sha256.New, []byte(key), []byte(thingID), []byte(oscoreSecretInfo))
+	if _, err := io.ReadFull(r, secret); err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
@@ -6,8 +6,10 @@ package mocks
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/things"
@@ -27,8 +29,11 @@ type channelRepositoryMock struct {
 	mu       sync.Mutex
 	counter  uint64
 	channels map[string]things.Channel
+	deleted  map[string]things.DeletedChannel
+	updated  map[string]time.Time
 	tconns   chan Connection                      // used for synchronization with thing repo
 	cconns   map[string]map[string]things.Channel // used to track connections
+	ctypes   map[string]string                    // used to track connection types
 	conns    map[string]string                    // used to track connections
 	things   things.ThingRepository
 }
@@ -37,8 +42,11 @@ type channelRepositoryMock struct {
 func NewChannelRepository(repo things.ThingRepository, tconns chan Connection) things.ChannelRepository {
 	return &channelRepositoryMock{
 		channels: make(map[string]things.Channel),
+		deleted:  make(map[string]things.DeletedChannel),
+		updated:  make(map[string]time.Time),
 		tconns:   tconns,
 		cconns:   make(map[string]map[string]things.Channel),
+		ctypes:   make(map[string]string),
 		things:   repo,
 	}
 }
@@ -53,6 +61,7 @@ func (crm *channelRepositoryMock) Save(_ context.Context, channels ...things.Cha
 			channels[i].ID = fmt.Sprintf("%03d", crm.counter)
 		}
 		crm.channels[key(channels[i].Owner, channels[i].ID)] = channels[i]
+		crm.updated[channels[i].ID] = time.Now()
 	}
 
 	return channels, nil
@@ -69,6 +78,7 @@ func (crm *channelRepositoryMock) Update(_ context.Context, channel things.Chann
 	}
 
 	crm.channels[dbKey] = channel
+	crm.updated[channel.ID] = time.Now()
 	return nil
 }
 
@@ -99,7 +109,7 @@ func (crm *channelRepositoryMock) RetrieveByOwner(_ context.Context, owner strin
 	// itself (see mocks/commons.go).
 	prefix := fmt.Sprintf("%s-", owner)
 	for k, v := range crm.channels {
-		if strings.HasPrefix(k, prefix) {
+		if strings.HasPrefix(k, prefix) && matchTags(pm.Tags, v.Tags) && matchIDs(pm.IDs, v.ID) {
 			chs = append(chs, v)
 		}
 	}
@@ -184,6 +194,10 @@ func (crm *channelRepositoryMock) Remove(_ context.Context, owner string, ids ..
 			return errors.ErrNotFound
 		}
 
+		crm.deleted[key(owner, id)] = things.DeletedChannel{
+			Channel:   crm.channels[key(owner, id)],
+			DeletedAt: time.Now(),
+		}
 		delete(crm.channels, key(owner, id))
 
 		for thk := range crm.cconns {
@@ -198,14 +212,104 @@ func (crm *channelRepositoryMock) Remove(_ context.Context, owner string, ids ..
 	return nil
 }
 
-func (crm *channelRepositoryMock) Connect(_ context.Context, owner, chID string, thIDs []string) error {
+func (crm *channelRepositoryMock) RetrieveDeleted(_ context.Context, owner string, pm things.PageMetadata) (things.DeletedChannelsPage, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var chs []things.DeletedChannel
+	prefix := fmt.Sprintf("%s-", owner)
+	for k, v := range crm.deleted {
+		if strings.HasPrefix(k, prefix) && matchIDs(pm.IDs, v.ID) {
+			chs = append(chs, v)
+		}
+	}
+	sort.SliceStable(chs, func(i, j int) bool {
+		return chs[i].ID < chs[j].ID
+	})
+
+	total := uint64(len(chs))
+	chs = chs[min(pm.Offset, total):]
+	if pm.Limit > 0 {
+		chs = chs[:min(pm.Limit, uint64(len(chs)))]
+	}
+
+	page := things.DeletedChannelsPage{
+		Channels: chs,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (crm *channelRepositoryMock) Restore(_ context.Context, owner string, ids ...string) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	for _, id := range ids {
+		dch, ok := crm.deleted[key(owner, id)]
+		if !ok {
+			return errors.ErrNotFound
+		}
+		crm.channels[key(owner, id)] = dch.Channel
+		delete(crm.deleted, key(owner, id))
+	}
+
+	return nil
+}
+
+func (crm *channelRepositoryMock) Purge(_ context.Context, before time.Time) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	for k, dch := range crm.deleted {
+		if dch.DeletedAt.Before(before) {
+			delete(crm.deleted, k)
+		}
+	}
+
+	return nil
+}
+
+func (crm *channelRepositoryMock) AddTags(_ context.Context, owner string, tags []string, ids ...string) error {
+	return crm.updateTags(owner, tags, true, ids...)
+}
+
+func (crm *channelRepositoryMock) RemoveTags(_ context.Context, owner string, tags []string, ids ...string) error {
+	return crm.updateTags(owner, tags, false, ids...)
+}
+
+func (crm *channelRepositoryMock) updateTags(owner string, tags []string, add bool, ids ...string) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := crm.channels[key(owner, id)]; !ok {
+			return errors.ErrNotFound
+		}
+	}
+
+	for _, id := range ids {
+		ch := crm.channels[key(owner, id)]
+		ch.Tags = updateTags(ch.Tags, tags, add)
+		crm.channels[key(owner, id)] = ch
+		crm.updated[id] = time.Now()
+	}
+
+	return nil
+}
+
+func (crm *channelRepositoryMock) Connect(_ context.Context, owner, chID string, thIDs []string, connType string) error {
 	ch, err := crm.RetrieveByID(context.Background(), chID)
 	if err != nil {
 		return err
 	}
 
 	for _, thID := range thIDs {
-		if _, ok := crm.cconns[thID]; ok {
+		if _, ok := crm.cconns[thID][chID]; ok {
 			return errors.ErrConflict
 		}
 		th, err := crm.things.RetrieveByID(context.Background(), thID)
@@ -221,6 +325,7 @@ func (crm *channelRepositoryMock) Connect(_ context.Context, owner, chID string,
 			crm.cconns[thID] = make(map[string]things.Channel)
 		}
 		crm.cconns[thID][chID] = ch
+		crm.ctypes[key(chID, thID)] = connType
 	}
 
 	return nil
@@ -242,27 +347,27 @@ func (crm *channelRepositoryMock) Disconnect(_ context.Context, owner, chID stri
 			connected: false,
 		}
 		delete(crm.cconns[thID], chID)
+		delete(crm.ctypes, key(chID, thID))
 	}
 
 	return nil
 }
 
-func (crm *channelRepositoryMock) RetrieveConnByThingKey(_ context.Context, token string) (things.Connection, error) {
-	tid, err := crm.things.RetrieveByKey(context.Background(), token)
-	if err != nil {
-		return things.Connection{}, err
-	}
-
-	chans, ok := crm.cconns[tid]
+func (crm *channelRepositoryMock) RetrieveConnection(_ context.Context, chID, thID string) (things.Connection, error) {
+	ch, ok := crm.cconns[thID][chID]
 	if !ok {
-		return things.Connection{}, errors.ErrAuthorization
+		return things.Connection{}, errors.ErrNotFound
 	}
 
-	if len(chans) == 0 {
-		return things.Connection{}, errors.ErrAuthorization
+	conn := things.Connection{
+		ChannelID:    chID,
+		ChannelOwner: ch.Owner,
+		ThingID:      thID,
+		ThingOwner:   ch.Owner,
+		Type:         crm.ctypes[key(chID, thID)],
 	}
 
-	return things.Connection{ThingID: tid}, nil
+	return conn, nil
 }
 
 func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingID string) error {
@@ -290,6 +395,20 @@ func (crm *channelRepositoryMock) RetrieveAll(ctx context.Context) ([]things.Cha
 	return chs, nil
 }
 
+func (crm *channelRepositoryMock) RetrieveUpdated(ctx context.Context, since time.Time) ([]things.Channel, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	var chs []things.Channel
+	for _, v := range crm.channels {
+		if !crm.updated[v.ID].Before(since) {
+			chs = append(chs, v)
+		}
+	}
+
+	return chs, nil
+}
+
 func (crm *channelRepositoryMock) RetrieveAllConnections(ctx context.Context) ([]things.Connection, error) {
 	crm.mu.Lock()
 	defer crm.mu.Unlock()
@@ -302,6 +421,7 @@ func (crm *channelRepositoryMock) RetrieveAllConnections(ctx context.Context) ([
 				ChannelOwner: v.Owner,
 				ThingID:      thingID,
 				ThingOwner:   v.Owner,
+				Type:         crm.ctypes[key(v.ID, thingID)],
 			}
 			conns = append(conns, con)
 		}
@@ -313,36 +433,43 @@ func (crm *channelRepositoryMock) RetrieveAllConnections(ctx context.Context) ([
 
 type channelCacheMock struct {
 	mu       sync.Mutex
-	channels map[string]string
+	channels map[string]map[string]string
 }
 
 // NewChannelCache returns mock cache instance.
 func NewChannelCache() things.ChannelCache {
 	return &channelCacheMock{
-		channels: make(map[string]string),
+		channels: make(map[string]map[string]string),
 	}
 }
 
-func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID string) error {
+func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID, connType string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	ccm.channels[chanID] = thingID
+	if _, ok := ccm.channels[chanID]; !ok {
+		ccm.channels[chanID] = make(map[string]string)
+	}
+	ccm.channels[chanID][thingID] = connType
 	return nil
 }
 
-func (ccm *channelCacheMock) HasThing(_ context.Context, chanID, thingID string) bool {
+func (ccm *channelCacheMock) CanAccess(_ context.Context, chanID, thingID, action string) bool {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	return ccm.channels[chanID] == thingID
+	connType, ok := ccm.channels[chanID][thingID]
+	if !ok {
+		return false
+	}
+	return things.ConnTypeAllows(connType, action)
 }
 
 func (ccm *channelCacheMock) Disconnect(_ context.Context, chanID, thingID string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	delete(ccm.channels, chanID)
+	delete(ccm.channels[chanID], thingID)
 	return nil
 }
 
@@ -97,3 +97,77 @@ func parseID(ID string) (id uint64) {
 
 	return
 }
+
+func min(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// updateTags returns the sorted tags, without duplicates, after adding or
+// removing the given tags.
+func updateTags(current, tags []string, add bool) []string {
+	set := make(map[string]bool)
+	for _, t := range current {
+		set[t] = true
+	}
+	for _, t := range tags {
+		set[t] = add
+	}
+
+	res := []string{}
+	for t, ok := range set {
+		if ok {
+			res = append(res, t)
+		}
+	}
+	sort.Strings(res)
+
+	return res
+}
+
+// matchTags reports whether the entity having the given tags satisfies all
+// of the tag filter terms. Malformed terms don't match any entity.
+func matchTags(terms []string, tags []string) bool {
+	for _, term := range terms {
+		tt, err := things.ParseTagTerm(term)
+		if err != nil || !matchTerm(tt, tags) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchTerm reports whether the entity having the given tags satisfies the term.
+func matchTerm(tt things.TagTerm, tags []string) bool {
+	for _, t := range tt.Tags {
+		for _, et := range tags {
+			if t == et {
+				return !tt.Negated
+			}
+		}
+	}
+
+	return tt.Negated
+}
+
+// matchIDs reports whether any of the entity IDs is in the page IDs. A nil
+// list of page IDs matches any entity.
+func matchIDs(pageIDs []string, ids ...string) bool {
+	if pageIDs == nil {
+		return true
+	}
+
+	for _, id := range ids {
+		for _, pid := range pageIDs {
+			if id == pid {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -5,6 +5,7 @@ package mocks
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -27,6 +28,8 @@ type groupRepositoryMock struct {
 	channelMembership map[string]string
 	// Map of group channel where group id is a key and channel ids are values.
 	channels map[string][]string
+	// Map of groups moved to the trash, group id as a key.
+	deleted map[string]things.DeletedGroup
 }
 
 // NewGroupRepository creates in-memory user repository
@@ -37,6 +40,7 @@ func NewGroupRepository() things.GroupRepository {
 		things:            make(map[string][]string),
 		channelMembership: make(map[string]string),
 		channels:          make(map[string][]string),
+		deleted:           make(map[string]things.DeletedGroup),
 	}
 }
 
@@ -48,7 +52,7 @@ func (grm *groupRepositoryMock) Save(ctx context.Context, group things.Group) (t
 	}
 
 	grm.groups[group.ID] = group
-	return group, nil
+	return grm.withPath(group), nil
 }
 
 func (grm *groupRepositoryMock) Update(ctx context.Context, group things.Group) (things.Group, error) {
@@ -61,6 +65,7 @@ func (grm *groupRepositoryMock) Update(ctx context.Context, group things.Group)
 	up.Name = group.Name
 	up.Description = group.Description
 	up.Metadata = group.Metadata
+	up.Tags = group.Tags
 	up.UpdatedAt = time.Now()
 
 	grm.groups[group.ID] = up
@@ -84,6 +89,15 @@ func (grm *groupRepositoryMock) Remove(ctx context.Context, ids ...string) error
 			delete(grm.channelMembership, channelID)
 		}
 
+		grm.deleted[id] = things.DeletedGroup{
+			Group:      grm.groups[id],
+			ThingIDs:   grm.things[id],
+			ChannelIDs: grm.channels[id],
+			DeletedAt:  time.Now(),
+		}
+		delete(grm.things, id)
+		delete(grm.channels, id)
+
 		// This is not quite exact, it should go in depth
 		delete(grm.groups, id)
 	}
@@ -91,13 +105,122 @@ func (grm *groupRepositoryMock) Remove(ctx context.Context, ids ...string) error
 
 }
 
+func (grm *groupRepositoryMock) RetrieveDeleted(ctx context.Context, ownerID string, pm things.PageMetadata) (things.DeletedGroupsPage, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	var items []things.DeletedGroup
+	for _, g := range grm.deleted {
+		if g.OwnerID == ownerID {
+			items = append(items, g)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].ID < items[j].ID
+	})
+
+	total := uint64(len(items))
+	items = items[min(pm.Offset, total):]
+	if pm.Limit > 0 {
+		items = items[:min(pm.Limit, uint64(len(items)))]
+	}
+
+	page := things.DeletedGroupsPage{
+		Groups: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (grm *groupRepositoryMock) Restore(ctx context.Context, ownerID string, ids ...string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	for _, id := range ids {
+		dg, ok := grm.deleted[id]
+		if !ok || dg.OwnerID != ownerID {
+			return errors.ErrNotFound
+		}
+
+		g := dg.Group
+		if _, ok := grm.groups[g.ParentID]; !ok {
+			g.ParentID = ""
+		}
+		grm.groups[id] = g
+
+		for _, thingID := range dg.ThingIDs {
+			if _, ok := grm.thingMembership[thingID]; !ok {
+				grm.thingMembership[thingID] = id
+				grm.things[id] = append(grm.things[id], thingID)
+			}
+		}
+
+		for _, channelID := range dg.ChannelIDs {
+			if _, ok := grm.channelMembership[channelID]; !ok {
+				grm.channelMembership[channelID] = id
+				grm.channels[id] = append(grm.channels[id], channelID)
+			}
+		}
+
+		delete(grm.deleted, id)
+	}
+
+	return nil
+}
+
+func (grm *groupRepositoryMock) Purge(ctx context.Context, before time.Time) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	for id, dg := range grm.deleted {
+		if dg.DeletedAt.Before(before) {
+			delete(grm.deleted, id)
+		}
+	}
+
+	return nil
+}
+
+func (grm *groupRepositoryMock) AddTags(ctx context.Context, ownerID string, tags []string, ids ...string) error {
+	return grm.updateTags(ownerID, tags, true, ids...)
+}
+
+func (grm *groupRepositoryMock) RemoveTags(ctx context.Context, ownerID string, tags []string, ids ...string) error {
+	return grm.updateTags(ownerID, tags, false, ids...)
+}
+
+func (grm *groupRepositoryMock) updateTags(ownerID string, tags []string, add bool, ids ...string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	for _, id := range ids {
+		if g, ok := grm.groups[id]; !ok || g.OwnerID != ownerID {
+			return errors.ErrNotFound
+		}
+	}
+
+	for _, id := range ids {
+		g := grm.groups[id]
+		g.Tags = updateTags(g.Tags, tags, add)
+		g.UpdatedAt = time.Now()
+		grm.groups[id] = g
+	}
+
+	return nil
+}
+
 func (grm *groupRepositoryMock) RetrieveAll(ctx context.Context) ([]things.Group, error) {
 	grm.mu.Lock()
 	defer grm.mu.Unlock()
 
 	var items []things.Group
 	for _, g := range grm.groups {
-		items = append(items, g)
+		items = append(items, grm.withPath(g))
 	}
 
 	return items, nil
@@ -111,7 +234,82 @@ func (grm *groupRepositoryMock) RetrieveByID(ctx context.Context, id string) (th
 	if !ok {
 		return things.Group{}, errors.ErrNotFound
 	}
-	return val, nil
+	return grm.withPath(val), nil
+}
+
+func (grm *groupRepositoryMock) RetrieveChildren(ctx context.Context, groupID string, depth uint64, pm things.PageMetadata) (things.GroupPage, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	var items []things.Group
+	for _, g := range grm.groups {
+		g = grm.withPath(g)
+		for i, id := range g.Path {
+			d := uint64(len(g.Path) - 1 - i)
+			if id == groupID && d > 0 && d <= depth {
+				items = append(items, g)
+			}
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if len(items[i].Path) != len(items[j].Path) {
+			return len(items[i].Path) < len(items[j].Path)
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	total := uint64(len(items))
+	if pm.Limit > 0 {
+		first := pm.Offset
+		if first > total {
+			first = total
+		}
+		last := first + pm.Limit
+		if last > total {
+			last = total
+		}
+		items = items[first:last]
+	}
+
+	return things.GroupPage{
+		Groups: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
+func (grm *groupRepositoryMock) Move(ctx context.Context, groupID, parentID string) error {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	g, ok := grm.groups[groupID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	if _, ok := grm.groups[parentID]; parentID != "" && !ok {
+		return errors.ErrNotFound
+	}
+
+	g.ParentID = parentID
+	grm.groups[groupID] = g
+
+	return nil
+}
+
+// withPath sets the path of the group by walking up its parents.
+func (grm *groupRepositoryMock) withPath(g things.Group) things.Group {
+	path := []string{g.ID}
+	for parent, ok := grm.groups[g.ParentID]; ok; parent, ok = grm.groups[parent.ParentID] {
+		path = append([]string{parent.ID}, path...)
+	}
+	g.Path = path
+
+	return g
 }
 
 func (grm *groupRepositoryMock) RetrieveByIDs(ctx context.Context, groupIDs []string) (things.GroupPage, error) {
@@ -123,7 +321,10 @@ func (grm *groupRepositoryMock) RetrieveByOwner(ctx context.Context, ownerID str
 	defer grm.mu.Unlock()
 	var items []things.Group
 	for _, g := range grm.groups {
-		items = append(items, g)
+		g = grm.withPath(g)
+		if matchTags(pm.Tags, g.Tags) && matchIDs(pm.IDs, g.Path...) {
+			items = append(items, g)
+		}
 	}
 	return things.GroupPage{
 		Groups: items,
@@ -189,14 +390,32 @@ func (grm *groupRepositoryMock) RetrieveThingMembership(ctx context.Context, thi
 	return groupID, nil
 }
 
+func (grm *groupRepositoryMock) RetrieveThingIDs(ctx context.Context, groupIDs []string) ([]string, error) {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	ids := []string{}
+	for thingID, groupID := range grm.thingMembership {
+		gr, ok := grm.groups[groupID]
+		if !ok {
+			continue
+		}
+		if matchIDs(groupIDs, grm.withPath(gr).Path...) {
+			ids = append(ids, thingID)
+		}
+	}
+
+	return ids, nil
+}
+
 func (grm *groupRepositoryMock) RetrieveGroupThings(ctx context.Context, groupID string, pm things.PageMetadata) (things.GroupThingsPage, error) {
 	grm.mu.Lock()
 	defer grm.mu.Unlock()
 	var items []things.Thing
-	ths, ok := grm.things[groupID]
-	if !ok {
+	if _, ok := grm.groups[groupID]; !ok {
 		return things.GroupThingsPage{}, errors.ErrNotFound
 	}
+	ths := grm.things[groupID]
 
 	first := uint64(pm.Offset)
 	last := first + uint64(pm.Limit)
@@ -212,7 +431,7 @@ func (grm *groupRepositoryMock) RetrieveGroupThings(ctx context.Context, groupID
 	return things.GroupThingsPage{
 		Things: items,
 		PageMetadata: things.PageMetadata{
-			Total: uint64(len(items)),
+			Total: uint64(len(ths)),
 		},
 	}, nil
 }
@@ -329,7 +548,7 @@ func (grm *groupRepositoryMock) RetrieveGroupChannels(ctx context.Context, group
 	return things.GroupChannelsPage{
 		Channels: items,
 		PageMetadata: things.PageMetadata{
-			Total: uint64(len(items)),
+			Total: uint64(len(chs)),
 		},
 	}, nil
 }
@@ -0,0 +1,145 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+)
+
+var _ things.ThingKeyRepository = (*thingKeyRepositoryMock)(nil)
+
+type thingKeyRepositoryMock struct {
+	mu   sync.Mutex
+	keys map[string]things.ThingKey
+}
+
+// NewThingKeyRepository creates in-memory thing key repository.
+func NewThingKeyRepository() things.ThingKeyRepository {
+	return &thingKeyRepositoryMock{
+		keys: make(map[string]things.ThingKey),
+	}
+}
+
+func (krm *thingKeyRepositoryMock) Save(_ context.Context, keys ...things.ThingKey) ([]things.ThingKey, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	for _, k := range keys {
+		for _, sk := range krm.keys {
+			if sk.Key == k.Key || sk.ID == k.ID {
+				return []things.ThingKey{}, errors.ErrConflict
+			}
+		}
+		krm.keys[k.ID] = k
+	}
+
+	return keys, nil
+}
+
+func (krm *thingKeyRepositoryMock) Update(_ context.Context, key things.ThingKey) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	k, ok := krm.keys[key.ID]
+	if !ok || k.ThingID != key.ThingID {
+		return errors.ErrNotFound
+	}
+
+	k.Name = key.Name
+	k.Enabled = key.Enabled
+	k.ExpiresAt = key.ExpiresAt
+	krm.keys[key.ID] = k
+
+	return nil
+}
+
+func (krm *thingKeyRepositoryMock) RetrieveByID(_ context.Context, thingID, id string) (things.ThingKey, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	k, ok := krm.keys[id]
+	if !ok || k.ThingID != thingID {
+		return things.ThingKey{}, errors.ErrNotFound
+	}
+
+	return k, nil
+}
+
+func (krm *thingKeyRepositoryMock) RetrieveByKey(_ context.Context, key string) (things.ThingKey, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	for _, k := range krm.keys {
+		if k.Key == key {
+			return k, nil
+		}
+	}
+
+	return things.ThingKey{}, errors.ErrNotFound
+}
+
+func (krm *thingKeyRepositoryMock) RetrieveByThing(_ context.Context, thingID string, pm things.PageMetadata) (things.ThingKeysPage, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	var keys []things.ThingKey
+	for _, k := range krm.keys {
+		if k.ThingID == thingID {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	total := uint64(len(keys))
+	switch {
+	case pm.Offset >= total:
+		keys = []things.ThingKey{}
+	case pm.Limit > 0 && pm.Offset+pm.Limit < total:
+		keys = keys[pm.Offset : pm.Offset+pm.Limit]
+	default:
+		keys = keys[pm.Offset:]
+	}
+
+	return things.ThingKeysPage{
+		Keys: keys,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
+func (krm *thingKeyRepositoryMock) RetrieveAll(_ context.Context) ([]things.ThingKey, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	var keys []things.ThingKey
+	for _, k := range krm.keys {
+		keys = append(keys, k)
+	}
+
+	return keys, nil
+}
+
+func (krm *thingKeyRepositoryMock) Remove(_ context.Context, thingID, id string) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	k, ok := krm.keys[id]
+	if !ok || k.ThingID != thingID {
+		return errors.ErrNotFound
+	}
+	delete(krm.keys, id)
+
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+)
+
+var _ things.ProfileRepository = (*profileRepositoryMock)(nil)
+
+type profileRepositoryMock struct {
+	mu       sync.Mutex
+	profiles map[string]things.Profile
+}
+
+// NewProfileRepository creates in-memory profile repository.
+func NewProfileRepository() things.ProfileRepository {
+	return &profileRepositoryMock{
+		profiles: make(map[string]things.Profile),
+	}
+}
+
+func (prm *profileRepositoryMock) Save(_ context.Context, prs ...things.Profile) ([]things.Profile, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	for _, p := range prs {
+		for _, sp := range prm.profiles {
+			if sp.ID == p.ID || sp.OwnerID == p.OwnerID && sp.Name == p.Name {
+				return []things.Profile{}, errors.ErrConflict
+			}
+		}
+		prm.profiles[p.ID] = p
+	}
+
+	return prs, nil
+}
+
+func (prm *profileRepositoryMock) Update(_ context.Context, pr things.Profile) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	p, ok := prm.profiles[pr.ID]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	p.Name = pr.Name
+	p.Description = pr.Description
+	p.Schema = pr.Schema
+	p.Defaults = pr.Defaults
+	p.UpdatedAt = pr.UpdatedAt
+	prm.profiles[pr.ID] = p
+
+	return nil
+}
+
+func (prm *profileRepositoryMock) RetrieveByID(_ context.Context, id string) (things.Profile, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	p, ok := prm.profiles[id]
+	if !ok {
+		return things.Profile{}, errors.ErrNotFound
+	}
+
+	return p, nil
+}
+
+func (prm *profileRepositoryMock) RetrieveByOwner(_ context.Context, ownerID string, pm things.PageMetadata) (things.ProfilesPage, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	var prs []things.Profile
+	for _, p := range prm.profiles {
+		if p.OwnerID == ownerID {
+			prs = append(prs, p)
+		}
+	}
+
+	sort.SliceStable(prs, func(i, j int) bool {
+		return prs[i].ID < prs[j].ID
+	})
+
+	total := uint64(len(prs))
+	switch {
+	case pm.Offset >= total:
+		prs = []things.Profile{}
+	case pm.Limit > 0 && pm.Offset+pm.Limit < total:
+		prs = prs[pm.Offset : pm.Offset+pm.Limit]
+	default:
+		prs = prs[pm.Offset:]
+	}
+
+	return things.ProfilesPage{
+		Profiles: prs,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
+func (prm *profileRepositoryMock) RetrieveAll(_ context.Context) ([]things.Profile, error) {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	var prs []things.Profile
+	for _, p := range prm.profiles {
+		prs = append(prs, p)
+	}
+
+	return prs, nil
+}
+
+func (prm *profileRepositoryMock) Remove(_ context.Context, ownerID string, ids ...string) error {
+	prm.mu.Lock()
+	defer prm.mu.Unlock()
+
+	for _, id := range ids {
+		if p, ok := prm.profiles[id]; ok && p.OwnerID == ownerID {
+			delete(prm.profiles, id)
+		}
+	}
+
+	return nil
+}
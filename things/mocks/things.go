@@ -6,8 +6,10 @@ package mocks
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/things"
@@ -21,14 +23,18 @@ type thingRepositoryMock struct {
 	conns   chan Connection
 	tconns  map[string]map[string]things.Thing
 	things  map[string]things.Thing
+	deleted map[string]things.DeletedThing
+	updated map[string]time.Time
 }
 
 // NewThingRepository creates in-memory thing repository.
 func NewThingRepository(conns chan Connection) things.ThingRepository {
 	repo := &thingRepositoryMock{
-		conns:  conns,
-		things: make(map[string]things.Thing),
-		tconns: make(map[string]map[string]things.Thing),
+		conns:   conns,
+		things:  make(map[string]things.Thing),
+		deleted: make(map[string]things.DeletedThing),
+		updated: make(map[string]time.Time),
+		tconns:  make(map[string]map[string]things.Thing),
 	}
 	go func(conns chan Connection, repo *thingRepositoryMock) {
 		for conn := range conns {
@@ -59,6 +65,7 @@ func (trm *thingRepositoryMock) Save(_ context.Context, ths ...things.Thing) ([]
 			ths[i].ID = fmt.Sprintf("%03d", trm.counter)
 		}
 		trm.things[key(ths[i].Owner, ths[i].ID)] = ths[i]
+		trm.updated[ths[i].ID] = time.Now()
 	}
 
 	return ths, nil
@@ -75,6 +82,7 @@ func (trm *thingRepositoryMock) Update(_ context.Context, thing things.Thing) er
 	}
 
 	trm.things[dbKey] = thing
+	trm.updated[thing.ID] = time.Now()
 
 	return nil
 }
@@ -98,6 +106,7 @@ func (trm *thingRepositoryMock) UpdateKey(_ context.Context, owner, id, val stri
 
 	th.Key = val
 	trm.things[dbKey] = th
+	trm.updated[id] = time.Now()
 
 	return nil
 }
@@ -127,6 +136,7 @@ func (trm *thingRepositoryMock) RetrieveByOwner(_ context.Context, owner string,
 	last := first + uint64(pm.Limit)
 
 	var ths []things.Thing
+	var total uint64
 
 	// This obscure way to examine map keys is enforced by the key structure
 	// itself (see mocks/commons.go).
@@ -134,6 +144,14 @@ func (trm *thingRepositoryMock) RetrieveByOwner(_ context.Context, owner string,
 	for k, v := range trm.things {
 		id := parseID(v.ID)
 
+		if !matchTags(pm.Tags, v.Tags) || !matchIDs(pm.IDs, v.ID) {
+			continue
+		}
+
+		if strings.HasPrefix(k, prefix) {
+			total++
+		}
+
 		if strings.HasPrefix(k, prefix) && id >= first && pm.Limit == 0 {
 			ths = append(ths, v)
 		}
@@ -148,7 +166,7 @@ func (trm *thingRepositoryMock) RetrieveByOwner(_ context.Context, owner string,
 	page := things.Page{
 		Things: ths,
 		PageMetadata: things.PageMetadata{
-			Total:  trm.counter,
+			Total:  total,
 			Offset: pm.Offset,
 			Limit:  pm.Limit,
 		},
@@ -196,6 +214,39 @@ func (trm *thingRepositoryMock) RetrieveByIDs(_ context.Context, thingIDs []stri
 	return page, nil
 }
 
+func (trm *thingRepositoryMock) RetrieveByProfile(_ context.Context, owner, profileID string, pm things.PageMetadata) (things.Page, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	var ths []things.Thing
+	for _, th := range trm.things {
+		if th.Owner == owner && th.ProfileID == profileID && matchIDs(pm.IDs, th.ID) {
+			ths = append(ths, th)
+		}
+	}
+
+	ths = sortThings(pm, ths)
+
+	total := uint64(len(ths))
+	switch {
+	case pm.Offset >= total:
+		ths = []things.Thing{}
+	case pm.Limit > 0 && pm.Offset+pm.Limit < total:
+		ths = ths[pm.Offset : pm.Offset+pm.Limit]
+	default:
+		ths = ths[pm.Offset:]
+	}
+
+	return things.Page{
+		Things: ths,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
 func (trm *thingRepositoryMock) RetrieveByChannel(_ context.Context, owner, chID string, pm things.PageMetadata) (things.Page, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
@@ -214,7 +265,7 @@ func (trm *thingRepositoryMock) RetrieveByChannel(_ context.Context, owner, chID
 	case false:
 		for _, co := range trm.tconns[chID] {
 			id := parseID(co.ID)
-			if id >= first && id < last || pm.Limit == 0 {
+			if (id >= first && id < last || pm.Limit == 0) && matchIDs(pm.IDs, co.ID) {
 				ths = append(ths, co)
 			}
 		}
@@ -222,7 +273,7 @@ func (trm *thingRepositoryMock) RetrieveByChannel(_ context.Context, owner, chID
 		for _, th := range trm.things {
 			conn := false
 			id := parseID(th.ID)
-			if id >= first && id < last || pm.Limit == 0 {
+			if (id >= first && id < last || pm.Limit == 0) && matchIDs(pm.IDs, th.ID) {
 				for _, co := range trm.tconns[chID] {
 					if th.ID == co.ID {
 						conn = true
@@ -260,12 +311,106 @@ func (trm *thingRepositoryMock) Remove(_ context.Context, owner string, ids ...s
 		if _, ok := trm.things[key(owner, id)]; !ok {
 			return errors.ErrNotFound
 		}
+		trm.deleted[key(owner, id)] = things.DeletedThing{
+			Thing:     trm.things[key(owner, id)],
+			DeletedAt: time.Now(),
+		}
 		delete(trm.things, key(owner, id))
 	}
 
 	return nil
 }
 
+func (trm *thingRepositoryMock) RetrieveDeleted(_ context.Context, owner string, pm things.PageMetadata) (things.DeletedThingsPage, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	var ths []things.DeletedThing
+	prefix := fmt.Sprintf("%s-", owner)
+	for k, v := range trm.deleted {
+		if strings.HasPrefix(k, prefix) {
+			ths = append(ths, v)
+		}
+	}
+	sort.SliceStable(ths, func(i, j int) bool {
+		return ths[i].ID < ths[j].ID
+	})
+
+	total := uint64(len(ths))
+	ths = ths[min(pm.Offset, total):]
+	if pm.Limit > 0 {
+		ths = ths[:min(pm.Limit, uint64(len(ths)))]
+	}
+
+	page := things.DeletedThingsPage{
+		Things: ths,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (trm *thingRepositoryMock) Restore(_ context.Context, owner string, ids ...string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	for _, id := range ids {
+		dth, ok := trm.deleted[key(owner, id)]
+		if !ok {
+			return errors.ErrNotFound
+		}
+		trm.things[key(owner, id)] = dth.Thing
+		delete(trm.deleted, key(owner, id))
+	}
+
+	return nil
+}
+
+func (trm *thingRepositoryMock) Purge(_ context.Context, before time.Time) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	for k, dth := range trm.deleted {
+		if dth.DeletedAt.Before(before) {
+			delete(trm.deleted, k)
+		}
+	}
+
+	return nil
+}
+
+func (trm *thingRepositoryMock) AddTags(_ context.Context, owner string, tags []string, ids ...string) error {
+	return trm.updateTags(owner, tags, true, ids...)
+}
+
+func (trm *thingRepositoryMock) RemoveTags(_ context.Context, owner string, tags []string, ids ...string) error {
+	return trm.updateTags(owner, tags, false, ids...)
+}
+
+func (trm *thingRepositoryMock) updateTags(owner string, tags []string, add bool, ids ...string) error {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := trm.things[key(owner, id)]; !ok {
+			return errors.ErrNotFound
+		}
+	}
+
+	for _, id := range ids {
+		th := trm.things[key(owner, id)]
+		th.Tags = updateTags(th.Tags, tags, add)
+		trm.things[key(owner, id)] = th
+		trm.updated[id] = time.Now()
+	}
+
+	return nil
+}
+
 func (trm *thingRepositoryMock) RetrieveByKey(_ context.Context, key string) (string, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
@@ -312,6 +457,20 @@ func (trm *thingRepositoryMock) RetrieveAll(_ context.Context) ([]things.Thing,
 	return ths, nil
 }
 
+func (trm *thingRepositoryMock) RetrieveUpdated(_ context.Context, since time.Time) ([]things.Thing, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+	var ths []things.Thing
+
+	for _, th := range trm.things {
+		if !trm.updated[th.ID].Before(since) {
+			ths = append(ths, th)
+		}
+	}
+
+	return ths, nil
+}
+
 func (trm *thingRepositoryMock) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.Page, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
@@ -376,7 +535,6 @@ func (tcm *thingCacheMock) Remove(_ context.Context, id string) error {
 	for key, val := range tcm.things {
 		if val == id {
 			delete(tcm.things, key)
-			return nil
 		}
 	}
 
@@ -10,9 +10,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/internal/dbutil"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/gofrs/uuid"
 	"github.com/jackc/pgerrcode"
@@ -31,6 +33,7 @@ type dbConnection struct {
 	Channel string `db:"channel"`
 	Thing   string `db:"thing"`
 	Owner   string `db:"owner"`
+	Type    string `db:"conn_type"`
 }
 
 // NewChannelRepository instantiates a PostgreSQL implementation of channel
@@ -47,8 +50,8 @@ func (cr channelRepository) Save(ctx context.Context, channels ...things.Channel
 		return nil, errors.Wrap(errors.ErrCreateEntity, err)
 	}
 
-	q := `INSERT INTO channels (id, owner, name, metadata)
-		  VALUES (:id, :owner, :name, :metadata);`
+	q := `INSERT INTO channels (id, owner, name, metadata, profile_id, payload_schema, tags)
+		  VALUES (:id, :owner, :name, :metadata, :profile_id, :payload_schema, :tags);`
 
 	for _, channel := range channels {
 		dbch := toDBChannel(channel)
@@ -63,6 +66,8 @@ func (cr channelRepository) Save(ctx context.Context, channels ...things.Channel
 					return []things.Channel{}, errors.Wrap(errors.ErrMalformedEntity, err)
 				case pgerrcode.UniqueViolation:
 					return []things.Channel{}, errors.Wrap(errors.ErrConflict, err)
+				case pgerrcode.ForeignKeyViolation:
+					return []things.Channel{}, errors.Wrap(errors.ErrNotFound, err)
 				case pgerrcode.StringDataRightTruncationDataException:
 					return []things.Channel{}, errors.Wrap(errors.ErrMalformedEntity, err)
 				}
@@ -79,7 +84,7 @@ func (cr channelRepository) Save(ctx context.Context, channels ...things.Channel
 }
 
 func (cr channelRepository) Update(ctx context.Context, channel things.Channel) error {
-	q := `UPDATE channels SET name = :name, metadata = :metadata WHERE owner = :owner AND id = :id;`
+	q := `UPDATE channels SET name = :name, metadata = :metadata, payload_schema = :payload_schema, tags = :tags, updated_at = NOW() WHERE owner = :owner AND id = :id;`
 
 	dbch := toDBChannel(channel)
 
@@ -111,7 +116,7 @@ func (cr channelRepository) Update(ctx context.Context, channel things.Channel)
 }
 
 func (cr channelRepository) RetrieveByID(ctx context.Context, id string) (things.Channel, error) {
-	q := `SELECT name, metadata, owner FROM channels WHERE id = $1;`
+	q := `SELECT name, metadata, owner, profile_id, payload_schema, tags FROM channels WHERE id = $1;`
 
 	dbch := dbChannel{
 		ID: id,
@@ -137,6 +142,14 @@ func (cr channelRepository) RetrieveByOwner(ctx context.Context, owner string, p
 	return cr.retrieve(ctx, owner, false, pm)
 }
 
+func (cr channelRepository) AddTags(ctx context.Context, owner string, tags []string, ids ...string) error {
+	return updateTags(ctx, cr.db, "channels", "owner", owner, tags, true, ids...)
+}
+
+func (cr channelRepository) RemoveTags(ctx context.Context, owner string, tags []string, ids ...string) error {
+	return updateTags(ctx, cr.db, "channels", "owner", owner, tags, false, ids...)
+}
+
 func (cr channelRepository) RetrieveAll(ctx context.Context) ([]things.Channel, error) {
 	chPage, err := cr.retrieve(ctx, "", true, things.PageMetadata{})
 	if err != nil {
@@ -146,6 +159,28 @@ func (cr channelRepository) RetrieveAll(ctx context.Context) ([]things.Channel,
 	return chPage.Channels, nil
 }
 
+func (cr channelRepository) RetrieveUpdated(ctx context.Context, since time.Time) ([]things.Channel, error) {
+	q := `SELECT id, owner, name, metadata, profile_id, payload_schema, tags FROM channels WHERE updated_at >= :since;`
+
+	rows, err := cr.db.NamedQueryContext(ctx, q, map[string]interface{}{"since": since})
+	if err != nil {
+		return []things.Channel{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []things.Channel
+	for rows.Next() {
+		var dbch dbChannel
+		if err := rows.StructScan(&dbch); err != nil {
+			return []things.Channel{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		items = append(items, toChannel(dbch))
+	}
+
+	return items, nil
+}
+
 func (cr channelRepository) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.ChannelsPage, error) {
 	return cr.retrieve(ctx, "", false, pm)
 }
@@ -157,7 +192,7 @@ func (cr channelRepository) RetrieveByThing(ctx context.Context, owner, thID str
 	}
 
 	var q string
-	q = fmt.Sprintf(`SELECT id, name, metadata FROM channels ch
+	q = fmt.Sprintf(`SELECT id, name, metadata, profile_id, payload_schema, tags FROM channels ch
 		        INNER JOIN connections conn
 		        ON ch.id = conn.channel_id
 		        WHERE ch.owner = :owner AND conn.thing_id = :thing;`)
@@ -200,7 +235,7 @@ func (cr channelRepository) RetrieveConns(ctx context.Context, thID string, pm t
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, metadata FROM channels ch
+	q := fmt.Sprintf(`SELECT id, name, metadata, profile_id, payload_schema, tags FROM channels ch
 		        INNER JOIN connections conn
 		        ON ch.id = conn.channel_id
 		        WHERE conn.thing_id = :thing
@@ -251,35 +286,213 @@ func (cr channelRepository) RetrieveConns(ctx context.Context, thID string, pm t
 }
 
 func (cr channelRepository) Remove(ctx context.Context, owner string, ids ...string) error {
+	// A channel is moved to the trash along with its connections and group
+	// membership, replacing the previously removed channel with the same ID.
+	qs := []string{
+		`DELETE FROM deleted_channels WHERE id = :id AND EXISTS (SELECT 1 FROM channels WHERE id = :id AND owner = :owner);`,
+		`INSERT INTO deleted_channels (id, owner, name, metadata, profile_id, payload_schema, tags, created_at, updated_at, deleted_at, connections, group_id)
+		 SELECT ch.id, ch.owner, ch.name, ch.metadata, ch.profile_id, ch.payload_schema, ch.tags, ch.created_at, ch.updated_at, :deleted_at,
+		   COALESCE((SELECT jsonb_agg(jsonb_build_object('thing_id', conn.thing_id, 'thing_owner', conn.thing_owner,
+		     'conn_type', conn.conn_type)) FROM connections conn WHERE conn.channel_id = ch.id), '[]'),
+		   (SELECT gc.group_id FROM group_channels gc WHERE gc.channel_id = ch.id)
+		 FROM channels ch WHERE ch.id = :id AND ch.owner = :owner;`,
+		`DELETE FROM group_channels WHERE channel_id = :id AND EXISTS (SELECT 1 FROM channels WHERE id = :id AND owner = :owner);`,
+		`DELETE FROM channels WHERE id = :id AND owner = :owner;`,
+	}
+
+	tx, err := cr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	deletedAt := time.Now().UTC()
+	for _, id := range ids {
+		params := map[string]interface{}{
+			"id":         id,
+			"owner":      owner,
+			"deleted_at": deletedAt,
+		}
+
+		for _, q := range qs {
+			if _, err := tx.NamedExecContext(ctx, q, params); err != nil {
+				tx.Rollback()
+				return errors.Wrap(errors.ErrRemoveEntity, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (cr channelRepository) RetrieveDeleted(ctx context.Context, owner string, pm things.PageMetadata) (things.DeletedChannelsPage, error) {
+	nq, name := dbutil.GetNameQuery(pm.Name)
+
+	query := []string{"owner = :owner"}
+	if nq != "" {
+		query = append(query, nq)
+	}
+	var ip map[string]interface{}
+	if pm.IDs != nil {
+		var iq string
+		iq, ip = getIDsQuery("id", pm.IDs)
+		query = append(query, iq)
+	}
+	whereClause := fmt.Sprintf(" WHERE %s", strings.Join(query, " AND "))
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT id, name, metadata, profile_id, payload_schema, tags, deleted_at, connections, group_id
+		  FROM deleted_channels %s ORDER BY deleted_at DESC %s;`, whereClause, olq)
+
+	params := map[string]interface{}{
+		"owner":  owner,
+		"name":   name,
+		"limit":  pm.Limit,
+		"offset": pm.Offset,
+	}
+	for k, v := range ip {
+		params[k] = v
+	}
+
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.DeletedChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []things.DeletedChannel
+	for rows.Next() {
+		dbch := dbDeletedChannel{Owner: owner}
+		if err := rows.StructScan(&dbch); err != nil {
+			return things.DeletedChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		ch, err := toDeletedChannel(dbch)
+		if err != nil {
+			return things.DeletedChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		items = append(items, ch)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM deleted_channels %s;`, whereClause)
+
+	total, err := total(ctx, cr.db, cq, params)
+	if err != nil {
+		return things.DeletedChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := things.DeletedChannelsPage{
+		Channels: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (cr channelRepository) Restore(ctx context.Context, owner string, ids ...string) error {
+	// The profile is dropped if it was removed in the meantime, while
+	// connections and group membership are restored only if the things
+	// and the group still exist.
+	qc := `INSERT INTO channels (id, owner, name, metadata, profile_id, payload_schema, tags, created_at, updated_at)
+		   SELECT dc.id, dc.owner, dc.name, dc.metadata, (SELECT p.id FROM profiles p WHERE p.id = dc.profile_id),
+		     dc.payload_schema, dc.tags, dc.created_at, :restored_at
+		   FROM deleted_channels dc WHERE dc.id = :id AND dc.owner = :owner;`
+
+	qs := []string{
+		`INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner, conn_type)
+		 SELECT dc.id, dc.owner, c.thing_id, c.thing_owner, c.conn_type
+		 FROM deleted_channels dc, jsonb_to_recordset(dc.connections) AS c(thing_id UUID, thing_owner VARCHAR(254), conn_type VARCHAR(32))
+		 WHERE dc.id = :id AND EXISTS (SELECT 1 FROM things th WHERE th.id = c.thing_id AND th.owner = c.thing_owner)
+		 ON CONFLICT DO NOTHING;`,
+		`INSERT INTO group_channels (channel_id, group_id, created_at, updated_at)
+		 SELECT dc.id, dc.group_id, :restored_at, :restored_at FROM deleted_channels dc
+		 WHERE dc.id = :id AND EXISTS (SELECT 1 FROM groups g WHERE g.id = dc.group_id)
+		 ON CONFLICT DO NOTHING;`,
+		`DELETE FROM deleted_channels WHERE id = :id;`,
+	}
+
+	tx, err := cr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	restoredAt := time.Now().UTC()
 	for _, id := range ids {
-		dbch := dbChannel{
-			ID:    id,
-			Owner: owner,
+		params := map[string]interface{}{
+			"id":          id,
+			"owner":       owner,
+			"restored_at": restoredAt,
 		}
-		q := `DELETE FROM channels WHERE id = :id AND owner = :owner`
-		_, err := cr.db.NamedExecContext(ctx, q, dbch)
+
+		res, err := tx.NamedExecContext(ctx, qc, params)
 		if err != nil {
-			return errors.Wrap(errors.ErrRemoveEntity, err)
+			tx.Rollback()
+			return restoreError(err)
+		}
+
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(errors.ErrCreateEntity, err)
 		}
+
+		if cnt == 0 {
+			tx.Rollback()
+			return errors.ErrNotFound
+		}
+
+		for _, q := range qs {
+			if _, err := tx.NamedExecContext(ctx, q, params); err != nil {
+				tx.Rollback()
+				return restoreError(err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (cr channelRepository) Purge(ctx context.Context, before time.Time) error {
+	q := `DELETE FROM deleted_channels WHERE deleted_at < :before;`
+
+	if _, err := cr.db.NamedExecContext(ctx, q, map[string]interface{}{"before": before}); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
 	}
 
 	return nil
 }
 
-func (cr channelRepository) Connect(ctx context.Context, owner, chID string, thIDs []string) error {
+func (cr channelRepository) Connect(ctx context.Context, owner, chID string, thIDs []string, connType string) error {
 	tx, err := cr.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return errors.Wrap(things.ErrConnect, err)
 	}
 
-	q := `INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner)
-	      VALUES (:channel, :owner, :thing, :owner);`
+	q := `INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner, conn_type)
+	      VALUES (:channel, :owner, :thing, :owner, :conn_type);`
 
 	for _, thID := range thIDs {
 		dbco := dbConnection{
 			Channel: chID,
 			Thing:   thID,
 			Owner:   owner,
+			Type:    connType,
 		}
 
 		_, err := tx.NamedExecContext(ctx, q, dbco)
@@ -355,42 +568,25 @@ func (cr channelRepository) Disconnect(ctx context.Context, owner, chID string,
 	return nil
 }
 
-func (cr channelRepository) RetrieveConnByThingKey(ctx context.Context, thingKey string) (things.Connection, error) {
-	var thingID string
-	q := `SELECT id FROM things WHERE key = $1`
-	if err := cr.db.QueryRowxContext(ctx, q, thingKey).Scan(&thingID); err != nil {
-		return things.Connection{}, errors.Wrap(errors.ErrRetrieveEntity, err)
-	}
-
-	q = fmt.Sprintf(`SELECT thing_id, channel_id FROM connections
-		               WHERE thing_id = :thing;`)
-
-	params := map[string]interface{}{
-		"thing": thingID,
-	}
-
-	rows, err := cr.db.NamedQueryContext(ctx, q, params)
-	if err != nil {
-		return things.Connection{}, errors.Wrap(errors.ErrRetrieveEntity, err)
-	}
-	defer rows.Close()
+func (cr channelRepository) RetrieveConnection(ctx context.Context, chID, thID string) (things.Connection, error) {
+	q := `SELECT channel_id, channel_owner, thing_id, thing_owner, conn_type FROM connections
+	      WHERE channel_id = $1 AND thing_id = $2;`
 
-	dbch := dbConn{}
-	for rows.Next() {
-		if err := rows.StructScan(&dbch); err != nil {
-			return things.Connection{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	var dbco dbConn
+	if err := cr.db.QueryRowxContext(ctx, q, chID, thID).StructScan(&dbco); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		//  If there is no result or ID is in an invalid format, return ErrNotFound.
+		if err == sql.ErrNoRows || ok && pgerrcode.InvalidTextRepresentation == pgErr.Code {
+			return things.Connection{}, errors.Wrap(errors.ErrNotFound, err)
 		}
-	}
-
-	if dbch.ChannelID == "" {
 		return things.Connection{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
 
-	return things.Connection{ThingID: thingID, ChannelID: dbch.ChannelID}, nil
+	return toConnection(dbco), nil
 }
 
 func (cr channelRepository) RetrieveAllConnections(ctx context.Context) ([]things.Connection, error) {
-	q := `SELECT channel_id, channel_owner, thing_id, thing_owner FROM connections;`
+	q := `SELECT channel_id, channel_owner, thing_id, thing_owner, conn_type FROM connections;`
 
 	rows, err := cr.db.NamedQueryContext(ctx, q, map[string]interface{}{})
 	if err != nil {
@@ -420,6 +616,10 @@ func (cr channelRepository) retrieve(ctx context.Context, owner string, includeO
 	if err != nil {
 		return things.ChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
+	fq, fp, err := getFiltersQuery(channelEntity, pm)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
 
 	var whereClause string
 	var query []string
@@ -432,6 +632,7 @@ func (cr channelRepository) retrieve(ctx context.Context, owner string, includeO
 	if nq != "" {
 		query = append(query, nq)
 	}
+	query = append(query, fq...)
 	if len(query) > 0 {
 		whereClause = fmt.Sprintf(" WHERE %s", strings.Join(query, " AND "))
 	}
@@ -441,10 +642,10 @@ func (cr channelRepository) retrieve(ctx context.Context, owner string, includeO
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, metadata FROM channels %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
+	q := fmt.Sprintf(`SELECT id, name, metadata, profile_id, payload_schema, tags FROM channels %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
 
 	if includeOwner {
-		q = "SELECT id, name, owner, metadata FROM channels;"
+		q = "SELECT id, name, owner, metadata, profile_id, payload_schema, tags FROM channels;"
 	}
 
 	params := map[string]interface{}{
@@ -454,6 +655,9 @@ func (cr channelRepository) retrieve(ctx context.Context, owner string, includeO
 		"name":     name,
 		"metadata": meta,
 	}
+	for k, v := range fp {
+		params[k] = v
+	}
 	rows, err := cr.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
 		return things.ChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
@@ -530,28 +734,107 @@ func (m dbMetadata) Value() (driver.Value, error) {
 	return b, err
 }
 
+// dbPayloadSchema type for handling the channel payload schema in database/sql.
+type dbPayloadSchema schema.Schema
+
+// Scan implements the database/sql scanner interface.
+func (ps *dbPayloadSchema) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.ErrScanMetadata
+	}
+
+	return json.Unmarshal(b, ps)
+}
+
+// Value implements database/sql valuer interface.
+func (ps dbPayloadSchema) Value() (driver.Value, error) {
+	if schema.Schema(ps).Empty() {
+		return nil, nil
+	}
+
+	return json.Marshal(ps)
+}
+
 type dbChannel struct {
-	ID       string     `db:"id"`
-	Owner    string     `db:"owner"`
-	Name     string     `db:"name"`
-	Metadata dbMetadata `db:"metadata"`
+	ID            string          `db:"id"`
+	Owner         string          `db:"owner"`
+	Name          string          `db:"name"`
+	Metadata      dbMetadata      `db:"metadata"`
+	ProfileID     sql.NullString  `db:"profile_id"`
+	PayloadSchema dbPayloadSchema `db:"payload_schema"`
+	Tags          dbTags          `db:"tags"`
+}
+
+type dbDeletedChannel struct {
+	ID            string          `db:"id"`
+	Owner         string          `db:"owner"`
+	Name          string          `db:"name"`
+	Metadata      dbMetadata      `db:"metadata"`
+	ProfileID     sql.NullString  `db:"profile_id"`
+	PayloadSchema dbPayloadSchema `db:"payload_schema"`
+	Tags          dbTags          `db:"tags"`
+	DeletedAt     time.Time       `db:"deleted_at"`
+	Connections   []byte          `db:"connections"`
+	GroupID       sql.NullString  `db:"group_id"`
+}
+
+func toDeletedChannel(dbch dbDeletedChannel) (things.DeletedChannel, error) {
+	var conns []struct {
+		ThingID string `json:"thing_id"`
+	}
+	if err := json.Unmarshal(dbch.Connections, &conns); err != nil {
+		return things.DeletedChannel{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	var thIDs []string
+	for _, c := range conns {
+		thIDs = append(thIDs, c.ThingID)
+	}
+
+	ch := toChannel(dbChannel{
+		ID:            dbch.ID,
+		Owner:         dbch.Owner,
+		Name:          dbch.Name,
+		Metadata:      dbch.Metadata,
+		ProfileID:     dbch.ProfileID,
+		PayloadSchema: dbch.PayloadSchema,
+		Tags:          dbch.Tags,
+	})
+
+	return things.DeletedChannel{
+		Channel:   ch,
+		ThingIDs:  thIDs,
+		GroupID:   dbch.GroupID.String,
+		DeletedAt: dbch.DeletedAt,
+	}, nil
 }
 
 func toDBChannel(ch things.Channel) dbChannel {
 	return dbChannel{
-		ID:       ch.ID,
-		Owner:    ch.Owner,
-		Name:     ch.Name,
-		Metadata: ch.Metadata,
+		ID:            ch.ID,
+		Owner:         ch.Owner,
+		Name:          ch.Name,
+		Metadata:      ch.Metadata,
+		ProfileID:     toNullString(ch.ProfileID),
+		PayloadSchema: dbPayloadSchema(ch.PayloadSchema),
+		Tags:          ch.Tags,
 	}
 }
 
 func toChannel(ch dbChannel) things.Channel {
 	return things.Channel{
-		ID:       ch.ID,
-		Owner:    ch.Owner,
-		Name:     ch.Name,
-		Metadata: ch.Metadata,
+		ID:            ch.ID,
+		Owner:         ch.Owner,
+		Name:          ch.Name,
+		Metadata:      ch.Metadata,
+		ProfileID:     ch.ProfileID.String,
+		PayloadSchema: schema.Schema(ch.PayloadSchema),
+		Tags:          ch.Tags,
 	}
 }
 
@@ -560,6 +843,7 @@ type dbConn struct {
 	ChannelOwner string `db:"channel_owner"`
 	ThingID      string `db:"thing_id"`
 	ThingOwner   string `db:"thing_owner"`
+	Type         string `db:"conn_type"`
 }
 
 func toConnection(co dbConn) things.Connection {
@@ -568,6 +852,7 @@ func toConnection(co dbConn) things.Connection {
 		ChannelOwner: co.ChannelOwner,
 		ThingID:      co.ThingID,
 		ThingOwner:   co.ThingOwner,
+		Type:         co.Type,
 	}
 }
 
@@ -580,6 +865,14 @@ func getOrderQuery(order string) string {
 	}
 }
 
+func getProfileQuery(profileID string) string {
+	if profileID == "" {
+		return ""
+	}
+
+	return "profile_id = :profile_id"
+}
+
 func getConnOrderQuery(order string, level string) string {
 	switch order {
 	case "name":
@@ -598,6 +891,20 @@ func getDirQuery(dir string) string {
 	}
 }
 
+func restoreError(err error) error {
+	pgErr, ok := err.(*pgconn.PgError)
+	if ok {
+		switch pgErr.Code {
+		case pgerrcode.InvalidTextRepresentation:
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		case pgerrcode.UniqueViolation:
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+	}
+
+	return errors.Wrap(errors.ErrCreateEntity, err)
+}
+
 func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
 	rows, err := db.NamedQueryContext(ctx, query, params)
 	if err != nil {
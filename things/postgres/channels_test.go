@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/MainfluxLabs/mainflux/things/postgres"
 	"github.com/stretchr/testify/assert"
@@ -139,6 +140,35 @@ func TestChannelUpdate(t *testing.T) {
 	}
 }
 
+func TestChannelPayloadSchema(t *testing.T) {
+	email := "channel-payload-schema@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	chanRepo := postgres.NewChannelRepository(dbMiddleware)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	ch := things.Channel{
+		ID:            id,
+		Owner:         email,
+		PayloadSchema: schema.Schema{SenML: map[string]string{"temp": "Cel"}},
+	}
+
+	_, err = chanRepo.Save(context.Background(), ch)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	saved, err := chanRepo.RetrieveByID(context.Background(), id)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, ch.PayloadSchema, saved.PayloadSchema, fmt.Sprintf("retrieve saved payload schema: expected %v got %v\n", ch.PayloadSchema, saved.PayloadSchema))
+
+	ch.PayloadSchema = schema.Schema{}
+	err = chanRepo.Update(context.Background(), ch)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	updated, err := chanRepo.RetrieveByID(context.Background(), id)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.True(t, updated.PayloadSchema.Empty(), fmt.Sprintf("retrieve removed payload schema: expected empty schema got %v\n", updated.PayloadSchema))
+}
+
 func TestSingleChannelRetrieval(t *testing.T) {
 	email := "channel-single-retrieval@example.com"
 	dbMiddleware := postgres.NewDatabase(db)
@@ -168,7 +198,7 @@ func TestSingleChannelRetrieval(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	ch := chs[0]
 
-	err = chanRepo.Connect(context.Background(), email, ch.ID, []string{th.ID})
+	err = chanRepo.Connect(context.Background(), email, ch.ID, []string{th.ID}, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
 
 	nonexistentChanID, err := idProvider.ID()
@@ -395,7 +425,7 @@ func TestRetrieveByThing(t *testing.T) {
 	_, err = chanRepo.Save(context.Background(), ch)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = chanRepo.Connect(context.Background(), email, chID, []string{thID})
+	err = chanRepo.Connect(context.Background(), email, chID, []string{thID}, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	nonexistentThingID, err := idProvider.ID()
@@ -571,7 +601,7 @@ func TestConnect(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		err := chanRepo.Connect(context.Background(), tc.owner, tc.chID, []string{tc.thID})
+		err := chanRepo.Connect(context.Background(), tc.owner, tc.chID, []string{tc.thID}, things.ConnTypePubSub)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
@@ -604,7 +634,7 @@ func TestDisconnect(t *testing.T) {
 	})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	ch := chs[0]
-	err = chanRepo.Connect(context.Background(), email, ch.ID, []string{thID})
+	err = chanRepo.Connect(context.Background(), email, ch.ID, []string{thID}, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	nonexistentThingID, err := idProvider.ID()
@@ -663,7 +693,7 @@ func TestDisconnect(t *testing.T) {
 	}
 }
 
-func TestRetrieveConnByThingKey(t *testing.T) {
+func TestRetrieveConnection(t *testing.T) {
 	email := "channel-access-check@example.com"
 	dbMiddleware := postgres.NewDatabase(db)
 	thingRepo := postgres.NewThingRepository(dbMiddleware)
@@ -691,28 +721,45 @@ func TestRetrieveConnByThingKey(t *testing.T) {
 	})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	chID = chs[0].ID
-	err = chanRepo.Connect(context.Background(), email, chID, []string{thID})
+	err = chanRepo.Connect(context.Background(), email, chID, []string{thID}, things.ConnTypePublish)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
+	wrongID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
 	cases := map[string]struct {
-		chID      string
-		key       string
-		hasAccess bool
+		chID     string
+		thID     string
+		connType string
+		err      error
 	}{
-		"access check for thing that has access": {
-			key:       th.Key,
-			hasAccess: true,
+		"retrieve existing connection": {
+			chID:     chID,
+			thID:     thID,
+			connType: things.ConnTypePublish,
+			err:      nil,
 		},
-		"access check for thing without access": {
-			key:       wrongValue,
-			hasAccess: false,
+		"retrieve connection of unconnected thing": {
+			chID: chID,
+			thID: wrongID,
+			err:  errors.ErrNotFound,
+		},
+		"retrieve connection of non-existing channel": {
+			chID: wrongID,
+			thID: thID,
+			err:  errors.ErrNotFound,
+		},
+		"retrieve connection with malformed channel id": {
+			chID: wrongValue,
+			thID: thID,
+			err:  errors.ErrNotFound,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := chanRepo.RetrieveConnByThingKey(context.Background(), tc.key)
-		hasAccess := err == nil
-		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
+		conn, err := chanRepo.RetrieveConnection(context.Background(), tc.chID, tc.thID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		assert.Equal(t, tc.connType, conn.Type, fmt.Sprintf("%s: expected type %s got %s\n", desc, tc.connType, conn.Type))
 	}
 }
 
@@ -813,7 +860,7 @@ func TestRetrieveAllConnections(t *testing.T) {
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 		chID = chs[0].ID
 
-		err = chanRepo.Connect(context.Background(), email, chID, []string{thID})
+		err = chanRepo.Connect(context.Background(), email, chID, []string{thID}, things.ConnTypePubSub)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	}
 
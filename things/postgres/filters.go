@@ -0,0 +1,179 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/things"
+	"github.com/gofrs/uuid"
+)
+
+// entity describes the tables needed to filter things or channels by
+// their group membership and connections.
+type entity struct {
+	table       string
+	groupsTable string
+	column      string
+}
+
+var (
+	thingEntity   = entity{table: "things", groupsTable: "group_things", column: "thing_id"}
+	channelEntity = entity{table: "channels", groupsTable: "group_channels", column: "channel_id"}
+)
+
+var comparators = map[string]string{
+	things.OpEq:  "==",
+	things.OpNe:  "!=",
+	things.OpGt:  ">",
+	things.OpGte: ">=",
+	things.OpLt:  "<",
+	things.OpLte: "<=",
+}
+
+// getFiltersQuery returns the conditions and their named parameters that
+// match the search filters of the page metadata. Metadata filters are
+// compiled to JSON path predicates, so they are served by the GIN index
+// on the metadata column.
+func getFiltersQuery(e entity, pm things.PageMetadata) ([]string, map[string]interface{}, error) {
+	var query []string
+	params := map[string]interface{}{}
+
+	for i, f := range pm.Filters {
+		jp, err := toJSONPath(f)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		key := fmt.Sprintf("filter_%d", i)
+		query = append(query, fmt.Sprintf("metadata @? CAST(:%s AS jsonpath)", key))
+		params[key] = jp
+	}
+
+	if !pm.Created.From.IsZero() {
+		query = append(query, "created_at >= :created_from")
+		params["created_from"] = pm.Created.From
+	}
+	if !pm.Created.To.IsZero() {
+		query = append(query, "created_at <= :created_to")
+		params["created_to"] = pm.Created.To
+	}
+	if !pm.Updated.From.IsZero() {
+		query = append(query, "updated_at >= :updated_from")
+		params["updated_from"] = pm.Updated.From
+	}
+	if !pm.Updated.To.IsZero() {
+		query = append(query, "updated_at <= :updated_to")
+		params["updated_to"] = pm.Updated.To
+	}
+
+	// Members of the descendant groups are members of the group as well.
+	if pm.GroupID != "" {
+		query = append(query, fmt.Sprintf(`%s.id IN (SELECT gm.%s FROM %s gm
+			JOIN group_hierarchy gh ON gh.descendant_id = gm.group_id WHERE gh.ancestor_id = :group_id)`,
+			e.table, e.column, e.groupsTable))
+		params["group_id"] = pm.GroupID
+	}
+
+	if pm.IDs != nil {
+		iq, ip := getIDsQuery(fmt.Sprintf("%s.id", e.table), pm.IDs)
+		query = append(query, iq)
+		for k, v := range ip {
+			params[k] = v
+		}
+	}
+
+	if pm.Connected != nil {
+		cq := fmt.Sprintf("EXISTS (SELECT 1 FROM connections conn WHERE conn.%s = %s.id)", e.column, e.table)
+		if !*pm.Connected {
+			cq = "NOT " + cq
+		}
+		query = append(query, cq)
+	}
+
+	tq, tp, err := getTagsQuery(fmt.Sprintf("%s.tags", e.table), pm.Tags)
+	if err != nil {
+		return nil, nil, err
+	}
+	query = append(query, tq...)
+	for k, v := range tp {
+		params[k] = v
+	}
+
+	return query, params, nil
+}
+
+// getIDsQuery returns the condition that matches the entities with the
+// given IDs. IDs that are not valid UUIDs can not match any entity.
+func getIDsQuery(column string, ids []string) (string, map[string]interface{}) {
+	var keys []string
+	params := map[string]interface{}{}
+	for i, id := range ids {
+		if _, err := uuid.FromString(id); err != nil {
+			continue
+		}
+
+		key := fmt.Sprintf("id_%d", i)
+		keys = append(keys, ":"+key)
+		params[key] = id
+	}
+
+	if len(keys) == 0 {
+		return "FALSE", params
+	}
+
+	return fmt.Sprintf("%s IN (%s)", column, strings.Join(keys, ", ")), params
+}
+
+// toJSONPath converts the metadata filter to a JSON path predicate. The
+// filter keys are restricted to a safe character set by validation and
+// values are encoded as JSON literals, which JSON path string and numeric
+// literals are compatible with.
+func toJSONPath(f things.MetadataFilter) (string, error) {
+	if err := f.Validate(); err != nil {
+		return "", err
+	}
+
+	path := "$"
+	for _, k := range f.Keys() {
+		path += fmt.Sprintf(".%q", k)
+	}
+
+	var cond string
+	switch f.Op {
+	case things.OpIn:
+		var conds []string
+		for _, v := range f.Value.([]interface{}) {
+			lit, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			conds = append(conds, "@ == "+string(lit))
+		}
+		cond = strings.Join(conds, " || ")
+	case things.OpPrefix:
+		lit, err := json.Marshal(f.Value)
+		if err != nil {
+			return "", err
+		}
+		cond = "@ starts with " + string(lit)
+	case things.OpContains:
+		lit, err := json.Marshal(regexp.QuoteMeta(f.Value.(string)))
+		if err != nil {
+			return "", err
+		}
+		cond = fmt.Sprintf(`@ like_regex %s flag "i"`, lit)
+	default:
+		lit, err := json.Marshal(f.Value)
+		if err != nil {
+			return "", err
+		}
+		cond = fmt.Sprintf("@ %s %s", comparators[f.Op], lit)
+	}
+
+	return fmt.Sprintf("%s ? (%s)", path, cond), nil
+}
@@ -6,7 +6,9 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -32,47 +34,55 @@ func NewGroupRepo(db Database) things.GroupRepository {
 }
 
 func (gr groupRepository) Save(ctx context.Context, g things.Group) (things.Group, error) {
-	q := `INSERT INTO groups (name, description, id, owner_id, metadata, created_at, updated_at)
-		  VALUES (:name, :description, :id, :owner_id, :metadata, :created_at, :updated_at)
-		  RETURNING id, name, owner_id, description, metadata, created_at, updated_at`
+	q := `INSERT INTO groups (name, description, id, owner_id, parent_id, metadata, tags, created_at, updated_at)
+		  VALUES (:name, :description, :id, :owner_id, :parent_id, :metadata, :tags, :created_at, :updated_at)`
+
+	// Every group is its own ancestor at depth 0 and inherits all ancestors of its parent.
+	qh := `INSERT INTO group_hierarchy (ancestor_id, descendant_id, depth)
+		   SELECT ancestor_id, CAST(:id AS UUID), depth + 1 FROM group_hierarchy WHERE descendant_id = :parent_id
+		   UNION ALL SELECT CAST(:id AS UUID), CAST(:id AS UUID), 0`
 
 	dbg, err := toDBGroup(g)
 	if err != nil {
 		return things.Group{}, err
 	}
 
-	row, err := gr.db.NamedQueryContext(ctx, q, dbg)
+	tx, err := gr.db.BeginTxx(ctx, nil)
 	if err != nil {
-		pgErr, ok := err.(*pgconn.PgError)
-		if ok {
-			switch pgErr.Code {
-			case pgerrcode.InvalidTextRepresentation:
-				return things.Group{}, errors.Wrap(errors.ErrMalformedEntity, err)
-			case pgerrcode.ForeignKeyViolation:
-				return things.Group{}, errors.Wrap(errors.ErrCreateEntity, err)
-			case pgerrcode.UniqueViolation:
-				return things.Group{}, errors.Wrap(errors.ErrConflict, err)
-			case pgerrcode.StringDataRightTruncationDataException:
-				return things.Group{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		return things.Group{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	for _, query := range []string{q, qh} {
+		if _, err := tx.NamedExecContext(ctx, query, dbg); err != nil {
+			tx.Rollback()
+			pgErr, ok := err.(*pgconn.PgError)
+			if ok {
+				switch pgErr.Code {
+				case pgerrcode.InvalidTextRepresentation:
+					return things.Group{}, errors.Wrap(errors.ErrMalformedEntity, err)
+				case pgerrcode.ForeignKeyViolation:
+					return things.Group{}, errors.Wrap(errors.ErrCreateEntity, err)
+				case pgerrcode.UniqueViolation:
+					return things.Group{}, errors.Wrap(errors.ErrConflict, err)
+				case pgerrcode.StringDataRightTruncationDataException:
+					return things.Group{}, errors.Wrap(errors.ErrMalformedEntity, err)
+				}
 			}
-		}
 
-		return things.Group{}, errors.Wrap(errors.ErrCreateEntity, err)
+			return things.Group{}, errors.Wrap(errors.ErrCreateEntity, err)
+		}
 	}
 
-	defer row.Close()
-	row.Next()
-	dbg = dbGroup{}
-	if err := row.StructScan(&dbg); err != nil {
-		return things.Group{}, err
+	if err := tx.Commit(); err != nil {
+		return things.Group{}, errors.Wrap(errors.ErrCreateEntity, err)
 	}
 
-	return toGroup(dbg)
+	return gr.RetrieveByID(ctx, g.ID)
 }
 
 func (gr groupRepository) Update(ctx context.Context, g things.Group) (things.Group, error) {
-	q := `UPDATE groups SET name = :name, description = :description, metadata = :metadata, updated_at = :updated_at WHERE id = :id
-		  RETURNING id, name, owner_id, description, metadata, created_at, updated_at`
+	q := fmt.Sprintf(`UPDATE groups g SET name = :name, description = :description, metadata = :metadata, tags = :tags, updated_at = :updated_at WHERE g.id = :id
+		  RETURNING %s`, groupColumns)
 
 	dbu, err := toDBGroup(g)
 	if err != nil {
@@ -106,20 +116,45 @@ func (gr groupRepository) Update(ctx context.Context, g things.Group) (things.Gr
 }
 
 func (gr groupRepository) Remove(ctx context.Context, groupIDs ...string) error {
+	// A group is moved to the trash along with its members and its depth in
+	// the hierarchy, so that restored ancestors precede their descendants.
+	qt := `DELETE FROM deleted_groups WHERE id = :id AND EXISTS (SELECT 1 FROM groups WHERE id = :id);`
+	qs := `INSERT INTO deleted_groups (id, owner_id, parent_id, name, description, metadata, tags, created_at, updated_at,
+		     deleted_at, depth, things, channels)
+		   SELECT g.id, g.owner_id, g.parent_id, g.name, g.description, g.metadata, g.tags, g.created_at, g.updated_at, :deleted_at,
+		     (SELECT MAX(gh.depth) FROM group_hierarchy gh WHERE gh.descendant_id = g.id),
+		     COALESCE((SELECT jsonb_agg(gt.thing_id) FROM group_things gt WHERE gt.group_id = g.id), '[]'),
+		     COALESCE((SELECT jsonb_agg(gc.channel_id) FROM group_channels gc WHERE gc.group_id = g.id), '[]')
+		   FROM groups g WHERE g.id = :id;`
 	qd := `DELETE FROM groups WHERE id = :id`
 
+	tx, err := gr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	deletedAt := time.Now().UTC()
 	for _, groupID := range groupIDs {
-		group := things.Group{
-			ID: groupID,
+		params := map[string]interface{}{
+			"id":         groupID,
+			"deleted_at": deletedAt,
 		}
 
-		dbg, err := toDBGroup(group)
-		if err != nil {
-			return errors.Wrap(errors.ErrUpdateEntity, err)
+		for _, q := range []string{qt, qs} {
+			if _, err := tx.NamedExecContext(ctx, q, params); err != nil {
+				tx.Rollback()
+				pqErr, ok := err.(*pgconn.PgError)
+				if ok && pqErr.Code == pgerrcode.InvalidTextRepresentation {
+					return errors.Wrap(errors.ErrMalformedEntity, err)
+				}
+
+				return errors.Wrap(errors.ErrRemoveEntity, err)
+			}
 		}
 
-		res, err := gr.db.NamedExecContext(ctx, qd, dbg)
+		res, err := tx.NamedExecContext(ctx, qd, params)
 		if err != nil {
+			tx.Rollback()
 			pqErr, ok := err.(*pgconn.PgError)
 			if ok {
 				switch pqErr.Code {
@@ -135,17 +170,193 @@ func (gr groupRepository) Remove(ctx context.Context, groupIDs ...string) error
 
 		cnt, err := res.RowsAffected()
 		if err != nil {
+			tx.Rollback()
 			return errors.Wrap(errors.ErrRemoveEntity, err)
 		}
 
 		if cnt != 1 {
+			tx.Rollback()
 			return errors.Wrap(errors.ErrRemoveEntity, err)
 		}
 	}
 
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (gr groupRepository) RetrieveDeleted(ctx context.Context, ownerID string, pm things.PageMetadata) (things.DeletedGroupsPage, error) {
+	nq, name := dbutil.GetNameQuery(pm.Name)
+
+	query := []string{"owner_id = :owner_id"}
+	if nq != "" {
+		query = append(query, nq)
+	}
+	whereClause := fmt.Sprintf(" WHERE %s", strings.Join(query, " AND "))
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner_id, parent_id, name, description, metadata, tags, created_at, updated_at, deleted_at, things, channels
+		  FROM deleted_groups %s ORDER BY deleted_at DESC %s;`, whereClause, olq)
+
+	params := map[string]interface{}{
+		"owner_id": ownerID,
+		"name":     name,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+
+	rows, err := gr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.DeletedGroupsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []things.DeletedGroup
+	for rows.Next() {
+		dbg := dbDeletedGroup{}
+		if err := rows.StructScan(&dbg); err != nil {
+			return things.DeletedGroupsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		g, err := toDeletedGroup(dbg)
+		if err != nil {
+			return things.DeletedGroupsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		items = append(items, g)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM deleted_groups %s;`, whereClause)
+
+	total, err := total(ctx, gr.db, cq, params)
+	if err != nil {
+		return things.DeletedGroupsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := things.DeletedGroupsPage{
+		Groups: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (gr groupRepository) Restore(ctx context.Context, ownerID string, groupIDs ...string) error {
+	// Groups are restored by ascending depth, so parents restored in the
+	// same call exist by the time their children are inserted.
+	qo := `SELECT depth FROM deleted_groups WHERE id = :id AND owner_id = :owner_id;`
+
+	qg := `INSERT INTO groups (id, owner_id, parent_id, name, description, metadata, tags, created_at, updated_at)
+		   SELECT dg.id, dg.owner_id, (SELECT g.id FROM groups g WHERE g.id = dg.parent_id), dg.name, dg.description,
+		     dg.metadata, dg.tags, dg.created_at, :restored_at
+		   FROM deleted_groups dg WHERE dg.id = :id AND dg.owner_id = :owner_id;`
+
+	qs := []string{
+		`INSERT INTO group_hierarchy (ancestor_id, descendant_id, depth)
+		 SELECT gh.ancestor_id, g.id, gh.depth + 1 FROM groups g
+		 JOIN group_hierarchy gh ON gh.descendant_id = g.parent_id WHERE g.id = :id
+		 UNION ALL SELECT CAST(:id AS UUID), CAST(:id AS UUID), 0;`,
+		`INSERT INTO group_things (thing_id, group_id, created_at, updated_at)
+		 SELECT CAST(t.id AS UUID), dg.id, :restored_at, :restored_at
+		 FROM deleted_groups dg, jsonb_array_elements_text(dg.things) AS t(id)
+		 WHERE dg.id = :id AND EXISTS (SELECT 1 FROM things th WHERE th.id = CAST(t.id AS UUID))
+		 ON CONFLICT DO NOTHING;`,
+		`INSERT INTO group_channels (channel_id, group_id, created_at, updated_at)
+		 SELECT CAST(c.id AS UUID), dg.id, :restored_at, :restored_at
+		 FROM deleted_groups dg, jsonb_array_elements_text(dg.channels) AS c(id)
+		 WHERE dg.id = :id AND EXISTS (SELECT 1 FROM channels ch WHERE ch.id = CAST(c.id AS UUID))
+		 ON CONFLICT DO NOTHING;`,
+		`DELETE FROM deleted_groups WHERE id = :id;`,
+	}
+
+	tx, err := gr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	depths := map[string]int{}
+	for _, id := range groupIDs {
+		rows, err := tx.NamedQuery(qo, map[string]interface{}{"id": id, "owner_id": ownerID})
+		if err != nil {
+			tx.Rollback()
+			return restoreError(err)
+		}
+
+		found := rows.Next()
+		var depth int
+		if found {
+			err = rows.Scan(&depth)
+		}
+		rows.Close()
+
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(errors.ErrCreateEntity, err)
+		}
+		if !found {
+			tx.Rollback()
+			return errors.ErrNotFound
+		}
+		depths[id] = depth
+	}
+
+	ids := make([]string, 0, len(depths))
+	for id := range depths {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return depths[ids[i]] < depths[ids[j]] })
+
+	restoredAt := time.Now().UTC()
+	for _, id := range ids {
+		params := map[string]interface{}{
+			"id":          id,
+			"owner_id":    ownerID,
+			"restored_at": restoredAt,
+		}
+
+		for _, q := range append([]string{qg}, qs...) {
+			if _, err := tx.NamedExecContext(ctx, q, params); err != nil {
+				tx.Rollback()
+				return restoreError(err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
 	return nil
 }
 
+func (gr groupRepository) Purge(ctx context.Context, before time.Time) error {
+	q := `DELETE FROM deleted_groups WHERE deleted_at < :before;`
+
+	if _, err := gr.db.NamedExecContext(ctx, q, map[string]interface{}{"before": before}); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (gr groupRepository) AddTags(ctx context.Context, ownerID string, tags []string, groupIDs ...string) error {
+	return updateTags(ctx, gr.db, "groups", "owner_id", ownerID, tags, true, groupIDs...)
+}
+
+func (gr groupRepository) RemoveTags(ctx context.Context, ownerID string, tags []string, groupIDs ...string) error {
+	return updateTags(ctx, gr.db, "groups", "owner_id", ownerID, tags, false, groupIDs...)
+}
+
 func (gr groupRepository) RetrieveAll(ctx context.Context) ([]things.Group, error) {
 	gp, err := gr.retrieve(ctx, "", things.PageMetadata{})
 	if err != nil {
@@ -159,7 +370,7 @@ func (gr groupRepository) RetrieveByID(ctx context.Context, id string) (things.G
 	dbu := dbGroup{
 		ID: id,
 	}
-	q := `SELECT id, name, owner_id, description, metadata, created_at, updated_at FROM groups WHERE id = $1`
+	q := fmt.Sprintf(`SELECT %s FROM groups g WHERE g.id = $1`, groupColumns)
 	if err := gr.db.QueryRowxContext(ctx, q, id).StructScan(&dbu); err != nil {
 		if err == sql.ErrNoRows {
 			return things.Group{}, errors.Wrap(errors.ErrNotFound, err)
@@ -176,7 +387,7 @@ func (gr groupRepository) RetrieveByIDs(ctx context.Context, groupIDs []string)
 	}
 
 	idq := fmt.Sprintf("WHERE id IN ('%s') ", strings.Join(groupIDs, "','"))
-	q := fmt.Sprintf(`SELECT id, name, owner_id, description, metadata, created_at, updated_at FROM groups %s;`, idq)
+	q := fmt.Sprintf(`SELECT %s FROM groups g %s;`, groupColumns, idq)
 
 	rows, err := gr.db.NamedQueryContext(ctx, q, map[string]interface{}{})
 	if err != nil {
@@ -215,6 +426,114 @@ func (gr groupRepository) RetrieveByIDs(ctx context.Context, groupIDs []string)
 	return page, nil
 }
 
+func (gr groupRepository) RetrieveChildren(ctx context.Context, groupID string, depth uint64, pm things.PageMetadata) (things.GroupPage, error) {
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT %s FROM groups g
+		JOIN group_hierarchy gh ON gh.descendant_id = g.id
+		WHERE gh.ancestor_id = :id AND gh.depth BETWEEN 1 AND :depth
+		ORDER BY gh.depth, g.name %s;`, groupColumns, olq)
+
+	params := map[string]interface{}{
+		"id":     groupID,
+		"depth":  depth,
+		"limit":  pm.Limit,
+		"offset": pm.Offset,
+	}
+
+	rows, err := gr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return things.GroupPage{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return things.GroupPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	items := []things.Group{}
+	for rows.Next() {
+		dbg := dbGroup{}
+		if err := rows.StructScan(&dbg); err != nil {
+			return things.GroupPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		gr, err := toGroup(dbg)
+		if err != nil {
+			return things.GroupPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, gr)
+	}
+
+	cq := `SELECT COUNT(*) FROM group_hierarchy WHERE ancestor_id = :id AND depth BETWEEN 1 AND :depth;`
+
+	total, err := total(ctx, gr.db, cq, params)
+	if err != nil {
+		return things.GroupPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := things.GroupPage{
+		Groups: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (gr groupRepository) Move(ctx context.Context, groupID, parentID string) error {
+	params := map[string]interface{}{
+		"id":        groupID,
+		"parent_id": toNullString(parentID),
+	}
+
+	// Detach the subtree from all ancestors of the moved group.
+	qd := `DELETE FROM group_hierarchy
+		   WHERE descendant_id IN (SELECT descendant_id FROM group_hierarchy WHERE ancestor_id = :id)
+		   AND ancestor_id IN (SELECT ancestor_id FROM group_hierarchy WHERE descendant_id = :id AND ancestor_id != :id)`
+
+	// Attach the subtree to all ancestors of the new parent.
+	qi := `INSERT INTO group_hierarchy (ancestor_id, descendant_id, depth)
+		   SELECT p.ancestor_id, c.descendant_id, p.depth + c.depth + 1
+		   FROM group_hierarchy p CROSS JOIN group_hierarchy c
+		   WHERE p.descendant_id = :parent_id AND c.ancestor_id = :id`
+
+	qu := `UPDATE groups SET parent_id = :parent_id WHERE id = :id`
+
+	tx, err := gr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	for _, q := range []string{qd, qi, qu} {
+		if _, err := tx.NamedExecContext(ctx, q, params); err != nil {
+			tx.Rollback()
+			pgErr, ok := err.(*pgconn.PgError)
+			if ok {
+				switch pgErr.Code {
+				case pgerrcode.InvalidTextRepresentation:
+					return errors.Wrap(errors.ErrMalformedEntity, err)
+				case pgerrcode.ForeignKeyViolation:
+					return errors.Wrap(errors.ErrNotFound, err)
+				}
+			}
+
+			return errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
 func (gr groupRepository) RetrieveByOwner(ctx context.Context, ownerID string, pm things.PageMetadata) (things.GroupPage, error) {
 	if ownerID == "" {
 		return things.GroupPage{}, errors.ErrRetrieveEntity
@@ -238,7 +557,7 @@ func (gr groupRepository) RetrieveGroupThings(ctx context.Context, groupID strin
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT t.id, t.owner, t.name, t.metadata, t.key
+	q := fmt.Sprintf(`SELECT t.id, t.owner, t.name, t.metadata, t.key, t.tags
 			FROM group_things gr, things t
 			WHERE gr.group_id = :group_id and gr.thing_id = t.id
 			%s %s;`, mq, olq)
@@ -300,7 +619,7 @@ func (gr groupRepository) RetrieveGroupThingsByChannel(ctx context.Context, grou
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT t.id, t.owner, t.name, t.metadata, t.key
+	q := fmt.Sprintf(`SELECT t.id, t.owner, t.name, t.metadata, t.key, t.tags
 		FROM group_things gr, things t, group_channels gc
 		WHERE gr.group_id = :group_id and gr.thing_id = t.id and gc.group_id = gr.group_id and gc.channel_id = :channel_id and t.id
 		NOT IN (SELECT c.thing_id FROM connections c)
@@ -377,6 +696,29 @@ func (gr groupRepository) RetrieveThingMembership(ctx context.Context, thingID s
 	return groupID, nil
 }
 
+func (gr groupRepository) RetrieveThingIDs(ctx context.Context, groupIDs []string) ([]string, error) {
+	iq, params := getIDsQuery("gh.ancestor_id", groupIDs)
+	q := fmt.Sprintf(`SELECT DISTINCT gt.thing_id FROM group_things gt
+		JOIN group_hierarchy gh ON gh.descendant_id = gt.group_id WHERE %s;`, iq)
+
+	rows, err := gr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 func (gr groupRepository) RetrieveAllThingRelations(ctx context.Context) ([]things.GroupThingRelation, error) {
 	q := `SELECT group_id, thing_id, created_at, updated_at FROM group_things`
 
@@ -495,7 +837,7 @@ func (gr groupRepository) RetrieveGroupChannels(ctx context.Context, groupID str
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT c.id, c.owner, c.name, c.metadata
+	q := fmt.Sprintf(`SELECT c.id, c.owner, c.name, c.metadata, c.tags
 			FROM group_channels gr, channels c
 			WHERE gr.group_id = :group_id and gr.channel_id = c.id
 			%s %s;`, mq, olq)
@@ -698,6 +1040,20 @@ func (gr groupRepository) retrieve(ctx context.Context, ownerID string, pm thing
 		query = append(query, nq)
 	}
 
+	tq, tp, err := getTagsQuery("tags", pm.Tags)
+	if err != nil {
+		return things.GroupPage{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	query = append(query, tq...)
+
+	// Descendants of the groups are in the page as well.
+	var ip map[string]interface{}
+	if pm.IDs != nil {
+		var iq string
+		iq, ip = getIDsQuery("ancestor_id", pm.IDs)
+		query = append(query, fmt.Sprintf("id IN (SELECT descendant_id FROM group_hierarchy WHERE %s)", iq))
+	}
+
 	if len(query) > 0 {
 		whereClause = fmt.Sprintf(" WHERE %s", strings.Join(query, " AND "))
 	}
@@ -707,7 +1063,7 @@ func (gr groupRepository) retrieve(ctx context.Context, ownerID string, pm thing
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT id, owner_id, name, description, metadata, created_at, updated_at FROM groups %s %s;`, whereClause, olq)
+	q := fmt.Sprintf(`SELECT %s FROM groups g %s %s;`, groupColumns, whereClause, olq)
 
 	params := map[string]interface{}{
 		"owner_id": ownerID,
@@ -716,6 +1072,12 @@ func (gr groupRepository) retrieve(ctx context.Context, ownerID string, pm thing
 		"name":     name,
 		"metadata": meta,
 	}
+	for k, v := range tp {
+		params[k] = v
+	}
+	for k, v := range ip {
+		params[k] = v
+	}
 
 	rows, err := gr.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
@@ -757,14 +1119,51 @@ func (gr groupRepository) retrieve(ctx context.Context, ownerID string, pm thing
 	return page, nil
 }
 
+// groupColumns lists the columns of a group, including its path computed
+// from the group hierarchy, selected from the groups table aliased as g.
+const groupColumns = `g.id, g.name, g.owner_id, g.parent_id, g.description, g.metadata, g.tags, g.created_at, g.updated_at,
+	(SELECT string_agg(CAST(gh.ancestor_id AS TEXT), ',' ORDER BY gh.depth DESC) FROM group_hierarchy gh WHERE gh.descendant_id = g.id) AS path`
+
 type dbGroup struct {
-	ID          string     `db:"id"`
-	OwnerID     string     `db:"owner_id"`
-	Name        string     `db:"name"`
-	Description string     `db:"description"`
-	Metadata    dbMetadata `db:"metadata"`
-	CreatedAt   time.Time  `db:"created_at"`
-	UpdatedAt   time.Time  `db:"updated_at"`
+	ID          string         `db:"id"`
+	OwnerID     string         `db:"owner_id"`
+	ParentID    sql.NullString `db:"parent_id"`
+	Name        string         `db:"name"`
+	Description string         `db:"description"`
+	Metadata    dbMetadata     `db:"metadata"`
+	Tags        dbTags         `db:"tags"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+	Path        sql.NullString `db:"path"`
+}
+
+type dbDeletedGroup struct {
+	dbGroup
+	DeletedAt time.Time `db:"deleted_at"`
+	Things    []byte    `db:"things"`
+	Channels  []byte    `db:"channels"`
+}
+
+func toDeletedGroup(dbg dbDeletedGroup) (things.DeletedGroup, error) {
+	g, err := toGroup(dbg.dbGroup)
+	if err != nil {
+		return things.DeletedGroup{}, err
+	}
+
+	var thIDs, chIDs []string
+	if err := json.Unmarshal(dbg.Things, &thIDs); err != nil {
+		return things.DeletedGroup{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	if err := json.Unmarshal(dbg.Channels, &chIDs); err != nil {
+		return things.DeletedGroup{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return things.DeletedGroup{
+		Group:      g,
+		ThingIDs:   thIDs,
+		ChannelIDs: chIDs,
+		DeletedAt:  dbg.DeletedAt,
+	}, nil
 }
 
 func toDBGroup(g things.Group) (dbGroup, error) {
@@ -772,23 +1171,36 @@ func toDBGroup(g things.Group) (dbGroup, error) {
 		ID:          g.ID,
 		Name:        g.Name,
 		OwnerID:     g.OwnerID,
+		ParentID:    toNullString(g.ParentID),
 		Description: g.Description,
 		Metadata:    dbMetadata(g.Metadata),
+		Tags:        g.Tags,
 		CreatedAt:   g.CreatedAt,
 		UpdatedAt:   g.UpdatedAt,
 	}, nil
 }
 
 func toGroup(dbu dbGroup) (things.Group, error) {
-	return things.Group{
+	g := things.Group{
 		ID:          dbu.ID,
 		Name:        dbu.Name,
 		OwnerID:     dbu.OwnerID,
+		ParentID:    dbu.ParentID.String,
 		Description: dbu.Description,
 		Metadata:    things.GroupMetadata(dbu.Metadata),
+		Tags:        dbu.Tags,
 		UpdatedAt:   dbu.UpdatedAt,
 		CreatedAt:   dbu.CreatedAt,
-	}, nil
+	}
+	if dbu.Path.Valid {
+		g.Path = strings.Split(dbu.Path.String, ",")
+	}
+
+	return g, nil
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
 }
 
 type dbThingRelation struct {
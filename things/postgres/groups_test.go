@@ -960,3 +960,133 @@ func TestRetrieveThingMembership(t *testing.T) {
 	}
 
 }
+
+func TestRetrieveChildren(t *testing.T) {
+	t.Cleanup(func() { cleanUp(t) })
+	dbMiddleware := postgres.NewDatabase(db)
+	groupRepo := postgres.NewGroupRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Create a chain of n groups where each group is the parent of the next one.
+	var grs []things.Group
+	parentID := ""
+	for i := uint64(0); i < n; i++ {
+		gr := things.Group{
+			ID:       generateGroupID(t),
+			Name:     fmt.Sprintf("%s-%d", groupName, i),
+			OwnerID:  uid,
+			ParentID: parentID,
+		}
+
+		gr, err = groupRepo.Save(context.Background(), gr)
+		require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+		assert.Equal(t, int(i+1), len(gr.Path), fmt.Sprintf("expected path length %d got %d\n", i+1, len(gr.Path)))
+
+		grs = append(grs, gr)
+		parentID = gr.ID
+	}
+
+	cases := map[string]struct {
+		groupID string
+		depth   uint64
+		pm      things.PageMetadata
+		size    uint64
+		total   uint64
+		err     error
+	}{
+		"retrieve direct children": {
+			groupID: grs[0].ID,
+			depth:   1,
+			size:    1,
+			total:   1,
+		},
+		"retrieve all descendants": {
+			groupID: grs[0].ID,
+			depth:   n,
+			size:    n - 1,
+			total:   n - 1,
+		},
+		"retrieve descendants with limit": {
+			groupID: grs[0].ID,
+			depth:   n,
+			pm:      things.PageMetadata{Offset: 1, Limit: 2},
+			size:    2,
+			total:   n - 1,
+		},
+		"retrieve children of a leaf group": {
+			groupID: grs[n-1].ID,
+			depth:   n,
+			size:    0,
+			total:   0,
+		},
+		"retrieve children with invalid group id": {
+			groupID: invalid,
+			depth:   1,
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := groupRepo.RetrieveChildren(context.Background(), tc.groupID, tc.depth, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		assert.Equal(t, tc.size, uint64(len(page.Groups)), fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, len(page.Groups)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.total, page.Total))
+	}
+}
+
+func TestMoveGroup(t *testing.T) {
+	t.Cleanup(func() { cleanUp(t) })
+	dbMiddleware := postgres.NewDatabase(db)
+	groupRepo := postgres.NewGroupRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	site, err := groupRepo.Save(context.Background(), things.Group{ID: generateGroupID(t), Name: "site", OwnerID: uid})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+	building, err := groupRepo.Save(context.Background(), things.Group{ID: generateGroupID(t), Name: "building", OwnerID: uid})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+	floor, err := groupRepo.Save(context.Background(), things.Group{ID: generateGroupID(t), Name: "floor", OwnerID: uid, ParentID: building.ID})
+	require.Nil(t, err, fmt.Sprintf("group save got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		groupID  string
+		parentID string
+		path     []string
+		err      error
+	}{
+		{
+			desc:     "move subtree under a new parent",
+			groupID:  building.ID,
+			parentID: site.ID,
+			path:     []string{site.ID, building.ID, floor.ID},
+			err:      nil,
+		},
+		{
+			desc:     "move group to the root",
+			groupID:  building.ID,
+			parentID: "",
+			path:     []string{building.ID, floor.ID},
+			err:      nil,
+		},
+		{
+			desc:     "move group under non-existing parent",
+			groupID:  building.ID,
+			parentID: generateGroupID(t),
+			path:     []string{building.ID, floor.ID},
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := groupRepo.Move(context.Background(), tc.groupID, tc.parentID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		gr, err := groupRepo.RetrieveByID(context.Background(), floor.ID)
+		require.Nil(t, err, fmt.Sprintf("%s: retrieve group got unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.path, gr.Path, fmt.Sprintf("%s: expected path %v got %v\n", tc.desc, tc.path, gr.Path))
+	}
+}
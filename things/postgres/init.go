@@ -158,6 +158,193 @@ func migrateDB(db *sqlx.DB) error {
 					`ALTER TABLE IF EXISTS connections ADD CONSTRAINT unique_thing_id_constraint UNIQUE (thing_id);`,
 				},
 			},*/
+			{
+				Id: "things_8",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS thing_keys (
+						id          UUID PRIMARY KEY,
+						thing_id    UUID NOT NULL,
+						name        VARCHAR(254),
+						key         VARCHAR(4096) UNIQUE NOT NULL,
+						enabled     BOOLEAN NOT NULL DEFAULT TRUE,
+						created_at  TIMESTAMPTZ,
+						expires_at  TIMESTAMPTZ,
+						FOREIGN KEY (thing_id) REFERENCES things (id) ON DELETE CASCADE ON UPDATE CASCADE
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE thing_keys",
+				},
+			},
+			{
+				Id: "things_9",
+				Up: []string{
+					`ALTER TABLE IF EXISTS connections ADD COLUMN IF NOT EXISTS conn_type VARCHAR(32) NOT NULL DEFAULT 'publish_subscribe'`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS connections DROP COLUMN IF EXISTS conn_type`,
+				},
+			},
+			{
+				Id: "things_10",
+				Up: []string{
+					`ALTER TABLE IF EXISTS groups ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES groups (id)`,
+					`CREATE TABLE IF NOT EXISTS group_hierarchy (
+						ancestor_id   UUID NOT NULL,
+						descendant_id UUID NOT NULL,
+						depth         INTEGER NOT NULL,
+						FOREIGN KEY (ancestor_id) REFERENCES groups (id) ON DELETE CASCADE ON UPDATE CASCADE,
+						FOREIGN KEY (descendant_id) REFERENCES groups (id) ON DELETE CASCADE ON UPDATE CASCADE,
+						PRIMARY KEY (ancestor_id, descendant_id)
+					)`,
+					`CREATE INDEX IF NOT EXISTS group_hierarchy_descendant_idx ON group_hierarchy (descendant_id)`,
+					`INSERT INTO group_hierarchy (ancestor_id, descendant_id, depth) SELECT id, id, 0 FROM groups ON CONFLICT DO NOTHING`,
+				},
+				Down: []string{
+					"DROP TABLE group_hierarchy",
+					`ALTER TABLE IF EXISTS groups DROP COLUMN IF EXISTS parent_id`,
+				},
+			},
+			{
+				Id: "things_11",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS profiles (
+						id          UUID PRIMARY KEY,
+						owner_id    UUID NOT NULL,
+						name        VARCHAR(254) NOT NULL,
+						description VARCHAR(1024),
+						schema      JSONB,
+						defaults    JSONB,
+						created_at  TIMESTAMPTZ,
+						updated_at  TIMESTAMPTZ,
+						UNIQUE (owner_id, name)
+					)`,
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS profile_id UUID REFERENCES profiles (id)`,
+					`ALTER TABLE IF EXISTS channels ADD COLUMN IF NOT EXISTS profile_id UUID REFERENCES profiles (id)`,
+					`CREATE INDEX IF NOT EXISTS things_profile_id_idx ON things (profile_id)`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS channels DROP COLUMN IF EXISTS profile_id`,
+					`ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS profile_id`,
+					"DROP TABLE profiles",
+				},
+			},
+			{
+				Id: "things_12",
+				Up: []string{
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()`,
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()`,
+					`ALTER TABLE IF EXISTS channels ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()`,
+					`ALTER TABLE IF EXISTS channels ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()`,
+					`CREATE INDEX IF NOT EXISTS things_metadata_idx ON things USING GIN (metadata jsonb_path_ops)`,
+					`CREATE INDEX IF NOT EXISTS channels_metadata_idx ON channels USING GIN (metadata jsonb_path_ops)`,
+					`CREATE INDEX IF NOT EXISTS things_created_at_idx ON things (created_at)`,
+					`CREATE INDEX IF NOT EXISTS channels_created_at_idx ON channels (created_at)`,
+					`CREATE INDEX IF NOT EXISTS connections_thing_id_idx ON connections (thing_id)`,
+				},
+				Down: []string{
+					"DROP INDEX IF EXISTS connections_thing_id_idx",
+					"DROP INDEX IF EXISTS channels_created_at_idx",
+					"DROP INDEX IF EXISTS things_created_at_idx",
+					"DROP INDEX IF EXISTS channels_metadata_idx",
+					"DROP INDEX IF EXISTS things_metadata_idx",
+					`ALTER TABLE IF EXISTS channels DROP COLUMN IF EXISTS updated_at`,
+					`ALTER TABLE IF EXISTS channels DROP COLUMN IF EXISTS created_at`,
+					`ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS updated_at`,
+					`ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS created_at`,
+				},
+			},
+			{
+				Id: "things_13",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS deleted_things (
+						id          UUID PRIMARY KEY,
+						owner       VARCHAR(254) NOT NULL,
+						name        VARCHAR(1024),
+						key         VARCHAR(4096) NOT NULL,
+						metadata    JSONB,
+						profile_id  UUID,
+						created_at  TIMESTAMPTZ,
+						updated_at  TIMESTAMPTZ,
+						deleted_at  TIMESTAMPTZ NOT NULL,
+						keys        JSONB NOT NULL DEFAULT '[]',
+						connections JSONB NOT NULL DEFAULT '[]',
+						group_id    UUID
+					)`,
+					`CREATE TABLE IF NOT EXISTS deleted_channels (
+						id          UUID PRIMARY KEY,
+						owner       VARCHAR(254) NOT NULL,
+						name        VARCHAR(1024),
+						metadata    JSONB,
+						profile_id  UUID,
+						created_at  TIMESTAMPTZ,
+						updated_at  TIMESTAMPTZ,
+						deleted_at  TIMESTAMPTZ NOT NULL,
+						connections JSONB NOT NULL DEFAULT '[]',
+						group_id    UUID
+					)`,
+					`CREATE TABLE IF NOT EXISTS deleted_groups (
+						id          UUID PRIMARY KEY,
+						owner_id    UUID NOT NULL,
+						parent_id   UUID,
+						name        VARCHAR(254) NOT NULL,
+						description VARCHAR(1024),
+						metadata    JSONB,
+						created_at  TIMESTAMPTZ,
+						updated_at  TIMESTAMPTZ,
+						deleted_at  TIMESTAMPTZ NOT NULL,
+						depth       INTEGER NOT NULL DEFAULT 0,
+						things      JSONB NOT NULL DEFAULT '[]',
+						channels    JSONB NOT NULL DEFAULT '[]'
+					)`,
+					`CREATE INDEX IF NOT EXISTS deleted_things_owner_idx ON deleted_things (owner, deleted_at)`,
+					`CREATE INDEX IF NOT EXISTS deleted_channels_owner_idx ON deleted_channels (owner, deleted_at)`,
+					`CREATE INDEX IF NOT EXISTS deleted_groups_owner_idx ON deleted_groups (owner_id, deleted_at)`,
+				},
+				Down: []string{
+					"DROP TABLE deleted_groups",
+					"DROP TABLE deleted_channels",
+					"DROP TABLE deleted_things",
+				},
+			},
+			{
+				Id: "things_14",
+				Up: []string{
+					`ALTER TABLE IF EXISTS channels ADD COLUMN IF NOT EXISTS payload_schema JSONB`,
+					`ALTER TABLE IF EXISTS deleted_channels ADD COLUMN IF NOT EXISTS payload_schema JSONB`,
+				},
+				Down: []string{
+					`ALTER TABLE IF EXISTS deleted_channels DROP COLUMN IF EXISTS payload_schema`,
+					`ALTER TABLE IF EXISTS channels DROP COLUMN IF EXISTS payload_schema`,
+				},
+			},
+			{
+				Id: "things_15",
+				Up: []string{
+					`ALTER TABLE IF EXISTS things ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'`,
+					`ALTER TABLE IF EXISTS channels ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'`,
+					`ALTER TABLE IF EXISTS groups ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'`,
+					`ALTER TABLE IF EXISTS deleted_things ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'`,
+					`ALTER TABLE IF EXISTS deleted_channels ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'`,
+					`ALTER TABLE IF EXISTS deleted_groups ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'`,
+					`UPDATE things SET tags = metadata->'tags', metadata = metadata - 'tags' WHERE jsonb_typeof(metadata->'tags') = 'array'`,
+					`UPDATE channels SET tags = metadata->'tags', metadata = metadata - 'tags' WHERE jsonb_typeof(metadata->'tags') = 'array'`,
+					`CREATE INDEX IF NOT EXISTS things_tags_idx ON things USING GIN (tags jsonb_path_ops)`,
+					`CREATE INDEX IF NOT EXISTS channels_tags_idx ON channels USING GIN (tags jsonb_path_ops)`,
+					`CREATE INDEX IF NOT EXISTS groups_tags_idx ON groups USING GIN (tags jsonb_path_ops)`,
+				},
+				Down: []string{
+					"DROP INDEX IF EXISTS groups_tags_idx",
+					"DROP INDEX IF EXISTS channels_tags_idx",
+					"DROP INDEX IF EXISTS things_tags_idx",
+					`ALTER TABLE IF EXISTS deleted_groups DROP COLUMN IF EXISTS tags`,
+					`ALTER TABLE IF EXISTS deleted_channels DROP COLUMN IF EXISTS tags`,
+					`ALTER TABLE IF EXISTS deleted_things DROP COLUMN IF EXISTS tags`,
+					`ALTER TABLE IF EXISTS groups DROP COLUMN IF EXISTS tags`,
+					`ALTER TABLE IF EXISTS channels DROP COLUMN IF EXISTS tags`,
+					`ALTER TABLE IF EXISTS things DROP COLUMN IF EXISTS tags`,
+				},
+			},
 		},
 	}
 
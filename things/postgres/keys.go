@@ -0,0 +1,259 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ things.ThingKeyRepository = (*thingKeyRepository)(nil)
+
+type thingKeyRepository struct {
+	db Database
+}
+
+// NewThingKeyRepository instantiates a PostgreSQL implementation of thing
+// key repository.
+func NewThingKeyRepository(db Database) things.ThingKeyRepository {
+	return &thingKeyRepository{
+		db: db,
+	}
+}
+
+func (kr thingKeyRepository) Save(ctx context.Context, keys ...things.ThingKey) ([]things.ThingKey, error) {
+	tx, err := kr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return []things.ThingKey{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	q := `INSERT INTO thing_keys (id, thing_id, name, key, enabled, created_at, expires_at)
+		  VALUES (:id, :thing_id, :name, :key, :enabled, :created_at, :expires_at);`
+
+	for _, key := range keys {
+		if _, err := tx.NamedExecContext(ctx, q, toDBThingKey(key)); err != nil {
+			tx.Rollback()
+			pgErr, ok := err.(*pgconn.PgError)
+			if ok {
+				switch pgErr.Code {
+				case pgerrcode.InvalidTextRepresentation:
+					return []things.ThingKey{}, errors.Wrap(errors.ErrMalformedEntity, err)
+				case pgerrcode.ForeignKeyViolation:
+					return []things.ThingKey{}, errors.Wrap(errors.ErrNotFound, err)
+				case pgerrcode.UniqueViolation:
+					return []things.ThingKey{}, errors.Wrap(errors.ErrConflict, err)
+				case pgerrcode.StringDataRightTruncationDataException:
+					return []things.ThingKey{}, errors.Wrap(errors.ErrMalformedEntity, err)
+				}
+			}
+
+			return []things.ThingKey{}, errors.Wrap(errors.ErrCreateEntity, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return []things.ThingKey{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return keys, nil
+}
+
+func (kr thingKeyRepository) Update(ctx context.Context, key things.ThingKey) error {
+	q := `UPDATE thing_keys SET name = :name, enabled = :enabled, expires_at = :expires_at
+		  WHERE id = :id AND thing_id = :thing_id;`
+
+	res, err := kr.db.NamedExecContext(ctx, q, toDBThingKey(key))
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.StringDataRightTruncationDataException:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+		}
+
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (kr thingKeyRepository) RetrieveByID(ctx context.Context, thingID, id string) (things.ThingKey, error) {
+	q := `SELECT id, thing_id, name, key, enabled, created_at, expires_at FROM thing_keys
+		  WHERE id = $1 AND thing_id = $2;`
+
+	return kr.retrieveOne(ctx, q, id, thingID)
+}
+
+func (kr thingKeyRepository) RetrieveByKey(ctx context.Context, key string) (things.ThingKey, error) {
+	q := `SELECT id, thing_id, name, key, enabled, created_at, expires_at FROM thing_keys WHERE key = $1;`
+
+	return kr.retrieveOne(ctx, q, key)
+}
+
+func (kr thingKeyRepository) RetrieveByThing(ctx context.Context, thingID string, pm things.PageMetadata) (things.ThingKeysPage, error) {
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := `SELECT id, thing_id, name, key, enabled, created_at, expires_at FROM thing_keys
+		  WHERE thing_id = :thing_id ORDER BY created_at ` + olq + `;`
+
+	params := map[string]interface{}{
+		"thing_id": thingID,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+
+	keys, err := kr.retrieve(ctx, q, params)
+	if err != nil {
+		return things.ThingKeysPage{}, err
+	}
+
+	cq := `SELECT COUNT(*) FROM thing_keys WHERE thing_id = :thing_id;`
+
+	total, err := total(ctx, kr.db, cq, params)
+	if err != nil {
+		return things.ThingKeysPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := things.ThingKeysPage{
+		Keys: keys,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (kr thingKeyRepository) RetrieveAll(ctx context.Context) ([]things.ThingKey, error) {
+	q := `SELECT id, thing_id, name, key, enabled, created_at, expires_at FROM thing_keys;`
+
+	return kr.retrieve(ctx, q, map[string]interface{}{})
+}
+
+func (kr thingKeyRepository) Remove(ctx context.Context, thingID, id string) error {
+	q := `DELETE FROM thing_keys WHERE id = :id AND thing_id = :thing_id;`
+
+	dbk := dbThingKey{
+		ID:      id,
+		ThingID: thingID,
+	}
+
+	res, err := kr.db.NamedExecContext(ctx, q, dbk)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return errors.Wrap(errors.ErrNotFound, err)
+		}
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (kr thingKeyRepository) retrieveOne(ctx context.Context, q string, args ...interface{}) (things.ThingKey, error) {
+	var dbk dbThingKey
+	if err := kr.db.QueryRowxContext(ctx, q, args...).StructScan(&dbk); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		//  If there is no result or ID is in an invalid format, return ErrNotFound.
+		if err == sql.ErrNoRows || ok && pgerrcode.InvalidTextRepresentation == pgErr.Code {
+			return things.ThingKey{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return things.ThingKey{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toThingKey(dbk), nil
+}
+
+func (kr thingKeyRepository) retrieve(ctx context.Context, q string, params map[string]interface{}) ([]things.ThingKey, error) {
+	rows, err := kr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return nil, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var keys []things.ThingKey
+	for rows.Next() {
+		var dbk dbThingKey
+		if err := rows.StructScan(&dbk); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		keys = append(keys, toThingKey(dbk))
+	}
+
+	return keys, nil
+}
+
+type dbThingKey struct {
+	ID        string       `db:"id"`
+	ThingID   string       `db:"thing_id"`
+	Name      string       `db:"name"`
+	Key       string       `db:"key"`
+	Enabled   bool         `db:"enabled"`
+	CreatedAt time.Time    `db:"created_at"`
+	ExpiresAt sql.NullTime `db:"expires_at"`
+}
+
+func toDBThingKey(k things.ThingKey) dbThingKey {
+	return dbThingKey{
+		ID:        k.ID,
+		ThingID:   k.ThingID,
+		Name:      k.Name,
+		Key:       k.Key,
+		Enabled:   k.Enabled,
+		CreatedAt: k.CreatedAt,
+		ExpiresAt: sql.NullTime{Time: k.ExpiresAt, Valid: !k.ExpiresAt.IsZero()},
+	}
+}
+
+func toThingKey(dbk dbThingKey) things.ThingKey {
+	k := things.ThingKey{
+		ID:        dbk.ID,
+		ThingID:   dbk.ThingID,
+		Name:      dbk.Name,
+		Key:       dbk.Key,
+		Enabled:   dbk.Enabled,
+		CreatedAt: dbk.CreatedAt,
+	}
+	if dbk.ExpiresAt.Valid {
+		k.ExpiresAt = dbk.ExpiresAt.Time
+	}
+
+	return k
+}
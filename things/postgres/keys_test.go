@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+	"github.com/MainfluxLabs/mainflux/things/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createThingKey(t *testing.T, thingID string) things.ThingKey {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	key, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return things.ThingKey{
+		ID:        id,
+		ThingID:   thingID,
+		Name:      "key",
+		Key:       key,
+		Enabled:   true,
+		CreatedAt: time.Now().UTC().Round(time.Millisecond),
+	}
+}
+
+func saveThing(t *testing.T, email string) things.Thing {
+	thingRepo := postgres.NewThingRepository(postgres.NewDatabase(db))
+
+	thID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thKey, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	ths, err := thingRepo.Save(context.Background(), things.Thing{ID: thID, Owner: email, Key: thKey})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return ths[0]
+}
+
+func TestThingKeysSave(t *testing.T) {
+	keyRepo := postgres.NewThingKeyRepository(postgres.NewDatabase(db))
+	th := saveThing(t, "thing-key-save@example.com")
+
+	key := createThingKey(t, th.ID)
+	nonexistent := createThingKey(t, th.ID)
+	nonexistent.ThingID = nonexistent.ID
+
+	cases := []struct {
+		desc string
+		key  things.ThingKey
+		err  error
+	}{
+		{
+			desc: "save thing key",
+			key:  key,
+			err:  nil,
+		},
+		{
+			desc: "save existing thing key",
+			key:  key,
+			err:  errors.ErrConflict,
+		},
+		{
+			desc: "save key of non-existing thing",
+			key:  nonexistent,
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := keyRepo.Save(context.Background(), tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestThingKeysUpdateAndRetrieve(t *testing.T) {
+	keyRepo := postgres.NewThingKeyRepository(postgres.NewDatabase(db))
+	th := saveThing(t, "thing-key-update@example.com")
+
+	key := createThingKey(t, th.ID)
+	_, err := keyRepo.Save(context.Background(), key)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	key.Enabled = false
+	key.ExpiresAt = key.CreatedAt.Add(time.Hour)
+	err = keyRepo.Update(context.Background(), key)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	saved, err := keyRepo.RetrieveByKey(context.Background(), key.Key)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.False(t, saved.Enabled, "expected disabled key")
+	assert.True(t, key.ExpiresAt.Equal(saved.ExpiresAt), fmt.Sprintf("expected %s got %s", key.ExpiresAt, saved.ExpiresAt))
+
+	page, err := keyRepo.RetrieveByThing(context.Background(), th.ID, things.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("expected total %d got %d", 1, page.Total))
+
+	err = keyRepo.Remove(context.Background(), th.ID, key.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	_, err = keyRepo.RetrieveByID(context.Background(), th.ID, key.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("expected %s got %s", errors.ErrNotFound, err))
+}
@@ -0,0 +1,262 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/internal/dbutil"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ things.ProfileRepository = (*profileRepository)(nil)
+
+type profileRepository struct {
+	db Database
+}
+
+// NewProfileRepository instantiates a PostgreSQL implementation of profile
+// repository.
+func NewProfileRepository(db Database) things.ProfileRepository {
+	return &profileRepository{
+		db: db,
+	}
+}
+
+func (pr profileRepository) Save(ctx context.Context, prs ...things.Profile) ([]things.Profile, error) {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return []things.Profile{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	q := `INSERT INTO profiles (id, owner_id, name, description, schema, defaults, created_at, updated_at)
+		  VALUES (:id, :owner_id, :name, :description, :schema, :defaults, :created_at, :updated_at);`
+
+	for _, p := range prs {
+		if _, err := tx.NamedExecContext(ctx, q, toDBProfile(p)); err != nil {
+			tx.Rollback()
+			pgErr, ok := err.(*pgconn.PgError)
+			if ok {
+				switch pgErr.Code {
+				case pgerrcode.InvalidTextRepresentation:
+					return []things.Profile{}, errors.Wrap(errors.ErrMalformedEntity, err)
+				case pgerrcode.UniqueViolation:
+					return []things.Profile{}, errors.Wrap(errors.ErrConflict, err)
+				case pgerrcode.StringDataRightTruncationDataException:
+					return []things.Profile{}, errors.Wrap(errors.ErrMalformedEntity, err)
+				}
+			}
+
+			return []things.Profile{}, errors.Wrap(errors.ErrCreateEntity, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return []things.Profile{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return prs, nil
+}
+
+func (pr profileRepository) Update(ctx context.Context, p things.Profile) error {
+	q := `UPDATE profiles SET name = :name, description = :description, schema = :schema,
+		  defaults = :defaults, updated_at = :updated_at WHERE id = :id;`
+
+	res, err := pr.db.NamedExecContext(ctx, q, toDBProfile(p))
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.UniqueViolation:
+				return errors.Wrap(errors.ErrConflict, err)
+			case pgerrcode.StringDataRightTruncationDataException:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+		}
+
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (pr profileRepository) RetrieveByID(ctx context.Context, id string) (things.Profile, error) {
+	q := `SELECT id, owner_id, name, description, schema, defaults, created_at, updated_at
+		  FROM profiles WHERE id = $1;`
+
+	var dbp dbProfile
+	if err := pr.db.QueryRowxContext(ctx, q, id).StructScan(&dbp); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		//  If there is no result or ID is in an invalid format, return ErrNotFound.
+		if err == sql.ErrNoRows || ok && pgerrcode.InvalidTextRepresentation == pgErr.Code {
+			return things.Profile{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return things.Profile{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toProfile(dbp), nil
+}
+
+func (pr profileRepository) RetrieveByOwner(ctx context.Context, ownerID string, pm things.PageMetadata) (things.ProfilesPage, error) {
+	if ownerID == "" {
+		return things.ProfilesPage{}, errors.ErrRetrieveEntity
+	}
+
+	nq, name := dbutil.GetNameQuery(pm.Name)
+	oq := getOrderQuery(pm.Order)
+	dq := getDirQuery(pm.Dir)
+
+	query := []string{"owner_id = :owner_id"}
+	if nq != "" {
+		query = append(query, nq)
+	}
+	whereClause := fmt.Sprintf(" WHERE %s", strings.Join(query, " AND "))
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner_id, name, description, schema, defaults, created_at, updated_at
+		  FROM profiles %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
+
+	params := map[string]interface{}{
+		"owner_id": ownerID,
+		"name":     name,
+		"limit":    pm.Limit,
+		"offset":   pm.Offset,
+	}
+
+	prs, err := pr.retrieve(ctx, q, params)
+	if err != nil {
+		return things.ProfilesPage{}, err
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM profiles %s;`, whereClause)
+
+	total, err := total(ctx, pr.db, cq, params)
+	if err != nil {
+		return things.ProfilesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := things.ProfilesPage{
+		Profiles: prs,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+			Order:  pm.Order,
+			Dir:    pm.Dir,
+		},
+	}
+
+	return page, nil
+}
+
+func (pr profileRepository) RetrieveAll(ctx context.Context) ([]things.Profile, error) {
+	q := `SELECT id, owner_id, name, description, schema, defaults, created_at, updated_at FROM profiles;`
+
+	return pr.retrieve(ctx, q, map[string]interface{}{})
+}
+
+func (pr profileRepository) Remove(ctx context.Context, ownerID string, ids ...string) error {
+	q := `DELETE FROM profiles WHERE id = :id AND owner_id = :owner_id;`
+
+	for _, id := range ids {
+		dbp := dbProfile{
+			ID:      id,
+			OwnerID: ownerID,
+		}
+
+		if _, err := pr.db.NamedExecContext(ctx, q, dbp); err != nil {
+			pgErr, ok := err.(*pgconn.PgError)
+			if ok {
+				switch pgErr.Code {
+				case pgerrcode.InvalidTextRepresentation:
+					return errors.Wrap(errors.ErrMalformedEntity, err)
+				case pgerrcode.ForeignKeyViolation:
+					return errors.Wrap(errors.ErrConflict, err)
+				}
+			}
+
+			return errors.Wrap(errors.ErrRemoveEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (pr profileRepository) retrieve(ctx context.Context, q string, params map[string]interface{}) ([]things.Profile, error) {
+	rows, err := pr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var prs []things.Profile
+	for rows.Next() {
+		var dbp dbProfile
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		prs = append(prs, toProfile(dbp))
+	}
+
+	return prs, nil
+}
+
+type dbProfile struct {
+	ID          string         `db:"id"`
+	OwnerID     string         `db:"owner_id"`
+	Name        string         `db:"name"`
+	Description sql.NullString `db:"description"`
+	Schema      dbMetadata     `db:"schema"`
+	Defaults    dbMetadata     `db:"defaults"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+}
+
+func toDBProfile(p things.Profile) dbProfile {
+	return dbProfile{
+		ID:          p.ID,
+		OwnerID:     p.OwnerID,
+		Name:        p.Name,
+		Description: toNullString(p.Description),
+		Schema:      p.Schema,
+		Defaults:    p.Defaults,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+func toProfile(dbp dbProfile) things.Profile {
+	return things.Profile{
+		ID:          dbp.ID,
+		OwnerID:     dbp.OwnerID,
+		Name:        dbp.Name,
+		Description: dbp.Description.String,
+		Schema:      dbp.Schema,
+		Defaults:    dbp.Defaults,
+		CreatedAt:   dbp.CreatedAt,
+		UpdatedAt:   dbp.UpdatedAt,
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+	"github.com/MainfluxLabs/mainflux/things/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createProfile(t *testing.T, ownerID, name string) things.Profile {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	timestamp := time.Now().UTC().Round(time.Millisecond)
+
+	return things.Profile{
+		ID:        id,
+		OwnerID:   ownerID,
+		Name:      name,
+		Schema:    map[string]interface{}{"type": "object"},
+		Defaults:  map[string]interface{}{"interval": float64(60)},
+		CreatedAt: timestamp,
+		UpdatedAt: timestamp,
+	}
+}
+
+func TestProfilesSave(t *testing.T) {
+	profileRepo := postgres.NewProfileRepository(postgres.NewDatabase(db))
+
+	ownerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	pr := createProfile(t, ownerID, "profile-save")
+	sameName := createProfile(t, ownerID, "profile-save")
+
+	cases := []struct {
+		desc    string
+		profile things.Profile
+		err     error
+	}{
+		{
+			desc:    "save profile",
+			profile: pr,
+			err:     nil,
+		},
+		{
+			desc:    "save existing profile",
+			profile: pr,
+			err:     errors.ErrConflict,
+		},
+		{
+			desc:    "save profile with existing name",
+			profile: sameName,
+			err:     errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := profileRepo.Save(context.Background(), tc.profile)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestProfilesUpdateAndRetrieve(t *testing.T) {
+	profileRepo := postgres.NewProfileRepository(postgres.NewDatabase(db))
+
+	ownerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	pr := createProfile(t, ownerID, "profile-update")
+	_, err = profileRepo.Save(context.Background(), pr)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	pr.Description = "updated"
+	pr.Defaults = map[string]interface{}{"interval": float64(10)}
+	err = profileRepo.Update(context.Background(), pr)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	saved, err := profileRepo.RetrieveByID(context.Background(), pr.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, pr.Description, saved.Description, fmt.Sprintf("expected %s got %s", pr.Description, saved.Description))
+	assert.Equal(t, pr.Defaults, saved.Defaults, fmt.Sprintf("expected %v got %v", pr.Defaults, saved.Defaults))
+
+	page, err := profileRepo.RetrieveByOwner(context.Background(), ownerID, things.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("expected total %d got %d", 1, page.Total))
+
+	_, err = profileRepo.RetrieveByID(context.Background(), ownerID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("expected %s got %s", errors.ErrNotFound, err))
+}
+
+func TestProfilesRemove(t *testing.T) {
+	profileRepo := postgres.NewProfileRepository(postgres.NewDatabase(db))
+	thingRepo := postgres.NewThingRepository(postgres.NewDatabase(db))
+
+	ownerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	used := createProfile(t, ownerID, "profile-used")
+	unused := createProfile(t, ownerID, "profile-unused")
+	_, err = profileRepo.Save(context.Background(), used, unused)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	thID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thKey, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = thingRepo.Save(context.Background(), things.Thing{ID: thID, Owner: ownerID, Key: thKey, ProfileID: used.ID})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	page, err := thingRepo.RetrieveByProfile(context.Background(), ownerID, used.ID, things.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("expected total %d got %d", 1, page.Total))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove profile referenced by thing",
+			id:   used.ID,
+			err:  errors.ErrConflict,
+		},
+		{
+			desc: "remove unused profile",
+			id:   unused.ID,
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := profileRepo.Remove(context.Background(), ownerID, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/things"
+)
+
+// dbTags type for handling tags in database/sql.
+type dbTags []string
+
+// Scan implements the database/sql scanner interface.
+func (t *dbTags) Scan(value interface{}) error {
+	if value == nil {
+		*t = dbTags{}
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.ErrScanMetadata
+	}
+
+	return json.Unmarshal(b, t)
+}
+
+// Value implements database/sql valuer interface.
+func (t dbTags) Value() (driver.Value, error) {
+	if t == nil {
+		t = dbTags{}
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// getTagsQuery returns the conditions and their named parameters that
+// match the tag filter terms. Every alternative is compiled to a
+// containment check, so it is served by the GIN index on the tags column.
+func getTagsQuery(column string, terms []string) ([]string, map[string]interface{}, error) {
+	var query []string
+	params := map[string]interface{}{}
+
+	for i, term := range terms {
+		tt, err := things.ParseTagTerm(term)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var conds []string
+		for j, t := range tt.Tags {
+			b, err := json.Marshal([]string{t})
+			if err != nil {
+				return nil, nil, err
+			}
+
+			key := fmt.Sprintf("tag_%d_%d", i, j)
+			conds = append(conds, fmt.Sprintf("%s @> :%s", column, key))
+			params[key] = string(b)
+		}
+
+		cond := fmt.Sprintf("(%s)", strings.Join(conds, " OR "))
+		if tt.Negated {
+			cond = "NOT " + cond
+		}
+		query = append(query, cond)
+	}
+
+	return query, params, nil
+}
+
+// updateTags adds the tags to, or removes them from, the entities of the
+// table having the provided identifiers and owner. Tags are kept sorted
+// and without duplicates.
+func updateTags(ctx context.Context, db Database, table, ownerColumn, owner string, tags []string, add bool, ids ...string) error {
+	b, err := json.Marshal(tags)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	src := fmt.Sprintf(`jsonb_array_elements_text(%s.tags || CAST(:tags AS jsonb)) t`, table)
+	if !add {
+		src = fmt.Sprintf(`jsonb_array_elements_text(%s.tags) t WHERE NOT CAST(:tags AS jsonb) @> jsonb_build_array(t)`, table)
+	}
+
+	q := fmt.Sprintf(`UPDATE %s SET tags = COALESCE((SELECT jsonb_agg(DISTINCT t ORDER BY t) FROM %s), '[]'), updated_at = NOW()
+		WHERE id = :id AND %s = :owner;`, table, src, ownerColumn)
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	for _, id := range ids {
+		params := map[string]interface{}{
+			"id":    id,
+			"owner": owner,
+			"tags":  string(b),
+		}
+
+		res, err := tx.NamedExecContext(ctx, q, params)
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+
+		if cnt == 0 {
+			tx.Rollback()
+			return errors.ErrNotFound
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
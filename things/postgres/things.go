@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gofrs/uuid"
 
@@ -39,8 +40,8 @@ func (tr thingRepository) Save(ctx context.Context, ths ...things.Thing) ([]thin
 		return []things.Thing{}, errors.Wrap(errors.ErrCreateEntity, err)
 	}
 
-	q := `INSERT INTO things (id, owner, name, key, metadata)
-		  VALUES (:id, :owner, :name, :key, :metadata);`
+	q := `INSERT INTO things (id, owner, name, key, metadata, profile_id, tags)
+		  VALUES (:id, :owner, :name, :key, :metadata, :profile_id, :tags);`
 
 	for _, thing := range ths {
 		dbth, err := toDBThing(thing)
@@ -57,6 +58,8 @@ func (tr thingRepository) Save(ctx context.Context, ths ...things.Thing) ([]thin
 					return []things.Thing{}, errors.Wrap(errors.ErrMalformedEntity, err)
 				case pgerrcode.UniqueViolation:
 					return []things.Thing{}, errors.Wrap(errors.ErrConflict, err)
+				case pgerrcode.ForeignKeyViolation:
+					return []things.Thing{}, errors.Wrap(errors.ErrNotFound, err)
 				case pgerrcode.StringDataRightTruncationDataException:
 					return []things.Thing{}, errors.Wrap(errors.ErrMalformedEntity, err)
 				}
@@ -74,7 +77,7 @@ func (tr thingRepository) Save(ctx context.Context, ths ...things.Thing) ([]thin
 }
 
 func (tr thingRepository) Update(ctx context.Context, t things.Thing) error {
-	q := `UPDATE things SET name = :name, metadata = :metadata WHERE id = :id;`
+	q := `UPDATE things SET name = :name, metadata = :metadata, tags = :tags, updated_at = NOW() WHERE id = :id;`
 
 	dbth, err := toDBThing(t)
 	if err != nil {
@@ -109,7 +112,7 @@ func (tr thingRepository) Update(ctx context.Context, t things.Thing) error {
 }
 
 func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string) error {
-	q := `UPDATE things SET key = :key WHERE owner = :owner AND id = :id;`
+	q := `UPDATE things SET key = :key, updated_at = NOW() WHERE owner = :owner AND id = :id;`
 
 	dbth := dbThing{
 		ID:    id,
@@ -147,7 +150,7 @@ func (tr thingRepository) UpdateKey(ctx context.Context, owner, id, key string)
 }
 
 func (tr thingRepository) RetrieveByID(ctx context.Context, id string) (things.Thing, error) {
-	q := `SELECT name, owner, key, metadata FROM things WHERE id = $1;`
+	q := `SELECT name, owner, key, metadata, profile_id, tags FROM things WHERE id = $1;`
 
 	dbth := dbThing{ID: id}
 
@@ -192,7 +195,7 @@ func (tr thingRepository) RetrieveByIDs(ctx context.Context, thingIDs []string,
 		return things.Page{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
 
-	q := fmt.Sprintf(`SELECT id, owner, name, key, metadata FROM things
+	q := fmt.Sprintf(`SELECT id, owner, name, key, metadata, profile_id, tags FROM things
 					   %s%s%s ORDER BY %s %s LIMIT :limit OFFSET :offset;`, idq, mq, nq, oq, dq)
 
 	params := map[string]interface{}{
@@ -249,11 +252,19 @@ func (tr thingRepository) RetrieveByOwner(ctx context.Context, owner string, pm
 		return things.Page{}, errors.ErrRetrieveEntity
 	}
 
-	return tr.retrieve(ctx, owner, false, pm)
+	return tr.retrieve(ctx, owner, "", false, pm)
+}
+
+func (tr thingRepository) AddTags(ctx context.Context, owner string, tags []string, ids ...string) error {
+	return updateTags(ctx, tr.db, "things", "owner", owner, tags, true, ids...)
+}
+
+func (tr thingRepository) RemoveTags(ctx context.Context, owner string, tags []string, ids ...string) error {
+	return updateTags(ctx, tr.db, "things", "owner", owner, tags, false, ids...)
 }
 
 func (tr thingRepository) RetrieveAll(ctx context.Context) ([]things.Thing, error) {
-	thPage, err := tr.retrieve(ctx, "", true, things.PageMetadata{})
+	thPage, err := tr.retrieve(ctx, "", "", true, things.PageMetadata{})
 	if err != nil {
 		return []things.Thing{}, err
 	}
@@ -261,8 +272,44 @@ func (tr thingRepository) RetrieveAll(ctx context.Context) ([]things.Thing, erro
 	return thPage.Things, nil
 }
 
+func (tr thingRepository) RetrieveUpdated(ctx context.Context, since time.Time) ([]things.Thing, error) {
+	q := `SELECT id, owner, name, key, metadata, profile_id, tags FROM things WHERE updated_at >= :since;`
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, map[string]interface{}{"since": since})
+	if err != nil {
+		return []things.Thing{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []things.Thing
+	for rows.Next() {
+		var dbth dbThing
+		if err := rows.StructScan(&dbth); err != nil {
+			return []things.Thing{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		th, err := toThing(dbth)
+		if err != nil {
+			return []things.Thing{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		items = append(items, th)
+	}
+
+	return items, nil
+}
+
 func (tr thingRepository) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.Page, error) {
-	return tr.retrieve(ctx, "", false, pm)
+	return tr.retrieve(ctx, "", "", false, pm)
+}
+
+func (tr thingRepository) RetrieveByProfile(ctx context.Context, owner, profileID string, pm things.PageMetadata) (things.Page, error) {
+	// Verify if UUID format is valid to avoid internal Postgres error
+	if _, err := uuid.FromString(profileID); err != nil {
+		return things.Page{}, errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	return tr.retrieve(ctx, owner, profileID, false, pm)
 }
 
 func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, chID string, pm things.PageMetadata) (things.Page, error) {
@@ -279,45 +326,55 @@ func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, chID str
 		olq = ""
 	}
 
+	params := map[string]interface{}{
+		"owner":   owner,
+		"channel": chID,
+		"limit":   pm.Limit,
+		"offset":  pm.Offset,
+	}
+
+	var iq string
+	if pm.IDs != nil {
+		var ip map[string]interface{}
+		iq, ip = getIDsQuery("th.id", pm.IDs)
+		iq = " AND " + iq
+		for k, v := range ip {
+			params[k] = v
+		}
+	}
+
 	var q, qc string
 	switch pm.Disconnected {
 	case true:
-		q = fmt.Sprintf(`SELECT id, name, key, metadata
+		q = fmt.Sprintf(`SELECT id, name, key, metadata, profile_id, tags
 		        FROM things th
-		        WHERE th.owner = :owner AND th.id NOT IN
+		        WHERE th.owner = :owner%s AND th.id NOT IN
 		        (SELECT id FROM things th
 		          INNER JOIN connections conn
 		          ON th.id = conn.thing_id
 		          WHERE th.owner = :owner AND conn.channel_id = :channel)
-		        ORDER BY %s %s %s;`, oq, dq, olq)
+		        ORDER BY %s %s %s;`, iq, oq, dq, olq)
 
-		qc = `SELECT COUNT(*)
+		qc = fmt.Sprintf(`SELECT COUNT(*)
 		        FROM things th
-		        WHERE th.owner = $1 AND th.id NOT IN
+		        WHERE th.owner = :owner%s AND th.id NOT IN
 		        (SELECT id FROM things th
 		          INNER JOIN connections conn
 		          ON th.id = conn.thing_id
-		          WHERE th.owner = $1 AND conn.channel_id = $2);`
+		          WHERE th.owner = :owner AND conn.channel_id = :channel);`, iq)
 	default:
-		q = fmt.Sprintf(`SELECT id, name, key, metadata
+		q = fmt.Sprintf(`SELECT id, name, key, metadata, profile_id, tags
 		        FROM things th
 		        INNER JOIN connections conn
 		        ON th.id = conn.thing_id
-		        WHERE th.owner = :owner AND conn.channel_id = :channel
-		        ORDER BY %s %s %s;`, oq, dq, olq)
+		        WHERE th.owner = :owner AND conn.channel_id = :channel%s
+		        ORDER BY %s %s %s;`, iq, oq, dq, olq)
 
-		qc = `SELECT COUNT(*)
+		qc = fmt.Sprintf(`SELECT COUNT(*)
 		        FROM things th
 		        INNER JOIN connections conn
 		        ON th.id = conn.thing_id
-		        WHERE th.owner = $1 AND conn.channel_id = $2;`
-	}
-
-	params := map[string]interface{}{
-		"owner":   owner,
-		"channel": chID,
-		"limit":   pm.Limit,
-		"offset":  pm.Offset,
+		        WHERE th.owner = :owner AND conn.channel_id = :channel%s;`, iq)
 	}
 
 	rows, err := tr.db.NamedQueryContext(ctx, q, params)
@@ -341,8 +398,8 @@ func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, chID str
 		items = append(items, th)
 	}
 
-	var total uint64
-	if err := tr.db.GetContext(ctx, &total, qc, owner, chID); err != nil {
+	total, err := total(ctx, tr.db, qc, params)
+	if err != nil {
 		return things.Page{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
 
@@ -357,23 +414,199 @@ func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, chID str
 }
 
 func (tr thingRepository) Remove(ctx context.Context, owner string, ids ...string) error {
+	// A thing is moved to the trash along with its keys, connections and
+	// group membership, replacing the previously removed thing with the same ID.
+	qs := []string{
+		`DELETE FROM deleted_things WHERE id = :id AND EXISTS (SELECT 1 FROM things WHERE id = :id AND owner = :owner);`,
+		`INSERT INTO deleted_things (id, owner, name, key, metadata, profile_id, tags, created_at, updated_at, deleted_at, keys, connections, group_id)
+		 SELECT th.id, th.owner, th.name, th.key, th.metadata, th.profile_id, th.tags, th.created_at, th.updated_at, :deleted_at,
+		   COALESCE((SELECT jsonb_agg(jsonb_build_object('id', k.id, 'name', k.name, 'key', k.key, 'enabled', k.enabled,
+		     'created_at', k.created_at, 'expires_at', k.expires_at)) FROM thing_keys k WHERE k.thing_id = th.id), '[]'),
+		   COALESCE((SELECT jsonb_agg(jsonb_build_object('channel_id', conn.channel_id, 'channel_owner', conn.channel_owner,
+		     'conn_type', conn.conn_type)) FROM connections conn WHERE conn.thing_id = th.id), '[]'),
+		   (SELECT gt.group_id FROM group_things gt WHERE gt.thing_id = th.id)
+		 FROM things th WHERE th.id = :id AND th.owner = :owner;`,
+		`DELETE FROM group_things WHERE thing_id = :id AND EXISTS (SELECT 1 FROM things WHERE id = :id AND owner = :owner);`,
+		`DELETE FROM things WHERE id = :id AND owner = :owner;`,
+	}
+
+	tx, err := tr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	deletedAt := time.Now().UTC()
+	for _, id := range ids {
+		params := map[string]interface{}{
+			"id":         id,
+			"owner":      owner,
+			"deleted_at": deletedAt,
+		}
+
+		for _, q := range qs {
+			if _, err := tx.NamedExecContext(ctx, q, params); err != nil {
+				tx.Rollback()
+				return errors.Wrap(errors.ErrRemoveEntity, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (tr thingRepository) RetrieveDeleted(ctx context.Context, owner string, pm things.PageMetadata) (things.DeletedThingsPage, error) {
+	nq, name := dbutil.GetNameQuery(pm.Name)
+
+	query := []string{"owner = :owner"}
+	if nq != "" {
+		query = append(query, nq)
+	}
+	whereClause := fmt.Sprintf(" WHERE %s", strings.Join(query, " AND "))
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT id, name, metadata, profile_id, tags, deleted_at, connections, group_id
+		  FROM deleted_things %s ORDER BY deleted_at DESC %s;`, whereClause, olq)
+
+	params := map[string]interface{}{
+		"owner":  owner,
+		"name":   name,
+		"limit":  pm.Limit,
+		"offset": pm.Offset,
+	}
+
+	rows, err := tr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return things.DeletedThingsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []things.DeletedThing
+	for rows.Next() {
+		dbth := dbDeletedThing{Owner: owner}
+		if err := rows.StructScan(&dbth); err != nil {
+			return things.DeletedThingsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		th, err := toDeletedThing(dbth)
+		if err != nil {
+			return things.DeletedThingsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		items = append(items, th)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM deleted_things %s;`, whereClause)
+
+	total, err := total(ctx, tr.db, cq, params)
+	if err != nil {
+		return things.DeletedThingsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	page := things.DeletedThingsPage{
+		Things: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
+func (tr thingRepository) Restore(ctx context.Context, owner string, ids ...string) error {
+	// The profile is dropped if it was removed in the meantime, while
+	// connections and group membership are restored only if the channels
+	// and the group still exist.
+	qt := `INSERT INTO things (id, owner, name, key, metadata, profile_id, tags, created_at, updated_at)
+		   SELECT dt.id, dt.owner, dt.name, dt.key, dt.metadata, (SELECT p.id FROM profiles p WHERE p.id = dt.profile_id),
+		     dt.tags, dt.created_at, :restored_at
+		   FROM deleted_things dt WHERE dt.id = :id AND dt.owner = :owner;`
+
+	qs := []string{
+		`INSERT INTO thing_keys (id, thing_id, name, key, enabled, created_at, expires_at)
+		 SELECT k.id, dt.id, k.name, k.key, k.enabled, k.created_at, k.expires_at
+		 FROM deleted_things dt, jsonb_to_recordset(dt.keys)
+		   AS k(id UUID, name VARCHAR(254), key VARCHAR(4096), enabled BOOLEAN, created_at TIMESTAMPTZ, expires_at TIMESTAMPTZ)
+		 WHERE dt.id = :id;`,
+		`INSERT INTO connections (channel_id, channel_owner, thing_id, thing_owner, conn_type)
+		 SELECT c.channel_id, c.channel_owner, dt.id, dt.owner, c.conn_type
+		 FROM deleted_things dt, jsonb_to_recordset(dt.connections) AS c(channel_id UUID, channel_owner VARCHAR(254), conn_type VARCHAR(32))
+		 WHERE dt.id = :id AND EXISTS (SELECT 1 FROM channels ch WHERE ch.id = c.channel_id AND ch.owner = c.channel_owner)
+		 ON CONFLICT DO NOTHING;`,
+		`INSERT INTO group_things (thing_id, group_id, created_at, updated_at)
+		 SELECT dt.id, dt.group_id, :restored_at, :restored_at FROM deleted_things dt
+		 WHERE dt.id = :id AND EXISTS (SELECT 1 FROM groups g WHERE g.id = dt.group_id)
+		 ON CONFLICT DO NOTHING;`,
+		`DELETE FROM deleted_things WHERE id = :id;`,
+	}
+
+	tx, err := tr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	restoredAt := time.Now().UTC()
 	for _, id := range ids {
-		dbth := dbThing{
-			ID:    id,
-			Owner: owner,
+		params := map[string]interface{}{
+			"id":          id,
+			"owner":       owner,
+			"restored_at": restoredAt,
 		}
-		q := `DELETE FROM things WHERE id = :id AND owner = :owner;`
-		_, err := tr.db.NamedExecContext(ctx, q, dbth)
+
+		res, err := tx.NamedExecContext(ctx, qt, params)
 		if err != nil {
-			return errors.Wrap(errors.ErrRemoveEntity, err)
+			tx.Rollback()
+			return restoreError(err)
+		}
+
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return errors.Wrap(errors.ErrCreateEntity, err)
+		}
+
+		if cnt == 0 {
+			tx.Rollback()
+			return errors.ErrNotFound
+		}
+
+		for _, q := range qs {
+			if _, err := tx.NamedExecContext(ctx, q, params); err != nil {
+				tx.Rollback()
+				return restoreError(err)
+			}
 		}
 	}
 
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (tr thingRepository) Purge(ctx context.Context, before time.Time) error {
+	q := `DELETE FROM deleted_things WHERE deleted_at < :before;`
+
+	if _, err := tr.db.NamedExecContext(ctx, q, map[string]interface{}{"before": before}); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
 	return nil
 }
 
-func (tr thingRepository) retrieve(ctx context.Context, owner string, includeOwner bool, pm things.PageMetadata) (things.Page, error) {
+func (tr thingRepository) retrieve(ctx context.Context, owner, profileID string, includeOwner bool, pm things.PageMetadata) (things.Page, error) {
 	ownq := dbutil.GetOwnerQuery(owner, ownerDbId)
+	pq := getProfileQuery(profileID)
 	nq, name := dbutil.GetNameQuery(pm.Name)
 	oq := getOrderQuery(pm.Order)
 	dq := getDirQuery(pm.Dir)
@@ -381,17 +614,25 @@ func (tr thingRepository) retrieve(ctx context.Context, owner string, includeOwn
 	if err != nil {
 		return things.Page{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
+	fq, fp, err := getFiltersQuery(thingEntity, pm)
+	if err != nil {
+		return things.Page{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
 
 	var query []string
 	if ownq != "" {
 		query = append(query, ownq)
 	}
+	if pq != "" {
+		query = append(query, pq)
+	}
 	if mq != "" {
 		query = append(query, mq)
 	}
 	if nq != "" {
 		query = append(query, nq)
 	}
+	query = append(query, fq...)
 
 	var whereClause string
 	if len(query) > 0 {
@@ -403,18 +644,22 @@ func (tr thingRepository) retrieve(ctx context.Context, owner string, includeOwn
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, key, metadata FROM things %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
+	q := fmt.Sprintf(`SELECT id, name, key, metadata, profile_id, tags FROM things %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
 
 	if includeOwner {
-		q = "SELECT id, owner, name, key, metadata FROM things;"
+		q = "SELECT id, owner, name, key, metadata, profile_id, tags FROM things;"
 	}
 
 	params := map[string]interface{}{
-		"owner":    owner,
-		"limit":    pm.Limit,
-		"offset":   pm.Offset,
-		"name":     name,
-		"metadata": m,
+		"owner":      owner,
+		"profile_id": profileID,
+		"limit":      pm.Limit,
+		"offset":     pm.Offset,
+		"name":       name,
+		"metadata":   m,
+	}
+	for k, v := range fp {
+		params[k] = v
 	}
 
 	rows, err := tr.db.NamedQueryContext(ctx, q, params)
@@ -460,11 +705,58 @@ func (tr thingRepository) retrieve(ctx context.Context, owner string, includeOwn
 }
 
 type dbThing struct {
-	ID       string `db:"id"`
-	Owner    string `db:"owner"`
-	Name     string `db:"name"`
-	Key      string `db:"key"`
-	Metadata []byte `db:"metadata"`
+	ID        string         `db:"id"`
+	Owner     string         `db:"owner"`
+	Name      string         `db:"name"`
+	Key       string         `db:"key"`
+	Metadata  []byte         `db:"metadata"`
+	ProfileID sql.NullString `db:"profile_id"`
+	Tags      dbTags         `db:"tags"`
+}
+
+type dbDeletedThing struct {
+	ID          string         `db:"id"`
+	Owner       string         `db:"owner"`
+	Name        string         `db:"name"`
+	Metadata    []byte         `db:"metadata"`
+	ProfileID   sql.NullString `db:"profile_id"`
+	Tags        dbTags         `db:"tags"`
+	DeletedAt   time.Time      `db:"deleted_at"`
+	Connections []byte         `db:"connections"`
+	GroupID     sql.NullString `db:"group_id"`
+}
+
+func toDeletedThing(dbth dbDeletedThing) (things.DeletedThing, error) {
+	th, err := toThing(dbThing{
+		ID:        dbth.ID,
+		Owner:     dbth.Owner,
+		Name:      dbth.Name,
+		Metadata:  dbth.Metadata,
+		ProfileID: dbth.ProfileID,
+		Tags:      dbth.Tags,
+	})
+	if err != nil {
+		return things.DeletedThing{}, err
+	}
+
+	var conns []struct {
+		ChannelID string `json:"channel_id"`
+	}
+	if err := json.Unmarshal(dbth.Connections, &conns); err != nil {
+		return things.DeletedThing{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	var chIDs []string
+	for _, c := range conns {
+		chIDs = append(chIDs, c.ChannelID)
+	}
+
+	return things.DeletedThing{
+		Thing:      th,
+		ChannelIDs: chIDs,
+		GroupID:    dbth.GroupID.String,
+		DeletedAt:  dbth.DeletedAt,
+	}, nil
 }
 
 func toDBThing(th things.Thing) (dbThing, error) {
@@ -478,11 +770,13 @@ func toDBThing(th things.Thing) (dbThing, error) {
 	}
 
 	return dbThing{
-		ID:       th.ID,
-		Owner:    th.Owner,
-		Name:     th.Name,
-		Key:      th.Key,
-		Metadata: data,
+		ID:        th.ID,
+		Owner:     th.Owner,
+		Name:      th.Name,
+		Key:       th.Key,
+		Metadata:  data,
+		ProfileID: toNullString(th.ProfileID),
+		Tags:      th.Tags,
 	}, nil
 }
 
@@ -493,10 +787,12 @@ func toThing(dbth dbThing) (things.Thing, error) {
 	}
 
 	return things.Thing{
-		ID:       dbth.ID,
-		Owner:    dbth.Owner,
-		Name:     dbth.Name,
-		Key:      dbth.Key,
-		Metadata: metadata,
+		ID:        dbth.ID,
+		Owner:     dbth.Owner,
+		Name:      dbth.Name,
+		Key:       dbth.Key,
+		Metadata:  metadata,
+		ProfileID: dbth.ProfileID.String,
+		Tags:      dbth.Tags,
 	}, nil
 }
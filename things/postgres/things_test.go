@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
@@ -547,6 +548,145 @@ func TestMultiThingRetrieval(t *testing.T) {
 	}
 }
 
+func TestThingRetrievalWithFilters(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+	channelRepo := postgres.NewChannelRepository(dbMiddleware)
+
+	email := "thing-retrieval-with-filters@example.com"
+
+	chID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = channelRepo.Save(context.Background(), things.Channel{ID: chID, Owner: email})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	n := 10
+	var ids []string
+	for i := 0; i < n; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		key, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		th := things.Thing{
+			ID:    id,
+			Owner: email,
+			Key:   key,
+			Metadata: things.Metadata{
+				"serial":   fmt.Sprintf("SN-%03d", i),
+				"interval": i * 10,
+				"location": map[string]interface{}{"room": fmt.Sprintf("A%d", i%3)},
+				"tags":     []string{fmt.Sprintf("floor-%d", i%2)},
+			},
+		}
+		_, err = thingRepo.Save(context.Background(), th)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		ids = append(ids, id)
+	}
+
+	connNum := 4
+	err = channelRepo.Connect(context.Background(), email, chID, ids[:connNum], things.ConnTypePubSub)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	connected, disconnected := true, false
+
+	cases := map[string]struct {
+		pageMetadata things.PageMetadata
+		size         uint64
+		err          error
+	}{
+		"retrieve things with equal metadata filter": {
+			pageMetadata: things.PageMetadata{
+				Filters: []things.MetadataFilter{{Path: "serial", Op: things.OpEq, Value: "SN-001"}},
+			},
+			size: 1,
+		},
+		"retrieve things with not equal metadata filter": {
+			pageMetadata: things.PageMetadata{
+				Filters: []things.MetadataFilter{{Path: "serial", Op: things.OpNe, Value: "SN-001"}},
+			},
+			size: uint64(n - 1),
+		},
+		"retrieve things with comparison metadata filter": {
+			pageMetadata: things.PageMetadata{
+				Filters: []things.MetadataFilter{{Path: "interval", Op: things.OpGte, Value: 50}},
+			},
+			size: 5,
+		},
+		"retrieve things with nested in metadata filter": {
+			pageMetadata: things.PageMetadata{
+				Filters: []things.MetadataFilter{{Path: "location.room", Op: things.OpIn, Value: []interface{}{"A0", "A1"}}},
+			},
+			size: 7,
+		},
+		"retrieve things with prefix metadata filter": {
+			pageMetadata: things.PageMetadata{
+				Filters: []things.MetadataFilter{{Path: "serial", Op: things.OpPrefix, Value: "SN-00"}},
+			},
+			size: uint64(n),
+		},
+		"retrieve things with contains metadata filter": {
+			pageMetadata: things.PageMetadata{
+				Filters: []things.MetadataFilter{{Path: "serial", Op: things.OpContains, Value: "n-00"}},
+			},
+			size: uint64(n),
+		},
+		"retrieve things with combined metadata filters": {
+			pageMetadata: things.PageMetadata{
+				Filters: []things.MetadataFilter{
+					{Path: "interval", Op: things.OpLt, Value: 50},
+					{Path: "location.room", Op: things.OpEq, Value: "A0"},
+				},
+			},
+			size: 2,
+		},
+		"retrieve things created in time range": {
+			pageMetadata: things.PageMetadata{
+				Created: things.TimeRange{From: time.Now().Add(-time.Hour), To: time.Now().Add(time.Hour)},
+			},
+			size: uint64(n),
+		},
+		"retrieve things created after time range": {
+			pageMetadata: things.PageMetadata{
+				Created: things.TimeRange{From: time.Now().Add(time.Hour)},
+			},
+			size: 0,
+		},
+		"retrieve connected things": {
+			pageMetadata: things.PageMetadata{
+				Connected: &connected,
+			},
+			size: uint64(connNum),
+		},
+		"retrieve disconnected things": {
+			pageMetadata: things.PageMetadata{
+				Connected: &disconnected,
+			},
+			size: uint64(n - connNum),
+		},
+		"retrieve things by tags": {
+			pageMetadata: things.PageMetadata{
+				Tags: []string{"floor-1"},
+			},
+			size: 5,
+		},
+		"retrieve things with invalid metadata filter": {
+			pageMetadata: things.PageMetadata{
+				Filters: []things.MetadataFilter{{Path: "serial", Op: "like", Value: "SN"}},
+			},
+			size: 0,
+			err:  errors.ErrMalformedEntity,
+		},
+	}
+
+	for desc, tc := range cases {
+		page, err := thingRepo.RetrieveByOwner(context.Background(), email, tc.pageMetadata)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		size := uint64(len(page.Things))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
+	}
+}
+
 func TestBackupThings(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	err := cleanTestTable(context.Background(), "things", dbMiddleware)
@@ -656,7 +796,7 @@ func TestMultiThingRetrievalByChannel(t *testing.T) {
 			break
 		}
 
-		err = channelRepo.Connect(context.Background(), email, chID, []string{thID})
+		err = channelRepo.Connect(context.Background(), email, chID, []string{thID}, things.ConnTypePubSub)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	}
 
@@ -843,6 +983,161 @@ func TestThingRemoval(t *testing.T) {
 	}
 }
 
+func TestThingRestore(t *testing.T) {
+	email := "thing-restore@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	key, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thing := things.Thing{
+		ID:    id,
+		Owner: email,
+		Key:   key,
+	}
+
+	_, err = thingRepo.Save(context.Background(), thing)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = thingRepo.Remove(context.Background(), email, thing.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	_, err = thingRepo.RetrieveByKey(context.Background(), key)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve removed thing by key: expected %s got %s\n", errors.ErrNotFound, err))
+
+	page, err := thingRepo.RetrieveDeleted(context.Background(), email, things.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, uint64(1), page.Total, fmt.Sprintf("retrieve deleted things: expected 1 got %d\n", page.Total))
+
+	cases := []struct {
+		desc    string
+		owner   string
+		thingID string
+		err     error
+	}{
+		{
+			desc:    "restore thing of other user",
+			owner:   wrongValue,
+			thingID: thing.ID,
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "restore removed thing",
+			owner:   email,
+			thingID: thing.ID,
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := thingRepo.Restore(context.Background(), tc.owner, tc.thingID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	thID, err := thingRepo.RetrieveByKey(context.Background(), key)
+	assert.Nil(t, err, fmt.Sprintf("retrieve restored thing by key: unexpected error: %s\n", err))
+	assert.Equal(t, thing.ID, thID, fmt.Sprintf("retrieve restored thing by key: expected %s got %s\n", thing.ID, thID))
+}
+
+func TestThingTags(t *testing.T) {
+	email := "thing-tags@example.com"
+	dbMiddleware := postgres.NewDatabase(db)
+	thingRepo := postgres.NewThingRepository(dbMiddleware)
+
+	var ths []things.Thing
+	for _, tags := range [][]string{{"floor-1", "outdoor"}, {"indoor"}} {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		key, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		th := things.Thing{
+			ID:    id,
+			Owner: email,
+			Key:   key,
+			Tags:  tags,
+		}
+		saved, err := thingRepo.Save(context.Background(), th)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		ths = append(ths, saved...)
+	}
+
+	cases := []struct {
+		desc  string
+		add   bool
+		owner string
+		ids   []string
+		tags  []string
+		err   error
+	}{
+		{
+			desc:  "add tags to thing of other user",
+			add:   true,
+			owner: wrongValue,
+			ids:   []string{ths[0].ID},
+			tags:  []string{"faulty"},
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "add tags to things",
+			add:   true,
+			owner: email,
+			ids:   []string{ths[0].ID, ths[1].ID},
+			tags:  []string{"faulty", "outdoor"},
+			err:   nil,
+		},
+		{
+			desc:  "remove tags from thing",
+			add:   false,
+			owner: email,
+			ids:   []string{ths[1].ID},
+			tags:  []string{"faulty"},
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		update := thingRepo.RemoveTags
+		if tc.add {
+			update = thingRepo.AddTags
+		}
+		err := update(context.Background(), tc.owner, tc.tags, tc.ids...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	th, err := thingRepo.RetrieveByID(context.Background(), ths[1].ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, []string{"indoor", "outdoor"}, th.Tags, fmt.Sprintf("retrieve thing: expected tags %v got %v\n", []string{"indoor", "outdoor"}, th.Tags))
+
+	filters := []struct {
+		desc string
+		tags []string
+		size uint64
+	}{
+		{
+			desc: "retrieve things having a tag",
+			tags: []string{"faulty"},
+			size: 1,
+		},
+		{
+			desc: "retrieve things not having a tag",
+			tags: []string{"!floor-1"},
+			size: 1,
+		},
+		{
+			desc: "retrieve things having any of the tags",
+			tags: []string{"indoor|floor-1", "outdoor"},
+			size: 2,
+		},
+	}
+
+	for _, tc := range filters {
+		page, err := thingRepo.RetrieveByOwner(context.Background(), email, things.PageMetadata{Limit: 10, Tags: tc.tags})
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.size, page.Total, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, page.Total))
+	}
+}
+
 func testSortThings(t *testing.T, pm things.PageMetadata, ths []things.Thing) {
 	if len(ths) < 1 {
 		return
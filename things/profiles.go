@@ -0,0 +1,135 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+var (
+	// ErrInvalidProfileSchema indicates that the profile schema is not a valid JSON schema.
+	ErrInvalidProfileSchema = errors.New("invalid profile schema")
+
+	// ErrInvalidMetadata indicates that the metadata doesn't conform to the profile schema.
+	ErrInvalidMetadata = errors.New("metadata doesn't conform to the profile schema")
+)
+
+// Profile represents a template for things and channels. It describes the
+// shape of their metadata by a JSON schema and provides default values for it.
+type Profile struct {
+	ID          string
+	OwnerID     string
+	Name        string
+	Description string
+	Schema      map[string]interface{}
+	Defaults    map[string]interface{}
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Validate checks whether the profile schema is a valid JSON schema and
+// whether the profile defaults conform to the types it declares.
+func (p Profile) Validate() error {
+	if len(p.Schema) == 0 {
+		return nil
+	}
+
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(p.Schema))
+	if err != nil {
+		return errors.Wrap(ErrInvalidProfileSchema, err)
+	}
+
+	res, err := schema.Validate(gojsonschema.NewGoLoader(p.defaults()))
+	if err != nil {
+		return errors.Wrap(ErrInvalidProfileSchema, err)
+	}
+
+	// Defaults don't have to contain the required fields, since those
+	// are expected to be provided by each thing or channel.
+	var msgs []string
+	for _, re := range res.Errors() {
+		if re.Type() == "required" {
+			continue
+		}
+		msgs = append(msgs, re.String())
+	}
+	if len(msgs) > 0 {
+		return errors.Wrap(ErrInvalidProfileSchema, errors.New(strings.Join(msgs, "; ")))
+	}
+
+	return nil
+}
+
+// Apply fills the top-level fields missing from the metadata with the
+// profile defaults and validates the result against the profile schema.
+func (p Profile) Apply(metadata map[string]interface{}) (map[string]interface{}, error) {
+	md := p.defaults()
+	for k, v := range metadata {
+		md[k] = v
+	}
+
+	if len(p.Schema) == 0 {
+		return md, nil
+	}
+
+	res, err := gojsonschema.Validate(gojsonschema.NewGoLoader(p.Schema), gojsonschema.NewGoLoader(md))
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidProfileSchema, err)
+	}
+
+	if !res.Valid() {
+		var msgs []string
+		for _, re := range res.Errors() {
+			msgs = append(msgs, re.String())
+		}
+		return nil, errors.Wrap(ErrInvalidMetadata, errors.New(strings.Join(msgs, "; ")))
+	}
+
+	return md, nil
+}
+
+func (p Profile) defaults() map[string]interface{} {
+	md := make(map[string]interface{}, len(p.Defaults))
+	for k, v := range p.Defaults {
+		md[k] = v
+	}
+
+	return md
+}
+
+// ProfilesPage contains page related metadata as well as list of profiles
+// that belong to this page.
+type ProfilesPage struct {
+	PageMetadata
+	Profiles []Profile
+}
+
+// ProfileRepository specifies a profile persistence API.
+type ProfileRepository interface {
+	// Save persists multiple profiles. Profiles are saved using a transaction.
+	// If one profile fails then none will be saved.
+	Save(ctx context.Context, prs ...Profile) ([]Profile, error)
+
+	// Update updates the name, description, schema and defaults of the profile.
+	Update(ctx context.Context, pr Profile) error
+
+	// RetrieveByID retrieves the profile having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Profile, error)
+
+	// RetrieveByOwner retrieves the subset of profiles owned by the specified user.
+	RetrieveByOwner(ctx context.Context, ownerID string, pm PageMetadata) (ProfilesPage, error)
+
+	// RetrieveAll retrieves all profiles for all users.
+	RetrieveAll(ctx context.Context) ([]Profile, error)
+
+	// Remove removes the profiles having the provided identifiers, that are
+	// owned by the specified user. Profiles referenced by things or channels
+	// can't be removed.
+	Remove(ctx context.Context, ownerID string, ids ...string) error
+}
@@ -0,0 +1,284 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+)
+
+// subtreeDepth is deep enough to reach every descendant of a group.
+const subtreeDepth = math.MaxInt32
+
+func (ts *thingsService) ViewUsage(ctx context.Context, token, orgID string) (map[string]quota.Usage, error) {
+	if orgID != "" {
+		if _, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.OrgSubject, Object: orgID, Action: auth.ViewerRole}); err != nil {
+			return nil, err
+		}
+
+		q, err := ts.auth.RetrieveQuota(ctx, &mainflux.QuotaReq{Subject: quota.Org, Id: orgID})
+		if err != nil {
+			return nil, err
+		}
+
+		ths, chs, err := ts.countOrgEntities(ctx, q.GetGroupIDs())
+		if err != nil {
+			return nil, err
+		}
+
+		usage := map[string]quota.Usage{
+			quota.Things:   {Used: ths, Limit: q.GetThings()},
+			quota.Channels: {Used: chs, Limit: q.GetChannels()},
+		}
+		return usage, nil
+	}
+
+	user, err := ts.identify(ctx, token, auth.ThingsReadScope)
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := ts.auth.RetrieveQuota(ctx, &mainflux.QuotaReq{Subject: quota.User, Id: user.GetId()})
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[string]quota.Usage)
+	for _, resource := range []string{quota.Things, quota.Channels, quota.Groups} {
+		used, err := ts.countOwned(ctx, user.GetId(), resource)
+		if err != nil {
+			return nil, err
+		}
+		usage[resource] = quota.Usage{Used: used, Limit: limitOf(q, resource)}
+	}
+
+	return usage, nil
+}
+
+// reserveUserQuota returns ErrQuotaExceeded if the user can't own n more
+// entities of the resource. Otherwise, it locks the user quota of the
+// resource until the returned release function is called, which the caller
+// does once the entities are saved, so that concurrent requests can't
+// exceed the quota together.
+func (ts *thingsService) reserveUserQuota(ctx context.Context, userID, resource string, n int) (func(), error) {
+	q, err := ts.auth.RetrieveQuota(ctx, &mainflux.QuotaReq{Subject: quota.User, Id: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	limit := limitOf(q, resource)
+	if limit == 0 {
+		return func() {}, nil
+	}
+
+	release := ts.quotaLocks.lock(quota.User, userID, resource)
+	used, err := ts.countOwned(ctx, userID, resource)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if err := quota.Check(used, uint64(n), limit); err != nil {
+		release()
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// reserveOrgQuota returns ErrQuotaExceeded if the given number of entities
+// of the resource, keyed by the ID of the group they are assigned to, can't
+// be assigned to the orgs that the groups belong to. Otherwise, it locks the
+// quotas of the orgs the same way as reserveUserQuota does.
+func (ts *thingsService) reserveOrgQuota(ctx context.Context, resource string, counts map[string]int) (func(), error) {
+	quotas := make(map[string]*mainflux.QuotaRes)
+	added := make(map[string]int)
+	for groupID, n := range counts {
+		q, err := ts.auth.RetrieveQuota(ctx, &mainflux.QuotaReq{Subject: quota.Group, Id: groupID})
+		if err != nil {
+			return nil, err
+		}
+		if limitOf(q, resource) == 0 {
+			continue
+		}
+
+		quotas[q.GetOrgID()] = q
+		added[q.GetOrgID()] += n
+	}
+
+	// Orgs are locked in the same order to prevent deadlocks.
+	var orgIDs []string
+	for orgID := range quotas {
+		orgIDs = append(orgIDs, orgID)
+	}
+	sort.Strings(orgIDs)
+
+	var releases []func()
+	release := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+
+	for _, orgID := range orgIDs {
+		releases = append(releases, ts.quotaLocks.lock(quota.Org, orgID, resource))
+
+		q := quotas[orgID]
+		ths, chs, err := ts.countOrgEntities(ctx, q.GetGroupIDs())
+		if err != nil {
+			release()
+			return nil, err
+		}
+
+		used := ths
+		if resource == quota.Channels {
+			used = chs
+		}
+
+		if err := quota.Check(used, uint64(added[orgID]), limitOf(q, resource)); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+// reserveRestoreQuota reserves the user quota for the n entities of the
+// resource restored from the trash, and the org quotas for those of them
+// rejoining their groups, keyed by the group ID.
+func (ts *thingsService) reserveRestoreQuota(ctx context.Context, userID, resource string, n int, groups map[string]int) (func(), error) {
+	releaseUser, err := ts.reserveUserQuota(ctx, userID, resource, n)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseOrgs, err := ts.reserveOrgQuota(ctx, resource, groups)
+	if err != nil {
+		releaseUser()
+		return nil, err
+	}
+
+	return func() {
+		releaseOrgs()
+		releaseUser()
+	}, nil
+}
+
+func (ts *thingsService) countOwned(ctx context.Context, userID, resource string) (uint64, error) {
+	pm := PageMetadata{Limit: 1}
+
+	switch resource {
+	case quota.Things:
+		page, err := ts.things.RetrieveByOwner(ctx, userID, pm)
+		return page.Total, err
+	case quota.Channels:
+		page, err := ts.channels.RetrieveByOwner(ctx, userID, pm)
+		return page.Total, err
+	default:
+		page, err := ts.groups.RetrieveByOwner(ctx, userID, pm)
+		return page.Total, err
+	}
+}
+
+// countOrgEntities counts the things and the channels assigned to the org
+// groups and their descendants.
+func (ts *thingsService) countOrgEntities(ctx context.Context, groupIDs []string) (uint64, uint64, error) {
+	var ths, chs uint64
+	counted := make(map[string]bool)
+	for _, groupID := range groupIDs {
+		children, err := ts.groups.RetrieveChildren(ctx, groupID, subtreeDepth, PageMetadata{})
+		if err != nil {
+			return 0, 0, err
+		}
+
+		ids := []string{groupID}
+		for _, child := range children.Groups {
+			ids = append(ids, child.ID)
+		}
+
+		for _, id := range ids {
+			// Nested groups can be assigned to the org along with their ancestors.
+			if counted[id] {
+				continue
+			}
+			counted[id] = true
+
+			tp, err := ts.groups.RetrieveGroupThings(ctx, id, PageMetadata{Limit: 1})
+			if err != nil {
+				return 0, 0, err
+			}
+			ths += tp.Total
+
+			cp, err := ts.groups.RetrieveGroupChannels(ctx, id, PageMetadata{Limit: 1})
+			if err != nil {
+				return 0, 0, err
+			}
+			chs += cp.Total
+		}
+	}
+
+	return ths, chs, nil
+}
+
+func limitOf(q *mainflux.QuotaRes, resource string) uint64 {
+	switch resource {
+	case quota.Things:
+		return q.GetThings()
+	case quota.Channels:
+		return q.GetChannels()
+	case quota.Groups:
+		return q.GetGroups()
+	default:
+		return 0
+	}
+}
+
+// quotaLocks serializes the quota checks with saving the entities they admit.
+// Since the locks are held in memory, they only guard the requests handled by
+// the same service instance.
+type quotaLocks struct {
+	mu    sync.Mutex
+	locks map[string]*quotaLock
+}
+
+type quotaLock struct {
+	sync.Mutex
+	refs int
+}
+
+func newQuotaLocks() *quotaLocks {
+	return &quotaLocks{locks: make(map[string]*quotaLock)}
+}
+
+// lock locks the quota of the subject's resource and returns the function
+// releasing it.
+func (ql *quotaLocks) lock(subject, id, resource string) func() {
+	key := subject + ":" + id + ":" + resource
+
+	ql.mu.Lock()
+	l, ok := ql.locks[key]
+	if !ok {
+		l = &quotaLock{}
+		ql.locks[key] = l
+	}
+	l.refs++
+	ql.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+
+		ql.mu.Lock()
+		defer ql.mu.Unlock()
+		if l.refs--; l.refs == 0 {
+			delete(ql.locks, key)
+		}
+	}
+}
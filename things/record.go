@@ -0,0 +1,39 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import "context"
+
+// Record holds the details of the operation resolved by the service while
+// performing it, i.e. the user performing the operation and the state of the
+// updated entity before the update. It lets the event store report them
+// without resolving them once again.
+type Record struct {
+	// Actor is the ID of the user performing the operation.
+	Actor string
+
+	// Before is the Thing, Channel or Group before the update.
+	Before interface{}
+}
+
+type recordKey struct{}
+
+// WithRecord returns a copy of the context carrying the empty record, which
+// the service fills in while performing the operation.
+func WithRecord(ctx context.Context) (context.Context, *Record) {
+	rec := &Record{}
+	return context.WithValue(ctx, recordKey{}, rec), rec
+}
+
+func recordActor(ctx context.Context, id string) {
+	if rec, ok := ctx.Value(recordKey{}).(*Record); ok {
+		rec.Actor = id
+	}
+}
+
+func recordBefore(ctx context.Context, entity interface{}) {
+	if rec, ok := ctx.Value(recordKey{}).(*Record); ok {
+		rec.Before = entity
+	}
+}
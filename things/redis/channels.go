@@ -12,7 +12,9 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
-const chanPrefix = "channel"
+// Connections are kept in a hash per channel that maps thing ID to the
+// connection type.
+const chanPrefix = "channel_conns"
 
 var _ things.ChannelCache = (*channelCache)(nil)
 
@@ -25,22 +27,26 @@ func NewChannelCache(client *redis.Client) things.ChannelCache {
 	return channelCache{client: client}
 }
 
-func (cc channelCache) Connect(ctx context.Context, chanID, thingID string) error {
+func (cc channelCache) Connect(ctx context.Context, chanID, thingID, connType string) error {
 	cid, tid := kv(chanID, thingID)
-	if err := cc.client.SAdd(ctx, cid, tid).Err(); err != nil {
+	if err := cc.client.HSet(ctx, cid, tid, connType).Err(); err != nil {
 		return errors.Wrap(errors.ErrCreateEntity, err)
 	}
 	return nil
 }
 
-func (cc channelCache) HasThing(ctx context.Context, chanID, thingID string) bool {
+func (cc channelCache) CanAccess(ctx context.Context, chanID, thingID, action string) bool {
 	cid, tid := kv(chanID, thingID)
-	return cc.client.SIsMember(ctx, cid, tid).Val()
+	connType, err := cc.client.HGet(ctx, cid, tid).Result()
+	if err != nil {
+		return false
+	}
+	return things.ConnTypeAllows(connType, action)
 }
 
 func (cc channelCache) Disconnect(ctx context.Context, chanID, thingID string) error {
 	cid, tid := kv(chanID, thingID)
-	if err := cc.client.SRem(ctx, cid, tid).Err(); err != nil {
+	if err := cc.client.HDel(ctx, cid, tid).Err(); err != nil {
 		return errors.Wrap(errors.ErrRemoveEntity, err)
 	}
 	return nil
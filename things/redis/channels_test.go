@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/MainfluxLabs/mainflux/things/redis"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,18 +37,18 @@ func TestConnect(t *testing.T) {
 		},
 	}
 	for _, tc := range cases {
-		err := channelCache.Connect(context.Background(), cid, tid)
+		err := channelCache.Connect(context.Background(), cid, tid, things.ConnTypePubSub)
 		assert.Nil(t, err, fmt.Sprintf("%s: fail to connect due to: %s\n", tc.desc, err))
 	}
 }
 
-func TestHasThing(t *testing.T) {
+func TestCanAccess(t *testing.T) {
 	channelCache := redis.NewChannelCache(redisClient)
 
 	cid := "123"
 	tid := "321"
 
-	err := channelCache.Connect(context.Background(), cid, tid)
+	err := channelCache.Connect(context.Background(), cid, tid, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
 
 	cases := map[string]struct {
@@ -73,10 +74,60 @@ func TestHasThing(t *testing.T) {
 	}
 
 	for desc, tc := range cases {
-		hasAccess := channelCache.HasThing(context.Background(), tc.cid, tc.tid)
+		hasAccess := channelCache.CanAccess(context.Background(), tc.cid, tc.tid, things.PublishAction)
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
 	}
 }
+
+func TestCanAccessByConnType(t *testing.T) {
+	channelCache := redis.NewChannelCache(redisClient)
+
+	cid := "223"
+	pubID := "421"
+	subID := "422"
+
+	err := channelCache.Connect(context.Background(), cid, pubID, things.ConnTypePublish)
+	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
+	err = channelCache.Connect(context.Background(), cid, subID, things.ConnTypeSubscribe)
+	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
+
+	cases := []struct {
+		desc      string
+		tid       string
+		action    string
+		hasAccess bool
+	}{
+		{
+			desc:      "publish using publish connection",
+			tid:       pubID,
+			action:    things.PublishAction,
+			hasAccess: true,
+		},
+		{
+			desc:      "subscribe using publish connection",
+			tid:       pubID,
+			action:    things.SubscribeAction,
+			hasAccess: false,
+		},
+		{
+			desc:      "subscribe using subscribe connection",
+			tid:       subID,
+			action:    things.SubscribeAction,
+			hasAccess: true,
+		},
+		{
+			desc:      "publish using subscribe connection",
+			tid:       subID,
+			action:    things.PublishAction,
+			hasAccess: false,
+		},
+	}
+
+	for _, tc := range cases {
+		hasAccess := channelCache.CanAccess(context.Background(), cid, tc.tid, tc.action)
+		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.hasAccess, hasAccess))
+	}
+}
 func TestDisconnect(t *testing.T) {
 	channelCache := redis.NewChannelCache(redisClient)
 
@@ -84,7 +135,7 @@ func TestDisconnect(t *testing.T) {
 	tid := "321"
 	tid2 := "322"
 
-	err := channelCache.Connect(context.Background(), cid, tid)
+	err := channelCache.Connect(context.Background(), cid, tid, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
 
 	cases := []struct {
@@ -110,7 +161,7 @@ func TestDisconnect(t *testing.T) {
 		err := channelCache.Disconnect(context.Background(), tc.cid, tc.tid)
 		assert.Nil(t, err, fmt.Sprintf("%s: fail due to: %s\n", tc.desc, err))
 
-		hasAccess := channelCache.HasThing(context.Background(), tc.cid, tc.tid)
+		hasAccess := channelCache.CanAccess(context.Background(), tc.cid, tc.tid, things.PublishAction)
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("access check after %s: expected %t got %t\n", tc.desc, tc.hasAccess, hasAccess))
 	}
 }
@@ -122,7 +173,7 @@ func TestRemove(t *testing.T) {
 	cid2 := "124"
 	tid := "321"
 
-	err := channelCache.Connect(context.Background(), cid, tid)
+	err := channelCache.Connect(context.Background(), cid, tid, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("connect thing to channel: fail to connect due to: %s\n", err))
 
 	cases := []struct {
@@ -151,7 +202,7 @@ func TestRemove(t *testing.T) {
 	for _, tc := range cases {
 		err := channelCache.Remove(context.Background(), tc.cid)
 		assert.Nil(t, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		hasAcces := channelCache.HasThing(context.Background(), tc.cid, tc.tid)
+		hasAcces := channelCache.CanAccess(context.Background(), tc.cid, tc.tid, things.PublishAction)
 		assert.Equal(t, tc.hasAccess, hasAcces, "%s - check access after removing channel: expected %t got %t\n", tc.desc, tc.hasAccess, hasAcces)
 	}
 }
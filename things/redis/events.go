@@ -1,19 +1,52 @@
 package redis
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/things"
+)
 
 const (
 	thingPrefix     = "thing."
 	thingCreate     = thingPrefix + "create"
 	thingUpdate     = thingPrefix + "update"
 	thingRemove     = thingPrefix + "remove"
+	thingRestore    = thingPrefix + "restore"
 	thingConnect    = thingPrefix + "connect"
 	thingDisconnect = thingPrefix + "disconnect"
-
-	channelPrefix = "channel."
-	channelCreate = channelPrefix + "create"
-	channelUpdate = channelPrefix + "update"
-	channelRemove = channelPrefix + "remove"
+	thingKeyCreate  = thingPrefix + "key_create"
+	thingKeyUpdate  = thingPrefix + "key_update"
+	thingKeyRemove  = thingPrefix + "key_remove"
+	thingKeyRotate  = thingPrefix + "key_rotate"
+	thingTagsAdd    = thingPrefix + "tags_add"
+	thingTagsRemove = thingPrefix + "tags_remove"
+
+	channelPrefix     = "channel."
+	channelCreate     = channelPrefix + "create"
+	channelUpdate     = channelPrefix + "update"
+	channelRemove     = channelPrefix + "remove"
+	channelRestore    = channelPrefix + "restore"
+	channelTagsAdd    = channelPrefix + "tags_add"
+	channelTagsRemove = channelPrefix + "tags_remove"
+
+	profilePrefix = "profile."
+	profileCreate = profilePrefix + "create"
+	profileUpdate = profilePrefix + "update"
+	profileRemove = profilePrefix + "remove"
+
+	groupPrefix          = "group."
+	groupCreate          = groupPrefix + "create"
+	groupUpdate          = groupPrefix + "update"
+	groupMove            = groupPrefix + "move"
+	groupRemove          = groupPrefix + "remove"
+	groupRestore         = groupPrefix + "restore"
+	groupAssignThing     = groupPrefix + "assign_thing"
+	groupUnassignThing   = groupPrefix + "unassign_thing"
+	groupAssignChannel   = groupPrefix + "assign_channel"
+	groupUnassignChannel = groupPrefix + "unassign_channel"
+	groupTagsAdd         = groupPrefix + "tags_add"
+	groupTagsRemove      = groupPrefix + "tags_remove"
 )
 
 type event interface {
@@ -24,18 +57,30 @@ var (
 	_ event = (*createThingEvent)(nil)
 	_ event = (*updateThingEvent)(nil)
 	_ event = (*removeThingEvent)(nil)
+	_ event = (*restoreThingEvent)(nil)
+	_ event = (*thingKeyEvent)(nil)
 	_ event = (*createChannelEvent)(nil)
 	_ event = (*updateChannelEvent)(nil)
 	_ event = (*removeChannelEvent)(nil)
+	_ event = (*restoreChannelEvent)(nil)
 	_ event = (*connectThingEvent)(nil)
 	_ event = (*disconnectThingEvent)(nil)
+	_ event = (*profileEvent)(nil)
+	_ event = (*createGroupEvent)(nil)
+	_ event = (*updateGroupEvent)(nil)
+	_ event = (*removeGroupEvent)(nil)
+	_ event = (*restoreGroupEvent)(nil)
+	_ event = (*moveGroupEvent)(nil)
+	_ event = (*groupMemberEvent)(nil)
+	_ event = (*tagsEvent)(nil)
 )
 
 type createThingEvent struct {
-	id       string
-	owner    string
-	name     string
-	metadata map[string]interface{}
+	id        string
+	owner     string
+	name      string
+	metadata  map[string]interface{}
+	profileID string
 }
 
 func (cte createThingEvent) Encode() map[string]interface{} {
@@ -49,6 +94,10 @@ func (cte createThingEvent) Encode() map[string]interface{} {
 		val["name"] = cte.name
 	}
 
+	if cte.profileID != "" {
+		val["profile_id"] = cte.profileID
+	}
+
 	if cte.metadata != nil {
 		metadata, err := json.Marshal(cte.metadata)
 		if err != nil {
@@ -65,6 +114,7 @@ type updateThingEvent struct {
 	id       string
 	name     string
 	metadata map[string]interface{}
+	before   map[string]interface{}
 }
 
 func (ute updateThingEvent) Encode() map[string]interface{} {
@@ -73,6 +123,13 @@ func (ute updateThingEvent) Encode() map[string]interface{} {
 		"operation": thingUpdate,
 	}
 
+	if ute.before != nil {
+		before, err := json.Marshal(ute.before)
+		if err == nil {
+			val["before"] = string(before)
+		}
+	}
+
 	if ute.name != "" {
 		val["name"] = ute.name
 	}
@@ -100,11 +157,59 @@ func (rte removeThingEvent) Encode() map[string]interface{} {
 	}
 }
 
+type restoreThingEvent struct {
+	id string
+}
+
+func (rte restoreThingEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        rte.id,
+		"operation": thingRestore,
+	}
+}
+
+// Thing key event notifies about changes of thing keys, so that services
+// caching thing keys can evict them. Key values are never sent over stream.
+type thingKeyEvent struct {
+	thingID   string
+	keyID     string
+	name      string
+	enabled   *bool
+	expiresAt time.Time
+	operation string
+}
+
+func (tke thingKeyEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"thing_id":  tke.thingID,
+		"operation": tke.operation,
+	}
+
+	if tke.keyID != "" {
+		val["key_id"] = tke.keyID
+	}
+
+	if tke.name != "" {
+		val["name"] = tke.name
+	}
+
+	if tke.enabled != nil {
+		val["enabled"] = *tke.enabled
+	}
+
+	if !tke.expiresAt.IsZero() {
+		val["expires_at"] = tke.expiresAt.Format(time.RFC3339)
+	}
+
+	return val
+}
+
 type createChannelEvent struct {
-	id       string
-	owner    string
-	name     string
-	metadata map[string]interface{}
+	id        string
+	owner     string
+	name      string
+	metadata  map[string]interface{}
+	profileID string
 }
 
 func (cce createChannelEvent) Encode() map[string]interface{} {
@@ -118,6 +223,10 @@ func (cce createChannelEvent) Encode() map[string]interface{} {
 		val["name"] = cce.name
 	}
 
+	if cce.profileID != "" {
+		val["profile_id"] = cce.profileID
+	}
+
 	if cce.metadata != nil {
 		metadata, err := json.Marshal(cce.metadata)
 		if err != nil {
@@ -134,6 +243,7 @@ type updateChannelEvent struct {
 	id       string
 	name     string
 	metadata map[string]interface{}
+	before   map[string]interface{}
 }
 
 func (uce updateChannelEvent) Encode() map[string]interface{} {
@@ -142,6 +252,13 @@ func (uce updateChannelEvent) Encode() map[string]interface{} {
 		"operation": channelUpdate,
 	}
 
+	if uce.before != nil {
+		before, err := json.Marshal(uce.before)
+		if err == nil {
+			val["before"] = string(before)
+		}
+	}
+
 	if uce.name != "" {
 		val["name"] = uce.name
 	}
@@ -169,15 +286,52 @@ func (rce removeChannelEvent) Encode() map[string]interface{} {
 	}
 }
 
+type restoreChannelEvent struct {
+	id string
+}
+
+func (rce restoreChannelEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        rce.id,
+		"operation": channelRestore,
+	}
+}
+
+type profileEvent struct {
+	id        string
+	ownerID   string
+	name      string
+	operation string
+}
+
+func (pe profileEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        pe.id,
+		"operation": pe.operation,
+	}
+
+	if pe.ownerID != "" {
+		val["owner_id"] = pe.ownerID
+	}
+
+	if pe.name != "" {
+		val["name"] = pe.name
+	}
+
+	return val
+}
+
 type connectThingEvent struct {
-	chanID  string
-	thingID string
+	chanID   string
+	thingID  string
+	connType string
 }
 
 func (cte connectThingEvent) Encode() map[string]interface{} {
 	return map[string]interface{}{
 		"chan_id":   cte.chanID,
 		"thing_id":  cte.thingID,
+		"conn_type": cte.connType,
 		"operation": thingConnect,
 	}
 }
@@ -194,3 +348,192 @@ func (dte disconnectThingEvent) Encode() map[string]interface{} {
 		"operation": thingDisconnect,
 	}
 }
+
+type createGroupEvent struct {
+	id          string
+	owner       string
+	parentID    string
+	name        string
+	description string
+	metadata    map[string]interface{}
+}
+
+func (cge createGroupEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        cge.id,
+		"owner":     cge.owner,
+		"name":      cge.name,
+		"operation": groupCreate,
+	}
+
+	if cge.parentID != "" {
+		val["parent_id"] = cge.parentID
+	}
+
+	if cge.description != "" {
+		val["description"] = cge.description
+	}
+
+	if cge.metadata != nil {
+		metadata, err := json.Marshal(cge.metadata)
+		if err != nil {
+			return val
+		}
+
+		val["metadata"] = string(metadata)
+	}
+
+	return val
+}
+
+type updateGroupEvent struct {
+	id          string
+	name        string
+	description string
+	metadata    map[string]interface{}
+	before      map[string]interface{}
+}
+
+func (uge updateGroupEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        uge.id,
+		"name":      uge.name,
+		"operation": groupUpdate,
+	}
+
+	if uge.description != "" {
+		val["description"] = uge.description
+	}
+
+	if uge.before != nil {
+		before, err := json.Marshal(uge.before)
+		if err == nil {
+			val["before"] = string(before)
+		}
+	}
+
+	if uge.metadata != nil {
+		metadata, err := json.Marshal(uge.metadata)
+		if err != nil {
+			return val
+		}
+
+		val["metadata"] = string(metadata)
+	}
+
+	return val
+}
+
+type removeGroupEvent struct {
+	id string
+}
+
+func (rge removeGroupEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        rge.id,
+		"operation": groupRemove,
+	}
+}
+
+type restoreGroupEvent struct {
+	id string
+}
+
+func (rge restoreGroupEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        rge.id,
+		"operation": groupRestore,
+	}
+}
+
+type moveGroupEvent struct {
+	id       string
+	parentID string
+}
+
+func (mge moveGroupEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        mge.id,
+		"operation": groupMove,
+	}
+
+	if mge.parentID != "" {
+		val["parent_id"] = mge.parentID
+	}
+
+	return val
+}
+
+// Group member event is either assign or unassign event of a thing or a channel.
+type groupMemberEvent struct {
+	groupID   string
+	memberID  string
+	memberKey string
+	operation string
+}
+
+func (gme groupMemberEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          gme.groupID,
+		gme.memberKey: gme.memberID,
+		"operation":   gme.operation,
+	}
+}
+
+// Tags event notifies about tags added to or removed from a thing, a
+// channel or a group.
+type tagsEvent struct {
+	id        string
+	tags      []string
+	operation string
+}
+
+func (te tagsEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        te.id,
+		"operation": te.operation,
+	}
+
+	tags, err := json.Marshal(te.tags)
+	if err != nil {
+		return val
+	}
+	val["tags"] = string(tags)
+
+	return val
+}
+
+// thingState returns the auditable state of the thing, omitting the thing key.
+func thingState(th things.Thing) map[string]interface{} {
+	if th.ID == "" {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"name":     th.Name,
+		"metadata": th.Metadata,
+	}
+}
+
+func channelState(ch things.Channel) map[string]interface{} {
+	if ch.ID == "" {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"name":     ch.Name,
+		"metadata": ch.Metadata,
+	}
+}
+
+func groupState(gr things.Group) map[string]interface{} {
+	if gr.ID == "" {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"name":        gr.Name,
+		"description": gr.Description,
+		"metadata":    gr.Metadata,
+	}
+}
@@ -5,7 +5,12 @@ package redis
 
 import (
 	"context"
+	"time"
 
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/go-redis/redis/v8"
 )
@@ -23,7 +28,8 @@ type eventStore struct {
 }
 
 // NewEventStoreMiddleware returns wrapper around things service that sends
-// events to event store.
+// events to event store. The user performing the operation is reported as
+// resolved by the service.
 func NewEventStoreMiddleware(svc things.Service, client *redis.Client) things.Service {
 	return eventStore{
 		svc:    svc,
@@ -32,6 +38,7 @@ func NewEventStoreMiddleware(svc things.Service, client *redis.Client) things.Se
 }
 
 func (es eventStore) CreateThings(ctx context.Context, token string, ths ...things.Thing) ([]things.Thing, error) {
+	ctx, rec := things.WithRecord(ctx)
 	sths, err := es.svc.CreateThings(ctx, token, ths...)
 	if err != nil {
 		return sths, err
@@ -39,47 +46,128 @@ func (es eventStore) CreateThings(ctx context.Context, token string, ths ...thin
 
 	for _, thing := range sths {
 		event := createThingEvent{
-			id:       thing.ID,
-			owner:    thing.Owner,
-			name:     thing.Name,
-			metadata: thing.Metadata,
+			id:        thing.ID,
+			owner:     thing.Owner,
+			name:      thing.Name,
+			metadata:  thing.Metadata,
+			profileID: thing.ProfileID,
 		}
-		record := &redis.XAddArgs{
-			Stream:       streamID,
-			MaxLenApprox: streamLen,
-			Values:       event.Encode(),
-		}
-		es.client.XAdd(ctx, record).Err()
+		es.add(ctx, rec.Actor, event)
 	}
 
 	return sths, nil
 }
 
 func (es eventStore) UpdateThing(ctx context.Context, token string, thing things.Thing) error {
+	ctx, rec := things.WithRecord(ctx)
 	if err := es.svc.UpdateThing(ctx, token, thing); err != nil {
 		return err
 	}
 
+	before, _ := rec.Before.(things.Thing)
 	event := updateThingEvent{
 		id:       thing.ID,
 		name:     thing.Name,
 		metadata: thing.Metadata,
+		before:   thingState(before),
 	}
-	record := &redis.XAddArgs{
-		Stream:       streamID,
-		MaxLenApprox: streamLen,
-		Values:       event.Encode(),
-	}
-	es.client.XAdd(ctx, record).Err()
+	es.add(ctx, rec.Actor, event)
 
 	return nil
 }
 
-// UpdateKey doesn't send event because key shouldn't be sent over stream.
-// Maybe we can start publishing this event at some point, without key value
-// in order to notify adapters to disconnect connected things after key update.
+// UpdateKey sends event without key value in order to notify adapters
+// to disconnect connected things after key update.
 func (es eventStore) UpdateKey(ctx context.Context, token, id, key string) error {
-	return es.svc.UpdateKey(ctx, token, id, key)
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.UpdateKey(ctx, token, id, key); err != nil {
+		return err
+	}
+
+	event := thingKeyEvent{
+		thingID:   id,
+		operation: thingKeyUpdate,
+	}
+	es.add(ctx, rec.Actor, event)
+
+	return nil
+}
+
+func (es eventStore) RotateKey(ctx context.Context, token, id, key string, grace time.Duration) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.RotateKey(ctx, token, id, key, grace); err != nil {
+		return err
+	}
+
+	event := thingKeyEvent{
+		thingID:   id,
+		operation: thingKeyRotate,
+	}
+	if grace > 0 {
+		event.expiresAt = time.Now().Add(grace)
+	}
+	es.add(ctx, rec.Actor, event)
+
+	return nil
+}
+
+func (es eventStore) CreateThingKey(ctx context.Context, token, thingID string, key things.ThingKey) (things.ThingKey, error) {
+	ctx, rec := things.WithRecord(ctx)
+	k, err := es.svc.CreateThingKey(ctx, token, thingID, key)
+	if err != nil {
+		return k, err
+	}
+
+	event := thingKeyEvent{
+		thingID:   thingID,
+		keyID:     k.ID,
+		name:      k.Name,
+		enabled:   &k.Enabled,
+		expiresAt: k.ExpiresAt,
+		operation: thingKeyCreate,
+	}
+	es.add(ctx, rec.Actor, event)
+
+	return k, nil
+}
+
+func (es eventStore) ListThingKeys(ctx context.Context, token, thingID string, pm things.PageMetadata) (things.ThingKeysPage, error) {
+	return es.svc.ListThingKeys(ctx, token, thingID, pm)
+}
+
+func (es eventStore) UpdateThingKey(ctx context.Context, token, thingID string, key things.ThingKey) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.UpdateThingKey(ctx, token, thingID, key); err != nil {
+		return err
+	}
+
+	event := thingKeyEvent{
+		thingID:   thingID,
+		keyID:     key.ID,
+		name:      key.Name,
+		enabled:   &key.Enabled,
+		expiresAt: key.ExpiresAt,
+		operation: thingKeyUpdate,
+	}
+	es.add(ctx, rec.Actor, event)
+
+	return nil
+}
+
+func (es eventStore) RemoveThingKey(ctx context.Context, token, thingID, keyID string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.RemoveThingKey(ctx, token, thingID, keyID); err != nil {
+		return err
+	}
+
+	event := thingKeyEvent{
+		thingID:   thingID,
+		keyID:     keyID,
+		operation: thingKeyRemove,
+	}
+	es.add(ctx, rec.Actor, event)
+
+	return nil
 }
 
 func (es eventStore) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
@@ -106,7 +194,20 @@ func (es eventStore) Restore(ctx context.Context, token string, backup things.Ba
 	return es.svc.Restore(ctx, token, backup)
 }
 
+func (es eventStore) ExportBackup(ctx context.Context, token string, f things.BackupFilter) (backup.Stream, error) {
+	return es.svc.ExportBackup(ctx, token, f)
+}
+
+func (es eventStore) ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (backup.Summary, error) {
+	return es.svc.ImportBackup(ctx, token, dec, strategy)
+}
+
+func (es eventStore) ViewUsage(ctx context.Context, token, orgID string) (map[string]quota.Usage, error) {
+	return es.svc.ViewUsage(ctx, token, orgID)
+}
+
 func (es eventStore) RemoveThings(ctx context.Context, token string, ids ...string) error {
+	ctx, rec := things.WithRecord(ctx)
 	for _, id := range ids {
 		if err := es.svc.RemoveThings(ctx, token, id); err != nil {
 			return err
@@ -115,18 +216,70 @@ func (es eventStore) RemoveThings(ctx context.Context, token string, ids ...stri
 		event := removeThingEvent{
 			id: id,
 		}
-		record := &redis.XAddArgs{
-			Stream:       streamID,
-			MaxLenApprox: streamLen,
-			Values:       event.Encode(),
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
+}
+
+func (es eventStore) ListDeletedThings(ctx context.Context, token string, pm things.PageMetadata) (things.DeletedThingsPage, error) {
+	return es.svc.ListDeletedThings(ctx, token, pm)
+}
+
+func (es eventStore) RestoreThings(ctx context.Context, token string, ids ...string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.RestoreThings(ctx, token, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		event := restoreThingEvent{
+			id: id,
 		}
-		es.client.XAdd(ctx, record).Err()
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
+}
+
+func (es eventStore) AddThingTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.AddThingTags(ctx, token, tags, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		event := tagsEvent{
+			id:        id,
+			tags:      tags,
+			operation: thingTagsAdd,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
+}
+
+func (es eventStore) RemoveThingTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.RemoveThingTags(ctx, token, tags, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		event := tagsEvent{
+			id:        id,
+			tags:      tags,
+			operation: thingTagsRemove,
+		}
+		es.add(ctx, rec.Actor, event)
 	}
 
 	return nil
 }
 
 func (es eventStore) CreateChannels(ctx context.Context, token string, channels ...things.Channel) ([]things.Channel, error) {
+	ctx, rec := things.WithRecord(ctx)
 	schs, err := es.svc.CreateChannels(ctx, token, channels...)
 	if err != nil {
 		return schs, err
@@ -134,38 +287,32 @@ func (es eventStore) CreateChannels(ctx context.Context, token string, channels
 
 	for _, channel := range schs {
 		event := createChannelEvent{
-			id:       channel.ID,
-			owner:    channel.Owner,
-			name:     channel.Name,
-			metadata: channel.Metadata,
-		}
-		record := &redis.XAddArgs{
-			Stream:       streamID,
-			MaxLenApprox: streamLen,
-			Values:       event.Encode(),
+			id:        channel.ID,
+			owner:     channel.Owner,
+			name:      channel.Name,
+			metadata:  channel.Metadata,
+			profileID: channel.ProfileID,
 		}
-		es.client.XAdd(ctx, record).Err()
+		es.add(ctx, rec.Actor, event)
 	}
 
 	return schs, nil
 }
 
 func (es eventStore) UpdateChannel(ctx context.Context, token string, channel things.Channel) error {
+	ctx, rec := things.WithRecord(ctx)
 	if err := es.svc.UpdateChannel(ctx, token, channel); err != nil {
 		return err
 	}
 
+	before, _ := rec.Before.(things.Channel)
 	event := updateChannelEvent{
 		id:       channel.ID,
 		name:     channel.Name,
 		metadata: channel.Metadata,
+		before:   channelState(before),
 	}
-	record := &redis.XAddArgs{
-		Stream:       streamID,
-		MaxLenApprox: streamLen,
-		Values:       event.Encode(),
-	}
-	es.client.XAdd(ctx, record).Err()
+	es.add(ctx, rec.Actor, event)
 
 	return nil
 }
@@ -183,6 +330,7 @@ func (es eventStore) ViewChannelByThing(ctx context.Context, token, thID string)
 }
 
 func (es eventStore) RemoveChannels(ctx context.Context, token string, ids ...string) error {
+	ctx, rec := things.WithRecord(ctx)
 	for _, id := range ids {
 		if err := es.svc.RemoveChannels(ctx, token, id); err != nil {
 			return err
@@ -191,40 +339,157 @@ func (es eventStore) RemoveChannels(ctx context.Context, token string, ids ...st
 		event := removeChannelEvent{
 			id: id,
 		}
-		record := &redis.XAddArgs{
-			Stream:       streamID,
-			MaxLenApprox: streamLen,
-			Values:       event.Encode(),
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
+}
+
+func (es eventStore) ListDeletedChannels(ctx context.Context, token string, pm things.PageMetadata) (things.DeletedChannelsPage, error) {
+	return es.svc.ListDeletedChannels(ctx, token, pm)
+}
+
+func (es eventStore) RestoreChannels(ctx context.Context, token string, ids ...string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.RestoreChannels(ctx, token, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		event := restoreChannelEvent{
+			id: id,
 		}
-		es.client.XAdd(ctx, record).Err()
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
+}
+
+func (es eventStore) AddChannelTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.AddChannelTags(ctx, token, tags, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		event := tagsEvent{
+			id:        id,
+			tags:      tags,
+			operation: channelTagsAdd,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
+}
+
+func (es eventStore) RemoveChannelTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.RemoveChannelTags(ctx, token, tags, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		event := tagsEvent{
+			id:        id,
+			tags:      tags,
+			operation: channelTagsRemove,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
+}
+
+func (es eventStore) CreateProfiles(ctx context.Context, token string, profiles ...things.Profile) ([]things.Profile, error) {
+	ctx, rec := things.WithRecord(ctx)
+	sprs, err := es.svc.CreateProfiles(ctx, token, profiles...)
+	if err != nil {
+		return sprs, err
+	}
+
+	for _, pr := range sprs {
+		event := profileEvent{
+			id:        pr.ID,
+			ownerID:   pr.OwnerID,
+			name:      pr.Name,
+			operation: profileCreate,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return sprs, nil
+}
+
+func (es eventStore) UpdateProfile(ctx context.Context, token string, profile things.Profile) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.UpdateProfile(ctx, token, profile); err != nil {
+		return err
+	}
+
+	event := profileEvent{
+		id:        profile.ID,
+		name:      profile.Name,
+		operation: profileUpdate,
+	}
+	es.add(ctx, rec.Actor, event)
+
+	return nil
+}
+
+func (es eventStore) ViewProfile(ctx context.Context, token, id string) (things.Profile, error) {
+	return es.svc.ViewProfile(ctx, token, id)
+}
 
+func (es eventStore) ListProfiles(ctx context.Context, token string, pm things.PageMetadata) (things.ProfilesPage, error) {
+	return es.svc.ListProfiles(ctx, token, pm)
+}
+
+func (es eventStore) ListThingsByProfile(ctx context.Context, token, profileID string, pm things.PageMetadata) (things.Page, error) {
+	return es.svc.ListThingsByProfile(ctx, token, profileID, pm)
+}
+
+func (es eventStore) RemoveProfiles(ctx context.Context, token string, ids ...string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.RemoveProfiles(ctx, token, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		event := profileEvent{
+			id:        id,
+			operation: profileRemove,
+		}
+		es.add(ctx, rec.Actor, event)
 	}
 
 	return nil
 }
 
-func (es eventStore) Connect(ctx context.Context, token, chID string, thIDs []string) error {
-	if err := es.svc.Connect(ctx, token, chID, thIDs); err != nil {
+func (es eventStore) Connect(ctx context.Context, token, chID string, thIDs []string, connType string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.Connect(ctx, token, chID, thIDs, connType); err != nil {
 		return err
 	}
 
+	if connType == "" {
+		connType = things.ConnTypePubSub
+	}
+
 	for _, thID := range thIDs {
 		event := connectThingEvent{
-			chanID:  chID,
-			thingID: thID,
+			chanID:   chID,
+			thingID:  thID,
+			connType: connType,
 		}
-		record := &redis.XAddArgs{
-			Stream:       streamID,
-			MaxLenApprox: streamLen,
-			Values:       event.Encode(),
-		}
-		es.client.XAdd(ctx, record).Err()
+		es.add(ctx, rec.Actor, event)
 	}
 
 	return nil
 }
 
 func (es eventStore) Disconnect(ctx context.Context, token, chID string, thIDs []string) error {
+	ctx, rec := things.WithRecord(ctx)
 	if err := es.svc.Disconnect(ctx, token, chID, thIDs); err != nil {
 		return err
 	}
@@ -234,29 +499,40 @@ func (es eventStore) Disconnect(ctx context.Context, token, chID string, thIDs [
 			chanID:  chID,
 			thingID: thID,
 		}
-		record := &redis.XAddArgs{
-			Stream:       streamID,
-			MaxLenApprox: streamLen,
-			Values:       event.Encode(),
-		}
-		es.client.XAdd(ctx, record).Err()
+		es.add(ctx, rec.Actor, event)
 	}
 
 	return nil
 }
 
-func (es eventStore) GetConnByKey(ctx context.Context, key string) (things.Connection, error) {
-	return es.svc.GetConnByKey(ctx, key)
+func (es eventStore) CanAccessByKey(ctx context.Context, chanID, key, action string) (string, error) {
+	return es.svc.CanAccessByKey(ctx, chanID, key, action)
 }
 
 func (es eventStore) IsChannelOwner(ctx context.Context, owner, chanID string) error {
 	return es.svc.IsChannelOwner(ctx, owner, chanID)
 }
 
+func (es eventStore) IsThingOwner(ctx context.Context, owner, thingID string) error {
+	return es.svc.IsThingOwner(ctx, owner, thingID)
+}
+
+func (es eventStore) CanReadChannel(ctx context.Context, token, chanID, permission string) error {
+	return es.svc.CanReadChannel(ctx, token, chanID, permission)
+}
+
 func (es eventStore) Identify(ctx context.Context, key string) (string, error) {
 	return es.svc.Identify(ctx, key)
 }
 
+func (es eventStore) GetChannelSchema(ctx context.Context, chanID string) (schema.Schema, error) {
+	return es.svc.GetChannelSchema(ctx, chanID)
+}
+
+func (es eventStore) GetOSCORESecret(ctx context.Context, thingID string) ([]byte, error) {
+	return es.svc.GetOSCORESecret(ctx, thingID)
+}
+
 func (es eventStore) ListGroupThings(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.GroupThingsPage, error) {
 	return es.svc.ListGroupThings(ctx, token, groupID, pm)
 }
@@ -266,7 +542,25 @@ func (es eventStore) ListGroupThingsByChannel(ctx context.Context, token, grID,
 }
 
 func (es eventStore) CreateGroups(ctx context.Context, token string, grs ...things.Group) ([]things.Group, error) {
-	return es.svc.CreateGroups(ctx, token, grs...)
+	ctx, rec := things.WithRecord(ctx)
+	sgrs, err := es.svc.CreateGroups(ctx, token, grs...)
+	if err != nil {
+		return sgrs, err
+	}
+
+	for _, group := range sgrs {
+		event := createGroupEvent{
+			id:          group.ID,
+			owner:       group.OwnerID,
+			parentID:    group.ParentID,
+			name:        group.Name,
+			description: group.Description,
+			metadata:    group.Metadata,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return sgrs, nil
 }
 
 func (es eventStore) ListGroups(ctx context.Context, token string, admin bool, pm things.PageMetadata) (things.GroupPage, error) {
@@ -277,12 +571,119 @@ func (es eventStore) ListGroupsByIDs(ctx context.Context, groupIDs []string) ([]
 	return es.svc.ListGroupsByIDs(ctx, groupIDs)
 }
 
+func (es eventStore) ListGroupChildren(ctx context.Context, token, groupID string, depth uint64, pm things.PageMetadata) (things.GroupPage, error) {
+	return es.svc.ListGroupChildren(ctx, token, groupID, depth, pm)
+}
+
+func (es eventStore) MoveGroup(ctx context.Context, token, groupID, parentID string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.MoveGroup(ctx, token, groupID, parentID); err != nil {
+		return err
+	}
+
+	event := moveGroupEvent{
+		id:       groupID,
+		parentID: parentID,
+	}
+	es.add(ctx, rec.Actor, event)
+
+	return nil
+}
+
 func (es eventStore) RemoveGroups(ctx context.Context, token string, ids ...string) error {
-	return es.svc.RemoveGroups(ctx, token, ids...)
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.RemoveGroups(ctx, token, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		event := removeGroupEvent{
+			id: id,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
+}
+
+func (es eventStore) ListDeletedGroups(ctx context.Context, token string, pm things.PageMetadata) (things.DeletedGroupsPage, error) {
+	return es.svc.ListDeletedGroups(ctx, token, pm)
+}
+
+func (es eventStore) RestoreGroups(ctx context.Context, token string, ids ...string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.RestoreGroups(ctx, token, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		event := restoreGroupEvent{
+			id: id,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
+}
+
+func (es eventStore) AddGroupTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.AddGroupTags(ctx, token, tags, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		event := tagsEvent{
+			id:        id,
+			tags:      tags,
+			operation: groupTagsAdd,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
+}
+
+func (es eventStore) RemoveGroupTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.RemoveGroupTags(ctx, token, tags, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		event := tagsEvent{
+			id:        id,
+			tags:      tags,
+			operation: groupTagsRemove,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
+}
+
+func (es eventStore) PurgeTrash(ctx context.Context, before time.Time) error {
+	return es.svc.PurgeTrash(ctx, before)
 }
 
 func (es eventStore) UpdateGroup(ctx context.Context, token string, group things.Group) (things.Group, error) {
-	return es.svc.UpdateGroup(ctx, token, group)
+	ctx, rec := things.WithRecord(ctx)
+	ugr, err := es.svc.UpdateGroup(ctx, token, group)
+	if err != nil {
+		return ugr, err
+	}
+
+	before, _ := rec.Before.(things.Group)
+	event := updateGroupEvent{
+		id:          ugr.ID,
+		name:        ugr.Name,
+		description: ugr.Description,
+		metadata:    ugr.Metadata,
+		before:      groupState(before),
+	}
+	es.add(ctx, rec.Actor, event)
+
+	return ugr, nil
 }
 
 func (es eventStore) ViewGroup(ctx context.Context, token, id string) (things.Group, error) {
@@ -290,11 +691,41 @@ func (es eventStore) ViewGroup(ctx context.Context, token, id string) (things.Gr
 }
 
 func (es eventStore) AssignThing(ctx context.Context, token string, groupID string, thingIDs ...string) error {
-	return es.svc.AssignThing(ctx, token, groupID, thingIDs...)
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.AssignThing(ctx, token, groupID, thingIDs...); err != nil {
+		return err
+	}
+
+	for _, memberID := range thingIDs {
+		event := groupMemberEvent{
+			groupID:   groupID,
+			memberID:  memberID,
+			memberKey: "thing_id",
+			operation: groupAssignThing,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
 }
 
 func (es eventStore) UnassignThing(ctx context.Context, token string, groupID string, thingIDs ...string) error {
-	return es.svc.UnassignThing(ctx, token, groupID, thingIDs...)
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.UnassignThing(ctx, token, groupID, thingIDs...); err != nil {
+		return err
+	}
+
+	for _, memberID := range thingIDs {
+		event := groupMemberEvent{
+			groupID:   groupID,
+			memberID:  memberID,
+			memberKey: "thing_id",
+			operation: groupUnassignThing,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
 }
 
 func (es eventStore) ViewThingMembership(ctx context.Context, token string, thingID string) (things.Group, error) {
@@ -302,11 +733,41 @@ func (es eventStore) ViewThingMembership(ctx context.Context, token string, thin
 }
 
 func (es eventStore) AssignChannel(ctx context.Context, token string, groupID string, channelIDs ...string) error {
-	return es.svc.AssignChannel(ctx, token, groupID, channelIDs...)
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.AssignChannel(ctx, token, groupID, channelIDs...); err != nil {
+		return err
+	}
+
+	for _, memberID := range channelIDs {
+		event := groupMemberEvent{
+			groupID:   groupID,
+			memberID:  memberID,
+			memberKey: "chan_id",
+			operation: groupAssignChannel,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
 }
 
 func (es eventStore) UnassignChannel(ctx context.Context, token string, groupID string, channelIDs ...string) error {
-	return es.svc.UnassignChannel(ctx, token, groupID, channelIDs...)
+	ctx, rec := things.WithRecord(ctx)
+	if err := es.svc.UnassignChannel(ctx, token, groupID, channelIDs...); err != nil {
+		return err
+	}
+
+	for _, memberID := range channelIDs {
+		event := groupMemberEvent{
+			groupID:   groupID,
+			memberID:  memberID,
+			memberKey: "chan_id",
+			operation: groupUnassignChannel,
+		}
+		es.add(ctx, rec.Actor, event)
+	}
+
+	return nil
 }
 
 func (es eventStore) ListGroupChannels(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.GroupChannelsPage, error) {
@@ -316,3 +777,20 @@ func (es eventStore) ListGroupChannels(ctx context.Context, token, groupID strin
 func (es eventStore) ViewChannelMembership(ctx context.Context, token string, channelID string) (things.Group, error) {
 	return es.svc.ViewChannelMembership(ctx, token, channelID)
 }
+
+func (es eventStore) add(ctx context.Context, actor string, ev event) {
+	values := ev.Encode()
+	if actor != "" {
+		values["actor"] = actor
+	}
+	if ip := auth.ClientIP(ctx); ip != "" {
+		values["ip"] = ip
+	}
+
+	record := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       values,
+	}
+	es.client.XAdd(ctx, record).Err()
+}
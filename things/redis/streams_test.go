@@ -47,19 +47,22 @@ var (
 	admin     = users.User{Email: adminEmail, Password: password}
 	usersList = []users.User{admin, user}
 	group     = things.Group{Name: "test-group", Description: "test-group-desc"}
+	authSvc   = mocks.NewAuthService("", usersList)
 )
 
 func newService(tokens map[string]string) things.Service {
-	auth := mocks.NewAuthService("", usersList)
+	auth := authSvc
 	conns := make(chan thmocks.Connection)
 	thingsRepo := thmocks.NewThingRepository(conns)
+	thingKeysRepo := thmocks.NewThingKeyRepository()
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
 	groupsRepo := thmocks.NewGroupRepository()
+	profilesRepo := thmocks.NewProfileRepository()
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, thingKeysRepo, channelsRepo, groupsRepo, profilesRepo, chanCache, thingCache, idProvider)
 }
 
 func TestCreateThings(t *testing.T) {
@@ -154,6 +157,7 @@ func TestUpdateThing(t *testing.T) {
 				"id":        sth.ID,
 				"name":      "a",
 				"metadata":  "{\"test\":\"test\"}",
+				"before":    "{\"metadata\":{\"test\":\"test\"},\"name\":\"a\"}",
 				"operation": thingUpdate,
 			},
 		},
@@ -234,7 +238,7 @@ func TestListThingsByChannel(t *testing.T) {
 	err = svc.AssignChannel(context.Background(), token, gr.ID, sch.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, sch.ID, []string{sth.ID})
+	err = svc.Connect(context.Background(), token, sch.ID, []string{sth.ID}, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 
 	essvc := redis.NewEventStoreMiddleware(svc, redisClient)
@@ -391,6 +395,7 @@ func TestUpdateChannel(t *testing.T) {
 				"id":        sch.ID,
 				"name":      "b",
 				"metadata":  "{\"test\":\"test\"}",
+				"before":    "{\"metadata\":null,\"name\":\"a\"}",
 				"operation": channelUpdate,
 			},
 		},
@@ -482,7 +487,7 @@ func TestListChannelsByThing(t *testing.T) {
 	err = svc.AssignChannel(context.Background(), token, gr.ID, sch.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, sch.ID, []string{sth.ID})
+	err = svc.Connect(context.Background(), token, sch.ID, []string{sth.ID}, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 
 	essvc := redis.NewEventStoreMiddleware(svc, redisClient)
@@ -592,6 +597,7 @@ func TestConnectEvent(t *testing.T) {
 			event: map[string]interface{}{
 				"chan_id":   sch.ID,
 				"thing_id":  sth.ID,
+				"conn_type": things.ConnTypePubSub,
 				"operation": thingConnect,
 			},
 		},
@@ -607,7 +613,7 @@ func TestConnectEvent(t *testing.T) {
 
 	lastID := "0"
 	for _, tc := range cases {
-		err := svc.Connect(context.Background(), tc.key, tc.chanID, []string{tc.thingID})
+		err := svc.Connect(context.Background(), tc.key, tc.chanID, []string{tc.thingID}, things.ConnTypePubSub)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 
 		streams := redisClient.XRead(context.Background(), &r.XReadArgs{
@@ -650,7 +656,7 @@ func TestDisconnectEvent(t *testing.T) {
 	err = svc.AssignChannel(context.Background(), token, gr.ID, sch.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, sch.ID, []string{sth.ID})
+	err = svc.Connect(context.Background(), token, sch.ID, []string{sth.ID}, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
 
 	svc = redis.NewEventStoreMiddleware(svc, redisClient)
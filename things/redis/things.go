@@ -13,8 +13,9 @@ import (
 )
 
 const (
-	keyPrefix = "thing_key"
-	idPrefix  = "thing"
+	keyPrefix  = "thing_key"
+	idPrefix   = "thing"
+	keysPrefix = "thing_keys"
 )
 
 var _ things.ThingCache = (*thingCache)(nil)
@@ -36,8 +37,10 @@ func (tc *thingCache) Save(ctx context.Context, thingKey string, thingID string)
 		return errors.Wrap(errors.ErrCreateEntity, err)
 	}
 
-	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
-	if err := tc.client.Set(ctx, tid, thingKey, 0).Err(); err != nil {
+	// Thing can be identified by multiple keys, so all the cached keys
+	// are tracked in order to evict them together.
+	tkeys := fmt.Sprintf("%s:%s", keysPrefix, thingID)
+	if err := tc.client.SAdd(ctx, tkeys, thingKey).Err(); err != nil {
 		return errors.Wrap(errors.ErrCreateEntity, err)
 	}
 	return nil
@@ -54,18 +57,30 @@ func (tc *thingCache) ID(ctx context.Context, thingKey string) (string, error) {
 }
 
 func (tc *thingCache) Remove(ctx context.Context, thingID string) error {
+	tkeys := fmt.Sprintf("%s:%s", keysPrefix, thingID)
+	keys, err := tc.client.SMembers(ctx, tkeys).Result()
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	// Entries cached before multiple keys were supported hold
+	// a single key under the thing ID.
 	tid := fmt.Sprintf("%s:%s", idPrefix, thingID)
 	key, err := tc.client.Get(ctx, tid).Result()
+	switch {
+	case err == nil:
+		keys = append(keys, key)
 	// Redis returns Nil Reply when key does not exist.
-	if err == redis.Nil {
-		return nil
-	}
-	if err != nil {
+	case err != redis.Nil:
 		return errors.Wrap(errors.ErrRemoveEntity, err)
 	}
 
-	tkey := fmt.Sprintf("%s:%s", keyPrefix, key)
-	if err := tc.client.Del(ctx, tkey, tid).Err(); err != nil {
+	entries := []string{tkeys, tid}
+	for _, k := range keys {
+		entries = append(entries, fmt.Sprintf("%s:%s", keyPrefix, k))
+	}
+
+	if err := tc.client.Del(ctx, entries...).Err(); err != nil {
 		return errors.Wrap(errors.ErrRemoveEntity, err)
 	}
 	return nil
@@ -120,3 +120,25 @@ func TestThingRemove(t *testing.T) {
 	}
 
 }
+
+func TestThingRemoveMultipleKeys(t *testing.T) {
+	thingCache := redis.NewThingCache(redisClient)
+
+	id := "456"
+	var keys []string
+	for i := 0; i < 3; i++ {
+		key, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		err = thingCache.Save(context.Background(), key, id)
+		require.Nil(t, err, fmt.Sprintf("Save thing to cache: expected nil got %s", err))
+		keys = append(keys, key)
+	}
+
+	err := thingCache.Remove(context.Background(), id)
+	require.Nil(t, err, fmt.Sprintf("Remove thing from cache: expected nil got %s", err))
+
+	for _, key := range keys {
+		_, err := thingCache.ID(context.Background(), key)
+		assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("expected %s got %s\n", errors.ErrNotFound, err))
+	}
+}
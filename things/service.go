@@ -5,11 +5,15 @@ package things
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 )
 
 // Service specifies an API that must be fullfiled by the domain service
@@ -26,6 +30,26 @@ type Service interface {
 	// returned to indicate operation failure.
 	UpdateKey(ctx context.Context, token, id, key string) error
 
+	// RotateKey replaces the key of the existing thing and keeps the previous
+	// key valid for the given grace period, so that deployed devices can be
+	// reconfigured without being locked out.
+	RotateKey(ctx context.Context, token, id, key string, grace time.Duration) error
+
+	// CreateThingKey adds an additional key to the thing identified by the
+	// provided ID.
+	CreateThingKey(ctx context.Context, token, thingID string, key ThingKey) (ThingKey, error)
+
+	// ListThingKeys retrieves additional keys of the thing identified by the
+	// provided ID.
+	ListThingKeys(ctx context.Context, token, thingID string, pm PageMetadata) (ThingKeysPage, error)
+
+	// UpdateThingKey updates name, enabled flag and expiration time of the
+	// thing key.
+	UpdateThingKey(ctx context.Context, token, thingID string, key ThingKey) error
+
+	// RemoveThingKey removes the thing key identified by the provided ID.
+	RemoveThingKey(ctx context.Context, token, thingID, keyID string) error
+
 	// ViewThing retrieves data about the thing identified with the provided
 	// ID, that belongs to the user identified by the provided key.
 	ViewThing(ctx context.Context, token, id string) (Thing, error)
@@ -46,6 +70,21 @@ type Service interface {
 	// belongs to the user identified by the provided key.
 	RemoveThings(ctx context.Context, token string, id ...string) error
 
+	// ListDeletedThings retrieves data about subset of things in the trash
+	// that belong to the user identified by the provided key.
+	ListDeletedThings(ctx context.Context, token string, pm PageMetadata) (DeletedThingsPage, error)
+
+	// RestoreThings moves the things identified by the provided IDs out of
+	// the trash, together with their keys, connections and group membership.
+	RestoreThings(ctx context.Context, token string, ids ...string) error
+
+	// AddThingTags adds the tags to the things identified by the provided IDs.
+	AddThingTags(ctx context.Context, token string, tags []string, ids ...string) error
+
+	// RemoveThingTags removes the tags from the things identified by the
+	// provided IDs.
+	RemoveThingTags(ctx context.Context, token string, tags []string, ids ...string) error
+
 	// CreateChannels adds channels to the user identified by the provided key.
 	CreateChannels(ctx context.Context, token string, channels ...Channel) ([]Channel, error)
 
@@ -70,29 +109,105 @@ type Service interface {
 	// belongs to the user identified by the provided key.
 	RemoveChannels(ctx context.Context, token string, ids ...string) error
 
-	// Connect connects a list of things to a channel.
-	Connect(ctx context.Context, token, chID string, thIDs []string) error
+	// ListDeletedChannels retrieves data about subset of channels in the
+	// trash that belong to the user identified by the provided key.
+	ListDeletedChannels(ctx context.Context, token string, pm PageMetadata) (DeletedChannelsPage, error)
+
+	// RestoreChannels moves the channels identified by the provided IDs out
+	// of the trash, together with their connections and group membership.
+	RestoreChannels(ctx context.Context, token string, ids ...string) error
+
+	// AddChannelTags adds the tags to the channels identified by the provided IDs.
+	AddChannelTags(ctx context.Context, token string, tags []string, ids ...string) error
+
+	// RemoveChannelTags removes the tags from the channels identified by the
+	// provided IDs.
+	RemoveChannelTags(ctx context.Context, token string, tags []string, ids ...string) error
+
+	// CreateProfiles adds profiles to the user identified by the provided key.
+	CreateProfiles(ctx context.Context, token string, profiles ...Profile) ([]Profile, error)
+
+	// UpdateProfile updates the profile identified by the provided ID, that
+	// belongs to the user identified by the provided key. Things and channels
+	// already created from the profile are validated against the new schema
+	// only when they are updated.
+	UpdateProfile(ctx context.Context, token string, profile Profile) error
+
+	// ViewProfile retrieves data about the profile identified by the provided
+	// ID, that belongs to the user identified by the provided key.
+	ViewProfile(ctx context.Context, token, id string) (Profile, error)
+
+	// ListProfiles retrieves data about subset of profiles that belongs to the
+	// user identified by the provided key.
+	ListProfiles(ctx context.Context, token string, pm PageMetadata) (ProfilesPage, error)
+
+	// ListThingsByProfile retrieves data about subset of things that are
+	// created from the profile identified by the provided ID.
+	ListThingsByProfile(ctx context.Context, token, profileID string, pm PageMetadata) (Page, error)
+
+	// RemoveProfiles removes the profiles identified by the provided IDs, that
+	// belongs to the user identified by the provided key.
+	RemoveProfiles(ctx context.Context, token string, ids ...string) error
+
+	// Connect connects a list of things to a channel using the given
+	// connection type. Empty type connects the things for both publishing
+	// and subscribing.
+	Connect(ctx context.Context, token, chID string, thIDs []string, connType string) error
 
 	// Disconnect disconnects a list of things from a channel.
 	Disconnect(ctx context.Context, token, chID string, thIDs []string) error
 
-	// GetConnByKey determines whether the channel can be accessed using the
-	// provided key and returns thing's id if access is allowed.
-	GetConnByKey(ctx context.Context, key string) (Connection, error)
+	// CanAccessByKey determines whether the thing identified by the provided
+	// key is allowed to perform the given action on the channel and returns
+	// thing's id if access is allowed.
+	CanAccessByKey(ctx context.Context, chanID, key, action string) (string, error)
 
 	// IsChannelOwner determines whether the channel can be accessed by
 	// the given user and returns error if it cannot.
 	IsChannelOwner(ctx context.Context, owner, chanID string) error
 
+	// IsThingOwner determines whether the thing is owned by the given user
+	// and returns error if it isn't.
+	IsThingOwner(ctx context.Context, owner, thingID string) error
+
+	// CanReadChannel determines whether the user identified by the provided
+	// token is allowed to access messages of the channel, either as the channel
+	// owner or through the given org permission on the channel group. An empty
+	// permission stands for the read_messages permission.
+	CanReadChannel(ctx context.Context, token, chanID, permission string) error
+
 	// Identify returns thing ID for given thing key.
 	Identify(ctx context.Context, key string) (string, error)
 
+	// GetChannelSchema returns the payload schema of the channel identified
+	// by the provided ID.
+	GetChannelSchema(ctx context.Context, chanID string) (schema.Schema, error)
+
+	// GetOSCORESecret returns the OSCORE master secret of the thing identified
+	// by the provided ID. The secret is derived from the thing key, which never
+	// leaves the service.
+	GetOSCORESecret(ctx context.Context, thingID string) ([]byte, error)
+
 	// Backup retrieves all things, channels and connections for all users. Only accessible by admin.
 	Backup(ctx context.Context, token string) (Backup, error)
 
 	// Restore adds things, channels and connections from a backup. Only accessible by admin.
 	Restore(ctx context.Context, token string, backup Backup) error
 
+	// ExportBackup returns a stream of the entities matching the filter.
+	// Full backups are only accessible by admin, while backups of a group
+	// are also accessible by the users that can manage the group.
+	ExportBackup(ctx context.Context, token string, f BackupFilter) (backup.Stream, error)
+
+	// ImportBackup restores the entities read from the decoder, resolving
+	// conflicts with the existing entities according to the strategy.
+	ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (backup.Summary, error)
+
+	// ViewUsage retrieves the number of things, channels and groups owned by
+	// the user or, if orgID is provided, the things and channels of the org,
+	// along with their limits.
+	ViewUsage(ctx context.Context, token, orgID string) (map[string]quota.Usage, error)
+
 	// CreateGroups adds groups to the user identified by the provided key.
 	CreateGroups(ctx context.Context, token string, groups ...Group) ([]Group, error)
 
@@ -108,6 +223,14 @@ type Service interface {
 	// ListGroupsByIDs retrieves groups by their IDs.
 	ListGroupsByIDs(ctx context.Context, ids []string) ([]Group, error)
 
+	// ListGroupChildren retrieves page of descendants of the group identified by groupID
+	// that are at most depth levels below it.
+	ListGroupChildren(ctx context.Context, token, groupID string, depth uint64, pm PageMetadata) (GroupPage, error)
+
+	// MoveGroup moves the group identified by groupID, together with its descendants,
+	// under the group identified by parentID. Empty parentID makes the group a root group.
+	MoveGroup(ctx context.Context, token, groupID, parentID string) error
+
 	// ListGroupThings retrieves page of things that are assigned to a group identified by groupID.
 	ListGroupThings(ctx context.Context, token string, groupID string, pm PageMetadata) (GroupThingsPage, error)
 
@@ -120,6 +243,26 @@ type Service interface {
 	// RemoveGroups removes the groups identified with the provided IDs.
 	RemoveGroups(ctx context.Context, token string, ids ...string) error
 
+	// ListDeletedGroups retrieves data about subset of groups in the trash
+	// that belong to the user identified by the provided key.
+	ListDeletedGroups(ctx context.Context, token string, pm PageMetadata) (DeletedGroupsPage, error)
+
+	// RestoreGroups moves the groups identified by the provided IDs out of
+	// the trash, together with their members. Connections removed along
+	// with the groups are not restored.
+	RestoreGroups(ctx context.Context, token string, ids ...string) error
+
+	// AddGroupTags adds the tags to the groups identified by the provided IDs.
+	AddGroupTags(ctx context.Context, token string, tags []string, ids ...string) error
+
+	// RemoveGroupTags removes the tags from the groups identified by the
+	// provided IDs.
+	RemoveGroupTags(ctx context.Context, token string, tags []string, ids ...string) error
+
+	// PurgeTrash permanently removes things, channels and groups moved to
+	// the trash before the provided time.
+	PurgeTrash(ctx context.Context, before time.Time) error
+
 	// AssignThing adds a thing with thingID into the group identified by groupID.
 	AssignThing(ctx context.Context, token, groupID string, thingIDs ...string) error
 
@@ -149,12 +292,24 @@ type PageMetadata struct {
 	Dir          string                 `json:"dir,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
 	Disconnected bool                   // Used for connected or disconnected lists
+	Filters      []MetadataFilter       `json:"filters,omitempty"`
+	Created      TimeRange              `json:"created,omitempty"`
+	Updated      TimeRange              `json:"updated,omitempty"`
+	GroupID      string                 `json:"group_id,omitempty"`
+	Connected    *bool                  `json:"connected,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`
+	// IDs limits the page to the entities with the given IDs and, in case
+	// of groups, to their descendants. It is set by the service to apply
+	// the resource restrictions of API keys.
+	IDs []string `json:"-"`
 }
 
 type Backup struct {
 	Things                []Thing
+	ThingKeys             []ThingKey
 	Channels              []Channel
 	Connections           []Connection
+	Profiles              []Profile
 	Groups                []Group
 	GroupThingRelations   []GroupThingRelation
 	GroupChannelRelations []GroupChannelRelation
@@ -165,31 +320,49 @@ var _ Service = (*thingsService)(nil)
 type thingsService struct {
 	auth         mainflux.AuthServiceClient
 	things       ThingRepository
+	thingKeys    ThingKeyRepository
 	channels     ChannelRepository
 	groups       GroupRepository
+	profiles     ProfileRepository
 	channelCache ChannelCache
 	thingCache   ThingCache
 	idProvider   mainflux.IDProvider
+	quotaLocks   *quotaLocks
 }
 
 // New instantiates the things service implementation.
-func New(auth mainflux.AuthServiceClient, things ThingRepository, channels ChannelRepository, groups GroupRepository, ccache ChannelCache, tcache ThingCache, idp mainflux.IDProvider) Service {
+func New(auth mainflux.AuthServiceClient, things ThingRepository, thingKeys ThingKeyRepository, channels ChannelRepository, groups GroupRepository, profiles ProfileRepository, ccache ChannelCache, tcache ThingCache, idp mainflux.IDProvider) Service {
 	return &thingsService{
 		auth:         auth,
 		things:       things,
+		thingKeys:    thingKeys,
 		channels:     channels,
 		groups:       groups,
+		profiles:     profiles,
 		channelCache: ccache,
 		thingCache:   tcache,
 		idProvider:   idp,
+		quotaLocks:   newQuotaLocks(),
 	}
 }
 
 func (ts *thingsService) CreateThings(ctx context.Context, token string, things ...Thing) ([]Thing, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
+	if err != nil {
+		return []Thing{}, err
+	}
+
+	// Things are created outside of any group, so the API keys
+	// restricted to groups or orgs can't create them.
+	if isGroupRestricted(res) {
+		return []Thing{}, errors.ErrAuthorization
+	}
+
+	release, err := ts.reserveUserQuota(ctx, res.GetId(), quota.Things, len(things))
 	if err != nil {
 		return []Thing{}, err
 	}
+	defer release()
 
 	ths := []Thing{}
 	for _, thing := range things {
@@ -224,6 +397,16 @@ func (ts *thingsService) createThing(ctx context.Context, thing *Thing, identity
 		thing.Key = key
 	}
 
+	md, err := ts.applyProfile(ctx, thing.Owner, thing.ProfileID, thing.Metadata)
+	if err != nil {
+		return Thing{}, err
+	}
+	thing.Metadata = md
+
+	if thing.Tags, err = NormalizeTags(thing.Tags); err != nil {
+		return Thing{}, err
+	}
+
 	ths, err := ts.things.Save(ctx, *thing)
 	if err != nil {
 		return Thing{}, err
@@ -235,322 +418,1009 @@ func (ts *thingsService) createThing(ctx context.Context, thing *Thing, identity
 }
 
 func (ts *thingsService) UpdateThing(ctx context.Context, token string, thing Thing) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
 	if err != nil {
 		return err
 	}
 
-	if err := ts.isThingOwner(ctx, res.GetId(), thing.ID); err != nil {
+	th, err := ts.things.RetrieveByID(ctx, thing.ID)
+	if err != nil {
 		return err
 	}
 
-	thing.Owner = res.GetId()
+	if th.Owner != res.GetId() {
+		return errors.ErrNotFound
+	}
 
-	return ts.things.Update(ctx, thing)
-}
+	if err := ts.checkThingScope(ctx, res, thing.ID); err != nil {
+		return err
+	}
+	recordBefore(ctx, th)
 
-func (ts *thingsService) UpdateKey(ctx context.Context, token, id, key string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	md, err := ts.applyProfile(ctx, th.Owner, th.ProfileID, thing.Metadata)
 	if err != nil {
-		return errors.Wrap(errors.ErrAuthentication, err)
+		return err
 	}
 
-	owner := res.GetId()
+	thing.Owner = th.Owner
+	thing.ProfileID = th.ProfileID
+	thing.Metadata = md
+
+	// Tags are kept unless the update provides them.
+	if thing.Tags == nil {
+		thing.Tags = th.Tags
+	}
+	if thing.Tags, err = NormalizeTags(thing.Tags); err != nil {
+		return err
+	}
 
-	return ts.things.UpdateKey(ctx, owner, id, key)
+	return ts.things.Update(ctx, thing)
 }
 
-func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing, error) {
-	thing, err := ts.things.RetrieveByID(ctx, id)
+func (ts *thingsService) UpdateKey(ctx context.Context, token, id, key string) error {
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
 	if err != nil {
-		return Thing{}, err
+		return err
 	}
 
-	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
-		return thing, nil
+	if err := ts.checkThingScope(ctx, res, id); err != nil {
+		return err
 	}
 
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
-	if err != nil {
-		return Thing{}, errors.Wrap(errors.ErrAuthentication, err)
+	if _, err := ts.thingKeys.RetrieveByKey(ctx, key); err == nil {
+		return errors.ErrConflict
 	}
 
-	if thing.Owner == res.GetId() {
-		return thing, nil
+	if err := ts.things.UpdateKey(ctx, res.GetId(), id, key); err != nil {
+		return err
 	}
 
-	groupID, err := ts.groups.RetrieveThingMembership(ctx, id)
+	return ts.thingCache.Remove(ctx, id)
+}
+
+func (ts *thingsService) RotateKey(ctx context.Context, token, id, key string, grace time.Duration) error {
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
 	if err != nil {
-		return Thing{}, errors.ErrAuthorization
+		return err
 	}
 
-	if _, err = ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction}); err == nil {
-		return thing, nil
+	th, err := ts.things.RetrieveByID(ctx, id)
+	if err != nil {
+		return err
 	}
 
-	return Thing{}, errors.ErrAuthorization
-}
+	if th.Owner != res.GetId() {
+		return errors.ErrNotFound
+	}
 
-func (ts *thingsService) ListThings(ctx context.Context, token string, admin bool, pm PageMetadata) (Page, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
-	if err != nil {
-		return Page{}, errors.Wrap(errors.ErrAuthentication, err)
+	if err := ts.checkThingScope(ctx, res, id); err != nil {
+		return err
 	}
 
-	if admin {
-		if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
-			return ts.things.RetrieveByAdmin(ctx, pm)
-		}
+	if _, err := ts.thingKeys.RetrieveByKey(ctx, key); err == nil {
+		return errors.ErrConflict
 	}
 
-	return ts.things.RetrieveByOwner(ctx, res.GetId(), pm)
-}
+	if grace <= 0 {
+		if err := ts.things.UpdateKey(ctx, th.Owner, id, key); err != nil {
+			return err
+		}
+		return ts.thingCache.Remove(ctx, id)
+	}
 
-func (ts *thingsService) ListThingsByIDs(ctx context.Context, ids []string) (Page, error) {
-	things, err := ts.things.RetrieveByIDs(ctx, ids, PageMetadata{})
+	keyID, err := ts.idProvider.ID()
 	if err != nil {
-		return Page{}, err
+		return err
 	}
-	return things, nil
-}
 
-func (ts *thingsService) ListThingsByChannel(ctx context.Context, token, chID string, pm PageMetadata) (Page, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
-	if err != nil {
-		return Page{}, errors.Wrap(errors.ErrAuthentication, err)
+	now := getTimestmap()
+	rotated := ThingKey{
+		ID:        keyID,
+		ThingID:   id,
+		Name:      RotatedKeyName,
+		Key:       th.Key,
+		Enabled:   true,
+		CreatedAt: now,
+		ExpiresAt: now.Add(grace),
+	}
+	if _, err := ts.thingKeys.Save(ctx, rotated); err != nil {
+		return err
 	}
 
-	return ts.things.RetrieveByChannel(ctx, res.GetId(), chID, pm)
+	if err := ts.things.UpdateKey(ctx, th.Owner, id, key); err != nil {
+		if errRm := ts.thingKeys.Remove(ctx, id, keyID); errRm != nil {
+			return errors.Wrap(err, errRm)
+		}
+		return err
+	}
+
+	return ts.thingCache.Remove(ctx, id)
 }
 
-func (ts *thingsService) RemoveThings(ctx context.Context, token string, ids ...string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) CreateThingKey(ctx context.Context, token, thingID string, key ThingKey) (ThingKey, error) {
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
 	if err != nil {
-		return errors.Wrap(errors.ErrAuthentication, err)
+		return ThingKey{}, err
 	}
 
-	for _, id := range ids {
-		if err := ts.thingCache.Remove(ctx, id); err != nil {
-			return err
-		}
+	if err := ts.isThingOwner(ctx, res.GetId(), thingID); err != nil {
+		return ThingKey{}, err
 	}
 
-	if err := ts.things.Remove(ctx, res.GetId(), ids...); err != nil {
-		return err
+	if err := ts.checkThingScope(ctx, res, thingID); err != nil {
+		return ThingKey{}, err
 	}
 
-	return nil
-}
-
-func (ts *thingsService) CreateChannels(ctx context.Context, token string, channels ...Channel) ([]Channel, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	id, err := ts.idProvider.ID()
 	if err != nil {
-		return []Channel{}, errors.Wrap(errors.ErrAuthentication, err)
+		return ThingKey{}, err
 	}
 
-	chs := []Channel{}
-	for _, channel := range channels {
-		ch, err := ts.createChannel(ctx, &channel, res)
-		if err != nil {
-			return []Channel{}, err
+	if key.Key == "" {
+		if key.Key, err = ts.idProvider.ID(); err != nil {
+			return ThingKey{}, err
 		}
-		chs = append(chs, ch)
 	}
-	return chs, nil
-}
 
-func (ts *thingsService) createChannel(ctx context.Context, channel *Channel, identity *mainflux.UserIdentity) (Channel, error) {
-	if channel.ID == "" {
-		chID, err := ts.idProvider.ID()
-		if err != nil {
-			return Channel{}, err
-		}
-		channel.ID = chID
+	if _, err := ts.things.RetrieveByKey(ctx, key.Key); err == nil {
+		return ThingKey{}, errors.ErrConflict
 	}
-	channel.Owner = identity.GetId()
 
-	chs, err := ts.channels.Save(ctx, *channel)
+	key.ID = id
+	key.ThingID = thingID
+	key.Enabled = true
+	key.CreatedAt = getTimestmap()
+
+	keys, err := ts.thingKeys.Save(ctx, key)
 	if err != nil {
-		return Channel{}, err
+		return ThingKey{}, err
 	}
-	if len(chs) == 0 {
-		return Channel{}, errors.ErrCreateEntity
+	if len(keys) == 0 {
+		return ThingKey{}, errors.ErrCreateEntity
 	}
 
-	return chs[0], nil
+	return keys[0], nil
 }
 
-func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channel Channel) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) ListThingKeys(ctx context.Context, token, thingID string, pm PageMetadata) (ThingKeysPage, error) {
+	res, err := ts.identify(ctx, token, auth.ThingsReadScope)
 	if err != nil {
-		return errors.Wrap(errors.ErrAuthentication, err)
+		return ThingKeysPage{}, err
 	}
 
-	channel.Owner = res.GetId()
-	return ts.channels.Update(ctx, channel)
+	if err := ts.isThingOwner(ctx, res.GetId(), thingID); err != nil {
+		return ThingKeysPage{}, err
+	}
+
+	if err := ts.checkThingScope(ctx, res, thingID); err != nil {
+		return ThingKeysPage{}, err
+	}
+
+	return ts.thingKeys.RetrieveByThing(ctx, thingID, pm)
 }
 
-func (ts *thingsService) ViewChannel(ctx context.Context, token, id string) (Channel, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) UpdateThingKey(ctx context.Context, token, thingID string, key ThingKey) error {
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
 	if err != nil {
-		return Channel{}, errors.Wrap(errors.ErrAuthentication, err)
+		return err
 	}
 
-	channel, err := ts.channels.RetrieveByID(ctx, id)
-	if err != nil {
-		return Channel{}, err
+	if err := ts.isThingOwner(ctx, res.GetId(), thingID); err != nil {
+		return err
 	}
 
-	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
-		return channel, nil
+	if err := ts.checkThingScope(ctx, res, thingID); err != nil {
+		return err
 	}
 
-	if channel.Owner != res.GetId() {
-		return Channel{}, errors.ErrAuthorization
+	key.ThingID = thingID
+	if err := ts.thingKeys.Update(ctx, key); err != nil {
+		return err
 	}
 
-	return channel, nil
+	// Cached keys of the thing are evicted, so that a disabled key
+	// stops being accepted immediately.
+	return ts.thingCache.Remove(ctx, thingID)
 }
 
-func (ts *thingsService) ListChannels(ctx context.Context, token string, admin bool, pm PageMetadata) (ChannelsPage, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) RemoveThingKey(ctx context.Context, token, thingID, keyID string) error {
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
 	if err != nil {
-		return ChannelsPage{}, errors.Wrap(errors.ErrAuthentication, err)
+		return err
 	}
 
-	if admin {
-		if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
-			return ts.channels.RetrieveByAdmin(ctx, pm)
-		}
+	if err := ts.isThingOwner(ctx, res.GetId(), thingID); err != nil {
+		return err
 	}
 
-	return ts.channels.RetrieveByOwner(ctx, res.GetId(), pm)
+	if err := ts.checkThingScope(ctx, res, thingID); err != nil {
+		return err
+	}
+
+	if err := ts.thingKeys.Remove(ctx, thingID, keyID); err != nil {
+		return err
+	}
+
+	return ts.thingCache.Remove(ctx, thingID)
 }
 
-func (ts *thingsService) ViewChannelByThing(ctx context.Context, token, thID string) (Channel, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing, error) {
+	thing, err := ts.things.RetrieveByID(ctx, id)
 	if err != nil {
-		return Channel{}, errors.Wrap(errors.ErrAuthentication, err)
+		return Thing{}, err
 	}
 
-	thing, err := ts.things.RetrieveByID(ctx, thID)
+	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+		return thing, nil
+	}
+
+	res, err := ts.identify(ctx, token, auth.ThingsReadScope)
 	if err != nil {
-		return Channel{}, err
+		return Thing{}, err
 	}
 
-	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
-		return ts.channels.RetrieveByThing(ctx, res.GetId(), thID)
+	if err := ts.checkThingScope(ctx, res, id); err != nil {
+		return Thing{}, err
 	}
 
 	if thing.Owner == res.GetId() {
-		return ts.channels.RetrieveByThing(ctx, res.GetId(), thID)
+		return thing, nil
 	}
 
-	groupID, err := ts.groups.RetrieveThingMembership(ctx, thID)
+	groupID, err := ts.groups.RetrieveThingMembership(ctx, id)
 	if err != nil {
-		return Channel{}, err
+		return Thing{}, errors.ErrAuthorization
 	}
 
 	if _, err = ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction}); err == nil {
-		return ts.channels.RetrieveByThing(ctx, res.GetId(), thID)
+		return thing, nil
 	}
 
-	return Channel{}, errors.ErrAuthorization
+	return Thing{}, errors.ErrAuthorization
 }
 
-func (ts *thingsService) RemoveChannels(ctx context.Context, token string, ids ...string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) ListThings(ctx context.Context, token string, admin bool, pm PageMetadata) (Page, error) {
+	res, err := ts.identify(ctx, token, auth.ThingsReadScope)
 	if err != nil {
-		return errors.Wrap(errors.ErrAuthentication, err)
+		return Page{}, err
 	}
 
-	for _, id := range ids {
-		if err := ts.channelCache.Remove(ctx, id); err != nil {
-			return err
+	if admin {
+		if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+			return ts.things.RetrieveByAdmin(ctx, pm)
 		}
 	}
 
-	return ts.channels.Remove(ctx, res.GetId(), ids...)
+	if pm.IDs, err = ts.scopeThingIDs(ctx, res); err != nil {
+		return Page{}, err
+	}
+
+	return ts.things.RetrieveByOwner(ctx, res.GetId(), pm)
 }
 
-func (ts *thingsService) Connect(ctx context.Context, token, chID string, thIDs []string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) ListThingsByIDs(ctx context.Context, ids []string) (Page, error) {
+	things, err := ts.things.RetrieveByIDs(ctx, ids, PageMetadata{})
 	if err != nil {
-		return errors.Wrap(errors.ErrAuthentication, err)
+		return Page{}, err
 	}
+	return things, nil
+}
 
-	for _, thID := range thIDs {
-		if err := ts.isThingOwner(ctx, res.GetId(), thID); err != nil {
-			return err
-		}
+func (ts *thingsService) ListThingsByChannel(ctx context.Context, token, chID string, pm PageMetadata) (Page, error) {
+	res, err := ts.identify(ctx, token, auth.ThingsReadScope)
+	if err != nil {
+		return Page{}, err
 	}
 
-	if err := ts.IsChannelOwner(ctx, res.GetId(), chID); err != nil {
-		return err
+	if !auth.HasResource(res.GetChannelIDs(), chID) {
+		return Page{}, errors.ErrAuthorization
 	}
 
-	cgrID, err := ts.groups.RetrieveChannelMembership(ctx, chID)
+	if pm.IDs, err = ts.scopeThingIDs(ctx, res); err != nil {
+		return Page{}, err
+	}
+
+	return ts.things.RetrieveByChannel(ctx, res.GetId(), chID, pm)
+}
+
+func (ts *thingsService) RemoveThings(ctx context.Context, token string, ids ...string) error {
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
 	if err != nil {
 		return err
 	}
 
-	if cgrID == "" {
-		return errors.ErrAuthorization
-	}
+	for _, id := range ids {
+		if err := ts.checkThingScope(ctx, res, id); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range ids {
+		if err := ts.thingCache.Remove(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	if err := ts.things.Remove(ctx, res.GetId(), ids...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (ts *thingsService) ListDeletedThings(ctx context.Context, token string, pm PageMetadata) (DeletedThingsPage, error) {
+	res, err := ts.identify(ctx, token, auth.ThingsReadScope)
+	if err != nil {
+		return DeletedThingsPage{}, err
+	}
+
+	// Things in the trash are no longer assigned to groups, so
+	// the API keys restricted to groups or orgs can't access them.
+	if isGroupRestricted(res) {
+		return DeletedThingsPage{}, errors.ErrAuthorization
+	}
+
+	return ts.things.RetrieveDeleted(ctx, res.GetId(), pm)
+}
+
+func (ts *thingsService) RestoreThings(ctx context.Context, token string, ids ...string) error {
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	if isGroupRestricted(res) {
+		return errors.ErrAuthorization
+	}
+
+	page, err := ts.things.RetrieveDeleted(ctx, res.GetId(), PageMetadata{})
+	if err != nil {
+		return err
+	}
+
+	restored := make(map[string]bool)
+	for _, id := range ids {
+		restored[id] = true
+	}
+
+	n, groups := 0, make(map[string]int)
+	for _, th := range page.Things {
+		if !restored[th.ID] {
+			continue
+		}
+		n++
+		if th.GroupID != "" {
+			groups[th.GroupID]++
+		}
+	}
+
+	release, err := ts.reserveRestoreQuota(ctx, res.GetId(), quota.Things, n, groups)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return ts.things.Restore(ctx, res.GetId(), ids...)
+}
+
+func (ts *thingsService) AddThingTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	return ts.updateThingTags(ctx, token, tags, true, ids...)
+}
+
+func (ts *thingsService) RemoveThingTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	return ts.updateThingTags(ctx, token, tags, false, ids...)
+}
+
+func (ts *thingsService) updateThingTags(ctx context.Context, token string, tags []string, add bool, ids ...string) error {
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := ts.checkThingScope(ctx, res, id); err != nil {
+			return err
+		}
+	}
+
+	if tags, err = NormalizeTags(tags); err != nil {
+		return err
+	}
+
+	if add {
+		return ts.things.AddTags(ctx, res.GetId(), tags, ids...)
+	}
+
+	return ts.things.RemoveTags(ctx, res.GetId(), tags, ids...)
+}
+
+func (ts *thingsService) CreateChannels(ctx context.Context, token string, channels ...Channel) ([]Channel, error) {
+	res, err := ts.identify(ctx, token, auth.ChannelsWriteScope)
+	if err != nil {
+		return []Channel{}, err
+	}
+
+	release, err := ts.reserveUserQuota(ctx, res.GetId(), quota.Channels, len(channels))
+	if err != nil {
+		return []Channel{}, err
+	}
+	defer release()
+
+	chs := []Channel{}
+	for _, channel := range channels {
+		ch, err := ts.createChannel(ctx, &channel, res)
+		if err != nil {
+			return []Channel{}, err
+		}
+		chs = append(chs, ch)
+	}
+	return chs, nil
+}
+
+func (ts *thingsService) createChannel(ctx context.Context, channel *Channel, identity *mainflux.UserIdentity) (Channel, error) {
+	if channel.ID == "" {
+		chID, err := ts.idProvider.ID()
+		if err != nil {
+			return Channel{}, err
+		}
+		channel.ID = chID
+	}
+	channel.Owner = identity.GetId()
+
+	if err := channel.PayloadSchema.Validate(); err != nil {
+		return Channel{}, err
+	}
+
+	md, err := ts.applyProfile(ctx, channel.Owner, channel.ProfileID, channel.Metadata)
+	if err != nil {
+		return Channel{}, err
+	}
+	channel.Metadata = md
+
+	if channel.Tags, err = NormalizeTags(channel.Tags); err != nil {
+		return Channel{}, err
+	}
+
+	chs, err := ts.channels.Save(ctx, *channel)
+	if err != nil {
+		return Channel{}, err
+	}
+	if len(chs) == 0 {
+		return Channel{}, errors.ErrCreateEntity
+	}
+
+	return chs[0], nil
+}
+
+func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channel Channel) error {
+	res, err := ts.identify(ctx, token, auth.ChannelsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	if !auth.HasResource(res.GetChannelIDs(), channel.ID) {
+		return errors.ErrAuthorization
+	}
+
+	ch, err := ts.channels.RetrieveByID(ctx, channel.ID)
+	if err != nil {
+		return err
+	}
+
+	if ch.Owner != res.GetId() {
+		return errors.ErrNotFound
+	}
+	recordBefore(ctx, ch)
+
+	if err := channel.PayloadSchema.Validate(); err != nil {
+		return err
+	}
+
+	md, err := ts.applyProfile(ctx, ch.Owner, ch.ProfileID, channel.Metadata)
+	if err != nil {
+		return err
+	}
+
+	channel.Owner = ch.Owner
+	channel.ProfileID = ch.ProfileID
+	channel.Metadata = md
+
+	// Tags are kept unless the update provides them.
+	if channel.Tags == nil {
+		channel.Tags = ch.Tags
+	}
+	if channel.Tags, err = NormalizeTags(channel.Tags); err != nil {
+		return err
+	}
+
+	return ts.channels.Update(ctx, channel)
+}
+
+func (ts *thingsService) ViewChannel(ctx context.Context, token, id string) (Channel, error) {
+	res, err := ts.identify(ctx, token, auth.ChannelsReadScope)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	if !auth.HasResource(res.GetChannelIDs(), id) {
+		return Channel{}, errors.ErrAuthorization
+	}
+
+	channel, err := ts.channels.RetrieveByID(ctx, id)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+		return channel, nil
+	}
+
+	if channel.Owner != res.GetId() {
+		return Channel{}, errors.ErrAuthorization
+	}
+
+	return channel, nil
+}
+
+func (ts *thingsService) ListChannels(ctx context.Context, token string, admin bool, pm PageMetadata) (ChannelsPage, error) {
+	res, err := ts.identify(ctx, token, auth.ChannelsReadScope)
+	if err != nil {
+		return ChannelsPage{}, err
+	}
+
+	if admin {
+		if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+			return ts.channels.RetrieveByAdmin(ctx, pm)
+		}
+	}
+
+	pm.IDs = res.GetChannelIDs()
+	return ts.channels.RetrieveByOwner(ctx, res.GetId(), pm)
+}
+
+func (ts *thingsService) ViewChannelByThing(ctx context.Context, token, thID string) (Channel, error) {
+	res, err := ts.identify(ctx, token, auth.ChannelsReadScope)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	thing, err := ts.things.RetrieveByID(ctx, thID)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+		return ts.channelByThing(ctx, res, thID)
+	}
+
+	if thing.Owner == res.GetId() {
+		return ts.channelByThing(ctx, res, thID)
+	}
+
+	groupID, err := ts.groups.RetrieveThingMembership(ctx, thID)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	if _, err = ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction}); err == nil {
+		return ts.channelByThing(ctx, res, thID)
+	}
+
+	return Channel{}, errors.ErrAuthorization
+}
+
+// channelByThing retrieves the channel the thing is connected to, unless the
+// API key of the user is restricted to other channels.
+func (ts *thingsService) channelByThing(ctx context.Context, user *mainflux.UserIdentity, thID string) (Channel, error) {
+	ch, err := ts.channels.RetrieveByThing(ctx, user.GetId(), thID)
+	if err != nil {
+		return Channel{}, err
+	}
+
+	if ch.ID != "" && !auth.HasResource(user.GetChannelIDs(), ch.ID) {
+		return Channel{}, errors.ErrAuthorization
+	}
+
+	return ch, nil
+}
+
+func (ts *thingsService) RemoveChannels(ctx context.Context, token string, ids ...string) error {
+	res, err := ts.identify(ctx, token, auth.ChannelsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if !auth.HasResource(res.GetChannelIDs(), id) {
+			return errors.ErrAuthorization
+		}
+	}
+
+	for _, id := range ids {
+		if err := ts.channelCache.Remove(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return ts.channels.Remove(ctx, res.GetId(), ids...)
+}
+
+func (ts *thingsService) ListDeletedChannels(ctx context.Context, token string, pm PageMetadata) (DeletedChannelsPage, error) {
+	res, err := ts.identify(ctx, token, auth.ChannelsReadScope)
+	if err != nil {
+		return DeletedChannelsPage{}, err
+	}
+
+	pm.IDs = res.GetChannelIDs()
+	return ts.channels.RetrieveDeleted(ctx, res.GetId(), pm)
+}
+
+func (ts *thingsService) RestoreChannels(ctx context.Context, token string, ids ...string) error {
+	res, err := ts.identify(ctx, token, auth.ChannelsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	restored := make(map[string]bool)
+	for _, id := range ids {
+		if !auth.HasResource(res.GetChannelIDs(), id) {
+			return errors.ErrAuthorization
+		}
+		restored[id] = true
+	}
+
+	page, err := ts.channels.RetrieveDeleted(ctx, res.GetId(), PageMetadata{})
+	if err != nil {
+		return err
+	}
+
+	n, groups := 0, make(map[string]int)
+	for _, ch := range page.Channels {
+		if !restored[ch.ID] {
+			continue
+		}
+		n++
+		if ch.GroupID != "" {
+			groups[ch.GroupID]++
+		}
+	}
+
+	release, err := ts.reserveRestoreQuota(ctx, res.GetId(), quota.Channels, n, groups)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return ts.channels.Restore(ctx, res.GetId(), ids...)
+}
+
+func (ts *thingsService) AddChannelTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	return ts.updateChannelTags(ctx, token, tags, true, ids...)
+}
+
+func (ts *thingsService) RemoveChannelTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	return ts.updateChannelTags(ctx, token, tags, false, ids...)
+}
+
+func (ts *thingsService) updateChannelTags(ctx context.Context, token string, tags []string, add bool, ids ...string) error {
+	res, err := ts.identify(ctx, token, auth.ChannelsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if !auth.HasResource(res.GetChannelIDs(), id) {
+			return errors.ErrAuthorization
+		}
+	}
+
+	if tags, err = NormalizeTags(tags); err != nil {
+		return err
+	}
+
+	if add {
+		return ts.channels.AddTags(ctx, res.GetId(), tags, ids...)
+	}
+
+	return ts.channels.RemoveTags(ctx, res.GetId(), tags, ids...)
+}
+
+func (ts *thingsService) CreateProfiles(ctx context.Context, token string, profiles ...Profile) ([]Profile, error) {
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
+	if err != nil {
+		return []Profile{}, err
+	}
+
+	timestamp := getTimestmap()
+
+	prs := []Profile{}
+	for _, pr := range profiles {
+		if err := pr.Validate(); err != nil {
+			return []Profile{}, err
+		}
+
+		id, err := ts.idProvider.ID()
+		if err != nil {
+			return []Profile{}, err
+		}
+
+		pr.ID = id
+		pr.OwnerID = res.GetId()
+		pr.CreatedAt = timestamp
+		pr.UpdatedAt = timestamp
+		prs = append(prs, pr)
+	}
+
+	return ts.profiles.Save(ctx, prs...)
+}
+
+func (ts *thingsService) UpdateProfile(ctx context.Context, token string, profile Profile) error {
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := ts.retrieveProfile(ctx, res.GetId(), profile.ID); err != nil {
+		return err
+	}
+
+	profile.OwnerID = res.GetId()
+	profile.UpdatedAt = getTimestmap()
+
+	return ts.profiles.Update(ctx, profile)
+}
+
+func (ts *thingsService) ViewProfile(ctx context.Context, token, id string) (Profile, error) {
+	res, err := ts.identify(ctx, token, auth.ThingsReadScope)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	return ts.retrieveProfile(ctx, res.GetId(), id)
+}
+
+func (ts *thingsService) ListProfiles(ctx context.Context, token string, pm PageMetadata) (ProfilesPage, error) {
+	res, err := ts.identify(ctx, token, auth.ThingsReadScope)
+	if err != nil {
+		return ProfilesPage{}, err
+	}
+
+	return ts.profiles.RetrieveByOwner(ctx, res.GetId(), pm)
+}
+
+func (ts *thingsService) ListThingsByProfile(ctx context.Context, token, profileID string, pm PageMetadata) (Page, error) {
+	res, err := ts.identify(ctx, token, auth.ThingsReadScope)
+	if err != nil {
+		return Page{}, err
+	}
+
+	if _, err := ts.retrieveProfile(ctx, res.GetId(), profileID); err != nil {
+		return Page{}, err
+	}
+
+	if pm.IDs, err = ts.scopeThingIDs(ctx, res); err != nil {
+		return Page{}, err
+	}
+
+	return ts.things.RetrieveByProfile(ctx, res.GetId(), profileID, pm)
+}
+
+func (ts *thingsService) RemoveProfiles(ctx context.Context, token string, ids ...string) error {
+	res, err := ts.identify(ctx, token, auth.ThingsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	return ts.profiles.Remove(ctx, res.GetId(), ids...)
+}
+
+// retrieveProfile retrieves the profile identified by the provided ID,
+// provided that it belongs to the given owner.
+func (ts *thingsService) retrieveProfile(ctx context.Context, owner, id string) (Profile, error) {
+	pr, err := ts.profiles.RetrieveByID(ctx, id)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	if pr.OwnerID != owner {
+		return Profile{}, errors.ErrNotFound
+	}
+
+	return pr, nil
+}
+
+// applyProfile fills the metadata with the defaults of the profile identified
+// by the provided ID and validates it against the profile schema. Metadata of
+// things and channels that aren't created from a profile is left intact.
+func (ts *thingsService) applyProfile(ctx context.Context, owner, profileID string, metadata map[string]interface{}) (map[string]interface{}, error) {
+	if profileID == "" {
+		return metadata, nil
+	}
+
+	pr, err := ts.retrieveProfile(ctx, owner, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pr.Apply(metadata)
+}
+
+func (ts *thingsService) Connect(ctx context.Context, token, chID string, thIDs []string, connType string) error {
+	if connType == "" {
+		connType = ConnTypePubSub
+	}
+
+	if err := ValidateConnType(connType); err != nil {
+		return err
+	}
+
+	res, err := ts.identify(ctx, token, auth.ChannelsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	if !auth.HasResource(res.GetChannelIDs(), chID) {
+		return errors.ErrAuthorization
+	}
+
+	for _, thID := range thIDs {
+		if err := ts.isThingOwner(ctx, res.GetId(), thID); err != nil {
+			return err
+		}
+	}
+
+	if err := ts.IsChannelOwner(ctx, res.GetId(), chID); err != nil {
+		return err
+	}
+
+	cgrID, err := ts.groups.RetrieveChannelMembership(ctx, chID)
+	if err != nil {
+		return err
+	}
+
+	if cgrID == "" {
+		return errors.ErrAuthorization
+	}
+
+	for _, thID := range thIDs {
+		tgrID, err := ts.groups.RetrieveThingMembership(ctx, thID)
+		if err != nil {
+			return err
+		}
+
+		if tgrID != cgrID {
+			return errors.ErrAuthorization
+		}
+	}
+
+	return ts.channels.Connect(ctx, res.GetId(), chID, thIDs, connType)
+}
+
+func (ts *thingsService) Disconnect(ctx context.Context, token, chID string, thIDs []string) error {
+	res, err := ts.identify(ctx, token, auth.ChannelsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	if !auth.HasResource(res.GetChannelIDs(), chID) {
+		return errors.ErrAuthorization
+	}
+
+	for _, thID := range thIDs {
+		if err := ts.channelCache.Disconnect(ctx, chID, thID); err != nil {
+			return err
+		}
+	}
+
+	return ts.channels.Disconnect(ctx, res.GetId(), chID, thIDs)
+}
+
+func (ts *thingsService) CanAccessByKey(ctx context.Context, chanID, key, action string) (string, error) {
+	if action != PublishAction && action != SubscribeAction {
+		return "", errors.ErrAuthorization
+	}
+
+	thID, err := ts.Identify(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	if ts.channelCache.CanAccess(ctx, chanID, thID, action) {
+		return thID, nil
+	}
+
+	conn, err := ts.channels.RetrieveConnection(ctx, chanID, thID)
+	if errors.Contains(err, errors.ErrNotFound) {
+		return "", errors.ErrAuthorization
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := ts.channelCache.Connect(ctx, chanID, thID, conn.Type); err != nil {
+		return "", err
+	}
+
+	if !conn.Allows(action) {
+		return "", errors.ErrAuthorization
+	}
+
+	return thID, nil
+}
 
-	for _, thID := range thIDs {
-		tgrID, err := ts.groups.RetrieveThingMembership(ctx, thID)
-		if err != nil {
-			return err
-		}
+func (ts *thingsService) IsChannelOwner(ctx context.Context, owner, chanID string) error {
+	ch, err := ts.channels.RetrieveByID(ctx, chanID)
+	if err != nil {
+		return err
+	}
 
-		if tgrID != cgrID {
-			return errors.ErrAuthorization
-		}
+	if ch.Owner != owner {
+		return errors.ErrAuthorization
 	}
 
-	return ts.channels.Connect(ctx, res.GetId(), chID, thIDs)
+	return nil
 }
 
-func (ts *thingsService) Disconnect(ctx context.Context, token, chID string, thIDs []string) error {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) IsThingOwner(ctx context.Context, owner, thingID string) error {
+	th, err := ts.things.RetrieveByID(ctx, thingID)
 	if err != nil {
-		return errors.Wrap(errors.ErrAuthentication, err)
+		return err
 	}
 
-	for _, thID := range thIDs {
-		if err := ts.channelCache.Disconnect(ctx, chID, thID); err != nil {
-			return err
-		}
+	if th.Owner != owner {
+		return errors.ErrAuthorization
 	}
 
-	return ts.channels.Disconnect(ctx, res.GetId(), chID, thIDs)
+	return nil
 }
 
-func (ts *thingsService) GetConnByKey(ctx context.Context, thingKey string) (Connection, error) {
-	conn, err := ts.channels.RetrieveConnByThingKey(ctx, thingKey)
+func (ts *thingsService) CanReadChannel(ctx context.Context, token, chanID, permission string) error {
+	if permission == "" {
+		permission = auth.ReadMessagesPermission
+	}
+
+	res, err := ts.identify(ctx, token, auth.MessagesReadScope)
 	if err != nil {
-		return Connection{}, err
+		return err
 	}
 
-	if err := ts.thingCache.Save(ctx, thingKey, conn.ThingID); err != nil {
-		return Connection{}, err
+	// Scoped keys are only issued for reading messages.
+	if permission != auth.ReadMessagesPermission && auth.IsScoped(res) {
+		return errors.ErrAuthorization
 	}
-	if err := ts.channelCache.Connect(ctx, conn.ChannelID, conn.ThingID); err != nil {
-		return Connection{}, err
+
+	if !auth.HasResource(res.GetChannelIDs(), chanID) {
+		return errors.ErrAuthorization
 	}
-	return Connection{ThingID: conn.ThingID, ChannelID: conn.ChannelID}, nil
-}
 
-func (ts *thingsService) IsChannelOwner(ctx context.Context, owner, chanID string) error {
 	ch, err := ts.channels.RetrieveByID(ctx, chanID)
 	if err != nil {
 		return err
 	}
 
-	if ch.Owner != owner {
+	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+		return nil
+	}
+
+	if ch.Owner == res.GetId() {
+		return nil
+	}
+
+	groupID, err := ts.groups.RetrieveChannelMembership(ctx, chanID)
+	if errors.Contains(err, errors.ErrNotFound) || (err == nil && groupID == "") {
 		return errors.ErrAuthorization
 	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: groupID, Action: permission}); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
 
 	return nil
 }
@@ -562,6 +1432,19 @@ func (ts *thingsService) Identify(ctx context.Context, key string) (string, erro
 	}
 
 	id, err = ts.things.RetrieveByKey(ctx, key)
+	if errors.Contains(err, errors.ErrNotFound) {
+		tk, kerr := ts.activeThingKey(ctx, key)
+		if kerr != nil {
+			return "", err
+		}
+
+		// Expiring keys are not cached, so that they stop being accepted
+		// as soon as they expire.
+		if !tk.ExpiresAt.IsZero() {
+			return tk.ThingID, nil
+		}
+		id, err = tk.ThingID, nil
+	}
 	if err != nil {
 		return "", err
 	}
@@ -572,6 +1455,39 @@ func (ts *thingsService) Identify(ctx context.Context, key string) (string, erro
 	return id, nil
 }
 
+func (ts *thingsService) GetChannelSchema(ctx context.Context, chanID string) (schema.Schema, error) {
+	ch, err := ts.channels.RetrieveByID(ctx, chanID)
+	if err != nil {
+		return schema.Schema{}, err
+	}
+
+	return ch.PayloadSchema, nil
+}
+
+func (ts *thingsService) GetOSCORESecret(ctx context.Context, thingID string) ([]byte, error) {
+	th, err := ts.things.RetrieveByID(ctx, thingID)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeriveOSCORESecret(th.ID, th.Key)
+}
+
+// activeThingKey retrieves the additional thing key having the given value,
+// provided that it is enabled and not expired.
+func (ts *thingsService) activeThingKey(ctx context.Context, key string) (ThingKey, error) {
+	tk, err := ts.thingKeys.RetrieveByKey(ctx, key)
+	if err != nil {
+		return ThingKey{}, err
+	}
+
+	if !tk.Active(time.Now()) {
+		return ThingKey{}, errors.ErrNotFound
+	}
+
+	return tk, nil
+}
+
 func (ts *thingsService) Backup(ctx context.Context, token string) (Backup, error) {
 	if err := ts.authorize(ctx, auth.RootSubject, token); err != nil {
 		return Backup{}, err
@@ -597,6 +1513,11 @@ func (ts *thingsService) Backup(ctx context.Context, token string) (Backup, erro
 		return Backup{}, err
 	}
 
+	thingKeys, err := ts.thingKeys.RetrieveAll(ctx)
+	if err != nil {
+		return Backup{}, err
+	}
+
 	channels, err := ts.channels.RetrieveAll(ctx)
 	if err != nil {
 		return Backup{}, err
@@ -607,10 +1528,17 @@ func (ts *thingsService) Backup(ctx context.Context, token string) (Backup, erro
 		return Backup{}, err
 	}
 
+	profiles, err := ts.profiles.RetrieveAll(ctx)
+	if err != nil {
+		return Backup{}, err
+	}
+
 	return Backup{
 		Things:                things,
+		ThingKeys:             thingKeys,
 		Channels:              channels,
 		Connections:           connections,
+		Profiles:              profiles,
 		Groups:                groups,
 		GroupThingRelations:   groupThingRelations,
 		GroupChannelRelations: groupChannelRelations,
@@ -622,7 +1550,13 @@ func (ts *thingsService) Restore(ctx context.Context, token string, backup Backu
 		return err
 	}
 
-	for _, group := range backup.Groups {
+	// Parents have to be restored before their children.
+	groups := append([]Group{}, backup.Groups...)
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].Path) < len(groups[j].Path)
+	})
+
+	for _, group := range groups {
 		if _, err := ts.groups.Save(ctx, group); err != nil {
 			return err
 		}
@@ -640,30 +1574,150 @@ func (ts *thingsService) Restore(ctx context.Context, token string, backup Backu
 		}
 	}
 
+	// Profiles have to be restored before the things and channels created from them.
+	if _, err := ts.profiles.Save(ctx, backup.Profiles...); err != nil {
+		return err
+	}
+
 	if _, err := ts.things.Save(ctx, backup.Things...); err != nil {
 		return err
 	}
 
+	if _, err := ts.thingKeys.Save(ctx, backup.ThingKeys...); err != nil {
+		return err
+	}
+
 	if _, err := ts.channels.Save(ctx, backup.Channels...); err != nil {
 		return err
 	}
 
 	for _, conn := range backup.Connections {
-		if err := ts.channels.Connect(ctx, conn.ThingOwner, conn.ChannelID, []string{conn.ThingID}); err != nil {
+		connType := conn.Type
+		if connType == "" {
+			connType = ConnTypePubSub
+		}
+		if err := ts.channels.Connect(ctx, conn.ThingOwner, conn.ChannelID, []string{conn.ThingID}, connType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ts *thingsService) ExportBackup(ctx context.Context, token string, f BackupFilter) (backup.Stream, error) {
+	if err := ts.authorizeBackup(ctx, token, f.GroupID); err != nil {
+		return nil, err
+	}
+
+	header := backup.Header{Service: BackupService}
+	if f.GroupID != "" {
+		header.Scope = &backup.Scope{Type: backup.ScopeGroup, ID: f.GroupID}
+	}
+	if !f.Since.IsZero() {
+		since := f.Since
+		header.Since = &since
+	}
+
+	bs, err := ts.collectBackup(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(enc *backup.Encoder) error {
+		if err := enc.WriteHeader(header); err != nil {
 			return err
 		}
+
+		return bs.encode(enc)
+	}, nil
+}
+
+func (ts *thingsService) ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (backup.Summary, error) {
+	if err := backup.ValidateStrategy(strategy); err != nil {
+		return backup.Summary{}, err
+	}
+
+	header, err := dec.Header()
+	if err != nil {
+		return backup.Summary{}, err
+	}
+	if header.Service != BackupService {
+		return backup.Summary{}, errors.Wrap(backup.ErrMalformedRecord, errors.New(header.Service))
+	}
+
+	var groupID string
+	if header.Scope != nil {
+		if header.Scope.Type != backup.ScopeGroup {
+			return backup.Summary{}, errors.Wrap(backup.ErrMalformedRecord, errors.New(header.Scope.Type))
+		}
+		groupID = header.Scope.ID
+	}
+
+	if err := ts.authorizeBackup(ctx, token, groupID); err != nil {
+		return backup.Summary{}, err
+	}
+
+	im := importer{ts: ts, strategy: strategy}
+	if groupID != "" && ts.authorize(ctx, auth.RootSubject, token) != nil {
+		user, err := ts.identify(ctx, token, auth.GroupsWriteScope)
+		if err != nil {
+			return backup.Summary{}, err
+		}
+
+		groups, err := ts.groups.RetrieveAll(ctx)
+		if err != nil {
+			return backup.Summary{}, err
+		}
+
+		im.userID = user.GetId()
+		im.groups = groupSubtree(groups, groupID)
+	}
+
+	return im.run(ctx, dec)
+}
+
+// authorizeBackup checks whether the user can back up and restore the
+// group identified by groupID or, if groupID is empty, all entities. Since
+// the backup contains the thing keys, both require write access to the group.
+func (ts *thingsService) authorizeBackup(ctx context.Context, token, groupID string) error {
+	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+		return nil
+	}
+	if groupID == "" {
+		return errors.ErrAuthorization
+	}
+
+	user, err := ts.identify(ctx, token, auth.GroupsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.checkGroupScope(ctx, user, groupID); err != nil {
+		return err
+	}
+
+	if err := ts.isGroupOwner(ctx, user.GetId(), groupID); err != nil {
+		if _, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: groupID, Action: auth.WriteAction}); err != nil {
+			return errors.Wrap(errors.ErrAuthorization, err)
+		}
 	}
 
 	return nil
 }
 
 func (ts *thingsService) CreateGroups(ctx context.Context, token string, groups ...Group) ([]Group, error) {
-	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	user, err := ts.identify(ctx, token, auth.GroupsWriteScope)
 	if err != nil {
-		return []Group{}, errors.Wrap(errors.ErrAuthentication, err)
+		return []Group{}, err
 	}
 
 	owner := user.GetId()
+	release, err := ts.reserveUserQuota(ctx, owner, quota.Groups, len(groups))
+	if err != nil {
+		return []Group{}, err
+	}
+	defer release()
+
 	timestamp := getTimestmap()
 
 	grs := []Group{}
@@ -672,6 +1726,21 @@ func (ts *thingsService) CreateGroups(ctx context.Context, token string, groups
 		group.CreatedAt = timestamp
 		group.UpdatedAt = timestamp
 
+		// API keys restricted to groups or orgs can only create their descendants.
+		if group.ParentID == "" && isGroupRestricted(user) {
+			return []Group{}, errors.ErrAuthorization
+		}
+
+		if group.ParentID != "" {
+			if err := ts.validateParent(ctx, owner, "", group.ParentID); err != nil {
+				return []Group{}, err
+			}
+
+			if err := ts.checkGroupScope(ctx, user, group.ParentID); err != nil {
+				return []Group{}, err
+			}
+		}
+
 		gr, err := ts.createGroup(ctx, group)
 		if err != nil {
 			return []Group{}, err
@@ -690,6 +1759,10 @@ func (ts *thingsService) createGroup(ctx context.Context, group Group) (Group, e
 	}
 	group.ID = id
 
+	if group.Tags, err = NormalizeTags(group.Tags); err != nil {
+		return Group{}, err
+	}
+
 	group, err = ts.groups.Save(ctx, group)
 	if err != nil {
 		return Group{}, err
@@ -699,7 +1772,7 @@ func (ts *thingsService) createGroup(ctx context.Context, group Group) (Group, e
 }
 
 func (ts *thingsService) ListGroups(ctx context.Context, token string, admin bool, pm PageMetadata) (GroupPage, error) {
-	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	user, err := ts.identify(ctx, token, auth.GroupsReadScope)
 	if err != nil {
 		return GroupPage{}, err
 	}
@@ -710,61 +1783,234 @@ func (ts *thingsService) ListGroups(ctx context.Context, token string, admin boo
 		}
 	}
 
-	return ts.groups.RetrieveByOwner(ctx, user.GetId(), pm)
-}
-
-func (ts *thingsService) ListGroupsByIDs(ctx context.Context, ids []string) ([]Group, error) {
-	page, err := ts.groups.RetrieveByIDs(ctx, ids)
+	if pm.IDs, err = ts.scopeGroupIDs(ctx, user); err != nil {
+		return GroupPage{}, err
+	}
+
+	return ts.groups.RetrieveByOwner(ctx, user.GetId(), pm)
+}
+
+func (ts *thingsService) ListGroupsByIDs(ctx context.Context, ids []string) ([]Group, error) {
+	page, err := ts.groups.RetrieveByIDs(ctx, ids)
+	if err != nil {
+		return []Group{}, err
+	}
+
+	return page.Groups, nil
+}
+
+func (ts *thingsService) RemoveGroups(ctx context.Context, token string, ids ...string) error {
+	user, err := ts.identify(ctx, token, auth.GroupsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	removed := make(map[string]bool)
+	for _, id := range ids {
+		removed[id] = true
+	}
+
+	depths := make(map[string]int)
+	for _, id := range ids {
+		gr, err := ts.groups.RetrieveByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if gr.OwnerID != user.GetId() {
+			return errors.ErrAuthorization
+		}
+
+		if err := ts.checkScope(ctx, user, gr); err != nil {
+			return err
+		}
+		depths[id] = len(gr.Path)
+
+		// A group can be removed only together with all of its children.
+		children, err := ts.groups.RetrieveChildren(ctx, id, 1, PageMetadata{})
+		if err != nil {
+			return err
+		}
+
+		for _, child := range children.Groups {
+			if !removed[child.ID] {
+				return ErrGroupHasChildren
+			}
+		}
+
+		cp, err := ts.groups.RetrieveGroupChannels(ctx, id, PageMetadata{})
+		if err != nil {
+			return err
+		}
+
+		for _, ch := range cp.Channels {
+			tp, err := ts.things.RetrieveByChannel(ctx, user.GetId(), ch.ID, PageMetadata{})
+			if err != nil {
+				return err
+			}
+
+			var thingIDs []string
+			for _, th := range tp.Things {
+				thingIDs = append(thingIDs, th.ID)
+			}
+
+			if err := ts.channels.Disconnect(ctx, user.GetId(), ch.ID, thingIDs); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Remove the deepest groups first so that no group is removed before its children.
+	sorted := append([]string{}, ids...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return depths[sorted[i]] > depths[sorted[j]]
+	})
+
+	return ts.groups.Remove(ctx, sorted...)
+}
+
+func (ts *thingsService) ListDeletedGroups(ctx context.Context, token string, pm PageMetadata) (DeletedGroupsPage, error) {
+	user, err := ts.identify(ctx, token, auth.GroupsReadScope)
+	if err != nil {
+		return DeletedGroupsPage{}, err
+	}
+
+	// Groups in the trash are no longer part of the hierarchy, so
+	// the API keys restricted to groups or orgs can't access them.
+	if isGroupRestricted(user) {
+		return DeletedGroupsPage{}, errors.ErrAuthorization
+	}
+
+	return ts.groups.RetrieveDeleted(ctx, user.GetId(), pm)
+}
+
+func (ts *thingsService) RestoreGroups(ctx context.Context, token string, ids ...string) error {
+	user, err := ts.identify(ctx, token, auth.GroupsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	if isGroupRestricted(user) {
+		return errors.ErrAuthorization
+	}
+
+	page, err := ts.groups.RetrieveDeleted(ctx, user.GetId(), PageMetadata{})
+	if err != nil {
+		return err
+	}
+
+	restored := make(map[string]bool)
+	for _, id := range ids {
+		restored[id] = true
+	}
+
+	n := 0
+	ths, chs := make(map[string]int), make(map[string]int)
+	for _, gr := range page.Groups {
+		if !restored[gr.ID] {
+			continue
+		}
+		n++
+
+		// The group in the trash belongs to the org of its parent, or to the
+		// org it was assigned to directly.
+		orgGroup := gr.ParentID
+		if orgGroup == "" {
+			orgGroup = gr.ID
+		}
+		ths[orgGroup] += len(gr.ThingIDs)
+		chs[orgGroup] += len(gr.ChannelIDs)
+	}
+
+	release, err := ts.reserveUserQuota(ctx, user.GetId(), quota.Groups, n)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	releaseThings, err := ts.reserveOrgQuota(ctx, quota.Things, ths)
+	if err != nil {
+		return err
+	}
+	defer releaseThings()
+
+	releaseChannels, err := ts.reserveOrgQuota(ctx, quota.Channels, chs)
 	if err != nil {
-		return []Group{}, err
+		return err
 	}
+	defer releaseChannels()
 
-	return page.Groups, nil
+	return ts.groups.Restore(ctx, user.GetId(), ids...)
 }
 
-func (ts *thingsService) RemoveGroups(ctx context.Context, token string, ids ...string) error {
-	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+func (ts *thingsService) AddGroupTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	return ts.updateGroupTags(ctx, token, tags, true, ids...)
+}
+
+func (ts *thingsService) RemoveGroupTags(ctx context.Context, token string, tags []string, ids ...string) error {
+	return ts.updateGroupTags(ctx, token, tags, false, ids...)
+}
+
+func (ts *thingsService) updateGroupTags(ctx context.Context, token string, tags []string, add bool, ids ...string) error {
+	user, err := ts.identify(ctx, token, auth.GroupsWriteScope)
 	if err != nil {
 		return err
 	}
 
 	for _, id := range ids {
-		if err := ts.isGroupOwner(ctx, user.GetId(), id); err != nil {
+		if err := ts.checkGroupScope(ctx, user, id); err != nil {
 			return err
 		}
+	}
 
-		cp, err := ts.groups.RetrieveGroupChannels(ctx, id, PageMetadata{})
-		if err != nil {
-			return err
-		}
+	if tags, err = NormalizeTags(tags); err != nil {
+		return err
+	}
 
-		for _, ch := range cp.Channels {
-			tp, err := ts.things.RetrieveByChannel(ctx, user.GetId(), ch.ID, PageMetadata{})
-			if err != nil {
-				return err
-			}
+	if add {
+		return ts.groups.AddTags(ctx, user.GetId(), tags, ids...)
+	}
 
-			var thingIDs []string
-			for _, th := range tp.Things {
-				thingIDs = append(thingIDs, th.ID)
-			}
+	return ts.groups.RemoveTags(ctx, user.GetId(), tags, ids...)
+}
 
-			if err := ts.channels.Disconnect(ctx, user.GetId(), ch.ID, thingIDs); err != nil {
-				return err
-			}
-		}
+func (ts *thingsService) PurgeTrash(ctx context.Context, before time.Time) error {
+	if err := ts.things.Purge(ctx, before); err != nil {
+		return err
 	}
 
-	return ts.groups.Remove(ctx, ids...)
+	if err := ts.channels.Purge(ctx, before); err != nil {
+		return err
+	}
+
+	return ts.groups.Purge(ctx, before)
 }
 
 func (ts *thingsService) UpdateGroup(ctx context.Context, token string, group Group) (Group, error) {
-	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	user, err := ts.identify(ctx, token, auth.GroupsWriteScope)
+	if err != nil {
+		return Group{}, err
+	}
+
+	gr, err := ts.groups.RetrieveByID(ctx, group.ID)
 	if err != nil {
 		return Group{}, err
 	}
 
-	if err := ts.isGroupOwner(ctx, user.GetId(), group.ID); err != nil {
+	if gr.OwnerID != user.GetId() {
+		return Group{}, errors.ErrAuthorization
+	}
+
+	if err := ts.checkScope(ctx, user, gr); err != nil {
+		return Group{}, err
+	}
+	recordBefore(ctx, gr)
+
+	// Tags are kept unless the update provides them.
+	if group.Tags == nil {
+		group.Tags = gr.Tags
+	}
+	if group.Tags, err = NormalizeTags(group.Tags); err != nil {
 		return Group{}, err
 	}
 
@@ -774,7 +2020,7 @@ func (ts *thingsService) UpdateGroup(ctx context.Context, token string, group Gr
 }
 
 func (ts *thingsService) ViewGroup(ctx context.Context, token, id string) (Group, error) {
-	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	user, err := ts.identify(ctx, token, auth.GroupsReadScope)
 	if err != nil {
 		return Group{}, err
 	}
@@ -784,6 +2030,10 @@ func (ts *thingsService) ViewGroup(ctx context.Context, token, id string) (Group
 		return Group{}, err
 	}
 
+	if err := ts.checkScope(ctx, user, gr); err != nil {
+		return Group{}, err
+	}
+
 	if user.GetId() != gr.OwnerID {
 		if _, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: id, Action: auth.ReadAction}); err != nil {
 			return Group{}, err
@@ -793,8 +2043,59 @@ func (ts *thingsService) ViewGroup(ctx context.Context, token, id string) (Group
 	return gr, nil
 }
 
+func (ts *thingsService) ListGroupChildren(ctx context.Context, token, groupID string, depth uint64, pm PageMetadata) (GroupPage, error) {
+	if _, err := ts.ViewGroup(ctx, token, groupID); err != nil {
+		return GroupPage{}, err
+	}
+
+	return ts.groups.RetrieveChildren(ctx, groupID, depth, pm)
+}
+
+func (ts *thingsService) MoveGroup(ctx context.Context, token, groupID, parentID string) error {
+	user, err := ts.identify(ctx, token, auth.GroupsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.isGroupOwner(ctx, user.GetId(), groupID); err != nil {
+		return err
+	}
+
+	if err := ts.checkGroupScope(ctx, user, groupID); err != nil {
+		return err
+	}
+
+	// The group has to stay within the scope after it is moved.
+	if parentID == "" {
+		if err := ts.checkRootScope(ctx, user, groupID); err != nil {
+			return err
+		}
+	}
+
+	if parentID != "" {
+		if err := ts.validateParent(ctx, user.GetId(), groupID, parentID); err != nil {
+			return err
+		}
+
+		if err := ts.checkGroupScope(ctx, user, parentID); err != nil {
+			return err
+		}
+	}
+
+	return ts.groups.Move(ctx, groupID, parentID)
+}
+
 func (ts *thingsService) AssignThing(ctx context.Context, token string, groupID string, thingIDs ...string) error {
-	if _, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
+	user, err := ts.identify(ctx, token, auth.GroupsWriteScope)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.checkGroupScope(ctx, user, groupID); err != nil {
+		return err
+	}
+
+	if err := ts.canCreateThings(ctx, token, user.GetId(), groupID); err != nil {
 		return err
 	}
 
@@ -807,7 +2108,17 @@ func (ts *thingsService) AssignThing(ctx context.Context, token string, groupID
 		if thing.ID == "" {
 			return errors.ErrNotFound
 		}
+
+		if thing.Owner != user.GetId() {
+			return errors.ErrAuthorization
+		}
+	}
+
+	release, err := ts.reserveOrgQuota(ctx, quota.Things, map[string]int{groupID: len(thingIDs)})
+	if err != nil {
+		return err
 	}
+	defer release()
 
 	if err := ts.groups.AssignThing(ctx, groupID, thingIDs...); err != nil {
 		return err
@@ -817,7 +2128,7 @@ func (ts *thingsService) AssignThing(ctx context.Context, token string, groupID
 }
 
 func (ts *thingsService) AssignChannel(ctx context.Context, token string, groupID string, channelIDs ...string) error {
-	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	user, err := ts.identify(ctx, token, auth.GroupsWriteScope)
 	if err != nil {
 		return err
 	}
@@ -826,7 +2137,15 @@ func (ts *thingsService) AssignChannel(ctx context.Context, token string, groupI
 		return err
 	}
 
+	if err := ts.checkGroupScope(ctx, user, groupID); err != nil {
+		return err
+	}
+
 	for _, channelID := range channelIDs {
+		if !auth.HasResource(user.GetChannelIDs(), channelID) {
+			return errors.ErrAuthorization
+		}
+
 		ch, err := ts.channels.RetrieveByID(ctx, channelID)
 		if err != nil {
 			return err
@@ -837,6 +2156,12 @@ func (ts *thingsService) AssignChannel(ctx context.Context, token string, groupI
 		}
 	}
 
+	release, err := ts.reserveOrgQuota(ctx, quota.Channels, map[string]int{groupID: len(channelIDs)})
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	if err := ts.groups.AssignChannel(ctx, groupID, channelIDs...); err != nil {
 		return err
 	}
@@ -845,7 +2170,7 @@ func (ts *thingsService) AssignChannel(ctx context.Context, token string, groupI
 }
 
 func (ts *thingsService) UnassignChannel(ctx context.Context, token string, groupID string, channelIDs ...string) error {
-	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	user, err := ts.identify(ctx, token, auth.GroupsWriteScope)
 	if err != nil {
 		return err
 	}
@@ -854,6 +2179,16 @@ func (ts *thingsService) UnassignChannel(ctx context.Context, token string, grou
 		return err
 	}
 
+	if err := ts.checkGroupScope(ctx, user, groupID); err != nil {
+		return err
+	}
+
+	for _, chID := range channelIDs {
+		if !auth.HasResource(user.GetChannelIDs(), chID) {
+			return errors.ErrAuthorization
+		}
+	}
+
 	for _, chID := range channelIDs {
 		tp, err := ts.things.RetrieveByChannel(ctx, user.GetId(), chID, PageMetadata{})
 		if err != nil {
@@ -878,7 +2213,7 @@ func (ts *thingsService) UnassignChannel(ctx context.Context, token string, grou
 }
 
 func (ts *thingsService) UnassignThing(ctx context.Context, token string, groupID string, thingIDs ...string) error {
-	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	user, err := ts.identify(ctx, token, auth.GroupsWriteScope)
 	if err != nil {
 		return err
 	}
@@ -887,6 +2222,10 @@ func (ts *thingsService) UnassignThing(ctx context.Context, token string, groupI
 		return err
 	}
 
+	if err := ts.checkGroupScope(ctx, user, groupID); err != nil {
+		return err
+	}
+
 	for _, thingID := range thingIDs {
 		ch, err := ts.channels.RetrieveByThing(ctx, user.GetId(), thingID)
 		if err != nil {
@@ -908,11 +2247,15 @@ func getTimestmap() time.Time {
 }
 
 func (ts *thingsService) ListGroupThings(ctx context.Context, token string, groupID string, pm PageMetadata) (GroupThingsPage, error) {
-	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	user, err := ts.identify(ctx, token, auth.GroupsReadScope)
 	if err != nil {
 		return GroupThingsPage{}, err
 	}
 
+	if err := ts.checkGroupScope(ctx, user, groupID); err != nil {
+		return GroupThingsPage{}, err
+	}
+
 	if err := ts.isGroupOwner(ctx, user.GetId(), groupID); err != nil {
 		if _, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction}); err != nil {
 			return GroupThingsPage{}, err
@@ -923,7 +2266,7 @@ func (ts *thingsService) ListGroupThings(ctx context.Context, token string, grou
 }
 
 func (ts *thingsService) ListGroupThingsByChannel(ctx context.Context, token, grID, chID string, pm PageMetadata) (GroupThingsPage, error) {
-	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	user, err := ts.identify(ctx, token, auth.GroupsReadScope)
 	if err != nil {
 		return GroupThingsPage{}, err
 	}
@@ -932,6 +2275,14 @@ func (ts *thingsService) ListGroupThingsByChannel(ctx context.Context, token, gr
 		return ts.groups.RetrieveGroupThingsByChannel(ctx, grID, chID, pm)
 	}
 
+	if !auth.HasResource(user.GetChannelIDs(), chID) {
+		return GroupThingsPage{}, errors.ErrAuthorization
+	}
+
+	if err := ts.checkGroupScope(ctx, user, grID); err != nil {
+		return GroupThingsPage{}, err
+	}
+
 	if err := ts.isGroupOwner(ctx, user.GetId(), grID); err != nil {
 		if _, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: grID, Action: auth.ReadAction}); err != nil {
 			return GroupThingsPage{}, err
@@ -942,11 +2293,15 @@ func (ts *thingsService) ListGroupThingsByChannel(ctx context.Context, token, gr
 }
 
 func (ts *thingsService) ListGroupChannels(ctx context.Context, token, groupID string, pm PageMetadata) (GroupChannelsPage, error) {
-	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	user, err := ts.identify(ctx, token, auth.GroupsReadScope)
 	if err != nil {
 		return GroupChannelsPage{}, err
 	}
 
+	if err := ts.checkGroupScope(ctx, user, groupID); err != nil {
+		return GroupChannelsPage{}, err
+	}
+
 	if err := ts.isGroupOwner(ctx, user.GetId(), groupID); err != nil {
 		if _, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction}); err != nil {
 			return GroupChannelsPage{}, err
@@ -957,7 +2312,8 @@ func (ts *thingsService) ListGroupChannels(ctx context.Context, token, groupID s
 }
 
 func (ts *thingsService) ViewThingMembership(ctx context.Context, token string, thingID string) (Group, error) {
-	if _, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
+	user, err := ts.identify(ctx, token, auth.GroupsReadScope)
+	if err != nil {
 		return Group{}, err
 	}
 
@@ -975,11 +2331,16 @@ func (ts *thingsService) ViewThingMembership(ctx context.Context, token string,
 		return Group{}, err
 	}
 
+	if err := ts.checkScope(ctx, user, group); err != nil {
+		return Group{}, err
+	}
+
 	return group, nil
 }
 
 func (ts *thingsService) ViewChannelMembership(ctx context.Context, token string, channelID string) (Group, error) {
-	if _, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
+	user, err := ts.identify(ctx, token, auth.GroupsReadScope)
+	if err != nil {
 		return Group{}, err
 	}
 
@@ -997,6 +2358,10 @@ func (ts *thingsService) ViewChannelMembership(ctx context.Context, token string
 		return Group{}, err
 	}
 
+	if err := ts.checkScope(ctx, user, group); err != nil {
+		return Group{}, err
+	}
+
 	return group, nil
 }
 
@@ -1038,3 +2403,241 @@ func (ts *thingsService) isGroupOwner(ctx context.Context, userID, groupID strin
 
 	return nil
 }
+
+// checkGroupScope checks that the group is within the groups and the orgs
+// the API key of the user is restricted to.
+func (ts *thingsService) checkGroupScope(ctx context.Context, user *mainflux.UserIdentity, groupID string) error {
+	if !isGroupRestricted(user) {
+		return nil
+	}
+
+	gr, err := ts.groups.RetrieveByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	return ts.checkScope(ctx, user, gr)
+}
+
+// checkScope checks that the retrieved group is within the groups and the
+// orgs the API key of the user is restricted to.
+func (ts *thingsService) checkScope(ctx context.Context, user *mainflux.UserIdentity, gr Group) error {
+	if !matchesGroupScope(user, gr) {
+		return errors.ErrAuthorization
+	}
+
+	if len(user.GetOrgIDs()) == 0 {
+		return nil
+	}
+
+	// The quota of a group is the quota of the org the group belongs to.
+	q, err := ts.auth.RetrieveQuota(ctx, &mainflux.QuotaReq{Subject: quota.Group, Id: gr.ID})
+	if err != nil {
+		return err
+	}
+
+	if !hasAnyID(user.GetOrgIDs(), q.GetOrgID()) {
+		return errors.ErrAuthorization
+	}
+
+	return nil
+}
+
+// checkRootScope checks that the group stays within the scope of the API key
+// of the user once it becomes a root group, i.e. that the key is restricted
+// to the group itself and that the group is assigned to one of its orgs.
+func (ts *thingsService) checkRootScope(ctx context.Context, user *mainflux.UserIdentity, groupID string) error {
+	if !auth.HasResource(user.GetGroupIDs(), groupID) {
+		return errors.ErrAuthorization
+	}
+
+	if len(user.GetOrgIDs()) == 0 {
+		return nil
+	}
+
+	orgGroupIDs, err := ts.orgGroupIDs(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if !hasAnyID(orgGroupIDs, groupID) {
+		return errors.ErrAuthorization
+	}
+
+	return nil
+}
+
+// checkThingScope checks that the thing is assigned to a group within the
+// groups and the orgs the API key of the user is restricted to.
+func (ts *thingsService) checkThingScope(ctx context.Context, user *mainflux.UserIdentity, thingID string) error {
+	if !isGroupRestricted(user) {
+		return nil
+	}
+
+	groupID, err := ts.groups.RetrieveThingMembership(ctx, thingID)
+	switch {
+	case errors.Contains(err, errors.ErrNotFound):
+		return errors.ErrAuthorization
+	case err != nil:
+		return err
+	case groupID == "":
+		return errors.ErrAuthorization
+	}
+
+	return ts.checkGroupScope(ctx, user, groupID)
+}
+
+// scopeGroupIDs returns the groups that, together with their descendants,
+// form the scope of the API key of the user. It returns nil if the key isn't
+// restricted to any groups or orgs.
+func (ts *thingsService) scopeGroupIDs(ctx context.Context, user *mainflux.UserIdentity) ([]string, error) {
+	if len(user.GetOrgIDs()) == 0 {
+		return user.GetGroupIDs(), nil
+	}
+
+	orgGroupIDs, err := ts.orgGroupIDs(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(user.GetGroupIDs()) == 0 {
+		return orgGroupIDs, nil
+	}
+
+	// Subtrees of the key groups and of the org groups overlap
+	// in the subtree of the deeper one of the two.
+	ids := []string{}
+	for _, id := range append(append([]string{}, user.GetGroupIDs()...), orgGroupIDs...) {
+		gr, err := ts.groups.RetrieveByID(ctx, id)
+		if errors.Contains(err, errors.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if matchesGroupScope(user, gr) && hasAnyID(orgGroupIDs, gr.Path...) {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// scopeThingIDs returns the IDs of the things within the scope of the API
+// key of the user. It returns nil if the key isn't restricted to any groups
+// or orgs.
+func (ts *thingsService) scopeThingIDs(ctx context.Context, user *mainflux.UserIdentity) ([]string, error) {
+	groupIDs, err := ts.scopeGroupIDs(ctx, user)
+	if err != nil || groupIDs == nil {
+		return nil, err
+	}
+
+	return ts.groups.RetrieveThingIDs(ctx, groupIDs)
+}
+
+// orgGroupIDs returns the groups assigned to the orgs the API key of the user
+// is restricted to.
+func (ts *thingsService) orgGroupIDs(ctx context.Context, user *mainflux.UserIdentity) ([]string, error) {
+	ids := []string{}
+	for _, orgID := range user.GetOrgIDs() {
+		q, err := ts.auth.RetrieveQuota(ctx, &mainflux.QuotaReq{Subject: quota.Org, Id: orgID})
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, q.GetGroupIDs()...)
+	}
+
+	return ids, nil
+}
+
+// isGroupRestricted reports whether the API key of the user is restricted
+// to groups or orgs.
+func isGroupRestricted(user *mainflux.UserIdentity) bool {
+	return len(user.GetGroupIDs()) > 0 || len(user.GetOrgIDs()) > 0
+}
+
+// hasAnyID reports whether any of the IDs is in the list. Unlike
+// auth.HasResource, it reports false for an empty list.
+func hasAnyID(list []string, ids ...string) bool {
+	for _, id := range ids {
+		for _, l := range list {
+			if l == id {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchesGroupScope reports whether the group or one of its ancestors is
+// among the groups the API key of the user is restricted to.
+func matchesGroupScope(user *mainflux.UserIdentity, gr Group) bool {
+	if len(user.GetGroupIDs()) == 0 {
+		return true
+	}
+
+	for _, id := range gr.Path {
+		if auth.HasResource(user.GetGroupIDs(), id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canCreateThings checks that the user owns the group or, for the org groups,
+// holds the permission to create things in it.
+func (ts *thingsService) canCreateThings(ctx context.Context, token, userID, groupID string) error {
+	err := ts.isGroupOwner(ctx, userID, groupID)
+	if err == nil || !errors.Contains(err, errors.ErrAuthorization) {
+		return err
+	}
+
+	if _, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: groupID, Action: auth.CreateThingsPermission}); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
+
+// validateParent checks that the parent group exists, belongs to the owner and
+// that the group identified by groupID is not the parent itself or one of its ancestors.
+func (ts *thingsService) validateParent(ctx context.Context, owner, groupID, parentID string) error {
+	parent, err := ts.groups.RetrieveByID(ctx, parentID)
+	if err != nil {
+		if errors.Contains(err, errors.ErrNotFound) {
+			return ErrInvalidParent
+		}
+		return err
+	}
+
+	if parent.OwnerID != owner {
+		return errors.ErrAuthorization
+	}
+
+	for _, id := range parent.Path {
+		if id == groupID {
+			return ErrInvalidParent
+		}
+	}
+
+	return nil
+}
+
+// identify validates the user token and checks that it grants the scope,
+// in case the token is an API key with restricted scopes.
+func (ts *thingsService) identify(ctx context.Context, token, scope string) (*mainflux.UserIdentity, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if !auth.HasScope(res.GetScopes(), scope) {
+		return nil, errors.ErrAuthorization
+	}
+	recordActor(ctx, res.GetId())
+
+	return res, nil
+}
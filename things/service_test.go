@@ -4,14 +4,23 @@
 package things_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	authmock "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/quota"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/MainfluxLabs/mainflux/things/mocks"
@@ -45,19 +54,40 @@ var (
 	admin     = users.User{Email: adminEmail, Password: password}
 	usersList = []users.User{admin, user, otherUser}
 	group     = things.Group{Name: "test-group", Description: "test-group-desc"}
+	profile   = things.Profile{
+		Name: "sensor",
+		Schema: map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"serial"},
+			"properties": map[string]interface{}{
+				"serial":   map[string]interface{}{"type": "string"},
+				"interval": map[string]interface{}{"type": "integer", "minimum": 1},
+			},
+		},
+		Defaults: map[string]interface{}{"interval": 60},
+	}
 )
 
 func newService() things.Service {
-	auth := authmock.NewAuthService(admin.ID, usersList)
+	return newServiceWithQuotas(map[string]*mainflux.QuotaRes{})
+}
+
+func newServiceWithQuotas(quotas map[string]*mainflux.QuotaRes) things.Service {
+	return newServiceWithAuth(authmock.NewAuthServiceWithQuotas(admin.ID, usersList, quotas))
+}
+
+func newServiceWithAuth(auth mainflux.AuthServiceClient) things.Service {
 	conns := make(chan mocks.Connection)
 	thingsRepo := mocks.NewThingRepository(conns)
+	thingKeysRepo := mocks.NewThingKeyRepository()
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
 	groupsRepo := mocks.NewGroupRepository()
+	profilesRepo := mocks.NewProfileRepository()
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
 	idProvider := uuid.NewMock()
 
-	return things.New(auth, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
+	return things.New(auth, thingsRepo, thingKeysRepo, channelsRepo, groupsRepo, profilesRepo, chanCache, thingCache, idProvider)
 }
 
 func TestInit(t *testing.T) {
@@ -146,6 +176,17 @@ func TestUpdateThing(t *testing.T) {
 		err := svc.UpdateThing(context.Background(), tc.token, tc.thing)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
+
+	// The service records the user and the thing before the update.
+	ctx, rec := things.WithRecord(context.Background())
+	updated := th
+	updated.Name = "updated"
+	err = svc.UpdateThing(ctx, token, updated)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, user.ID, rec.Actor, fmt.Sprintf("expected actor %s got %s\n", user.ID, rec.Actor))
+	before, ok := rec.Before.(things.Thing)
+	assert.True(t, ok, "expected thing before the update to be recorded")
+	assert.Equal(t, th.Name, before.Name, fmt.Sprintf("expected name before the update %s got %s\n", th.Name, before.Name))
 }
 
 func TestUpdateKey(t *testing.T) {
@@ -191,6 +232,216 @@ func TestUpdateKey(t *testing.T) {
 	}
 }
 
+func TestRotateKey(t *testing.T) {
+	svc := newService()
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	err = svc.RotateKey(context.Background(), otherToken, th.ID, "other-key", time.Hour)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("rotate key of other user's thing: expected %s got %s\n", errors.ErrNotFound, err))
+
+	err = svc.RotateKey(context.Background(), token, th.ID, "rotated-key", time.Hour)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		key string
+		id  string
+		err error
+	}{
+		"identify thing with new key": {
+			key: "rotated-key",
+			id:  th.ID,
+			err: nil,
+		},
+		"identify thing with previous key during grace period": {
+			key: th.Key,
+			id:  th.ID,
+			err: nil,
+		},
+	}
+
+	for desc, tc := range cases {
+		id, err := svc.Identify(context.Background(), tc.key)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.id, id))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+
+	page, err := svc.ListThingKeys(context.Background(), token, th.ID, things.PageMetadata{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	require.Len(t, page.Keys, 1)
+	assert.Equal(t, things.RotatedKeyName, page.Keys[0].Name, fmt.Sprintf("expected %s got %s\n", things.RotatedKeyName, page.Keys[0].Name))
+
+	err = svc.RotateKey(context.Background(), token, th.ID, "second-key", 0)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	_, err = svc.Identify(context.Background(), "rotated-key")
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("identify thing with key rotated without grace period: expected %s got %s\n", errors.ErrNotFound, err))
+}
+
+func TestCreateThingKey(t *testing.T) {
+	svc := newService()
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	cases := []struct {
+		desc    string
+		token   string
+		thingID string
+		key     things.ThingKey
+		err     error
+	}{
+		{
+			desc:    "create thing key",
+			token:   token,
+			thingID: th.ID,
+			key:     things.ThingKey{Name: "backup"},
+			err:     nil,
+		},
+		{
+			desc:    "create thing key with custom value",
+			token:   token,
+			thingID: th.ID,
+			key:     things.ThingKey{Name: "custom", Key: "custom-key"},
+			err:     nil,
+		},
+		{
+			desc:    "create thing key equal to the primary key",
+			token:   token,
+			thingID: th.ID,
+			key:     things.ThingKey{Key: th.Key},
+			err:     errors.ErrConflict,
+		},
+		{
+			desc:    "create thing key with invalid credentials",
+			token:   wrongValue,
+			thingID: th.ID,
+			key:     things.ThingKey{},
+			err:     errors.ErrAuthentication,
+		},
+		{
+			desc:    "create thing key for other user's thing",
+			token:   otherToken,
+			thingID: th.ID,
+			key:     things.ThingKey{},
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		key, err := svc.CreateThingKey(context.Background(), tc.token, tc.thingID, tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.True(t, key.Enabled, fmt.Sprintf("%s: expected enabled key\n", tc.desc))
+		assert.NotEmpty(t, key.Key, fmt.Sprintf("%s: expected non-empty key\n", tc.desc))
+	}
+}
+
+func TestIdentifyThingKey(t *testing.T) {
+	svc := newService()
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	active, err := svc.CreateThingKey(context.Background(), token, th.ID, things.ThingKey{Name: "active"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	expired, err := svc.CreateThingKey(context.Background(), token, th.ID, things.ThingKey{Name: "expired", ExpiresAt: time.Now().Add(-time.Minute)})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	disabled, err := svc.CreateThingKey(context.Background(), token, th.ID, things.ThingKey{Name: "disabled"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	// Identify first, so that the key gets cached before it is disabled.
+	_, err = svc.Identify(context.Background(), disabled.Key)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	disabled.Enabled = false
+	err = svc.UpdateThingKey(context.Background(), token, th.ID, disabled)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	removed, err := svc.CreateThingKey(context.Background(), token, th.ID, things.ThingKey{Name: "removed"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	_, err = svc.Identify(context.Background(), removed.Key)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.RemoveThingKey(context.Background(), token, th.ID, removed.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		key string
+		id  string
+		err error
+	}{
+		"identify thing with active key": {
+			key: active.Key,
+			id:  th.ID,
+			err: nil,
+		},
+		"identify thing with expired key": {
+			key: expired.Key,
+			id:  "",
+			err: errors.ErrNotFound,
+		},
+		"identify thing with disabled key": {
+			key: disabled.Key,
+			id:  "",
+			err: errors.ErrNotFound,
+		},
+		"identify thing with removed key": {
+			key: removed.Key,
+			id:  "",
+			err: errors.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		id, err := svc.Identify(context.Background(), tc.key)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.id, id))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestRemoveThingKey(t *testing.T) {
+	svc := newService()
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	key, err := svc.CreateThingKey(context.Background(), token, th.ID, things.ThingKey{Name: "key"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "remove thing key of other user's thing",
+			token: otherToken,
+			id:    key.ID,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "remove thing key",
+			token: token,
+			id:    key.ID,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed thing key",
+			token: token,
+			id:    key.ID,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveThingKey(context.Background(), tc.token, th.ID, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestViewThing(t *testing.T) {
 	svc := newService()
 	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
@@ -415,7 +666,7 @@ func TestListThingsByChannel(t *testing.T) {
 	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, ch.ID, thIDs[0:n-thsDisconNum])
+	err = svc.Connect(context.Background(), token, ch.ID, thIDs[0:n-thsDisconNum], things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	// Wait for things and channels to connect
@@ -615,114 +866,335 @@ func TestRemoveThings(t *testing.T) {
 	}
 }
 
-func TestCreateChannels(t *testing.T) {
+func TestListDeletedThings(t *testing.T) {
 	svc := newService()
+	ths, err := svc.CreateThings(context.Background(), token, thingList[0], thingList[1])
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.RemoveThings(context.Background(), token, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	cases := []struct {
-		desc     string
-		channels []things.Channel
-		token    string
-		err      error
+		desc  string
+		token string
+		size  uint64
+		err   error
 	}{
 		{
-			desc:     "create new channels",
-			channels: []things.Channel{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}},
-			token:    token,
-			err:      nil,
-		},
-		{
-			desc:     "create channel with wrong credentials",
-			channels: []things.Channel{{Name: "e"}},
-			token:    wrongValue,
-			err:      errors.ErrAuthentication,
+			desc:  "list deleted things",
+			token: token,
+			size:  1,
+			err:   nil,
 		},
 		{
-			desc:     "create new channels with external UUID",
-			channels: chsExtID,
-			token:    token,
-			err:      nil,
+			desc:  "list deleted things of other user",
+			token: otherToken,
+			size:  0,
+			err:   nil,
 		},
 		{
-			desc:     "create new channels with invalid external UUID",
-			channels: []things.Channel{{ID: "b0aa-000000000001", Name: "a"}, {ID: "b0aa-000000000002", Name: "b"}},
-			token:    token,
-			err:      nil,
+			desc:  "list deleted things with wrong credentials",
+			token: wrongValue,
+			size:  0,
+			err:   errors.ErrAuthentication,
 		},
 	}
 
-	for _, cc := range cases {
-		_, err := svc.CreateChannels(context.Background(), cc.token, cc.channels...)
-		assert.True(t, errors.Contains(err, cc.err), fmt.Sprintf("%s: expected %s got %s\n", cc.desc, cc.err, err))
+	for _, tc := range cases {
+		page, err := svc.ListDeletedThings(context.Background(), tc.token, things.PageMetadata{Limit: 10})
+		assert.Equal(t, tc.size, uint64(len(page.Things)), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Things)))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
 
-func TestUpdateChannel(t *testing.T) {
+func TestRestoreThings(t *testing.T) {
 	svc := newService()
-	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	ch := chs[0]
-	other := things.Channel{ID: wrongID}
+	sth := ths[0]
+	err = svc.RemoveThings(context.Background(), token, sth.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	_, err = svc.Identify(context.Background(), sth.Key)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("identify removed thing: expected %s got %s\n", errors.ErrNotFound, err))
 
 	cases := []struct {
-		desc    string
-		channel things.Channel
-		token   string
-		err     error
+		desc  string
+		id    string
+		token string
+		err   error
 	}{
 		{
-			desc:    "update existing channel",
-			channel: ch,
-			token:   token,
-			err:     nil,
+			desc:  "restore thing with wrong credentials",
+			id:    sth.ID,
+			token: wrongValue,
+			err:   errors.ErrAuthentication,
 		},
 		{
-			desc:    "update channel with wrong credentials",
-			channel: ch,
-			token:   wrongValue,
-			err:     errors.ErrAuthentication,
+			desc:  "restore thing of other user",
+			id:    sth.ID,
+			token: otherToken,
+			err:   errors.ErrNotFound,
 		},
 		{
-			desc:    "update non-existing channel",
-			channel: other,
-			token:   token,
-			err:     errors.ErrNotFound,
+			desc:  "restore removed thing",
+			id:    sth.ID,
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "restore restored thing",
+			id:    sth.ID,
+			token: token,
+			err:   errors.ErrNotFound,
 		},
 	}
 
 	for _, tc := range cases {
-		err := svc.UpdateChannel(context.Background(), tc.token, tc.channel)
+		err := svc.RestoreThings(context.Background(), tc.token, tc.id)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
+
+	id, err := svc.Identify(context.Background(), sth.Key)
+	assert.Nil(t, err, fmt.Sprintf("identify restored thing: unexpected error: %s\n", err))
+	assert.Equal(t, sth.ID, id, fmt.Sprintf("identify restored thing: expected %s got %s\n", sth.ID, id))
 }
 
-func TestViewChannel(t *testing.T) {
+func TestThingTags(t *testing.T) {
 	svc := newService()
-	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	ths, err := svc.CreateThings(context.Background(), token,
+		things.Thing{Name: "a", Tags: []string{"outdoor", "floor-1", "outdoor"}},
+		things.Thing{Name: "b", Tags: []string{"indoor"}})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	ch := chs[0]
+	assert.Equal(t, []string{"floor-1", "outdoor"}, ths[0].Tags, fmt.Sprintf("create thing: expected normalized tags got %v\n", ths[0].Tags))
 
-	cases := map[string]struct {
-		id       string
-		token    string
-		err      error
-		metadata things.Metadata
+	_, err = svc.CreateThings(context.Background(), token, things.Thing{Name: "c", Tags: []string{"out door"}})
+	assert.True(t, errors.Contains(err, things.ErrInvalidTag), fmt.Sprintf("create thing with invalid tag: expected %s got %s\n", things.ErrInvalidTag, err))
+
+	cases := []struct {
+		desc  string
+		add   bool
+		ids   []string
+		tags  []string
+		token string
+		err   error
 	}{
-		"view existing channel": {
-			id:    ch.ID,
-			token: token,
-			err:   nil,
-		},
-		"view existing channel as admin": {
-			id:    ch.ID,
-			token: adminToken,
-			err:   nil,
-		},
-		"view channel with wrong credentials": {
-			id:    ch.ID,
+		{
+			desc:  "add tags with wrong credentials",
+			add:   true,
+			ids:   []string{ths[0].ID},
+			tags:  []string{"faulty"},
 			token: wrongValue,
 			err:   errors.ErrAuthentication,
 		},
-		"view non-existing channel": {
+		{
+			desc:  "add tags to thing of other user",
+			add:   true,
+			ids:   []string{ths[0].ID},
+			tags:  []string{"faulty"},
+			token: otherToken,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "add invalid tags",
+			add:   true,
+			ids:   []string{ths[0].ID},
+			tags:  []string{""},
+			token: token,
+			err:   things.ErrInvalidTag,
+		},
+		{
+			desc:  "add tags to things",
+			add:   true,
+			ids:   []string{ths[0].ID, ths[1].ID},
+			tags:  []string{"faulty"},
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "remove tags from thing",
+			add:   false,
+			ids:   []string{ths[1].ID},
+			tags:  []string{"faulty", "missing"},
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "remove tags from non-existing thing",
+			add:   false,
+			ids:   []string{wrongValue},
+			tags:  []string{"faulty"},
+			token: token,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		update := svc.RemoveThingTags
+		if tc.add {
+			update = svc.AddThingTags
+		}
+		err := update(context.Background(), tc.token, tc.tags, tc.ids...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	filters := []struct {
+		desc  string
+		tags  []string
+		names []string
+	}{
+		{
+			desc:  "list things having a tag",
+			tags:  []string{"faulty"},
+			names: []string{"a"},
+		},
+		{
+			desc:  "list things not having a tag",
+			tags:  []string{"!faulty"},
+			names: []string{"b"},
+		},
+		{
+			desc:  "list things having any of the tags",
+			tags:  []string{"indoor|outdoor"},
+			names: []string{"a", "b"},
+		},
+		{
+			desc:  "list things having all of the tags",
+			tags:  []string{"outdoor", "floor-1", "faulty"},
+			names: []string{"a"},
+		},
+	}
+
+	for _, tc := range filters {
+		page, err := svc.ListThings(context.Background(), token, false, things.PageMetadata{Tags: tc.tags})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		var names []string
+		for _, th := range page.Things {
+			names = append(names, th.Name)
+		}
+		assert.ElementsMatch(t, tc.names, names, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.names, names))
+	}
+}
+
+func TestCreateChannels(t *testing.T) {
+	svc := newService()
+
+	cases := []struct {
+		desc     string
+		channels []things.Channel
+		token    string
+		err      error
+	}{
+		{
+			desc:     "create new channels",
+			channels: []things.Channel{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}},
+			token:    token,
+			err:      nil,
+		},
+		{
+			desc:     "create channel with wrong credentials",
+			channels: []things.Channel{{Name: "e"}},
+			token:    wrongValue,
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "create new channels with external UUID",
+			channels: chsExtID,
+			token:    token,
+			err:      nil,
+		},
+		{
+			desc:     "create new channels with invalid external UUID",
+			channels: []things.Channel{{ID: "b0aa-000000000001", Name: "a"}, {ID: "b0aa-000000000002", Name: "b"}},
+			token:    token,
+			err:      nil,
+		},
+		{
+			desc:     "create new channel with payload schema",
+			channels: []things.Channel{{Name: "f", PayloadSchema: schema.Schema{SenML: map[string]string{"temp": "Cel"}}}},
+			token:    token,
+			err:      nil,
+		},
+		{
+			desc:     "create new channel with invalid payload schema",
+			channels: []things.Channel{{Name: "g", PayloadSchema: schema.Schema{JSON: map[string]interface{}{"type": 1}}}},
+			token:    token,
+			err:      schema.ErrInvalidSchema,
+		},
+	}
+
+	for _, cc := range cases {
+		_, err := svc.CreateChannels(context.Background(), cc.token, cc.channels...)
+		assert.True(t, errors.Contains(err, cc.err), fmt.Sprintf("%s: expected %s got %s\n", cc.desc, cc.err, err))
+	}
+}
+
+func TestUpdateChannel(t *testing.T) {
+	svc := newService()
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+	other := things.Channel{ID: wrongID}
+
+	cases := []struct {
+		desc    string
+		channel things.Channel
+		token   string
+		err     error
+	}{
+		{
+			desc:    "update existing channel",
+			channel: ch,
+			token:   token,
+			err:     nil,
+		},
+		{
+			desc:    "update channel with wrong credentials",
+			channel: ch,
+			token:   wrongValue,
+			err:     errors.ErrAuthentication,
+		},
+		{
+			desc:    "update non-existing channel",
+			channel: other,
+			token:   token,
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateChannel(context.Background(), tc.token, tc.channel)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestViewChannel(t *testing.T) {
+	svc := newService()
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	cases := map[string]struct {
+		id       string
+		token    string
+		err      error
+		metadata things.Metadata
+	}{
+		"view existing channel": {
+			id:    ch.ID,
+			token: token,
+			err:   nil,
+		},
+		"view existing channel as admin": {
+			id:    ch.ID,
+			token: adminToken,
+			err:   nil,
+		},
+		"view channel with wrong credentials": {
+			id:    ch.ID,
+			token: wrongValue,
+			err:   errors.ErrAuthentication,
+		},
+		"view non-existing channel": {
 			id:    wrongID,
 			token: token,
 			err:   errors.ErrNotFound,
@@ -926,7 +1398,7 @@ func TestViewChannelByThing(t *testing.T) {
 	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
+	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID}, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
 	// Wait for things and channels to connect.
@@ -1021,286 +1493,391 @@ func TestRemoveChannel(t *testing.T) {
 	}
 }
 
-func TestConnect(t *testing.T) {
+func TestRestoreChannels(t *testing.T) {
 	svc := newService()
-
-	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	th := ths[0]
 	chs, err := svc.CreateChannels(context.Background(), token, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	ch := chs[0]
-
-	grs, err := svc.CreateGroups(context.Background(), token, group)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	gr := grs[0]
-
-	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	sch := chs[0]
+	err = svc.RemoveChannels(context.Background(), token, sch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
-	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	page, err := svc.ListDeletedChannels(context.Background(), token, things.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, 1, len(page.Channels), fmt.Sprintf("list deleted channels: expected 1 got %d\n", len(page.Channels)))
 
 	cases := []struct {
-		desc    string
-		token   string
-		chanID  string
-		thingID string
-		err     error
+		desc  string
+		id    string
+		token string
+		err   error
 	}{
 		{
-			desc:    "connect thing",
-			token:   token,
-			chanID:  ch.ID,
-			thingID: th.ID,
-			err:     nil,
-		},
-		{
-			desc:    "connect thing with wrong credentials",
-			token:   wrongValue,
-			chanID:  ch.ID,
-			thingID: th.ID,
-			err:     errors.ErrAuthentication,
+			desc:  "restore channel with wrong credentials",
+			id:    sch.ID,
+			token: wrongValue,
+			err:   errors.ErrAuthentication,
 		},
 		{
-			desc:    "connect thing to non-existing channel",
-			token:   token,
-			chanID:  wrongID,
-			thingID: th.ID,
-			err:     errors.ErrNotFound,
+			desc:  "restore removed channel",
+			id:    sch.ID,
+			token: token,
+			err:   nil,
 		},
 		{
-			desc:    "connect non-existing thing to channel",
-			token:   token,
-			chanID:  ch.ID,
-			thingID: wrongID,
-			err:     errors.ErrNotFound,
+			desc:  "restore non-existing channel",
+			id:    wrongID,
+			token: token,
+			err:   errors.ErrNotFound,
 		},
 	}
 
 	for _, tc := range cases {
-		err := svc.Connect(context.Background(), tc.token, tc.chanID, []string{tc.thingID})
+		err := svc.RestoreChannels(context.Background(), tc.token, tc.id)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
+
+	_, err = svc.ViewChannel(context.Background(), token, sch.ID)
+	assert.Nil(t, err, fmt.Sprintf("view restored channel: unexpected error: %s\n", err))
 }
 
-func TestDisconnect(t *testing.T) {
+func TestCreateProfiles(t *testing.T) {
 	svc := newService()
 
-	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	th := ths[0]
-
-	chs, err := svc.CreateChannels(context.Background(), token, channel)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	ch := chs[0]
-
-	grs, err := svc.CreateGroups(context.Background(), token, group)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	gr := grs[0]
-
-	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-
-	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-
-	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-
 	cases := []struct {
-		desc    string
-		token   string
-		chanID  string
-		thingID string
-		err     error
+		desc     string
+		token    string
+		profiles []things.Profile
+		err      error
 	}{
 		{
-			desc:    "disconnect connected thing",
-			token:   token,
-			chanID:  ch.ID,
-			thingID: th.ID,
-			err:     nil,
+			desc:     "create profile",
+			token:    token,
+			profiles: []things.Profile{profile},
+			err:      nil,
 		},
 		{
-			desc:    "disconnect disconnected thing",
-			token:   token,
-			chanID:  ch.ID,
-			thingID: th.ID,
-			err:     errors.ErrNotFound,
+			desc:     "create profile without schema",
+			token:    token,
+			profiles: []things.Profile{{Name: "plain", Defaults: map[string]interface{}{"location": "lab"}}},
+			err:      nil,
 		},
 		{
-			desc:    "disconnect with wrong credentials",
-			token:   wrongValue,
-			chanID:  ch.ID,
-			thingID: th.ID,
-			err:     errors.ErrAuthentication,
+			desc:     "create profile with invalid schema",
+			token:    token,
+			profiles: []things.Profile{{Name: "invalid", Schema: map[string]interface{}{"type": 5}}},
+			err:      things.ErrInvalidProfileSchema,
 		},
 		{
-			desc:    "disconnect from non-existing channel",
-			token:   token,
-			chanID:  wrongID,
-			thingID: th.ID,
-			err:     errors.ErrNotFound,
+			desc:     "create profile with defaults that don't conform to schema",
+			token:    token,
+			profiles: []things.Profile{{Name: "invalid-defaults", Schema: profile.Schema, Defaults: map[string]interface{}{"interval": "often"}}},
+			err:      things.ErrInvalidProfileSchema,
 		},
 		{
-			desc:    "disconnect non-existing thing",
-			token:   token,
-			chanID:  ch.ID,
-			thingID: wrongID,
-			err:     errors.ErrNotFound,
+			desc:     "create profile with existing name",
+			token:    token,
+			profiles: []things.Profile{profile},
+			err:      errors.ErrConflict,
+		},
+		{
+			desc:     "create profile with invalid credentials",
+			token:    wrongValue,
+			profiles: []things.Profile{profile},
+			err:      errors.ErrAuthentication,
 		},
 	}
 
 	for _, tc := range cases {
-		err := svc.Disconnect(context.Background(), tc.token, tc.chanID, []string{tc.thingID})
+		_, err := svc.CreateProfiles(context.Background(), tc.token, tc.profiles...)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
-
 }
 
-func TestGetConnByKey(t *testing.T) {
+func TestCreateThingsWithProfile(t *testing.T) {
 	svc := newService()
-
-	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
+	prs, err := svc.CreateProfiles(context.Background(), token, profile)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	th := ths[0]
+	pr := prs[0]
 
-	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
+	cases := []struct {
+		desc     string
+		token    string
+		thing    things.Thing
+		metadata things.Metadata
+		err      error
+	}{
+		{
+			desc:     "create thing with profile defaults",
+			token:    token,
+			thing:    things.Thing{Name: "a", ProfileID: pr.ID, Metadata: things.Metadata{"serial": "a1"}},
+			metadata: things.Metadata{"serial": "a1", "interval": 60},
+			err:      nil,
+		},
+		{
+			desc:     "create thing overriding profile defaults",
+			token:    token,
+			thing:    things.Thing{Name: "b", ProfileID: pr.ID, Metadata: things.Metadata{"serial": "b1", "interval": 5}},
+			metadata: things.Metadata{"serial": "b1", "interval": 5},
+			err:      nil,
+		},
+		{
+			desc:  "create thing without required metadata",
+			token: token,
+			thing: things.Thing{Name: "c", ProfileID: pr.ID},
+			err:   things.ErrInvalidMetadata,
+		},
+		{
+			desc:  "create thing with invalid metadata",
+			token: token,
+			thing: things.Thing{Name: "d", ProfileID: pr.ID, Metadata: things.Metadata{"serial": "d1", "interval": 0}},
+			err:   things.ErrInvalidMetadata,
+		},
+		{
+			desc:  "create thing with non-existing profile",
+			token: token,
+			thing: things.Thing{Name: "e", ProfileID: wrongValue},
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "create thing with other user's profile",
+			token: otherToken,
+			thing: things.Thing{Name: "f", ProfileID: pr.ID, Metadata: things.Metadata{"serial": "f1"}},
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		ths, err := svc.CreateThings(context.Background(), tc.token, tc.thing)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tc.metadata, ths[0].Metadata, fmt.Sprintf("%s: expected metadata %v got %v\n", tc.desc, tc.metadata, ths[0].Metadata))
+	}
+}
+
+func TestUpdateThingWithProfile(t *testing.T) {
+	svc := newService()
+	prs, err := svc.CreateProfiles(context.Background(), token, profile)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	ch := chs[0]
 
-	grs, err := svc.CreateGroups(context.Background(), token, group)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	gr := grs[0]
+	th := things.Thing{Name: "a", ProfileID: prs[0].ID, Metadata: things.Metadata{"serial": "a1"}}
+	ths, err := svc.CreateThings(context.Background(), token, th)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th = ths[0]
 
-	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	cases := []struct {
+		desc     string
+		metadata things.Metadata
+		err      error
+	}{
+		{
+			desc:     "update thing with valid metadata",
+			metadata: things.Metadata{"serial": "a2", "interval": 10},
+			err:      nil,
+		},
+		{
+			desc:     "update thing with metadata missing required field",
+			metadata: things.Metadata{"interval": 10},
+			err:      things.ErrInvalidMetadata,
+		},
+		{
+			desc:     "update thing with metadata of wrong type",
+			metadata: things.Metadata{"serial": 2},
+			err:      things.ErrInvalidMetadata,
+		},
+	}
 
-	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	for _, tc := range cases {
+		thing := things.Thing{ID: th.ID, Name: th.Name, Metadata: tc.metadata}
+		err := svc.UpdateThing(context.Background(), token, thing)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
 
-	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
+func TestCreateChannelsWithProfile(t *testing.T) {
+	svc := newService()
+	prs, err := svc.CreateProfiles(context.Background(), token, things.Profile{
+		Name:     "transform",
+		Schema:   map[string]interface{}{"properties": map[string]interface{}{"format": map[string]interface{}{"enum": []interface{}{"senml", "json"}}}},
+		Defaults: map[string]interface{}{"format": "senml"},
+	})
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	pr := prs[0]
 
-	cases := map[string]struct {
-		key string
-		err error
+	cases := []struct {
+		desc     string
+		channel  things.Channel
+		metadata map[string]interface{}
+		err      error
 	}{
-		"allowed access": {
-			key: th.Key,
-			err: nil,
+		{
+			desc:     "create channel with profile defaults",
+			channel:  things.Channel{Name: "a", ProfileID: pr.ID},
+			metadata: map[string]interface{}{"format": "senml"},
+			err:      nil,
 		},
-		"non-existing thing": {
-			key: wrongValue,
-			err: errors.ErrNotFound,
+		{
+			desc:    "create channel with invalid metadata",
+			channel: things.Channel{Name: "b", ProfileID: pr.ID, Metadata: map[string]interface{}{"format": "xml"}},
+			err:     things.ErrInvalidMetadata,
 		},
 	}
 
-	for desc, tc := range cases {
-		_, err := svc.GetConnByKey(context.Background(), tc.key)
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected '%s' got '%s'\n", desc, tc.err, err))
+	for _, tc := range cases {
+		chs, err := svc.CreateChannels(context.Background(), token, tc.channel)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tc.metadata, chs[0].Metadata, fmt.Sprintf("%s: expected metadata %v got %v\n", tc.desc, tc.metadata, chs[0].Metadata))
 	}
 }
 
-func TestIsChannelOwner(t *testing.T) {
+func TestListThingsByProfile(t *testing.T) {
 	svc := newService()
-	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	prs, err := svc.CreateProfiles(context.Background(), token, profile)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	ownedCh := chs[0]
-	chs, err = svc.CreateChannels(context.Background(), otherToken, channel)
+	pr := prs[0]
+
+	_, err = svc.CreateThings(context.Background(), token,
+		things.Thing{Name: "a", ProfileID: pr.ID, Metadata: things.Metadata{"serial": "a1"}},
+		things.Thing{Name: "b", ProfileID: pr.ID, Metadata: things.Metadata{"serial": "b1"}},
+		things.Thing{Name: "c"},
+	)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	nonOwnedCh := chs[0]
 
-	cases := map[string]struct {
-		channel string
-		err     error
+	cases := []struct {
+		desc      string
+		token     string
+		profileID string
+		size      uint64
+		err       error
 	}{
-		"user owns channel": {
-			channel: ownedCh.ID,
-			err:     nil,
+		{
+			desc:      "list things by profile",
+			token:     token,
+			profileID: pr.ID,
+			size:      2,
+			err:       nil,
 		},
-		"user does not own channel": {
-			channel: nonOwnedCh.ID,
-			err:     errors.ErrAuthorization,
+		{
+			desc:      "list things by non-existing profile",
+			token:     token,
+			profileID: wrongValue,
+			size:      0,
+			err:       errors.ErrNotFound,
 		},
-		"access to non-existing channel": {
-			channel: wrongID,
-			err:     errors.ErrNotFound,
+		{
+			desc:      "list things by other user's profile",
+			token:     otherToken,
+			profileID: pr.ID,
+			size:      0,
+			err:       errors.ErrNotFound,
+		},
+		{
+			desc:      "list things by profile with invalid credentials",
+			token:     wrongValue,
+			profileID: pr.ID,
+			size:      0,
+			err:       errors.ErrAuthentication,
 		},
 	}
 
-	for desc, tc := range cases {
-		err := svc.IsChannelOwner(context.Background(), user.ID, tc.channel)
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	for _, tc := range cases {
+		page, err := svc.ListThingsByProfile(context.Background(), tc.token, tc.profileID, things.PageMetadata{Limit: 10})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, uint64(len(page.Things)), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Things)))
 	}
 }
 
-func TestIdentify(t *testing.T) {
+func TestConnect(t *testing.T) {
 	svc := newService()
 
 	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th := ths[0]
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
 
-	cases := map[string]struct {
-		token string
-		id    string
-		err   error
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		chanID   string
+		thingID  string
+		connType string
+		err      error
 	}{
-		"identify existing thing": {
-			token: th.Key,
-			id:    th.ID,
-			err:   nil,
+		{
+			desc:     "connect thing",
+			token:    token,
+			chanID:   ch.ID,
+			thingID:  th.ID,
+			connType: things.ConnTypePubSub,
+			err:      nil,
 		},
-		"identify non-existing thing": {
-			token: wrongValue,
-			id:    wrongID,
-			err:   errors.ErrNotFound,
+		{
+			desc:     "connect thing with invalid connection type",
+			token:    token,
+			chanID:   ch.ID,
+			thingID:  th.ID,
+			connType: wrongValue,
+			err:      things.ErrInvalidConnType,
+		},
+		{
+			desc:    "connect thing with wrong credentials",
+			token:   wrongValue,
+			chanID:  ch.ID,
+			thingID: th.ID,
+			err:     errors.ErrAuthentication,
+		},
+		{
+			desc:    "connect thing to non-existing channel",
+			token:   token,
+			chanID:  wrongID,
+			thingID: th.ID,
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "connect non-existing thing to channel",
+			token:   token,
+			chanID:  ch.ID,
+			thingID: wrongID,
+			err:     errors.ErrNotFound,
 		},
 	}
 
-	for desc, tc := range cases {
-		id, err := svc.Identify(context.Background(), tc.token)
-		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.id, id))
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	for _, tc := range cases {
+		err := svc.Connect(context.Background(), tc.token, tc.chanID, []string{tc.thingID}, tc.connType)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
 
-func TestBackup(t *testing.T) {
+func TestDisconnect(t *testing.T) {
 	svc := newService()
 
-	var groups []things.Group
-	for i := uint64(0); i < 10; i++ {
-		num := strconv.FormatUint(i, 10)
-		group := things.Group{
-			Name:        "test-group-" + num,
-			Description: "test group desc",
-		}
-
-		grs, err := svc.CreateGroups(context.Background(), token, group)
-		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-		gr := grs[0]
-
-		groups = append(groups, gr)
-	}
-	gr := groups[0]
-
-	ths, err := svc.CreateThings(context.Background(), token, thing)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th := ths[0]
 
-	var chs []things.Channel
-	n := uint64(10)
-	for i := uint64(0); i < n; i++ {
-		ch := channel
-		ch.Name = fmt.Sprintf("name-%d", i)
-		chs = append(chs, ch)
-	}
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
 
-	chsc, err := svc.CreateChannels(context.Background(), token, chs...)
+	grs, err := svc.CreateGroups(context.Background(), token, group)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	ch := chsc[0]
+	gr := grs[0]
 
 	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
@@ -1308,31 +1885,776 @@ func TestBackup(t *testing.T) {
 	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
+	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID}, things.ConnTypePubSub)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 
-	// Wait for things and channels to connect.
-	time.Sleep(time.Second)
-
-	for _, group := range groups {
-		err := svc.AssignThing(context.Background(), token, group.ID, th.ID)
-		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	}
-
-	gtr := []things.GroupThingRelation{}
-	for _, group := range groups {
-		grRel := things.GroupThingRelation{
-			GroupID: group.ID,
-			ThingID: th.ID,
-		}
-		gtr = append(gtr, grRel)
-	}
-	grRel1 := things.GroupThingRelation{
-		GroupID: gr.ID,
-		ThingID: th.ID,
-	}
-	gtr = append(gtr, grRel1)
-
+	cases := []struct {
+		desc    string
+		token   string
+		chanID  string
+		thingID string
+		err     error
+	}{
+		{
+			desc:    "disconnect connected thing",
+			token:   token,
+			chanID:  ch.ID,
+			thingID: th.ID,
+			err:     nil,
+		},
+		{
+			desc:    "disconnect disconnected thing",
+			token:   token,
+			chanID:  ch.ID,
+			thingID: th.ID,
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "disconnect with wrong credentials",
+			token:   wrongValue,
+			chanID:  ch.ID,
+			thingID: th.ID,
+			err:     errors.ErrAuthentication,
+		},
+		{
+			desc:    "disconnect from non-existing channel",
+			token:   token,
+			chanID:  wrongID,
+			thingID: th.ID,
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "disconnect non-existing thing",
+			token:   token,
+			chanID:  ch.ID,
+			thingID: wrongID,
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Disconnect(context.Background(), tc.token, tc.chanID, []string{tc.thingID})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+}
+
+func TestCanAccessByKey(t *testing.T) {
+	svc := newService()
+
+	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	pubSubCh, pubCh, otherCh := chs[0], chs[1], chs[2]
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	for _, ch := range chs {
+		err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	err = svc.Connect(context.Background(), token, pubSubCh.ID, []string{th.ID}, "")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.Connect(context.Background(), token, pubCh.ID, []string{th.ID}, things.ConnTypePublish)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	tk, err := svc.CreateThingKey(context.Background(), token, th.ID, things.ThingKey{Name: "key"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	expired, err := svc.CreateThingKey(context.Background(), token, th.ID, things.ThingKey{Name: "expired", ExpiresAt: time.Now().Add(-time.Minute)})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		key    string
+		chanID string
+		action string
+		id     string
+		err    error
+	}{
+		{
+			desc:   "publish to publish-subscribe channel",
+			key:    th.Key,
+			chanID: pubSubCh.ID,
+			action: things.PublishAction,
+			id:     th.ID,
+			err:    nil,
+		},
+		{
+			desc:   "subscribe to publish-subscribe channel",
+			key:    th.Key,
+			chanID: pubSubCh.ID,
+			action: things.SubscribeAction,
+			id:     th.ID,
+			err:    nil,
+		},
+		{
+			desc:   "publish to publish-only channel",
+			key:    th.Key,
+			chanID: pubCh.ID,
+			action: things.PublishAction,
+			id:     th.ID,
+			err:    nil,
+		},
+		{
+			desc:   "subscribe to publish-only channel",
+			key:    th.Key,
+			chanID: pubCh.ID,
+			action: things.SubscribeAction,
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "subscribe to publish-only channel after the connection is cached",
+			key:    th.Key,
+			chanID: pubCh.ID,
+			action: things.SubscribeAction,
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "publish to unconnected channel",
+			key:    th.Key,
+			chanID: otherCh.ID,
+			action: things.PublishAction,
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "publish with additional key",
+			key:    tk.Key,
+			chanID: pubSubCh.ID,
+			action: things.PublishAction,
+			id:     th.ID,
+			err:    nil,
+		},
+		{
+			desc:   "publish with expired key",
+			key:    expired.Key,
+			chanID: pubSubCh.ID,
+			action: things.PublishAction,
+			err:    errors.ErrNotFound,
+		},
+		{
+			desc:   "publish with non-existing key",
+			key:    wrongValue,
+			chanID: pubSubCh.ID,
+			action: things.PublishAction,
+			err:    errors.ErrNotFound,
+		},
+		{
+			desc:   "access with invalid action",
+			key:    th.Key,
+			chanID: pubSubCh.ID,
+			action: wrongValue,
+			err:    errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		id, err := svc.CanAccessByKey(context.Background(), tc.chanID, tc.key, tc.action)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.id, id))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected '%s' got '%s'\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestIsChannelOwner(t *testing.T) {
+	svc := newService()
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ownedCh := chs[0]
+	chs, err = svc.CreateChannels(context.Background(), otherToken, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	nonOwnedCh := chs[0]
+
+	cases := map[string]struct {
+		channel string
+		err     error
+	}{
+		"user owns channel": {
+			channel: ownedCh.ID,
+			err:     nil,
+		},
+		"user does not own channel": {
+			channel: nonOwnedCh.ID,
+			err:     errors.ErrAuthorization,
+		},
+		"access to non-existing channel": {
+			channel: wrongID,
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := svc.IsChannelOwner(context.Background(), user.ID, tc.channel)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestIsThingOwner(t *testing.T) {
+	svc := newService()
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ownedTh := ths[0]
+	ths, err = svc.CreateThings(context.Background(), otherToken, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	nonOwnedTh := ths[0]
+
+	cases := map[string]struct {
+		thing string
+		err   error
+	}{
+		"user owns thing": {
+			thing: ownedTh.ID,
+			err:   nil,
+		},
+		"user does not own thing": {
+			thing: nonOwnedTh.ID,
+			err:   errors.ErrAuthorization,
+		},
+		"access to non-existing thing": {
+			thing: wrongID,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := svc.IsThingOwner(context.Background(), user.ID, tc.thing)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestCanReadChannel(t *testing.T) {
+	svc := newService()
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ownedCh := chs[0]
+	chs, err = svc.CreateChannels(context.Background(), otherToken, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	nonOwnedCh := chs[0]
+
+	cases := map[string]struct {
+		token      string
+		channel    string
+		permission string
+		err        error
+	}{
+		"read owned channel": {
+			token:   token,
+			channel: ownedCh.ID,
+			err:     nil,
+		},
+		"read channel as admin": {
+			token:   adminToken,
+			channel: nonOwnedCh.ID,
+			err:     nil,
+		},
+		"read channel owned by other user": {
+			token:   token,
+			channel: nonOwnedCh.ID,
+			err:     errors.ErrAuthorization,
+		},
+		"read non-existing channel": {
+			token:   token,
+			channel: wrongValue,
+			err:     errors.ErrNotFound,
+		},
+		"read channel with invalid token": {
+			token:   wrongValue,
+			channel: ownedCh.ID,
+			err:     errors.ErrAuthentication,
+		},
+		"manage notifiers of owned channel": {
+			token:      token,
+			channel:    ownedCh.ID,
+			permission: auth.ManageNotifiersPermission,
+			err:        nil,
+		},
+		"manage notifiers of channel owned by other user": {
+			token:      token,
+			channel:    nonOwnedCh.ID,
+			permission: auth.ManageNotifiersPermission,
+			err:        errors.ErrAuthorization,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := svc.CanReadChannel(context.Background(), tc.token, tc.channel, tc.permission)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestAssignThing(t *testing.T) {
+	svc := newService()
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ths2, err := svc.CreateThings(context.Background(), otherToken, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	nonOwnedTh := ths2[0]
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	gr := grs[0]
+
+	cases := map[string]struct {
+		token   string
+		groupID string
+		thingID string
+		err     error
+	}{
+		"assign owned thing to owned group": {
+			token:   token,
+			groupID: gr.ID,
+			thingID: ths[0].ID,
+			err:     nil,
+		},
+		"assign thing to group without create things permission": {
+			token:   otherToken,
+			groupID: gr.ID,
+			thingID: nonOwnedTh.ID,
+			err:     errors.ErrAuthorization,
+		},
+		"assign thing owned by other user": {
+			token:   token,
+			groupID: gr.ID,
+			thingID: nonOwnedTh.ID,
+			err:     errors.ErrAuthorization,
+		},
+		"assign thing to non-existing group": {
+			token:   token,
+			groupID: wrongValue,
+			thingID: ths[1].ID,
+			err:     errors.ErrNotFound,
+		},
+		"assign thing with invalid token": {
+			token:   wrongValue,
+			groupID: gr.ID,
+			thingID: ths[1].ID,
+			err:     errors.ErrAuthentication,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := svc.AssignThing(context.Background(), tc.token, tc.groupID, tc.thingID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestChannelRestrictedKey(t *testing.T) {
+	keys := map[string]*mainflux.UserIdentity{}
+	svc := newServiceWithAuth(authmock.NewAuthServiceWithKeys(admin.ID, usersList, keys))
+
+	ths, err := svc.CreateThings(context.Background(), token, thingList[0], thingList[1])
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignThing(context.Background(), token, grs[0].ID, ths[0].ID, ths[1].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignChannel(context.Background(), token, grs[0].ID, chs[0].ID, chs[1].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	for i, ch := range chs {
+		err = svc.Connect(context.Background(), token, ch.ID, []string{ths[i].ID}, things.ConnTypePubSub)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
+	// Wait for things and channels to connect.
+	time.Sleep(time.Second)
+
+	key := "channel-key"
+	keys[key] = &mainflux.UserIdentity{Id: user.ID, Email: user.Email, ChannelIDs: []string{chs[0].ID}}
+
+	page, err := svc.ListChannels(context.Background(), key, false, things.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("list channels: unexpected error: %s\n", err))
+	require.Equal(t, 1, len(page.Channels), fmt.Sprintf("list channels: expected 1 got %d\n", len(page.Channels)))
+	assert.Equal(t, chs[0].ID, page.Channels[0].ID, fmt.Sprintf("list channels: expected %s got %s\n", chs[0].ID, page.Channels[0].ID))
+
+	cases := []struct {
+		desc string
+		chID string
+		thID string
+		err  error
+	}{
+		{
+			desc: "access allowed channel",
+			chID: chs[0].ID,
+			thID: ths[0].ID,
+			err:  nil,
+		},
+		{
+			desc: "access channel the key is not restricted to",
+			chID: chs[1].ID,
+			thID: ths[1].ID,
+			err:  errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.ListThingsByChannel(context.Background(), key, tc.chID, things.PageMetadata{Limit: 10})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("list things by channel: %s: expected %s got %s\n", tc.desc, tc.err, err))
+		_, err = svc.ViewChannelByThing(context.Background(), key, tc.thID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("view channel by thing: %s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestGroupRestrictedKey(t *testing.T) {
+	keys := map[string]*mainflux.UserIdentity{}
+	svc := newServiceWithAuth(authmock.NewAuthServiceWithKeys(admin.ID, usersList, keys))
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	grs, err := svc.CreateGroups(context.Background(), token, group, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	gr, other := grs[0], grs[1]
+	children, err := svc.CreateGroups(context.Background(), token, things.Group{Name: "child", ParentID: gr.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	child := children[0]
+
+	key := "group-key"
+	keys[key] = &mainflux.UserIdentity{Id: user.ID, Email: user.Email, GroupIDs: []string{gr.ID}}
+
+	page, err := svc.ListGroups(context.Background(), key, false, things.PageMetadata{})
+	require.Nil(t, err, fmt.Sprintf("list groups: unexpected error: %s\n", err))
+	assert.Equal(t, 2, len(page.Groups), fmt.Sprintf("list groups: expected 2 got %d\n", len(page.Groups)))
+
+	_, err = svc.CreateGroups(context.Background(), key, group)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("create root group: expected %s got %s\n", errors.ErrAuthorization, err))
+	_, err = svc.CreateGroups(context.Background(), key, things.Group{Name: "child", ParentID: other.ID})
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("create group under other group: expected %s got %s\n", errors.ErrAuthorization, err))
+	err = svc.MoveGroup(context.Background(), key, child.ID, other.ID)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("move group out of scope: expected %s got %s\n", errors.ErrAuthorization, err))
+	_, err = svc.ListDeletedGroups(context.Background(), key, things.PageMetadata{})
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("list deleted groups: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	cases := []struct {
+		desc    string
+		groupID string
+		thingID string
+		err     error
+	}{
+		{
+			desc:    "access allowed group",
+			groupID: gr.ID,
+			thingID: ths[0].ID,
+			err:     nil,
+		},
+		{
+			desc:    "access descendant of allowed group",
+			groupID: child.ID,
+			thingID: ths[1].ID,
+			err:     nil,
+		},
+		{
+			desc:    "access group the key is not restricted to",
+			groupID: other.ID,
+			thingID: ths[2].ID,
+			err:     errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.ViewGroup(context.Background(), key, tc.groupID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("view group: %s: expected %s got %s\n", tc.desc, tc.err, err))
+		_, err = svc.ListGroupThings(context.Background(), key, tc.groupID, things.PageMetadata{})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("list group things: %s: expected %s got %s\n", tc.desc, tc.err, err))
+		err = svc.AssignThing(context.Background(), key, tc.groupID, tc.thingID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("assign thing: %s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestThingRestrictedKey(t *testing.T) {
+	keys := map[string]*mainflux.UserIdentity{}
+	quotas := map[string]*mainflux.QuotaRes{}
+	svc := newServiceWithAuth(authmock.NewAuthServiceWithKeysAndQuotas(admin.ID, usersList, keys, quotas))
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	grs, err := svc.CreateGroups(context.Background(), token, group, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	gr, other := grs[0], grs[1]
+	children, err := svc.CreateGroups(context.Background(), token, things.Group{Name: "child", ParentID: gr.ID})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	child := children[0]
+
+	for i, groupID := range []string{gr.ID, child.ID, other.ID} {
+		err := svc.AssignThing(context.Background(), token, groupID, ths[i].ID)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
+	orgID := "org"
+	quotas[orgID] = &mainflux.QuotaRes{OrgID: orgID, GroupIDs: []string{other.ID}}
+	quotas[other.ID] = &mainflux.QuotaRes{OrgID: orgID}
+
+	groupKey, orgKey := "group-key", "org-key"
+	keys[groupKey] = &mainflux.UserIdentity{Id: user.ID, Email: user.Email, GroupIDs: []string{gr.ID}}
+	keys[orgKey] = &mainflux.UserIdentity{Id: user.ID, Email: user.Email, OrgIDs: []string{orgID}}
+
+	_, err = svc.CreateThings(context.Background(), groupKey, thing)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("create thing: expected %s got %s\n", errors.ErrAuthorization, err))
+	_, err = svc.ListDeletedThings(context.Background(), orgKey, things.PageMetadata{})
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("list deleted things: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	listCases := []struct {
+		desc string
+		key  string
+		ids  []string
+	}{
+		{
+			desc: "list things with key restricted to group",
+			key:  groupKey,
+			ids:  []string{ths[0].ID, ths[1].ID},
+		},
+		{
+			desc: "list things with key restricted to org",
+			key:  orgKey,
+			ids:  []string{ths[2].ID},
+		},
+	}
+
+	for _, tc := range listCases {
+		page, err := svc.ListThings(context.Background(), tc.key, false, things.PageMetadata{})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		var ids []string
+		for _, th := range page.Things {
+			ids = append(ids, th.ID)
+		}
+		assert.ElementsMatch(t, tc.ids, ids, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.ids, ids))
+	}
+
+	cases := []struct {
+		desc    string
+		key     string
+		thingID string
+		err     error
+	}{
+		{
+			desc:    "access thing in allowed group",
+			key:     groupKey,
+			thingID: ths[0].ID,
+			err:     nil,
+		},
+		{
+			desc:    "access thing in descendant of allowed group",
+			key:     groupKey,
+			thingID: ths[1].ID,
+			err:     nil,
+		},
+		{
+			desc:    "access thing in group the key is not restricted to",
+			key:     groupKey,
+			thingID: ths[2].ID,
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "access thing outside of groups",
+			key:     groupKey,
+			thingID: ths[3].ID,
+			err:     errors.ErrAuthorization,
+		},
+		{
+			desc:    "access thing in group of allowed org",
+			key:     orgKey,
+			thingID: ths[2].ID,
+			err:     nil,
+		},
+		{
+			desc:    "access thing in group outside of allowed org",
+			key:     orgKey,
+			thingID: ths[0].ID,
+			err:     errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.ViewThing(context.Background(), tc.key, tc.thingID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("view thing: %s: expected %s got %s\n", tc.desc, tc.err, err))
+		err = svc.UpdateThing(context.Background(), tc.key, things.Thing{ID: tc.thingID, Name: "updated"})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("update thing: %s: expected %s got %s\n", tc.desc, tc.err, err))
+		_, err = svc.ListThingKeys(context.Background(), tc.key, tc.thingID, things.PageMetadata{})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("list thing keys: %s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = svc.RemoveThings(context.Background(), groupKey, ths[0].ID, ths[2].ID)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("remove things: expected %s got %s\n", errors.ErrAuthorization, err))
+	_, err = svc.ViewThing(context.Background(), token, ths[0].ID)
+	assert.Nil(t, err, fmt.Sprintf("remove things: expected thing to be kept got %s\n", err))
+}
+
+func TestIdentify(t *testing.T) {
+	svc := newService()
+
+	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+
+	cases := map[string]struct {
+		token string
+		id    string
+		err   error
+	}{
+		"identify existing thing": {
+			token: th.Key,
+			id:    th.ID,
+			err:   nil,
+		},
+		"identify non-existing thing": {
+			token: wrongValue,
+			id:    wrongID,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		id, err := svc.Identify(context.Background(), tc.token)
+		assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.id, id))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestGetChannelSchema(t *testing.T) {
+	svc := newService()
+
+	ps := schema.Schema{SenML: map[string]string{"temp": "Cel"}}
+	chs, err := svc.CreateChannels(context.Background(), token, things.Channel{Name: "a", PayloadSchema: ps}, things.Channel{Name: "b"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		id     string
+		schema schema.Schema
+		err    error
+	}{
+		"get schema of channel with payload schema": {
+			id:     chs[0].ID,
+			schema: ps,
+			err:    nil,
+		},
+		"get schema of channel without payload schema": {
+			id:     chs[1].ID,
+			schema: schema.Schema{},
+			err:    nil,
+		},
+		"get schema of non-existing channel": {
+			id:     wrongID,
+			schema: schema.Schema{},
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		s, err := svc.GetChannelSchema(context.Background(), tc.id)
+		assert.Equal(t, tc.schema, s, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.schema, s))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestGetOSCORESecret(t *testing.T) {
+	svc := newService()
+
+	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	secret, err := things.DeriveOSCORESecret(th.ID, th.Key)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := map[string]struct {
+		id     string
+		secret []byte
+		err    error
+	}{
+		"get OSCORE secret of existing thing": {
+			id:     th.ID,
+			secret: secret,
+			err:    nil,
+		},
+		"get OSCORE secret of non-existing thing": {
+			id:     wrongID,
+			secret: nil,
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for desc, tc := range cases {
+		s, err := svc.GetOSCORESecret(context.Background(), tc.id)
+		assert.Equal(t, tc.secret, s, fmt.Sprintf("%s: expected %x got %x\n", desc, tc.secret, s))
+		assert.NotEqual(t, []byte(th.Key), s, fmt.Sprintf("%s: expected secret to differ from the thing key\n", desc))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestBackup(t *testing.T) {
+	svc := newService()
+
+	var groups []things.Group
+	for i := uint64(0); i < 10; i++ {
+		num := strconv.FormatUint(i, 10)
+		group := things.Group{
+			Name:        "test-group-" + num,
+			Description: "test group desc",
+		}
+
+		grs, err := svc.CreateGroups(context.Background(), token, group)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		gr := grs[0]
+
+		groups = append(groups, gr)
+	}
+	gr := groups[0]
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	th := ths[0]
+
+	var chs []things.Channel
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		ch := channel
+		ch.Name = fmt.Sprintf("name-%d", i)
+		chs = append(chs, ch)
+	}
+
+	chsc, err := svc.CreateChannels(context.Background(), token, chs...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ch := chsc[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID}, things.ConnTypePubSub)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	// Wait for things and channels to connect.
+	time.Sleep(time.Second)
+
+	for _, group := range groups {
+		err := svc.AssignThing(context.Background(), token, group.ID, th.ID)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
+	gtr := []things.GroupThingRelation{}
+	for _, group := range groups {
+		grRel := things.GroupThingRelation{
+			GroupID: group.ID,
+			ThingID: th.ID,
+		}
+		gtr = append(gtr, grRel)
+	}
+	grRel1 := things.GroupThingRelation{
+		GroupID: gr.ID,
+		ThingID: th.ID,
+	}
+	gtr = append(gtr, grRel1)
+
 	gcr := []things.GroupChannelRelation{
 		{
 			GroupID:   gr.ID,
@@ -1340,21 +2662,137 @@ func TestBackup(t *testing.T) {
 		},
 	}
 
-	connections := []things.Connection{}
-	connections = append(connections, things.Connection{
+	connections := []things.Connection{}
+	connections = append(connections, things.Connection{
+		ChannelID:    ch.ID,
+		ChannelOwner: ch.Owner,
+		ThingID:      th.ID,
+		ThingOwner:   th.Owner,
+	})
+
+	backup := things.Backup{
+		Groups:                groups,
+		Things:                ths,
+		Channels:              chsc,
+		Connections:           connections,
+		GroupThingRelations:   gtr,
+		GroupChannelRelations: gcr,
+	}
+
+	cases := map[string]struct {
+		token  string
+		backup things.Backup
+		err    error
+	}{
+		"list backups": {
+			token:  adminToken,
+			backup: backup,
+			err:    nil,
+		},
+		"list backups with invalid token": {
+			token:  wrongValue,
+			backup: things.Backup{},
+			err:    errors.ErrAuthentication,
+		},
+		"list backups with empty token": {
+			token:  "",
+			backup: things.Backup{},
+			err:    errors.ErrAuthentication,
+		},
+	}
+
+	for desc, tc := range cases {
+		backup, err := svc.Backup(context.Background(), tc.token)
+		groupSize := len(backup.Groups)
+		thingsSize := len(backup.Things)
+		channelsSize := len(backup.Channels)
+		connectionsSize := len(backup.Connections)
+		groupThingRelationsSize := len(backup.GroupThingRelations)
+		groupChannelRelationsSize := len(backup.GroupChannelRelations)
+		assert.Equal(t, len(tc.backup.Groups), groupSize, fmt.Sprintf("%s: expected %v got %d\n", desc, len(tc.backup.Groups), groupSize))
+		assert.Equal(t, len(tc.backup.Things), thingsSize, fmt.Sprintf("%s: expected %v got %d\n", desc, len(tc.backup.Things), thingsSize))
+		assert.Equal(t, len(tc.backup.Channels), channelsSize, fmt.Sprintf("%s: expected %v got %d\n", desc, len(tc.backup.Channels), channelsSize))
+		assert.Equal(t, len(tc.backup.Connections), connectionsSize, fmt.Sprintf("%s: expected %v got %d\n", desc, len(tc.backup.Connections), connectionsSize))
+		assert.Equal(t, len(tc.backup.GroupThingRelations), groupThingRelationsSize, fmt.Sprintf("%s: expected %v got %d\n", desc, len(tc.backup.GroupThingRelations), groupThingRelationsSize))
+		assert.Equal(t, len(tc.backup.GroupChannelRelations), groupChannelRelationsSize, fmt.Sprintf("%s: expected %v got %d\n", desc, len(tc.backup.GroupChannelRelations), groupChannelRelationsSize))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+
+	}
+}
+
+func TestRestore(t *testing.T) {
+	svc := newService()
+	idProvider := uuid.New()
+
+	thkey, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	thID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	chID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	var groups []things.Group
+	for i := uint64(0); i < 10; i++ {
+		num := strconv.FormatUint(i, 10)
+		gr := things.Group{
+			ID:          fmt.Sprintf("%s%012d", prefix, i+1),
+			Name:        "test-group-" + num,
+			Description: "test group desc",
+		}
+
+		groups = append(groups, gr)
+	}
+
+	var gtr []things.GroupThingRelation
+	for _, group := range groups {
+		grRel := things.GroupThingRelation{
+			GroupID: group.ID,
+			ThingID: thID,
+		}
+		gtr = append(gtr, grRel)
+	}
+
+	ths := []things.Thing{
+		{
+			ID:       thID,
+			Name:     "testThing",
+			Owner:    adminEmail,
+			Key:      thkey,
+			Metadata: map[string]interface{}{},
+		},
+	}
+	th := ths[0]
+
+	var chs []things.Channel
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		ch := things.Channel{
+			ID:       chID,
+			Name:     "testChannel",
+			Owner:    adminEmail,
+			Metadata: map[string]interface{}{},
+		}
+		ch.Name = fmt.Sprintf("name-%d", i)
+		chs = append(chs, ch)
+	}
+	ch := chs[0]
+
+	var connections []things.Connection
+	conn := things.Connection{
 		ChannelID:    ch.ID,
 		ChannelOwner: ch.Owner,
 		ThingID:      th.ID,
 		ThingOwner:   th.Owner,
-	})
+	}
+
+	connections = append(connections, conn)
 
 	backup := things.Backup{
-		Groups:                groups,
-		Things:                ths,
-		Channels:              chsc,
-		Connections:           connections,
-		GroupThingRelations:   gtr,
-		GroupChannelRelations: gcr,
+		Groups:              groups,
+		Things:              ths,
+		Channels:            chs,
+		Connections:         connections,
+		GroupThingRelations: gtr,
 	}
 
 	cases := map[string]struct {
@@ -1362,175 +2800,707 @@ func TestBackup(t *testing.T) {
 		backup things.Backup
 		err    error
 	}{
-		"list backups": {
+		"Restore backup": {
 			token:  adminToken,
 			backup: backup,
 			err:    nil,
 		},
-		"list backups with invalid token": {
+		"Restore backup with invalid token": {
+			token: wrongValue,
+			err:   errors.ErrAuthentication,
+		},
+		"Restore backup with empty token": {
+			token: "",
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := svc.Restore(context.Background(), tc.token, tc.backup)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestRestoreGroups(t *testing.T) {
+	svc := newService()
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th := ths[0]
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	gr := grs[0]
+	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.RemoveGroups(context.Background(), token, gr.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	page, err := svc.ListDeletedGroups(context.Background(), token, things.PageMetadata{Limit: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	require.Equal(t, 1, len(page.Groups), fmt.Sprintf("list deleted groups: expected 1 got %d\n", len(page.Groups)))
+	assert.Equal(t, []string{th.ID}, page.Groups[0].ThingIDs, fmt.Sprintf("list deleted groups: expected %v got %v\n", []string{th.ID}, page.Groups[0].ThingIDs))
+
+	cases := []struct {
+		desc  string
+		id    string
+		token string
+		err   error
+	}{
+		{
+			desc:  "restore group with wrong credentials",
+			id:    gr.ID,
+			token: wrongValue,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "restore group of other user",
+			id:    gr.ID,
+			token: otherToken,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "restore removed group",
+			id:    gr.ID,
+			token: token,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RestoreGroups(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	mgr, err := svc.ViewThingMembership(context.Background(), token, th.ID)
+	assert.Nil(t, err, fmt.Sprintf("view thing membership: unexpected error: %s\n", err))
+	assert.Equal(t, gr.ID, mgr.ID, fmt.Sprintf("view thing membership: expected %s got %s\n", gr.ID, mgr.ID))
+}
+
+func TestGroupTags(t *testing.T) {
+	svc := newService()
+	grs, err := svc.CreateGroups(context.Background(), token,
+		things.Group{Name: "a", Tags: []string{"site:berlin"}},
+		things.Group{Name: "b", Tags: []string{"site:paris"}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AddGroupTags(context.Background(), otherToken, []string{"prod"}, grs[0].ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("add tags to group of other user: expected %s got %s\n", errors.ErrNotFound, err))
+
+	err = svc.AddGroupTags(context.Background(), token, []string{"prod"}, grs[0].ID, grs[1].ID)
+	require.Nil(t, err, fmt.Sprintf("add tags to groups: unexpected error: %s\n", err))
+	err = svc.RemoveGroupTags(context.Background(), token, []string{"site:paris"}, grs[1].ID)
+	require.Nil(t, err, fmt.Sprintf("remove tags from group: unexpected error: %s\n", err))
+
+	gr, err := svc.ViewGroup(context.Background(), token, grs[1].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, []string{"prod"}, gr.Tags, fmt.Sprintf("view group: expected %v got %v\n", []string{"prod"}, gr.Tags))
+
+	_, err = svc.UpdateGroup(context.Background(), token, things.Group{ID: grs[0].ID, Name: "c"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	gr, err = svc.ViewGroup(context.Background(), token, grs[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, []string{"prod", "site:berlin"}, gr.Tags, fmt.Sprintf("update group without tags: expected %v got %v\n", []string{"prod", "site:berlin"}, gr.Tags))
+
+	page, err := svc.ListGroups(context.Background(), token, false, things.PageMetadata{Tags: []string{"prod", "!site:berlin"}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	require.Equal(t, 1, len(page.Groups), fmt.Sprintf("list groups by tags: expected 1 got %d\n", len(page.Groups)))
+	assert.Equal(t, grs[1].ID, page.Groups[0].ID, fmt.Sprintf("list groups by tags: expected %s got %s\n", grs[1].ID, page.Groups[0].ID))
+}
+
+func TestPurgeTrash(t *testing.T) {
+	svc := newService()
+	ths, err := svc.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.RemoveThings(context.Background(), token, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		before time.Time
+		size   uint64
+	}{
+		{
+			desc:   "purge things removed before retention period",
+			before: time.Now().Add(-time.Hour),
+			size:   1,
+		},
+		{
+			desc:   "purge things removed after retention period",
+			before: time.Now(),
+			size:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.PurgeTrash(context.Background(), tc.before)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+
+		page, err := svc.ListDeletedThings(context.Background(), token, things.PageMetadata{})
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, uint64(len(page.Things)), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Things)))
+	}
+}
+
+func testSortThings(t *testing.T, pm things.PageMetadata, ths []things.Thing) {
+	switch pm.Order {
+	case "name":
+		current := ths[0]
+		for _, res := range ths {
+			if pm.Dir == "asc" {
+				assert.GreaterOrEqual(t, res.Name, current.Name)
+			}
+			if pm.Dir == "desc" {
+				assert.GreaterOrEqual(t, current.Name, res.Name)
+			}
+			current = res
+		}
+	default:
+		break
+	}
+}
+
+func testSortChannels(t *testing.T, pm things.PageMetadata, chs []things.Channel) {
+	switch pm.Order {
+	case "name":
+		current := chs[0]
+		for _, res := range chs {
+			if pm.Dir == "asc" {
+				assert.GreaterOrEqual(t, res.Name, current.Name)
+			}
+			if pm.Dir == "desc" {
+				assert.GreaterOrEqual(t, current.Name, res.Name)
+			}
+			current = res
+		}
+	default:
+		break
+	}
+}
+
+func TestExportBackup(t *testing.T) {
+	svc := newService()
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	child := group
+	child.ParentID = gr.ID
+	grs, err = svc.CreateGroups(context.Background(), token, child, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	chGr, otherGr := grs[0], grs[1]
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = svc.AssignThing(context.Background(), token, chGr.ID, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.AssignThing(context.Background(), token, otherGr.ID, ths[1].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = svc.AssignChannel(context.Background(), token, gr.ID, chs[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	grs, err = svc.CreateGroups(context.Background(), otherToken, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	nonOwnedGr := grs[0]
+
+	cases := []struct {
+		desc    string
+		token   string
+		filter  things.BackupFilter
+		records map[string]int
+		err     error
+	}{
+		{
+			desc:    "export full backup as admin",
+			token:   adminToken,
+			filter:  things.BackupFilter{},
+			records: map[string]int{"group": 4, "thing": 2, "channel": 1, "group_thing": 2, "group_channel": 1},
+			err:     nil,
+		},
+		{
+			desc:    "export backup of group subtree as group owner",
+			token:   token,
+			filter:  things.BackupFilter{GroupID: gr.ID},
+			records: map[string]int{"group": 2, "thing": 1, "channel": 1, "group_thing": 1, "group_channel": 1},
+			err:     nil,
+		},
+		{
+			desc:    "export incremental backup",
+			token:   adminToken,
+			filter:  things.BackupFilter{Since: time.Now().Add(time.Hour)},
+			records: map[string]int{},
+			err:     nil,
+		},
+		{
+			desc:   "export full backup as user",
+			token:  token,
+			filter: things.BackupFilter{},
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "export backup of group without write access",
+			token:  token,
+			filter: things.BackupFilter{GroupID: nonOwnedGr.ID},
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "export backup of group with invalid token",
 			token:  wrongValue,
-			backup: things.Backup{},
+			filter: things.BackupFilter{GroupID: gr.ID},
 			err:    errors.ErrAuthentication,
 		},
-		"list backups with empty token": {
-			token:  "",
-			backup: things.Backup{},
-			err:    errors.ErrAuthentication,
+		{
+			desc:   "export backup of non-existing group",
+			token:  adminToken,
+			filter: things.BackupFilter{GroupID: wrongValue},
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		stream, err := svc.ExportBackup(context.Background(), tc.token, tc.filter)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+
+		var buf bytes.Buffer
+		err = stream(backup.NewEncoder(&buf))
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		records := countRecords(t, &buf)
+		for typ, count := range tc.records {
+			assert.Equal(t, count, records[typ], fmt.Sprintf("%s: expected %d %s records got %d\n", tc.desc, count, typ, records[typ]))
+		}
+		if len(tc.records) == 0 {
+			assert.Empty(t, records, fmt.Sprintf("%s: expected no records got %v\n", tc.desc, records))
+		}
+	}
+}
+
+func TestImportBackup(t *testing.T) {
+	src := newService()
+
+	grs, err := src.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	ths, err := src.CreateThings(context.Background(), token, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	chs, err := src.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = src.AssignThing(context.Background(), token, gr.ID, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = src.AssignChannel(context.Background(), token, gr.ID, chs[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = src.Connect(context.Background(), token, chs[0].ID, []string{ths[0].ID}, things.ConnTypePubSub)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	stream, err := src.ExportBackup(context.Background(), adminToken, things.BackupFilter{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	var buf bytes.Buffer
+	err = stream(backup.NewEncoder(&buf))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	data := buf.String()
+
+	svc := newService()
+
+	cases := []struct {
+		desc     string
+		token    string
+		strategy string
+		summary  backup.Summary
+		err      error
+	}{
+		{
+			desc:     "import backup as user",
+			token:    token,
+			strategy: backup.StrategyFail,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "import backup with invalid strategy",
+			token:    adminToken,
+			strategy: wrongValue,
+			err:      backup.ErrInvalidStrategy,
+		},
+		{
+			desc:     "import backup",
+			token:    adminToken,
+			strategy: backup.StrategyFail,
+			summary:  backup.Summary{Created: 6},
+			err:      nil,
+		},
+		{
+			desc:     "import existing backup skipping conflicts",
+			token:    adminToken,
+			strategy: backup.StrategySkip,
+			summary:  backup.Summary{Skipped: 6},
+			err:      nil,
+		},
+		{
+			desc:     "import existing backup overwriting conflicts",
+			token:    adminToken,
+			strategy: backup.StrategyOverwrite,
+			summary:  backup.Summary{Updated: 6},
+			err:      nil,
+		},
+		{
+			desc:     "import existing backup failing on conflicts",
+			token:    adminToken,
+			strategy: backup.StrategyFail,
+			err:      errors.ErrConflict,
 		},
 	}
 
-	for desc, tc := range cases {
-		backup, err := svc.Backup(context.Background(), tc.token)
-		groupSize := len(backup.Groups)
-		thingsSize := len(backup.Things)
-		channelsSize := len(backup.Channels)
-		connectionsSize := len(backup.Connections)
-		groupThingRelationsSize := len(backup.GroupThingRelations)
-		groupChannelRelationsSize := len(backup.GroupChannelRelations)
-		assert.Equal(t, len(tc.backup.Groups), groupSize, fmt.Sprintf("%s: expected %v got %d\n", desc, len(tc.backup.Groups), groupSize))
-		assert.Equal(t, len(tc.backup.Things), thingsSize, fmt.Sprintf("%s: expected %v got %d\n", desc, len(tc.backup.Things), thingsSize))
-		assert.Equal(t, len(tc.backup.Channels), channelsSize, fmt.Sprintf("%s: expected %v got %d\n", desc, len(tc.backup.Channels), channelsSize))
-		assert.Equal(t, len(tc.backup.Connections), connectionsSize, fmt.Sprintf("%s: expected %v got %d\n", desc, len(tc.backup.Connections), connectionsSize))
-		assert.Equal(t, len(tc.backup.GroupThingRelations), groupThingRelationsSize, fmt.Sprintf("%s: expected %v got %d\n", desc, len(tc.backup.GroupThingRelations), groupThingRelationsSize))
-		assert.Equal(t, len(tc.backup.GroupChannelRelations), groupChannelRelationsSize, fmt.Sprintf("%s: expected %v got %d\n", desc, len(tc.backup.GroupChannelRelations), groupChannelRelationsSize))
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
-
+	for _, tc := range cases {
+		dec := backup.NewDecoder(strings.NewReader(data))
+		summary, err := svc.ImportBackup(context.Background(), tc.token, dec, tc.strategy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.summary, summary, fmt.Sprintf("%s: expected summary %v got %v\n", tc.desc, tc.summary, summary))
+		}
 	}
+
+	// Wait for things and channels to connect.
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = svc.CanAccessByKey(context.Background(), chs[0].ID, ths[0].Key, things.PublishAction)
+	assert.Nil(t, err, fmt.Sprintf("restored thing expected to access restored channel: %s", err))
 }
 
-func TestRestore(t *testing.T) {
+func TestImportScopedBackup(t *testing.T) {
 	svc := newService()
-	idProvider := uuid.New()
 
-	thkey, err := idProvider.ID()
+	grs, err := svc.CreateGroups(context.Background(), token, group)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	thID, err := idProvider.ID()
+	gr := grs[0]
+
+	ths, err := svc.CreateThings(context.Background(), token, thing)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	chID, err := idProvider.ID()
+	th := ths[0]
+	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	var groups []things.Group
-	for i := uint64(0); i < 10; i++ {
-		num := strconv.FormatUint(i, 10)
-		gr := things.Group{
-			ID:          fmt.Sprintf("%s%012d", prefix, i+1),
-			Name:        "test-group-" + num,
-			Description: "test group desc",
+	prs, err := svc.CreateProfiles(context.Background(), otherToken, profile)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	otherPr := prs[0]
+
+	otherUserID := "other-user-id"
+	type record struct {
+		typ  string
+		data map[string]interface{}
+	}
+
+	cases := []struct {
+		desc     string
+		records  []record
+		strategy string
+		summary  backup.Summary
+		err      error
+	}{
+		{
+			desc:     "import new thing",
+			records:  []record{{"thing", map[string]interface{}{"id": prefix + "000000000101", "owner": user.ID, "key": prefix + "000000000101"}}},
+			strategy: backup.StrategyFail,
+			summary:  backup.Summary{Created: 1},
+			err:      nil,
+		},
+		{
+			desc:     "import new thing owned by other user",
+			records:  []record{{"thing", map[string]interface{}{"id": prefix + "000000000102", "owner": otherUserID, "key": prefix + "000000000102"}}},
+			strategy: backup.StrategyFail,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "import new channel with profile of other user",
+			records:  []record{{"channel", map[string]interface{}{"id": prefix + "000000000103", "profile_id": otherPr.ID}}},
+			strategy: backup.StrategyFail,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "import new group owned by other user",
+			records:  []record{{"group", map[string]interface{}{"id": prefix + "000000000104", "owner_id": otherUserID, "parent_id": gr.ID, "name": "child"}}},
+			strategy: backup.StrategyFail,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "import existing thing changing its owner",
+			records:  []record{{"thing", map[string]interface{}{"id": th.ID, "owner": otherUserID, "key": th.Key}}},
+			strategy: backup.StrategyOverwrite,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "import existing group moving it out of scope",
+			records:  []record{{"group", map[string]interface{}{"id": gr.ID, "owner_id": user.ID, "parent_id": wrongValue, "name": gr.Name}}},
+			strategy: backup.StrategyOverwrite,
+			err:      errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		var buf bytes.Buffer
+		enc := backup.NewEncoder(&buf)
+		err := enc.WriteHeader(backup.Header{Service: things.BackupService, Scope: &backup.Scope{Type: backup.ScopeGroup, ID: gr.ID}})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		for _, rec := range tc.records {
+			err := enc.Encode(rec.typ, rec.data)
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 		}
 
-		groups = append(groups, gr)
+		summary, err := svc.ImportBackup(context.Background(), token, backup.NewDecoder(&buf), tc.strategy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.summary, summary, fmt.Sprintf("%s: expected summary %v got %v\n", tc.desc, tc.summary, summary))
+		}
 	}
+}
 
-	var gtr []things.GroupThingRelation
-	for _, group := range groups {
-		grRel := things.GroupThingRelation{
-			GroupID: group.ID,
-			ThingID: thID,
+func countRecords(t *testing.T, r io.Reader) map[string]int {
+	dec := backup.NewDecoder(r)
+	_, err := dec.Header()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	records := map[string]int{}
+	for {
+		rec, err := dec.Next()
+		if err == io.EOF {
+			return records
 		}
-		gtr = append(gtr, grRel)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		records[rec.Type]++
 	}
+}
 
-	ths := []things.Thing{
+func TestUserQuotas(t *testing.T) {
+	quotas := map[string]*mainflux.QuotaRes{user.ID: {Things: 2, Channels: 1, Groups: 1}}
+	svc := newServiceWithQuotas(quotas)
+
+	ths, err := svc.CreateThings(context.Background(), token, things.Thing{Name: "a"}, things.Thing{Name: "b"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+
+	err = svc.RemoveThings(context.Background(), token, ths[1].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = svc.CreateThings(context.Background(), token, things.Thing{Name: "c"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	var buf bytes.Buffer
+	enc := backup.NewEncoder(&buf)
+	err = enc.WriteHeader(backup.Header{Service: things.BackupService, Scope: &backup.Scope{Type: backup.ScopeGroup, ID: gr.ID}})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	err = enc.Encode("thing", map[string]interface{}{"id": prefix + "000000000201", "key": prefix + "000000000201"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	data := buf.String()
+
+	cases := []struct {
+		desc   string
+		create func() error
+		err    error
+	}{
 		{
-			ID:       thID,
-			Name:     "testThing",
-			Owner:    adminEmail,
-			Key:      thkey,
-			Metadata: map[string]interface{}{},
+			desc: "create thing over quota",
+			create: func() error {
+				_, err := svc.CreateThings(context.Background(), token, thing)
+				return err
+			},
+			err: quota.ErrQuotaExceeded,
+		},
+		{
+			desc: "create channel over quota",
+			create: func() error {
+				_, err := svc.CreateChannels(context.Background(), token, channel)
+				return err
+			},
+			err: quota.ErrQuotaExceeded,
+		},
+		{
+			desc: "create group over quota",
+			create: func() error {
+				_, err := svc.CreateGroups(context.Background(), token, group)
+				return err
+			},
+			err: quota.ErrQuotaExceeded,
+		},
+		{
+			desc: "restore thing from trash over quota",
+			create: func() error {
+				return svc.RestoreThings(context.Background(), token, ths[1].ID)
+			},
+			err: quota.ErrQuotaExceeded,
+		},
+		{
+			desc: "import thing over quota",
+			create: func() error {
+				_, err := svc.ImportBackup(context.Background(), token, backup.NewDecoder(strings.NewReader(data)), backup.StrategyFail)
+				return err
+			},
+			err: quota.ErrQuotaExceeded,
+		},
+		{
+			desc: "create thing without quota",
+			create: func() error {
+				_, err := svc.CreateThings(context.Background(), otherToken, thing)
+				return err
+			},
+			err: nil,
 		},
 	}
-	th := ths[0]
 
-	var chs []things.Channel
-	n := uint64(10)
-	for i := uint64(0); i < n; i++ {
-		ch := things.Channel{
-			ID:       chID,
-			Name:     "testChannel",
-			Owner:    adminEmail,
-			Metadata: map[string]interface{}{},
-		}
-		ch.Name = fmt.Sprintf("name-%d", i)
-		chs = append(chs, ch)
+	for _, tc := range cases {
+		err := tc.create()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
-	ch := chs[0]
+}
 
-	var connections []things.Connection
-	conn := things.Connection{
-		ChannelID:    ch.ID,
-		ChannelOwner: ch.Owner,
-		ThingID:      th.ID,
-		ThingOwner:   th.Owner,
-	}
+// slowThingRepository delays saving things, which widens the window between
+// checking the quota and saving the things it admits.
+type slowThingRepository struct {
+	things.ThingRepository
+}
 
-	connections = append(connections, conn)
+func (r slowThingRepository) Save(ctx context.Context, ths ...things.Thing) ([]things.Thing, error) {
+	time.Sleep(10 * time.Millisecond)
+	return r.ThingRepository.Save(ctx, ths...)
+}
 
-	backup := things.Backup{
-		Groups:              groups,
-		Things:              ths,
-		Channels:            chs,
-		Connections:         connections,
-		GroupThingRelations: gtr,
+func TestConcurrentUserQuota(t *testing.T) {
+	limit := 5
+	quotas := map[string]*mainflux.QuotaRes{user.ID: {Things: uint64(limit)}}
+	auth := authmock.NewAuthServiceWithQuotas(admin.ID, usersList, quotas)
+	conns := make(chan mocks.Connection)
+	thingsRepo := mocks.NewThingRepository(conns)
+	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	svc := things.New(auth, slowThingRepository{thingsRepo}, mocks.NewThingKeyRepository(), channelsRepo, mocks.NewGroupRepository(), mocks.NewProfileRepository(), mocks.NewChannelCache(), mocks.NewThingCache(), uuid.NewMock())
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4*limit)
+	for i := 0; i < 4*limit; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.CreateThings(context.Background(), token, thing)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		assert.True(t, errors.Contains(err, quota.ErrQuotaExceeded), fmt.Sprintf("expected %s got %s\n", quota.ErrQuotaExceeded, err))
 	}
+	assert.Equal(t, limit, created, fmt.Sprintf("expected %d things created got %d\n", limit, created))
+}
 
-	cases := map[string]struct {
-		token  string
-		backup things.Backup
+func TestOrgQuotas(t *testing.T) {
+	quotas := map[string]*mainflux.QuotaRes{}
+	svc := newServiceWithQuotas(quotas)
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	gr := grs[0]
+	child := group
+	child.ParentID = gr.ID
+	grs, err = svc.CreateGroups(context.Background(), token, child)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	ch := grs[0]
+
+	ths, err := svc.CreateThings(context.Background(), token, things.Thing{Name: "a"}, things.Thing{Name: "b"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	orgQuota := &mainflux.QuotaRes{Things: 1, Channels: 1, GroupIDs: []string{gr.ID}}
+	quotas[gr.ID] = orgQuota
+	quotas[ch.ID] = orgQuota
+
+	cases := []struct {
+		desc   string
+		assign func() error
 		err    error
 	}{
-		"Restore backup": {
-			token:  adminToken,
-			backup: backup,
-			err:    nil,
+		{
+			desc: "assign thing within org quota",
+			assign: func() error {
+				return svc.AssignThing(context.Background(), token, gr.ID, ths[0].ID)
+			},
+			err: nil,
 		},
-		"Restore backup with invalid token": {
-			token: wrongValue,
-			err:   errors.ErrAuthentication,
+		{
+			desc: "assign thing to nested group over org quota",
+			assign: func() error {
+				return svc.AssignThing(context.Background(), token, ch.ID, ths[1].ID)
+			},
+			err: quota.ErrQuotaExceeded,
 		},
-		"Restore backup with empty token": {
-			token: "",
-			err:   errors.ErrAuthentication,
+		{
+			desc: "assign channel to nested group within org quota",
+			assign: func() error {
+				return svc.AssignChannel(context.Background(), token, ch.ID, chs[0].ID)
+			},
+			err: nil,
+		},
+		{
+			desc: "assign channel over org quota",
+			assign: func() error {
+				return svc.AssignChannel(context.Background(), token, gr.ID, chs[1].ID)
+			},
+			err: quota.ErrQuotaExceeded,
 		},
 	}
 
-	for desc, tc := range cases {
-		err := svc.Restore(context.Background(), tc.token, tc.backup)
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	for _, tc := range cases {
+		err := tc.assign()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
 
-func testSortThings(t *testing.T, pm things.PageMetadata, ths []things.Thing) {
-	switch pm.Order {
-	case "name":
-		current := ths[0]
-		for _, res := range ths {
-			if pm.Dir == "asc" {
-				assert.GreaterOrEqual(t, res.Name, current.Name)
-			}
-			if pm.Dir == "desc" {
-				assert.GreaterOrEqual(t, current.Name, res.Name)
-			}
-			current = res
-		}
-	default:
-		break
+func TestViewUsage(t *testing.T) {
+	quotas := map[string]*mainflux.QuotaRes{user.ID: {Things: 5}}
+	svc := newServiceWithQuotas(quotas)
+
+	_, err := svc.CreateThings(context.Background(), token, things.Thing{Name: "a"}, things.Thing{Name: "b"})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		usage map[string]quota.Usage
+		err   error
+	}{
+		{
+			desc:  "view usage",
+			token: token,
+			usage: map[string]quota.Usage{
+				quota.Things:   {Used: 2, Limit: 5},
+				quota.Channels: {Used: 0, Limit: 0},
+				quota.Groups:   {Used: 0, Limit: 0},
+			},
+			err: nil,
+		},
+		{
+			desc:  "view usage with wrong credentials",
+			token: wrongValue,
+			usage: nil,
+			err:   errors.ErrAuthentication,
+		},
 	}
-}
 
-func testSortChannels(t *testing.T, pm things.PageMetadata, chs []things.Channel) {
-	switch pm.Order {
-	case "name":
-		current := chs[0]
-		for _, res := range chs {
-			if pm.Dir == "asc" {
-				assert.GreaterOrEqual(t, res.Name, current.Name)
-			}
-			if pm.Dir == "desc" {
-				assert.GreaterOrEqual(t, current.Name, res.Name)
-			}
-			current = res
-		}
-	default:
-		break
+	for _, tc := range cases {
+		usage, err := svc.ViewUsage(context.Background(), tc.token, "")
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.usage, usage, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.usage, usage))
 	}
 }
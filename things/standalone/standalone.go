@@ -68,3 +68,7 @@ func (repo singleUserRepo) AssignRole(ctx context.Context, req *mainflux.AssignR
 func (repo singleUserRepo) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (r *mainflux.RetrieveRoleRes, err error) {
 	return &mainflux.RetrieveRoleRes{}, errUnsupported
 }
+
+func (repo singleUserRepo) RetrieveQuota(ctx context.Context, req *mainflux.QuotaReq, _ ...grpc.CallOption) (r *mainflux.QuotaRes, err error) {
+	return &mainflux.QuotaRes{}, nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	maxTagLen = 64
+
+	// tagNot negates a tag filter term.
+	tagNot = "!"
+	// tagOr separates the alternatives of a tag filter term.
+	tagOr = "|"
+)
+
+// ErrInvalidTag indicates a malformed tag.
+var ErrInvalidTag = errors.New("invalid tag")
+
+var tagRegexp = regexp.MustCompile(`^[A-Za-z0-9_.:/=-]+$`)
+
+// ValidateTag checks whether the tag is non-empty, at most 64 characters
+// long and consists of letters, digits and the characters _.:/=- only.
+func ValidateTag(tag string) error {
+	if len(tag) > maxTagLen || !tagRegexp.MatchString(tag) {
+		return errors.Wrap(ErrInvalidTag, errors.New(tag))
+	}
+
+	return nil
+}
+
+// NormalizeTags validates the tags and returns them sorted and without
+// duplicates.
+func NormalizeTags(tags []string) ([]string, error) {
+	set := make(map[string]bool)
+	res := []string{}
+	for _, t := range tags {
+		if err := ValidateTag(t); err != nil {
+			return nil, err
+		}
+		if set[t] {
+			continue
+		}
+		set[t] = true
+		res = append(res, t)
+	}
+	sort.Strings(res)
+
+	return res, nil
+}
+
+// TagTerm is a single term of a tag filter. A term is a list of tags
+// separated by "|", matching the entities having any of them, optionally
+// prefixed with "!" to match the entities having none of them instead.
+type TagTerm struct {
+	Tags    []string
+	Negated bool
+}
+
+// ParseTagTerm parses the tag filter term, e.g. "outdoor", "!faulty" or
+// "floor-1|floor-2".
+func ParseTagTerm(term string) (TagTerm, error) {
+	var tt TagTerm
+	if strings.HasPrefix(term, tagNot) {
+		tt.Negated = true
+		term = strings.TrimPrefix(term, tagNot)
+	}
+
+	for _, t := range strings.Split(term, tagOr) {
+		if err := ValidateTag(t); err != nil {
+			return TagTerm{}, errors.Wrap(ErrInvalidFilter, err)
+		}
+		tt.Tags = append(tt.Tags, t)
+	}
+
+	return tt, nil
+}
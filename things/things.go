@@ -5,6 +5,7 @@ package things
 
 import (
 	"context"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 )
@@ -32,6 +33,10 @@ type Thing struct {
 	Name     string
 	Key      string
 	Metadata Metadata
+	// ProfileID identifies the profile that the thing metadata conforms to.
+	ProfileID string
+	// Tags label the thing, e.g. to filter things or to apply group policies.
+	Tags []string
 }
 
 // Page contains page related metadata as well as list of things that
@@ -73,13 +78,43 @@ type ThingRepository interface {
 	// user and connected or not connected to specified channel.
 	RetrieveByChannel(ctx context.Context, owner, chID string, pm PageMetadata) (Page, error)
 
-	// Remove removes the things having the provided identifiers, that is owned
-	// by the specified user.
+	// RetrieveByProfile retrieves the subset of things owned by the specified
+	// user that are created from the specified profile.
+	RetrieveByProfile(ctx context.Context, owner, profileID string, pm PageMetadata) (Page, error)
+
+	// Remove moves the things having the provided identifiers, that are owned
+	// by the specified user, to the trash along with their keys, connections
+	// and group membership.
 	Remove(ctx context.Context, owner string, ids ...string) error
 
+	// RetrieveDeleted retrieves the subset of things in the trash that are
+	// owned by the specified user.
+	RetrieveDeleted(ctx context.Context, owner string, pm PageMetadata) (DeletedThingsPage, error)
+
+	// Restore moves the things having the provided identifiers, that are
+	// owned by the specified user, out of the trash. Connections and group
+	// membership are restored if the related channels and groups still exist.
+	Restore(ctx context.Context, owner string, ids ...string) error
+
+	// Purge permanently removes things moved to the trash before the
+	// provided time.
+	Purge(ctx context.Context, before time.Time) error
+
+	// AddTags adds the tags to the things having the provided identifiers,
+	// that are owned by the specified user.
+	AddTags(ctx context.Context, owner string, tags []string, ids ...string) error
+
+	// RemoveTags removes the tags from the things having the provided
+	// identifiers, that are owned by the specified user.
+	RemoveTags(ctx context.Context, owner string, tags []string, ids ...string) error
+
 	// RetrieveAll retrieves all things for all users.
 	RetrieveAll(ctx context.Context) ([]Thing, error)
 
+	// RetrieveUpdated retrieves all things, for all users, that were created
+	// or updated at or after the given time.
+	RetrieveUpdated(ctx context.Context, since time.Time) ([]Thing, error)
+
 	// RetrieveByAdmin retrieves all things for all users with pagination.
 	RetrieveByAdmin(ctx context.Context, pm PageMetadata) (Page, error)
 }
@@ -5,24 +5,31 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
 )
 
 const (
-	saveChannelsOp           = "save_channels"
-	updateChannelOp          = "update_channel"
-	retrieveChannelByIDOp    = "retrieve_channel_by_id"
-	retrieveByThingOp        = "retrieve_by_thing"
-	retrieveChannelConnsOp   = "retrieve_channels_conns"
-	removeChannelOp          = "retrieve_channel"
-	connectOp                = "connect"
-	disconnectOp             = "disconnect"
-	hasThingOp               = "has_thing"
-	hasThingByIDOp           = "has_thing_by_id"
-	retrieveAllChannelsOp    = "retrieve_all_channels"
-	retrieveAllConnectionsOp = "retrieve_all_connections"
+	saveChannelsOp            = "save_channels"
+	updateChannelOp           = "update_channel"
+	retrieveChannelByIDOp     = "retrieve_channel_by_id"
+	retrieveByThingOp         = "retrieve_by_thing"
+	retrieveChannelConnsOp    = "retrieve_channels_conns"
+	removeChannelOp           = "retrieve_channel"
+	connectOp                 = "connect"
+	disconnectOp              = "disconnect"
+	retrieveConnectionOp      = "retrieve_connection"
+	canAccessOp               = "can_access"
+	retrieveAllChannelsOp     = "retrieve_all_channels"
+	retrieveUpdatedChannelsOp = "retrieve_updated_channels"
+	retrieveAllConnectionsOp  = "retrieve_all_connections"
+	retrieveDeletedChannelsOp = "retrieve_deleted_channels"
+	restoreChannelsOp         = "restore_channels"
+	purgeChannelsOp           = "purge_channels"
+	addChannelTagsOp          = "add_channel_tags"
+	removeChannelTagsOp       = "remove_channel_tags"
 )
 
 var (
@@ -100,12 +107,52 @@ func (crm channelRepositoryMiddleware) Remove(ctx context.Context, owner string,
 	return crm.repo.Remove(ctx, owner, ids...)
 }
 
-func (crm channelRepositoryMiddleware) Connect(ctx context.Context, owner, chID string, thIDs []string) error {
+func (crm channelRepositoryMiddleware) RetrieveDeleted(ctx context.Context, owner string, pm things.PageMetadata) (things.DeletedChannelsPage, error) {
+	span := createSpan(ctx, crm.tracer, retrieveDeletedChannelsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveDeleted(ctx, owner, pm)
+}
+
+func (crm channelRepositoryMiddleware) Restore(ctx context.Context, owner string, ids ...string) error {
+	span := createSpan(ctx, crm.tracer, restoreChannelsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.Restore(ctx, owner, ids...)
+}
+
+func (crm channelRepositoryMiddleware) AddTags(ctx context.Context, owner string, tags []string, ids ...string) error {
+	span := createSpan(ctx, crm.tracer, addChannelTagsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.AddTags(ctx, owner, tags, ids...)
+}
+
+func (crm channelRepositoryMiddleware) RemoveTags(ctx context.Context, owner string, tags []string, ids ...string) error {
+	span := createSpan(ctx, crm.tracer, removeChannelTagsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RemoveTags(ctx, owner, tags, ids...)
+}
+
+func (crm channelRepositoryMiddleware) Purge(ctx context.Context, before time.Time) error {
+	span := createSpan(ctx, crm.tracer, purgeChannelsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.Purge(ctx, before)
+}
+
+func (crm channelRepositoryMiddleware) Connect(ctx context.Context, owner, chID string, thIDs []string, connType string) error {
 	span := createSpan(ctx, crm.tracer, connectOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.Connect(ctx, owner, chID, thIDs)
+	return crm.repo.Connect(ctx, owner, chID, thIDs, connType)
 }
 
 func (crm channelRepositoryMiddleware) Disconnect(ctx context.Context, owner, chID string, thIDs []string) error {
@@ -116,12 +163,12 @@ func (crm channelRepositoryMiddleware) Disconnect(ctx context.Context, owner, ch
 	return crm.repo.Disconnect(ctx, owner, chID, thIDs)
 }
 
-func (crm channelRepositoryMiddleware) RetrieveConnByThingKey(ctx context.Context, key string) (things.Connection, error) {
-	span := createSpan(ctx, crm.tracer, hasThingOp)
+func (crm channelRepositoryMiddleware) RetrieveConnection(ctx context.Context, chID, thID string) (things.Connection, error) {
+	span := createSpan(ctx, crm.tracer, retrieveConnectionOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.RetrieveConnByThingKey(ctx, key)
+	return crm.repo.RetrieveConnection(ctx, chID, thID)
 }
 
 func (crm channelRepositoryMiddleware) RetrieveAll(ctx context.Context) ([]things.Channel, error) {
@@ -132,6 +179,14 @@ func (crm channelRepositoryMiddleware) RetrieveAll(ctx context.Context) ([]thing
 	return crm.repo.RetrieveAll(ctx)
 }
 
+func (crm channelRepositoryMiddleware) RetrieveUpdated(ctx context.Context, since time.Time) ([]things.Channel, error) {
+	span := createSpan(ctx, crm.tracer, retrieveUpdatedChannelsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveUpdated(ctx, since)
+}
+
 func (crm channelRepositoryMiddleware) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.ChannelsPage, error) {
 	span := createSpan(ctx, crm.tracer, retrieveAllChannelsOp)
 	defer span.Finish()
@@ -162,20 +217,20 @@ func ChannelCacheMiddleware(tracer opentracing.Tracer, cache things.ChannelCache
 	}
 }
 
-func (ccm channelCacheMiddleware) Connect(ctx context.Context, chanID, thingID string) error {
+func (ccm channelCacheMiddleware) Connect(ctx context.Context, chanID, thingID, connType string) error {
 	span := createSpan(ctx, ccm.tracer, connectOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return ccm.cache.Connect(ctx, chanID, thingID)
+	return ccm.cache.Connect(ctx, chanID, thingID, connType)
 }
 
-func (ccm channelCacheMiddleware) HasThing(ctx context.Context, chanID, thingID string) bool {
-	span := createSpan(ctx, ccm.tracer, hasThingOp)
+func (ccm channelCacheMiddleware) CanAccess(ctx context.Context, chanID, thingID, action string) bool {
+	span := createSpan(ctx, ccm.tracer, canAccessOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return ccm.cache.HasThing(ctx, chanID, thingID)
+	return ccm.cache.CanAccess(ctx, chanID, thingID, action)
 }
 
 func (ccm channelCacheMiddleware) Disconnect(ctx context.Context, chanID, thingID string) error {
@@ -2,6 +2,7 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -15,8 +16,11 @@ const (
 	retrieveGroupByIDOp            = "retrieve_group_by_id"
 	retrieveGroupByIDsOp           = "retrieve_group_by_ids"
 	retrieveByOwnerOp              = "retrieve_by_owner"
+	retrieveChildrenOp             = "retrieve_children"
+	moveGroupOp                    = "move_group"
 	retrieveThingMembershipOp      = "retrieve_thing_membership"
 	retrieveChannelMembershipOp    = "retrieve_channel_membership"
+	retrieveThingIDsOp             = "retrieve_thing_ids"
 	retrieveGroupThingsOp          = "retrieve_group_things"
 	retrieveGroupThingsByChannelOp = "retrieve_group_things_by_channel"
 	retrieveGroupChannelsOp        = "retrieve_group_channels"
@@ -26,6 +30,11 @@ const (
 	unassignChannelOp              = "unassign_channel"
 	retrieveAllThingRelationsOp    = "retrieve_all_thing_relations"
 	retrieveAllChannelRelationsOp  = "retrieve_all_channel_relations"
+	retrieveDeletedGroupsOp        = "retrieve_deleted_groups"
+	restoreGroupsOp                = "restore_groups"
+	purgeGroupsOp                  = "purge_groups"
+	addGroupTagsOp                 = "add_group_tags"
+	removeGroupTagsOp              = "remove_group_tags"
 )
 
 var _ things.GroupRepository = (*groupRepositoryMiddleware)(nil)
@@ -67,6 +76,46 @@ func (grm groupRepositoryMiddleware) Remove(ctx context.Context, groupIDs ...str
 	return grm.repo.Remove(ctx, groupIDs...)
 }
 
+func (grm groupRepositoryMiddleware) RetrieveDeleted(ctx context.Context, ownerID string, pm things.PageMetadata) (things.DeletedGroupsPage, error) {
+	span := createSpan(ctx, grm.tracer, retrieveDeletedGroupsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveDeleted(ctx, ownerID, pm)
+}
+
+func (grm groupRepositoryMiddleware) Restore(ctx context.Context, ownerID string, ids ...string) error {
+	span := createSpan(ctx, grm.tracer, restoreGroupsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.Restore(ctx, ownerID, ids...)
+}
+
+func (grm groupRepositoryMiddleware) AddTags(ctx context.Context, ownerID string, tags []string, ids ...string) error {
+	span := createSpan(ctx, grm.tracer, addGroupTagsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.AddTags(ctx, ownerID, tags, ids...)
+}
+
+func (grm groupRepositoryMiddleware) RemoveTags(ctx context.Context, ownerID string, tags []string, ids ...string) error {
+	span := createSpan(ctx, grm.tracer, removeGroupTagsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RemoveTags(ctx, ownerID, tags, ids...)
+}
+
+func (grm groupRepositoryMiddleware) Purge(ctx context.Context, before time.Time) error {
+	span := createSpan(ctx, grm.tracer, purgeGroupsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.Purge(ctx, before)
+}
+
 func (grm groupRepositoryMiddleware) RetrieveAll(ctx context.Context) ([]things.Group, error) {
 	span := createSpan(ctx, grm.tracer, retrieveAllOp)
 	defer span.Finish()
@@ -98,6 +147,22 @@ func (grm groupRepositoryMiddleware) RetrieveByIDs(ctx context.Context, groupIDs
 	return grm.repo.RetrieveByIDs(ctx, groupIDs)
 }
 
+func (grm groupRepositoryMiddleware) RetrieveChildren(ctx context.Context, groupID string, depth uint64, pm things.PageMetadata) (things.GroupPage, error) {
+	span := createSpan(ctx, grm.tracer, retrieveChildrenOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveChildren(ctx, groupID, depth, pm)
+}
+
+func (grm groupRepositoryMiddleware) Move(ctx context.Context, groupID, parentID string) error {
+	span := createSpan(ctx, grm.tracer, moveGroupOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.Move(ctx, groupID, parentID)
+}
+
 func (grm groupRepositoryMiddleware) RetrieveByOwner(ctx context.Context, ownerID string, pm things.PageMetadata) (things.GroupPage, error) {
 	span := createSpan(ctx, grm.tracer, retrieveByOwnerOp)
 	defer span.Finish()
@@ -114,6 +179,14 @@ func (grm groupRepositoryMiddleware) RetrieveThingMembership(ctx context.Context
 	return grm.repo.RetrieveThingMembership(ctx, thingID)
 }
 
+func (grm groupRepositoryMiddleware) RetrieveThingIDs(ctx context.Context, groupIDs []string) ([]string, error) {
+	span := createSpan(ctx, grm.tracer, retrieveThingIDsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return grm.repo.RetrieveThingIDs(ctx, groupIDs)
+}
+
 func (grm groupRepositoryMiddleware) RetrieveGroupThings(ctx context.Context, groupID string, pm things.PageMetadata) (things.GroupThingsPage, error) {
 	span := createSpan(ctx, grm.tracer, retrieveGroupThingsOp)
 	defer span.Finish()
@@ -0,0 +1,93 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/things"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveThingKeysOp            = "save_thing_keys"
+	updateThingKeyEntryOp      = "update_thing_key_entry"
+	retrieveThingKeyByIDOp     = "retrieve_thing_key_by_id"
+	retrieveThingKeyByKeyOp    = "retrieve_thing_key_by_key"
+	retrieveThingKeysByThingOp = "retrieve_thing_keys_by_thing"
+	retrieveAllThingKeysOp     = "retrieve_all_thing_keys"
+	removeThingKeyOp           = "remove_thing_key"
+)
+
+var _ things.ThingKeyRepository = (*thingKeyRepositoryMiddleware)(nil)
+
+type thingKeyRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   things.ThingKeyRepository
+}
+
+// ThingKeyRepositoryMiddleware tracks request and their latency, and adds spans
+// to context.
+func ThingKeyRepositoryMiddleware(tracer opentracing.Tracer, repo things.ThingKeyRepository) things.ThingKeyRepository {
+	return thingKeyRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (krm thingKeyRepositoryMiddleware) Save(ctx context.Context, keys ...things.ThingKey) ([]things.ThingKey, error) {
+	span := createSpan(ctx, krm.tracer, saveThingKeysOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.Save(ctx, keys...)
+}
+
+func (krm thingKeyRepositoryMiddleware) Update(ctx context.Context, key things.ThingKey) error {
+	span := createSpan(ctx, krm.tracer, updateThingKeyEntryOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.Update(ctx, key)
+}
+
+func (krm thingKeyRepositoryMiddleware) RetrieveByID(ctx context.Context, thingID, id string) (things.ThingKey, error) {
+	span := createSpan(ctx, krm.tracer, retrieveThingKeyByIDOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RetrieveByID(ctx, thingID, id)
+}
+
+func (krm thingKeyRepositoryMiddleware) RetrieveByKey(ctx context.Context, key string) (things.ThingKey, error) {
+	span := createSpan(ctx, krm.tracer, retrieveThingKeyByKeyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RetrieveByKey(ctx, key)
+}
+
+func (krm thingKeyRepositoryMiddleware) RetrieveByThing(ctx context.Context, thingID string, pm things.PageMetadata) (things.ThingKeysPage, error) {
+	span := createSpan(ctx, krm.tracer, retrieveThingKeysByThingOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RetrieveByThing(ctx, thingID, pm)
+}
+
+func (krm thingKeyRepositoryMiddleware) RetrieveAll(ctx context.Context) ([]things.ThingKey, error) {
+	span := createSpan(ctx, krm.tracer, retrieveAllThingKeysOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RetrieveAll(ctx)
+}
+
+func (krm thingKeyRepositoryMiddleware) Remove(ctx context.Context, thingID, id string) error {
+	span := createSpan(ctx, krm.tracer, removeThingKeyOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.Remove(ctx, thingID, id)
+}
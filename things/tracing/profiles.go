@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/things"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveProfilesOp            = "save_profiles"
+	updateProfileOp           = "update_profile"
+	retrieveProfileByIDOp     = "retrieve_profile_by_id"
+	retrieveProfilesByOwnerOp = "retrieve_profiles_by_owner"
+	retrieveAllProfilesOp     = "retrieve_all_profiles"
+	removeProfilesOp          = "remove_profiles"
+)
+
+var _ things.ProfileRepository = (*profileRepositoryMiddleware)(nil)
+
+type profileRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   things.ProfileRepository
+}
+
+// ProfileRepositoryMiddleware tracks request and their latency, and adds spans
+// to context.
+func ProfileRepositoryMiddleware(tracer opentracing.Tracer, repo things.ProfileRepository) things.ProfileRepository {
+	return profileRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (prm profileRepositoryMiddleware) Save(ctx context.Context, prs ...things.Profile) ([]things.Profile, error) {
+	span := createSpan(ctx, prm.tracer, saveProfilesOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Save(ctx, prs...)
+}
+
+func (prm profileRepositoryMiddleware) Update(ctx context.Context, pr things.Profile) error {
+	span := createSpan(ctx, prm.tracer, updateProfileOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Update(ctx, pr)
+}
+
+func (prm profileRepositoryMiddleware) RetrieveByID(ctx context.Context, id string) (things.Profile, error) {
+	span := createSpan(ctx, prm.tracer, retrieveProfileByIDOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveByID(ctx, id)
+}
+
+func (prm profileRepositoryMiddleware) RetrieveByOwner(ctx context.Context, ownerID string, pm things.PageMetadata) (things.ProfilesPage, error) {
+	span := createSpan(ctx, prm.tracer, retrieveProfilesByOwnerOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveByOwner(ctx, ownerID, pm)
+}
+
+func (prm profileRepositoryMiddleware) RetrieveAll(ctx context.Context) ([]things.Profile, error) {
+	span := createSpan(ctx, prm.tracer, retrieveAllProfilesOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.RetrieveAll(ctx)
+}
+
+func (prm profileRepositoryMiddleware) Remove(ctx context.Context, ownerID string, ids ...string) error {
+	span := createSpan(ctx, prm.tracer, removeProfilesOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return prm.repo.Remove(ctx, ownerID, ids...)
+}
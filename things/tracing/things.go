@@ -5,6 +5,7 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/things"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -20,10 +21,16 @@ const (
 	retrieveThingByKeyOp      = "retrieve_thing_by_key"
 	retrieveThingsByOwnerOp   = "retrieve_things_by_owner"
 	retrieveThingsByChannelOp = "retrieve_things_by_chan"
+	retrieveThingsByProfileOp = "retrieve_things_by_profile"
 	removeThingOp             = "remove_thing"
 	retrieveThingIDByKeyOp    = "retrieve_id_by_key"
 	retrieveAllThingsOp       = "retrieve_all_things"
+	retrieveUpdatedThingsOp   = "retrieve_updated_things"
 	restoreThingsOp           = "restore_things"
+	retrieveDeletedThingsOp   = "retrieve_deleted_things"
+	purgeThingsOp             = "purge_things"
+	addThingTagsOp            = "add_thing_tags"
+	removeThingTagsOp         = "remove_thing_tags"
 )
 
 var (
@@ -109,6 +116,14 @@ func (trm thingRepositoryMiddleware) RetrieveByChannel(ctx context.Context, owne
 	return trm.repo.RetrieveByChannel(ctx, owner, chID, pm)
 }
 
+func (trm thingRepositoryMiddleware) RetrieveByProfile(ctx context.Context, owner, profileID string, pm things.PageMetadata) (things.Page, error) {
+	span := createSpan(ctx, trm.tracer, retrieveThingsByProfileOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveByProfile(ctx, owner, profileID, pm)
+}
+
 func (trm thingRepositoryMiddleware) Remove(ctx context.Context, owner string, ids ...string) error {
 	span := createSpan(ctx, trm.tracer, removeThingOp)
 	defer span.Finish()
@@ -117,6 +132,46 @@ func (trm thingRepositoryMiddleware) Remove(ctx context.Context, owner string, i
 	return trm.repo.Remove(ctx, owner, ids...)
 }
 
+func (trm thingRepositoryMiddleware) RetrieveDeleted(ctx context.Context, owner string, pm things.PageMetadata) (things.DeletedThingsPage, error) {
+	span := createSpan(ctx, trm.tracer, retrieveDeletedThingsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveDeleted(ctx, owner, pm)
+}
+
+func (trm thingRepositoryMiddleware) Restore(ctx context.Context, owner string, ids ...string) error {
+	span := createSpan(ctx, trm.tracer, restoreThingsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Restore(ctx, owner, ids...)
+}
+
+func (trm thingRepositoryMiddleware) AddTags(ctx context.Context, owner string, tags []string, ids ...string) error {
+	span := createSpan(ctx, trm.tracer, addThingTagsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.AddTags(ctx, owner, tags, ids...)
+}
+
+func (trm thingRepositoryMiddleware) RemoveTags(ctx context.Context, owner string, tags []string, ids ...string) error {
+	span := createSpan(ctx, trm.tracer, removeThingTagsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RemoveTags(ctx, owner, tags, ids...)
+}
+
+func (trm thingRepositoryMiddleware) Purge(ctx context.Context, before time.Time) error {
+	span := createSpan(ctx, trm.tracer, purgeThingsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.Purge(ctx, before)
+}
+
 func (trm thingRepositoryMiddleware) RetrieveAll(ctx context.Context) ([]things.Thing, error) {
 	span := createSpan(ctx, trm.tracer, retrieveAllThingsOp)
 	defer span.Finish()
@@ -125,6 +180,14 @@ func (trm thingRepositoryMiddleware) RetrieveAll(ctx context.Context) ([]things.
 	return trm.repo.RetrieveAll(ctx)
 }
 
+func (trm thingRepositoryMiddleware) RetrieveUpdated(ctx context.Context, since time.Time) ([]things.Thing, error) {
+	span := createSpan(ctx, trm.tracer, retrieveUpdatedThingsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveUpdated(ctx, since)
+}
+
 func (trm thingRepositoryMiddleware) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.Page, error) {
 	span := createSpan(ctx, trm.tracer, retrieveAllThingsOp)
 	defer span.Finish()
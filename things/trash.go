@@ -0,0 +1,54 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package things
+
+import "time"
+
+// DeletedThing represents a soft-deleted thing along with the channels it
+// was connected to and the group it was a member of when it was removed.
+type DeletedThing struct {
+	Thing
+	ChannelIDs []string
+	GroupID    string
+	DeletedAt  time.Time
+}
+
+// DeletedThingsPage contains page related metadata as well as list of
+// soft-deleted things that belong to this page.
+type DeletedThingsPage struct {
+	PageMetadata
+	Things []DeletedThing
+}
+
+// DeletedChannel represents a soft-deleted channel along with the things
+// connected to it and the group it was a member of when it was removed.
+type DeletedChannel struct {
+	Channel
+	ThingIDs  []string
+	GroupID   string
+	DeletedAt time.Time
+}
+
+// DeletedChannelsPage contains page related metadata as well as list of
+// soft-deleted channels that belong to this page.
+type DeletedChannelsPage struct {
+	PageMetadata
+	Channels []DeletedChannel
+}
+
+// DeletedGroup represents a soft-deleted group along with its members at
+// the time it was removed.
+type DeletedGroup struct {
+	Group
+	ThingIDs   []string
+	ChannelIDs []string
+	DeletedAt  time.Time
+}
+
+// DeletedGroupsPage contains page related metadata as well as list of
+// soft-deleted groups that belong to this page.
+type DeletedGroupsPage struct {
+	PageMetadata
+	Groups []DeletedGroup
+}
@@ -259,6 +259,38 @@ func restoreEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func exportBackupEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportBackupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		stream, err := svc.ExportBackup(ctx, req.token, req.since)
+		if err != nil {
+			return nil, err
+		}
+
+		return exportBackupRes{stream: stream}, nil
+	}
+}
+
+func importBackupEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(importBackupReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		summary, err := svc.ImportBackup(ctx, req.token, req.decoder, req.strategy)
+		if err != nil {
+			return nil, err
+		}
+
+		return importBackupRes(summary), nil
+	}
+}
+
 func buildUsersResponse(up users.UserPage) userPageRes {
 	res := userPageRes{
 		pageRes: pageRes{
@@ -328,3 +360,56 @@ func buildBackup(req restoreReq) (users.User, []users.User) {
 
 	return admin, u
 }
+
+func listLockoutsEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listLockoutsReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		lp, err := svc.ListLockouts(ctx, req.token, req.lockoutType, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildLockoutsResponse(lp), nil
+	}
+}
+
+func clearLockoutEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(clearLockoutReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ClearLockout(ctx, req.token, req.lockoutType, req.subject); err != nil {
+			return nil, err
+		}
+
+		return deleteRes{}, nil
+	}
+}
+
+func buildLockoutsResponse(lp users.LockoutsPage) lockoutsPageRes {
+	res := lockoutsPageRes{
+		pageRes: pageRes{
+			Total:  lp.Total,
+			Offset: lp.Offset,
+			Limit:  lp.Limit,
+		},
+		Lockouts: []lockoutRes{},
+	}
+
+	for _, l := range lp.Lockouts {
+		res.Lockouts = append(res.Lockouts, lockoutRes{
+			Type:        l.Type,
+			Subject:     l.Subject,
+			Lockouts:    l.Lockouts,
+			LockedUntil: l.LockedUntil,
+		})
+	}
+
+	return res
+}
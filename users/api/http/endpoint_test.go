@@ -15,10 +15,12 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
@@ -59,6 +61,18 @@ var (
 	invalidRestPassRes = toJSON(apiutil.ErrorRes{Err: apiutil.ErrInvalidResetPass.Error()})
 	idProvider         = uuid.New()
 	passRegex          = regexp.MustCompile("^.{8,}$")
+	lockedOutRes       = toJSON(apiutil.ErrorRes{Err: users.ErrLockedOut.Error()})
+	breachedPass       = "breached-password"
+	passPolicy         = users.PasswordPolicy{Regex: passRegex}
+	lockoutCfg         = users.LockoutConfig{
+		MaxAttempts:   3,
+		MaxIPAttempts: 10,
+		Duration:      time.Minute,
+		MaxDuration:   time.Hour,
+		Window:        time.Hour,
+		ResetLimit:    3,
+		ResetWindow:   time.Hour,
+	}
 )
 
 type testRequest struct {
@@ -91,7 +105,9 @@ func newService() users.Service {
 	hasher := usmocks.NewHasher()
 	auth := mocks.NewAuthService(admin.ID, usersList)
 	email := usmocks.NewEmailer()
-	return users.New(usersRepo, hasher, auth, email, idProvider, passRegex)
+	pp := passPolicy
+	pp.Breached, _ = users.ReadBreachedPasswords(strings.NewReader(breachedPass))
+	return users.New(usersRepo, hasher, auth, email, idProvider, pp, usmocks.NewLockoutRepository(), lockoutCfg)
 }
 
 func newServer(svc users.Service) *httptest.Server {
@@ -113,6 +129,7 @@ func TestSelfRegister(t *testing.T) {
 	data := toJSON(newUser)
 	invalidData := toJSON(users.User{Email: invalidEmail, Password: validPass})
 	invalidPasswordData := toJSON(users.User{Email: validEmail, Password: invalidPass})
+	breachedPasswordData := toJSON(users.User{Email: validEmail, Password: breachedPass})
 	invalidFieldData := fmt.Sprintf(`{"email": "%s", "pass": "%s"}`, user.Email, user.Password)
 
 	cases := []struct {
@@ -123,6 +140,7 @@ func TestSelfRegister(t *testing.T) {
 		token       string
 	}{
 		{"register new user", data, contentType, http.StatusCreated, ""},
+		{"register user with breached password", breachedPasswordData, contentType, http.StatusBadRequest, ""},
 		{"register existing user", data, contentType, http.StatusConflict, ""},
 		{"register user with invalid email address", invalidData, contentType, http.StatusBadRequest, ""},
 		{"register user with weak password", invalidPasswordData, contentType, http.StatusBadRequest, ""},
@@ -713,6 +731,193 @@ func TestPasswordChange(t *testing.T) {
 	}
 }
 
+func TestLoginLockout(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	data := toJSON(user)
+	invalidData := toJSON(users.User{
+		Email:    user.Email,
+		Password: "invalid_password",
+	})
+
+	cases := []struct {
+		desc   string
+		req    string
+		status int
+		res    string
+	}{
+		{"login with invalid credentials", invalidData, http.StatusUnauthorized, unauthRes},
+		{"login with invalid credentials", invalidData, http.StatusUnauthorized, unauthRes},
+		{"login with invalid credentials reaching the limit", invalidData, http.StatusUnauthorized, unauthRes},
+		{"login locked account with valid credentials", data, http.StatusTooManyRequests, lockedOutRes},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/tokens", ts.URL),
+			contentType: contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		resBody := strings.Trim(string(body), "\n")
+
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, resBody, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, resBody))
+	}
+}
+
+func TestListLockouts(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	for i := uint64(0); i < lockoutCfg.MaxAttempts; i++ {
+		_, err := svc.Login(context.Background(), users.User{Email: user.Email, Password: invalidPass})
+		require.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("expected %s got %s", errors.ErrAuthentication, err))
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		url    string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list lockouts",
+			token:  admin.Email,
+			url:    fmt.Sprintf("%s/lockouts", ts.URL),
+			status: http.StatusOK,
+			size:   1,
+		},
+		{
+			desc:   "list account lockouts",
+			token:  admin.Email,
+			url:    fmt.Sprintf("%s/lockouts?type=%s", ts.URL, users.AccountLockout),
+			status: http.StatusOK,
+			size:   1,
+		},
+		{
+			desc:   "list IP lockouts",
+			token:  admin.Email,
+			url:    fmt.Sprintf("%s/lockouts?type=%s", ts.URL, users.IPLockout),
+			status: http.StatusOK,
+			size:   0,
+		},
+		{
+			desc:   "list lockouts of invalid type",
+			token:  admin.Email,
+			url:    fmt.Sprintf("%s/lockouts?type=invalid", ts.URL),
+			status: http.StatusBadRequest,
+			size:   0,
+		},
+		{
+			desc:   "list lockouts with limit greater than max",
+			token:  admin.Email,
+			url:    fmt.Sprintf("%s/lockouts?limit=110", ts.URL),
+			status: http.StatusBadRequest,
+			size:   0,
+		},
+		{
+			desc:   "list lockouts as non-admin user",
+			token:  user.Email,
+			url:    fmt.Sprintf("%s/lockouts", ts.URL),
+			status: http.StatusForbidden,
+			size:   0,
+		},
+		{
+			desc:   "list lockouts with empty token",
+			token:  "",
+			url:    fmt.Sprintf("%s/lockouts", ts.URL),
+			status: http.StatusUnauthorized,
+			size:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		var data lockoutsRes
+		json.NewDecoder(res.Body).Decode(&data)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.size, len(data.Lockouts), fmt.Sprintf("%s: expected size %d got %d", tc.desc, tc.size, len(data.Lockouts)))
+	}
+}
+
+func TestClearLockout(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	for i := uint64(0); i < lockoutCfg.MaxAttempts; i++ {
+		_, err := svc.Login(context.Background(), users.User{Email: user.Email, Password: invalidPass})
+		require.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("expected %s got %s", errors.ErrAuthentication, err))
+	}
+
+	cases := []struct {
+		desc        string
+		token       string
+		lockoutType string
+		status      int
+	}{
+		{
+			desc:        "clear lockout as non-admin user",
+			token:       user.Email,
+			lockoutType: users.AccountLockout,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "clear lockout with empty token",
+			token:       "",
+			lockoutType: users.AccountLockout,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "clear lockout of invalid type",
+			token:       admin.Email,
+			lockoutType: "invalid",
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "clear account lockout",
+			token:       admin.Email,
+			lockoutType: users.AccountLockout,
+			status:      http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodDelete,
+			url:    fmt.Sprintf("%s/lockouts/%s/%s", ts.URL, tc.lockoutType, user.Email),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+
+	_, err := svc.Login(context.Background(), user)
+	assert.Nil(t, err, fmt.Sprintf("login after clearing lockout: unexpected error: %s", err))
+}
+
 type viewUserRes struct {
 	ID    string `json:"id"`
 	Email string `json:"email"`
@@ -728,3 +933,88 @@ type userRes struct {
 	pageRes
 	Users []viewUserRes `json:"users"`
 }
+
+type lockoutRes struct {
+	Type    string `json:"type"`
+	Subject string `json:"subject"`
+}
+
+type lockoutsRes struct {
+	pageRes
+	Lockouts []lockoutRes `json:"lockouts"`
+}
+
+func TestExportBackup(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	since := time.Now().UTC()
+	_, err := svc.Register(context.Background(), admin.Email, users.User{Email: "export@example.com", Password: validPass})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		token  string
+		url    string
+		status int
+		users  int
+	}{
+		{
+			desc:   "export backup",
+			token:  admin.Email,
+			url:    fmt.Sprintf("%s/backup/export", ts.URL),
+			status: http.StatusOK,
+			users:  len(usersList),
+		},
+		{
+			desc:   "export incremental backup",
+			token:  admin.Email,
+			url:    fmt.Sprintf("%s/backup/export?since=%s", ts.URL, since.Format(time.RFC3339Nano)),
+			status: http.StatusOK,
+			users:  1,
+		},
+		{
+			desc:   "export backup with invalid since",
+			token:  admin.Email,
+			url:    fmt.Sprintf("%s/backup/export?since=invalid", ts.URL),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export backup as non-admin user",
+			token:  user.Email,
+			url:    fmt.Sprintf("%s/backup/export", ts.URL),
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if res.StatusCode != http.StatusOK {
+			continue
+		}
+
+		dec := backup.NewDecoder(res.Body)
+		var count int
+		for {
+			rec, err := dec.Next()
+			if err == io.EOF {
+				break
+			}
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			if rec.Type == "user" {
+				count++
+			}
+		}
+		assert.Equal(t, tc.users, count, fmt.Sprintf("%s: expected %d users got %d", tc.desc, tc.users, count))
+	}
+}
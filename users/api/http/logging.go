@@ -11,6 +11,7 @@ import (
 	"time"
 
 	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/users"
 )
 
@@ -254,3 +255,55 @@ func (lm *loggingMiddleware) Restore(ctx context.Context, token string, admin us
 
 	return lm.svc.Restore(ctx, token, admin, users)
 }
+
+func (lm *loggingMiddleware) ExportBackup(ctx context.Context, token string, since time.Time) (stream backup.Stream, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method export_backup for token %s since %s took %s to complete", token, since, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ExportBackup(ctx, token, since)
+}
+
+func (lm *loggingMiddleware) ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (summary backup.Summary, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method import_backup for token %s with strategy %s took %s to complete", token, strategy, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ImportBackup(ctx, token, dec, strategy)
+}
+
+func (lm *loggingMiddleware) ListLockouts(ctx context.Context, token, lockoutType string, offset, limit uint64) (lp users.LockoutsPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_lockouts for type %s took %s to complete", lockoutType, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListLockouts(ctx, token, lockoutType, offset, limit)
+}
+
+func (lm *loggingMiddleware) ClearLockout(ctx context.Context, token, lockoutType, subject string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method clear_lockout for %s %s took %s to complete", lockoutType, subject, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ClearLockout(ctx, token, lockoutType, subject)
+}
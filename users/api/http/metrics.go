@@ -9,6 +9,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/users"
 	"github.com/go-kit/kit/metrics"
 )
@@ -191,3 +192,39 @@ func (ms *metricsMiddleware) Restore(ctx context.Context, token string, admin us
 
 	return ms.svc.Restore(ctx, token, admin, users)
 }
+
+func (ms *metricsMiddleware) ExportBackup(ctx context.Context, token string, since time.Time) (backup.Stream, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "export_backup").Add(1)
+		ms.latency.With("method", "export_backup").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ExportBackup(ctx, token, since)
+}
+
+func (ms *metricsMiddleware) ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (backup.Summary, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "import_backup").Add(1)
+		ms.latency.With("method", "import_backup").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ImportBackup(ctx, token, dec, strategy)
+}
+
+func (ms *metricsMiddleware) ListLockouts(ctx context.Context, token, lockoutType string, offset, limit uint64) (users.LockoutsPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_lockouts").Add(1)
+		ms.latency.With("method", "list_lockouts").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListLockouts(ctx, token, lockoutType, offset, limit)
+}
+
+func (ms *metricsMiddleware) ClearLockout(ctx context.Context, token, lockoutType, subject string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "clear_lockout").Add(1)
+		ms.latency.With("method", "clear_lockout").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ClearLockout(ctx, token, lockoutType, subject)
+}
@@ -4,7 +4,10 @@
 package http
 
 import (
+	"time"
+
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/users"
 )
@@ -181,6 +184,18 @@ func (req backupReq) validate() error {
 	return nil
 }
 
+type exportBackupReq struct {
+	token string
+	since time.Time
+}
+
+func (req exportBackupReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	return nil
+}
+
 type restoreUserReq struct {
 	ID       string                 `json:"id"`
 	Email    string                 `json:"email"`
@@ -209,3 +224,54 @@ func (req restoreReq) validate() error {
 
 	return nil
 }
+
+type listLockoutsReq struct {
+	token       string
+	lockoutType string
+	offset      uint64
+	limit       uint64
+}
+
+func (req listLockoutsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type clearLockoutReq struct {
+	token       string
+	lockoutType string
+	subject     string
+}
+
+func (req clearLockoutReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.subject == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type importBackupReq struct {
+	token    string
+	strategy string
+	decoder  *backup.Decoder
+}
+
+func (req importBackupReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return backup.ValidateStrategy(req.strategy)
+}
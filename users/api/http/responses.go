@@ -6,8 +6,10 @@ package http
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 )
 
 var (
@@ -16,6 +18,8 @@ var (
 	_ mainflux.Response = (*passwChangeRes)(nil)
 	_ mainflux.Response = (*createUserRes)(nil)
 	_ mainflux.Response = (*deleteRes)(nil)
+	_ mainflux.Response = (*lockoutsPageRes)(nil)
+	_ mainflux.Response = (*importBackupRes)(nil)
 )
 
 // MailSent message response when link is sent
@@ -203,3 +207,49 @@ func (res restoreRes) Headers() map[string]string {
 func (res restoreRes) Empty() bool {
 	return true
 }
+
+type exportBackupRes struct {
+	stream backup.Stream
+}
+
+type importBackupRes struct {
+	Created uint64 `json:"created"`
+	Updated uint64 `json:"updated"`
+	Skipped uint64 `json:"skipped"`
+}
+
+func (res importBackupRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res importBackupRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res importBackupRes) Empty() bool {
+	return false
+}
+
+type lockoutRes struct {
+	Type        string    `json:"type"`
+	Subject     string    `json:"subject"`
+	Lockouts    uint64    `json:"lockouts"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+type lockoutsPageRes struct {
+	pageRes
+	Lockouts []lockoutRes `json:"lockouts"`
+}
+
+func (res lockoutsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res lockoutsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res lockoutsPageRes) Empty() bool {
+	return false
+}
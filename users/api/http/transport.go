@@ -8,10 +8,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/users"
@@ -29,6 +31,9 @@ const (
 	emailKey    = "email"
 	metadataKey = "metadata"
 	statusKey   = "status"
+	typeKey     = "type"
+	strategyKey = "strategy"
+	sinceKey    = "since"
 	defOffset   = 0
 	defLimit    = 10
 )
@@ -37,6 +42,7 @@ const (
 func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Logger) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+		kithttp.ServerBefore(apiutil.ClientIPToContext),
 	}
 
 	mux := bone.New()
@@ -139,6 +145,34 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Log
 		opts...,
 	))
 
+	mux.Get("/backup/export", kithttp.NewServer(
+		kitot.TraceServer(tracer, "export_backup")(exportBackupEndpoint(svc)),
+		decodeExportBackup,
+		encodeBackupStream,
+		opts...,
+	))
+
+	mux.Post("/backup/import", kithttp.NewServer(
+		kitot.TraceServer(tracer, "import_backup")(importBackupEndpoint(svc)),
+		decodeImportBackup,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/lockouts", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_lockouts")(listLockoutsEndpoint(svc)),
+		decodeListLockouts,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/lockouts/:type/:subject", kithttp.NewServer(
+		kitot.TraceServer(tracer, "clear_lockout")(clearLockoutEndpoint(svc)),
+		decodeClearLockout,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.GetFunc("/health", mainflux.Health("users"))
 	mux.Handle("/metrics", promhttp.Handler())
 
@@ -306,6 +340,27 @@ func decodeBackup(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeExportBackup(_ context.Context, r *http.Request) (interface{}, error) {
+	s, err := apiutil.ReadStringQuery(r, sinceKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if s != "" {
+		if since, err = time.Parse(time.RFC3339, s); err != nil {
+			return nil, errors.Wrap(apiutil.ErrInvalidQueryParams, err)
+		}
+	}
+
+	req := exportBackupReq{
+		token: apiutil.ExtractBearerToken(r),
+		since: since,
+	}
+
+	return req, nil
+}
+
 func decodeRestore(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, apiutil.ErrUnsupportedContentType
@@ -319,6 +374,42 @@ func decodeRestore(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeListLockouts(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := apiutil.ReadStringQuery(r, typeKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req := listLockoutsReq{
+		token:       apiutil.ExtractBearerToken(r),
+		lockoutType: t,
+		offset:      o,
+		limit:       l,
+	}
+
+	return req, nil
+}
+
+func decodeClearLockout(_ context.Context, r *http.Request) (interface{}, error) {
+	req := clearLockoutReq{
+		token:       apiutil.ExtractBearerToken(r),
+		lockoutType: bone.GetValue(r, "type"),
+		subject:     bone.GetValue(r, "subject"),
+	}
+
+	return req, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	if ar, ok := response.(mainflux.Response); ok {
 		for k, v := range ar.Headers() {
@@ -335,12 +426,52 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 	return json.NewEncoder(w).Encode(response)
 }
 
+func decodeImportBackup(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), backup.ContentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	strategy, err := apiutil.ReadStringQuery(r, strategyKey, backup.StrategyFail)
+	if err != nil {
+		return nil, err
+	}
+
+	req := importBackupReq{
+		token:    apiutil.ExtractBearerToken(r),
+		strategy: strategy,
+		decoder:  backup.NewDecoder(r.Body),
+	}
+
+	return req, nil
+}
+
+func encodeBackupStream(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(exportBackupRes)
+
+	w.Header().Set("Content-Type", backup.ContentType)
+	w.WriteHeader(http.StatusOK)
+
+	return res.stream(backup.NewEncoder(w))
+}
+
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, apiutil.ErrInvalidQueryParams),
 		errors.Contains(err, apiutil.ErrMalformedEntity),
 		errors.Contains(err, users.ErrPasswordFormat),
+		errors.Contains(err, users.ErrPasswordTooShort),
+		errors.Contains(err, users.ErrPasswordUpper),
+		errors.Contains(err, users.ErrPasswordLower),
+		errors.Contains(err, users.ErrPasswordDigit),
+		errors.Contains(err, users.ErrPasswordSpecial),
+		errors.Contains(err, users.ErrPasswordBreached),
+		errors.Contains(err, users.ErrPasswordReused),
+		errors.Contains(err, users.ErrInvalidLockoutType),
+		errors.Contains(err, backup.ErrMalformedRecord),
+		errors.Contains(err, backup.ErrUnsupportedVersion),
+		err == backup.ErrInvalidStrategy,
 		err == apiutil.ErrMissingEmail,
+		err == apiutil.ErrMissingID,
 		err == apiutil.ErrMissingHost,
 		err == apiutil.ErrMissingPass,
 		err == apiutil.ErrMissingConfPass,
@@ -360,6 +491,9 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	case errors.Contains(err, errors.ErrNotFound):
 		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, users.ErrLockedOut),
+		errors.Contains(err, users.ErrResetLimit):
+		w.WriteHeader(http.StatusTooManyRequests)
 
 	case errors.Contains(err, uuid.ErrGeneratingID),
 		errors.Contains(err, users.ErrRecoveryToken):
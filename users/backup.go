@@ -0,0 +1,211 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// BackupService is the name of the service written to the backup header.
+const BackupService = "users"
+
+const (
+	adminRecord = "admin"
+	userRecord  = "user"
+)
+
+type backupUser struct {
+	ID       string                 `json:"id"`
+	Email    string                 `json:"email"`
+	Password string                 `json:"password"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Status   string                 `json:"status"`
+}
+
+func (svc usersService) ExportBackup(ctx context.Context, token string, since time.Time) (backup.Stream, error) {
+	identity, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
+		return nil, err
+	}
+
+	header := backup.Header{Service: BackupService}
+
+	var users []User
+	if since.IsZero() {
+		users, err = svc.users.RetrieveAll(ctx)
+	} else {
+		header.Since = &since
+		users, err = svc.users.RetrieveUpdated(ctx, since)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return func(enc *backup.Encoder) error {
+		if err := enc.WriteHeader(header); err != nil {
+			return err
+		}
+
+		// The admin is written first, and only if it was updated in case
+		// of an incremental backup.
+		for _, u := range users {
+			if u.Email != identity.email {
+				continue
+			}
+			if err := enc.Encode(adminRecord, toBackupUser(u)); err != nil {
+				return err
+			}
+		}
+
+		for _, u := range users {
+			if u.Email == identity.email {
+				continue
+			}
+			if err := enc.Encode(userRecord, toBackupUser(u)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+func (svc usersService) ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (backup.Summary, error) {
+	if err := backup.ValidateStrategy(strategy); err != nil {
+		return backup.Summary{}, err
+	}
+
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
+		return backup.Summary{}, err
+	}
+
+	header, err := dec.Header()
+	if err != nil {
+		return backup.Summary{}, err
+	}
+	if header.Service != BackupService || header.Scope != nil {
+		return backup.Summary{}, errors.Wrap(backup.ErrMalformedRecord, errors.New(header.Service))
+	}
+
+	var summary backup.Summary
+	for {
+		rec, err := dec.Next()
+		if err == io.EOF {
+			return summary, nil
+		}
+		if err != nil {
+			return summary, err
+		}
+
+		var bu backupUser
+		if err := rec.Decode(&bu); err != nil {
+			return summary, err
+		}
+		u := User{
+			ID:       bu.ID,
+			Email:    bu.Email,
+			Password: bu.Password,
+			Metadata: bu.Metadata,
+			Status:   bu.Status,
+		}
+
+		switch rec.Type {
+		case adminRecord:
+			// The admin is always restored, so that the restored entities
+			// remain owned by the same root identity.
+			if err := svc.restoreAdmin(ctx, u); err != nil {
+				return summary, err
+			}
+			summary.Updated++
+		case userRecord:
+			if err := svc.restoreUser(ctx, u, strategy, &summary); err != nil {
+				return summary, err
+			}
+		default:
+			return summary, errors.Wrap(backup.ErrMalformedRecord, errors.New(rec.Type))
+		}
+	}
+}
+
+func (svc usersService) restoreAdmin(ctx context.Context, admin User) error {
+	if err := svc.users.UpdateUser(ctx, admin); err != nil {
+		return err
+	}
+
+	req := mainflux.AssignRoleReq{
+		Id:   admin.ID,
+		Role: auth.RoleRootAdmin,
+	}
+	_, err := svc.auth.AssignRole(ctx, &req)
+
+	return err
+}
+
+func (svc usersService) restoreUser(ctx context.Context, u User, strategy string, summary *backup.Summary) error {
+	_, err := svc.users.RetrieveByID(ctx, u.ID)
+	switch {
+	case errors.Contains(err, errors.ErrNotFound):
+		if _, err := svc.users.Save(ctx, u); err != nil {
+			if errors.Contains(err, errors.ErrConflict) && strategy == backup.StrategySkip {
+				summary.Skipped++
+				return nil
+			}
+			return err
+		}
+		summary.Created++
+		return nil
+	case err != nil:
+		return err
+	}
+
+	switch strategy {
+	case backup.StrategySkip:
+		summary.Skipped++
+		return nil
+	case backup.StrategyOverwrite:
+	default:
+		return errors.ErrConflict
+	}
+
+	// Disabled users can't be updated, so the user is enabled first and
+	// gets the backed up status once it is updated.
+	if err := svc.users.ChangeStatus(ctx, u.ID, EnabledStatusKey); err != nil {
+		return err
+	}
+	if err := svc.users.UpdateUser(ctx, u); err != nil {
+		return err
+	}
+	if err := svc.users.UpdatePassword(ctx, u.Email, u.Password); err != nil {
+		return err
+	}
+	if u.Status == DisabledStatusKey {
+		if err := svc.users.ChangeStatus(ctx, u.ID, DisabledStatusKey); err != nil {
+			return err
+		}
+	}
+
+	summary.Updated++
+	return nil
+}
+
+func toBackupUser(u User) backupUser {
+	return backupUser{
+		ID:       u.ID,
+		Email:    u.Email,
+		Password: u.Password,
+		Metadata: u.Metadata,
+		Status:   u.Status,
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// bloomFPRate is the false positive rate of the breached passwords filter,
+// i.e. the share of the passwords rejected although they aren't breached.
+const bloomFPRate = 0.001
+
+var errBreachedLookup = errors.New("failed to look up breached passwords")
+
+// BreachedPasswords checks the passwords against a list of breached passwords.
+type BreachedPasswords interface {
+	// Contains reports whether the password is in the list.
+	Contains(password string) (bool, error)
+}
+
+var _ BreachedPasswords = (*bloomFilter)(nil)
+
+// bloomFilter holds the SHA-1 hashes of the breached passwords in a Bloom
+// filter, which takes about 1.8 bytes per password, instead of keeping the
+// whole list in memory.
+type bloomFilter struct {
+	bits   []uint64
+	m      uint64
+	hashes uint64
+}
+
+// ReadBreachedPasswords reads the list of breached passwords, one per line,
+// into a Bloom filter. Lines in the "HASH:COUNT" format, used by the Have I
+// Been Pwned password lists, are read as SHA-1 hashes, and the other lines
+// as plain text passwords. The list is read twice, first to size the filter.
+func ReadBreachedPasswords(r io.ReadSeeker) (BreachedPasswords, error) {
+	var n uint64
+	if err := scanBreached(r, func([sha1.Size]byte) { n++ }); err != nil {
+		return nil, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	bf := newBloomFilter(n, bloomFPRate)
+	if err := scanBreached(r, bf.add); err != nil {
+		return nil, err
+	}
+
+	return bf, nil
+}
+
+func newBloomFilter(n uint64, fpRate float64) *bloomFilter {
+	if n == 0 {
+		n = 1
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	m = (m + 63) / 64 * 64
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	if k == 0 {
+		k = 1
+	}
+
+	return &bloomFilter{
+		bits:   make([]uint64, m/64),
+		m:      m,
+		hashes: k,
+	}
+}
+
+func (bf *bloomFilter) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	for _, i := range bf.indexes(sum) {
+		if bf.bits[i/64]&(1<<(i%64)) == 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (bf *bloomFilter) add(sum [sha1.Size]byte) {
+	for _, i := range bf.indexes(sum) {
+		bf.bits[i/64] |= 1 << (i % 64)
+	}
+}
+
+// indexes derives the bit indexes of the hash by double hashing. SHA-1
+// hashes are uniformly distributed, so their halves are used as is.
+func (bf *bloomFilter) indexes(sum [sha1.Size]byte) []uint64 {
+	h1 := binary.BigEndian.Uint64(sum[:8])
+	h2 := binary.BigEndian.Uint64(sum[8:16]) | 1
+
+	idx := make([]uint64, bf.hashes)
+	for i := range idx {
+		idx[i] = (h1 + uint64(i)*h2) % bf.m
+	}
+
+	return idx
+}
+
+// scanBreached calls fn with the SHA-1 hash of each password of the list.
+func scanBreached(r io.Reader, fn func([sha1.Size]byte)) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var sum [sha1.Size]byte
+		hash, _, ok := strings.Cut(line, ":")
+		if b, err := hex.DecodeString(hash); ok && err == nil && len(b) == sha1.Size {
+			copy(sum[:], b)
+		} else {
+			sum = sha1.Sum([]byte(line))
+		}
+
+		fn(sum)
+	}
+
+	return scanner.Err()
+}
+
+var _ BreachedPasswords = (*rangeLookup)(nil)
+
+// rangeLookup checks the passwords with the k-anonymity range API of the Have
+// I Been Pwned passwords service, or a compatible one. Only the first five
+// hex digits of the SHA-1 hash of the password are sent, and the suffixes of
+// the breached hashes sharing the prefix are searched in the response.
+type rangeLookup struct {
+	url    string
+	client *http.Client
+}
+
+// NewBreachedPasswordsRange returns the breached passwords lookup using the
+// range API at the URL, e.g. https://api.pwnedpasswords.com/range.
+func NewBreachedPasswordsRange(url string, timeout time.Duration) BreachedPasswords {
+	return rangeLookup{
+		url:    strings.TrimSuffix(url, "/"),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (rl rangeLookup) Contains(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	res, err := rl.client.Get(fmt.Sprintf("%s/%s", rl.url, prefix))
+	if err != nil {
+		return false, errors.Wrap(errBreachedLookup, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return false, errors.Wrap(errBreachedLookup, fmt.Errorf("unexpected status %d", res.StatusCode))
+	}
+
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		s, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if strings.EqualFold(s, suffix) {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, errors.Wrap(errBreachedLookup, err)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,245 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	// AccountLockout identifies failed login attempts counted per account email.
+	AccountLockout = "account"
+
+	// IPLockout identifies failed login attempts counted per source IP address.
+	IPLockout = "ip"
+
+	// ResetLockout identifies password reset requests counted per account email.
+	ResetLockout = "reset"
+)
+
+var (
+	// ErrLockedOut indicates that the login is temporarily locked
+	// due to too many failed attempts.
+	ErrLockedOut = errors.New("too many failed login attempts, try again later")
+
+	// ErrResetLimit indicates that too many password reset
+	// requests have been made for the account.
+	ErrResetLimit = errors.New("too many password reset requests, try again later")
+
+	// ErrInvalidLockoutType indicates unknown lockout type.
+	ErrInvalidLockoutType = errors.New("invalid lockout type")
+)
+
+// Lockout keeps track of the attempts made by the subject, i.e. account
+// email or IP address, and of the time the subject is locked until.
+type Lockout struct {
+	Type        string
+	Subject     string
+	Attempts    uint64
+	Lockouts    uint64
+	LockedUntil time.Time
+	UpdatedAt   time.Time
+}
+
+// Locked returns true if the subject is locked at the given time.
+func (l Lockout) Locked(t time.Time) bool {
+	return l.LockedUntil.After(t)
+}
+
+// LockoutsPage contains a page of lockouts.
+type LockoutsPage struct {
+	Total    uint64
+	Offset   uint64
+	Limit    uint64
+	Lockouts []Lockout
+}
+
+// LockoutConfig contains the login and password reset limits.
+type LockoutConfig struct {
+	// MaxAttempts is the number of failed login attempts after which
+	// the account is locked. Zero disables the account lockout.
+	MaxAttempts uint64
+
+	// MaxIPAttempts is the number of failed login attempts after which
+	// logins from the source IP address are locked. Zero disables the
+	// IP address lockout.
+	MaxIPAttempts uint64
+
+	// Duration is the duration of the first lockout. Every consecutive
+	// lockout doubles the duration, up to MaxDuration.
+	Duration    time.Duration
+	MaxDuration time.Duration
+
+	// Window is the period after which failed attempts are forgotten.
+	Window time.Duration
+
+	// ResetLimit is the number of password reset requests per account
+	// allowed within ResetWindow. Zero disables the limit.
+	ResetLimit  uint64
+	ResetWindow time.Duration
+}
+
+// LockoutRepository specifies a lockout persistence API.
+type LockoutRepository interface {
+	// Save persists the lockout, replacing the existing one of the same subject.
+	Save(ctx context.Context, l Lockout) error
+
+	// Retrieve retrieves the lockout of the subject.
+	Retrieve(ctx context.Context, lockoutType, subject string) (Lockout, error)
+
+	// RegisterAttempt atomically counts the attempt of the subject made at
+	// the given time, and returns the updated lockout. The attempts counted
+	// before windowStart are forgotten first, and so is the number of the
+	// lockouts which ended before progressionStart.
+	RegisterAttempt(ctx context.Context, lockoutType, subject string, t, windowStart, progressionStart time.Time) (Lockout, error)
+
+	// Lock locks the subject until the given time, counts the lockout and
+	// forgets the attempts of the subject, unless the subject has made fewer
+	// attempts than given, e.g. due to being locked by a concurrent attempt.
+	Lock(ctx context.Context, lockoutType, subject string, attempts uint64, until time.Time) error
+
+	// RetrieveLocked retrieves the subjects locked at the given time. An empty
+	// type retrieves the lockouts of all types.
+	RetrieveLocked(ctx context.Context, lockoutType string, t time.Time, offset, limit uint64) (LockoutsPage, error)
+
+	// Remove removes the lockout of the subject.
+	Remove(ctx context.Context, lockoutType, subject string) error
+}
+
+// limit defines how many attempts are allowed before the subject is locked.
+type limit struct {
+	attempts    uint64
+	duration    time.Duration
+	maxDuration time.Duration
+	window      time.Duration
+}
+
+func (svc usersService) ListLockouts(ctx context.Context, token, lockoutType string, offset, limit uint64) (LockoutsPage, error) {
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
+		return LockoutsPage{}, err
+	}
+
+	if lockoutType != "" && !validLockoutType(lockoutType) {
+		return LockoutsPage{}, ErrInvalidLockoutType
+	}
+
+	return svc.lockouts.RetrieveLocked(ctx, lockoutType, time.Now(), offset, limit)
+}
+
+func (svc usersService) ClearLockout(ctx context.Context, token, lockoutType, subject string) error {
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
+		return err
+	}
+
+	if !validLockoutType(lockoutType) {
+		return ErrInvalidLockoutType
+	}
+
+	return svc.lockouts.Remove(ctx, lockoutType, subject)
+}
+
+// loginLimits returns the limits applied to the login of the account
+// with the given email, keyed by the lockout type and subject.
+func (svc usersService) loginLimits(ctx context.Context, email string) map[[2]string]limit {
+	limits := map[[2]string]limit{}
+	if svc.lockoutCfg.MaxAttempts > 0 {
+		limits[[2]string{AccountLockout, email}] = svc.loginLimit(svc.lockoutCfg.MaxAttempts)
+	}
+
+	if ip := auth.ClientIP(ctx); ip != "" && svc.lockoutCfg.MaxIPAttempts > 0 {
+		limits[[2]string{IPLockout, ip}] = svc.loginLimit(svc.lockoutCfg.MaxIPAttempts)
+	}
+
+	return limits
+}
+
+func (svc usersService) loginLimit(attempts uint64) limit {
+	return limit{
+		attempts:    attempts,
+		duration:    svc.lockoutCfg.Duration,
+		maxDuration: svc.lockoutCfg.MaxDuration,
+		window:      svc.lockoutCfg.Window,
+	}
+}
+
+// checkLocked returns an error if any of the subjects is currently locked.
+func (svc usersService) checkLocked(ctx context.Context, limits map[[2]string]limit, lockedErr error) error {
+	now := time.Now()
+	for key := range limits {
+		l, err := svc.lockouts.Retrieve(ctx, key[0], key[1])
+		if err != nil {
+			if errors.Contains(err, errors.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+
+		if l.Locked(now) {
+			return lockedErr
+		}
+	}
+
+	return nil
+}
+
+// registerAttempts counts an attempt for each of the subjects and locks
+// the subjects which reached the allowed number of attempts. Each
+// consecutive lockout of the subject doubles the lockout duration.
+func (svc usersService) registerAttempts(ctx context.Context, limits map[[2]string]limit) error {
+	now := time.Now()
+	for key, lim := range limits {
+		var windowStart, progressionStart time.Time
+		if lim.window > 0 {
+			windowStart = now.Add(-lim.window)
+		}
+		// Lockout progression is forgotten once the subject stays
+		// unlocked for longer than the maximal lockout duration.
+		if lim.maxDuration > 0 {
+			progressionStart = now.Add(-lim.maxDuration)
+		}
+
+		l, err := svc.lockouts.RegisterAttempt(ctx, key[0], key[1], now, windowStart, progressionStart)
+		if err != nil {
+			return err
+		}
+
+		if l.Attempts >= lim.attempts {
+			until := now.Add(lockoutDuration(lim, l.Lockouts))
+			if err := svc.lockouts.Lock(ctx, key[0], key[1], lim.attempts, until); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func lockoutDuration(lim limit, lockouts uint64) time.Duration {
+	d := lim.duration
+	for i := uint64(0); i < lockouts; i++ {
+		if lim.maxDuration > 0 && d >= lim.maxDuration {
+			break
+		}
+		d *= 2
+	}
+
+	if lim.maxDuration > 0 && d > lim.maxDuration {
+		return lim.maxDuration
+	}
+
+	return d
+}
+
+func validLockoutType(lockoutType string) bool {
+	switch lockoutType {
+	case AccountLockout, IPLockout, ResetLockout:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+)
+
+var _ users.LockoutRepository = (*lockoutRepositoryMock)(nil)
+
+type lockoutRepositoryMock struct {
+	mu       sync.Mutex
+	lockouts map[string]users.Lockout
+}
+
+// NewLockoutRepository creates in-memory lockout repository.
+func NewLockoutRepository() users.LockoutRepository {
+	return &lockoutRepositoryMock{
+		lockouts: make(map[string]users.Lockout),
+	}
+}
+
+func (lrm *lockoutRepositoryMock) Save(_ context.Context, l users.Lockout) error {
+	lrm.mu.Lock()
+	defer lrm.mu.Unlock()
+
+	if l.Type == "" || l.Subject == "" {
+		return errors.ErrMalformedEntity
+	}
+
+	lrm.lockouts[key(l.Type, l.Subject)] = l
+	return nil
+}
+
+func (lrm *lockoutRepositoryMock) Retrieve(_ context.Context, lockoutType, subject string) (users.Lockout, error) {
+	lrm.mu.Lock()
+	defer lrm.mu.Unlock()
+
+	l, ok := lrm.lockouts[key(lockoutType, subject)]
+	if !ok {
+		return users.Lockout{}, errors.ErrNotFound
+	}
+
+	return l, nil
+}
+
+func (lrm *lockoutRepositoryMock) RegisterAttempt(_ context.Context, lockoutType, subject string, t, windowStart, progressionStart time.Time) (users.Lockout, error) {
+	lrm.mu.Lock()
+	defer lrm.mu.Unlock()
+
+	if lockoutType == "" || subject == "" {
+		return users.Lockout{}, errors.ErrMalformedEntity
+	}
+
+	l, ok := lrm.lockouts[key(lockoutType, subject)]
+	if !ok {
+		l = users.Lockout{Type: lockoutType, Subject: subject}
+	}
+	if l.UpdatedAt.Before(windowStart) {
+		l.Attempts = 0
+	}
+	if !l.LockedUntil.IsZero() && l.LockedUntil.Before(progressionStart) {
+		l.Lockouts = 0
+	}
+	l.Attempts++
+	l.UpdatedAt = t
+
+	lrm.lockouts[key(lockoutType, subject)] = l
+	return l, nil
+}
+
+func (lrm *lockoutRepositoryMock) Lock(_ context.Context, lockoutType, subject string, attempts uint64, until time.Time) error {
+	lrm.mu.Lock()
+	defer lrm.mu.Unlock()
+
+	l, ok := lrm.lockouts[key(lockoutType, subject)]
+	if !ok || l.Attempts < attempts {
+		return nil
+	}
+	l.Attempts = 0
+	l.Lockouts++
+	l.LockedUntil = until
+
+	lrm.lockouts[key(lockoutType, subject)] = l
+	return nil
+}
+
+func (lrm *lockoutRepositoryMock) RetrieveLocked(_ context.Context, lockoutType string, t time.Time, offset, limit uint64) (users.LockoutsPage, error) {
+	lrm.mu.Lock()
+	defer lrm.mu.Unlock()
+
+	var items []users.Lockout
+	for _, l := range lrm.lockouts {
+		if (lockoutType == "" || l.Type == lockoutType) && l.Locked(t) {
+			items = append(items, l)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Subject < items[j].Subject
+	})
+
+	page := users.LockoutsPage{
+		Total:  uint64(len(items)),
+		Offset: offset,
+		Limit:  limit,
+	}
+
+	if offset >= uint64(len(items)) {
+		return page, nil
+	}
+	end := uint64(len(items))
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	page.Lockouts = items[offset:end]
+
+	return page, nil
+}
+
+func (lrm *lockoutRepositoryMock) Remove(_ context.Context, lockoutType, subject string) error {
+	lrm.mu.Lock()
+	defer lrm.mu.Unlock()
+
+	delete(lrm.lockouts, key(lockoutType, subject))
+	return nil
+}
+
+func key(lockoutType, subject string) string {
+	return lockoutType + ":" + subject
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/users"
@@ -18,6 +19,8 @@ type userRepositoryMock struct {
 	mu           sync.Mutex
 	usersByID    map[string]users.User
 	usersByEmail map[string]users.User
+	passHistory  map[string][]string
+	updated      map[string]time.Time
 }
 
 // NewUserRepository creates in-memory user repository
@@ -33,6 +36,8 @@ func NewUserRepository(us []users.User) users.UserRepository {
 	return &userRepositoryMock{
 		usersByEmail: usersByEmail,
 		usersByID:    usersByID,
+		passHistory:  make(map[string][]string),
+		updated:      make(map[string]time.Time),
 	}
 }
 
@@ -46,6 +51,7 @@ func (urm *userRepositoryMock) Save(ctx context.Context, u users.User) (string,
 
 	urm.usersByEmail[u.Email] = u
 	urm.usersByID[u.ID] = u
+	urm.updated[u.ID] = time.Now()
 	return u.ID, nil
 }
 
@@ -59,6 +65,7 @@ func (urm *userRepositoryMock) Update(ctx context.Context, u users.User) error {
 
 	urm.usersByEmail[u.Email] = u
 	urm.usersByID[u.ID] = u
+	urm.updated[u.ID] = time.Now()
 	return nil
 }
 
@@ -72,6 +79,7 @@ func (urm *userRepositoryMock) UpdateUser(ctx context.Context, u users.User) err
 
 	urm.usersByEmail[u.Email] = u
 	urm.usersByID[u.ID] = u
+	urm.updated[u.ID] = time.Now()
 	return nil
 }
 
@@ -153,13 +161,32 @@ func (urm *userRepositoryMock) RetrieveAll(ctx context.Context) ([]users.User, e
 	return users, nil
 }
 
+func (urm *userRepositoryMock) RetrieveUpdated(_ context.Context, since time.Time) ([]users.User, error) {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	var users []users.User
+	for _, u := range urm.usersByEmail {
+		if !urm.updated[u.ID].Before(since) {
+			users = append(users, u)
+		}
+	}
+
+	return users, nil
+}
+
 func (urm *userRepositoryMock) UpdatePassword(_ context.Context, token, password string) error {
 	urm.mu.Lock()
 	defer urm.mu.Unlock()
 
-	if _, ok := urm.usersByEmail[token]; !ok {
+	u, ok := urm.usersByEmail[token]
+	if !ok {
 		return errors.ErrNotFound
 	}
+	u.Password = password
+	urm.usersByEmail[u.Email] = u
+	urm.usersByID[u.ID] = u
+	urm.updated[u.ID] = time.Now()
 	return nil
 }
 
@@ -174,9 +201,30 @@ func (urm *userRepositoryMock) ChangeStatus(ctx context.Context, id, status stri
 	u.Status = status
 	urm.usersByID[id] = u
 	urm.usersByEmail[u.Email] = u
+	urm.updated[id] = time.Now()
 	return nil
 }
 
+func (urm *userRepositoryMock) SavePasswordHistory(_ context.Context, userID, password string) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	urm.passHistory[userID] = append([]string{password}, urm.passHistory[userID]...)
+	return nil
+}
+
+func (urm *userRepositoryMock) RetrievePasswordHistory(_ context.Context, userID string, limit uint64) ([]string, error) {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	hist := urm.passHistory[userID]
+	if uint64(len(hist)) > limit {
+		hist = hist[:limit]
+	}
+
+	return hist, nil
+}
+
 func sortUsers(us map[string]users.User) []users.User {
 	users := []users.User{}
 	ids := make([]string, 0, len(us))
@@ -191,3 +239,14 @@ func sortUsers(us map[string]users.User) []users.User {
 
 	return users
 }
+
+func (urm *userRepositoryMock) RemovePasswordHistory(_ context.Context, userID string, keep uint64) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	if hist := urm.passHistory[userID]; uint64(len(hist)) > keep {
+		urm.passHistory[userID] = hist[:keep]
+	}
+
+	return nil
+}
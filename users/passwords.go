@@ -0,0 +1,160 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var (
+	// ErrPasswordTooShort indicates that the password is shorter than required.
+	ErrPasswordTooShort = errors.New("password is too short")
+
+	// ErrPasswordUpper indicates that the password lacks an upper case letter.
+	ErrPasswordUpper = errors.New("password must contain an upper case letter")
+
+	// ErrPasswordLower indicates that the password lacks a lower case letter.
+	ErrPasswordLower = errors.New("password must contain a lower case letter")
+
+	// ErrPasswordDigit indicates that the password lacks a digit.
+	ErrPasswordDigit = errors.New("password must contain a digit")
+
+	// ErrPasswordSpecial indicates that the password lacks a special character.
+	ErrPasswordSpecial = errors.New("password must contain a special character")
+
+	// ErrPasswordBreached indicates that the password is found in the list
+	// of breached passwords.
+	ErrPasswordBreached = errors.New("password is found in a list of breached passwords")
+
+	// ErrPasswordCheck indicates that the password couldn't be checked
+	// against the list of breached passwords.
+	ErrPasswordCheck = errors.New("failed to check the password against breached passwords")
+
+	// ErrPasswordReused indicates that the password matches one of the
+	// recently used passwords.
+	ErrPasswordReused = errors.New("password matches a recently used password")
+)
+
+// PasswordPolicy defines the requirements a password has to meet.
+type PasswordPolicy struct {
+	// Regex is the regular expression the password has to match.
+	Regex *regexp.Regexp
+
+	// MinLength is the minimal number of characters in the password.
+	MinLength int
+
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+
+	// History is the number of the most recent passwords of the user
+	// which can't be reused. Zero disables the check.
+	History uint64
+
+	// Breached checks the password against known breached passwords.
+	// Nil disables the check.
+	Breached BreachedPasswords
+}
+
+// Validate returns an error if the password doesn't meet the policy.
+func (pp PasswordPolicy) Validate(password string) error {
+	if pp.Regex != nil && !pp.Regex.MatchString(password) {
+		return ErrPasswordFormat
+	}
+
+	if utf8.RuneCountInString(password) < pp.MinLength {
+		return ErrPasswordTooShort
+	}
+
+	var upper, lower, digit, special bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r), unicode.IsSymbol(r), unicode.IsSpace(r):
+			special = true
+		}
+	}
+
+	switch {
+	case pp.RequireUpper && !upper:
+		return ErrPasswordUpper
+	case pp.RequireLower && !lower:
+		return ErrPasswordLower
+	case pp.RequireDigit && !digit:
+		return ErrPasswordDigit
+	case pp.RequireSpecial && !special:
+		return ErrPasswordSpecial
+	}
+
+	if pp.Breached != nil {
+		breached, err := pp.Breached.Contains(password)
+		if err != nil {
+			return errors.Wrap(ErrPasswordCheck, err)
+		}
+		if breached {
+			return ErrPasswordBreached
+		}
+	}
+
+	return nil
+}
+
+// checkHistory returns an error if the password matches the current
+// password of the user or any of the recently used ones.
+func (svc usersService) checkHistory(ctx context.Context, user User, password string) error {
+	if svc.passPolicy.History == 0 {
+		return nil
+	}
+
+	// The current password counts as one of the recently used.
+	var hashes []string
+	if svc.passPolicy.History > 1 {
+		h, err := svc.users.RetrievePasswordHistory(ctx, user.ID, svc.passPolicy.History-1)
+		if err != nil {
+			return err
+		}
+		hashes = h
+	}
+
+	for _, hash := range append([]string{user.Password}, hashes...) {
+		if hash == "" {
+			continue
+		}
+		if err := svc.hasher.Compare(password, hash); err == nil {
+			return ErrPasswordReused
+		}
+	}
+
+	return nil
+}
+
+// updatePassword stores the hashed password of the user, keeping the
+// previous one in the password history.
+func (svc usersService) updatePassword(ctx context.Context, user User, hash string) error {
+	if err := svc.users.UpdatePassword(ctx, user.Email, hash); err != nil {
+		return err
+	}
+
+	if svc.passPolicy.History < 2 || user.Password == "" {
+		return nil
+	}
+
+	if err := svc.users.SavePasswordHistory(ctx, user.ID, user.Password); err != nil {
+		return err
+	}
+
+	// Older passwords aren't checked anymore, so they aren't kept either.
+	return svc.users.RemovePasswordHistory(ctx, user.ID, svc.passPolicy.History-1)
+}
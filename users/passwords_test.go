@@ -0,0 +1,150 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const breachedList = `
+123456
+qwerty123
+
+5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493
+`
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	breached, err := users.ReadBreachedPasswords(strings.NewReader(breachedList))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	policy := users.PasswordPolicy{
+		Regex:          regexp.MustCompile("^[^ ]*$"),
+		MinLength:      8,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+		Breached:       breached,
+	}
+
+	cases := map[string]struct {
+		policy   users.PasswordPolicy
+		password string
+		err      error
+	}{
+		"validate valid password": {
+			policy:   policy,
+			password: "Pa55word!",
+			err:      nil,
+		},
+		"validate password not matching regex": {
+			policy:   policy,
+			password: "Pa55 word!",
+			err:      users.ErrPasswordFormat,
+		},
+		"validate too short password": {
+			policy:   policy,
+			password: "Pa5!",
+			err:      users.ErrPasswordTooShort,
+		},
+		"validate password without upper case letter": {
+			policy:   policy,
+			password: "pa55word!",
+			err:      users.ErrPasswordUpper,
+		},
+		"validate password without lower case letter": {
+			policy:   policy,
+			password: "PA55WORD!",
+			err:      users.ErrPasswordLower,
+		},
+		"validate password without digit": {
+			policy:   policy,
+			password: "Password!",
+			err:      users.ErrPasswordDigit,
+		},
+		"validate password without special character": {
+			policy:   policy,
+			password: "Pa55word",
+			err:      users.ErrPasswordSpecial,
+		},
+		"validate breached plain text password": {
+			policy:   users.PasswordPolicy{Breached: breached},
+			password: "qwerty123",
+			err:      users.ErrPasswordBreached,
+		},
+		"validate breached hashed password": {
+			policy:   users.PasswordPolicy{Breached: breached},
+			password: "password",
+			err:      users.ErrPasswordBreached,
+		},
+		"validate password with empty policy": {
+			policy:   users.PasswordPolicy{},
+			password: "password",
+			err:      nil,
+		},
+	}
+
+	for desc, tc := range cases {
+		err := tc.policy.Validate(tc.password)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
+func TestBreachedPasswordsRange(t *testing.T) {
+	// The range of "password" holds its hash suffix, and the range of
+	// "Pa55word!" holds only the suffixes of other passwords.
+	ranges := map[string]string{
+		"5BAA6": "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n1E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\r\n",
+		"7E78E": "0000000000000000000000000000000000A:2\r\n",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok := ranges[strings.TrimPrefix(r.URL.Path, "/range/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, res)
+	}))
+	defer srv.Close()
+
+	breached := users.NewBreachedPasswordsRange(srv.URL+"/range", time.Second)
+	policy := users.PasswordPolicy{Breached: breached}
+
+	cases := map[string]struct {
+		policy   users.PasswordPolicy
+		password string
+		err      error
+	}{
+		"validate breached password": {
+			policy:   policy,
+			password: "password",
+			err:      users.ErrPasswordBreached,
+		},
+		"validate password not in its range": {
+			policy:   policy,
+			password: "Pa55word!",
+			err:      nil,
+		},
+		"validate password with failing lookup": {
+			policy:   users.PasswordPolicy{Breached: users.NewBreachedPasswordsRange(srv.URL+"/invalid", time.Second)},
+			password: "password",
+			err:      users.ErrPasswordCheck,
+		},
+	}
+	for desc, tc := range cases {
+		err := tc.policy.Validate(tc.password)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
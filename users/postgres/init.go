@@ -91,6 +91,48 @@ func migrateDB(db *sqlx.DB) error {
 					status USER_STATUS NOT NULL DEFAULT 'enabled'`,
 				},
 			},
+			{
+				Id: "users_6",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS lockouts (
+						type         VARCHAR(16),
+						subject      VARCHAR(254),
+						attempts     BIGINT NOT NULL DEFAULT 0,
+						lockouts     BIGINT NOT NULL DEFAULT 0,
+						locked_until TIMESTAMPTZ,
+						updated_at   TIMESTAMPTZ,
+						PRIMARY KEY (type, subject)
+					)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS lockouts",
+				},
+			},
+			{
+				Id: "users_7",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS password_history (
+						user_id    UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+						password   TEXT NOT NULL,
+						created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+					)`,
+					`CREATE INDEX IF NOT EXISTS password_history_user_id_idx ON password_history (user_id, created_at DESC)`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS password_history",
+				},
+			},
+			{
+				Id: "users_8",
+				Up: []string{
+					`ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now()`,
+					`CREATE INDEX IF NOT EXISTS users_updated_at_idx ON users (updated_at)`,
+				},
+				Down: []string{
+					"DROP INDEX IF EXISTS users_updated_at_idx",
+					"ALTER TABLE IF EXISTS users DROP COLUMN IF EXISTS updated_at",
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,209 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+)
+
+var _ users.LockoutRepository = (*lockoutRepository)(nil)
+
+type lockoutRepository struct {
+	db Database
+}
+
+// NewLockoutRepo instantiates a PostgreSQL implementation of lockout
+// repository.
+func NewLockoutRepo(db Database) users.LockoutRepository {
+	return &lockoutRepository{
+		db: db,
+	}
+}
+
+func (lr lockoutRepository) Save(ctx context.Context, l users.Lockout) error {
+	q := `INSERT INTO lockouts (type, subject, attempts, lockouts, locked_until, updated_at)
+		VALUES (:type, :subject, :attempts, :lockouts, :locked_until, :updated_at)
+		ON CONFLICT (type, subject) DO UPDATE SET attempts = :attempts, lockouts = :lockouts,
+		locked_until = :locked_until, updated_at = :updated_at`
+
+	if l.Type == "" || l.Subject == "" {
+		return errors.ErrMalformedEntity
+	}
+
+	if _, err := lr.db.NamedExecContext(ctx, q, toDBLockout(l)); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (lr lockoutRepository) Retrieve(ctx context.Context, lockoutType, subject string) (users.Lockout, error) {
+	q := `SELECT type, subject, attempts, lockouts, locked_until, updated_at FROM lockouts WHERE type = $1 AND subject = $2`
+
+	var dbl dbLockout
+	if err := lr.db.QueryRowxContext(ctx, q, lockoutType, subject).StructScan(&dbl); err != nil {
+		if err == sql.ErrNoRows {
+			return users.Lockout{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return users.Lockout{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toLockout(dbl), nil
+}
+
+func (lr lockoutRepository) RegisterAttempt(ctx context.Context, lockoutType, subject string, t, windowStart, progressionStart time.Time) (users.Lockout, error) {
+	q := `INSERT INTO lockouts (type, subject, attempts, lockouts, updated_at)
+		VALUES (:type, :subject, 1, 0, :time)
+		ON CONFLICT (type, subject) DO UPDATE SET
+		attempts = CASE WHEN lockouts.updated_at < :window_start THEN 1 ELSE lockouts.attempts + 1 END,
+		lockouts = CASE WHEN lockouts.locked_until < :progression_start THEN 0 ELSE lockouts.lockouts END,
+		updated_at = :time
+		RETURNING type, subject, attempts, lockouts, locked_until, updated_at`
+
+	if lockoutType == "" || subject == "" {
+		return users.Lockout{}, errors.ErrMalformedEntity
+	}
+
+	params := map[string]interface{}{
+		"type":              lockoutType,
+		"subject":           subject,
+		"time":              t,
+		"window_start":      windowStart,
+		"progression_start": progressionStart,
+	}
+
+	rows, err := lr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return users.Lockout{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+	defer rows.Close()
+
+	var dbl dbLockout
+	if !rows.Next() {
+		return users.Lockout{}, errors.Wrap(errors.ErrCreateEntity, rows.Err())
+	}
+	if err := rows.StructScan(&dbl); err != nil {
+		return users.Lockout{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return toLockout(dbl), nil
+}
+
+func (lr lockoutRepository) Lock(ctx context.Context, lockoutType, subject string, attempts uint64, until time.Time) error {
+	q := `UPDATE lockouts SET attempts = 0, lockouts = lockouts + 1, locked_until = :until
+		WHERE type = :type AND subject = :subject AND attempts >= :attempts`
+
+	params := map[string]interface{}{
+		"type":     lockoutType,
+		"subject":  subject,
+		"attempts": attempts,
+		"until":    until,
+	}
+
+	if _, err := lr.db.NamedExecContext(ctx, q, params); err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (lr lockoutRepository) RetrieveLocked(ctx context.Context, lockoutType string, t time.Time, offset, limit uint64) (users.LockoutsPage, error) {
+	wq := "WHERE locked_until > :time"
+	if lockoutType != "" {
+		wq = fmt.Sprintf("%s AND type = :type", wq)
+	}
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT type, subject, attempts, lockouts, locked_until, updated_at FROM lockouts %s ORDER BY locked_until DESC %s;`, wq, olq)
+	params := map[string]interface{}{
+		"time":   t,
+		"type":   lockoutType,
+		"limit":  limit,
+		"offset": offset,
+	}
+
+	rows, err := lr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return users.LockoutsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []users.Lockout
+	for rows.Next() {
+		var dbl dbLockout
+		if err := rows.StructScan(&dbl); err != nil {
+			return users.LockoutsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		items = append(items, toLockout(dbl))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM lockouts %s;`, wq)
+	total, err := total(ctx, lr.db, cq, params)
+	if err != nil {
+		return users.LockoutsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return users.LockoutsPage{
+		Total:    total,
+		Offset:   offset,
+		Limit:    limit,
+		Lockouts: items,
+	}, nil
+}
+
+func (lr lockoutRepository) Remove(ctx context.Context, lockoutType, subject string) error {
+	q := `DELETE FROM lockouts WHERE type = :type AND subject = :subject`
+
+	dbl := dbLockout{
+		Type:    lockoutType,
+		Subject: subject,
+	}
+
+	if _, err := lr.db.NamedExecContext(ctx, q, dbl); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+type dbLockout struct {
+	Type        string       `db:"type"`
+	Subject     string       `db:"subject"`
+	Attempts    uint64       `db:"attempts"`
+	Lockouts    uint64       `db:"lockouts"`
+	LockedUntil sql.NullTime `db:"locked_until"`
+	UpdatedAt   sql.NullTime `db:"updated_at"`
+}
+
+func toDBLockout(l users.Lockout) dbLockout {
+	return dbLockout{
+		Type:        l.Type,
+		Subject:     l.Subject,
+		Attempts:    l.Attempts,
+		Lockouts:    l.Lockouts,
+		LockedUntil: sql.NullTime{Time: l.LockedUntil, Valid: !l.LockedUntil.IsZero()},
+		UpdatedAt:   sql.NullTime{Time: l.UpdatedAt, Valid: !l.UpdatedAt.IsZero()},
+	}
+}
+
+func toLockout(dbl dbLockout) users.Lockout {
+	return users.Lockout{
+		Type:        dbl.Type,
+		Subject:     dbl.Subject,
+		Attempts:    dbl.Attempts,
+		Lockouts:    dbl.Lockouts,
+		LockedUntil: dbl.LockedUntil.Time,
+		UpdatedAt:   dbl.UpdatedAt.Time,
+	}
+}
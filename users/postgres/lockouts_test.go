@@ -0,0 +1,284 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/MainfluxLabs/mainflux/users/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockoutSave(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewLockoutRepo(dbMiddleware)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	cases := []struct {
+		desc    string
+		lockout users.Lockout
+		err     error
+	}{
+		{
+			desc:    "save new lockout",
+			lockout: users.Lockout{Type: users.AccountLockout, Subject: "save@example.com", Attempts: 1, UpdatedAt: now},
+			err:     nil,
+		},
+		{
+			desc:    "save existing lockout",
+			lockout: users.Lockout{Type: users.AccountLockout, Subject: "save@example.com", Lockouts: 1, LockedUntil: now.Add(time.Minute), UpdatedAt: now},
+			err:     nil,
+		},
+		{
+			desc:    "save lockout without subject",
+			lockout: users.Lockout{Type: users.AccountLockout},
+			err:     errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.lockout)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+		l, err := repo.Retrieve(context.Background(), tc.lockout.Type, tc.lockout.Subject)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.lockout.Attempts, l.Attempts, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.lockout.Attempts, l.Attempts))
+		assert.True(t, tc.lockout.LockedUntil.Equal(l.LockedUntil), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.lockout.LockedUntil, l.LockedUntil))
+	}
+}
+
+func TestLockoutRetrieve(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewLockoutRepo(dbMiddleware)
+
+	l := users.Lockout{Type: users.IPLockout, Subject: "10.0.0.1", Attempts: 2, UpdatedAt: time.Now()}
+	err := repo.Save(context.Background(), l)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		typ     string
+		subject string
+		err     error
+	}{
+		{
+			desc:    "retrieve existing lockout",
+			typ:     users.IPLockout,
+			subject: l.Subject,
+			err:     nil,
+		},
+		{
+			desc:    "retrieve lockout of another type",
+			typ:     users.AccountLockout,
+			subject: l.Subject,
+			err:     errors.ErrNotFound,
+		},
+		{
+			desc:    "retrieve non-existing lockout",
+			typ:     users.IPLockout,
+			subject: "10.0.0.2",
+			err:     errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.Retrieve(context.Background(), tc.typ, tc.subject)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestLockoutRetrieveLocked(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewLockoutRepo(dbMiddleware)
+
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		l := users.Lockout{
+			Type:        users.ResetLockout,
+			Subject:     fmt.Sprintf("locked-%d@example.com", i),
+			Lockouts:    1,
+			LockedUntil: now.Add(time.Hour),
+			UpdatedAt:   now,
+		}
+		if i%2 == 0 {
+			l.LockedUntil = now.Add(-time.Hour)
+		}
+		err := repo.Save(context.Background(), l)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		typ    string
+		offset uint64
+		limit  uint64
+		size   int
+		total  uint64
+	}{
+		{
+			desc:  "retrieve locked subjects",
+			typ:   users.ResetLockout,
+			limit: 10,
+			size:  5,
+			total: 5,
+		},
+		{
+			desc:   "retrieve locked subjects with offset",
+			typ:    users.ResetLockout,
+			offset: 3,
+			limit:  10,
+			size:   2,
+			total:  5,
+		},
+		{
+			desc:  "retrieve locked subjects of another type",
+			typ:   users.IPLockout,
+			limit: 10,
+			size:  0,
+			total: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveLocked(context.Background(), tc.typ, now, tc.offset, tc.limit)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(page.Lockouts), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Lockouts)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
+func TestLockoutRemove(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewLockoutRepo(dbMiddleware)
+
+	l := users.Lockout{Type: users.AccountLockout, Subject: "remove@example.com", Attempts: 1, UpdatedAt: time.Now()}
+	err := repo.Save(context.Background(), l)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), l.Type, l.Subject)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	_, err = repo.Retrieve(context.Background(), l.Type, l.Subject)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("expected %s got %s\n", errors.ErrNotFound, err))
+}
+
+func TestLockoutRegisterAttempt(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewLockoutRepo(dbMiddleware)
+
+	subject := "attempts@example.com"
+	now := time.Now().UTC().Round(time.Millisecond)
+
+	cases := []struct {
+		desc             string
+		t                time.Time
+		windowStart      time.Time
+		progressionStart time.Time
+		attempts         uint64
+		err              error
+	}{
+		{
+			desc:     "register first attempt",
+			t:        now,
+			attempts: 1,
+			err:      nil,
+		},
+		{
+			desc:     "register consecutive attempt",
+			t:        now.Add(time.Second),
+			attempts: 2,
+			err:      nil,
+		},
+		{
+			desc:        "register attempt after window",
+			t:           now.Add(time.Hour),
+			windowStart: now.Add(time.Minute),
+			attempts:    1,
+			err:         nil,
+		},
+	}
+
+	for _, tc := range cases {
+		l, err := repo.RegisterAttempt(context.Background(), users.AccountLockout, subject, tc.t, tc.windowStart, tc.progressionStart)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.attempts, l.Attempts, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.attempts, l.Attempts))
+	}
+
+	_, err := repo.RegisterAttempt(context.Background(), users.AccountLockout, "", now, time.Time{}, time.Time{})
+	assert.True(t, errors.Contains(err, errors.ErrMalformedEntity), fmt.Sprintf("register attempt without subject: expected %s got %s\n", errors.ErrMalformedEntity, err))
+}
+
+func TestLockoutRegisterConcurrentAttempts(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewLockoutRepo(dbMiddleware)
+
+	subject := "concurrent@example.com"
+	n := 20
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.RegisterAttempt(context.Background(), users.IPLockout, subject, time.Now(), time.Time{}, time.Time{})
+			assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		}()
+	}
+	wg.Wait()
+
+	l, err := repo.Retrieve(context.Background(), users.IPLockout, subject)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, uint64(n), l.Attempts, fmt.Sprintf("expected %d attempts got %d\n", n, l.Attempts))
+}
+
+func TestLockoutLock(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewLockoutRepo(dbMiddleware)
+
+	subject := "lock@example.com"
+	now := time.Now().UTC().Round(time.Millisecond)
+	for i := 0; i < 3; i++ {
+		_, err := repo.RegisterAttempt(context.Background(), users.AccountLockout, subject, now, time.Time{}, time.Time{})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc     string
+		attempts uint64
+		lockouts uint64
+	}{
+		{
+			desc:     "lock subject having fewer attempts",
+			attempts: 5,
+			lockouts: 0,
+		},
+		{
+			desc:     "lock subject having enough attempts",
+			attempts: 3,
+			lockouts: 1,
+		},
+		{
+			desc:     "lock subject locked by concurrent attempt",
+			attempts: 3,
+			lockouts: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Lock(context.Background(), users.AccountLockout, subject, tc.attempts, now.Add(time.Hour))
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		l, err := repo.Retrieve(context.Background(), users.AccountLockout, subject)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.lockouts, l.Lockouts, fmt.Sprintf("%s: expected %d lockouts got %d\n", tc.desc, tc.lockouts, l.Lockouts))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/internal/dbutil"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -67,7 +68,7 @@ func (ur userRepository) Save(ctx context.Context, user users.User) (string, err
 }
 
 func (ur userRepository) Update(ctx context.Context, user users.User) error {
-	q := `UPDATE users SET(email, password, metadata, status) VALUES (:email, :password, :metadata, :status) WHERE email = :email;`
+	q := `UPDATE users SET(email, password, metadata, status, updated_at) VALUES (:email, :password, :metadata, :status, now()) WHERE email = :email;`
 
 	dbu, err := toDBUser(user)
 	if err != nil {
@@ -87,7 +88,7 @@ func (ur userRepository) UpdateUser(ctx context.Context, user users.User) error
 		idq = "id = :id,"
 	}
 
-	q := fmt.Sprintf(`UPDATE users SET %s metadata = :metadata WHERE email = :email AND status = 'enabled'`, idq)
+	q := fmt.Sprintf(`UPDATE users SET %s metadata = :metadata, updated_at = now() WHERE email = :email AND status = 'enabled'`, idq)
 
 	dbu, err := toDBUser(user)
 	if err != nil {
@@ -253,8 +254,35 @@ func (ur userRepository) RetrieveAll(ctx context.Context) ([]users.User, error)
 	return items, nil
 }
 
+func (ur userRepository) RetrieveUpdated(ctx context.Context, since time.Time) ([]users.User, error) {
+	q := `SELECT id, email, password, metadata, status FROM users WHERE updated_at >= :since;`
+
+	rows, err := ur.db.NamedQueryContext(ctx, q, map[string]interface{}{"since": since})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var items []users.User
+	for rows.Next() {
+		dbusr := dbUser{}
+		if err := rows.StructScan(&dbusr); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		user, err := toUser(dbusr)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, user)
+	}
+
+	return items, nil
+}
+
 func (ur userRepository) UpdatePassword(ctx context.Context, email, password string) error {
-	q := `UPDATE users SET password = :password WHERE status = 'enabled' AND email = :email`
+	q := `UPDATE users SET password = :password, updated_at = now() WHERE status = 'enabled' AND email = :email`
 
 	db := dbUser{
 		Email:    email,
@@ -269,7 +297,7 @@ func (ur userRepository) UpdatePassword(ctx context.Context, email, password str
 }
 
 func (ur userRepository) ChangeStatus(ctx context.Context, id, status string) error {
-	q := fmt.Sprintf(`UPDATE users SET status = '%s' WHERE id = :id`, status)
+	q := fmt.Sprintf(`UPDATE users SET status = '%s', updated_at = now() WHERE id = :id`, status)
 
 	dbu := dbUser{
 		ID: id,
@@ -282,6 +310,63 @@ func (ur userRepository) ChangeStatus(ctx context.Context, id, status string) er
 	return nil
 }
 
+func (ur userRepository) SavePasswordHistory(ctx context.Context, userID, password string) error {
+	q := `INSERT INTO password_history (user_id, password) VALUES (:id, :password)`
+
+	dbu := dbUser{
+		ID:       userID,
+		Password: password,
+	}
+
+	if _, err := ur.db.NamedExecContext(ctx, q, dbu); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (ur userRepository) RemovePasswordHistory(ctx context.Context, userID string, keep uint64) error {
+	q := `DELETE FROM password_history WHERE user_id = :id AND ctid NOT IN
+		(SELECT ctid FROM password_history WHERE user_id = :id ORDER BY created_at DESC LIMIT :keep)`
+
+	params := map[string]interface{}{
+		"id":   userID,
+		"keep": keep,
+	}
+
+	if _, err := ur.db.NamedExecContext(ctx, q, params); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (ur userRepository) RetrievePasswordHistory(ctx context.Context, userID string, limit uint64) ([]string, error) {
+	q := `SELECT password FROM password_history WHERE user_id = :id ORDER BY created_at DESC LIMIT :limit`
+
+	params := map[string]interface{}{
+		"id":    userID,
+		"limit": limit,
+	}
+
+	rows, err := ur.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
 type dbUser struct {
 	ID       string `db:"id"`
 	Email    string `db:"email"`
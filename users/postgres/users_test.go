@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
@@ -329,6 +330,64 @@ func TestRetrieveAll(t *testing.T) {
 	}
 }
 
+func TestRetrieveUpdated(t *testing.T) {
+	_, err := db.Exec(fmt.Sprintf("DELETE FROM %s", usersTable))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	dbMiddleware := postgres.NewDatabase(db)
+	userRepo := postgres.NewUserRepo(dbMiddleware)
+
+	var ids []string
+	for i := 0; i < usersNum; i++ {
+		uid, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		user := users.User{
+			ID:       uid,
+			Email:    fmt.Sprintf("TestRetrieveUpdated%d@example.com", i),
+			Password: "password",
+			Status:   users.EnabledStatusKey,
+		}
+		_, err = userRepo.Save(context.Background(), user)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		ids = append(ids, uid)
+	}
+
+	var since time.Time
+	err = db.QueryRow("SELECT now()").Scan(&since)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = userRepo.ChangeStatus(context.Background(), ids[0], users.DisabledStatusKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		since time.Time
+		size  int
+	}{
+		{
+			desc:  "retrieve users updated since zero time",
+			since: time.Time{},
+			size:  usersNum,
+		},
+		{
+			desc:  "retrieve users updated since given time",
+			since: since,
+			size:  1,
+		},
+		{
+			desc:  "retrieve users updated since future time",
+			since: since.Add(time.Hour),
+			size:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		us, err := userRepo.RetrieveUpdated(context.Background(), tc.since)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(us), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(us)))
+	}
+}
+
 func TestUpdateUser(t *testing.T) {
 	dbMiddleware := postgres.NewDatabase(db)
 	userRepo := postgres.NewUserRepo(dbMiddleware)
@@ -385,3 +444,115 @@ func TestUpdateUser(t *testing.T) {
 		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %d\n", desc, err))
 	}
 }
+
+func TestPasswordHistory(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewUserRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	user := users.User{
+		ID:       uid,
+		Email:    "password-history@example.com",
+		Password: password,
+		Status:   users.EnabledStatusKey,
+	}
+	_, err = repo.Save(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	hashes := []string{"hash-1", "hash-2", "hash-3"}
+	for _, h := range hashes {
+		err := repo.SavePasswordHistory(context.Background(), uid, h)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		userID string
+		limit  uint64
+		size   int
+	}{
+		{
+			desc:   "retrieve password history",
+			userID: uid,
+			limit:  10,
+			size:   len(hashes),
+		},
+		{
+			desc:   "retrieve limited password history",
+			userID: uid,
+			limit:  2,
+			size:   2,
+		},
+		{
+			desc:   "retrieve password history of user without history",
+			userID: "c9f4d5a2-07bc-4c2c-8f3a-6c0d8e4ab1f2",
+			limit:  10,
+			size:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		hist, err := repo.RetrievePasswordHistory(context.Background(), tc.userID, tc.limit)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(hist), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(hist)))
+	}
+}
+
+func TestRemovePasswordHistory(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewUserRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	user := users.User{
+		ID:       uid,
+		Email:    "remove-password-history@example.com",
+		Password: password,
+		Status:   users.EnabledStatusKey,
+	}
+	_, err = repo.Save(context.Background(), user)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	hashes := []string{"hash-1", "hash-2", "hash-3", "hash-4"}
+	for _, h := range hashes {
+		err := repo.SavePasswordHistory(context.Background(), uid, h)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		userID string
+		keep   uint64
+		hashes []string
+	}{
+		{
+			desc:   "remove password history beyond its size",
+			userID: uid,
+			keep:   10,
+			hashes: []string{"hash-4", "hash-3", "hash-2", "hash-1"},
+		},
+		{
+			desc:   "remove old password history",
+			userID: uid,
+			keep:   2,
+			hashes: []string{"hash-4", "hash-3"},
+		},
+		{
+			desc:   "remove whole password history",
+			userID: uid,
+			keep:   0,
+			hashes: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.RemovePasswordHistory(context.Background(), tc.userID, tc.keep)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		hist, err := repo.RetrievePasswordHistory(context.Background(), tc.userID, 10)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.hashes, hist, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.hashes, hist))
+	}
+}
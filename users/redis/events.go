@@ -0,0 +1,106 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"encoding/json"
+)
+
+const (
+	userPrefix         = "user."
+	userRegister       = userPrefix + "register"
+	userUpdate         = userPrefix + "update"
+	userPasswordChange = userPrefix + "password_change"
+	userPasswordReset  = userPrefix + "password_reset"
+	userEnable         = userPrefix + "enable"
+	userDisable        = userPrefix + "disable"
+	userLockoutClear   = userPrefix + "lockout_clear"
+)
+
+type event interface {
+	Encode() map[string]interface{}
+}
+
+var (
+	_ event = (*registerUserEvent)(nil)
+	_ event = (*updateUserEvent)(nil)
+	_ event = (*userOperationEvent)(nil)
+	_ event = (*clearLockoutEvent)(nil)
+)
+
+type registerUserEvent struct {
+	id       string
+	email    string
+	metadata map[string]interface{}
+}
+
+func (rue registerUserEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        rue.id,
+		"email":     rue.email,
+		"operation": userRegister,
+	}
+
+	if rue.metadata != nil {
+		if metadata, err := json.Marshal(rue.metadata); err == nil {
+			val["metadata"] = string(metadata)
+		}
+	}
+
+	return val
+}
+
+type updateUserEvent struct {
+	id       string
+	metadata map[string]interface{}
+	before   map[string]interface{}
+}
+
+func (uue updateUserEvent) Encode() map[string]interface{} {
+	val := map[string]interface{}{
+		"id":        uue.id,
+		"operation": userUpdate,
+	}
+
+	if uue.metadata != nil {
+		if metadata, err := json.Marshal(uue.metadata); err == nil {
+			val["metadata"] = string(metadata)
+		}
+	}
+
+	if uue.before != nil {
+		if before, err := json.Marshal(uue.before); err == nil {
+			val["before"] = string(before)
+		}
+	}
+
+	return val
+}
+
+// userOperationEvent is emitted for operations which carry no other
+// data than the ID of the affected user, e.g. password change.
+type userOperationEvent struct {
+	id        string
+	operation string
+}
+
+func (uoe userOperationEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        uoe.id,
+		"operation": uoe.operation,
+	}
+}
+
+type clearLockoutEvent struct {
+	lockoutType string
+	subject     string
+}
+
+func (cle clearLockoutEvent) Encode() map[string]interface{} {
+	return map[string]interface{}{
+		"id":        cle.subject,
+		"type":      cle.lockoutType,
+		"operation": userLockoutClear,
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	streamID  = "mainflux.users"
+	streamLen = 1000
+)
+
+var _ users.Service = (*eventStore)(nil)
+
+type eventStore struct {
+	svc    users.Service
+	client *redis.Client
+}
+
+// NewEventStoreMiddleware returns wrapper around users service that sends
+// events to event store.
+func NewEventStoreMiddleware(svc users.Service, client *redis.Client) users.Service {
+	return eventStore{
+		svc:    svc,
+		client: client,
+	}
+}
+
+func (es eventStore) SelfRegister(ctx context.Context, user users.User) (string, error) {
+	id, err := es.svc.SelfRegister(ctx, user)
+	if err != nil {
+		return id, err
+	}
+
+	ev := registerUserEvent{
+		id:       id,
+		email:    user.Email,
+		metadata: user.Metadata,
+	}
+	es.add(ctx, id, ev)
+
+	return id, nil
+}
+
+func (es eventStore) Register(ctx context.Context, token string, user users.User) (string, error) {
+	id, err := es.svc.Register(ctx, token, user)
+	if err != nil {
+		return id, err
+	}
+
+	ev := registerUserEvent{
+		id:       id,
+		email:    user.Email,
+		metadata: user.Metadata,
+	}
+	es.add(ctx, es.actor(ctx, token), ev)
+
+	return id, nil
+}
+
+func (es eventStore) RegisterAdmin(ctx context.Context, user users.User) error {
+	return es.svc.RegisterAdmin(ctx, user)
+}
+
+func (es eventStore) Login(ctx context.Context, user users.User) (string, error) {
+	return es.svc.Login(ctx, user)
+}
+
+func (es eventStore) ViewUser(ctx context.Context, token, id string) (users.User, error) {
+	return es.svc.ViewUser(ctx, token, id)
+}
+
+func (es eventStore) ViewProfile(ctx context.Context, token string) (users.User, error) {
+	return es.svc.ViewProfile(ctx, token)
+}
+
+func (es eventStore) ListUsers(ctx context.Context, token string, pm users.PageMetadata) (users.UserPage, error) {
+	return es.svc.ListUsers(ctx, token, pm)
+}
+
+func (es eventStore) ListUsersByIDs(ctx context.Context, ids []string) (users.UserPage, error) {
+	return es.svc.ListUsersByIDs(ctx, ids)
+}
+
+func (es eventStore) ListUsersByEmails(ctx context.Context, emails []string) ([]users.User, error) {
+	return es.svc.ListUsersByEmails(ctx, emails)
+}
+
+func (es eventStore) UpdateUser(ctx context.Context, token string, user users.User) error {
+	before, err := es.svc.ViewProfile(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if err := es.svc.UpdateUser(ctx, token, user); err != nil {
+		return err
+	}
+
+	ev := updateUserEvent{
+		id:       before.ID,
+		metadata: user.Metadata,
+		before:   map[string]interface{}{"metadata": before.Metadata},
+	}
+	es.add(ctx, before.ID, ev)
+
+	return nil
+}
+
+func (es eventStore) GenerateResetToken(ctx context.Context, email, host string) error {
+	return es.svc.GenerateResetToken(ctx, email, host)
+}
+
+func (es eventStore) ChangePassword(ctx context.Context, authToken, password, oldPassword string) error {
+	if err := es.svc.ChangePassword(ctx, authToken, password, oldPassword); err != nil {
+		return err
+	}
+
+	actor := es.actor(ctx, authToken)
+	es.add(ctx, actor, userOperationEvent{id: actor, operation: userPasswordChange})
+
+	return nil
+}
+
+func (es eventStore) ResetPassword(ctx context.Context, resetToken, password string) error {
+	actor := es.actor(ctx, resetToken)
+	if err := es.svc.ResetPassword(ctx, resetToken, password); err != nil {
+		return err
+	}
+
+	es.add(ctx, actor, userOperationEvent{id: actor, operation: userPasswordReset})
+
+	return nil
+}
+
+func (es eventStore) SendPasswordReset(ctx context.Context, host, email, token string) error {
+	return es.svc.SendPasswordReset(ctx, host, email, token)
+}
+
+func (es eventStore) EnableUser(ctx context.Context, token, id string) error {
+	if err := es.svc.EnableUser(ctx, token, id); err != nil {
+		return err
+	}
+
+	es.add(ctx, es.actor(ctx, token), userOperationEvent{id: id, operation: userEnable})
+
+	return nil
+}
+
+func (es eventStore) DisableUser(ctx context.Context, token, id string) error {
+	if err := es.svc.DisableUser(ctx, token, id); err != nil {
+		return err
+	}
+
+	es.add(ctx, es.actor(ctx, token), userOperationEvent{id: id, operation: userDisable})
+
+	return nil
+}
+
+func (es eventStore) Backup(ctx context.Context, token string) (users.User, []users.User, error) {
+	return es.svc.Backup(ctx, token)
+}
+
+func (es eventStore) Restore(ctx context.Context, token string, admin users.User, us []users.User) error {
+	return es.svc.Restore(ctx, token, admin, us)
+}
+
+func (es eventStore) ExportBackup(ctx context.Context, token string, since time.Time) (backup.Stream, error) {
+	return es.svc.ExportBackup(ctx, token, since)
+}
+
+func (es eventStore) ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (backup.Summary, error) {
+	return es.svc.ImportBackup(ctx, token, dec, strategy)
+}
+
+func (es eventStore) ListLockouts(ctx context.Context, token, lockoutType string, offset, limit uint64) (users.LockoutsPage, error) {
+	return es.svc.ListLockouts(ctx, token, lockoutType, offset, limit)
+}
+
+func (es eventStore) ClearLockout(ctx context.Context, token, lockoutType, subject string) error {
+	if err := es.svc.ClearLockout(ctx, token, lockoutType, subject); err != nil {
+		return err
+	}
+
+	es.add(ctx, es.actor(ctx, token), clearLockoutEvent{lockoutType: lockoutType, subject: subject})
+
+	return nil
+}
+
+func (es eventStore) actor(ctx context.Context, token string) string {
+	user, err := es.svc.ViewProfile(ctx, token)
+	if err != nil {
+		return ""
+	}
+
+	return user.ID
+}
+
+func (es eventStore) add(ctx context.Context, actor string, ev event) {
+	values := ev.Encode()
+	if actor != "" {
+		values["actor"] = actor
+	}
+	if ip := auth.ClientIP(ctx); ip != "" {
+		values["ip"] = ip
+	}
+
+	record := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       values,
+	}
+	es.client.XAdd(ctx, record).Err()
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/users"
+	usmocks "github.com/MainfluxLabs/mainflux/users/mocks"
+	"github.com/MainfluxLabs/mainflux/users/redis"
+	r "github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	streamID     = "mainflux.users"
+	userRegister = "user.register"
+	userUpdate   = "user.update"
+)
+
+var (
+	user      = users.User{Email: "user@example.com", ID: "574106f7-030e-4881-8ab0-151195c29f96", Password: "password"}
+	usersList = []users.User{user}
+	passRegex = regexp.MustCompile("^.{8,}$")
+)
+
+func newService() users.Service {
+	auth := mocks.NewAuthService("", usersList)
+	repo := usmocks.NewUserRepository(usersList)
+	svc := users.New(repo, usmocks.NewHasher(), auth, usmocks.NewEmailer(), uuid.New(), users.PasswordPolicy{Regex: passRegex}, usmocks.NewLockoutRepository(), users.LockoutConfig{})
+	return redis.NewEventStoreMiddleware(svc, redisClient)
+}
+
+func readEvent(t *testing.T, lastID string) (map[string]interface{}, string) {
+	streams := redisClient.XRead(context.Background(), &r.XReadArgs{
+		Streams: []string{streamID, lastID},
+		Count:   1,
+		Block:   time.Second,
+	}).Val()
+
+	if len(streams) == 0 || len(streams[0].Messages) == 0 {
+		return nil, lastID
+	}
+
+	msg := streams[0].Messages[0]
+	return msg.Values, msg.ID
+}
+
+func TestSelfRegister(t *testing.T) {
+	redisClient.FlushAll(context.Background()).Err()
+	svc := newService()
+
+	cases := []struct {
+		desc  string
+		user  users.User
+		err   error
+		event bool
+	}{
+		{
+			desc:  "self register new user",
+			user:  users.User{Email: "new@example.com", Password: "password"},
+			err:   nil,
+			event: true,
+		},
+		{
+			desc:  "self register existing user",
+			user:  user,
+			err:   errors.ErrConflict,
+			event: false,
+		},
+	}
+
+	lastID := "0"
+	for _, tc := range cases {
+		id, err := svc.SelfRegister(context.Background(), tc.user)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		var event map[string]interface{}
+		event, lastID = readEvent(t, lastID)
+		if !tc.event {
+			assert.Nil(t, event, fmt.Sprintf("%s: expected no event got %v\n", tc.desc, event))
+			continue
+		}
+
+		assert.Equal(t, userRegister, event["operation"], fmt.Sprintf("%s: expected operation %s got %v\n", tc.desc, userRegister, event["operation"]))
+		assert.Equal(t, id, event["id"], fmt.Sprintf("%s: expected id %s got %v\n", tc.desc, id, event["id"]))
+		assert.Equal(t, id, event["actor"], fmt.Sprintf("%s: expected actor %s got %v\n", tc.desc, id, event["actor"]))
+		assert.Equal(t, tc.user.Email, event["email"], fmt.Sprintf("%s: expected email %s got %v\n", tc.desc, tc.user.Email, event["email"]))
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	redisClient.FlushAll(context.Background()).Err()
+	svc := newService()
+
+	cases := []struct {
+		desc  string
+		token string
+		user  users.User
+		err   error
+		event map[string]interface{}
+	}{
+		{
+			desc:  "update user metadata",
+			token: user.Email,
+			user:  users.User{Metadata: map[string]interface{}{"role": "test"}},
+			err:   nil,
+			event: map[string]interface{}{
+				"id":        user.ID,
+				"actor":     user.ID,
+				"metadata":  "{\"role\":\"test\"}",
+				"before":    "{\"metadata\":null}",
+				"operation": userUpdate,
+			},
+		},
+		{
+			desc:  "update user with invalid token",
+			token: "invalid",
+			user:  users.User{Metadata: map[string]interface{}{"role": "test"}},
+			err:   errors.ErrAuthentication,
+			event: nil,
+		},
+	}
+
+	lastID := "0"
+	for _, tc := range cases {
+		err := svc.UpdateUser(context.Background(), tc.token, tc.user)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		var event map[string]interface{}
+		event, lastID = readEvent(t, lastID)
+		assert.Equal(t, tc.event, event, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.event, event))
+	}
+}
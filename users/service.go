@@ -5,11 +5,12 @@ package users
 
 import (
 	"context"
-	"regexp"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 )
 
@@ -107,6 +108,23 @@ type Service interface {
 
 	// Restore restores users from backup. Only accessible by admin.
 	Restore(ctx context.Context, token string, admin User, users []User) error
+
+	// ExportBackup returns a stream of the admin and all users. Non-zero
+	// since limits the stream to the users created or updated after it.
+	// Only accessible by admin.
+	ExportBackup(ctx context.Context, token string, since time.Time) (backup.Stream, error)
+
+	// ImportBackup restores users read from the decoder, resolving conflicts
+	// with the existing users according to the strategy. Only accessible by
+	// admin.
+	ImportBackup(ctx context.Context, token string, dec *backup.Decoder, strategy string) (backup.Summary, error)
+
+	// ListLockouts retrieves the currently locked subjects of the given type.
+	// Only accessible by admin.
+	ListLockouts(ctx context.Context, token, lockoutType string, offset, limit uint64) (LockoutsPage, error)
+
+	// ClearLockout removes the lockout of the subject. Only accessible by admin.
+	ClearLockout(ctx context.Context, token, lockoutType, subject string) error
 }
 
 // PageMetadata contains page metadata that helps navigation.
@@ -133,24 +151,28 @@ type usersService struct {
 	email      Emailer
 	auth       mainflux.AuthServiceClient
 	idProvider mainflux.IDProvider
-	passRegex  *regexp.Regexp
+	passPolicy PasswordPolicy
+	lockouts   LockoutRepository
+	lockoutCfg LockoutConfig
 }
 
 // New instantiates the users service implementation
-func New(users UserRepository, hasher Hasher, auth mainflux.AuthServiceClient, e Emailer, idp mainflux.IDProvider, passRegex *regexp.Regexp) Service {
+func New(users UserRepository, hasher Hasher, auth mainflux.AuthServiceClient, e Emailer, idp mainflux.IDProvider, pp PasswordPolicy, lockouts LockoutRepository, lc LockoutConfig) Service {
 	return &usersService{
 		users:      users,
 		hasher:     hasher,
 		auth:       auth,
 		email:      e,
 		idProvider: idp,
-		passRegex:  passRegex,
+		passPolicy: pp,
+		lockouts:   lockouts,
+		lockoutCfg: lc,
 	}
 }
 
 func (svc usersService) SelfRegister(ctx context.Context, user User) (string, error) {
-	if !svc.passRegex.MatchString(user.Password) {
-		return "", ErrPasswordFormat
+	if err := svc.passPolicy.Validate(user.Password); err != nil {
+		return "", err
 	}
 
 	uid, err := svc.idProvider.ID()
@@ -198,8 +220,8 @@ func (svc usersService) RegisterAdmin(ctx context.Context, user User) error {
 		return nil
 	}
 
-	if !svc.passRegex.MatchString(user.Password) {
-		return ErrPasswordFormat
+	if err := svc.passPolicy.Validate(user.Password); err != nil {
+		return err
 	}
 
 	uid, err := svc.idProvider.ID()
@@ -241,8 +263,8 @@ func (svc usersService) Register(ctx context.Context, token string, user User) (
 		return "", err
 	}
 
-	if !svc.passRegex.MatchString(user.Password) {
-		return "", ErrPasswordFormat
+	if err := svc.passPolicy.Validate(user.Password); err != nil {
+		return "", err
 	}
 
 	uid, err := svc.idProvider.ID()
@@ -275,16 +297,36 @@ func (svc usersService) Register(ctx context.Context, token string, user User) (
 }
 
 func (svc usersService) Login(ctx context.Context, user User) (string, error) {
+	limits := svc.loginLimits(ctx, user.Email)
+	if err := svc.checkLocked(ctx, limits, ErrLockedOut); err != nil {
+		return "", err
+	}
+
 	dbUser, err := svc.users.RetrieveByEmail(ctx, user.Email)
 	if err != nil {
-		return "", errors.Wrap(errors.ErrAuthentication, err)
+		return "", svc.failLogin(ctx, limits, err)
 	}
 	if err := svc.hasher.Compare(user.Password, dbUser.Password); err != nil {
-		return "", errors.Wrap(errors.ErrAuthentication, err)
+		return "", svc.failLogin(ctx, limits, err)
+	}
+
+	if svc.lockoutCfg.MaxAttempts > 0 {
+		if err := svc.lockouts.Remove(ctx, AccountLockout, user.Email); err != nil {
+			return "", err
+		}
 	}
+
 	return svc.issue(ctx, dbUser.ID, dbUser.Email, auth.LoginKey)
 }
 
+func (svc usersService) failLogin(ctx context.Context, limits map[[2]string]limit, err error) error {
+	if err := svc.registerAttempts(ctx, limits); err != nil {
+		return err
+	}
+
+	return errors.Wrap(errors.ErrAuthentication, err)
+}
+
 func (svc usersService) ViewUser(ctx context.Context, token, id string) (User, error) {
 	if _, err := svc.identify(ctx, token); err != nil {
 		return User{}, err
@@ -423,6 +465,23 @@ func (svc usersService) UpdateUser(ctx context.Context, token string, u User) er
 }
 
 func (svc usersService) GenerateResetToken(ctx context.Context, email, host string) error {
+	if svc.lockoutCfg.ResetLimit > 0 {
+		limits := map[[2]string]limit{
+			{ResetLockout, email}: {
+				attempts:    svc.lockoutCfg.ResetLimit,
+				duration:    svc.lockoutCfg.ResetWindow,
+				maxDuration: svc.lockoutCfg.ResetWindow,
+				window:      svc.lockoutCfg.ResetWindow,
+			},
+		}
+		if err := svc.checkLocked(ctx, limits, ErrResetLimit); err != nil {
+			return err
+		}
+		if err := svc.registerAttempts(ctx, limits); err != nil {
+			return err
+		}
+	}
+
 	user, err := svc.users.RetrieveByEmail(ctx, email)
 	if err != nil || user.Email == "" {
 		return errors.ErrNotFound
@@ -446,14 +505,17 @@ func (svc usersService) ResetPassword(ctx context.Context, resetToken, password
 	if u.Email == "" {
 		return errors.ErrNotFound
 	}
-	if !svc.passRegex.MatchString(password) {
-		return ErrPasswordFormat
+	if err := svc.passPolicy.Validate(password); err != nil {
+		return err
+	}
+	if err := svc.checkHistory(ctx, u, password); err != nil {
+		return err
 	}
-	password, err = svc.hasher.Hash(password)
+	hash, err := svc.hasher.Hash(password)
 	if err != nil {
 		return err
 	}
-	return svc.users.UpdatePassword(ctx, ir.email, password)
+	return svc.updatePassword(ctx, u, hash)
 }
 
 func (svc usersService) ChangePassword(ctx context.Context, authToken, password, oldPassword string) error {
@@ -461,8 +523,8 @@ func (svc usersService) ChangePassword(ctx context.Context, authToken, password,
 	if err != nil {
 		return errors.Wrap(errors.ErrAuthentication, err)
 	}
-	if !svc.passRegex.MatchString(password) {
-		return ErrPasswordFormat
+	if err := svc.passPolicy.Validate(password); err != nil {
+		return err
 	}
 	u := User{
 		Email:    ir.email,
@@ -476,12 +538,15 @@ func (svc usersService) ChangePassword(ctx context.Context, authToken, password,
 	if err != nil || u.Email == "" {
 		return errors.ErrNotFound
 	}
+	if err := svc.checkHistory(ctx, u, password); err != nil {
+		return err
+	}
 
-	password, err = svc.hasher.Hash(password)
+	hash, err := svc.hasher.Hash(password)
 	if err != nil {
 		return err
 	}
-	return svc.users.UpdatePassword(ctx, ir.email, password)
+	return svc.updatePassword(ctx, u, hash)
 }
 
 func (svc usersService) SendPasswordReset(_ context.Context, host, email, token string) error {
@@ -542,6 +607,10 @@ func (svc usersService) identify(ctx context.Context, token string) (userIdentit
 		return userIdentity{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
 
+	if auth.IsScoped(identity) {
+		return userIdentity{}, errors.ErrAuthorization
+	}
+
 	return userIdentity{identity.Id, identity.Email}, nil
 }
 
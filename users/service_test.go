@@ -4,12 +4,19 @@
 package users_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/backup"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
@@ -20,8 +27,9 @@ import (
 )
 
 const (
-	wrong   = "wrong-value"
-	userNum = 101
+	wrong     = "wrong-value"
+	userNum   = 101
+	scopedKey = "scoped-key"
 )
 
 var (
@@ -36,15 +44,28 @@ var (
 
 	idProvider = uuid.New()
 	passRegex  = regexp.MustCompile("^.{8,}$")
+	passPolicy = users.PasswordPolicy{Regex: passRegex, History: 3}
+	lockoutCfg = users.LockoutConfig{
+		MaxAttempts:   3,
+		MaxIPAttempts: 5,
+		Duration:      time.Minute,
+		MaxDuration:   time.Hour,
+		Window:        time.Hour,
+		ResetLimit:    2,
+		ResetWindow:   time.Hour,
+	}
 )
 
 func newService() users.Service {
 	hasher := usmocks.NewHasher()
 	userRepo := usmocks.NewUserRepository(usersList)
-	authSvc := mocks.NewAuthService(admin.ID, usersList)
+	keys := map[string]*mainflux.UserIdentity{
+		scopedKey: {Id: user.ID, Email: user.Email, Scopes: []string{auth.ThingsReadScope}},
+	}
+	authSvc := mocks.NewAuthServiceWithKeys(admin.ID, usersList, keys)
 	e := usmocks.NewEmailer()
 
-	return users.New(userRepo, hasher, authSvc, e, idProvider, passRegex)
+	return users.New(userRepo, hasher, authSvc, e, idProvider, passPolicy, usmocks.NewLockoutRepository(), lockoutCfg)
 }
 
 func TestSelfRegister(t *testing.T) {
@@ -132,6 +153,95 @@ func TestLogin(t *testing.T) {
 	}
 }
 
+func TestLoginLockout(t *testing.T) {
+	svc := newService()
+	wrongPass := users.User{Email: registerUser.Email, Password: wrong}
+
+	for i := uint64(0); i < lockoutCfg.MaxAttempts; i++ {
+		_, err := svc.Login(context.Background(), wrongPass)
+		assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("expected %s got %s\n", errors.ErrAuthentication, err))
+	}
+
+	cases := []struct {
+		desc string
+		user users.User
+		err  error
+	}{
+		{
+			desc: "login locked account with good credentials",
+			user: registerUser,
+			err:  users.ErrLockedOut,
+		},
+		{
+			desc: "login locked account with wrong password",
+			user: wrongPass,
+			err:  users.ErrLockedOut,
+		},
+		{
+			desc: "login other account with good credentials",
+			user: admin,
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.Login(context.Background(), tc.user)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestLoginIPLockout(t *testing.T) {
+	svc := newService()
+	ctx := auth.WithClientIP(context.Background(), "10.0.0.1")
+
+	for i := uint64(0); i < lockoutCfg.MaxIPAttempts; i++ {
+		u := users.User{Email: fmt.Sprintf("user%d@example.com", i), Password: wrong}
+		_, err := svc.Login(ctx, u)
+		assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("expected %s got %s\n", errors.ErrAuthentication, err))
+	}
+
+	cases := []struct {
+		desc string
+		ctx  context.Context
+		err  error
+	}{
+		{
+			desc: "login from locked IP address",
+			ctx:  ctx,
+			err:  users.ErrLockedOut,
+		},
+		{
+			desc: "login from other IP address",
+			ctx:  auth.WithClientIP(context.Background(), "10.0.0.2"),
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.Login(tc.ctx, registerUser)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestLoginConcurrentAttempts(t *testing.T) {
+	svc := newService()
+	wrongPass := users.User{Email: registerUser.Email, Password: wrong}
+
+	// Every concurrent failed attempt is counted.
+	var wg sync.WaitGroup
+	for i := uint64(0); i < lockoutCfg.MaxAttempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svc.Login(context.Background(), wrongPass)
+		}()
+	}
+	wg.Wait()
+
+	_, err := svc.Login(context.Background(), registerUser)
+	assert.True(t, errors.Contains(err, users.ErrLockedOut), fmt.Sprintf("expected %s got %s\n", users.ErrLockedOut, err))
+}
+
 func TestViewUser(t *testing.T) {
 	svc := newService()
 
@@ -200,6 +310,11 @@ func TestViewProfile(t *testing.T) {
 			token: "",
 			err:   errors.ErrAuthentication,
 		},
+		"scoped API key's user info": {
+			user:  users.User{},
+			token: scopedKey,
+			err:   errors.ErrAuthorization,
+		},
 	}
 
 	for desc, tc := range cases {
@@ -356,6 +471,29 @@ func TestGenerateResetToken(t *testing.T) {
 	}
 }
 
+func TestGenerateResetTokenLimit(t *testing.T) {
+	svc := newService()
+
+	for i := uint64(0); i < lockoutCfg.ResetLimit; i++ {
+		err := svc.GenerateResetToken(context.Background(), registerUser.Email, host)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := map[string]struct {
+		email string
+		err   error
+	}{
+		"reset token over the limit":         {registerUser.Email, users.ErrResetLimit},
+		"reset token for other user":         {admin.Email, nil},
+		"reset token for non-existing email": {nonExistingUser.Email, errors.ErrNotFound},
+	}
+
+	for desc, tc := range cases {
+		err := svc.GenerateResetToken(context.Background(), tc.email, host)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+	}
+}
+
 func TestChangePassword(t *testing.T) {
 	svc := newService()
 	token, _ := svc.Login(context.Background(), registerUser)
@@ -378,6 +516,61 @@ func TestChangePassword(t *testing.T) {
 	}
 }
 
+func TestChangePasswordHistory(t *testing.T) {
+	svc := newService()
+	token, err := svc.Login(context.Background(), registerUser)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc        string
+		password    string
+		oldPassword string
+		err         error
+	}{
+		{
+			desc:        "change password to the current one",
+			password:    registerUser.Password,
+			oldPassword: registerUser.Password,
+			err:         users.ErrPasswordReused,
+		},
+		{
+			desc:        "change password to a new one",
+			password:    "new-password-1",
+			oldPassword: registerUser.Password,
+			err:         nil,
+		},
+		{
+			desc:        "change password to a new one again",
+			password:    "new-password-2",
+			oldPassword: "new-password-1",
+			err:         nil,
+		},
+		{
+			desc:        "change password to a recently used one",
+			password:    registerUser.Password,
+			oldPassword: "new-password-2",
+			err:         users.ErrPasswordReused,
+		},
+		{
+			desc:        "change password to a new one for the third time",
+			password:    "new-password-3",
+			oldPassword: "new-password-2",
+			err:         nil,
+		},
+		{
+			desc:        "change password to the one outside of the history",
+			password:    registerUser.Password,
+			oldPassword: "new-password-3",
+			err:         nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ChangePassword(context.Background(), token, tc.password, tc.oldPassword)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestResetPassword(t *testing.T) {
 	svc := newService()
 	authSvc := mocks.NewAuthService("", []users.User{registerUser})
@@ -417,3 +610,240 @@ func TestSendPasswordReset(t *testing.T) {
 
 	}
 }
+
+func TestListLockouts(t *testing.T) {
+	svc := newService()
+	wrongPass := users.User{Email: registerUser.Email, Password: wrong}
+	for i := uint64(0); i < lockoutCfg.MaxAttempts; i++ {
+		_, err := svc.Login(context.Background(), wrongPass)
+		require.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("expected %s got %s\n", errors.ErrAuthentication, err))
+	}
+
+	cases := []struct {
+		desc        string
+		token       string
+		lockoutType string
+		size        int
+		err         error
+	}{
+		{
+			desc:        "list account lockouts",
+			token:       admin.Email,
+			lockoutType: users.AccountLockout,
+			size:        1,
+			err:         nil,
+		},
+		{
+			desc:  "list all lockouts",
+			token: admin.Email,
+			size:  1,
+			err:   nil,
+		},
+		{
+			desc:        "list reset lockouts",
+			token:       admin.Email,
+			lockoutType: users.ResetLockout,
+			size:        0,
+			err:         nil,
+		},
+		{
+			desc:        "list lockouts of invalid type",
+			token:       admin.Email,
+			lockoutType: wrong,
+			size:        0,
+			err:         users.ErrInvalidLockoutType,
+		},
+		{
+			desc:        "list lockouts as non-admin user",
+			token:       registerUser.Email,
+			lockoutType: users.AccountLockout,
+			size:        0,
+			err:         errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		lp, err := svc.ListLockouts(context.Background(), tc.token, tc.lockoutType, 0, 10)
+		assert.Equal(t, tc.size, len(lp.Lockouts), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(lp.Lockouts)))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestClearLockout(t *testing.T) {
+	svc := newService()
+	wrongPass := users.User{Email: registerUser.Email, Password: wrong}
+	for i := uint64(0); i < lockoutCfg.MaxAttempts; i++ {
+		_, err := svc.Login(context.Background(), wrongPass)
+		require.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("expected %s got %s\n", errors.ErrAuthentication, err))
+	}
+
+	cases := []struct {
+		desc        string
+		token       string
+		lockoutType string
+		err         error
+	}{
+		{
+			desc:        "clear lockout as non-admin user",
+			token:       registerUser.Email,
+			lockoutType: users.AccountLockout,
+			err:         errors.ErrAuthorization,
+		},
+		{
+			desc:        "clear lockout of invalid type",
+			token:       admin.Email,
+			lockoutType: wrong,
+			err:         users.ErrInvalidLockoutType,
+		},
+		{
+			desc:        "clear account lockout",
+			token:       admin.Email,
+			lockoutType: users.AccountLockout,
+			err:         nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ClearLockout(context.Background(), tc.token, tc.lockoutType, registerUser.Email)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err := svc.Login(context.Background(), registerUser)
+	assert.Nil(t, err, fmt.Sprintf("login after clearing lockout: unexpected error: %s", err))
+}
+
+func TestExportBackup(t *testing.T) {
+	svc := newService()
+	since := time.Now()
+	_, err := svc.Register(context.Background(), admin.Email, nonExistingUser)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		since   time.Time
+		records map[string]int
+		err     error
+	}{
+		{
+			desc:    "export backup as admin",
+			token:   admin.Email,
+			records: map[string]int{"admin": 1, "user": len(usersList)},
+			err:     nil,
+		},
+		{
+			desc:    "export incremental backup as admin",
+			token:   admin.Email,
+			since:   since,
+			records: map[string]int{"user": 1},
+			err:     nil,
+		},
+		{
+			desc:    "export incremental backup without updated users",
+			token:   admin.Email,
+			since:   time.Now(),
+			records: map[string]int{},
+			err:     nil,
+		},
+		{
+			desc:  "export backup as user",
+			token: user.Email,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "export backup with invalid token",
+			token: wrong,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		stream, err := svc.ExportBackup(context.Background(), tc.token, tc.since)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+
+		var buf bytes.Buffer
+		err = stream(backup.NewEncoder(&buf))
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		dec := backup.NewDecoder(&buf)
+		records := map[string]int{}
+		for {
+			rec, err := dec.Next()
+			if err == io.EOF {
+				break
+			}
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			records[rec.Type]++
+		}
+		assert.Equal(t, tc.records, records, fmt.Sprintf("%s: expected records %v got %v\n", tc.desc, tc.records, records))
+	}
+}
+
+func TestImportBackup(t *testing.T) {
+	src := newService()
+	_, err := src.Register(context.Background(), admin.Email, nonExistingUser)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	stream, err := src.ExportBackup(context.Background(), admin.Email, time.Time{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	var buf bytes.Buffer
+	err = stream(backup.NewEncoder(&buf))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	data := buf.String()
+
+	svc := newService()
+	existing := uint64(len(usersList) - 1)
+
+	cases := []struct {
+		desc     string
+		token    string
+		strategy string
+		summary  backup.Summary
+		err      error
+	}{
+		{
+			desc:     "import backup as user",
+			token:    user.Email,
+			strategy: backup.StrategySkip,
+			err:      errors.ErrAuthorization,
+		},
+		{
+			desc:     "import backup with invalid strategy",
+			token:    admin.Email,
+			strategy: wrong,
+			err:      backup.ErrInvalidStrategy,
+		},
+		{
+			desc:     "import backup skipping existing users",
+			token:    admin.Email,
+			strategy: backup.StrategySkip,
+			summary:  backup.Summary{Created: 1, Updated: 1, Skipped: existing},
+			err:      nil,
+		},
+		{
+			desc:     "import backup overwriting existing users",
+			token:    admin.Email,
+			strategy: backup.StrategyOverwrite,
+			summary:  backup.Summary{Updated: existing + 2},
+			err:      nil,
+		},
+		{
+			desc:     "import backup failing on existing users",
+			token:    admin.Email,
+			strategy: backup.StrategyFail,
+			err:      errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		dec := backup.NewDecoder(strings.NewReader(data))
+		summary, err := svc.ImportBackup(context.Background(), tc.token, dec, tc.strategy)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.summary, summary, fmt.Sprintf("%s: expected summary %v got %v\n", tc.desc, tc.summary, summary))
+		}
+	}
+}
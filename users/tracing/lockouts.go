@@ -0,0 +1,85 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/users"
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+const (
+	saveLockoutOp     = "save_lockout"
+	retrieveLockoutOp = "retrieve_lockout"
+	registerAttemptOp = "register_attempt"
+	lockOp            = "lock"
+	retrieveLockedOp  = "retrieve_locked"
+	removeLockoutOp   = "remove_lockout"
+)
+
+var _ users.LockoutRepository = (*lockoutRepositoryMiddleware)(nil)
+
+type lockoutRepositoryMiddleware struct {
+	tracer opentracing.Tracer
+	repo   users.LockoutRepository
+}
+
+// LockoutRepositoryMiddleware tracks request and their latency, and adds spans
+// to context.
+func LockoutRepositoryMiddleware(repo users.LockoutRepository, tracer opentracing.Tracer) users.LockoutRepository {
+	return lockoutRepositoryMiddleware{
+		tracer: tracer,
+		repo:   repo,
+	}
+}
+
+func (lrm lockoutRepositoryMiddleware) Save(ctx context.Context, l users.Lockout) error {
+	span := createSpan(ctx, lrm.tracer, saveLockoutOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return lrm.repo.Save(ctx, l)
+}
+
+func (lrm lockoutRepositoryMiddleware) Retrieve(ctx context.Context, lockoutType, subject string) (users.Lockout, error) {
+	span := createSpan(ctx, lrm.tracer, retrieveLockoutOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return lrm.repo.Retrieve(ctx, lockoutType, subject)
+}
+
+func (lrm lockoutRepositoryMiddleware) RegisterAttempt(ctx context.Context, lockoutType, subject string, t, windowStart, progressionStart time.Time) (users.Lockout, error) {
+	span := createSpan(ctx, lrm.tracer, registerAttemptOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return lrm.repo.RegisterAttempt(ctx, lockoutType, subject, t, windowStart, progressionStart)
+}
+
+func (lrm lockoutRepositoryMiddleware) Lock(ctx context.Context, lockoutType, subject string, attempts uint64, until time.Time) error {
+	span := createSpan(ctx, lrm.tracer, lockOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return lrm.repo.Lock(ctx, lockoutType, subject, attempts, until)
+}
+
+func (lrm lockoutRepositoryMiddleware) RetrieveLocked(ctx context.Context, lockoutType string, t time.Time, offset, limit uint64) (users.LockoutsPage, error) {
+	span := createSpan(ctx, lrm.tracer, retrieveLockedOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return lrm.repo.RetrieveLocked(ctx, lockoutType, t, offset, limit)
+}
+
+func (lrm lockoutRepositoryMiddleware) Remove(ctx context.Context, lockoutType, subject string) error {
+	span := createSpan(ctx, lrm.tracer, removeLockoutOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return lrm.repo.Remove(ctx, lockoutType, subject)
+}
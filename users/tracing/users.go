@@ -7,20 +7,25 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/users"
 	opentracing "github.com/opentracing/opentracing-go"
 )
 
 const (
-	saveOp            = "save"
-	updateOp          = "update"
-	retrieveByEmailOp = "retrieve_by_email"
-	retrieveByIDOp    = "retrieve_by_id"
-	retrieveByIDsOp   = "retrieve_by_ids"
-	retrieveAllOp     = "retrieve_all"
-	updatePasswordOp  = "update_password"
-	changeStatusOp    = "change_status"
+	saveOp             = "save"
+	updateOp           = "update"
+	retrieveByEmailOp  = "retrieve_by_email"
+	retrieveByIDOp     = "retrieve_by_id"
+	retrieveByIDsOp    = "retrieve_by_ids"
+	retrieveAllOp      = "retrieve_all"
+	retrieveUpdatedOp  = "retrieve_updated"
+	updatePasswordOp   = "update_password"
+	changeStatusOp     = "change_status"
+	savePassHistOp     = "save_password_history"
+	retrievePassHistOp = "retrieve_password_history"
+	removePassHistOp   = "remove_password_history"
 )
 
 var _ users.UserRepository = (*userRepositoryMiddleware)(nil)
@@ -103,6 +108,38 @@ func (urm userRepositoryMiddleware) ChangeStatus(ctx context.Context, id, status
 	return urm.repo.ChangeStatus(ctx, id, status)
 }
 
+func (urm userRepositoryMiddleware) RetrieveUpdated(ctx context.Context, since time.Time) ([]users.User, error) {
+	span := createSpan(ctx, urm.tracer, retrieveUpdatedOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.RetrieveUpdated(ctx, since)
+}
+
+func (urm userRepositoryMiddleware) SavePasswordHistory(ctx context.Context, userID, password string) error {
+	span := createSpan(ctx, urm.tracer, savePassHistOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.SavePasswordHistory(ctx, userID, password)
+}
+
+func (urm userRepositoryMiddleware) RetrievePasswordHistory(ctx context.Context, userID string, limit uint64) ([]string, error) {
+	span := createSpan(ctx, urm.tracer, retrievePassHistOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.RetrievePasswordHistory(ctx, userID, limit)
+}
+
+func (urm userRepositoryMiddleware) RemovePasswordHistory(ctx context.Context, userID string, keep uint64) error {
+	span := createSpan(ctx, urm.tracer, removePassHistOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.RemovePasswordHistory(ctx, userID, keep)
+}
+
 func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		return tracer.StartSpan(
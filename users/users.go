@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"golang.org/x/net/idna"
@@ -77,6 +78,20 @@ type UserRepository interface {
 
 	// RetrieveAll retrieves all users.
 	RetrieveAll(ctx context.Context) ([]User, error)
+
+	// RetrieveUpdated retrieves the users created or updated since the given time.
+	RetrieveUpdated(ctx context.Context, since time.Time) ([]User, error)
+
+	// SavePasswordHistory stores the password hash the user used before.
+	SavePasswordHistory(ctx context.Context, userID, password string) error
+
+	// RetrievePasswordHistory retrieves up to limit most recent password
+	// hashes the user used before.
+	RetrievePasswordHistory(ctx context.Context, userID string, limit uint64) ([]string, error)
+
+	// RemovePasswordHistory removes all but the keep most recent password
+	// hashes the user used before.
+	RemovePasswordHistory(ctx context.Context, userID string, keep uint64) error
 }
 
 func isEmail(email string) bool {
@@ -13,6 +13,9 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
+	"github.com/MainfluxLabs/mainflux/things"
 )
 
 const (
@@ -55,26 +58,47 @@ type Service interface {
 
 	// Unsubscribe method is used to stop observing resource.
 	Unsubscribe(ctx context.Context, thingKey, chanID, subtopic string) error
+
+	// SubscribeStream subscribes the user stream to messages of the channel,
+	// provided that the user identified by the token can read them.
+	SubscribeStream(ctx context.Context, token, chanID, subtopic string, stream *Stream) error
+
+	// UnsubscribeStream unsubscribes the user stream from messages of the channel.
+	UnsubscribeStream(ctx context.Context, chanID, subtopic string, stream *Stream) error
+
+	// CloseStream unsubscribes the user stream from all the channels and closes it.
+	CloseStream(ctx context.Context, stream *Stream) error
+
+	// History retrieves messages of the channel stored since the given time,
+	// in seconds, which are replayed to the user streams resuming from it.
+	History(ctx context.Context, token, chanID, subtopic string, from float64) (History, error)
 }
 
 var _ Service = (*adapterService)(nil)
 
 type adapterService struct {
-	things mainflux.ThingsServiceClient
-	pubsub messaging.PubSub
+	things    mainflux.ThingsServiceClient
+	pubsub    messaging.PubSub
+	limiter   ratelimit.Limiter
+	validator schema.ChannelValidator
+	reader    MessageReader
 }
 
-// New instantiates the WS adapter implementation
-func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub) Service {
+// New instantiates the WS adapter implementation. The reader is optional,
+// streams don't replay stored messages without it.
+func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub, limiter ratelimit.Limiter, validator schema.ChannelValidator, reader MessageReader) Service {
 	return &adapterService{
-		things: things,
-		pubsub: pubsub,
+		things:    things,
+		pubsub:    pubsub,
+		limiter:   limiter,
+		validator: validator,
+		reader:    reader,
 	}
 }
 
 // Publish publishes the message using the broker
 func (svc *adapterService) Publish(ctx context.Context, thingKey string, msg messaging.Message) error {
-	conn, err := svc.authorize(ctx, thingKey, msg.GetChannel())
+	thingID, err := svc.authorize(ctx, thingKey, msg.GetChannel(), things.PublishAction)
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
@@ -83,7 +107,19 @@ func (svc *adapterService) Publish(ctx context.Context, thingKey string, msg mes
 		return ErrFailedMessagePublish
 	}
 
-	msg.Publisher = conn.ThingID
+	if err := svc.limiter.CheckPayload(len(msg.Payload)); err != nil {
+		return err
+	}
+
+	if err := svc.limiter.Allow(thingID); err != nil {
+		return err
+	}
+
+	if err := svc.validator.Validate(ctx, msg.GetChannel(), msg.Payload); err != nil {
+		return err
+	}
+
+	msg.Publisher = thingID
 
 	if err := svc.pubsub.Publish(msg.GetChannel(), msg); err != nil {
 		return ErrFailedMessagePublish
@@ -98,19 +134,14 @@ func (svc *adapterService) Subscribe(ctx context.Context, thingKey, chanID, subt
 		return ErrUnauthorizedAccess
 	}
 
-	conn, err := svc.authorize(ctx, thingKey, chanID)
+	thingID, err := svc.authorize(ctx, thingKey, chanID, things.SubscribeAction)
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
 
-	c.id = conn.ThingID
+	c.id = thingID
 
-	subject := fmt.Sprintf("%s.%s", chansPrefix, chanID)
-	if subtopic != "" {
-		subject = fmt.Sprintf("%s.%s", subject, subtopic)
-	}
-
-	if err := svc.pubsub.Subscribe(conn.ChannelID, subject, c); err != nil {
+	if err := svc.pubsub.Subscribe(thingID, subject(chanID, subtopic), c); err != nil {
 		return ErrFailedSubscription
 	}
 
@@ -123,27 +154,94 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, thingKey, chanID, su
 		return ErrUnauthorizedAccess
 	}
 
-	conn, err := svc.authorize(ctx, thingKey, chanID)
+	thingID, err := svc.authorize(ctx, thingKey, chanID, things.SubscribeAction)
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
 
+	return svc.pubsub.Unsubscribe(thingID, subject(chanID, subtopic))
+}
+
+// SubscribeStream subscribes the user stream to the channel topic.
+func (svc *adapterService) SubscribeStream(ctx context.Context, token, chanID, subtopic string, stream *Stream) error {
+	if chanID == "" {
+		return ErrEmptyID
+	}
+	if token == "" {
+		return ErrUnauthorizedAccess
+	}
+
+	if _, err := svc.things.CanReadChannel(ctx, &mainflux.ChannelReadReq{Token: token, ChanID: chanID}); err != nil {
+		return ErrUnauthorizedAccess
+	}
+
+	topic := subject(chanID, subtopic)
+	if err := svc.pubsub.Subscribe(stream.ID(), topic, stream); err != nil {
+		return ErrFailedSubscription
+	}
+	stream.add(topic)
+
+	return nil
+}
+
+// UnsubscribeStream unsubscribes the user stream from the channel topic.
+func (svc *adapterService) UnsubscribeStream(ctx context.Context, chanID, subtopic string, stream *Stream) error {
+	if chanID == "" {
+		return ErrEmptyID
+	}
+
+	topic := subject(chanID, subtopic)
+	if err := svc.pubsub.Unsubscribe(stream.ID(), topic); err != nil {
+		return ErrFailedUnsubscribe
+	}
+	stream.remove(topic)
+
+	return nil
+}
+
+// CloseStream unsubscribes the user stream from all of its topics.
+func (svc *adapterService) CloseStream(ctx context.Context, stream *Stream) error {
+	stream.close()
+
+	var err error
+	for _, topic := range stream.subscriptions() {
+		if e := svc.pubsub.Unsubscribe(stream.ID(), topic); e != nil {
+			err = ErrFailedUnsubscribe
+		}
+		stream.remove(topic)
+	}
+
+	return err
+}
+
+// History retrieves the stored channel messages using the readers.
+func (svc *adapterService) History(ctx context.Context, token, chanID, subtopic string, from float64) (History, error) {
+	if svc.reader == nil {
+		return History{}, nil
+	}
+
+	return svc.reader.ReadMessages(ctx, token, chanID, subtopic, from)
+}
+
+func subject(chanID, subtopic string) string {
 	subject := fmt.Sprintf("%s.%s", chansPrefix, chanID)
 	if subtopic != "" {
 		subject = fmt.Sprintf("%s.%s", subject, subtopic)
 	}
 
-	return svc.pubsub.Unsubscribe(conn.ChannelID, subject)
+	return subject
 }
 
-func (svc *adapterService) authorize(ctx context.Context, thingKey, chanID string) (*mainflux.ConnByKeyRes, error) {
-	ar := &mainflux.ConnByKeyReq{
-		Key: thingKey,
+func (svc *adapterService) authorize(ctx context.Context, thingKey, chanID, action string) (string, error) {
+	ar := &mainflux.AccessByKeyReq{
+		Token:  thingKey,
+		ChanID: chanID,
+		Action: action,
 	}
-	conn, err := svc.things.GetConnByKey(ctx, ar)
+	thid, err := svc.things.CanAccessByKey(ctx, ar)
 	if err != nil {
-		return nil, errors.Wrap(errors.ErrAuthorization, err)
+		return "", errors.Wrap(errors.ErrAuthorization, err)
 	}
 
-	return conn, nil
+	return thid.GetValue(), nil
 }
@@ -5,12 +5,16 @@ package ws_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	thmock "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/ws"
 	"github.com/MainfluxLabs/mainflux/ws/mocks"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +25,7 @@ const (
 	id       = "1"
 	thingKey = "thing_key"
 	subTopic = "subtopic"
+	token    = "user_token"
 	protocol = "ws"
 )
 
@@ -34,7 +39,7 @@ var msg = messaging.Message{
 
 func newService(tc mainflux.ThingsServiceClient) (ws.Service, mocks.MockPubSub) {
 	pubsub := mocks.NewPubSub()
-	return ws.New(tc, pubsub), pubsub
+	return ws.New(tc, pubsub, ratelimit.New(ratelimit.Config{}), schema.NewChannelValidator(tc), nil), pubsub
 }
 
 func TestPublish(t *testing.T) {
@@ -68,9 +73,15 @@ func TestPublish(t *testing.T) {
 		{
 			desc:     "publish an empty message with valid thingKey",
 			thingKey: thingKey,
-			msg:      messaging.Message{},
+			msg:      messaging.Message{Channel: chanID},
 			err:      ws.ErrFailedMessagePublish,
 		},
+		{
+			desc:     "publish a valid message to a channel the thing is not connected to",
+			thingKey: thingKey,
+			msg:      messaging.Message{Channel: "2", Payload: msg.Payload},
+			err:      ws.ErrUnauthorizedAccess,
+		},
 		{
 			desc:     "publish an empty message with empty thingKey",
 			thingKey: "",
@@ -91,6 +102,69 @@ func TestPublish(t *testing.T) {
 	}
 }
 
+func TestPublishLimits(t *testing.T) {
+	thingsClient := thmock.NewThingsServiceClient(map[string]string{thingKey: chanID}, nil)
+	limiter := ratelimit.New(ratelimit.Config{Rate: 0.001, Burst: 1, MaxPayloadSize: len(msg.Payload)})
+	svc := ws.New(thingsClient, mocks.NewPubSub(), limiter, schema.NewChannelValidator(thingsClient), nil)
+
+	cases := []struct {
+		desc string
+		msg  messaging.Message
+		err  error
+	}{
+		{
+			desc: "publish a message exceeding the maximum payload size",
+			msg:  messaging.Message{Channel: chanID, Payload: append(msg.Payload, ' ')},
+			err:  ratelimit.ErrPayloadTooLarge,
+		},
+		{
+			desc: "publish a message within the rate limit",
+			msg:  msg,
+			err:  nil,
+		},
+		{
+			desc: "publish a message exceeding the rate limit",
+			msg:  msg,
+			err:  ratelimit.ErrRateLimited,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Publish(context.Background(), thingKey, tc.msg)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPublishSchema(t *testing.T) {
+	schemas := map[string]schema.Schema{
+		chanID: {SenML: map[string]string{"current": "A"}},
+	}
+	thingsClient := thmock.NewThingsServiceClientWithSchemas(map[string]string{thingKey: chanID}, nil, schemas)
+	svc, _ := newService(thingsClient)
+
+	cases := []struct {
+		desc string
+		msg  messaging.Message
+		err  error
+	}{
+		{
+			desc: "publish a message conforming to the channel schema",
+			msg:  messaging.Message{Channel: chanID, Payload: []byte(`[{"n":"current","u":"A","v":1.2}]`)},
+			err:  nil,
+		},
+		{
+			desc: "publish a message not conforming to the channel schema",
+			msg:  messaging.Message{Channel: chanID, Payload: []byte(`[{"n":"voltage","u":"V","v":230}]`)},
+			err:  schema.ErrInvalidPayload,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Publish(context.Background(), thingKey, tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestSubscribe(t *testing.T) {
 	thingsClient := thmock.NewThingsServiceClient(map[string]string{thingKey: chanID}, nil)
 	svc, pubsub := newService(thingsClient)
@@ -238,3 +312,212 @@ func TestUnsubscribe(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestSubscribeStream(t *testing.T) {
+	thingsClient := thmock.NewThingsServiceClient(map[string]string{token: chanID}, nil)
+	svc, pubsub := newService(thingsClient)
+
+	stream, err := ws.NewStream(1)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error creating stream: %s\n", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		chanID   string
+		subtopic string
+		fail     bool
+		err      error
+	}{
+		{
+			desc:     "subscribe stream to channel with valid token, chanID, subtopic",
+			token:    token,
+			chanID:   chanID,
+			subtopic: subTopic,
+			fail:     false,
+			err:      nil,
+		},
+		{
+			desc:     "subscribe stream to channel with valid token, chanID and empty subtopic",
+			token:    token,
+			chanID:   chanID,
+			subtopic: "",
+			fail:     false,
+			err:      nil,
+		},
+		{
+			desc:     "subscribe stream to channel with subscribe set to fail",
+			token:    token,
+			chanID:   chanID,
+			subtopic: subTopic,
+			fail:     true,
+			err:      ws.ErrFailedSubscription,
+		},
+		{
+			desc:     "subscribe stream to channel the user can't read",
+			token:    token,
+			chanID:   "2",
+			subtopic: subTopic,
+			fail:     false,
+			err:      ws.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "subscribe stream to channel with invalid token",
+			token:    "invalid",
+			chanID:   chanID,
+			subtopic: subTopic,
+			fail:     false,
+			err:      ws.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "subscribe stream to channel with empty token",
+			token:    "",
+			chanID:   chanID,
+			subtopic: subTopic,
+			fail:     false,
+			err:      ws.ErrUnauthorizedAccess,
+		},
+		{
+			desc:     "subscribe stream to channel with empty channel",
+			token:    token,
+			chanID:   "",
+			subtopic: subTopic,
+			fail:     false,
+			err:      ws.ErrEmptyID,
+		},
+	}
+
+	for _, tc := range cases {
+		pubsub.SetFail(tc.fail)
+		err := svc.SubscribeStream(context.Background(), tc.token, tc.chanID, tc.subtopic, stream)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUnsubscribeStream(t *testing.T) {
+	thingsClient := thmock.NewThingsServiceClient(map[string]string{token: chanID}, nil)
+	svc, pubsub := newService(thingsClient)
+
+	stream, err := ws.NewStream(1)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error creating stream: %s\n", err))
+	err = svc.SubscribeStream(context.Background(), token, chanID, subTopic, stream)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error subscribing stream: %s\n", err))
+
+	cases := []struct {
+		desc     string
+		chanID   string
+		subtopic string
+		fail     bool
+		err      error
+	}{
+		{
+			desc:     "unsubscribe stream from channel with valid chanID and subtopic",
+			chanID:   chanID,
+			subtopic: subTopic,
+			fail:     false,
+			err:      nil,
+		},
+		{
+			desc:     "unsubscribe stream from channel with unsubscribe set to fail",
+			chanID:   chanID,
+			subtopic: subTopic,
+			fail:     true,
+			err:      ws.ErrFailedUnsubscribe,
+		},
+		{
+			desc:     "unsubscribe stream from channel with empty channel",
+			chanID:   "",
+			subtopic: subTopic,
+			fail:     false,
+			err:      ws.ErrEmptyID,
+		},
+	}
+
+	for _, tc := range cases {
+		pubsub.SetFail(tc.fail)
+		err := svc.UnsubscribeStream(context.Background(), tc.chanID, tc.subtopic, stream)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestCloseStream(t *testing.T) {
+	thingsClient := thmock.NewThingsServiceClient(map[string]string{token: chanID}, nil)
+	svc, _ := newService(thingsClient)
+
+	stream, err := ws.NewStream(1)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error creating stream: %s\n", err))
+	err = svc.SubscribeStream(context.Background(), token, chanID, subTopic, stream)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error subscribing stream: %s\n", err))
+
+	err = stream.Handle(msg)
+	assert.Nil(t, err, fmt.Sprintf("handle message: expected no error got %s\n", err))
+	err = stream.Handle(msg)
+	assert.Equal(t, ws.ErrStreamFull, err, fmt.Sprintf("handle message on full stream: expected %s got %s\n", ws.ErrStreamFull, err))
+
+	err = svc.CloseStream(context.Background(), stream)
+	assert.Nil(t, err, fmt.Sprintf("close stream: expected no error got %s\n", err))
+
+	select {
+	case <-stream.Done():
+	default:
+		assert.Fail(t, "close stream: expected stream to be done")
+	}
+	err = stream.Handle(msg)
+	assert.Nil(t, err, fmt.Sprintf("handle message on closed stream: expected no error got %s\n", err))
+}
+
+func TestHistory(t *testing.T) {
+	thingsClient := thmock.NewThingsServiceClient(map[string]string{token: chanID}, nil)
+	msgs := []json.RawMessage{
+		json.RawMessage(`{"channel":"1","name":"current","value":1.2}`),
+		json.RawMessage(`{"channel":"1","name":"current","value":1.3}`),
+		json.RawMessage(`{"channel":"1","name":"current","value":1.4}`),
+	}
+	reader := mocks.NewReader(map[string][]json.RawMessage{chanID: msgs[:1]}, 0)
+	limitedReader := mocks.NewReader(map[string][]json.RawMessage{chanID: msgs}, 2)
+	svc := ws.New(thingsClient, mocks.NewPubSub(), ratelimit.New(ratelimit.Config{}), schema.NewChannelValidator(thingsClient), reader)
+	limitedSvc := ws.New(thingsClient, mocks.NewPubSub(), ratelimit.New(ratelimit.Config{}), schema.NewChannelValidator(thingsClient), limitedReader)
+	noHistorySvc, _ := newService(thingsClient)
+
+	cases := []struct {
+		desc    string
+		svc     ws.Service
+		token   string
+		history ws.History
+		err     error
+	}{
+		{
+			desc:    "read history with valid token",
+			svc:     svc,
+			token:   token,
+			history: ws.History{Messages: msgs[:1]},
+			err:     nil,
+		},
+		{
+			desc:    "read history exceeding the limit",
+			svc:     limitedSvc,
+			token:   token,
+			history: ws.History{Messages: msgs[1:], Skipped: 1},
+			err:     nil,
+		},
+		{
+			desc:    "read history with empty token",
+			svc:     svc,
+			token:   "",
+			history: ws.History{},
+			err:     ws.ErrUnauthorizedAccess,
+		},
+		{
+			desc:    "read history without reader",
+			svc:     noHistorySvc,
+			token:   token,
+			history: ws.History{},
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := tc.svc.History(context.Background(), tc.token, chanID, "", 1)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.history, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.history, res))
+	}
+}
@@ -4,15 +4,21 @@
 package api_test
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	log "github.com/MainfluxLabs/mainflux/logger"
 	thmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/ws"
 	"github.com/MainfluxLabs/mainflux/ws/api"
 	"github.com/MainfluxLabs/mainflux/ws/mocks"
@@ -25,18 +31,19 @@ const (
 	id       = "1"
 	thingKey = "c02ff576-ccd5-40f6-ba5f-c85377aad529"
 	protocol = "ws"
+	token    = "user_token"
 )
 
 var msg = []byte(`[{"n":"current","t":-1,"v":1.6}]`)
 
 func newService(tc mainflux.ThingsServiceClient) (ws.Service, mocks.MockPubSub) {
 	pubsub := mocks.NewPubSub()
-	return ws.New(tc, pubsub), pubsub
+	return ws.New(tc, pubsub, ratelimit.New(ratelimit.Config{}), schema.NewChannelValidator(tc), nil), pubsub
 }
 
 func newHTTPServer(svc ws.Service) *httptest.Server {
 	logger := log.NewMock()
-	mux := api.MakeHandler(svc, logger)
+	mux := api.MakeHandler(svc, time.Second, logger)
 	return httptest.NewServer(mux)
 }
 
@@ -185,3 +192,210 @@ func TestHandshake(t *testing.T) {
 		}
 	}
 }
+
+func TestStreamHandshake(t *testing.T) {
+	thingsClient := thmocks.NewThingsServiceClient(map[string]string{token: chanID}, nil)
+	svc, _ := newService(thingsClient)
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	u.Scheme = protocol
+	u.Path = "/messages"
+
+	cases := []struct {
+		desc    string
+		token   string
+		status  int
+		req     string
+		resType string
+	}{
+		{
+			desc:    "subscribe to channel",
+			token:   token,
+			status:  http.StatusSwitchingProtocols,
+			req:     fmt.Sprintf(`{"type":"subscribe","channel":"%s","subtopic":"subtopic"}`, chanID),
+			resType: "subscribed",
+		},
+		{
+			desc:    "unsubscribe from channel",
+			token:   token,
+			status:  http.StatusSwitchingProtocols,
+			req:     fmt.Sprintf(`{"type":"unsubscribe","channel":"%s","subtopic":"subtopic"}`, chanID),
+			resType: "unsubscribed",
+		},
+		{
+			desc:    "subscribe to channel the user can't read",
+			token:   token,
+			status:  http.StatusSwitchingProtocols,
+			req:     `{"type":"subscribe","channel":"invalid"}`,
+			resType: "error",
+		},
+		{
+			desc:    "subscribe to subtopic with invalid name",
+			token:   token,
+			status:  http.StatusSwitchingProtocols,
+			req:     fmt.Sprintf(`{"type":"subscribe","channel":"%s","subtopic":"a*b"}`, chanID),
+			resType: "error",
+		},
+		{
+			desc:    "send unknown command",
+			token:   token,
+			status:  http.StatusSwitchingProtocols,
+			req:     fmt.Sprintf(`{"type":"unknown","channel":"%s"}`, chanID),
+			resType: "error",
+		},
+		{
+			desc:    "send malformed command",
+			token:   token,
+			status:  http.StatusSwitchingProtocols,
+			req:     `{"type":`,
+			resType: "error",
+		},
+		{
+			desc:   "connect with empty token",
+			token:  "",
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		header := http.Header{}
+		if tc.token != "" {
+			header.Set("Authorization", "Bearer "+tc.token)
+		}
+		conn, res, err := websocket.DefaultDialer.Dial(u.String(), header)
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code '%d' got '%d'\n", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusSwitchingProtocols {
+			continue
+		}
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+
+		err = conn.WriteMessage(websocket.TextMessage, []byte(tc.req))
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+
+		var ev struct {
+			Type string `json:"type"`
+		}
+		err = conn.ReadJSON(&ev)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.resType, ev.Type, fmt.Sprintf("%s: expected event '%s' got '%s'\n", tc.desc, tc.resType, ev.Type))
+
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		conn.ReadMessage()
+		conn.Close()
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	gapToken, gapChanID := "gap-token", "gap-channel"
+	thingsClient := thmocks.NewThingsServiceClient(map[string]string{token: chanID, gapToken: gapChanID}, nil)
+	history := json.RawMessage(`{"channel":"1","name":"current","value":1.2}`)
+	reader := mocks.NewReader(map[string][]json.RawMessage{chanID: {history}, gapChanID: {history, history, history}}, 2)
+	svc := ws.New(thingsClient, mocks.NewPubSub(), ratelimit.New(ratelimit.Config{}), schema.NewChannelValidator(thingsClient), reader)
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		chanID      string
+		token       string
+		query       string
+		lastEventID string
+		status      int
+		event       string
+	}{
+		{
+			desc:   "stream channel messages",
+			chanID: chanID,
+			token:  token,
+			status: http.StatusOK,
+			event:  ": heartbeat",
+		},
+		{
+			desc:   "stream channel messages from time",
+			chanID: chanID,
+			token:  token,
+			query:  "?from=1",
+			status: http.StatusOK,
+			event:  "event: history",
+		},
+		{
+			desc:   "stream channel messages from time with skipped messages",
+			chanID: gapChanID,
+			token:  gapToken,
+			query:  "?from=1",
+			status: http.StatusOK,
+			event:  "event: gap",
+		},
+		{
+			desc:        "stream channel messages from last event",
+			chanID:      chanID,
+			token:       token,
+			lastEventID: "1000000000",
+			status:      http.StatusOK,
+			event:       "event: history",
+		},
+		{
+			desc:   "stream channel messages with token as query parameter",
+			chanID: chanID,
+			query:  fmt.Sprintf("?authorization=%s", token),
+			status: http.StatusOK,
+			event:  ": heartbeat",
+		},
+		{
+			desc:   "stream channel messages with invalid from",
+			chanID: chanID,
+			token:  token,
+			query:  "?from=invalid",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "stream channel messages with invalid subtopic",
+			chanID: chanID,
+			token:  token,
+			query:  "?subtopic=a*b",
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "stream messages of channel the user can't read",
+			chanID: "invalid",
+			token:  token,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "stream channel messages with empty token",
+			chanID: chanID,
+			token:  "",
+			status: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		ctx, cancel := context.WithCancel(context.Background())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/channels/%s/messages/stream%s", ts.URL, tc.chanID, tc.query), nil)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+		if tc.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+		}
+		if tc.lastEventID != "" {
+			req.Header.Set("Last-Event-ID", tc.lastEventID)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code '%d' got '%d'\n", tc.desc, tc.status, res.StatusCode))
+
+		if tc.status == http.StatusOK {
+			ct := res.Header.Get("Content-Type")
+			assert.Equal(t, "text/event-stream", ct, fmt.Sprintf("%s: expected content type 'text/event-stream' got '%s'\n", tc.desc, ct))
+
+			line, err := bufio.NewReader(res.Body).ReadString('\n')
+			assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error %s\n", tc.desc, err))
+			assert.Equal(t, tc.event+"\n", line, fmt.Sprintf("%s: expected line '%s' got '%s'\n", tc.desc, tc.event, line))
+		}
+
+		cancel()
+		res.Body.Close()
+	}
+}
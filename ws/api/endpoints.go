@@ -158,9 +158,9 @@ func encodeError(w http.ResponseWriter, err error) {
 	switch err {
 	case ws.ErrEmptyID, ws.ErrEmptyTopic:
 		statusCode = http.StatusBadRequest
-	case errUnauthorizedAccess:
+	case errUnauthorizedAccess, ws.ErrUnauthorizedAccess:
 		statusCode = http.StatusForbidden
-	case errMalformedSubtopic, apiutil.ErrMalformedEntity:
+	case errMalformedSubtopic, apiutil.ErrMalformedEntity, apiutil.ErrInvalidQueryParams:
 		statusCode = http.StatusBadRequest
 	default:
 		statusCode = http.StatusNotFound
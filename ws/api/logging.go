@@ -77,3 +77,67 @@ func (lm *loggingMiddleware) Unsubscribe(ctx context.Context, thingKey, chanID,
 
 	return lm.svc.Unsubscribe(ctx, thingKey, chanID, subtopic)
 }
+
+func (lm *loggingMiddleware) SubscribeStream(ctx context.Context, token, chanID, subtopic string, stream *ws.Stream) (err error) {
+	defer func(begin time.Time) {
+		destChannel := chanID
+		if subtopic != "" {
+			destChannel = fmt.Sprintf("%s.%s", destChannel, subtopic)
+		}
+		message := fmt.Sprintf("Method subscribe_stream %s to channel %s took %s to complete", stream.ID(), destChannel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.SubscribeStream(ctx, token, chanID, subtopic, stream)
+}
+
+func (lm *loggingMiddleware) UnsubscribeStream(ctx context.Context, chanID, subtopic string, stream *ws.Stream) (err error) {
+	defer func(begin time.Time) {
+		destChannel := chanID
+		if subtopic != "" {
+			destChannel = fmt.Sprintf("%s.%s", destChannel, subtopic)
+		}
+		message := fmt.Sprintf("Method unsubscribe_stream %s from channel %s took %s to complete", stream.ID(), destChannel, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UnsubscribeStream(ctx, chanID, subtopic, stream)
+}
+
+func (lm *loggingMiddleware) CloseStream(ctx context.Context, stream *ws.Stream) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method close_stream %s took %s to complete", stream.ID(), time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CloseStream(ctx, stream)
+}
+
+func (lm *loggingMiddleware) History(ctx context.Context, token, chanID, subtopic string, from float64) (history ws.History, err error) {
+	defer func(begin time.Time) {
+		destChannel := chanID
+		if subtopic != "" {
+			destChannel = fmt.Sprintf("%s.%s", destChannel, subtopic)
+		}
+		message := fmt.Sprintf("Method history of channel %s from %f skipping %d messages took %s to complete", destChannel, from, history.Skipped, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.History(ctx, token, chanID, subtopic, from)
+}
@@ -10,24 +10,29 @@ import (
 	"time"
 
 	"github.com/go-kit/kit/metrics"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/schema"
 	"github.com/MainfluxLabs/mainflux/ws"
 )
 
 var _ ws.Service = (*metricsMiddleware)(nil)
 
 type metricsMiddleware struct {
-	counter metrics.Counter
-	latency metrics.Histogram
-	svc     ws.Service
+	counter    metrics.Counter
+	latency    metrics.Histogram
+	violations metrics.Counter
+	svc        ws.Service
 }
 
-// MetricsMiddleware instruments adapter by tracking request count and latency
-func MetricsMiddleware(svc ws.Service, counter metrics.Counter, latency metrics.Histogram) ws.Service {
+// MetricsMiddleware instruments adapter by tracking request count and latency,
+// and the number of payload schema violations per channel
+func MetricsMiddleware(svc ws.Service, counter metrics.Counter, latency metrics.Histogram, violations metrics.Counter) ws.Service {
 	return &metricsMiddleware{
-		counter: counter,
-		latency: latency,
-		svc:     svc,
+		counter:    counter,
+		latency:    latency,
+		violations: violations,
+		svc:        svc,
 	}
 }
 
@@ -37,7 +42,12 @@ func (mm *metricsMiddleware) Publish(ctx context.Context, thingKey string, msg m
 		mm.latency.With("method", "publish").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return mm.svc.Publish(ctx, thingKey, msg)
+	err := mm.svc.Publish(ctx, thingKey, msg)
+	if errors.Contains(err, schema.ErrInvalidPayload) {
+		mm.violations.With("channel", msg.GetChannel()).Add(1)
+	}
+
+	return err
 }
 
 func (mm *metricsMiddleware) Subscribe(ctx context.Context, thingKey, chanID, subtopic string, c *ws.Client) error {
@@ -57,3 +67,39 @@ func (mm *metricsMiddleware) Unsubscribe(ctx context.Context, thingKey, chanID,
 
 	return mm.svc.Unsubscribe(ctx, thingKey, chanID, subtopic)
 }
+
+func (mm *metricsMiddleware) SubscribeStream(ctx context.Context, token, chanID, subtopic string, stream *ws.Stream) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "subscribe_stream").Add(1)
+		mm.latency.With("method", "subscribe_stream").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SubscribeStream(ctx, token, chanID, subtopic, stream)
+}
+
+func (mm *metricsMiddleware) UnsubscribeStream(ctx context.Context, chanID, subtopic string, stream *ws.Stream) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "unsubscribe_stream").Add(1)
+		mm.latency.With("method", "unsubscribe_stream").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UnsubscribeStream(ctx, chanID, subtopic, stream)
+}
+
+func (mm *metricsMiddleware) CloseStream(ctx context.Context, stream *ws.Stream) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "close_stream").Add(1)
+		mm.latency.With("method", "close_stream").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CloseStream(ctx, stream)
+}
+
+func (mm *metricsMiddleware) History(ctx context.Context, token, chanID, subtopic string, from float64) (ws.History, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "history").Add(1)
+		mm.latency.With("method", "history").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.History(ctx, token, chanID, subtopic, from)
+}
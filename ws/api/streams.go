@@ -0,0 +1,364 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/ws"
+	"github.com/go-zoo/bone"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	streamBufferSize = 256
+	writeWait        = 10 * time.Second
+	lastEventIDKey   = "Last-Event-ID"
+	fromKey          = "from"
+	subtopicKey      = "subtopic"
+	authorizationKey = "authorization"
+
+	subscribeCmd   = "subscribe"
+	unsubscribeCmd = "unsubscribe"
+
+	subscribedEvent   = "subscribed"
+	unsubscribedEvent = "unsubscribed"
+	messageEvent      = "message"
+	historyEvent      = "history"
+	gapEvent          = "gap"
+	errorEvent        = "error"
+)
+
+// streamReq is a command sent by the user over the stream WebSocket.
+type streamReq struct {
+	Type     string  `json:"type"`
+	Channel  string  `json:"channel"`
+	Subtopic string  `json:"subtopic,omitempty"`
+	From     float64 `json:"from,omitempty"`
+}
+
+// streamRes is an event sent to the user over the stream WebSocket or SSE.
+type streamRes struct {
+	Type      string          `json:"type,omitempty"`
+	Channel   string          `json:"channel,omitempty"`
+	Subtopic  string          `json:"subtopic,omitempty"`
+	Publisher string          `json:"publisher,omitempty"`
+	Protocol  string          `json:"protocol,omitempty"`
+	Created   int64           `json:"created,omitempty"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Message   json.RawMessage `json:"message,omitempty"`
+	Skipped   uint64          `json:"skipped,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+func newMessageRes(msg messaging.Message) streamRes {
+	payload := json.RawMessage(msg.Payload)
+	if !json.Valid(msg.Payload) {
+		// Payloads which aren't JSON are sent as strings.
+		payload, _ = json.Marshal(string(msg.Payload))
+	}
+
+	return streamRes{
+		Type:      messageEvent,
+		Channel:   msg.Channel,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Created:   msg.Created,
+		Payload:   payload,
+	}
+}
+
+// streamHandshake upgrades the user connection to a WebSocket, over which
+// the user subscribes to and unsubscribes from any number of channels.
+func streamHandshake(svc ws.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := extractToken(r)
+		if token == "" {
+			encodeError(w, errUnauthorizedAccess)
+			return
+		}
+
+		stream, err := ws.NewStream(streamBufferSize)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to create stream: %s", err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("Failed to upgrade connection to websocket: %s", err.Error()))
+			return
+		}
+
+		out := make(chan streamRes)
+		done := make(chan struct{})
+		go writeStream(conn, stream, out, done)
+		readStream(svc, conn, token, stream, out, done)
+
+		if err := svc.CloseStream(context.Background(), stream); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to close stream %s: %s", stream.ID(), err))
+		}
+	}
+}
+
+// readStream handles the user commands until the connection is closed.
+// Responses are dropped once the writer is done, which closes the
+// connection and so ends reading as well.
+func readStream(svc ws.Service, conn *websocket.Conn, token string, stream *ws.Stream, out chan<- streamRes, done <-chan struct{}) {
+	send := func(res streamRes) {
+		select {
+		case out <- res:
+		case <-done:
+		}
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				logger.Warn(fmt.Sprintf("Failed to read stream command: %s", err))
+			}
+			return
+		}
+
+		var req streamReq
+		if err := json.Unmarshal(data, &req); err != nil {
+			send(streamRes{Type: errorEvent, Error: apiutil.ErrMalformedEntity.Error()})
+			continue
+		}
+
+		subtopic, err := parseSubTopic(req.Subtopic)
+		if err != nil {
+			send(streamRes{Type: errorEvent, Channel: req.Channel, Subtopic: req.Subtopic, Error: err.Error()})
+			continue
+		}
+
+		ctx := context.Background()
+		switch req.Type {
+		case subscribeCmd:
+			if err := svc.SubscribeStream(ctx, token, req.Channel, subtopic, stream); err != nil {
+				send(streamRes{Type: errorEvent, Channel: req.Channel, Subtopic: subtopic, Error: err.Error()})
+				continue
+			}
+			send(streamRes{Type: subscribedEvent, Channel: req.Channel, Subtopic: subtopic})
+
+			if req.From <= 0 {
+				continue
+			}
+			history, err := svc.History(ctx, token, req.Channel, subtopic, req.From)
+			if err != nil {
+				send(streamRes{Type: errorEvent, Channel: req.Channel, Subtopic: subtopic, Error: err.Error()})
+				continue
+			}
+			// The gap notice tells the user that the older messages have
+			// to be read from the readers.
+			if history.Skipped > 0 {
+				send(streamRes{Type: gapEvent, Channel: req.Channel, Subtopic: subtopic, Skipped: history.Skipped})
+			}
+			for _, m := range history.Messages {
+				send(streamRes{Type: historyEvent, Channel: req.Channel, Subtopic: subtopic, Message: m})
+			}
+		case unsubscribeCmd:
+			if err := svc.UnsubscribeStream(ctx, req.Channel, subtopic, stream); err != nil {
+				send(streamRes{Type: errorEvent, Channel: req.Channel, Subtopic: subtopic, Error: err.Error()})
+				continue
+			}
+			send(streamRes{Type: unsubscribedEvent, Channel: req.Channel, Subtopic: subtopic})
+		default:
+			send(streamRes{Type: errorEvent, Channel: req.Channel, Subtopic: subtopic, Error: errUnknownCommand.Error()})
+		}
+	}
+}
+
+// writeStream is the only writer of the connection. It sends the received
+// messages, the responses to the user commands and the heartbeat pings, and
+// closes the connection and the done channel once the stream is closed or a
+// write fails.
+func writeStream(conn *websocket.Conn, stream *ws.Stream, out <-chan streamRes, done chan<- struct{}) {
+	ticker := time.NewTicker(heartbeat)
+	defer func() {
+		ticker.Stop()
+		conn.Close()
+		close(done)
+	}()
+
+	for {
+		var res streamRes
+		select {
+		case <-stream.Done():
+			return
+		case msg := <-stream.Messages():
+			res = newMessageRes(msg)
+		case res = <-out:
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				return
+			}
+			continue
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(writeWait))
+		if err := conn.WriteJSON(res); err != nil {
+			logger.Warn(fmt.Sprintf("Failed to write to stream %s: %s", stream.ID(), err))
+			return
+		}
+	}
+}
+
+// events serves the Server-Sent Events stream of the channel, unless the
+// request is a thing WebSocket handshake on the "stream" subtopic.
+func events(svc ws.Service) http.HandlerFunc {
+	thing, user := handshake(svc), streamEvents(svc)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if websocket.IsWebSocketUpgrade(r) {
+			thing(w, r)
+			return
+		}
+		user(w, r)
+	}
+}
+
+// streamEvents sends the messages of the channel as Server-Sent Events.
+func streamEvents(svc ws.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		chanID := bone.GetValue(r, "id")
+		subtopic, err := parseSubTopic(r.URL.Query().Get(subtopicKey))
+		if err != nil {
+			encodeError(w, err)
+			return
+		}
+		from, err := readFrom(r)
+		if err != nil {
+			encodeError(w, err)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		stream, err := ws.NewStream(streamBufferSize)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to create stream: %s", err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		token := extractToken(r)
+		if err := svc.SubscribeStream(r.Context(), token, chanID, subtopic, stream); err != nil {
+			encodeError(w, err)
+			return
+		}
+		defer func() {
+			if err := svc.CloseStream(context.Background(), stream); err != nil {
+				logger.Warn(fmt.Sprintf("Failed to close stream %s: %s", stream.ID(), err))
+			}
+		}()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		if from > 0 {
+			history, err := svc.History(r.Context(), token, chanID, subtopic, from)
+			if err != nil {
+				writeEvent(w, "", errorEvent, streamRes{Error: err.Error()})
+			}
+			if history.Skipped > 0 {
+				writeEvent(w, "", gapEvent, streamRes{Skipped: history.Skipped})
+			}
+			for _, m := range history.Messages {
+				writeEvent(w, "", historyEvent, m)
+			}
+		}
+		flusher.Flush()
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case msg := <-stream.Messages():
+				res := newMessageRes(msg)
+				res.Type = ""
+				writeEvent(w, strconv.FormatInt(msg.Created, 10), messageEvent, res)
+			case <-ticker.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEvent writes the Server-Sent Event. The event ID, if set, is the
+// message creation time, which lets the reconnecting clients resume from it.
+func writeEvent(w http.ResponseWriter, id, event string, data interface{}) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Failed to encode event: %s", err))
+		return
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err != nil {
+		logger.Warn(fmt.Sprintf("Failed to encode event: %s", err))
+		return
+	}
+
+	if id != "" {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, buf.Bytes())
+}
+
+// readFrom returns the time, in seconds, to resume the stream from. It is
+// read from the from query parameter or from the ID of the last event
+// received before reconnecting, which is the message creation time in
+// nanoseconds.
+func readFrom(r *http.Request) (float64, error) {
+	if v := r.URL.Query().Get(fromKey); v != "" {
+		from, err := strconv.ParseFloat(v, 64)
+		if err != nil || from < 0 {
+			return 0, apiutil.ErrInvalidQueryParams
+		}
+		return from, nil
+	}
+
+	if v := r.Header.Get(lastEventIDKey); v != "" {
+		created, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || created < 0 {
+			return 0, apiutil.ErrInvalidQueryParams
+		}
+		return float64(created) / float64(time.Second), nil
+	}
+
+	return 0, nil
+}
+
+// extractToken returns the user token from the Authorization header or,
+// since browsers can't set headers of WebSocket and EventSource requests,
+// from the authorization query parameter.
+func extractToken(r *http.Request) string {
+	if token := apiutil.ExtractBearerToken(r); token != "" {
+		return token
+	}
+
+	return r.URL.Query().Get(authorizationKey)
+}
@@ -6,6 +6,7 @@ package api
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/go-zoo/bone"
 	"github.com/gorilla/websocket"
@@ -23,6 +24,7 @@ const (
 var (
 	errUnauthorizedAccess = errors.New("missing or invalid credentials provided")
 	errMalformedSubtopic  = errors.New("malformed subtopic")
+	errUnknownCommand     = errors.New("unknown command")
 )
 
 var (
@@ -31,15 +33,22 @@ var (
 		WriteBufferSize: readwriteBufferSize,
 		CheckOrigin:     func(r *http.Request) bool { return true },
 	}
-	logger log.Logger
+	logger    log.Logger
+	heartbeat time.Duration
 )
 
-// MakeHandler returns http handler with handshake endpoint.
-func MakeHandler(svc ws.Service, l log.Logger) http.Handler {
+// MakeHandler returns http handler with handshake endpoints of things and
+// user streams. User streams are kept alive by heartbeats sent at the given
+// interval.
+func MakeHandler(svc ws.Service, hb time.Duration, l log.Logger) http.Handler {
 	logger = l
+	heartbeat = hb
 
 	mux := bone.New()
+	mux.GetFunc("/messages", streamHandshake(svc))
 	mux.GetFunc("/channels/:id/messages", handshake(svc))
+	// Must be registered before the subtopic route, which matches it as well.
+	mux.GetFunc("/channels/:id/messages/stream", events(svc))
 	mux.GetFunc("/channels/:id/messages/*", handshake(svc))
 	mux.GetFunc("/version", mainflux.Health(protocol))
 	mux.Handle("/metrics", promhttp.Handler())
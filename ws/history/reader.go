@@ -0,0 +1,94 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package history contains the readers backed implementation of the
+// messages history replayed to the resuming user streams.
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/ws"
+)
+
+// ErrReadMessages indicates failure to read messages from the readers.
+var ErrReadMessages = errors.New("failed to read messages history")
+
+var _ ws.MessageReader = (*reader)(nil)
+
+type reader struct {
+	url    string
+	limit  uint64
+	client *http.Client
+}
+
+type messagesRes struct {
+	Total    uint64            `json:"total"`
+	Messages []json.RawMessage `json:"messages"`
+}
+
+// NewReader returns the message reader using the readers HTTP API at the
+// given URL. At most limit latest messages are read, and the older ones are
+// reported as skipped.
+func NewReader(url string, limit uint64, client *http.Client) ws.MessageReader {
+	return &reader{
+		url:    url,
+		limit:  limit,
+		client: client,
+	}
+}
+
+func (r *reader) ReadMessages(ctx context.Context, token, chanID, subtopic string, from float64) (ws.History, error) {
+	q := url.Values{}
+	q.Set("limit", strconv.FormatUint(r.limit, 10))
+	q.Set("from", strconv.FormatFloat(from, 'f', -1, 64))
+	if subtopic != "" {
+		q.Set("subtopic", subtopic)
+	}
+	u := fmt.Sprintf("%s/channels/%s/messages?%s", r.url, url.PathEscape(chanID), q.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return ws.History{}, errors.Wrap(ErrReadMessages, err)
+	}
+	req.Header.Set("Authorization", apiutil.BearerPrefix+token)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return ws.History{}, errors.Wrap(ErrReadMessages, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ws.History{}, errors.Wrap(ws.ErrUnauthorizedAccess, errors.New(resp.Status))
+	default:
+		return ws.History{}, errors.Wrap(ErrReadMessages, errors.New(resp.Status))
+	}
+
+	var res messagesRes
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return ws.History{}, errors.Wrap(ErrReadMessages, err)
+	}
+
+	// Readers return the latest messages first.
+	msgs := res.Messages
+	for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+		msgs[i], msgs[j] = msgs[j], msgs[i]
+	}
+
+	history := ws.History{Messages: msgs}
+	if n := uint64(len(msgs)); res.Total > n {
+		history.Skipped = res.Total - n
+	}
+
+	return history, nil
+}
@@ -0,0 +1,37 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/MainfluxLabs/mainflux/ws"
+)
+
+var _ ws.MessageReader = (*mockReader)(nil)
+
+type mockReader struct {
+	msgs  map[string][]json.RawMessage
+	limit int
+}
+
+// NewReader returns mock message reader, holding the messages by channel ID.
+// At most limit latest messages are read, zero limit reads all of them.
+func NewReader(msgs map[string][]json.RawMessage, limit int) ws.MessageReader {
+	return &mockReader{msgs: msgs, limit: limit}
+}
+
+func (r *mockReader) ReadMessages(_ context.Context, token, chanID, _ string, _ float64) (ws.History, error) {
+	if token == "" {
+		return ws.History{}, ws.ErrUnauthorizedAccess
+	}
+
+	msgs := r.msgs[chanID]
+	if r.limit > 0 && len(msgs) > r.limit {
+		return ws.History{Messages: msgs[len(msgs)-r.limit:], Skipped: uint64(len(msgs) - r.limit)}, nil
+	}
+
+	return ws.History{Messages: msgs}, nil
+}
@@ -0,0 +1,132 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const streamIDSize = 16
+
+// ErrStreamFull indicates that a message was dropped because the stream
+// consumer can't keep up with the rate of messages.
+var ErrStreamFull = errors.New("stream buffer is full")
+
+// History contains the stored messages replayed to a resuming stream.
+type History struct {
+	// Messages are the replayed messages in the format of the readers,
+	// oldest first.
+	Messages []json.RawMessage
+	// Skipped is the number of the messages stored since the resume point
+	// which are older than the replayed ones and aren't replayed, since
+	// the number of the replayed messages is limited.
+	Skipped uint64
+}
+
+// MessageReader retrieves the stored channel messages, i.e. it is backed by
+// the readers.
+type MessageReader interface {
+	// ReadMessages retrieves the latest messages of the channel and subtopic
+	// stored since the given time, in seconds, on behalf of the user
+	// identified by the token.
+	ReadMessages(ctx context.Context, token, chanID, subtopic string, from float64) (History, error)
+}
+
+// Stream delivers messages of the channels a user is subscribed to, e.g. to
+// a dashboard connected over WebSocket or Server-Sent Events. It is a single
+// handler shared by all the subscriptions of the connection.
+type Stream struct {
+	id     string
+	msgs   chan messaging.Message
+	done   chan struct{}
+	once   sync.Once
+	mu     sync.Mutex
+	topics map[string]bool
+}
+
+// NewStream returns a stream buffering up to size messages.
+func NewStream(size int) (*Stream, error) {
+	b := make([]byte, streamIDSize)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	return &Stream{
+		id:     hex.EncodeToString(b),
+		msgs:   make(chan messaging.Message, size),
+		done:   make(chan struct{}),
+		topics: make(map[string]bool),
+	}, nil
+}
+
+// ID returns the unique ID of the stream.
+func (s *Stream) ID() string {
+	return s.id
+}
+
+// Messages returns the channel of the received messages.
+func (s *Stream) Messages() <-chan messaging.Message {
+	return s.msgs
+}
+
+// Done returns the channel which is closed when the stream is closed.
+func (s *Stream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Handle queues the message, dropping it if the stream buffer is full.
+func (s *Stream) Handle(msg messaging.Message) error {
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+
+	select {
+	case s.msgs <- msg:
+		return nil
+	default:
+		return ErrStreamFull
+	}
+}
+
+// Cancel is a no-op, since a stream outlives its subscriptions.
+func (s *Stream) Cancel() error {
+	return nil
+}
+
+func (s *Stream) close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+func (s *Stream) add(topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.topics[topic] = true
+}
+
+func (s *Stream) remove(topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.topics, topic)
+}
+
+func (s *Stream) subscriptions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	topics := make([]string, 0, len(s.topics))
+	for t := range s.topics {
+		topics = append(topics, t)
+	}
+
+	return topics
+}